@@ -0,0 +1,10 @@
+-- ============================================================
+-- 001 - Banner variants cho Event
+-- Lưu URL các bản resize (thumbnail/card/hero) để listing không tải ảnh gốc
+-- ============================================================
+USE `fpteventmanagement`;
+
+ALTER TABLE `event`
+  ADD COLUMN `banner_thumbnail_url` varchar(500) COLLATE utf8mb4_unicode_ci DEFAULT NULL AFTER `banner_url`,
+  ADD COLUMN `banner_card_url` varchar(500) COLLATE utf8mb4_unicode_ci DEFAULT NULL AFTER `banner_thumbnail_url`,
+  ADD COLUMN `banner_hero_url` varchar(500) COLLATE utf8mb4_unicode_ci DEFAULT NULL AFTER `banner_card_url`;
@@ -0,0 +1,12 @@
+-- ============================================================
+-- 002 - Clone event request
+-- cloned_from_request_id: request gốc
+-- draft_payload: snapshot speaker + tickets, áp dụng vào Event khi APPROVED
+-- ============================================================
+USE `fpteventmanagement`;
+
+ALTER TABLE `event_request`
+  ADD COLUMN `cloned_from_request_id` int DEFAULT NULL AFTER `created_event_id`,
+  ADD COLUMN `draft_payload` json DEFAULT NULL AFTER `cloned_from_request_id`,
+  ADD KEY `FK_EventRequest_ClonedFrom` (`cloned_from_request_id`),
+  ADD CONSTRAINT `FK_EventRequest_ClonedFrom` FOREIGN KEY (`cloned_from_request_id`) REFERENCES `event_request` (`request_id`);
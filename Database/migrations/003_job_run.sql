@@ -0,0 +1,24 @@
+-- ============================================================
+-- 003 - Lịch sử chạy job định kỳ (scheduler.Manager)
+-- Mỗi lần chạy (theo lịch hoặc run-now) ghi một dòng RUNNING,
+-- cập nhật SUCCESS/FAILED + error_message khi kết thúc
+-- ============================================================
+USE `fpteventmanagement`;
+
+CREATE TABLE `job_run` (
+  `job_run_id` bigint NOT NULL AUTO_INCREMENT,
+  `job_name` varchar(100) NOT NULL,
+  `trigger_type` varchar(20) NOT NULL DEFAULT 'SCHEDULE',
+  `triggered_by` int DEFAULT NULL,
+  `status` varchar(20) NOT NULL DEFAULT 'RUNNING',
+  `started_at` datetime NOT NULL,
+  `finished_at` datetime DEFAULT NULL,
+  `duration_ms` bigint DEFAULT NULL,
+  `error_message` text,
+  PRIMARY KEY (`job_run_id`),
+  KEY `IX_JobRun_Name_Started` (`job_name`,`started_at`),
+  KEY `FK_JobRun_TriggeredBy` (`triggered_by`),
+  CONSTRAINT `FK_JobRun_TriggeredBy` FOREIGN KEY (`triggered_by`) REFERENCES `users` (`user_id`),
+  CONSTRAINT `CK_JobRun_Status` CHECK (`status` in ('RUNNING','SUCCESS','FAILED')),
+  CONSTRAINT `CK_JobRun_Trigger` CHECK (`trigger_type` in ('SCHEDULE','MANUAL'))
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,9 @@
+-- ============================================================
+-- 004 - Ghi instance chạy job (multi-instance)
+-- Scheduler lấy khóa GET_LOCK('fpt_event:job:<name>') trước mỗi lần chạy,
+-- instance_id = hostname-pid của instance đã giữ khóa
+-- ============================================================
+USE `fpteventmanagement`;
+
+ALTER TABLE `job_run`
+  ADD COLUMN `instance_id` varchar(100) DEFAULT NULL AFTER `triggered_by`;
@@ -0,0 +1,25 @@
+-- ============================================================
+-- 005 - Lưu trữ sự kiện đã kết thúc (job event-archival)
+-- event_summary: thống kê chốt tại thời điểm đóng sự kiện
+-- event_request.status: thêm FINISHED cho sự kiện đã diễn ra xong
+-- ============================================================
+USE `fpteventmanagement`;
+
+CREATE TABLE `event_summary` (
+  `event_id` int NOT NULL,
+  `total_seats` int NOT NULL DEFAULT '0',
+  `tickets_sold` int NOT NULL DEFAULT '0',
+  `checked_in_count` int NOT NULL DEFAULT '0',
+  `checked_out_count` int NOT NULL DEFAULT '0',
+  `no_show_count` int NOT NULL DEFAULT '0',
+  `refunded_count` int NOT NULL DEFAULT '0',
+  `expired_pending_count` int NOT NULL DEFAULT '0',
+  `unsold_seats` int NOT NULL DEFAULT '0',
+  `total_revenue` decimal(18,2) NOT NULL DEFAULT '0.00',
+  `finalized_at` datetime(6) NOT NULL,
+  PRIMARY KEY (`event_id`),
+  CONSTRAINT `FK_EventSummary_Event` FOREIGN KEY (`event_id`) REFERENCES `event` (`event_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+ALTER TABLE `event_request`
+  MODIFY COLUMN `status` enum('PENDING','APPROVED','REJECTED','UPDATING','CANCELLED','EXPIRED','FINISHED') COLLATE utf8mb4_unicode_ci DEFAULT 'PENDING';
@@ -0,0 +1,11 @@
+-- ============================================================
+-- 006 - Thời hạn giữ ghế của vé PENDING
+-- hold_expires_at: thời điểm job pending-ticket-cleanup được phép xóa vé
+-- hold_extended: user đã dùng lượt gia hạn (+3 phút, tối đa 1 lần)
+-- ============================================================
+USE `fpteventmanagement`;
+
+ALTER TABLE `ticket`
+  ADD COLUMN `hold_expires_at` datetime DEFAULT NULL AFTER `created_at`,
+  ADD COLUMN `hold_extended` tinyint(1) NOT NULL DEFAULT '0' AFTER `hold_expires_at`,
+  ADD KEY `IX_Ticket_Status_HoldExpires` (`status`,`hold_expires_at`);
@@ -0,0 +1,37 @@
+-- ============================================================
+-- 007 - Quyền dữ liệu cá nhân (GDPR/PDPA)
+-- user_login_history: lịch sử đăng nhập (xuất cho user, xóa khi ẩn danh hóa)
+-- user_data_export: file ZIP xuất dữ liệu, tạo nền, hết hạn sau 48 giờ
+-- users.anonymized_at: thời điểm tài khoản bị ẩn danh hóa (DELETE /api/me)
+-- ============================================================
+USE `fpteventmanagement`;
+
+CREATE TABLE `user_login_history` (
+  `login_id` bigint NOT NULL AUTO_INCREMENT,
+  `user_id` int NOT NULL,
+  `ip_address` varchar(64) COLLATE utf8mb4_unicode_ci DEFAULT NULL,
+  `user_agent` varchar(255) COLLATE utf8mb4_unicode_ci DEFAULT NULL,
+  `logged_in_at` datetime(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+  PRIMARY KEY (`login_id`),
+  KEY `IX_LoginHistory_User` (`user_id`,`logged_in_at`),
+  CONSTRAINT `FK_LoginHistory_User` FOREIGN KEY (`user_id`) REFERENCES `users` (`user_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE `user_data_export` (
+  `export_id` int NOT NULL AUTO_INCREMENT,
+  `user_id` int NOT NULL,
+  `status` enum('PENDING','READY','FAILED') COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT 'PENDING',
+  `file_name` varchar(100) COLLATE utf8mb4_unicode_ci DEFAULT NULL,
+  `file_data` longblob,
+  `file_size` int DEFAULT NULL,
+  `error_message` varchar(500) COLLATE utf8mb4_unicode_ci DEFAULT NULL,
+  `requested_at` datetime(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+  `completed_at` datetime(6) DEFAULT NULL,
+  `expires_at` datetime(6) DEFAULT NULL,
+  PRIMARY KEY (`export_id`),
+  KEY `IX_DataExport_User` (`user_id`,`requested_at`),
+  CONSTRAINT `FK_DataExport_User` FOREIGN KEY (`user_id`) REFERENCES `users` (`user_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+ALTER TABLE `users`
+  ADD COLUMN `anonymized_at` datetime(6) DEFAULT NULL AFTER `created_at`;
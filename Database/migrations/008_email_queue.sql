@@ -0,0 +1,44 @@
+-- ============================================================
+-- 008 - Hàng đợi email (retry/backoff, dead-letter) và sự kiện giao nhận
+-- email_queue: mỗi email cần gửi; nội dung được xóa sau khi gửi thành công
+-- email_event: bounce/complaint/delivered do nhà cung cấp gửi về qua webhook
+-- ============================================================
+USE `fpteventmanagement`;
+
+CREATE TABLE `email_queue` (
+  `email_id` bigint NOT NULL AUTO_INCREMENT,
+  `message_id` varchar(100) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `template` varchar(50) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `recipient` varchar(255) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `subject` varchar(255) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `html_body` mediumtext COLLATE utf8mb4_unicode_ci,
+  `attachments` longtext COLLATE utf8mb4_unicode_ci COMMENT 'JSON [{filename, mimeType, data(base64)}]',
+  `reference` varchar(100) COLLATE utf8mb4_unicode_ci DEFAULT NULL COMMENT 'vd: bill#12, ticket#5',
+  `status` enum('PENDING','SENDING','SENT','DEAD','BOUNCED','COMPLAINED') COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT 'PENDING',
+  `attempts` int NOT NULL DEFAULT '0',
+  `max_attempts` int NOT NULL DEFAULT '5',
+  `next_attempt_at` datetime(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+  `last_error` varchar(1000) COLLATE utf8mb4_unicode_ci DEFAULT NULL,
+  `created_at` datetime(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+  `sent_at` datetime(6) DEFAULT NULL,
+  PRIMARY KEY (`email_id`),
+  UNIQUE KEY `UQ_EmailQueue_MessageId` (`message_id`),
+  KEY `IX_EmailQueue_Status_Next` (`status`,`next_attempt_at`),
+  KEY `IX_EmailQueue_Recipient` (`recipient`,`created_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE `email_event` (
+  `event_id` bigint NOT NULL AUTO_INCREMENT,
+  `email_id` bigint DEFAULT NULL,
+  `message_id` varchar(100) COLLATE utf8mb4_unicode_ci DEFAULT NULL,
+  `recipient` varchar(255) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `event_type` enum('DELIVERED','BOUNCE','COMPLAINT') COLLATE utf8mb4_unicode_ci NOT NULL,
+  `bounce_type` varchar(20) COLLATE utf8mb4_unicode_ci DEFAULT NULL COMMENT 'HARD | SOFT',
+  `reason` varchar(1000) COLLATE utf8mb4_unicode_ci DEFAULT NULL,
+  `occurred_at` datetime(6) NOT NULL,
+  `received_at` datetime(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+  PRIMARY KEY (`event_id`),
+  KEY `IX_EmailEvent_Email` (`email_id`),
+  KEY `IX_EmailEvent_Recipient` (`recipient`,`occurred_at`),
+  CONSTRAINT `FK_EmailEvent_Email` FOREIGN KEY (`email_id`) REFERENCES `email_queue` (`email_id`) ON DELETE SET NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,22 @@
+-- ============================================================
+-- 009 - Đồng tổ chức sự kiện (co-organizer)
+-- Chủ sự kiện mời ORGANIZER khác với quyền chi tiết:
+--   EDIT_DETAILS, VIEW_STATS, MANAGE_ANNOUNCEMENTS
+-- Lời mời ở trạng thái PENDING cho đến khi người được mời chấp nhận
+-- ============================================================
+USE `fpteventmanagement`;
+
+CREATE TABLE `event_collaborator` (
+  `event_id` int NOT NULL,
+  `user_id` int NOT NULL,
+  `permissions` set('EDIT_DETAILS','VIEW_STATS','MANAGE_ANNOUNCEMENTS') COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '',
+  `status` enum('PENDING','ACCEPTED') COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT 'PENDING',
+  `invited_by` int NOT NULL,
+  `invited_at` datetime(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+  `accepted_at` datetime(6) DEFAULT NULL,
+  PRIMARY KEY (`event_id`, `user_id`),
+  KEY `IX_EventCollaborator_User` (`user_id`, `status`),
+  CONSTRAINT `FK_EventCollaborator_Event` FOREIGN KEY (`event_id`) REFERENCES `event` (`event_id`) ON DELETE CASCADE,
+  CONSTRAINT `FK_EventCollaborator_User` FOREIGN KEY (`user_id`) REFERENCES `users` (`user_id`) ON DELETE CASCADE,
+  CONSTRAINT `FK_EventCollaborator_InvitedBy` FOREIGN KEY (`invited_by`) REFERENCES `users` (`user_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
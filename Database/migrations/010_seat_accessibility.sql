@@ -0,0 +1,12 @@
+-- ============================================================
+-- 010 - Ghế dành cho xe lăn và ghế người đi kèm
+-- is_accessible: ghế dành cho người dùng xe lăn
+-- companion_of_seat_id: ghế người đi kèm, chỉ được mua cùng ghế xe lăn liền kề này
+-- ============================================================
+USE `fpteventmanagement`;
+
+ALTER TABLE `seat`
+  ADD COLUMN `is_accessible` tinyint(1) NOT NULL DEFAULT '0' AFTER `category_ticket_id`,
+  ADD COLUMN `companion_of_seat_id` int DEFAULT NULL AFTER `is_accessible`,
+  ADD KEY `IX_Seat_CompanionOf` (`companion_of_seat_id`),
+  ADD CONSTRAINT `FK_Seat_CompanionOf` FOREIGN KEY (`companion_of_seat_id`) REFERENCES `seat` (`seat_id`) ON DELETE SET NULL;
@@ -0,0 +1,17 @@
+-- ============================================================
+-- 011 - Chống bán trùng ghế bằng ràng buộc trong DB
+-- UQ_Ticket_Event_Seat (event_id, seat_id) chặn cả vé EXPIRED/REFUNDED
+-- nên ghế đã hoàn tiền không bán lại được.
+-- active_seat_id chỉ có giá trị khi vé đang giữ ghế (PENDING, BOOKED, CHECKED_IN, CHECKED_OUT;
+-- ghế chỉ được giải phóng khi vé EXPIRED/REFUNDED),
+-- unique (event_id, active_seat_id) đảm bảo mỗi ghế chỉ có một vé đang hiệu lực
+-- kể cả khi hai request cùng vượt qua bước kiểm tra COUNT(*)
+-- ============================================================
+USE `fpteventmanagement`;
+
+ALTER TABLE `ticket`
+  ADD COLUMN `active_seat_id` int GENERATED ALWAYS AS (
+    CASE WHEN `status` IN ('PENDING','BOOKED','CHECKED_IN','CHECKED_OUT') THEN `seat_id` ELSE NULL END
+  ) STORED,
+  ADD UNIQUE KEY `UQ_Ticket_Event_ActiveSeat` (`event_id`,`active_seat_id`),
+  DROP INDEX `UQ_Ticket_Event_Seat`;
@@ -0,0 +1,16 @@
+-- ============================================================
+-- 012 - Vé mời (complimentary) do ban tổ chức phát
+-- ticket.is_complimentary: vé 0 đồng, không có bill, không tính doanh thu
+-- ticket.issued_by: người phát vé mời
+-- event.comp_ticket_quota: số vé mời tối đa của sự kiện (NULL = COMP_TICKET_DEFAULT_QUOTA)
+-- ============================================================
+USE `fpteventmanagement`;
+
+ALTER TABLE `ticket`
+  ADD COLUMN `is_complimentary` tinyint(1) NOT NULL DEFAULT '0' AFTER `status`,
+  ADD COLUMN `issued_by` int DEFAULT NULL AFTER `is_complimentary`,
+  ADD KEY `IX_Ticket_Event_Complimentary` (`event_id`,`is_complimentary`),
+  ADD CONSTRAINT `FK_Ticket_IssuedBy` FOREIGN KEY (`issued_by`) REFERENCES `users` (`user_id`);
+
+ALTER TABLE `event`
+  ADD COLUMN `comp_ticket_quota` int DEFAULT NULL;
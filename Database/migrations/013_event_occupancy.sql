@@ -0,0 +1,28 @@
+-- ============================================================
+-- 013 - Bộ đếm số người đang ở trong khu vực sự kiện
+-- Cập nhật cùng transaction với check-in / check-out,
+-- API occupancy chỉ đọc 1 dòng thay vì COUNT(*) trên Ticket
+-- inside = checked_in_total - checked_out_total
+-- ============================================================
+USE `fpteventmanagement`;
+
+CREATE TABLE IF NOT EXISTS `event_occupancy` (
+  `event_id` int NOT NULL,
+  `checked_in_total` int NOT NULL DEFAULT '0',
+  `checked_out_total` int NOT NULL DEFAULT '0',
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`event_id`),
+  CONSTRAINT `FK_EventOccupancy_Event` FOREIGN KEY (`event_id`) REFERENCES `event` (`event_id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Khởi tạo từ dữ liệu check-in/check-out hiện có
+INSERT INTO `event_occupancy` (`event_id`, `checked_in_total`, `checked_out_total`)
+SELECT `event_id`,
+       COUNT(`checkin_time`),
+       COUNT(CASE WHEN `checkin_time` IS NOT NULL THEN `check_out_time` END)
+FROM `ticket`
+GROUP BY `event_id`
+HAVING COUNT(`checkin_time`) > 0
+ON DUPLICATE KEY UPDATE
+  `checked_in_total` = VALUES(`checked_in_total`),
+  `checked_out_total` = VALUES(`checked_out_total`);
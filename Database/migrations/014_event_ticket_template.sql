@@ -0,0 +1,23 @@
+-- ============================================================
+-- 014 - Cấu hình vé PDF theo sự kiện
+-- layout: classic (mặc định cũ) | branded (font tiếng Việt, banner, logo BTC, dải nhà tài trợ)
+-- Sự kiện không có dòng nào dùng layout classic khổ A4
+-- sponsor_logo_urls: mảng JSON các URL logo nhà tài trợ
+-- ============================================================
+USE `fpteventmanagement`;
+
+CREATE TABLE IF NOT EXISTS `event_ticket_template` (
+  `event_id` int NOT NULL,
+  `layout` varchar(20) COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT 'classic',
+  `page_size` varchar(10) COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT 'A4',
+  `accent_color` varchar(7) COLLATE utf8mb4_unicode_ci DEFAULT NULL,
+  `show_banner` tinyint(1) NOT NULL DEFAULT '1',
+  `organizer_logo_url` varchar(500) COLLATE utf8mb4_unicode_ci DEFAULT NULL,
+  `sponsor_logo_urls` json DEFAULT NULL,
+  `footer_note` varchar(300) COLLATE utf8mb4_unicode_ci DEFAULT NULL,
+  `updated_by` int DEFAULT NULL,
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`event_id`),
+  CONSTRAINT `FK_EventTicketTemplate_Event` FOREIGN KEY (`event_id`) REFERENCES `event` (`event_id`) ON DELETE CASCADE,
+  CONSTRAINT `FK_EventTicketTemplate_User` FOREIGN KEY (`updated_by`) REFERENCES `users` (`user_id`) ON DELETE SET NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
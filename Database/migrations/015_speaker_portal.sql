@@ -0,0 +1,52 @@
+-- ============================================================
+-- 015 - Cổng tự phục vụ cho diễn giả (SPEAKER)
+-- Ban tổ chức mời speaker của sự kiện qua email; speaker nhận lời mời để
+-- liên kết hồ sơ Speaker với tài khoản (tạo tài khoản SPEAKER nếu chưa có)
+-- Một tài khoản có thể liên kết nhiều dòng Speaker (mỗi sự kiện một dòng)
+-- ============================================================
+USE `fpteventmanagement`;
+
+ALTER TABLE `users`
+  MODIFY COLUMN `role` enum('ADMIN','STAFF','ORGANIZER','STUDENT','SPEAKER') COLLATE utf8mb4_unicode_ci NOT NULL;
+
+ALTER TABLE `speaker`
+  ADD COLUMN `user_id` int DEFAULT NULL AFTER `avatar_url`,
+  ADD KEY `IX_Speaker_User` (`user_id`),
+  ADD CONSTRAINT `FK_Speaker_User` FOREIGN KEY (`user_id`) REFERENCES `users` (`user_id`) ON DELETE SET NULL;
+
+-- Lời mời: chỉ lưu SHA-256 của token, token gốc chỉ nằm trong email
+CREATE TABLE IF NOT EXISTS `speaker_invitation` (
+  `invitation_id` int NOT NULL AUTO_INCREMENT,
+  `speaker_id` int NOT NULL,
+  `event_id` int NOT NULL,
+  `email` varchar(100) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `token_hash` char(64) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `invited_by` int NOT NULL,
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `expires_at` datetime NOT NULL,
+  `accepted_at` datetime DEFAULT NULL,
+  `accepted_user_id` int DEFAULT NULL,
+  PRIMARY KEY (`invitation_id`),
+  UNIQUE KEY `UX_SpeakerInvitation_Token` (`token_hash`),
+  KEY `IX_SpeakerInvitation_Speaker` (`speaker_id`),
+  CONSTRAINT `FK_SpeakerInvitation_Speaker` FOREIGN KEY (`speaker_id`) REFERENCES `speaker` (`speaker_id`) ON DELETE CASCADE,
+  CONSTRAINT `FK_SpeakerInvitation_Event` FOREIGN KEY (`event_id`) REFERENCES `event` (`event_id`) ON DELETE CASCADE,
+  CONSTRAINT `FK_SpeakerInvitation_InvitedBy` FOREIGN KEY (`invited_by`) REFERENCES `users` (`user_id`),
+  CONSTRAINT `FK_SpeakerInvitation_User` FOREIGN KEY (`accepted_user_id`) REFERENCES `users` (`user_id`) ON DELETE SET NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Tài liệu buổi nói (slide, link...) do speaker đăng, hiển thị trong chi tiết sự kiện
+CREATE TABLE IF NOT EXISTS `event_session_material` (
+  `material_id` int NOT NULL AUTO_INCREMENT,
+  `event_id` int NOT NULL,
+  `speaker_id` int NOT NULL,
+  `title` varchar(200) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `url` varchar(500) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `created_by` int NOT NULL,
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`material_id`),
+  KEY `IX_EventSessionMaterial_Event` (`event_id`),
+  CONSTRAINT `FK_EventSessionMaterial_Event` FOREIGN KEY (`event_id`) REFERENCES `event` (`event_id`) ON DELETE CASCADE,
+  CONSTRAINT `FK_EventSessionMaterial_Speaker` FOREIGN KEY (`speaker_id`) REFERENCES `speaker` (`speaker_id`) ON DELETE CASCADE,
+  CONSTRAINT `FK_EventSessionMaterial_User` FOREIGN KEY (`created_by`) REFERENCES `users` (`user_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,25 @@
+-- ============================================================
+-- 016 - Tài liệu đính kèm của sự kiện (agenda, bản đồ, slide...)
+-- Mỗi tài liệu là link ngoài (url) hoặc file đã upload lên storage (object_key)
+-- visibility: PUBLIC = ai cũng thấy, TICKET_HOLDERS = chỉ người có vé của sự kiện
+-- ============================================================
+USE `fpteventmanagement`;
+
+CREATE TABLE IF NOT EXISTS `event_attachment` (
+  `attachment_id` int NOT NULL AUTO_INCREMENT,
+  `event_id` int NOT NULL,
+  `title` varchar(200) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `type` enum('AGENDA','MAP','SLIDES','DOCUMENT','OTHER') COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT 'DOCUMENT',
+  `url` varchar(500) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `object_key` varchar(300) COLLATE utf8mb4_unicode_ci DEFAULT NULL,
+  `content_type` varchar(100) COLLATE utf8mb4_unicode_ci DEFAULT NULL,
+  `size_bytes` bigint DEFAULT NULL,
+  `visibility` enum('PUBLIC','TICKET_HOLDERS') COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT 'PUBLIC',
+  `created_by` int NOT NULL,
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`attachment_id`),
+  KEY `IX_EventAttachment_Event` (`event_id`),
+  CONSTRAINT `FK_EventAttachment_Event` FOREIGN KEY (`event_id`) REFERENCES `event` (`event_id`) ON DELETE CASCADE,
+  CONSTRAINT `FK_EventAttachment_User` FOREIGN KEY (`created_by`) REFERENCES `users` (`user_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,30 @@
+-- ============================================================
+-- 017 - Nhà tài trợ theo sự kiện
+-- sponsor: hồ sơ nhà tài trợ (dùng lại được cho nhiều sự kiện)
+-- event_sponsor: nhà tài trợ của từng sự kiện kèm hạng (GOLD được in logo trong email vé)
+-- ============================================================
+USE `fpteventmanagement`;
+
+CREATE TABLE IF NOT EXISTS `sponsor` (
+  `sponsor_id` int NOT NULL AUTO_INCREMENT,
+  `name` varchar(150) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `logo_url` varchar(500) COLLATE utf8mb4_unicode_ci DEFAULT NULL,
+  `website_url` varchar(500) COLLATE utf8mb4_unicode_ci DEFAULT NULL,
+  `created_by` int NOT NULL,
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`sponsor_id`),
+  CONSTRAINT `FK_Sponsor_CreatedBy` FOREIGN KEY (`created_by`) REFERENCES `users` (`user_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS `event_sponsor` (
+  `event_id` int NOT NULL,
+  `sponsor_id` int NOT NULL,
+  `tier` enum('GOLD','SILVER','BRONZE','PARTNER') COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT 'PARTNER',
+  `display_order` int NOT NULL DEFAULT 0,
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`event_id`, `sponsor_id`),
+  KEY `IX_EventSponsor_Sponsor` (`sponsor_id`),
+  CONSTRAINT `FK_EventSponsor_Event` FOREIGN KEY (`event_id`) REFERENCES `event` (`event_id`) ON DELETE CASCADE,
+  CONSTRAINT `FK_EventSponsor_Sponsor` FOREIGN KEY (`sponsor_id`) REFERENCES `sponsor` (`sponsor_id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,39 @@
+-- ============================================================
+-- 018 - Ngân sách của yêu cầu sự kiện
+-- event_request.requested_funding: số tiền ban tổ chức xin tài trợ
+-- event_request_budget_item: dự toán theo hạng mục, actual_amount nhập sau sự kiện
+-- event_request_budget_review: lịch sử duyệt ngân sách của staff (kèm nhận xét)
+-- ============================================================
+USE `fpteventmanagement`;
+
+ALTER TABLE `event_request`
+  ADD COLUMN `requested_funding` decimal(15,2) DEFAULT NULL AFTER `expected_capacity`,
+  ADD COLUMN `actuals_note` varchar(1000) COLLATE utf8mb4_unicode_ci DEFAULT NULL AFTER `draft_payload`,
+  ADD COLUMN `actuals_submitted_at` datetime DEFAULT NULL AFTER `actuals_note`;
+
+CREATE TABLE IF NOT EXISTS `event_request_budget_item` (
+  `item_id` int NOT NULL AUTO_INCREMENT,
+  `request_id` int NOT NULL,
+  `category` enum('VENUE','CATERING','SPEAKER','MARKETING','EQUIPMENT','PRINTING','OTHER') COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT 'OTHER',
+  `description` varchar(255) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `estimated_amount` decimal(15,2) NOT NULL DEFAULT 0,
+  `actual_amount` decimal(15,2) DEFAULT NULL,
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`item_id`),
+  KEY `IX_BudgetItem_Request` (`request_id`),
+  CONSTRAINT `FK_BudgetItem_Request` FOREIGN KEY (`request_id`) REFERENCES `event_request` (`request_id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS `event_request_budget_review` (
+  `review_id` int NOT NULL AUTO_INCREMENT,
+  `request_id` int NOT NULL,
+  `reviewer_id` int NOT NULL,
+  `decision` enum('APPROVED','NEEDS_CHANGES','REJECTED') COLLATE utf8mb4_unicode_ci NOT NULL,
+  `approved_funding` decimal(15,2) DEFAULT NULL,
+  `comment` varchar(1000) COLLATE utf8mb4_unicode_ci DEFAULT NULL,
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`review_id`),
+  KEY `IX_BudgetReview_Request` (`request_id`, `created_at`),
+  CONSTRAINT `FK_BudgetReview_Request` FOREIGN KEY (`request_id`) REFERENCES `event_request` (`request_id`) ON DELETE CASCADE,
+  CONSTRAINT `FK_BudgetReview_Reviewer` FOREIGN KEY (`reviewer_id`) REFERENCES `users` (`user_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
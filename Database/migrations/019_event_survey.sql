@@ -0,0 +1,60 @@
+-- ============================================================
+-- 019 - Khảo sát sau sự kiện
+-- event_survey: mỗi sự kiện một bộ câu hỏi (DRAFT → OPEN → CLOSED)
+-- survey_question: SINGLE_CHOICE, MULTI_CHOICE, TEXT, SCALE (options: JSON mảng lựa chọn)
+-- survey_response / survey_answer: mỗi người tham dự trả lời một lần
+-- ============================================================
+USE `fpteventmanagement`;
+
+CREATE TABLE IF NOT EXISTS `event_survey` (
+  `survey_id` int NOT NULL AUTO_INCREMENT,
+  `event_id` int NOT NULL,
+  `title` varchar(200) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `description` varchar(1000) COLLATE utf8mb4_unicode_ci DEFAULT NULL,
+  `status` enum('DRAFT','OPEN','CLOSED') COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT 'DRAFT',
+  `created_by` int NOT NULL,
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`survey_id`),
+  UNIQUE KEY `UQ_EventSurvey_Event` (`event_id`),
+  CONSTRAINT `FK_EventSurvey_Event` FOREIGN KEY (`event_id`) REFERENCES `event` (`event_id`) ON DELETE CASCADE,
+  CONSTRAINT `FK_EventSurvey_User` FOREIGN KEY (`created_by`) REFERENCES `users` (`user_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS `survey_question` (
+  `question_id` int NOT NULL AUTO_INCREMENT,
+  `survey_id` int NOT NULL,
+  `position` int NOT NULL,
+  `type` enum('SINGLE_CHOICE','MULTI_CHOICE','TEXT','SCALE') COLLATE utf8mb4_unicode_ci NOT NULL,
+  `prompt` varchar(500) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `required` tinyint(1) NOT NULL DEFAULT 0,
+  `options` json DEFAULT NULL,
+  `scale_min` int DEFAULT NULL,
+  `scale_max` int DEFAULT NULL,
+  PRIMARY KEY (`question_id`),
+  KEY `IX_SurveyQuestion_Survey` (`survey_id`, `position`),
+  CONSTRAINT `FK_SurveyQuestion_Survey` FOREIGN KEY (`survey_id`) REFERENCES `event_survey` (`survey_id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS `survey_response` (
+  `response_id` int NOT NULL AUTO_INCREMENT,
+  `survey_id` int NOT NULL,
+  `user_id` int NOT NULL,
+  `submitted_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`response_id`),
+  UNIQUE KEY `UQ_SurveyResponse_User` (`survey_id`, `user_id`),
+  CONSTRAINT `FK_SurveyResponse_Survey` FOREIGN KEY (`survey_id`) REFERENCES `event_survey` (`survey_id`) ON DELETE CASCADE,
+  CONSTRAINT `FK_SurveyResponse_User` FOREIGN KEY (`user_id`) REFERENCES `users` (`user_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS `survey_answer` (
+  `response_id` int NOT NULL,
+  `question_id` int NOT NULL,
+  `choices` json DEFAULT NULL,
+  `text_value` text COLLATE utf8mb4_unicode_ci DEFAULT NULL,
+  `scale_value` int DEFAULT NULL,
+  PRIMARY KEY (`response_id`, `question_id`),
+  KEY `IX_SurveyAnswer_Question` (`question_id`),
+  CONSTRAINT `FK_SurveyAnswer_Response` FOREIGN KEY (`response_id`) REFERENCES `survey_response` (`response_id`) ON DELETE CASCADE,
+  CONSTRAINT `FK_SurveyAnswer_Question` FOREIGN KEY (`question_id`) REFERENCES `survey_question` (`question_id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
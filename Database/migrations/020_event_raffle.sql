@@ -0,0 +1,40 @@
+-- ============================================================
+-- 020 - Bốc thăm trúng thưởng (lucky draw) trong sự kiện
+-- event_raffle: mỗi lần bốc thăm; seed + danh sách vé tham gia để kiểm chứng lại kết quả
+--   (HMAC-SHA256(seed, "<bước>:<lần thử>") → Fisher–Yates trên vé sắp theo ticket_id)
+-- event_raffle_winner: người trúng theo thứ tự bốc
+-- ============================================================
+USE `fpteventmanagement`;
+
+CREATE TABLE IF NOT EXISTS `event_raffle` (
+  `raffle_id` int NOT NULL AUTO_INCREMENT,
+  `event_id` int NOT NULL,
+  `prize` varchar(255) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `winner_count` int NOT NULL,
+  `seed` char(64) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `candidate_count` int NOT NULL,
+  `candidates_hash` char(64) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `candidate_ticket_ids` json NOT NULL,
+  `exclude_staff` tinyint(1) NOT NULL DEFAULT 1,
+  `exclude_complimentary` tinyint(1) NOT NULL DEFAULT 1,
+  `exclude_previous_winners` tinyint(1) NOT NULL DEFAULT 1,
+  `drawn_by` int NOT NULL,
+  `drawn_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`raffle_id`),
+  KEY `IX_EventRaffle_Event` (`event_id`, `drawn_at`),
+  CONSTRAINT `FK_EventRaffle_Event` FOREIGN KEY (`event_id`) REFERENCES `event` (`event_id`) ON DELETE CASCADE,
+  CONSTRAINT `FK_EventRaffle_User` FOREIGN KEY (`drawn_by`) REFERENCES `users` (`user_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS `event_raffle_winner` (
+  `raffle_id` int NOT NULL,
+  `position` int NOT NULL,
+  `ticket_id` int NOT NULL,
+  `user_id` int NOT NULL,
+  PRIMARY KEY (`raffle_id`, `position`),
+  UNIQUE KEY `UQ_RaffleWinner_Ticket` (`raffle_id`, `ticket_id`),
+  KEY `IX_RaffleWinner_User` (`user_id`),
+  CONSTRAINT `FK_RaffleWinner_Raffle` FOREIGN KEY (`raffle_id`) REFERENCES `event_raffle` (`raffle_id`) ON DELETE CASCADE,
+  CONSTRAINT `FK_RaffleWinner_Ticket` FOREIGN KEY (`ticket_id`) REFERENCES `ticket` (`ticket_id`),
+  CONSTRAINT `FK_RaffleWinner_User` FOREIGN KEY (`user_id`) REFERENCES `users` (`user_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,20 @@
+-- ============================================================
+-- 021 - Bậc giá theo thời gian cho loại vé (early-bird, giá sát ngày)
+-- category_ticket_price_tier: giá áp dụng trong [valid_from, valid_to) (valid_to NULL = đến khi hết bán)
+-- Ngoài mọi khung giờ thì dùng category_ticket.price; các khung của một loại vé không chồng nhau
+-- Xóa loại vé (organizer sửa danh sách vé) thì xóa luôn bậc giá
+-- ============================================================
+USE `fpteventmanagement`;
+
+CREATE TABLE IF NOT EXISTS `category_ticket_price_tier` (
+  `tier_id` int NOT NULL AUTO_INCREMENT,
+  `category_ticket_id` int NOT NULL,
+  `name` varchar(100) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `price` decimal(15,2) NOT NULL,
+  `valid_from` datetime NOT NULL,
+  `valid_to` datetime DEFAULT NULL,
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`tier_id`),
+  KEY `IX_PriceTier_Category_ValidFrom` (`category_ticket_id`, `valid_from`),
+  CONSTRAINT `FK_PriceTier_CategoryTicket` FOREIGN KEY (`category_ticket_id`) REFERENCES `category_ticket` (`category_ticket_id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
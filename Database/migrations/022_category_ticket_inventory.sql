@@ -0,0 +1,25 @@
+-- ============================================================
+-- 022 - Bộ đếm vé đã bán theo loại vé
+-- Mua vé giữ suất bằng UPDATE ... SET sold = sold + ? WHERE sold + ? <= max_quantity
+-- trong cùng transaction tạo vé, thay cho COUNT(*) trên ticket mỗi lượt mua
+-- sold = số vé PENDING / BOOKED / CHECKED_IN / CHECKED_OUT của loại vé
+-- Job inventory-reconcile đối soát lại mỗi đêm
+-- ============================================================
+USE `fpteventmanagement`;
+
+CREATE TABLE IF NOT EXISTS `category_ticket_inventory` (
+  `category_ticket_id` int NOT NULL,
+  `sold` int NOT NULL DEFAULT '0',
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`category_ticket_id`),
+  CONSTRAINT `FK_Inventory_CategoryTicket` FOREIGN KEY (`category_ticket_id`) REFERENCES `category_ticket` (`category_ticket_id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Khởi tạo từ vé hiện có (loại vé tạo sau migration được khởi tạo ở lượt mua đầu tiên)
+INSERT INTO `category_ticket_inventory` (`category_ticket_id`, `sold`)
+SELECT ct.`category_ticket_id`,
+       COUNT(CASE WHEN t.`status` IN ('PENDING', 'BOOKED', 'CHECKED_IN', 'CHECKED_OUT') THEN 1 END)
+FROM `category_ticket` ct
+LEFT JOIN `ticket` t ON t.`category_ticket_id` = ct.`category_ticket_id`
+GROUP BY ct.`category_ticket_id`
+ON DUPLICATE KEY UPDATE `sold` = VALUES(`sold`);
@@ -0,0 +1,41 @@
+-- ============================================================
+-- 023 - Kho số liệu thống kê theo ngày
+-- Job stats-aggregation (mỗi đêm) gom hoạt động của ngày hôm trước:
+--   daily_event_stats: theo (ngày, sự kiện)
+--   daily_revenue: tổng toàn hệ thống theo ngày, MAX(stat_date) là mốc đã tổng hợp
+-- API thống kê/dashboard đọc bảng tổng hợp + phần realtime từ ngày sau mốc
+-- Ngày cũ được tính lại qua POST /api/admin/jobs/stats-aggregation/backfill
+-- ============================================================
+USE `fpteventmanagement`;
+
+CREATE TABLE IF NOT EXISTS `daily_event_stats` (
+  `stat_date` date NOT NULL,
+  `event_id` int NOT NULL,
+  `tickets_sold` int NOT NULL DEFAULT '0',
+  `complimentary` int NOT NULL DEFAULT '0',
+  `revenue` decimal(18,2) NOT NULL DEFAULT '0.00',
+  `check_ins` int NOT NULL DEFAULT '0',
+  `check_outs` int NOT NULL DEFAULT '0',
+  `refunds` int NOT NULL DEFAULT '0',
+  `refund_amount` decimal(18,2) NOT NULL DEFAULT '0.00',
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`stat_date`, `event_id`),
+  KEY `IX_DailyEventStats_Event` (`event_id`, `stat_date`),
+  CONSTRAINT `FK_DailyEventStats_Event` FOREIGN KEY (`event_id`) REFERENCES `event` (`event_id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS `daily_revenue` (
+  `stat_date` date NOT NULL,
+  `tickets_sold` int NOT NULL DEFAULT '0',
+  `complimentary` int NOT NULL DEFAULT '0',
+  `revenue` decimal(18,2) NOT NULL DEFAULT '0.00',
+  `check_ins` int NOT NULL DEFAULT '0',
+  `refunds` int NOT NULL DEFAULT '0',
+  `refund_amount` decimal(18,2) NOT NULL DEFAULT '0.00',
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`stat_date`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Job_Run ghi thêm lần chạy backfill
+ALTER TABLE `job_run` DROP CHECK `CK_JobRun_Trigger`;
+ALTER TABLE `job_run` ADD CONSTRAINT `CK_JobRun_Trigger` CHECK (`trigger_type` in ('SCHEDULE','MANUAL','BACKFILL'));
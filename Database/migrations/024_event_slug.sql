@@ -0,0 +1,10 @@
+-- ============================================================
+-- 024 - Slug của sự kiện cho trang public (GET /api/public/events/{slug})
+-- Slug sinh từ tiêu đề khi sự kiện được tạo (duyệt Event_Request); trùng thì thêm -{event_id}
+-- Sự kiện cũ được điền slug khi server khởi động (startup janitor)
+-- ============================================================
+USE `fpteventmanagement`;
+
+ALTER TABLE `event`
+  ADD COLUMN `slug` varchar(100) COLLATE utf8mb4_unicode_ci DEFAULT NULL AFTER `title`,
+  ADD UNIQUE KEY `UX_Event_Slug` (`slug`);
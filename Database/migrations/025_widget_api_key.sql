@@ -0,0 +1,23 @@
+-- ============================================================
+-- 025 - API key cho widget nhúng danh sách sự kiện (GET /api/widget/events)
+-- Mỗi key thuộc một organizer, chỉ trả sự kiện của organizer đó
+-- allowed_origins: các origin được gọi widget từ trình duyệt (CORS), mỗi dòng một origin
+-- Chỉ lưu SHA-256 của key; key gốc chỉ trả về một lần lúc tạo
+-- ============================================================
+USE `fpteventmanagement`;
+
+CREATE TABLE IF NOT EXISTS `widget_api_key` (
+  `key_id` int NOT NULL AUTO_INCREMENT,
+  `organizer_id` int NOT NULL,
+  `name` varchar(100) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `key_prefix` varchar(12) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `key_hash` char(64) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `allowed_origins` text COLLATE utf8mb4_unicode_ci NOT NULL,
+  `status` enum('ACTIVE','REVOKED') COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT 'ACTIVE',
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `revoked_at` datetime DEFAULT NULL,
+  PRIMARY KEY (`key_id`),
+  UNIQUE KEY `UX_WidgetApiKey_Hash` (`key_hash`),
+  KEY `IX_WidgetApiKey_Organizer` (`organizer_id`),
+  CONSTRAINT `FK_WidgetApiKey_Organizer` FOREIGN KEY (`organizer_id`) REFERENCES `users` (`user_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
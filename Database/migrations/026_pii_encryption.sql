@@ -0,0 +1,15 @@
+-- ============================================================
+-- 026 - Mã hóa cột PII ở tầng ứng dụng (AES-256-GCM, common/crypto)
+-- Giá trị mã hóa có dạng "enc:v1:<base64>" (~80 ký tự với số điện thoại/email thông thường)
+-- nên cần nới độ dài cột. Sau khi chạy migration và cấu hình PII_ENCRYPTION_KEY:
+--   backend admin encrypt-pii --dry-run   (đếm số dòng còn lưu nguyên văn)
+--   backend admin encrypt-pii             (mã hóa dữ liệu cũ)
+-- ============================================================
+USE `fpteventmanagement`;
+
+ALTER TABLE `users`
+  MODIFY `phone` varchar(255) COLLATE utf8mb4_unicode_ci DEFAULT NULL;
+
+ALTER TABLE `speaker`
+  MODIFY `email` varchar(255) COLLATE utf8mb4_unicode_ci DEFAULT NULL,
+  MODIFY `phone` varchar(255) COLLATE utf8mb4_unicode_ci DEFAULT NULL;
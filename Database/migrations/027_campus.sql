@@ -0,0 +1,49 @@
+-- ============================================================
+-- 027 - Nhiều campus (HCM, Hà Nội, Đà Nẵng)
+-- venue.campus_id: campus của địa điểm; event.campus_id lấy theo địa điểm lúc tạo sự kiện
+-- users.campus_id: campus của người dùng. STAFF/ADMIN có campus_id chỉ thao tác trong campus đó;
+-- ADMIN không có campus_id là super admin (xem báo cáo liên campus)
+-- ============================================================
+USE `fpteventmanagement`;
+
+CREATE TABLE IF NOT EXISTS `campus` (
+  `campus_id` int NOT NULL AUTO_INCREMENT,
+  `code` varchar(10) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `campus_name` varchar(100) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `address` varchar(255) COLLATE utf8mb4_unicode_ci DEFAULT NULL,
+  `status` enum('ACTIVE','INACTIVE') COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT 'ACTIVE',
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`campus_id`),
+  UNIQUE KEY `UX_Campus_Code` (`code`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+INSERT INTO `campus` (`campus_id`, `code`, `campus_name`, `address`) VALUES
+  (1, 'HCM', 'FPT University HCM', 'Khu Công nghệ cao, Phường Long Thạnh Mỹ, Thành Phố Thủ Đức, Tp.HCM'),
+  (2, 'HN', 'FPT University Hà Nội', 'Khu Công nghệ cao Hòa Lạc, Thạch Thất, Hà Nội'),
+  (3, 'DN', 'FPT University Đà Nẵng', 'Khu đô thị FPT City, Ngũ Hành Sơn, Đà Nẵng')
+ON DUPLICATE KEY UPDATE `code` = `code`;
+
+ALTER TABLE `venue`
+  ADD COLUMN `campus_id` int DEFAULT NULL AFTER `venue_id`,
+  ADD KEY `FK_Venue_Campus` (`campus_id`),
+  ADD CONSTRAINT `FK_Venue_Campus` FOREIGN KEY (`campus_id`) REFERENCES `campus` (`campus_id`);
+
+ALTER TABLE `event`
+  ADD COLUMN `campus_id` int DEFAULT NULL AFTER `area_id`,
+  ADD KEY `IX_Event_Campus_Status` (`campus_id`, `status`),
+  ADD CONSTRAINT `FK_Event_Campus` FOREIGN KEY (`campus_id`) REFERENCES `campus` (`campus_id`);
+
+ALTER TABLE `users`
+  ADD COLUMN `campus_id` int DEFAULT NULL AFTER `role`,
+  ADD KEY `FK_Users_Campus` (`campus_id`),
+  ADD CONSTRAINT `FK_Users_Campus` FOREIGN KEY (`campus_id`) REFERENCES `campus` (`campus_id`);
+
+-- Dữ liệu hiện có: địa điểm Đà Nẵng thuộc campus DN, còn lại thuộc HCM
+UPDATE `venue` SET `campus_id` = 3 WHERE `campus_id` IS NULL AND `venue_name` LIKE '%Da Nang%';
+UPDATE `venue` SET `campus_id` = 1 WHERE `campus_id` IS NULL;
+
+UPDATE `event` e
+  JOIN `venue_area` va ON va.`area_id` = e.`area_id`
+  JOIN `venue` v ON v.`venue_id` = va.`venue_id`
+SET e.`campus_id` = v.`campus_id`
+WHERE e.`campus_id` IS NULL;
@@ -0,0 +1,68 @@
+-- ============================================================
+-- 028 - Role và quyền (thay cho 4 role cố định trong code)
+-- role: role có sẵn (is_system = 1) và role tùy chỉnh do admin tạo;
+-- parent_role: role con kế thừa toàn bộ quyền của role cha
+-- role_permission: quyền gán trực tiếp cho role (tên quyền xem common/permission)
+-- users.role chuyển từ enum sang varchar tham chiếu role
+-- ============================================================
+USE `fpteventmanagement`;
+
+CREATE TABLE IF NOT EXISTS `role` (
+  `role_name` varchar(50) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `description` varchar(255) COLLATE utf8mb4_unicode_ci DEFAULT NULL,
+  `parent_role` varchar(50) COLLATE utf8mb4_unicode_ci DEFAULT NULL,
+  `is_system` tinyint(1) NOT NULL DEFAULT 0,
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`role_name`),
+  KEY `FK_Role_Parent` (`parent_role`),
+  CONSTRAINT `FK_Role_Parent` FOREIGN KEY (`parent_role`) REFERENCES `role` (`role_name`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS `role_permission` (
+  `role_name` varchar(50) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `permission` varchar(64) COLLATE utf8mb4_unicode_ci NOT NULL,
+  PRIMARY KEY (`role_name`, `permission`),
+  CONSTRAINT `FK_RolePermission_Role` FOREIGN KEY (`role_name`) REFERENCES `role` (`role_name`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+INSERT INTO `role` (`role_name`, `description`, `is_system`) VALUES
+  ('ADMIN', 'Quản trị hệ thống', 1),
+  ('STAFF', 'Nhân viên duyệt sự kiện và xử lý report', 1),
+  ('ORGANIZER', 'Ban tổ chức sự kiện', 1),
+  ('STUDENT', 'Sinh viên', 1),
+  ('SPEAKER', 'Diễn giả', 1)
+ON DUPLICATE KEY UPDATE `is_system` = 1;
+
+-- Khớp permission.SystemRoles
+INSERT IGNORE INTO `role_permission` (`role_name`, `permission`) VALUES
+  ('ADMIN', 'event.request.create'),
+  ('ADMIN', 'event.request.review'),
+  ('ADMIN', 'event.manage_any'),
+  ('ADMIN', 'event.stats.view'),
+  ('ADMIN', 'ticket.view_all'),
+  ('ADMIN', 'ticket.comp.issue'),
+  ('ADMIN', 'report.review'),
+  ('ADMIN', 'venue.manage'),
+  ('ADMIN', 'campus.manage'),
+  ('ADMIN', 'user.manage'),
+  ('ADMIN', 'role.manage'),
+  ('ADMIN', 'email.manage'),
+  ('ADMIN', 'job.manage'),
+  ('ADMIN', 'system.config'),
+  ('ADMIN', 'diagnostics.view'),
+  ('ADMIN', 'dashboard.admin'),
+  ('STAFF', 'event.request.review'),
+  ('STAFF', 'event.stats.view'),
+  ('STAFF', 'ticket.view_all'),
+  ('STAFF', 'report.review'),
+  ('STAFF', 'email.manage'),
+  ('ORGANIZER', 'event.request.create'),
+  ('ORGANIZER', 'event.stats.view'),
+  ('ORGANIZER', 'ticket.checkin'),
+  ('ORGANIZER', 'ticket.comp.issue'),
+  ('ORGANIZER', 'widget.manage');
+
+ALTER TABLE `users`
+  MODIFY COLUMN `role` varchar(50) COLLATE utf8mb4_unicode_ci NOT NULL,
+  ADD KEY `FK_Users_Role` (`role`),
+  ADD CONSTRAINT `FK_Users_Role` FOREIGN KEY (`role`) REFERENCES `role` (`role_name`);
@@ -0,0 +1,14 @@
+-- ============================================================
+-- 029 - Nhận xử lý yêu cầu sự kiện (claim) trong hàng đợi của STAFF
+-- claimed_by: người duyệt đang giữ yêu cầu; claimed_at: lúc nhận
+-- claim_touched_at: lần thao tác gần nhất, quá 30 phút không thao tác thì claim hết hiệu lực
+-- (job event-request-claim-release dọn claim hết hạn)
+-- ============================================================
+USE `fpteventmanagement`;
+
+ALTER TABLE `event_request`
+  ADD COLUMN `claimed_by` int DEFAULT NULL,
+  ADD COLUMN `claimed_at` datetime DEFAULT NULL,
+  ADD COLUMN `claim_touched_at` datetime DEFAULT NULL,
+  ADD KEY `FK_EventRequest_ClaimedBy` (`claimed_by`),
+  ADD CONSTRAINT `FK_EventRequest_ClaimedBy` FOREIGN KEY (`claimed_by`) REFERENCES `users` (`user_id`);
@@ -0,0 +1,34 @@
+-- ============================================================
+-- 030 - Thư viện mẫu sự kiện
+-- event_template: tiêu đề mẫu ({date}, {month}, {year}), mô tả, sức chứa mặc định,
+-- cơ cấu vé (JSON [{name, description, price, maxQuantity}])
+-- is_global = 1: mẫu chung (quyền event.template.manage), 0: mẫu riêng của organizer tạo
+-- event_request.template_id: request được tạo từ mẫu nào
+-- ============================================================
+USE `fpteventmanagement`;
+
+CREATE TABLE IF NOT EXISTS `event_template` (
+  `template_id` int NOT NULL AUTO_INCREMENT,
+  `name` varchar(100) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `title_pattern` varchar(255) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `description` text COLLATE utf8mb4_unicode_ci,
+  `default_capacity` int DEFAULT NULL,
+  `ticket_structure` json DEFAULT NULL,
+  `is_global` tinyint(1) NOT NULL DEFAULT 0,
+  `created_by` int NOT NULL,
+  `status` enum('ACTIVE','ARCHIVED') COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT 'ACTIVE',
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`template_id`),
+  KEY `FK_EventTemplate_CreatedBy` (`created_by`),
+  CONSTRAINT `FK_EventTemplate_CreatedBy` FOREIGN KEY (`created_by`) REFERENCES `users` (`user_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+ALTER TABLE `event_request`
+  ADD COLUMN `template_id` int DEFAULT NULL AFTER `cloned_from_request_id`,
+  ADD KEY `FK_EventRequest_Template` (`template_id`),
+  ADD CONSTRAINT `FK_EventRequest_Template` FOREIGN KEY (`template_id`) REFERENCES `event_template` (`template_id`);
+
+-- Khớp permission.SystemRoles
+INSERT IGNORE INTO `role_permission` (`role_name`, `permission`) VALUES
+  ('ADMIN', 'event.template.manage');
@@ -0,0 +1,26 @@
+-- ============================================================
+-- 031 - Quy tắc nghiệp vụ cấu hình được (common/rules)
+-- system_config: cặp key/value cấu hình hệ thống; quy tắc dùng key rule.*
+-- (thiếu key = giá trị mặc định trong code)
+-- event.cancel_cutoff_hours / update_window_hours: ghi đè theo sự kiện, NULL = theo hệ thống
+-- ============================================================
+USE `fpteventmanagement`;
+
+CREATE TABLE IF NOT EXISTS `system_config` (
+  `config_key` varchar(100) NOT NULL,
+  `config_value` varchar(255) NOT NULL,
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`config_key`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+INSERT IGNORE INTO `system_config` (`config_key`, `config_value`) VALUES
+  ('rule.cancel_cutoff_hours', '24'),
+  ('rule.hold_minutes', '5'),
+  ('rule.hold_extension_minutes', '3'),
+  ('rule.max_events_per_day', '2'),
+  ('rule.update_window_hours', '24'),
+  ('rule.min_advance_hours', '24');
+
+ALTER TABLE `event`
+  ADD COLUMN `cancel_cutoff_hours` int DEFAULT NULL COMMENT 'Ghi đè rule.cancel_cutoff_hours',
+  ADD COLUMN `update_window_hours` int DEFAULT NULL COMMENT 'Ghi đè rule.update_window_hours';
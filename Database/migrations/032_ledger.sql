@@ -0,0 +1,55 @@
+-- ============================================================
+-- 032 - Sổ cái kế toán kép (common/ledger)
+-- ledger_entry: một nghiệp vụ (thanh toán hóa đơn, nạp ví, hoàn tiền, số dư đầu kỳ)
+-- ledger_posting: các dòng Nợ / Có của nghiệp vụ, tổng Nợ = tổng Có
+-- Tài khoản: USER_WALLET (theo user_id), PLATFORM_REVENUE, VNPAY_CLEARING,
+-- REFUNDS_PAYABLE, OPENING_BALANCE
+-- Số dư ví hiện có được ghi thành bút toán đầu kỳ để USER_WALLET khớp users.Wallet
+-- ============================================================
+USE `fpteventmanagement`;
+
+CREATE TABLE `ledger_entry` (
+  `entry_id` bigint NOT NULL AUTO_INCREMENT,
+  `entry_type` enum('BILL_PAYMENT','TOPUP','REFUND','OPENING_BALANCE') COLLATE utf8mb4_unicode_ci NOT NULL,
+  `reference_type` varchar(20) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `reference_id` int NOT NULL,
+  `description` varchar(255) COLLATE utf8mb4_unicode_ci DEFAULT NULL,
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`entry_id`),
+  UNIQUE KEY `UQ_LedgerEntry_Reference` (`entry_type`,`reference_type`,`reference_id`),
+  KEY `IX_LedgerEntry_CreatedAt` (`created_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE `ledger_posting` (
+  `posting_id` bigint NOT NULL AUTO_INCREMENT,
+  `entry_id` bigint NOT NULL,
+  `account` varchar(32) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `user_id` int DEFAULT NULL,
+  `debit` decimal(18,2) NOT NULL DEFAULT '0.00',
+  `credit` decimal(18,2) NOT NULL DEFAULT '0.00',
+  PRIMARY KEY (`posting_id`),
+  KEY `FK_LedgerPosting_Entry` (`entry_id`),
+  KEY `IX_LedgerPosting_Account_User` (`account`,`user_id`),
+  CONSTRAINT `FK_LedgerPosting_Entry` FOREIGN KEY (`entry_id`) REFERENCES `ledger_entry` (`entry_id`),
+  CONSTRAINT `FK_LedgerPosting_User` FOREIGN KEY (`user_id`) REFERENCES `users` (`user_id`),
+  CONSTRAINT `CK_LedgerPosting_OneSide` CHECK ((`debit` >= 0) AND (`credit` >= 0) AND ((`debit` = 0) <> (`credit` = 0)))
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Số dư đầu kỳ: Nợ OPENING_BALANCE / Có USER_WALLET cho mỗi ví đang có tiền
+INSERT INTO `ledger_entry` (`entry_type`, `reference_type`, `reference_id`, `description`)
+SELECT 'OPENING_BALANCE', 'USER', `user_id`, 'Wallet balance when the ledger was enabled'
+FROM `users` WHERE `Wallet` > 0;
+
+INSERT INTO `ledger_posting` (`entry_id`, `account`, `user_id`, `debit`, `credit`)
+SELECT le.`entry_id`, 'OPENING_BALANCE', NULL, u.`Wallet`, 0
+FROM `ledger_entry` le JOIN `users` u ON u.`user_id` = le.`reference_id`
+WHERE le.`entry_type` = 'OPENING_BALANCE';
+
+INSERT INTO `ledger_posting` (`entry_id`, `account`, `user_id`, `debit`, `credit`)
+SELECT le.`entry_id`, 'USER_WALLET', u.`user_id`, 0, u.`Wallet`
+FROM `ledger_entry` le JOIN `users` u ON u.`user_id` = le.`reference_id`
+WHERE le.`entry_type` = 'OPENING_BALANCE';
+
+-- Khớp permission.SystemRoles
+INSERT IGNORE INTO `role_permission` (`role_name`, `permission`) VALUES
+  ('ADMIN', 'ledger.view');
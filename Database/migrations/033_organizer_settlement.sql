@@ -0,0 +1,74 @@
+-- ============================================================
+-- 033 - Đối soát doanh thu cho ban tổ chức (common/settlement)
+-- settlement_period: kỳ đối soát theo tháng [period_start, period_end)
+-- organizer_settlement: số tiền nợ từng organizer trong kỳ (gross - refund - fee = net)
+--   PENDING (job settlement-compute tính lại mỗi đêm) → APPROVED → PAID (đã chuyển khoản)
+-- organizer_settlement_line: chi tiết theo sự kiện CLOSED có end_time trong kỳ
+-- rule.platform_fee_bps: phí nền tảng theo basis point (100 = 1%) trên doanh thu sau hoàn tiền
+-- ============================================================
+USE `fpteventmanagement`;
+
+CREATE TABLE `settlement_period` (
+  `period_id` int NOT NULL AUTO_INCREMENT,
+  `period_start` date NOT NULL,
+  `period_end` date NOT NULL,
+  `computed_at` datetime DEFAULT NULL,
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`period_id`),
+  UNIQUE KEY `UQ_SettlementPeriod_Start` (`period_start`),
+  CONSTRAINT `CK_SettlementPeriod_Range` CHECK ((`period_end` > `period_start`))
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE `organizer_settlement` (
+  `settlement_id` int NOT NULL AUTO_INCREMENT,
+  `period_id` int NOT NULL,
+  `organizer_id` int NOT NULL,
+  `event_count` int NOT NULL DEFAULT '0',
+  `tickets_sold` int NOT NULL DEFAULT '0',
+  `gross_amount` decimal(18,2) NOT NULL DEFAULT '0.00',
+  `refund_amount` decimal(18,2) NOT NULL DEFAULT '0.00',
+  `fee_amount` decimal(18,2) NOT NULL DEFAULT '0.00',
+  `net_amount` decimal(18,2) NOT NULL DEFAULT '0.00',
+  `fee_bps` int NOT NULL DEFAULT '0',
+  `status` enum('PENDING','APPROVED','PAID') COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT 'PENDING',
+  `computed_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `approved_by` int DEFAULT NULL,
+  `approved_at` datetime DEFAULT NULL,
+  `paid_by` int DEFAULT NULL,
+  `paid_at` datetime DEFAULT NULL,
+  `payment_reference` varchar(100) COLLATE utf8mb4_unicode_ci DEFAULT NULL,
+  PRIMARY KEY (`settlement_id`),
+  UNIQUE KEY `UQ_OrganizerSettlement_Period_Organizer` (`period_id`,`organizer_id`),
+  KEY `IX_OrganizerSettlement_Organizer` (`organizer_id`),
+  KEY `IX_OrganizerSettlement_Status` (`status`),
+  CONSTRAINT `FK_OrganizerSettlement_Period` FOREIGN KEY (`period_id`) REFERENCES `settlement_period` (`period_id`),
+  CONSTRAINT `FK_OrganizerSettlement_Organizer` FOREIGN KEY (`organizer_id`) REFERENCES `users` (`user_id`),
+  CONSTRAINT `FK_OrganizerSettlement_ApprovedBy` FOREIGN KEY (`approved_by`) REFERENCES `users` (`user_id`),
+  CONSTRAINT `FK_OrganizerSettlement_PaidBy` FOREIGN KEY (`paid_by`) REFERENCES `users` (`user_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE `organizer_settlement_line` (
+  `line_id` int NOT NULL AUTO_INCREMENT,
+  `settlement_id` int NOT NULL,
+  `event_id` int NOT NULL,
+  `event_title` varchar(200) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `event_end_time` datetime NOT NULL,
+  `tickets_sold` int NOT NULL DEFAULT '0',
+  `gross_amount` decimal(18,2) NOT NULL DEFAULT '0.00',
+  `refund_amount` decimal(18,2) NOT NULL DEFAULT '0.00',
+  `fee_amount` decimal(18,2) NOT NULL DEFAULT '0.00',
+  `net_amount` decimal(18,2) NOT NULL DEFAULT '0.00',
+  PRIMARY KEY (`line_id`),
+  UNIQUE KEY `UQ_SettlementLine_Settlement_Event` (`settlement_id`,`event_id`),
+  KEY `FK_SettlementLine_Event` (`event_id`),
+  CONSTRAINT `FK_SettlementLine_Settlement` FOREIGN KEY (`settlement_id`) REFERENCES `organizer_settlement` (`settlement_id`) ON DELETE CASCADE,
+  CONSTRAINT `FK_SettlementLine_Event` FOREIGN KEY (`event_id`) REFERENCES `event` (`event_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+INSERT IGNORE INTO `system_config` (`config_key`, `config_value`) VALUES
+  ('rule.platform_fee_bps', '0');
+
+-- Khớp permission.SystemRoles
+INSERT IGNORE INTO `role_permission` (`role_name`, `permission`) VALUES
+  ('ADMIN', 'settlement.manage'),
+  ('ORGANIZER', 'settlement.view');
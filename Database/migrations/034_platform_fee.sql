@@ -0,0 +1,55 @@
+-- ============================================================
+-- 034 - Phí nền tảng theo sự kiện / organizer, lưu trên từng dòng hóa đơn
+-- Mức phí (basis point, 100 = 1%): event.platform_fee_bps → organizer_fee → rule.platform_fee_bps
+-- bill_item: mỗi vé của hóa đơn một dòng, giữ giá và phí tại thời điểm mua
+--   (hóa đơn cũ: chia đều total_amount cho các vé, phí 0)
+-- daily_event_stats / daily_revenue: thêm platform_fee (phí của vé bán trừ phí của vé hoàn tiền)
+-- organizer_settlement.fee_bps bị bỏ: phí đối soát là tổng phí đã lưu trên bill_item
+-- ============================================================
+USE `fpteventmanagement`;
+
+ALTER TABLE `event`
+  ADD COLUMN `platform_fee_bps` int DEFAULT NULL COMMENT 'Ghi đè phí nền tảng của organizer / rule.platform_fee_bps';
+
+CREATE TABLE `organizer_fee` (
+  `organizer_id` int NOT NULL,
+  `platform_fee_bps` int NOT NULL,
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`organizer_id`),
+  CONSTRAINT `FK_OrganizerFee_Organizer` FOREIGN KEY (`organizer_id`) REFERENCES `users` (`user_id`) ON DELETE CASCADE,
+  CONSTRAINT `CK_OrganizerFee_Range` CHECK ((`platform_fee_bps` between 0 and 10000))
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE `bill_item` (
+  `item_id` int NOT NULL AUTO_INCREMENT,
+  `bill_id` int NOT NULL,
+  `ticket_id` int NOT NULL,
+  `event_id` int NOT NULL,
+  `unit_price` decimal(18,2) NOT NULL,
+  `fee_bps` int NOT NULL DEFAULT '0',
+  `fee_amount` decimal(18,2) NOT NULL DEFAULT '0.00',
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`item_id`),
+  UNIQUE KEY `UQ_BillItem_Ticket` (`ticket_id`),
+  KEY `FK_BillItem_Bill` (`bill_id`),
+  KEY `IX_BillItem_Event` (`event_id`),
+  CONSTRAINT `FK_BillItem_Bill` FOREIGN KEY (`bill_id`) REFERENCES `bill` (`bill_id`),
+  CONSTRAINT `FK_BillItem_Ticket` FOREIGN KEY (`ticket_id`) REFERENCES `ticket` (`ticket_id`),
+  CONSTRAINT `FK_BillItem_Event` FOREIGN KEY (`event_id`) REFERENCES `event` (`event_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+INSERT INTO `bill_item` (`bill_id`, `ticket_id`, `event_id`, `unit_price`, `fee_bps`, `fee_amount`)
+SELECT t.`bill_id`, t.`ticket_id`, t.`event_id`, ROUND(b.`total_amount` / c.`n`, 2), 0, 0
+FROM `ticket` t
+JOIN `bill` b ON b.`bill_id` = t.`bill_id`
+JOIN (SELECT `bill_id`, COUNT(*) AS `n` FROM `ticket` WHERE `bill_id` IS NOT NULL GROUP BY `bill_id`) c
+  ON c.`bill_id` = t.`bill_id`;
+
+ALTER TABLE `daily_event_stats`
+  ADD COLUMN `platform_fee` decimal(18,2) NOT NULL DEFAULT '0.00' AFTER `refund_amount`;
+
+ALTER TABLE `daily_revenue`
+  ADD COLUMN `platform_fee` decimal(18,2) NOT NULL DEFAULT '0.00' AFTER `refund_amount`;
+
+ALTER TABLE `organizer_settlement`
+  DROP COLUMN `fee_bps`;
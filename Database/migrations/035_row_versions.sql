@@ -0,0 +1,13 @@
+-- ============================================================
+-- 035 - Số phiên bản (version) cho event / event_request
+-- Mỗi UPDATE thay đổi nội dung tăng version lên 1 (version = version + 1 trong câu lệnh)
+-- API trả version dưới dạng ETag ở endpoint chi tiết; update-details / update-config / process
+--   nhận If-Match và trả 412 Precondition Failed khi version đã đổi
+-- ============================================================
+USE `fpteventmanagement`;
+
+ALTER TABLE `event`
+  ADD COLUMN `version` int NOT NULL DEFAULT '1';
+
+ALTER TABLE `event_request`
+  ADD COLUMN `version` int NOT NULL DEFAULT '1';
@@ -0,0 +1,11 @@
+-- ============================================================
+-- 036 - Index cho danh sách vé có bộ lọc (GET /api/tickets/list)
+-- Lọc theo sự kiện + trạng thái / loại vé / đã check-in, mặc định sắp xếp ticket_id DESC:
+-- InnoDB tự thêm khóa chính (ticket_id) vào cuối index phụ nên sắp xếp không cần filesort
+-- ============================================================
+USE `fpteventmanagement`;
+
+ALTER TABLE `ticket`
+  ADD KEY `IX_Ticket_Event_Status` (`event_id`,`status`),
+  ADD KEY `IX_Ticket_Event_Category` (`event_id`,`category_ticket_id`),
+  ADD KEY `IX_Ticket_Event_Checkin` (`event_id`,`checkin_time`);
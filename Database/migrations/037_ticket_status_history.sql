@@ -0,0 +1,44 @@
+-- ============================================================
+-- 037 - Lịch sử trạng thái vé (common/tickethistory)
+-- Mỗi lần Ticket.status đổi (tạo vé PENDING/BOOKED, thanh toán, check-in, check-out,
+--   hoàn tiền, hết hạn) ghi một dòng trong cùng transaction với UPDATE Ticket
+-- Vé PENDING bị xóa khi hết thời gian giữ ghế: lịch sử xóa theo (ON DELETE CASCADE)
+-- Dữ liệu cũ: dựng lại từ created_at / checkin_time / check_out_time, report đã duyệt
+--   (hoàn tiền) và end_time của sự kiện (vé hết hạn)
+-- ============================================================
+USE `fpteventmanagement`;
+
+CREATE TABLE `ticket_status_history` (
+  `history_id` bigint NOT NULL AUTO_INCREMENT,
+  `ticket_id` int NOT NULL,
+  `status` enum('PENDING','BOOKED','CHECKED_IN','CHECKED_OUT','EXPIRED','REFUNDED') COLLATE utf8mb4_unicode_ci NOT NULL,
+  `changed_at` datetime(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+  `changed_by` int DEFAULT NULL,
+  PRIMARY KEY (`history_id`),
+  KEY `IX_TicketStatusHistory_Ticket` (`ticket_id`,`changed_at`),
+  CONSTRAINT `FK_TicketStatusHistory_Ticket` FOREIGN KEY (`ticket_id`) REFERENCES `ticket` (`ticket_id`) ON DELETE CASCADE,
+  CONSTRAINT `FK_TicketStatusHistory_ChangedBy` FOREIGN KEY (`changed_by`) REFERENCES `users` (`user_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+INSERT INTO `ticket_status_history` (`ticket_id`, `status`, `changed_at`)
+SELECT `ticket_id`, IF(`status` IN ('PENDING','EXPIRED'), 'PENDING', 'BOOKED'), COALESCE(`created_at`, `qr_issued_at`)
+FROM `ticket`
+WHERE COALESCE(`created_at`, `qr_issued_at`) IS NOT NULL;
+
+INSERT INTO `ticket_status_history` (`ticket_id`, `status`, `changed_at`)
+SELECT `ticket_id`, 'CHECKED_IN', `checkin_time` FROM `ticket` WHERE `checkin_time` IS NOT NULL;
+
+INSERT INTO `ticket_status_history` (`ticket_id`, `status`, `changed_at`)
+SELECT `ticket_id`, 'CHECKED_OUT', `check_out_time` FROM `ticket` WHERE `check_out_time` IS NOT NULL;
+
+INSERT INTO `ticket_status_history` (`ticket_id`, `status`, `changed_at`, `changed_by`)
+SELECT r.`ticket_id`, 'REFUNDED', r.`processed_at`, r.`processed_by`
+FROM `report` r
+JOIN `ticket` t ON t.`ticket_id` = r.`ticket_id`
+WHERE r.`status` = 'APPROVED' AND t.`status` = 'REFUNDED' AND r.`processed_at` IS NOT NULL;
+
+INSERT INTO `ticket_status_history` (`ticket_id`, `status`, `changed_at`)
+SELECT t.`ticket_id`, 'EXPIRED', e.`end_time`
+FROM `ticket` t
+JOIN `event` e ON e.`event_id` = t.`event_id`
+WHERE t.`status` = 'EXPIRED';
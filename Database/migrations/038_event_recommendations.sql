@@ -0,0 +1,23 @@
+-- ============================================================
+-- 038 - Gợi ý sự kiện theo lịch sử tham dự (GET /api/me/recommendations)
+-- Job recommendation-scoring (mỗi đêm) chấm điểm sự kiện OPEN chưa bắt đầu cho từng student
+-- theo các sự kiện đã check-in: cùng loại (mẫu sự kiện), cùng ban tổ chức, cùng buổi trong ngày;
+-- độ "hot" lấy từ daily_event_stats (warehouse, migration 023)
+-- users.recommendations_opt_out = 1: không chấm điểm, không trả gợi ý (PUT /api/me/recommendations)
+-- ============================================================
+USE `fpteventmanagement`;
+
+ALTER TABLE `users`
+  ADD COLUMN `recommendations_opt_out` tinyint(1) NOT NULL DEFAULT 0 AFTER `anonymized_at`;
+
+CREATE TABLE IF NOT EXISTS `event_recommendation` (
+  `user_id` int NOT NULL,
+  `event_id` int NOT NULL,
+  `score` decimal(8,4) NOT NULL,
+  `reasons` varchar(64) COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '',
+  `computed_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`user_id`, `event_id`),
+  KEY `IX_EventRecommendation_Score` (`user_id`, `score`),
+  CONSTRAINT `FK_EventRecommendation_User` FOREIGN KEY (`user_id`) REFERENCES `users` (`user_id`) ON DELETE CASCADE,
+  CONSTRAINT `FK_EventRecommendation_Event` FOREIGN KEY (`event_id`) REFERENCES `event` (`event_id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,26 @@
+-- ============================================================
+-- 039 - Mẫu email do admin chỉnh sửa (common/email, /api/admin/email-templates)
+-- email_template_version: mỗi lần lưu mẫu tạo một phiên bản mới (subject + HTML dạng Go template)
+-- Mỗi template_key có tối đa một phiên bản is_active = 1; không có phiên bản active
+--   (chưa sửa lần nào hoặc đã rollback về mặc định) thì dùng mẫu có sẵn trong code
+-- Rollback = kích hoạt lại phiên bản cũ, không xóa lịch sử
+-- ============================================================
+USE `fpteventmanagement`;
+
+CREATE TABLE `email_template_version` (
+  `template_key` varchar(64) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `version` int NOT NULL,
+  `subject` varchar(255) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `html_body` mediumtext COLLATE utf8mb4_unicode_ci NOT NULL,
+  `is_active` tinyint(1) NOT NULL DEFAULT 0,
+  `note` varchar(255) COLLATE utf8mb4_unicode_ci DEFAULT NULL,
+  `created_by` int DEFAULT NULL,
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`template_key`,`version`),
+  KEY `IX_EmailTemplateVersion_Active` (`template_key`,`is_active`),
+  CONSTRAINT `FK_EmailTemplateVersion_CreatedBy` FOREIGN KEY (`created_by`) REFERENCES `users` (`user_id`) ON DELETE SET NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Khớp permission.SystemRoles
+INSERT IGNORE INTO `role_permission` (`role_name`, `permission`) VALUES
+  ('ADMIN', 'email.template.manage');
@@ -0,0 +1,25 @@
+-- ============================================================
+-- 040 - Transactional outbox cho side effect sau thanh toán (common/outbox)
+-- outbox_message được ghi trong CÙNG transaction với nghiệp vụ (vd: VNPay callback),
+--   nên process chết ngay sau commit vẫn không mất việc cần làm (gửi email vé...)
+-- Sau commit process gửi ngay; lỗi / bị bỏ dở thì job "outbox" chạy lại theo backoff
+--   (ít nhất một lần), quá max_attempts thì chuyển DEAD
+-- ============================================================
+USE `fpteventmanagement`;
+
+CREATE TABLE `outbox_message` (
+  `message_id` bigint NOT NULL AUTO_INCREMENT,
+  `topic` varchar(64) COLLATE utf8mb4_unicode_ci NOT NULL COMMENT 'vd: ticket.booked.email',
+  `payload` json NOT NULL,
+  `reference` varchar(100) COLLATE utf8mb4_unicode_ci DEFAULT NULL COMMENT 'vd: bill#12',
+  `status` enum('PENDING','PROCESSING','DONE','DEAD') COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT 'PENDING',
+  `attempts` int NOT NULL DEFAULT '0',
+  `max_attempts` int NOT NULL DEFAULT '8',
+  `next_attempt_at` datetime(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+  `last_error` varchar(1000) COLLATE utf8mb4_unicode_ci DEFAULT NULL,
+  `created_at` datetime(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+  `processed_at` datetime(6) DEFAULT NULL,
+  PRIMARY KEY (`message_id`),
+  KEY `IX_Outbox_Status_Next` (`status`,`next_attempt_at`),
+  KEY `IX_Outbox_Reference` (`reference`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
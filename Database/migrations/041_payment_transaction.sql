@@ -0,0 +1,24 @@
+-- ============================================================
+-- 041 - Chống xử lý trùng callback VNPay (ProcessVNPayCallback)
+-- payment_transaction: một dòng cho mỗi vnp_TxnRef đã thanh toán thành công,
+--   ghi trong CÙNG transaction tạo Bill / chuyển vé BOOKED
+-- Dòng được INSERT đầu tiên trong transaction nên PRIMARY KEY txn_ref chặn callback
+--   đồng thời (callback sau chờ khóa rồi nhận lỗi trùng); callback lặp lại (F5 return URL,
+--   IPN gửi lại) trả về kết quả của lần đầu thay vì tạo Bill mới
+-- bill_id / result được điền trước khi commit nên dòng đã commit luôn đầy đủ
+-- ============================================================
+USE `fpteventmanagement`;
+
+CREATE TABLE `payment_transaction` (
+  `txn_ref` varchar(255) COLLATE utf8mb4_unicode_ci NOT NULL COMMENT 'vnp_TxnRef',
+  `provider` varchar(20) COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT 'VNPAY',
+  `user_id` int NOT NULL,
+  `bill_id` int DEFAULT NULL,
+  `amount` decimal(18,2) NOT NULL COMMENT 'VND (vnp_Amount / 100)',
+  `response_code` varchar(10) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `result` varchar(255) COLLATE utf8mb4_unicode_ci DEFAULT NULL COMMENT 'ticket_id đã BOOKED, phân cách bằng dấu phẩy',
+  `created_at` datetime(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+  PRIMARY KEY (`txn_ref`),
+  KEY `IX_PaymentTransaction_Bill` (`bill_id`),
+  CONSTRAINT `FK_PaymentTransaction_Bill` FOREIGN KEY (`bill_id`) REFERENCES `bill` (`bill_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
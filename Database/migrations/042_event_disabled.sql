@@ -0,0 +1,12 @@
+-- ============================================================
+-- 042 - Admin vô hiệu hóa sự kiện (POST /api/events/disable)
+-- event.status: thêm DISABLED (ngừng bán vé, vé PENDING đang giữ chỗ bị EXPIRED)
+-- disabled_at / disabled_by: thời điểm và admin vô hiệu hóa
+-- ============================================================
+USE `fpteventmanagement`;
+
+ALTER TABLE `event`
+  MODIFY COLUMN `status` enum('OPEN','CLOSED','CANCELLED','UPDATING','DISABLED') COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT 'UPDATING',
+  ADD COLUMN `disabled_at` datetime DEFAULT NULL,
+  ADD COLUMN `disabled_by` int DEFAULT NULL,
+  ADD CONSTRAINT `FK_Event_DisabledBy` FOREIGN KEY (`disabled_by`) REFERENCES `users` (`user_id`);
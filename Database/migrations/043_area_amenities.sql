@@ -0,0 +1,14 @@
+-- ============================================================
+-- 043 - Tiện ích của khu vực và tiện ích sự kiện cần
+-- venue_area.amenities: thiết bị sẵn có (admin cập nhật qua /api/venues/areas)
+-- event_request.required_amenities: organizer khai báo khi gửi yêu cầu;
+--   /api/events/available-areas xếp khu vực đủ tiện ích lên trước
+-- Danh mục khớp common/amenity.All
+-- ============================================================
+USE `fpteventmanagement`;
+
+ALTER TABLE `venue_area`
+  ADD COLUMN `amenities` set('PROJECTOR','STAGE','LIVESTREAM','SOUND_SYSTEM','MICROPHONE','WHITEBOARD','AIR_CONDITIONING','WHEELCHAIR_ACCESS') COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '';
+
+ALTER TABLE `event_request`
+  ADD COLUMN `required_amenities` set('PROJECTOR','STAGE','LIVESTREAM','SOUND_SYSTEM','MICROPHONE','WHITEBOARD','AIR_CONDITIONING','WHEELCHAIR_ACCESS') COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '';
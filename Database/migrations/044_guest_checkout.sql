@@ -0,0 +1,30 @@
+-- ============================================================
+-- 044 - Khách mua vé không cần tài khoản (POST /api/guest/checkout)
+-- role GUEST: user "vỏ" gắn với email khách, không có quyền, không đăng nhập được
+-- event.allow_guest_checkout: organizer bật cho sự kiện mở cho khách ngoài trường
+-- guest_lookup_code: mã tra cứu vé gửi qua email sau khi thanh toán
+--   (GET /api/guest/tickets?code=...); chỉ lưu SHA-256 của mã
+-- Đăng ký tài khoản bằng email khách (qua OTP) nâng user GUEST lên STUDENT và xóa mã
+-- ============================================================
+USE `fpteventmanagement`;
+
+INSERT INTO `role` (`role_name`, `description`, `is_system`) VALUES
+  ('GUEST', 'Khách mua vé không có tài khoản', 1)
+ON DUPLICATE KEY UPDATE `is_system` = 1;
+
+ALTER TABLE `event`
+  ADD COLUMN `allow_guest_checkout` tinyint(1) NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS `guest_lookup_code` (
+  `code_id` int NOT NULL AUTO_INCREMENT,
+  `user_id` int NOT NULL,
+  `code_hash` char(64) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `bill_id` int DEFAULT NULL,
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `expires_at` datetime NOT NULL,
+  `last_used_at` datetime DEFAULT NULL,
+  PRIMARY KEY (`code_id`),
+  UNIQUE KEY `UX_GuestLookupCode_Hash` (`code_hash`),
+  KEY `IX_GuestLookupCode_User` (`user_id`),
+  CONSTRAINT `FK_GuestLookupCode_User` FOREIGN KEY (`user_id`) REFERENCES `users` (`user_id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
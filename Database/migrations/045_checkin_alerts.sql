@@ -0,0 +1,22 @@
+-- ============================================================
+-- 045 - Cảnh báo check-in theo % sức chứa cho organizer và staff phụ trách
+-- event.checkin_alert_thresholds: danh sách % (vd "50,80,100"); NULL = mặc định
+--   của hệ thống (CHECKIN_ALERT_THRESHOLDS), chuỗi rỗng = tắt cảnh báo
+-- event_checkin_alert: mỗi ngưỡng đã báo một dòng; khóa chính (event_id, threshold_percent)
+--   đảm bảo mỗi ngưỡng chỉ báo đúng một lần kể cả khi nhiều instance cùng chạy
+-- ============================================================
+USE `fpteventmanagement`;
+
+ALTER TABLE `event`
+  ADD COLUMN `checkin_alert_thresholds` varchar(100) COLLATE utf8mb4_unicode_ci DEFAULT NULL;
+
+CREATE TABLE IF NOT EXISTS `event_checkin_alert` (
+  `event_id` int NOT NULL,
+  `threshold_percent` smallint NOT NULL,
+  `checked_in` int NOT NULL,
+  `capacity` int NOT NULL,
+  `recipients` int NOT NULL DEFAULT 0,
+  `fired_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`event_id`, `threshold_percent`),
+  CONSTRAINT `FK_EventCheckinAlert_Event` FOREIGN KEY (`event_id`) REFERENCES `event` (`event_id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,52 @@
+-- ============================================================
+-- 046 - Chính sách lưu giữ dữ liệu (common/retention, job data-retention)
+-- system_config retention.*_days: số ngày giữ của từng chính sách, 0 = giữ vĩnh viễn
+-- ticket_archive / ticket_status_history_archive: vé của sự kiện đã kết thúc lâu
+--   (mặc định 3 năm) được chuyển sang đây; không giữ qr_code_value
+-- bill_item.ticket_id giữ nguyên khi vé được lưu trữ (dòng hóa đơn là chứng từ tài chính),
+--   nên bỏ khóa ngoại tới ticket; vé của dòng hóa đơn nằm ở ticket hoặc ticket_archive
+-- ============================================================
+USE `fpteventmanagement`;
+
+INSERT IGNORE INTO `system_config` (`config_key`, `config_value`) VALUES
+  ('retention.tickets_days', '1095'),
+  ('retention.login_history_days', '365'),
+  ('retention.email_queue_days', '90'),
+  ('retention.email_events_days', '180'),
+  ('retention.outbox_days', '30'),
+  ('retention.job_runs_days', '90'),
+  ('retention.notifications_days', '180'),
+  ('retention.guest_lookup_codes_days', '30');
+
+CREATE TABLE IF NOT EXISTS `ticket_archive` (
+  `ticket_id` int NOT NULL,
+  `event_id` int NOT NULL,
+  `user_id` int NOT NULL,
+  `category_ticket_id` int NOT NULL,
+  `bill_id` int DEFAULT NULL,
+  `seat_id` int DEFAULT NULL,
+  `status` enum('PENDING','BOOKED','CHECKED_IN','CHECKED_OUT','EXPIRED','REFUNDED') COLLATE utf8mb4_unicode_ci DEFAULT NULL,
+  `is_complimentary` tinyint(1) NOT NULL DEFAULT '0',
+  `issued_by` int DEFAULT NULL,
+  `checkin_time` datetime(6) DEFAULT NULL,
+  `check_out_time` datetime(6) DEFAULT NULL,
+  `created_at` datetime DEFAULT NULL,
+  `archived_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`ticket_id`),
+  KEY `IX_TicketArchive_Event` (`event_id`),
+  KEY `IX_TicketArchive_User` (`user_id`),
+  KEY `IX_TicketArchive_Bill` (`bill_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS `ticket_status_history_archive` (
+  `history_id` bigint NOT NULL,
+  `ticket_id` int NOT NULL,
+  `status` enum('PENDING','BOOKED','CHECKED_IN','CHECKED_OUT','EXPIRED','REFUNDED') COLLATE utf8mb4_unicode_ci NOT NULL,
+  `changed_at` datetime(6) NOT NULL,
+  `changed_by` int DEFAULT NULL,
+  `archived_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`history_id`),
+  KEY `IX_TicketStatusHistoryArchive_Ticket` (`ticket_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+ALTER TABLE `bill_item` DROP FOREIGN KEY `FK_BillItem_Ticket`;
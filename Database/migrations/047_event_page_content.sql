@@ -0,0 +1,12 @@
+-- ============================================================
+-- 047 - Nội dung trang sự kiện do organizer soạn
+-- event.schedule_blocks: JSON mảng [{startTime, endTime, title, speaker, description}]
+--   (giờ dạng HH:MM, sắp theo startTime)
+-- event.faq_entries: JSON mảng [{question, answer}]
+-- NULL = chưa soạn; mô tả rich-text vẫn nằm ở event.description
+-- ============================================================
+USE `fpteventmanagement`;
+
+ALTER TABLE `event`
+  ADD COLUMN `schedule_blocks` json DEFAULT NULL,
+  ADD COLUMN `faq_entries` json DEFAULT NULL;
@@ -0,0 +1,25 @@
+-- ============================================================
+-- 048 - Giá theo từng ghế trong một loại vé (vd. hai hàng đầu +20%)
+-- seat_price: % cộng / trừ trên giá đang áp dụng của loại vé (kể cả bậc giá theo thời gian);
+--   khóa theo (category_ticket_id, seat_id) vì ghế thuộc khu vực và được gán
+--   lại cho loại vé của sự kiện khác, override không được đi theo ghế
+-- Giới hạn % nằm trong rule.seat_premium_max_percent / rule.seat_discount_max_percent
+-- Xóa loại vé thì xóa luôn override
+-- ============================================================
+USE `fpteventmanagement`;
+
+CREATE TABLE IF NOT EXISTS `seat_price` (
+  `category_ticket_id` int NOT NULL,
+  `seat_id` int NOT NULL,
+  `adjust_percent` smallint NOT NULL,
+  `updated_by` int DEFAULT NULL,
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`category_ticket_id`, `seat_id`),
+  KEY `IX_SeatPrice_Seat` (`seat_id`),
+  CONSTRAINT `FK_SeatPrice_CategoryTicket` FOREIGN KEY (`category_ticket_id`) REFERENCES `category_ticket` (`category_ticket_id`) ON DELETE CASCADE,
+  CONSTRAINT `FK_SeatPrice_Seat` FOREIGN KEY (`seat_id`) REFERENCES `seat` (`seat_id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+INSERT IGNORE INTO `system_config` (`config_key`, `config_value`) VALUES
+  ('rule.seat_premium_max_percent', '50'),
+  ('rule.seat_discount_max_percent', '50');
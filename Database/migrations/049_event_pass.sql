@@ -0,0 +1,70 @@
+-- ============================================================
+-- 049 - Vé tháng / vé học kỳ (season pass) của CLB
+-- event_pass: sản phẩm do organizer bán; scope ORGANIZER = mọi sự kiện của organizer,
+--   EVENTS = chỉ các sự kiện trong event_pass_event; chỉ sự kiện bắt đầu trong
+--   [valid_from, valid_to] mới được tính. max_holders NULL = không giới hạn
+-- event_pass_event.category_ticket_id: loại vé cấp cho người giữ pass (NULL = loại vé
+--   ACTIVE rẻ nhất còn chỗ lúc cấp vé)
+-- event_pass_holder: một lượt mua (Wallet / VNPay, có bill); REVOKED thì vé đã cấp
+--   không check-in được
+-- ticket.pass_holder_id: vé cấp từ pass (BOOKED, không bill riêng, giữ suất như vé thường);
+--   ticket_archive giữ cột này khi vé được lưu trữ
+-- ============================================================
+USE `fpteventmanagement`;
+
+CREATE TABLE IF NOT EXISTS `event_pass` (
+  `pass_id` int NOT NULL AUTO_INCREMENT,
+  `organizer_id` int NOT NULL,
+  `name` varchar(200) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `description` text COLLATE utf8mb4_unicode_ci,
+  `price` decimal(18,2) NOT NULL,
+  `scope` enum('ORGANIZER','EVENTS') COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT 'ORGANIZER',
+  `valid_from` datetime NOT NULL,
+  `valid_to` datetime NOT NULL,
+  `max_holders` int DEFAULT NULL,
+  `status` enum('ACTIVE','INACTIVE') COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT 'ACTIVE',
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`pass_id`),
+  KEY `IX_EventPass_Organizer` (`organizer_id`, `status`),
+  CONSTRAINT `FK_EventPass_Organizer` FOREIGN KEY (`organizer_id`) REFERENCES `users` (`user_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS `event_pass_event` (
+  `pass_id` int NOT NULL,
+  `event_id` int NOT NULL,
+  `category_ticket_id` int DEFAULT NULL,
+  PRIMARY KEY (`pass_id`, `event_id`),
+  KEY `IX_EventPassEvent_Event` (`event_id`),
+  CONSTRAINT `FK_EventPassEvent_Pass` FOREIGN KEY (`pass_id`) REFERENCES `event_pass` (`pass_id`) ON DELETE CASCADE,
+  CONSTRAINT `FK_EventPassEvent_Event` FOREIGN KEY (`event_id`) REFERENCES `event` (`event_id`) ON DELETE CASCADE,
+  CONSTRAINT `FK_EventPassEvent_Category` FOREIGN KEY (`category_ticket_id`) REFERENCES `category_ticket` (`category_ticket_id`) ON DELETE SET NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS `event_pass_holder` (
+  `holder_id` int NOT NULL AUTO_INCREMENT,
+  `pass_id` int NOT NULL,
+  `user_id` int NOT NULL,
+  `bill_id` int DEFAULT NULL,
+  `status` enum('ACTIVE','REVOKED') COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT 'ACTIVE',
+  `purchased_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`holder_id`),
+  UNIQUE KEY `UX_EventPassHolder_PassUser` (`pass_id`, `user_id`),
+  KEY `IX_EventPassHolder_User` (`user_id`),
+  CONSTRAINT `FK_EventPassHolder_Pass` FOREIGN KEY (`pass_id`) REFERENCES `event_pass` (`pass_id`),
+  CONSTRAINT `FK_EventPassHolder_User` FOREIGN KEY (`user_id`) REFERENCES `users` (`user_id`),
+  CONSTRAINT `FK_EventPassHolder_Bill` FOREIGN KEY (`bill_id`) REFERENCES `bill` (`bill_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+ALTER TABLE `ticket`
+  ADD COLUMN `pass_holder_id` int DEFAULT NULL,
+  ADD KEY `IX_Ticket_PassHolder` (`pass_holder_id`, `event_id`),
+  ADD CONSTRAINT `FK_Ticket_PassHolder` FOREIGN KEY (`pass_holder_id`) REFERENCES `event_pass_holder` (`holder_id`);
+
+ALTER TABLE `ticket_archive`
+  ADD COLUMN `pass_holder_id` int DEFAULT NULL AFTER `issued_by`;
+
+-- Khớp permission.SystemRoles
+INSERT IGNORE INTO `role_permission` (`role_name`, `permission`) VALUES
+  ('ADMIN', 'pass.manage'),
+  ('ORGANIZER', 'pass.manage');
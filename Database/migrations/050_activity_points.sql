@@ -0,0 +1,17 @@
+-- ============================================================
+-- 050 - Điểm rèn luyện (activity points) theo sự kiện
+-- event.activity_points: điểm cộng cho sinh viên đã check-in (0 = không tính điểm),
+--   do chủ sự kiện / co-organizer (EDIT_DETAILS) hoặc STAFF / ADMIN đặt
+-- Lịch sử tham dự đọc vé CHECKED_IN / CHECKED_OUT ở cả ticket và ticket_archive;
+--   học kỳ suy ra từ event.start_time (SP: tháng 1-4, SU: 5-8, FA: 9-12)
+-- ============================================================
+USE `fpteventmanagement`;
+
+ALTER TABLE `event`
+  ADD COLUMN `activity_points` int NOT NULL DEFAULT 0;
+
+-- Khớp permission.SystemRoles
+INSERT IGNORE INTO `role_permission` (`role_name`, `permission`) VALUES
+  ('ADMIN', 'attendance.points'),
+  ('ADMIN', 'attendance.export'),
+  ('STAFF', 'attendance.points');
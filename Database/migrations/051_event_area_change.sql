@@ -0,0 +1,40 @@
+-- ============================================================
+-- 051 - Đổi khu vực cho sự kiện đã duyệt (POST /api/staff/events/{id}/change-area)
+-- event_area_change: mỗi lần STAFF / ADMIN chuyển sự kiện sang khu vực khác
+-- event_area_change_ticket: ghế cũ → ghế mới của từng vé đã bán; needs_reassignment = 1
+-- khi không còn ghế phù hợp (NO_ACCESSIBLE_SEAT: vé xe lăn đang ngồi tạm ghế thường,
+-- NO_SEAT: chưa có ghế). ticket_id không khóa ngoại vì vé cũ được chuyển sang ticket_archive
+-- ============================================================
+USE `fpteventmanagement`;
+
+CREATE TABLE IF NOT EXISTS `event_area_change` (
+  `change_id` int NOT NULL AUTO_INCREMENT,
+  `event_id` int NOT NULL,
+  `from_area_id` int DEFAULT NULL,
+  `to_area_id` int NOT NULL,
+  `changed_by` int NOT NULL,
+  `reason` varchar(500) COLLATE utf8mb4_unicode_ci DEFAULT NULL,
+  `tickets_moved` int NOT NULL DEFAULT 0,
+  `tickets_flagged` int NOT NULL DEFAULT 0,
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`change_id`),
+  KEY `IX_EventAreaChange_Event` (`event_id`, `created_at`),
+  CONSTRAINT `FK_EventAreaChange_Event` FOREIGN KEY (`event_id`) REFERENCES `event` (`event_id`) ON DELETE CASCADE,
+  CONSTRAINT `FK_EventAreaChange_FromArea` FOREIGN KEY (`from_area_id`) REFERENCES `venue_area` (`area_id`) ON DELETE SET NULL,
+  CONSTRAINT `FK_EventAreaChange_ToArea` FOREIGN KEY (`to_area_id`) REFERENCES `venue_area` (`area_id`),
+  CONSTRAINT `FK_EventAreaChange_User` FOREIGN KEY (`changed_by`) REFERENCES `users` (`user_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS `event_area_change_ticket` (
+  `change_id` int NOT NULL,
+  `ticket_id` int NOT NULL,
+  `user_id` int NOT NULL,
+  `category_ticket_id` int NOT NULL,
+  `from_seat_code` varchar(20) COLLATE utf8mb4_unicode_ci DEFAULT NULL,
+  `to_seat_code` varchar(20) COLLATE utf8mb4_unicode_ci DEFAULT NULL,
+  `needs_reassignment` tinyint(1) NOT NULL DEFAULT 0,
+  `reason` enum('NO_ACCESSIBLE_SEAT','NO_SEAT') COLLATE utf8mb4_unicode_ci DEFAULT NULL,
+  PRIMARY KEY (`change_id`, `ticket_id`),
+  KEY `IX_EventAreaChangeTicket_Flagged` (`change_id`, `needs_reassignment`),
+  CONSTRAINT `FK_EventAreaChangeTicket_Change` FOREIGN KEY (`change_id`) REFERENCES `event_area_change` (`change_id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,45 @@
+-- ============================================================
+-- 052 - Chống lạm dụng khi đăng ký tài khoản
+-- users.status QUARANTINED: tài khoản mới từ IP / thiết bị đã tạo nhiều tài khoản gần đây,
+-- không đăng nhập được cho tới khi admin duyệt (APPROVED → ACTIVE, REJECTED → BLOCKED)
+-- user_registration_signal: IP và SHA-256 của header X-Device-Fingerprint lúc tạo tài khoản,
+-- dùng để đếm tài khoản theo IP / thiết bị trong ABUSE_QUARANTINE_WINDOW_HOURS
+-- account_review: hàng chờ admin xét duyệt (GET /api/admin/abuse/reviews)
+-- ============================================================
+USE `fpteventmanagement`;
+
+ALTER TABLE `users`
+  MODIFY COLUMN `status` enum('ACTIVE','INACTIVE','BLOCKED','QUARANTINED') COLLATE utf8mb4_unicode_ci DEFAULT 'ACTIVE';
+
+CREATE TABLE IF NOT EXISTS `user_registration_signal` (
+  `user_id` int NOT NULL,
+  `ip_address` varchar(64) COLLATE utf8mb4_unicode_ci DEFAULT NULL,
+  `device_fingerprint` char(64) COLLATE utf8mb4_unicode_ci DEFAULT NULL,
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`user_id`),
+  KEY `IX_RegistrationSignal_IP` (`ip_address`, `created_at`),
+  KEY `IX_RegistrationSignal_Device` (`device_fingerprint`, `created_at`),
+  CONSTRAINT `FK_RegistrationSignal_User` FOREIGN KEY (`user_id`) REFERENCES `users` (`user_id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS `account_review` (
+  `review_id` int NOT NULL AUTO_INCREMENT,
+  `user_id` int NOT NULL,
+  `reasons` varchar(255) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `ip_address` varchar(64) COLLATE utf8mb4_unicode_ci DEFAULT NULL,
+  `device_fingerprint` char(64) COLLATE utf8mb4_unicode_ci DEFAULT NULL,
+  `status` enum('PENDING','APPROVED','REJECTED') COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT 'PENDING',
+  `note` varchar(500) COLLATE utf8mb4_unicode_ci DEFAULT NULL,
+  `reviewed_by` int DEFAULT NULL,
+  `reviewed_at` datetime DEFAULT NULL,
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`review_id`),
+  KEY `IX_AccountReview_Status` (`status`, `created_at`),
+  KEY `IX_AccountReview_User` (`user_id`),
+  CONSTRAINT `FK_AccountReview_User` FOREIGN KEY (`user_id`) REFERENCES `users` (`user_id`) ON DELETE CASCADE,
+  CONSTRAINT `FK_AccountReview_Reviewer` FOREIGN KEY (`reviewed_by`) REFERENCES `users` (`user_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Khớp permission.SystemRoles
+INSERT IGNORE INTO `role_permission` (`role_name`, `permission`) VALUES
+  ('ADMIN', 'abuse.review');
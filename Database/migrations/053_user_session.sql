@@ -0,0 +1,26 @@
+-- ============================================================
+-- 053 - Phiên đăng nhập (GET/DELETE /api/me/sessions)
+-- user_session: mỗi lần đăng nhập / đăng ký cấp token là một phiên; session_id nằm trong
+-- claim jti của JWT, authMiddleware từ chối token của phiên đã thu hồi hoặc không còn
+-- Token cấp trước migration này không có jti: vẫn hợp lệ tới khi hết hạn, không được liệt kê
+-- retention.sessions_days: xóa phiên đã hết hạn / thu hồi quá số ngày này
+-- ============================================================
+USE `fpteventmanagement`;
+
+CREATE TABLE IF NOT EXISTS `user_session` (
+  `session_id` char(32) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `user_id` int NOT NULL,
+  `ip_address` varchar(64) COLLATE utf8mb4_unicode_ci DEFAULT NULL,
+  `user_agent` varchar(255) COLLATE utf8mb4_unicode_ci DEFAULT NULL,
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `last_used_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `expires_at` datetime NOT NULL,
+  `revoked_at` datetime DEFAULT NULL,
+  PRIMARY KEY (`session_id`),
+  KEY `IX_UserSession_User` (`user_id`, `revoked_at`, `expires_at`),
+  KEY `IX_UserSession_Expires` (`expires_at`),
+  CONSTRAINT `FK_UserSession_User` FOREIGN KEY (`user_id`) REFERENCES `users` (`user_id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+INSERT IGNORE INTO `system_config` (`config_key`, `config_value`) VALUES
+  ('retention.sessions_days', '30');
@@ -0,0 +1,12 @@
+-- ============================================================
+-- 054 - Biên lai thanh toán (email payment_receipt, GET /api/bills/{id}/resend-receipt)
+-- bill.vat_bps: thuế suất VAT (basis point) lúc thanh toán, giá vé đã gồm VAT
+-- (NULL = hóa đơn cũ, biên lai dùng rule.vat_bps hiện tại)
+-- bill.payment_ref: mã giao dịch của cổng thanh toán (VNPay vnp_TransactionNo)
+-- hoặc mã bút toán sổ cái của giao dịch ví (WALLET-<entry_id>)
+-- ============================================================
+USE `fpteventmanagement`;
+
+ALTER TABLE `bill`
+  ADD COLUMN `vat_bps` int DEFAULT NULL COMMENT 'Thuế suất VAT lúc thanh toán (basis point)',
+  ADD COLUMN `payment_ref` varchar(100) COLLATE utf8mb4_unicode_ci DEFAULT NULL COMMENT 'Mã giao dịch VNPay / mã bút toán ví';
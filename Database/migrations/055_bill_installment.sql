@@ -0,0 +1,43 @@
+-- ============================================================
+-- 055 - Trả góp hóa đơn vé (đặt cọc + các kỳ còn lại)
+-- event_installment_plan: sự kiện cho phép trả góp (PUT /api/events/{id}/installment-plan)
+-- bill_installment: lịch thanh toán của hóa đơn; seq 0 = tiền cọc trả lúc mua
+-- bill.payment_status PARTIAL: đã cọc, còn kỳ chưa trả (đủ tiền → PAID)
+-- ticket.suspended_at: vé bị tạm khóa vì có kỳ quá hạn, không check-in được
+-- tới khi trả đủ các kỳ quá hạn
+-- ============================================================
+USE `fpteventmanagement`;
+
+CREATE TABLE IF NOT EXISTS `event_installment_plan` (
+  `event_id` int NOT NULL,
+  `deposit_percent` int NOT NULL COMMENT '% tổng tiền trả trước (10..90)',
+  `installment_count` int NOT NULL COMMENT 'Số kỳ sau đặt cọc (1..6)',
+  `interval_days` int NOT NULL COMMENT 'Khoảng cách giữa các kỳ',
+  `updated_by` int DEFAULT NULL,
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`event_id`),
+  CONSTRAINT `FK_InstallmentPlan_Event` FOREIGN KEY (`event_id`) REFERENCES `event` (`event_id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS `bill_installment` (
+  `installment_id` int NOT NULL AUTO_INCREMENT,
+  `bill_id` int NOT NULL,
+  `seq` int NOT NULL,
+  `amount` decimal(18,2) NOT NULL,
+  `due_at` datetime NOT NULL,
+  `status` enum('PENDING','PAID') COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT 'PENDING',
+  `paid_at` datetime DEFAULT NULL,
+  `payment_method` varchar(20) COLLATE utf8mb4_unicode_ci DEFAULT NULL,
+  `payment_ref` varchar(100) COLLATE utf8mb4_unicode_ci DEFAULT NULL,
+  `reminded_at` datetime DEFAULT NULL COMMENT 'Lần cuối gửi email nhắc hạn',
+  PRIMARY KEY (`installment_id`),
+  UNIQUE KEY `UQ_BillInstallment_Seq` (`bill_id`, `seq`),
+  KEY `IX_BillInstallment_Due` (`status`, `due_at`),
+  CONSTRAINT `FK_BillInstallment_Bill` FOREIGN KEY (`bill_id`) REFERENCES `bill` (`bill_id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+ALTER TABLE `bill`
+  MODIFY COLUMN `payment_status` enum('PENDING','PAID','PARTIAL','FAILED','REFUNDED') COLLATE utf8mb4_unicode_ci DEFAULT 'PENDING';
+
+ALTER TABLE `ticket`
+  ADD COLUMN `suspended_at` datetime DEFAULT NULL COMMENT 'Tạm khóa vì kỳ trả góp quá hạn';
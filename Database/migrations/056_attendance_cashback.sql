@@ -0,0 +1,29 @@
+-- ============================================================
+-- 056 - Hoàn tiền khuyến khích tham dự (cashback)
+-- event.cashback_percent: % giá vé hoàn vào ví khi check-out (0 = tắt, tối đa 50),
+--   event.cashback_max_amount: trần mỗi vé (NULL = không giới hạn);
+--   do chủ sự kiện / co-organizer (EDIT_DETAILS) hoặc ADMIN đặt
+-- ticket_cashback: mỗi vé được hoàn tối đa một lần, ghi cùng transaction với check-out
+--   và bút toán CASHBACK trên sổ cái (Nợ PLATFORM_REVENUE / Có USER_WALLET)
+-- ============================================================
+USE `fpteventmanagement`;
+
+ALTER TABLE `event`
+  ADD COLUMN `cashback_percent` int NOT NULL DEFAULT 0,
+  ADD COLUMN `cashback_max_amount` decimal(18,2) DEFAULT NULL;
+
+CREATE TABLE IF NOT EXISTS `ticket_cashback` (
+  `ticket_id` int NOT NULL,
+  `event_id` int NOT NULL,
+  `user_id` int NOT NULL,
+  `bill_id` int NOT NULL,
+  `percent` int NOT NULL COMMENT 'Mức hoàn lúc check-out',
+  `amount` decimal(18,2) NOT NULL,
+  `entry_id` bigint NOT NULL COMMENT 'Ledger_Entry CASHBACK',
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`ticket_id`),
+  KEY `IX_TicketCashback_Event` (`event_id`),
+  KEY `IX_TicketCashback_User` (`user_id`),
+  CONSTRAINT `FK_TicketCashback_Event` FOREIGN KEY (`event_id`) REFERENCES `event` (`event_id`),
+  CONSTRAINT `FK_TicketCashback_User` FOREIGN KEY (`user_id`) REFERENCES `users` (`user_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,10 @@
+-- ============================================================
+-- 057 - QR động (xoay vòng 30 giây) cho sự kiện cần bảo mật cao
+-- event.dynamic_qr = 1: check-in chỉ nhận mã RQR: lấy từ GET /api/registrations/{ticketId}/qr-token
+--   (common/qrtoken), QR tĩnh trong email / PDF bị từ chối; do chủ sự kiện / co-organizer
+--   (EDIT_DETAILS) hoặc ADMIN bật qua PUT /api/events/{id}/dynamic-qr
+-- ============================================================
+USE `fpteventmanagement`;
+
+ALTER TABLE `event`
+  ADD COLUMN `dynamic_qr` tinyint(1) NOT NULL DEFAULT 0;
@@ -0,0 +1,12 @@
+-- ============================================================
+-- 058 - Hàng chờ mở bán (phòng chờ ảo) cho sự kiện flash sale
+-- event.booking_queue = 1: đặt vé (VNPay / ví) chỉ nhận người đã được dispatcher cho vào
+--   (common/waitingroom); người chưa tới lượt nhận vị trí hàng chờ; mua vé khách tạm đóng
+-- event.queue_admit_per_interval: số người được vào bước giữ ghế mỗi nhịp
+--   (BOOKING_QUEUE_INTERVAL_SECONDS); bật qua PUT /api/events/{id}/booking-queue
+-- ============================================================
+USE `fpteventmanagement`;
+
+ALTER TABLE `event`
+  ADD COLUMN `booking_queue` tinyint(1) NOT NULL DEFAULT 0,
+  ADD COLUMN `queue_admit_per_interval` int NOT NULL DEFAULT 50 COMMENT 'Số người được vào mỗi nhịp dispatcher';
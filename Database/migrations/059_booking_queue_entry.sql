@@ -0,0 +1,25 @@
+-- ============================================================
+-- 059 - Hàng chờ mở bán lưu trong MySQL (common/waitingroom)
+-- booking_queue_entry: mỗi người đang chờ / đã được vào của một sự kiện bật booking_queue
+--   entry_id tăng dần = thứ tự đến; admitted_until NULL = đang chờ
+--   last_seen_at: lần hỏi trạng thái gần nhất (quá BOOKING_QUEUE_IDLE_SECONDS thì bị bỏ)
+-- Dispatcher là job "booking-queue-dispatch" của scheduler (GET_LOCK: một instance mỗi nhịp)
+-- nên mọi instance API / Lambda dùng chung một hàng và giới hạn admitPerInterval
+-- ============================================================
+USE `fpteventmanagement`;
+
+CREATE TABLE IF NOT EXISTS `booking_queue_entry` (
+  `entry_id` bigint NOT NULL AUTO_INCREMENT,
+  `event_id` int NOT NULL,
+  `user_id` int NOT NULL,
+  `token` char(32) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `joined_at` datetime(6) NOT NULL,
+  `last_seen_at` datetime(6) NOT NULL,
+  `admitted_until` datetime(6) DEFAULT NULL,
+  PRIMARY KEY (`entry_id`),
+  UNIQUE KEY `UQ_BookingQueue_Event_User` (`event_id`, `user_id`),
+  UNIQUE KEY `UQ_BookingQueue_Token` (`token`),
+  KEY `IX_BookingQueue_Event_Waiting` (`event_id`, `admitted_until`, `entry_id`),
+  CONSTRAINT `FK_BookingQueue_Event` FOREIGN KEY (`event_id`) REFERENCES `event` (`event_id`) ON DELETE CASCADE,
+  CONSTRAINT `FK_BookingQueue_User` FOREIGN KEY (`user_id`) REFERENCES `users` (`user_id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,372 @@
+package cli
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/fpt-event-services/common/crypto"
+	"github.com/fpt-event-services/common/db"
+	apperrors "github.com/fpt-event-services/common/errors"
+	"github.com/fpt-event-services/common/scheduler"
+	"github.com/fpt-event-services/common/validator"
+	authModels "github.com/fpt-event-services/services/auth-lambda/models"
+	authRepository "github.com/fpt-event-services/services/auth-lambda/repository"
+	eventRepository "github.com/fpt-event-services/services/event-lambda/repository"
+	ticketRepository "github.com/fpt-event-services/services/ticket-lambda/repository"
+	"github.com/spf13/cobra"
+)
+
+// ============================================================
+// backend admin - Thao tác vận hành trực tiếp trên DB
+// Mỗi lệnh gọi lại repository của service thay vì SQL viết tay
+// ============================================================
+
+func newAdminCommand() *cobra.Command {
+	admin := &cobra.Command{
+		Use:   "admin",
+		Short: "Operational tasks (accounts, venues, tickets, jobs, speakers)",
+	}
+	admin.AddCommand(
+		newCreateAdminCommand(),
+		newResetPasswordCommand(),
+		newReleaseVenueCommand(),
+		newReissueTicketEmailCommand(),
+		newRunJobCommand(),
+		newAssignSpeakerCommand(),
+		newEncryptPIICommand(),
+	)
+	return admin
+}
+
+// withDB mở kết nối DB cho một lệnh rồi đóng lại khi xong
+func withDB(cmd *cobra.Command, fn func(ctx context.Context) error) error {
+	if err := openDB(); err != nil {
+		return err
+	}
+	defer db.CloseDB()
+	return fn(cmd.Context())
+}
+
+// ------------------------------------------------------------
+// create-admin --email --name [--phone] [--password]
+// ------------------------------------------------------------
+func newCreateAdminCommand() *cobra.Command {
+	var email, name, phone, password string
+	cmd := &cobra.Command{
+		Use:   "create-admin",
+		Short: "Create an ADMIN account (prints a generated password when --password is omitted)",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			email = strings.TrimSpace(email)
+			if msg := validator.GetEmailError(email); msg != "" {
+				return errors.New(msg)
+			}
+			if msg := validator.GetFullNameError(name); msg != "" {
+				return errors.New(msg)
+			}
+			if phone != "" {
+				if msg := validator.GetPhoneError(phone); msg != "" {
+					return errors.New(msg)
+				}
+			}
+			generated := password == ""
+			if generated {
+				password = generatePassword()
+			} else if msg := validator.GetPasswordError(password); msg != "" {
+				return errors.New(msg)
+			}
+
+			return withDB(cmd, func(ctx context.Context) error {
+				repo := authRepository.NewUserRepository()
+				exists, err := repo.ExistsByEmail(ctx, email)
+				if err != nil {
+					return err
+				}
+				if exists {
+					return fmt.Errorf("email %s đã tồn tại", email)
+				}
+				userID, err := repo.AdminCreateAccount(ctx, authModels.AdminCreateAccountRequest{
+					FullName: strings.TrimSpace(name),
+					Email:    email,
+					Phone:    strings.TrimSpace(phone),
+					Password: password,
+					Role:     "ADMIN",
+					Status:   "ACTIVE",
+				})
+				if err != nil {
+					return err
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "Created ADMIN user #%d (%s)\n", userID, email)
+				if generated {
+					fmt.Fprintf(cmd.OutOrStdout(), "Password: %s\n", password)
+				}
+				return nil
+			})
+		},
+	}
+	cmd.Flags().StringVar(&email, "email", "", "login email")
+	cmd.Flags().StringVar(&name, "name", "", "full name")
+	cmd.Flags().StringVar(&phone, "phone", "", "Vietnamese phone number (optional)")
+	cmd.Flags().StringVar(&password, "password", "", "password (generated when omitted)")
+	cmd.MarkFlagRequired("email")
+	cmd.MarkFlagRequired("name")
+	return cmd
+}
+
+// ------------------------------------------------------------
+// reset-password --email [--password]
+// ------------------------------------------------------------
+func newResetPasswordCommand() *cobra.Command {
+	var email, password string
+	cmd := &cobra.Command{
+		Use:   "reset-password",
+		Short: "Set a new password for an account (prints a generated password when --password is omitted)",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			email = strings.TrimSpace(email)
+			generated := password == ""
+			if generated {
+				password = generatePassword()
+			} else if msg := validator.GetPasswordError(password); msg != "" {
+				return errors.New(msg)
+			}
+
+			return withDB(cmd, func(ctx context.Context) error {
+				if err := authRepository.NewUserRepository().UpdatePasswordByEmail(ctx, email, password); err != nil {
+					return err
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "Password reset for %s\n", email)
+				if generated {
+					fmt.Fprintf(cmd.OutOrStdout(), "Password: %s\n", password)
+				}
+				return nil
+			})
+		},
+	}
+	cmd.Flags().StringVar(&email, "email", "", "account email")
+	cmd.Flags().StringVar(&password, "password", "", "new password (generated when omitted)")
+	cmd.MarkFlagRequired("email")
+	return cmd
+}
+
+// ------------------------------------------------------------
+// release-venue --area-id N | --all
+// ------------------------------------------------------------
+func newReleaseVenueCommand() *cobra.Command {
+	var areaID int
+	var all bool
+	cmd := &cobra.Command{
+		Use:   "release-venue",
+		Short: "Mark a venue area AVAILABLE again when no OPEN/UPDATING event uses it",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if all == (areaID > 0) {
+				return errors.New("dùng đúng một trong --area-id hoặc --all")
+			}
+
+			return withDB(cmd, func(ctx context.Context) error {
+				repo := eventRepository.NewEventRepository()
+				if all {
+					return repo.AutoReleaseVenues(ctx)
+				}
+				released, err := repo.ReleaseVenueArea(ctx, areaID)
+				if errors.Is(err, eventRepository.ErrAreaNotFound) {
+					return fmt.Errorf("khu vực #%d không tồn tại", areaID)
+				}
+				if errors.Is(err, eventRepository.ErrAreaInUse) {
+					return fmt.Errorf("khu vực #%d vẫn đang được sự kiện OPEN/UPDATING sử dụng", areaID)
+				}
+				if err != nil {
+					return err
+				}
+				if released {
+					fmt.Fprintf(cmd.OutOrStdout(), "Released venue area #%d\n", areaID)
+				} else {
+					fmt.Fprintf(cmd.OutOrStdout(), "Venue area #%d is not UNAVAILABLE, nothing to do\n", areaID)
+				}
+				return nil
+			})
+		},
+	}
+	cmd.Flags().IntVar(&areaID, "area-id", 0, "venue area to release")
+	cmd.Flags().BoolVar(&all, "all", false, "release every area without an active event")
+	return cmd
+}
+
+// ------------------------------------------------------------
+// reissue-ticket-email --ticket-id N [--ticket-id M ...]
+// ------------------------------------------------------------
+func newReissueTicketEmailCommand() *cobra.Command {
+	var ticketIDs []int
+	cmd := &cobra.Command{
+		Use:   "reissue-ticket-email",
+		Short: "Send the e-ticket email (QR + PDF) again to the ticket owner",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return withDB(cmd, func(ctx context.Context) error {
+				repo := ticketRepository.NewTicketRepository()
+				failed := 0
+				for _, id := range ticketIDs {
+					if err := repo.ReissueTicketEmail(ctx, id); err != nil {
+						failed++
+						if appErr, ok := apperrors.AsAppError(err); ok {
+							err = errors.New(appErr.Message)
+						}
+						fmt.Fprintf(cmd.ErrOrStderr(), "Ticket #%d: %v\n", id, err)
+						continue
+					}
+					fmt.Fprintf(cmd.OutOrStdout(), "Ticket #%d: email queued\n", id)
+				}
+				if failed > 0 {
+					return fmt.Errorf("%d/%d ticket(s) failed", failed, len(ticketIDs))
+				}
+				return nil
+			})
+		},
+	}
+	cmd.Flags().IntSliceVar(&ticketIDs, "ticket-id", nil, "ticket ID (repeatable or comma-separated)")
+	cmd.MarkFlagRequired("ticket-id")
+	return cmd
+}
+
+// ------------------------------------------------------------
+// run-job [name] - Chạy lại job đối soát/dọn dẹp và chờ kết quả (không tên = liệt kê)
+// ------------------------------------------------------------
+func newRunJobCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "run-job [name]",
+		Short: "Run a scheduler job (archival, cleanup, venue release, email queue) and wait for it",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return withDB(cmd, func(ctx context.Context) error {
+				conn := db.GetDB()
+				manager := scheduler.NewManager(scheduler.NewRunStore(conn), scheduler.NewMySQLLocker(conn))
+				if err := scheduler.RegisterDefaultJobs(manager); err != nil {
+					return err
+				}
+				if len(args) == 0 {
+					for _, name := range manager.Names() {
+						fmt.Fprintln(cmd.OutOrStdout(), name)
+					}
+					return nil
+				}
+
+				run, err := manager.RunNowAndWait(args[0], nil)
+				if err != nil {
+					return err
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "Job %s: %s", run.JobName, run.Status)
+				if run.DurationMs != nil {
+					fmt.Fprintf(cmd.OutOrStdout(), " (%dms)", *run.DurationMs)
+				}
+				fmt.Fprintln(cmd.OutOrStdout())
+				if run.Status == scheduler.RunStatusFailed && run.ErrorMessage != nil {
+					return errors.New(*run.ErrorMessage)
+				}
+				return nil
+			})
+		},
+	}
+}
+
+// ------------------------------------------------------------
+// assign-speaker --event-id N --speaker-id M
+// ------------------------------------------------------------
+func newAssignSpeakerCommand() *cobra.Command {
+	var eventID, speakerID int
+	cmd := &cobra.Command{
+		Use:   "assign-speaker",
+		Short: "Attach an existing speaker to an event",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if eventID <= 0 || speakerID <= 0 {
+				return errors.New("--event-id và --speaker-id phải là số dương")
+			}
+			return withDB(cmd, func(ctx context.Context) error {
+				err := eventRepository.NewEventRepository().AssignSpeaker(ctx, eventID, speakerID)
+				switch {
+				case errors.Is(err, eventRepository.ErrSpeakerNotFound):
+					return fmt.Errorf("speaker #%d không tồn tại", speakerID)
+				case errors.Is(err, eventRepository.ErrEventNotFound):
+					return fmt.Errorf("sự kiện #%d không tồn tại", eventID)
+				case err != nil:
+					return err
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "Assigned speaker #%d to event #%d\n", speakerID, eventID)
+				return nil
+			})
+		},
+	}
+	cmd.Flags().IntVar(&eventID, "event-id", 0, "event ID")
+	cmd.Flags().IntVar(&speakerID, "speaker-id", 0, "speaker ID")
+	cmd.MarkFlagRequired("event-id")
+	cmd.MarkFlagRequired("speaker-id")
+	return cmd
+}
+
+// ------------------------------------------------------------
+// encrypt-pii [--dry-run] [--batch N] - Mã hóa số điện thoại user, email/số điện thoại
+// speaker còn lưu nguyên văn (chạy sau migration 026, chạy lại nhiều lần vẫn an toàn)
+// ------------------------------------------------------------
+func newEncryptPIICommand() *cobra.Command {
+	var dryRun bool
+	var batch int
+	cmd := &cobra.Command{
+		Use:   "encrypt-pii",
+		Short: "Encrypt plaintext PII columns (user phone, speaker email/phone) with PII_ENCRYPTION_KEY",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if batch <= 0 {
+				return errors.New("--batch phải là số dương")
+			}
+			c, err := crypto.Default()
+			if err != nil {
+				return err
+			}
+			if c == nil {
+				return errors.New("chưa cấu hình PII_ENCRYPTION_KEY / PII_ENCRYPTION_KEY_FILE")
+			}
+			return withDB(cmd, func(ctx context.Context) error {
+				users, err := authRepository.NewUserRepository().EncryptPlaintextPhones(ctx, batch, dryRun)
+				if err != nil {
+					return err
+				}
+				speakers, err := eventRepository.NewEventRepository().EncryptPlaintextSpeakerContacts(ctx, batch, dryRun)
+				if err != nil {
+					return err
+				}
+				verb := "Encrypted"
+				if dryRun {
+					verb = "Would encrypt"
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "%s %d user phone(s), %d speaker contact(s)\n", verb, users, speakers)
+				return nil
+			})
+		},
+	}
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "only count rows that still hold plaintext")
+	cmd.Flags().IntVar(&batch, "batch", 200, "rows per batch")
+	return cmd
+}
+
+// generatePassword sinh mật khẩu ngẫu nhiên 14 ký tự thỏa validator (có chữ và số)
+func generatePassword() string {
+	const alphabet = "ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnpqrstuvwxyz23456789"
+	for {
+		b := make([]byte, 14)
+		for i := range b {
+			n, err := rand.Int(rand.Reader, big.NewInt(int64(len(alphabet))))
+			if err != nil {
+				panic(fmt.Sprintf("cli: cannot read random: %v", err))
+			}
+			b[i] = alphabet[n.Int64()]
+		}
+		if p := string(b); validator.IsValidPassword(p) {
+			return p
+		}
+	}
+}
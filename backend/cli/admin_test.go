@@ -0,0 +1,52 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/fpt-event-services/common/validator"
+)
+
+func TestAdminCommandTree(t *testing.T) {
+	want := []string{"create-admin", "reset-password", "release-venue", "reissue-ticket-email", "run-job", "assign-speaker", "encrypt-pii"}
+	admin := newAdminCommand()
+	for _, name := range want {
+		if cmd, _, err := admin.Find([]string{name}); err != nil || cmd.Name() != name {
+			t.Errorf("admin %s not registered", name)
+		}
+	}
+}
+
+// Thiếu cờ bắt buộc / cờ không hợp lệ phải báo lỗi trước khi mở kết nối DB
+func TestAdminFlagValidation(t *testing.T) {
+	cases := [][]string{
+		{"admin", "create-admin", "--email", "admin@fpt.edu.vn"},
+		{"admin", "create-admin", "--email", "not-an-email", "--name", "Quản trị viên"},
+		{"admin", "reset-password", "--email", "a@fpt.edu.vn", "--password", "123"},
+		{"admin", "release-venue"},
+		{"admin", "release-venue", "--area-id", "3", "--all"},
+		{"admin", "assign-speaker", "--event-id", "1"},
+		{"admin", "encrypt-pii", "--batch", "0"},
+	}
+	for _, args := range cases {
+		root := newRootCommand()
+		var out bytes.Buffer
+		root.SetOut(&out)
+		root.SetErr(&out)
+		root.SetArgs(args)
+		if err := root.Execute(); err == nil {
+			t.Errorf("%s: expected error", strings.Join(args, " "))
+		}
+	}
+}
+
+func TestGeneratePassword(t *testing.T) {
+	p := generatePassword()
+	if len(p) != 14 || !validator.IsValidPassword(p) {
+		t.Fatalf("generated password %q does not satisfy the password policy", p)
+	}
+	if p == generatePassword() {
+		t.Fatal("generated passwords must differ")
+	}
+}
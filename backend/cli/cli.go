@@ -0,0 +1,44 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/fpt-event-services/common/db"
+	"github.com/spf13/cobra"
+)
+
+// ============================================================
+// CLI - Các lệnh chạy bằng chính binary backend thay vì HTTP server
+//   backend admin <lệnh>   thao tác vận hành (tạo admin, reset mật khẩu...)
+//   backend seed           nạp dữ liệu mẫu cho môi trường local / CI
+// Không tham số → main.go khởi động server như trước
+// ============================================================
+
+// Execute chạy lệnh CLI (args không gồm tên chương trình), trả về exit code
+func Execute(args []string) int {
+	root := newRootCommand()
+	root.SetArgs(args)
+	if err := root.Execute(); err != nil {
+		return 1
+	}
+	return 0
+}
+
+func newRootCommand() *cobra.Command {
+	root := &cobra.Command{
+		Use:           "backend",
+		Short:         "FPT Event Services backend",
+		SilenceUsage:  true,
+		SilenceErrors: false,
+	}
+	root.AddCommand(newAdminCommand(), newSeedCommand())
+	return root
+}
+
+// openDB kết nối MySQL theo biến môi trường (DB_SERVER, DB_NAME...) như server
+func openDB() error {
+	if err := db.InitDB(); err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	return nil
+}
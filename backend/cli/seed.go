@@ -0,0 +1,73 @@
+package cli
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/fpt-event-services/common/db"
+	"github.com/fpt-event-services/common/fixtures"
+	"github.com/spf13/cobra"
+)
+
+// ============================================================
+// backend seed - Nạp dữ liệu mẫu (common/fixtures) cho môi trường local / CI
+// Chạy lại nhiều lần không tạo trùng; sự kiện đã có được giữ nguyên
+// ============================================================
+
+func newSeedCommand() *cobra.Command {
+	var password string
+	var loadTestUsers int
+	var loadTestWallet float64
+	cmd := &cobra.Command{
+		Use:   "seed",
+		Short: "Load sample venues, users, events, tickets and bills (idempotent)",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if loadTestUsers < 0 {
+				return fmt.Errorf("--load-test-users must not be negative")
+			}
+			if err := openDB(); err != nil {
+				return err
+			}
+			defer db.CloseDB()
+
+			set := fixtures.Default()
+			set.Users = append(set.Users, fixtures.LoadTestUsers(loadTestUsers, loadTestWallet)...)
+			res, err := fixtures.Load(cmd.Context(), db.GetDB(), set, fixtures.Options{Password: password})
+			if err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+			kinds := make([]string, 0, len(res.Created))
+			for kind := range res.Created {
+				kinds = append(kinds, kind)
+			}
+			sort.Strings(kinds)
+			if len(kinds) == 0 {
+				fmt.Fprintln(out, "Seed data already present, nothing created")
+			}
+			for _, kind := range kinds {
+				fmt.Fprintf(out, "Created %d %s\n", res.Created[kind], kind)
+			}
+
+			fmt.Fprintln(out, "\nAccounts (new accounts use the seed password):")
+			for _, u := range set.Users[:len(set.Users)-loadTestUsers] {
+				fmt.Fprintf(out, "  %-10s #%-5d %s\n", u.Role, res.UserIDs[u.Key], u.Email)
+			}
+			if loadTestUsers > 0 {
+				fmt.Fprintf(out, "  %-10s %d load-test accounts %s\n", "STUDENT", loadTestUsers,
+					fmt.Sprintf(fixtures.LoadTestEmailPattern+" .. "+fixtures.LoadTestEmailPattern, 1, loadTestUsers))
+			}
+			fmt.Fprintln(out, "\nEvents:")
+			for _, e := range set.Events {
+				fmt.Fprintf(out, "  %-10s #%-5d %s\n", e.Status, res.EventIDs[e.Key], e.Title)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&password, "password", fixtures.DefaultPassword, "password for newly created accounts")
+	cmd.Flags().IntVar(&loadTestUsers, "load-test-users", 0, "also create N student accounts for cmd/loadgen")
+	cmd.Flags().Float64Var(&loadTestWallet, "load-test-wallet", 1000000, "wallet balance of new load-test accounts")
+	return cmd
+}
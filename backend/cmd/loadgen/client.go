@@ -0,0 +1,321 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ============================================================
+// CLIENT - Gọi API của môi trường cần đo, ghi độ trễ từng bước vào Recorder
+// Không tự follow redirect: /api/buyTicket trả 302 về trang kết quả thanh toán
+// ============================================================
+
+type apiClient struct {
+	base string
+	http *http.Client
+	rec  *Recorder
+}
+
+func newAPIClient(base string, timeout time.Duration, maxConns int, rec *Recorder) *apiClient {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConns = maxConns
+	transport.MaxIdleConnsPerHost = maxConns
+	return &apiClient{
+		base: strings.TrimRight(base, "/"),
+		http: &http.Client{
+			Timeout:   timeout,
+			Transport: transport,
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		rec: rec,
+	}
+}
+
+type apiResponse struct {
+	Status   int
+	Body     map[string]interface{}
+	Location string
+	Latency  time.Duration
+}
+
+// message lấy thông báo lỗi server trả về (message / error) để gom nhóm lý do thất bại
+func (r *apiResponse) message() string {
+	for _, key := range []string{"message", "error"} {
+		if msg, ok := r.Body[key].(string); ok && msg != "" {
+			if len(msg) > 80 {
+				msg = msg[:80] + "..."
+			}
+			return fmt.Sprintf("%d %s", r.Status, msg)
+		}
+	}
+	return fmt.Sprintf("%d %s", r.Status, http.StatusText(r.Status))
+}
+
+// call gửi request và ghi kết quả mặc định: 2xx ok, 4xx rejected, còn lại error
+func (c *apiClient) call(ctx context.Context, op, method, path, token string, body interface{}) (*apiResponse, error) {
+	resp, err := c.send(ctx, method, path, token, body)
+	if err != nil {
+		c.recordTransportError(ctx, op, resp, err)
+		return nil, err
+	}
+	switch {
+	case resp.Status >= 200 && resp.Status < 300:
+		c.rec.Record(op, resp.Latency, outcomeOK, "")
+		return resp, nil
+	case resp.Status >= 400 && resp.Status < 500:
+		c.rec.Record(op, resp.Latency, outcomeRejected, resp.message())
+	default:
+		c.rec.Record(op, resp.Latency, outcomeError, resp.message())
+	}
+	return resp, fmt.Errorf("%s: %s", op, resp.message())
+}
+
+func (c *apiClient) send(ctx context.Context, method, path, token string, body interface{}) (*apiResponse, error) {
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return &apiResponse{}, err
+		}
+		reader = bytes.NewReader(payload)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.base+path, reader)
+	if err != nil {
+		return &apiResponse{}, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	start := time.Now()
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return &apiResponse{Latency: time.Since(start)}, err
+	}
+	defer resp.Body.Close()
+	raw, err := io.ReadAll(resp.Body)
+	out := &apiResponse{Status: resp.StatusCode, Location: resp.Header.Get("Location"), Latency: time.Since(start)}
+	if err != nil {
+		return out, err
+	}
+	// Body không phải JSON object (mảng, text) thì bỏ qua, chỉ dùng status
+	_ = json.Unmarshal(raw, &out.Body)
+	return out, nil
+}
+
+// recordTransportError ghi lỗi kết nối / timeout
+// Request bị hủy do hết -duration hoặc Ctrl+C thì không tính là lỗi của server
+func (c *apiClient) recordTransportError(ctx context.Context, op string, resp *apiResponse, err error) {
+	if ctx.Err() != nil {
+		return
+	}
+	reason := "transport: " + err.Error()
+	var netErr interface{ Timeout() bool }
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		reason = "timeout"
+	}
+	c.rec.Record(op, resp.Latency, outcomeError, reason)
+}
+
+// ============================================================
+// Các bước nghiệp vụ
+// ============================================================
+
+type session struct {
+	Token  string
+	UserID int
+}
+
+func (c *apiClient) login(ctx context.Context, email, password string) (*session, error) {
+	resp, err := c.call(ctx, "login", http.MethodPost, "/api/login", "", map[string]string{"email": email, "password": password})
+	if err != nil {
+		return nil, err
+	}
+	token, _ := resp.Body["token"].(string)
+	user, _ := resp.Body["user"].(map[string]interface{})
+	id, _ := user["id"].(float64)
+	if token == "" || id == 0 {
+		return nil, fmt.Errorf("login %s: no token/user in response", email)
+	}
+	return &session{Token: token, UserID: int(id)}, nil
+}
+
+// seat - Trường cần dùng từ GET /api/seats
+type seat struct {
+	SeatID            int    `json:"seatId"`
+	Status            string `json:"status"`
+	CategoryTicketID  *int   `json:"categoryTicketId"`
+	CompanionOfSeatID *int   `json:"companionOfSeatId"`
+}
+
+func (c *apiClient) seats(ctx context.Context, token string, eventID int) ([]seat, error) {
+	resp, err := c.call(ctx, "seats", http.MethodGet, "/api/seats?eventId="+strconv.Itoa(eventID), token, nil)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := json.Marshal(resp.Body["seats"])
+	if err != nil {
+		return nil, err
+	}
+	var seats []seat
+	if err := json.Unmarshal(raw, &seats); err != nil {
+		return nil, fmt.Errorf("seats: %w", err)
+	}
+	return seats, nil
+}
+
+// hold - GET /api/payment-ticket: giữ ghế (vé PENDING) và nhận link VNPay
+func (c *apiClient) hold(ctx context.Context, s *session, eventID, categoryID int, seatIDs []int) (paymentURL string, ticketIDs []int, err error) {
+	q := url.Values{}
+	q.Set("userId", strconv.Itoa(s.UserID))
+	q.Set("eventId", strconv.Itoa(eventID))
+	q.Set("categoryTicketId", strconv.Itoa(categoryID))
+	q.Set("seatIds", joinInts(seatIDs))
+	resp, err := c.call(ctx, "hold", http.MethodGet, "/api/payment-ticket?"+q.Encode(), s.Token, nil)
+	if err != nil {
+		return "", nil, err
+	}
+	paymentURL, _ = resp.Body["paymentUrl"].(string)
+	return paymentURL, parseTicketIDs(resp.Body["ticketIds"]), nil
+}
+
+// vnpayReturn - Giả lập VNPay redirect về /api/buyTicket
+// responseCode "00" = thanh toán thành công, "24" = khách hủy (server xóa vé PENDING, nhả ghế)
+func (c *apiClient) vnpayReturn(ctx context.Context, op, paymentURL, responseCode, secret string) error {
+	params, err := vnpayReturnParams(paymentURL, responseCode, secret)
+	if err != nil {
+		c.rec.Record(op, 0, outcomeError, err.Error())
+		return err
+	}
+	resp, err := c.send(ctx, http.MethodGet, "/api/buyTicket?"+params.Encode(), "", nil)
+	if err != nil {
+		c.recordTransportError(ctx, op, resp, err)
+		return err
+	}
+	if resp.Status != http.StatusFound {
+		c.rec.Record(op, resp.Latency, outcomeError, resp.message())
+		return fmt.Errorf("%s: %s", op, resp.message())
+	}
+
+	// Kết quả nằm trong URL redirect: status=success|failed&reason=...
+	loc, err := url.Parse(resp.Location)
+	if err != nil {
+		c.rec.Record(op, resp.Latency, outcomeError, "invalid redirect location")
+		return err
+	}
+	result := loc.Query()
+	wantSuccess := responseCode == "00"
+	if (result.Get("status") == "success") != wantSuccess {
+		reason := "302 " + result.Get("reason")
+		c.rec.Record(op, resp.Latency, outcomeRejected, reason)
+		return fmt.Errorf("%s: %s", op, reason)
+	}
+	c.rec.Record(op, resp.Latency, outcomeOK, "")
+	return nil
+}
+
+// walletPay - POST /api/wallet/pay-ticket: trừ ví và tạo vé ngay, không qua bước giữ ghế
+func (c *apiClient) walletPay(ctx context.Context, s *session, eventID, categoryID int, seatIDs []int) ([]int, error) {
+	resp, err := c.call(ctx, "pay", http.MethodPost, "/api/wallet/pay-ticket", s.Token, map[string]interface{}{
+		"eventId":          eventID,
+		"categoryTicketId": categoryID,
+		"seatIds":          seatIDs,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return parseTicketIDs(resp.Body["ticketIds"]), nil
+}
+
+// checkin - POST /api/staff/checkin bằng tài khoản Organizer của sự kiện
+func (c *apiClient) checkin(ctx context.Context, s *session, ticketID int) error {
+	_, err := c.call(ctx, "checkin", http.MethodPost, "/api/staff/checkin?ticketCode="+strconv.Itoa(ticketID), s.Token, nil)
+	return err
+}
+
+// vnpayReturnParams dựng query VNPay trả về từ link thanh toán
+// Ký HMAC-SHA512 giống common/vnpay khi có secret (server kiểm tra chữ ký); không có secret thì bỏ qua
+func vnpayReturnParams(paymentURL, responseCode, secret string) (url.Values, error) {
+	u, err := url.Parse(paymentURL)
+	if err != nil || u.Query().Get("vnp_TxnRef") == "" {
+		return nil, fmt.Errorf("invalid payment url")
+	}
+	src := u.Query()
+	params := map[string]string{
+		"vnp_Amount":            src.Get("vnp_Amount"),
+		"vnp_BankCode":          "NCB",
+		"vnp_OrderInfo":         src.Get("vnp_OrderInfo"),
+		"vnp_PayDate":           time.Now().Format("20060102150405"),
+		"vnp_ResponseCode":      responseCode,
+		"vnp_TmnCode":           src.Get("vnp_TmnCode"),
+		"vnp_TransactionNo":     strconv.FormatInt(time.Now().UnixNano()%1e8, 10),
+		"vnp_TransactionStatus": responseCode,
+		"vnp_TxnRef":            src.Get("vnp_TxnRef"),
+	}
+
+	out := url.Values{}
+	keys := make([]string, 0, len(params))
+	for k, v := range params {
+		out.Set(k, v)
+		keys = append(keys, k)
+	}
+	if secret != "" {
+		sort.Strings(keys)
+		parts := make([]string, 0, len(keys))
+		for _, k := range keys {
+			if params[k] != "" {
+				parts = append(parts, k+"="+url.QueryEscape(params[k]))
+			}
+		}
+		mac := hmac.New(sha512.New, []byte(secret))
+		mac.Write([]byte(strings.Join(parts, "&")))
+		out.Set("vnp_SecureHash", strings.ToUpper(hex.EncodeToString(mac.Sum(nil))))
+	}
+	return out, nil
+}
+
+// parseTicketIDs đọc ticketIds dạng mảng số ([1,2]) hoặc chuỗi "1,2" (API ví)
+func parseTicketIDs(v interface{}) []int {
+	var ids []int
+	switch val := v.(type) {
+	case []interface{}:
+		for _, item := range val {
+			if f, ok := item.(float64); ok {
+				ids = append(ids, int(f))
+			}
+		}
+	case string:
+		for _, part := range strings.Split(val, ",") {
+			if id, err := strconv.Atoi(strings.TrimSpace(part)); err == nil {
+				ids = append(ids, id)
+			}
+		}
+	}
+	return ids
+}
+
+func joinInts(ids []int) string {
+	parts := make([]string, len(ids))
+	for i, id := range ids {
+		parts[i] = strconv.Itoa(id)
+	}
+	return strings.Join(parts, ",")
+}
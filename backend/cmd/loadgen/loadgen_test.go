@@ -0,0 +1,119 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/fpt-event-services/common/vnpay"
+)
+
+func TestPercentile(t *testing.T) {
+	var samples []time.Duration
+	for i := 1; i <= 100; i++ {
+		samples = append(samples, time.Duration(i)*time.Millisecond)
+	}
+	cases := map[float64]time.Duration{50: 50 * time.Millisecond, 95: 95 * time.Millisecond, 99: 99 * time.Millisecond, 100: 100 * time.Millisecond}
+	for p, want := range cases {
+		if got := percentile(samples, p); got != want {
+			t.Errorf("p%v = %v, want %v", p, got, want)
+		}
+	}
+	if got := percentile(samples[:1], 99); got != time.Millisecond {
+		t.Errorf("single sample p99 = %v", got)
+	}
+	if got := percentile(nil, 50); got != 0 {
+		t.Errorf("empty p50 = %v", got)
+	}
+}
+
+func TestRecorderSummaries(t *testing.T) {
+	rec := NewRecorder()
+	rec.Record("hold", 10*time.Millisecond, outcomeOK, "")
+	rec.Record("hold", 20*time.Millisecond, outcomeRejected, "400 seat taken")
+	rec.Record("hold", 30*time.Millisecond, outcomeRejected, "400 seat taken")
+	rec.Record("hold", 40*time.Millisecond, outcomeError, "timeout")
+	rec.Record("pay", 5*time.Millisecond, outcomeOK, "")
+
+	sums := rec.Summaries()
+	if len(sums) != 2 || sums[0].Op != "hold" || sums[1].Op != "pay" {
+		t.Fatalf("unexpected steps %+v", sums)
+	}
+	hold := sums[0]
+	if hold.Count != 4 || hold.OK != 1 || hold.Rejected != 2 || hold.Errors != 1 {
+		t.Errorf("counts = %+v", hold)
+	}
+	if hold.ErrorRate != 0.25 || hold.RPS <= 0 || hold.Max != 40*time.Millisecond {
+		t.Errorf("rates = %v %v %v", hold.ErrorRate, hold.RPS, hold.Max)
+	}
+	if len(hold.TopReasons) != 2 || hold.TopReasons[0] != (ReasonCount{"400 seat taken", 2}) {
+		t.Errorf("top reasons = %+v", hold.TopReasons)
+	}
+}
+
+// Chữ ký của callback giả lập phải qua được bước kiểm tra của common/vnpay
+func TestVNPayReturnParamsSigned(t *testing.T) {
+	cfg := vnpay.DefaultConfig()
+	cfg.TmnCode, cfg.HashSecret = "LOADTEST", "LOADTESTSECRET"
+	service := vnpay.NewVNPayService(cfg)
+	paymentURL, err := service.CreatePaymentURL(vnpay.PaymentRequest{
+		OrderInfo: "Payment for Hội thảo - 2 seats",
+		Amount:    150000,
+		TxnRef:    "7_3_12_101,102_1700000000000",
+		IPAddr:    "127.0.0.1",
+	})
+	if err != nil {
+		t.Fatalf("CreatePaymentURL: %v", err)
+	}
+
+	params, err := vnpayReturnParams(paymentURL, "00", cfg.HashSecret)
+	if err != nil {
+		t.Fatalf("vnpayReturnParams: %v", err)
+	}
+	resp, err := service.VerifyCallback(params)
+	if err != nil {
+		t.Fatalf("VerifyCallback: %v", err)
+	}
+	if !resp.IsSuccess || resp.TxnRef != "7_3_12_101,102_1700000000000" || resp.Amount != "15000000" {
+		t.Errorf("unexpected callback %+v", resp)
+	}
+
+	if _, err := vnpayReturnParams("http://example.com/pay", "00", ""); err == nil {
+		t.Error("payment url without vnp_TxnRef must be rejected")
+	}
+}
+
+func TestParseTicketIDs(t *testing.T) {
+	if got := parseTicketIDs([]interface{}{float64(4), float64(5)}); !reflect.DeepEqual(got, []int{4, 5}) {
+		t.Errorf("array = %v", got)
+	}
+	if got := parseTicketIDs("4, 5"); !reflect.DeepEqual(got, []int{4, 5}) {
+		t.Errorf("string = %v", got)
+	}
+}
+
+func TestPickSeatsSameCategory(t *testing.T) {
+	vip, std, companion := 1, 2, 10
+	seats := []seat{
+		{SeatID: 10, Status: "AVAILABLE", CategoryTicketID: &vip},
+		{SeatID: 11, Status: "BOOKED", CategoryTicketID: &vip},
+		{SeatID: 12, Status: "AVAILABLE", CategoryTicketID: &vip, CompanionOfSeatID: &companion},
+		{SeatID: 20, Status: "AVAILABLE", CategoryTicketID: &std},
+		{SeatID: 21, Status: "AVAILABLE", CategoryTicketID: &std},
+		{SeatID: 30, Status: "AVAILABLE"},
+	}
+	w := &worker{cfg: &config{seatsPerBuy: 4}}
+	for seed := int64(0); seed < 20; seed++ {
+		w.rnd = newRand(seed)
+		category, ids := w.pickSeats(seats)
+		want := map[int][]int{vip: {10}, std: {20, 21}}[category]
+		if len(ids) != len(want) {
+			t.Fatalf("category %d: picked %v, want seats %v", category, ids, want)
+		}
+	}
+
+	w.rnd = newRand(1)
+	if _, ids := w.pickSeats(seats[1:2]); len(ids) != 0 {
+		t.Errorf("booked seat picked: %v", ids)
+	}
+}
@@ -0,0 +1,292 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/fpt-event-services/common/fixtures"
+)
+
+// ============================================================
+// LOADGEN - Giả lập nhiều sinh viên cùng giữ ghế, thanh toán và check-in
+// trên một môi trường đang chạy để ước lượng tải khi mở bán sự kiện lớn
+//
+// Mỗi worker là một sinh viên, lặp lại:
+//   xem sơ đồ ghế → giữ ghế (VNPay) → nghĩ → thanh toán / bỏ giữ → Organizer check-in
+// Cuối cùng in p50/p90/p95/p99, thông lượng và tỉ lệ lỗi từng bước
+//
+// Chuẩn bị tài khoản:  backend seed --load-test-users 200
+// Ví dụ:  go run ./cmd/loadgen -target http://localhost:8080 -event 2 -concurrency 200 \
+//           -duration 2m -organizer-email organizer@seed.fpt.edu.vn
+// ============================================================
+
+type config struct {
+	target       string
+	eventID      int
+	concurrency  int
+	duration     time.Duration
+	iterations   int
+	rampUp       time.Duration
+	startAt      string
+	think        time.Duration
+	jitter       float64
+	seatsPerBuy  int
+	payment      string
+	abandonRate  float64
+	checkinRate  float64
+	vnpaySecret  string
+	timeout      time.Duration
+	accountsFile string
+	emailPattern string
+	users        int
+	password     string
+	orgEmail     string
+	orgPassword  string
+	jsonOutput   bool
+}
+
+type account struct {
+	Email    string
+	Password string
+}
+
+func main() {
+	cfg := parseFlags()
+	if err := cfg.validate(); err != nil {
+		fmt.Fprintln(os.Stderr, "loadgen:", err)
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	accounts, err := cfg.loadAccounts()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "loadgen:", err)
+		os.Exit(2)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	report, err := run(ctx, cfg, accounts)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "loadgen:", err)
+		os.Exit(1)
+	}
+	if cfg.jsonOutput {
+		report.WriteJSON(os.Stdout)
+	} else {
+		report.WriteText(os.Stdout)
+	}
+
+	// Exit code 1 khi có lỗi thật (5xx/timeout) để dùng được trong CI
+	for _, s := range report.Steps {
+		if s.Errors > 0 {
+			os.Exit(1)
+		}
+	}
+}
+
+func parseFlags() *config {
+	cfg := &config{}
+	flag.StringVar(&cfg.target, "target", "http://localhost:8080", "base URL of the environment under test")
+	flag.IntVar(&cfg.eventID, "event", 0, "event ID to book (must be OPEN and not started)")
+	flag.IntVar(&cfg.concurrency, "concurrency", 20, "number of concurrent virtual students")
+	flag.DurationVar(&cfg.duration, "duration", time.Minute, "how long to run (0 = until -iterations or sold out)")
+	flag.IntVar(&cfg.iterations, "iterations", 0, "bookings per worker (0 = unlimited)")
+	flag.DurationVar(&cfg.rampUp, "ramp-up", 0, "spread worker start over this duration (0 = all at once, like a sale opening)")
+	flag.StringVar(&cfg.startAt, "start-at", "", "wait until this time before booking (RFC3339), e.g. the announced sale opening")
+	flag.DurationVar(&cfg.think, "think", time.Second, "think time between steps")
+	flag.Float64Var(&cfg.jitter, "jitter", 0.5, "random think time variation (0.5 = ±50%)")
+	flag.IntVar(&cfg.seatsPerBuy, "seats", 1, "seats per booking (1-4)")
+	flag.StringVar(&cfg.payment, "payment", "vnpay", "payment flow: vnpay (hold + simulated VNPay return) or wallet")
+	flag.Float64Var(&cfg.abandonRate, "abandon", 0.1, "fraction of VNPay holds cancelled instead of paid")
+	flag.Float64Var(&cfg.checkinRate, "checkin", 1, "fraction of bought tickets checked in right away (needs -organizer-email)")
+	flag.StringVar(&cfg.vnpaySecret, "vnpay-secret", os.Getenv("VNPAY_HASH_SECRET"), "sign simulated VNPay returns with this secret")
+	flag.DurationVar(&cfg.timeout, "timeout", 10*time.Second, "per-request timeout")
+	flag.StringVar(&cfg.accountsFile, "accounts", "", "file with one \"email,password\" per line (overrides -email)")
+	flag.StringVar(&cfg.emailPattern, "email", fixtures.LoadTestEmailPattern, "student email pattern, %d = account number from 1")
+	flag.IntVar(&cfg.users, "users", 0, "number of accounts from -email (default = -concurrency)")
+	flag.StringVar(&cfg.password, "password", fixtures.DefaultPassword, "password of -email accounts")
+	flag.StringVar(&cfg.orgEmail, "organizer-email", "", "organizer of the event, used for check-in (empty = skip check-in)")
+	flag.StringVar(&cfg.orgPassword, "organizer-password", fixtures.DefaultPassword, "organizer password")
+	flag.BoolVar(&cfg.jsonOutput, "json", false, "print the report as JSON")
+	flag.Parse()
+	return cfg
+}
+
+func (c *config) validate() error {
+	switch {
+	case c.eventID <= 0:
+		return errors.New("-event is required")
+	case c.concurrency <= 0:
+		return errors.New("-concurrency must be positive")
+	case c.seatsPerBuy < 1 || c.seatsPerBuy > 4:
+		return errors.New("-seats must be between 1 and 4")
+	case c.payment != "vnpay" && c.payment != "wallet":
+		return errors.New("-payment must be vnpay or wallet")
+	case c.duration == 0 && c.iterations == 0:
+		return errors.New("set -duration or -iterations")
+	case c.abandonRate < 0 || c.abandonRate > 1 || c.checkinRate < 0 || c.checkinRate > 1 || c.jitter < 0 || c.jitter > 1:
+		return errors.New("-abandon, -checkin and -jitter must be between 0 and 1")
+	}
+	if c.startAt != "" {
+		if _, err := time.Parse(time.RFC3339, c.startAt); err != nil {
+			return fmt.Errorf("-start-at: %w", err)
+		}
+	}
+	return nil
+}
+
+func (c *config) loadAccounts() ([]account, error) {
+	if c.accountsFile == "" {
+		n := c.users
+		if n <= 0 {
+			n = c.concurrency
+		}
+		accounts := make([]account, n)
+		for i := range accounts {
+			accounts[i] = account{Email: fmt.Sprintf(c.emailPattern, i+1), Password: c.password}
+		}
+		return accounts, nil
+	}
+
+	f, err := os.Open(c.accountsFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var accounts []account
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		email, password, ok := strings.Cut(text, ",")
+		if !ok {
+			return nil, fmt.Errorf("%s:%d: expected email,password", c.accountsFile, line)
+		}
+		accounts = append(accounts, account{Email: strings.TrimSpace(email), Password: strings.TrimSpace(password)})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(accounts) == 0 {
+		return nil, fmt.Errorf("%s: no accounts", c.accountsFile)
+	}
+	return accounts, nil
+}
+
+// ============================================================
+// Chạy các worker và gom báo cáo
+// ============================================================
+
+func run(ctx context.Context, cfg *config, accounts []account) (*Report, error) {
+	rec := NewRecorder()
+	client := newAPIClient(cfg.target, cfg.timeout, cfg.concurrency*2, rec)
+
+	var organizer *session
+	if cfg.orgEmail != "" && cfg.checkinRate > 0 {
+		var err error
+		if organizer, err = client.login(ctx, cfg.orgEmail, cfg.orgPassword); err != nil {
+			return nil, fmt.Errorf("organizer login: %w", err)
+		}
+	}
+
+	// Đăng nhập trước giờ mở bán, giống người dùng thật ngồi chờ sẵn
+	sessions := make([]*session, cfg.concurrency)
+	var wg sync.WaitGroup
+	for i := range sessions {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			acc := accounts[i%len(accounts)]
+			s, err := client.login(ctx, acc.Email, acc.Password)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "worker %d: %v\n", i, err)
+				return
+			}
+			sessions[i] = s
+		}(i)
+	}
+	wg.Wait()
+
+	loggedIn := 0
+	for _, s := range sessions {
+		if s != nil {
+			loggedIn++
+		}
+	}
+	if loggedIn == 0 {
+		return nil, errors.New("no student account could log in (create them with: backend seed --load-test-users N)")
+	}
+
+	if cfg.startAt != "" {
+		at, _ := time.Parse(time.RFC3339, cfg.startAt)
+		fmt.Fprintf(os.Stderr, "Waiting until %s...\n", at.Format(time.RFC3339))
+		select {
+		case <-time.After(time.Until(at)):
+		case <-ctx.Done():
+		}
+	}
+
+	runCtx := ctx
+	if cfg.duration > 0 {
+		var cancel context.CancelFunc
+		runCtx, cancel = context.WithTimeout(ctx, cfg.duration)
+		defer cancel()
+	}
+
+	var iterations, soldOut int64
+	start := time.Now()
+	for i, s := range sessions {
+		if s == nil {
+			continue
+		}
+		w := &worker{
+			cfg:       cfg,
+			client:    client,
+			student:   s,
+			organizer: organizer,
+			rnd:       newRand(time.Now().UnixNano() + int64(i)),
+		}
+		delay := time.Duration(0)
+		if cfg.rampUp > 0 {
+			delay = cfg.rampUp * time.Duration(i) / time.Duration(len(sessions))
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if !w.sleep(runCtx, delay) {
+				return
+			}
+			n, out := w.run(runCtx)
+			atomic.AddInt64(&iterations, int64(n))
+			if out {
+				atomic.AddInt64(&soldOut, 1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	elapsed := time.Since(start)
+	return &Report{
+		Target:      cfg.target,
+		EventID:     cfg.eventID,
+		Concurrency: cfg.concurrency,
+		Elapsed:     elapsed,
+		Iterations:  iterations,
+		SoldOut:     soldOut,
+		Steps:       rec.Summaries(),
+	}, nil
+}
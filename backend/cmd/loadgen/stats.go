@@ -0,0 +1,250 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+// ============================================================
+// STATS - Gom độ trễ và kết quả theo từng bước (login, seats, hold, pay...)
+// ok       = 2xx (hoặc redirect thanh toán thành công)
+// rejected = server từ chối hợp lệ (4xx: ghế đã bị giữ, hết vé...) - tranh chấp mong đợi
+// error    = 5xx, timeout, lỗi kết nối - đây mới là lỗi cần xử lý
+// ============================================================
+
+type outcome int
+
+const (
+	outcomeOK outcome = iota
+	outcomeRejected
+	outcomeError
+)
+
+type opStats struct {
+	latencies []time.Duration
+	ok        int
+	rejected  int
+	errors    int
+	reasons   map[string]int
+	first     time.Time // Thời điểm bắt đầu request sớm nhất
+	last      time.Time // Thời điểm kết thúc request muộn nhất
+}
+
+// Recorder - An toàn khi nhiều worker ghi đồng thời
+type Recorder struct {
+	mu    sync.Mutex
+	ops   map[string]*opStats
+	order []string
+}
+
+func NewRecorder() *Recorder {
+	return &Recorder{ops: make(map[string]*opStats)}
+}
+
+// Record ghi một lần gọi; reason chỉ dùng cho rejected/error (gom nhóm để in top lý do)
+func (r *Recorder) Record(op string, latency time.Duration, o outcome, reason string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	s, ok := r.ops[op]
+	if !ok {
+		s = &opStats{reasons: make(map[string]int)}
+		r.ops[op] = s
+		r.order = append(r.order, op)
+	}
+	s.latencies = append(s.latencies, latency)
+	end := time.Now()
+	if begin := end.Add(-latency); s.first.IsZero() || begin.Before(s.first) {
+		s.first = begin
+	}
+	if end.After(s.last) {
+		s.last = end
+	}
+	switch o {
+	case outcomeOK:
+		s.ok++
+	case outcomeRejected:
+		s.rejected++
+	default:
+		s.errors++
+	}
+	if o != outcomeOK && reason != "" {
+		s.reasons[reason]++
+	}
+}
+
+// ReasonCount - Một lý do thất bại và số lần gặp
+type ReasonCount struct {
+	Reason string `json:"reason"`
+	Count  int    `json:"count"`
+}
+
+// Summary - Thống kê của một bước
+type Summary struct {
+	Op         string        `json:"op"`
+	Count      int           `json:"count"`
+	OK         int           `json:"ok"`
+	Rejected   int           `json:"rejected"`
+	Errors     int           `json:"errors"`
+	ErrorRate  float64       `json:"errorRate"` // errors / count
+	RPS        float64       `json:"rps"`
+	P50        time.Duration `json:"-"`
+	P90        time.Duration `json:"-"`
+	P95        time.Duration `json:"-"`
+	P99        time.Duration `json:"-"`
+	Max        time.Duration `json:"-"`
+	TopReasons []ReasonCount `json:"topReasons,omitempty"`
+}
+
+// Summaries tính percentile cho từng bước theo thứ tự bước xuất hiện lần đầu
+// rps tính trên khoảng thời gian bước đó thực sự chạy (login chỉ chạy lúc khởi động)
+func (r *Recorder) Summaries() []Summary {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	out := make([]Summary, 0, len(r.order))
+	for _, op := range r.order {
+		s := r.ops[op]
+		sorted := append([]time.Duration(nil), s.latencies...)
+		sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+		sum := Summary{
+			Op:         op,
+			Count:      len(sorted),
+			OK:         s.ok,
+			Rejected:   s.rejected,
+			Errors:     s.errors,
+			P50:        percentile(sorted, 50),
+			P90:        percentile(sorted, 90),
+			P95:        percentile(sorted, 95),
+			P99:        percentile(sorted, 99),
+			TopReasons: topReasons(s.reasons, 5),
+		}
+		if len(sorted) > 0 {
+			sum.Max = sorted[len(sorted)-1]
+			sum.ErrorRate = float64(s.errors) / float64(len(sorted))
+		}
+		if window := s.last.Sub(s.first); window > 0 {
+			sum.RPS = float64(len(sorted)) / window.Seconds()
+		}
+		out = append(out, sum)
+	}
+	return out
+}
+
+// percentile theo nearest-rank trên slice đã sắp xếp tăng dần
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	if rank > len(sorted) {
+		rank = len(sorted)
+	}
+	return sorted[rank-1]
+}
+
+func topReasons(reasons map[string]int, n int) []ReasonCount {
+	out := make([]ReasonCount, 0, len(reasons))
+	for reason, count := range reasons {
+		out = append(out, ReasonCount{Reason: reason, Count: count})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Count != out[j].Count {
+			return out[i].Count > out[j].Count
+		}
+		return out[i].Reason < out[j].Reason
+	})
+	if len(out) > n {
+		out = out[:n]
+	}
+	return out
+}
+
+// Report - Kết quả một lần chạy
+type Report struct {
+	Target      string
+	EventID     int
+	Concurrency int
+	Elapsed     time.Duration
+	Iterations  int64
+	SoldOut     int64 // Worker dừng vì hết ghế trống
+	Steps       []Summary
+}
+
+// WriteText in bảng thống kê dễ đọc
+func (rep *Report) WriteText(w io.Writer) {
+	fmt.Fprintf(w, "\nTarget %s, event #%d, %d workers, %s, %d iterations",
+		rep.Target, rep.EventID, rep.Concurrency, rep.Elapsed.Round(time.Millisecond), rep.Iterations)
+	if rep.SoldOut > 0 {
+		fmt.Fprintf(w, ", %d workers stopped on sold out", rep.SoldOut)
+	}
+	fmt.Fprint(w, "\n\n")
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "step\tcount\tok\trejected\terrors\terr%\trps\tp50\tp90\tp95\tp99\tmax\t")
+	for _, s := range rep.Steps {
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%d\t%d\t%.2f\t%.1f\t%s\t%s\t%s\t%s\t%s\t\n",
+			s.Op, s.Count, s.OK, s.Rejected, s.Errors, s.ErrorRate*100, s.RPS,
+			ms(s.P50), ms(s.P90), ms(s.P95), ms(s.P99), ms(s.Max))
+	}
+	tw.Flush()
+
+	for _, s := range rep.Steps {
+		if len(s.TopReasons) == 0 {
+			continue
+		}
+		fmt.Fprintf(w, "\n%s failures:\n", s.Op)
+		for _, rc := range s.TopReasons {
+			fmt.Fprintf(w, "  %6d  %s\n", rc.Count, rc.Reason)
+		}
+	}
+}
+
+// WriteJSON in báo cáo dạng JSON (độ trễ tính bằng mili giây) để so sánh giữa các lần chạy
+func (rep *Report) WriteJSON(w io.Writer) error {
+	type jsonSummary struct {
+		Summary
+		P50 float64 `json:"p50Ms"`
+		P90 float64 `json:"p90Ms"`
+		P95 float64 `json:"p95Ms"`
+		P99 float64 `json:"p99Ms"`
+		Max float64 `json:"maxMs"`
+	}
+	steps := make([]jsonSummary, 0, len(rep.Steps))
+	for _, s := range rep.Steps {
+		steps = append(steps, jsonSummary{
+			Summary: s,
+			P50:     msFloat(s.P50), P90: msFloat(s.P90), P95: msFloat(s.P95), P99: msFloat(s.P99), Max: msFloat(s.Max),
+		})
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(map[string]interface{}{
+		"target":         rep.Target,
+		"eventId":        rep.EventID,
+		"concurrency":    rep.Concurrency,
+		"elapsedMs":      msFloat(rep.Elapsed),
+		"iterations":     rep.Iterations,
+		"soldOutWorkers": rep.SoldOut,
+		"steps":          steps,
+	})
+}
+
+func ms(d time.Duration) string {
+	return fmt.Sprintf("%.1fms", msFloat(d))
+}
+
+func msFloat(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
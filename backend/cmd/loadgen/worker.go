@@ -0,0 +1,149 @@
+package main
+
+import (
+	"context"
+	"math/rand"
+	"time"
+)
+
+// ============================================================
+// WORKER - Một sinh viên giả lập đặt vé lặp lại cho tới khi hết giờ / hết ghế
+// ============================================================
+
+type worker struct {
+	cfg       *config
+	client    *apiClient
+	student   *session
+	organizer *session // nil = không check-in
+	rnd       *rand.Rand
+}
+
+// newRand - Mỗi worker một nguồn ngẫu nhiên riêng (rand.Rand không an toàn khi dùng chung)
+func newRand(seed int64) *rand.Rand {
+	return rand.New(rand.NewSource(seed))
+}
+
+// run trả về số lượt đặt vé đã chạy và true nếu dừng vì sự kiện hết ghế trống
+func (w *worker) run(ctx context.Context) (int, bool) {
+	n := 0
+	for w.cfg.iterations == 0 || n < w.cfg.iterations {
+		if ctx.Err() != nil {
+			return n, false
+		}
+		n++
+		if soldOut := w.book(ctx); soldOut {
+			return n, true
+		}
+		if !w.sleep(ctx, w.thinkTime()) {
+			return n, false
+		}
+	}
+	return n, false
+}
+
+// book chạy một lượt: xem ghế → giữ/thanh toán → check-in
+// Lỗi từng bước đã được Recorder ghi lại, lượt đó dừng và sang lượt sau
+func (w *worker) book(ctx context.Context) (soldOut bool) {
+	seats, err := w.client.seats(ctx, w.student.Token, w.cfg.eventID)
+	if err != nil {
+		return false
+	}
+	categoryID, seatIDs := w.pickSeats(seats)
+	if len(seatIDs) == 0 {
+		// Còn ghế đang được giữ thì có thể được nhả lại (hủy / hết hạn giữ) → thử lại lượt sau
+		for _, s := range seats {
+			if s.Status == "HOLD" {
+				return false
+			}
+		}
+		return true
+	}
+	if !w.sleep(ctx, w.thinkTime()) {
+		return false
+	}
+
+	var ticketIDs []int
+	if w.cfg.payment == "wallet" {
+		if ticketIDs, err = w.client.walletPay(ctx, w.student, w.cfg.eventID, categoryID, seatIDs); err != nil {
+			return false
+		}
+	} else {
+		paymentURL, held, err := w.client.hold(ctx, w.student, w.cfg.eventID, categoryID, seatIDs)
+		if err != nil {
+			return false
+		}
+		// Thời gian người dùng ở trang VNPay
+		if !w.sleep(ctx, w.thinkTime()) {
+			return false
+		}
+		if w.rnd.Float64() < w.cfg.abandonRate {
+			w.client.vnpayReturn(ctx, "release", paymentURL, "24", w.cfg.vnpaySecret)
+			return false
+		}
+		if err := w.client.vnpayReturn(ctx, "pay", paymentURL, "00", w.cfg.vnpaySecret); err != nil {
+			return false
+		}
+		ticketIDs = held
+	}
+
+	if w.organizer == nil {
+		return false
+	}
+	for _, id := range ticketIDs {
+		if w.rnd.Float64() < w.cfg.checkinRate {
+			w.client.checkin(ctx, w.organizer, id)
+		}
+	}
+	return false
+}
+
+// pickSeats chọn ngẫu nhiên ghế trống cùng loại vé (API giữ ghế nhận một loại vé mỗi lần)
+// Bỏ qua ghế người đi kèm: phải mua cùng ghế xe lăn, không hợp với chọn ngẫu nhiên
+func (w *worker) pickSeats(seats []seat) (int, []int) {
+	byCategory := map[int][]int{}
+	var categories []int
+	for _, s := range seats {
+		if s.Status != "AVAILABLE" || s.CategoryTicketID == nil || s.CompanionOfSeatID != nil {
+			continue
+		}
+		id := *s.CategoryTicketID
+		if _, ok := byCategory[id]; !ok {
+			categories = append(categories, id)
+		}
+		byCategory[id] = append(byCategory[id], s.SeatID)
+	}
+	if len(categories) == 0 {
+		return 0, nil
+	}
+
+	categoryID := categories[w.rnd.Intn(len(categories))]
+	free := byCategory[categoryID]
+	w.rnd.Shuffle(len(free), func(i, j int) { free[i], free[j] = free[j], free[i] })
+	if len(free) > w.cfg.seatsPerBuy {
+		free = free[:w.cfg.seatsPerBuy]
+	}
+	return categoryID, free
+}
+
+func (w *worker) thinkTime() time.Duration {
+	if w.cfg.think <= 0 {
+		return 0
+	}
+	factor := 1 + w.cfg.jitter*(2*w.rnd.Float64()-1)
+	return time.Duration(float64(w.cfg.think) * factor)
+}
+
+// sleep chờ d, trả về false nếu context đã kết thúc
+func (w *worker) sleep(ctx context.Context, d time.Duration) bool {
+	if d <= 0 {
+		return ctx.Err() == nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
@@ -0,0 +1,201 @@
+package abuse
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fpt-event-services/common/metrics"
+)
+
+// ============================================================
+// ABUSE - Chống tạo tài khoản hàng loạt ở các API đăng ký / OTP
+// - Email dùng một lần (disposable): chặn ngay khi đăng ký
+// - Tốc độ theo IP: giới hạn số lần đăng ký / gửi OTP trong cửa sổ trượt (in-memory, 429)
+// - Dấu vân tay thiết bị: header X-Device-Fingerprint do frontend gửi, chỉ lưu SHA-256
+// - Cách ly (QUARANTINED): tài khoản mới từ IP / thiết bị đã tạo nhiều tài khoản gần đây
+// vẫn được tạo nhưng không đăng nhập được cho tới khi admin duyệt
+// Cấu hình: ABUSE_REGISTER_PER_IP (5), ABUSE_OTP_PER_IP (20) lần trong ABUSE_WINDOW_MINUTES (60);
+// ABUSE_QUARANTINE_IP_ACCOUNTS (3), ABUSE_QUARANTINE_DEVICE_ACCOUNTS (2) tài khoản trong
+// ABUSE_QUARANTINE_WINDOW_HOURS (24); DISPOSABLE_EMAIL_DOMAINS thêm domain chặn (dấu phẩy)
+// ============================================================
+
+// DeviceHeader - Header dấu vân tay thiết bị
+const DeviceHeader = "X-Device-Fingerprint"
+
+// maxFingerprintLength - Header dài hơn bị cắt trước khi băm
+const maxFingerprintLength = 512
+
+// Tín hiệu lạm dụng (nhãn signal của metric, lưu trong lý do cách ly)
+const (
+	SignalDisposableEmail = "disposable_email"
+	SignalRegisterIP      = "register_ip_velocity"
+	SignalOTPIP           = "otp_ip_velocity"
+	SignalIPReuse         = "ip_reuse"
+	SignalDeviceReuse     = "device_reuse"
+)
+
+// Hành động đã áp dụng (nhãn action của metric)
+const (
+	ActionBlocked     = "blocked"
+	ActionRateLimited = "rate_limited"
+	ActionQuarantined = "quarantined"
+)
+
+// signals - Số lần phát hiện theo tín hiệu và hành động
+var signals = metrics.NewCounter("abuse_signals_total",
+	"Abuse signals detected on registration and OTP endpoints, by signal and action taken.", "signal", "action")
+
+// reviews - Kết quả admin xét duyệt tài khoản bị cách ly
+var reviews = metrics.NewCounter("abuse_account_reviews_total",
+	"Quarantined accounts reviewed by an admin, by decision (approved, rejected).", "decision")
+
+// Record - Ghi nhận một tín hiệu vào metric
+func Record(signal, action string) { signals.Inc(signal, action) }
+
+// RecordReview - Ghi nhận kết quả xét duyệt (approved, rejected)
+func RecordReview(decision string) { reviews.Inc(decision) }
+
+// Client - IP và dấu vân tay (đã băm) của request đăng ký
+type Client struct {
+	IP     string
+	Device string // "" nếu client không gửi header
+}
+
+// Fingerprint - SHA-256 hex của header X-Device-Fingerprint ("" nếu không có)
+// headers là map của APIGatewayProxyRequest (tên header không phân biệt hoa thường)
+func Fingerprint(headers map[string]string) string {
+	var raw string
+	for k, v := range headers {
+		if strings.EqualFold(k, DeviceHeader) {
+			raw = strings.TrimSpace(v)
+			break
+		}
+	}
+	if raw == "" {
+		return ""
+	}
+	if len(raw) > maxFingerprintLength {
+		raw = raw[:maxFingerprintLength]
+	}
+	sum := sha256.Sum256([]byte(raw))
+	return hex.EncodeToString(sum[:])
+}
+
+// ============================================================
+// Limiter - Giới hạn số lần theo khóa trong cửa sổ trượt (in-memory, theo process)
+// ============================================================
+type Limiter struct {
+	mu     sync.Mutex
+	limit  int
+	window time.Duration
+	hits   map[string][]time.Time
+	now    func() time.Time
+}
+
+// NewLimiter tạo limiter cho phép limit lần mỗi window
+func NewLimiter(limit int, window time.Duration) *Limiter {
+	return &Limiter{limit: limit, window: window, hits: make(map[string][]time.Time), now: time.Now}
+}
+
+// Allow ghi nhận một lần cho key; false kèm thời gian phải chờ nếu vượt hạn mức
+// key rỗng (không xác định được IP) luôn được cho qua
+func (l *Limiter) Allow(key string) (bool, time.Duration) {
+	if key == "" {
+		return true, 0
+	}
+	now := l.now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	recent := l.hits[key][:0]
+	for _, t := range l.hits[key] {
+		if now.Sub(t) < l.window {
+			recent = append(recent, t)
+		}
+	}
+	if len(recent) >= l.limit {
+		l.hits[key] = recent
+		return false, l.window - now.Sub(recent[0])
+	}
+	l.hits[key] = append(recent, now)
+	return true, 0
+}
+
+var (
+	limitersOnce     sync.Once
+	registerLimiter  *Limiter
+	otpLimiterByIP   *Limiter
+	quarantinePolicy Thresholds
+)
+
+func loadDefaults() {
+	limitersOnce.Do(func() {
+		window := time.Duration(envInt("ABUSE_WINDOW_MINUTES", 60)) * time.Minute
+		registerLimiter = NewLimiter(envInt("ABUSE_REGISTER_PER_IP", 5), window)
+		otpLimiterByIP = NewLimiter(envInt("ABUSE_OTP_PER_IP", 20), window)
+		quarantinePolicy = Thresholds{
+			IPAccounts:     envInt("ABUSE_QUARANTINE_IP_ACCOUNTS", 3),
+			DeviceAccounts: envInt("ABUSE_QUARANTINE_DEVICE_ACCOUNTS", 2),
+			Window:         time.Duration(envInt("ABUSE_QUARANTINE_WINDOW_HOURS", 24)) * time.Hour,
+		}
+	})
+}
+
+// RegisterLimiter - Giới hạn đăng ký (gửi OTP đăng ký, đăng ký trực tiếp) theo IP
+func RegisterLimiter() *Limiter {
+	loadDefaults()
+	return registerLimiter
+}
+
+// OTPLimiter - Giới hạn mọi lần gửi OTP theo IP (bổ sung cho giới hạn theo địa chỉ nhận)
+func OTPLimiter() *Limiter {
+	loadDefaults()
+	return otpLimiterByIP
+}
+
+// ============================================================
+// Thresholds - Ngưỡng cách ly tài khoản mới
+// Tài khoản thứ N trở đi từ cùng IP / thiết bị trong Window bị cách ly
+// ============================================================
+type Thresholds struct {
+	IPAccounts     int
+	DeviceAccounts int
+	Window         time.Duration
+}
+
+// DefaultThresholds - Ngưỡng theo biến môi trường
+func DefaultThresholds() Thresholds {
+	loadDefaults()
+	return quarantinePolicy
+}
+
+// Assess - Tín hiệu cách ly của tài khoản sắp tạo
+// ipAccounts / deviceAccounts: số tài khoản đã tạo trước đó từ cùng IP / thiết bị trong Window
+func (t Thresholds) Assess(client Client, ipAccounts, deviceAccounts int) []string {
+	var reasons []string
+	if client.IP != "" && t.IPAccounts > 0 && ipAccounts+1 >= t.IPAccounts {
+		reasons = append(reasons, SignalIPReuse)
+	}
+	if client.Device != "" && t.DeviceAccounts > 0 && deviceAccounts+1 >= t.DeviceAccounts {
+		reasons = append(reasons, SignalDeviceReuse)
+	}
+	return reasons
+}
+
+// RetryAfterHeader - Giá trị header Retry-After (giây, làm tròn lên)
+func RetryAfterHeader(retryAfter time.Duration) string {
+	return strconv.Itoa(int(retryAfter.Seconds()) + 1)
+}
+
+func envInt(key string, defaultValue int) int {
+	if n, err := strconv.Atoi(os.Getenv(key)); err == nil && n > 0 {
+		return n
+	}
+	return defaultValue
+}
@@ -0,0 +1,76 @@
+package abuse
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestIsDisposableEmail(t *testing.T) {
+	for _, email := range []string{"a@yopmail.com", "b@MAILINATOR.com", "c@inbox.guerrillamail.com", "d@yopmail.com."} {
+		if !IsDisposableEmail(email) {
+			t.Errorf("%s must be disposable", email)
+		}
+	}
+	for _, email := range []string{"a@fpt.edu.vn", "b@gmail.com", "c@notyopmail.com", "invalid"} {
+		if IsDisposableEmail(email) {
+			t.Errorf("%s must not be disposable", email)
+		}
+	}
+}
+
+func TestLimiterSlidingWindow(t *testing.T) {
+	now := time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC)
+	l := NewLimiter(2, time.Hour)
+	l.now = func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		if ok, _ := l.Allow("1.2.3.4"); !ok {
+			t.Fatalf("attempt %d must be allowed", i+1)
+		}
+	}
+	ok, retry := l.Allow("1.2.3.4")
+	if ok || retry != time.Hour {
+		t.Fatalf("third attempt: ok=%v retry=%v", ok, retry)
+	}
+	if ok, _ := l.Allow("5.6.7.8"); !ok {
+		t.Fatal("other IPs have their own budget")
+	}
+	if ok, _ := l.Allow(""); !ok {
+		t.Fatal("unknown IP must not be limited")
+	}
+
+	now = now.Add(time.Hour)
+	if ok, _ := l.Allow("1.2.3.4"); !ok {
+		t.Fatal("window elapsed, attempt must be allowed")
+	}
+}
+
+func TestFingerprint(t *testing.T) {
+	a := Fingerprint(map[string]string{"X-Device-Fingerprint": " abc "})
+	b := Fingerprint(map[string]string{"x-device-fingerprint": "abc"})
+	if a == "" || a != b || len(a) != 64 {
+		t.Fatalf("fingerprint must be a stable SHA-256 hex: %q %q", a, b)
+	}
+	if Fingerprint(map[string]string{"User-Agent": "x"}) != "" {
+		t.Fatal("missing header must give an empty fingerprint")
+	}
+}
+
+func TestThresholdsAssess(t *testing.T) {
+	th := Thresholds{IPAccounts: 3, DeviceAccounts: 2, Window: 24 * time.Hour}
+	client := Client{IP: "1.2.3.4", Device: "f"}
+
+	if got := th.Assess(client, 0, 0); got != nil {
+		t.Fatalf("first account: got %v", got)
+	}
+	if got, want := th.Assess(client, 1, 1), []string{SignalDeviceReuse}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("second account: got %v, want %v", got, want)
+	}
+	if got, want := th.Assess(client, 2, 0), []string{SignalIPReuse}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("third account from IP: got %v, want %v", got, want)
+	}
+	if got := th.Assess(Client{IP: "1.2.3.4"}, 0, 5); got != nil {
+		t.Fatalf("no fingerprint must not count devices: got %v", got)
+	}
+}
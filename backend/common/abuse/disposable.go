@@ -0,0 +1,64 @@
+package abuse
+
+import (
+	"os"
+	"strings"
+	"sync"
+)
+
+// builtinDisposableDomains - Dịch vụ email dùng một lần phổ biến
+// Khớp cả subdomain (vd. abc.mailinator.com); bổ sung qua DISPOSABLE_EMAIL_DOMAINS
+var builtinDisposableDomains = []string{
+	"10minutemail.com", "20minutemail.com", "33mail.com", "anonbox.net", "burnermail.io",
+	"dispostable.com", "dropmail.me", "emailondeck.com", "fakeinbox.com", "getairmail.com",
+	"getnada.com", "guerrillamail.com", "guerrillamail.net", "guerrillamailblock.com", "inboxkitten.com",
+	"mailcatch.com", "maildrop.cc", "mailinator.com", "mailnesia.com", "mintemail.com",
+	"mohmal.com", "mytemp.email", "sharklasers.com", "spamgourmet.com", "temp-mail.org",
+	"tempail.com", "tempmail.com", "tempmail.dev", "tempmailo.com", "tempr.email",
+	"throwawaymail.com", "trashmail.com", "yopmail.com", "yopmail.net", "moakt.com",
+}
+
+var (
+	disposableOnce    sync.Once
+	disposableDomains map[string]bool
+)
+
+func loadDisposableDomains() map[string]bool {
+	disposableOnce.Do(func() {
+		disposableDomains = make(map[string]bool, len(builtinDisposableDomains))
+		for _, d := range builtinDisposableDomains {
+			disposableDomains[d] = true
+		}
+		for _, d := range strings.Split(os.Getenv("DISPOSABLE_EMAIL_DOMAINS"), ",") {
+			if d = strings.ToLower(strings.TrimSpace(d)); d != "" {
+				disposableDomains[d] = true
+			}
+		}
+	})
+	return disposableDomains
+}
+
+// IsDisposableEmail - Email thuộc dịch vụ email dùng một lần (hoặc subdomain của nó)
+func IsDisposableEmail(email string) bool {
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return false
+	}
+	return isDisposableDomain(loadDisposableDomains(), strings.ToLower(strings.TrimSpace(email[at+1:])))
+}
+
+// isDisposableDomain - Kiểm tra domain và mọi domain cha (a.b.yopmail.com → b.yopmail.com → yopmail.com)
+func isDisposableDomain(domains map[string]bool, domain string) bool {
+	domain = strings.TrimSuffix(domain, ".")
+	for domain != "" {
+		if domains[domain] {
+			return true
+		}
+		dot := strings.Index(domain, ".")
+		if dot < 0 {
+			return false
+		}
+		domain = domain[dot+1:]
+	}
+	return false
+}
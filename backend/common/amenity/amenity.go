@@ -0,0 +1,89 @@
+package amenity
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ============================================================
+// Package amenity - Trang thiết bị / tiện ích của khu vực (Venue_Area.amenities)
+// và yêu cầu của sự kiện (Event_Request.required_amenities)
+// Lưu dạng SET của MySQL: "PROJECTOR,STAGE" (thứ tự theo All)
+// ============================================================
+
+// Các tiện ích được hỗ trợ (khớp SET trong migration 043_area_amenities.sql)
+const (
+	Projector        = "PROJECTOR"
+	Stage            = "STAGE"
+	Livestream       = "LIVESTREAM"
+	SoundSystem      = "SOUND_SYSTEM"
+	Microphone       = "MICROPHONE"
+	Whiteboard       = "WHITEBOARD"
+	AirConditioning  = "AIR_CONDITIONING"
+	WheelchairAccess = "WHEELCHAIR_ACCESS"
+)
+
+// All - Danh mục tiện ích theo thứ tự hiển thị
+var All = []string{Projector, Stage, Livestream, SoundSystem, Microphone, Whiteboard, AirConditioning, WheelchairAccess}
+
+// ErrUnknown - Tiện ích không có trong All
+var ErrUnknown = errors.New("unknown amenity")
+
+// Normalize - Chữ hoa, bỏ trùng, sắp theo All; tiện ích lạ trả ErrUnknown
+// nil / rỗng trả slice rỗng (không yêu cầu tiện ích nào)
+func Normalize(values []string) ([]string, error) {
+	seen := make(map[string]bool, len(values))
+	for _, v := range values {
+		v = strings.ToUpper(strings.TrimSpace(v))
+		if v == "" {
+			continue
+		}
+		if !slices.Contains(All, v) {
+			return nil, fmt.Errorf("%w: %q", ErrUnknown, v)
+		}
+		seen[v] = true
+	}
+	result := []string{}
+	for _, a := range All {
+		if seen[a] {
+			result = append(result, a)
+		}
+	}
+	return result, nil
+}
+
+// Parse - Danh sách phân cách dấu phẩy (query string / giá trị SET) => Normalize
+func Parse(csv string) ([]string, error) {
+	return Normalize(strings.Split(csv, ","))
+}
+
+// Split - Giá trị cột SET đọc từ DB (đã hợp lệ) => slice, không bao giờ nil
+func Split(set string) []string {
+	result := []string{}
+	for _, v := range strings.Split(set, ",") {
+		if v != "" {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+// Join - Slice đã Normalize => giá trị cột SET
+func Join(values []string) string {
+	return strings.Join(values, ",")
+}
+
+// Match - Tiện ích yêu cầu mà khu vực có / còn thiếu
+func Match(required, available []string) (matched, missing []string) {
+	matched, missing = []string{}, []string{}
+	for _, r := range required {
+		if slices.Contains(available, r) {
+			matched = append(matched, r)
+		} else {
+			missing = append(missing, r)
+		}
+	}
+	return matched, missing
+}
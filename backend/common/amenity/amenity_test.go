@@ -0,0 +1,31 @@
+package amenity
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	got, err := Normalize([]string{" stage", "PROJECTOR", "Stage", ""})
+	if err != nil {
+		t.Fatalf("Normalize: %v", err)
+	}
+	if !slices.Equal(got, []string{Projector, Stage}) {
+		t.Errorf("got %v, want [PROJECTOR STAGE]", got)
+	}
+
+	if got, err := Normalize(nil); err != nil || got == nil || len(got) != 0 {
+		t.Errorf("Normalize(nil) = %#v, %v", got, err)
+	}
+	if _, err := Normalize([]string{"JACUZZI"}); !errors.Is(err, ErrUnknown) {
+		t.Errorf("err = %v, want ErrUnknown", err)
+	}
+}
+
+func TestMatch(t *testing.T) {
+	matched, missing := Match([]string{Projector, Livestream}, Split("PROJECTOR,STAGE"))
+	if !slices.Equal(matched, []string{Projector}) || !slices.Equal(missing, []string{Livestream}) {
+		t.Errorf("matched = %v, missing = %v", matched, missing)
+	}
+}
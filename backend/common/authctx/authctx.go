@@ -0,0 +1,81 @@
+package authctx
+
+import (
+	"context"
+	"errors"
+)
+
+// ============================================================
+// AUTHCTX - Danh tính người dùng đã xác thực trong context.Context
+// authMiddleware gắn userID/role sau khi kiểm tra JWT, handler đọc lại bằng UserID/Role
+// Key có kiểu riêng (unexported) nên không package nào khác ghi đè được,
+// khác với header X-User-Id do client tự gửi lên
+// ============================================================
+
+type contextKey int
+
+const (
+	userIDKey contextKey = iota
+	roleKey
+	sessionKey
+)
+
+var (
+	// ErrUnauthenticated - Context không có người dùng đã đăng nhập
+	ErrUnauthenticated = errors.New("unauthenticated")
+	// ErrForbidden - Người dùng đã đăng nhập nhưng không có role được yêu cầu
+	ErrForbidden = errors.New("forbidden")
+)
+
+// WithUser gắn userID và role vào context (chỉ gọi sau khi đã xác thực token)
+func WithUser(ctx context.Context, userID int, role string) context.Context {
+	ctx = context.WithValue(ctx, userIDKey, userID)
+	return context.WithValue(ctx, roleKey, role)
+}
+
+// WithSession gắn ID phiên đăng nhập của token (claim jti) vào context
+func WithSession(ctx context.Context, sessionID string) context.Context {
+	return context.WithValue(ctx, sessionKey, sessionID)
+}
+
+// SessionID trả về ID phiên của token hiện tại (rỗng nếu token không gắn phiên)
+func SessionID(ctx context.Context) string {
+	sessionID, _ := ctx.Value(sessionKey).(string)
+	return sessionID
+}
+
+// UserID trả về userID đã xác thực; false nếu request chưa đăng nhập
+func UserID(ctx context.Context) (int, bool) {
+	userID, ok := ctx.Value(userIDKey).(int)
+	return userID, ok && userID > 0
+}
+
+// Role trả về role đã xác thực (rỗng nếu chưa đăng nhập)
+func Role(ctx context.Context) string {
+	role, _ := ctx.Value(roleKey).(string)
+	return role
+}
+
+// HasRole kiểm tra role hiện tại có nằm trong danh sách không
+func HasRole(ctx context.Context, roles ...string) bool {
+	role := Role(ctx)
+	for _, r := range roles {
+		if role == r {
+			return true
+		}
+	}
+	return false
+}
+
+// MustBeRole yêu cầu người dùng đã đăng nhập với một trong các role cho phép
+// Trả về userID; ErrUnauthenticated nếu chưa đăng nhập, ErrForbidden nếu sai role
+func MustBeRole(ctx context.Context, roles ...string) (int, error) {
+	userID, ok := UserID(ctx)
+	if !ok {
+		return 0, ErrUnauthenticated
+	}
+	if !HasRole(ctx, roles...) {
+		return 0, ErrForbidden
+	}
+	return userID, nil
+}
@@ -0,0 +1,43 @@
+package authctx
+
+import (
+	"context"
+	"testing"
+)
+
+func TestUserAndRole(t *testing.T) {
+	ctx := context.Background()
+	if _, ok := UserID(ctx); ok {
+		t.Fatal("empty context must not have a user")
+	}
+	if Role(ctx) != "" {
+		t.Fatal("empty context must not have a role")
+	}
+
+	ctx = WithUser(ctx, 42, "ORGANIZER")
+	if id, ok := UserID(ctx); !ok || id != 42 {
+		t.Fatalf("UserID = %d, %v", id, ok)
+	}
+	if Role(ctx) != "ORGANIZER" {
+		t.Fatalf("Role = %q", Role(ctx))
+	}
+
+	// Key string cũ không được đọc ra danh tính
+	spoofed := context.WithValue(context.Background(), "userID", 7)
+	if _, ok := UserID(spoofed); ok {
+		t.Fatal("string key must not be accepted")
+	}
+}
+
+func TestMustBeRole(t *testing.T) {
+	if _, err := MustBeRole(context.Background(), "ADMIN"); err != ErrUnauthenticated {
+		t.Fatalf("err = %v, want ErrUnauthenticated", err)
+	}
+	ctx := WithUser(context.Background(), 5, "STAFF")
+	if _, err := MustBeRole(ctx, "ADMIN"); err != ErrForbidden {
+		t.Fatalf("err = %v, want ErrForbidden", err)
+	}
+	if id, err := MustBeRole(ctx, "ADMIN", "STAFF"); err != nil || id != 5 {
+		t.Fatalf("MustBeRole = %d, %v", id, err)
+	}
+}
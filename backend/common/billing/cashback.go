@@ -0,0 +1,14 @@
+package billing
+
+// Cashback - Tiền hoàn vào ví khi check-out vé giá price với mức percent (%),
+// làm tròn xuống đồng và không vượt maxAmount (0 = không giới hạn)
+func Cashback(price int64, percent int, maxAmount int64) int64 {
+	if price <= 0 || percent <= 0 {
+		return 0
+	}
+	amount := price * int64(percent) / 100
+	if maxAmount > 0 && amount > maxAmount {
+		amount = maxAmount
+	}
+	return amount
+}
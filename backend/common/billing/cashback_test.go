@@ -0,0 +1,22 @@
+package billing
+
+import "testing"
+
+func TestCashback(t *testing.T) {
+	for _, tc := range []struct {
+		price   int64
+		percent int
+		max     int64
+		want    int64
+	}{
+		{150000, 10, 0, 15000},
+		{99999, 10, 0, 9999}, // 9999.9 → 9999
+		{500000, 20, 50000, 50000},
+		{150000, 0, 0, 0},
+		{0, 10, 0, 0},
+	} {
+		if got := Cashback(tc.price, tc.percent, tc.max); got != tc.want {
+			t.Errorf("Cashback(%d, %d, %d) = %d, want %d", tc.price, tc.percent, tc.max, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package billing
+
+import "time"
+
+// ============================================================
+// INSTALLMENTS - Trả góp hóa đơn (migration 055)
+// Kỳ 0 là tiền đặt cọc trả lúc mua; phần còn lại chia đều cho Count kỳ,
+// kỳ cuối nhận phần lẻ. Hạn kỳ i = lúc mua + i * IntervalDays, không trễ hơn deadline
+// (trước giờ bắt đầu sự kiện) để vé trả đủ trước khi check-in.
+// ============================================================
+
+// InstallmentPlan - Cấu hình trả góp của sự kiện
+type InstallmentPlan struct {
+	DepositPercent int // % tổng tiền trả trước (10..90)
+	Count          int // Số kỳ sau đặt cọc (1..6)
+	IntervalDays   int // Khoảng cách giữa các kỳ
+}
+
+// Installment - Một kỳ thanh toán (đơn vị: đồng)
+type Installment struct {
+	Seq    int
+	Amount int64
+	DueAt  time.Time
+}
+
+// Deposit - Tiền đặt cọc của tổng tiền total, làm tròn đến đồng
+func (p InstallmentPlan) Deposit(total int64) int64 {
+	if total <= 0 {
+		return 0
+	}
+	return (total*int64(p.DepositPercent) + 50) / 100
+}
+
+// Schedule - Lịch trả góp khi đã cọc deposit: kỳ 0 (cọc, hạn = start) và Count kỳ còn lại
+// Tổng các kỳ luôn bằng total
+func (p InstallmentPlan) Schedule(total, deposit int64, start, deadline time.Time) []Installment {
+	count := p.Count
+	if count < 1 {
+		count = 1
+	}
+	rest := total - deposit
+	if rest < 0 {
+		rest = 0
+	}
+	schedule := []Installment{{Seq: 0, Amount: total - rest, DueAt: start}}
+	each := rest / int64(count)
+	for i := 1; i <= count; i++ {
+		amount := each
+		if i == count {
+			amount = rest - each*int64(count-1)
+		}
+		due := start.AddDate(0, 0, i*p.IntervalDays)
+		if due.After(deadline) {
+			due = deadline
+		}
+		schedule = append(schedule, Installment{Seq: i, Amount: amount, DueAt: due})
+	}
+	return schedule
+}
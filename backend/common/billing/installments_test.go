@@ -0,0 +1,49 @@
+package billing
+
+import (
+	"testing"
+	"time"
+)
+
+func TestInstallmentSchedule(t *testing.T) {
+	start := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	deadline := time.Date(2026, 3, 20, 10, 0, 0, 0, time.UTC)
+	plan := InstallmentPlan{DepositPercent: 30, Count: 3, IntervalDays: 7}
+
+	deposit := plan.Deposit(500000)
+	if deposit != 150000 {
+		t.Fatalf("Deposit = %d, want 150000", deposit)
+	}
+	schedule := plan.Schedule(500001, deposit, start, deadline)
+	if len(schedule) != 4 {
+		t.Fatalf("len = %d, want 4", len(schedule))
+	}
+	var sum int64
+	for _, inst := range schedule {
+		sum += inst.Amount
+	}
+	if sum != 500001 {
+		t.Errorf("sum = %d, want 500001", sum)
+	}
+	if schedule[0].Amount != 150000 || !schedule[0].DueAt.Equal(start) {
+		t.Errorf("deposit = %+v", schedule[0])
+	}
+	if schedule[1].Amount != 116667 || schedule[3].Amount != 116667 {
+		t.Errorf("installments = %+v", schedule[1:])
+	}
+	if want := start.AddDate(0, 0, 7); !schedule[1].DueAt.Equal(want) {
+		t.Errorf("seq 1 due %v, want %v", schedule[1].DueAt, want)
+	}
+	// Kỳ 3 (ngày 22) vượt deadline → dời về deadline
+	if !schedule[3].DueAt.Equal(deadline) {
+		t.Errorf("seq 3 due %v, want deadline %v", schedule[3].DueAt, deadline)
+	}
+}
+
+func TestInstallmentScheduleOverpaidDeposit(t *testing.T) {
+	now := time.Now()
+	schedule := InstallmentPlan{Count: 2}.Schedule(100000, 120000, now, now)
+	if schedule[0].Amount != 100000 || schedule[1].Amount != 0 || schedule[2].Amount != 0 {
+		t.Errorf("schedule = %+v", schedule)
+	}
+}
@@ -0,0 +1,112 @@
+package billing
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/fpt-event-services/common/ledger"
+)
+
+// ============================================================
+// BILLING - Dòng hóa đơn (Bill_Item, migration 034)
+// Mỗi vé của hóa đơn một dòng, giữ giá và phí nền tảng tại thời điểm mua để
+// đổi mức phí về sau không làm thay đổi thống kê / đối soát của vé đã bán.
+// Một lần mua chỉ gồm vé cùng một loại; tổng hóa đơn chia đều cho các vé, hoặc theo
+// tỷ lệ giá từng ghế khi ghế có giá riêng (SplitWeighted).
+// ============================================================
+
+// Item - Một dòng hóa đơn (đơn vị: đồng)
+type Item struct {
+	TicketID  int
+	EventID   int
+	UnitPrice int64
+	FeeBps    int
+	FeeAmount int64
+}
+
+// Execer - *sql.Tx hoặc *sql.DB
+type Execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// FeeAmount - Phí nền tảng của số tiền amount theo basis point, làm tròn đến đồng
+func FeeAmount(amount int64, feeBps int) int64 {
+	if amount <= 0 || feeBps <= 0 {
+		return 0
+	}
+	return (amount*int64(feeBps) + 5000) / 10000
+}
+
+// Split - Chia tổng hóa đơn cho các vé; phần dư (đồng lẻ) cộng vào các vé đầu
+// để tổng các dòng luôn bằng Bill.total_amount
+func Split(total float64, eventID int, ticketIDs []int, feeBps int) []Item {
+	if len(ticketIDs) == 0 {
+		return nil
+	}
+	amount := ledger.Dong(total)
+	n := int64(len(ticketIDs))
+	unit, rest := amount/n, amount%n
+
+	items := make([]Item, len(ticketIDs))
+	for i, ticketID := range ticketIDs {
+		price := unit
+		if int64(i) < rest {
+			price++
+		}
+		items[i] = Item{
+			TicketID:  ticketID,
+			EventID:   eventID,
+			UnitPrice: price,
+			FeeBps:    feeBps,
+			FeeAmount: FeeAmount(price, feeBps),
+		}
+	}
+	return items
+}
+
+// SplitWeighted - Chia tổng hóa đơn theo tỷ lệ weights (giá từng ghế lúc mua);
+// phần dư cộng vào các vé đầu như Split. weights không khớp số vé hoặc tổng 0 thì chia đều
+func SplitWeighted(total float64, eventID int, ticketIDs []int, weights []int64, feeBps int) []Item {
+	var sum int64
+	for _, w := range weights {
+		if w < 0 {
+			sum = 0
+			break
+		}
+		sum += w
+	}
+	if len(weights) != len(ticketIDs) || sum == 0 {
+		return Split(total, eventID, ticketIDs, feeBps)
+	}
+
+	amount := ledger.Dong(total)
+	items := make([]Item, len(ticketIDs))
+	var allocated int64
+	for i, ticketID := range ticketIDs {
+		price := amount * weights[i] / sum
+		allocated += price
+		items[i] = Item{TicketID: ticketID, EventID: eventID, UnitPrice: price, FeeBps: feeBps}
+	}
+	for i := 0; allocated < amount; i = (i + 1) % len(items) {
+		items[i].UnitPrice++
+		allocated++
+	}
+	for i := range items {
+		items[i].FeeAmount = FeeAmount(items[i].UnitPrice, feeBps)
+	}
+	return items
+}
+
+// InsertItems - Ghi các dòng của hóa đơn trong transaction mua vé
+func InsertItems(ctx context.Context, tx Execer, billID int, items []Item) error {
+	for _, it := range items {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO Bill_Item (bill_id, ticket_id, event_id, unit_price, fee_bps, fee_amount)
+			VALUES (?, ?, ?, ?, ?, ?)
+		`, billID, it.TicketID, it.EventID, it.UnitPrice, it.FeeBps, it.FeeAmount); err != nil {
+			return fmt.Errorf("failed to insert bill item for ticket %d: %w", it.TicketID, err)
+		}
+	}
+	return nil
+}
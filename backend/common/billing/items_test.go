@@ -0,0 +1,61 @@
+package billing
+
+import "testing"
+
+func TestFeeAmount(t *testing.T) {
+	for _, tc := range []struct {
+		amount int64
+		bps    int
+		want   int64
+	}{
+		{100000, 0, 0},
+		{100000, 250, 2500},
+		{333, 150, 5}, // 4.995 → 5
+		{0, 500, 0},
+	} {
+		if got := FeeAmount(tc.amount, tc.bps); got != tc.want {
+			t.Errorf("FeeAmount(%d, %d) = %d, want %d", tc.amount, tc.bps, got, tc.want)
+		}
+	}
+}
+
+func TestSplitKeepsBillTotal(t *testing.T) {
+	items := Split(100001, 7, []int{11, 12, 13}, 1000)
+	if len(items) != 3 {
+		t.Fatalf("got %d items", len(items))
+	}
+	var sum int64
+	for _, it := range items {
+		sum += it.UnitPrice
+		if it.EventID != 7 || it.FeeBps != 1000 || it.FeeAmount != FeeAmount(it.UnitPrice, 1000) {
+			t.Errorf("item = %+v", it)
+		}
+	}
+	if sum != 100001 {
+		t.Errorf("sum of unit prices = %d, want 100001", sum)
+	}
+	if items[0].UnitPrice != 33334 || items[2].UnitPrice != 33333 {
+		t.Errorf("remainder not on first items: %+v", items)
+	}
+	if Split(50000, 7, nil, 0) != nil {
+		t.Error("Split without tickets must return nil")
+	}
+}
+
+func TestSplitWeightedFollowsSeatPrices(t *testing.T) {
+	// Hàng đầu +20%: 120000 + 100000 + 100000
+	items := SplitWeighted(320000, 7, []int{11, 12, 13}, []int64{120000, 100000, 100000}, 0)
+	if items[0].UnitPrice != 120000 || items[1].UnitPrice != 100000 || items[2].UnitPrice != 100000 {
+		t.Fatalf("items = %+v", items)
+	}
+
+	// Bill khác tổng giá ghế (bậc giá đổi giữa lúc tạo link và callback): vẫn theo tỷ lệ, tổng khớp bill
+	items = SplitWeighted(100001, 7, []int{11, 12}, []int64{2, 1}, 0)
+	if items[0].UnitPrice+items[1].UnitPrice != 100001 || items[0].UnitPrice != 66668 {
+		t.Fatalf("items = %+v", items)
+	}
+
+	if got := SplitWeighted(90000, 7, []int{11, 12}, []int64{1}, 0); got[0].UnitPrice != 45000 {
+		t.Fatalf("mismatched weights must fall back to an even split: %+v", got)
+	}
+}
@@ -0,0 +1,12 @@
+package billing
+
+// VATBreakdown - Tách tổng tiền đã gồm VAT thành tiền trước thuế và tiền thuế
+// theo thuế suất vatBps (basis point), làm tròn đến đồng; net + vat luôn bằng gross
+func VATBreakdown(gross int64, vatBps int) (net, vat int64) {
+	if gross <= 0 || vatBps <= 0 {
+		return gross, 0
+	}
+	divisor := int64(10000 + vatBps)
+	vat = (gross*int64(vatBps) + divisor/2) / divisor
+	return gross - vat, vat
+}
@@ -0,0 +1,22 @@
+package billing
+
+import "testing"
+
+func TestVATBreakdown(t *testing.T) {
+	for _, tc := range []struct {
+		gross    int64
+		bps      int
+		net, vat int64
+	}{
+		{110000, 1000, 100000, 10000},
+		{150000, 1000, 136364, 13636}, // 13636.36 → 13636
+		{108000, 800, 100000, 8000},
+		{50000, 0, 50000, 0},
+		{0, 1000, 0, 0},
+	} {
+		net, vat := VATBreakdown(tc.gross, tc.bps)
+		if net != tc.net || vat != tc.vat {
+			t.Errorf("VATBreakdown(%d, %d) = %d, %d, want %d, %d", tc.gross, tc.bps, net, vat, tc.net, tc.vat)
+		}
+	}
+}
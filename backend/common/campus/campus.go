@@ -0,0 +1,115 @@
+package campus
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/fpt-event-services/common/authctx"
+	"github.com/fpt-event-services/common/db"
+	"github.com/fpt-event-services/common/permission"
+)
+
+// ============================================================
+// Phạm vi campus của người dùng đang đăng nhập
+// STAFF/ADMIN (và role tùy chỉnh phía nhà trường) có users.campus_id chỉ xem /
+// thao tác dữ liệu của campus đó; campus_id NULL = không giới hạn
+// (có quyền campus.manage và không giới hạn là super admin).
+// Sinh viên, organizer, speaker và khách chọn campus qua tham số ?campusId= khi liệt kê.
+// ============================================================
+
+var (
+	// ErrOutOfScope - Dữ liệu thuộc campus khác campus được phân quyền
+	ErrOutOfScope = errors.New("campus out of scope")
+	// ErrInvalidCampus - campusId không hợp lệ
+	ErrInvalidCampus = errors.New("invalid campus id")
+)
+
+// lookup đọc campus của user; thay được trong test
+var lookup = UserCampus
+
+// ParseID đọc tham số campusId; chuỗi rỗng = không lọc (nil)
+func ParseID(raw string) (*int, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil, nil
+	}
+	id, err := strconv.Atoi(raw)
+	if err != nil || id <= 0 {
+		return nil, ErrInvalidCampus
+	}
+	return &id, nil
+}
+
+// UserCampus trả về users.campus_id (nil nếu user không gắn campus)
+func UserCampus(ctx context.Context, userID int) (*int, error) {
+	var campusID sql.NullInt64
+	err := db.GetDB().QueryRowContext(ctx, `SELECT campus_id FROM Users WHERE user_id = ?`, userID).Scan(&campusID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("failed to load user campus: %w", err)
+	}
+	if !campusID.Valid {
+		return nil, nil
+	}
+	id := int(campusID.Int64)
+	return &id, nil
+}
+
+// Scope - Campus giới hạn của người dùng hiện tại; nil = mọi campus
+// Role phía người dùng cuối (STUDENT, ORGANIZER, SPEAKER) và khách luôn trả nil
+func Scope(ctx context.Context) (*int, error) {
+	if authctx.Role(ctx) == "" || authctx.HasRole(ctx, "STUDENT", "ORGANIZER", "SPEAKER") {
+		return nil, nil
+	}
+	userID, ok := authctx.UserID(ctx)
+	if !ok {
+		return nil, nil
+	}
+	return lookup(ctx, userID)
+}
+
+// Resolve - Bộ lọc campus dùng cho truy vấn liệt kê
+// Người bị giới hạn: mặc định campus của mình, chọn campus khác => ErrOutOfScope
+// Người không bị giới hạn: đúng campus yêu cầu (nil = mọi campus)
+func Resolve(ctx context.Context, requested *int) (*int, error) {
+	scope, err := Scope(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if scope == nil {
+		return requested, nil
+	}
+	if requested != nil && *requested != *scope {
+		return nil, ErrOutOfScope
+	}
+	return scope, nil
+}
+
+// Allows - Phạm vi scope có bao gồm campusID không (scope nil = mọi campus)
+func Allows(scope, campusID *int) bool {
+	return scope == nil || (campusID != nil && *campusID == *scope)
+}
+
+// Check - ErrOutOfScope khi dữ liệu thuộc campus ngoài phạm vi người dùng hiện tại
+func Check(ctx context.Context, campusID *int) error {
+	scope, err := Scope(ctx)
+	if err != nil {
+		return err
+	}
+	if !Allows(scope, campusID) {
+		return ErrOutOfScope
+	}
+	return nil
+}
+
+// IsSuperAdmin - Có quyền campus.manage và không gắn campus (được xem báo cáo liên campus)
+func IsSuperAdmin(ctx context.Context) (bool, error) {
+	if !permission.Has(ctx, permission.CampusManage) {
+		return false, nil
+	}
+	scope, err := Scope(ctx)
+	return err == nil && scope == nil, err
+}
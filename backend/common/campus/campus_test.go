@@ -0,0 +1,70 @@
+package campus
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/fpt-event-services/common/authctx"
+)
+
+func intPtr(v int) *int { return &v }
+
+// stubLookup: user 1 là staff campus HCM (1), user 2 là super admin
+func stubLookup(t *testing.T) {
+	prev := lookup
+	lookup = func(_ context.Context, userID int) (*int, error) {
+		if userID == 1 {
+			return intPtr(1), nil
+		}
+		return nil, nil
+	}
+	t.Cleanup(func() { lookup = prev })
+}
+
+func TestParseID(t *testing.T) {
+	if id, err := ParseID(""); id != nil || err != nil {
+		t.Fatalf("empty = %v, %v", id, err)
+	}
+	if id, err := ParseID(" 2 "); err != nil || *id != 2 {
+		t.Fatalf("2 = %v, %v", id, err)
+	}
+	for _, raw := range []string{"0", "-1", "HCM"} {
+		if _, err := ParseID(raw); !errors.Is(err, ErrInvalidCampus) {
+			t.Errorf("%q: err = %v, want ErrInvalidCampus", raw, err)
+		}
+	}
+}
+
+func TestResolve(t *testing.T) {
+	stubLookup(t)
+	staff := authctx.WithUser(context.Background(), 1, "STAFF")
+	superAdmin := authctx.WithUser(context.Background(), 2, "ADMIN")
+	student := authctx.WithUser(context.Background(), 1, "STUDENT")
+
+	if got, err := Resolve(staff, nil); err != nil || got == nil || *got != 1 {
+		t.Fatalf("scoped staff default = %v, %v", got, err)
+	}
+	if _, err := Resolve(staff, intPtr(2)); !errors.Is(err, ErrOutOfScope) {
+		t.Fatalf("scoped staff other campus: err = %v", err)
+	}
+	if got, _ := Resolve(superAdmin, nil); got != nil {
+		t.Fatalf("super admin without filter = %v, want all campuses", *got)
+	}
+	if got, _ := Resolve(student, intPtr(3)); got == nil || *got != 3 {
+		t.Fatal("student must get the requested campus")
+	}
+
+	if ok, _ := IsSuperAdmin(superAdmin); !ok {
+		t.Fatal("ADMIN without campus must be super admin")
+	}
+	if ok, _ := IsSuperAdmin(authctx.WithUser(context.Background(), 1, "ADMIN")); ok {
+		t.Fatal("campus ADMIN must not be super admin")
+	}
+	if err := Check(staff, intPtr(3)); !errors.Is(err, ErrOutOfScope) {
+		t.Fatalf("Check other campus: err = %v", err)
+	}
+	if err := Check(staff, nil); !errors.Is(err, ErrOutOfScope) {
+		t.Fatal("scoped staff must not touch data without campus")
+	}
+}
@@ -0,0 +1,192 @@
+package capability
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ============================================================
+// CAPABILITY - Sổ đăng ký thao tác đã / chưa hiện thực của repository
+// Repository khai báo thao tác của mình (Declare), route khai báo thao tác nó
+// dùng (Require). Lúc khởi động Gate đối chiếu hai bên: route dựa vào thao tác
+// UNIMPLEMENTED (stub trả nil, không ghi gì) thì log cảnh báo hoặc dừng server
+// (CAPABILITY_GATE=warn|fail|off). Ma trận xem qua GET /api/admin/capabilities.
+// ============================================================
+
+// Status - Mức độ hiện thực của một thao tác
+type Status string
+
+const (
+	Implemented   Status = "IMPLEMENTED"
+	Partial       Status = "PARTIAL"       // Chạy được nhưng thiếu một phần (vd. luôn dùng giá trị mặc định)
+	Unimplemented Status = "UNIMPLEMENTED" // Stub: trả về thành công nhưng không làm gì
+	Undeclared    Status = "UNDECLARED"    // Route cần thao tác không repository nào khai báo
+)
+
+// Chế độ của Gate (biến môi trường CAPABILITY_GATE)
+const (
+	GateWarn = "warn"
+	GateFail = "fail"
+	GateOff  = "off"
+)
+
+// ErrUnimplemented - Thao tác là stub; repository trả lỗi này thay vì giả vờ thành công
+var ErrUnimplemented = errors.New("operation is not implemented")
+
+// Capability - Một thao tác của repository
+type Capability struct {
+	Name      string   `json:"name"`
+	Component string   `json:"component"`
+	Status    Status   `json:"status"`
+	Note      string   `json:"note,omitempty"`
+	UsedBy    []string `json:"usedBy"` // Route khai báo cần thao tác này
+}
+
+// Route - Route và các thao tác nó cần
+type Route struct {
+	Route    string            `json:"route"`
+	Requires map[string]Status `json:"requires"`
+}
+
+// Violation - Route dựa vào thao tác chưa hiện thực đủ
+type Violation struct {
+	Route      string `json:"route"`
+	Capability string `json:"capability"`
+	Status     Status `json:"status"`
+	Note       string `json:"note,omitempty"`
+}
+
+// Matrix - GET /api/admin/capabilities
+// Dead: thao tác chưa hiện thực và không route nào dùng (ứng viên xóa)
+type Matrix struct {
+	Gate         string       `json:"gate"`
+	Capabilities []Capability `json:"capabilities"`
+	Routes       []Route      `json:"routes"`
+	Violations   []Violation  `json:"violations"`
+	Dead         []string     `json:"dead"`
+}
+
+// Registry - Sổ đăng ký thao tác và route
+type Registry struct {
+	mu     sync.RWMutex
+	caps   map[string]Capability
+	routes map[string][]string
+}
+
+// NewRegistry tạo sổ đăng ký rỗng
+func NewRegistry() *Registry {
+	return &Registry{caps: make(map[string]Capability), routes: make(map[string][]string)}
+}
+
+// Default - Sổ đăng ký của process (main.go khai báo lúc đăng ký route)
+var Default = NewRegistry()
+
+// Declare - Repository khai báo thao tác của mình (khai báo lại cùng tên thì ghi đè)
+func (r *Registry) Declare(component string, caps ...Capability) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, c := range caps {
+		c.Component = component
+		c.UsedBy = nil
+		r.caps[c.Name] = c
+	}
+}
+
+// Require - Route cần các thao tác names
+func (r *Registry) Require(route string, names ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.routes[route] = append(r.routes[route], names...)
+}
+
+// Implemented - Thao tác đã hiện thực đầy đủ
+func (r *Registry) Implemented(name string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.caps[name].Status == Implemented
+}
+
+// Matrix - Toàn bộ thao tác, route và vi phạm (sắp theo tên để so sánh được giữa các lần)
+func (r *Registry) Matrix() Matrix {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	used := make(map[string][]string)
+	m := Matrix{Gate: gateMode(), Capabilities: []Capability{}, Routes: []Route{}, Violations: []Violation{}, Dead: []string{}}
+	for route, names := range r.routes {
+		view := Route{Route: route, Requires: make(map[string]Status, len(names))}
+		for _, name := range names {
+			c, ok := r.caps[name]
+			status := c.Status
+			if !ok {
+				status = Undeclared
+			}
+			view.Requires[name] = status
+			used[name] = append(used[name], route)
+			if status != Implemented {
+				m.Violations = append(m.Violations, Violation{Route: route, Capability: name, Status: status, Note: c.Note})
+			}
+		}
+		m.Routes = append(m.Routes, view)
+	}
+	for name, c := range r.caps {
+		c.UsedBy = used[name]
+		sort.Strings(c.UsedBy)
+		if c.UsedBy == nil {
+			c.UsedBy = []string{}
+			if c.Status != Implemented {
+				m.Dead = append(m.Dead, name)
+			}
+		}
+		m.Capabilities = append(m.Capabilities, c)
+	}
+
+	sort.Slice(m.Capabilities, func(i, j int) bool { return m.Capabilities[i].Name < m.Capabilities[j].Name })
+	sort.Slice(m.Routes, func(i, j int) bool { return m.Routes[i].Route < m.Routes[j].Route })
+	sort.Slice(m.Violations, func(i, j int) bool {
+		if m.Violations[i].Route != m.Violations[j].Route {
+			return m.Violations[i].Route < m.Violations[j].Route
+		}
+		return m.Violations[i].Capability < m.Violations[j].Capability
+	})
+	sort.Strings(m.Dead)
+	return m
+}
+
+// Gate - Kiểm tra lúc khởi động; chỉ trả lỗi ở chế độ fail khi có route dựa vào
+// thao tác UNIMPLEMENTED / UNDECLARED (PARTIAL chỉ cảnh báo)
+func (r *Registry) Gate() error {
+	mode := gateMode()
+	if mode == GateOff {
+		return nil
+	}
+	m := r.Matrix()
+	var blocking []string
+	for _, v := range m.Violations {
+		log.Printf("⚠️  [CAPABILITY] %s depends on %s (%s) %s", v.Route, v.Capability, v.Status, v.Note)
+		if v.Status == Unimplemented || v.Status == Undeclared {
+			blocking = append(blocking, v.Route+" → "+v.Capability)
+		}
+	}
+	for _, name := range m.Dead {
+		log.Printf("[CAPABILITY] %s is not implemented and no route uses it (dead code candidate)", name)
+	}
+	if len(blocking) > 0 && mode == GateFail {
+		return fmt.Errorf("routes depend on unimplemented capabilities: %s", strings.Join(blocking, ", "))
+	}
+	return nil
+}
+
+// gateMode - CAPABILITY_GATE, mặc định warn
+func gateMode() string {
+	switch mode := strings.ToLower(strings.TrimSpace(os.Getenv("CAPABILITY_GATE"))); mode {
+	case GateFail, GateOff:
+		return mode
+	}
+	return GateWarn
+}
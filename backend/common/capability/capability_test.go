@@ -0,0 +1,76 @@
+package capability
+
+import (
+	"reflect"
+	"testing"
+)
+
+func testRegistry() *Registry {
+	r := NewRegistry()
+	r.Declare("event-lambda/repository",
+		Capability{Name: "event.update-details", Status: Implemented},
+		Capability{Name: "event.config.read", Status: Partial, Note: "global only"},
+		Capability{Name: "event.config.write", Status: Unimplemented},
+		Capability{Name: "event.update", Status: Unimplemented},
+	)
+	r.Require("POST /api/events/update-details", "event.update-details")
+	r.Require("GET /api/events/config", "event.config.read")
+	r.Require("POST /api/events/update-config", "event.config.write")
+	return r
+}
+
+func TestMatrixViolationsAndDead(t *testing.T) {
+	r := testRegistry()
+	r.Require("GET /api/unknown", "missing.capability")
+	m := r.Matrix()
+
+	want := []Violation{
+		{Route: "GET /api/events/config", Capability: "event.config.read", Status: Partial, Note: "global only"},
+		{Route: "GET /api/unknown", Capability: "missing.capability", Status: Undeclared},
+		{Route: "POST /api/events/update-config", Capability: "event.config.write", Status: Unimplemented},
+	}
+	if !reflect.DeepEqual(m.Violations, want) {
+		t.Fatalf("violations:\n got %+v\nwant %+v", m.Violations, want)
+	}
+	if !reflect.DeepEqual(m.Dead, []string{"event.update"}) {
+		t.Fatalf("dead: got %v", m.Dead)
+	}
+	for _, c := range m.Capabilities {
+		if c.Name == "event.update-details" && !reflect.DeepEqual(c.UsedBy, []string{"POST /api/events/update-details"}) {
+			t.Fatalf("usedBy: got %v", c.UsedBy)
+		}
+	}
+}
+
+func TestGateModes(t *testing.T) {
+	r := testRegistry()
+
+	t.Setenv("CAPABILITY_GATE", "")
+	if err := r.Gate(); err != nil {
+		t.Fatalf("warn mode should not fail: %v", err)
+	}
+	t.Setenv("CAPABILITY_GATE", "fail")
+	if err := r.Gate(); err == nil {
+		t.Fatal("fail mode should fail on an unimplemented dependency")
+	}
+	t.Setenv("CAPABILITY_GATE", "off")
+	if err := r.Gate(); err != nil {
+		t.Fatalf("off mode should not fail: %v", err)
+	}
+
+	// PARTIAL chỉ cảnh báo
+	partialOnly := NewRegistry()
+	partialOnly.Declare("x", Capability{Name: "a", Status: Partial})
+	partialOnly.Require("GET /a", "a")
+	t.Setenv("CAPABILITY_GATE", "fail")
+	if err := partialOnly.Gate(); err != nil {
+		t.Fatalf("partial capabilities should not block startup: %v", err)
+	}
+}
+
+func TestImplemented(t *testing.T) {
+	r := testRegistry()
+	if !r.Implemented("event.update-details") || r.Implemented("event.config.read") || r.Implemented("nope") {
+		t.Fatal("Implemented reports wrong status")
+	}
+}
@@ -0,0 +1,165 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// ============================================================
+// CORSConfig - Cấu hình CORS của HTTP server theo môi trường
+// Chỉ phản hồi lại Origin nằm trong AllowedOrigins, không dùng "*" kèm credentials
+// (trình duyệt từ chối tổ hợp này và nó mở API cho mọi website)
+// ============================================================
+type CORSConfig struct {
+	// AllowedOrigins: Danh sách origin chính xác (scheme://host[:port]); "*" = mọi origin
+	AllowedOrigins []string
+	// AllowedMethods / AllowedHeaders: Trả về cho preflight
+	AllowedMethods []string
+	AllowedHeaders []string
+	// ExposedHeaders: Header response mà JS của frontend được đọc
+	ExposedHeaders []string
+	// MaxAge: Số giây trình duyệt cache kết quả preflight
+	MaxAge int
+	// AllowCredentials: Cho phép cookie / credentials: 'include'
+	AllowCredentials bool
+}
+
+// Mặc định khi không đặt biến môi trường
+var (
+	defaultCORSDevOrigins = []string{"http://localhost:3000", "http://localhost:5173"}
+	defaultCORSMethods    = []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"}
+	defaultCORSHeaders    = []string{"Content-Type", "Authorization", "traceparent", "If-Match", "If-None-Match", "X-Device-Fingerprint"}
+	defaultCORSExposed    = []string{"ETag", "Retry-After"}
+)
+
+const defaultCORSMaxAge = 600
+
+// IsProduction - APP_ENV=production (hoặc prod)
+func IsProduction() bool {
+	env := strings.ToLower(strings.TrimSpace(os.Getenv("APP_ENV")))
+	return env == "production" || env == "prod"
+}
+
+// CORSConfigFromEnv đọc cấu hình CORS từ biến môi trường:
+//
+//	CORS_ORIGINS            danh sách origin, phân cách bằng dấu phẩy
+//	CORS_METHODS            mặc định GET,POST,PUT,PATCH,DELETE,OPTIONS
+//	CORS_HEADERS            mặc định Content-Type,Authorization,traceparent,If-Match,If-None-Match,X-Device-Fingerprint
+//	CORS_EXPOSED_HEADERS    mặc định ETag,Retry-After
+//	CORS_MAX_AGE            giây, mặc định 600
+//	CORS_ALLOW_CREDENTIALS  mặc định true
+//
+// Không đặt CORS_ORIGINS: môi trường dev mở cho localhost:3000/5173,
+// production (APP_ENV=production) không mở cho origin nào
+func CORSConfigFromEnv() (*CORSConfig, error) {
+	cfg := &CORSConfig{
+		AllowedMethods:   envList("CORS_METHODS", defaultCORSMethods),
+		AllowedHeaders:   envList("CORS_HEADERS", defaultCORSHeaders),
+		ExposedHeaders:   envList("CORS_EXPOSED_HEADERS", defaultCORSExposed),
+		MaxAge:           defaultCORSMaxAge,
+		AllowCredentials: true,
+	}
+	if IsProduction() {
+		cfg.AllowedOrigins = envList("CORS_ORIGINS", nil)
+	} else {
+		cfg.AllowedOrigins = envList("CORS_ORIGINS", defaultCORSDevOrigins)
+	}
+
+	if v := os.Getenv("CORS_MAX_AGE"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("invalid CORS_MAX_AGE %q", v)
+		}
+		cfg.MaxAge = n
+	}
+	if v := os.Getenv("CORS_ALLOW_CREDENTIALS"); v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CORS_ALLOW_CREDENTIALS %q", v)
+		}
+		cfg.AllowCredentials = b
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// Validate kiểm tra origin hợp lệ và chặn "*" khi bật credentials
+func (c *CORSConfig) Validate() error {
+	for i, o := range c.AllowedOrigins {
+		if o == "*" {
+			if c.AllowCredentials {
+				return fmt.Errorf("CORS_ORIGINS=* cannot be combined with CORS_ALLOW_CREDENTIALS=true")
+			}
+			continue
+		}
+		u, err := url.Parse(o)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" ||
+			(u.Path != "" && u.Path != "/") || u.RawQuery != "" {
+			return fmt.Errorf("invalid CORS origin %q (expected scheme://host[:port])", o)
+		}
+		c.AllowedOrigins[i] = u.Scheme + "://" + strings.ToLower(u.Host)
+	}
+	return nil
+}
+
+// AllowOrigin trả về giá trị cho Access-Control-Allow-Origin
+// ok = false khi origin không được phép (không set header CORS nào)
+func (c *CORSConfig) AllowOrigin(origin string) (string, bool) {
+	if origin == "" {
+		return "", false
+	}
+	lower := strings.ToLower(strings.TrimSuffix(origin, "/"))
+	for _, allowed := range c.AllowedOrigins {
+		if allowed == "*" {
+			return "*", true
+		}
+		if allowed == lower {
+			return origin, true
+		}
+	}
+	return "", false
+}
+
+// Headers - Header CORS cho request từ origin; rỗng khi origin không được phép
+// Dùng chung cho server HTTP (main.go) và entrypoint Lambda (common/lambdainit)
+// preflight: request OPTIONS có Access-Control-Request-Method
+func (c *CORSConfig) Headers(origin string, preflight bool) map[string]string {
+	allowOrigin, ok := c.AllowOrigin(origin)
+	if !ok {
+		return nil
+	}
+	h := map[string]string{"Access-Control-Allow-Origin": allowOrigin}
+	// AllowOrigin chỉ trả "*" khi tắt credentials (xem Validate)
+	if c.AllowCredentials && allowOrigin != "*" {
+		h["Access-Control-Allow-Credentials"] = "true"
+	}
+	if preflight {
+		h["Access-Control-Allow-Methods"] = strings.Join(c.AllowedMethods, ",")
+		h["Access-Control-Allow-Headers"] = strings.Join(c.AllowedHeaders, ",")
+		h["Access-Control-Max-Age"] = strconv.Itoa(c.MaxAge)
+	} else if len(c.ExposedHeaders) > 0 {
+		h["Access-Control-Expose-Headers"] = strings.Join(c.ExposedHeaders, ",")
+	}
+	return h
+}
+
+// envList đọc danh sách phân cách bằng dấu phẩy, bỏ phần tử rỗng
+func envList(key string, def []string) []string {
+	v := os.Getenv(key)
+	if strings.TrimSpace(v) == "" {
+		return append([]string(nil), def...)
+	}
+	var out []string
+	for _, item := range strings.Split(v, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			out = append(out, item)
+		}
+	}
+	return out
+}
@@ -0,0 +1,82 @@
+package config
+
+import "testing"
+
+func TestCORSConfigFromEnvDefaults(t *testing.T) {
+	t.Setenv("APP_ENV", "")
+	t.Setenv("CORS_ORIGINS", "")
+	cfg, err := CORSConfigFromEnv()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(cfg.AllowedOrigins) != 2 || !cfg.AllowCredentials || cfg.MaxAge != 600 {
+		t.Fatalf("unexpected dev defaults: %+v", cfg)
+	}
+
+	t.Setenv("APP_ENV", "production")
+	cfg, err = CORSConfigFromEnv()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := cfg.AllowOrigin("http://localhost:3000"); ok {
+		t.Fatal("production must not allow localhost by default")
+	}
+}
+
+func TestCORSConfigRejectsWildcardWithCredentials(t *testing.T) {
+	t.Setenv("CORS_ORIGINS", "*")
+	t.Setenv("CORS_ALLOW_CREDENTIALS", "true")
+	if _, err := CORSConfigFromEnv(); err == nil {
+		t.Fatal("* with credentials must be rejected")
+	}
+
+	t.Setenv("CORS_ALLOW_CREDENTIALS", "false")
+	cfg, err := CORSConfigFromEnv()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if v, ok := cfg.AllowOrigin("https://any.example.com"); !ok || v != "*" {
+		t.Fatalf("AllowOrigin = %q, %v; want *", v, ok)
+	}
+}
+
+func TestCORSAllowOrigin(t *testing.T) {
+	t.Setenv("CORS_ORIGINS", "https://Events.FPT.edu.vn/, http://localhost:5173")
+	cfg, err := CORSConfigFromEnv()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if v, ok := cfg.AllowOrigin("https://events.fpt.edu.vn"); !ok || v != "https://events.fpt.edu.vn" {
+		t.Fatalf("AllowOrigin = %q, %v", v, ok)
+	}
+	for _, o := range []string{"", "null", "https://evil.example.com", "http://events.fpt.edu.vn", "http://localhost:3000"} {
+		if _, ok := cfg.AllowOrigin(o); ok {
+			t.Errorf("origin %q must be rejected", o)
+		}
+	}
+
+	t.Setenv("CORS_ORIGINS", "events.fpt.edu.vn")
+	if _, err := CORSConfigFromEnv(); err == nil {
+		t.Fatal("origin without scheme must be rejected")
+	}
+}
+
+func TestCORSHeaders(t *testing.T) {
+	t.Setenv("APP_ENV", "")
+	t.Setenv("CORS_ORIGINS", "https://events.fpt.edu.vn")
+	cfg, err := CORSConfigFromEnv()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if h := cfg.Headers("https://evil.example.com", false); len(h) != 0 {
+		t.Fatalf("disallowed origin got CORS headers: %v", h)
+	}
+	h := cfg.Headers("https://events.fpt.edu.vn", false)
+	if h["Access-Control-Allow-Origin"] != "https://events.fpt.edu.vn" || h["Access-Control-Allow-Credentials"] != "true" ||
+		h["Access-Control-Expose-Headers"] == "" || h["Access-Control-Allow-Methods"] != "" {
+		t.Fatalf("unexpected headers: %v", h)
+	}
+	if h := cfg.Headers("https://events.fpt.edu.vn", true); h["Access-Control-Allow-Methods"] == "" || h["Access-Control-Max-Age"] != "600" {
+		t.Fatalf("unexpected preflight headers: %v", h)
+	}
+}
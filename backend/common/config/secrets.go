@@ -0,0 +1,136 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/fpt-event-services/common/secrets"
+)
+
+// ============================================================
+// Nạp secret lúc khởi động từ AWS Secrets Manager / SSM Parameter Store
+// Giá trị lấy được ghi đè biến môi trường cùng tên, nên các package đọc os.Getenv
+// (db, jwt, vnpay, email, crypto) không cần đổi; secret không có trên AWS
+// thì giữ giá trị từ .env / môi trường (env fallback).
+//
+//	SECRETS_PROVIDER           env (mặc định) | secretsmanager | ssm
+//	SECRETS_MANAGER_SECRET_ID  secret JSON chứa các khóa bên dưới (vd: fpt-event/prod)
+//	SSM_PARAMETER_PREFIX       tiền tố tham số SecureString (vd: /fpt-event/prod/)
+//	SECRETS_ENDPOINT           endpoint tùy chỉnh (LocalStack), mặc định theo AWS_REGION
+//	SECRETS_CACHE_TTL          chu kỳ đọc lại để nhận secret đã xoay, mặc định 15m (0 = không đọc lại)
+//
+// Credential AWS theo default credential chain của SDK (IAM role của Lambda, profile, biến môi trường).
+// Giới hạn khi xoay: lần đọc lại chỉ cập nhật biến môi trường. Package đọc os.Getenv mỗi lần dùng
+// (JWT, QR token, email dựng theo từng lần gửi) nhận giá trị mới; DB_USER/DB_PASSWORD (pool kết nối
+// tạo ở db.InitDB), PII_ENCRYPTION_KEY (crypto.Default() giữ cipher), VNPAY_HASH_SECRET và
+// SMTP_PASSWORD của EmailService tạo lúc khởi động chỉ có hiệu lực sau khi khởi động lại / cold start. Khi xoay mật khẩu DB, giữ mật khẩu cũ hợp lệ tới khi mọi instance
+// đã khởi động lại; khi xoay khóa PII, đưa khóa cũ vào PII_ENCRYPTION_OLD_KEYS.
+// ============================================================
+
+// ManagedSecrets - Các biến môi trường được lấy từ secrets provider
+var ManagedSecrets = []string{
+	"DB_USER",
+	"DB_PASSWORD",
+	"JWT_SECRET",
+	"VNPAY_HASH_SECRET",
+	"SMTP_PASSWORD",
+	"PII_ENCRYPTION_KEY",
+	"QR_TOKEN_SECRET",
+}
+
+const defaultSecretsCacheTTL = 15 * time.Minute
+
+// SecretsProviderFromEnv tạo provider theo SECRETS_PROVIDER; backend AWS luôn kèm env fallback
+func SecretsProviderFromEnv(ctx context.Context) (secrets.Provider, error) {
+	region := os.Getenv("AWS_REGION")
+	if region == "" {
+		region = "ap-southeast-1"
+	}
+	endpoint := os.Getenv("SECRETS_ENDPOINT")
+
+	kind := strings.ToLower(strings.TrimSpace(os.Getenv("SECRETS_PROVIDER")))
+	switch kind {
+	case "", "env":
+		return secrets.EnvProvider{}, nil
+	case "secretsmanager", "ssm":
+	default:
+		return nil, fmt.Errorf("invalid SECRETS_PROVIDER %q (env, secretsmanager, ssm)", kind)
+	}
+
+	cfg, err := secrets.LoadAWSConfig(ctx, region)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS config: %w", err)
+	}
+	if kind == "secretsmanager" {
+		id := os.Getenv("SECRETS_MANAGER_SECRET_ID")
+		if id == "" {
+			return nil, errors.New("SECRETS_MANAGER_SECRET_ID is required when SECRETS_PROVIDER=secretsmanager")
+		}
+		return secrets.Chain{secrets.NewSecretsManagerProvider(id, cfg, endpoint), secrets.EnvProvider{}}, nil
+	}
+	prefix := os.Getenv("SSM_PARAMETER_PREFIX")
+	if prefix == "" {
+		return nil, errors.New("SSM_PARAMETER_PREFIX is required when SECRETS_PROVIDER=ssm")
+	}
+	return secrets.Chain{secrets.NewSSMProvider(prefix, cfg, endpoint), secrets.EnvProvider{}}, nil
+}
+
+// LoadSecrets nạp ManagedSecrets vào biến môi trường; gọi một lần khi khởi động,
+// trước khi kết nối DB / khởi tạo service. Với SECRETS_CACHE_TTL > 0, goroutine nền
+// đọc lại định kỳ để secret xoay trên AWS có hiệu lực mà không cần deploy lại.
+func LoadSecrets(ctx context.Context) error {
+	provider, err := SecretsProviderFromEnv(ctx)
+	if err != nil {
+		return err
+	}
+	if _, ok := provider.(secrets.EnvProvider); ok {
+		return nil
+	}
+
+	ttl := defaultSecretsCacheTTL
+	if v := os.Getenv("SECRETS_CACHE_TTL"); v != "" {
+		if ttl, err = time.ParseDuration(v); err != nil || ttl < 0 {
+			return fmt.Errorf("invalid SECRETS_CACHE_TTL %q", v)
+		}
+	}
+	cache := secrets.NewCache(provider, ttl)
+	if err := applySecrets(ctx, cache); err != nil {
+		return err
+	}
+	log.Printf("🔐 [SECRETS] Loaded %d secrets from %s", len(ManagedSecrets), provider.Name())
+	if ttl > 0 {
+		go refreshSecrets(cache, ttl)
+	}
+	return nil
+}
+
+func applySecrets(ctx context.Context, p secrets.Provider) error {
+	for _, name := range ManagedSecrets {
+		v, err := p.GetSecret(ctx, name)
+		if errors.Is(err, secrets.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to load secret %s: %w", name, err)
+		}
+		os.Setenv(name, v)
+	}
+	return nil
+}
+
+func refreshSecrets(cache *secrets.Cache, ttl time.Duration) {
+	ticker := time.NewTicker(ttl)
+	defer ticker.Stop()
+	for range ticker.C {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		if err := applySecrets(ctx, cache); err != nil {
+			log.Printf("⚠️  [SECRETS] Refresh failed, keeping previous values: %v", err)
+		}
+		cancel()
+	}
+}
@@ -0,0 +1,202 @@
+package crypto
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"sync"
+)
+
+// ============================================================
+// Mã hóa cột PII ở tầng ứng dụng (AES-256-GCM)
+// Giá trị lưu DB: "enc:v1:" + base64(nonce || ciphertext || tag)
+// Giá trị không có tiền tố là dữ liệu cũ chưa mã hóa: đọc vẫn trả nguyên văn,
+// lệnh `backend admin encrypt-pii` mã hóa dần dữ liệu này.
+//
+// Khóa 32 byte (base64) lấy từ:
+//   PII_ENCRYPTION_KEY       giá trị khóa (vd: biến môi trường Lambda mã hóa bằng KMS)
+//   PII_ENCRYPTION_KEY_FILE  file chứa khóa (secret mount / file giải mã từ KMS)
+//   PII_ENCRYPTION_OLD_KEYS  khóa cũ, phân cách dấu phẩy, chỉ dùng để giải mã khi xoay khóa
+// ============================================================
+
+// EncryptedPrefix - Tiền tố nhận biết giá trị đã mã hóa
+const EncryptedPrefix = "enc:v1:"
+
+// ErrDecrypt - Không giải mã được (sai khóa / dữ liệu hỏng)
+var ErrDecrypt = errors.New("failed to decrypt PII value")
+
+// Cipher mã hóa / giải mã giá trị PII; Cipher nil = chưa cấu hình khóa (lưu nguyên văn)
+type Cipher struct {
+	primary cipher.AEAD
+	// old: khóa cũ, thử lần lượt khi khóa chính không giải mã được
+	old []cipher.AEAD
+}
+
+// NewCipher tạo Cipher từ khóa chính 32 byte và các khóa cũ (tùy chọn)
+func NewCipher(key []byte, oldKeys ...[]byte) (*Cipher, error) {
+	primary, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	c := &Cipher{primary: primary}
+	for _, k := range oldKeys {
+		aead, err := newAEAD(k)
+		if err != nil {
+			return nil, fmt.Errorf("old key: %w", err)
+		}
+		c.old = append(c.old, aead)
+	}
+	return c, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	if len(key) != 32 {
+		return nil, fmt.Errorf("PII encryption key must be 32 bytes, got %d", len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// Encrypt mã hóa giá trị; chuỗi rỗng và giá trị đã mã hóa giữ nguyên
+func (c *Cipher) Encrypt(plain string) (string, error) {
+	if c == nil || plain == "" || IsEncrypted(plain) {
+		return plain, nil
+	}
+	nonce := make([]byte, c.primary.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	sealed := c.primary.Seal(nonce, nonce, []byte(plain), nil)
+	return EncryptedPrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt giải mã giá trị có tiền tố; giá trị cũ chưa mã hóa trả nguyên văn
+func (c *Cipher) Decrypt(value string) (string, error) {
+	if !IsEncrypted(value) {
+		return value, nil
+	}
+	if c == nil {
+		return "", fmt.Errorf("%w: PII_ENCRYPTION_KEY is not configured", ErrDecrypt)
+	}
+	sealed, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(value, EncryptedPrefix))
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrDecrypt, err)
+	}
+	for _, aead := range append([]cipher.AEAD{c.primary}, c.old...) {
+		n := aead.NonceSize()
+		if len(sealed) < n+aead.Overhead() {
+			return "", ErrDecrypt
+		}
+		if plain, err := aead.Open(nil, sealed[:n], sealed[n:], nil); err == nil {
+			return string(plain), nil
+		}
+	}
+	return "", ErrDecrypt
+}
+
+// IsEncrypted - Giá trị đã được mã hóa (có tiền tố enc:v1:)
+func IsEncrypted(value string) bool {
+	return strings.HasPrefix(value, EncryptedPrefix)
+}
+
+var (
+	defaultCipher *Cipher
+	defaultErr    error
+	defaultOnce   sync.Once
+)
+
+// LoadFromEnv đọc khóa theo biến môi trường; (nil, nil) khi chưa cấu hình khóa
+func LoadFromEnv() (*Cipher, error) {
+	raw := strings.TrimSpace(os.Getenv("PII_ENCRYPTION_KEY"))
+	if raw == "" {
+		if path := os.Getenv("PII_ENCRYPTION_KEY_FILE"); path != "" {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read PII_ENCRYPTION_KEY_FILE: %w", err)
+			}
+			raw = strings.TrimSpace(string(data))
+		}
+	}
+	if raw == "" {
+		return nil, nil
+	}
+	key, err := base64.StdEncoding.DecodeString(raw)
+	if err != nil {
+		return nil, fmt.Errorf("PII encryption key must be base64: %w", err)
+	}
+	var oldKeys [][]byte
+	for _, s := range strings.Split(os.Getenv("PII_ENCRYPTION_OLD_KEYS"), ",") {
+		if s = strings.TrimSpace(s); s == "" {
+			continue
+		}
+		k, err := base64.StdEncoding.DecodeString(s)
+		if err != nil {
+			return nil, fmt.Errorf("PII_ENCRYPTION_OLD_KEYS must be base64: %w", err)
+		}
+		oldKeys = append(oldKeys, k)
+	}
+	return NewCipher(key, oldKeys...)
+}
+
+// Default - Cipher dùng chung theo biến môi trường (đọc một lần)
+func Default() (*Cipher, error) {
+	defaultOnce.Do(func() {
+		defaultCipher, defaultErr = LoadFromEnv()
+		if defaultErr == nil && defaultCipher == nil {
+			log.Println("⚠️  [PII] PII_ENCRYPTION_KEY is not set, PII columns are stored in plaintext")
+		}
+	})
+	return defaultCipher, defaultErr
+}
+
+// EncryptPII mã hóa bằng Cipher mặc định (dùng trong repository trước khi INSERT/UPDATE)
+func EncryptPII(plain string) (string, error) {
+	c, err := Default()
+	if err != nil {
+		return "", err
+	}
+	return c.Encrypt(plain)
+}
+
+// DecryptPII giải mã bằng Cipher mặc định (dùng khi Scan từ DB)
+// Lỗi giải mã được log và trả về chuỗi rỗng để không lộ ciphertext ra API
+func DecryptPII(value string) string {
+	c, err := Default()
+	if err == nil {
+		var plain string
+		if plain, err = c.Decrypt(value); err == nil {
+			return plain
+		}
+	}
+	log.Printf("[PII] %v", err)
+	return ""
+}
+
+// EncryptOptionalPII / DecryptOptionalPII - Bản cho cột NULL được (*string)
+func EncryptOptionalPII(plain *string) (*string, error) {
+	if plain == nil {
+		return nil, nil
+	}
+	v, err := EncryptPII(*plain)
+	if err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+func DecryptOptionalPII(value *string) *string {
+	if value == nil {
+		return nil
+	}
+	v := DecryptPII(*value)
+	return &v
+}
@@ -0,0 +1,72 @@
+package crypto
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func testKey(b byte) []byte {
+	return bytes.Repeat([]byte{b}, 32)
+}
+
+func TestEncryptDecryptRoundTrip(t *testing.T) {
+	c, err := NewCipher(testKey(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	enc, err := c.Encrypt("0912345678")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !IsEncrypted(enc) || strings.Contains(enc, "0912345678") {
+		t.Fatalf("value not encrypted: %q", enc)
+	}
+	if again, _ := c.Encrypt("0912345678"); again == enc {
+		t.Fatal("nonce must differ between encryptions")
+	}
+	if twice, _ := c.Encrypt(enc); twice != enc {
+		t.Fatal("encrypted value must not be encrypted twice")
+	}
+	if plain, err := c.Decrypt(enc); err != nil || plain != "0912345678" {
+		t.Fatalf("Decrypt = %q, %v", plain, err)
+	}
+	if empty, _ := c.Encrypt(""); empty != "" {
+		t.Fatal("empty value must stay empty")
+	}
+}
+
+func TestDecryptLegacyAndRotation(t *testing.T) {
+	oldCipher, _ := NewCipher(testKey(1))
+	enc, _ := oldCipher.Encrypt("speaker@fpt.edu.vn")
+
+	rotated, err := NewCipher(testKey(2), testKey(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plain, err := rotated.Decrypt(enc); err != nil || plain != "speaker@fpt.edu.vn" {
+		t.Fatalf("old key decrypt = %q, %v", plain, err)
+	}
+	if plain, err := rotated.Decrypt("legacy@fpt.edu.vn"); err != nil || plain != "legacy@fpt.edu.vn" {
+		t.Fatalf("plaintext must pass through, got %q, %v", plain, err)
+	}
+
+	wrong, _ := NewCipher(testKey(3))
+	if _, err := wrong.Decrypt(enc); !errors.Is(err, ErrDecrypt) {
+		t.Fatalf("wrong key: err = %v, want ErrDecrypt", err)
+	}
+	var none *Cipher
+	if _, err := none.Decrypt(enc); !errors.Is(err, ErrDecrypt) {
+		t.Fatalf("nil cipher: err = %v, want ErrDecrypt", err)
+	}
+}
+
+func TestNewCipherKeyLength(t *testing.T) {
+	if _, err := NewCipher([]byte("short")); err == nil {
+		t.Fatal("short key must be rejected")
+	}
+	if _, err := NewCipher(testKey(1), []byte("short")); err == nil {
+		t.Fatal("short old key must be rejected")
+	}
+}
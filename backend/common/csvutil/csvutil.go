@@ -0,0 +1,16 @@
+package csvutil
+
+import "strings"
+
+// ============================================================
+// CSVUTIL - Tiện ích cho các file CSV xuất ra cho người dùng mở bằng Excel / Sheets
+// ============================================================
+
+// Safe - Chặn CSV injection: ô bắt đầu bằng = + - @ (hoặc tab / CR) bị Excel hiểu là công thức,
+// nên thêm dấu ' phía trước để hiển thị nguyên văn
+func Safe(s string) string {
+	if s != "" && strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		return "'" + s
+	}
+	return s
+}
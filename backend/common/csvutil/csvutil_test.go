@@ -0,0 +1,20 @@
+package csvutil
+
+import "testing"
+
+func TestSafe(t *testing.T) {
+	for in, want := range map[string]string{
+		"":             "",
+		"Nguyễn Văn A": "Nguyễn Văn A",
+		"=HYPERLINK()": "'=HYPERLINK()",
+		"+84901234567": "'+84901234567",
+		"-1":           "'-1",
+		"@SUM(A1)":     "'@SUM(A1)",
+		"\tcmd":        "'\tcmd",
+		"a=b":          "a=b",
+	} {
+		if got := Safe(in); got != want {
+			t.Errorf("Safe(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -5,13 +5,18 @@ import (
 	"database/sql"
 	"fmt"
 	"os"
+	"strconv"
+	"sync"
 	"time"
 
-	_ "github.com/go-sql-driver/mysql"
+	"github.com/go-sql-driver/mysql"
 )
 
 var db *sql.DB
 
+// initMu - Chống InitDB chạy song song khi Ensure được gọi từ nhiều goroutine
+var initMu sync.Mutex
+
 // Config holds database configuration
 type Config struct {
 	Server   string
@@ -19,21 +24,61 @@ type Config struct {
 	Database string
 	User     string
 	Password string
+	Pool     PoolConfig
+}
+
+// ============================================================
+// PoolConfig - Cấu hình pool kết nối (đọc từ biến môi trường, xem PoolConfigFromEnv)
+// Lambda: mỗi execution environment chỉ xử lý một request tại một thời điểm nên
+// pool nhỏ là đủ; giữ kết nối idle lâu hơn khoảng giữa hai request để request sau
+// không phải bắt tay TCP/TLS/auth lại. ConnMaxIdleTime phải nhỏ hơn wait_timeout
+// của MySQL (hoặc idle timeout của RDS Proxy) để không dùng phải kết nối đã bị server đóng
+// ============================================================
+type PoolConfig struct {
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	ConnMaxIdleTime time.Duration
+	WarmConns       int // Số kết nối mở sẵn lúc khởi tạo (0 = chỉ kết nối của lệnh ping)
+}
+
+// PoolConfigFromEnv - DB_MAX_OPEN_CONNS (25), DB_MAX_IDLE_CONNS (5),
+// DB_CONN_MAX_LIFETIME (5m), DB_CONN_MAX_IDLE_TIME (3m), DB_WARM_CONNS (0)
+func PoolConfigFromEnv() PoolConfig {
+	return PoolConfig{
+		MaxOpenConns:    getEnvInt("DB_MAX_OPEN_CONNS", 25),
+		MaxIdleConns:    getEnvInt("DB_MAX_IDLE_CONNS", 5),
+		ConnMaxLifetime: getEnvDuration("DB_CONN_MAX_LIFETIME", 5*time.Minute),
+		ConnMaxIdleTime: getEnvDuration("DB_CONN_MAX_IDLE_TIME", 3*time.Minute),
+		WarmConns:       getEnvInt("DB_WARM_CONNS", 0),
+	}
 }
 
 // InitDB initializes database connection pool
 func InitDB() error {
 	config := Config{
 		Server:   getEnv("DB_SERVER", "127.0.0.1"),
-		Port:     3306,
+		Port:     getEnvInt("DB_PORT", 3306),
 		Database: getEnv("DB_NAME", "FPTEventManagement"),
 		User:     getEnv("DB_USER", "root"),
 		Password: getEnv("DB_PASSWORD", ""),
+		Pool:     PoolConfigFromEnv(),
 	}
 
 	return InitDBWithConfig(config)
 }
 
+// Ensure - InitDB nếu chưa kết nối; gọi được nhiều lần và từ nhiều goroutine
+// Lần khởi tạo lỗi không được ghi nhớ (khác sync.Once) nên lần gọi sau sẽ thử lại
+func Ensure() error {
+	initMu.Lock()
+	defer initMu.Unlock()
+	if db != nil {
+		return nil
+	}
+	return InitDB()
+}
+
 // InitDBWithConfig initializes database with custom config
 func InitDBWithConfig(config Config) error {
 	// Build MySQL DSN: user:password@tcp(host:port)/database?parseTime=true&loc=Asia/Ho_Chi_Minh
@@ -48,24 +93,37 @@ func InitDBWithConfig(config Config) error {
 		config.Database,
 	)
 
-	var err error
-	db, err = sql.Open("mysql", dsn)
+	cfg, err := mysql.ParseDSN(dsn)
+	if err != nil {
+		return fmt.Errorf("failed to parse database DSN: %w", err)
+	}
+	connector, err := mysql.NewConnector(cfg)
 	if err != nil {
 		return fmt.Errorf("failed to open database: %w", err)
 	}
+	// Bọc connector để đo thời gian/số dòng mọi truy vấn (xem trace.go)
+	conn := sql.OpenDB(wrapConnector(connector))
 
-	// Configure connection pool
-	db.SetMaxOpenConns(25)
-	db.SetMaxIdleConns(5)
-	db.SetConnMaxLifetime(5 * time.Minute)
+	// Configure connection pool (Config tạo tay không có Pool → giá trị mặc định)
+	pool := config.Pool
+	if pool.MaxOpenConns <= 0 {
+		pool = PoolConfigFromEnv()
+	}
+	conn.SetMaxOpenConns(pool.MaxOpenConns)
+	conn.SetMaxIdleConns(pool.MaxIdleConns)
+	conn.SetConnMaxLifetime(pool.ConnMaxLifetime)
+	conn.SetConnMaxIdleTime(pool.ConnMaxIdleTime)
 
 	// Test connection
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	if err := db.PingContext(ctx); err != nil {
+	if err := conn.PingContext(ctx); err != nil {
+		conn.Close()
 		return fmt.Errorf("failed to ping database: %w", err)
 	}
+	warmPool(ctx, conn, min(pool.WarmConns, pool.MaxIdleConns))
+	db = conn
 
 	// ✅ Log successful connection with timezone confirmation
 	fmt.Printf("✅ [DB] Connected successfully with timezone: Asia/Ho_Chi_Minh (UTC+7)\n")
@@ -96,6 +154,42 @@ func getEnv(key, fallback string) string {
 	return fallback
 }
 
+// warmPool mở sẵn n kết nối rồi trả về pool (idle) để các request đầu tiên
+// không phải chờ kết nối mới; lỗi chỉ ghi log vì pool vẫn tự mở kết nối khi cần
+func warmPool(ctx context.Context, conn *sql.DB, n int) {
+	if n <= 1 {
+		return
+	}
+	conns := make([]*sql.Conn, 0, n)
+	for i := 0; i < n; i++ {
+		c, err := conn.Conn(ctx)
+		if err != nil {
+			fmt.Printf("⚠️  [DB] Warm-up stopped after %d connections: %v\n", len(conns), err)
+			break
+		}
+		conns = append(conns, c)
+	}
+	for _, c := range conns {
+		c.Close()
+	}
+}
+
+// getEnvDuration gets duration environment variable (vd: "90s", "5m") with fallback
+func getEnvDuration(key string, fallback time.Duration) time.Duration {
+	if d, err := time.ParseDuration(os.Getenv(key)); err == nil && d > 0 {
+		return d
+	}
+	return fallback
+}
+
+// getEnvInt gets integer environment variable with fallback
+func getEnvInt(key string, fallback int) int {
+	if n, err := strconv.Atoi(os.Getenv(key)); err == nil && n > 0 {
+		return n
+	}
+	return fallback
+}
+
 // Transaction helpers
 
 // WithTransaction executes a function within a transaction
@@ -0,0 +1,17 @@
+package db
+
+import (
+	"errors"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// Mã lỗi MySQL: ER_DUP_ENTRY
+const mysqlErrDuplicateEntry = 1062
+
+// IsDuplicateEntry kiểm tra lỗi vi phạm UNIQUE KEY / PRIMARY KEY
+// Dùng để xử lý tranh chấp ghi đồng thời mà DB đã chặn (ví dụ hai người giữ cùng một ghế)
+func IsDuplicateEntry(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlErrDuplicateEntry
+}
@@ -0,0 +1,25 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+func TestIsDuplicateEntry(t *testing.T) {
+	dup := &mysql.MySQLError{Number: 1062, Message: "Duplicate entry '5-12' for key 'UQ_Ticket_Event_ActiveSeat'"}
+	if !IsDuplicateEntry(dup) {
+		t.Error("1062 must be a duplicate entry")
+	}
+	if !IsDuplicateEntry(fmt.Errorf("insert ticket: %w", dup)) {
+		t.Error("wrapped 1062 must be a duplicate entry")
+	}
+	if IsDuplicateEntry(&mysql.MySQLError{Number: 1452}) {
+		t.Error("foreign key error is not a duplicate entry")
+	}
+	if IsDuplicateEntry(errors.New("Duplicate entry")) || IsDuplicateEntry(nil) {
+		t.Error("only MySQL errors are classified")
+	}
+}
@@ -0,0 +1,134 @@
+package db
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ============================================================
+// Query - Ghép câu SELECT có bộ lọc động cho các API danh sách / phân trang
+// Mọi giá trị người dùng đi qua tham số "?"; chỉ chuỗi hằng trong code (cột, điều kiện)
+// được ghép vào SQL. Cột sắp xếp do người dùng chọn phải qua whitelist (OrderByAllowed).
+//
+//	q := db.From(`Ticket t LEFT JOIN Event e ON t.event_id = e.event_id`).
+//		Where("t.user_id = ?", userID).
+//		WhereIf(search != "", "e.title LIKE ?", db.Contains(search)).
+//		WhereIn("t.status", db.Values(statuses)...)
+//	countSQL, countArgs := q.Count()
+//	listSQL, listArgs := q.OrderBy("t.ticket_id DESC").Page(page, limit).Select("t.ticket_id, e.title")
+//
+// ============================================================
+type Query struct {
+	from    string
+	where   []string
+	args    []any
+	orderBy []string
+	limit   int
+	offset  int
+}
+
+// From - Bắt đầu query với mệnh đề FROM (bảng + JOIN, không kèm từ khóa FROM)
+func From(from string) *Query {
+	return &Query{from: from}
+}
+
+// Where - Thêm điều kiện (nối bằng AND), cond được bọc ngoặc nên có thể chứa OR
+func (q *Query) Where(cond string, args ...any) *Query {
+	q.where = append(q.where, "("+cond+")")
+	q.args = append(q.args, args...)
+	return q
+}
+
+// WhereIf - Where chỉ khi ok (bộ lọc tùy chọn)
+func (q *Query) WhereIf(ok bool, cond string, args ...any) *Query {
+	if ok {
+		q.Where(cond, args...)
+	}
+	return q
+}
+
+// WhereIn - column IN (?, ?, ...); danh sách rỗng không khớp dòng nào
+func (q *Query) WhereIn(column string, values ...any) *Query {
+	if len(values) == 0 {
+		return q.Where("1 = 0")
+	}
+	return q.Where(column+" IN (?"+strings.Repeat(", ?", len(values)-1)+")", values...)
+}
+
+// OrderBy - Thêm biểu thức ORDER BY cố định trong code, ví dụ "t.ticket_id DESC"
+func (q *Query) OrderBy(expr string) *Query {
+	q.orderBy = append(q.orderBy, expr)
+	return q
+}
+
+// OrderByAllowed - Sắp xếp theo khóa người dùng gửi (?sort=), chỉ nhận khóa có trong allowed
+// (khóa → cột SQL); khóa lạ dùng cột fallback. tiebreak (thường là khóa chính) được thêm cùng
+// chiều để thứ tự giữa các trang ổn định, bỏ qua nếu trùng cột đã chọn
+func (q *Query) OrderByAllowed(key string, desc bool, allowed map[string]string, fallback, tiebreak string) *Query {
+	column, ok := allowed[key]
+	if !ok {
+		column = fallback
+	}
+	direction := " ASC"
+	if desc {
+		direction = " DESC"
+	}
+	q.OrderBy(column + direction)
+	if tiebreak != "" && tiebreak != column {
+		q.OrderBy(tiebreak + direction)
+	}
+	return q
+}
+
+// Limit - LIMIT / OFFSET (limit <= 0: không giới hạn)
+func (q *Query) Limit(limit, offset int) *Query {
+	q.limit, q.offset = limit, max(offset, 0)
+	return q
+}
+
+// Page - Limit theo số trang (bắt đầu từ 1)
+func (q *Query) Page(page, limit int) *Query {
+	return q.Limit(limit, (page-1)*limit)
+}
+
+// WhereSQL - Mệnh đề " WHERE ..." (rỗng nếu không có điều kiện) và tham số tương ứng
+func (q *Query) WhereSQL() (string, []any) {
+	if len(q.where) == 0 {
+		return "", append([]any(nil), q.args...)
+	}
+	return " WHERE " + strings.Join(q.where, " AND "), append([]any(nil), q.args...)
+}
+
+// Count - SELECT COUNT(*) với cùng FROM / WHERE (bỏ ORDER BY và LIMIT)
+func (q *Query) Count() (string, []any) {
+	where, args := q.WhereSQL()
+	return "SELECT COUNT(*) FROM " + q.from + where, args
+}
+
+// Select - Câu SELECT đầy đủ với danh sách cột
+func (q *Query) Select(columns string) (string, []any) {
+	where, args := q.WhereSQL()
+	var sb strings.Builder
+	sb.WriteString("SELECT " + columns + " FROM " + q.from + where)
+	if len(q.orderBy) > 0 {
+		sb.WriteString(" ORDER BY " + strings.Join(q.orderBy, ", "))
+	}
+	if q.limit > 0 {
+		sb.WriteString(" LIMIT " + strconv.Itoa(q.limit) + " OFFSET " + strconv.Itoa(q.offset))
+	}
+	return sb.String(), args
+}
+
+// Values - []T → []any để truyền vào WhereIn(column, db.Values(ids)...)
+func Values[T any](values []T) []any {
+	out := make([]any, len(values))
+	for i, v := range values {
+		out[i] = v
+	}
+	return out
+}
+
+// Contains - Tham số cho "col LIKE ?" tìm chuỗi con; %, _ và \ trong s được tìm đúng nghĩa đen
+func Contains(s string) string {
+	return "%" + strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s) + "%"
+}
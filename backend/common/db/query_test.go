@@ -0,0 +1,63 @@
+package db
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestQuerySelectAndCount(t *testing.T) {
+	q := From("Bill b").
+		Where("b.user_id = ?", 5).
+		WhereIf(false, "b.payment_status = ?", "PAID").
+		WhereIf(true, "b.payment_method = ? OR b.payment_method IS NULL", "VNPAY").
+		WhereIn("b.bill_id", Values([]int{1, 2, 3})...)
+
+	countSQL, countArgs := q.Count()
+	wantWhere := " WHERE (b.user_id = ?) AND (b.payment_method = ? OR b.payment_method IS NULL) AND (b.bill_id IN (?, ?, ?))"
+	if countSQL != "SELECT COUNT(*) FROM Bill b"+wantWhere {
+		t.Errorf("count = %q", countSQL)
+	}
+	wantArgs := []any{5, "VNPAY", 1, 2, 3}
+	if !reflect.DeepEqual(countArgs, wantArgs) {
+		t.Errorf("count args = %v", countArgs)
+	}
+
+	listSQL, listArgs := q.OrderBy("b.created_at DESC").Page(3, 10).Select("b.bill_id")
+	if listSQL != "SELECT b.bill_id FROM Bill b"+wantWhere+" ORDER BY b.created_at DESC LIMIT 10 OFFSET 20" {
+		t.Errorf("select = %q", listSQL)
+	}
+	if !reflect.DeepEqual(listArgs, wantArgs) {
+		t.Errorf("select args = %v", listArgs)
+	}
+}
+
+func TestQueryEmptyInMatchesNothing(t *testing.T) {
+	where, args := From("Ticket t").WhereIn("t.ticket_id").WhereSQL()
+	if where != " WHERE (1 = 0)" || len(args) != 0 {
+		t.Errorf("empty IN = %q %v", where, args)
+	}
+}
+
+func TestOrderByAllowed(t *testing.T) {
+	allowed := map[string]string{"name": "u.full_name", "id": "t.ticket_id"}
+	for _, tc := range []struct {
+		key  string
+		desc bool
+		want string
+	}{
+		{"name", true, "SELECT 1 FROM T ORDER BY u.full_name DESC, t.ticket_id DESC"},
+		{"id", false, "SELECT 1 FROM T ORDER BY t.ticket_id ASC"},
+		{"1; DROP TABLE Users", false, "SELECT 1 FROM T ORDER BY t.ticket_id ASC"},
+	} {
+		got, _ := From("T").OrderByAllowed(tc.key, tc.desc, allowed, "t.ticket_id", "t.ticket_id").Select("1")
+		if got != tc.want {
+			t.Errorf("sort %q = %q, want %q", tc.key, got, tc.want)
+		}
+	}
+}
+
+func TestContainsEscapesWildcards(t *testing.T) {
+	if got := Contains(`50%_a\b`); got != `%50\%\_a\\b%` {
+		t.Errorf("Contains = %q", got)
+	}
+}
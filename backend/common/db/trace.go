@@ -0,0 +1,415 @@
+package db
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"log"
+	"reflect"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fpt-event-services/common/metrics"
+	"github.com/fpt-event-services/common/tracing"
+)
+
+// ============================================================
+// Query tracing - Bọc driver.Connector để đo mọi truy vấn:
+//   - thời gian, số dòng, hàm gọi (repository) → histogram ở GET /metrics
+//   - truy vấn chậm hơn ngưỡng (DB_SLOW_QUERY_MS, mặc định 200ms) được log,
+//     tham số chỉ in kiểu/độ dài (không lộ dữ liệu người dùng)
+// ============================================================
+
+// defaultSlowQueryThreshold - Ngưỡng log truy vấn chậm nếu không cấu hình
+const defaultSlowQueryThreshold = 200 * time.Millisecond
+
+var (
+	queryDuration = metrics.NewHistogram("db_query_duration_seconds",
+		"Duration of database queries by operation and calling function.",
+		metrics.DefaultDurationBuckets, "op", "caller")
+	queryRows = metrics.NewHistogram("db_query_rows",
+		"Rows returned (SELECT) or affected (INSERT/UPDATE/DELETE) per query.",
+		[]float64{0, 1, 5, 10, 50, 100, 500, 1000, 5000}, "op", "caller")
+	queryErrors = metrics.NewCounter("db_query_errors_total",
+		"Database queries that returned an error.", "op", "caller")
+)
+
+// tracer ghi nhận kết quả của từng truy vấn
+type tracer struct {
+	slowThreshold time.Duration
+}
+
+func newTracer() *tracer {
+	threshold := defaultSlowQueryThreshold
+	if v := getEnv("DB_SLOW_QUERY_MS", ""); v != "" {
+		if ms, err := strconv.Atoi(v); err == nil && ms > 0 {
+			threshold = time.Duration(ms) * time.Millisecond
+		}
+	}
+	return &tracer{slowThreshold: threshold}
+}
+
+// queryTrace - Một truy vấn đang chạy
+type queryTrace struct {
+	t        *tracer
+	query    string
+	args     []driver.NamedValue
+	caller   string
+	started  time.Time
+	duration time.Duration
+	span     *tracing.Span
+}
+
+// start ghi nhận truy vấn bắt đầu lúc started và mở client span con của span trong ctx
+func (t *tracer) start(ctx context.Context, query string, args []driver.NamedValue, started time.Time) *queryTrace {
+	q := &queryTrace{t: t, query: query, args: args, caller: callerName(), started: started}
+	op := queryOperation(query)
+	_, q.span = tracing.Start(ctx, "db."+op,
+		tracing.WithKind(tracing.SpanKindClient),
+		tracing.WithStartTime(started),
+		tracing.WithAttributes(
+			tracing.String("db.system", "mysql"),
+			tracing.String("db.operation", op),
+			tracing.String("db.statement", compactQuery(query)), // Chỉ có placeholder ?, không có giá trị
+			tracing.String("code.function", q.caller),
+		))
+	return q
+}
+
+// finish ghi metric và log nếu chậm; rows < 0 nghĩa là không xác định
+func (q *queryTrace) finish(rows int64, err error) {
+	if q.duration == 0 {
+		q.duration = time.Since(q.started)
+	}
+	op := queryOperation(q.query)
+
+	queryDuration.Observe(q.duration.Seconds(), op, q.caller)
+	if rows >= 0 {
+		queryRows.Observe(float64(rows), op, q.caller)
+	}
+	if err != nil && err != driver.ErrSkip {
+		queryErrors.Inc(op, q.caller)
+		q.span.RecordError(err)
+	}
+	if rows >= 0 {
+		q.span.SetAttributes(tracing.Int("db.rows", int(rows)))
+	}
+	q.span.End()
+
+	if q.duration >= q.t.slowThreshold {
+		log.Printf("[DB_SLOW] %dms rows=%d caller=%s query=%s args=%s",
+			q.duration.Milliseconds(), rows, q.caller, compactQuery(q.query), redactArgs(q.args))
+	}
+}
+
+// queryOperation - Loại câu lệnh (select/insert/update/delete/other)
+func queryOperation(query string) string {
+	q := strings.TrimLeft(query, " \t\r\n(")
+	if i := strings.IndexAny(q, " \t\r\n"); i > 0 {
+		q = q[:i]
+	}
+	switch strings.ToLower(q) {
+	case "select", "with":
+		return "select"
+	case "insert", "replace":
+		return "insert"
+	case "update":
+		return "update"
+	case "delete":
+		return "delete"
+	}
+	return "other"
+}
+
+// compactQuery gộp khoảng trắng để log một dòng
+func compactQuery(query string) string {
+	q := strings.Join(strings.Fields(query), " ")
+	if len(q) > 500 {
+		q = q[:500] + "..."
+	}
+	return q
+}
+
+// redactArgs chỉ giữ kiểu và độ dài của tham số
+func redactArgs(args []driver.NamedValue) string {
+	parts := make([]string, len(args))
+	for i, arg := range args {
+		switch v := arg.Value.(type) {
+		case nil:
+			parts[i] = "<nil>"
+		case string:
+			parts[i] = fmt.Sprintf("<string len=%d>", len(v))
+		case []byte:
+			parts[i] = fmt.Sprintf("<bytes len=%d>", len(v))
+		case time.Time:
+			parts[i] = "<time>"
+		default:
+			parts[i] = "<" + reflect.TypeOf(v).String() + ">"
+		}
+	}
+	return "[" + strings.Join(parts, ", ") + "]"
+}
+
+// callerName - Hàm đầu tiên ngoài database/sql và package db gọi truy vấn
+// (vd: "repository.(*EventRepository).GetOpenEvents")
+func callerName() string {
+	pcs := make([]uintptr, 32)
+	n := runtime.Callers(3, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := frames.Next()
+		fn := frame.Function
+		if fn != "" && !isInternalFrame(fn) {
+			if i := strings.LastIndex(fn, "/"); i >= 0 {
+				fn = fn[i+1:]
+			}
+			return fn
+		}
+		if !more {
+			return "unknown"
+		}
+	}
+}
+
+func isInternalFrame(fn string) bool {
+	return strings.HasPrefix(fn, "database/sql.") ||
+		strings.HasPrefix(fn, "runtime.") ||
+		strings.HasPrefix(fn, "github.com/fpt-event-services/common/db.")
+}
+
+// ============================================================
+// driver wrappers
+// ============================================================
+
+type tracedConnector struct {
+	driver.Connector
+	tracer *tracer
+}
+
+func wrapConnector(c driver.Connector) driver.Connector {
+	return &tracedConnector{Connector: c, tracer: newTracer()}
+}
+
+func (c *tracedConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.Connector.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &tracedConn{Conn: conn, tracer: c.tracer}, nil
+}
+
+type tracedConn struct {
+	driver.Conn
+	tracer *tracer
+}
+
+func (c *tracedConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	queryer, ok := c.Conn.(driver.QueryerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	started := time.Now()
+	rows, err := queryer.QueryContext(ctx, query, args)
+	if err == driver.ErrSkip {
+		// database/sql sẽ chuyển sang Prepare + stmt.Query (được đo ở tracedStmt)
+		return nil, err
+	}
+	trace := c.tracer.start(ctx, query, args, started)
+	if err != nil {
+		trace.finish(-1, err)
+		return nil, err
+	}
+	trace.duration = time.Since(trace.started)
+	return &tracedRows{Rows: rows, trace: trace}, nil
+}
+
+func (c *tracedConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	execer, ok := c.Conn.(driver.ExecerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	started := time.Now()
+	result, err := execer.ExecContext(ctx, query, args)
+	if err == driver.ErrSkip {
+		return nil, err
+	}
+	trace := c.tracer.start(ctx, query, args, started)
+	trace.finish(rowsAffected(result), err)
+	return result, err
+}
+
+func (c *tracedConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	var stmt driver.Stmt
+	var err error
+	if preparer, ok := c.Conn.(driver.ConnPrepareContext); ok {
+		stmt, err = preparer.PrepareContext(ctx, query)
+	} else {
+		stmt, err = c.Conn.Prepare(query)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &tracedStmt{Stmt: stmt, query: query, tracer: c.tracer}, nil
+}
+
+func (c *tracedConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if beginner, ok := c.Conn.(driver.ConnBeginTx); ok {
+		return beginner.BeginTx(ctx, opts)
+	}
+	return c.Conn.Begin()
+}
+
+func (c *tracedConn) Ping(ctx context.Context) error {
+	if pinger, ok := c.Conn.(driver.Pinger); ok {
+		return pinger.Ping(ctx)
+	}
+	return nil
+}
+
+func (c *tracedConn) ResetSession(ctx context.Context) error {
+	if resetter, ok := c.Conn.(driver.SessionResetter); ok {
+		return resetter.ResetSession(ctx)
+	}
+	return nil
+}
+
+func (c *tracedConn) IsValid() bool {
+	if validator, ok := c.Conn.(driver.Validator); ok {
+		return validator.IsValid()
+	}
+	return true
+}
+
+func (c *tracedConn) CheckNamedValue(nv *driver.NamedValue) error {
+	if checker, ok := c.Conn.(driver.NamedValueChecker); ok {
+		return checker.CheckNamedValue(nv)
+	}
+	return driver.ErrSkip
+}
+
+type tracedStmt struct {
+	driver.Stmt
+	query  string
+	tracer *tracer
+}
+
+func (s *tracedStmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	execer, ok := s.Stmt.(driver.StmtExecContext)
+	if !ok {
+		return nil, fmt.Errorf("db: driver statement does not support ExecContext")
+	}
+	trace := s.tracer.start(ctx, s.query, args, time.Now())
+	result, err := execer.ExecContext(ctx, args)
+	trace.finish(rowsAffected(result), err)
+	return result, err
+}
+
+func (s *tracedStmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	queryer, ok := s.Stmt.(driver.StmtQueryContext)
+	if !ok {
+		return nil, fmt.Errorf("db: driver statement does not support QueryContext")
+	}
+	trace := s.tracer.start(ctx, s.query, args, time.Now())
+	rows, err := queryer.QueryContext(ctx, args)
+	if err != nil {
+		trace.finish(-1, err)
+		return nil, err
+	}
+	trace.duration = time.Since(trace.started)
+	return &tracedRows{Rows: rows, trace: trace}, nil
+}
+
+func (s *tracedStmt) CheckNamedValue(nv *driver.NamedValue) error {
+	if checker, ok := s.Stmt.(driver.NamedValueChecker); ok {
+		return checker.CheckNamedValue(nv)
+	}
+	return driver.ErrSkip
+}
+
+// tracedRows đếm số dòng đã đọc, ghi metric khi Close
+type tracedRows struct {
+	driver.Rows
+	trace  *queryTrace
+	count  int64
+	closed bool
+}
+
+func (r *tracedRows) Next(dest []driver.Value) error {
+	err := r.Rows.Next(dest)
+	if err == nil {
+		r.count++
+	}
+	return err
+}
+
+func (r *tracedRows) Close() error {
+	err := r.Rows.Close()
+	if !r.closed {
+		r.closed = true
+		r.trace.finish(r.count, nil)
+	}
+	return err
+}
+
+func (r *tracedRows) HasNextResultSet() bool {
+	if rs, ok := r.Rows.(driver.RowsNextResultSet); ok {
+		return rs.HasNextResultSet()
+	}
+	return false
+}
+
+func (r *tracedRows) NextResultSet() error {
+	if rs, ok := r.Rows.(driver.RowsNextResultSet); ok {
+		return rs.NextResultSet()
+	}
+	return io.EOF
+}
+
+func (r *tracedRows) ColumnTypeScanType(index int) reflect.Type {
+	if ct, ok := r.Rows.(driver.RowsColumnTypeScanType); ok {
+		return ct.ColumnTypeScanType(index)
+	}
+	return reflect.TypeOf(new(any)).Elem()
+}
+
+func (r *tracedRows) ColumnTypeDatabaseTypeName(index int) string {
+	if ct, ok := r.Rows.(driver.RowsColumnTypeDatabaseTypeName); ok {
+		return ct.ColumnTypeDatabaseTypeName(index)
+	}
+	return ""
+}
+
+func (r *tracedRows) ColumnTypeNullable(index int) (nullable, ok bool) {
+	if ct, isCT := r.Rows.(driver.RowsColumnTypeNullable); isCT {
+		return ct.ColumnTypeNullable(index)
+	}
+	return false, false
+}
+
+func (r *tracedRows) ColumnTypeLength(index int) (length int64, ok bool) {
+	if ct, isCT := r.Rows.(driver.RowsColumnTypeLength); isCT {
+		return ct.ColumnTypeLength(index)
+	}
+	return 0, false
+}
+
+func (r *tracedRows) ColumnTypePrecisionScale(index int) (precision, scale int64, ok bool) {
+	if ct, isCT := r.Rows.(driver.RowsColumnTypePrecisionScale); isCT {
+		return ct.ColumnTypePrecisionScale(index)
+	}
+	return 0, 0, false
+}
+
+func rowsAffected(result driver.Result) int64 {
+	if result == nil {
+		return -1
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return -1
+	}
+	return n
+}
@@ -0,0 +1,45 @@
+package db
+
+import (
+	"database/sql/driver"
+	"testing"
+	"time"
+)
+
+func TestQueryOperation(t *testing.T) {
+	cases := map[string]string{
+		"\n\t\tSELECT * FROM Event":            "select",
+		"(SELECT 1) UNION (SELECT 2)":          "select",
+		"WITH x AS (SELECT 1) SELECT * FROM x": "select",
+		"INSERT INTO Ticket VALUES (?)":        "insert",
+		"update Event SET status = ?":          "update",
+		"DELETE FROM Ticket":                   "delete",
+		"DO RELEASE_LOCK(?)":                   "other",
+	}
+	for query, want := range cases {
+		if got := queryOperation(query); got != want {
+			t.Errorf("queryOperation(%q) = %q, want %q", query, got, want)
+		}
+	}
+}
+
+func TestRedactArgsHidesValues(t *testing.T) {
+	got := redactArgs([]driver.NamedValue{
+		{Value: "student@fpt.edu.vn"},
+		{Value: int64(42)},
+		{Value: nil},
+		{Value: time.Now()},
+		{Value: []byte("secret")},
+	})
+	want := "[<string len=18>, <int64>, <nil>, <time>, <bytes len=6>]"
+	if got != want {
+		t.Fatalf("redactArgs = %s, want %s", got, want)
+	}
+}
+
+func TestCallerNameSkipsDBPackage(t *testing.T) {
+	// Gọi trực tiếp từ test: frame đầu tiên ngoài package db là testing runner
+	if got := callerName(); got != "testing.tRunner" {
+		t.Fatalf("callerName() = %q, want testing.tRunner", got)
+	}
+}
@@ -0,0 +1,76 @@
+package dbscan
+
+import (
+	"database/sql"
+	"time"
+)
+
+// ============================================================
+// DBSCAN - Đích Scan cho cột có thể NULL, thay cho cặp
+// "var x sql.NullString ... if x.Valid { item.X = &x.String }"
+//
+// Quy ước trong repository:
+//   - Field con trỏ (*string, *int, *float64, *time.Time): Scan thẳng &item.X,
+//     database/sql gán nil khi NULL và tự cấp phát khi có giá trị
+//   - Field thường cần giá trị zero khi NULL: dbscan.OrZero(&item.X)
+//   - DATETIME trả ra dạng chuỗi RFC3339: dbscan.RFC3339 / dbscan.RFC3339Ptr
+//
+//	rows.Scan(&item.EventID, &item.Description, dbscan.OrZero(&item.MaxSeats), dbscan.RFC3339(&item.StartTime))
+//
+// Chuyển kiểu (int64 → int, DECIMAL []byte → float64, ...) giống database/sql
+// ============================================================
+
+type zeroScanner[T any] struct{ dst *T }
+
+func (s zeroScanner[T]) Scan(src any) error {
+	var v sql.Null[T]
+	if err := v.Scan(src); err != nil {
+		return err
+	}
+	*s.dst = v.V
+	return nil
+}
+
+// OrZero - Scan cột NULL-able vào field thường; NULL → giá trị zero ("" / 0 / false)
+func OrZero[T any](dst *T) sql.Scanner {
+	return zeroScanner[T]{dst: dst}
+}
+
+type rfc3339Scanner struct{ dst *string }
+
+func (s rfc3339Scanner) Scan(src any) error {
+	var t sql.NullTime
+	if err := t.Scan(src); err != nil {
+		return err
+	}
+	*s.dst = ""
+	if t.Valid {
+		*s.dst = t.Time.Format(time.RFC3339)
+	}
+	return nil
+}
+
+// RFC3339 - Scan DATETIME vào field string dạng RFC3339 (NULL → "")
+func RFC3339(dst *string) sql.Scanner {
+	return rfc3339Scanner{dst: dst}
+}
+
+type rfc3339PtrScanner struct{ dst **string }
+
+func (s rfc3339PtrScanner) Scan(src any) error {
+	var t sql.NullTime
+	if err := t.Scan(src); err != nil {
+		return err
+	}
+	*s.dst = nil
+	if t.Valid {
+		formatted := t.Time.Format(time.RFC3339)
+		*s.dst = &formatted
+	}
+	return nil
+}
+
+// RFC3339Ptr - Như RFC3339 nhưng cho field *string (NULL → nil)
+func RFC3339Ptr(dst **string) sql.Scanner {
+	return rfc3339PtrScanner{dst: dst}
+}
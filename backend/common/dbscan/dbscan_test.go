@@ -0,0 +1,31 @@
+package dbscan
+
+import (
+	"testing"
+	"time"
+)
+
+func TestOrZero(t *testing.T) {
+	maxSeats := 7
+	if err := OrZero(&maxSeats).Scan(nil); err != nil || maxSeats != 0 {
+		t.Fatalf("NULL → %d, %v; want 0", maxSeats, err)
+	}
+	status := ""
+	if err := OrZero(&status).Scan([]byte("OPEN")); err != nil || status != "OPEN" {
+		t.Fatalf("[]byte → %q, %v", status, err)
+	}
+	if err := OrZero(&maxSeats).Scan([]byte("abc")); err == nil {
+		t.Error("non-numeric value must fail")
+	}
+}
+
+func TestRFC3339(t *testing.T) {
+	s := "stale"
+	if err := RFC3339(&s).Scan(nil); err != nil || s != "" {
+		t.Fatalf("NULL → %q, %v", s, err)
+	}
+	at := time.Date(2026, 3, 1, 9, 0, 0, 0, time.FixedZone("ICT", 7*3600))
+	if err := RFC3339(&s).Scan(at); err != nil || s != "2026-03-01T09:00:00+07:00" {
+		t.Fatalf("time → %q, %v", s, err)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"net/smtp"
 	"net/url"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -43,7 +44,7 @@ func DefaultConfig() *Config {
 type EmailService struct {
 	config    *Config
 	devMode   bool
-	templates map[string]*template.Template
+	templates *TemplateStore
 }
 
 func NewEmailService(config *Config) *EmailService {
@@ -54,7 +55,7 @@ func NewEmailService(config *Config) *EmailService {
 	return &EmailService{
 		config:    config,
 		devMode:   devMode,
-		templates: make(map[string]*template.Template),
+		templates: DefaultTemplates(),
 	}
 }
 
@@ -68,12 +69,13 @@ type EmailMessage struct {
 	Body        string
 	HTMLBody    string
 	Attachments []Attachment
+	MessageID   string // Header Message-ID, dùng để đối chiếu bounce/complaint từ nhà cung cấp
 }
 
 type Attachment struct {
-	Filename string
-	Data     []byte
-	MimeType string
+	Filename string `json:"filename"`
+	Data     []byte `json:"data"`
+	MimeType string `json:"mimeType"`
 }
 
 type TicketEmailData struct {
@@ -93,6 +95,7 @@ type TicketEmailData struct {
 	PaymentMethod string
 	PDFAttachment []byte
 	PDFFilename   string
+	Sponsors      []SponsorLogo // Nhà tài trợ GOLD in logo cuối email
 }
 
 type MultipleTicketsEmailData struct {
@@ -107,6 +110,7 @@ type MultipleTicketsEmailData struct {
 	TotalAmount    string
 	GoogleMapsURL  string
 	PDFAttachments []PDFAttachment
+	Sponsors       []SponsorLogo
 }
 
 type PDFAttachment struct {
@@ -114,6 +118,38 @@ type PDFAttachment struct {
 	Data     []byte
 }
 
+// ReceiptEmailData - Biên lai thanh toán của một hóa đơn (số tiền tính bằng đồng, đã gồm VAT)
+type ReceiptEmailData struct {
+	UserEmail     string
+	UserName      string
+	BillID        int
+	PaidAt        time.Time
+	PaymentMethod string // VNPay / Wallet
+	PaymentRef    string // Mã giao dịch VNPay hoặc mã bút toán ví
+	OrderRef      string // vnp_TxnRef (rỗng với thanh toán ví)
+	Items         []ReceiptItem
+	Subtotal      int64 // Tiền trước thuế
+	VATBps        int
+	VATAmount     int64
+	Total         int64
+}
+
+// ReceiptItem - Một vé trên biên lai
+type ReceiptItem struct {
+	TicketID   int
+	EventTitle string
+	Category   string
+	Seat       string
+	UnitPrice  int64
+}
+
+// SponsorLogo - Logo nhà tài trợ in trong email vé (WebsiteURL rỗng thì logo không có link)
+type SponsorLogo struct {
+	Name       string
+	LogoURL    string
+	WebsiteURL string
+}
+
 // ============================================================
 // HELPER FUNCTIONS
 // ============================================================
@@ -138,6 +174,11 @@ func formatVND(amount string) string {
 	return string(result)
 }
 
+// formatBps - Thuế suất basis point dạng phần trăm (1000 → "10%", 550 → "5.5%")
+func formatBps(bps int) string {
+	return strconv.FormatFloat(float64(bps)/100, 'f', -1, 64) + "%"
+}
+
 func cleanVietnameseText(text string) string {
 	replacements := map[rune]string{
 		'á': "a", 'à': "a", 'ả': "a", 'ã': "a", 'ạ': "a", 'ă': "a", 'ắ': "a", 'ằ': "a", 'ẳ': "a", 'ẵ': "a", 'ặ': "a", 'â': "a", 'ấ': "a", 'ầ': "a", 'ẩ': "a", 'ẫ': "a", 'ậ': "a",
@@ -156,6 +197,30 @@ func cleanVietnameseText(text string) string {
 	return string(result)
 }
 
+// sponsorStripHTML - Hàng "Sponsored by" với logo nhà tài trợ; rỗng khi không có nhà tài trợ
+func sponsorStripHTML(sponsors []SponsorLogo) string {
+	if len(sponsors) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString(`<tr><td align="center" style="padding:0 40px 30px 40px;"><p style="margin:0 0 12px 0;font-size:12px;color:#999999;text-transform:uppercase;">Sponsored by</p>`)
+	for _, sp := range sponsors {
+		img := fmt.Sprintf(`<img src="%s" alt="%s" height="40" style="height:40px;max-width:140px;margin:0 10px;border:0;"/>`,
+			template.HTMLEscapeString(sp.LogoURL), template.HTMLEscapeString(cleanVietnameseText(sp.Name)))
+		if sp.WebsiteURL != "" {
+			img = fmt.Sprintf(`<a href="%s">%s</a>`, template.HTMLEscapeString(sp.WebsiteURL), img)
+		}
+		b.WriteString(img)
+	}
+	b.WriteString(`</td></tr>`)
+	return b.String()
+}
+
+// mapSearchURL - Link Google Maps tìm theo địa chỉ
+func mapSearchURL(address string) string {
+	return "https://www.google.com/maps/search/?api=1&query=" + url.QueryEscape(address)
+}
+
 func getEnv(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
 		return value
@@ -177,7 +242,11 @@ func (s *EmailService) Send(msg EmailMessage) error {
 	auth := smtp.PlainAuth("", s.config.Username, s.config.Password, s.config.Host)
 	var body bytes.Buffer
 	boundary := fmt.Sprintf("boundary_%d", time.Now().UnixNano())
-	body.WriteString(fmt.Sprintf("From: %s <%s>\r\nTo: %s\r\nSubject: %s\r\nMIME-Version: 1.0\r\nContent-Type: multipart/mixed; boundary=%s\r\n\r\n", s.config.FromName, s.config.From, strings.Join(msg.To, ", "), msg.Subject, boundary))
+	body.WriteString(fmt.Sprintf("From: %s <%s>\r\nTo: %s\r\nSubject: %s\r\n", s.config.FromName, s.config.From, strings.Join(msg.To, ", "), msg.Subject))
+	if msg.MessageID != "" {
+		body.WriteString(fmt.Sprintf("Message-ID: <%s>\r\n", msg.MessageID))
+	}
+	body.WriteString(fmt.Sprintf("MIME-Version: 1.0\r\nContent-Type: multipart/mixed; boundary=%s\r\n\r\n", boundary))
 	body.WriteString(fmt.Sprintf("--%s\r\nContent-Type: text/html; charset=UTF-8\r\n\r\n%s\r\n", boundary, msg.HTMLBody))
 	for _, att := range msg.Attachments {
 		body.WriteString(fmt.Sprintf("--%s\r\nContent-Type: %s; name=\"%s\"\r\nContent-Transfer-Encoding: base64\r\nContent-Disposition: attachment; filename=\"%s\"\r\n\r\n%s\r\n", boundary, att.MimeType, att.Filename, att.Filename, base64.StdEncoding.EncodeToString(att.Data)))
@@ -191,79 +260,147 @@ func (s *EmailService) Send(msg EmailMessage) error {
 // ============================================================
 
 func (s *EmailService) SendTicketEmail(data TicketEmailData) error {
+	return s.Send(s.BuildTicketEmail(data))
+}
+
+// BuildTicketEmail dựng email vé đơn (dùng chung cho gửi trực tiếp và hàng đợi)
+func (s *EmailService) BuildTicketEmail(data TicketEmailData) EmailMessage {
 	data.UserName, data.EventTitle, data.VenueName, data.VenueAddress = cleanVietnameseText(data.UserName), cleanVietnameseText(data.EventTitle), cleanVietnameseText(data.VenueName), cleanVietnameseText(data.VenueAddress)
-	data.TotalAmount = formatVND(data.TotalAmount)
-	html := s.buildTicketEmailHTML(data)
-	msg := EmailMessage{To: []string{data.UserEmail}, Subject: fmt.Sprintf("[FPT Event] E-Ticket - %s", data.EventTitle), HTMLBody: html}
+	rendered := s.templates.Render(TemplateTicket, map[string]any{
+		"UserName": data.UserName, "EventTitle": data.EventTitle, "TicketIDs": data.TicketIDs,
+		"VenueName": data.VenueName, "VenueAddress": data.VenueAddress, "StartTime": data.StartTime,
+		"TotalAmount": formatVND(data.TotalAmount), "MapURL": mapSearchURL(data.VenueAddress),
+		"SponsorsHTML": template.HTML(sponsorStripHTML(data.Sponsors)),
+	})
+	msg := EmailMessage{To: []string{data.UserEmail}, Subject: rendered.Subject, HTMLBody: rendered.HTML}
 	if len(data.PDFAttachment) > 0 {
 		msg.Attachments = []Attachment{{Filename: "ticket.pdf", Data: data.PDFAttachment, MimeType: "application/pdf"}}
 	}
-	return s.Send(msg)
-}
-
-func (s *EmailService) buildTicketEmailHTML(data TicketEmailData) string {
-	mapURL := "https://www.google.com/maps/search/?api=1&query=" + url.QueryEscape(data.VenueAddress)
-	return fmt.Sprintf(`<!DOCTYPE html><html><body style="margin:0;padding:0;font-family:Arial,sans-serif;background-color:#f5f5f5;">
-    <table width="100%%" border="0" cellspacing="0" cellpadding="0" bgcolor="#f5f5f5"><tr><td align="center" style="padding:40px 0;">
-    <table width="600" border="0" cellspacing="0" cellpadding="0" bgcolor="#ffffff" style="border-radius:16px;overflow:hidden;box-shadow:0 4px 15px rgba(0,0,0,0.1);">
-    <tr><td height="8" bgcolor="#F27124" style="line-height:8px;font-size:8px;">&nbsp;</td></tr>
-    <tr><td align="left" style="padding:35px 40px;"><h1 style="margin:0;color:#F27124;font-size:24px;font-weight:bold;letter-spacing:1px;">FPT EVENT SYSTEM</h1></td></tr>
-    <tr><td style="padding:10px 40px 40px 40px;"><p style="font-size:18px;color:#666666;margin:0 0 10px 0;">Registration confirmed</p>
-    <h2 style="font-size:32px;font-weight:bold;color:#000000;margin:0 0 30px 0;line-height:1.2;">%s</h2>
-    <p>Hello <strong>%s</strong>, your payment was successful. Details below:</p>
-    <table width="100%%" border="0" cellpadding="15" bgcolor="#fafafa" style="margin-bottom:20px;border-left:4px solid #F27124;">
-    <tr><td><small style="color:#999999;text-transform:uppercase;">Ticket ID</small><br/><strong>#%s</strong></td></tr>
-    <tr><td><small style="color:#999999;text-transform:uppercase;">Location</small><br/><strong>%s</strong><br/><small>%s</small></td></tr>
-    <tr><td><small style="color:#999999;text-transform:uppercase;">Date & Time</small><br/><strong>%s</strong></td></tr>
-    <tr><td><small style="color:#999999;text-transform:uppercase;">Total Amount</small><br/><strong style="color:#F27124;font-size:22px;">%s VND</strong></td></tr>
-    </table>
-    <table width="100%%" bgcolor="#FFF8E1" style="border:1px solid #FFE082;border-radius:8px;margin-bottom:30px;"><tr><td style="padding:15px;"><strong>This email contains 1 PDF file.</strong> Please present the QR code at the entrance.</td></tr></table>
-    <table border="0" cellspacing="0" cellpadding="0"><tr><td bgcolor="#F27124" style="border-radius:50px;padding:15px 35px;"><a href="%s" style="color:#ffffff;text-decoration:none;font-weight:bold;">VIEW ON MAP</a></td></tr></table>
-    </td></tr><tr><td align="center" bgcolor="#2c2c2c" style="padding:25px;"><p style="margin:0;font-size:12px;color:#999999;">© 2026 FPT Event Management. All rights reserved.</p></td></tr></table></td></tr></table></body></html>`,
-		data.EventTitle, data.UserName, data.TicketIDs, data.VenueName, data.VenueAddress, data.StartTime, data.TotalAmount, mapURL)
+	return msg
 }
 
 func (s *EmailService) SendMultipleTicketsEmail(data MultipleTicketsEmailData) error {
+	return s.Send(s.BuildMultipleTicketsEmail(data))
+}
+
+// BuildMultipleTicketsEmail dựng email nhiều vé (mỗi vé 1 PDF)
+func (s *EmailService) BuildMultipleTicketsEmail(data MultipleTicketsEmailData) EmailMessage {
 	data.UserName, data.EventTitle, data.VenueName, data.VenueAddress = cleanVietnameseText(data.UserName), cleanVietnameseText(data.EventTitle), cleanVietnameseText(data.VenueName), cleanVietnameseText(data.VenueAddress)
-	data.TotalAmount = formatVND(data.TotalAmount)
-	mapURL := "https://www.google.com/maps/search/?api=1&query=" + url.QueryEscape(data.VenueAddress)
-	html := fmt.Sprintf(`<!DOCTYPE html><html><body style="margin:0;padding:0;font-family:Arial,sans-serif;background-color:#f5f5f5;">
-    <table width="100%%" border="0" cellspacing="0" cellpadding="0" bgcolor="#f5f5f5"><tr><td align="center" style="padding:40px 0;">
-    <table width="600" border="0" cellspacing="0" cellpadding="0" bgcolor="#ffffff" style="border-radius:16px;overflow:hidden;box-shadow:0 4px 15px rgba(0,0,0,0.1);">
-    <tr><td height="8" bgcolor="#F27124" style="line-height:8px;font-size:8px;">&nbsp;</td></tr>
-    <tr><td align="left" style="padding:35px 40px;"><h1 style="margin:0;color:#F27124;font-size:24px;font-weight:bold;letter-spacing:1px;">FPT EVENT SYSTEM</h1></td></tr>
-    <tr><td style="padding:10px 40px 40px 40px;"><p style="font-size:18px;color:#666666;margin:0 0 10px 0;">Registration confirmed</p><h2 style="font-size:32px;font-weight:bold;color:#000000;margin:0 0 30px 0;">%s</h2>
-    <p>Hello <strong>%s</strong>, you have <strong>%d tickets</strong> for this event.</p>
-    <table width="100%%" border="0" cellpadding="15" bgcolor="#fafafa" style="margin-bottom:20px;border-left:4px solid #F27124;">
-    <tr><td><small style="color:#999999;text-transform:uppercase;">SEATS</small><br/><strong>%s</strong></td></tr>
-    <tr><td><small style="color:#999999;text-transform:uppercase;">LOCATION</small><br/><strong>%s</strong><br/><small>%s</small></td></tr>
-    <tr><td><small style="color:#999999;text-transform:uppercase;">DATE & TIME</small><br/><strong>%s</strong></td></tr>
-    <tr><td><small style="color:#999999;text-transform:uppercase;">TOTAL AMOUNT</small><br/><strong style="color:#F27124;font-size:22px;">%s VND</strong></td></tr>
-    </table>
-    <table width="100%%" bgcolor="#FFF8E1" style="border:1px solid #FFE082;border-radius:8px;margin-bottom:30px;"><tr><td style="padding:15px;"><strong>This email contains %d PDF files.</strong></td></tr></table>
-    <table border="0" cellspacing="0" cellpadding="0"><tr><td bgcolor="#F27124" style="border-radius:50px;padding:15px 35px;"><a href="%s" style="color:#ffffff;text-decoration:none;font-weight:bold;">VIEW ON MAP</a></td></tr></table>
-    </td></tr><tr><td align="center" bgcolor="#2c2c2c" style="padding:25px;"><p style="margin:0;font-size:12px;color:#999999;">© 2026 FPT Event Management. All rights reserved.</p></td></tr></table></td></tr></table></body></html>`,
-		data.EventTitle, data.UserName, data.TicketCount, data.SeatList, data.VenueName, data.VenueAddress, data.EventDate, data.TotalAmount, data.TicketCount, mapURL)
-	msg := EmailMessage{To: []string{data.UserEmail}, Subject: fmt.Sprintf("[FPT Event] %d E-Tickets - %s", data.TicketCount, data.EventTitle), HTMLBody: html}
+	rendered := s.templates.Render(TemplateMultipleTickets, map[string]any{
+		"UserName": data.UserName, "EventTitle": data.EventTitle, "EventDate": data.EventDate,
+		"TicketCount": data.TicketCount, "SeatList": data.SeatList,
+		"VenueName": data.VenueName, "VenueAddress": data.VenueAddress,
+		"TotalAmount": formatVND(data.TotalAmount), "MapURL": mapSearchURL(data.VenueAddress),
+		"SponsorsHTML": template.HTML(sponsorStripHTML(data.Sponsors)),
+	})
+	msg := EmailMessage{To: []string{data.UserEmail}, Subject: rendered.Subject, HTMLBody: rendered.HTML}
 	for _, att := range data.PDFAttachments {
 		msg.Attachments = append(msg.Attachments, Attachment{Filename: att.Filename, Data: att.Data, MimeType: "application/pdf"})
 	}
-	return s.Send(msg)
+	return msg
 }
 
 func (s *EmailService) SendOTPEmail(to, otp, purpose string) error {
-	var subject, title string
+	return s.Send(s.BuildOTPEmail(to, otp, purpose))
+}
+
+// BuildOTPEmail dựng email mã OTP theo mục đích (register, forgot_password)
+func (s *EmailService) BuildOTPEmail(to, otp, purpose string) EmailMessage {
+	var label, title string
 	switch purpose {
 	case "register":
-		subject, title = "FPT Event - Account Verification", "WELCOME TO FPT EVENT"
+		label, title = "Account Verification", "WELCOME TO FPT EVENT"
 	case "forgot_password":
-		subject, title = "FPT Event - Password Reset", "PASSWORD RESET"
+		label, title = "Password Reset", "PASSWORD RESET"
 	default:
-		subject, title = "FPT Event - Verification Code", "VERIFICATION CODE"
+		label, title = "Verification Code", "VERIFICATION CODE"
+	}
+	rendered := s.templates.Render(TemplateOTP, map[string]any{"OTP": otp, "Purpose": purpose, "Title": title, "Label": label})
+	return EmailMessage{To: []string{to}, Subject: rendered.Subject, HTMLBody: rendered.HTML}
+}
+
+// BuildSpeakerInvitationEmail dựng email mời diễn giả liên kết tài khoản (link chứa token một lần)
+func (s *EmailService) BuildSpeakerInvitationEmail(to, speakerName, eventTitle, acceptURL string, expiresAt time.Time) EmailMessage {
+	rendered := s.templates.Render(TemplateSpeakerInvite, map[string]any{
+		"SpeakerName": cleanVietnameseText(speakerName), "EventTitle": cleanVietnameseText(eventTitle),
+		"AcceptURL": acceptURL, "ExpiresAt": expiresAt.Format("02/01/2006 15:04"),
+	})
+	return EmailMessage{To: []string{to}, Subject: rendered.Subject, HTMLBody: rendered.HTML}
+}
+
+// BuildRaffleWinnerEmail dựng email báo trúng thưởng bốc thăm trong sự kiện
+func (s *EmailService) BuildRaffleWinnerEmail(to, fullName, eventTitle, prize string, ticketID int) EmailMessage {
+	rendered := s.templates.Render(TemplateRaffleWinner, map[string]any{
+		"FullName": cleanVietnameseText(fullName), "EventTitle": cleanVietnameseText(eventTitle), "Prize": prize, "TicketID": ticketID,
+	})
+	return EmailMessage{To: []string{to}, Subject: rendered.Subject, HTMLBody: rendered.HTML}
+}
+
+// BuildGuestLookupEmail dựng email mã tra cứu vé cho khách mua vé không có tài khoản
+func (s *EmailService) BuildGuestLookupEmail(to, fullName, eventTitle, code, lookupURL string, expiresAt time.Time) EmailMessage {
+	rendered := s.templates.Render(TemplateGuestLookup, map[string]any{
+		"FullName": cleanVietnameseText(fullName), "EventTitle": cleanVietnameseText(eventTitle),
+		"Code": code, "LookupURL": lookupURL, "ExpiresAt": expiresAt.Format("02/01/2006 15:04"),
+	})
+	return EmailMessage{To: []string{to}, Subject: rendered.Subject, HTMLBody: rendered.HTML}
+}
+
+// BuildCheckinAlertEmail dựng email báo số check-in đã chạm ngưỡng % sức chứa của sự kiện
+func (s *EmailService) BuildCheckinAlertEmail(to, fullName, eventTitle string, threshold, checkedIn, capacity int, reachedAt time.Time, dashboardURL string) EmailMessage {
+	rendered := s.templates.Render(TemplateCheckinAlert, map[string]any{
+		"FullName": cleanVietnameseText(fullName), "EventTitle": cleanVietnameseText(eventTitle), "Threshold": threshold,
+		"CheckedIn": checkedIn, "Capacity": capacity, "ReachedAt": reachedAt.Format("02/01/2006 15:04"), "DashboardURL": dashboardURL,
+	})
+	return EmailMessage{To: []string{to}, Subject: rendered.Subject, HTMLBody: rendered.HTML}
+}
+
+// BuildReceiptEmail dựng biên lai thanh toán: phương thức, mã giao dịch, từng vé và dòng thuế
+func (s *EmailService) BuildReceiptEmail(data ReceiptEmailData) EmailMessage {
+	items := make([]map[string]any, len(data.Items))
+	for i, item := range data.Items {
+		items[i] = map[string]any{
+			"TicketID": item.TicketID, "EventTitle": cleanVietnameseText(item.EventTitle), "Category": cleanVietnameseText(item.Category),
+			"Seat": item.Seat, "UnitPrice": formatVND(strconv.FormatInt(item.UnitPrice, 10)),
+		}
 	}
-	html := fmt.Sprintf(`<!DOCTYPE html><html><body style="margin:0;padding:0;font-family:Arial;background-color:#f5f5f5;"><table width="100%%" border="0" cellspacing="0" cellpadding="0" bgcolor="#f5f5f5"><tr><td align="center" style="padding:40px 0;"><table width="600" border="0" cellspacing="0" cellpadding="0" bgcolor="#ffffff" style="border-radius:16px;overflow:hidden;box-shadow:0 4px 15px rgba(0,0,0,0.1);">
-    <tr><td height="8" bgcolor="#F27124" style="line-height:8px;font-size:8px;">&nbsp;</td></tr>
-    <tr><td align="left" style="padding:35px 40px;"><h1 style="margin:0;color:#F27124;font-size:24px;font-weight:bold;">FPT EVENT SYSTEM</h1></td></tr>
-    <tr><td style="padding:10px 40px 40px 40px;"><h2 style="color:#000000;margin:0 0 10px 0;">%s</h2><p>Your OTP code is below. It expires in 5 minutes:</p><table width="100%%" bgcolor="#fafafa" style="border:2px dashed #F27124;border-radius:8px;"><tr><td align="center" style="padding:25px;"><p style="font-size:42px;font-weight:bold;color:#F27124;letter-spacing:10px;margin:0;">%s</p></td></tr></table><p style="margin-top:25px;color:#999999;font-size:13px;">If you did not request this, please ignore this email.</p></td></tr><tr><td align="center" bgcolor="#2c2c2c" style="padding:20px;color:#999999;font-size:12px;">© 2026 FPT Event Management</td></tr></table></td></tr></table></body></html>`, title, otp)
-	return s.Send(EmailMessage{To: []string{to}, Subject: subject, HTMLBody: html})
+	rendered := s.templates.Render(TemplateReceipt, map[string]any{
+		"UserName": cleanVietnameseText(data.UserName), "BillID": data.BillID, "PaidAt": data.PaidAt.Format("02/01/2006 15:04"),
+		"PaymentMethod": data.PaymentMethod, "PaymentRef": data.PaymentRef, "OrderRef": data.OrderRef, "Items": items,
+		"Subtotal": formatVND(strconv.FormatInt(data.Subtotal, 10)), "VATRate": formatBps(data.VATBps),
+		"VATAmount": formatVND(strconv.FormatInt(data.VATAmount, 10)), "Total": formatVND(strconv.FormatInt(data.Total, 10)),
+	})
+	return EmailMessage{To: []string{data.UserEmail}, Subject: rendered.Subject, HTMLBody: rendered.HTML}
+}
+
+// InstallmentEmailData - Nhắc kỳ trả góp sắp đến hạn / quá hạn (số tiền tính bằng đồng)
+type InstallmentEmailData struct {
+	UserEmail  string
+	UserName   string
+	EventTitle string
+	BillID     int
+	Seq        int
+	Amount     int64
+	Remaining  int64
+	DueAt      time.Time
+	Overdue    bool
+	PayURL     string
+}
+
+// BuildInstallmentEmail dựng email nhắc kỳ trả góp; Overdue báo vé đang bị tạm khóa
+func (s *EmailService) BuildInstallmentEmail(data InstallmentEmailData) EmailMessage {
+	rendered := s.templates.Render(TemplateInstallment, map[string]any{
+		"FullName": cleanVietnameseText(data.UserName), "EventTitle": cleanVietnameseText(data.EventTitle), "BillID": data.BillID,
+		"Seq": data.Seq, "Amount": formatVND(strconv.FormatInt(data.Amount, 10)), "Remaining": formatVND(strconv.FormatInt(data.Remaining, 10)),
+		"DueAt": data.DueAt.Format("02/01/2006 15:04"), "Overdue": data.Overdue, "PayURL": data.PayURL,
+	})
+	return EmailMessage{To: []string{data.UserEmail}, Subject: rendered.Subject, HTMLBody: rendered.HTML}
+}
+
+// BuildSeatChangeEmail dựng email báo sự kiện đổi khu vực và ghế mới của người mua
+func (s *EmailService) BuildSeatChangeEmail(to, fullName, eventTitle string, startTime time.Time, oldVenue, newVenue, seatChanges string, needsReassignment bool, ticketsURL string) EmailMessage {
+	rendered := s.templates.Render(TemplateSeatChange, map[string]any{
+		"FullName": cleanVietnameseText(fullName), "EventTitle": cleanVietnameseText(eventTitle), "StartTime": startTime.Format("02/01/2006 15:04"),
+		"OldVenue": oldVenue, "NewVenue": newVenue, "SeatChanges": seatChanges, "NeedsReassignment": needsReassignment, "TicketsURL": ticketsURL,
+	})
+	return EmailMessage{To: []string{to}, Subject: rendered.Subject, HTMLBody: rendered.HTML}
 }
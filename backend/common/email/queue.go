@@ -0,0 +1,564 @@
+package email
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fpt-event-services/common/db"
+	"github.com/fpt-event-services/common/metrics"
+)
+
+// ============================================================
+// EMAIL QUEUE - Hàng đợi bền vững trên bảng Email_Queue
+// Queue.Send ghi email vào DB rồi gửi ngay; lỗi thì worker (job email-queue)
+// gửi lại theo backoff, quá maxAttempts thì chuyển DEAD (dead-letter)
+// ============================================================
+
+// Template - Tên loại email (nhãn metrics, tra cứu cho support)
+const (
+	TemplateTicket          = "ticket"
+	TemplateMultipleTickets = "multiple_tickets"
+	TemplateOTP             = "otp"
+	TemplateSpeakerInvite   = "speaker_invitation"
+	TemplateRaffleWinner    = "raffle_winner"
+	TemplateGuestLookup     = "guest_lookup_code"
+	TemplateCheckinAlert    = "checkin_alert"
+	TemplateSeatChange      = "seat_change"
+	TemplateReceipt         = "payment_receipt"
+	TemplateInstallment     = "installment_reminder"
+)
+
+// Trạng thái Email_Queue.status
+const (
+	QueueStatusPending    = "PENDING"
+	QueueStatusSending    = "SENDING"
+	QueueStatusSent       = "SENT"
+	QueueStatusDead       = "DEAD"
+	QueueStatusBounced    = "BOUNCED"
+	QueueStatusComplained = "COMPLAINED"
+)
+
+// Loại sự kiện giao nhận (Email_Event.event_type)
+const (
+	EventDelivered = "DELIVERED"
+	EventBounce    = "BOUNCE"
+	EventComplaint = "COMPLAINT"
+)
+
+const (
+	defaultMaxAttempts = 5
+	// sendingLease - Email SENDING quá thời hạn này (process chết giữa chừng) được gửi lại
+	sendingLease = 10 * time.Minute
+)
+
+var (
+	sendTotal = metrics.NewCounter("email_send_total",
+		"Email send attempts by template and result (sent, retry, dead).", "template", "result")
+	sendDuration = metrics.NewHistogram("email_send_duration_seconds",
+		"Duration of SMTP send calls by template.", metrics.DefaultDurationBuckets, "template")
+	deliveryEvents = metrics.NewCounter("email_delivery_events_total",
+		"Provider delivery events received by type.", "type")
+)
+
+// Sender gửi một email (EmailService thỏa interface này)
+type Sender interface {
+	Send(msg EmailMessage) error
+}
+
+// QueuedEmail - Một dòng Email_Queue (không kèm nội dung) cho API support
+type QueuedEmail struct {
+	EmailID       int64           `json:"emailId"`
+	MessageID     string          `json:"messageId"`
+	Template      string          `json:"template"`
+	Recipient     string          `json:"recipient"`
+	Subject       string          `json:"subject"`
+	Reference     string          `json:"reference,omitempty"`
+	Status        string          `json:"status"`
+	Attempts      int             `json:"attempts"`
+	MaxAttempts   int             `json:"maxAttempts"`
+	NextAttemptAt *time.Time      `json:"nextAttemptAt,omitempty"`
+	LastError     string          `json:"lastError,omitempty"`
+	CreatedAt     time.Time       `json:"createdAt"`
+	SentAt        *time.Time      `json:"sentAt,omitempty"`
+	Events        []DeliveryEvent `json:"events"`
+}
+
+// DeliveryEvent - Bounce/complaint/delivered do nhà cung cấp báo về
+type DeliveryEvent struct {
+	Type       string    `json:"type"`
+	MessageID  string    `json:"messageId,omitempty"`
+	Recipient  string    `json:"recipient"`
+	BounceType string    `json:"bounceType,omitempty"` // HARD | SOFT
+	Reason     string    `json:"reason,omitempty"`
+	OccurredAt time.Time `json:"occurredAt"`
+}
+
+// QueueFilter - Điều kiện tra cứu email cho support
+type QueueFilter struct {
+	Recipient string
+	Reference string
+	Status    string
+	Limit     int
+}
+
+// QueueStats - Kết quả một lần worker chạy
+type QueueStats struct {
+	Sent    int
+	Retried int
+	Dead    int
+}
+
+// Queue - Hàng đợi email; db == nil thì gửi trực tiếp (không lưu, không retry)
+type Queue struct {
+	db          *sql.DB
+	sender      Sender
+	maxAttempts int
+	domain      string
+}
+
+// NewQueue creates a new email queue
+func NewQueue(conn *sql.DB, sender Sender) *Queue {
+	domain := "fpt-event.local"
+	if _, d, ok := strings.Cut(getEnv("SMTP_FROM", ""), "@"); ok && d != "" {
+		domain = d
+	}
+	return &Queue{db: conn, sender: sender, maxAttempts: defaultMaxAttempts, domain: domain}
+}
+
+var (
+	defaultQueue     *Queue
+	defaultQueueOnce sync.Once
+)
+
+// DefaultQueue trả về hàng đợi dùng chung (DB hiện tại + SMTP theo biến môi trường)
+func DefaultQueue() *Queue {
+	defaultQueueOnce.Do(func() {
+		defaultQueue = NewQueue(db.GetDB(), NewEmailService(nil))
+	})
+	return defaultQueue
+}
+
+// Send lưu email vào hàng đợi rồi gửi ngay một lần
+// Trả về lỗi của lần gửi đầu; email vẫn nằm trong hàng đợi để worker gửi lại
+func (q *Queue) Send(ctx context.Context, template string, msg EmailMessage, reference string) error {
+	if q.db == nil {
+		return q.deliver(template, msg)
+	}
+
+	msg.MessageID = q.newMessageID()
+	attachments, err := json.Marshal(msg.Attachments)
+	if err != nil {
+		return fmt.Errorf("encode attachments: %w", err)
+	}
+
+	// Ghi thẳng ở trạng thái SENDING (attempts = 1) để worker không gửi trùng lần đầu
+	result, err := q.db.ExecContext(ctx, `
+		INSERT INTO Email_Queue
+			(message_id, template, recipient, subject, html_body, attachments, reference,
+			 status, attempts, max_attempts, next_attempt_at)
+		VALUES (?, ?, ?, ?, ?, ?, NULLIF(?, ''), ?, 1, ?, ?)
+	`, msg.MessageID, template, strings.Join(msg.To, ", "), msg.Subject, msg.HTMLBody, string(attachments),
+		reference, QueueStatusSending, q.maxAttempts, time.Now().Add(sendingLease))
+	if err != nil {
+		// Không lưu được hàng đợi: vẫn cố gửi trực tiếp như trước
+		log.Printf("[EMAIL_QUEUE] ⚠️ Failed to enqueue %s email, sending directly: %v", template, err)
+		return q.deliver(template, msg)
+	}
+	emailID, _ := result.LastInsertId()
+
+	sendErr := q.deliver(template, msg)
+	q.finishAttempt(ctx, emailID, template, 1, q.maxAttempts, sendErr)
+	return sendErr
+}
+
+// ProcessDue gửi lại các email đến hạn (job email-queue)
+func (q *Queue) ProcessDue(ctx context.Context, limit int) (QueueStats, error) {
+	var stats QueueStats
+	if q.db == nil {
+		return stats, nil
+	}
+
+	claimed, err := q.claimDue(ctx, limit)
+	if err != nil {
+		return stats, err
+	}
+
+	for _, item := range claimed {
+		var attachments []Attachment
+		if item.attachments != "" {
+			if err := json.Unmarshal([]byte(item.attachments), &attachments); err != nil {
+				log.Printf("[EMAIL_QUEUE] ⚠️ Email #%d has invalid attachments: %v", item.id, err)
+			}
+		}
+		msg := EmailMessage{
+			To:          strings.Split(item.recipient, ", "),
+			Subject:     item.subject,
+			HTMLBody:    item.htmlBody,
+			Attachments: attachments,
+			MessageID:   item.messageID,
+		}
+
+		sendErr := q.deliver(item.template, msg)
+		switch q.finishAttempt(ctx, item.id, item.template, item.attempts, item.maxAttempts, sendErr) {
+		case QueueStatusSent:
+			stats.Sent++
+		case QueueStatusDead:
+			stats.Dead++
+		default:
+			stats.Retried++
+		}
+	}
+	return stats, nil
+}
+
+type claimedEmail struct {
+	id          int64
+	messageID   string
+	template    string
+	recipient   string
+	subject     string
+	htmlBody    string
+	attachments string
+	attempts    int
+	maxAttempts int
+}
+
+// claimDue khóa các email đến hạn (SKIP LOCKED để nhiều instance không gửi trùng),
+// chuyển sang SENDING và tăng attempts trong cùng transaction
+func (q *Queue) claimDue(ctx context.Context, limit int) ([]claimedEmail, error) {
+	tx, err := q.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("begin claim: %w", err)
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, `
+		SELECT email_id, message_id, template, recipient, subject,
+		       COALESCE(html_body, ''), COALESCE(attachments, ''), attempts, max_attempts
+		FROM Email_Queue
+		WHERE status IN (?, ?) AND next_attempt_at <= NOW(6)
+		ORDER BY next_attempt_at
+		LIMIT ?
+		FOR UPDATE SKIP LOCKED
+	`, QueueStatusPending, QueueStatusSending, limit)
+	if err != nil {
+		return nil, fmt.Errorf("query due emails: %w", err)
+	}
+
+	var items []claimedEmail
+	for rows.Next() {
+		var it claimedEmail
+		if err := rows.Scan(&it.id, &it.messageID, &it.template, &it.recipient, &it.subject,
+			&it.htmlBody, &it.attachments, &it.attempts, &it.maxAttempts); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		it.attempts++
+		items = append(items, it)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	leaseUntil := time.Now().Add(sendingLease)
+	for _, it := range items {
+		if _, err := tx.ExecContext(ctx, `
+			UPDATE Email_Queue SET status = ?, attempts = ?, next_attempt_at = ? WHERE email_id = ?
+		`, QueueStatusSending, it.attempts, leaseUntil, it.id); err != nil {
+			return nil, fmt.Errorf("claim email #%d: %w", it.id, err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit claim: %w", err)
+	}
+	return items, nil
+}
+
+// finishAttempt ghi kết quả một lần gửi, trả về trạng thái mới
+func (q *Queue) finishAttempt(ctx context.Context, emailID int64, template string, attempts, maxAttempts int, sendErr error) string {
+	var status string
+	var err error
+	switch {
+	case sendErr == nil:
+		status = QueueStatusSent
+		// Gửi xong thì bỏ nội dung (OTP, PDF vé): chỉ giữ metadata để tra cứu
+		_, err = q.db.ExecContext(ctx, `
+			UPDATE Email_Queue
+			SET status = ?, sent_at = NOW(6), html_body = NULL, attachments = NULL, last_error = NULL
+			WHERE email_id = ?
+		`, status, emailID)
+	case attempts >= maxAttempts:
+		status = QueueStatusDead
+		_, err = q.db.ExecContext(ctx, `
+			UPDATE Email_Queue SET status = ?, last_error = ? WHERE email_id = ?
+		`, status, truncateError(sendErr), emailID)
+		log.Printf("[EMAIL_QUEUE] ☠️ Email #%d (%s) moved to dead-letter after %d attempts: %v", emailID, template, attempts, sendErr)
+	default:
+		status = QueueStatusPending
+		_, err = q.db.ExecContext(ctx, `
+			UPDATE Email_Queue SET status = ?, last_error = ?, next_attempt_at = ? WHERE email_id = ?
+		`, status, truncateError(sendErr), time.Now().Add(retryDelay(attempts)), emailID)
+		log.Printf("[EMAIL_QUEUE] 🔁 Email #%d (%s) attempt %d failed, retry in %s: %v", emailID, template, attempts, retryDelay(attempts), sendErr)
+	}
+	if err != nil {
+		log.Printf("[EMAIL_QUEUE] ⚠️ Failed to update email #%d: %v", emailID, err)
+	}
+
+	switch status {
+	case QueueStatusSent:
+		sendTotal.Inc(template, "sent")
+	case QueueStatusDead:
+		sendTotal.Inc(template, "dead")
+	default:
+		sendTotal.Inc(template, "retry")
+	}
+	return status
+}
+
+func (q *Queue) deliver(template string, msg EmailMessage) error {
+	started := time.Now()
+	err := q.sender.Send(msg)
+	sendDuration.Observe(time.Since(started).Seconds(), template)
+	if q.db == nil {
+		result := "sent"
+		if err != nil {
+			result = "dead"
+		}
+		sendTotal.Inc(template, result)
+	}
+	return err
+}
+
+// retryDelay - Backoff theo số lần đã thử: 1m, 5m, 15m, 1h, tối đa 4h
+func retryDelay(attempts int) time.Duration {
+	delays := []time.Duration{time.Minute, 5 * time.Minute, 15 * time.Minute, time.Hour}
+	if attempts < 1 {
+		return delays[0]
+	}
+	if attempts > len(delays) {
+		return 4 * time.Hour
+	}
+	return delays[attempts-1]
+}
+
+func truncateError(err error) string {
+	msg := err.Error()
+	if len(msg) > 1000 {
+		msg = msg[:1000]
+	}
+	return msg
+}
+
+func (q *Queue) newMessageID() string {
+	b := make([]byte, 12)
+	rand.Read(b)
+	return fmt.Sprintf("%s.%d@%s", hex.EncodeToString(b), time.Now().Unix(), q.domain)
+}
+
+// ============================================================
+// Delivery events & tra cứu cho support
+// ============================================================
+
+// ErrEmailNotRetryable - Chỉ email DEAD (còn nội dung) mới gửi lại được
+var ErrEmailNotRetryable = errors.New("email cannot be retried")
+
+// RecordEvent lưu sự kiện giao nhận từ webhook nhà cung cấp
+// Hard bounce / complaint chuyển email tương ứng sang BOUNCED / COMPLAINED
+func (q *Queue) RecordEvent(ctx context.Context, ev DeliveryEvent) error {
+	if q.db == nil {
+		return nil
+	}
+	ev.MessageID = strings.Trim(ev.MessageID, "<> ")
+	if ev.OccurredAt.IsZero() {
+		ev.OccurredAt = time.Now()
+	}
+
+	var emailID sql.NullInt64
+	var recipient string
+	if ev.MessageID != "" {
+		err := q.db.QueryRowContext(ctx, `SELECT email_id, recipient FROM Email_Queue WHERE message_id = ?`, ev.MessageID).
+			Scan(&emailID, &recipient)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("lookup email: %w", err)
+		}
+	}
+	if ev.Recipient == "" {
+		ev.Recipient = recipient
+	}
+	if ev.Recipient == "" {
+		return fmt.Errorf("recipient or known messageId is required")
+	}
+
+	if _, err := q.db.ExecContext(ctx, `
+		INSERT INTO Email_Event (email_id, message_id, recipient, event_type, bounce_type, reason, occurred_at)
+		VALUES (?, NULLIF(?, ''), ?, ?, NULLIF(?, ''), NULLIF(?, ''), ?)
+	`, emailID, ev.MessageID, ev.Recipient, ev.Type, ev.BounceType, ev.Reason, ev.OccurredAt); err != nil {
+		return fmt.Errorf("insert email event: %w", err)
+	}
+	deliveryEvents.Inc(ev.Type)
+
+	newStatus := ""
+	switch {
+	case ev.Type == EventComplaint:
+		newStatus = QueueStatusComplained
+	case ev.Type == EventBounce && ev.BounceType == "HARD":
+		newStatus = QueueStatusBounced
+	}
+	if newStatus != "" && emailID.Valid {
+		if _, err := q.db.ExecContext(ctx, `
+			UPDATE Email_Queue SET status = ?, last_error = COALESCE(NULLIF(?, ''), last_error) WHERE email_id = ?
+		`, newStatus, ev.Reason, emailID.Int64); err != nil {
+			return fmt.Errorf("update email status: %w", err)
+		}
+	}
+	return nil
+}
+
+// List tra cứu email theo người nhận / reference / trạng thái (mới nhất trước), kèm sự kiện giao nhận
+func (q *Queue) List(ctx context.Context, filter QueueFilter) ([]QueuedEmail, error) {
+	if q.db == nil {
+		return []QueuedEmail{}, nil
+	}
+	if filter.Limit <= 0 || filter.Limit > 200 {
+		filter.Limit = 50
+	}
+
+	query := `
+		SELECT email_id, message_id, template, recipient, subject, COALESCE(reference, ''), status,
+		       attempts, max_attempts, next_attempt_at, COALESCE(last_error, ''), created_at, sent_at
+		FROM Email_Queue
+		WHERE 1 = 1`
+	var args []interface{}
+	if filter.Recipient != "" {
+		query += ` AND recipient = ?`
+		args = append(args, filter.Recipient)
+	}
+	if filter.Reference != "" {
+		query += ` AND reference = ?`
+		args = append(args, filter.Reference)
+	}
+	if filter.Status != "" {
+		query += ` AND status = ?`
+		args = append(args, filter.Status)
+	}
+	query += ` ORDER BY created_at DESC, email_id DESC LIMIT ?`
+	args = append(args, filter.Limit)
+
+	rows, err := q.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query email queue: %w", err)
+	}
+	defer rows.Close()
+
+	emails := []QueuedEmail{}
+	index := map[int64]int{}
+	for rows.Next() {
+		var e QueuedEmail
+		var nextAt, sentAt sql.NullTime
+		if err := rows.Scan(&e.EmailID, &e.MessageID, &e.Template, &e.Recipient, &e.Subject, &e.Reference,
+			&e.Status, &e.Attempts, &e.MaxAttempts, &nextAt, &e.LastError, &e.CreatedAt, &sentAt); err != nil {
+			return nil, err
+		}
+		if nextAt.Valid && (e.Status == QueueStatusPending || e.Status == QueueStatusSending) {
+			e.NextAttemptAt = &nextAt.Time
+		}
+		if sentAt.Valid {
+			e.SentAt = &sentAt.Time
+		}
+		e.Events = []DeliveryEvent{}
+		index[e.EmailID] = len(emails)
+		emails = append(emails, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(emails) == 0 {
+		return emails, nil
+	}
+
+	placeholders := make([]string, 0, len(emails))
+	ids := make([]interface{}, 0, len(emails))
+	for _, e := range emails {
+		placeholders = append(placeholders, "?")
+		ids = append(ids, e.EmailID)
+	}
+	evRows, err := q.db.QueryContext(ctx, `
+		SELECT email_id, event_type, COALESCE(message_id, ''), recipient, COALESCE(bounce_type, ''),
+		       COALESCE(reason, ''), occurred_at
+		FROM Email_Event
+		WHERE email_id IN (`+strings.Join(placeholders, ",")+`)
+		ORDER BY occurred_at
+	`, ids...)
+	if err != nil {
+		return nil, fmt.Errorf("query email events: %w", err)
+	}
+	defer evRows.Close()
+	for evRows.Next() {
+		var emailID int64
+		var ev DeliveryEvent
+		if err := evRows.Scan(&emailID, &ev.Type, &ev.MessageID, &ev.Recipient, &ev.BounceType,
+			&ev.Reason, &ev.OccurredAt); err != nil {
+			return nil, err
+		}
+		if i, ok := index[emailID]; ok {
+			emails[i].Events = append(emails[i].Events, ev)
+		}
+	}
+	return emails, evRows.Err()
+}
+
+// StatusCounts đếm email theo trạng thái; due = số email PENDING đã tới hạn gửi (chẩn đoán hàng đợi)
+func (q *Queue) StatusCounts(ctx context.Context) (counts map[string]int, due int, err error) {
+	counts = map[string]int{}
+	if q.db == nil {
+		return counts, 0, nil
+	}
+	rows, err := q.db.QueryContext(ctx, `
+		SELECT status, COUNT(*), COALESCE(SUM(status = ? AND next_attempt_at <= NOW(6)), 0)
+		FROM Email_Queue
+		GROUP BY status
+	`, QueueStatusPending)
+	if err != nil {
+		return nil, 0, fmt.Errorf("count email queue: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var status string
+		var n, dueN int
+		if err := rows.Scan(&status, &n, &dueN); err != nil {
+			return nil, 0, err
+		}
+		counts[status] = n
+		due += dueN
+	}
+	return counts, due, rows.Err()
+}
+
+// Retry đưa email DEAD về PENDING để gửi lại ngay (support thao tác thủ công)
+func (q *Queue) Retry(ctx context.Context, emailID int64) error {
+	if q.db == nil {
+		return ErrEmailNotRetryable
+	}
+	result, err := q.db.ExecContext(ctx, `
+		UPDATE Email_Queue
+		SET status = ?, attempts = 0, next_attempt_at = NOW(6)
+		WHERE email_id = ? AND status = ? AND html_body IS NOT NULL
+	`, QueueStatusPending, emailID, QueueStatusDead)
+	if err != nil {
+		return fmt.Errorf("retry email: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return ErrEmailNotRetryable
+	}
+	return nil
+}
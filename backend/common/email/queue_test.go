@@ -0,0 +1,56 @@
+package email
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+type fakeSender struct {
+	sent []EmailMessage
+	err  error
+}
+
+func (f *fakeSender) Send(msg EmailMessage) error {
+	f.sent = append(f.sent, msg)
+	return f.err
+}
+
+func TestRetryDelay(t *testing.T) {
+	cases := map[int]time.Duration{
+		0: time.Minute,
+		1: time.Minute,
+		2: 5 * time.Minute,
+		3: 15 * time.Minute,
+		4: time.Hour,
+		5: 4 * time.Hour,
+		9: 4 * time.Hour,
+	}
+	for attempts, want := range cases {
+		if got := retryDelay(attempts); got != want {
+			t.Errorf("retryDelay(%d) = %s, want %s", attempts, got, want)
+		}
+	}
+}
+
+func TestQueueSendWithoutDB(t *testing.T) {
+	sender := &fakeSender{}
+	q := NewQueue(nil, sender)
+
+	msg := EmailMessage{To: []string{"a@fpt.edu.vn"}, Subject: "OTP"}
+	if err := q.Send(context.Background(), TemplateOTP, msg, "otp:register"); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if len(sender.sent) != 1 || sender.sent[0].Subject != "OTP" {
+		t.Fatalf("expected message to be delivered directly, got %+v", sender.sent)
+	}
+
+	sender.err = errors.New("smtp down")
+	if err := q.Send(context.Background(), TemplateOTP, msg, ""); err == nil {
+		t.Error("expected send error to be returned when there is no queue")
+	}
+	if err := q.Retry(context.Background(), 1); !errors.Is(err, ErrEmailNotRetryable) {
+		t.Errorf("Retry without db = %v, want ErrEmailNotRetryable", err)
+	}
+}
@@ -0,0 +1,434 @@
+package email
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"log"
+	"strings"
+	"sync"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/fpt-event-services/common/db"
+)
+
+// ============================================================
+// TEMPLATE STORE - Mẫu email admin sửa được (bảng email_template_version, migration 039)
+// Mỗi lần lưu tạo phiên bản mới và kích hoạt ngay; rollback = kích hoạt lại phiên bản cũ
+// (version 0 = quay về mẫu mặc định trong code). Phiên bản active được cache templateCacheTTL
+// trên mỗi process. Khi gửi email, phiên bản trong DB lỗi (parse / execute / DB không đọc được)
+// thì log cảnh báo và dùng mẫu mặc định, không bao giờ làm hỏng việc gửi email.
+// ============================================================
+
+var (
+	ErrUnknownTemplate         = errors.New("unknown email template")
+	ErrTemplateVersionNotFound = errors.New("email template version not found")
+	ErrInvalidTemplate         = errors.New("invalid email template")
+)
+
+const (
+	// templateCacheTTL - Process khác thấy phiên bản mới chậm nhất sau khoảng này
+	templateCacheTTL = time.Minute
+	// maxTemplateHTMLSize - Giới hạn kích thước HTML một phiên bản
+	maxTemplateHTMLSize = 256 * 1024
+	maxTemplateSubject  = 255
+)
+
+// TemplateVersion - Một phiên bản mẫu đã lưu
+type TemplateVersion struct {
+	Version   int       `json:"version"`
+	Subject   string    `json:"subject"`
+	HTML      string    `json:"html"`
+	Active    bool      `json:"active"`
+	Note      *string   `json:"note,omitempty"`
+	CreatedBy *int      `json:"createdBy,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// TemplateSummary - Một dòng trong danh sách mẫu (activeVersion nil = đang dùng mẫu mặc định)
+type TemplateSummary struct {
+	Key           string     `json:"key"`
+	Description   string     `json:"description"`
+	ActiveVersion *int       `json:"activeVersion,omitempty"`
+	LatestVersion int        `json:"latestVersion"`
+	UpdatedAt     *time.Time `json:"updatedAt,omitempty"`
+}
+
+// TemplateDetail - Mẫu mặc định, nội dung đang dùng và lịch sử phiên bản (mới nhất trước)
+type TemplateDetail struct {
+	TemplateDefinition
+	ActiveVersion *int              `json:"activeVersion,omitempty"`
+	Subject       string            `json:"subject"`
+	HTML          string            `json:"html"`
+	Versions      []TemplateVersion `json:"versions"`
+}
+
+// PreviewRequest - Nội dung cần xem trước: Version (0 = mặc định) hoặc bản nháp Subject / HTML;
+// trường để trống lấy từ phiên bản đang dùng
+type PreviewRequest struct {
+	Version *int   `json:"version,omitempty"`
+	Subject string `json:"subject,omitempty"`
+	HTML    string `json:"html,omitempty"`
+}
+
+// RenderedEmail - Kết quả render với dữ liệu mẫu
+type RenderedEmail struct {
+	Subject string `json:"subject"`
+	HTML    string `json:"html"`
+}
+
+type cachedTemplate struct {
+	version  *TemplateVersion // nil = không có phiên bản active
+	loadedAt time.Time
+}
+
+// TemplateStore - Đọc / ghi phiên bản mẫu; db == nil thì luôn dùng mẫu mặc định
+type TemplateStore struct {
+	db    *sql.DB
+	mu    sync.Mutex
+	cache map[string]cachedTemplate
+}
+
+// NewTemplateStore creates a new template store
+func NewTemplateStore(conn *sql.DB) *TemplateStore {
+	return &TemplateStore{db: conn, cache: make(map[string]cachedTemplate)}
+}
+
+var (
+	defaultTemplates     *TemplateStore
+	defaultTemplatesOnce sync.Once
+)
+
+// DefaultTemplates trả về kho mẫu dùng chung (DB hiện tại)
+func DefaultTemplates() *TemplateStore {
+	defaultTemplatesOnce.Do(func() {
+		defaultTemplates = NewTemplateStore(db.GetDB())
+	})
+	return defaultTemplates
+}
+
+// ============================================================
+// RENDERING
+// ============================================================
+
+// renderTemplate - Render subject (text/template) và HTML (html/template) với data
+// Biến không có trong data là lỗi (missingkey=error) để lỗi chính tả không lọt vào email
+func renderTemplate(subject, html string, data map[string]any) (RenderedEmail, error) {
+	subjectTmpl, err := texttemplate.New("subject").Option("missingkey=error").Parse(subject)
+	if err != nil {
+		return RenderedEmail{}, fmt.Errorf("%w: subject: %v", ErrInvalidTemplate, err)
+	}
+	htmlTmpl, err := htmltemplate.New("html").Option("missingkey=error").Parse(html)
+	if err != nil {
+		return RenderedEmail{}, fmt.Errorf("%w: html: %v", ErrInvalidTemplate, err)
+	}
+	var subjectBuf, htmlBuf bytes.Buffer
+	if err := subjectTmpl.Execute(&subjectBuf, data); err != nil {
+		return RenderedEmail{}, fmt.Errorf("%w: subject: %v", ErrInvalidTemplate, err)
+	}
+	if err := htmlTmpl.Execute(&htmlBuf, data); err != nil {
+		return RenderedEmail{}, fmt.Errorf("%w: html: %v", ErrInvalidTemplate, err)
+	}
+	return RenderedEmail{
+		Subject: strings.Join(strings.Fields(subjectBuf.String()), " "),
+		HTML:    htmlBuf.String(),
+	}, nil
+}
+
+// Render - Dựng email key với data: phiên bản active nếu render được, ngược lại mẫu mặc định
+func (s *TemplateStore) Render(key string, data map[string]any) RenderedEmail {
+	def, ok := builtinTemplates[key]
+	if !ok {
+		log.Printf("[EMAIL] ⚠️ Unknown template %q", key)
+		return RenderedEmail{}
+	}
+	if s != nil {
+		active, err := s.active(key)
+		if err != nil {
+			log.Printf("[EMAIL] ⚠️ Failed to load template %s, using built-in default: %v", key, err)
+		} else if active != nil {
+			rendered, err := renderTemplate(active.Subject, active.HTML, data)
+			if err == nil {
+				return rendered
+			}
+			log.Printf("[EMAIL] ⚠️ Template %s v%d is broken, using built-in default: %v", key, active.Version, err)
+		}
+	}
+	rendered, err := renderTemplate(def.DefaultSubject, def.DefaultHTML, data)
+	if err != nil {
+		log.Printf("[EMAIL] ❌ Built-in template %s failed to render: %v", key, err)
+	}
+	return rendered
+}
+
+// active - Phiên bản active của key (qua cache)
+func (s *TemplateStore) active(key string) (*TemplateVersion, error) {
+	s.mu.Lock()
+	cached, ok := s.cache[key]
+	s.mu.Unlock()
+	if ok && time.Since(cached.loadedAt) < templateCacheTTL {
+		return cached.version, nil
+	}
+	if s.db == nil {
+		return nil, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	var v TemplateVersion
+	err := s.db.QueryRowContext(ctx, `
+		SELECT version, subject, html_body, note, created_by, created_at
+		FROM Email_Template_Version
+		WHERE template_key = ? AND is_active = 1
+	`, key).Scan(&v.Version, &v.Subject, &v.HTML, &v.Note, &v.CreatedBy, &v.CreatedAt)
+	var version *TemplateVersion
+	switch {
+	case err == nil:
+		v.Active = true
+		version = &v
+	case !errors.Is(err, sql.ErrNoRows):
+		return nil, err
+	}
+
+	s.mu.Lock()
+	s.cache[key] = cachedTemplate{version: version, loadedAt: time.Now()}
+	s.mu.Unlock()
+	return version, nil
+}
+
+// invalidate - Bỏ cache của key sau khi admin lưu / rollback
+func (s *TemplateStore) invalidate(key string) {
+	s.mu.Lock()
+	delete(s.cache, key)
+	s.mu.Unlock()
+}
+
+// ============================================================
+// ADMIN
+// ============================================================
+
+// List - Mọi loại email kèm phiên bản đang dùng / mới nhất
+func (s *TemplateStore) List(ctx context.Context) ([]TemplateSummary, error) {
+	summaries := make(map[string]*TemplateSummary, len(builtinTemplates))
+	list := make([]TemplateSummary, 0, len(builtinTemplates))
+	for _, def := range BuiltinTemplates() {
+		list = append(list, TemplateSummary{Key: def.Key, Description: def.Description})
+	}
+	for i := range list {
+		summaries[list[i].Key] = &list[i]
+	}
+	if s.db == nil {
+		return list, nil
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT template_key, MAX(version), MAX(CASE WHEN is_active = 1 THEN version END), MAX(created_at)
+		FROM Email_Template_Version
+		GROUP BY template_key
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list email templates: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var key string
+		var latest int
+		var active *int
+		var updatedAt time.Time
+		if err := rows.Scan(&key, &latest, &active, &updatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan email template: %w", err)
+		}
+		if sum, ok := summaries[key]; ok {
+			sum.LatestVersion, sum.ActiveVersion, sum.UpdatedAt = latest, active, &updatedAt
+		}
+	}
+	return list, rows.Err()
+}
+
+// Get - Chi tiết một mẫu và lịch sử phiên bản
+func (s *TemplateStore) Get(ctx context.Context, key string) (*TemplateDetail, error) {
+	def, ok := builtinTemplates[key]
+	if !ok {
+		return nil, ErrUnknownTemplate
+	}
+	detail := &TemplateDetail{
+		TemplateDefinition: def,
+		Subject:            def.DefaultSubject,
+		HTML:               def.DefaultHTML,
+		Versions:           []TemplateVersion{},
+	}
+	if s.db == nil {
+		return detail, nil
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT version, subject, html_body, is_active, note, created_by, created_at
+		FROM Email_Template_Version
+		WHERE template_key = ?
+		ORDER BY version DESC
+	`, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load email template versions: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var v TemplateVersion
+		if err := rows.Scan(&v.Version, &v.Subject, &v.HTML, &v.Active, &v.Note, &v.CreatedBy, &v.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan email template version: %w", err)
+		}
+		if v.Active {
+			detail.ActiveVersion = &v.Version
+			detail.Subject, detail.HTML = v.Subject, v.HTML
+		}
+		detail.Versions = append(detail.Versions, v)
+	}
+	return detail, rows.Err()
+}
+
+// Validate - Subject / HTML hợp lệ: không rỗng, trong giới hạn và render được với dữ liệu mẫu
+func Validate(key, subject, html string) error {
+	def, ok := builtinTemplates[key]
+	if !ok {
+		return ErrUnknownTemplate
+	}
+	if strings.TrimSpace(subject) == "" || strings.TrimSpace(html) == "" {
+		return fmt.Errorf("%w: subject and html are required", ErrInvalidTemplate)
+	}
+	if len(subject) > maxTemplateSubject {
+		return fmt.Errorf("%w: subject is longer than %d characters", ErrInvalidTemplate, maxTemplateSubject)
+	}
+	if len(html) > maxTemplateHTMLSize {
+		return fmt.Errorf("%w: html is larger than %d KB", ErrInvalidTemplate, maxTemplateHTMLSize/1024)
+	}
+	_, err := renderTemplate(subject, html, def.SampleData)
+	return err
+}
+
+// SaveVersion - Lưu phiên bản mới (đã Validate) và kích hoạt ngay
+func (s *TemplateStore) SaveVersion(ctx context.Context, key, subject, html string, note *string, userID int) (*TemplateVersion, error) {
+	if err := Validate(key, subject, html); err != nil {
+		return nil, err
+	}
+	if s.db == nil {
+		return nil, errors.New("database not available")
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Khóa các phiên bản của key để hai admin lưu cùng lúc không trùng số phiên bản
+	var latest int
+	if err := tx.QueryRowContext(ctx, `
+		SELECT COALESCE(MAX(version), 0) FROM Email_Template_Version WHERE template_key = ? FOR UPDATE
+	`, key).Scan(&latest); err != nil {
+		return nil, fmt.Errorf("failed to read latest version: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE Email_Template_Version SET is_active = 0 WHERE template_key = ? AND is_active = 1`, key,
+	); err != nil {
+		return nil, fmt.Errorf("failed to deactivate template: %w", err)
+	}
+	v := TemplateVersion{Version: latest + 1, Subject: subject, HTML: html, Active: true, Note: note, CreatedBy: &userID, CreatedAt: time.Now()}
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO Email_Template_Version (template_key, version, subject, html_body, is_active, note, created_by, created_at)
+		VALUES (?, ?, ?, ?, 1, ?, ?, ?)
+	`, key, v.Version, subject, html, note, userID, v.CreatedAt); err != nil {
+		return nil, fmt.Errorf("failed to insert template version: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit: %w", err)
+	}
+	s.invalidate(key)
+	return &v, nil
+}
+
+// Activate - Rollback: kích hoạt lại phiên bản version (0 = mẫu mặc định trong code)
+func (s *TemplateStore) Activate(ctx context.Context, key string, version int) error {
+	if _, ok := builtinTemplates[key]; !ok {
+		return ErrUnknownTemplate
+	}
+	if version < 0 {
+		return ErrTemplateVersionNotFound
+	}
+	if s.db == nil {
+		return errors.New("database not available")
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if version > 0 {
+		var exists int
+		err := tx.QueryRowContext(ctx, `
+			SELECT 1 FROM Email_Template_Version WHERE template_key = ? AND version = ? FOR UPDATE
+		`, key, version).Scan(&exists)
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrTemplateVersionNotFound
+		}
+		if err != nil {
+			return fmt.Errorf("failed to load template version: %w", err)
+		}
+	}
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE Email_Template_Version SET is_active = (version = ?) WHERE template_key = ?
+	`, version, key); err != nil {
+		return fmt.Errorf("failed to activate template version: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit: %w", err)
+	}
+	s.invalidate(key)
+	return nil
+}
+
+// Preview - Render với dữ liệu mẫu; khác Render, lỗi template được trả về thay vì fallback
+func (s *TemplateStore) Preview(ctx context.Context, key string, req PreviewRequest) (RenderedEmail, error) {
+	def, ok := builtinTemplates[key]
+	if !ok {
+		return RenderedEmail{}, ErrUnknownTemplate
+	}
+	subject, html := def.DefaultSubject, def.DefaultHTML
+	switch {
+	case req.Version != nil && *req.Version < 0:
+		return RenderedEmail{}, ErrTemplateVersionNotFound
+	case req.Version != nil && *req.Version > 0:
+		detail, err := s.Get(ctx, key)
+		if err != nil {
+			return RenderedEmail{}, err
+		}
+		found := false
+		for _, v := range detail.Versions {
+			if v.Version == *req.Version {
+				subject, html, found = v.Subject, v.HTML, true
+			}
+		}
+		if !found {
+			return RenderedEmail{}, ErrTemplateVersionNotFound
+		}
+	case req.Version == nil:
+		active, err := s.active(key)
+		if err != nil {
+			return RenderedEmail{}, fmt.Errorf("failed to load template: %w", err)
+		}
+		if active != nil {
+			subject, html = active.Subject, active.HTML
+		}
+	}
+	if req.Subject != "" {
+		subject = req.Subject
+	}
+	if req.HTML != "" {
+		html = req.HTML
+	}
+	return renderTemplate(subject, html, def.SampleData)
+}
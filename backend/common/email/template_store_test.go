@@ -0,0 +1,75 @@
+package email
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBuiltinTemplatesRenderSampleData(t *testing.T) {
+	for _, def := range BuiltinTemplates() {
+		rendered, err := renderTemplate(def.DefaultSubject, def.DefaultHTML, def.SampleData)
+		if err != nil {
+			t.Errorf("%s: %v", def.Key, err)
+			continue
+		}
+		if rendered.Subject == "" || !strings.Contains(rendered.HTML, "FPT EVENT SYSTEM") {
+			t.Errorf("%s: unexpected output %+v", def.Key, rendered.Subject)
+		}
+	}
+}
+
+func TestRenderFallsBackToBuiltinWhenActiveVersionIsBroken(t *testing.T) {
+	store := NewTemplateStore(nil)
+	store.cache[TemplateRaffleWinner] = cachedTemplate{
+		version:  &TemplateVersion{Version: 2, Subject: "Hi {{.FullName}}", HTML: "<p>{{.Unknown}}</p>"},
+		loadedAt: time.Now(),
+	}
+	data := builtinTemplates[TemplateRaffleWinner].SampleData
+
+	rendered := store.Render(TemplateRaffleWinner, data)
+	if !strings.HasPrefix(rendered.Subject, "[FPT Event] You won the lucky draw") {
+		t.Fatalf("expected built-in subject, got %q", rendered.Subject)
+	}
+
+	store.cache[TemplateRaffleWinner] = cachedTemplate{
+		version:  &TemplateVersion{Version: 3, Subject: "Hi {{.FullName}}", HTML: "<p>{{.Prize}}</p>"},
+		loadedAt: time.Now(),
+	}
+	rendered = store.Render(TemplateRaffleWinner, data)
+	if rendered.Subject != "Hi Nguyen Van A" || rendered.HTML != "<p>Mechanical keyboard</p>" {
+		t.Fatalf("expected custom version, got %+v", rendered)
+	}
+}
+
+func TestRenderEscapesHTMLVariables(t *testing.T) {
+	rendered := NewTemplateStore(nil).Render(TemplateRaffleWinner, map[string]any{
+		"FullName": "<script>x</script>", "EventTitle": "E", "Prize": "P", "TicketID": 1,
+	})
+	if strings.Contains(rendered.HTML, "<script>") {
+		t.Fatal("variables must be HTML-escaped")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	cases := []struct {
+		name    string
+		key     string
+		subject string
+		html    string
+		want    error
+	}{
+		{"ok", TemplateOTP, "Code {{.OTP}}", "<p>{{.OTP}}</p>", nil},
+		{"unknown key", "newsletter", "s", "h", ErrUnknownTemplate},
+		{"empty html", TemplateOTP, "s", "  ", ErrInvalidTemplate},
+		{"parse error", TemplateOTP, "s", "<p>{{.OTP</p>", ErrInvalidTemplate},
+		{"unknown variable", TemplateOTP, "s", "<p>{{.Otp}}</p>", ErrInvalidTemplate},
+	}
+	for _, c := range cases {
+		err := Validate(c.key, c.subject, c.html)
+		if (c.want == nil && err != nil) || (c.want != nil && !errors.Is(err, c.want)) {
+			t.Errorf("%s: got %v, want %v", c.name, err, c.want)
+		}
+	}
+}
@@ -0,0 +1,256 @@
+package email
+
+import (
+	"html/template"
+	"sort"
+)
+
+// ============================================================
+// BUILT-IN TEMPLATES - Mẫu email mặc định (Go template: {{.Bien}})
+// Admin có thể lưu phiên bản khác trong email_template_version (TemplateStore);
+// mẫu ở đây luôn được giữ làm bản dự phòng khi phiên bản trong DB lỗi
+// Subject dùng text/template, HTML dùng html/template (biến được escape tự động)
+// ============================================================
+
+// TemplateDefinition - Một loại email sửa được: mẫu mặc định, biến dùng được và dữ liệu xem trước
+type TemplateDefinition struct {
+	Key            string         `json:"key"`
+	Description    string         `json:"description"`
+	Variables      []string       `json:"variables"`
+	DefaultSubject string         `json:"defaultSubject"`
+	DefaultHTML    string         `json:"defaultHtml"`
+	SampleData     map[string]any `json:"sampleData"`
+}
+
+// Phần đầu / cuối dùng chung của các mẫu mặc định
+const (
+	layoutHeader = `<!DOCTYPE html><html><body style="margin:0;padding:0;font-family:Arial,sans-serif;background-color:#f5f5f5;">
+    <table width="100%" border="0" cellspacing="0" cellpadding="0" bgcolor="#f5f5f5"><tr><td align="center" style="padding:40px 0;">
+    <table width="600" border="0" cellspacing="0" cellpadding="0" bgcolor="#ffffff" style="border-radius:16px;overflow:hidden;box-shadow:0 4px 15px rgba(0,0,0,0.1);">
+    <tr><td height="8" bgcolor="#F27124" style="line-height:8px;font-size:8px;">&nbsp;</td></tr>
+    <tr><td align="left" style="padding:35px 40px;"><h1 style="margin:0;color:#F27124;font-size:24px;font-weight:bold;letter-spacing:1px;">FPT EVENT SYSTEM</h1></td></tr>`
+	layoutFooter = `<tr><td align="center" bgcolor="#2c2c2c" style="padding:20px;color:#999999;font-size:12px;">© 2026 FPT Event Management</td></tr></table></td></tr></table></body></html>`
+	ticketFooter = `<tr><td align="center" bgcolor="#2c2c2c" style="padding:25px;"><p style="margin:0;font-size:12px;color:#999999;">© 2026 FPT Event Management. All rights reserved.</p></td></tr></table></td></tr></table></body></html>`
+)
+
+const defaultTicketHTML = layoutHeader + `
+    <tr><td style="padding:10px 40px 40px 40px;"><p style="font-size:18px;color:#666666;margin:0 0 10px 0;">Registration confirmed</p>
+    <h2 style="font-size:32px;font-weight:bold;color:#000000;margin:0 0 30px 0;line-height:1.2;">{{.EventTitle}}</h2>
+    <p>Hello <strong>{{.UserName}}</strong>, your payment was successful. Details below:</p>
+    <table width="100%" border="0" cellpadding="15" bgcolor="#fafafa" style="margin-bottom:20px;border-left:4px solid #F27124;">
+    <tr><td><small style="color:#999999;text-transform:uppercase;">Ticket ID</small><br/><strong>#{{.TicketIDs}}</strong></td></tr>
+    <tr><td><small style="color:#999999;text-transform:uppercase;">Location</small><br/><strong>{{.VenueName}}</strong><br/><small>{{.VenueAddress}}</small></td></tr>
+    <tr><td><small style="color:#999999;text-transform:uppercase;">Date & Time</small><br/><strong>{{.StartTime}}</strong></td></tr>
+    <tr><td><small style="color:#999999;text-transform:uppercase;">Total Amount</small><br/><strong style="color:#F27124;font-size:22px;">{{.TotalAmount}} VND</strong></td></tr>
+    </table>
+    <table width="100%" bgcolor="#FFF8E1" style="border:1px solid #FFE082;border-radius:8px;margin-bottom:30px;"><tr><td style="padding:15px;"><strong>This email contains 1 PDF file.</strong> Please present the QR code at the entrance.</td></tr></table>
+    <table border="0" cellspacing="0" cellpadding="0"><tr><td bgcolor="#F27124" style="border-radius:50px;padding:15px 35px;"><a href="{{.MapURL}}" style="color:#ffffff;text-decoration:none;font-weight:bold;">VIEW ON MAP</a></td></tr></table>
+    </td></tr>{{.SponsorsHTML}}` + ticketFooter
+
+const defaultMultipleTicketsHTML = layoutHeader + `
+    <tr><td style="padding:10px 40px 40px 40px;"><p style="font-size:18px;color:#666666;margin:0 0 10px 0;">Registration confirmed</p><h2 style="font-size:32px;font-weight:bold;color:#000000;margin:0 0 30px 0;">{{.EventTitle}}</h2>
+    <p>Hello <strong>{{.UserName}}</strong>, you have <strong>{{.TicketCount}} tickets</strong> for this event.</p>
+    <table width="100%" border="0" cellpadding="15" bgcolor="#fafafa" style="margin-bottom:20px;border-left:4px solid #F27124;">
+    <tr><td><small style="color:#999999;text-transform:uppercase;">SEATS</small><br/><strong>{{.SeatList}}</strong></td></tr>
+    <tr><td><small style="color:#999999;text-transform:uppercase;">LOCATION</small><br/><strong>{{.VenueName}}</strong><br/><small>{{.VenueAddress}}</small></td></tr>
+    <tr><td><small style="color:#999999;text-transform:uppercase;">DATE & TIME</small><br/><strong>{{.EventDate}}</strong></td></tr>
+    <tr><td><small style="color:#999999;text-transform:uppercase;">TOTAL AMOUNT</small><br/><strong style="color:#F27124;font-size:22px;">{{.TotalAmount}} VND</strong></td></tr>
+    </table>
+    <table width="100%" bgcolor="#FFF8E1" style="border:1px solid #FFE082;border-radius:8px;margin-bottom:30px;"><tr><td style="padding:15px;"><strong>This email contains {{.TicketCount}} PDF files.</strong></td></tr></table>
+    <table border="0" cellspacing="0" cellpadding="0"><tr><td bgcolor="#F27124" style="border-radius:50px;padding:15px 35px;"><a href="{{.MapURL}}" style="color:#ffffff;text-decoration:none;font-weight:bold;">VIEW ON MAP</a></td></tr></table>
+    </td></tr>{{.SponsorsHTML}}` + ticketFooter
+
+const defaultOTPHTML = layoutHeader + `
+    <tr><td style="padding:10px 40px 40px 40px;"><h2 style="color:#000000;margin:0 0 10px 0;">{{.Title}}</h2><p>Your OTP code is below. It expires in 5 minutes:</p><table width="100%" bgcolor="#fafafa" style="border:2px dashed #F27124;border-radius:8px;"><tr><td align="center" style="padding:25px;"><p style="font-size:42px;font-weight:bold;color:#F27124;letter-spacing:10px;margin:0;">{{.OTP}}</p></td></tr></table><p style="margin-top:25px;color:#999999;font-size:13px;">If you did not request this, please ignore this email.</p></td></tr>` + layoutFooter
+
+const defaultSpeakerInvitationHTML = layoutHeader + `
+    <tr><td style="padding:10px 40px 40px 40px;"><h2 style="color:#000000;margin:0 0 10px 0;">SPEAKER INVITATION</h2><p>Hello <strong>{{.SpeakerName}}</strong>, you are listed as the speaker of <strong>{{.EventTitle}}</strong>.</p><p>Accept the invitation to manage your speaker profile and share session materials with attendees:</p>
+    <table border="0" cellspacing="0" cellpadding="0" style="margin:25px 0;"><tr><td bgcolor="#F27124" style="border-radius:50px;padding:15px 35px;"><a href="{{.AcceptURL}}" style="color:#ffffff;text-decoration:none;font-weight:bold;">ACCEPT INVITATION</a></td></tr></table>
+    <p style="color:#999999;font-size:13px;">This link expires on {{.ExpiresAt}}. If you were not expecting this email, please ignore it.</p></td></tr>` + layoutFooter
+
+const defaultRaffleWinnerHTML = layoutHeader + `
+    <tr><td style="padding:10px 40px 40px 40px;"><h2 style="color:#000000;margin:0 0 10px 0;">CONGRATULATIONS!</h2><p>Hello <strong>{{.FullName}}</strong>, your ticket <strong>#{{.TicketID}}</strong> was drawn in the lucky draw at <strong>{{.EventTitle}}</strong>.</p><table width="100%" bgcolor="#fafafa" style="border:2px dashed #F27124;border-radius:8px;"><tr><td align="center" style="padding:25px;"><p style="font-size:22px;font-weight:bold;color:#F27124;margin:0;">{{.Prize}}</p></td></tr></table><p style="margin-top:25px;">Please bring this ticket to the organizer desk to receive your prize.</p></td></tr>` + layoutFooter
+
+const defaultGuestLookupHTML = layoutHeader + `
+    <tr><td style="padding:10px 40px 40px 40px;"><h2 style="color:#000000;margin:0 0 10px 0;">YOUR TICKET LOOKUP CODE</h2><p>Hello <strong>{{.FullName}}</strong>, thank you for registering for <strong>{{.EventTitle}}</strong>. Your e-tickets were sent in a separate email.</p><p>Use the code below to view your tickets again without an account:</p><table width="100%" bgcolor="#fafafa" style="border:2px dashed #F27124;border-radius:8px;"><tr><td align="center" style="padding:25px;"><p style="font-size:26px;font-weight:bold;color:#F27124;letter-spacing:3px;margin:0;">{{.Code}}</p></td></tr></table>
+    <table border="0" cellspacing="0" cellpadding="0" style="margin:25px 0;"><tr><td bgcolor="#F27124" style="border-radius:50px;padding:15px 35px;"><a href="{{.LookupURL}}" style="color:#ffffff;text-decoration:none;font-weight:bold;">VIEW MY TICKETS</a></td></tr></table>
+    <p style="color:#999999;font-size:13px;">The code is valid until {{.ExpiresAt}}. Keep it private: anyone with the code can see your tickets. Create an account with this email to manage your tickets in the app.</p></td></tr>` + layoutFooter
+
+const defaultCheckinAlertHTML = layoutHeader + `
+    <tr><td style="padding:10px 40px 40px 40px;"><h2 style="color:#000000;margin:0 0 10px 0;">CHECK-IN REACHED {{.Threshold}}%</h2><p>Hello <strong>{{.FullName}}</strong>, check-ins for <strong>{{.EventTitle}}</strong> have reached <strong>{{.Threshold}}%</strong> of capacity.</p><table width="100%" bgcolor="#fafafa" style="border:2px dashed #F27124;border-radius:8px;"><tr><td align="center" style="padding:25px;"><p style="font-size:26px;font-weight:bold;color:#F27124;margin:0;">{{.CheckedIn}} / {{.Capacity}}</p><p style="color:#666666;margin:8px 0 0 0;">attendees checked in at {{.ReachedAt}}</p></td></tr></table>
+    <table border="0" cellspacing="0" cellpadding="0" style="margin:25px 0;"><tr><td bgcolor="#F27124" style="border-radius:50px;padding:15px 35px;"><a href="{{.DashboardURL}}" style="color:#ffffff;text-decoration:none;font-weight:bold;">VIEW EVENT</a></td></tr></table>
+    <p style="color:#999999;font-size:13px;">You receive this alert once per threshold as the event organizer or assigned staff.</p></td></tr>` + layoutFooter
+
+const defaultSeatChangeHTML = layoutHeader + `
+    <tr><td style="padding:10px 40px 40px 40px;"><h2 style="color:#000000;margin:0 0 10px 0;">YOUR SEAT HAS CHANGED</h2><p>Hello <strong>{{.FullName}}</strong>, <strong>{{.EventTitle}}</strong> ({{.StartTime}}) has moved from <strong>{{.OldVenue}}</strong> to <strong>{{.NewVenue}}</strong>.</p><table width="100%" bgcolor="#fafafa" style="border:2px dashed #F27124;border-radius:8px;"><tr><td align="center" style="padding:25px;"><p style="color:#666666;margin:0 0 8px 0;">Your seats</p><p style="font-size:20px;font-weight:bold;color:#F27124;margin:0;">{{.SeatChanges}}</p></td></tr></table>
+    {{if .NeedsReassignment}}<p style="color:#c0392b;">Some of your seats could not be matched in the new room. Our staff will assign them before the event and let you know.</p>{{end}}
+    <table border="0" cellspacing="0" cellpadding="0" style="margin:25px 0;"><tr><td bgcolor="#F27124" style="border-radius:50px;padding:15px 35px;"><a href="{{.TicketsURL}}" style="color:#ffffff;text-decoration:none;font-weight:bold;">VIEW MY TICKETS</a></td></tr></table>
+    <p style="color:#999999;font-size:13px;">Your QR codes stay the same; show them at the new venue.</p></td></tr>` + layoutFooter
+
+const defaultReceiptHTML = layoutHeader + `
+    <tr><td style="padding:10px 40px 40px 40px;"><h2 style="color:#000000;margin:0 0 10px 0;">PAYMENT RECEIPT</h2><p>Hello <strong>{{.UserName}}</strong>, we received your payment. Your e-tickets are sent in a separate email.</p>
+    <table width="100%" border="0" cellpadding="8" bgcolor="#fafafa" style="margin-bottom:20px;border-left:4px solid #F27124;">
+    <tr><td><small style="color:#999999;text-transform:uppercase;">RECEIPT NO.</small><br/><strong>#{{.BillID}}</strong></td><td><small style="color:#999999;text-transform:uppercase;">PAID AT</small><br/><strong>{{.PaidAt}}</strong></td></tr>
+    <tr><td><small style="color:#999999;text-transform:uppercase;">PAYMENT METHOD</small><br/><strong>{{.PaymentMethod}}</strong></td><td><small style="color:#999999;text-transform:uppercase;">TRANSACTION REF.</small><br/><strong>{{.PaymentRef}}</strong>{{if .OrderRef}}<br/><small>Order: {{.OrderRef}}</small>{{end}}</td></tr>
+    </table>
+    <table width="100%" border="0" cellpadding="8" cellspacing="0" style="border-collapse:collapse;font-size:14px;">
+    <tr style="background:#F27124;color:#ffffff;"><th align="left">Ticket</th><th align="left">Event</th><th align="left">Category / Seat</th><th align="right">Amount (VND)</th></tr>
+    {{range .Items}}<tr style="border-bottom:1px solid #eeeeee;"><td>#{{.TicketID}}</td><td>{{.EventTitle}}</td><td>{{.Category}}{{if .Seat}} / {{.Seat}}{{end}}</td><td align="right">{{.UnitPrice}}</td></tr>{{end}}
+    <tr><td colspan="3" align="right">Subtotal (excl. VAT)</td><td align="right">{{.Subtotal}}</td></tr>
+    <tr><td colspan="3" align="right">VAT ({{.VATRate}})</td><td align="right">{{.VATAmount}}</td></tr>
+    <tr><td colspan="3" align="right"><strong>Total paid</strong></td><td align="right"><strong style="color:#F27124;font-size:18px;">{{.Total}}</strong></td></tr>
+    </table>
+    <p style="margin-top:25px;color:#999999;font-size:13px;">Ticket prices include VAT. Keep this email as proof of payment.</p></td></tr>` + layoutFooter
+
+const defaultInstallmentHTML = layoutHeader + `
+    <tr><td style="padding:10px 40px 40px 40px;"><h2 style="color:#000000;margin:0 0 10px 0;">{{if .Overdue}}INSTALLMENT OVERDUE{{else}}INSTALLMENT DUE SOON{{end}}</h2><p>Hello <strong>{{.FullName}}</strong>, installment <strong>{{.Seq}}</strong> of your order <strong>#{{.BillID}}</strong> for <strong>{{.EventTitle}}</strong> {{if .Overdue}}was due on{{else}}is due on{{end}} <strong>{{.DueAt}}</strong>.</p><table width="100%" bgcolor="#fafafa" style="border:2px dashed #F27124;border-radius:8px;"><tr><td align="center" style="padding:25px;"><p style="color:#666666;margin:0 0 8px 0;">Amount due (VND)</p><p style="font-size:26px;font-weight:bold;color:#F27124;margin:0;">{{.Amount}}</p><p style="color:#666666;margin:8px 0 0 0;">Remaining balance: {{.Remaining}}</p></td></tr></table>
+    {{if .Overdue}}<p style="color:#c0392b;">Your tickets for this order are suspended and cannot be checked in until the overdue installments are paid.</p>{{end}}
+    <table border="0" cellspacing="0" cellpadding="0" style="margin:25px 0;"><tr><td bgcolor="#F27124" style="border-radius:50px;padding:15px 35px;"><a href="{{.PayURL}}" style="color:#ffffff;text-decoration:none;font-weight:bold;">PAY NOW</a></td></tr></table>
+    <p style="color:#999999;font-size:13px;">You can pay with your FPT Event wallet or VNPay.</p></td></tr>` + layoutFooter
+
+// sampleSponsorsHTML - Hàng nhà tài trợ mẫu cho xem trước email vé
+var sampleSponsorsHTML = template.HTML(sponsorStripHTML([]SponsorLogo{
+	{Name: "FPT Software", LogoURL: "https://example.com/logos/fpt-software.png", WebsiteURL: "https://example.com"},
+}))
+
+// builtinTemplates - Mẫu mặc định theo template key (khớp hằng Template* của hàng đợi)
+var builtinTemplates = map[string]TemplateDefinition{
+	TemplateTicket: {
+		Key:            TemplateTicket,
+		Description:    "E-ticket for a single-ticket purchase (PDF attached)",
+		Variables:      []string{"UserName", "EventTitle", "TicketIDs", "VenueName", "VenueAddress", "StartTime", "TotalAmount", "MapURL", "SponsorsHTML"},
+		DefaultSubject: `[FPT Event] E-Ticket - {{.EventTitle}}`,
+		DefaultHTML:    defaultTicketHTML,
+		SampleData: map[string]any{
+			"UserName": "Nguyen Van A", "EventTitle": "FPT Tech Day 2026", "TicketIDs": "1024",
+			"VenueName": "FPT University HCMC", "VenueAddress": "Lo E2a-7, D1 Street, Thu Duc City",
+			"StartTime": "20/11/2026 08:00", "TotalAmount": "150.000",
+			"MapURL":       "https://www.google.com/maps/search/?api=1&query=FPT+University+HCMC",
+			"SponsorsHTML": sampleSponsorsHTML,
+		},
+	},
+	TemplateMultipleTickets: {
+		Key:            TemplateMultipleTickets,
+		Description:    "E-tickets for a multi-ticket purchase (one PDF per ticket)",
+		Variables:      []string{"UserName", "EventTitle", "EventDate", "TicketCount", "SeatList", "VenueName", "VenueAddress", "TotalAmount", "MapURL", "SponsorsHTML"},
+		DefaultSubject: `[FPT Event] {{.TicketCount}} E-Tickets - {{.EventTitle}}`,
+		DefaultHTML:    defaultMultipleTicketsHTML,
+		SampleData: map[string]any{
+			"UserName": "Nguyen Van A", "EventTitle": "FPT Tech Day 2026", "EventDate": "20/11/2026 08:00",
+			"TicketCount": 3, "SeatList": "A1, A2, A3",
+			"VenueName": "FPT University HCMC", "VenueAddress": "Lo E2a-7, D1 Street, Thu Duc City",
+			"TotalAmount":  "450.000",
+			"MapURL":       "https://www.google.com/maps/search/?api=1&query=FPT+University+HCMC",
+			"SponsorsHTML": sampleSponsorsHTML,
+		},
+	},
+	TemplateOTP: {
+		Key:            TemplateOTP,
+		Description:    "One-time code for registration and password reset (Purpose: register, forgot_password)",
+		Variables:      []string{"OTP", "Purpose", "Title", "Label"},
+		DefaultSubject: `FPT Event - {{.Label}}`,
+		DefaultHTML:    defaultOTPHTML,
+		SampleData: map[string]any{
+			"OTP": "482913", "Purpose": "register", "Title": "WELCOME TO FPT EVENT", "Label": "Account Verification",
+		},
+	},
+	TemplateSpeakerInvite: {
+		Key:            TemplateSpeakerInvite,
+		Description:    "Invitation for a speaker to link their account (one-time link)",
+		Variables:      []string{"SpeakerName", "EventTitle", "AcceptURL", "ExpiresAt"},
+		DefaultSubject: `[FPT Event] Speaker invitation - {{.EventTitle}}`,
+		DefaultHTML:    defaultSpeakerInvitationHTML,
+		SampleData: map[string]any{
+			"SpeakerName": "Tran Thi B", "EventTitle": "FPT Tech Day 2026",
+			"AcceptURL": "https://example.com/speaker/accept?token=sample", "ExpiresAt": "27/11/2026 23:59",
+		},
+	},
+	TemplateRaffleWinner: {
+		Key:            TemplateRaffleWinner,
+		Description:    "Lucky draw winner notification",
+		Variables:      []string{"FullName", "EventTitle", "Prize", "TicketID"},
+		DefaultSubject: `[FPT Event] You won the lucky draw - {{.EventTitle}}`,
+		DefaultHTML:    defaultRaffleWinnerHTML,
+		SampleData: map[string]any{
+			"FullName": "Nguyen Van A", "EventTitle": "FPT Tech Day 2026", "Prize": "Mechanical keyboard", "TicketID": 1024,
+		},
+	},
+	TemplateGuestLookup: {
+		Key:            TemplateGuestLookup,
+		Description:    "Ticket lookup code for a guest checkout without an account",
+		Variables:      []string{"FullName", "EventTitle", "Code", "LookupURL", "ExpiresAt"},
+		DefaultSubject: `[FPT Event] Your ticket lookup code - {{.EventTitle}}`,
+		DefaultHTML:    defaultGuestLookupHTML,
+		SampleData: map[string]any{
+			"FullName": "Le Van C", "EventTitle": "FPT Tech Day 2026", "Code": "GT-7KQ2-M9XD-4HPA-WR3E",
+			"LookupURL": "https://example.com/guest/tickets?code=GT-7KQ2-M9XD-4HPA-WR3E", "ExpiresAt": "20/05/2027 23:59",
+		},
+	},
+	TemplateCheckinAlert: {
+		Key:            TemplateCheckinAlert,
+		Description:    "Check-in capacity threshold reached (organizer and assigned staff)",
+		Variables:      []string{"FullName", "EventTitle", "Threshold", "CheckedIn", "Capacity", "ReachedAt", "DashboardURL"},
+		DefaultSubject: `[FPT Event] Check-in reached {{.Threshold}}% - {{.EventTitle}}`,
+		DefaultHTML:    defaultCheckinAlertHTML,
+		SampleData: map[string]any{
+			"FullName": "Tran Thi B", "EventTitle": "FPT Tech Day 2026", "Threshold": 80, "CheckedIn": 240, "Capacity": 300,
+			"ReachedAt": "20/11/2026 08:35", "DashboardURL": "https://example.com/dashboard/events/42",
+		},
+	},
+	TemplateSeatChange: {
+		Key:            TemplateSeatChange,
+		Description:    "Seat changes after staff move an event to another area",
+		Variables:      []string{"FullName", "EventTitle", "StartTime", "OldVenue", "NewVenue", "SeatChanges", "NeedsReassignment", "TicketsURL"},
+		DefaultSubject: `[FPT Event] Seat change - {{.EventTitle}}`,
+		DefaultHTML:    defaultSeatChangeHTML,
+		SampleData: map[string]any{
+			"FullName": "Nguyen Van A", "EventTitle": "FPT Tech Day 2026", "StartTime": "20/11/2026 08:00",
+			"OldVenue": "Hall A - FPT University HCMC", "NewVenue": "Hall B - FPT University HCMC",
+			"SeatChanges": "A5 → A5, A6 → B1", "NeedsReassignment": false, "TicketsURL": "https://example.com/my-tickets",
+		},
+	},
+	TemplateReceipt: {
+		Key:            TemplateReceipt,
+		Description:    "Payment receipt with transaction reference, ticket lines and VAT (sent separately from e-tickets)",
+		Variables:      []string{"UserName", "BillID", "PaidAt", "PaymentMethod", "PaymentRef", "OrderRef", "Items", "Subtotal", "VATRate", "VATAmount", "Total"},
+		DefaultSubject: `[FPT Event] Payment receipt #{{.BillID}}`,
+		DefaultHTML:    defaultReceiptHTML,
+		SampleData: map[string]any{
+			"UserName": "Nguyen Van A", "BillID": 1024, "PaidAt": "20/10/2026 14:30",
+			"PaymentMethod": "VNPay", "PaymentRef": "14567890", "OrderRef": "11_7_3_2048,2049_1792480200",
+			"Items": []map[string]any{
+				{"TicketID": 2048, "EventTitle": "FPT Tech Day 2026", "Category": "VIP", "Seat": "A1", "UnitPrice": "180.000"},
+				{"TicketID": 2049, "EventTitle": "FPT Tech Day 2026", "Category": "VIP", "Seat": "A2", "UnitPrice": "150.000"},
+			},
+			"Subtotal": "300.000", "VATRate": "10%", "VATAmount": "30.000", "Total": "330.000",
+		},
+	},
+	TemplateInstallment: {
+		Key:            TemplateInstallment,
+		Description:    "Installment due soon / overdue reminder (overdue tickets are suspended)",
+		Variables:      []string{"FullName", "EventTitle", "BillID", "Seq", "Amount", "Remaining", "DueAt", "Overdue", "PayURL"},
+		DefaultSubject: `[FPT Event] {{if .Overdue}}Overdue{{else}}Upcoming{{end}} installment - {{.EventTitle}}`,
+		DefaultHTML:    defaultInstallmentHTML,
+		SampleData: map[string]any{
+			"FullName": "Nguyen Van A", "EventTitle": "AI Workshop 2026", "BillID": 1024, "Seq": 1,
+			"Amount": "116.667", "Remaining": "233.334", "DueAt": "27/10/2026 14:30", "Overdue": false,
+			"PayURL": "https://example.com/my-bills/1024",
+		},
+	},
+}
+
+// BuiltinTemplates - Danh sách mẫu mặc định theo key (GET /api/admin/email-templates)
+func BuiltinTemplates() []TemplateDefinition {
+	defs := make([]TemplateDefinition, 0, len(builtinTemplates))
+	for _, def := range builtinTemplates {
+		defs = append(defs, def)
+	}
+	sort.Slice(defs, func(i, j int) bool { return defs[i].Key < defs[j].Key })
+	return defs
+}
+
+// LookupTemplate - Mẫu mặc định của key (ok = false nếu key không tồn tại)
+func LookupTemplate(key string) (TemplateDefinition, bool) {
+	def, ok := builtinTemplates[key]
+	return def, ok
+}
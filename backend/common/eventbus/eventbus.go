@@ -0,0 +1,145 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+
+	"github.com/fpt-event-services/common/metrics"
+)
+
+// ============================================================
+// Package eventbus - Bus domain event trong process
+// Repository / usecase Publish sự kiện nghiệp vụ (TicketBooked, EventApproved...)
+// sau khi transaction đã commit; side effect (bộ đếm realtime, xóa cache...)
+// đăng ký Subscribe ở nơi sở hữu side effect đó thay vì gọi thẳng trong nghiệp vụ.
+//
+//	eventbus.On(eventbus.Default, "open-events-cache", eventbus.Sync, func(ctx context.Context, e eventbus.TicketBooked) error {
+//		...
+//	})
+//	eventbus.Default.Publish(ctx, eventbus.TicketBooked{...})
+//
+// Sync: chạy ngay trong Publish theo thứ tự đăng ký, lỗi được gom trả về cho bên publish
+// Async: chạy trong goroutine riêng (context không bị hủy theo request), lỗi chỉ ghi log
+// Panic của subscriber được recover và tính như lỗi, không làm hỏng request
+// Chỉ thấy sự kiện của process hiện tại, không thay cho hàng đợi bền vững (Email_Queue)
+// ============================================================
+
+// Event - Một domain event; Name là khóa để subscriber đăng ký
+type Event interface {
+	EventName() string
+}
+
+// Mode - Cách dispatch một subscriber
+type Mode int
+
+const (
+	Sync Mode = iota
+	Async
+)
+
+// Handler xử lý một sự kiện
+type Handler func(ctx context.Context, event Event) error
+
+type subscription struct {
+	name    string
+	mode    Mode
+	handler Handler
+}
+
+var (
+	publishedTotal = metrics.NewCounter("domain_events_published_total",
+		"Domain events published by event name.", "event")
+	handlerErrors = metrics.NewCounter("domain_event_handler_errors_total",
+		"Domain event subscriber failures by event name and mode.", "event", "mode")
+)
+
+// Bus - Danh sách subscriber theo tên sự kiện
+type Bus struct {
+	mu      sync.RWMutex
+	subs    map[string][]subscription
+	pending sync.WaitGroup
+}
+
+// New creates an empty bus
+func New() *Bus {
+	return &Bus{subs: make(map[string][]subscription)}
+}
+
+// Default - Bus dùng chung của process
+var Default = New()
+
+// Subscribe - Đăng ký handler cho sự kiện có EventName() == eventName
+// name dùng trong log khi handler lỗi
+func (b *Bus) Subscribe(eventName, name string, mode Mode, handler Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.subs[eventName] = append(b.subs[eventName], subscription{name: name, mode: mode, handler: handler})
+}
+
+// On - Subscribe có kiểu: handler nhận đúng kiểu sự kiện E
+func On[E Event](b *Bus, name string, mode Mode, handler func(ctx context.Context, event E) error) {
+	var zero E
+	b.Subscribe(zero.EventName(), name, mode, func(ctx context.Context, event Event) error {
+		e, ok := event.(E)
+		if !ok {
+			return fmt.Errorf("unexpected event type %T for %s", event, zero.EventName())
+		}
+		return handler(ctx, e)
+	})
+}
+
+// Publish - Gửi sự kiện cho mọi subscriber; trả về lỗi gộp của các subscriber Sync
+// Bên publish thường chỉ ghi log lỗi: nghiệp vụ chính đã commit trước khi Publish
+func (b *Bus) Publish(ctx context.Context, event Event) error {
+	name := event.EventName()
+	publishedTotal.Inc(name)
+
+	b.mu.RLock()
+	subs := append([]subscription(nil), b.subs[name]...)
+	b.mu.RUnlock()
+
+	var errs []error
+	for _, sub := range subs {
+		if sub.mode == Async {
+			b.pending.Add(1)
+			go func(sub subscription) {
+				defer b.pending.Done()
+				if err := dispatch(context.WithoutCancel(ctx), sub, event); err != nil {
+					handlerErrors.Inc(name, "async")
+					log.Printf("[EVENTBUS] ⚠️ %s → %s failed: %v", name, sub.name, err)
+				}
+			}(sub)
+			continue
+		}
+		if err := dispatch(ctx, sub, event); err != nil {
+			handlerErrors.Inc(name, "sync")
+			errs = append(errs, fmt.Errorf("%s: %w", sub.name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Wait - Chờ các subscriber Async đang chạy xong (test, tắt process)
+func (b *Bus) Wait() {
+	b.pending.Wait()
+}
+
+// dispatch - Gọi handler, đổi panic thành lỗi
+func dispatch(ctx context.Context, sub subscription, event Event) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return sub.handler(ctx, event)
+}
+
+// Publish - Default.Publish, lỗi subscriber Sync chỉ ghi log
+func Publish(ctx context.Context, event Event) {
+	if err := Default.Publish(ctx, event); err != nil {
+		log.Printf("[EVENTBUS] ⚠️ %s: %v", event.EventName(), err)
+	}
+}
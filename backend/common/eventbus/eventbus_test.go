@@ -0,0 +1,56 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestSyncSubscribersRunInOrderAndErrorsAreJoined(t *testing.T) {
+	bus := New()
+	var calls []string
+	On(bus, "first", Sync, func(_ context.Context, e TicketBooked) error {
+		calls = append(calls, "first")
+		return errors.New("boom")
+	})
+	On(bus, "second", Sync, func(_ context.Context, e TicketBooked) error {
+		calls = append(calls, "second")
+		panic("bad subscriber")
+	})
+	On(bus, "other event", Sync, func(_ context.Context, e EventApproved) error {
+		calls = append(calls, "other")
+		return nil
+	})
+
+	err := bus.Publish(context.Background(), TicketBooked{BillID: 1})
+	if strings.Join(calls, ",") != "first,second" {
+		t.Fatalf("calls = %v", calls)
+	}
+	if err == nil || !strings.Contains(err.Error(), "first: boom") || !strings.Contains(err.Error(), "second: panic") {
+		t.Fatalf("err = %v", err)
+	}
+}
+
+func TestAsyncSubscriberOutlivesCanceledContext(t *testing.T) {
+	bus := New()
+	var got atomic.Int64
+	On(bus, "async", Async, func(ctx context.Context, e ReportResolved) error {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		got.Store(int64(e.ReportID))
+		return errors.New("async errors are only logged")
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := bus.Publish(ctx, ReportResolved{ReportID: 7}); err != nil {
+		t.Fatalf("async failure must not be returned: %v", err)
+	}
+	bus.Wait()
+	if got.Load() != 7 {
+		t.Fatalf("async subscriber did not run with a live context, got %d", got.Load())
+	}
+}
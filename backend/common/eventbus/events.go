@@ -0,0 +1,93 @@
+package eventbus
+
+import "time"
+
+// ============================================================
+// Domain events - Publish sau khi transaction của nghiệp vụ đã commit
+// ============================================================
+
+// TicketBooked - Thanh toán thành công, vé đã BOOKED (ví hoặc VNPay)
+type TicketBooked struct {
+	BillID        int
+	UserID        int
+	EventID       int
+	TicketIDs     []int
+	TotalAmount   float64
+	PaymentMethod string // wallet | vnpay
+	OccurredAt    time.Time
+}
+
+func (TicketBooked) EventName() string { return "TicketBooked" }
+
+// TicketCheckedIn - Staff / organizer check-in vé tại cửa
+type TicketCheckedIn struct {
+	TicketID   int
+	EventID    int
+	OccurredAt time.Time
+}
+
+func (TicketCheckedIn) EventName() string { return "TicketCheckedIn" }
+
+// TicketCheckedOut - Check-out vé khi rời sự kiện
+type TicketCheckedOut struct {
+	TicketID   int
+	EventID    int
+	OccurredAt time.Time
+}
+
+func (TicketCheckedOut) EventName() string { return "TicketCheckedOut" }
+
+// EventApproved - Yêu cầu sự kiện được duyệt, Event đã được tạo (status UPDATING)
+type EventApproved struct {
+	RequestID  int
+	ApprovedBy int
+	OccurredAt time.Time
+}
+
+func (EventApproved) EventName() string { return "EventApproved" }
+
+// EventDisabled - Admin vô hiệu hóa sự kiện, vé PENDING đã được giải phóng
+type EventDisabled struct {
+	EventID         int
+	DisabledBy      int
+	ReleasedPending int64
+	OccurredAt      time.Time
+}
+
+func (EventDisabled) EventName() string { return "EventDisabled" }
+
+// EventCancelled - Organizer hủy sự kiện hoặc rút yêu cầu đã duyệt, Event đã CANCELLED
+type EventCancelled struct {
+	EventID     int
+	RequestID   int // 0 nếu hủy trực tiếp sự kiện
+	CancelledBy int
+	OccurredAt  time.Time
+}
+
+func (EventCancelled) EventName() string { return "EventCancelled" }
+
+// Lý do đóng sự kiện (EventClosed.Reason)
+const (
+	CloseReasonEnded          = "ended"           // Đã kết thúc, job event-archival lưu trữ
+	CloseReasonUpdateDeadline = "update_deadline" // Organizer không hoàn tất trước hạn cập nhật
+)
+
+// EventClosed - Sự kiện chuyển sang CLOSED
+type EventClosed struct {
+	EventID    int
+	Reason     string // CloseReason*
+	OccurredAt time.Time
+}
+
+func (EventClosed) EventName() string { return "EventClosed" }
+
+// ReportResolved - Staff xử lý xong report (Approved = hoàn tiền, vé REFUNDED)
+type ReportResolved struct {
+	ReportID     int
+	StaffID      int
+	Approved     bool
+	RefundAmount *float64
+	OccurredAt   time.Time
+}
+
+func (ReportResolved) EventName() string { return "ReportResolved" }
@@ -0,0 +1,233 @@
+package fixtures
+
+import (
+	"fmt"
+	"time"
+)
+
+// ============================================================
+// FIXTURES - Bộ dữ liệu mẫu cho môi trường local / CI / integration test
+// Venue + khu vực (kèm sơ đồ ghế), tài khoản mỗi role, sự kiện ở mọi trạng thái,
+// vé và hóa đơn. Load chạy lại nhiều lần không tạo trùng (khóa tự nhiên: email, tên...)
+// ============================================================
+
+// DefaultPassword - Mật khẩu của mọi tài khoản seed khi không chỉ định
+const DefaultPassword = "Seed@123456"
+
+// LoadTestEmailPattern - Email tài khoản sinh viên dùng cho cmd/loadgen (%03d = số thứ tự từ 1)
+const LoadTestEmailPattern = "loadgen%03d@seed.fpt.edu.vn"
+
+// Set - Một bộ dữ liệu mẫu
+type Set struct {
+	Users    []User
+	Speakers []Speaker
+	Venues   []Venue
+	Events   []Event
+	Requests []Request // Yêu cầu chưa duyệt / bị từ chối (không gắn sự kiện)
+}
+
+// User - Tài khoản, định danh bằng email
+type User struct {
+	Key      string // Khóa tham chiếu trong Set (không lưu DB)
+	FullName string
+	Email    string
+	Phone    string
+	Role     string // ADMIN, STAFF, ORGANIZER, STUDENT
+	Wallet   float64
+}
+
+// Speaker - Diễn giả, định danh bằng email
+type Speaker struct {
+	Key      string
+	FullName string
+	Bio      string
+	Email    string
+	Phone    string
+}
+
+// Venue - Địa điểm, định danh bằng tên
+type Venue struct {
+	Name     string
+	Location string
+	Areas    []Area
+}
+
+// Area - Khu vực, định danh bằng (venue, tên); ghế sinh theo hàng 10 ghế A1..A10, B1...
+type Area struct {
+	Key      string
+	Name     string
+	Floor    string
+	Capacity int
+}
+
+// Event - Sự kiện, định danh bằng tiêu đề
+// Sự kiện đã tồn tại thì bỏ qua toàn bộ (loại vé, vé, hóa đơn) để không ghi đè dữ liệu đã chỉnh tay
+type Event struct {
+	Key          string
+	Title        string
+	Description  string
+	Status       string        // OPEN, CLOSED, CANCELLED, UPDATING
+	StartIn      time.Duration // Thời điểm bắt đầu so với lúc seed (âm = đã diễn ra)
+	Duration     time.Duration
+	AreaKey      string
+	OrganizerKey string
+	SpeakerKey   string
+	Categories   []Category
+	Tickets      []Ticket
+}
+
+// Category - Loại vé; ghế được gán theo thứ tự loại vé (VIP trước như luồng duyệt sự kiện)
+type Category struct {
+	Name        string
+	Description string
+	Price       float64
+	Seats       int
+}
+
+// Ticket - Vé đã mua; BillStatus rỗng = không có hóa đơn
+type Ticket struct {
+	UserKey       string
+	Category      string
+	Status        string // BOOKED, CHECKED_IN, CHECKED_OUT, REFUNDED...
+	PaymentMethod string // VNPAY, Wallet
+	BillStatus    string // PAID, REFUNDED
+}
+
+// Request - Yêu cầu tổ chức sự kiện chưa tạo sự kiện, định danh bằng (người gửi, tiêu đề)
+type Request struct {
+	Title            string
+	Description      string
+	RequesterKey     string
+	Status           string // PENDING, REJECTED
+	StartIn          time.Duration
+	Duration         time.Duration
+	ExpectedCapacity int
+	RejectReason     string
+}
+
+// Default - Bộ dữ liệu chuẩn cho `backend seed`
+func Default() *Set {
+	const day = 24 * time.Hour
+	return &Set{
+		Users: []User{
+			{Key: "admin", FullName: "Quản Trị Viên", Email: "admin@seed.fpt.edu.vn", Phone: "0901000001", Role: "ADMIN"},
+			{Key: "staff", FullName: "Nhân Viên Soát Vé", Email: "staff@seed.fpt.edu.vn", Phone: "0901000002", Role: "STAFF"},
+			{Key: "organizer", FullName: "Ban Tổ Chức", Email: "organizer@seed.fpt.edu.vn", Phone: "0901000003", Role: "ORGANIZER"},
+			{Key: "student1", FullName: "Nguyễn Văn An", Email: "student1@seed.fpt.edu.vn", Phone: "0901000004", Role: "STUDENT", Wallet: 500000},
+			{Key: "student2", FullName: "Trần Thị Bình", Email: "student2@seed.fpt.edu.vn", Phone: "0901000005", Role: "STUDENT", Wallet: 200000},
+		},
+		Speakers: []Speaker{
+			{Key: "speaker", FullName: "Lê Minh Khoa", Bio: "Kỹ sư phần mềm, diễn giả khách mời", Email: "speaker@seed.fpt.edu.vn", Phone: "0901000006"},
+		},
+		Venues: []Venue{
+			{
+				Name:     "FPT University HCM Campus (Seed)",
+				Location: "Khu Công nghệ cao, Thành Phố Thủ Đức, Tp.HCM",
+				Areas: []Area{
+					{Key: "hall", Name: "Hội trường A", Floor: "1", Capacity: 100},
+					{Key: "room", Name: "Phòng sự kiện 306", Floor: "3", Capacity: 50},
+					{Key: "lab", Name: "Phòng Lab 408", Floor: "4", Capacity: 30},
+				},
+			},
+			{
+				Name:     "Nhà văn hóa sinh viên (Seed)",
+				Location: "Khu đô thị Đại học Quốc gia TP.HCM",
+				Areas: []Area{
+					{Key: "auditorium", Name: "Hội trường lớn", Floor: "2", Capacity: 100},
+					{Key: "lobby", Name: "Sảnh tầng trệt", Floor: "1", Capacity: 40},
+				},
+			},
+		},
+		Events: []Event{
+			{
+				Key: "open", Title: "[Seed] Hội thảo Cloud Computing", Status: "OPEN",
+				Description: "Sự kiện đang mở bán vé (VIP + STANDARD)",
+				StartIn:     7 * day, Duration: 3 * time.Hour,
+				AreaKey: "hall", OrganizerKey: "organizer", SpeakerKey: "speaker",
+				Categories: []Category{
+					{Name: "VIP", Description: "Hàng ghế đầu", Price: 200000, Seats: 20},
+					{Name: "STANDARD", Description: "Ghế thường", Price: 100000, Seats: 80},
+				},
+				Tickets: []Ticket{
+					{UserKey: "student1", Category: "VIP", Status: "BOOKED", PaymentMethod: "VNPAY", BillStatus: "PAID"},
+					{UserKey: "student2", Category: "STANDARD", Status: "BOOKED", PaymentMethod: "Wallet", BillStatus: "PAID"},
+				},
+			},
+			{
+				Key: "today", Title: "[Seed] Workshop Git cơ bản", Status: "OPEN",
+				Description: "Sự kiện sắp bắt đầu, đang trong khung giờ check-in",
+				StartIn:     30 * time.Minute, Duration: 2 * time.Hour,
+				AreaKey: "room", OrganizerKey: "organizer", SpeakerKey: "speaker",
+				Categories: []Category{
+					{Name: "STANDARD", Description: "Ghế thường", Price: 50000, Seats: 50},
+				},
+				Tickets: []Ticket{
+					{UserKey: "student1", Category: "STANDARD", Status: "CHECKED_IN", PaymentMethod: "VNPAY", BillStatus: "PAID"},
+					{UserKey: "student2", Category: "STANDARD", Status: "BOOKED", PaymentMethod: "VNPAY", BillStatus: "PAID"},
+				},
+			},
+			{
+				Key: "closed", Title: "[Seed] Talkshow Khởi nghiệp", Status: "CLOSED",
+				Description: "Sự kiện đã kết thúc",
+				StartIn:     -7 * day, Duration: 2 * time.Hour,
+				AreaKey: "auditorium", OrganizerKey: "organizer", SpeakerKey: "speaker",
+				Categories: []Category{
+					{Name: "VIP", Description: "Hàng ghế đầu", Price: 150000, Seats: 10},
+					{Name: "STANDARD", Description: "Ghế thường", Price: 0, Seats: 90},
+				},
+				Tickets: []Ticket{
+					{UserKey: "student1", Category: "VIP", Status: "CHECKED_OUT", PaymentMethod: "VNPAY", BillStatus: "PAID"},
+					{UserKey: "student2", Category: "STANDARD", Status: "BOOKED"},
+				},
+			},
+			{
+				Key: "updating", Title: "[Seed] Ngày hội Việc làm", Status: "UPDATING",
+				Description: "Sự kiện đã duyệt, ban tổ chức chưa cấu hình vé",
+				StartIn:     14 * day, Duration: 4 * time.Hour,
+				AreaKey: "lab", OrganizerKey: "organizer",
+			},
+			{
+				Key: "cancelled", Title: "[Seed] Đêm nhạc Acoustic", Status: "CANCELLED",
+				Description: "Sự kiện đã hủy, vé đã hoàn tiền vào ví",
+				StartIn:     10 * day, Duration: 3 * time.Hour,
+				AreaKey: "lobby", OrganizerKey: "organizer",
+				Categories: []Category{
+					{Name: "STANDARD", Description: "Ghế thường", Price: 80000, Seats: 40},
+				},
+				Tickets: []Ticket{
+					{UserKey: "student1", Category: "STANDARD", Status: "REFUNDED", PaymentMethod: "VNPAY", BillStatus: "REFUNDED"},
+				},
+			},
+		},
+		Requests: []Request{
+			{
+				Title: "[Seed] Cuộc thi Hackathon", Description: "Yêu cầu đang chờ duyệt",
+				RequesterKey: "organizer", Status: "PENDING",
+				StartIn: 30 * day, Duration: 8 * time.Hour, ExpectedCapacity: 100,
+			},
+			{
+				Title: "[Seed] Giải bóng đá sinh viên", Description: "Yêu cầu bị từ chối",
+				RequesterKey: "organizer", Status: "REJECTED",
+				StartIn: 20 * day, Duration: 6 * time.Hour, ExpectedCapacity: 500,
+				RejectReason: "Không có khu vực đủ sức chứa",
+			},
+		},
+	}
+}
+
+// LoadTestUsers - n tài khoản sinh viên giả lập cho cmd/loadgen, ví nạp sẵn wallet
+// Tài khoản đã tồn tại được giữ nguyên (không nạp lại ví)
+func LoadTestUsers(n int, wallet float64) []User {
+	users := make([]User, 0, n)
+	for i := 1; i <= n; i++ {
+		users = append(users, User{
+			Key:      fmt.Sprintf("loadgen%03d", i),
+			FullName: fmt.Sprintf("Load Test %03d", i),
+			Email:    fmt.Sprintf(LoadTestEmailPattern, i),
+			Phone:    fmt.Sprintf("09020%05d", i),
+			Role:     "STUDENT",
+			Wallet:   wallet,
+		})
+	}
+	return users
+}
@@ -0,0 +1,104 @@
+package fixtures
+
+import (
+	"testing"
+
+	"github.com/fpt-event-services/common/validator"
+)
+
+func TestSeatRow(t *testing.T) {
+	cases := map[int]string{0: "A", 9: "J", 25: "Z", 26: "AA", 27: "AB"}
+	for in, want := range cases {
+		if got := SeatRow(in); got != want {
+			t.Errorf("SeatRow(%d) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+// Bộ dữ liệu mặc định phải nhất quán để Load không lỗi giữa chừng
+func TestDefaultSetConsistent(t *testing.T) {
+	set := Default()
+	if !validator.IsValidPassword(DefaultPassword) {
+		t.Fatalf("DefaultPassword does not satisfy the password policy")
+	}
+
+	users := map[string]bool{}
+	roles := map[string]bool{}
+	for _, u := range set.Users {
+		if !validator.IsValidEmail(u.Email) || !validator.IsValidVNPhone(u.Phone) {
+			t.Errorf("user %s: invalid email/phone", u.Key)
+		}
+		users[u.Key] = true
+		roles[u.Role] = true
+	}
+	for _, role := range []string{"ADMIN", "STAFF", "ORGANIZER", "STUDENT"} {
+		if !roles[role] {
+			t.Errorf("no user with role %s", role)
+		}
+	}
+
+	areas := map[string]int{}
+	for _, v := range set.Venues {
+		for _, a := range v.Areas {
+			areas[a.Key] = a.Capacity
+		}
+	}
+	speakers := map[string]bool{}
+	for _, s := range set.Speakers {
+		speakers[s.Key] = true
+	}
+
+	statuses := map[string]bool{}
+	usedAreas := map[string]string{}
+	for _, e := range set.Events {
+		statuses[e.Status] = true
+		capacity, ok := areas[e.AreaKey]
+		if !ok {
+			t.Errorf("event %s: unknown area %s", e.Key, e.AreaKey)
+		}
+		// Ghế gán theo loại vé của khu vực → mỗi sự kiện một khu vực riêng
+		if other, dup := usedAreas[e.AreaKey]; dup {
+			t.Errorf("events %s and %s share area %s", other, e.Key, e.AreaKey)
+		}
+		usedAreas[e.AreaKey] = e.Key
+		if !users[e.OrganizerKey] || (e.SpeakerKey != "" && !speakers[e.SpeakerKey]) {
+			t.Errorf("event %s: unknown organizer/speaker", e.Key)
+		}
+
+		seats := map[string]int{}
+		total := 0
+		for _, c := range e.Categories {
+			seats[c.Name] = c.Seats
+			total += c.Seats
+		}
+		if total > capacity {
+			t.Errorf("event %s: %d seats exceed area capacity %d", e.Key, total, capacity)
+		}
+		for _, tk := range e.Tickets {
+			if !users[tk.UserKey] {
+				t.Errorf("event %s: unknown ticket user %s", e.Key, tk.UserKey)
+			}
+			if seats[tk.Category]--; seats[tk.Category] < 0 {
+				t.Errorf("event %s: category %s oversold", e.Key, tk.Category)
+			}
+		}
+	}
+	for _, status := range []string{"OPEN", "CLOSED", "CANCELLED", "UPDATING"} {
+		if !statuses[status] {
+			t.Errorf("no event with status %s", status)
+		}
+	}
+}
+
+func TestLoadTestUsers(t *testing.T) {
+	emails := map[string]bool{}
+	for _, u := range append(Default().Users, LoadTestUsers(250, 1000000)...) {
+		if !validator.IsValidEmail(u.Email) || !validator.IsValidVNPhone(u.Phone) {
+			t.Errorf("user %s: invalid email %q / phone %q", u.Key, u.Email, u.Phone)
+		}
+		if emails[u.Email] {
+			t.Errorf("duplicate email %s", u.Email)
+		}
+		emails[u.Email] = true
+	}
+}
@@ -0,0 +1,582 @@
+package fixtures
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fpt-event-services/common/billing"
+	"github.com/fpt-event-services/common/crypto"
+	"github.com/fpt-event-services/common/hash"
+	"github.com/fpt-event-services/common/ledger"
+	"github.com/fpt-event-services/common/qrcode"
+	"github.com/fpt-event-services/common/rules"
+	"github.com/fpt-event-services/common/slug"
+)
+
+// Options - Tùy chọn khi nạp dữ liệu
+type Options struct {
+	Password string    // Mật khẩu cho tài khoản mới (rỗng = DefaultPassword)
+	Now      time.Time // Mốc tính thời gian sự kiện (zero = time.Now())
+}
+
+// Result - ID của các bản ghi theo Key trong Set (cả bản ghi đã có sẵn)
+type Result struct {
+	UserIDs    map[string]int
+	SpeakerIDs map[string]int
+	AreaIDs    map[string]int
+	EventIDs   map[string]int
+	Created    map[string]int // Số bản ghi mới theo loại (users, venues, areas, seats, events, tickets, bills...)
+}
+
+func newResult() *Result {
+	return &Result{
+		UserIDs:    make(map[string]int),
+		SpeakerIDs: make(map[string]int),
+		AreaIDs:    make(map[string]int),
+		EventIDs:   make(map[string]int),
+		Created:    make(map[string]int),
+	}
+}
+
+// Load nạp bộ dữ liệu vào DB; chạy lại nhiều lần chỉ thêm phần còn thiếu
+func Load(ctx context.Context, conn *sql.DB, set *Set, opts Options) (*Result, error) {
+	if opts.Password == "" {
+		opts.Password = DefaultPassword
+	}
+	if opts.Now.IsZero() {
+		opts.Now = time.Now()
+	}
+
+	res := newResult()
+	for _, u := range set.Users {
+		if err := loadUser(ctx, conn, u, opts.Password, res); err != nil {
+			return res, err
+		}
+	}
+	for _, s := range set.Speakers {
+		if err := loadSpeaker(ctx, conn, s, res); err != nil {
+			return res, err
+		}
+	}
+	for _, v := range set.Venues {
+		if err := loadVenue(ctx, conn, v, res); err != nil {
+			return res, err
+		}
+	}
+	for _, e := range set.Events {
+		if err := loadEvent(ctx, conn, e, opts.Now, res); err != nil {
+			return res, err
+		}
+	}
+	for _, r := range set.Requests {
+		if err := loadRequest(ctx, conn, r, opts.Now, res); err != nil {
+			return res, err
+		}
+	}
+	return res, nil
+}
+
+// ============================================================
+// Users / Speaker / Venue / Area / Seat
+// ============================================================
+
+func loadUser(ctx context.Context, conn *sql.DB, u User, password string, res *Result) error {
+	id, err := lookupID(ctx, conn, `SELECT user_id FROM Users WHERE email = ?`, u.Email)
+	if err != nil {
+		return fmt.Errorf("failed to check user %s: %w", u.Email, err)
+	}
+	if id == 0 {
+		phone, err := crypto.EncryptPII(u.Phone)
+		if err != nil {
+			return err
+		}
+		result, err := conn.ExecContext(ctx, `
+			INSERT INTO Users (full_name, email, phone, password_hash, role, status, created_at, Wallet)
+			VALUES (?, ?, ?, ?, ?, 'ACTIVE', NOW(), ?)
+		`, u.FullName, u.Email, phone, hash.HashPassword(password), u.Role, u.Wallet)
+		if err != nil {
+			return fmt.Errorf("failed to create user %s: %w", u.Email, err)
+		}
+		if id, err = lastInsertID(result); err != nil {
+			return err
+		}
+		if _, err := ledger.Post(ctx, conn, ledger.OpeningBalance("USER", id, id, u.Wallet)); err != nil {
+			return fmt.Errorf("failed to post wallet of user %s: %w", u.Email, err)
+		}
+		res.Created["users"]++
+	}
+	res.UserIDs[u.Key] = id
+	return nil
+}
+
+func loadSpeaker(ctx context.Context, conn *sql.DB, s Speaker, res *Result) error {
+	id, err := findSpeakerByEmail(ctx, conn, s.FullName, s.Email)
+	if err != nil {
+		return fmt.Errorf("failed to check speaker %s: %w", s.Email, err)
+	}
+	if id == 0 {
+		email, phone, err := encryptContact(s.Email, s.Phone)
+		if err != nil {
+			return err
+		}
+		result, err := conn.ExecContext(ctx,
+			`INSERT INTO Speaker (full_name, bio, email, phone, avatar_url) VALUES (?, ?, ?, ?, NULL)`,
+			s.FullName, s.Bio, email, phone)
+		if err != nil {
+			return fmt.Errorf("failed to create speaker %s: %w", s.Email, err)
+		}
+		if id, err = lastInsertID(result); err != nil {
+			return err
+		}
+		res.Created["speakers"]++
+	}
+	res.SpeakerIDs[s.Key] = id
+	return nil
+}
+
+func loadVenue(ctx context.Context, conn *sql.DB, v Venue, res *Result) error {
+	venueID, err := lookupID(ctx, conn, `SELECT venue_id FROM Venue WHERE venue_name = ? ORDER BY venue_id LIMIT 1`, v.Name)
+	if err != nil {
+		return fmt.Errorf("failed to check venue %s: %w", v.Name, err)
+	}
+	if venueID == 0 {
+		result, err := conn.ExecContext(ctx,
+			`INSERT INTO Venue (venue_name, location, status) VALUES (?, ?, 'AVAILABLE')`, v.Name, v.Location)
+		if err != nil {
+			return fmt.Errorf("failed to create venue %s: %w", v.Name, err)
+		}
+		if venueID, err = lastInsertID(result); err != nil {
+			return err
+		}
+		res.Created["venues"]++
+	}
+
+	for _, a := range v.Areas {
+		areaID, err := lookupID(ctx, conn,
+			`SELECT area_id FROM Venue_Area WHERE venue_id = ? AND area_name = ? ORDER BY area_id LIMIT 1`, venueID, a.Name)
+		if err != nil {
+			return fmt.Errorf("failed to check area %s: %w", a.Name, err)
+		}
+		if areaID == 0 {
+			result, err := conn.ExecContext(ctx,
+				`INSERT INTO Venue_Area (venue_id, area_name, floor, capacity, status) VALUES (?, ?, ?, ?, 'AVAILABLE')`,
+				venueID, a.Name, a.Floor, a.Capacity)
+			if err != nil {
+				return fmt.Errorf("failed to create area %s: %w", a.Name, err)
+			}
+			if areaID, err = lastInsertID(result); err != nil {
+				return err
+			}
+			res.Created["areas"]++
+		}
+		res.AreaIDs[a.Key] = areaID
+
+		created, err := ensureSeats(ctx, conn, areaID, a.Capacity)
+		if err != nil {
+			return fmt.Errorf("failed to create seats for area %s: %w", a.Name, err)
+		}
+		if created > 0 {
+			res.Created["seats"] += created
+		}
+	}
+	return nil
+}
+
+// ensureSeats sinh sơ đồ ghế hàng 10 ghế (A1..A10, B1...) như luồng cấu hình sự kiện
+// INSERT IGNORE theo (area_id, seat_code) nên ghế đã có được giữ nguyên
+func ensureSeats(ctx context.Context, conn *sql.DB, areaID, capacity int) (int, error) {
+	const seatsPerRow = 10
+	created := 0
+	for i := 0; i < capacity; i++ {
+		row := SeatRow(i / seatsPerRow)
+		col := strconv.Itoa(i%seatsPerRow + 1)
+		result, err := conn.ExecContext(ctx,
+			`INSERT IGNORE INTO Seat (area_id, seat_code, row_no, col_no, status) VALUES (?, ?, ?, ?, 'ACTIVE')`,
+			areaID, row+col, row, col)
+		if err != nil {
+			return created, err
+		}
+		if n, _ := result.RowsAffected(); n > 0 {
+			created++
+		}
+	}
+	return created, nil
+}
+
+// SeatRow - Tên hàng ghế theo chỉ số: 0 → A, 25 → Z, 26 → AA
+func SeatRow(index int) string {
+	name := ""
+	for index >= 0 {
+		name = string(rune('A'+index%26)) + name
+		index = index/26 - 1
+	}
+	return name
+}
+
+// ============================================================
+// Event - Sự kiện + yêu cầu đã duyệt + loại vé + ghế + vé + hóa đơn (một transaction)
+// ============================================================
+
+func loadEvent(ctx context.Context, conn *sql.DB, e Event, now time.Time, res *Result) error {
+	eventID, err := lookupID(ctx, conn, `SELECT event_id FROM Event WHERE title = ? ORDER BY event_id LIMIT 1`, e.Title)
+	if err != nil {
+		return fmt.Errorf("failed to check event %s: %w", e.Title, err)
+	}
+	if eventID != 0 {
+		res.EventIDs[e.Key] = eventID
+		return nil
+	}
+
+	areaID, ok := res.AreaIDs[e.AreaKey]
+	if !ok {
+		return fmt.Errorf("event %s: unknown area %q", e.Key, e.AreaKey)
+	}
+	organizerID, ok := res.UserIDs[e.OrganizerKey]
+	if !ok {
+		return fmt.Errorf("event %s: unknown organizer %q", e.Key, e.OrganizerKey)
+	}
+	var speakerID sql.NullInt64
+	if e.SpeakerKey != "" {
+		id, ok := res.SpeakerIDs[e.SpeakerKey]
+		if !ok {
+			return fmt.Errorf("event %s: unknown speaker %q", e.Key, e.SpeakerKey)
+		}
+		speakerID = sql.NullInt64{Int64: int64(id), Valid: true}
+	}
+
+	start := now.Add(e.StartIn).Truncate(time.Minute)
+	end := start.Add(e.Duration)
+	maxSeats := 0
+	for _, c := range e.Categories {
+		maxSeats += c.Seats
+	}
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+		INSERT INTO Event (title, description, start_time, end_time, max_seats, area_id, speaker_id, status, created_by, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, NOW())
+	`, e.Title, e.Description, start, end, maxSeats, areaID, speakerID, e.Status, organizerID)
+	if err != nil {
+		return fmt.Errorf("failed to create event %s: %w", e.Title, err)
+	}
+	if eventID, err = lastInsertID(result); err != nil {
+		return err
+	}
+	// Slug cho trang public; fixture luôn thêm -{id} để nạp nhiều lần không trùng
+	if _, err := tx.ExecContext(ctx, `UPDATE Event SET slug = ? WHERE event_id = ?`,
+		slug.WithID(slug.Make(e.Title), int64(eventID)), eventID); err != nil {
+		return fmt.Errorf("failed to set slug for event %s: %w", e.Title, err)
+	}
+
+	// Yêu cầu đã duyệt sinh ra sự kiện (giống luồng APPROVE)
+	processedBy := userRef(res, "admin")
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO Event_Request (requester_id, title, description, preferred_start_time, preferred_end_time,
+			expected_capacity, status, created_at, processed_by, processed_at, created_event_id)
+		VALUES (?, ?, ?, ?, ?, ?, 'APPROVED', NOW(), ?, NOW(), ?)
+	`, organizerID, e.Title, e.Description, start, end, maxSeats, processedBy, eventID); err != nil {
+		return fmt.Errorf("failed to create request for event %s: %w", e.Title, err)
+	}
+
+	// Khu vực đang có sự kiện OPEN/UPDATING bị khóa cho đến khi sự kiện kết thúc
+	if e.Status == "OPEN" || e.Status == "UPDATING" {
+		if _, err := tx.ExecContext(ctx, `UPDATE Venue_Area SET status = 'UNAVAILABLE' WHERE area_id = ?`, areaID); err != nil {
+			return fmt.Errorf("failed to lock area for event %s: %w", e.Title, err)
+		}
+	}
+
+	categories, err := loadCategories(ctx, tx, eventID, areaID, e)
+	if err != nil {
+		return err
+	}
+
+	checkedIn, checkedOut := 0, 0
+	for _, t := range e.Tickets {
+		if err := loadTicket(ctx, tx, eventID, t, categories, start, res); err != nil {
+			return fmt.Errorf("event %s: %w", e.Key, err)
+		}
+		switch t.Status {
+		case "CHECKED_IN":
+			checkedIn++
+		case "CHECKED_OUT":
+			checkedIn++
+			checkedOut++
+		}
+	}
+	if checkedIn > 0 {
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO Event_Occupancy (event_id, checked_in_total, checked_out_total) VALUES (?, ?, ?)`,
+			eventID, checkedIn, checkedOut); err != nil {
+			return fmt.Errorf("failed to create occupancy for event %s: %w", e.Title, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	res.EventIDs[e.Key] = eventID
+	res.Created["events"]++
+	res.Created["categories"] += len(e.Categories)
+	res.Created["tickets"] += len(e.Tickets)
+	return nil
+}
+
+// seededCategory - Loại vé đã tạo và danh sách ghế được gán
+type seededCategory struct {
+	id    int
+	price float64
+	seats []int
+	next  int
+}
+
+// loadCategories tạo loại vé và gán ghế của khu vực lần lượt theo thứ tự loại vé
+func loadCategories(ctx context.Context, tx *sql.Tx, eventID, areaID int, e Event) (map[string]*seededCategory, error) {
+	categories := make(map[string]*seededCategory)
+	if len(e.Categories) == 0 {
+		return categories, nil
+	}
+
+	rows, err := tx.QueryContext(ctx, `SELECT seat_id FROM Seat WHERE area_id = ? AND status = 'ACTIVE' ORDER BY seat_id`, areaID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query seats: %w", err)
+	}
+	var seatIDs []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		seatIDs = append(seatIDs, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	offset := 0
+	for _, c := range e.Categories {
+		if offset+c.Seats > len(seatIDs) {
+			return nil, fmt.Errorf("event %s: insufficient seats: have %d, need %d", e.Key, len(seatIDs), offset+c.Seats)
+		}
+		result, err := tx.ExecContext(ctx, `
+			INSERT INTO category_ticket (event_id, name, description, price, max_quantity, status)
+			VALUES (?, ?, ?, ?, ?, 'ACTIVE')
+		`, eventID, c.Name, c.Description, c.Price, c.Seats)
+		if err != nil {
+			return nil, fmt.Errorf("failed to insert category_ticket: %w", err)
+		}
+		categoryID, err := lastInsertID(result)
+		if err != nil {
+			return nil, err
+		}
+
+		seats := seatIDs[offset : offset+c.Seats]
+		for _, seatID := range seats {
+			if _, err := tx.ExecContext(ctx, `UPDATE Seat SET category_ticket_id = ? WHERE seat_id = ?`, categoryID, seatID); err != nil {
+				return nil, fmt.Errorf("failed to update seat %d: %w", seatID, err)
+			}
+		}
+		offset += c.Seats
+		categories[c.Name] = &seededCategory{id: categoryID, price: c.Price, seats: seats}
+	}
+	return categories, nil
+}
+
+// loadTicket tạo vé (ghế kế tiếp của loại vé), QR và hóa đơn nếu có
+func loadTicket(ctx context.Context, tx *sql.Tx, eventID int, t Ticket, categories map[string]*seededCategory, start time.Time, res *Result) error {
+	userID, ok := res.UserIDs[t.UserKey]
+	if !ok {
+		return fmt.Errorf("unknown user %q", t.UserKey)
+	}
+	category, ok := categories[t.Category]
+	if !ok {
+		return fmt.Errorf("unknown category %q", t.Category)
+	}
+	if category.next >= len(category.seats) {
+		return fmt.Errorf("category %s has no seat left", t.Category)
+	}
+	seatID := category.seats[category.next]
+	category.next++
+
+	var billID sql.NullInt64
+	if t.BillStatus != "" {
+		result, err := tx.ExecContext(ctx, `
+			INSERT INTO Bill (user_id, total_amount, currency, payment_method, payment_status, created_at, paid_at)
+			VALUES (?, ?, 'VND', ?, ?, NOW(), NOW())
+		`, userID, category.price, t.PaymentMethod, t.BillStatus)
+		if err != nil {
+			return fmt.Errorf("failed to create bill: %w", err)
+		}
+		id, err := lastInsertID(result)
+		if err != nil {
+			return err
+		}
+		billID = sql.NullInt64{Int64: int64(id), Valid: true}
+		// Users.Wallet của fixture là số dư SAU khi mua: vé trả bằng ví được nạp
+		// đầu kỳ đúng bằng giá vé để số dư sổ cái khớp
+		if t.PaymentMethod == "Wallet" {
+			if _, err := ledger.Post(ctx, tx, ledger.OpeningBalance("BILL", id, userID, category.price)); err != nil {
+				return fmt.Errorf("failed to post bill opening balance: %w", err)
+			}
+		}
+		if _, err := ledger.Post(ctx, tx, ledger.BillPayment(id, userID, t.PaymentMethod, category.price)); err != nil {
+			return fmt.Errorf("failed to post bill: %w", err)
+		}
+		res.Created["bills"]++
+	}
+
+	var checkinTime, checkoutTime sql.NullTime
+	switch t.Status {
+	case "CHECKED_IN":
+		checkinTime = sql.NullTime{Time: start.Add(-15 * time.Minute), Valid: true}
+	case "CHECKED_OUT":
+		checkinTime = sql.NullTime{Time: start.Add(-15 * time.Minute), Valid: true}
+		checkoutTime = sql.NullTime{Time: start.Add(90 * time.Minute), Valid: true}
+	}
+
+	result, err := tx.ExecContext(ctx, `
+		INSERT INTO Ticket (user_id, event_id, category_ticket_id, bill_id, seat_id, qr_code_value, status,
+			checkin_time, check_out_time, created_at)
+		VALUES (?, ?, ?, ?, ?, 'PENDING_QR', ?, ?, ?, NOW())
+	`, userID, eventID, category.id, billID, seatID, t.Status, checkinTime, checkoutTime)
+	if err != nil {
+		return fmt.Errorf("failed to create ticket: %w", err)
+	}
+	ticketID, err := lastInsertID(result)
+	if err != nil {
+		return err
+	}
+
+	qrBase64, err := qrcode.GenerateTicketQRBase64(ticketID, 300)
+	if err != nil {
+		qrBase64 = fmt.Sprintf("PENDING_QR_%d", ticketID)
+	}
+	if _, err := tx.ExecContext(ctx, `UPDATE Ticket SET qr_code_value = ? WHERE ticket_id = ?`, qrBase64, ticketID); err != nil {
+		return fmt.Errorf("failed to save QR for ticket %d: %w", ticketID, err)
+	}
+
+	if billID.Valid {
+		feeBps, _, err := rules.PlatformFee(ctx, tx, eventID)
+		if err != nil {
+			return err
+		}
+		items := billing.Split(category.price, eventID, []int{ticketID}, feeBps)
+		if err := billing.InsertItems(ctx, tx, int(billID.Int64), items); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ============================================================
+// Event_Request chưa tạo sự kiện (PENDING / REJECTED)
+// ============================================================
+
+func loadRequest(ctx context.Context, conn *sql.DB, r Request, now time.Time, res *Result) error {
+	requesterID, ok := res.UserIDs[r.RequesterKey]
+	if !ok {
+		return fmt.Errorf("request %s: unknown requester %q", r.Title, r.RequesterKey)
+	}
+	id, err := lookupID(ctx, conn,
+		`SELECT request_id FROM Event_Request WHERE requester_id = ? AND title = ? LIMIT 1`, requesterID, r.Title)
+	if err != nil {
+		return fmt.Errorf("failed to check request %s: %w", r.Title, err)
+	}
+	if id != 0 {
+		return nil
+	}
+
+	start := now.Add(r.StartIn).Truncate(time.Minute)
+	var processedBy sql.NullInt64
+	var processedAt sql.NullTime
+	var rejectReason sql.NullString
+	if r.Status != "PENDING" {
+		processedBy = userRef(res, "admin")
+		processedAt = sql.NullTime{Time: now, Valid: true}
+	}
+	if r.RejectReason != "" {
+		rejectReason = sql.NullString{String: r.RejectReason, Valid: true}
+	}
+
+	if _, err := conn.ExecContext(ctx, `
+		INSERT INTO Event_Request (requester_id, title, description, preferred_start_time, preferred_end_time,
+			expected_capacity, status, created_at, processed_by, processed_at, reject_reason)
+		VALUES (?, ?, ?, ?, ?, ?, ?, NOW(), ?, ?, ?)
+	`, requesterID, r.Title, r.Description, start, start.Add(r.Duration), r.ExpectedCapacity, r.Status,
+		processedBy, processedAt, rejectReason); err != nil {
+		return fmt.Errorf("failed to create request %s: %w", r.Title, err)
+	}
+	res.Created["requests"]++
+	return nil
+}
+
+// ============================================================
+// Helpers
+// ============================================================
+
+// lookupID trả về ID của bản ghi đầu tiên (0 nếu không có)
+func lookupID(ctx context.Context, conn *sql.DB, query string, args ...interface{}) (int, error) {
+	var id int
+	err := conn.QueryRowContext(ctx, query, args...).Scan(&id)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	return id, err
+}
+
+// findSpeakerByEmail - Email speaker được mã hóa (nonce ngẫu nhiên) nên không so sánh
+// được bằng SQL: lọc theo họ tên rồi so email sau khi giải mã
+func findSpeakerByEmail(ctx context.Context, conn *sql.DB, fullName, email string) (int, error) {
+	rows, err := conn.QueryContext(ctx,
+		`SELECT speaker_id, COALESCE(email, '') FROM Speaker WHERE full_name = ? ORDER BY speaker_id`, fullName)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id int
+		var stored string
+		if err := rows.Scan(&id, &stored); err != nil {
+			return 0, err
+		}
+		if strings.EqualFold(crypto.DecryptPII(stored), email) {
+			return id, nil
+		}
+	}
+	return 0, rows.Err()
+}
+
+func encryptContact(email, phone string) (string, string, error) {
+	storedEmail, err := crypto.EncryptPII(email)
+	if err != nil {
+		return "", "", err
+	}
+	storedPhone, err := crypto.EncryptPII(phone)
+	return storedEmail, storedPhone, err
+}
+
+func lastInsertID(result sql.Result) (int, error) {
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get inserted ID: %w", err)
+	}
+	return int(id), nil
+}
+
+// userRef - ID tài khoản theo Key dạng cột nullable (NULL nếu Set không có)
+func userRef(res *Result, key string) sql.NullInt64 {
+	if id, ok := res.UserIDs[key]; ok {
+		return sql.NullInt64{Int64: int64(id), Valid: true}
+	}
+	return sql.NullInt64{}
+}
@@ -0,0 +1,357 @@
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// Execute parse, kiểm tra giới hạn và thực thi một request
+// Lỗi của từng field không làm hỏng cả response: field đó trả null kèm lỗi có path
+func (s *Schema) Execute(ctx context.Context, req Request) *Response {
+	doc, err := Parse(req.Query)
+	if err != nil {
+		return errorResponse("Syntax error: %v", err)
+	}
+
+	op, err := selectOperation(doc, req.OperationName)
+	if err != nil {
+		return errorResponse("%v", err)
+	}
+
+	vars, err := coerceVariables(op, req.Variables)
+	if err != nil {
+		return errorResponse("%v", err)
+	}
+
+	v := &validator{vars: vars}
+	v.validate(s.Query, op.Selections, 1)
+	if len(v.errs) > 0 {
+		return &Response{Errors: v.errs}
+	}
+	if s.MaxDepth > 0 && v.maxDepth > s.MaxDepth {
+		return errorResponse("Query depth %d exceeds the limit of %d", v.maxDepth, s.MaxDepth)
+	}
+	if complexity := v.complexity(s.Query, op.Selections); s.MaxComplexity > 0 && complexity > s.MaxComplexity {
+		return errorResponse("Query complexity %d exceeds the limit of %d", complexity, s.MaxComplexity)
+	}
+
+	e := &executor{ctx: ctx, vars: vars}
+	data := e.executeObject(s.Query, nil, op.Selections, nil)
+	return &Response{Data: data, Errors: e.errs}
+}
+
+func selectOperation(doc *Document, name string) (*Operation, error) {
+	if name == "" {
+		if len(doc.Operations) > 1 {
+			return nil, fmt.Errorf("operationName is required when the document has multiple operations")
+		}
+		return doc.Operations[0], nil
+	}
+	for _, op := range doc.Operations {
+		if op.Name == name {
+			return op, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown operation %q", name)
+}
+
+func coerceVariables(op *Operation, input map[string]interface{}) (map[string]interface{}, error) {
+	vars := map[string]interface{}{}
+	for _, def := range op.Variables {
+		value, ok := input[def.Name]
+		if !ok || value == nil {
+			if def.Default != nil {
+				vars[def.Name] = def.Default
+				continue
+			}
+			if def.Required {
+				return nil, fmt.Errorf("variable $%s of type %s is required", def.Name, def.Type)
+			}
+			vars[def.Name] = nil
+			continue
+		}
+		vars[def.Name] = value
+	}
+	return vars, nil
+}
+
+// ============================================================
+// Validation: field tồn tại, argument hợp lệ, selection đúng kiểu,
+// biến đã khai báo; đồng thời đo độ sâu và độ phức tạp
+// ============================================================
+
+type validator struct {
+	vars     map[string]interface{}
+	errs     []Error
+	maxDepth int
+}
+
+func (v *validator) validate(obj *Object, sels []*Selection, depth int) {
+	if depth > v.maxDepth {
+		v.maxDepth = depth
+	}
+	for _, sel := range sels {
+		if sel.Name == "__typename" {
+			continue
+		}
+		f, ok := obj.Fields[sel.Name]
+		if !ok {
+			v.errs = append(v.errs, Error{Message: fmt.Sprintf("Cannot query field %q on type %q", sel.Name, obj.Name)})
+			continue
+		}
+		for _, arg := range sel.Arguments {
+			if !contains(f.Args, arg.Name) {
+				v.errs = append(v.errs, Error{Message: fmt.Sprintf("Unknown argument %q on field %q", arg.Name, obj.Name+"."+sel.Name)})
+			}
+			v.checkVariables(arg.Value)
+		}
+		switch {
+		case f.Type == nil && len(sel.Selections) > 0:
+			v.errs = append(v.errs, Error{Message: fmt.Sprintf("Field %q must not have a selection since it is a scalar", sel.Name)})
+		case f.Type != nil && len(sel.Selections) == 0:
+			v.errs = append(v.errs, Error{Message: fmt.Sprintf("Field %q of type %q must have a selection of subfields", sel.Name, f.Type.Name)})
+		case f.Type != nil:
+			v.validate(f.Type, sel.Selections, depth+1)
+		}
+	}
+}
+
+func (v *validator) checkVariables(value interface{}) {
+	switch val := value.(type) {
+	case Variable:
+		if _, ok := v.vars[val.Name]; !ok {
+			v.errs = append(v.errs, Error{Message: fmt.Sprintf("Variable $%s is not defined", val.Name)})
+		}
+	case []interface{}:
+		for _, item := range val {
+			v.checkVariables(item)
+		}
+	case map[string]interface{}:
+		for _, item := range val {
+			v.checkVariables(item)
+		}
+	}
+}
+
+// complexity: mỗi field tính 1, field danh sách nhân độ phức tạp của field con
+// với "limit"/"first" (nếu có) hoặc defaultListSize
+func (v *validator) complexity(obj *Object, sels []*Selection) int {
+	total := 0
+	for _, sel := range sels {
+		f, ok := obj.Fields[sel.Name]
+		if !ok {
+			continue
+		}
+		cost := 1
+		if f.Type != nil {
+			child := v.complexity(f.Type, sel.Selections)
+			if f.List {
+				child *= v.listSize(sel)
+			}
+			cost += child
+		}
+		total += cost
+	}
+	return total
+}
+
+func (v *validator) listSize(sel *Selection) int {
+	for _, arg := range sel.Arguments {
+		if arg.Name != "limit" && arg.Name != "first" {
+			continue
+		}
+		p := ResolveParams{Args: map[string]interface{}{arg.Name: resolveValue(arg.Value, v.vars)}}
+		if n, ok := p.Int(arg.Name); ok && n > 0 {
+			return n
+		}
+	}
+	return defaultListSize
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// ============================================================
+// Execution
+// ============================================================
+
+type executor struct {
+	ctx  context.Context
+	vars map[string]interface{}
+	errs []Error
+}
+
+func (e *executor) executeObject(obj *Object, source interface{}, sels []*Selection, path []interface{}) *orderedMap {
+	result := &orderedMap{values: map[string]interface{}{}}
+
+	var sourceMap map[string]interface{}
+	var sourceErr error
+	sourceLoaded := false
+
+	for _, sel := range sels {
+		key := sel.ResponseKey()
+		fieldPath := appendPath(path, key)
+
+		if sel.Name == "__typename" {
+			result.set(key, obj.Name)
+			continue
+		}
+		f := obj.Fields[sel.Name]
+
+		var value interface{}
+		var err error
+		if f.Resolve != nil {
+			args := map[string]interface{}{}
+			for _, arg := range sel.Arguments {
+				args[arg.Name] = resolveValue(arg.Value, e.vars)
+			}
+			value, err = f.Resolve(ResolveParams{Context: e.ctx, Source: source, Args: args})
+		} else {
+			if !sourceLoaded {
+				sourceMap, sourceErr = toMap(source)
+				sourceLoaded = true
+			}
+			err = sourceErr
+			if err == nil && sourceMap != nil {
+				value = sourceMap[f.jsonKey]
+			}
+		}
+		if err != nil {
+			e.errs = append(e.errs, Error{Message: err.Error(), Path: fieldPath})
+			result.set(key, nil)
+			continue
+		}
+		result.set(key, e.complete(f, value, sel, fieldPath))
+	}
+	return result
+}
+
+func (e *executor) complete(f *Field, value interface{}, sel *Selection, path []interface{}) interface{} {
+	if isNil(value) {
+		return nil
+	}
+	if f.Type == nil {
+		return value
+	}
+	if !f.List {
+		return e.executeObject(f.Type, value, sel.Selections, path)
+	}
+
+	rv := reflect.ValueOf(value)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		e.errs = append(e.errs, Error{Message: fmt.Sprintf("expected a list for field %q", sel.Name), Path: path})
+		return nil
+	}
+	items := make([]interface{}, rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		item := rv.Index(i).Interface()
+		if isNil(item) {
+			continue
+		}
+		items[i] = e.executeObject(f.Type, item, sel.Selections, appendPath(path, i))
+	}
+	return items
+}
+
+// resolveValue thay biến trong giá trị argument bằng giá trị thật
+func resolveValue(value interface{}, vars map[string]interface{}) interface{} {
+	switch v := value.(type) {
+	case Variable:
+		return vars[v.Name]
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, item := range v {
+			out[i] = resolveValue(item, vars)
+		}
+		return out
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for k, item := range v {
+			out[k] = resolveValue(item, vars)
+		}
+		return out
+	}
+	return value
+}
+
+// toMap chuyển struct sang map theo json tag (field mặc định đọc từ đây)
+func toMap(source interface{}) (map[string]interface{}, error) {
+	if isNil(source) {
+		return nil, nil
+	}
+	if m, ok := source.(map[string]interface{}); ok {
+		return m, nil
+	}
+	data, err := json.Marshal(source)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var m map[string]interface{}
+	if err := dec.Decode(&m); err != nil {
+		return nil, fmt.Errorf("cannot read fields of %T", source)
+	}
+	return m, nil
+}
+
+func isNil(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Interface:
+		return rv.IsNil()
+	}
+	return false
+}
+
+func appendPath(path []interface{}, elem interface{}) []interface{} {
+	out := make([]interface{}, len(path), len(path)+1)
+	copy(out, path)
+	return append(out, elem)
+}
+
+// orderedMap giữ thứ tự field theo selection khi encode JSON
+type orderedMap struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+func (m *orderedMap) set(key string, value interface{}) {
+	if _, exists := m.values[key]; !exists {
+		m.keys = append(m.keys, key)
+	}
+	m.values[key] = value
+}
+
+// MarshalJSON encodes fields in selection order
+func (m *orderedMap) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, key := range m.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		k, _ := json.Marshal(key)
+		buf.Write(k)
+		buf.WriteByte(':')
+		v, err := json.Marshal(m.values[key])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(v)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,118 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+type testArea struct {
+	AreaID   int    `json:"areaId"`
+	AreaName string `json:"areaName"`
+}
+
+type testVenue struct {
+	VenueID   int        `json:"venueId"`
+	VenueName string     `json:"venueName"`
+	Location  *string    `json:"location"`
+	Areas     []testArea `json:"areas,omitempty"`
+}
+
+func testSchema() *Schema {
+	loc := "Q9"
+	venues := []testVenue{
+		{VenueID: 1, VenueName: "Hall A", Location: &loc, Areas: []testArea{{AreaID: 10, AreaName: "Main"}}},
+		{VenueID: 2, VenueName: "Hall B"},
+	}
+	venue := NewObject("Venue", testVenue{}, map[string]*Field{
+		"secret": {Resolve: func(p ResolveParams) (interface{}, error) {
+			return nil, errors.New("forbidden")
+		}},
+	})
+	query := NewObject("Query", nil, map[string]*Field{
+		"venues": {Type: venue, List: true, Args: []string{"limit"}, Resolve: func(p ResolveParams) (interface{}, error) {
+			if n, ok := p.Int("limit"); ok && n < len(venues) {
+				return venues[:n], nil
+			}
+			return venues, nil
+		}},
+		"venue": {Type: venue, Args: []string{"id"}, Resolve: func(p ResolveParams) (interface{}, error) {
+			id, _ := p.Int("id")
+			for i := range venues {
+				if venues[i].VenueID == id {
+					return &venues[i], nil
+				}
+			}
+			return nil, nil
+		}},
+	})
+	return NewSchema(query)
+}
+
+func execute(t *testing.T, s *Schema, req Request) (string, []Error) {
+	t.Helper()
+	resp := s.Execute(context.Background(), req)
+	data, err := json.Marshal(resp.Data)
+	if err != nil {
+		t.Fatalf("marshal data: %v", err)
+	}
+	return string(data), resp.Errors
+}
+
+func TestExecuteSelectsRequestedShape(t *testing.T) {
+	data, errs := execute(t, testSchema(), Request{
+		Query: `query Venues($n: Int = 5) {
+			venues(limit: $n) { venueName areas { areaName } }
+			hallB: venue(id: 2) { __typename venueId location }
+		}`,
+		Variables: map[string]interface{}{"n": float64(1)},
+	})
+	if len(errs) > 0 {
+		t.Fatalf("unexpected errors: %+v", errs)
+	}
+	want := `{"venues":[{"venueName":"Hall A","areas":[{"areaName":"Main"}]}],"hallB":{"__typename":"Venue","venueId":2,"location":null}}`
+	if data != want {
+		t.Fatalf("data = %s\nwant  %s", data, want)
+	}
+}
+
+func TestExecuteFieldErrorKeepsPartialData(t *testing.T) {
+	data, errs := execute(t, testSchema(), Request{Query: `{ venue(id: 1) { venueId secret } }`})
+	if data != `{"venue":{"venueId":1,"secret":null}}` {
+		t.Fatalf("data = %s", data)
+	}
+	if len(errs) != 1 || errs[0].Message != "forbidden" || len(errs[0].Path) != 2 {
+		t.Fatalf("errors = %+v", errs)
+	}
+}
+
+func TestExecuteRejectsInvalidQueries(t *testing.T) {
+	s := testSchema()
+	s.MaxDepth = 2
+	s.MaxComplexity = 30
+
+	cases := map[string]string{
+		`{ venues { unknown } }`:                            "Cannot query field",
+		`{ venues }`:                                        "must have a selection",
+		`{ venues { venueName { x } } }`:                    "must not have a selection",
+		`{ venue(slug: "a") { venueId } }`:                  "Unknown argument",
+		`{ venue(id: $id) { venueId } }`:                    "is not defined",
+		`query Q($id: Int!) { venue(id: $id) { venueId } }`: "is required",
+		`{ venues { areas { areaId } } }`:                   "depth",
+		`{ venues(limit: 50) { venueId venueName } }`:       "complexity",
+		`mutation { venues { venueId } }`:                   "not supported",
+		`{ venues { ...F } }`:                               "fragments",
+		`{ venues { venueId }`:                              "Syntax error",
+	}
+	for query, wantErr := range cases {
+		resp := s.Execute(context.Background(), Request{Query: query})
+		if len(resp.Errors) == 0 || !strings.Contains(resp.Errors[0].Message, wantErr) {
+			t.Errorf("query %s: errors = %+v, want %q", query, resp.Errors, wantErr)
+		}
+		if resp.Data != nil {
+			t.Errorf("query %s: data = %v, want nil", query, resp.Data)
+		}
+	}
+}
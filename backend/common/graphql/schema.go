@@ -0,0 +1,173 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Giới hạn mặc định cho mỗi query
+const (
+	DefaultMaxDepth      = 6
+	DefaultMaxComplexity = 1000
+	// defaultListSize - Hệ số nhân độ phức tạp cho field trả về danh sách
+	// khi query không truyền "limit"/"first"
+	defaultListSize = 10
+)
+
+// ResolveParams - Tham số truyền vào resolver
+type ResolveParams struct {
+	Context context.Context
+	Source  interface{} // Giá trị của object cha (nil ở Query)
+	Args    map[string]interface{}
+}
+
+// Int đọc argument kiểu số nguyên (literal hoặc biến JSON)
+func (p ResolveParams) Int(name string) (int, bool) {
+	switch v := p.Args[name].(type) {
+	case int:
+		return v, true
+	case float64:
+		if v == float64(int(v)) {
+			return int(v), true
+		}
+	case json.Number:
+		if n, err := v.Int64(); err == nil {
+			return int(n), true
+		}
+	}
+	return 0, false
+}
+
+// String đọc argument kiểu chuỗi (chấp nhận cả enum)
+func (p ResolveParams) String(name string) (string, bool) {
+	switch v := p.Args[name].(type) {
+	case string:
+		return v, true
+	case EnumValue:
+		return string(v), true
+	}
+	return "", false
+}
+
+// ResolveFunc - Hàm lấy giá trị của field
+type ResolveFunc func(p ResolveParams) (interface{}, error)
+
+// Field - Định nghĩa field của một Object
+type Field struct {
+	Description string
+	Type        *Object  // nil = scalar (trả nguyên giá trị JSON)
+	List        bool     // Field trả về danh sách
+	Args        []string // Tên argument hợp lệ
+	Resolve     ResolveFunc
+
+	jsonKey string // Field sinh từ model: đọc theo json tag của Source
+}
+
+// Object - Kiểu object trong schema
+type Object struct {
+	Name   string
+	Fields map[string]*Field
+}
+
+// NewObject tạo object type; nếu model != nil, mọi field có json tag của struct
+// được thêm làm field mặc định (struct con/slice struct thành object lồng nhau)
+// fields ghi đè hoặc bổ sung field có resolver riêng
+func NewObject(name string, model interface{}, fields map[string]*Field) *Object {
+	obj := &Object{Name: name, Fields: map[string]*Field{}}
+	if model != nil {
+		addModelFields(obj, reflect.TypeOf(model), map[reflect.Type]*Object{})
+	}
+	for fieldName, f := range fields {
+		obj.Fields[fieldName] = f
+	}
+	return obj
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+func addModelFields(obj *Object, t reflect.Type, seen map[reflect.Type]*Object) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return
+	}
+	seen[t] = obj
+
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag := sf.Tag.Get("json")
+		if sf.Anonymous && tag == "" {
+			addModelFields(obj, sf.Type, seen)
+			continue
+		}
+		if !sf.IsExported() || tag == "-" {
+			continue
+		}
+		key := strings.Split(tag, ",")[0]
+		if key == "" {
+			key = sf.Name
+		}
+
+		f := &Field{jsonKey: key}
+		ft := sf.Type
+		for ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		if ft.Kind() == reflect.Slice && ft.Elem().Kind() != reflect.Uint8 {
+			f.List = true
+			ft = ft.Elem()
+			for ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+		}
+		if ft.Kind() == reflect.Struct && ft != timeType {
+			child, ok := seen[ft]
+			if !ok {
+				child = &Object{Name: ft.Name(), Fields: map[string]*Field{}}
+				addModelFields(child, ft, seen)
+			}
+			f.Type = child
+		}
+		obj.Fields[key] = f
+	}
+}
+
+// Schema - Gốc của GraphQL API (chỉ có Query)
+type Schema struct {
+	Query         *Object
+	MaxDepth      int
+	MaxComplexity int
+}
+
+// NewSchema tạo schema với giới hạn mặc định
+func NewSchema(query *Object) *Schema {
+	return &Schema{Query: query, MaxDepth: DefaultMaxDepth, MaxComplexity: DefaultMaxComplexity}
+}
+
+// Request - Body của một GraphQL request
+type Request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName,omitempty"`
+	Variables     map[string]interface{} `json:"variables,omitempty"`
+}
+
+// Error - Lỗi trả về theo chuẩn GraphQL
+type Error struct {
+	Message string        `json:"message"`
+	Path    []interface{} `json:"path,omitempty"`
+}
+
+// Response - Kết quả thực thi
+type Response struct {
+	Data   interface{} `json:"data"`
+	Errors []Error     `json:"errors,omitempty"`
+}
+
+func errorResponse(format string, args ...interface{}) *Response {
+	return &Response{Errors: []Error{{Message: fmt.Sprintf(format, args...)}}}
+}
@@ -0,0 +1,85 @@
+package health
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/fpt-event-services/common/scheduler"
+)
+
+// Migration - Migration mới nhất mà code cần, nhận biết qua một cột (hoặc index) do nó tạo ra
+// (repo chưa có bảng ghi lịch sử migration)
+type Migration struct {
+	Name   string
+	Table  string
+	Column string
+	Index  string // Khác rỗng: kiểm tra index thay cho cột (migration chỉ thêm index)
+}
+
+// LatestMigration - Cập nhật mỗi khi thêm migration vào Database/migrations
+var LatestMigration = Migration{Name: "059_booking_queue_entry", Table: "booking_queue_entry", Column: "admitted_until"}
+
+// Database - Ping DB và báo số kết nối của pool
+func Database(conn *sql.DB) CheckFunc {
+	return func(ctx context.Context) (map[string]interface{}, error) {
+		if conn == nil {
+			return nil, errors.New("database not initialized")
+		}
+		if err := conn.PingContext(ctx); err != nil {
+			return nil, err
+		}
+		stats := conn.Stats()
+		return map[string]interface{}{
+			"openConnections": stats.OpenConnections,
+			"inUse":           stats.InUse,
+		}, nil
+	}
+}
+
+// Migrations - Schema đã chạy tới migration m chưa
+func Migrations(conn *sql.DB, m Migration) CheckFunc {
+	return func(ctx context.Context) (map[string]interface{}, error) {
+		detail := map[string]interface{}{"expected": m.Name}
+		if conn == nil {
+			return detail, errors.New("database not initialized")
+		}
+		query := `
+			SELECT COUNT(*) FROM information_schema.columns
+			WHERE table_schema = DATABASE() AND LOWER(table_name) = ? AND LOWER(column_name) = LOWER(?)
+		`
+		object := m.Column
+		if m.Index != "" {
+			query = `
+			SELECT COUNT(*) FROM information_schema.statistics
+			WHERE table_schema = DATABASE() AND LOWER(table_name) = ? AND LOWER(index_name) = LOWER(?)
+		`
+			object = m.Index
+		}
+		var n int
+		err := conn.QueryRowContext(ctx, query, m.Table, object).Scan(&n)
+		if err != nil {
+			return detail, err
+		}
+		if n == 0 {
+			return detail, fmt.Errorf("migration %s has not been applied", m.Name)
+		}
+		return detail, nil
+	}
+}
+
+// Schedulers - Các job định kỳ đã đăng ký và vòng lặp lập lịch đã chạy
+func Schedulers(m *scheduler.Manager) CheckFunc {
+	return func(ctx context.Context) (map[string]interface{}, error) {
+		jobs := len(m.Names())
+		detail := map[string]interface{}{"jobs": jobs}
+		if jobs == 0 {
+			return detail, errors.New("no scheduled jobs registered")
+		}
+		if !m.Started() {
+			return detail, errors.New("scheduler not started")
+		}
+		return detail, nil
+	}
+}
@@ -0,0 +1,175 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// ============================================================
+// HEALTH - Liveness / readiness cho ALB, ECS, Kubernetes
+//   - live:  process còn phục vụ request (không gọi dependency nào)
+//   - ready: các dependency đã sẵn sàng (DB, migration, scheduler...);
+//     check bắt buộc lỗi → 503 để load balancer không chuyển traffic tới
+//     instance khởi động dở
+// HEALTH_READY_REQUIRED: danh sách check bắt buộc, cách nhau bởi dấu phẩy
+// (không đặt = tất cả, "none" = chỉ báo cáo, luôn 200)
+// ============================================================
+
+const (
+	StatusUp       = "up"
+	StatusDown     = "down"
+	StatusLive     = "live"
+	StatusReady    = "ready"
+	StatusNotReady = "not_ready"
+)
+
+// defaultCheckTimeout - Thời gian tối đa của một check
+const defaultCheckTimeout = 2 * time.Second
+
+// startedAt - Thời điểm process khởi động (uptime của live)
+var startedAt = time.Now()
+
+// CheckFunc - Kiểm tra một dependency; detail (có thể nil) được trả kèm kết quả
+type CheckFunc func(ctx context.Context) (map[string]interface{}, error)
+
+// Result - Kết quả của một check
+type Result struct {
+	Status    string                 `json:"status"`
+	Required  bool                   `json:"required"`
+	LatencyMs int64                  `json:"latencyMs"`
+	Error     string                 `json:"error,omitempty"`
+	Detail    map[string]interface{} `json:"detail,omitempty"`
+}
+
+// Report - Body của /health/live và /health/ready
+type Report struct {
+	Status        string            `json:"status"`
+	UptimeSeconds int64             `json:"uptimeSeconds"`
+	Checks        map[string]Result `json:"checks,omitempty"`
+	CheckedAt     time.Time         `json:"checkedAt"`
+}
+
+// HTTPStatus - 200 khi live/ready, 503 khi chưa sẵn sàng
+func (r Report) HTTPStatus() int {
+	if r.Status == StatusNotReady {
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusOK
+}
+
+type check struct {
+	name string
+	fn   CheckFunc
+}
+
+// Checker - Tập check của readiness theo thứ tự đăng ký
+type Checker struct {
+	checks   []check
+	required map[string]bool // nil = tất cả check đều bắt buộc
+	timeout  time.Duration
+}
+
+// NewChecker - required nil = mọi check bắt buộc; slice rỗng = không check nào bắt buộc
+func NewChecker(required []string) *Checker {
+	c := &Checker{timeout: defaultCheckTimeout}
+	if required != nil {
+		c.required = make(map[string]bool, len(required))
+		for _, name := range required {
+			c.required[name] = true
+		}
+	}
+	return c
+}
+
+// RequiredFromEnv - Đọc HEALTH_READY_REQUIRED (xem đầu file)
+func RequiredFromEnv() []string {
+	v := strings.TrimSpace(os.Getenv("HEALTH_READY_REQUIRED"))
+	switch {
+	case v == "":
+		return nil
+	case strings.EqualFold(v, "none"):
+		return []string{}
+	}
+	var names []string
+	for _, name := range strings.Split(v, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// Add đăng ký một check
+func (c *Checker) Add(name string, fn CheckFunc) {
+	c.checks = append(c.checks, check{name: name, fn: fn})
+}
+
+func (c *Checker) isRequired(name string) bool {
+	return c.required == nil || c.required[name]
+}
+
+// Live - Process còn chạy; không phụ thuộc DB để orchestrator không restart
+// instance chỉ vì dependency tạm lỗi
+func Live() Report {
+	now := time.Now()
+	return Report{
+		Status:        StatusLive,
+		UptimeSeconds: int64(now.Sub(startedAt).Seconds()),
+		CheckedAt:     now,
+	}
+}
+
+// Ready chạy lần lượt các check; not_ready khi có check bắt buộc lỗi
+func (c *Checker) Ready(ctx context.Context) Report {
+	report := Live()
+	report.Status = StatusReady
+	report.Checks = make(map[string]Result, len(c.checks))
+
+	for _, ch := range c.checks {
+		checkCtx, cancel := context.WithTimeout(ctx, c.timeout)
+		start := time.Now()
+		detail, err := ch.fn(checkCtx)
+		cancel()
+
+		result := Result{
+			Status:    StatusUp,
+			Required:  c.isRequired(ch.name),
+			LatencyMs: time.Since(start).Milliseconds(),
+			Detail:    detail,
+		}
+		if err != nil {
+			result.Status = StatusDown
+			result.Error = err.Error()
+			if result.Required {
+				report.Status = StatusNotReady
+			}
+		}
+		report.Checks[ch.name] = result
+	}
+	return report
+}
+
+// LiveHandler - GET /health/live
+func LiveHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		writeReport(w, Live())
+	}
+}
+
+// ReadyHandler - GET /health/ready
+func (c *Checker) ReadyHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		writeReport(w, c.Ready(r.Context()))
+	}
+}
+
+func writeReport(w http.ResponseWriter, report Report) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(report.HTTPStatus())
+	json.NewEncoder(w).Encode(report)
+}
@@ -0,0 +1,55 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func up(context.Context) (map[string]interface{}, error) { return nil, nil }
+
+func down(context.Context) (map[string]interface{}, error) { return nil, errors.New("boom") }
+
+func TestReadyRequiresAllChecksByDefault(t *testing.T) {
+	c := NewChecker(nil)
+	c.Add("database", up)
+	c.Add("schedulers", down)
+
+	report := c.Ready(context.Background())
+	if report.Status != StatusNotReady || report.HTTPStatus() != http.StatusServiceUnavailable {
+		t.Fatalf("status = %s (%d), want not_ready (503)", report.Status, report.HTTPStatus())
+	}
+	if r := report.Checks["schedulers"]; r.Status != StatusDown || r.Error != "boom" || !r.Required {
+		t.Errorf("schedulers = %+v", r)
+	}
+}
+
+func TestReadyIgnoresOptionalFailures(t *testing.T) {
+	c := NewChecker([]string{"database"})
+	c.Add("database", up)
+	c.Add("schedulers", down)
+
+	report := c.Ready(context.Background())
+	if report.Status != StatusReady || report.HTTPStatus() != http.StatusOK {
+		t.Fatalf("status = %s, want ready", report.Status)
+	}
+	if r := report.Checks["schedulers"]; r.Status != StatusDown || r.Required {
+		t.Errorf("optional failing check = %+v", r)
+	}
+}
+
+func TestRequiredFromEnv(t *testing.T) {
+	t.Setenv("HEALTH_READY_REQUIRED", "")
+	if RequiredFromEnv() != nil {
+		t.Error("unset must require every check")
+	}
+	t.Setenv("HEALTH_READY_REQUIRED", "none")
+	if got := RequiredFromEnv(); got == nil || len(got) != 0 {
+		t.Errorf("none = %v, want empty", got)
+	}
+	t.Setenv("HEALTH_READY_REQUIRED", " database, migrations ,")
+	if got := RequiredFromEnv(); len(got) != 2 || got[1] != "migrations" {
+		t.Errorf("list = %v", got)
+	}
+}
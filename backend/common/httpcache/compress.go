@@ -0,0 +1,186 @@
+package httpcache
+
+import (
+	"compress/flate"
+	"compress/gzip"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ============================================================
+// httpcache - Giảm băng thông cho các API bị poll (danh sách sự kiện, sơ đồ ghế)
+// - Compress: nén gzip/deflate theo Accept-Encoding (bọc ngoài mux)
+// - ETag: ETag theo nội dung + If-None-Match → 304 (bọc từng route GET)
+// ============================================================
+
+// MinCompressBytes - Body nhỏ hơn ngưỡng này gửi nguyên (nén không lợi hơn overhead)
+const MinCompressBytes = 1024
+
+var (
+	gzipPool = sync.Pool{New: func() any {
+		w, _ := gzip.NewWriterLevel(io.Discard, gzip.DefaultCompression)
+		return w
+	}}
+	flatePool = sync.Pool{New: func() any {
+		w, _ := flate.NewWriter(io.Discard, flate.DefaultCompression)
+		return w
+	}}
+)
+
+// Compress nén response khi client chấp nhận gzip hoặc deflate
+// Chỉ nén body dạng văn bản (JSON, text, XML) từ MinCompressBytes trở lên;
+// bỏ qua response đã có Content-Encoding và text/event-stream (SSE cần flush từng sự kiện)
+func Compress(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		encoding := negotiateEncoding(r.Header.Get("Accept-Encoding"))
+		if encoding == "" || r.Method == http.MethodHead {
+			next.ServeHTTP(w, r)
+			return
+		}
+		cw := &compressWriter{ResponseWriter: w, encoding: encoding, status: http.StatusOK}
+		defer cw.close()
+		next.ServeHTTP(cw, r)
+	})
+}
+
+// negotiateEncoding - "gzip", "deflate" hoặc "" theo Accept-Encoding (ưu tiên gzip, bỏ q=0)
+func negotiateEncoding(header string) string {
+	accepted := map[string]bool{}
+	for _, part := range strings.Split(header, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		name = strings.ToLower(strings.TrimSpace(name))
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if v, err := strconv.ParseFloat(q, 64); err == nil && v <= 0 {
+				continue
+			}
+		}
+		accepted[name] = true
+	}
+	switch {
+	case accepted["gzip"] || accepted["*"]:
+		return "gzip"
+	case accepted["deflate"]:
+		return "deflate"
+	}
+	return ""
+}
+
+// compressible - Content-Type dạng văn bản đáng nén
+func compressible(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	switch {
+	case mediaType == "text/event-stream":
+		return false
+	case strings.HasPrefix(mediaType, "text/"),
+		strings.HasSuffix(mediaType, "+json"), strings.HasSuffix(mediaType, "+xml"):
+		return true
+	}
+	switch mediaType {
+	case "application/json", "application/xml", "application/javascript", "image/svg+xml":
+		return true
+	}
+	return false
+}
+
+// compressWriter quyết định nén ở lần Write / Flush đầu tiên, khi đã biết header và kích thước
+type compressWriter struct {
+	http.ResponseWriter
+	encoding    string
+	status      int
+	wroteHeader bool
+	decided     bool
+	enc         io.WriteCloser // nil = ghi nguyên
+}
+
+func (cw *compressWriter) WriteHeader(code int) {
+	if cw.wroteHeader {
+		return
+	}
+	cw.wroteHeader = true
+	cw.status = code
+}
+
+func (cw *compressWriter) Write(p []byte) (int, error) {
+	if !cw.decided {
+		cw.decide(len(p))
+	}
+	if cw.enc != nil {
+		return cw.enc.Write(p)
+	}
+	return cw.ResponseWriter.Write(p)
+}
+
+func (cw *compressWriter) decide(firstWrite int) {
+	cw.decided = true
+	h := cw.ResponseWriter.Header()
+	if cw.status >= http.StatusOK && cw.status != http.StatusNoContent && cw.status != http.StatusNotModified &&
+		h.Get("Content-Encoding") == "" && compressible(h.Get("Content-Type")) {
+		h.Add("Vary", "Accept-Encoding")
+		size := firstWrite
+		if n, err := strconv.Atoi(h.Get("Content-Length")); err == nil {
+			size = n
+		}
+		if size >= MinCompressBytes {
+			h.Del("Content-Length")
+			h.Set("Content-Encoding", cw.encoding)
+			cw.enc = cw.newEncoder()
+		}
+	}
+	cw.ResponseWriter.WriteHeader(cw.status)
+}
+
+func (cw *compressWriter) newEncoder() io.WriteCloser {
+	if cw.encoding == "gzip" {
+		gz := gzipPool.Get().(*gzip.Writer)
+		gz.Reset(cw.ResponseWriter)
+		return gz
+	}
+	fl := flatePool.Get().(*flate.Writer)
+	fl.Reset(cw.ResponseWriter)
+	return fl
+}
+
+// Flush - http.Flusher: đẩy phần đã nén rồi flush writer gốc
+func (cw *compressWriter) Flush() {
+	if !cw.decided {
+		cw.decide(0)
+	}
+	if f, ok := cw.enc.(interface{ Flush() error }); ok {
+		f.Flush()
+	}
+	http.NewResponseController(cw.ResponseWriter).Flush()
+}
+
+// Unwrap cho http.ResponseController tìm writer gốc
+func (cw *compressWriter) Unwrap() http.ResponseWriter {
+	return cw.ResponseWriter
+}
+
+func (cw *compressWriter) close() {
+	if !cw.decided {
+		// Handler không ghi body (204, 304, lỗi rỗng...)
+		if cw.wroteHeader {
+			cw.ResponseWriter.WriteHeader(cw.status)
+		}
+		return
+	}
+	if cw.enc == nil {
+		return
+	}
+	cw.enc.Close()
+	switch enc := cw.enc.(type) {
+	case *gzip.Writer:
+		enc.Reset(io.Discard)
+		gzipPool.Put(enc)
+	case *flate.Writer:
+		enc.Reset(io.Discard)
+		flatePool.Put(enc)
+	}
+}
@@ -0,0 +1,81 @@
+package httpcache
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+)
+
+// ETag gắn ETag (weak, hash nội dung) cho response 200 của GET/HEAD và trả 304
+// khi If-None-Match khớp. Response được giữ trong bộ nhớ đến khi handler xong,
+// nên chỉ dùng cho route JSON thông thường (không dùng cho SSE / file lớn).
+// Route chưa đặt Cache-Control nhận "private, no-cache": trình duyệt luôn hỏi lại
+// server nhưng chỉ tải lại body khi nội dung đổi
+func ETag(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			next(w, r)
+			return
+		}
+		bw := &bufferWriter{ResponseWriter: w, status: http.StatusOK}
+		next(bw, r)
+
+		h := w.Header()
+		if bw.status != http.StatusOK || h.Get("ETag") != "" {
+			w.WriteHeader(bw.status)
+			w.Write(bw.buf.Bytes())
+			return
+		}
+		tag := contentTag(bw.buf.Bytes())
+		h.Set("ETag", tag)
+		if h.Get("Cache-Control") == "" {
+			h.Set("Cache-Control", "private, no-cache")
+		}
+		if noneMatch(r.Header.Get("If-None-Match"), tag) {
+			h.Del("Content-Length")
+			h.Del("Content-Type")
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write(bw.buf.Bytes())
+	}
+}
+
+// contentTag - W/"<16 ký tự hex sha256>"; weak vì Compress có thể đổi byte gửi đi
+func contentTag(body []byte) string {
+	sum := sha256.Sum256(body)
+	return `W/"` + hex.EncodeToString(sum[:8]) + `"`
+}
+
+// noneMatch - If-None-Match chứa tag (so sánh weak, hỗ trợ danh sách và "*")
+func noneMatch(header, tag string) bool {
+	if header == "" {
+		return false
+	}
+	want := strings.TrimPrefix(tag, "W/")
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == want {
+			return true
+		}
+	}
+	return false
+}
+
+// bufferWriter giữ status và body cho ETag
+type bufferWriter struct {
+	http.ResponseWriter
+	status int
+	buf    bytes.Buffer
+}
+
+func (bw *bufferWriter) WriteHeader(code int) {
+	bw.status = code
+}
+
+func (bw *bufferWriter) Write(p []byte) (int, error) {
+	return bw.buf.Write(p)
+}
@@ -0,0 +1,124 @@
+package httpcache
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func jsonHandler(body string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(body))
+	}
+}
+
+func TestNegotiateEncoding(t *testing.T) {
+	for header, want := range map[string]string{
+		"":                        "",
+		"gzip, deflate, br":       "gzip",
+		"deflate":                 "deflate",
+		"gzip;q=0, deflate;q=0.5": "deflate",
+		"br, *":                   "gzip",
+		"identity":                "",
+	} {
+		if got := negotiateEncoding(header); got != want {
+			t.Errorf("negotiateEncoding(%q) = %q, want %q", header, got, want)
+		}
+	}
+}
+
+func TestCompressLargeJSON(t *testing.T) {
+	body := `{"events":"` + strings.Repeat("a", 4*MinCompressBytes) + `"}`
+	req := httptest.NewRequest(http.MethodGet, "/api/events", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	rec := httptest.NewRecorder()
+	Compress(jsonHandler(body)).ServeHTTP(rec, req)
+
+	if rec.Header().Get("Content-Encoding") != "gzip" || rec.Header().Get("Vary") != "Accept-Encoding" {
+		t.Fatalf("headers = %v", rec.Header())
+	}
+	zr, err := gzip.NewReader(rec.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, _ := io.ReadAll(zr)
+	if string(got) != body {
+		t.Error("decompressed body differs")
+	}
+}
+
+func TestCompressSkipsSmallAndBinary(t *testing.T) {
+	for name, h := range map[string]http.HandlerFunc{
+		"small": jsonHandler(`{"ok":true}`),
+		"pdf": func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/pdf")
+			w.Write(make([]byte, 4*MinCompressBytes))
+		},
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Accept-Encoding", "gzip")
+		rec := httptest.NewRecorder()
+		Compress(h).ServeHTTP(rec, req)
+		if rec.Header().Get("Content-Encoding") != "" {
+			t.Errorf("%s: response must not be compressed", name)
+		}
+	}
+}
+
+func TestETagNotModified(t *testing.T) {
+	h := ETag(jsonHandler(`{"events":[]}`))
+
+	rec := httptest.NewRecorder()
+	h(rec, httptest.NewRequest(http.MethodGet, "/api/events/open", nil))
+	tag := rec.Header().Get("ETag")
+	if rec.Code != http.StatusOK || !strings.HasPrefix(tag, `W/"`) || rec.Body.String() != `{"events":[]}` {
+		t.Fatalf("first response = %d %q %q", rec.Code, tag, rec.Body.String())
+	}
+	if rec.Header().Get("Cache-Control") != "private, no-cache" {
+		t.Errorf("Cache-Control = %q", rec.Header().Get("Cache-Control"))
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/events/open", nil)
+	req.Header.Set("If-None-Match", `"other", `+strings.TrimPrefix(tag, "W/"))
+	rec = httptest.NewRecorder()
+	h(rec, req)
+	if rec.Code != http.StatusNotModified || rec.Body.Len() != 0 {
+		t.Errorf("revalidation = %d with %d bytes, want 304 without body", rec.Code, rec.Body.Len())
+	}
+}
+
+func TestETagSkipsErrors(t *testing.T) {
+	h := ETag(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "boom", http.StatusInternalServerError)
+	})
+	rec := httptest.NewRecorder()
+	h(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	if rec.Code != http.StatusInternalServerError || rec.Header().Get("ETag") != "" {
+		t.Errorf("error response = %d, ETag %q", rec.Code, rec.Header().Get("ETag"))
+	}
+}
+
+func TestIfMatchVersion(t *testing.T) {
+	if v, err := IfMatchVersion(VersionTag(7)); err != nil || v == nil || *v != 7 {
+		t.Errorf("VersionTag round trip = %v, %v", v, err)
+	}
+	for _, header := range []string{"", "*"} {
+		if v, err := IfMatchVersion(header); v != nil || err != nil {
+			t.Errorf("IfMatchVersion(%q) = %v, %v, want no check", header, v, err)
+		}
+	}
+	for _, header := range []string{`W/"v7"`, `"7"`, `"v0"`, `"vx"`, `v7`} {
+		if _, err := IfMatchVersion(header); err != ErrInvalidIfMatch {
+			t.Errorf("IfMatchVersion(%q) error = %v", header, err)
+		}
+	}
+	t.Setenv("IF_MATCH_REQUIRED", "true")
+	if _, err := IfMatchVersion(""); err != ErrIfMatchRequired {
+		t.Errorf("missing header with IF_MATCH_REQUIRED = %v", err)
+	}
+}
@@ -0,0 +1,56 @@
+package httpcache
+
+import (
+	"errors"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// ============================================================
+// Precondition - ETag theo version của bản ghi + If-Match cho API sửa
+// GET chi tiết trả ETag: "v<version>"; client gửi lại trong If-Match khi cập nhật,
+// repository so với version hiện tại và trả 412 nếu đã có người sửa trước
+// IF_MATCH_REQUIRED=true: thiếu If-Match bị từ chối (428) thay vì bỏ qua kiểm tra
+// ============================================================
+
+var (
+	// ErrIfMatchRequired - Thiếu If-Match khi IF_MATCH_REQUIRED=true (428)
+	ErrIfMatchRequired = errors.New("If-Match header is required")
+	// ErrInvalidIfMatch - If-Match không phải ETag do API này cấp (400)
+	ErrInvalidIfMatch = errors.New(`If-Match must be an ETag returned by the detail endpoint, e.g. "v3"`)
+)
+
+// VersionTag - ETag mạnh cho version của bản ghi
+func VersionTag(version int) string {
+	return `"v` + strconv.Itoa(version) + `"`
+}
+
+// IfMatchVersion - Version client mong đợi từ header If-Match
+// nil = không kiểm tra (không gửi header hoặc "*")
+func IfMatchVersion(header string) (*int, error) {
+	header = strings.TrimSpace(header)
+	switch header {
+	case "":
+		if os.Getenv("IF_MATCH_REQUIRED") == "true" {
+			return nil, ErrIfMatchRequired
+		}
+		return nil, nil
+	case "*":
+		return nil, nil
+	}
+	// If-Match so sánh mạnh: ETag weak (W/...) không bao giờ khớp nên coi là sai định dạng
+	tag, ok := strings.CutPrefix(header, `"v`)
+	if !ok {
+		return nil, ErrInvalidIfMatch
+	}
+	tag, ok = strings.CutSuffix(tag, `"`)
+	if !ok {
+		return nil, ErrInvalidIfMatch
+	}
+	version, err := strconv.Atoi(tag)
+	if err != nil || version < 1 {
+		return nil, ErrInvalidIfMatch
+	}
+	return &version, nil
+}
@@ -0,0 +1,122 @@
+package httpguard
+
+import (
+	"context"
+	"fmt"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// ============================================================
+// httpguard - Lớp bảo vệ HTTP dùng chung cho server local
+// - SecurityHeaders: nosniff, chống clickjacking, HSTS khi chạy qua TLS
+// - Policy: giới hạn kích thước body và Content-Type theo nhóm route
+// ============================================================
+
+// DefaultMaxBodyBytes - Giới hạn body JSON mặc định (1MB)
+const DefaultMaxBodyBytes int64 = 1 << 20
+
+// hstsMaxAge - 180 ngày
+const hstsMaxAge = 180 * 24 * 60 * 60
+
+// Policy - Giới hạn request của một nhóm route
+type Policy struct {
+	// Name: Tên nhóm (log / thông báo lỗi)
+	Name string
+	// MaxBodyBytes: Kích thước body tối đa; <= 0 = DefaultMaxBodyBytes
+	MaxBodyBytes int64
+	// ContentTypes: Media type chấp nhận cho request có body, hỗ trợ "image/*"; rỗng = không kiểm tra
+	ContentTypes []string
+}
+
+// JSONPolicy - Mặc định cho API: chỉ application/json, tối đa 1MB
+var JSONPolicy = Policy{
+	Name:         "json",
+	MaxBodyBytes: DefaultMaxBodyBytes,
+	ContentTypes: []string{"application/json"},
+}
+
+type policyKey struct{}
+
+// WithPolicy - Gắn policy riêng cho route (bọc ngoài corsMiddleware/authMiddleware)
+func WithPolicy(p Policy, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		next(w, r.WithContext(context.WithValue(r.Context(), policyKey{}, p)))
+	}
+}
+
+// PolicyFrom - Policy của route, JSONPolicy nếu route không khai báo
+func PolicyFrom(ctx context.Context) Policy {
+	if p, ok := ctx.Value(policyKey{}).(Policy); ok {
+		return p
+	}
+	return JSONPolicy
+}
+
+// EnforceBody kiểm tra Content-Type và giới hạn body theo policy
+// Trả về false khi đã ghi response lỗi (413/415), handler không được chạy tiếp
+func EnforceBody(w http.ResponseWriter, r *http.Request, p Policy) bool {
+	if !hasBody(r) {
+		return true
+	}
+	max := p.MaxBodyBytes
+	if max <= 0 {
+		max = DefaultMaxBodyBytes
+	}
+	if r.ContentLength > max {
+		http.Error(w, fmt.Sprintf("Request body too large (max %d bytes)", max), http.StatusRequestEntityTooLarge)
+		return false
+	}
+	if len(p.ContentTypes) > 0 && !ContentTypeAllowed(r.Header.Get("Content-Type"), p.ContentTypes) {
+		http.Error(w, "Unsupported Content-Type, expected "+strings.Join(p.ContentTypes, " or "), http.StatusUnsupportedMediaType)
+		return false
+	}
+	// Body không khai báo Content-Length (chunked) bị cắt khi vượt giới hạn
+	r.Body = http.MaxBytesReader(w, r.Body, max)
+	return true
+}
+
+// ContentTypeAllowed - "application/json; charset=utf-8" khớp "application/json", "image/png" khớp "image/*"
+func ContentTypeAllowed(contentType string, allowed []string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	for _, a := range allowed {
+		if a == mediaType || (strings.HasSuffix(a, "/*") && strings.HasPrefix(mediaType, strings.TrimSuffix(a, "*"))) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasBody - Request có body cần kiểm tra (GET/DELETE không body, POST rỗng bỏ qua)
+func hasBody(r *http.Request) bool {
+	if r.Body == nil || r.Body == http.NoBody {
+		return false
+	}
+	return r.ContentLength > 0 || (r.ContentLength < 0 && len(r.TransferEncoding) > 0)
+}
+
+// SecurityHeaders - Header bảo mật mặc định cho mọi response của server
+// HSTS chỉ gửi khi request đến qua HTTPS (trực tiếp hoặc qua proxy báo X-Forwarded-Proto)
+func SecurityHeaders(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h := w.Header()
+		h.Set("X-Content-Type-Options", "nosniff")
+		h.Set("X-Frame-Options", "DENY")
+		h.Set("Referrer-Policy", "strict-origin-when-cross-origin")
+		if IsTLS(r) {
+			h.Set("Strict-Transport-Security", "max-age="+strconv.Itoa(hstsMaxAge)+"; includeSubDomains")
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// IsTLS - Request đến qua HTTPS
+// Tin X-Forwarded-Proto là an toàn ở đây: trình duyệt bỏ qua HSTS nhận qua HTTP
+func IsTLS(r *http.Request) bool {
+	return r.TLS != nil || strings.EqualFold(r.Header.Get("X-Forwarded-Proto"), "https")
+}
@@ -0,0 +1,124 @@
+package httpguard
+
+import (
+	"crypto/tls"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestContentTypeAllowed(t *testing.T) {
+	allowed := []string{"application/json", "image/*"}
+	for _, ct := range []string{"application/json", "application/json; charset=utf-8", "image/png", "IMAGE/JPEG"} {
+		if !ContentTypeAllowed(ct, allowed) {
+			t.Errorf("%q must be allowed", ct)
+		}
+	}
+	for _, ct := range []string{"", "text/plain", "application/x-www-form-urlencoded", "multipart/form-data; boundary=x", "imagex/png"} {
+		if ContentTypeAllowed(ct, allowed) {
+			t.Errorf("%q must be rejected", ct)
+		}
+	}
+}
+
+func guarded(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if EnforceBody(w, r, PolicyFrom(r.Context())) {
+			next(w, r)
+		}
+	}
+}
+
+func TestEnforceBody(t *testing.T) {
+	var read int
+	h := guarded(func(w http.ResponseWriter, r *http.Request) {
+		b, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		read = len(b)
+	})
+
+	cases := []struct {
+		name        string
+		method      string
+		contentType string
+		body        string
+		policy      *Policy
+		want        int
+	}{
+		{"json ok", http.MethodPost, "application/json", `{"a":1}`, nil, http.StatusOK},
+		{"get without body", http.MethodGet, "", "", nil, http.StatusOK},
+		{"empty post", http.MethodPost, "", "", nil, http.StatusOK},
+		{"form rejected", http.MethodPost, "application/x-www-form-urlencoded", "a=1", nil, http.StatusUnsupportedMediaType},
+		{"missing content type", http.MethodPut, "", `{"a":1}`, nil, http.StatusUnsupportedMediaType},
+		{"too large", http.MethodPost, "application/json", strings.Repeat("x", int(DefaultMaxBodyBytes)+1), nil, http.StatusRequestEntityTooLarge},
+		{"upload group", http.MethodPost, "image/png", strings.Repeat("x", int(DefaultMaxBodyBytes)+1),
+			&Policy{Name: "upload", MaxBodyBytes: 4 << 20, ContentTypes: []string{"image/*"}}, http.StatusOK},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, "/api/x", strings.NewReader(tc.body))
+			if tc.body == "" {
+				req.Body = http.NoBody
+				req.ContentLength = 0
+			}
+			if tc.contentType != "" {
+				req.Header.Set("Content-Type", tc.contentType)
+			}
+			handler := h
+			if tc.policy != nil {
+				handler = WithPolicy(*tc.policy, h)
+			}
+			rec := httptest.NewRecorder()
+			handler(rec, req)
+			if rec.Code != tc.want {
+				t.Fatalf("status = %d, want %d (%s)", rec.Code, tc.want, rec.Body.String())
+			}
+			if tc.want == http.StatusOK && read != len(tc.body) {
+				t.Fatalf("handler read %d bytes, want %d", read, len(tc.body))
+			}
+		})
+	}
+}
+
+func TestEnforceBodyChunked(t *testing.T) {
+	h := guarded(func(w http.ResponseWriter, r *http.Request) {
+		if _, err := io.ReadAll(r.Body); err != nil {
+			http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+		}
+	})
+	req := httptest.NewRequest(http.MethodPost, "/api/x", strings.NewReader(strings.Repeat("x", int(DefaultMaxBodyBytes)+1)))
+	req.ContentLength = -1
+	req.TransferEncoding = []string{"chunked"}
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	h(rec, req)
+	if rec.Code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("status = %d, want 413", rec.Code)
+	}
+}
+
+func TestSecurityHeaders(t *testing.T) {
+	h := SecurityHeaders(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/health", nil))
+	if rec.Header().Get("X-Content-Type-Options") != "nosniff" || rec.Header().Get("X-Frame-Options") != "DENY" {
+		t.Fatalf("missing security headers: %v", rec.Header())
+	}
+	if rec.Header().Get("Strict-Transport-Security") != "" {
+		t.Fatal("HSTS must not be sent over plain HTTP")
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/health", nil)
+	req.TLS = &tls.ConnectionState{}
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if !strings.HasPrefix(rec.Header().Get("Strict-Transport-Security"), "max-age=") {
+		t.Fatal("HSTS missing over TLS")
+	}
+}
@@ -0,0 +1,197 @@
+package imageproc
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	_ "image/gif"
+	"image/jpeg"
+	_ "image/png"
+	"net/http"
+)
+
+// ============================================================
+// Image processing cho banner sự kiện
+// Validate định dạng/kích thước và sinh các bản resize (thumbnail, card, hero)
+// Chỉ dùng thư viện chuẩn: decode JPEG/PNG/GIF, encode JPEG
+// ============================================================
+
+const (
+	// MaxUploadBytes - Dung lượng tối đa của ảnh gốc (10MB)
+	MaxUploadBytes = 10 << 20
+
+	// MinWidth/MinHeight - Kích thước tối thiểu để banner không bị vỡ khi hiển thị hero
+	MinWidth  = 640
+	MinHeight = 320
+
+	// MaxWidth/MaxHeight - Chặn ảnh quá lớn (decompression bomb)
+	MaxWidth  = 8000
+	MaxHeight = 8000
+
+	jpegQuality = 85
+)
+
+// Variant mô tả một bản resize của banner
+type Variant struct {
+	Name  string // thumbnail, card, hero
+	Width int    // Chiều rộng đích (chiều cao giữ nguyên tỉ lệ)
+}
+
+// DefaultVariants - Các bản resize dùng cho listing/card/detail
+var DefaultVariants = []Variant{
+	{Name: "thumbnail", Width: 320},
+	{Name: "card", Width: 640},
+	{Name: "hero", Width: 1600},
+}
+
+// allowedContentTypes - Chỉ chấp nhận các định dạng decode được bằng stdlib
+var allowedContentTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/gif":  true,
+}
+
+// ValidationError - Lỗi validate ảnh (trả về 400 cho client)
+type ValidationError struct {
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	return e.Message
+}
+
+// Decode kiểm tra định dạng + kích thước và decode ảnh
+// Trả về image đã decode và content type thực tế (sniff từ bytes, không tin header client)
+func Decode(data []byte) (image.Image, string, error) {
+	if len(data) == 0 {
+		return nil, "", &ValidationError{Message: "Ảnh trống"}
+	}
+	if len(data) > MaxUploadBytes {
+		return nil, "", &ValidationError{Message: fmt.Sprintf("Ảnh vượt quá dung lượng cho phép (%dMB)", MaxUploadBytes>>20)}
+	}
+
+	contentType := http.DetectContentType(data)
+	if !allowedContentTypes[contentType] {
+		return nil, "", &ValidationError{Message: fmt.Sprintf("Định dạng ảnh không được hỗ trợ: %s (chỉ nhận JPEG, PNG, GIF)", contentType)}
+	}
+
+	// Đọc header trước để chặn ảnh quá lớn trước khi decode toàn bộ
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", &ValidationError{Message: "Không đọc được thông tin ảnh"}
+	}
+	if cfg.Width < MinWidth || cfg.Height < MinHeight {
+		return nil, "", &ValidationError{Message: fmt.Sprintf("Ảnh quá nhỏ (%dx%d), tối thiểu %dx%d", cfg.Width, cfg.Height, MinWidth, MinHeight)}
+	}
+	if cfg.Width > MaxWidth || cfg.Height > MaxHeight {
+		return nil, "", &ValidationError{Message: fmt.Sprintf("Ảnh quá lớn (%dx%d), tối đa %dx%d", cfg.Width, cfg.Height, MaxWidth, MaxHeight)}
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", &ValidationError{Message: "Ảnh bị lỗi, không thể decode"}
+	}
+	return img, contentType, nil
+}
+
+// ResizeToWidth resize ảnh về chiều rộng cho trước, giữ nguyên tỉ lệ (bilinear)
+// Không phóng to: nếu ảnh gốc nhỏ hơn width thì giữ nguyên kích thước
+func ResizeToWidth(src image.Image, width int) image.Image {
+	b := src.Bounds()
+	srcW, srcH := b.Dx(), b.Dy()
+	if width <= 0 || width >= srcW {
+		width = srcW
+	}
+	height := srcH * width / srcW
+	if height < 1 {
+		height = 1
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	if width == srcW && height == srcH {
+		draw.Draw(dst, dst.Bounds(), src, b.Min, draw.Src)
+		return dst
+	}
+
+	xRatio := float64(srcW) / float64(width)
+	yRatio := float64(srcH) / float64(height)
+
+	for y := 0; y < height; y++ {
+		sy := (float64(y)+0.5)*yRatio - 0.5
+		y0 := clamp(int(sy), 0, srcH-1)
+		y1 := clamp(y0+1, 0, srcH-1)
+		fy := sy - float64(y0)
+		if fy < 0 {
+			fy = 0
+		}
+
+		for x := 0; x < width; x++ {
+			sx := (float64(x)+0.5)*xRatio - 0.5
+			x0 := clamp(int(sx), 0, srcW-1)
+			x1 := clamp(x0+1, 0, srcW-1)
+			fx := sx - float64(x0)
+			if fx < 0 {
+				fx = 0
+			}
+
+			c00 := color.RGBAModel.Convert(src.At(b.Min.X+x0, b.Min.Y+y0)).(color.RGBA)
+			c10 := color.RGBAModel.Convert(src.At(b.Min.X+x1, b.Min.Y+y0)).(color.RGBA)
+			c01 := color.RGBAModel.Convert(src.At(b.Min.X+x0, b.Min.Y+y1)).(color.RGBA)
+			c11 := color.RGBAModel.Convert(src.At(b.Min.X+x1, b.Min.Y+y1)).(color.RGBA)
+
+			dst.SetRGBA(x, y, color.RGBA{
+				R: lerp2(c00.R, c10.R, c01.R, c11.R, fx, fy),
+				G: lerp2(c00.G, c10.G, c01.G, c11.G, fx, fy),
+				B: lerp2(c00.B, c10.B, c01.B, c11.B, fx, fy),
+				A: lerp2(c00.A, c10.A, c01.A, c11.A, fx, fy),
+			})
+		}
+	}
+	return dst
+}
+
+// EncodeJPEG encode ảnh thành JPEG (nền trắng cho vùng trong suốt của PNG/GIF)
+func EncodeJPEG(img image.Image) ([]byte, error) {
+	b := img.Bounds()
+	flat := image.NewRGBA(b)
+	draw.Draw(flat, b, &image.Uniform{C: color.White}, image.Point{}, draw.Src)
+	draw.Draw(flat, b, img, b.Min, draw.Over)
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, flat, &jpeg.Options{Quality: jpegQuality}); err != nil {
+		return nil, fmt.Errorf("failed to encode jpeg: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// GenerateVariants sinh tất cả bản resize theo DefaultVariants
+// Trả về map: tên variant -> JPEG bytes
+func GenerateVariants(img image.Image) (map[string][]byte, error) {
+	result := make(map[string][]byte, len(DefaultVariants))
+	for _, v := range DefaultVariants {
+		data, err := EncodeJPEG(ResizeToWidth(img, v.Width))
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate %s variant: %w", v.Name, err)
+		}
+		result[v.Name] = data
+	}
+	return result, nil
+}
+
+func clamp(v, lo, hi int) int {
+	if v < lo {
+		return lo
+	}
+	if v > hi {
+		return hi
+	}
+	return v
+}
+
+func lerp2(c00, c10, c01, c11 uint8, fx, fy float64) uint8 {
+	top := float64(c00)*(1-fx) + float64(c10)*fx
+	bottom := float64(c01)*(1-fx) + float64(c11)*fx
+	return uint8(top*(1-fy) + bottom*fy + 0.5)
+}
@@ -0,0 +1,101 @@
+package imageproc
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+)
+
+func makePNG(t *testing.T, w, h int) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 128, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("png encode: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestDecode(t *testing.T) {
+	t.Run("Valid PNG", func(t *testing.T) {
+		img, contentType, err := Decode(makePNG(t, 1200, 600))
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if contentType != "image/png" {
+			t.Errorf("expected image/png, got %s", contentType)
+		}
+		if img.Bounds().Dx() != 1200 || img.Bounds().Dy() != 600 {
+			t.Errorf("unexpected bounds %v", img.Bounds())
+		}
+	})
+
+	t.Run("Too small", func(t *testing.T) {
+		_, _, err := Decode(makePNG(t, 100, 100))
+		if _, ok := err.(*ValidationError); !ok {
+			t.Fatalf("expected ValidationError, got %v", err)
+		}
+	})
+
+	t.Run("Not an image", func(t *testing.T) {
+		_, _, err := Decode([]byte("<html>not an image</html>"))
+		if _, ok := err.(*ValidationError); !ok {
+			t.Fatalf("expected ValidationError, got %v", err)
+		}
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		if _, _, err := Decode(nil); err == nil {
+			t.Fatal("expected error for empty data")
+		}
+	})
+}
+
+func TestResizeToWidth(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 1600, 800))
+
+	resized := ResizeToWidth(src, 320)
+	if resized.Bounds().Dx() != 320 || resized.Bounds().Dy() != 160 {
+		t.Errorf("expected 320x160, got %v", resized.Bounds())
+	}
+
+	// Không phóng to ảnh nhỏ hơn width đích
+	notUpscaled := ResizeToWidth(src, 4000)
+	if notUpscaled.Bounds().Dx() != 1600 {
+		t.Errorf("expected width to stay 1600, got %d", notUpscaled.Bounds().Dx())
+	}
+}
+
+func TestGenerateVariants(t *testing.T) {
+	img, _, err := Decode(makePNG(t, 2000, 1000))
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+
+	variants, err := GenerateVariants(img)
+	if err != nil {
+		t.Fatalf("GenerateVariants: %v", err)
+	}
+
+	for _, v := range DefaultVariants {
+		data, ok := variants[v.Name]
+		if !ok {
+			t.Fatalf("missing variant %s", v.Name)
+		}
+		decoded, err := jpeg.Decode(bytes.NewReader(data))
+		if err != nil {
+			t.Fatalf("variant %s is not a valid JPEG: %v", v.Name, err)
+		}
+		if decoded.Bounds().Dx() != v.Width {
+			t.Errorf("variant %s: expected width %d, got %d", v.Name, v.Width, decoded.Bounds().Dx())
+		}
+	}
+}
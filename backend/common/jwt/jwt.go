@@ -17,15 +17,24 @@ type Claims struct {
 }
 
 var (
-	// JWT secret key from environment variable
-	secretKey = []byte(getEnv("JWT_SECRET", "m5b0u7V6Zy0pZr5j3z2mJ8jJj2cZbYxJw0l0pWlCk8hM6m8cJz7JbZc+oQd8hQ1f"))
-
 	// Token expiration time (7 days)
 	tokenExpiration = 7 * 24 * time.Hour
 )
 
+// secretKey đọc JWT_SECRET mỗi lần ký/kiểm tra (không cache lúc init) để nhận giá trị
+// nạp từ .env / secrets provider sau khi package khởi tạo và giá trị đã xoay
+func secretKey() []byte {
+	return []byte(getEnv("JWT_SECRET", "m5b0u7V6Zy0pZr5j3z2mJ8jJj2cZbYxJw0l0pWlCk8hM6m8cJz7JbZc+oQd8hQ1f"))
+}
+
 // GenerateToken generates a JWT token for a user (khớp JwtUtils.generateToken)
 func GenerateToken(userID int, email, role string) (string, error) {
+	return GenerateSessionToken(userID, email, role, "")
+}
+
+// GenerateSessionToken - Token gắn với phiên đăng nhập (claim jti = sessionID, common/session)
+// sessionID rỗng: token không gắn phiên
+func GenerateSessionToken(userID int, email, role, sessionID string) (string, error) {
 	now := time.Now()
 
 	claims := Claims{
@@ -33,13 +42,19 @@ func GenerateToken(userID int, email, role string) (string, error) {
 		Email:  email,
 		Role:   role,
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        sessionID,
 			IssuedAt:  jwt.NewNumericDate(now),
 			ExpiresAt: jwt.NewNumericDate(now.Add(tokenExpiration)),
 		},
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString(secretKey)
+	return token.SignedString(secretKey())
+}
+
+// Expiration - Thời hạn của token (cũng là thời hạn của phiên đăng nhập)
+func Expiration() time.Duration {
+	return tokenExpiration
 }
 
 // ValidateToken validates a JWT token and returns claims
@@ -49,7 +64,7 @@ func ValidateToken(tokenString string) (*Claims, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, errors.New("invalid signing method")
 		}
-		return secretKey, nil
+		return secretKey(), nil
 	})
 
 	if err != nil {
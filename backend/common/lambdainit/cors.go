@@ -0,0 +1,75 @@
+package lambdainit
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/fpt-event-services/common/config"
+)
+
+// corsConfig - Cấu hình CORS của execution environment (CORS_ORIGINS, APP_ENV...), đọc một lần
+var corsConfig = sync.OnceValues(config.CORSConfigFromEnv)
+
+// ============================================================
+// CORS bọc handler của entrypoint Lambda bằng cùng config.CORSConfig với main.go:
+// chỉ origin trong danh sách nhận Access-Control-Allow-Origin (kèm credentials nếu bật),
+// preflight OPTIONS trả 204 mà không gọi handler
+// Cấu hình CORS sai: không gắn header nào (trình duyệt chặn) thay vì mở cho mọi origin
+// ============================================================
+func CORS(next Handler) Handler {
+	return func(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		origin := requestHeader(request, "Origin")
+		preflight := request.HTTPMethod == http.MethodOptions && requestHeader(request, "Access-Control-Request-Method") != ""
+
+		var cors map[string]string
+		if cfg, err := corsConfig(); err != nil {
+			log.Printf("[LAMBDA] Invalid CORS configuration: %v", err)
+		} else {
+			cors = cfg.Headers(origin, preflight)
+		}
+
+		if preflight {
+			return events.APIGatewayProxyResponse{
+				StatusCode: http.StatusNoContent,
+				Headers:    withCORS(nil, cors),
+			}, nil
+		}
+
+		resp, err := next(ctx, request)
+		resp.Headers = withCORS(resp.Headers, cors)
+		return resp, err
+	}
+}
+
+// withCORS thêm header CORS và Vary: Origin vào headers của response
+func withCORS(headers, cors map[string]string) map[string]string {
+	if headers == nil {
+		headers = make(map[string]string, len(cors)+1)
+	}
+	for key, value := range cors {
+		headers[key] = value
+	}
+	if vary := headers["Vary"]; vary == "" {
+		headers["Vary"] = "Origin"
+	} else if !strings.Contains(vary, "Origin") {
+		headers["Vary"] = vary + ", Origin"
+	}
+	return headers
+}
+
+// requestHeader - Header của request API Gateway, không phân biệt hoa thường
+func requestHeader(request events.APIGatewayProxyRequest, name string) string {
+	if v, ok := request.Headers[name]; ok {
+		return v
+	}
+	for key, v := range request.Headers {
+		if strings.EqualFold(key, name) {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,41 @@
+package lambdainit
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+func TestCORS(t *testing.T) {
+	t.Setenv("APP_ENV", "")
+	t.Setenv("CORS_ORIGINS", "https://events.fpt.edu.vn")
+	calls := 0
+	h := CORS(func(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		calls++
+		return events.APIGatewayProxyResponse{StatusCode: http.StatusOK, Headers: map[string]string{"Content-Type": "application/json"}}, nil
+	})
+
+	resp, _ := h(context.Background(), events.APIGatewayProxyRequest{HTTPMethod: "GET", Headers: map[string]string{"origin": "https://events.fpt.edu.vn"}})
+	if resp.Headers["Access-Control-Allow-Origin"] != "https://events.fpt.edu.vn" || resp.Headers["Access-Control-Allow-Credentials"] != "true" ||
+		resp.Headers["Vary"] != "Origin" || resp.Headers["Content-Type"] != "application/json" {
+		t.Errorf("allowed origin headers = %v", resp.Headers)
+	}
+
+	resp, _ = h(context.Background(), events.APIGatewayProxyRequest{HTTPMethod: "GET", Headers: map[string]string{"Origin": "https://evil.example.com"}})
+	if _, ok := resp.Headers["Access-Control-Allow-Origin"]; ok {
+		t.Errorf("disallowed origin got CORS headers: %v", resp.Headers)
+	}
+
+	resp, _ = h(context.Background(), events.APIGatewayProxyRequest{HTTPMethod: "OPTIONS", Headers: map[string]string{
+		"Origin":                        "https://events.fpt.edu.vn",
+		"Access-Control-Request-Method": "POST",
+	}})
+	if resp.StatusCode != http.StatusNoContent || resp.Headers["Access-Control-Allow-Methods"] == "" {
+		t.Errorf("preflight = %d %v", resp.StatusCode, resp.Headers)
+	}
+	if calls != 2 {
+		t.Errorf("handler called %d times, want 2 (preflight must not reach the handler)", calls)
+	}
+}
@@ -0,0 +1,83 @@
+package lambdainit
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"sync"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/fpt-event-services/common/config"
+	"github.com/fpt-event-services/common/db"
+)
+
+// ============================================================
+// LAMBDAINIT - Khởi tạo execution environment của Lambda (secret + DB)
+// Gọi Warm() trong init(): với provisioned concurrency phần này chạy trước
+// request đầu tiên nên request không phải chờ kết nối MySQL.
+// Handler gọi Ready() ở mỗi request: đã khởi tạo xong thì trả về ngay,
+// lần trước lỗi (DB chưa sẵn sàng...) thì thử lại thay vì log.Fatal
+// làm môi trường bị hủy và phải cold start lại từ đầu
+//
+// Repository dùng chung của mỗi service (repository.Default*Repository = sync.OnceValue)
+// được tạo ở lần gọi đầu tiên và giữ *sql.DB lúc đó, nên chỉ gọi chúng sau Ready()
+// (main.go: sau db.InitDB; Lambda: bên trong build của Lazy)
+//
+// Header CORS do CORS() gắn ở entrypoint (cùng config.CORSConfig với main.go), handler không tự gắn
+// ============================================================
+
+var (
+	mu    sync.Mutex
+	ready bool
+)
+
+// Ready - Nạp secret và kết nối DB một lần cho cả execution environment
+func Ready(ctx context.Context) error {
+	mu.Lock()
+	defer mu.Unlock()
+	if ready {
+		return nil
+	}
+	if err := config.LoadSecrets(ctx); err != nil {
+		return err
+	}
+	if err := db.Ensure(); err != nil {
+		return err
+	}
+	ready = true
+	return nil
+}
+
+// Warm - Ready() lúc init; lỗi chỉ ghi log, request đầu tiên sẽ thử lại
+func Warm() {
+	if err := Ready(context.Background()); err != nil {
+		log.Printf("[LAMBDA] Init failed, will retry on first request: %v", err)
+	}
+}
+
+// Handler - Handler API Gateway dùng chung của execution environment
+type Handler func(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error)
+
+// Lazy bọc hàm tạo handler: handler chỉ được tạo (một lần, sync.Once) sau khi Ready()
+// thành công, nên repository bên trong luôn giữ kết nối DB đã mở
+func Lazy(build func() Handler) Handler {
+	var (
+		once    sync.Once
+		handler Handler
+	)
+	return func(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		if err := Ready(ctx); err != nil {
+			log.Printf("[LAMBDA] Not ready: %v", err)
+			return events.APIGatewayProxyResponse{
+				StatusCode: http.StatusServiceUnavailable,
+				Body:       `{"error":"Service Unavailable"}`,
+				Headers: map[string]string{
+					"Content-Type": "application/json",
+					"Retry-After":  "1",
+				},
+			}, nil
+		}
+		once.Do(func() { handler = build() })
+		return handler(ctx, request)
+	}
+}
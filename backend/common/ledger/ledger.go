@@ -0,0 +1,227 @@
+package ledger
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ============================================================
+// LEDGER - Sổ cái kế toán kép cho hóa đơn, ví và hoàn tiền
+// Mỗi nghiệp vụ tiền tệ ghi một Entry gồm các Posting Nợ / Có cân bằng,
+// trong CÙNG transaction với thay đổi Bill / Users.Wallet / Report (migration 032)
+//
+// Tài khoản:
+//   - USER_WALLET      (nợ phải trả, theo user_id) - số dư = users.Wallet
+//   - PLATFORM_REVENUE (doanh thu)                  - tiền vé trừ hoàn tiền
+//   - VNPAY_CLEARING   (phải thu)                   - tiền VNPay sẽ chuyển về
+//   - REFUNDS_PAYABLE  (nợ phải trả)                - hoàn tiền đã duyệt chưa trả
+//   - OPENING_BALANCE  (vốn)                        - số dư ví trước khi có sổ cái
+// ============================================================
+
+// Tài khoản
+const (
+	AccountUserWallet      = "USER_WALLET"
+	AccountPlatformRevenue = "PLATFORM_REVENUE"
+	AccountVNPayClearing   = "VNPAY_CLEARING"
+	AccountRefundsPayable  = "REFUNDS_PAYABLE"
+	AccountOpeningBalance  = "OPENING_BALANCE"
+)
+
+// Loại nghiệp vụ (Ledger_Entry.entry_type)
+const (
+	EntryBillPayment    = "BILL_PAYMENT"
+	EntryTopup          = "TOPUP"
+	EntryRefund         = "REFUND"
+	EntryOpeningBalance = "OPENING_BALANCE"
+	EntryCashback       = "CASHBACK"
+)
+
+// ErrUnbalanced - Entry không hợp lệ (tổng Nợ khác tổng Có, dòng rỗng...)
+var ErrUnbalanced = errors.New("ledger entry is not balanced")
+
+// Posting - Một dòng Nợ hoặc Có (đơn vị: đồng, đúng một vế > 0)
+type Posting struct {
+	Account string
+	UserID  *int
+	Debit   int64
+	Credit  int64
+}
+
+// Entry - Một nghiệp vụ và các dòng của nó
+type Entry struct {
+	Type          string
+	ReferenceType string // BILL, REPORT, USER...
+	ReferenceID   int
+	Description   string
+	Postings      []Posting
+}
+
+// Execer - *sql.Tx hoặc *sql.DB
+type Execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// Validate - Kiểm tra entry cân bằng trước khi ghi
+func (e Entry) Validate() error {
+	if len(e.Postings) < 2 {
+		return fmt.Errorf("%w: at least 2 postings required", ErrUnbalanced)
+	}
+	var debit, credit int64
+	for _, p := range e.Postings {
+		if p.Debit < 0 || p.Credit < 0 || (p.Debit == 0) == (p.Credit == 0) {
+			return fmt.Errorf("%w: posting on %s must have exactly one positive side", ErrUnbalanced, p.Account)
+		}
+		if p.Account == AccountUserWallet && p.UserID == nil {
+			return fmt.Errorf("%w: %s posting needs a user", ErrUnbalanced, AccountUserWallet)
+		}
+		debit += p.Debit
+		credit += p.Credit
+	}
+	if debit != credit {
+		return fmt.Errorf("%w: debit %d != credit %d", ErrUnbalanced, debit, credit)
+	}
+	return nil
+}
+
+// Post - Ghi entry trong transaction của nghiệp vụ, trả về entry_id
+// Entry 0 đồng (vé miễn phí) không được ghi, trả về 0
+func Post(ctx context.Context, tx Execer, e Entry) (int64, error) {
+	if e.zero() {
+		return 0, nil
+	}
+	if err := e.Validate(); err != nil {
+		return 0, err
+	}
+	res, err := tx.ExecContext(ctx, `
+		INSERT INTO Ledger_Entry (entry_type, reference_type, reference_id, description)
+		VALUES (?, ?, ?, ?)
+	`, e.Type, e.ReferenceType, e.ReferenceID, e.Description)
+	if err != nil {
+		return 0, fmt.Errorf("failed to insert ledger entry: %w", err)
+	}
+	entryID, err := res.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get ledger entry ID: %w", err)
+	}
+	for _, p := range e.Postings {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO Ledger_Posting (entry_id, account, user_id, debit, credit)
+			VALUES (?, ?, ?, ?, ?)
+		`, entryID, p.Account, p.UserID, p.Debit, p.Credit); err != nil {
+			return 0, fmt.Errorf("failed to insert ledger posting: %w", err)
+		}
+	}
+	return entryID, nil
+}
+
+func (e Entry) zero() bool {
+	for _, p := range e.Postings {
+		if p.Debit != 0 || p.Credit != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// BillPayment - Thanh toán hóa đơn vé
+// VNPAY: Nợ VNPAY_CLEARING / Có PLATFORM_REVENUE; Wallet: Nợ USER_WALLET / Có PLATFORM_REVENUE
+func BillPayment(billID, userID int, method string, amount float64) Entry {
+	n := Dong(amount)
+	debit := Posting{Account: AccountVNPayClearing, Debit: n}
+	if method == "Wallet" {
+		debit = Posting{Account: AccountUserWallet, UserID: &userID, Debit: n}
+	}
+	return Entry{
+		Type:          EntryBillPayment,
+		ReferenceType: "BILL",
+		ReferenceID:   billID,
+		Description:   fmt.Sprintf("Bill #%d paid by %s", billID, method),
+		Postings: []Posting{
+			debit,
+			{Account: AccountPlatformRevenue, Credit: n},
+		},
+	}
+}
+
+// InstallmentPayment - Thanh toán một kỳ trả góp của hóa đơn (kỳ đặt cọc ghi bằng BillPayment)
+// Cùng tài khoản với BillPayment; tham chiếu BILL_INSTALLMENT để mỗi kỳ một bút toán
+func InstallmentPayment(installmentID, billID, userID int, method string, amount float64) Entry {
+	e := BillPayment(billID, userID, method, amount)
+	e.ReferenceType = "BILL_INSTALLMENT"
+	e.ReferenceID = installmentID
+	e.Description = fmt.Sprintf("Bill #%d installment #%d paid by %s", billID, installmentID, method)
+	return e
+}
+
+// Topup - Nạp ví qua VNPay: Nợ VNPAY_CLEARING / Có USER_WALLET
+func Topup(referenceID, userID int, amount float64) Entry {
+	n := Dong(amount)
+	return Entry{
+		Type:          EntryTopup,
+		ReferenceType: "TOPUP",
+		ReferenceID:   referenceID,
+		Description:   fmt.Sprintf("Wallet top-up for user #%d", userID),
+		Postings: []Posting{
+			{Account: AccountVNPayClearing, Debit: n},
+			{Account: AccountUserWallet, UserID: &userID, Credit: n},
+		},
+	}
+}
+
+// Refund - Hoàn tiền report đã duyệt vào ví
+// Ghi giảm doanh thu thành khoản phải trả, rồi trả ngay vào ví:
+// Nợ PLATFORM_REVENUE / Có REFUNDS_PAYABLE, Nợ REFUNDS_PAYABLE / Có USER_WALLET
+func Refund(reportID, userID int, amount float64) Entry {
+	n := Dong(amount)
+	return Entry{
+		Type:          EntryRefund,
+		ReferenceType: "REPORT",
+		ReferenceID:   reportID,
+		Description:   fmt.Sprintf("Refund for report #%d", reportID),
+		Postings: []Posting{
+			{Account: AccountPlatformRevenue, Debit: n},
+			{Account: AccountRefundsPayable, Credit: n},
+			{Account: AccountRefundsPayable, Debit: n},
+			{Account: AccountUserWallet, UserID: &userID, Credit: n},
+		},
+	}
+}
+
+// Cashback - Hoàn tiền khuyến khích tham dự khi check-out vé: Nợ PLATFORM_REVENUE / Có USER_WALLET
+func Cashback(ticketID, userID int, amount float64) Entry {
+	n := Dong(amount)
+	return Entry{
+		Type:          EntryCashback,
+		ReferenceType: "TICKET",
+		ReferenceID:   ticketID,
+		Description:   fmt.Sprintf("Attendance cashback for ticket #%d", ticketID),
+		Postings: []Posting{
+			{Account: AccountPlatformRevenue, Debit: n},
+			{Account: AccountUserWallet, UserID: &userID, Credit: n},
+		},
+	}
+}
+
+// OpeningBalance - Số dư ví có sẵn ngoài sổ cái (dữ liệu cũ, fixtures):
+// Nợ OPENING_BALANCE / Có USER_WALLET
+func OpeningBalance(referenceType string, referenceID, userID int, amount float64) Entry {
+	n := Dong(amount)
+	return Entry{
+		Type:          EntryOpeningBalance,
+		ReferenceType: referenceType,
+		ReferenceID:   referenceID,
+		Description:   fmt.Sprintf("Opening wallet balance for user #%d", userID),
+		Postings: []Posting{
+			{Account: AccountOpeningBalance, Debit: n},
+			{Account: AccountUserWallet, UserID: &userID, Credit: n},
+		},
+	}
+}
+
+// Dong - Làm tròn số tiền DECIMAL về đồng
+func Dong(amount float64) int64 {
+	return int64(math.Round(amount))
+}
@@ -0,0 +1,82 @@
+package ledger
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestBuiltEntriesAreBalanced(t *testing.T) {
+	for _, e := range []Entry{
+		BillPayment(1, 7, "VNPAY", 150000),
+		BillPayment(2, 7, "Wallet", 50000),
+		InstallmentPayment(5, 2, 7, "Wallet", 25000),
+		Topup(3, 7, 200000),
+		Refund(4, 7, 30000.4),
+		OpeningBalance("USER", 7, 7, 500000),
+		Cashback(9, 7, 15000),
+	} {
+		if err := e.Validate(); err != nil {
+			t.Errorf("%s #%d: %v", e.Type, e.ReferenceID, err)
+		}
+	}
+}
+
+func TestBillPaymentAccounts(t *testing.T) {
+	vnpay := BillPayment(1, 7, "VNPAY", 150000)
+	if vnpay.Postings[0].Account != AccountVNPayClearing || vnpay.Postings[1].Account != AccountPlatformRevenue {
+		t.Errorf("VNPAY postings = %+v", vnpay.Postings)
+	}
+	wallet := BillPayment(2, 7, "Wallet", 50000)
+	if p := wallet.Postings[0]; p.Account != AccountUserWallet || p.UserID == nil || *p.UserID != 7 || p.Debit != 50000 {
+		t.Errorf("Wallet debit posting = %+v", p)
+	}
+}
+
+func TestRefundSettlesPayable(t *testing.T) {
+	var payable int64
+	for _, p := range Refund(4, 7, 30000).Postings {
+		if p.Account == AccountRefundsPayable {
+			payable += p.Credit - p.Debit
+		}
+	}
+	if payable != 0 {
+		t.Errorf("refund paid into wallet must leave REFUNDS_PAYABLE at 0, got %d", payable)
+	}
+}
+
+func TestValidateRejects(t *testing.T) {
+	user := 7
+	tests := map[string]Entry{
+		"single posting": {Postings: []Posting{{Account: AccountPlatformRevenue, Credit: 1}}},
+		"unbalanced": {Postings: []Posting{
+			{Account: AccountVNPayClearing, Debit: 100},
+			{Account: AccountPlatformRevenue, Credit: 90},
+		}},
+		"both sides": {Postings: []Posting{
+			{Account: AccountVNPayClearing, Debit: 100, Credit: 100},
+			{Account: AccountPlatformRevenue, Credit: 0, Debit: 0},
+		}},
+		"wallet without user": {Postings: []Posting{
+			{Account: AccountUserWallet, Debit: 100},
+			{Account: AccountPlatformRevenue, Credit: 100},
+		}},
+		"negative": {Postings: []Posting{
+			{Account: AccountUserWallet, UserID: &user, Debit: -100},
+			{Account: AccountPlatformRevenue, Credit: -100},
+		}},
+	}
+	for name, e := range tests {
+		if err := e.Validate(); !errors.Is(err, ErrUnbalanced) {
+			t.Errorf("%s: Validate = %v, want ErrUnbalanced", name, err)
+		}
+	}
+}
+
+func TestZeroEntryIsSkipped(t *testing.T) {
+	if !BillPayment(1, 7, "VNPAY", 0).zero() {
+		t.Error("free bill must be skipped")
+	}
+	if BillPayment(1, 7, "VNPAY", 1).zero() {
+		t.Error("paid bill must be posted")
+	}
+}
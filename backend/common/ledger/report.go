@@ -0,0 +1,153 @@
+package ledger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/fpt-event-services/common/db"
+)
+
+// ============================================================
+// Báo cáo sổ cái - bảng cân đối thử và kiểm tra bất biến
+// ============================================================
+
+var errNoDB = errors.New("database not initialized")
+
+// maxViolations - Số dòng vi phạm tối đa trả về cho mỗi loại kiểm tra
+const maxViolations = 50
+
+// TrialBalanceRow - Tổng phát sinh của một tài khoản (USER_WALLET gộp mọi ví)
+// Balance = Debit - Credit (âm: số dư bên Có)
+type TrialBalanceRow struct {
+	Account string  `json:"account"`
+	Debit   float64 `json:"debit"`
+	Credit  float64 `json:"credit"`
+	Balance float64 `json:"balance"`
+}
+
+// TrialBalance - Bảng cân đối thử đến hết ngày AsOf (nil = đến hiện tại)
+type TrialBalance struct {
+	AsOf        *string           `json:"asOf,omitempty"`
+	Accounts    []TrialBalanceRow `json:"accounts"`
+	TotalDebit  float64           `json:"totalDebit"`
+	TotalCredit float64           `json:"totalCredit"`
+	Balanced    bool              `json:"balanced"`
+}
+
+// Violation - Một vi phạm bất biến của sổ cái
+type Violation struct {
+	Check  string `json:"check"`
+	Detail string `json:"detail"`
+}
+
+// GetTrialBalance - Tổng Nợ / Có theo tài khoản, tính đến hết ngày asOf
+func GetTrialBalance(ctx context.Context, asOf *time.Time) (*TrialBalance, error) {
+	conn := db.GetDB()
+	if conn == nil {
+		return nil, errNoDB
+	}
+	query := `
+		SELECT p.account, COALESCE(SUM(p.debit), 0), COALESCE(SUM(p.credit), 0)
+		FROM Ledger_Posting p
+		JOIN Ledger_Entry e ON e.entry_id = p.entry_id`
+	args := []any{}
+	tb := &TrialBalance{Accounts: []TrialBalanceRow{}}
+	if asOf != nil {
+		query += ` WHERE e.created_at < ?`
+		args = append(args, asOf.AddDate(0, 0, 1))
+		day := asOf.Format("2006-01-02")
+		tb.AsOf = &day
+	}
+	query += ` GROUP BY p.account ORDER BY p.account`
+
+	rows, err := conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query trial balance: %w", err)
+	}
+	defer rows.Close()
+
+	// Cộng bằng đồng để tổng không lệch do float
+	var debit, credit int64
+	for rows.Next() {
+		var r TrialBalanceRow
+		if err := rows.Scan(&r.Account, &r.Debit, &r.Credit); err != nil {
+			return nil, fmt.Errorf("failed to scan trial balance: %w", err)
+		}
+		r.Balance = r.Debit - r.Credit
+		debit += Dong(r.Debit)
+		credit += Dong(r.Credit)
+		tb.Accounts = append(tb.Accounts, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	tb.TotalDebit = float64(debit)
+	tb.TotalCredit = float64(credit)
+	tb.Balanced = debit == credit
+	return tb, nil
+}
+
+// CheckInvariants - Kiểm tra bất biến của sổ cái (job ledger-invariants)
+//  1. Mỗi entry: tổng Nợ = tổng Có
+//  2. Số dư USER_WALLET của từng user = users.Wallet
+//  3. REFUNDS_PAYABLE không có số dư bên Nợ (trả nhiều hơn đã duyệt)
+//  4. Hóa đơn PAID / PARTIAL (đã cọc) từ khi có sổ cái đều có bút toán BILL_PAYMENT
+func CheckInvariants(ctx context.Context) ([]Violation, error) {
+	conn := db.GetDB()
+	if conn == nil {
+		return nil, errNoDB
+	}
+	checks := []struct {
+		name  string
+		query string
+	}{
+		{"unbalanced-entry", `
+			SELECT CONCAT('entry #', entry_id, ': debit ', SUM(debit), ' != credit ', SUM(credit))
+			FROM Ledger_Posting
+			GROUP BY entry_id
+			HAVING SUM(debit) <> SUM(credit)`},
+		{"wallet-mismatch", `
+			SELECT CONCAT('user #', u.user_id, ': Wallet ', COALESCE(u.Wallet, 0), ' != ledger ', COALESCE(w.balance, 0))
+			FROM Users u
+			LEFT JOIN (
+				SELECT user_id, SUM(credit) - SUM(debit) AS balance
+				FROM Ledger_Posting WHERE account = 'USER_WALLET'
+				GROUP BY user_id
+			) w ON w.user_id = u.user_id
+			WHERE ROUND(COALESCE(u.Wallet, 0)) <> ROUND(COALESCE(w.balance, 0))`},
+		{"refunds-payable-overdrawn", `
+			SELECT CONCAT('REFUNDS_PAYABLE debit balance ', SUM(debit) - SUM(credit))
+			FROM Ledger_Posting WHERE account = 'REFUNDS_PAYABLE'
+			HAVING SUM(debit) > SUM(credit)`},
+		{"bill-not-posted", `
+			SELECT CONCAT('bill #', b.bill_id, ' (', b.payment_method, ', ', b.total_amount, ') has no ledger entry')
+			FROM Bill b
+			LEFT JOIN Ledger_Entry e ON e.entry_type = 'BILL_PAYMENT' AND e.reference_type = 'BILL' AND e.reference_id = b.bill_id
+			WHERE b.payment_status IN ('PAID', 'PARTIAL', 'REFUNDED') AND b.total_amount > 0 AND e.entry_id IS NULL
+			  AND b.created_at >= (SELECT COALESCE(MIN(created_at), NOW()) FROM Ledger_Entry)`},
+	}
+
+	violations := []Violation{}
+	for _, c := range checks {
+		rows, err := conn.QueryContext(ctx, c.query+fmt.Sprintf(" LIMIT %d", maxViolations))
+		if err != nil {
+			return nil, fmt.Errorf("ledger check %s: %w", c.name, err)
+		}
+		for rows.Next() {
+			var detail string
+			if err := rows.Scan(&detail); err != nil {
+				rows.Close()
+				return nil, fmt.Errorf("ledger check %s: %w", c.name, err)
+			}
+			violations = append(violations, Violation{Check: c.name, Detail: detail})
+		}
+		err = rows.Err()
+		rows.Close()
+		if err != nil {
+			return nil, fmt.Errorf("ledger check %s: %w", c.name, err)
+		}
+	}
+	return violations, nil
+}
@@ -0,0 +1,102 @@
+package livestats
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"github.com/fpt-event-services/common/eventbus"
+)
+
+// ============================================================
+// Package livestats - Bộ đếm thay đổi trong bộ nhớ cho luồng thống kê realtime
+// Check-in / check-out thành công (domain event TicketCheckedIn / TicketCheckedOut,
+// xem RegisterSubscribers) gọi Bump(eventID); mỗi luồng SSE đang mở của
+// sự kiện có một bộ đếm riêng và chỉ đọc lại DB khi bộ đếm > 0
+// Chỉ thấy thay đổi của process hiện tại: luồng SSE vẫn đọc lại DB định kỳ
+// để bắt các lượt check-in xử lý ở instance khác
+// ============================================================
+
+// Hub - Tập subscription theo sự kiện
+type Hub struct {
+	mu   sync.Mutex
+	subs map[int]map[*Subscription]struct{}
+}
+
+// Subscription - Bộ đếm thay đổi của một sự kiện cho một người nghe
+type Subscription struct {
+	hub     *Hub
+	eventID int
+	changes atomic.Int64
+}
+
+// NewHub creates an empty hub
+func NewHub() *Hub {
+	return &Hub{subs: make(map[int]map[*Subscription]struct{})}
+}
+
+// Default - Hub dùng chung của process (staff check-in và luồng SSE của organizer)
+var Default = NewHub()
+
+// Bump - Ghi nhận một thay đổi của sự kiện cho mọi subscription đang mở
+// Không có ai nghe thì không làm gì
+func (h *Hub) Bump(eventID int) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for s := range h.subs[eventID] {
+		s.changes.Add(1)
+	}
+}
+
+// Subscribe - Mở bộ đếm cho sự kiện; phải gọi Close khi luồng kết thúc
+func (h *Hub) Subscribe(eventID int) *Subscription {
+	s := &Subscription{hub: h, eventID: eventID}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.subs[eventID] == nil {
+		h.subs[eventID] = make(map[*Subscription]struct{})
+	}
+	h.subs[eventID][s] = struct{}{}
+	return s
+}
+
+// Subscribers - Số subscription đang mở của sự kiện
+func (h *Hub) Subscribers(eventID int) int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.subs[eventID])
+}
+
+// Take - Số thay đổi kể từ lần Take trước (đưa bộ đếm về 0)
+func (s *Subscription) Take() int64 {
+	return s.changes.Swap(0)
+}
+
+// Close - Gỡ subscription khỏi hub (gọi nhiều lần không sao)
+func (s *Subscription) Close() {
+	h := s.hub
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.subs[s.eventID], s)
+	if len(h.subs[s.eventID]) == 0 {
+		delete(h.subs, s.eventID)
+	}
+}
+
+// Bump - Ghi nhận thay đổi trên Default hub
+func Bump(eventID int) { Default.Bump(eventID) }
+
+// Subscribe - Mở subscription trên Default hub
+func Subscribe(eventID int) *Subscription { return Default.Subscribe(eventID) }
+
+// RegisterSubscribers - Bump hub Default khi có check-in / check-out trên bus
+func RegisterSubscribers(bus *eventbus.Bus) {
+	eventbus.On(bus, "livestats", eventbus.Sync, func(_ context.Context, e eventbus.TicketCheckedIn) error {
+		Bump(e.EventID)
+		return nil
+	})
+	eventbus.On(bus, "livestats", eventbus.Sync, func(_ context.Context, e eventbus.TicketCheckedOut) error {
+		Bump(e.EventID)
+		return nil
+	})
+}
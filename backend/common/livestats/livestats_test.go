@@ -0,0 +1,64 @@
+package livestats
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestHubBumpOnlyReachesSubscribersOfEvent(t *testing.T) {
+	h := NewHub()
+	a := h.Subscribe(1)
+	b := h.Subscribe(1)
+	other := h.Subscribe(2)
+
+	h.Bump(1)
+	h.Bump(1)
+	h.Bump(3) // không ai nghe
+
+	if got := a.Take(); got != 2 {
+		t.Fatalf("a.Take() = %d, want 2", got)
+	}
+	if got := a.Take(); got != 0 {
+		t.Fatalf("a.Take() after reset = %d, want 0", got)
+	}
+	if got := b.Take(); got != 2 {
+		t.Fatalf("b.Take() = %d, want 2", got)
+	}
+	if got := other.Take(); got != 0 {
+		t.Fatalf("other.Take() = %d, want 0", got)
+	}
+}
+
+func TestSubscriptionClose(t *testing.T) {
+	h := NewHub()
+	s := h.Subscribe(7)
+	s.Close()
+	s.Close()
+
+	h.Bump(7)
+	if got := s.Take(); got != 0 {
+		t.Fatalf("closed subscription got %d changes", got)
+	}
+	if n := h.Subscribers(7); n != 0 {
+		t.Fatalf("Subscribers(7) = %d, want 0", n)
+	}
+}
+
+func TestHubConcurrentBump(t *testing.T) {
+	h := NewHub()
+	s := h.Subscribe(1)
+	defer s.Close()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			h.Bump(1)
+		}()
+	}
+	wg.Wait()
+	if got := s.Take(); got != 100 {
+		t.Fatalf("Take() = %d, want 100", got)
+	}
+}
@@ -10,6 +10,8 @@ import (
 	"strings"
 	"sync"
 	"time"
+
+	"github.com/fpt-event-services/common/authctx"
 )
 
 // Level represents log level
@@ -163,7 +165,7 @@ func (l *Logger) WithContext(ctx context.Context) *Logger {
 	if requestID, ok := ctx.Value("requestID").(string); ok && requestID != "" {
 		newLogger.fields["request_id"] = requestID
 	}
-	if userID, ok := ctx.Value("userID").(int); ok && userID > 0 {
+	if userID, ok := authctx.UserID(ctx); ok {
 		newLogger.fields["user_id"] = userID
 	}
 	if traceID, ok := ctx.Value("traceID").(string); ok && traceID != "" {
@@ -230,6 +232,10 @@ func (l *Logger) log(level Level, msg string, args ...interface{}) {
 	}
 	l.mu.RUnlock()
 
+	if level >= ERROR {
+		errorSamples.add(entry)
+	}
+
 	// Output
 	if l.config.JSONFormat {
 		l.outputJSON(entry)
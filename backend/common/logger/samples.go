@@ -0,0 +1,53 @@
+package logger
+
+import "sync"
+
+// ============================================================
+// ERROR SAMPLES - Giữ N log ERROR/FATAL gần nhất trong bộ nhớ
+// Dùng cho API chẩn đoán của ADMIN (không thay thế log tập trung)
+// ============================================================
+
+// errorSampleCapacity - Số log lỗi tối đa được giữ lại (vòng tròn, mới đè cũ)
+const errorSampleCapacity = 100
+
+type sampleRing struct {
+	mu      sync.Mutex
+	entries []LogEntry
+	next    int
+	full    bool
+}
+
+var errorSamples = &sampleRing{entries: make([]LogEntry, errorSampleCapacity)}
+
+func (r *sampleRing) add(entry LogEntry) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.entries[r.next] = entry
+	r.next = (r.next + 1) % len(r.entries)
+	if r.next == 0 {
+		r.full = true
+	}
+}
+
+// recent trả về tối đa limit log, mới nhất trước
+func (r *sampleRing) recent(limit int) []LogEntry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	size := r.next
+	if r.full {
+		size = len(r.entries)
+	}
+	if limit <= 0 || limit > size {
+		limit = size
+	}
+	out := make([]LogEntry, 0, limit)
+	for i := 1; i <= limit; i++ {
+		out = append(out, r.entries[(r.next-i+len(r.entries))%len(r.entries)])
+	}
+	return out
+}
+
+// RecentErrors trả về tối đa limit log ERROR/FATAL gần nhất của process (mới nhất trước)
+func RecentErrors(limit int) []LogEntry {
+	return errorSamples.recent(limit)
+}
@@ -0,0 +1,30 @@
+package logger
+
+import (
+	"fmt"
+	"io"
+	"testing"
+)
+
+func TestRecentErrors(t *testing.T) {
+	errorSamples = &sampleRing{entries: make([]LogEntry, 3)}
+	l := New(&Config{Level: DEBUG, Output: io.Discard})
+
+	l.Info("not sampled")
+	for i := 1; i <= 4; i++ {
+		l.Error("failure %d", i)
+	}
+
+	got := RecentErrors(10)
+	if len(got) != 3 {
+		t.Fatalf("len = %d, want 3", len(got))
+	}
+	for i, want := range []int{4, 3, 2} {
+		if got[i].Message != fmt.Sprintf("failure %d", want) {
+			t.Errorf("got[%d] = %q, want failure %d", i, got[i].Message, want)
+		}
+	}
+	if got := RecentErrors(1); len(got) != 1 || got[0].Message != "failure 4" {
+		t.Errorf("RecentErrors(1) = %v", got)
+	}
+}
@@ -0,0 +1,279 @@
+package metrics
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ============================================================
+// Package metrics - Histogram/counter tối giản, xuất theo định dạng
+// text của Prometheus tại GET /metrics
+// ============================================================
+
+// DefaultDurationBuckets - Bucket (giây) cho thời gian truy vấn/request
+var DefaultDurationBuckets = []float64{0.001, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5}
+
+// Collector - Metric có thể ghi ra định dạng text
+type Collector interface {
+	Name() string
+	WriteText(w io.Writer)
+}
+
+// Registry - Tập metric được xuất ra endpoint
+type Registry struct {
+	mu         sync.RWMutex
+	collectors map[string]Collector
+}
+
+// NewRegistry creates an empty registry
+func NewRegistry() *Registry {
+	return &Registry{collectors: make(map[string]Collector)}
+}
+
+// DefaultRegistry - Registry dùng chung của process (GET /metrics)
+var DefaultRegistry = NewRegistry()
+
+// Register thêm metric; đăng ký trùng tên thì trả về metric đã có
+func (r *Registry) Register(c Collector) Collector {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if existing, ok := r.collectors[c.Name()]; ok {
+		return existing
+	}
+	r.collectors[c.Name()] = c
+	return c
+}
+
+// WriteText ghi toàn bộ metric theo thứ tự tên
+func (r *Registry) WriteText(w io.Writer) {
+	r.mu.RLock()
+	names := make([]string, 0, len(r.collectors))
+	for name := range r.collectors {
+		names = append(names, name)
+	}
+	collectors := r.collectors
+	r.mu.RUnlock()
+
+	sort.Strings(names)
+	for _, name := range names {
+		collectors[name].WriteText(w)
+	}
+}
+
+// Handler trả về http.HandlerFunc xuất metric của DefaultRegistry
+func Handler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var buf bytes.Buffer
+		DefaultRegistry.WriteText(&buf)
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		w.Write(buf.Bytes())
+	}
+}
+
+// ============================================================
+// Histogram
+// ============================================================
+
+// Histogram - Phân phối giá trị theo bucket, tách series theo label
+type Histogram struct {
+	name       string
+	help       string
+	labelNames []string
+	buckets    []float64
+
+	mu     sync.Mutex
+	series map[string]*histogramSeries
+}
+
+type histogramSeries struct {
+	labelValues []string
+	counts      []uint64 // Số mẫu <= buckets[i] (chưa cộng dồn)
+	count       uint64
+	sum         float64
+}
+
+// NewHistogram tạo histogram và đăng ký vào DefaultRegistry
+func NewHistogram(name, help string, buckets []float64, labelNames ...string) *Histogram {
+	h := &Histogram{
+		name:       name,
+		help:       help,
+		labelNames: labelNames,
+		buckets:    append([]float64(nil), buckets...),
+		series:     make(map[string]*histogramSeries),
+	}
+	sort.Float64s(h.buckets)
+	if registered, ok := DefaultRegistry.Register(h).(*Histogram); ok {
+		return registered
+	}
+	return h
+}
+
+// Name returns the metric name
+func (h *Histogram) Name() string { return h.name }
+
+// Observe ghi một mẫu; số labelValues phải khớp labelNames
+func (h *Histogram) Observe(value float64, labelValues ...string) {
+	if len(labelValues) != len(h.labelNames) {
+		return
+	}
+	key := strings.Join(labelValues, "\xff")
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	s, ok := h.series[key]
+	if !ok {
+		s = &histogramSeries{
+			labelValues: append([]string(nil), labelValues...),
+			counts:      make([]uint64, len(h.buckets)),
+		}
+		h.series[key] = s
+	}
+	for i, upper := range h.buckets {
+		if value <= upper {
+			s.counts[i]++
+			break
+		}
+	}
+	s.count++
+	s.sum += value
+}
+
+// WriteText ghi histogram theo định dạng Prometheus
+func (h *Histogram) WriteText(w io.Writer) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	fmt.Fprintf(w, "# HELP %s %s\n", h.name, h.help)
+	fmt.Fprintf(w, "# TYPE %s histogram\n", h.name)
+
+	keys := make([]string, 0, len(h.series))
+	for key := range h.series {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		s := h.series[key]
+		var cumulative uint64
+		for i, upper := range h.buckets {
+			cumulative += s.counts[i]
+			fmt.Fprintf(w, "%s_bucket%s %d\n", h.name,
+				formatLabels(h.labelNames, s.labelValues, "le", formatFloat(upper)), cumulative)
+		}
+		fmt.Fprintf(w, "%s_bucket%s %d\n", h.name, formatLabels(h.labelNames, s.labelValues, "le", "+Inf"), s.count)
+		fmt.Fprintf(w, "%s_sum%s %s\n", h.name, formatLabels(h.labelNames, s.labelValues), formatFloat(s.sum))
+		fmt.Fprintf(w, "%s_count%s %d\n", h.name, formatLabels(h.labelNames, s.labelValues), s.count)
+	}
+}
+
+// ============================================================
+// Counter
+// ============================================================
+
+// Counter - Bộ đếm tăng dần, tách series theo label
+type Counter struct {
+	name       string
+	help       string
+	labelNames []string
+
+	mu     sync.Mutex
+	series map[string]*counterSeries
+}
+
+type counterSeries struct {
+	labelValues []string
+	value       float64
+}
+
+// NewCounter tạo counter và đăng ký vào DefaultRegistry
+func NewCounter(name, help string, labelNames ...string) *Counter {
+	c := &Counter{name: name, help: help, labelNames: labelNames, series: make(map[string]*counterSeries)}
+	if registered, ok := DefaultRegistry.Register(c).(*Counter); ok {
+		return registered
+	}
+	return c
+}
+
+// Name returns the metric name
+func (c *Counter) Name() string { return c.name }
+
+// Add cộng delta (>= 0) vào series
+func (c *Counter) Add(delta float64, labelValues ...string) {
+	if delta < 0 || len(labelValues) != len(c.labelNames) {
+		return
+	}
+	key := strings.Join(labelValues, "\xff")
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	s, ok := c.series[key]
+	if !ok {
+		s = &counterSeries{labelValues: append([]string(nil), labelValues...)}
+		c.series[key] = s
+	}
+	s.value += delta
+}
+
+// Inc tăng series thêm 1
+func (c *Counter) Inc(labelValues ...string) { c.Add(1, labelValues...) }
+
+// WriteText ghi counter theo định dạng Prometheus
+func (c *Counter) WriteText(w io.Writer) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	fmt.Fprintf(w, "# HELP %s %s\n", c.name, c.help)
+	fmt.Fprintf(w, "# TYPE %s counter\n", c.name)
+
+	keys := make([]string, 0, len(c.series))
+	for key := range c.series {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		s := c.series[key]
+		fmt.Fprintf(w, "%s%s %s\n", c.name, formatLabels(c.labelNames, s.labelValues), formatFloat(s.value))
+	}
+}
+
+func formatLabels(names, values []string, extra ...string) string {
+	if len(names) == 0 && len(extra) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteByte('{')
+	write := func(name, value string) {
+		if sb.Len() > 1 {
+			sb.WriteByte(',')
+		}
+		sb.WriteString(name)
+		sb.WriteString(`="`)
+		sb.WriteString(escapeLabel(value))
+		sb.WriteByte('"')
+	}
+	for i, name := range names {
+		write(name, values[i])
+	}
+	for i := 0; i+1 < len(extra); i += 2 {
+		write(extra[i], extra[i+1])
+	}
+	sb.WriteByte('}')
+	return sb.String()
+}
+
+func escapeLabel(v string) string {
+	v = strings.ReplaceAll(v, `\`, `\\`)
+	v = strings.ReplaceAll(v, "\n", `\n`)
+	return strings.ReplaceAll(v, `"`, `\"`)
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
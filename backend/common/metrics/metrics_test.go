@@ -0,0 +1,50 @@
+package metrics
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestHistogramWriteText(t *testing.T) {
+	h := NewHistogram("test_duration_seconds", "Test histogram.", []float64{0.1, 1}, "op")
+	h.Observe(0.05, "select")
+	h.Observe(0.5, "select")
+	h.Observe(3, "select")
+	h.Observe(0.2, `up"date`)
+	h.Observe(1, "ignored", "extra-label")
+
+	var buf bytes.Buffer
+	h.WriteText(&buf)
+	out := buf.String()
+
+	for _, want := range []string{
+		"# TYPE test_duration_seconds histogram",
+		`test_duration_seconds_bucket{op="select",le="0.1"} 1`,
+		`test_duration_seconds_bucket{op="select",le="1"} 2`,
+		`test_duration_seconds_bucket{op="select",le="+Inf"} 3`,
+		`test_duration_seconds_sum{op="select"} 3.55`,
+		`test_duration_seconds_count{op="select"} 3`,
+		`test_duration_seconds_count{op="up\"date"} 1`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "ignored") {
+		t.Errorf("observation with wrong label count must be dropped\n%s", out)
+	}
+}
+
+func TestRegisterReturnsExistingMetric(t *testing.T) {
+	a := NewCounter("test_events_total", "Test counter.", "kind")
+	b := NewCounter("test_events_total", "Test counter.", "kind")
+	a.Inc("x")
+	b.Add(2, "x")
+
+	var buf bytes.Buffer
+	DefaultRegistry.WriteText(&buf)
+	if !strings.Contains(buf.String(), `test_events_total{kind="x"} 3`) {
+		t.Fatalf("counter not shared between registrations:\n%s", buf.String())
+	}
+}
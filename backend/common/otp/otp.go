@@ -0,0 +1,234 @@
+package otp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ============================================================
+// OTP - Sinh và xác thực mã OTP dùng chung cho các luồng
+// (đăng ký, quên mật khẩu, đổi email...)
+// Mã chỉ lưu dạng HMAC-SHA256, so sánh constant-time, khóa tạm khi nhập sai quá số lần
+// ============================================================
+
+// Purpose - Luồng sử dụng OTP; mỗi luồng có OTP riêng cho cùng một email
+type Purpose string
+
+const (
+	PurposeRegister       Purpose = "register"
+	PurposeForgotPassword Purpose = "forgot_password"
+	PurposeEmailChange    Purpose = "email_change"
+)
+
+// Lý do xác thực thất bại (VerifyError.Reason)
+const (
+	ReasonNotFound = "NOT_FOUND"
+	ReasonExpired  = "EXPIRED"
+	ReasonInvalid  = "INVALID"
+	ReasonLocked   = "LOCKED"
+)
+
+// Policy - Cấu hình OTP
+type Policy struct {
+	Length      int
+	TTL         time.Duration
+	MaxAttempts int           // Số lần nhập sai tối đa cho một mã
+	Lockout     time.Duration // Thời gian khóa sau khi nhập sai quá MaxAttempts
+}
+
+// DefaultPolicy đọc cấu hình từ biến môi trường
+// OTP_LENGTH (6), OTP_TTL_MINUTES (5), OTP_MAX_ATTEMPTS (5), OTP_LOCKOUT_MINUTES (15)
+func DefaultPolicy() Policy {
+	length := envInt("OTP_LENGTH", 6)
+	if length < 4 || length > 10 {
+		length = 6
+	}
+	return Policy{
+		Length:      length,
+		TTL:         time.Duration(envInt("OTP_TTL_MINUTES", 5)) * time.Minute,
+		MaxAttempts: envInt("OTP_MAX_ATTEMPTS", 5),
+		Lockout:     time.Duration(envInt("OTP_LOCKOUT_MINUTES", 15)) * time.Minute,
+	}
+}
+
+// VerifyError - Lỗi OTP kèm thông tin để client hiển thị (số lần còn lại, thời gian còn hiệu lực/khóa)
+type VerifyError struct {
+	Reason            string
+	Message           string
+	RemainingAttempts int
+	ExpiresIn         time.Duration // Thời gian mã còn hiệu lực (ReasonInvalid)
+	RetryAfter        time.Duration // Thời gian còn bị khóa (ReasonLocked)
+}
+
+func (e *VerifyError) Error() string { return e.Message }
+
+// AsVerifyError lấy *VerifyError từ chuỗi lỗi
+func AsVerifyError(err error) (*VerifyError, bool) {
+	var verr *VerifyError
+	ok := errors.As(err, &verr)
+	return verr, ok
+}
+
+type record struct {
+	hash        []byte
+	expiresAt   time.Time
+	attempts    int
+	lockedUntil time.Time
+}
+
+// Store - Kho OTP in-memory (một instance); key = purpose + email
+type Store struct {
+	policy  Policy
+	key     []byte // Khóa HMAC ngẫu nhiên theo process, không bao giờ rời bộ nhớ
+	mu      sync.Mutex
+	records map[string]*record
+	now     func() time.Time
+}
+
+// NewStore creates a new OTP store
+func NewStore(policy Policy) *Store {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		panic(fmt.Sprintf("otp: cannot read random key: %v", err))
+	}
+	return &Store{policy: policy, key: key, records: make(map[string]*record), now: time.Now}
+}
+
+// Policy trả về cấu hình đang dùng
+func (s *Store) Policy() Policy { return s.policy }
+
+// Generate sinh mã mới cho (purpose, subject), thay thế mã cũ
+// Trả về *VerifyError (ReasonLocked) nếu subject đang bị khóa do nhập sai quá nhiều
+func (s *Store) Generate(purpose Purpose, subject string) (string, error) {
+	k := storeKey(purpose, subject)
+	now := s.now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if rec, ok := s.records[k]; ok && now.Before(rec.lockedUntil) {
+		return "", s.lockedError(rec, now)
+	}
+
+	code := randomDigits(s.policy.Length)
+	s.records[k] = &record{
+		hash:      s.digest(k, code),
+		expiresAt: now.Add(s.policy.TTL),
+	}
+	return code, nil
+}
+
+// Verify kiểm tra mã; đúng thì mã bị xóa (chỉ dùng một lần)
+func (s *Store) Verify(purpose Purpose, subject, code string) error {
+	k := storeKey(purpose, subject)
+	now := s.now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rec, ok := s.records[k]
+	if !ok {
+		return &VerifyError{Reason: ReasonNotFound, Message: "OTP không tồn tại, vui lòng yêu cầu gửi lại"}
+	}
+	if now.Before(rec.lockedUntil) {
+		return s.lockedError(rec, now)
+	}
+	if rec.hash == nil || now.After(rec.expiresAt) {
+		return &VerifyError{Reason: ReasonExpired, Message: "OTP đã hết hạn"}
+	}
+
+	if !hmac.Equal(rec.hash, s.digest(k, strings.TrimSpace(code))) {
+		rec.attempts++
+		remaining := s.policy.MaxAttempts - rec.attempts
+		if remaining <= 0 {
+			// Khóa và hủy mã hiện tại: phải chờ hết thời gian khóa rồi yêu cầu mã mới
+			rec.hash = nil
+			rec.lockedUntil = now.Add(s.policy.Lockout)
+			return s.lockedError(rec, now)
+		}
+		return &VerifyError{
+			Reason:            ReasonInvalid,
+			Message:           fmt.Sprintf("OTP không đúng, còn %d lần thử", remaining),
+			RemainingAttempts: remaining,
+			ExpiresIn:         rec.expiresAt.Sub(now),
+		}
+	}
+
+	delete(s.records, k)
+	return nil
+}
+
+// Invalidate hủy mã của (purpose, subject) (không gỡ khóa)
+func (s *Store) Invalidate(purpose Purpose, subject string) {
+	k := storeKey(purpose, subject)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if rec, ok := s.records[k]; ok && s.now().Before(rec.lockedUntil) {
+		rec.hash = nil
+		return
+	}
+	delete(s.records, k)
+}
+
+// Cleanup xóa mã hết hạn và khóa đã hết hiệu lực
+func (s *Store) Cleanup() {
+	now := s.now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for k, rec := range s.records {
+		if now.After(rec.expiresAt) && !now.Before(rec.lockedUntil) {
+			delete(s.records, k)
+		}
+	}
+}
+
+func (s *Store) lockedError(rec *record, now time.Time) *VerifyError {
+	wait := rec.lockedUntil.Sub(now)
+	return &VerifyError{
+		Reason:     ReasonLocked,
+		Message:    fmt.Sprintf("Đã nhập sai quá %d lần, vui lòng thử lại sau %d phút", s.policy.MaxAttempts, int(wait.Minutes())+1),
+		RetryAfter: wait,
+	}
+}
+
+// digest - HMAC(key, purpose|subject|code): lộ bộ nhớ cũng không suy ra được mã
+func (s *Store) digest(storeKey, code string) []byte {
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write([]byte(storeKey))
+	mac.Write([]byte{0})
+	mac.Write([]byte(code))
+	return mac.Sum(nil)
+}
+
+func storeKey(purpose Purpose, subject string) string {
+	return string(purpose) + "|" + strings.ToLower(strings.TrimSpace(subject))
+}
+
+// randomDigits sinh chuỗi n chữ số ngẫu nhiên (crypto/rand)
+func randomDigits(n int) string {
+	digits := make([]byte, n)
+	for i := range digits {
+		v, err := rand.Int(rand.Reader, big.NewInt(10))
+		if err != nil {
+			panic(fmt.Sprintf("otp: cannot read random digit: %v", err))
+		}
+		digits[i] = byte('0' + v.Int64())
+	}
+	return string(digits)
+}
+
+func envInt(key string, defaultValue int) int {
+	if n, err := strconv.Atoi(os.Getenv(key)); err == nil && n > 0 {
+		return n
+	}
+	return defaultValue
+}
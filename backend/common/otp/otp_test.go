@@ -0,0 +1,84 @@
+package otp
+
+import (
+	"testing"
+	"time"
+)
+
+func newTestStore() (*Store, *time.Time) {
+	now := time.Date(2026, 1, 1, 8, 0, 0, 0, time.UTC)
+	s := NewStore(Policy{Length: 6, TTL: 5 * time.Minute, MaxAttempts: 3, Lockout: 15 * time.Minute})
+	s.now = func() time.Time { return now }
+	return s, &now
+}
+
+func TestGenerateAndVerify(t *testing.T) {
+	s, _ := newTestStore()
+	code, err := s.Generate(PurposeRegister, "A@fpt.edu.vn")
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	if len(code) != 6 {
+		t.Fatalf("code length = %d", len(code))
+	}
+	for _, rec := range s.records {
+		if string(rec.hash) == code {
+			t.Fatal("code must not be stored in plain text")
+		}
+	}
+
+	// Mỗi purpose có mã riêng
+	if err := s.Verify(PurposeForgotPassword, "a@fpt.edu.vn", code); err == nil {
+		t.Fatal("code must be scoped to its purpose")
+	}
+	if err := s.Verify(PurposeRegister, "a@fpt.edu.vn", code); err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	// Chỉ dùng một lần
+	if verr, _ := AsVerifyError(s.Verify(PurposeRegister, "a@fpt.edu.vn", code)); verr == nil || verr.Reason != ReasonNotFound {
+		t.Fatalf("reused code: %v", verr)
+	}
+}
+
+func TestVerifyExpired(t *testing.T) {
+	s, now := newTestStore()
+	code, _ := s.Generate(PurposeForgotPassword, "a@fpt.edu.vn")
+	*now = now.Add(6 * time.Minute)
+	verr, _ := AsVerifyError(s.Verify(PurposeForgotPassword, "a@fpt.edu.vn", code))
+	if verr == nil || verr.Reason != ReasonExpired {
+		t.Fatalf("expected expired, got %v", verr)
+	}
+}
+
+func TestWrongCodeLockout(t *testing.T) {
+	s, now := newTestStore()
+	code, _ := s.Generate(PurposeRegister, "a@fpt.edu.vn")
+	wrong := "000000"
+	if code == wrong {
+		wrong = "111111"
+	}
+
+	*now = now.Add(time.Minute)
+	verr, _ := AsVerifyError(s.Verify(PurposeRegister, "a@fpt.edu.vn", wrong))
+	if verr == nil || verr.Reason != ReasonInvalid || verr.RemainingAttempts != 2 || verr.ExpiresIn != 4*time.Minute {
+		t.Fatalf("first wrong attempt: %+v", verr)
+	}
+	s.Verify(PurposeRegister, "a@fpt.edu.vn", wrong)
+	verr, _ = AsVerifyError(s.Verify(PurposeRegister, "a@fpt.edu.vn", wrong))
+	if verr == nil || verr.Reason != ReasonLocked || verr.RetryAfter != 15*time.Minute {
+		t.Fatalf("expected lockout, got %+v", verr)
+	}
+
+	// Đang khóa: mã đúng cũng bị từ chối và không sinh được mã mới
+	if verr, _ := AsVerifyError(s.Verify(PurposeRegister, "a@fpt.edu.vn", code)); verr == nil || verr.Reason != ReasonLocked {
+		t.Fatalf("correct code during lockout: %v", verr)
+	}
+	if _, err := s.Generate(PurposeRegister, "a@fpt.edu.vn"); err == nil {
+		t.Fatal("Generate must fail during lockout")
+	}
+
+	*now = now.Add(16 * time.Minute)
+	if _, err := s.Generate(PurposeRegister, "a@fpt.edu.vn"); err != nil {
+		t.Fatalf("Generate after lockout: %v", err)
+	}
+}
@@ -0,0 +1,370 @@
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fpt-event-services/common/db"
+	"github.com/fpt-event-services/common/metrics"
+	"github.com/fpt-event-services/common/tracing"
+)
+
+// ============================================================
+// Package outbox - Transactional outbox trên bảng Outbox_Message
+// Side effect của một giao dịch (email vé, thông báo, webhook...) được Enqueue
+// bằng CHÍNH transaction của nghiệp vụ: commit thành công thì việc cần làm chắc chắn
+// đã được lưu, kể cả khi process chết ngay sau đó.
+//
+//	id, err := outbox.Enqueue(ctx, tx, outbox.Message{Topic: "ticket.booked.email", Payload: p, Reference: "bill#12"})
+//	tx.Commit()
+//	go outbox.Default().Deliver(context.WithoutCancel(ctx), id) // gửi ngay, không chờ job
+//
+// Handler đăng ký theo topic (Handle); job "outbox" (ProcessDue) chạy lại message
+// lỗi / bị bỏ dở theo backoff, quá maxAttempts thì chuyển DEAD.
+// Đảm bảo ít nhất một lần: handler có thể chạy lại sau khi đã làm xong
+// (process chết trước khi kịp đánh dấu DONE) nên phải chịu được chạy trùng.
+// Payload dạng object được thêm khóa "traceparent" của span lúc Enqueue; mỗi lần chạy
+// (kể cả do job thử lại) mở span "outbox <topic>" nối vào trace của request gốc.
+// ============================================================
+
+// Trạng thái Outbox_Message.status
+const (
+	StatusPending    = "PENDING"
+	StatusProcessing = "PROCESSING"
+	StatusDone       = "DONE"
+	StatusDead       = "DEAD"
+)
+
+const (
+	defaultMaxAttempts = 8
+	// processingLease - Message PROCESSING quá thời hạn này (process chết giữa chừng) được chạy lại
+	processingLease = 10 * time.Minute
+)
+
+var processedTotal = metrics.NewCounter("outbox_messages_total",
+	"Outbox message handler runs by topic and result (done, retry, dead).", "topic", "result")
+
+// Handler xử lý payload (JSON) của một message
+type Handler func(ctx context.Context, payload json.RawMessage) error
+
+// Message - Side effect cần thực hiện sau khi transaction commit
+type Message struct {
+	Topic     string
+	Payload   any    // encode JSON
+	Reference string // vd: bill#12, để tra cứu
+}
+
+// Stats - Kết quả một lần chạy
+type Stats struct {
+	Done    int
+	Retried int
+	Dead    int
+}
+
+// Outbox - Bảng handler theo topic + worker xử lý Outbox_Message
+type Outbox struct {
+	db       *sql.DB
+	mu       sync.RWMutex
+	handlers map[string]Handler
+}
+
+// New creates an outbox worker on conn
+func New(conn *sql.DB) *Outbox {
+	return &Outbox{db: conn, handlers: make(map[string]Handler)}
+}
+
+var (
+	defaultOutbox     *Outbox
+	defaultOutboxOnce sync.Once
+)
+
+// Default trả về outbox dùng chung của process (gọi sau khi đã kết nối DB)
+func Default() *Outbox {
+	defaultOutboxOnce.Do(func() {
+		defaultOutbox = New(db.GetDB())
+	})
+	return defaultOutbox
+}
+
+// Enqueue ghi message trong transaction tx của nghiệp vụ, trả về message_id
+func Enqueue(ctx context.Context, tx *sql.Tx, msg Message) (int64, error) {
+	payload, err := json.Marshal(msg.Payload)
+	if err != nil {
+		return 0, fmt.Errorf("encode outbox payload: %w", err)
+	}
+	if payload, err = withTraceparent(payload, tracing.Traceparent(ctx)); err != nil {
+		return 0, fmt.Errorf("encode outbox payload: %w", err)
+	}
+	result, err := tx.ExecContext(ctx, `
+		INSERT INTO Outbox_Message (topic, payload, reference, status, max_attempts, next_attempt_at)
+		VALUES (?, ?, NULLIF(?, ''), ?, ?, NOW(6))
+	`, msg.Topic, string(payload), msg.Reference, StatusPending, defaultMaxAttempts)
+	if err != nil {
+		return 0, fmt.Errorf("enqueue outbox message %s: %w", msg.Topic, err)
+	}
+	return result.LastInsertId()
+}
+
+// traceparentKey - Khóa trong payload giữ trace của request đã Enqueue
+const traceparentKey = "traceparent"
+
+// withTraceparent thêm traceparent vào payload dạng JSON object
+// Payload không phải object (mảng, chuỗi...) hoặc không có trace thì giữ nguyên
+func withTraceparent(payload []byte, traceparent string) ([]byte, error) {
+	if traceparent == "" || len(payload) == 0 || payload[0] != '{' {
+		return payload, nil
+	}
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(payload, &fields); err != nil {
+		return nil, err
+	}
+	if _, exists := fields[traceparentKey]; exists {
+		return payload, nil
+	}
+	value, _ := json.Marshal(traceparent)
+	fields[traceparentKey] = value
+	return json.Marshal(fields)
+}
+
+// traceparentOf đọc traceparent đã lưu trong payload ("" nếu không có)
+func traceparentOf(payload json.RawMessage) string {
+	var carrier struct {
+		Traceparent string `json:"traceparent"`
+	}
+	if len(payload) == 0 || payload[0] != '{' || json.Unmarshal(payload, &carrier) != nil {
+		return ""
+	}
+	return carrier.Traceparent
+}
+
+// Handle đăng ký handler cho topic (một handler mỗi topic, đăng ký lại thì thay thế)
+// Message của topic chưa có handler trong process giữ nguyên PENDING
+func (o *Outbox) Handle(topic string, handler Handler) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.handlers[topic] = handler
+}
+
+// topics - Các topic đã có handler, sắp xếp để câu SQL ổn định
+func (o *Outbox) topics() []string {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	topics := make([]string, 0, len(o.handlers))
+	for t := range o.handlers {
+		topics = append(topics, t)
+	}
+	sort.Strings(topics)
+	return topics
+}
+
+func (o *Outbox) handler(topic string) Handler {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	return o.handlers[topic]
+}
+
+// Deliver chạy ngay các message vừa Enqueue (sau commit) thay vì chờ job
+// Message đã bị job khác nhận hoặc đã xong thì bỏ qua
+func (o *Outbox) Deliver(ctx context.Context, ids ...int64) Stats {
+	if o.db == nil || len(ids) == 0 {
+		return Stats{}
+	}
+	where := `status = ? AND message_id IN (?` + strings.Repeat(", ?", len(ids)-1) + `)`
+	args := []any{StatusPending}
+	for _, id := range ids {
+		args = append(args, id)
+	}
+	claimed, err := o.claim(ctx, where, args, len(ids))
+	if err != nil {
+		log.Printf("[OUTBOX] ⚠️ Failed to claim messages %v, job will retry: %v", ids, err)
+		return Stats{}
+	}
+	return o.run(ctx, claimed)
+}
+
+// ProcessDue chạy các message đến hạn (job "outbox")
+func (o *Outbox) ProcessDue(ctx context.Context, limit int) (Stats, error) {
+	if o.db == nil {
+		return Stats{}, nil
+	}
+	claimed, err := o.claim(ctx, `status IN (?, ?) AND next_attempt_at <= NOW(6)`, []any{StatusPending, StatusProcessing}, limit)
+	if err != nil {
+		return Stats{}, err
+	}
+	return o.run(ctx, claimed), nil
+}
+
+type claimedMessage struct {
+	id          int64
+	topic       string
+	payload     string
+	attempts    int
+	maxAttempts int
+}
+
+// claim khóa message thỏa where (SKIP LOCKED để nhiều instance không chạy trùng),
+// chuyển sang PROCESSING và tăng attempts trong cùng transaction
+// Chỉ nhận topic đã có handler trong process
+func (o *Outbox) claim(ctx context.Context, where string, args []any, limit int) ([]claimedMessage, error) {
+	topics := o.topics()
+	if len(topics) == 0 {
+		return nil, nil
+	}
+	where += ` AND topic IN (?` + strings.Repeat(", ?", len(topics)-1) + `)`
+	for _, t := range topics {
+		args = append(args, t)
+	}
+	args = append(args, limit)
+
+	tx, err := o.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("begin claim: %w", err)
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, `
+		SELECT message_id, topic, payload, attempts, max_attempts
+		FROM Outbox_Message
+		WHERE `+where+`
+		ORDER BY next_attempt_at
+		LIMIT ?
+		FOR UPDATE SKIP LOCKED
+	`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query outbox messages: %w", err)
+	}
+
+	var items []claimedMessage
+	for rows.Next() {
+		var it claimedMessage
+		if err := rows.Scan(&it.id, &it.topic, &it.payload, &it.attempts, &it.maxAttempts); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		it.attempts++
+		items = append(items, it)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	leaseUntil := time.Now().Add(processingLease)
+	for _, it := range items {
+		if _, err := tx.ExecContext(ctx, `
+			UPDATE Outbox_Message SET status = ?, attempts = ?, next_attempt_at = ? WHERE message_id = ?
+		`, StatusProcessing, it.attempts, leaseUntil, it.id); err != nil {
+			return nil, fmt.Errorf("claim outbox message #%d: %w", it.id, err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit claim: %w", err)
+	}
+	return items, nil
+}
+
+// run gọi handler cho từng message đã claim và ghi kết quả
+func (o *Outbox) run(ctx context.Context, items []claimedMessage) Stats {
+	var stats Stats
+	for _, it := range items {
+		payload := json.RawMessage(it.payload)
+		msgCtx, span := tracing.Start(tracing.ContextWithTraceparent(ctx, traceparentOf(payload)), "outbox "+it.topic,
+			tracing.WithKind(tracing.SpanKindConsumer),
+			tracing.WithAttributes(
+				tracing.String("outbox.topic", it.topic),
+				tracing.Int("outbox.message_id", int(it.id)),
+				tracing.Int("outbox.attempt", it.attempts),
+			))
+		err := o.dispatch(msgCtx, it.topic, payload)
+		span.RecordError(err)
+		span.End()
+		switch o.finish(ctx, it, err) {
+		case StatusDone:
+			stats.Done++
+		case StatusDead:
+			stats.Dead++
+		default:
+			stats.Retried++
+		}
+	}
+	return stats
+}
+
+// dispatch - Gọi handler của topic, đổi panic thành lỗi
+func (o *Outbox) dispatch(ctx context.Context, topic string, payload json.RawMessage) (err error) {
+	h := o.handler(topic)
+	if h == nil {
+		return fmt.Errorf("no handler for topic %s", topic)
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return h(ctx, payload)
+}
+
+// finish ghi kết quả một lần chạy, trả về trạng thái mới
+func (o *Outbox) finish(ctx context.Context, it claimedMessage, runErr error) string {
+	var status string
+	var err error
+	switch {
+	case runErr == nil:
+		status = StatusDone
+		_, err = o.db.ExecContext(ctx, `
+			UPDATE Outbox_Message SET status = ?, processed_at = NOW(6), last_error = NULL WHERE message_id = ?
+		`, status, it.id)
+	case it.attempts >= it.maxAttempts:
+		status = StatusDead
+		_, err = o.db.ExecContext(ctx, `
+			UPDATE Outbox_Message SET status = ?, last_error = ? WHERE message_id = ?
+		`, status, truncateError(runErr), it.id)
+		log.Printf("[OUTBOX] ☠️ Message #%d (%s) moved to DEAD after %d attempts: %v", it.id, it.topic, it.attempts, runErr)
+	default:
+		status = StatusPending
+		_, err = o.db.ExecContext(ctx, `
+			UPDATE Outbox_Message SET status = ?, last_error = ?, next_attempt_at = ? WHERE message_id = ?
+		`, status, truncateError(runErr), time.Now().Add(retryDelay(it.attempts)), it.id)
+		log.Printf("[OUTBOX] 🔁 Message #%d (%s) attempt %d failed, retry in %s: %v", it.id, it.topic, it.attempts, retryDelay(it.attempts), runErr)
+	}
+	if err != nil {
+		log.Printf("[OUTBOX] ⚠️ Failed to update message #%d: %v", it.id, err)
+	}
+
+	switch status {
+	case StatusDone:
+		processedTotal.Inc(it.topic, "done")
+	case StatusDead:
+		processedTotal.Inc(it.topic, "dead")
+	default:
+		processedTotal.Inc(it.topic, "retry")
+	}
+	return status
+}
+
+// retryDelay - Backoff theo số lần đã thử: 30s, 1m, 5m, 15m, 1h, tối đa 4h
+func retryDelay(attempts int) time.Duration {
+	delays := []time.Duration{30 * time.Second, time.Minute, 5 * time.Minute, 15 * time.Minute, time.Hour}
+	if attempts < 1 {
+		return delays[0]
+	}
+	if attempts > len(delays) {
+		return 4 * time.Hour
+	}
+	return delays[attempts-1]
+}
+
+func truncateError(err error) string {
+	msg := err.Error()
+	if len(msg) > 1000 {
+		msg = msg[:1000]
+	}
+	return msg
+}
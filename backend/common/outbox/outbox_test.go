@@ -0,0 +1,100 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestRetryDelay(t *testing.T) {
+	cases := map[int]time.Duration{
+		0: 30 * time.Second,
+		1: 30 * time.Second,
+		2: time.Minute,
+		5: time.Hour,
+		6: 4 * time.Hour,
+		9: 4 * time.Hour,
+	}
+	for attempts, want := range cases {
+		if got := retryDelay(attempts); got != want {
+			t.Errorf("retryDelay(%d) = %s, want %s", attempts, got, want)
+		}
+	}
+}
+
+func TestHandleTopics(t *testing.T) {
+	o := New(nil)
+	o.Handle("webhook", func(context.Context, json.RawMessage) error { return nil })
+	o.Handle("email", func(context.Context, json.RawMessage) error { return nil })
+	o.Handle("email", func(context.Context, json.RawMessage) error { return errors.New("replaced") })
+
+	if got, want := o.topics(), []string{"email", "webhook"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("topics() = %v, want %v", got, want)
+	}
+	if err := o.dispatch(context.Background(), "email", nil); err == nil || err.Error() != "replaced" {
+		t.Fatalf("dispatch used old handler: %v", err)
+	}
+}
+
+func TestDispatch(t *testing.T) {
+	o := New(nil)
+	o.Handle("panics", func(context.Context, json.RawMessage) error { panic("boom") })
+
+	var got struct{ BillID int }
+	o.Handle("decode", func(_ context.Context, payload json.RawMessage) error {
+		return json.Unmarshal(payload, &got)
+	})
+
+	if err := o.dispatch(context.Background(), "panics", nil); err == nil {
+		t.Fatal("expected panic to be returned as error")
+	}
+	if err := o.dispatch(context.Background(), "missing", nil); err == nil {
+		t.Fatal("expected error for topic without handler")
+	}
+	if err := o.dispatch(context.Background(), "decode", json.RawMessage(`{"BillID":12}`)); err != nil || got.BillID != 12 {
+		t.Fatalf("dispatch decode: err=%v, got=%+v", err, got)
+	}
+}
+
+func TestWithoutDB(t *testing.T) {
+	o := New(nil)
+	if stats := o.Deliver(context.Background(), 1, 2); stats != (Stats{}) {
+		t.Fatalf("Deliver without DB = %+v", stats)
+	}
+	if stats, err := o.ProcessDue(context.Background(), 10); err != nil || stats != (Stats{}) {
+		t.Fatalf("ProcessDue without DB = %+v, %v", stats, err)
+	}
+}
+
+func TestTraceparentInPayload(t *testing.T) {
+	const tp = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+	payload, err := withTraceparent([]byte(`{"billId":12}`), tp)
+	if err != nil {
+		t.Fatalf("withTraceparent: %v", err)
+	}
+	if got := traceparentOf(payload); got != tp {
+		t.Fatalf("traceparentOf = %q, want %q", got, tp)
+	}
+	var p struct {
+		BillID int `json:"billId"`
+	}
+	if err := json.Unmarshal(payload, &p); err != nil || p.BillID != 12 {
+		t.Fatalf("payload fields changed: %s", payload)
+	}
+
+	for _, raw := range []string{`[1,2]`, `"text"`} {
+		if got, _ := withTraceparent([]byte(raw), tp); string(got) != raw {
+			t.Errorf("non-object payload %s changed to %s", raw, got)
+		}
+	}
+	if got, _ := withTraceparent([]byte(`{"billId":1}`), ""); string(got) != `{"billId":1}` {
+		t.Errorf("payload without trace changed to %s", got)
+	}
+	if got := traceparentOf(json.RawMessage(`{"billId":12}`)); got != "" {
+		t.Errorf("traceparentOf without key = %q", got)
+	}
+}
@@ -0,0 +1,113 @@
+package pagination
+
+import (
+	"strconv"
+)
+
+// ============================================================
+// PAGINATION - Envelope chung cho mọi API danh sách có phân trang
+//
+//	{
+//	  "items": [...],
+//	  "page": 2, "pageSize": 10,
+//	  "totalItems": 37, "totalPages": 4,
+//	  "nextCursor": "3"        // bỏ trống ở trang cuối
+//	}
+//
+// Query params: page (bắt đầu từ 1), pageSize; cursor = giá trị nextCursor của
+// trang trước (thay cho page). Tham số cũ vẫn được nhận: limit (= pageSize)
+// và offset (quy ra page) cho các màn hình chưa chuyển sang page / pageSize
+// ============================================================
+
+// Mặc định dùng chung: 10 dòng / trang, tối đa 100
+const (
+	DefaultPageSize = 10
+	MaxPageSize     = 100
+)
+
+// Params - Trang được yêu cầu (đã chuẩn hóa: Page >= 1, 1 <= PageSize <= max)
+type Params struct {
+	Page     int
+	PageSize int
+}
+
+// Offset - OFFSET tương ứng cho SQL
+func (p Params) Offset() int {
+	return (p.Page - 1) * p.PageSize
+}
+
+// Requested - Query có tham số phân trang nào không (page / pageSize / limit / offset / cursor)
+// Dùng cho endpoint vẫn trả mảng trần khi không phân trang
+func Requested(query map[string]string) bool {
+	for _, key := range []string{"page", "pageSize", "limit", "offset", "cursor"} {
+		if query[key] != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseParams - Đọc page / pageSize (hoặc cursor, limit, offset) từ query string
+// Giá trị thiếu hoặc không hợp lệ dùng mặc định; pageSize vượt maxSize bị cắt về maxSize
+func ParseParams(query map[string]string, defaultSize, maxSize int) Params {
+	size := positive(query["pageSize"])
+	if size == 0 {
+		size = positive(query["limit"])
+	}
+	if size == 0 {
+		size = defaultSize
+	}
+	size = min(size, maxSize)
+
+	page := positive(query["cursor"])
+	if page == 0 {
+		page = positive(query["page"])
+	}
+	if page == 0 {
+		if offset, err := strconv.Atoi(query["offset"]); err == nil && offset > 0 {
+			page = offset/size + 1
+		}
+	}
+	return Params{Page: max(page, 1), PageSize: size}
+}
+
+func positive(s string) int {
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 1 {
+		return 0
+	}
+	return n
+}
+
+// Page - Một trang kết quả
+type Page[T any] struct {
+	Items      []T    `json:"items"`
+	Page       int    `json:"page"`
+	PageSize   int    `json:"pageSize"`
+	TotalItems int    `json:"totalItems"`
+	TotalPages int    `json:"totalPages"`
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+// New - Dựng trang từ danh sách đã LIMIT / OFFSET theo p và tổng số dòng khớp bộ lọc
+// items nil được đổi thành mảng rỗng để JSON luôn là [] thay vì null
+func New[T any](items []T, p Params, totalItems int) Page[T] {
+	if items == nil {
+		items = []T{}
+	}
+	totalPages := 0
+	if p.PageSize > 0 {
+		totalPages = (totalItems + p.PageSize - 1) / p.PageSize
+	}
+	page := Page[T]{
+		Items:      items,
+		Page:       p.Page,
+		PageSize:   p.PageSize,
+		TotalItems: totalItems,
+		TotalPages: totalPages,
+	}
+	if p.Page < totalPages {
+		page.NextCursor = strconv.Itoa(p.Page + 1)
+	}
+	return page
+}
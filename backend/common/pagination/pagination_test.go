@@ -0,0 +1,34 @@
+package pagination
+
+import "testing"
+
+func TestParseParams(t *testing.T) {
+	cases := []struct {
+		name  string
+		query map[string]string
+		want  Params
+	}{
+		{"defaults", nil, Params{1, 10}},
+		{"page and size", map[string]string{"page": "3", "pageSize": "20"}, Params{3, 20}},
+		{"size capped", map[string]string{"pageSize": "500"}, Params{1, 100}},
+		{"invalid values", map[string]string{"page": "-2", "pageSize": "abc"}, Params{1, 10}},
+		{"legacy limit/offset", map[string]string{"limit": "5", "offset": "10"}, Params{3, 5}},
+		{"cursor wins over page", map[string]string{"cursor": "4", "page": "2"}, Params{4, 10}},
+	}
+	for _, c := range cases {
+		if got := ParseParams(c.query, DefaultPageSize, MaxPageSize); got != c.want {
+			t.Errorf("%s: got %+v, want %+v", c.name, got, c.want)
+		}
+	}
+}
+
+func TestNew(t *testing.T) {
+	p := New([]int{1, 2}, Params{Page: 1, PageSize: 2}, 5)
+	if p.TotalPages != 3 || p.NextCursor != "2" {
+		t.Fatalf("got totalPages=%d nextCursor=%q", p.TotalPages, p.NextCursor)
+	}
+	last := New[int](nil, Params{Page: 3, PageSize: 2}, 5)
+	if last.Items == nil || last.NextCursor != "" {
+		t.Fatalf("last page: items=%v nextCursor=%q", last.Items, last.NextCursor)
+	}
+}
@@ -0,0 +1,28 @@
+package pdf
+
+import (
+	_ "embed"
+
+	"github.com/jung-kurt/gofpdf"
+)
+
+// ============================================================
+// Font Unicode nhúng sẵn cho layout có tiếng Việt
+// Core font (Arial/Helvetica) của gofpdf chỉ có bảng mã cp1252 → mất dấu,
+// nên layout mới dùng DejaVu Sans Condensed (đủ glyph tiếng Việt và ₫)
+// ============================================================
+
+// unicodeFamily - Tên family đăng ký với gofpdf
+const unicodeFamily = "DejaVu"
+
+//go:embed fonts/DejaVuSansCondensed.ttf
+var dejaVuRegular []byte
+
+//go:embed fonts/DejaVuSansCondensed-Bold.ttf
+var dejaVuBold []byte
+
+// registerUnicodeFonts đăng ký font thường + đậm cho tài liệu
+func registerUnicodeFonts(pdf *gofpdf.Fpdf) {
+	pdf.AddUTF8FontFromBytes(unicodeFamily, "", dejaVuRegular)
+	pdf.AddUTF8FontFromBytes(unicodeFamily, "B", dejaVuBold)
+}
@@ -0,0 +1,428 @@
+package pdf
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	"image/png"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jung-kurt/gofpdf"
+)
+
+// ============================================================
+// TICKET TEMPLATE - Chọn layout + khổ giấy + tùy biến theo sự kiện
+//   classic: layout cũ của GenerateTicketPDF (A4, bỏ dấu tiếng Việt) - mặc định
+//   branded: font Unicode (đủ dấu), banner sự kiện, logo BTC, dải nhà tài trợ
+// Layout branded tính mọi kích thước theo chiều rộng trang nên dùng được A4/A5/A6/Letter
+// ============================================================
+
+// Tên layout
+const (
+	LayoutClassic = "classic"
+	LayoutBranded = "branded"
+)
+
+// Khổ giấy hỗ trợ (tên theo gofpdf)
+const (
+	PageA4     = "A4"
+	PageA5     = "A5"
+	PageA6     = "A6"
+	PageLetter = "Letter"
+)
+
+const (
+	// DefaultAccentColor - Màu cam FPT cho thanh tiêu đề / nhãn
+	DefaultAccentColor = "#F27124"
+
+	// MaxSponsorLogos - Số logo tối đa trên dải nhà tài trợ
+	MaxSponsorLogos = 6
+
+	// maxAssetPixels - Chặn ảnh quá lớn khi decode (logo/banner in trên vé không cần hơn)
+	maxAssetPixels = 4000
+
+	defaultFooterNote = "Vui lòng mang theo vé này (file PDF hoặc ảnh chụp) khi tham dự.\nQuét mã QR tại cổng để check-in."
+)
+
+// ErrInvalidTemplate - Cấu hình template không hợp lệ
+var ErrInvalidTemplate = errors.New("invalid ticket template")
+
+// TicketTemplate - Cấu hình in vé của một sự kiện
+type TicketTemplate struct {
+	Layout      string // classic | branded
+	PageSize    string // A4 | A5 | A6 | Letter (classic chỉ hỗ trợ A4)
+	AccentColor string // #RRGGBB, rỗng = DefaultAccentColor
+	FooterNote  string // Ghi chú cuối vé, rỗng = câu hướng dẫn mặc định
+}
+
+// DefaultTemplate - Giữ nguyên vé như trước khi có template
+func DefaultTemplate() TicketTemplate {
+	return TicketTemplate{Layout: LayoutClassic, PageSize: PageA4}
+}
+
+// Validate kiểm tra layout, khổ giấy và màu
+func (t TicketTemplate) Validate() error {
+	switch t.Layout {
+	case LayoutClassic:
+		if t.PageSize != "" && t.PageSize != PageA4 {
+			return fmt.Errorf("%w: layout classic only supports A4", ErrInvalidTemplate)
+		}
+	case LayoutBranded:
+		switch t.PageSize {
+		case "", PageA4, PageA5, PageA6, PageLetter:
+		default:
+			return fmt.Errorf("%w: unsupported page size %q", ErrInvalidTemplate, t.PageSize)
+		}
+	default:
+		return fmt.Errorf("%w: unknown layout %q", ErrInvalidTemplate, t.Layout)
+	}
+	if t.AccentColor != "" {
+		if _, _, _, err := parseHexColor(t.AccentColor); err != nil {
+			return err
+		}
+	}
+	if len([]rune(t.FooterNote)) > 300 {
+		return fmt.Errorf("%w: footer note is longer than 300 characters", ErrInvalidTemplate)
+	}
+	return nil
+}
+
+// GenerateTicketPDFWithTemplate tạo PDF vé theo template
+// Ảnh banner/logo lỗi hoặc không đọc được thì bỏ qua, vé vẫn được tạo
+func GenerateTicketPDFWithTemplate(data TicketPDFData, tpl TicketTemplate) ([]byte, error) {
+	if tpl.Layout == "" {
+		tpl = DefaultTemplate()
+	}
+	if err := tpl.Validate(); err != nil {
+		return nil, err
+	}
+	if tpl.Layout == LayoutClassic {
+		return GenerateTicketPDF(data)
+	}
+	return generateBrandedPDF(data, tpl)
+}
+
+// ============================================================
+// Layout branded
+//   ┌ thanh màu nhấn ─────────────────────────┐
+//   │ logo BTC                    VÉ ĐIỆN TỬ   │
+//   │ [ banner sự kiện ]                       │
+//   │ Tên sự kiện                              │
+//   │ Thời gian   | Địa điểm                   │
+//   │ Khách mời   | Loại vé                    │
+//   │ Ghế         | Giá vé                     │
+//   │             [ QR ]                       │
+//   │          Mã vé: TKT_123                  │
+//   │ Nhà tài trợ: logo logo logo              │
+//   │ ghi chú                                  │
+//   └──────────────────────────────────────────┘
+// ============================================================
+
+// brandedPage - Kích thước đã quy đổi theo khổ giấy
+type brandedPage struct {
+	pdf      *gofpdf.Fpdf
+	width    float64
+	height   float64
+	margin   float64
+	scale    float64 // 1 = A4 (210mm)
+	accent   [3]int
+	contentW float64
+}
+
+// fontSize quy đổi cỡ chữ thiết kế cho A4 sang khổ hiện tại
+func (p *brandedPage) fontSize(pt float64) float64 {
+	return pt * p.scale
+}
+
+// lineHeight - Chiều cao dòng (mm) cho cỡ chữ pt đã quy đổi
+func (p *brandedPage) lineHeight(pt float64) float64 {
+	return p.fontSize(pt) * 0.3528 * 1.3
+}
+
+func generateBrandedPDF(data TicketPDFData, tpl TicketTemplate) ([]byte, error) {
+	pageSize := tpl.PageSize
+	if pageSize == "" {
+		pageSize = PageA4
+	}
+	accent := tpl.AccentColor
+	if accent == "" {
+		accent = DefaultAccentColor
+	}
+	r, g, b, _ := parseHexColor(accent)
+
+	pdf := gofpdf.New("P", "mm", pageSize, "")
+	registerUnicodeFonts(pdf)
+	pdf.SetAutoPageBreak(false, 0)
+	pdf.SetTitle(data.EventName, true)
+	pdf.AddPage()
+
+	w, h := pdf.GetPageSize()
+	p := &brandedPage{pdf: pdf, width: w, height: h, scale: w / 210, accent: [3]int{r, g, b}}
+	p.margin = 14 * p.scale
+	p.contentW = w - 2*p.margin
+
+	// Thanh màu nhấn trên cùng
+	pdf.SetFillColor(r, g, b)
+	pdf.Rect(0, 0, w, 5*p.scale, "F")
+	y := 5*p.scale + 6*p.scale
+
+	// Logo BTC (trái) + nhãn vé (phải)
+	headerH := 14 * p.scale
+	if name := p.registerImage("organizer_logo", data.OrganizerLogo); name != "" {
+		p.drawImageFit(name, p.margin, y, p.contentW*0.45, headerH, "L")
+	}
+	pdf.SetFont(unicodeFamily, "B", p.fontSize(11))
+	pdf.SetTextColor(r, g, b)
+	pdf.SetXY(p.margin, y)
+	pdf.CellFormat(p.contentW, headerH, "VÉ ĐIỆN TỬ", "", 0, "RM", false, 0, "")
+	y += headerH + 4*p.scale
+
+	// Banner sự kiện: tối đa 22% chiều cao trang
+	if name := p.registerImage("event_banner", data.BannerImage); name != "" {
+		y += p.drawImageFit(name, p.margin, y, p.contentW, h*0.22, "C") + 5*p.scale
+	}
+
+	// Tên sự kiện (tối đa 2 dòng)
+	pdf.SetTextColor(30, 30, 30)
+	pdf.SetFont(unicodeFamily, "B", p.fontSize(20))
+	for _, line := range p.fitLines(data.EventName, p.contentW, 2) {
+		pdf.SetXY(p.margin, y)
+		pdf.CellFormat(p.contentW, p.lineHeight(20), line, "", 0, "L", false, 0, "")
+		y += p.lineHeight(20)
+	}
+	y += 3 * p.scale
+
+	// Bảng thông tin 2 cột
+	colW := (p.contentW - 6*p.scale) / 2
+	rows := [][2][2]string{
+		{{"THỜI GIAN", formatVietnameseDate(data.EventDate)}, {"ĐỊA ĐIỂM", venueLine(data)}},
+		{{"KHÁCH MỜI", data.UserName}, {"LOẠI VÉ", data.CategoryName}},
+		{{"GHẾ", seatLine(data)}, {"GIÁ VÉ", data.Price}},
+	}
+	for _, row := range rows {
+		left := p.drawField(p.margin, y, colW, row[0][0], row[0][1])
+		right := p.drawField(p.margin+colW+6*p.scale, y, colW, row[1][0], row[1][1])
+		if right > left {
+			left = right
+		}
+		y += left + 2.5*p.scale
+	}
+
+	// Phần cuối trang (dải nhà tài trợ + ghi chú) tính từ dưới lên để QR lấp phần còn lại
+	note := tpl.FooterNote
+	if note == "" {
+		note = defaultFooterNote
+	}
+	pdf.SetFont(unicodeFamily, "", p.fontSize(9))
+	var noteLines []string
+	for _, part := range strings.Split(note, "\n") {
+		noteLines = append(noteLines, p.fitLines(part, p.contentW, 3)...)
+	}
+	bottom := h - p.margin - float64(len(noteLines))*p.lineHeight(9)
+	noteY := bottom
+
+	var sponsors []string
+	for i, logo := range data.SponsorLogos {
+		if len(sponsors) == MaxSponsorLogos {
+			break
+		}
+		if name := p.registerImage(fmt.Sprintf("sponsor_%d", i), logo); name != "" {
+			sponsors = append(sponsors, name)
+		}
+	}
+	stripH := 12 * p.scale
+	if len(sponsors) > 0 {
+		bottom -= stripH + p.lineHeight(8) + 4*p.scale
+	}
+
+	// QR + mã vé
+	codeH := p.lineHeight(12)
+	qrSize := bottom - y - codeH - 4*p.scale
+	if maxQR := p.contentW * 0.6; qrSize > maxQR {
+		qrSize = maxQR
+	}
+	if name := p.registerImage("qr_"+data.TicketCode, data.QRCodePngBytes); name != "" && qrSize > 0 {
+		pdf.ImageOptions(name, (w-qrSize)/2, y, qrSize, qrSize, false, gofpdf.ImageOptions{}, 0, "")
+		y += qrSize
+	}
+	pdf.SetFont(unicodeFamily, "B", p.fontSize(12))
+	pdf.SetTextColor(60, 60, 60)
+	pdf.SetXY(p.margin, y+1*p.scale)
+	pdf.CellFormat(p.contentW, codeH, "Mã vé: "+data.TicketCode, "", 0, "C", false, 0, "")
+
+	// Dải nhà tài trợ: chia đều chiều rộng, mỗi logo giữ tỉ lệ
+	if len(sponsors) > 0 {
+		stripY := bottom + 2*p.scale
+		pdf.SetDrawColor(220, 220, 220)
+		pdf.SetLineWidth(0.3 * p.scale)
+		pdf.Line(p.margin, stripY, w-p.margin, stripY)
+		pdf.SetFont(unicodeFamily, "", p.fontSize(8))
+		pdf.SetTextColor(130, 130, 130)
+		pdf.SetXY(p.margin, stripY+1*p.scale)
+		pdf.CellFormat(p.contentW, p.lineHeight(8), "NHÀ TÀI TRỢ", "", 0, "C", false, 0, "")
+		slotW := p.contentW / float64(len(sponsors))
+		logoY := stripY + 1*p.scale + p.lineHeight(8)
+		for i, name := range sponsors {
+			p.drawImageFit(name, p.margin+float64(i)*slotW+2*p.scale, logoY, slotW-4*p.scale, stripH, "C")
+		}
+	}
+
+	pdf.SetFont(unicodeFamily, "", p.fontSize(9))
+	pdf.SetTextColor(110, 110, 110)
+	for _, line := range noteLines {
+		pdf.SetXY(p.margin, noteY)
+		pdf.CellFormat(p.contentW, p.lineHeight(9), line, "", 0, "C", false, 0, "")
+		noteY += p.lineHeight(9)
+	}
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, fmt.Errorf("failed to generate PDF: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// drawField in nhãn nhỏ màu nhấn + giá trị đậm (tối đa 2 dòng), trả về chiều cao đã dùng
+func (p *brandedPage) drawField(x, y, w float64, label, value string) float64 {
+	if strings.TrimSpace(value) == "" {
+		value = "-"
+	}
+	pdf := p.pdf
+	pdf.SetFont(unicodeFamily, "B", p.fontSize(8))
+	pdf.SetTextColor(p.accent[0], p.accent[1], p.accent[2])
+	pdf.SetXY(x, y)
+	pdf.CellFormat(w, p.lineHeight(8), label, "", 0, "L", false, 0, "")
+	used := p.lineHeight(8)
+
+	pdf.SetFont(unicodeFamily, "B", p.fontSize(12))
+	pdf.SetTextColor(30, 30, 30)
+	for _, line := range p.fitLines(value, w, 2) {
+		pdf.SetXY(x, y+used)
+		pdf.CellFormat(w, p.lineHeight(12), line, "", 0, "L", false, 0, "")
+		used += p.lineHeight(12)
+	}
+	return used
+}
+
+// fitLines cắt text theo chiều rộng với font hiện tại, dòng cuối thêm "…" nếu còn thừa
+func (p *brandedPage) fitLines(text string, w float64, maxLines int) []string {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil
+	}
+	lines := p.pdf.SplitText(text, w)
+	if len(lines) <= maxLines {
+		return lines
+	}
+	lines = lines[:maxLines]
+	last := []rune(strings.TrimSpace(lines[maxLines-1]))
+	for len(last) > 0 && p.pdf.GetStringWidth(string(last)+"…") > w {
+		last = last[:len(last)-1]
+	}
+	lines[maxLines-1] = string(last) + "…"
+	return lines
+}
+
+// registerImage chuẩn hóa ảnh rồi đăng ký với gofpdf, trả về tên ảnh ("" = bỏ qua)
+// JPEG giữ nguyên bytes; PNG/GIF encode lại thành PNG thường vì gofpdf không đọc được PNG interlaced
+func (p *brandedPage) registerImage(name string, raw []byte) string {
+	if len(raw) == 0 {
+		return ""
+	}
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(raw))
+	if err != nil || cfg.Width == 0 || cfg.Height == 0 || cfg.Width > maxAssetPixels || cfg.Height > maxAssetPixels {
+		return ""
+	}
+	imgType := "JPG"
+	if format != "jpeg" {
+		img, _, err := image.Decode(bytes.NewReader(raw))
+		if err != nil {
+			return ""
+		}
+		var buf bytes.Buffer
+		if err := png.Encode(&buf, img); err != nil {
+			return ""
+		}
+		raw, imgType = buf.Bytes(), "PNG"
+	}
+
+	p.pdf.RegisterImageOptionsReader(name, gofpdf.ImageOptions{ImageType: imgType}, bytes.NewReader(raw))
+	if !p.pdf.Ok() {
+		// Lỗi của gofpdf là lỗi "dính" cho cả tài liệu → xóa để phần còn lại vẫn in được
+		p.pdf.ClearError()
+		return ""
+	}
+	return name
+}
+
+// drawImageFit vẽ ảnh vừa khung (giữ tỉ lệ), align L/C theo chiều ngang, trả về chiều cao thực tế
+func (p *brandedPage) drawImageFit(name string, x, y, boxW, boxH float64, align string) float64 {
+	info := p.pdf.GetImageInfo(name)
+	if info == nil || info.Width() == 0 || info.Height() == 0 {
+		return 0
+	}
+	ratio := info.Height() / info.Width()
+	drawW, drawH := boxW, boxW*ratio
+	if drawH > boxH {
+		drawW, drawH = boxH/ratio, boxH
+	}
+	if align == "C" {
+		x += (boxW - drawW) / 2
+	}
+	p.pdf.ImageOptions(name, x, y, drawW, drawH, false, gofpdf.ImageOptions{}, 0, "")
+	return drawH
+}
+
+// ============================================================
+// Helpers định dạng nội dung
+// ============================================================
+
+var vietnameseWeekdays = [...]string{"Chủ Nhật", "Thứ Hai", "Thứ Ba", "Thứ Tư", "Thứ Năm", "Thứ Sáu", "Thứ Bảy"}
+
+// formatVietnameseDate - "18:00, Thứ Bảy 25/10/2025"
+func formatVietnameseDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return fmt.Sprintf("%s, %s %s", t.Format("15:04"), vietnameseWeekdays[t.Weekday()], t.Format("02/01/2006"))
+}
+
+// venueLine - Khu vực, địa điểm, địa chỉ (bỏ các phần "Chưa xác định")
+func venueLine(data TicketPDFData) string {
+	var parts []string
+	for _, s := range []string{data.AreaName, data.VenueName, data.Address} {
+		if s = strings.TrimSpace(s); s != "" && s != "Chưa xác định" {
+			parts = append(parts, s)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// seatLine - "Hàng A, số 5"
+func seatLine(data TicketPDFData) string {
+	switch {
+	case data.SeatRow != "" && data.SeatNumber != "":
+		return fmt.Sprintf("Hàng %s, số %s", data.SeatRow, data.SeatNumber)
+	case data.SeatRow != "":
+		return data.SeatRow
+	}
+	return data.SeatNumber
+}
+
+// parseHexColor đọc màu dạng #RRGGBB
+func parseHexColor(s string) (int, int, int, error) {
+	hex := strings.TrimPrefix(s, "#")
+	if len(hex) != 6 {
+		return 0, 0, 0, fmt.Errorf("%w: accent color must be #RRGGBB", ErrInvalidTemplate)
+	}
+	v, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return 0, 0, 0, fmt.Errorf("%w: accent color must be #RRGGBB", ErrInvalidTemplate)
+	}
+	return int(v >> 16 & 0xFF), int(v >> 8 & 0xFF), int(v & 0xFF), nil
+}
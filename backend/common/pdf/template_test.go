@@ -0,0 +1,109 @@
+package pdf
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+	"time"
+
+	"github.com/fpt-event-services/common/qrcode"
+)
+
+func testImage(t *testing.T, w, h int, asJPEG bool) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for x := 0; x < w; x++ {
+		for y := 0; y < h; y++ {
+			img.Set(x, y, color.RGBA{uint8(x), uint8(y), 120, 255})
+		}
+	}
+	var buf bytes.Buffer
+	var err error
+	if asJPEG {
+		err = jpeg.Encode(&buf, img, nil)
+	} else {
+		err = png.Encode(&buf, img)
+	}
+	if err != nil {
+		t.Fatalf("encode test image: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func testTicketData(t *testing.T) TicketPDFData {
+	t.Helper()
+	qr, err := qrcode.GenerateTicketQRPngBytes(123, 256)
+	if err != nil {
+		t.Fatalf("qr: %v", err)
+	}
+	return TicketPDFData{
+		TicketCode:     "TKT_123",
+		EventName:      "Hội thảo Trí tuệ nhân tạo và Ứng dụng trong Giáo dục Đại học Việt Nam năm 2025",
+		EventDate:      time.Date(2025, 10, 25, 18, 0, 0, 0, time.Local),
+		VenueName:      "Nhà văn hóa sinh viên",
+		AreaName:       "Hội trường lớn",
+		Address:        "Lưu Hữu Phước, Đông Hòa, Dĩ An, Bình Dương",
+		SeatRow:        "A",
+		SeatNumber:     "5",
+		CategoryName:   "VIP",
+		Price:          "150.000 đ",
+		UserName:       "Nguyễn Thị Hồng Nhung",
+		UserEmail:      "nhung@fpt.edu.vn",
+		QRCodePngBytes: qr,
+	}
+}
+
+func TestGenerateTicketPDFWithTemplate(t *testing.T) {
+	data := testTicketData(t)
+	branded := data
+	branded.BannerImage = testImage(t, 320, 120, true)
+	branded.OrganizerLogo = testImage(t, 120, 40, false)
+	branded.SponsorLogos = [][]byte{testImage(t, 80, 40, false), testImage(t, 60, 60, true), []byte("not an image")}
+
+	cases := []struct {
+		name string
+		data TicketPDFData
+		tpl  TicketTemplate
+	}{
+		{"default", data, TicketTemplate{}},
+		{"classic", data, DefaultTemplate()},
+		{"branded A4 no assets", data, TicketTemplate{Layout: LayoutBranded}},
+		{"branded A4", branded, TicketTemplate{Layout: LayoutBranded, PageSize: PageA4, AccentColor: "#1E88E5", FooterNote: "Mang theo thẻ sinh viên."}},
+		{"branded A5", branded, TicketTemplate{Layout: LayoutBranded, PageSize: PageA5}},
+		{"branded A6", branded, TicketTemplate{Layout: LayoutBranded, PageSize: PageA6}},
+		{"branded Letter", branded, TicketTemplate{Layout: LayoutBranded, PageSize: PageLetter}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			out, err := GenerateTicketPDFWithTemplate(tc.data, tc.tpl)
+			if err != nil {
+				t.Fatalf("generate: %v", err)
+			}
+			if !bytes.HasPrefix(out, []byte("%PDF-")) || len(out) < 1000 {
+				t.Fatalf("output is not a PDF (%d bytes)", len(out))
+			}
+		})
+	}
+}
+
+func TestTicketTemplateValidate(t *testing.T) {
+	invalid := []TicketTemplate{
+		{Layout: "poster"},
+		{Layout: LayoutClassic, PageSize: PageA6},
+		{Layout: LayoutBranded, PageSize: "B5"},
+		{Layout: LayoutBranded, AccentColor: "orange"},
+		{Layout: LayoutBranded, AccentColor: "#12345G"},
+	}
+	for _, tpl := range invalid {
+		if err := tpl.Validate(); !errors.Is(err, ErrInvalidTemplate) {
+			t.Errorf("%+v: want ErrInvalidTemplate, got %v", tpl, err)
+		}
+	}
+	if err := (TicketTemplate{Layout: LayoutBranded, PageSize: PageLetter, AccentColor: "#f27124"}).Validate(); err != nil {
+		t.Errorf("valid template rejected: %v", err)
+	}
+}
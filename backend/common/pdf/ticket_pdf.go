@@ -24,6 +24,11 @@ type TicketPDFData struct {
 	UserName       string
 	UserEmail      string
 	QRCodePngBytes []byte // QR code PNG bytes (không phải Base64)
+
+	// Tùy biến theo sự kiện - chỉ layout branded dùng (JPEG/PNG/GIF bytes, rỗng = không in)
+	BannerImage   []byte
+	OrganizerLogo []byte
+	SponsorLogos  [][]byte
 }
 
 // toUTF8 restores corrupted Vietnamese text encoding to proper UTF-8
@@ -97,7 +102,7 @@ func contains(str, substr string) bool {
 	return false
 }
 
-// GenerateTicketPDF tạo PDF vé điện tử với QR code
+// GenerateTicketPDF tạo PDF vé điện tử với QR code (layout classic, template mặc định)
 // Trả về PDF bytes có thể lưu file hoặc attach email
 func GenerateTicketPDF(data TicketPDFData) ([]byte, error) {
 	// Khởi tạo PDF
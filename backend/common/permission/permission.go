@@ -0,0 +1,284 @@
+package permission
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/fpt-event-services/common/authctx"
+	"github.com/fpt-event-services/common/db"
+)
+
+// ============================================================
+// PERMISSION - Quyền theo tên thay cho so sánh role cứng trong handler
+// Role → tập quyền lưu trong bảng Role / Role_Permission (migration 028),
+// role con kế thừa quyền của parent_role. Bảng được đọc lại sau cacheTTL
+// hoặc ngay khi admin sửa role (Invalidate).
+// Khi chưa đọc được DB (chưa chạy migration, test) dùng SystemRoles.
+// ============================================================
+
+// Tên quyền
+const (
+	EventRequestCreate  = "event.request.create"  // Gửi / sửa / clone yêu cầu, cập nhật sự kiện của mình
+	EventRequestReview  = "event.request.review"  // Xem và duyệt / từ chối yêu cầu sự kiện
+	EventManageAny      = "event.manage_any"      // Sửa cấu hình, vô hiệu hóa sự kiện của người khác
+	EventStatsView      = "event.stats.view"      // Xem occupancy / thống kê sự kiện
+	EventTemplateManage = "event.template.manage" // Quản lý mẫu sự kiện dùng chung
+	TicketCheckin       = "ticket.checkin"        // Check-in / check-out vé
+	TicketViewAll       = "ticket.view_all"       // Xem danh sách vé (và hóa đơn) của mọi sự kiện
+	TicketCompIssue     = "ticket.comp.issue"     // Phát vé mời
+	PassManage          = "pass.manage"           // Tạo / ngừng bán vé học kỳ (season pass)
+	AttendancePoints    = "attendance.points"     // Đặt điểm rèn luyện cho sự kiện bất kỳ
+	AttendanceExport    = "attendance.export"     // Xuất điểm rèn luyện theo sinh viên cho phòng CTSV
+	ReportReview        = "report.review"         // Xem và xử lý report hoàn tiền
+	VenueManage         = "venue.manage"          // Quản lý venue / area
+	CampusManage        = "campus.manage"         // Tạo campus, xem báo cáo liên campus
+	UserManage          = "user.manage"           // Tạo / sửa / xóa tài khoản
+	RoleManage          = "role.manage"           // Quản lý role và quyền
+	EmailManage         = "email.manage"          // Xem / gửi lại email trong hàng đợi
+	EmailTemplateManage = "email.template.manage" // Sửa, xem trước, rollback mẫu email
+	JobManage           = "job.manage"            // Chạy job nền, backfill
+	SystemConfig        = "system.config"         // Cấu hình hệ thống
+	DiagnosticsView     = "diagnostics.view"      // Trang chẩn đoán
+	DashboardAdmin      = "dashboard.admin"       // KPI toàn hệ thống
+	LedgerView          = "ledger.view"           // Xem sổ cái, bảng cân đối thử
+	SettlementView      = "settlement.view"       // Xem bảng kê đối soát của mình
+	SettlementManage    = "settlement.manage"     // Xem mọi bản đối soát, duyệt, đánh dấu đã trả
+	WidgetManage        = "widget.manage"         // Quản lý widget API key
+	AbuseReview         = "abuse.review"          // Xét duyệt tài khoản bị cách ly do nghi lạm dụng
+)
+
+// Info - Mô tả một quyền (GET /api/admin/permissions)
+type Info struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// Catalog - Danh sách mọi quyền hợp lệ
+var Catalog = []Info{
+	{EventRequestCreate, "Create and update own event requests and events"},
+	{EventRequestReview, "Review, approve and reject event requests"},
+	{EventManageAny, "Configure and disable events of any organizer"},
+	{EventStatsView, "View event occupancy and statistics"},
+	{EventTemplateManage, "Manage shared event templates"},
+	{TicketCheckin, "Check tickets in and out"},
+	{TicketViewAll, "View tickets and bills of all events"},
+	{TicketCompIssue, "Issue complimentary tickets"},
+	{PassManage, "Create and manage season passes"},
+	{AttendancePoints, "Set activity points of any event"},
+	{AttendanceExport, "Export activity points per student"},
+	{ReportReview, "Review refund reports"},
+	{VenueManage, "Manage venues and areas"},
+	{CampusManage, "Create campuses and view cross-campus reports"},
+	{UserManage, "Create, update and delete accounts"},
+	{RoleManage, "Manage roles and permissions"},
+	{EmailManage, "View and retry queued emails"},
+	{EmailTemplateManage, "Edit, preview and roll back email templates"},
+	{JobManage, "Run background jobs and backfills"},
+	{SystemConfig, "Change system configuration"},
+	{DiagnosticsView, "View diagnostics"},
+	{DashboardAdmin, "View the system-wide dashboard"},
+	{LedgerView, "View the accounting ledger and trial balance"},
+	{SettlementView, "View own organizer settlement statements"},
+	{SettlementManage, "Approve organizer settlements and mark them paid"},
+	{WidgetManage, "Manage widget API keys"},
+	{AbuseReview, "Review accounts quarantined by abuse detection"},
+}
+
+// SystemRoles - Quyền mặc định của các role có sẵn (khớp dữ liệu seed của migration 028, 030, 032, 033, 039, 044, 049, 050, 052)
+var SystemRoles = map[string][]string{
+	"ADMIN": {
+		EventRequestCreate, EventRequestReview, EventManageAny, EventStatsView, EventTemplateManage, TicketViewAll,
+		TicketCompIssue, PassManage, AttendancePoints, AttendanceExport, ReportReview, VenueManage, CampusManage, UserManage, RoleManage,
+		EmailManage, EmailTemplateManage, JobManage, SystemConfig, DiagnosticsView, DashboardAdmin, LedgerView, SettlementManage,
+		AbuseReview,
+	},
+	"STAFF":     {EventRequestReview, EventStatsView, TicketViewAll, AttendancePoints, ReportReview, EmailManage},
+	"ORGANIZER": {EventRequestCreate, EventStatsView, TicketCheckin, TicketCompIssue, PassManage, WidgetManage, SettlementView},
+	"STUDENT":   {},
+	"SPEAKER":   {},
+	"GUEST":     {},
+}
+
+// Role - Một role và quyền được gán trực tiếp (chưa tính kế thừa)
+type Role struct {
+	Name        string   `json:"name"`
+	Description *string  `json:"description,omitempty"`
+	ParentRole  *string  `json:"parentRole,omitempty"`
+	System      bool     `json:"system"`
+	Permissions []string `json:"permissions"`
+}
+
+const cacheTTL = 60 * time.Second
+
+var errNoDB = errors.New("database not initialized")
+
+// loader đọc bảng role; thay được trong test
+var loader = LoadRoles
+
+var cache struct {
+	mu        sync.Mutex
+	effective map[string]map[string]bool
+	loadedAt  time.Time
+}
+
+// IsValid - Tên quyền có trong Catalog
+func IsValid(name string) bool {
+	for _, p := range Catalog {
+		if p.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Has - Người dùng hiện tại có quyền perm không (chưa đăng nhập = không)
+func Has(ctx context.Context, perm string) bool {
+	return RoleHas(ctx, authctx.Role(ctx), perm)
+}
+
+// RoleHas - role có quyền perm không (kể cả quyền kế thừa)
+func RoleHas(ctx context.Context, role, perm string) bool {
+	if role == "" {
+		return false
+	}
+	return effectivePermissions(ctx)[role][perm]
+}
+
+// Require - Giống authctx.MustBeRole nhưng theo quyền
+// Trả về userID; ErrUnauthenticated nếu chưa đăng nhập, ErrForbidden nếu thiếu quyền
+func Require(ctx context.Context, perm string) (int, error) {
+	userID, ok := authctx.UserID(ctx)
+	if !ok {
+		return 0, authctx.ErrUnauthenticated
+	}
+	if !Has(ctx, perm) {
+		return 0, authctx.ErrForbidden
+	}
+	return userID, nil
+}
+
+// Effective - Toàn bộ quyền (đã tính kế thừa) của role, sắp xếp theo tên
+func Effective(ctx context.Context, role string) []string {
+	perms := []string{}
+	for p := range effectivePermissions(ctx)[role] {
+		perms = append(perms, p)
+	}
+	sort.Strings(perms)
+	return perms
+}
+
+// Invalidate - Bỏ cache, lần kiểm tra tiếp theo đọc lại từ DB
+func Invalidate() {
+	cache.mu.Lock()
+	cache.effective = nil
+	cache.mu.Unlock()
+}
+
+func effectivePermissions(ctx context.Context) map[string]map[string]bool {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	if cache.effective != nil && time.Since(cache.loadedAt) < cacheTTL {
+		return cache.effective
+	}
+
+	roles, err := loader(ctx)
+	if err != nil {
+		if cache.effective != nil {
+			log.Printf("⚠️  [PERMISSION] Reload failed, keeping cached roles: %v", err)
+			return cache.effective
+		}
+		if !errors.Is(err, errNoDB) {
+			log.Printf("⚠️  [PERMISSION] Failed to load roles, using system defaults: %v", err)
+		}
+		roles = defaultRoles()
+	}
+	cache.effective = resolve(roles)
+	cache.loadedAt = time.Now()
+	return cache.effective
+}
+
+// resolve gộp quyền của role với mọi role tổ tiên (bỏ qua vòng lặp parent)
+func resolve(roles []Role) map[string]map[string]bool {
+	byName := make(map[string]Role, len(roles))
+	for _, r := range roles {
+		byName[r.Name] = r
+	}
+	effective := make(map[string]map[string]bool, len(roles))
+	for _, r := range roles {
+		perms := map[string]bool{}
+		seen := map[string]bool{}
+		for cur, ok := r, true; ok && !seen[cur.Name]; {
+			seen[cur.Name] = true
+			for _, p := range cur.Permissions {
+				perms[p] = true
+			}
+			if cur.ParentRole == nil {
+				break
+			}
+			cur, ok = byName[*cur.ParentRole]
+		}
+		effective[r.Name] = perms
+	}
+	return effective
+}
+
+func defaultRoles() []Role {
+	roles := make([]Role, 0, len(SystemRoles))
+	for name, perms := range SystemRoles {
+		roles = append(roles, Role{Name: name, System: true, Permissions: perms})
+	}
+	return roles
+}
+
+// LoadRoles đọc mọi role cùng quyền gán trực tiếp từ DB
+func LoadRoles(ctx context.Context) ([]Role, error) {
+	conn := db.GetDB()
+	if conn == nil {
+		return nil, errNoDB
+	}
+	rows, err := conn.QueryContext(ctx, `
+		SELECT r.role_name, r.description, r.parent_role, r.is_system, rp.permission
+		FROM Role r
+		LEFT JOIN Role_Permission rp ON rp.role_name = r.role_name
+		ORDER BY r.role_name, rp.permission
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query roles: %w", err)
+	}
+	defer rows.Close()
+
+	var roles []Role
+	for rows.Next() {
+		var (
+			name        string
+			description sql.NullString
+			parent      sql.NullString
+			system      bool
+			perm        sql.NullString
+		)
+		if err := rows.Scan(&name, &description, &parent, &system, &perm); err != nil {
+			return nil, fmt.Errorf("failed to scan role: %w", err)
+		}
+		if len(roles) == 0 || roles[len(roles)-1].Name != name {
+			r := Role{Name: name, System: system, Permissions: []string{}}
+			if description.Valid {
+				r.Description = &description.String
+			}
+			if parent.Valid {
+				r.ParentRole = &parent.String
+			}
+			roles = append(roles, r)
+		}
+		if perm.Valid {
+			last := &roles[len(roles)-1]
+			last.Permissions = append(last.Permissions, perm.String)
+		}
+	}
+	return roles, rows.Err()
+}
@@ -0,0 +1,72 @@
+package permission
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/fpt-event-services/common/authctx"
+)
+
+func strPtr(s string) *string { return &s }
+
+func stubRoles(t *testing.T, roles []Role, err error) {
+	prevLoader := loader
+	loader = func(context.Context) ([]Role, error) { return roles, err }
+	Invalidate()
+	t.Cleanup(func() {
+		loader = prevLoader
+		Invalidate()
+	})
+}
+
+func TestInheritedPermissions(t *testing.T) {
+	stubRoles(t, []Role{
+		{Name: "STAFF", Permissions: []string{EventRequestReview, ReportReview}},
+		{Name: "FINANCE_STAFF", ParentRole: strPtr("STAFF"), Permissions: []string{TicketViewAll}},
+		// Vòng lặp parent không được treo
+		{Name: "A", ParentRole: strPtr("B"), Permissions: []string{JobManage}},
+		{Name: "B", ParentRole: strPtr("A"), Permissions: []string{SystemConfig}},
+	}, nil)
+
+	ctx := authctx.WithUser(context.Background(), 9, "FINANCE_STAFF")
+	for _, p := range []string{TicketViewAll, EventRequestReview, ReportReview} {
+		if !Has(ctx, p) {
+			t.Errorf("FINANCE_STAFF must have %s", p)
+		}
+	}
+	if Has(ctx, VenueManage) {
+		t.Error("FINANCE_STAFF must not have venue.manage")
+	}
+	if RoleHas(ctx, "STAFF", TicketViewAll) {
+		t.Error("parent must not get child permissions")
+	}
+	if !RoleHas(ctx, "A", SystemConfig) || !RoleHas(ctx, "B", JobManage) {
+		t.Error("cyclic roles must still merge both permission sets")
+	}
+	if got := Effective(ctx, "FINANCE_STAFF"); len(got) != 3 {
+		t.Errorf("Effective = %v", got)
+	}
+}
+
+func TestRequireAndDefaults(t *testing.T) {
+	stubRoles(t, nil, errNoDB)
+
+	if _, err := Require(context.Background(), VenueManage); !errors.Is(err, authctx.ErrUnauthenticated) {
+		t.Fatalf("anonymous: err = %v", err)
+	}
+	staff := authctx.WithUser(context.Background(), 3, "STAFF")
+	if _, err := Require(staff, VenueManage); !errors.Is(err, authctx.ErrForbidden) {
+		t.Fatalf("staff venue.manage: err = %v", err)
+	}
+	if id, err := Require(staff, ReportReview); err != nil || id != 3 {
+		t.Fatalf("staff report.review = %d, %v", id, err)
+	}
+	for role, perms := range SystemRoles {
+		for _, p := range perms {
+			if !IsValid(p) {
+				t.Errorf("%s has unknown permission %q", role, p)
+			}
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package qrtoken
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ============================================================
+// QRTOKEN - Mã QR xoay vòng cho sự kiện bật QR động (event.dynamic_qr, migration 057)
+// Mã = "RQR:<ticketId>:<counter>:<chữ ký>", counter = Unix / 30 giây,
+// chữ ký = HMAC-SHA256(ticketId:counter) với QR_TOKEN_SECRET (mặc định suy từ JWT_SECRET;
+// thiếu cả hai thì không ký / kiểm tra mã nào - ErrNoSecret).
+// Mã chỉ dùng được trong bước 30 giây của nó (cộng Grace cho độ trễ quét),
+// nên ảnh chụp màn hình gửi cho người khác hết hạn gần như ngay lập tức
+// ============================================================
+
+const (
+	// Prefix - Tiền tố phân biệt mã xoay vòng với mã QR tĩnh (ticket_id, TICKETS:, TKT_)
+	Prefix = "RQR:"
+	// Step - Thời gian hiệu lực của một mã
+	Step = 30 * time.Second
+	// Grace - Mã vừa hết hạn vẫn nhận thêm chừng này (độ trễ quét, lệch đồng hồ máy quét)
+	Grace = 5 * time.Second
+)
+
+var (
+	// ErrMalformed - Không phải mã xoay vòng hợp lệ
+	ErrMalformed = errors.New("malformed rotating QR token")
+	// ErrSignature - Chữ ký sai (mã bị sửa hoặc ký bằng khóa khác)
+	ErrSignature = errors.New("invalid rotating QR token signature")
+	// ErrExpired - Mã đã quá bước 30 giây của nó (hoặc thuộc bước tương lai)
+	ErrExpired = errors.New("rotating QR token expired")
+	// ErrNoSecret - Chưa cấu hình QR_TOKEN_SECRET lẫn JWT_SECRET
+	ErrNoSecret = errors.New("QR_TOKEN_SECRET and JWT_SECRET are both empty")
+)
+
+// Token - Mã xoay vòng đã ký và thời điểm hết hạn
+type Token struct {
+	Value     string
+	ExpiresAt time.Time
+}
+
+// secretKey đọc QR_TOKEN_SECRET mỗi lần ký / kiểm tra (giống common/jwt) để nhận khóa đã xoay
+func secretKey() ([]byte, error) {
+	if s := os.Getenv("QR_TOKEN_SECRET"); s != "" {
+		return []byte(s), nil
+	}
+	if s := os.Getenv("JWT_SECRET"); s != "" {
+		return []byte("qr-token:" + s), nil
+	}
+	return nil, ErrNoSecret
+}
+
+// IsToken - value là mã xoay vòng (chưa kiểm tra chữ ký)
+func IsToken(value string) bool {
+	return strings.HasPrefix(strings.TrimSpace(value), Prefix)
+}
+
+// Issue - Mã của vé cho bước chứa now
+func Issue(ticketID int, now time.Time) (Token, error) {
+	counter := now.Unix() / int64(Step/time.Second)
+	sig, err := sign(ticketID, counter)
+	if err != nil {
+		return Token{}, err
+	}
+	return Token{
+		Value:     fmt.Sprintf("%s%d:%d:%s", Prefix, ticketID, counter, sig),
+		ExpiresAt: time.Unix((counter+1)*int64(Step/time.Second), 0),
+	}, nil
+}
+
+// Verify - Kiểm tra chữ ký và hạn của mã, trả về ticket_id
+func Verify(value string, now time.Time) (int, error) {
+	parts := strings.Split(strings.TrimPrefix(strings.TrimSpace(value), Prefix), ":")
+	if !IsToken(value) || len(parts) != 3 {
+		return 0, ErrMalformed
+	}
+	ticketID, err := strconv.Atoi(parts[0])
+	if err != nil || ticketID <= 0 {
+		return 0, ErrMalformed
+	}
+	counter, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil || counter < 0 {
+		return 0, ErrMalformed
+	}
+	sig, err := sign(ticketID, counter)
+	if err != nil {
+		return 0, err
+	}
+	if !hmac.Equal([]byte(parts[2]), []byte(sig)) {
+		return 0, ErrSignature
+	}
+	issuedAt := time.Unix(counter*int64(Step/time.Second), 0)
+	if now.Before(issuedAt.Add(-Grace)) || !now.Before(issuedAt.Add(Step+Grace)) {
+		return 0, ErrExpired
+	}
+	return ticketID, nil
+}
+
+func sign(ticketID int, counter int64) (string, error) {
+	key, err := secretKey()
+	if err != nil {
+		return "", err
+	}
+	mac := hmac.New(sha256.New, key)
+	fmt.Fprintf(mac, "%d:%d", ticketID, counter)
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil)[:16]), nil
+}
@@ -0,0 +1,66 @@
+package qrtoken
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestIssueVerify(t *testing.T) {
+	t.Setenv("QR_TOKEN_SECRET", "test-secret")
+	now := time.Unix(1_800_000_010, 0) // 10 giây sau đầu bước
+	tok, err := Issue(42, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !IsToken(tok.Value) {
+		t.Fatalf("IsToken(%q) = false", tok.Value)
+	}
+	if want := time.Unix(1_800_000_030, 0); !tok.ExpiresAt.Equal(want) {
+		t.Errorf("ExpiresAt = %v, want %v", tok.ExpiresAt, want)
+	}
+
+	for _, at := range []time.Time{now, tok.ExpiresAt.Add(Grace - time.Second)} {
+		if id, err := Verify(tok.Value, at); err != nil || id != 42 {
+			t.Errorf("Verify at %v = %d, %v", at, id, err)
+		}
+	}
+	if _, err := Verify(tok.Value, tok.ExpiresAt.Add(Grace)); !errors.Is(err, ErrExpired) {
+		t.Errorf("expired token: got %v", err)
+	}
+}
+
+func TestVerifyRejectsTampering(t *testing.T) {
+	t.Setenv("QR_TOKEN_SECRET", "test-secret")
+	now := time.Now()
+	tok, err := Issue(42, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	forged := strings.Replace(tok.Value, "RQR:42:", "RQR:43:", 1)
+	if _, err := Verify(forged, now); !errors.Is(err, ErrSignature) {
+		t.Errorf("forged ticket: got %v", err)
+	}
+	t.Setenv("QR_TOKEN_SECRET", "rotated")
+	if _, err := Verify(tok.Value, now); !errors.Is(err, ErrSignature) {
+		t.Errorf("other key: got %v", err)
+	}
+	for _, v := range []string{"42", "RQR:42", "RQR:x:1:abc", "RQR:42:-1:abc"} {
+		if _, err := Verify(v, now); !errors.Is(err, ErrMalformed) {
+			t.Errorf("Verify(%q) = %v, want ErrMalformed", v, err)
+		}
+	}
+}
+
+func TestNoSecret(t *testing.T) {
+	t.Setenv("QR_TOKEN_SECRET", "")
+	t.Setenv("JWT_SECRET", "")
+	if _, err := Issue(42, time.Now()); !errors.Is(err, ErrNoSecret) {
+		t.Errorf("Issue without secret: got %v", err)
+	}
+	if _, err := Verify("RQR:42:1:abc", time.Now()); !errors.Is(err, ErrNoSecret) {
+		t.Errorf("Verify without secret: got %v", err)
+	}
+}
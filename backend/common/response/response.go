@@ -4,13 +4,6 @@ import (
 	"encoding/json"
 )
 
-// CORS Headers for API responses
-var CORSHeaders = map[string]string{
-	"Access-Control-Allow-Origin":  "*",
-	"Access-Control-Allow-Methods": "GET,POST,PUT,DELETE,OPTIONS",
-	"Access-Control-Allow-Headers": "Content-Type,Authorization",
-}
-
 // APIResponse represents a standard API response
 type APIResponse struct {
 	Success bool        `json:"success"`
@@ -0,0 +1,426 @@
+package retention
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fpt-event-services/common/db"
+)
+
+// ============================================================
+// RETENTION - Chính sách lưu giữ dữ liệu cũ (migration 046)
+// Số ngày giữ của từng chính sách lưu trong System_Config (key retention.*),
+// 0 = giữ vĩnh viễn. Job data-retention chạy hằng ngày; admin xem trước (dry run)
+// hoặc chạy tay qua POST /api/admin/retention/run.
+//   - ARCHIVE: chuyển dòng sang bảng *_archive rồi xóa khỏi bảng chính (vé)
+//   - DELETE: xóa hẳn (log, hàng đợi đã xử lý, thông báo đã đọc...)
+// OTP không có chính sách: mã chỉ nằm trong bộ nhớ (common/otp) và tự hết hạn.
+// Vé PENDING hết hạn giữ ghế do job pending-ticket-cleanup xóa, file export dữ liệu
+// cá nhân hết hạn do auth-lambda xóa.
+// ============================================================
+
+// Hành động của chính sách
+const (
+	ActionArchive = "ARCHIVE"
+	ActionDelete  = "DELETE"
+)
+
+// Nguồn của số ngày giữ
+const (
+	SourceDefault = "default"
+	SourceSystem  = "system"
+)
+
+const (
+	maxDays = 3650
+	// batchSize - Số dòng mỗi transaction (tránh khóa bảng lâu)
+	batchSize = 500
+	// maxBatches - Số batch tối đa mỗi chính sách mỗi lần chạy; còn dư thì lần sau chạy tiếp
+	maxBatches = 200
+)
+
+var (
+	// ErrInvalidPolicy - Tên chính sách không tồn tại hoặc số ngày ngoài khoảng cho phép
+	ErrInvalidPolicy = errors.New("invalid retention policy")
+	errNoDB          = errors.New("database not initialized")
+)
+
+// Policy - Chính sách đang có hiệu lực (GET /api/admin/retention)
+type Policy struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Table       string `json:"table"`
+	Action      string `json:"action"`
+	Days        int    `json:"days"` // 0 = giữ vĩnh viễn
+	DefaultDays int    `json:"defaultDays"`
+	MinDays     int    `json:"minDays"`
+	Source      string `json:"source"`
+}
+
+// PolicyReport - Kết quả một chính sách trong một lần chạy (dry run: chỉ đếm)
+type PolicyReport struct {
+	Name      string     `json:"name"`
+	Table     string     `json:"table"`
+	Action    string     `json:"action"`
+	Days      int        `json:"days"`
+	Cutoff    *time.Time `json:"cutoff,omitempty"` // nil = chính sách tắt
+	Eligible  int64      `json:"eligible"`
+	Oldest    *time.Time `json:"oldest,omitempty"`
+	Processed int64      `json:"processed"`
+	Remaining bool       `json:"remaining"` // Chạm giới hạn batch, lần chạy sau xử lý tiếp
+	Error     string     `json:"error,omitempty"`
+}
+
+// Report - Kết quả một lần chạy
+type Report struct {
+	DryRun     bool           `json:"dryRun"`
+	StartedAt  time.Time      `json:"startedAt"`
+	FinishedAt time.Time      `json:"finishedAt"`
+	Policies   []PolicyReport `json:"policies"`
+}
+
+// definition - Một chính sách: bảng, điều kiện dòng quá hạn, khoảng ngày hợp lệ
+type definition struct {
+	name        string
+	key         string // Key trong System_Config
+	description string
+	table       string
+	idColumn    string
+	ageColumn   string
+	action      string
+	defaultDays int
+	minDays     int
+	// expired - Điều kiện WHERE của dòng quá hạn; đúng một tham số ? là số ngày
+	expired string
+	// archive - Chép các dòng ids sang bảng lưu trữ trước khi xóa (chỉ ARCHIVE)
+	archive func(ctx context.Context, tx *sql.Tx, in string, ids []any) error
+}
+
+var definitions = []definition{
+	{
+		name:        "tickets",
+		key:         "retention.tickets_days",
+		description: "Vé của sự kiện đã kết thúc (kèm lịch sử trạng thái / check-in) chuyển sang Ticket_Archive",
+		table:       "Ticket", idColumn: "ticket_id", ageColumn: "created_at",
+		action: ActionArchive, defaultDays: 1095, minDays: 365,
+		// Vé còn bị Report / Event_Raffle_Winner tham chiếu thì giữ lại
+		expired: `Ticket.status <> 'PENDING'
+			AND Ticket.event_id IN (SELECT e.event_id FROM Event e WHERE e.end_time < NOW() - INTERVAL ? DAY)
+			AND NOT EXISTS (SELECT 1 FROM Report r WHERE r.ticket_id = Ticket.ticket_id)
+			AND NOT EXISTS (SELECT 1 FROM Event_Raffle_Winner w WHERE w.ticket_id = Ticket.ticket_id)`,
+		archive: archiveTickets,
+	},
+	{
+		name:        "loginHistory",
+		key:         "retention.login_history_days",
+		description: "Lịch sử đăng nhập (IP, user agent)",
+		table:       "User_Login_History", idColumn: "login_id", ageColumn: "logged_in_at",
+		action: ActionDelete, defaultDays: 365, minDays: 30,
+		expired: `logged_in_at < NOW() - INTERVAL ? DAY`,
+	},
+	{
+		name:        "sessions",
+		key:         "retention.sessions_days",
+		description: "Phiên đăng nhập đã hết hạn hoặc bị thu hồi",
+		table:       "User_Session", idColumn: "session_id", ageColumn: "expires_at",
+		action: ActionDelete, defaultDays: 30, minDays: 1,
+		expired: `COALESCE(revoked_at, expires_at) < NOW() - INTERVAL ? DAY`,
+	},
+	{
+		name:        "emailQueue",
+		key:         "retention.email_queue_days",
+		description: "Email đã gửi / DEAD / bị bounce (kèm nội dung và file đính kèm)",
+		table:       "Email_Queue", idColumn: "email_id", ageColumn: "created_at",
+		action: ActionDelete, defaultDays: 90, minDays: 7,
+		expired: `status IN ('SENT', 'DEAD', 'BOUNCED', 'COMPLAINED') AND created_at < NOW() - INTERVAL ? DAY`,
+	},
+	{
+		name:        "emailEvents",
+		key:         "retention.email_events_days",
+		description: "Sự kiện delivered / bounce / complaint từ nhà cung cấp email",
+		table:       "Email_Event", idColumn: "event_id", ageColumn: "received_at",
+		action: ActionDelete, defaultDays: 180, minDays: 30,
+		expired: `received_at < NOW() - INTERVAL ? DAY`,
+	},
+	{
+		name:        "outbox",
+		key:         "retention.outbox_days",
+		description: "Side effect trong outbox đã xong hoặc DEAD",
+		table:       "Outbox_Message", idColumn: "message_id", ageColumn: "created_at",
+		action: ActionDelete, defaultDays: 30, minDays: 7,
+		expired: `status IN ('DONE', 'DEAD') AND created_at < NOW() - INTERVAL ? DAY`,
+	},
+	{
+		name:        "jobRuns",
+		key:         "retention.job_runs_days",
+		description: "Lịch sử chạy job đã kết thúc",
+		table:       "Job_Run", idColumn: "job_run_id", ageColumn: "started_at",
+		action: ActionDelete, defaultDays: 90, minDays: 7,
+		expired: `status <> 'RUNNING' AND started_at < NOW() - INTERVAL ? DAY`,
+	},
+	{
+		name:        "notifications",
+		key:         "retention.notifications_days",
+		description: "Thông báo trong app đã đọc",
+		table:       "Notification", idColumn: "notification_id", ageColumn: "created_at",
+		action: ActionDelete, defaultDays: 180, minDays: 30,
+		expired: `is_read = 1 AND created_at < NOW() - INTERVAL ? DAY`,
+	},
+	{
+		name:        "guestLookupCodes",
+		key:         "retention.guest_lookup_codes_days",
+		description: "Mã tra cứu vé của khách đã hết hạn (tính từ expires_at)",
+		table:       "Guest_Lookup_Code", idColumn: "code_id", ageColumn: "expires_at",
+		action: ActionDelete, defaultDays: 30, minDays: 1,
+		expired: `expires_at < NOW() - INTERVAL ? DAY`,
+	},
+}
+
+// Policies - Các chính sách với số ngày đang áp dụng
+func Policies(ctx context.Context) ([]Policy, error) {
+	values, err := loadValues(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resolve(values), nil
+}
+
+// Update - Ghi số ngày giữ cho các chính sách trong values (tên → số ngày, 0 = giữ vĩnh viễn)
+func Update(ctx context.Context, values map[string]int) error {
+	if len(values) == 0 {
+		return fmt.Errorf("%w: no policies to update", ErrInvalidPolicy)
+	}
+	keys := make(map[string]int, len(values))
+	for name, days := range values {
+		key, err := validate(name, days)
+		if err != nil {
+			return err
+		}
+		keys[key] = days
+	}
+
+	conn := db.GetDB()
+	if conn == nil {
+		return errNoDB
+	}
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+	for key, days := range keys {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO System_Config (config_key, config_value) VALUES (?, ?)
+			ON DUPLICATE KEY UPDATE config_value = VALUES(config_value)
+		`, key, strconv.Itoa(days)); err != nil {
+			return fmt.Errorf("failed to save retention policy %s: %w", key, err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit retention policies: %w", err)
+	}
+	log.Printf("[RETENTION] Updated retention policies: %v", values)
+	return nil
+}
+
+// Run - Áp mọi chính sách đang bật; dryRun chỉ đếm số dòng sẽ bị xử lý
+// Lỗi của một chính sách không dừng các chính sách khác, được ghi vào báo cáo và trả về cuối cùng
+func Run(ctx context.Context, dryRun bool) (*Report, error) {
+	conn := db.GetDB()
+	if conn == nil {
+		return nil, errNoDB
+	}
+	values, err := loadValues(ctx)
+	if err != nil {
+		return nil, err
+	}
+	policies := resolve(values)
+
+	report := &Report{DryRun: dryRun, StartedAt: time.Now(), Policies: make([]PolicyReport, 0, len(definitions))}
+	var failed []string
+	for i, d := range definitions {
+		pr := PolicyReport{Name: d.name, Table: d.table, Action: d.action, Days: policies[i].Days}
+		if pr.Days > 0 {
+			cutoff := report.StartedAt.AddDate(0, 0, -pr.Days)
+			pr.Cutoff = &cutoff
+			if err := d.apply(ctx, conn, &pr, dryRun); err != nil {
+				pr.Error = err.Error()
+				failed = append(failed, d.name)
+				log.Printf("[RETENTION] ❌ Policy %s failed: %v", d.name, err)
+			} else if !dryRun && pr.Processed > 0 {
+				log.Printf("[RETENTION] %s %d row(s) from %s older than %d day(s)", d.action, pr.Processed, d.table, pr.Days)
+			}
+		}
+		report.Policies = append(report.Policies, pr)
+	}
+	report.FinishedAt = time.Now()
+	if len(failed) > 0 {
+		return report, fmt.Errorf("retention policies failed: %s", strings.Join(failed, ", "))
+	}
+	return report, nil
+}
+
+// apply - Đếm dòng quá hạn, rồi (nếu không phải dry run) xử lý theo batch
+func (d definition) apply(ctx context.Context, conn *sql.DB, pr *PolicyReport, dryRun bool) error {
+	var oldest sql.NullTime
+	if err := conn.QueryRowContext(ctx,
+		fmt.Sprintf(`SELECT COUNT(*), MIN(%s) FROM %s WHERE %s`, d.ageColumn, d.table, d.expired), pr.Days,
+	).Scan(&pr.Eligible, &oldest); err != nil {
+		return fmt.Errorf("count expired rows: %w", err)
+	}
+	if oldest.Valid {
+		pr.Oldest = &oldest.Time
+	}
+	if dryRun || pr.Eligible == 0 {
+		return nil
+	}
+
+	for batch := 0; batch < maxBatches; batch++ {
+		n, err := d.processBatch(ctx, conn, pr.Days)
+		pr.Processed += n
+		if err != nil {
+			return err
+		}
+		if n < batchSize {
+			return nil
+		}
+	}
+	pr.Remaining = true
+	return nil
+}
+
+// processBatch - Một transaction: lấy tối đa batchSize id quá hạn, lưu trữ (nếu ARCHIVE) rồi xóa
+func (d definition) processBatch(ctx context.Context, conn *sql.DB, days int) (int64, error) {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, fmt.Sprintf(`SELECT %s FROM %s WHERE %s ORDER BY %s LIMIT %d FOR UPDATE`,
+		d.idColumn, d.table, d.expired, d.idColumn, batchSize), days)
+	if err != nil {
+		return 0, fmt.Errorf("select expired rows: %w", err)
+	}
+	var ids []any
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("scan expired row: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("iterate expired rows: %w", err)
+	}
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	in := "(" + strings.TrimSuffix(strings.Repeat("?, ", len(ids)), ", ") + ")"
+	if d.archive != nil {
+		if err := d.archive(ctx, tx, in, ids); err != nil {
+			return 0, err
+		}
+	}
+	result, err := tx.ExecContext(ctx, fmt.Sprintf(`DELETE FROM %s WHERE %s IN %s`, d.table, d.idColumn, in), ids...)
+	if err != nil {
+		return 0, fmt.Errorf("delete expired rows: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("commit batch: %w", err)
+	}
+	n, _ := result.RowsAffected()
+	return n, nil
+}
+
+// archiveTickets - Chép vé và lịch sử trạng thái sang bảng lưu trữ
+// Không chép qr_code_value: vé đã lưu trữ không còn dùng để check-in
+func archiveTickets(ctx context.Context, tx *sql.Tx, in string, ids []any) error {
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO Ticket_Status_History_Archive (history_id, ticket_id, status, changed_at, changed_by)
+		SELECT history_id, ticket_id, status, changed_at, changed_by
+		FROM Ticket_Status_History
+		WHERE ticket_id IN `+in, ids...); err != nil {
+		return fmt.Errorf("archive ticket status history: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO Ticket_Archive (ticket_id, event_id, user_id, category_ticket_id, bill_id, seat_id, status,
+			is_complimentary, issued_by, pass_holder_id, checkin_time, check_out_time, created_at)
+		SELECT ticket_id, event_id, user_id, category_ticket_id, bill_id, seat_id, status,
+			is_complimentary, issued_by, pass_holder_id, checkin_time, check_out_time, created_at
+		FROM Ticket
+		WHERE ticket_id IN `+in, ids...); err != nil {
+		return fmt.Errorf("archive tickets: %w", err)
+	}
+	return nil
+}
+
+// resolve - Số ngày đang áp dụng của từng chính sách; giá trị hỏng bị bỏ qua (giữ mặc định)
+func resolve(values map[string]string) []Policy {
+	policies := make([]Policy, 0, len(definitions))
+	for _, d := range definitions {
+		p := Policy{
+			Name: d.name, Description: d.description, Table: d.table, Action: d.action,
+			Days: d.defaultDays, DefaultDays: d.defaultDays, MinDays: d.minDays, Source: SourceDefault,
+		}
+		if raw, ok := values[d.key]; ok {
+			if n, err := strconv.Atoi(raw); err == nil && d.allowed(n) {
+				p.Days, p.Source = n, SourceSystem
+			} else {
+				log.Printf("⚠️  [RETENTION] Ignoring %s=%q (allowed 0 or %d-%d)", d.key, raw, d.minDays, maxDays)
+			}
+		}
+		policies = append(policies, p)
+	}
+	return policies
+}
+
+// validate - Kiểm tra tên chính sách và số ngày, trả về key trong System_Config
+func validate(name string, days int) (string, error) {
+	for _, d := range definitions {
+		if d.name != name {
+			continue
+		}
+		if !d.allowed(days) {
+			return "", fmt.Errorf("%w: %s must be 0 (keep forever) or between %d and %d days", ErrInvalidPolicy, name, d.minDays, maxDays)
+		}
+		return d.key, nil
+	}
+	return "", fmt.Errorf("%w: unknown policy %q", ErrInvalidPolicy, name)
+}
+
+// allowed - 0 (tắt) hoặc trong [minDays, maxDays]; minDays là thời hạn giữ tối thiểu bắt buộc
+func (d definition) allowed(days int) bool {
+	return days == 0 || (days >= d.minDays && days <= maxDays)
+}
+
+func loadValues(ctx context.Context) (map[string]string, error) {
+	conn := db.GetDB()
+	if conn == nil {
+		return nil, errNoDB
+	}
+	rows, err := conn.QueryContext(ctx, `SELECT config_key, config_value FROM System_Config WHERE config_key LIKE 'retention.%'`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query retention policies: %w", err)
+	}
+	defer rows.Close()
+
+	values := map[string]string{}
+	for rows.Next() {
+		var key, value string
+		if err := rows.Scan(&key, &value); err != nil {
+			return nil, fmt.Errorf("failed to scan retention policy: %w", err)
+		}
+		values[key] = value
+	}
+	return values, rows.Err()
+}
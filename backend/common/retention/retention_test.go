@@ -0,0 +1,67 @@
+package retention
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestResolveAppliesValidValues(t *testing.T) {
+	policies := resolve(map[string]string{
+		"retention.tickets_days":       "730",
+		"retention.login_history_days": "0",
+		"retention.outbox_days":        "3", // dưới minDays - bỏ qua
+		"retention.job_runs_days":      "abc",
+	})
+	byName := map[string]Policy{}
+	for _, p := range policies {
+		byName[p.Name] = p
+	}
+	if p := byName["tickets"]; p.Days != 730 || p.Source != SourceSystem {
+		t.Errorf("tickets: got %d (%s)", p.Days, p.Source)
+	}
+	if p := byName["loginHistory"]; p.Days != 0 || p.Source != SourceSystem {
+		t.Errorf("loginHistory: 0 should disable the policy, got %d (%s)", p.Days, p.Source)
+	}
+	if p := byName["outbox"]; p.Days != p.DefaultDays || p.Source != SourceDefault {
+		t.Errorf("outbox: invalid value should keep the default, got %d (%s)", p.Days, p.Source)
+	}
+	if p := byName["jobRuns"]; p.Days != p.DefaultDays {
+		t.Errorf("jobRuns: got %d", p.Days)
+	}
+	if len(policies) != len(definitions) {
+		t.Errorf("expected %d policies, got %d", len(definitions), len(policies))
+	}
+}
+
+func TestValidate(t *testing.T) {
+	if key, err := validate("tickets", 365); err != nil || key != "retention.tickets_days" {
+		t.Fatalf("got %q, %v", key, err)
+	}
+	if _, err := validate("tickets", 0); err != nil {
+		t.Fatalf("0 should be allowed (keep forever): %v", err)
+	}
+	for _, tc := range []struct {
+		name string
+		days int
+	}{{"tickets", 30}, {"tickets", maxDays + 1}, {"notifications", -1}, {"otp", 30}} {
+		if _, err := validate(tc.name, tc.days); !errors.Is(err, ErrInvalidPolicy) {
+			t.Errorf("%s=%d: expected ErrInvalidPolicy, got %v", tc.name, tc.days, err)
+		}
+	}
+}
+
+func TestDefinitionsHaveUniqueKeys(t *testing.T) {
+	seen := map[string]bool{}
+	for _, d := range definitions {
+		if seen[d.key] || seen[d.name] {
+			t.Errorf("duplicate policy %s / %s", d.name, d.key)
+		}
+		seen[d.key], seen[d.name] = true, true
+		if (d.action == ActionArchive) != (d.archive != nil) {
+			t.Errorf("%s: ARCHIVE policies need an archive func", d.name)
+		}
+		if !d.allowed(d.defaultDays) {
+			t.Errorf("%s: default %d outside allowed range", d.name, d.defaultDays)
+		}
+	}
+}
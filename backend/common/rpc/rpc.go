@@ -0,0 +1,118 @@
+package rpc
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// ============================================================
+// Package rpc - gRPC nội bộ giữa các service (contract: services/proto)
+// Mỗi service có client riêng (services/<x>-lambda/client) với hai adapter:
+// in-process (gọi thẳng repository, mặc định) và gRPC (khi đặt địa chỉ service).
+//
+//	GRPC_PORT                 cổng server gRPC của process (rỗng = không mở)
+//	GRPC_AUTH_TOKEN           token dùng chung: server bắt buộc metadata
+//	                          "authorization: Bearer <token>", client tự gửi (rỗng = không kiểm tra)
+//	EVENT_SERVICE_GRPC_ADDR   host:port của EventService (rỗng = in-process)
+//	TICKET_SERVICE_GRPC_ADDR  host:port của TicketService (rỗng = in-process)
+//	VENUE_SERVICE_GRPC_ADDR   host:port của VenueService (rỗng = in-process)
+//
+// Kết nối không mã hóa: chỉ dùng trong mạng nội bộ (VPC / service mesh lo TLS)
+// Span được truyền qua metadata traceparent (otelgrpc)
+// ============================================================
+
+// ServiceAddr - Địa chỉ gRPC của service đọc từ biến môi trường, "" = in-process
+func ServiceAddr(envKey string) string {
+	return strings.TrimSpace(os.Getenv(envKey))
+}
+
+// NewServer tạo gRPC server có tracing và kiểm tra GRPC_AUTH_TOKEN
+func NewServer() *grpc.Server {
+	return grpc.NewServer(
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.UnaryInterceptor(authInterceptor(os.Getenv("GRPC_AUTH_TOKEN"))),
+	)
+}
+
+// authInterceptor từ chối request không mang đúng token (token rỗng = không kiểm tra)
+func authInterceptor(token string) grpc.UnaryServerInterceptor {
+	want := "Bearer " + token
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if token == "" {
+			return handler(ctx, req)
+		}
+		md, _ := metadata.FromIncomingContext(ctx)
+		values := md.Get("authorization")
+		if len(values) == 0 || subtle.ConstantTimeCompare([]byte(values[0]), []byte(want)) != 1 {
+			return nil, status.Error(codes.Unauthenticated, "invalid internal token")
+		}
+		return handler(ctx, req)
+	}
+}
+
+// Dial mở kết nối tới service nội bộ tại addr (kết nối thật được tạo ở lần gọi đầu tiên)
+func Dial(addr string) (*grpc.ClientConn, error) {
+	opts := []grpc.DialOption{
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+	}
+	if token := os.Getenv("GRPC_AUTH_TOKEN"); token != "" {
+		opts = append(opts, grpc.WithPerRPCCredentials(bearerToken(token)))
+	}
+	conn, err := grpc.NewClient(addr, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("dial %s: %w", addr, err)
+	}
+	return conn, nil
+}
+
+// bearerToken - Gửi GRPC_AUTH_TOKEN qua metadata authorization
+type bearerToken string
+
+func (t bearerToken) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + string(t)}, nil
+}
+
+func (bearerToken) RequireTransportSecurity() bool { return false }
+
+// MustDial - Dial cho Default() của các client; địa chỉ sai là lỗi cấu hình nên dừng process
+func MustDial(envKey string) *grpc.ClientConn {
+	conn, err := Dial(ServiceAddr(envKey))
+	if err != nil {
+		log.Fatalf("[RPC] Invalid %s: %v", envKey, err)
+	}
+	log.Printf("[RPC] %s → %s", envKey, conn.Target())
+	return conn
+}
+
+// Listen mở cổng GRPC_PORT và chạy srv ở goroutine nền
+// Trả về false nếu GRPC_PORT rỗng (process không phục vụ gRPC)
+func Listen(srv *grpc.Server) (bool, error) {
+	port := strings.TrimSpace(os.Getenv("GRPC_PORT"))
+	if port == "" {
+		return false, nil
+	}
+	lis, err := net.Listen("tcp", ":"+port)
+	if err != nil {
+		return false, fmt.Errorf("listen gRPC on :%s: %w", port, err)
+	}
+	go func() {
+		if err := srv.Serve(lis); err != nil {
+			log.Printf("[RPC] gRPC server stopped: %v", err)
+		}
+	}()
+	log.Printf("[RPC] gRPC server listening on :%s", port)
+	return true, nil
+}
@@ -0,0 +1,71 @@
+package rpc
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// checkHealth gọi grpc.health.v1 trên server của NewServer với token của client
+func checkHealth(t *testing.T, serverToken, clientToken string) error {
+	t.Helper()
+	t.Setenv("GRPC_AUTH_TOKEN", serverToken)
+	lis := bufconn.Listen(1 << 20)
+	srv := NewServer()
+	healthpb.RegisterHealthServer(srv, health.NewServer())
+	go srv.Serve(lis)
+	defer srv.Stop()
+
+	opts := []grpc.DialOption{
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return lis.Dial() }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	}
+	if clientToken != "" {
+		opts = append(opts, grpc.WithPerRPCCredentials(bearerToken(clientToken)))
+	}
+	conn, err := grpc.NewClient("passthrough:///bufnet", opts...)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer conn.Close()
+	_, err = healthpb.NewHealthClient(conn).Check(context.Background(), &healthpb.HealthCheckRequest{})
+	return err
+}
+
+func TestAuthInterceptor(t *testing.T) {
+	tests := []struct {
+		name        string
+		serverToken string
+		clientToken string
+		wantCode    codes.Code
+	}{
+		{"no token configured", "", "", codes.OK},
+		{"matching token", "s3cret", "s3cret", codes.OK},
+		{"missing token", "s3cret", "", codes.Unauthenticated},
+		{"wrong token", "s3cret", "guess", codes.Unauthenticated},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkHealth(t, tt.serverToken, tt.clientToken)
+			if got := status.Code(err); got != tt.wantCode {
+				t.Errorf("code = %v, want %v (err %v)", got, tt.wantCode, err)
+			}
+		})
+	}
+}
+
+func TestListenWithoutPort(t *testing.T) {
+	t.Setenv("GRPC_PORT", "")
+	ok, err := Listen(grpc.NewServer())
+	if ok || err != nil {
+		t.Errorf("Listen = %v, %v; want false, nil when GRPC_PORT is empty", ok, err)
+	}
+}
@@ -0,0 +1,237 @@
+package rules
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/fpt-event-services/common/db"
+)
+
+// ============================================================
+// Quản trị quy tắc - xem quy tắc có hiệu lực, sửa giá trị hệ thống,
+// ghi đè hạn hủy / hạn cập nhật / phí nền tảng cho từng sự kiện, phí nền tảng cho từng organizer
+// ============================================================
+
+// Overrides - Quy tắc ghi đè theo sự kiện (nil = theo hệ thống)
+type Overrides struct {
+	CancelCutoffHours *int `json:"cancelCutoffHours"`
+	UpdateWindowHours *int `json:"updateWindowHours"`
+	PlatformFeeBps    *int `json:"platformFeeBps"`
+}
+
+// OrganizerFee - Phí nền tảng của một organizer (GET|PUT /api/admin/rules/organizers/{id})
+// Override nil = theo rule.platform_fee_bps
+type OrganizerFee struct {
+	OrganizerID    int    `json:"organizerId"`
+	PlatformFeeBps int    `json:"platformFeeBps"`
+	Source         string `json:"source"`
+	Override       *int   `json:"override"`
+}
+
+// View - Quy tắc có hiệu lực (GET /api/admin/rules)
+// Sources: tên quy tắc → default | system | organizer | event
+type View struct {
+	EventID   *int              `json:"eventId,omitempty"`
+	Rules     Rules             `json:"rules"`
+	Sources   map[string]string `json:"sources"`
+	Overrides *Overrides        `json:"overrides,omitempty"`
+}
+
+// Effective - Quy tắc hệ thống, hoặc của một sự kiện nếu eventID != nil
+func Effective(ctx context.Context, eventID *int) (*View, error) {
+	r, systemSources := current(ctx)
+	sources := make(map[string]string, len(systemSources))
+	for k, v := range systemSources {
+		sources[k] = v
+	}
+	view := &View{EventID: eventID, Rules: r, Sources: sources}
+	if eventID == nil {
+		return view, nil
+	}
+
+	o, err := GetEventOverrides(ctx, *eventID)
+	if err != nil {
+		return nil, err
+	}
+	view.Overrides = o
+	if o.CancelCutoffHours != nil {
+		view.Rules.CancelCutoffHours = *o.CancelCutoffHours
+		view.Sources["cancelCutoffHours"] = SourceEvent
+	}
+	if o.UpdateWindowHours != nil {
+		view.Rules.UpdateWindowHours = *o.UpdateWindowHours
+		view.Sources["updateWindowHours"] = SourceEvent
+	}
+	fee, source, err := PlatformFee(ctx, db.GetDB(), *eventID)
+	if err != nil {
+		return nil, err
+	}
+	view.Rules.PlatformFeeBps = fee
+	view.Sources["platformFeeBps"] = source
+	return view, nil
+}
+
+// Update - Ghi giá trị hệ thống cho các quy tắc trong values (tên JSON → giá trị)
+// Quy tắc không có trong values giữ nguyên; mọi giá trị được kiểm tra trước khi ghi
+func Update(ctx context.Context, values map[string]int) error {
+	if len(values) == 0 {
+		return fmt.Errorf("%w: no rules to update", ErrInvalidRule)
+	}
+	keys := make(map[string]int, len(values))
+	for name, v := range values {
+		key, err := validate(name, v)
+		if err != nil {
+			return err
+		}
+		keys[key] = v
+	}
+
+	conn := db.GetDB()
+	if conn == nil {
+		return errNoDB
+	}
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+	for key, v := range keys {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO System_Config (config_key, config_value) VALUES (?, ?)
+			ON DUPLICATE KEY UPDATE config_value = VALUES(config_value)
+		`, key, fmt.Sprint(v)); err != nil {
+			return fmt.Errorf("failed to save rule %s: %w", key, err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit rules: %w", err)
+	}
+	Invalidate()
+	log.Printf("[RULES] Updated system rules: %v", values)
+	return nil
+}
+
+// GetEventOverrides - Quy tắc ghi đè của sự kiện
+func GetEventOverrides(ctx context.Context, eventID int) (*Overrides, error) {
+	conn := db.GetDB()
+	if conn == nil {
+		return nil, errNoDB
+	}
+	var cancel, update, fee sql.NullInt64
+	err := conn.QueryRowContext(ctx, `
+		SELECT cancel_cutoff_hours, update_window_hours, platform_fee_bps FROM Event WHERE event_id = ?
+	`, eventID).Scan(&cancel, &update, &fee)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrEventNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load event rule overrides: %w", err)
+	}
+	o := &Overrides{}
+	if cancel.Valid {
+		o.CancelCutoffHours = intPtr(int(cancel.Int64))
+	}
+	if update.Valid {
+		o.UpdateWindowHours = intPtr(int(update.Int64))
+	}
+	if fee.Valid {
+		o.PlatformFeeBps = intPtr(int(fee.Int64))
+	}
+	return o, nil
+}
+
+// SetEventOverrides - Ghi đè (hoặc bỏ ghi đè khi nil) quy tắc của sự kiện
+func SetEventOverrides(ctx context.Context, eventID int, o Overrides) error {
+	if o.CancelCutoffHours != nil {
+		if _, err := validate("cancelCutoffHours", *o.CancelCutoffHours); err != nil {
+			return err
+		}
+	}
+	if o.UpdateWindowHours != nil {
+		if _, err := validate("updateWindowHours", *o.UpdateWindowHours); err != nil {
+			return err
+		}
+	}
+	if o.PlatformFeeBps != nil {
+		if _, err := validate("platformFeeBps", *o.PlatformFeeBps); err != nil {
+			return err
+		}
+	}
+	// Kiểm tra tồn tại trước: UPDATE không đổi giá trị cũng trả 0 dòng
+	if _, err := GetEventOverrides(ctx, eventID); err != nil {
+		return err
+	}
+	_, err := db.GetDB().ExecContext(ctx, `
+		UPDATE Event SET cancel_cutoff_hours = ?, update_window_hours = ?, platform_fee_bps = ?, version = version + 1
+		WHERE event_id = ?
+	`, o.CancelCutoffHours, o.UpdateWindowHours, o.PlatformFeeBps, eventID)
+	if err != nil {
+		return fmt.Errorf("failed to save event rule overrides: %w", err)
+	}
+	return nil
+}
+
+// GetOrganizerFee - Phí nền tảng có hiệu lực của organizer (chưa tính ghi đè theo sự kiện)
+func GetOrganizerFee(ctx context.Context, organizerID int) (*OrganizerFee, error) {
+	conn := db.GetDB()
+	if conn == nil {
+		return nil, errNoDB
+	}
+	var override sql.NullInt64
+	err := conn.QueryRowContext(ctx, `
+		SELECT f.platform_fee_bps
+		FROM Users u
+		LEFT JOIN Organizer_Fee f ON f.organizer_id = u.user_id
+		WHERE u.user_id = ?
+	`, organizerID).Scan(&override)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrOrganizerNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load organizer fee: %w", err)
+	}
+
+	r, sources := current(ctx)
+	fee := &OrganizerFee{OrganizerID: organizerID}
+	fee.PlatformFeeBps, fee.Source = resolveFee(r.PlatformFeeBps, sources["platformFeeBps"], override, sql.NullInt64{})
+	if override.Valid {
+		fee.Override = intPtr(int(override.Int64))
+	}
+	return fee, nil
+}
+
+// SetOrganizerFee - Ghi đè (hoặc bỏ ghi đè khi nil) phí nền tảng của organizer
+// Chỉ áp cho vé mua sau khi đổi: vé đã bán giữ phí lưu trên Bill_Item
+func SetOrganizerFee(ctx context.Context, organizerID int, bps *int) error {
+	if bps != nil {
+		if _, err := validate("platformFeeBps", *bps); err != nil {
+			return err
+		}
+	}
+	if _, err := GetOrganizerFee(ctx, organizerID); err != nil {
+		return err
+	}
+	var err error
+	if bps == nil {
+		_, err = db.GetDB().ExecContext(ctx, `DELETE FROM Organizer_Fee WHERE organizer_id = ?`, organizerID)
+	} else {
+		_, err = db.GetDB().ExecContext(ctx, `
+			INSERT INTO Organizer_Fee (organizer_id, platform_fee_bps) VALUES (?, ?)
+			ON DUPLICATE KEY UPDATE platform_fee_bps = VALUES(platform_fee_bps)
+		`, organizerID, *bps)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to save organizer fee: %w", err)
+	}
+	if bps == nil {
+		log.Printf("[RULES] Organizer %d platform fee override removed", organizerID)
+	} else {
+		log.Printf("[RULES] Organizer %d platform fee override: %d bps", organizerID, *bps)
+	}
+	return nil
+}
+
+func intPtr(n int) *int { return &n }
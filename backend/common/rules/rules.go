@@ -0,0 +1,282 @@
+package rules
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/fpt-event-services/common/db"
+)
+
+// ============================================================
+// RULES - Quy tắc nghiệp vụ cấu hình được thay cho hằng số rải rác trong repository
+// Giá trị lưu trong bảng System_Config (key rule.*, migration 031), đọc lại sau cacheTTL
+// hoặc ngay khi admin sửa (Invalidate). Thiếu key, giá trị ngoài khoảng cho phép
+// hoặc chưa có DB (test) thì dùng Defaults.
+// Hạn hủy và hạn cập nhật ghi đè được theo sự kiện (cột Event.*, NULL = theo hệ thống);
+// phí nền tảng ghi đè được theo sự kiện hoặc theo organizer (Organizer_Fee, migration 034).
+// ============================================================
+
+// Rules - Bộ quy tắc đang có hiệu lực
+type Rules struct {
+	CancelCutoffHours    int `json:"cancelCutoffHours"`      // Không được hủy sự kiện khi còn dưới N giờ
+	HoldMinutes          int `json:"holdMinutes"`            // Thời gian giữ ghế của vé PENDING
+	HoldExtensionMinutes int `json:"holdExtensionMinutes"`   // Gia hạn giữ ghế (một lần)
+	MaxEventsPerDay      int `json:"maxEventsPerDay"`        // Số sự kiện được duyệt tối đa trong một ngày
+	UpdateWindowHours    int `json:"updateWindowHours"`      // Sự kiện APPROVED/UPDATING phải cập nhật xong trước giờ bắt đầu N giờ
+	MinAdvanceHours      int `json:"minAdvanceHours"`        // Sự kiện phải được lên lịch trước ít nhất N giờ
+	PlatformFeeBps       int `json:"platformFeeBps"`         // Phí nền tảng trên doanh thu vé (basis point, 100 = 1%)
+	SeatPremiumMaxPct    int `json:"seatPremiumMaxPercent"`  // Giá riêng của ghế cao hơn giá loại vé tối đa N%
+	SeatDiscountMaxPct   int `json:"seatDiscountMaxPercent"` // Giá riêng của ghế thấp hơn giá loại vé tối đa N%
+	VATBps               int `json:"vatBps"`                 // Thuế suất VAT đã gồm trong giá vé, in trên biên lai (basis point)
+}
+
+// Defaults - Giá trị trước khi có quy tắc cấu hình được
+var Defaults = Rules{
+	CancelCutoffHours:    24,
+	HoldMinutes:          5,
+	HoldExtensionMinutes: 3,
+	MaxEventsPerDay:      2,
+	UpdateWindowHours:    24,
+	MinAdvanceHours:      24,
+	PlatformFeeBps:       0,
+	SeatPremiumMaxPct:    50,
+	SeatDiscountMaxPct:   50,
+	VATBps:               1000,
+}
+
+// Nguồn của một giá trị trong View.Sources
+const (
+	SourceDefault   = "default"
+	SourceSystem    = "system"
+	SourceEvent     = "event"
+	SourceOrganizer = "organizer"
+)
+
+// ErrInvalidRule - Tên quy tắc không tồn tại hoặc giá trị ngoài khoảng cho phép
+var ErrInvalidRule = errors.New("invalid business rule")
+
+// ErrEventNotFound - Sự kiện cần xem / ghi đè quy tắc không tồn tại
+var ErrEventNotFound = errors.New("event not found")
+
+// ErrOrganizerNotFound - Organizer cần xem / ghi đè phí không tồn tại
+var ErrOrganizerNotFound = errors.New("organizer not found")
+
+// Querier - *sql.Tx hoặc *sql.DB
+type Querier interface {
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// definition - Một quy tắc: tên JSON, key trong System_Config, khoảng hợp lệ
+type definition struct {
+	name     string
+	key      string
+	min, max int
+	field    func(*Rules) *int
+}
+
+var definitions = []definition{
+	{"cancelCutoffHours", "rule.cancel_cutoff_hours", 0, 720, func(r *Rules) *int { return &r.CancelCutoffHours }},
+	{"holdMinutes", "rule.hold_minutes", 1, 60, func(r *Rules) *int { return &r.HoldMinutes }},
+	{"holdExtensionMinutes", "rule.hold_extension_minutes", 0, 60, func(r *Rules) *int { return &r.HoldExtensionMinutes }},
+	{"maxEventsPerDay", "rule.max_events_per_day", 1, 50, func(r *Rules) *int { return &r.MaxEventsPerDay }},
+	{"updateWindowHours", "rule.update_window_hours", 0, 720, func(r *Rules) *int { return &r.UpdateWindowHours }},
+	{"minAdvanceHours", "rule.min_advance_hours", 0, 720, func(r *Rules) *int { return &r.MinAdvanceHours }},
+	{"platformFeeBps", "rule.platform_fee_bps", 0, 10000, func(r *Rules) *int { return &r.PlatformFeeBps }},
+	{"seatPremiumMaxPercent", "rule.seat_premium_max_percent", 0, 200, func(r *Rules) *int { return &r.SeatPremiumMaxPct }},
+	{"seatDiscountMaxPercent", "rule.seat_discount_max_percent", 0, 90, func(r *Rules) *int { return &r.SeatDiscountMaxPct }},
+	{"vatBps", "rule.vat_bps", 0, 10000, func(r *Rules) *int { return &r.VATBps }},
+}
+
+const cacheTTL = 60 * time.Second
+
+var errNoDB = errors.New("database not initialized")
+
+// loader đọc các key rule.* từ System_Config; thay được trong test
+var loader = loadValues
+
+var cache struct {
+	mu       sync.Mutex
+	rules    *Rules
+	sources  map[string]string
+	loadedAt time.Time
+}
+
+// Get - Bộ quy tắc hệ thống hiện tại
+func Get(ctx context.Context) Rules {
+	r, _ := current(ctx)
+	return r
+}
+
+// CancelCutoff - Hạn hủy sự kiện trước giờ bắt đầu
+// override: Event.cancel_cutoff_hours (NULL = theo hệ thống)
+func CancelCutoff(ctx context.Context, override sql.NullInt64) time.Duration {
+	hours := Get(ctx).CancelCutoffHours
+	if override.Valid {
+		hours = int(override.Int64)
+	}
+	return time.Duration(hours) * time.Hour
+}
+
+// UpdateWindow - Hạn hoàn tất cập nhật sự kiện trước giờ bắt đầu
+// override: Event.update_window_hours (NULL = theo hệ thống)
+func UpdateWindow(ctx context.Context, override sql.NullInt64) time.Duration {
+	hours := Get(ctx).UpdateWindowHours
+	if override.Valid {
+		hours = int(override.Int64)
+	}
+	return time.Duration(hours) * time.Hour
+}
+
+// HoldDuration - Thời gian giữ ghế của vé PENDING kể từ lúc tạo URL thanh toán
+func HoldDuration(ctx context.Context) time.Duration {
+	return time.Duration(Get(ctx).HoldMinutes) * time.Minute
+}
+
+// HoldExtension - Thời gian gia hạn giữ ghế (mỗi vé một lần)
+func HoldExtension(ctx context.Context) time.Duration {
+	return time.Duration(Get(ctx).HoldExtensionMinutes) * time.Minute
+}
+
+// MaxEventsPerDay - Số sự kiện được duyệt tối đa trong một ngày
+func MaxEventsPerDay(ctx context.Context) int {
+	return Get(ctx).MaxEventsPerDay
+}
+
+// MinAdvance - Khoảng thời gian tối thiểu giữa lúc gửi yêu cầu và giờ bắt đầu
+func MinAdvance(ctx context.Context) time.Duration {
+	return time.Duration(Get(ctx).MinAdvanceHours) * time.Hour
+}
+
+// SeatPriceBounds - Khoảng % cộng / trừ cho phép của giá riêng từng ghế so với giá loại vé
+func SeatPriceBounds(ctx context.Context) (maxDiscount, maxPremium int) {
+	r := Get(ctx)
+	return r.SeatDiscountMaxPct, r.SeatPremiumMaxPct
+}
+
+// PlatformFee - Phí nền tảng (basis point) áp cho vé của sự kiện tại thời điểm mua và nguồn của nó:
+// Event.platform_fee_bps → Organizer_Fee của người tạo sự kiện → rule.platform_fee_bps
+// q: transaction mua vé (hoặc DB)
+func PlatformFee(ctx context.Context, q Querier, eventID int) (int, string, error) {
+	var eventFee, organizerFee sql.NullInt64
+	err := q.QueryRowContext(ctx, `
+		SELECT e.platform_fee_bps, f.platform_fee_bps
+		FROM Event e
+		LEFT JOIN Organizer_Fee f ON f.organizer_id = e.created_by
+		WHERE e.event_id = ?
+	`, eventID).Scan(&eventFee, &organizerFee)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, "", ErrEventNotFound
+	}
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to load platform fee: %w", err)
+	}
+	r, sources := current(ctx)
+	bps, source := resolveFee(r.PlatformFeeBps, sources["platformFeeBps"], organizerFee, eventFee)
+	return bps, source, nil
+}
+
+// resolveFee - Ghi đè của sự kiện thắng ghi đè của organizer, rồi mới tới giá trị hệ thống
+func resolveFee(system int, systemSource string, organizer, event sql.NullInt64) (int, string) {
+	switch {
+	case event.Valid:
+		return int(event.Int64), SourceEvent
+	case organizer.Valid:
+		return int(organizer.Int64), SourceOrganizer
+	}
+	return system, systemSource
+}
+
+// Invalidate - Bỏ cache, lần đọc tiếp theo lấy lại từ DB
+func Invalidate() {
+	cache.mu.Lock()
+	cache.rules = nil
+	cache.mu.Unlock()
+}
+
+func current(ctx context.Context) (Rules, map[string]string) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	if cache.rules != nil && time.Since(cache.loadedAt) < cacheTTL {
+		return *cache.rules, cache.sources
+	}
+
+	values, err := loader(ctx)
+	if err != nil {
+		if cache.rules != nil {
+			log.Printf("⚠️  [RULES] Reload failed, keeping cached rules: %v", err)
+			return *cache.rules, cache.sources
+		}
+		if !errors.Is(err, errNoDB) {
+			log.Printf("⚠️  [RULES] Failed to load rules, using defaults: %v", err)
+		}
+		values = nil
+	}
+	r, sources := apply(values)
+	cache.rules = &r
+	cache.sources = sources
+	cache.loadedAt = time.Now()
+	return r, sources
+}
+
+// apply - Áp các giá trị key → value lên Defaults; giá trị hỏng bị bỏ qua (giữ mặc định)
+func apply(values map[string]string) (Rules, map[string]string) {
+	r := Defaults
+	sources := make(map[string]string, len(definitions))
+	for _, d := range definitions {
+		sources[d.name] = SourceDefault
+		raw, ok := values[d.key]
+		if !ok {
+			continue
+		}
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < d.min || n > d.max {
+			log.Printf("⚠️  [RULES] Ignoring %s=%q (allowed %d-%d)", d.key, raw, d.min, d.max)
+			continue
+		}
+		*d.field(&r) = n
+		sources[d.name] = SourceSystem
+	}
+	return r, sources
+}
+
+// validate - Kiểm tra tên quy tắc và khoảng giá trị, trả về key trong System_Config
+func validate(name string, value int) (string, error) {
+	for _, d := range definitions {
+		if d.name != name {
+			continue
+		}
+		if value < d.min || value > d.max {
+			return "", fmt.Errorf("%w: %s must be between %d and %d", ErrInvalidRule, name, d.min, d.max)
+		}
+		return d.key, nil
+	}
+	return "", fmt.Errorf("%w: unknown rule %q", ErrInvalidRule, name)
+}
+
+func loadValues(ctx context.Context) (map[string]string, error) {
+	conn := db.GetDB()
+	if conn == nil {
+		return nil, errNoDB
+	}
+	rows, err := conn.QueryContext(ctx, `SELECT config_key, config_value FROM System_Config WHERE config_key LIKE 'rule.%'`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query rules: %w", err)
+	}
+	defer rows.Close()
+
+	values := map[string]string{}
+	for rows.Next() {
+		var key, value string
+		if err := rows.Scan(&key, &value); err != nil {
+			return nil, fmt.Errorf("failed to scan rule: %w", err)
+		}
+		values[key] = value
+	}
+	return values, rows.Err()
+}
@@ -0,0 +1,102 @@
+package rules
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+)
+
+func stubValues(t *testing.T, values map[string]string, err error) {
+	prevLoader := loader
+	loader = func(context.Context) (map[string]string, error) { return values, err }
+	Invalidate()
+	t.Cleanup(func() {
+		loader = prevLoader
+		Invalidate()
+	})
+}
+
+func TestGetAppliesSystemValues(t *testing.T) {
+	stubValues(t, map[string]string{
+		"rule.cancel_cutoff_hours": "48",
+		"rule.max_events_per_day":  "3",
+		// Giá trị hỏng / ngoài khoảng giữ mặc định
+		"rule.hold_minutes":        "abc",
+		"rule.min_advance_hours":   "-1",
+		"rule.update_window_hours": "721",
+	}, nil)
+
+	ctx := context.Background()
+	got := Get(ctx)
+	want := Defaults
+	want.CancelCutoffHours = 48
+	want.MaxEventsPerDay = 3
+	if got != want {
+		t.Fatalf("Get = %+v, want %+v", got, want)
+	}
+
+	_, sources := current(ctx)
+	if sources["cancelCutoffHours"] != SourceSystem || sources["holdMinutes"] != SourceDefault {
+		t.Errorf("sources = %v", sources)
+	}
+}
+
+func TestLoadFailureUsesDefaults(t *testing.T) {
+	stubValues(t, nil, errors.New("table missing"))
+	if got := Get(context.Background()); got != Defaults {
+		t.Errorf("Get = %+v, want defaults", got)
+	}
+}
+
+func TestPerEventOverride(t *testing.T) {
+	stubValues(t, map[string]string{"rule.cancel_cutoff_hours": "12"}, nil)
+	ctx := context.Background()
+
+	if got := CancelCutoff(ctx, sql.NullInt64{}); got != 12*time.Hour {
+		t.Errorf("system cutoff = %v", got)
+	}
+	if got := CancelCutoff(ctx, sql.NullInt64{Int64: 0, Valid: true}); got != 0 {
+		t.Errorf("override 0 must allow cancelling any time, got %v", got)
+	}
+	if got := UpdateWindow(ctx, sql.NullInt64{Int64: 6, Valid: true}); got != 6*time.Hour {
+		t.Errorf("update window override = %v", got)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	if key, err := validate("holdMinutes", 10); err != nil || key != "rule.hold_minutes" {
+		t.Errorf("validate(holdMinutes, 10) = %q, %v", key, err)
+	}
+	for _, tc := range []struct {
+		name  string
+		value int
+	}{
+		{"holdMinutes", 0},
+		{"maxEventsPerDay", 51},
+		{"unknownRule", 1},
+	} {
+		if _, err := validate(tc.name, tc.value); !errors.Is(err, ErrInvalidRule) {
+			t.Errorf("validate(%s, %d) = %v, want ErrInvalidRule", tc.name, tc.value, err)
+		}
+	}
+}
+
+func TestResolveFee(t *testing.T) {
+	none := sql.NullInt64{}
+	for _, tc := range []struct {
+		organizer, event sql.NullInt64
+		want             int
+		source           string
+	}{
+		{none, none, 100, SourceSystem},
+		{sql.NullInt64{Int64: 250, Valid: true}, none, 250, SourceOrganizer},
+		{sql.NullInt64{Int64: 250, Valid: true}, sql.NullInt64{Int64: 0, Valid: true}, 0, SourceEvent},
+	} {
+		got, source := resolveFee(100, SourceSystem, tc.organizer, tc.event)
+		if got != tc.want || source != tc.source {
+			t.Errorf("resolveFee(%v, %v) = %d, %s; want %d, %s", tc.organizer, tc.event, got, source, tc.want, tc.source)
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/fpt-event-services/common/waitingroom"
+)
+
+// BookingQueueDispatchScheduler cho người đầu hàng chờ mở bán vào bước giữ ghế
+// Khóa GET_LOCK của Manager đảm bảo mỗi nhịp chỉ một instance cho người vào
+type BookingQueueDispatchScheduler struct {
+	room *waitingroom.Room
+}
+
+// NewBookingQueueDispatchScheduler creates a new scheduler
+func NewBookingQueueDispatchScheduler() *BookingQueueDispatchScheduler {
+	return &BookingQueueDispatchScheduler{
+		room: waitingroom.Default(),
+	}
+}
+
+// Run admits the next batch of every event queue (job "booking-queue-dispatch")
+func (s *BookingQueueDispatchScheduler) Run(ctx context.Context) error {
+	n, err := s.room.Tick(ctx)
+	if n > 0 {
+		log.Printf("[WAITING_ROOM] Admitted %d user(s) to seat hold", n)
+	}
+	if err != nil {
+		return fmt.Errorf("dispatch booking queue: %w", err)
+	}
+	return nil
+}
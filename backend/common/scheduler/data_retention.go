@@ -0,0 +1,33 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fpt-event-services/common/retention"
+)
+
+// DataRetentionScheduler applies the retention policies (common/retention)
+// Tickets of long-finished events are archived, logs and processed queue rows are deleted
+type DataRetentionScheduler struct{}
+
+// NewDataRetentionScheduler creates a new data retention scheduler
+func NewDataRetentionScheduler() *DataRetentionScheduler {
+	return &DataRetentionScheduler{}
+}
+
+// Run archives / deletes rows older than their policy (job "data-retention")
+func (s *DataRetentionScheduler) Run(ctx context.Context) error {
+	report, err := retention.Run(ctx, false)
+	if report != nil {
+		for _, p := range report.Policies {
+			if p.Processed > 0 || p.Remaining {
+				fmt.Printf("[RETENTION] %s: %s %d row(s), remaining=%v\n", p.Name, p.Action, p.Processed, p.Remaining)
+			}
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("data retention: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/fpt-event-services/common/email"
+)
+
+// emailQueueBatchSize - Số email tối đa gửi lại mỗi lần chạy
+const emailQueueBatchSize = 50
+
+// EmailQueueScheduler gửi lại các email lỗi trong Email_Queue theo backoff
+type EmailQueueScheduler struct {
+	queue *email.Queue
+}
+
+// NewEmailQueueScheduler creates a new email queue scheduler
+func NewEmailQueueScheduler() *EmailQueueScheduler {
+	return &EmailQueueScheduler{
+		queue: email.DefaultQueue(),
+	}
+}
+
+// Run processes due emails (job "email-queue")
+func (s *EmailQueueScheduler) Run(ctx context.Context) error {
+	stats, err := s.queue.ProcessDue(ctx, emailQueueBatchSize)
+	if err != nil {
+		return fmt.Errorf("process email queue: %w", err)
+	}
+	if stats.Sent+stats.Retried+stats.Dead > 0 {
+		log.Printf("[EMAIL_QUEUE] sent=%d, retry=%d, dead=%d", stats.Sent, stats.Retried, stats.Dead)
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/fpt-event-services/common/eventbus"
+	"github.com/fpt-event-services/services/event-lambda/repository"
+)
+
+// archivalBatchSize - Số sự kiện tối đa xử lý mỗi lần chạy
+const archivalBatchSize = 100
+
+// EventArchivalScheduler closes ended events and finalizes their statistics
+// Thay thế job event-cleanup cũ (chỉ đổi status sang CLOSED)
+type EventArchivalScheduler struct {
+	eventRepo *repository.EventRepository
+}
+
+// NewEventArchivalScheduler creates a new scheduler
+func NewEventArchivalScheduler() *EventArchivalScheduler {
+	return &EventArchivalScheduler{
+		eventRepo: repository.DefaultEventRepository(),
+	}
+}
+
+// Run archives all OPEN events whose end_time has passed (job "event-archival")
+// Mỗi sự kiện một transaction riêng: lỗi ở một sự kiện không chặn các sự kiện khác
+func (s *EventArchivalScheduler) Run(ctx context.Context) error {
+	eventIDs, err := s.eventRepo.ListEventsToArchive(ctx, archivalBatchSize)
+	if err != nil {
+		return err
+	}
+
+	var archivedCount, failedCount int
+	for _, eventID := range eventIDs {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		_, archived, err := s.eventRepo.ArchiveEndedEvent(ctx, eventID)
+		if err != nil {
+			log.Printf("[SCHEDULER] Error archiving event #%d: %v", eventID, err)
+			failedCount++
+			continue
+		}
+		if archived {
+			archivedCount++
+			eventbus.Publish(ctx, eventbus.EventClosed{EventID: eventID, Reason: eventbus.CloseReasonEnded, OccurredAt: time.Now()})
+		}
+	}
+
+	if archivedCount > 0 {
+		log.Printf("[SCHEDULER] 📊 Archived %d ended events", archivedCount)
+	}
+	if failedCount > 0 {
+		return fmt.Errorf("%d ended events failed to archive", failedCount)
+	}
+	return nil
+}
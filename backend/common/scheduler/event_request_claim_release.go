@@ -0,0 +1,33 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fpt-event-services/services/event-lambda/repository"
+)
+
+// EventRequestClaimReleaseScheduler nhả claim của người duyệt đã quá hạn không thao tác
+type EventRequestClaimReleaseScheduler struct {
+	eventRepo *repository.EventRepository
+}
+
+// NewEventRequestClaimReleaseScheduler creates a new claim release scheduler
+func NewEventRequestClaimReleaseScheduler() *EventRequestClaimReleaseScheduler {
+	return &EventRequestClaimReleaseScheduler{
+		eventRepo: repository.DefaultEventRepository(),
+	}
+}
+
+// Run clears expired claims and claims of already processed requests (job "event-request-claim-release")
+func (s *EventRequestClaimReleaseScheduler) Run(ctx context.Context) error {
+	released, err := s.eventRepo.ReleaseStaleEventRequestClaims(ctx)
+	if err != nil {
+		fmt.Printf("[CLAIM_RELEASE] ❌ Error releasing stale claims: %v\n", err)
+		return fmt.Errorf("release stale claims: %w", err)
+	}
+	if released > 0 {
+		fmt.Printf("[CLAIM_RELEASE] Released %d stale event request claim(s)\n", released)
+	}
+	return nil
+}
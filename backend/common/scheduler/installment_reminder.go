@@ -0,0 +1,37 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/fpt-event-services/services/ticket-lambda/repository"
+)
+
+// installmentRemindBefore - Gửi email nhắc kỳ trả góp trước hạn bao lâu
+const installmentRemindBefore = 72 * time.Hour
+
+// InstallmentReminderScheduler reminds buyers of upcoming installments and suspends tickets of overdue bills
+type InstallmentReminderScheduler struct {
+	ticketRepo *repository.TicketRepository
+}
+
+// NewInstallmentReminderScheduler creates a new scheduler
+func NewInstallmentReminderScheduler() *InstallmentReminderScheduler {
+	return &InstallmentReminderScheduler{
+		ticketRepo: repository.DefaultTicketRepository(),
+	}
+}
+
+// Run sends due-soon reminders and suspends tickets with an overdue installment (job "installment-reminder")
+func (s *InstallmentReminderScheduler) Run(ctx context.Context) error {
+	reminded, suspended, err := s.ticketRepo.RemindInstallments(ctx, installmentRemindBefore)
+	if err != nil {
+		return err
+	}
+	if suspended > 0 {
+		log.Printf("[SCHEDULER] ⚠️ Suspended tickets of %d bill(s) with overdue installments", suspended)
+	}
+	log.Printf("[SCHEDULER] 💳 Sent %d installment reminder(s)", reminded)
+	return nil
+}
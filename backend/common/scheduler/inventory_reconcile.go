@@ -0,0 +1,36 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+
+	"github.com/fpt-event-services/services/ticket-lambda/repository"
+)
+
+// InventoryReconcileScheduler recounts Category_Ticket_Inventory from the Ticket table
+// Bộ đếm được cập nhật cùng transaction với vé nên bình thường không lệch;
+// job này sửa các trường hợp sửa tay DB hoặc đường xử lý cũ quên trả suất
+type InventoryReconcileScheduler struct {
+	ticketRepo *repository.TicketRepository
+}
+
+// NewInventoryReconcileScheduler creates a new scheduler
+func NewInventoryReconcileScheduler() *InventoryReconcileScheduler {
+	return &InventoryReconcileScheduler{
+		ticketRepo: repository.DefaultTicketRepository(),
+	}
+}
+
+// Run fixes every counter that differs from COUNT(*) of active tickets (job "inventory-reconcile")
+func (s *InventoryReconcileScheduler) Run(ctx context.Context) error {
+	drifts, err := s.ticketRepo.ReconcileInventory(ctx)
+	for _, d := range drifts {
+		log.Printf("[SCHEDULER] ⚠️ Inventory drift on category ticket #%d: counter=%d, actual=%d (fixed)",
+			d.CategoryTicketID, d.Counter, d.Actual)
+	}
+	if err != nil {
+		return err
+	}
+	log.Printf("[SCHEDULER] 📊 Inventory reconciled, %d counters fixed", len(drifts))
+	return nil
+}
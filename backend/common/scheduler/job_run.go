@@ -0,0 +1,134 @@
+package scheduler
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// Trạng thái của một lần chạy job (Job_Run.status)
+const (
+	RunStatusRunning = "RUNNING"
+	RunStatusSuccess = "SUCCESS"
+	RunStatusFailed  = "FAILED"
+)
+
+// Nguồn kích hoạt job (Job_Run.trigger_type)
+const (
+	TriggerSchedule = "SCHEDULE"
+	TriggerManual   = "MANUAL"
+	TriggerBackfill = "BACKFILL"
+)
+
+// JobRun - Một lần chạy job (bảng Job_Run)
+type JobRun struct {
+	RunID        int64      `json:"runId"`
+	JobName      string     `json:"jobName"`
+	TriggerType  string     `json:"triggerType"`
+	TriggeredBy  *int       `json:"triggeredBy,omitempty"`
+	InstanceID   string     `json:"instanceId"`
+	Status       string     `json:"status"`
+	StartedAt    time.Time  `json:"startedAt"`
+	FinishedAt   *time.Time `json:"finishedAt,omitempty"`
+	DurationMs   *int64     `json:"durationMs,omitempty"`
+	ErrorMessage *string    `json:"errorMessage,omitempty"`
+}
+
+// RunStore ghi lịch sử chạy job vào bảng Job_Run
+// db == nil (chưa kết nối DB) thì mọi thao tác là no-op, job vẫn chạy bình thường
+type RunStore struct {
+	db *sql.DB
+}
+
+// NewRunStore creates a new run store
+func NewRunStore(db *sql.DB) *RunStore {
+	return &RunStore{db: db}
+}
+
+// Start ghi nhận job bắt đầu chạy, trả về run_id (0 nếu không lưu được)
+func (s *RunStore) Start(ctx context.Context, run *JobRun) (int64, error) {
+	if s == nil || s.db == nil {
+		return 0, nil
+	}
+
+	var triggeredBy sql.NullInt64
+	if run.TriggeredBy != nil {
+		triggeredBy = sql.NullInt64{Int64: int64(*run.TriggeredBy), Valid: true}
+	}
+
+	result, err := s.db.ExecContext(ctx, `
+		INSERT INTO Job_Run (job_name, trigger_type, triggered_by, instance_id, status, started_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`, run.JobName, run.TriggerType, triggeredBy, run.InstanceID, run.Status, run.StartedAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+// Finish cập nhật kết quả của lần chạy
+func (s *RunStore) Finish(ctx context.Context, run *JobRun) error {
+	if s == nil || s.db == nil || run.RunID == 0 {
+		return nil
+	}
+
+	var errMsg sql.NullString
+	if run.ErrorMessage != nil {
+		errMsg = sql.NullString{String: *run.ErrorMessage, Valid: true}
+	}
+
+	_, err := s.db.ExecContext(ctx, `
+		UPDATE Job_Run
+		SET status = ?, finished_at = ?, duration_ms = ?, error_message = ?
+		WHERE job_run_id = ?
+	`, run.Status, run.FinishedAt, run.DurationMs, errMsg, run.RunID)
+	return err
+}
+
+// Recent lấy các lần chạy gần nhất của một job (mới nhất trước)
+func (s *RunStore) Recent(ctx context.Context, jobName string, limit int) ([]JobRun, error) {
+	if s == nil || s.db == nil {
+		return nil, nil
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT job_run_id, job_name, trigger_type, triggered_by, COALESCE(instance_id, ''), status,
+		       started_at, finished_at, duration_ms, error_message
+		FROM Job_Run
+		WHERE job_name = ?
+		ORDER BY started_at DESC, job_run_id DESC
+		LIMIT ?
+	`, jobName, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var runs []JobRun
+	for rows.Next() {
+		var run JobRun
+		var triggeredBy, durationMs sql.NullInt64
+		var finishedAt sql.NullTime
+		var errMsg sql.NullString
+
+		if err := rows.Scan(&run.RunID, &run.JobName, &run.TriggerType, &triggeredBy, &run.InstanceID, &run.Status,
+			&run.StartedAt, &finishedAt, &durationMs, &errMsg); err != nil {
+			return nil, err
+		}
+		if triggeredBy.Valid {
+			v := int(triggeredBy.Int64)
+			run.TriggeredBy = &v
+		}
+		if finishedAt.Valid {
+			run.FinishedAt = &finishedAt.Time
+		}
+		if durationMs.Valid {
+			run.DurationMs = &durationMs.Int64
+		}
+		if errMsg.Valid {
+			run.ErrorMessage = &errMsg.String
+		}
+		runs = append(runs, run)
+	}
+	return runs, rows.Err()
+}
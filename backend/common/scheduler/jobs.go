@@ -0,0 +1,170 @@
+package scheduler
+
+import (
+	"time"
+
+	"github.com/fpt-event-services/common/waitingroom"
+)
+
+// ============================================================
+// RegisterDefaultJobs - Đăng ký các job định kỳ của hệ thống
+// Tên job dùng cho POST /api/admin/jobs/{name}/run-now và cột Job_Run.job_name
+// ============================================================
+func RegisterDefaultJobs(m *Manager) error {
+	statsAggregation := NewStatsAggregationScheduler()
+	settlementCompute := NewSettlementComputeScheduler()
+	jobs := []Job{
+		{
+			// Đóng sự kiện OPEN đã kết thúc, chốt thống kê vào Event_Summary,
+			// khóa ghế chưa bán, giải phóng khu vực và chuyển Event_Request → FINISHED
+			Name:        "event-archival",
+			Description: "Đóng và lưu trữ các sự kiện đã qua end_time",
+			Schedule:    "@every 5m",
+			RunOnStart:  true,
+			Run:         NewEventArchivalScheduler().Run,
+		},
+		{
+			// Giống Java backend - release ghế nếu user không hoàn thành thanh toán
+			// Hạn giữ ghế 5 phút, gia hạn được 1 lần +3 phút (Ticket.hold_expires_at)
+			Name:        "pending-ticket-cleanup",
+			Description: "Xóa vé PENDING đã hết hạn giữ ghế",
+			Schedule:    "@every 1m",
+			RunOnStart:  true,
+			Timeout:     2 * time.Minute,
+			Run:         NewPendingTicketCleanupScheduler().Run,
+		},
+		{
+			// Sự kiện APPROVED/UPDATING đã vào hạn cập nhật (rule.update_window_hours) → CLOSED + giải phóng địa điểm
+			Name:        "expired-requests-cleanup",
+			Description: "Bãi bỏ sự kiện quá hạn cập nhật (mặc định 24h trước giờ bắt đầu)",
+			Schedule:    "0 * * * *",
+			RunOnStart:  true,
+			Run:         NewExpiredRequestsCleanupScheduler().Run,
+		},
+		{
+			// Chỉ giải phóng các địa điểm thuộc sự kiện đã CLOSED
+			Name:        "venue-release",
+			Description: "Giải phóng địa điểm của các sự kiện đã kết thúc",
+			Schedule:    "@every 5m",
+			RunOnStart:  true,
+			Run:         NewVenueReleaseScheduler().Run,
+		},
+		{
+			// Gom số liệu các ngày đã qua vào Daily_Event_Stats / Daily_Revenue
+			// API thống kê đọc bảng tổng hợp + phần realtime từ ngày chưa tổng hợp
+			Name:        "stats-aggregation",
+			Description: "Tổng hợp thống kê vé và doanh thu theo ngày",
+			Schedule:    "15 1 * * *",
+			RunOnStart:  true,
+			Timeout:     30 * time.Minute,
+			Run:         statsAggregation.Run,
+			Backfill:    statsAggregation.Backfill,
+		},
+		{
+			// Đếm lại vé đang chiếm suất, sửa Category_Ticket_Inventory nếu lệch
+			Name:        "inventory-reconcile",
+			Description: "Đối soát bộ đếm vé đã bán theo loại vé",
+			Schedule:    "30 2 * * *",
+			Timeout:     10 * time.Minute,
+			Run:         NewInventoryReconcileScheduler().Run,
+		},
+		{
+			// Người giữ vé học kỳ nhận vé cho sự kiện của pass vừa mở bán (mỗi sự kiện một vé,
+			// bỏ qua người đã tự mua vé); hết chỗ thì lần chạy sau thử lại
+			Name:        "pass-ticket-issue",
+			Description: "Cấp vé cho người giữ vé học kỳ ở các sự kiện mới mở bán",
+			Schedule:    "@every 10m",
+			Timeout:     5 * time.Minute,
+			Run:         NewPassTicketIssueScheduler().Run,
+		},
+		{
+			// Nhắc kỳ trả góp đến hạn trong 72 giờ; hóa đơn có kỳ quá hạn thì khóa vé
+			// (không check-in được) tới khi trả xong kỳ quá hạn
+			Name:        "installment-reminder",
+			Description: "Nhắc hạn trả góp và tạm khóa vé có kỳ quá hạn",
+			Schedule:    "@every 1h",
+			Timeout:     10 * time.Minute,
+			Run:         NewInstallmentReminderScheduler().Run,
+		},
+		{
+			// Entry cân bằng, USER_WALLET khớp users.Wallet, hóa đơn PAID đều đã ghi sổ
+			Name:        "ledger-invariants",
+			Description: "Kiểm tra bất biến của sổ cái kế toán",
+			Schedule:    "45 2 * * *",
+			Timeout:     10 * time.Minute,
+			Run:         NewLedgerInvariantsScheduler().Run,
+		},
+		{
+			// Doanh thu sự kiện CLOSED theo tháng → bản đối soát PENDING cho từng organizer
+			// (bản đã duyệt / đã trả giữ nguyên)
+			Name:        "settlement-compute",
+			Description: "Tính đối soát doanh thu cho ban tổ chức",
+			Schedule:    "0 3 * * *",
+			Timeout:     10 * time.Minute,
+			Run:         settlementCompute.Run,
+			Backfill:    settlementCompute.Backfill,
+		},
+		{
+			// Chấm điểm sự kiện OPEN theo lịch sử check-in (mẫu sự kiện, ban tổ chức, buổi)
+			// + số vé bán gần đây từ Daily_Event_Stats → Event_Recommendation
+			Name:        "recommendation-scoring",
+			Description: "Tính gợi ý sự kiện cho student theo lịch sử tham dự",
+			Schedule:    "30 3 * * *",
+			Timeout:     15 * time.Minute,
+			Run:         NewRecommendationScoringScheduler().Run,
+		},
+		{
+			// Claim của người duyệt quá 30 phút không thao tác, hoặc yêu cầu đã xử lý xong
+			// (API đã coi claim quá hạn là trống, job chỉ dọn dữ liệu)
+			Name:        "event-request-claim-release",
+			Description: "Nhả claim yêu cầu sự kiện không còn hoạt động",
+			Schedule:    "@every 5m",
+			Run:         NewEventRequestClaimReleaseScheduler().Run,
+		},
+		{
+			// Gửi lại email lỗi (1m, 5m, 15m, 1h, 4h), quá 5 lần → DEAD
+			Name:        "email-queue",
+			Description: "Gửi lại email trong hàng đợi theo backoff",
+			Schedule:    "@every 1m",
+			Timeout:     5 * time.Minute,
+			Run:         NewEmailQueueScheduler().Run,
+		},
+		{
+			// Side effect ghi trong transaction nghiệp vụ (email vé sau VNPay callback...)
+			// chưa chạy xong: lỗi (30s, 1m, 5m, 15m, 1h, 4h), quá 8 lần → DEAD
+			Name:        "outbox",
+			Description: "Chạy lại side effect trong transactional outbox",
+			Schedule:    "@every 1m",
+			RunOnStart:  true,
+			Timeout:     5 * time.Minute,
+			Run:         NewOutboxScheduler().Run,
+		},
+		{
+			// Chính sách lưu giữ (System_Config retention.*): vé của sự kiện kết thúc lâu → Ticket_Archive,
+			// log đăng nhập / email / outbox / job run / thông báo đã đọc quá hạn → xóa
+			// Xem trước: POST /api/admin/retention/run?dryRun=true
+			Name:        "data-retention",
+			Description: "Lưu trữ / xóa dữ liệu cũ theo chính sách lưu giữ",
+			Schedule:    "0 4 * * *",
+			Timeout:     30 * time.Minute,
+			Run:         NewDataRetentionScheduler().Run,
+		},
+	}
+
+	// Hàng chờ mở bán (bảng booking_queue_entry): mỗi BOOKING_QUEUE_INTERVAL_SECONDS cho một nhóm
+	// người của sự kiện bật booking_queue vào giữ ghế; nhịp ngắn nên không chạy lúc khởi động
+	jobs = append(jobs, Job{
+		Name:        "booking-queue-dispatch",
+		Description: "Cho người trong hàng chờ mở bán vào giữ ghế",
+		Schedule:    "@every " + waitingroom.ConfigFromEnv().Interval.String(),
+		Timeout:     time.Minute,
+		Run:         NewBookingQueueDispatchScheduler().Run,
+	})
+
+	for _, job := range jobs {
+		if err := m.Register(job); err != nil {
+			return err
+		}
+	}
+	return nil
+}
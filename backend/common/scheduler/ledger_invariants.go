@@ -0,0 +1,34 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/fpt-event-services/common/ledger"
+)
+
+// LedgerInvariantsScheduler kiểm tra bất biến của sổ cái kế toán kép
+// Không tự sửa dữ liệu: vi phạm được ghi log và job kết thúc FAILED để tài chính đối soát
+type LedgerInvariantsScheduler struct{}
+
+// NewLedgerInvariantsScheduler creates a new scheduler
+func NewLedgerInvariantsScheduler() *LedgerInvariantsScheduler {
+	return &LedgerInvariantsScheduler{}
+}
+
+// Run checks balanced entries, wallet balances, refunds payable and unposted bills (job "ledger-invariants")
+func (s *LedgerInvariantsScheduler) Run(ctx context.Context) error {
+	violations, err := ledger.CheckInvariants(ctx)
+	if err != nil {
+		return fmt.Errorf("check ledger invariants: %w", err)
+	}
+	for _, v := range violations {
+		log.Printf("[SCHEDULER] ⚠️ Ledger invariant %s violated: %s", v.Check, v.Detail)
+	}
+	if len(violations) > 0 {
+		return fmt.Errorf("%d ledger invariant violation(s)", len(violations))
+	}
+	log.Printf("[SCHEDULER] 📊 Ledger invariants OK")
+	return nil
+}
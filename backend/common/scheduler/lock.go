@@ -0,0 +1,80 @@
+package scheduler
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"log"
+	"os"
+)
+
+// Locker - Khóa phân tán giữa nhiều instance backend
+// TryLock không chờ: ok == false nghĩa là instance khác đang giữ khóa
+type Locker interface {
+	TryLock(ctx context.Context, name string) (unlock func(), ok bool, err error)
+}
+
+// ============================================================
+// MySQLLocker - Khóa bằng GET_LOCK/RELEASE_LOCK của MySQL
+// GET_LOCK gắn với session nên mỗi khóa giữ riêng một connection
+// trong suốt thời gian job chạy. Nếu instance chết, MySQL đóng
+// session và tự nhả khóa (không cần lease/heartbeat)
+// ============================================================
+type MySQLLocker struct {
+	db     *sql.DB
+	prefix string
+}
+
+// NewMySQLLocker creates a new MySQL advisory locker
+func NewMySQLLocker(db *sql.DB) *MySQLLocker {
+	return &MySQLLocker{db: db, prefix: "fpt_event:job:"}
+}
+
+// TryLock lấy khóa ngay lập tức (timeout 0), trả về hàm nhả khóa
+func (l *MySQLLocker) TryLock(ctx context.Context, name string) (func(), bool, error) {
+	if l == nil || l.db == nil {
+		return func() {}, true, nil
+	}
+
+	// MySQL giới hạn tên khóa 64 ký tự
+	lockName := l.prefix + name
+	if len(lockName) > 64 {
+		lockName = lockName[:64]
+	}
+
+	conn, err := l.db.Conn(ctx)
+	if err != nil {
+		return nil, false, fmt.Errorf("get connection for lock: %w", err)
+	}
+
+	var acquired sql.NullInt64
+	if err := conn.QueryRowContext(ctx, "SELECT GET_LOCK(?, 0)", lockName).Scan(&acquired); err != nil {
+		conn.Close()
+		return nil, false, fmt.Errorf("GET_LOCK %s: %w", lockName, err)
+	}
+	if !acquired.Valid || acquired.Int64 != 1 {
+		conn.Close()
+		return nil, false, nil
+	}
+
+	unlock := func() {
+		// Dùng context mới: ctx của job có thể đã hết hạn
+		if _, err := conn.ExecContext(context.Background(), "DO RELEASE_LOCK(?)", lockName); err != nil {
+			log.Printf("[SCHEDULER] ⚠️ Failed to release lock %s: %v", lockName, err)
+			// Bỏ connection khỏi pool để session đóng hẳn và MySQL tự nhả khóa
+			conn.Raw(func(any) error { return driver.ErrBadConn })
+		}
+		conn.Close()
+	}
+	return unlock, true, nil
+}
+
+// instanceID định danh instance đang chạy job (ghi vào Job_Run.instance_id)
+func instanceID() string {
+	host, err := os.Hostname()
+	if err != nil || host == "" {
+		host = "unknown"
+	}
+	return fmt.Sprintf("%s-%d", host, os.Getpid())
+}
@@ -0,0 +1,441 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"runtime/debug"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/fpt-event-services/common/db"
+	"github.com/fpt-event-services/common/tracing"
+)
+
+var (
+	// ErrJobNotFound - Tên job chưa được đăng ký
+	ErrJobNotFound = errors.New("job not found")
+	// ErrJobAlreadyRunning - Job đang chạy, không cho chạy chồng
+	ErrJobAlreadyRunning = errors.New("job is already running")
+	// ErrJobLockedElsewhere - Instance khác đang giữ khóa của job
+	ErrJobLockedElsewhere = errors.New("job is running on another instance")
+	// ErrBackfillNotSupported - Job không khai báo Backfill
+	ErrBackfillNotSupported = errors.New("job does not support backfill")
+)
+
+// defaultJobTimeout - Thời gian tối đa một lần chạy nếu job không khai báo Timeout
+const defaultJobTimeout = 10 * time.Minute
+
+// JobFunc - Hàm thực thi job, trả về error để ghi nhận FAILED vào Job_Run
+type JobFunc func(ctx context.Context) error
+
+// BackfillFunc - Chạy lại job cho khoảng ngày [from, to] (ngày theo giờ địa phương)
+type BackfillFunc func(ctx context.Context, from, to time.Time) error
+
+// Job - Định nghĩa một job định kỳ
+type Job struct {
+	Name        string
+	Description string
+	Schedule    string        // Biểu thức cron hoặc "@every 5m" (xem ParseSchedule)
+	RunOnStart  bool          // Chạy ngay một lần khi Start()
+	Timeout     time.Duration // Mặc định defaultJobTimeout
+	Run         JobFunc
+	Backfill    BackfillFunc // nil = không hỗ trợ POST /api/admin/jobs/{name}/backfill
+}
+
+// JobStatus - Trạng thái job trả về cho API admin
+type JobStatus struct {
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	Schedule    string     `json:"schedule"`
+	Running     bool       `json:"running"`
+	Backfill    bool       `json:"backfill"` // Hỗ trợ POST /api/admin/jobs/{name}/backfill
+	NextRunAt   *time.Time `json:"nextRunAt,omitempty"`
+	LastRun     *JobRun    `json:"lastRun,omitempty"`
+	RecentRuns  []JobRun   `json:"recentRuns"`
+}
+
+type registeredJob struct {
+	job      Job
+	schedule Schedule
+	running  atomic.Bool
+
+	mu      sync.Mutex
+	nextRun time.Time
+	lastRun *JobRun
+}
+
+// Manager quản lý các job: lập lịch, chống chạy chồng và ghi lịch sử vào Job_Run
+type Manager struct {
+	mu       sync.RWMutex
+	jobs     map[string]*registeredJob
+	order    []string
+	store    *RunStore
+	locker   Locker // nil = không khóa phân tán (chạy một instance)
+	instance string
+	started  bool
+	stopChan chan struct{}
+	wg       sync.WaitGroup
+}
+
+// NewManager creates a new job manager
+func NewManager(store *RunStore, locker Locker) *Manager {
+	return &Manager{
+		jobs:     make(map[string]*registeredJob),
+		store:    store,
+		locker:   locker,
+		instance: instanceID(),
+		stopChan: make(chan struct{}),
+	}
+}
+
+var (
+	defaultManager     *Manager
+	defaultManagerOnce sync.Once
+)
+
+// DefaultManager trả về manager dùng chung của process
+// (lưu lịch sử vào DB hiện tại, khóa bằng GET_LOCK để nhiều instance không chạy trùng job)
+func DefaultManager() *Manager {
+	defaultManagerOnce.Do(func() {
+		conn := db.GetDB()
+		defaultManager = NewManager(NewRunStore(conn), NewMySQLLocker(conn))
+	})
+	return defaultManager
+}
+
+// Register đăng ký job; phải gọi trước Start()
+func (m *Manager) Register(job Job) error {
+	if job.Name == "" || job.Run == nil {
+		return fmt.Errorf("job name and run func are required")
+	}
+	schedule, err := ParseSchedule(job.Schedule)
+	if err != nil {
+		return fmt.Errorf("job %s: %w", job.Name, err)
+	}
+	if job.Timeout <= 0 {
+		job.Timeout = defaultJobTimeout
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.started {
+		return fmt.Errorf("job %s: cannot register after manager started", job.Name)
+	}
+	if _, exists := m.jobs[job.Name]; exists {
+		return fmt.Errorf("job %s is already registered", job.Name)
+	}
+	m.jobs[job.Name] = &registeredJob{job: job, schedule: schedule}
+	m.order = append(m.order, job.Name)
+	return nil
+}
+
+// Start chạy vòng lặp lập lịch cho từng job (mỗi job một goroutine)
+func (m *Manager) Start() {
+	m.mu.Lock()
+	if m.started {
+		m.mu.Unlock()
+		return
+	}
+	m.started = true
+	jobs := make([]*registeredJob, 0, len(m.order))
+	for _, name := range m.order {
+		jobs = append(jobs, m.jobs[name])
+	}
+	m.mu.Unlock()
+
+	for _, rj := range jobs {
+		m.wg.Add(1)
+		go m.loop(rj)
+		log.Printf("[SCHEDULER] ✅ Job %s registered (schedule: %s)", rj.job.Name, rj.job.Schedule)
+	}
+}
+
+// Stop dừng lập lịch và chờ các vòng lặp kết thúc (job đang chạy vẫn được chạy nốt)
+func (m *Manager) Stop() {
+	m.mu.Lock()
+	if !m.started {
+		m.mu.Unlock()
+		return
+	}
+	m.started = false
+	m.mu.Unlock()
+
+	close(m.stopChan)
+	m.wg.Wait()
+	log.Println("[SCHEDULER] All jobs stopped")
+}
+
+func (m *Manager) loop(rj *registeredJob) {
+	defer m.wg.Done()
+
+	if rj.job.RunOnStart {
+		m.runScheduled(rj)
+	}
+
+	for {
+		next := rj.schedule.Next(time.Now())
+		if next.IsZero() {
+			log.Printf("[SCHEDULER] Job %s has no upcoming run, loop ended", rj.job.Name)
+			return
+		}
+		rj.mu.Lock()
+		rj.nextRun = next
+		rj.mu.Unlock()
+
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-timer.C:
+			m.runScheduled(rj)
+		case <-m.stopChan:
+			timer.Stop()
+			return
+		}
+	}
+}
+
+func (m *Manager) runScheduled(rj *registeredJob) {
+	run, unlock, err := m.begin(rj, TriggerSchedule, nil)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrJobAlreadyRunning):
+			log.Printf("[SCHEDULER] ⏭️ Job %s skipped: previous run still in progress", rj.job.Name)
+		case errors.Is(err, ErrJobLockedElsewhere):
+			log.Printf("[SCHEDULER] ⏭️ Job %s skipped: running on another instance", rj.job.Name)
+		default:
+			log.Printf("[SCHEDULER] ❌ Job %s not started: %v", rj.job.Name, err)
+		}
+		return
+	}
+	m.execute(rj, run, unlock, rj.job.Run)
+}
+
+// RunNow kích hoạt job ngay lập tức (chạy nền), trả về bản ghi Job_Run vừa tạo
+// Trả về ErrJobAlreadyRunning nếu job đang chạy, ErrJobLockedElsewhere nếu instance khác đang chạy
+func (m *Manager) RunNow(name string, triggeredBy *int) (*JobRun, error) {
+	m.mu.RLock()
+	rj, ok := m.jobs[name]
+	m.mu.RUnlock()
+	if !ok {
+		return nil, ErrJobNotFound
+	}
+
+	run, unlock, err := m.begin(rj, TriggerManual, triggeredBy)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := *run
+	go m.execute(rj, run, unlock, rj.job.Run)
+	return &snapshot, nil
+}
+
+// Backfill chạy lại job cho khoảng ngày [from, to] (chạy nền), ghi Job_Run với trigger BACKFILL
+// Dùng chung khóa với lần chạy theo lịch nên không chạy chồng với job đó
+func (m *Manager) Backfill(name string, from, to time.Time, triggeredBy *int) (*JobRun, error) {
+	m.mu.RLock()
+	rj, ok := m.jobs[name]
+	m.mu.RUnlock()
+	if !ok {
+		return nil, ErrJobNotFound
+	}
+	if rj.job.Backfill == nil {
+		return nil, ErrBackfillNotSupported
+	}
+
+	run, unlock, err := m.begin(rj, TriggerBackfill, triggeredBy)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := *run
+	go m.execute(rj, run, unlock, func(ctx context.Context) error {
+		return rj.job.Backfill(ctx, from, to)
+	})
+	return &snapshot, nil
+}
+
+// RunNowAndWait chạy job ngay trong goroutine hiện tại và trả về kết quả cuối (CLI quản trị)
+// Lỗi của job nằm trong JobRun.Status/ErrorMessage; error chỉ dùng cho lỗi không chạy được job
+func (m *Manager) RunNowAndWait(name string, triggeredBy *int) (*JobRun, error) {
+	m.mu.RLock()
+	rj, ok := m.jobs[name]
+	m.mu.RUnlock()
+	if !ok {
+		return nil, ErrJobNotFound
+	}
+
+	run, unlock, err := m.begin(rj, TriggerManual, triggeredBy)
+	if err != nil {
+		return nil, err
+	}
+	m.execute(rj, run, unlock, rj.job.Run)
+
+	rj.mu.Lock()
+	defer rj.mu.Unlock()
+	snapshot := *run
+	return &snapshot, nil
+}
+
+// Names trả về tên các job đã đăng ký theo thứ tự đăng ký
+func (m *Manager) Names() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return append([]string(nil), m.order...)
+}
+
+// Started cho biết vòng lặp lập lịch đã chạy (giữa Start và Stop)
+func (m *Manager) Started() bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.started
+}
+
+// begin đánh dấu job đang chạy, lấy khóa phân tán và ghi bản ghi RUNNING vào Job_Run
+// Hàm unlock trả về phải được gọi khi job kết thúc (execute tự gọi)
+func (m *Manager) begin(rj *registeredJob, trigger string, triggeredBy *int) (*JobRun, func(), error) {
+	if !rj.running.CompareAndSwap(false, true) {
+		return nil, nil, ErrJobAlreadyRunning
+	}
+
+	unlock := func() {}
+	if m.locker != nil {
+		release, ok, err := m.locker.TryLock(context.Background(), rj.job.Name)
+		if err != nil {
+			rj.running.Store(false)
+			return nil, nil, fmt.Errorf("acquire lock: %w", err)
+		}
+		if !ok {
+			rj.running.Store(false)
+			return nil, nil, ErrJobLockedElsewhere
+		}
+		unlock = release
+	}
+
+	run := &JobRun{
+		JobName:     rj.job.Name,
+		TriggerType: trigger,
+		TriggeredBy: triggeredBy,
+		InstanceID:  m.instance,
+		Status:      RunStatusRunning,
+		StartedAt:   time.Now(),
+	}
+
+	runID, err := m.store.Start(context.Background(), run)
+	if err != nil {
+		// Không lưu được lịch sử không nên chặn job chạy
+		log.Printf("[SCHEDULER] ⚠️ Failed to record start of job %s: %v", rj.job.Name, err)
+	}
+	run.RunID = runID
+
+	rj.mu.Lock()
+	rj.lastRun = run
+	rj.mu.Unlock()
+	return run, unlock, nil
+}
+
+// execute chạy fn (Run hoặc Backfill của job) với timeout, bắt panic, ghi kết quả rồi nhả khóa
+func (m *Manager) execute(rj *registeredJob, run *JobRun, unlock func(), fn JobFunc) {
+	defer rj.running.Store(false)
+	defer unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), rj.job.Timeout)
+	defer cancel()
+
+	// Mỗi lần chạy là một trace gốc, truy vấn DB của job thành span con
+	ctx, span := tracing.Start(ctx, "job "+run.JobName, tracing.WithAttributes(
+		tracing.String("job.name", run.JobName),
+		tracing.String("job.trigger", run.TriggerType),
+		tracing.Int("job.run_id", int(run.RunID)),
+	))
+	defer span.End()
+
+	err := safeRun(ctx, fn)
+	span.RecordError(err)
+
+	finishedAt := time.Now()
+	duration := finishedAt.Sub(run.StartedAt).Milliseconds()
+
+	rj.mu.Lock()
+	run.FinishedAt = &finishedAt
+	run.DurationMs = &duration
+	if err != nil {
+		msg := err.Error()
+		run.Status = RunStatusFailed
+		run.ErrorMessage = &msg
+	} else {
+		run.Status = RunStatusSuccess
+	}
+	rj.mu.Unlock()
+
+	if err != nil {
+		log.Printf("[SCHEDULER] ❌ Job %s failed after %dms: %v", run.JobName, duration, err)
+	}
+
+	if storeErr := m.store.Finish(context.Background(), run); storeErr != nil {
+		log.Printf("[SCHEDULER] ⚠️ Failed to record result of job %s: %v", run.JobName, storeErr)
+	}
+}
+
+func safeRun(ctx context.Context, fn JobFunc) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+			log.Printf("[SCHEDULER] panic stack: %s", debug.Stack())
+		}
+	}()
+	return fn(ctx)
+}
+
+// List trả về trạng thái các job theo thứ tự đăng ký, kèm historyLimit lần chạy gần nhất
+func (m *Manager) List(ctx context.Context, historyLimit int) []JobStatus {
+	m.mu.RLock()
+	jobs := make([]*registeredJob, 0, len(m.order))
+	for _, name := range m.order {
+		jobs = append(jobs, m.jobs[name])
+	}
+	m.mu.RUnlock()
+
+	statuses := make([]JobStatus, 0, len(jobs))
+	for _, rj := range jobs {
+		status := JobStatus{
+			Name:        rj.job.Name,
+			Description: rj.job.Description,
+			Schedule:    rj.job.Schedule,
+			Running:     rj.running.Load(),
+			Backfill:    rj.job.Backfill != nil,
+			RecentRuns:  []JobRun{},
+		}
+
+		rj.mu.Lock()
+		if !rj.nextRun.IsZero() {
+			next := rj.nextRun
+			status.NextRunAt = &next
+		}
+		if rj.lastRun != nil {
+			last := *rj.lastRun
+			status.LastRun = &last
+		}
+		rj.mu.Unlock()
+
+		if historyLimit > 0 {
+			runs, err := m.store.Recent(ctx, rj.job.Name, historyLimit)
+			if err != nil {
+				log.Printf("[SCHEDULER] ⚠️ Failed to load run history of job %s: %v", rj.job.Name, err)
+			} else if len(runs) > 0 {
+				status.RecentRuns = runs
+				// Sau khi restart process, lastRun trong bộ nhớ rỗng → lấy từ DB
+				if status.LastRun == nil {
+					last := runs[0]
+					status.LastRun = &last
+				}
+			}
+		}
+
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
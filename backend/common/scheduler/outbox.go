@@ -0,0 +1,36 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/fpt-event-services/common/outbox"
+)
+
+// outboxBatchSize - Số message tối đa xử lý mỗi lần chạy
+const outboxBatchSize = 50
+
+// OutboxScheduler chạy lại các message Outbox_Message lỗi / bị bỏ dở theo backoff
+type OutboxScheduler struct {
+	outbox *outbox.Outbox
+}
+
+// NewOutboxScheduler creates a new outbox scheduler
+func NewOutboxScheduler() *OutboxScheduler {
+	return &OutboxScheduler{
+		outbox: outbox.Default(),
+	}
+}
+
+// Run processes due outbox messages (job "outbox")
+func (s *OutboxScheduler) Run(ctx context.Context) error {
+	stats, err := s.outbox.ProcessDue(ctx, outboxBatchSize)
+	if err != nil {
+		return fmt.Errorf("process outbox: %w", err)
+	}
+	if stats.Done+stats.Retried+stats.Dead > 0 {
+		log.Printf("[OUTBOX] done=%d, retry=%d, dead=%d", stats.Done, stats.Retried, stats.Dead)
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+
+	"github.com/fpt-event-services/services/ticket-lambda/repository"
+)
+
+// PassTicketIssueScheduler issues tickets to season pass holders for events that opened after purchase
+// Vé của sự kiện đang mở bán lúc mua pass đã được cấp ngay; job này lo các sự kiện mở bán sau đó
+type PassTicketIssueScheduler struct {
+	ticketRepo *repository.TicketRepository
+}
+
+// NewPassTicketIssueScheduler creates a new scheduler
+func NewPassTicketIssueScheduler() *PassTicketIssueScheduler {
+	return &PassTicketIssueScheduler{
+		ticketRepo: repository.DefaultTicketRepository(),
+	}
+}
+
+// Run issues one ticket per active holder and open event of the pass (job "pass-ticket-issue")
+func (s *PassTicketIssueScheduler) Run(ctx context.Context) error {
+	issued, failed, err := s.ticketRepo.IssuePassTickets(ctx)
+	if err != nil {
+		return err
+	}
+	if failed > 0 {
+		log.Printf("[SCHEDULER] ⚠️ %d pass ticket(s) could not be issued (sold out / no free seat)", failed)
+	}
+	log.Printf("[SCHEDULER] 🎟️ Issued %d pass ticket(s)", issued)
+	return nil
+}
@@ -3,93 +3,62 @@ package scheduler
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"log"
 	"time"
 
 	"github.com/fpt-event-services/common/db"
+	"github.com/fpt-event-services/common/rules"
 )
 
 // PendingTicketCleanupScheduler handles automatic cleanup of expired PENDING tickets
 type PendingTicketCleanupScheduler struct {
-	db            *sql.DB
-	interval      time.Duration
-	timeoutMinute int
-	stopChan      chan bool
+	db *sql.DB
 }
 
 // NewPendingTicketCleanupScheduler creates a new scheduler
-func NewPendingTicketCleanupScheduler(intervalMinutes int) *PendingTicketCleanupScheduler {
+func NewPendingTicketCleanupScheduler() *PendingTicketCleanupScheduler {
 	return &PendingTicketCleanupScheduler{
-		db:            db.GetDB(),
-		interval:      time.Duration(intervalMinutes) * time.Minute,
-		timeoutMinute: 5,
-		stopChan:      make(chan bool),
+		db: db.GetDB(),
 	}
 }
 
-// Start begins the scheduled cleanup job
-func (s *PendingTicketCleanupScheduler) Start() {
-	log.Printf("[SCHEDULER] PENDING ticket cleanup job started (runs every %v minutes, timeout: %d minutes)",
-		s.interval, s.timeoutMinute)
-
-	// Run immediately once at startup
-	s.cleanupExpiredPendingTickets()
-
-	// Then run periodically
-	ticker := time.NewTicker(s.interval)
-	go func() {
-		for {
-			select {
-			case <-ticker.C:
-				s.cleanupExpiredPendingTickets()
-			case <-s.stopChan:
-				ticker.Stop()
-				log.Println("[SCHEDULER] PENDING ticket cleanup job stopped")
-				return
-			}
-		}
-	}()
-}
-
-// Stop stops the scheduler
-func (s *PendingTicketCleanupScheduler) Stop() {
-	s.stopChan <- true
-}
-
-// cleanupExpiredPendingTickets removes PENDING tickets that exceed timeout
-func (s *PendingTicketCleanupScheduler) cleanupExpiredPendingTickets() {
-	ctx := context.Background()
-
-	// Find all PENDING tickets that were created more than timeoutMinute ago
+// Run removes PENDING tickets whose hold has expired (job "pending-ticket-cleanup")
+func (s *PendingTicketCleanupScheduler) Run(ctx context.Context) error {
+	// Find all PENDING tickets whose hold has expired
+	// hold_expires_at có thể đã được user gia hạn (POST /api/registrations/holds/extend)
+	// Vé cũ chưa có hold_expires_at: fallback created_at + rule.hold_minutes
+	timeoutMinute := rules.Get(ctx).HoldMinutes
 	// ✅ FIXED: Removed non-existent registration_id column
 	query := `
-		SELECT ticket_id, user_id, event_id, seat_id, created_at
+		SELECT ticket_id, user_id, event_id, category_ticket_id, seat_id, created_at
 		FROM Ticket 
 		WHERE status = 'PENDING' 
-		  AND created_at < DATE_SUB(NOW(), INTERVAL ? MINUTE)
+		  AND COALESCE(hold_expires_at, DATE_ADD(created_at, INTERVAL ? MINUTE)) < NOW()
 	`
 
-	rows, err := s.db.QueryContext(ctx, query, s.timeoutMinute)
+	rows, err := s.db.QueryContext(ctx, query, timeoutMinute)
 	if err != nil {
-		log.Printf("[SCHEDULER] Error querying expired PENDING tickets: %v", err)
-		return
+		return fmt.Errorf("query expired PENDING tickets: %w", err)
 	}
 	defer rows.Close()
 
 	var ticketIDs []int
 	var seatIDs []int
+	categoryOf := map[int]int{}
 	var processedCount int
 
 	for rows.Next() {
-		var ticketID, userID, eventID, seatID int
+		var ticketID, userID, eventID, categoryTicketID, seatID int
 		var createdAt time.Time
 
-		if err := rows.Scan(&ticketID, &userID, &eventID, &seatID, &createdAt); err != nil {
+		if err := rows.Scan(&ticketID, &userID, &eventID, &categoryTicketID, &seatID, &createdAt); err != nil {
 			log.Printf("[SCHEDULER] Error scanning ticket row: %v", err)
 			continue
 		}
 
 		ticketIDs = append(ticketIDs, ticketID)
+		categoryOf[ticketID] = categoryTicketID
 		seatIDs = append(seatIDs, seatID)
 		processedCount++
 
@@ -97,22 +66,26 @@ func (s *PendingTicketCleanupScheduler) cleanupExpiredPendingTickets() {
 			ticketID, userID, eventID, createdAt.Format("2006-01-02 15:04:05"))
 	}
 
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("iterate expired PENDING tickets: %w", err)
+	}
 	if len(ticketIDs) == 0 {
-		return
+		return nil
 	}
 
 	// Begin transaction
 	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
-		log.Printf("[SCHEDULER] Error starting transaction: %v", err)
-		return
+		return fmt.Errorf("begin transaction: %w", err)
 	}
 	defer tx.Rollback()
 
 	// Delete PENDING tickets
 	for _, ticketID := range ticketIDs {
-		deleteQuery := `DELETE FROM Ticket WHERE ticket_id = ? AND status = 'PENDING'`
-		result, err := tx.ExecContext(ctx, deleteQuery, ticketID)
+		// Điều kiện hết hạn kiểm tra lại: user có thể vừa gia hạn sau lúc SELECT
+		deleteQuery := `DELETE FROM Ticket WHERE ticket_id = ? AND status = 'PENDING'
+			AND COALESCE(hold_expires_at, DATE_ADD(created_at, INTERVAL ? MINUTE)) < NOW()`
+		result, err := tx.ExecContext(ctx, deleteQuery, ticketID, timeoutMinute)
 		if err != nil {
 			log.Printf("[SCHEDULER] Error deleting ticket #%d: %v", ticketID, err)
 			continue
@@ -120,6 +93,12 @@ func (s *PendingTicketCleanupScheduler) cleanupExpiredPendingTickets() {
 
 		rowsAffected, _ := result.RowsAffected()
 		if rowsAffected > 0 {
+			// Trả lại suất cho bộ đếm Category_Ticket_Inventory
+			if _, err := tx.ExecContext(ctx,
+				`UPDATE Category_Ticket_Inventory SET sold = GREATEST(sold - 1, 0) WHERE category_ticket_id = ?`,
+				categoryOf[ticketID]); err != nil {
+				return fmt.Errorf("release inventory of ticket #%d: %w", ticketID, err)
+			}
 			log.Printf("[SCHEDULER] ✅ Deleted expired PENDING ticket #%d", ticketID)
 		}
 	}
@@ -130,9 +109,9 @@ func (s *PendingTicketCleanupScheduler) cleanupExpiredPendingTickets() {
 
 	// Commit transaction
 	if err := tx.Commit(); err != nil {
-		log.Printf("[SCHEDULER] Error committing transaction: %v", err)
-		return
+		return fmt.Errorf("commit transaction: %w", err)
 	}
 
 	log.Printf("[SCHEDULER] 📊 Cleaned up %d expired PENDING tickets", processedCount)
+	return nil
 }
@@ -0,0 +1,135 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/fpt-event-services/services/dashboard-lambda/models"
+	"github.com/fpt-event-services/services/dashboard-lambda/repository"
+)
+
+// Trọng số chấm điểm gợi ý: tỉ lệ sự kiện đã tham dự khớp từng đặc trưng × trọng số
+const (
+	recommendationCategoryWeight   = 3.0
+	recommendationOrganizerWeight  = 2.0
+	recommendationTimeOfDayWeight  = 1.0
+	recommendationPopularityWeight = 0.5 // Chỉ để xếp các sự kiện cùng mức khớp, không tự tạo gợi ý
+	// recommendationsPerUser - Số gợi ý lưu cho mỗi user
+	recommendationsPerUser = 20
+	// recommendationHistoryDays - Chỉ xét lịch sử check-in trong khoảng này
+	recommendationHistoryDays = 365
+)
+
+// RecommendationScoringScheduler recomputes Event_Recommendation from attendance history
+type RecommendationScoringScheduler struct {
+	repo *repository.DashboardRepository
+}
+
+// NewRecommendationScoringScheduler creates a new scheduler
+func NewRecommendationScoringScheduler() *RecommendationScoringScheduler {
+	return &RecommendationScoringScheduler{
+		repo: repository.DefaultDashboardRepository(),
+	}
+}
+
+// Run scores every open event for every student with check-ins (job "recommendation-scoring")
+func (s *RecommendationScoringScheduler) Run(ctx context.Context) error {
+	history, err := s.repo.LoadAttendanceHistory(ctx, time.Now().AddDate(0, 0, -recommendationHistoryDays))
+	if err != nil {
+		return err
+	}
+	candidates, err := s.repo.LoadRecommendationCandidates(ctx)
+	if err != nil {
+		return err
+	}
+	recs := scoreRecommendations(history, candidates, recommendationsPerUser)
+	if err := s.repo.ReplaceRecommendations(ctx, recs); err != nil {
+		return err
+	}
+	log.Printf("[SCHEDULER] 🎯 Recommendations computed: %d users, %d open events, %d rows",
+		len(history), len(candidates), len(recs))
+	return nil
+}
+
+// timeOfDay - Buổi của giờ bắt đầu: sáng (< 12h), chiều (< 17h), tối
+func timeOfDay(hour int) string {
+	switch {
+	case hour < 12:
+		return "MORNING"
+	case hour < 17:
+		return "AFTERNOON"
+	default:
+		return "EVENING"
+	}
+}
+
+// scoreRecommendations - Điểm của từng ứng viên cho từng user
+// Sự kiện user đã tham dự không được gợi ý lại; ứng viên không khớp đặc trưng nào bị bỏ.
+// Mỗi user giữ tối đa perUser gợi ý, điểm cao trước
+func scoreRecommendations(history map[int][]models.RecommendationEvent, candidates []models.RecommendationEvent, perUser int) []models.EventRecommendation {
+	maxPopularity := 0
+	for _, c := range candidates {
+		maxPopularity = max(maxPopularity, c.Popularity)
+	}
+
+	userIDs := make([]int, 0, len(history))
+	for userID := range history {
+		userIDs = append(userIDs, userID)
+	}
+	sort.Ints(userIDs)
+
+	var recs []models.EventRecommendation
+	for _, userID := range userIDs {
+		attended := history[userID]
+		seen := map[int]bool{}
+		categories, organizers, periods := map[int]int{}, map[int]int{}, map[string]int{}
+		for _, e := range attended {
+			seen[e.EventID] = true
+			if e.CategoryID != nil {
+				categories[*e.CategoryID]++
+			}
+			if e.OrganizerID != nil {
+				organizers[*e.OrganizerID]++
+			}
+			periods[timeOfDay(e.StartHour)]++
+		}
+		total := float64(len(attended))
+
+		var userRecs []models.EventRecommendation
+		for _, c := range candidates {
+			if seen[c.EventID] {
+				continue
+			}
+			score := 0.0
+			reasons := []string{}
+			if c.CategoryID != nil && categories[*c.CategoryID] > 0 {
+				score += recommendationCategoryWeight * float64(categories[*c.CategoryID]) / total
+				reasons = append(reasons, models.ReasonCategory)
+			}
+			if c.OrganizerID != nil && organizers[*c.OrganizerID] > 0 {
+				score += recommendationOrganizerWeight * float64(organizers[*c.OrganizerID]) / total
+				reasons = append(reasons, models.ReasonOrganizer)
+			}
+			if n := periods[timeOfDay(c.StartHour)]; n > 0 {
+				score += recommendationTimeOfDayWeight * float64(n) / total
+				reasons = append(reasons, models.ReasonTimeOfDay)
+			}
+			if len(reasons) == 0 {
+				continue
+			}
+			if maxPopularity > 0 {
+				score += recommendationPopularityWeight * float64(c.Popularity) / float64(maxPopularity)
+			}
+			userRecs = append(userRecs, models.EventRecommendation{
+				UserID: userID, EventID: c.EventID, Score: math.Round(score*10000) / 10000, Reasons: reasons,
+			})
+		}
+
+		sort.SliceStable(userRecs, func(i, j int) bool { return userRecs[i].Score > userRecs[j].Score })
+		recs = append(recs, userRecs[:min(len(userRecs), perUser)]...)
+	}
+	return recs
+}
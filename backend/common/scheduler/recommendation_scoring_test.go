@@ -0,0 +1,44 @@
+package scheduler
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/fpt-event-services/services/dashboard-lambda/models"
+)
+
+func TestScoreRecommendations(t *testing.T) {
+	id := func(n int) *int { return &n }
+	history := map[int][]models.RecommendationEvent{
+		// Student 7: hai hội thảo buổi sáng của CLB 3 (mẫu 1)
+		7: {
+			{EventID: 1, CategoryID: id(1), OrganizerID: id(3), StartHour: 8},
+			{EventID: 2, CategoryID: id(1), OrganizerID: id(3), StartHour: 9},
+		},
+	}
+	candidates := []models.RecommendationEvent{
+		{EventID: 2, CategoryID: id(1), OrganizerID: id(3), StartHour: 8},    // đã tham dự
+		{EventID: 10, CategoryID: id(1), OrganizerID: id(18), StartHour: 19}, // cùng mẫu
+		{EventID: 11, OrganizerID: id(3), StartHour: 9, Popularity: 40},      // cùng CLB, cùng buổi
+		{EventID: 12, OrganizerID: id(18), StartHour: 20, Popularity: 100},   // không khớp gì
+		{EventID: 13, OrganizerID: id(18), StartHour: 10},                    // chỉ cùng buổi
+	}
+
+	recs := scoreRecommendations(history, candidates, 2)
+	if len(recs) != 2 {
+		t.Fatalf("got %d recommendations, want 2 (perUser)", len(recs))
+	}
+	if recs[0].EventID != 11 || recs[0].Score != 3.2 {
+		t.Fatalf("first = %+v, want event 11 with score 3.2", recs[0])
+	}
+	if !slices.Equal(recs[0].Reasons, []string{models.ReasonOrganizer, models.ReasonTimeOfDay}) {
+		t.Fatalf("reasons = %v", recs[0].Reasons)
+	}
+	if recs[1].EventID != 10 || recs[1].Score != 3 {
+		t.Fatalf("second = %+v, want event 10 with score 3", recs[1])
+	}
+
+	if got := scoreRecommendations(nil, candidates, 5); len(got) != 0 {
+		t.Fatalf("no history: got %v", got)
+	}
+}
@@ -0,0 +1,197 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseSchedule(t *testing.T) {
+	base := time.Date(2025, 3, 14, 10, 7, 30, 0, time.UTC) // Thứ sáu
+
+	tests := []struct {
+		spec string
+		want time.Time
+	}{
+		{"@every 5m", base.Add(5 * time.Minute)},
+		{"*/15 * * * *", time.Date(2025, 3, 14, 10, 15, 0, 0, time.UTC)},
+		{"0 * * * *", time.Date(2025, 3, 14, 11, 0, 0, 0, time.UTC)},
+		{"30 2 * * *", time.Date(2025, 3, 15, 2, 30, 0, 0, time.UTC)},
+		{"0 9 * * 1-5", time.Date(2025, 3, 17, 9, 0, 0, 0, time.UTC)},
+		{"0 0 1 * *", time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)},
+		{"@weekly", time.Date(2025, 3, 16, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			schedule, err := ParseSchedule(tt.spec)
+			if err != nil {
+				t.Fatalf("ParseSchedule(%q): %v", tt.spec, err)
+			}
+			if got := schedule.Next(base); !got.Equal(tt.want) {
+				t.Errorf("Next = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	for _, bad := range []string{"", "* * *", "60 * * * *", "*/0 * * * *", "@every 10ms", "a * * * *"} {
+		if _, err := ParseSchedule(bad); err == nil {
+			t.Errorf("expected error for %q", bad)
+		}
+	}
+}
+
+func TestManagerPreventsOverlap(t *testing.T) {
+	m := NewManager(nil, nil)
+
+	release := make(chan struct{})
+	err := m.Register(Job{
+		Name:     "slow",
+		Schedule: "@every 1h",
+		Run: func(ctx context.Context) error {
+			<-release
+			return errors.New("boom")
+		},
+	})
+	if err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	if _, err := m.RunNow("slow", nil); err != nil {
+		t.Fatalf("first RunNow: %v", err)
+	}
+	if _, err := m.RunNow("slow", nil); !errors.Is(err, ErrJobAlreadyRunning) {
+		t.Fatalf("expected ErrJobAlreadyRunning, got %v", err)
+	}
+	if _, err := m.RunNow("missing", nil); !errors.Is(err, ErrJobNotFound) {
+		t.Fatalf("expected ErrJobNotFound, got %v", err)
+	}
+
+	close(release)
+
+	// Chờ execute ghi kết quả và nhả cờ running
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		statuses := m.List(context.Background(), 0)
+		if !statuses[0].Running && statuses[0].LastRun != nil && statuses[0].LastRun.Status == RunStatusFailed {
+			if statuses[0].LastRun.ErrorMessage == nil || *statuses[0].LastRun.ErrorMessage != "boom" {
+				t.Fatalf("expected error message boom, got %+v", statuses[0].LastRun)
+			}
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("job did not finish: %+v", statuses[0])
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// fakeLocker giả lập khóa đang bị instance khác giữ
+type fakeLocker struct {
+	held map[string]bool
+}
+
+func (l *fakeLocker) TryLock(ctx context.Context, name string) (func(), bool, error) {
+	if l.held[name] {
+		return nil, false, nil
+	}
+	l.held[name] = true
+	return func() { delete(l.held, name) }, true, nil
+}
+
+func TestManagerSkipsWhenLockedElsewhere(t *testing.T) {
+	locker := &fakeLocker{held: map[string]bool{"cleanup": true}}
+	m := NewManager(nil, locker)
+
+	ran := make(chan struct{}, 1)
+	if err := m.Register(Job{
+		Name:     "cleanup",
+		Schedule: "@every 1h",
+		Run: func(ctx context.Context) error {
+			ran <- struct{}{}
+			return nil
+		},
+	}); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	if _, err := m.RunNow("cleanup", nil); !errors.Is(err, ErrJobLockedElsewhere) {
+		t.Fatalf("expected ErrJobLockedElsewhere, got %v", err)
+	}
+	if m.List(context.Background(), 0)[0].Running {
+		t.Fatal("job must not stay marked running when lock is not acquired")
+	}
+
+	// Instance kia nhả khóa → chạy được
+	delete(locker.held, "cleanup")
+	if _, err := m.RunNow("cleanup", nil); err != nil {
+		t.Fatalf("RunNow after lock released: %v", err)
+	}
+	<-ran
+}
+
+func TestManagerRunNowAndWait(t *testing.T) {
+	m := NewManager(nil, nil)
+	if err := m.Register(Job{
+		Name:     "reconcile",
+		Schedule: "@every 1h",
+		Run:      func(ctx context.Context) error { return errors.New("mismatch") },
+	}); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	run, err := m.RunNowAndWait("reconcile", nil)
+	if err != nil {
+		t.Fatalf("RunNowAndWait: %v", err)
+	}
+	if run.Status != RunStatusFailed || run.ErrorMessage == nil || *run.ErrorMessage != "mismatch" || run.FinishedAt == nil {
+		t.Fatalf("unexpected run: %+v", run)
+	}
+	if m.List(context.Background(), 0)[0].Running {
+		t.Fatal("job must not stay marked running after RunNowAndWait")
+	}
+}
+
+func TestManagerBackfill(t *testing.T) {
+	m := NewManager(nil, nil)
+	type window struct{ from, to time.Time }
+	got := make(chan window, 1)
+	if err := m.Register(Job{
+		Name:     "stats",
+		Schedule: "@every 1h",
+		Run:      func(ctx context.Context) error { return nil },
+		Backfill: func(ctx context.Context, from, to time.Time) error {
+			got <- window{from, to}
+			return nil
+		},
+	}); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	if err := m.Register(Job{Name: "plain", Schedule: "@every 1h", Run: func(ctx context.Context) error { return nil }}); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	if _, err := m.Backfill("plain", time.Now(), time.Now(), nil); !errors.Is(err, ErrBackfillNotSupported) {
+		t.Fatalf("expected ErrBackfillNotSupported, got %v", err)
+	}
+	if _, err := m.Backfill("missing", time.Now(), time.Now(), nil); !errors.Is(err, ErrJobNotFound) {
+		t.Fatalf("expected ErrJobNotFound, got %v", err)
+	}
+
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC)
+	run, err := m.Backfill("stats", from, to, nil)
+	if err != nil {
+		t.Fatalf("Backfill: %v", err)
+	}
+	if run.TriggerType != TriggerBackfill {
+		t.Fatalf("TriggerType = %q, want %q", run.TriggerType, TriggerBackfill)
+	}
+	if w := <-got; !w.from.Equal(from) || !w.to.Equal(to) {
+		t.Fatalf("backfill window = %v..%v, want %v..%v", w.from, w.to, from, to)
+	}
+	if statuses := m.List(context.Background(), 0); !statuses[0].Backfill || statuses[1].Backfill {
+		t.Fatalf("Backfill flags = %v, %v; want true, false", statuses[0].Backfill, statuses[1].Backfill)
+	}
+}
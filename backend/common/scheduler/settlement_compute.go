@@ -0,0 +1,46 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/fpt-event-services/common/settlement"
+)
+
+// SettlementComputeScheduler tính doanh thu phải trả ban tổ chức theo kỳ (tháng)
+type SettlementComputeScheduler struct{}
+
+// NewSettlementComputeScheduler creates a new scheduler
+func NewSettlementComputeScheduler() *SettlementComputeScheduler {
+	return &SettlementComputeScheduler{}
+}
+
+// Run recomputes the previous and current periods (job "settlement-compute")
+// Kỳ trước vẫn được tính lại vì sự kiện cuối tháng có thể được đóng sau nửa đêm
+func (s *SettlementComputeScheduler) Run(ctx context.Context) error {
+	current := settlement.PeriodStart(time.Now())
+	return s.compute(ctx, current.AddDate(0, -1, 0), current)
+}
+
+// Backfill recomputes every period in [from, to]; bản đã duyệt / đã trả không bị thay đổi
+func (s *SettlementComputeScheduler) Backfill(ctx context.Context, from, to time.Time) error {
+	return s.compute(ctx, settlement.PeriodStart(from), settlement.PeriodStart(to))
+}
+
+func (s *SettlementComputeScheduler) compute(ctx context.Context, from, to time.Time) error {
+	total := 0
+	for month := from; !month.After(to); month = month.AddDate(0, 1, 0) {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		n, err := settlement.Compute(ctx, month)
+		if err != nil {
+			return fmt.Errorf("compute settlement %s: %w", month.Format("2006-01"), err)
+		}
+		total += n
+	}
+	log.Printf("[SCHEDULER] 💰 Computed %d organizer settlement(s) (%s → %s)", total, from.Format("2006-01"), to.Format("2006-01"))
+	return nil
+}
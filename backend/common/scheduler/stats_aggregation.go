@@ -0,0 +1,70 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/fpt-event-services/services/event-lambda/repository"
+)
+
+// StatsAggregationScheduler fills Daily_Event_Stats / Daily_Revenue for days that have ended
+type StatsAggregationScheduler struct {
+	eventRepo *repository.EventRepository
+}
+
+// NewStatsAggregationScheduler creates a new scheduler
+func NewStatsAggregationScheduler() *StatsAggregationScheduler {
+	return &StatsAggregationScheduler{
+		eventRepo: repository.DefaultEventRepository(),
+	}
+}
+
+// Run aggregates every day after the watermark up to yesterday (job "stats-aggregation")
+// Bỏ lỡ vài đêm thì lần chạy sau tự bù; lần chạy đầu tiên bắt đầu từ ngày có vé đầu tiên
+func (s *StatsAggregationScheduler) Run(ctx context.Context) error {
+	from, err := s.eventRepo.RealtimeSince(ctx)
+	if err != nil {
+		return err
+	}
+	if from.IsZero() {
+		first, err := s.eventRepo.FirstActivityDay(ctx)
+		if err != nil {
+			return err
+		}
+		if first == nil {
+			// Chưa có vé: ghi ngày hôm qua (toàn số 0) làm mốc
+			from = repository.StatsDay(time.Now()).AddDate(0, 0, -1)
+		} else {
+			from = *first
+		}
+	}
+	return s.aggregate(ctx, from, repository.StatsDay(time.Now()).AddDate(0, 0, -1))
+}
+
+// Backfill recomputes the days in [from, to]; hôm nay chưa kết thúc nên không được tổng hợp
+func (s *StatsAggregationScheduler) Backfill(ctx context.Context, from, to time.Time) error {
+	yesterday := repository.StatsDay(time.Now()).AddDate(0, 0, -1)
+	if to.After(yesterday) {
+		to = yesterday
+	}
+	return s.aggregate(ctx, repository.StatsDay(from), repository.StatsDay(to))
+}
+
+func (s *StatsAggregationScheduler) aggregate(ctx context.Context, from, to time.Time) error {
+	days := 0
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err := s.eventRepo.AggregateStatsDay(ctx, day); err != nil {
+			return fmt.Errorf("aggregate %s: %w", day.Format("2006-01-02"), err)
+		}
+		days++
+	}
+	if days > 0 {
+		log.Printf("[SCHEDULER] 📊 Aggregated stats for %d days (%s → %s)", days, from.Format("2006-01-02"), to.Format("2006-01-02"))
+	}
+	return nil
+}
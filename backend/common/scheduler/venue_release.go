@@ -3,67 +3,31 @@ package scheduler
 import (
 	"context"
 	"fmt"
-	"log"
-	"time"
 
 	"github.com/fpt-event-services/services/event-lambda/repository"
 )
 
-// VenueReleaseScheduler handles automatic release of venue areas when events end
+// VenueReleaseScheduler reconciles venue areas left UNAVAILABLE with no active event
+// Areas are normally released right away by the EventCancelled / EventClosed / EventDisabled
+// subscriber; this job catches releases missed when a process stopped before the subscriber ran
 type VenueReleaseScheduler struct {
 	eventRepo *repository.EventRepository
-	interval  time.Duration
-	stopChan  chan bool
-	ticker    *time.Ticker
 }
 
 // NewVenueReleaseScheduler creates a new venue release scheduler
-func NewVenueReleaseScheduler(intervalMinutes int) *VenueReleaseScheduler {
+func NewVenueReleaseScheduler() *VenueReleaseScheduler {
 	return &VenueReleaseScheduler{
-		eventRepo: repository.NewEventRepository(),
-		interval:  time.Duration(intervalMinutes) * time.Minute,
-		stopChan:  make(chan bool),
-		ticker:    time.NewTicker(time.Duration(intervalMinutes) * time.Minute),
+		eventRepo: repository.DefaultEventRepository(),
 	}
 }
 
-// Start begins the scheduled venue release job
-func (s *VenueReleaseScheduler) Start() {
-	fmt.Printf("[SCHEDULER] Venue release job started (runs every %v)\n", s.interval)
-
-	// Run immediately once at startup
-	s.releaseVenues()
-
-	// Then run periodically
-	go func() {
-		for {
-			select {
-			case <-s.ticker.C:
-				s.releaseVenues()
-			case <-s.stopChan:
-				s.ticker.Stop()
-				fmt.Println("[SCHEDULER] Venue release job stopped")
-				return
-			}
-		}
-	}()
-
-	log.Printf("[SCHEDULER] ✅ Venue release scheduler initialized (interval: %v)", s.interval)
-}
-
-// Stop stops the scheduler
-func (s *VenueReleaseScheduler) Stop() {
-	s.stopChan <- true
-}
-
-// releaseVenues calls the AutoReleaseVenues function to release ended event venues
-func (s *VenueReleaseScheduler) releaseVenues() {
-	ctx := context.Background()
-
+// Run calls AutoReleaseVenues to release areas missed by the event bus (job "venue-release")
+func (s *VenueReleaseScheduler) Run(ctx context.Context) error {
 	fmt.Println("[VENUE_JANITOR] Venue release routine triggered")
 
 	if err := s.eventRepo.AutoReleaseVenues(ctx); err != nil {
 		fmt.Printf("[VENUE_JANITOR] ❌ Error in venue release routine: %v\n", err)
-		log.Printf("[VENUE_JANITOR] Error: %v", err)
+		return fmt.Errorf("auto release venues: %w", err)
 	}
+	return nil
 }
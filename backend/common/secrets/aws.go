@@ -0,0 +1,151 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	smtypes "github.com/aws/aws-sdk-go-v2/service/secretsmanager/types"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	ssmtypes "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+)
+
+// ============================================================
+// AWS Secrets Manager / SSM Parameter Store qua aws-sdk-go-v2
+// Credential theo default credential chain của SDK: biến môi trường, shared config/profile,
+// IAM role của Lambda / ECS task / EC2 instance
+// ============================================================
+
+// LoadAWSConfig nạp cấu hình AWS mặc định cho region; mỗi request tối đa 5 giây
+func LoadAWSConfig(ctx context.Context, region string) (aws.Config, error) {
+	return awsconfig.LoadDefaultConfig(ctx,
+		awsconfig.WithRegion(region),
+		awsconfig.WithHTTPClient(awshttp.NewBuildableClient().WithTimeout(5*time.Second)),
+	)
+}
+
+type secretsManagerAPI interface {
+	GetSecretValue(ctx context.Context, in *secretsmanager.GetSecretValueInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.GetSecretValueOutput, error)
+}
+
+// SecretsManagerProvider - Một secret JSON trên Secrets Manager chứa nhiều khóa
+// vd: fpt-event/prod = {"DB_PASSWORD":"...","JWT_SECRET":"...","VNPAY_HASH_SECRET":"..."}
+// Cả secret được đọc và parse một lần mỗi lần Refresh; GetSecret lấy từng khóa từ bản đã parse
+type SecretsManagerProvider struct {
+	SecretID string
+	client   secretsManagerAPI
+
+	mu     sync.Mutex
+	values map[string]string // nil = chưa đọc lần nào
+}
+
+// NewSecretsManagerProvider - endpoint rỗng = endpoint mặc định của region
+func NewSecretsManagerProvider(secretID string, cfg aws.Config, endpoint string) *SecretsManagerProvider {
+	client := secretsmanager.NewFromConfig(cfg, func(o *secretsmanager.Options) {
+		if endpoint != "" {
+			o.BaseEndpoint = aws.String(endpoint)
+		}
+	})
+	return &SecretsManagerProvider{SecretID: secretID, client: client}
+}
+
+func (p *SecretsManagerProvider) Name() string { return "secretsmanager" }
+
+// Refresh đọc lại secret bằng một lần GetSecretValue; lỗi thì giữ bản đã parse trước đó
+// Secret không tồn tại = không có khóa nào (Chain chuyển sang provider kế tiếp)
+func (p *SecretsManagerProvider) Refresh(ctx context.Context) error {
+	values := map[string]string{}
+	out, err := p.client.GetSecretValue(ctx, &secretsmanager.GetSecretValueInput{SecretId: aws.String(p.SecretID)})
+	if err != nil {
+		var notFound *smtypes.ResourceNotFoundException
+		if !errors.As(err, &notFound) {
+			return fmt.Errorf("secretsmanager.GetSecretValue: %w", err)
+		}
+	} else {
+		raw := map[string]interface{}{}
+		if err := json.Unmarshal([]byte(aws.ToString(out.SecretString)), &raw); err != nil {
+			return fmt.Errorf("secret %s is not a JSON object: %w", p.SecretID, err)
+		}
+		for name, value := range raw {
+			switch v := value.(type) {
+			case string:
+				if v != "" {
+					values[name] = v
+				}
+			case float64, bool:
+				values[name] = fmt.Sprint(v)
+			}
+		}
+	}
+
+	p.mu.Lock()
+	p.values = values
+	p.mu.Unlock()
+	return nil
+}
+
+// GetSecret trả khóa name trong bản đã parse; chưa đọc lần nào thì Refresh trước
+func (p *SecretsManagerProvider) GetSecret(ctx context.Context, name string) (string, error) {
+	p.mu.Lock()
+	loaded := p.values != nil
+	p.mu.Unlock()
+	if !loaded {
+		if err := p.Refresh(ctx); err != nil {
+			return "", err
+		}
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if v, ok := p.values[name]; ok {
+		return v, nil
+	}
+	return "", ErrNotFound
+}
+
+type ssmAPI interface {
+	GetParameter(ctx context.Context, in *ssm.GetParameterInput, optFns ...func(*ssm.Options)) (*ssm.GetParameterOutput, error)
+}
+
+// SSMProvider - Mỗi secret là một SecureString: Prefix + tên (vd: /fpt-event/prod/DB_PASSWORD)
+type SSMProvider struct {
+	Prefix string
+	client ssmAPI
+}
+
+// NewSSMProvider - endpoint rỗng = endpoint mặc định của region
+func NewSSMProvider(prefix string, cfg aws.Config, endpoint string) *SSMProvider {
+	client := ssm.NewFromConfig(cfg, func(o *ssm.Options) {
+		if endpoint != "" {
+			o.BaseEndpoint = aws.String(endpoint)
+		}
+	})
+	return &SSMProvider{Prefix: prefix, client: client}
+}
+
+func (p *SSMProvider) Name() string { return "ssm" }
+
+func (p *SSMProvider) GetSecret(ctx context.Context, name string) (string, error) {
+	out, err := p.client.GetParameter(ctx, &ssm.GetParameterInput{
+		Name:           aws.String(p.Prefix + name),
+		WithDecryption: aws.Bool(true),
+	})
+	if err != nil {
+		var notFound *ssmtypes.ParameterNotFound
+		if errors.As(err, &notFound) {
+			return "", ErrNotFound
+		}
+		return "", fmt.Errorf("ssm.GetParameter: %w", err)
+	}
+	if out.Parameter == nil || aws.ToString(out.Parameter.Value) == "" {
+		return "", ErrNotFound
+	}
+	return aws.ToString(out.Parameter.Value), nil
+}
@@ -0,0 +1,175 @@
+package secrets
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sync"
+	"time"
+)
+
+// ============================================================
+// Secrets provider - Nguồn lấy giá trị bí mật (mật khẩu DB, JWT secret, VNPay, SMTP)
+// Backend: biến môi trường (.env), AWS Secrets Manager, AWS SSM Parameter Store
+// Cache giữ giá trị trong TTL; hết TTL thì đọc lại để nhận giá trị đã xoay (rotation)
+// ============================================================
+
+// ErrNotFound - Backend không có secret với tên này
+var ErrNotFound = errors.New("secret not found")
+
+// Provider trả về giá trị secret theo tên (tên trùng tên biến môi trường, vd: DB_PASSWORD)
+type Provider interface {
+	Name() string
+	GetSecret(ctx context.Context, name string) (string, error)
+}
+
+// Refresher - Provider đọc cả bộ secret bằng một lần gọi backend (Secrets Manager);
+// Refresh nạp lại bản chụp, GetSecret chỉ đọc từ bản chụp đó
+type Refresher interface {
+	Refresh(ctx context.Context) error
+}
+
+// EnvProvider - Đọc từ biến môi trường (giá trị rỗng coi như không có)
+type EnvProvider struct{}
+
+func (EnvProvider) Name() string { return "env" }
+
+func (EnvProvider) GetSecret(_ context.Context, name string) (string, error) {
+	if v := os.Getenv(name); v != "" {
+		return v, nil
+	}
+	return "", ErrNotFound
+}
+
+// Chain thử lần lượt từng provider; ErrNotFound chuyển sang provider kế tiếp,
+// lỗi khác (mạng, quyền IAM) trả về ngay để không âm thầm dùng giá trị cũ trong .env
+type Chain []Provider
+
+func (c Chain) Name() string {
+	name := ""
+	for i, p := range c {
+		if i > 0 {
+			name += ","
+		}
+		name += p.Name()
+	}
+	return name
+}
+
+func (c Chain) GetSecret(ctx context.Context, name string) (string, error) {
+	for _, p := range c {
+		v, err := p.GetSecret(ctx, name)
+		if err == nil {
+			return v, nil
+		}
+		if !errors.Is(err, ErrNotFound) {
+			return "", err
+		}
+	}
+	return "", ErrNotFound
+}
+
+// Refresh nạp lại các provider trong chuỗi có Refresher
+func (c Chain) Refresh(ctx context.Context) error {
+	for _, p := range c {
+		if r, ok := p.(Refresher); ok {
+			if err := r.Refresh(ctx); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Cache bọc một Provider, giữ giá trị trong TTL
+// Khi backend lỗi lúc làm mới, giá trị cũ (nếu có) vẫn được dùng để không làm sập request
+// Provider có Refresher được nạp lại tối đa một lần mỗi TTL, dù đọc bao nhiêu secret
+type Cache struct {
+	provider Provider
+	ttl      time.Duration
+	now      func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+
+	refreshMu   sync.Mutex
+	refreshedAt time.Time // Lần Refresh thành công gần nhất; zero = cần nạp lại
+}
+
+type cacheEntry struct {
+	value     string
+	found     bool
+	fetchedAt time.Time
+}
+
+// NewCache tạo cache với TTL; ttl <= 0 = không bao giờ hết hạn
+func NewCache(provider Provider, ttl time.Duration) *Cache {
+	return &Cache{provider: provider, ttl: ttl, now: time.Now, entries: map[string]cacheEntry{}}
+}
+
+func (c *Cache) Name() string { return c.provider.Name() }
+
+// GetSecret trả giá trị trong cache hoặc đọc lại từ provider khi hết TTL
+func (c *Cache) GetSecret(ctx context.Context, name string) (string, error) {
+	c.mu.Lock()
+	entry, ok := c.entries[name]
+	c.mu.Unlock()
+	if ok && (c.ttl <= 0 || c.now().Sub(entry.fetchedAt) < c.ttl) {
+		if !entry.found {
+			return "", ErrNotFound
+		}
+		return entry.value, nil
+	}
+
+	v, err := "", c.refresh(ctx)
+	if err == nil {
+		v, err = c.provider.GetSecret(ctx, name)
+	}
+	switch {
+	case err == nil:
+		c.store(name, cacheEntry{value: v, found: true, fetchedAt: c.now()})
+		return v, nil
+	case errors.Is(err, ErrNotFound):
+		c.store(name, cacheEntry{fetchedAt: c.now()})
+		return "", err
+	case ok && entry.found:
+		return entry.value, nil
+	default:
+		return "", err
+	}
+}
+
+// Invalidate bỏ cache của một secret (vd: DB báo sai mật khẩu sau khi xoay)
+func (c *Cache) Invalidate(name string) {
+	c.mu.Lock()
+	delete(c.entries, name)
+	c.mu.Unlock()
+	c.refreshMu.Lock()
+	c.refreshedAt = time.Time{}
+	c.refreshMu.Unlock()
+}
+
+// refresh gọi Refresh của provider nếu lần nạp trước đã quá TTL (hoặc chưa nạp lần nào)
+func (c *Cache) refresh(ctx context.Context) error {
+	r, ok := c.provider.(Refresher)
+	if !ok {
+		return nil
+	}
+	c.refreshMu.Lock()
+	defer c.refreshMu.Unlock()
+	now := c.now()
+	if !c.refreshedAt.IsZero() && (c.ttl <= 0 || now.Sub(c.refreshedAt) < c.ttl) {
+		return nil
+	}
+	if err := r.Refresh(ctx); err != nil {
+		return err
+	}
+	c.refreshedAt = now
+	return nil
+}
+
+func (c *Cache) store(name string, e cacheEntry) {
+	c.mu.Lock()
+	c.entries[name] = e
+	c.mu.Unlock()
+}
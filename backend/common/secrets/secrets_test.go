@@ -0,0 +1,201 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+)
+
+type fakeProvider struct {
+	values map[string]string
+	err    error
+	calls  int
+}
+
+func (f *fakeProvider) Name() string { return "fake" }
+
+func (f *fakeProvider) GetSecret(_ context.Context, name string) (string, error) {
+	f.calls++
+	if f.err != nil {
+		return "", f.err
+	}
+	if v, ok := f.values[name]; ok {
+		return v, nil
+	}
+	return "", ErrNotFound
+}
+
+func TestCacheRefreshesAfterTTL(t *testing.T) {
+	fake := &fakeProvider{values: map[string]string{"JWT_SECRET": "v1"}}
+	cache := NewCache(fake, time.Minute)
+	now := time.Date(2025, 1, 1, 8, 0, 0, 0, time.UTC)
+	cache.now = func() time.Time { return now }
+
+	for i := 0; i < 3; i++ {
+		if v, err := cache.GetSecret(context.Background(), "JWT_SECRET"); err != nil || v != "v1" {
+			t.Fatalf("GetSecret = %q, %v", v, err)
+		}
+	}
+	if fake.calls != 1 {
+		t.Fatalf("provider called %d times within TTL, want 1", fake.calls)
+	}
+
+	// Secret được xoay trên backend: hết TTL mới nhận giá trị mới
+	fake.values["JWT_SECRET"] = "v2"
+	now = now.Add(2 * time.Minute)
+	if v, _ := cache.GetSecret(context.Background(), "JWT_SECRET"); v != "v2" {
+		t.Fatalf("after TTL got %q, want v2", v)
+	}
+
+	// Backend lỗi khi làm mới: giữ giá trị cũ
+	fake.err = errors.New("throttled")
+	now = now.Add(2 * time.Minute)
+	if v, err := cache.GetSecret(context.Background(), "JWT_SECRET"); err != nil || v != "v2" {
+		t.Fatalf("on refresh error got %q, %v", v, err)
+	}
+}
+
+// fakeRefresher - Provider đọc cả bộ secret một lần mỗi Refresh (như Secrets Manager)
+type fakeRefresher struct {
+	fakeProvider
+	backend   map[string]string
+	refreshes int
+}
+
+func (f *fakeRefresher) Refresh(context.Context) error {
+	f.refreshes++
+	f.values = map[string]string{}
+	for k, v := range f.backend {
+		f.values[k] = v
+	}
+	return nil
+}
+
+func TestCacheRefreshesBundleOncePerTTL(t *testing.T) {
+	fake := &fakeRefresher{backend: map[string]string{"DB_PASSWORD": "db1", "JWT_SECRET": "jwt1"}}
+	cache := NewCache(Chain{fake, EnvProvider{}}, time.Minute)
+	now := time.Date(2025, 1, 1, 8, 0, 0, 0, time.UTC)
+	cache.now = func() time.Time { return now }
+
+	names := []string{"DB_PASSWORD", "JWT_SECRET", "MISSING_SECRET"}
+	for _, name := range names {
+		cache.GetSecret(context.Background(), name)
+	}
+	if fake.refreshes != 1 {
+		t.Fatalf("%d refreshes for %d secrets, want 1", fake.refreshes, len(names))
+	}
+
+	fake.backend["JWT_SECRET"] = "jwt2"
+	now = now.Add(2 * time.Minute)
+	for _, name := range names {
+		cache.GetSecret(context.Background(), name)
+	}
+	if v, _ := cache.GetSecret(context.Background(), "JWT_SECRET"); v != "jwt2" || fake.refreshes != 2 {
+		t.Fatalf("after TTL got %q with %d refreshes, want jwt2 with 2", v, fake.refreshes)
+	}
+
+	cache.Invalidate("DB_PASSWORD")
+	cache.GetSecret(context.Background(), "DB_PASSWORD")
+	if fake.refreshes != 3 {
+		t.Fatalf("Invalidate did not reload the bundle (%d refreshes)", fake.refreshes)
+	}
+}
+
+func TestChainFallback(t *testing.T) {
+	t.Setenv("SMTP_PASSWORD", "from-env")
+	t.Setenv("DB_PASSWORD", "from-env")
+	chain := Chain{&fakeProvider{values: map[string]string{"DB_PASSWORD": "from-aws"}}, EnvProvider{}}
+
+	if v, _ := chain.GetSecret(context.Background(), "DB_PASSWORD"); v != "from-aws" {
+		t.Fatalf("DB_PASSWORD = %q, want from-aws", v)
+	}
+	if v, _ := chain.GetSecret(context.Background(), "SMTP_PASSWORD"); v != "from-env" {
+		t.Fatalf("SMTP_PASSWORD = %q, want env fallback", v)
+	}
+	if _, err := chain.GetSecret(context.Background(), "MISSING_SECRET"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("err = %v, want ErrNotFound", err)
+	}
+
+	failing := Chain{&fakeProvider{err: errors.New("access denied")}, EnvProvider{}}
+	if _, err := failing.GetSecret(context.Background(), "DB_PASSWORD"); err == nil || errors.Is(err, ErrNotFound) {
+		t.Fatalf("backend error must not fall back to env, got %v", err)
+	}
+}
+
+// testConfig - Cấu hình SDK với credential tĩnh, không đọc profile/IMDS của máy chạy test
+var testConfig = aws.Config{
+	Region:      "ap-southeast-1",
+	Credentials: credentials.NewStaticCredentialsProvider("AKIDEXAMPLE", "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY", ""),
+}
+
+func TestSecretsManagerProvider(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("X-Amz-Target") != "secretsmanager.GetSecretValue" ||
+			!strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/") {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		var in struct{ SecretId string }
+		json.NewDecoder(r.Body).Decode(&in)
+		if in.SecretId != "fpt-event/prod" {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"__type": "ResourceNotFoundException", "message": "no secret"})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{"SecretString": `{"DB_PASSWORD":"s3cret","SMTP_PASSWORD":""}`})
+	}))
+	defer srv.Close()
+
+	p := NewSecretsManagerProvider("fpt-event/prod", testConfig, srv.URL)
+	if v, err := p.GetSecret(context.Background(), "DB_PASSWORD"); err != nil || v != "s3cret" {
+		t.Fatalf("DB_PASSWORD = %q, %v", v, err)
+	}
+	if _, err := p.GetSecret(context.Background(), "SMTP_PASSWORD"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("empty key: err = %v, want ErrNotFound", err)
+	}
+	if requests != 1 {
+		t.Fatalf("%d GetSecretValue calls for two keys, want 1", requests)
+	}
+	if err := p.Refresh(context.Background()); err != nil || requests != 2 {
+		t.Fatalf("Refresh = %v after %d calls, want one more call", err, requests)
+	}
+	missing := NewSecretsManagerProvider("other", testConfig, srv.URL)
+	if _, err := missing.GetSecret(context.Background(), "DB_PASSWORD"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("missing secret: err = %v, want ErrNotFound", err)
+	}
+}
+
+func TestSSMProvider(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var in struct {
+			Name           string
+			WithDecryption bool
+		}
+		json.NewDecoder(r.Body).Decode(&in)
+		if r.Header.Get("X-Amz-Target") != "AmazonSSM.GetParameter" || !in.WithDecryption || in.Name != "/fpt-event/prod/JWT_SECRET" {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"__type": "ParameterNotFound"})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"Parameter": map[string]string{"Value": "jwt-from-ssm"}})
+	}))
+	defer srv.Close()
+
+	p := NewSSMProvider("/fpt-event/prod/", testConfig, srv.URL)
+	if v, err := p.GetSecret(context.Background(), "JWT_SECRET"); err != nil || v != "jwt-from-ssm" {
+		t.Fatalf("JWT_SECRET = %q, %v", v, err)
+	}
+	if _, err := p.GetSecret(context.Background(), "VNPAY_HASH_SECRET"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("missing parameter: err = %v, want ErrNotFound", err)
+	}
+}
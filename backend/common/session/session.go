@@ -0,0 +1,119 @@
+package session
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/fpt-event-services/common/db"
+)
+
+// ============================================================
+// SESSION - Phiên đăng nhập gắn với JWT (migration 053)
+// Mỗi token cấp khi đăng nhập / đăng ký có claim jti = session_id trong User_Session.
+// authMiddleware gọi Active cho mọi request có jti: phiên đã thu hồi, hết hạn hoặc
+// bị xóa (ẩn danh hóa tài khoản) thì token không còn dùng được.
+// Kết quả được cache cacheTTL mỗi instance; thu hồi ở instance khác có hiệu lực chậm
+// nhất sau cacheTTL. last_used_at cập nhật mỗi lần cache hết hạn.
+// ============================================================
+
+// cacheTTL - Thời gian tin kết quả kiểm tra phiên trước khi đọc lại DB
+const cacheTTL = 30 * time.Second
+
+// maxCacheEntries - Vượt ngưỡng thì dọn các mục đã hết hạn
+const maxCacheEntries = 10000
+
+var errNoDB = errors.New("database not initialized")
+
+type entry struct {
+	userID    int
+	active    bool
+	checkedAt time.Time
+}
+
+var cache = struct {
+	mu      sync.Mutex
+	entries map[string]entry
+}{entries: map[string]entry{}}
+
+// lookup đọc phiên từ DB và cập nhật last_used_at (thay được trong test)
+var lookup = lookupSession
+
+// NewID - ID phiên ngẫu nhiên (32 ký tự hex)
+func NewID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate session id: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// Active - Phiên còn hiệu lực và thuộc userID
+// Lỗi DB không chặn request (fail open) - chỉ ghi log
+func Active(ctx context.Context, userID int, sessionID string) bool {
+	now := time.Now()
+	cache.mu.Lock()
+	e, ok := cache.entries[sessionID]
+	cache.mu.Unlock()
+	if ok && now.Sub(e.checkedAt) < cacheTTL {
+		return e.active && e.userID == userID
+	}
+
+	owner, active, err := lookup(ctx, sessionID)
+	if err != nil {
+		if !errors.Is(err, errNoDB) {
+			log.Printf("⚠️  [SESSION] Failed to check session: %v", err)
+		}
+		return true
+	}
+
+	cache.mu.Lock()
+	if len(cache.entries) >= maxCacheEntries {
+		for id, old := range cache.entries {
+			if now.Sub(old.checkedAt) >= cacheTTL {
+				delete(cache.entries, id)
+			}
+		}
+	}
+	cache.entries[sessionID] = entry{userID: owner, active: active, checkedAt: now}
+	cache.mu.Unlock()
+	return active && owner == userID
+}
+
+// Forget - Bỏ cache của phiên (gọi sau khi thu hồi để instance hiện tại áp dụng ngay)
+func Forget(sessionID string) {
+	cache.mu.Lock()
+	delete(cache.entries, sessionID)
+	cache.mu.Unlock()
+}
+
+func lookupSession(ctx context.Context, sessionID string) (int, bool, error) {
+	conn := db.GetDB()
+	if conn == nil {
+		return 0, false, errNoDB
+	}
+	var userID int
+	var active bool
+	err := conn.QueryRowContext(ctx, `
+		SELECT user_id, revoked_at IS NULL AND expires_at > NOW()
+		FROM User_Session WHERE session_id = ?
+	`, sessionID).Scan(&userID, &active)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to query session: %w", err)
+	}
+	if active {
+		if _, err := conn.ExecContext(ctx, `UPDATE User_Session SET last_used_at = NOW() WHERE session_id = ?`, sessionID); err != nil {
+			log.Printf("⚠️  [SESSION] Failed to update last_used_at: %v", err)
+		}
+	}
+	return userID, active, nil
+}
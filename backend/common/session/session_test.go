@@ -0,0 +1,70 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func stubLookup(t *testing.T, sessions map[string]int, revoked map[string]bool) *int {
+	calls := 0
+	orig := lookup
+	lookup = func(ctx context.Context, sessionID string) (int, bool, error) {
+		calls++
+		if sessionID == "broken" {
+			return 0, false, errors.New("db down")
+		}
+		userID, ok := sessions[sessionID]
+		return userID, ok && !revoked[sessionID], nil
+	}
+	t.Cleanup(func() {
+		lookup = orig
+		cache.entries = map[string]entry{}
+	})
+	return &calls
+}
+
+func TestActiveChecksOwnerAndRevocation(t *testing.T) {
+	revoked := map[string]bool{}
+	calls := stubLookup(t, map[string]int{"a": 1, "b": 2}, revoked)
+	ctx := context.Background()
+
+	if !Active(ctx, 1, "a") {
+		t.Fatal("own active session must be accepted")
+	}
+	if Active(ctx, 2, "a") {
+		t.Fatal("session of another user must be rejected")
+	}
+	if Active(ctx, 1, "missing") {
+		t.Fatal("unknown session must be rejected")
+	}
+	if *calls != 2 {
+		t.Fatalf("second check of %q must hit the cache, lookups = %d", "a", *calls)
+	}
+
+	revoked["a"] = true
+	if !Active(ctx, 1, "a") {
+		t.Fatal("cached result is kept until the TTL expires")
+	}
+	Forget("a")
+	if Active(ctx, 1, "a") {
+		t.Fatal("revoked session must be rejected after Forget")
+	}
+}
+
+func TestActiveFailsOpen(t *testing.T) {
+	stubLookup(t, nil, nil)
+	if !Active(context.Background(), 1, "broken") {
+		t.Fatal("lookup errors must not reject the request")
+	}
+}
+
+func TestNewID(t *testing.T) {
+	a, err := NewID()
+	if err != nil || len(a) != 32 {
+		t.Fatalf("got %q, %v", a, err)
+	}
+	if b, _ := NewID(); a == b {
+		t.Fatal("ids must be random")
+	}
+}
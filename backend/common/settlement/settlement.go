@@ -0,0 +1,529 @@
+package settlement
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fpt-event-services/common/db"
+	"github.com/fpt-event-services/common/ledger"
+)
+
+// ============================================================
+// SETTLEMENT - Đối soát doanh thu vé phải trả cho ban tổ chức (migration 033)
+// Kỳ đối soát theo tháng; mỗi sự kiện CLOSED có end_time trong kỳ được tính cho
+// người tạo sự kiện (Event.created_by):
+//   - gross:  tổng Bill PAID / REFUNDED có vé của sự kiện
+//   - refund: tổng Report APPROVED của vé thuộc sự kiện
+//   - fee:    phí nền tảng lưu trên Bill_Item lúc mua (vé đã hoàn tiền không tính phí)
+//   - net = gross - refund - fee
+// Job settlement-compute tính lại các bản PENDING; APPROVED / PAID đã chốt, không đổi nữa
+// ============================================================
+
+// Trạng thái của Organizer_Settlement
+const (
+	StatusPending  = "PENDING"
+	StatusApproved = "APPROVED"
+	StatusPaid     = "PAID"
+)
+
+var (
+	// ErrNotFound - Bản đối soát không tồn tại (hoặc không thuộc organizer)
+	ErrNotFound = errors.New("settlement not found")
+	// ErrInvalidStatus - Bản đối soát không ở trạng thái cho phép thao tác
+	ErrInvalidStatus = errors.New("settlement status does not allow this action")
+	// ErrPeriodOpen - Kỳ đối soát chưa kết thúc, chưa duyệt được
+	ErrPeriodOpen = errors.New("settlement period has not ended")
+	// ErrMissingReference - Đánh dấu đã trả cần mã giao dịch chuyển khoản
+	ErrMissingReference = errors.New("payment reference is required")
+)
+
+var errNoDB = errors.New("database not initialized")
+
+// location - Múi giờ chia kỳ đối soát, khớp loc của DSN (common/db)
+var location = func() *time.Location {
+	if loc, err := time.LoadLocation("Asia/Ho_Chi_Minh"); err == nil {
+		return loc
+	}
+	return time.FixedZone("UTC+7", 7*60*60)
+}()
+
+// Settlement - Số tiền phải trả một organizer trong một kỳ
+type Settlement struct {
+	SettlementID     int        `json:"settlementId"`
+	PeriodID         int        `json:"periodId"`
+	PeriodStart      string     `json:"periodStart"` // YYYY-MM-DD
+	PeriodEnd        string     `json:"periodEnd"`   // YYYY-MM-DD, không tính ngày này
+	OrganizerID      int        `json:"organizerId"`
+	OrganizerName    string     `json:"organizerName"`
+	EventCount       int        `json:"eventCount"`
+	TicketsSold      int        `json:"ticketsSold"`
+	GrossAmount      float64    `json:"grossAmount"`
+	RefundAmount     float64    `json:"refundAmount"`
+	FeeAmount        float64    `json:"feeAmount"`
+	NetAmount        float64    `json:"netAmount"`
+	Status           string     `json:"status"`
+	ComputedAt       time.Time  `json:"computedAt"`
+	ApprovedBy       *int       `json:"approvedBy,omitempty"`
+	ApprovedAt       *time.Time `json:"approvedAt,omitempty"`
+	PaidBy           *int       `json:"paidBy,omitempty"`
+	PaidAt           *time.Time `json:"paidAt,omitempty"`
+	PaymentReference *string    `json:"paymentReference,omitempty"`
+	Lines            []Line     `json:"lines,omitempty"`
+}
+
+// Line - Doanh thu của một sự kiện trong bản đối soát
+type Line struct {
+	EventID      int       `json:"eventId"`
+	EventTitle   string    `json:"eventTitle"`
+	EventEndTime time.Time `json:"eventEndTime"`
+	TicketsSold  int       `json:"ticketsSold"`
+	GrossAmount  float64   `json:"grossAmount"`
+	RefundAmount float64   `json:"refundAmount"`
+	FeeAmount    float64   `json:"feeAmount"`
+	NetAmount    float64   `json:"netAmount"`
+}
+
+// Filter - Điều kiện lọc danh sách (giá trị 0 / "" = không lọc)
+type Filter struct {
+	OrganizerID int
+	PeriodID    int
+	Status      string
+}
+
+// PeriodStart - Ngày đầu tháng (giờ Việt Nam) của kỳ chứa t
+func PeriodStart(t time.Time) time.Time {
+	y, m, _ := t.In(location).Date()
+	return time.Date(y, m, 1, 0, 0, 0, 0, location)
+}
+
+// ValidStatus - status là một trạng thái đối soát hợp lệ
+func ValidStatus(status string) bool {
+	return status == StatusPending || status == StatusApproved || status == StatusPaid
+}
+
+// eventTotals - Doanh thu của một sự kiện (đơn vị: đồng)
+type eventTotals struct {
+	eventID     int
+	organizerID int
+	title       string
+	endTime     time.Time
+	ticketsSold int
+	gross       int64
+	refunds     int64
+	fees        int64
+}
+
+// draft - Bản đối soát vừa tính, chưa ghi DB
+type draft struct {
+	organizerID int
+	lines       []Line
+	ticketsSold int
+	gross       int64
+	refunds     int64
+	fees        int64
+}
+
+func (d draft) net() int64 {
+	return d.gross - d.refunds - d.fees
+}
+
+// build - Gom doanh thu sự kiện thành bản đối soát theo organizer (bỏ sự kiện miễn phí)
+func build(totals []eventTotals) []draft {
+	byOrganizer := map[int]*draft{}
+	for _, t := range totals {
+		if t.gross == 0 && t.refunds == 0 {
+			continue
+		}
+		d := byOrganizer[t.organizerID]
+		if d == nil {
+			d = &draft{organizerID: t.organizerID}
+			byOrganizer[t.organizerID] = d
+		}
+		d.lines = append(d.lines, Line{
+			EventID:      t.eventID,
+			EventTitle:   t.title,
+			EventEndTime: t.endTime,
+			TicketsSold:  t.ticketsSold,
+			GrossAmount:  float64(t.gross),
+			RefundAmount: float64(t.refunds),
+			FeeAmount:    float64(t.fees),
+			NetAmount:    float64(t.gross - t.refunds - t.fees),
+		})
+		d.ticketsSold += t.ticketsSold
+		d.gross += t.gross
+		d.refunds += t.refunds
+		d.fees += t.fees
+	}
+
+	drafts := make([]draft, 0, len(byOrganizer))
+	for _, d := range byOrganizer {
+		drafts = append(drafts, *d)
+	}
+	sort.Slice(drafts, func(i, j int) bool { return drafts[i].organizerID < drafts[j].organizerID })
+	return drafts
+}
+
+// Compute - Tính lại kỳ đối soát chứa month (chạy lại nhiều lần cho cùng kết quả)
+// Bản PENDING được ghi đè, bản APPROVED / PAID giữ nguyên; trả về số bản đã ghi
+func Compute(ctx context.Context, month time.Time) (int, error) {
+	conn := db.GetDB()
+	if conn == nil {
+		return 0, errNoDB
+	}
+	start := PeriodStart(month)
+	end := start.AddDate(0, 1, 0)
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx, `
+		INSERT INTO Settlement_Period (period_start, period_end, computed_at)
+		VALUES (?, ?, NOW())
+		ON DUPLICATE KEY UPDATE period_id = LAST_INSERT_ID(period_id), computed_at = NOW()
+	`, start, end)
+	if err != nil {
+		return 0, fmt.Errorf("failed to upsert settlement period: %w", err)
+	}
+	periodID, err := res.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get settlement period ID: %w", err)
+	}
+
+	frozen, err := frozenOrganizers(ctx, tx, periodID)
+	if err != nil {
+		return 0, err
+	}
+	totals, err := loadEventTotals(ctx, tx, start, end)
+	if err != nil {
+		return 0, err
+	}
+
+	written := 0
+	keep := []any{periodID}
+	for _, d := range build(totals) {
+		if frozen[d.organizerID] {
+			continue
+		}
+		if err := saveDraft(ctx, tx, periodID, d); err != nil {
+			return 0, err
+		}
+		keep = append(keep, d.organizerID)
+		written++
+	}
+
+	// Organizer không còn doanh thu trong kỳ (sự kiện bị mở lại...) → bỏ bản PENDING cũ
+	stale := `DELETE FROM Organizer_Settlement WHERE period_id = ? AND status = 'PENDING'`
+	if len(keep) > 1 {
+		stale += ` AND organizer_id NOT IN (?` + strings.Repeat(", ?", len(keep)-2) + `)`
+	}
+	if _, err := tx.ExecContext(ctx, stale, keep...); err != nil {
+		return 0, fmt.Errorf("failed to delete stale settlements: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit settlement: %w", err)
+	}
+	return written, nil
+}
+
+func frozenOrganizers(ctx context.Context, tx *sql.Tx, periodID int64) (map[int]bool, error) {
+	rows, err := tx.QueryContext(ctx, `
+		SELECT organizer_id FROM Organizer_Settlement
+		WHERE period_id = ? AND status <> 'PENDING'
+		FOR UPDATE
+	`, periodID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query frozen settlements: %w", err)
+	}
+	defer rows.Close()
+
+	frozen := map[int]bool{}
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan frozen settlement: %w", err)
+		}
+		frozen[id] = true
+	}
+	return frozen, rows.Err()
+}
+
+func loadEventTotals(ctx context.Context, tx *sql.Tx, start, end time.Time) ([]eventTotals, error) {
+	rows, err := tx.QueryContext(ctx, `
+		SELECT e.event_id, e.created_by, e.title, e.end_time,
+		       (SELECT COUNT(*) FROM Ticket t
+		        WHERE t.event_id = e.event_id AND t.is_complimentary = 0
+		          AND t.status IN ('BOOKED', 'CHECKED_IN', 'CHECKED_OUT', 'REFUNDED')),
+		       (SELECT COALESCE(SUM(b.total_amount), 0) FROM Bill b
+		        WHERE b.payment_status IN ('PAID', 'REFUNDED')
+		          AND b.bill_id IN (SELECT t.bill_id FROM Ticket t WHERE t.event_id = e.event_id)),
+		       (SELECT COALESCE(SUM(rp.refund_amount), 0) FROM Report rp
+		        JOIN Ticket t ON t.ticket_id = rp.ticket_id
+		        WHERE t.event_id = e.event_id AND rp.status = 'APPROVED'),
+		       (SELECT COALESCE(SUM(bi.fee_amount), 0) FROM Bill_Item bi
+		        JOIN Ticket t ON t.ticket_id = bi.ticket_id
+		        WHERE bi.event_id = e.event_id AND t.status <> 'REFUNDED')
+		FROM Event e
+		WHERE e.status = 'CLOSED' AND e.created_by IS NOT NULL
+		  AND e.end_time >= ? AND e.end_time < ?
+		ORDER BY e.end_time, e.event_id
+	`, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query event revenue: %w", err)
+	}
+	defer rows.Close()
+
+	var totals []eventTotals
+	for rows.Next() {
+		var t eventTotals
+		var gross, refunds, fees float64
+		if err := rows.Scan(&t.eventID, &t.organizerID, &t.title, &t.endTime, &t.ticketsSold, &gross, &refunds, &fees); err != nil {
+			return nil, fmt.Errorf("failed to scan event revenue: %w", err)
+		}
+		t.gross = ledger.Dong(gross)
+		t.refunds = ledger.Dong(refunds)
+		t.fees = ledger.Dong(fees)
+		totals = append(totals, t)
+	}
+	return totals, rows.Err()
+}
+
+func saveDraft(ctx context.Context, tx *sql.Tx, periodID int64, d draft) error {
+	res, err := tx.ExecContext(ctx, `
+		INSERT INTO Organizer_Settlement (period_id, organizer_id, event_count, tickets_sold,
+		                                  gross_amount, refund_amount, fee_amount, net_amount, computed_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, NOW())
+		ON DUPLICATE KEY UPDATE
+		  settlement_id = LAST_INSERT_ID(settlement_id),
+		  event_count = VALUES(event_count), tickets_sold = VALUES(tickets_sold),
+		  gross_amount = VALUES(gross_amount), refund_amount = VALUES(refund_amount),
+		  fee_amount = VALUES(fee_amount), net_amount = VALUES(net_amount), computed_at = NOW()
+	`, periodID, d.organizerID, len(d.lines), d.ticketsSold, d.gross, d.refunds, d.fees, d.net())
+	if err != nil {
+		return fmt.Errorf("failed to save settlement for organizer %d: %w", d.organizerID, err)
+	}
+	settlementID, err := res.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get settlement ID: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM Organizer_Settlement_Line WHERE settlement_id = ?`, settlementID); err != nil {
+		return fmt.Errorf("failed to clear settlement lines: %w", err)
+	}
+	for _, l := range d.lines {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO Organizer_Settlement_Line (settlement_id, event_id, event_title, event_end_time,
+			                                       tickets_sold, gross_amount, refund_amount, fee_amount, net_amount)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		`, settlementID, l.EventID, l.EventTitle, l.EventEndTime, l.TicketsSold,
+			l.GrossAmount, l.RefundAmount, l.FeeAmount, l.NetAmount); err != nil {
+			return fmt.Errorf("failed to save settlement line for event %d: %w", l.EventID, err)
+		}
+	}
+	return nil
+}
+
+const selectSettlement = `
+	SELECT s.settlement_id, s.period_id, p.period_start, p.period_end, s.organizer_id, COALESCE(u.full_name, ''),
+	       s.event_count, s.tickets_sold, s.gross_amount, s.refund_amount, s.fee_amount, s.net_amount,
+	       s.status, s.computed_at, s.approved_by, s.approved_at, s.paid_by, s.paid_at, s.payment_reference
+	FROM Organizer_Settlement s
+	JOIN Settlement_Period p ON p.period_id = s.period_id
+	LEFT JOIN Users u ON u.user_id = s.organizer_id`
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanSettlement(row scanner) (*Settlement, error) {
+	var s Settlement
+	var start, end time.Time
+	var approvedBy, paidBy sql.NullInt64
+	var approvedAt, paidAt sql.NullTime
+	var reference sql.NullString
+	if err := row.Scan(&s.SettlementID, &s.PeriodID, &start, &end, &s.OrganizerID, &s.OrganizerName,
+		&s.EventCount, &s.TicketsSold, &s.GrossAmount, &s.RefundAmount, &s.FeeAmount, &s.NetAmount,
+		&s.Status, &s.ComputedAt, &approvedBy, &approvedAt, &paidBy, &paidAt, &reference); err != nil {
+		return nil, err
+	}
+	s.PeriodStart = start.Format("2006-01-02")
+	s.PeriodEnd = end.Format("2006-01-02")
+	if approvedBy.Valid {
+		id := int(approvedBy.Int64)
+		s.ApprovedBy = &id
+	}
+	if approvedAt.Valid {
+		s.ApprovedAt = &approvedAt.Time
+	}
+	if paidBy.Valid {
+		id := int(paidBy.Int64)
+		s.PaidBy = &id
+	}
+	if paidAt.Valid {
+		s.PaidAt = &paidAt.Time
+	}
+	if reference.Valid {
+		s.PaymentReference = &reference.String
+	}
+	return &s, nil
+}
+
+// List - Danh sách bản đối soát, kỳ mới nhất trước
+func List(ctx context.Context, f Filter) ([]Settlement, error) {
+	conn := db.GetDB()
+	if conn == nil {
+		return nil, errNoDB
+	}
+	query := selectSettlement + ` WHERE 1 = 1`
+	args := []any{}
+	if f.OrganizerID > 0 {
+		query += ` AND s.organizer_id = ?`
+		args = append(args, f.OrganizerID)
+	}
+	if f.PeriodID > 0 {
+		query += ` AND s.period_id = ?`
+		args = append(args, f.PeriodID)
+	}
+	if f.Status != "" {
+		query += ` AND s.status = ?`
+		args = append(args, f.Status)
+	}
+	query += ` ORDER BY p.period_start DESC, s.organizer_id`
+
+	rows, err := conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query settlements: %w", err)
+	}
+	defer rows.Close()
+
+	list := []Settlement{}
+	for rows.Next() {
+		s, err := scanSettlement(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan settlement: %w", err)
+		}
+		list = append(list, *s)
+	}
+	return list, rows.Err()
+}
+
+// Get - Bản đối soát kèm chi tiết theo sự kiện
+func Get(ctx context.Context, settlementID int) (*Settlement, error) {
+	conn := db.GetDB()
+	if conn == nil {
+		return nil, errNoDB
+	}
+	s, err := scanSettlement(conn.QueryRowContext(ctx, selectSettlement+` WHERE s.settlement_id = ?`, settlementID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get settlement: %w", err)
+	}
+
+	rows, err := conn.QueryContext(ctx, `
+		SELECT event_id, event_title, event_end_time, tickets_sold,
+		       gross_amount, refund_amount, fee_amount, net_amount
+		FROM Organizer_Settlement_Line
+		WHERE settlement_id = ?
+		ORDER BY event_end_time, event_id
+	`, settlementID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query settlement lines: %w", err)
+	}
+	defer rows.Close()
+
+	s.Lines = []Line{}
+	for rows.Next() {
+		var l Line
+		if err := rows.Scan(&l.EventID, &l.EventTitle, &l.EventEndTime, &l.TicketsSold,
+			&l.GrossAmount, &l.RefundAmount, &l.FeeAmount, &l.NetAmount); err != nil {
+			return nil, fmt.Errorf("failed to scan settlement line: %w", err)
+		}
+		s.Lines = append(s.Lines, l)
+	}
+	return s, rows.Err()
+}
+
+// Approve - Duyệt bản đối soát PENDING của kỳ đã kết thúc (số liệu được chốt)
+func Approve(ctx context.Context, settlementID, adminID int) (*Settlement, error) {
+	err := transition(ctx, settlementID, StatusPending, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, `
+			UPDATE Organizer_Settlement
+			SET status = 'APPROVED', approved_by = ?, approved_at = NOW()
+			WHERE settlement_id = ?
+		`, adminID, settlementID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return Get(ctx, settlementID)
+}
+
+// MarkPaid - Ghi nhận đã chuyển tiền cho organizer (bản APPROVED)
+func MarkPaid(ctx context.Context, settlementID, adminID int, reference string) (*Settlement, error) {
+	reference = strings.TrimSpace(reference)
+	if reference == "" || len(reference) > 100 {
+		return nil, ErrMissingReference
+	}
+	err := transition(ctx, settlementID, StatusApproved, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, `
+			UPDATE Organizer_Settlement
+			SET status = 'PAID', paid_by = ?, paid_at = NOW(), payment_reference = ?
+			WHERE settlement_id = ?
+		`, adminID, reference, settlementID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return Get(ctx, settlementID)
+}
+
+// transition - Khóa bản đối soát, kiểm tra trạng thái hiện tại rồi chạy update
+func transition(ctx context.Context, settlementID int, from string, update func(*sql.Tx) error) error {
+	conn := db.GetDB()
+	if conn == nil {
+		return errNoDB
+	}
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var status string
+	var periodEnded bool
+	err = tx.QueryRowContext(ctx, `
+		SELECT s.status, p.period_end <= CURDATE()
+		FROM Organizer_Settlement s
+		JOIN Settlement_Period p ON p.period_id = s.period_id
+		WHERE s.settlement_id = ?
+		FOR UPDATE
+	`, settlementID).Scan(&status, &periodEnded)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to lock settlement: %w", err)
+	}
+	if status != from {
+		return fmt.Errorf("%w: settlement is %s, expected %s", ErrInvalidStatus, status, from)
+	}
+	if !periodEnded {
+		return ErrPeriodOpen
+	}
+	if err := update(tx); err != nil {
+		return fmt.Errorf("failed to update settlement: %w", err)
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,65 @@
+package settlement
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPeriodStart(t *testing.T) {
+	// 23:30 UTC ngày 31/01 là 06:30 ngày 01/02 giờ Việt Nam
+	got := PeriodStart(time.Date(2026, 1, 31, 23, 30, 0, 0, time.UTC))
+	if got.Format("2006-01-02") != "2026-02-01" || got.Hour() != 0 {
+		t.Errorf("PeriodStart = %v, want 2026-02-01 00:00", got)
+	}
+}
+
+func TestBuildGroupsByOrganizer(t *testing.T) {
+	end := time.Date(2026, 2, 10, 16, 0, 0, 0, location)
+	drafts := build([]eventTotals{
+		{eventID: 1, organizerID: 18, title: "A", endTime: end, ticketsSold: 3, gross: 300000, refunds: 100000, fees: 20000},
+		{eventID: 2, organizerID: 4, title: "Free", endTime: end},
+		{eventID: 3, organizerID: 18, title: "B", endTime: end, ticketsSold: 1, gross: 50000, fees: 5000},
+	})
+
+	if len(drafts) != 1 {
+		t.Fatalf("got %d drafts, want 1 (free event skipped)", len(drafts))
+	}
+	d := drafts[0]
+	if d.organizerID != 18 || len(d.lines) != 2 || d.ticketsSold != 4 {
+		t.Fatalf("draft = %+v", d)
+	}
+	if d.gross != 350000 || d.refunds != 100000 || d.fees != 25000 || d.net() != 225000 {
+		t.Errorf("totals gross=%d refunds=%d fees=%d net=%d", d.gross, d.refunds, d.fees, d.net())
+	}
+	var lineNet float64
+	for _, l := range d.lines {
+		lineNet += l.NetAmount
+	}
+	if int64(lineNet) != d.net() {
+		t.Errorf("line net %v != settlement net %d", lineNet, d.net())
+	}
+}
+
+func TestStatementCSV(t *testing.T) {
+	s := &Settlement{
+		SettlementID: 7, PeriodStart: "2026-02-01", PeriodEnd: "2026-03-01",
+		OrganizerID: 18, OrganizerName: "=CLB", Status: StatusApproved,
+		TicketsSold: 2, GrossAmount: 200000, NetAmount: 200000,
+		Lines: []Line{{EventID: 1, EventTitle: "Talk", TicketsSold: 2, GrossAmount: 200000, NetAmount: 200000}},
+	}
+	data, err := StatementCSV(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := string(data)
+	if !strings.Contains(out, "Organizer,'=CLB") {
+		t.Errorf("organizer name not escaped:\n%s", out)
+	}
+	if !strings.Contains(out, "Total,,,2,200000,0,0,200000") {
+		t.Errorf("missing total row:\n%s", out)
+	}
+	if got := StatementFileName(s); got != "settlement_2026-02_organizer_18.csv" {
+		t.Errorf("StatementFileName = %q", got)
+	}
+}
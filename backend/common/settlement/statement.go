@@ -0,0 +1,58 @@
+package settlement
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/fpt-event-services/common/csvutil"
+)
+
+// ============================================================
+// Bảng kê đối soát (CSV) gửi cho ban tổ chức / kế toán
+// Phần đầu: thông tin kỳ và trạng thái; tiếp theo: từng sự kiện và dòng tổng
+// ============================================================
+
+// StatementFileName - Tên file bảng kê
+func StatementFileName(s *Settlement) string {
+	return fmt.Sprintf("settlement_%s_organizer_%d.csv", s.PeriodStart[:7], s.OrganizerID)
+}
+
+// StatementCSV - Bảng kê của một bản đối soát (cần Lines, xem Get)
+func StatementCSV(s *Settlement) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString("\ufeff")
+	w := csv.NewWriter(&buf)
+
+	rows := [][]string{
+		{"Settlement ID", strconv.Itoa(s.SettlementID)},
+		{"Organizer", csvutil.Safe(s.OrganizerName)},
+		{"Period", s.PeriodStart + " - " + s.PeriodEnd},
+		{"Status", s.Status},
+	}
+	if s.PaymentReference != nil {
+		rows = append(rows, []string{"Payment reference", csvutil.Safe(*s.PaymentReference)})
+	}
+	rows = append(rows, []string{}, []string{"Event ID", "Event", "Ended At", "Tickets Sold", "Gross", "Refunds", "Fee", "Net"})
+	for _, l := range s.Lines {
+		rows = append(rows, []string{
+			strconv.Itoa(l.EventID), csvutil.Safe(l.EventTitle), l.EventEndTime.Format(time.RFC3339), strconv.Itoa(l.TicketsSold),
+			amount(l.GrossAmount), amount(l.RefundAmount), amount(l.FeeAmount), amount(l.NetAmount),
+		})
+	}
+	rows = append(rows, []string{
+		"Total", "", "", strconv.Itoa(s.TicketsSold),
+		amount(s.GrossAmount), amount(s.RefundAmount), amount(s.FeeAmount), amount(s.NetAmount),
+	})
+
+	if err := w.WriteAll(rows); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func amount(v float64) string {
+	return strconv.FormatFloat(v, 'f', 0, 64)
+}
@@ -0,0 +1,78 @@
+package slug
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// ============================================================
+// Package slug - Chuỗi URL thân thiện từ tiêu đề tiếng Việt
+// "Hội thảo AI & Tương lai" => "hoi-thao-ai-tuong-lai"
+// ============================================================
+
+// MaxLength - Độ dài tối đa của slug (chưa tính hậu tố -{id})
+const MaxLength = 80
+
+// Fallback - Slug khi tiêu đề không còn ký tự nào dùng được
+const Fallback = "su-kien"
+
+// vietnameseFold - Bỏ dấu tiếng Việt (chữ thường; Make hạ chữ trước khi tra)
+var vietnameseFold = func() map[rune]rune {
+	groups := map[rune]string{
+		'a': "àáảãạăằắẳẵặâầấẩẫậ",
+		'e': "èéẻẽẹêềếểễệ",
+		'i': "ìíỉĩị",
+		'o': "òóỏõọôồốổỗộơờớởỡợ",
+		'u': "ùúủũụưừứửữự",
+		'y': "ỳýỷỹỵ",
+		'd': "đ",
+	}
+	m := make(map[rune]rune)
+	for base, chars := range groups {
+		for _, c := range chars {
+			m[c] = base
+		}
+	}
+	return m
+}()
+
+// Make - Slug của s: chữ thường không dấu, số, nối bằng "-"
+// Cắt ở ranh giới từ nếu dài hơn MaxLength; rỗng thì trả về Fallback
+func Make(s string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(s) {
+		if f, ok := vietnameseFold[r]; ok {
+			r = f
+		}
+		switch {
+		case r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)):
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			dash = false
+		default:
+			dash = true
+		}
+	}
+
+	out := b.String()
+	if len(out) > MaxLength {
+		out = out[:MaxLength]
+		if i := strings.LastIndexByte(out, '-'); i > MaxLength/2 {
+			out = out[:i]
+		}
+		out = strings.TrimRight(out, "-")
+	}
+	if out == "" {
+		return Fallback
+	}
+	return out
+}
+
+// WithID - Slug kèm hậu tố ID, dùng khi slug gốc đã có bản ghi khác chiếm
+func WithID(base string, id int64) string {
+	return base + "-" + strconv.FormatInt(id, 10)
+}
@@ -0,0 +1,41 @@
+package slug
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMake(t *testing.T) {
+	cases := []struct {
+		in, want string
+	}{
+		{"Hội thảo AI & Tương lai", "hoi-thao-ai-tuong-lai"},
+		{"ĐÊM NHẠC Đường Phố 2025", "dem-nhac-duong-pho-2025"},
+		{"  --Workshop: Go/Cloud--  ", "workshop-go-cloud"},
+		{"Ngày hội việc làm – FPT", "ngay-hoi-viec-lam-fpt"},
+		{"!!!", Fallback},
+		{"", Fallback},
+		{"活動", Fallback},
+	}
+	for _, c := range cases {
+		if got := Make(c.in); got != c.want {
+			t.Errorf("Make(%q) = %q, want %q", c.in, got, c.want)
+		}
+	}
+}
+
+func TestMakeTruncatesAtWordBoundary(t *testing.T) {
+	got := Make(strings.Repeat("chuyen de ", 20))
+	if len(got) > MaxLength {
+		t.Fatalf("len = %d, want <= %d", len(got), MaxLength)
+	}
+	if strings.HasSuffix(got, "-") || !strings.HasSuffix(got, "de") && !strings.HasSuffix(got, "chuyen") {
+		t.Fatalf("Make cut inside a word: %q", got)
+	}
+}
+
+func TestWithID(t *testing.T) {
+	if got := WithID("hoi-thao", 42); got != "hoi-thao-42" {
+		t.Fatalf("WithID = %q", got)
+	}
+}
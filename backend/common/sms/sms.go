@@ -0,0 +1,315 @@
+package sms
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/fpt-event-services/common/metrics"
+)
+
+// ============================================================
+// SMS / ZALO ZNS - Gửi OTP qua số điện thoại
+// Nhiều nhà cung cấp theo thứ tự SMS_PROVIDERS, lỗi thì chuyển sang nhà cung cấp kế tiếp
+// ============================================================
+
+// Tên nhà cung cấp (giá trị trong SMS_PROVIDERS)
+const (
+	ProviderSpeedSMS = "speedsms"
+	ProviderZaloZNS  = "zalo_zns"
+)
+
+var sendTotal = metrics.NewCounter("sms_send_total",
+	"OTP sends over phone providers by provider and result (sent, failed).", "provider", "result")
+
+// ErrNotConfigured - Không có nhà cung cấp nào được cấu hình
+var ErrNotConfigured = errors.New("no SMS provider configured")
+
+type Config struct {
+	Providers []string // Thứ tự thử, ví dụ: speedsms,zalo_zns
+
+	SpeedSMSURL    string
+	SpeedSMSToken  string
+	SpeedSMSSender string // Brandname đã đăng ký (rỗng = đầu số ngẫu nhiên)
+
+	ZaloZNSURL         string
+	ZaloAccessToken    string
+	ZaloOTPTemplateID  string
+	ZaloOTPTemplateKey string // Tên tham số OTP trong template ZNS
+
+	Timeout time.Duration
+}
+
+func DefaultConfig() *Config {
+	var providers []string
+	for _, p := range strings.Split(getEnv("SMS_PROVIDERS", ""), ",") {
+		if p = strings.ToLower(strings.TrimSpace(p)); p != "" {
+			providers = append(providers, p)
+		}
+	}
+	return &Config{
+		Providers:          providers,
+		SpeedSMSURL:        getEnv("SPEEDSMS_URL", "https://api.speedsms.vn/index.php/sms/send"),
+		SpeedSMSToken:      getEnv("SPEEDSMS_ACCESS_TOKEN", ""),
+		SpeedSMSSender:     getEnv("SPEEDSMS_SENDER", ""),
+		ZaloZNSURL:         getEnv("ZALO_ZNS_URL", "https://business.openapi.zalo.me/message/template"),
+		ZaloAccessToken:    getEnv("ZALO_ZNS_ACCESS_TOKEN", ""),
+		ZaloOTPTemplateID:  getEnv("ZALO_ZNS_OTP_TEMPLATE_ID", ""),
+		ZaloOTPTemplateKey: getEnv("ZALO_ZNS_OTP_PARAM", "otp"),
+		Timeout:            10 * time.Second,
+	}
+}
+
+// Provider gửi OTP tới một số điện thoại (định dạng 84xxxxxxxxx)
+type Provider interface {
+	Name() string
+	SendOTP(ctx context.Context, phone, otp string) error
+}
+
+// SMSService gửi OTP qua các nhà cung cấp theo thứ tự, có failover
+type SMSService struct {
+	providers []Provider
+}
+
+// NewSMSService creates a new SMS service from config (nil = biến môi trường)
+func NewSMSService(config *Config) *SMSService {
+	if config == nil {
+		config = DefaultConfig()
+	}
+	client := &http.Client{Timeout: config.Timeout}
+
+	var providers []Provider
+	for _, name := range config.Providers {
+		switch name {
+		case ProviderSpeedSMS:
+			if config.SpeedSMSToken == "" {
+				log.Printf("[SMS] ⚠️ speedsms listed in SMS_PROVIDERS but SPEEDSMS_ACCESS_TOKEN is empty, skipped")
+				continue
+			}
+			providers = append(providers, &speedSMSProvider{
+				url: config.SpeedSMSURL, token: config.SpeedSMSToken, sender: config.SpeedSMSSender, client: client,
+			})
+		case ProviderZaloZNS:
+			if config.ZaloAccessToken == "" || config.ZaloOTPTemplateID == "" {
+				log.Printf("[SMS] ⚠️ zalo_zns listed in SMS_PROVIDERS but access token/template is empty, skipped")
+				continue
+			}
+			providers = append(providers, &zaloZNSProvider{
+				url: config.ZaloZNSURL, token: config.ZaloAccessToken,
+				templateID: config.ZaloOTPTemplateID, param: config.ZaloOTPTemplateKey, client: client,
+			})
+		default:
+			log.Printf("[SMS] ⚠️ Unknown SMS provider %q, skipped", name)
+		}
+	}
+	return &SMSService{providers: providers}
+}
+
+// NewSMSServiceWithProviders tạo service từ danh sách provider có sẵn (test, provider tùy biến)
+func NewSMSServiceWithProviders(providers ...Provider) *SMSService {
+	return &SMSService{providers: providers}
+}
+
+// IsConfigured returns true if at least one provider is available
+func (s *SMSService) IsConfigured() bool {
+	return len(s.providers) > 0
+}
+
+// HasProvider kiểm tra nhà cung cấp có được cấu hình không
+func (s *SMSService) HasProvider(name string) bool {
+	for _, p := range s.providers {
+		if p.Name() == name {
+			return true
+		}
+	}
+	return false
+}
+
+// SendOTP gửi OTP, thử preferred trước (nếu có) rồi lần lượt các provider còn lại
+// Trả về tên provider đã gửi thành công
+func (s *SMSService) SendOTP(ctx context.Context, phone, otp, preferred string) (string, error) {
+	if len(s.providers) == 0 {
+		return "", ErrNotConfigured
+	}
+	phone = NormalizePhone(phone)
+	if phone == "" {
+		return "", fmt.Errorf("invalid phone number")
+	}
+
+	ordered := make([]Provider, 0, len(s.providers))
+	for _, p := range s.providers {
+		if p.Name() == preferred {
+			ordered = append(ordered, p)
+		}
+	}
+	for _, p := range s.providers {
+		if p.Name() != preferred {
+			ordered = append(ordered, p)
+		}
+	}
+
+	var errs []error
+	for _, p := range ordered {
+		err := p.SendOTP(ctx, phone, otp)
+		if err == nil {
+			sendTotal.Inc(p.Name(), "sent")
+			return p.Name(), nil
+		}
+		sendTotal.Inc(p.Name(), "failed")
+		log.Printf("[SMS] ⚠️ Provider %s failed for %s, trying next: %v", p.Name(), MaskPhone(phone), err)
+		errs = append(errs, fmt.Errorf("%s: %w", p.Name(), err))
+	}
+	return "", errors.Join(errs...)
+}
+
+// NormalizePhone chuyển số Việt Nam về dạng 84xxxxxxxxx (rỗng nếu không hợp lệ)
+func NormalizePhone(phone string) string {
+	var b strings.Builder
+	for _, r := range phone {
+		if r >= '0' && r <= '9' {
+			b.WriteRune(r)
+		}
+	}
+	digits := b.String()
+	switch {
+	case strings.HasPrefix(digits, "84") && len(digits) == 11:
+		return digits
+	case strings.HasPrefix(digits, "0") && len(digits) == 10:
+		return "84" + digits[1:]
+	default:
+		return ""
+	}
+}
+
+// MaskPhone che số điện thoại khi log / trả về client: 84912***678
+func MaskPhone(phone string) string {
+	if len(phone) < 7 {
+		return "***"
+	}
+	return phone[:len(phone)-6] + "***" + phone[len(phone)-3:]
+}
+
+// ============================================================
+// SpeedSMS - https://speedsms.vn (Basic auth: token làm username)
+// ============================================================
+
+type speedSMSProvider struct {
+	url    string
+	token  string
+	sender string
+	client *http.Client
+}
+
+func (p *speedSMSProvider) Name() string { return ProviderSpeedSMS }
+
+func (p *speedSMSProvider) SendOTP(ctx context.Context, phone, otp string) error {
+	smsType := 2 // đầu số ngẫu nhiên
+	if p.sender != "" {
+		smsType = 3 // brandname
+	}
+	payload := map[string]interface{}{
+		"to":       []string{phone},
+		"content":  fmt.Sprintf("Ma OTP FPT Event cua ban la %s. Ma co hieu luc trong 5 phut. Khong chia se ma nay.", otp),
+		"sms_type": smsType,
+		"sender":   p.sender,
+	}
+
+	var result struct {
+		Status  string `json:"status"`
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	}
+	if err := postJSON(ctx, p.client, p.url, payload, func(req *http.Request) {
+		req.SetBasicAuth(p.token, "x")
+	}, &result); err != nil {
+		return err
+	}
+	if result.Status != "success" {
+		return fmt.Errorf("speedsms error %s: %s", result.Code, result.Message)
+	}
+	return nil
+}
+
+// ============================================================
+// Zalo ZNS - Gửi OTP qua template Zalo Notification Service
+// ============================================================
+
+type zaloZNSProvider struct {
+	url        string
+	token      string
+	templateID string
+	param      string
+	client     *http.Client
+}
+
+func (p *zaloZNSProvider) Name() string { return ProviderZaloZNS }
+
+func (p *zaloZNSProvider) SendOTP(ctx context.Context, phone, otp string) error {
+	payload := map[string]interface{}{
+		"phone":         phone,
+		"template_id":   p.templateID,
+		"template_data": map[string]string{p.param: otp},
+		"tracking_id":   fmt.Sprintf("otp-%d", time.Now().UnixNano()),
+	}
+
+	var result struct {
+		Error   int    `json:"error"`
+		Message string `json:"message"`
+	}
+	if err := postJSON(ctx, p.client, p.url, payload, func(req *http.Request) {
+		req.Header.Set("access_token", p.token)
+	}, &result); err != nil {
+		return err
+	}
+	if result.Error != 0 {
+		return fmt.Errorf("zalo zns error %d: %s", result.Error, result.Message)
+	}
+	return nil
+}
+
+// postJSON gửi body JSON và decode response vào out
+func postJSON(ctx context.Context, client *http.Client, url string, payload interface{}, auth func(*http.Request), out interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	auth(req)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("HTTP %d: %s", resp.StatusCode, strings.TrimSpace(string(data)))
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("invalid response: %w", err)
+	}
+	return nil
+}
+
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
@@ -0,0 +1,77 @@
+package sms
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestNormalizePhone(t *testing.T) {
+	cases := map[string]string{
+		"0912345678":      "84912345678",
+		"+84 912 345 678": "84912345678",
+		"84912345678":     "84912345678",
+		"091234":          "",
+		"":                "",
+	}
+	for in, want := range cases {
+		if got := NormalizePhone(in); got != want {
+			t.Errorf("NormalizePhone(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestSendOTPFailover(t *testing.T) {
+	speed := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer speed.Close()
+
+	var got map[string]interface{}
+	zalo := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("access_token") != "zalo-token" {
+			t.Errorf("missing zalo access token")
+		}
+		json.NewDecoder(r.Body).Decode(&got)
+		w.Write([]byte(`{"error":0,"message":"Success"}`))
+	}))
+	defer zalo.Close()
+
+	svc := NewSMSService(&Config{
+		Providers:          []string{ProviderSpeedSMS, ProviderZaloZNS},
+		SpeedSMSURL:        speed.URL,
+		SpeedSMSToken:      "speed-token",
+		ZaloZNSURL:         zalo.URL,
+		ZaloAccessToken:    "zalo-token",
+		ZaloOTPTemplateID:  "123",
+		ZaloOTPTemplateKey: "otp",
+		Timeout:            time.Second,
+	})
+
+	provider, err := svc.SendOTP(context.Background(), "0912345678", "123456", "")
+	if err != nil {
+		t.Fatalf("SendOTP: %v", err)
+	}
+	if provider != ProviderZaloZNS {
+		t.Errorf("provider = %q, want failover to %q", provider, ProviderZaloZNS)
+	}
+	if got["phone"] != "84912345678" {
+		t.Errorf("phone sent to zalo = %v", got["phone"])
+	}
+	if data, _ := got["template_data"].(map[string]interface{}); data["otp"] != "123456" {
+		t.Errorf("template_data = %v", got["template_data"])
+	}
+}
+
+func TestSendOTPNotConfigured(t *testing.T) {
+	svc := NewSMSService(&Config{Providers: []string{ProviderSpeedSMS}})
+	if svc.IsConfigured() {
+		t.Fatal("provider without token must be skipped")
+	}
+	if _, err := svc.SendOTP(context.Background(), "0912345678", "123456", ""); err != ErrNotConfigured {
+		t.Errorf("err = %v, want ErrNotConfigured", err)
+	}
+}
@@ -0,0 +1,118 @@
+package statuslabel
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/fpt-event-services/common/response"
+)
+
+// ============================================================
+// STATUS LABEL - Nhãn hiển thị cho mã trạng thái
+// Frontend không hiển thị mã enum thô (UPDATING, CHECKED_IN...): response
+// list/detail kèm nhãn tiếng Việt / tiếng Anh và gợi ý màu; toàn bộ bảng
+// nhãn trả qua GET /api/meta/statuses để client cache (ETag).
+// ============================================================
+
+// Loại trạng thái
+const (
+	KindEvent        = "event"
+	KindEventRequest = "eventRequest"
+	KindTicket       = "ticket"
+	KindReport       = "report"
+)
+
+// Gợi ý màu (frontend tự ánh xạ sang palette của mình)
+const (
+	ColorSuccess = "success"
+	ColorWarning = "warning"
+	ColorDanger  = "danger"
+	ColorInfo    = "info"
+	ColorNeutral = "neutral"
+)
+
+// Label - Mã trạng thái kèm nhãn đã bản địa hóa
+type Label struct {
+	Code  string `json:"code"`
+	Vi    string `json:"vi"`
+	En    string `json:"en"`
+	Color string `json:"color"`
+}
+
+// tables - Thứ tự trong mỗi bảng là thứ tự hiển thị (bộ lọc, chú thích)
+var tables = map[string][]Label{
+	KindEvent: {
+		{Code: "UPDATING", Vi: "Đang cập nhật", En: "Being updated", Color: ColorWarning},
+		{Code: "OPEN", Vi: "Đang mở đăng ký", En: "Open", Color: ColorSuccess},
+		{Code: "CLOSED", Vi: "Đã kết thúc", En: "Closed", Color: ColorNeutral},
+		{Code: "CANCELLED", Vi: "Đã hủy", En: "Cancelled", Color: ColorDanger},
+		{Code: "DISABLED", Vi: "Đã vô hiệu hóa", En: "Disabled", Color: ColorNeutral},
+	},
+	KindEventRequest: {
+		{Code: "PENDING", Vi: "Chờ duyệt", En: "Pending review", Color: ColorWarning},
+		{Code: "APPROVED", Vi: "Đã duyệt", En: "Approved", Color: ColorSuccess},
+		{Code: "UPDATING", Vi: "Chờ bổ sung thông tin", En: "Awaiting details", Color: ColorInfo},
+		{Code: "REJECTED", Vi: "Bị từ chối", En: "Rejected", Color: ColorDanger},
+		{Code: "CANCELLED", Vi: "Đã hủy", En: "Cancelled", Color: ColorNeutral},
+		{Code: "EXPIRED", Vi: "Đã hết hạn", En: "Expired", Color: ColorNeutral},
+		{Code: "FINISHED", Vi: "Đã hoàn tất", En: "Finished", Color: ColorSuccess},
+	},
+	KindTicket: {
+		{Code: "PENDING", Vi: "Chờ thanh toán", En: "Awaiting payment", Color: ColorWarning},
+		{Code: "BOOKED", Vi: "Đã đặt", En: "Booked", Color: ColorInfo},
+		{Code: "CHECKED_IN", Vi: "Đã check-in", En: "Checked in", Color: ColorSuccess},
+		{Code: "CHECKED_OUT", Vi: "Đã check-out", En: "Checked out", Color: ColorNeutral},
+		{Code: "EXPIRED", Vi: "Đã hết hạn", En: "Expired", Color: ColorNeutral},
+		{Code: "REFUNDED", Vi: "Đã hoàn tiền", En: "Refunded", Color: ColorDanger},
+	},
+	KindReport: {
+		{Code: "PENDING", Vi: "Chờ xử lý", En: "Pending", Color: ColorWarning},
+		{Code: "APPROVED", Vi: "Đã chấp nhận", En: "Approved", Color: ColorSuccess},
+		{Code: "REJECTED", Vi: "Bị từ chối", En: "Rejected", Color: ColorDanger},
+		{Code: "CANCELLED", Vi: "Đã hủy", En: "Cancelled", Color: ColorNeutral},
+	},
+}
+
+// Lookup - Nhãn của code trong bảng kind; code rỗng → nil,
+// code chưa có trong bảng → nhãn dùng chính mã (không làm hỏng response)
+func Lookup(kind, code string) *Label {
+	if code == "" {
+		return nil
+	}
+	for _, l := range tables[kind] {
+		if l.Code == code {
+			return &l
+		}
+	}
+	return &Label{Code: code, Vi: code, En: code, Color: ColorNeutral}
+}
+
+// LookupPtr - Như Lookup cho cột trạng thái có thể NULL
+func LookupPtr(kind string, code *string) *Label {
+	if code == nil {
+		return nil
+	}
+	return Lookup(kind, *code)
+}
+
+// All - Bản sao toàn bộ bảng nhãn theo loại
+func All() map[string][]Label {
+	out := make(map[string][]Label, len(tables))
+	for kind, labels := range tables {
+		out[kind] = append([]Label(nil), labels...)
+	}
+	return out
+}
+
+// Handler - GET /api/meta/statuses (public; bảng nhãn chỉ đổi khi deploy nên cho cache 1 giờ)
+func Handler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "public, max-age=3600")
+		json.NewEncoder(w).Encode(response.SuccessResponse(All()))
+	}
+}
@@ -0,0 +1,33 @@
+package statuslabel
+
+import "testing"
+
+func TestLookup(t *testing.T) {
+	l := Lookup(KindEvent, "UPDATING")
+	if l == nil || l.Vi != "Đang cập nhật" || l.En != "Being updated" || l.Color != ColorWarning {
+		t.Fatalf("UPDATING: got %+v", l)
+	}
+	// Cùng mã, khác loại → khác nhãn
+	if Lookup(KindEventRequest, "UPDATING").Vi == l.Vi {
+		t.Fatal("event request UPDATING should have its own label")
+	}
+	if Lookup(KindTicket, "") != nil {
+		t.Fatal("empty code should have no label")
+	}
+	if u := Lookup(KindReport, "ARCHIVED"); u == nil || u.Vi != "ARCHIVED" || u.Color != ColorNeutral {
+		t.Fatalf("unknown code should fall back to the raw code: %+v", u)
+	}
+}
+
+func TestAllReturnsCopy(t *testing.T) {
+	all := All()
+	for _, kind := range []string{KindEvent, KindEventRequest, KindTicket, KindReport} {
+		if len(all[kind]) == 0 {
+			t.Fatalf("missing kind %s", kind)
+		}
+	}
+	all[KindEvent][0].Vi = "x"
+	if Lookup(KindEvent, "UPDATING").Vi == "x" {
+		t.Fatal("All must not expose the internal tables")
+	}
+}
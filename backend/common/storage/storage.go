@@ -0,0 +1,102 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// ============================================================
+// Object storage cho file upload (banner, ...)
+// STORAGE_DRIVER=local (mặc định) hoặc s3
+// ============================================================
+
+// Storage - Lưu object và trả về URL public
+type Storage interface {
+	Put(ctx context.Context, key string, contentType string, data []byte) (string, error)
+}
+
+var (
+	defaultStorage Storage
+	storageOnce    sync.Once
+)
+
+// Default trả về storage theo cấu hình môi trường (singleton)
+func Default() Storage {
+	storageOnce.Do(func() {
+		defaultStorage = NewFromEnv()
+	})
+	return defaultStorage
+}
+
+// NewFromEnv tạo storage từ biến môi trường
+//
+//	STORAGE_DRIVER   = local | s3
+//	UPLOAD_DIR       = thư mục lưu file (local, mặc định ./uploads)
+//	UPLOAD_BASE_URL  = URL public tương ứng (local, mặc định /uploads)
+//	S3_BUCKET, S3_REGION, S3_PUBLIC_BASE_URL, AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY, AWS_SESSION_TOKEN
+func NewFromEnv() Storage {
+	if strings.EqualFold(os.Getenv("STORAGE_DRIVER"), "s3") {
+		return NewS3Storage(S3Config{
+			Bucket:        os.Getenv("S3_BUCKET"),
+			Region:        getEnv("S3_REGION", getEnv("AWS_REGION", "ap-southeast-1")),
+			AccessKey:     os.Getenv("AWS_ACCESS_KEY_ID"),
+			SecretKey:     os.Getenv("AWS_SECRET_ACCESS_KEY"),
+			SessionToken:  os.Getenv("AWS_SESSION_TOKEN"),
+			PublicBaseURL: os.Getenv("S3_PUBLIC_BASE_URL"),
+		})
+	}
+	return NewLocalStorage(getEnv("UPLOAD_DIR", "uploads"), getEnv("UPLOAD_BASE_URL", "/uploads"))
+}
+
+// LocalStorage lưu file vào thư mục local (dùng cho dev / monolith)
+// main.go serve thư mục này qua UPLOAD_BASE_URL
+type LocalStorage struct {
+	BaseDir string
+	BaseURL string
+}
+
+// NewLocalStorage creates a new local storage
+func NewLocalStorage(baseDir, baseURL string) *LocalStorage {
+	return &LocalStorage{
+		BaseDir: baseDir,
+		BaseURL: strings.TrimRight(baseURL, "/"),
+	}
+}
+
+// Put ghi file xuống đĩa và trả về URL public
+func (s *LocalStorage) Put(ctx context.Context, key string, contentType string, data []byte) (string, error) {
+	cleanKey, err := cleanObjectKey(key)
+	if err != nil {
+		return "", err
+	}
+
+	fullPath := filepath.Join(s.BaseDir, filepath.FromSlash(cleanKey))
+	if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+		return "", fmt.Errorf("failed to create upload directory: %w", err)
+	}
+	if err := os.WriteFile(fullPath, data, 0644); err != nil {
+		return "", fmt.Errorf("failed to write file: %w", err)
+	}
+	return s.BaseURL + "/" + cleanKey, nil
+}
+
+// cleanObjectKey chặn path traversal (../) trong key
+func cleanObjectKey(key string) (string, error) {
+	cleaned := filepath.ToSlash(filepath.Clean("/" + key))
+	cleaned = strings.TrimPrefix(cleaned, "/")
+	if cleaned == "" || cleaned == "." {
+		return "", fmt.Errorf("invalid object key: %q", key)
+	}
+	return cleaned, nil
+}
+
+func getEnv(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
@@ -0,0 +1,160 @@
+package tickethistory
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ============================================================
+// TICKETHISTORY - Lịch sử trạng thái vé (bảng Ticket_Status_History, migration 037)
+// Lịch sử được ghi bởi Create / Transition / TransitionWhere (transition.go) trong CÙNG
+// transaction với câu UPDATE / INSERT Ticket, nên luôn khớp trạng thái hiện tại.
+// Timeline dựng dòng thời gian cho người mua: mua → đã thanh toán → check-in → check-out / hoàn tiền
+// ============================================================
+
+// Trạng thái vé (Ticket.status)
+const (
+	StatusPending    = "PENDING"
+	StatusBooked     = "BOOKED"
+	StatusCheckedIn  = "CHECKED_IN"
+	StatusCheckedOut = "CHECKED_OUT"
+	StatusExpired    = "EXPIRED"
+	StatusRefunded   = "REFUNDED"
+)
+
+// StepPurchased - Bước đầu của timeline (lúc tạo vé), không phải một Ticket.status
+const StepPurchased = "PURCHASED"
+
+// Execer - *sql.Tx hoặc *sql.DB
+type Execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// Querier - *sql.Tx hoặc *sql.DB
+type Querier interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+// Entry - Một lần đổi trạng thái
+type Entry struct {
+	Status    string    `json:"status"`
+	ChangedAt time.Time `json:"changedAt"`
+	ChangedBy *int      `json:"changedBy,omitempty"`
+}
+
+// record - Ghi một lần đổi trạng thái của vé; actorID nil = hệ thống / chính người mua
+func record(ctx context.Context, exec Execer, ticketID int, status string, actorID *int) error {
+	_, err := exec.ExecContext(ctx,
+		`INSERT INTO Ticket_Status_History (ticket_id, status, changed_at, changed_by) VALUES (?, ?, NOW(6), ?)`,
+		ticketID, status, actorID)
+	if err != nil {
+		return fmt.Errorf("failed to record ticket status: %w", err)
+	}
+	return nil
+}
+
+// recordWhere - Ghi status cho mọi vé khớp điều kiện (alias t), chạy TRƯỚC câu UPDATE hàng loạt cùng điều kiện
+func recordWhere(ctx context.Context, exec Execer, status string, where string, args ...any) (int64, error) {
+	result, err := exec.ExecContext(ctx, `
+		INSERT INTO Ticket_Status_History (ticket_id, status, changed_at)
+		SELECT t.ticket_id, ?, NOW(6) FROM Ticket t WHERE `+where,
+		append([]any{status}, args...)...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to record ticket status: %w", err)
+	}
+	return result.RowsAffected()
+}
+
+// ForTickets - Lịch sử của nhiều vé (cũ → mới), theo ticket_id
+func ForTickets(ctx context.Context, q Querier, ticketIDs []int) (map[int][]Entry, error) {
+	history := make(map[int][]Entry, len(ticketIDs))
+	if len(ticketIDs) == 0 {
+		return history, nil
+	}
+	args := make([]any, len(ticketIDs))
+	for i, id := range ticketIDs {
+		args[i] = id
+	}
+	rows, err := q.QueryContext(ctx, `
+		SELECT ticket_id, status, changed_at, changed_by
+		FROM Ticket_Status_History
+		WHERE ticket_id IN (?`+strings.Repeat(",?", len(ticketIDs)-1)+`)
+		ORDER BY ticket_id, changed_at, history_id
+	`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query ticket status history: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var ticketID int
+		var e Entry
+		var changedBy sql.NullInt64
+		if err := rows.Scan(&ticketID, &e.Status, &e.ChangedAt, &changedBy); err != nil {
+			return nil, fmt.Errorf("failed to scan ticket status history: %w", err)
+		}
+		if changedBy.Valid {
+			id := int(changedBy.Int64)
+			e.ChangedBy = &id
+		}
+		history[ticketID] = append(history[ticketID], e)
+	}
+	return history, rows.Err()
+}
+
+// Snapshot - Các mốc thời gian lưu trên chính dòng Ticket (dùng khi vé chưa có lịch sử)
+type Snapshot struct {
+	Status       string
+	CreatedAt    *time.Time
+	CheckInTime  *time.Time
+	CheckOutTime *time.Time
+}
+
+// Step - Một bước của timeline hiển thị cho người mua
+type Step struct {
+	Step string    `json:"step"` // PURCHASED hoặc một Ticket.status
+	At   time.Time `json:"at"`
+}
+
+// Timeline - Dòng thời gian của một vé: PURCHASED (lúc tạo vé) rồi các lần đổi trạng thái
+// PENDING chỉ là giữ ghế nên gộp vào PURCHASED; trạng thái trùng liền nhau chỉ giữ lần đầu.
+// Không có lịch sử thì dựng từ các cột thời gian của Ticket
+func Timeline(history []Entry, snap Snapshot) []Step {
+	if len(history) == 0 {
+		history = fromSnapshot(snap)
+	}
+	steps := []Step{}
+	if snap.CreatedAt != nil {
+		steps = append(steps, Step{Step: StepPurchased, At: *snap.CreatedAt})
+	} else if len(history) > 0 {
+		steps = append(steps, Step{Step: StepPurchased, At: history[0].ChangedAt})
+	}
+	last := ""
+	for _, e := range history {
+		if e.Status == StatusPending || e.Status == last {
+			continue
+		}
+		steps = append(steps, Step{Step: e.Status, At: e.ChangedAt})
+		last = e.Status
+	}
+	return steps
+}
+
+func fromSnapshot(snap Snapshot) []Entry {
+	var entries []Entry
+	if snap.CreatedAt != nil && snap.Status != StatusPending && snap.Status != StatusExpired {
+		entries = append(entries, Entry{Status: StatusBooked, ChangedAt: *snap.CreatedAt})
+	}
+	if snap.CheckInTime != nil {
+		entries = append(entries, Entry{Status: StatusCheckedIn, ChangedAt: *snap.CheckInTime})
+	}
+	if snap.CheckOutTime != nil {
+		entries = append(entries, Entry{Status: StatusCheckedOut, ChangedAt: *snap.CheckOutTime})
+	}
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].ChangedAt.Before(entries[j].ChangedAt) })
+	return entries
+}
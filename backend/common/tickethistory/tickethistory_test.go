@@ -0,0 +1,67 @@
+package tickethistory
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func steps(timeline []Step) []string {
+	var names []string
+	for _, s := range timeline {
+		names = append(names, s.Step)
+	}
+	return names
+}
+
+func TestTimelineFromHistory(t *testing.T) {
+	created := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	history := []Entry{
+		{Status: StatusPending, ChangedAt: created},
+		{Status: StatusBooked, ChangedAt: created.Add(2 * time.Minute)},
+		{Status: StatusCheckedIn, ChangedAt: created.Add(48 * time.Hour)},
+		{Status: StatusRefunded, ChangedAt: created.Add(72 * time.Hour)},
+	}
+	got := Timeline(history, Snapshot{Status: StatusRefunded, CreatedAt: &created})
+	want := []string{StepPurchased, StatusBooked, StatusCheckedIn, StatusRefunded}
+	if !reflect.DeepEqual(steps(got), want) {
+		t.Errorf("steps = %v, want %v", steps(got), want)
+	}
+	if !got[1].At.Equal(created.Add(2 * time.Minute)) {
+		t.Errorf("booked at %v", got[1].At)
+	}
+}
+
+func TestTimelineFallsBackToTicketColumns(t *testing.T) {
+	created := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	in, out := created.Add(time.Hour), created.Add(3*time.Hour)
+	got := Timeline(nil, Snapshot{Status: StatusCheckedOut, CreatedAt: &created, CheckInTime: &in, CheckOutTime: &out})
+	want := []string{StepPurchased, StatusBooked, StatusCheckedIn, StatusCheckedOut}
+	if !reflect.DeepEqual(steps(got), want) {
+		t.Errorf("steps = %v, want %v", steps(got), want)
+	}
+}
+
+func TestCanTransition(t *testing.T) {
+	allowed := [][2]string{
+		{"", StatusPending}, {"", StatusBooked},
+		{StatusPending, StatusBooked}, {StatusPending, StatusExpired},
+		{StatusBooked, StatusCheckedIn}, {StatusCheckedIn, StatusCheckedOut},
+		{StatusBooked, StatusRefunded}, {StatusCheckedIn, StatusRefunded},
+	}
+	for _, p := range allowed {
+		if !CanTransition(p[0], p[1]) {
+			t.Errorf("%q → %q should be allowed", p[0], p[1])
+		}
+	}
+	rejected := [][2]string{
+		{StatusCheckedOut, StatusBooked}, {StatusRefunded, StatusCheckedIn},
+		{StatusExpired, StatusBooked}, {StatusPending, StatusCheckedIn},
+		{StatusBooked, StatusBooked}, {"", StatusCheckedIn},
+	}
+	for _, p := range rejected {
+		if CanTransition(p[0], p[1]) {
+			t.Errorf("%q → %q should be rejected", p[0], p[1])
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package tickethistory
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// ============================================================
+// Chuyển trạng thái vé tập trung
+// Mọi thay đổi Ticket.status (đặt vé, thanh toán, check-in, check-out, hoàn tiền,
+// hết hạn khi đóng sự kiện) đi qua Create / Transition / TransitionWhere:
+// kiểm tra cặp (từ → đến) có nằm trong allowedTransitions, UPDATE Ticket và ghi
+// Ticket_Status_History trong cùng transaction.
+// Xóa vé PENDING hết thời gian giữ ghế không phải chuyển trạng thái (lịch sử bị xóa theo)
+// ============================================================
+
+var (
+	// ErrTicketNotFound - Vé không tồn tại (hoặc vé PENDING đã bị job dọn dẹp xóa)
+	ErrTicketNotFound = errors.New("ticket not found")
+	// ErrInvalidTransition - Cặp trạng thái không được phép, ví dụ CHECKED_OUT → BOOKED
+	ErrInvalidTransition = errors.New("invalid ticket status transition")
+)
+
+// allowedTransitions - Trạng thái hiện tại → các trạng thái được chuyển tới ("" = vé mới tạo)
+var allowedTransitions = map[string][]string{
+	"":              {StatusPending, StatusBooked},
+	StatusPending:   {StatusBooked, StatusExpired},
+	StatusBooked:    {StatusCheckedIn, StatusRefunded},
+	StatusCheckedIn: {StatusCheckedOut, StatusRefunded},
+}
+
+// CanTransition - from → to có hợp lệ không (from "" = tạo vé)
+func CanTransition(from, to string) bool {
+	return slices.Contains(allowedTransitions[from], to)
+}
+
+// Tx - *sql.Tx (Transition khóa dòng vé nên cần transaction)
+type Tx interface {
+	Execer
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// Change - Một lần đổi trạng thái của một vé
+type Change struct {
+	TicketID int
+	To       string
+	ActorID  *int   // nil = hệ thống / chính người mua
+	Set      string // Cột cập nhật kèm, ví dụ "checkin_time = NOW()" (tham số trong SetArgs)
+	SetArgs  []any
+}
+
+// Create - Ghi trạng thái đầu tiên của vé vừa INSERT (PENDING khi giữ ghế, BOOKED khi trả ngay / vé mời)
+func Create(ctx context.Context, exec Execer, ticketID int, status string, actorID *int) error {
+	if !CanTransition("", status) {
+		return fmt.Errorf("%w: new ticket cannot start as %s", ErrInvalidTransition, status)
+	}
+	return record(ctx, exec, ticketID, status, actorID)
+}
+
+// Transition - Khóa vé (FOR UPDATE), kiểm tra chuyển trạng thái, UPDATE và ghi lịch sử
+// Trả về trạng thái trước đó; lỗi ErrTicketNotFound / ErrInvalidTransition (kèm from → to)
+func Transition(ctx context.Context, tx Tx, c Change) (string, error) {
+	var from string
+	err := tx.QueryRowContext(ctx, `SELECT status FROM Ticket WHERE ticket_id = ? FOR UPDATE`, c.TicketID).Scan(&from)
+	if err == sql.ErrNoRows {
+		return "", ErrTicketNotFound
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to lock ticket: %w", err)
+	}
+	if !CanTransition(from, c.To) {
+		return from, fmt.Errorf("%w: ticket %d %s → %s", ErrInvalidTransition, c.TicketID, from, c.To)
+	}
+
+	set := "status = ?"
+	args := []any{c.To}
+	if c.Set != "" {
+		set += ", " + c.Set
+		args = append(args, c.SetArgs...)
+	}
+	args = append(args, c.TicketID, from)
+	if _, err := tx.ExecContext(ctx, `UPDATE Ticket SET `+set+` WHERE ticket_id = ? AND status = ?`, args...); err != nil {
+		return from, fmt.Errorf("failed to update ticket status: %w", err)
+	}
+	if err := record(ctx, tx, c.TicketID, c.To, c.ActorID); err != nil {
+		return from, err
+	}
+	return from, nil
+}
+
+// TransitionWhere - Chuyển hàng loạt mọi vé đang ở trạng thái from và khớp where (alias t)
+// Ví dụ: TransitionWhere(ctx, tx, StatusPending, StatusExpired, "t.event_id = ?", eventID)
+func TransitionWhere(ctx context.Context, tx Execer, from, to, where string, args ...any) (int64, error) {
+	if !CanTransition(from, to) {
+		return 0, fmt.Errorf("%w: %s → %s", ErrInvalidTransition, from, to)
+	}
+	condition := "t.status = ? AND (" + where + ")"
+	conditionArgs := append([]any{from}, args...)
+	if _, err := recordWhere(ctx, tx, to, condition, conditionArgs...); err != nil {
+		return 0, err
+	}
+	result, err := tx.ExecContext(ctx, `UPDATE Ticket t SET t.status = ? WHERE `+condition,
+		append([]any{to}, conditionArgs...)...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to update ticket status: %w", err)
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,41 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// TraceparentHeader - Header W3C Trace Context
+const TraceparentHeader = "traceparent"
+
+// propagator - W3C Trace Context; Init cũng đặt làm propagator toàn cục (otelhttp dùng)
+var propagator = propagation.TraceContext{}
+
+// Extract đọc traceparent từ header; span tạo sau đó sẽ nối vào trace của caller
+func Extract(ctx context.Context, header http.Header) context.Context {
+	return propagator.Extract(ctx, propagation.HeaderCarrier(header))
+}
+
+// Inject ghi traceparent của span hiện tại vào header của request đi ra
+func Inject(ctx context.Context, header http.Header) {
+	propagator.Inject(ctx, propagation.HeaderCarrier(header))
+}
+
+// Traceparent - Giá trị traceparent của span trong ctx ("" nếu không có)
+// Dùng khi trace phải đi qua nơi không có header HTTP (vd: payload Outbox_Message)
+func Traceparent(ctx context.Context) string {
+	carrier := propagation.MapCarrier{}
+	propagator.Inject(ctx, carrier)
+	return carrier.Get(TraceparentHeader)
+}
+
+// ContextWithTraceparent - Ngược lại với Traceparent: span tạo từ ctx trả về nối vào trace đó
+// Giá trị rỗng hoặc sai định dạng thì trả nguyên ctx
+func ContextWithTraceparent(ctx context.Context, traceparent string) context.Context {
+	if traceparent == "" {
+		return ctx
+	}
+	return propagator.Extract(ctx, propagation.MapCarrier{TraceparentHeader: traceparent})
+}
@@ -0,0 +1,211 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ============================================================
+// Package tracing - Lớp mỏng trên OpenTelemetry SDK cho code nghiệp vụ:
+//   - TracerProvider của SDK, sampler ParentBased(TraceIDRatioBased)
+//   - Xuất span qua otlptracehttp khi đặt OTEL_EXPORTER_OTLP_ENDPOINT
+//   - Propagator W3C Trace Context (header traceparent)
+//   - Chưa cấu hình exporter: span không được gửi đi nhưng trace ID vẫn
+//     được sinh/truyền tiếp để đối chiếu log
+// ============================================================
+
+// instrumentationName - Tên tracer (scope) của các span tạo qua package này
+const instrumentationName = "github.com/fpt-event-services/common/tracing"
+
+// SpanKind - Loại span (trace.SpanKind của OpenTelemetry)
+type SpanKind = trace.SpanKind
+
+const (
+	SpanKindInternal = trace.SpanKindInternal
+	SpanKindServer   = trace.SpanKindServer
+	SpanKindClient   = trace.SpanKindClient
+	SpanKindConsumer = trace.SpanKindConsumer
+)
+
+// Attr - Thuộc tính của span
+type Attr = attribute.KeyValue
+
+// String tạo thuộc tính chuỗi
+func String(key, value string) Attr { return attribute.String(key, value) }
+
+// Int tạo thuộc tính số nguyên
+func Int(key string, value int) Attr { return attribute.Int(key, value) }
+
+// Bool tạo thuộc tính boolean
+func Bool(key string, value bool) Attr { return attribute.Bool(key, value) }
+
+// Span - Bọc trace.Span; RecordError đồng thời đặt status ERROR
+// Mọi method an toàn khi span == nil
+type Span struct {
+	span trace.Span
+}
+
+// SpanContext trả về định danh của span (TraceID/SpanID)
+func (s *Span) SpanContext() trace.SpanContext {
+	if s == nil {
+		return trace.SpanContext{}
+	}
+	return s.span.SpanContext()
+}
+
+// SetAttributes thêm thuộc tính (bỏ qua nếu span không được ghi)
+func (s *Span) SetAttributes(attrs ...Attr) {
+	if s == nil {
+		return
+	}
+	s.span.SetAttributes(attrs...)
+}
+
+// RecordError ghi lỗi vào span và đánh dấu span lỗi (status ERROR)
+func (s *Span) RecordError(err error) {
+	if s == nil || err == nil {
+		return
+	}
+	s.span.RecordError(err)
+	s.span.SetStatus(codes.Error, err.Error())
+}
+
+// End kết thúc span; gọi nhiều lần chỉ tính lần đầu
+func (s *Span) End() {
+	if s == nil {
+		return
+	}
+	s.span.End()
+}
+
+// SpanOption - Tùy chọn khi tạo span
+type SpanOption = trace.SpanStartOption
+
+// WithKind đặt loại span (mặc định Internal)
+func WithKind(kind SpanKind) SpanOption { return trace.WithSpanKind(kind) }
+
+// WithStartTime đặt thời điểm bắt đầu (khi span được tạo sau khi công việc đã chạy)
+func WithStartTime(t time.Time) SpanOption { return trace.WithTimestamp(t) }
+
+// WithAttributes gán thuộc tính ngay khi tạo span
+func WithAttributes(attrs ...Attr) SpanOption { return trace.WithAttributes(attrs...) }
+
+// Start tạo span con của span trong ctx (hoặc của trace từ traceparent đã Extract)
+// Luôn phải gọi span.End()
+func Start(ctx context.Context, name string, opts ...SpanOption) (context.Context, *Span) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, span := otel.Tracer(instrumentationName).Start(ctx, name, opts...)
+	return ctx, &Span{span: span}
+}
+
+// SpanFromContext lấy span hiện tại (span không ghi gì nếu ctx chưa có span)
+func SpanFromContext(ctx context.Context) *Span {
+	if ctx == nil {
+		return nil
+	}
+	return &Span{span: trace.SpanFromContext(ctx)}
+}
+
+// TraceIDFromContext - Trace ID hex của ctx, "" nếu không có (dùng để ghi log)
+func TraceIDFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	if sc := trace.SpanContextFromContext(ctx); sc.HasTraceID() {
+		return sc.TraceID().String()
+	}
+	return ""
+}
+
+// Config - Cấu hình tracing
+type Config struct {
+	ServiceName  string
+	Endpoint     string // Gốc OTLP/HTTP, vd: http://localhost:4318 ("" = không export)
+	Headers      map[string]string
+	SampleRatio  float64 // 0..1
+	BatchTimeout time.Duration
+}
+
+// Init cài TracerProvider và propagator toàn cục, trả về hàm shutdown (flush span còn lại)
+func Init(cfg Config) (func(context.Context) error, error) {
+	if cfg.ServiceName == "" {
+		cfg.ServiceName = "fpt-event-services"
+	}
+	if cfg.SampleRatio < 0 || cfg.SampleRatio > 1 {
+		return nil, fmt.Errorf("sample ratio must be between 0 and 1, got %v", cfg.SampleRatio)
+	}
+	if cfg.BatchTimeout <= 0 {
+		cfg.BatchTimeout = 5 * time.Second
+	}
+
+	opts := []sdktrace.TracerProviderOption{
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", cfg.ServiceName))),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+	}
+	if cfg.Endpoint != "" {
+		exporter, err := otlptracehttp.New(context.Background(),
+			otlptracehttp.WithEndpointURL(tracesURL(cfg.Endpoint)),
+			otlptracehttp.WithHeaders(cfg.Headers),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("create OTLP exporter: %w", err)
+		}
+		opts = append(opts, sdktrace.WithBatcher(exporter, sdktrace.WithBatchTimeout(cfg.BatchTimeout)))
+	}
+	tp := sdktrace.NewTracerProvider(opts...)
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagator)
+
+	return tp.Shutdown, nil
+}
+
+// tracesURL - Endpoint gốc (OTEL_EXPORTER_OTLP_ENDPOINT) thêm /v1/traces
+func tracesURL(endpoint string) string {
+	url := strings.TrimRight(endpoint, "/")
+	if !strings.HasSuffix(url, "/v1/traces") {
+		url += "/v1/traces"
+	}
+	return url
+}
+
+// ConfigFromEnv đọc cấu hình theo biến môi trường chuẩn của OpenTelemetry:
+// OTEL_SERVICE_NAME, OTEL_EXPORTER_OTLP_ENDPOINT (hoặc ..._TRACES_ENDPOINT),
+// OTEL_EXPORTER_OTLP_HEADERS ("k1=v1,k2=v2"), OTEL_TRACES_SAMPLER_ARG (0..1)
+func ConfigFromEnv() Config {
+	cfg := Config{
+		ServiceName: os.Getenv("OTEL_SERVICE_NAME"),
+		Endpoint:    os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT"),
+		SampleRatio: 1,
+	}
+	if cfg.Endpoint == "" {
+		cfg.Endpoint = os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT")
+	}
+	if v := os.Getenv("OTEL_TRACES_SAMPLER_ARG"); v != "" {
+		if ratio, err := strconv.ParseFloat(v, 64); err == nil {
+			cfg.SampleRatio = ratio
+		}
+	}
+	if v := os.Getenv("OTEL_EXPORTER_OTLP_HEADERS"); v != "" {
+		cfg.Headers = map[string]string{}
+		for _, pair := range strings.Split(v, ",") {
+			if k, val, ok := strings.Cut(pair, "="); ok {
+				cfg.Headers[strings.TrimSpace(k)] = strings.TrimSpace(val)
+			}
+		}
+	}
+	return cfg
+}
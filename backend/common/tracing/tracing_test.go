@@ -0,0 +1,163 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	collectortrace "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// useRecorder cài TracerProvider ghi span vào bộ nhớ cho test, khôi phục provider cũ sau test
+func useRecorder(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+	rec := tracetest.NewSpanRecorder()
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(rec)))
+	t.Cleanup(func() { otel.SetTracerProvider(prev) })
+	return rec
+}
+
+func TestTraceparentRoundTrip(t *testing.T) {
+	useRecorder(t)
+	const header = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+	ctx := ContextWithTraceparent(context.Background(), header)
+	if got := Traceparent(ctx); got != header {
+		t.Errorf("Traceparent = %q, want %q", got, header)
+	}
+
+	for _, bad := range []string{
+		"",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7",
+		"00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01",
+		"ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e473z-00f067aa0ba902b7-01",
+	} {
+		if got := Traceparent(ContextWithTraceparent(context.Background(), bad)); got != "" {
+			t.Errorf("traceparent %q should be ignored, got %q", bad, got)
+		}
+	}
+}
+
+func TestStartJoinsRemoteAndParentSpans(t *testing.T) {
+	rec := useRecorder(t)
+	header := http.Header{}
+	header.Set(TraceparentHeader, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+
+	ctx, root := Start(Extract(context.Background(), header), "GET /api/events")
+	_, child := Start(ctx, "db.select")
+	child.RecordError(errors.New("boom"))
+	child.End()
+	root.End()
+
+	if got := root.SpanContext().TraceID().String(); got != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("root trace id = %s, want trace id from traceparent", got)
+	}
+	if got := TraceIDFromContext(ctx); got != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("TraceIDFromContext = %q", got)
+	}
+
+	ended := map[string]sdktrace.ReadOnlySpan{}
+	for _, s := range rec.Ended() {
+		ended[s.Name()] = s
+	}
+	r, c := ended["GET /api/events"], ended["db.select"]
+	if r == nil || c == nil {
+		t.Fatalf("spans not recorded: %v", ended)
+	}
+	if r.Parent().SpanID().String() != "00f067aa0ba902b7" {
+		t.Errorf("root parent = %s, want remote span id", r.Parent().SpanID())
+	}
+	if c.Parent().SpanID() != r.SpanContext().SpanID() || c.SpanContext().TraceID() != r.SpanContext().TraceID() {
+		t.Error("child must be a child of the root span in the same trace")
+	}
+	if c.Status().Code != codes.Error || c.Status().Description != "boom" {
+		t.Errorf("child status = %+v, want ERROR boom", c.Status())
+	}
+
+	out := http.Header{}
+	Inject(ctx, out)
+	if want := "00-" + r.SpanContext().TraceID().String() + "-" + r.SpanContext().SpanID().String() + "-01"; out.Get(TraceparentHeader) != want {
+		t.Errorf("Inject wrote %q, want %q", out.Get(TraceparentHeader), want)
+	}
+}
+
+func TestExportOTLP(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		requests []*collectortrace.ExportTraceServiceRequest
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/traces" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		body, _ := io.ReadAll(r.Body)
+		req := &collectortrace.ExportTraceServiceRequest{}
+		if err := proto.Unmarshal(body, req); err != nil {
+			t.Errorf("invalid OTLP body: %v", err)
+		}
+		mu.Lock()
+		requests = append(requests, req)
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/x-protobuf")
+	}))
+	defer srv.Close()
+
+	prev := otel.GetTracerProvider()
+	defer otel.SetTracerProvider(prev)
+	shutdown, err := Init(Config{ServiceName: "test-service", Endpoint: srv.URL, SampleRatio: 1})
+	if err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+
+	ctx, parent := Start(context.Background(), "parent", WithKind(SpanKindServer))
+	_, child := Start(ctx, "child", WithAttributes(String("db.operation", "select"), Int("db.rows", 3)))
+	child.End()
+	parent.End()
+
+	if err := shutdown(context.Background()); err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(requests) == 0 {
+		t.Fatal("no spans exported")
+	}
+	rs := requests[0].GetResourceSpans()[0]
+	var service string
+	for _, kv := range rs.GetResource().GetAttributes() {
+		if kv.GetKey() == "service.name" {
+			service = kv.GetValue().GetStringValue()
+		}
+	}
+	if service != "test-service" {
+		t.Errorf("service.name = %q, want test-service", service)
+	}
+	names := map[string]bool{}
+	for _, ss := range rs.GetScopeSpans() {
+		for _, s := range ss.GetSpans() {
+			names[s.GetName()] = true
+		}
+	}
+	if !names["parent"] || !names["child"] {
+		t.Errorf("exported spans = %v, want parent and child", names)
+	}
+}
+
+func TestInitRejectsInvalidRatio(t *testing.T) {
+	if _, err := Init(Config{SampleRatio: 2}); err == nil {
+		t.Fatal("expected error for sample ratio > 1")
+	}
+}
@@ -0,0 +1,195 @@
+package validator
+
+import (
+	"fmt"
+	"html"
+	"net/url"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ============================================================
+// Sanitize nội dung do người dùng nhập (mô tả sự kiện, báo cáo, bio speaker)
+// - SanitizeText: văn bản thuần, bỏ toàn bộ thẻ HTML
+// - SanitizeRichText: HTML theo allow-list (định dạng cơ bản + link http/https/mailto)
+// Cả hai bỏ ký tự điều khiển / bidi override và chuẩn hóa xuống dòng.
+// Đầu ra JSON luôn qua json.Marshal (escape <, >, &); frontend hiển thị như text,
+// chỉ render HTML với nội dung đã qua SanitizeRichText.
+// ============================================================
+
+// Độ dài tối đa (tính theo ký tự) sau khi sanitize
+const (
+	MaxEventTitleLength        = 200
+	MaxEventDescriptionLength  = 10000
+	MaxReportTitleLength       = 200
+	MaxReportDescriptionLength = 2000
+	MaxSpeakerBioLength        = 2000
+)
+
+var (
+	// Khối script/style/... bị bỏ cả nội dung
+	dangerousBlockPattern = regexp.MustCompile(`(?is)<(script|style|iframe|object|embed|template|noscript|svg|math)\b[^>]*>.*?</\s*(script|style|iframe|object|embed|template|noscript|svg|math)\s*>`)
+	commentPattern        = regexp.MustCompile(`(?s)<!--.*?-->`)
+	tagPattern            = regexp.MustCompile(`</?[a-zA-Z][^>]*>`)
+	richTagPattern        = regexp.MustCompile(`<(/?)([a-zA-Z][a-zA-Z0-9]*)([^>]*)>`)
+	hrefPattern           = regexp.MustCompile(`(?i)\bhref\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s"'>]+))`)
+)
+
+// richAllowedTags - Thẻ được giữ trong SanitizeRichText (không thuộc tính, trừ href của <a>)
+var richAllowedTags = map[string]bool{
+	"p": true, "br": true, "b": true, "strong": true, "i": true, "em": true, "u": true, "s": true,
+	"ul": true, "ol": true, "li": true, "h2": true, "h3": true, "h4": true, "blockquote": true, "a": true,
+}
+
+// richVoidTags - Thẻ không có thẻ đóng
+var richVoidTags = map[string]bool{"br": true}
+
+// SanitizeText - Văn bản thuần: bỏ thẻ HTML, ký tự điều khiển, khoảng trắng thừa ở hai đầu
+func SanitizeText(s string) string {
+	s = dangerousBlockPattern.ReplaceAllString(s, "")
+	s = commentPattern.ReplaceAllString(s, "")
+	s = tagPattern.ReplaceAllString(s, "")
+	return strings.TrimSpace(stripControlChars(s))
+}
+
+// SanitizeRichText - HTML theo allow-list; thẻ khác bị bỏ (giữ nội dung chữ),
+// mọi thuộc tính bị bỏ, thẻ được cân bằng lại và chữ được escape
+func SanitizeRichText(s string) string {
+	s = dangerousBlockPattern.ReplaceAllString(stripControlChars(s), "")
+	s = commentPattern.ReplaceAllString(s, "")
+
+	var b strings.Builder
+	var open []string
+	last := 0
+	for _, m := range richTagPattern.FindAllStringSubmatchIndex(s, -1) {
+		b.WriteString(escapeText(s[last:m[0]]))
+		last = m[1]
+
+		closing := m[3] > m[2]
+		name := strings.ToLower(s[m[4]:m[5]])
+		if !richAllowedTags[name] {
+			continue
+		}
+		if richVoidTags[name] {
+			if !closing {
+				b.WriteString("<" + name + ">")
+			}
+			continue
+		}
+		if !closing {
+			if name == "a" {
+				href, ok := safeHref(s[m[6]:m[7]])
+				if !ok {
+					// Link không an toàn: giữ chữ, bỏ thẻ (cả thẻ đóng tương ứng)
+					open = append(open, "")
+					continue
+				}
+				b.WriteString(`<a href="` + html.EscapeString(href) + `" rel="nofollow noopener noreferrer">`)
+			} else {
+				b.WriteString("<" + name + ">")
+			}
+			open = append(open, name)
+			continue
+		}
+		// Thẻ đóng: chỉ nhận khi khớp thẻ đang mở gần nhất cùng tên
+		for i := len(open) - 1; i >= 0; i-- {
+			if open[i] == name || (name == "a" && open[i] == "") {
+				for j := len(open) - 1; j >= i; j-- {
+					if open[j] != "" {
+						b.WriteString("</" + open[j] + ">")
+					}
+				}
+				open = open[:i]
+				break
+			}
+		}
+	}
+	b.WriteString(escapeText(s[last:]))
+	for i := len(open) - 1; i >= 0; i-- {
+		if open[i] != "" {
+			b.WriteString("</" + open[i] + ">")
+		}
+	}
+	return strings.TrimSpace(b.String())
+}
+
+// SanitizeOptionalText / SanitizeOptionalRichText - Bản cho trường *string; chuỗi rỗng sau sanitize => nil
+func SanitizeOptionalText(s *string) *string {
+	if s == nil {
+		return nil
+	}
+	v := SanitizeText(*s)
+	if v == "" {
+		return nil
+	}
+	return &v
+}
+
+func SanitizeOptionalRichText(s *string) *string {
+	if s == nil {
+		return nil
+	}
+	v := SanitizeRichText(*s)
+	if v == "" {
+		return nil
+	}
+	return &v
+}
+
+// GetLengthError returns user-friendly error message khi nội dung vượt quá max ký tự
+func GetLengthError(label, s string, max int) string {
+	if utf8.RuneCountInString(s) > max {
+		return fmt.Sprintf("%s không được vượt quá %d ký tự", label, max)
+	}
+	return ""
+}
+
+// escapeText - Chuẩn hóa entity rồi escape lại: "&amp;lt;" giữ nguyên nghĩa, "<" lẻ thành "&lt;"
+func escapeText(s string) string {
+	return html.EscapeString(html.UnescapeString(s))
+}
+
+// safeHref - Chỉ nhận URL tuyệt đối http/https hoặc mailto
+func safeHref(attrs string) (string, bool) {
+	m := hrefPattern.FindStringSubmatch(attrs)
+	if m == nil {
+		return "", false
+	}
+	raw := strings.TrimSpace(html.UnescapeString(m[1] + m[2] + m[3]))
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "", false
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "http", "https":
+		if u.Host == "" {
+			return "", false
+		}
+	case "mailto":
+	default:
+		return "", false
+	}
+	return u.String(), true
+}
+
+// stripControlChars - Bỏ byte UTF-8 lỗi, ký tự điều khiển (giữ \n, \t) và bidi override
+// \r\n, \r được chuẩn hóa thành \n
+func stripControlChars(s string) string {
+	s = strings.ToValidUTF8(s, "")
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r == '\r':
+			return '\n'
+		case r == '\n' || r == '\t':
+			return r
+		case unicode.IsControl(r):
+			return -1
+		case (r >= 0x202A && r <= 0x202E) || (r >= 0x2066 && r <= 0x2069) || r == 0xFEFF:
+			return -1
+		}
+		return r
+	}, s)
+}
@@ -0,0 +1,68 @@
+package validator
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSanitizeText(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"  Máy chiếu hỏng  ", "Máy chiếu hỏng"},
+		{"<b>Vé</b> bị lỗi<script>alert(1)</script>", "Vé bị lỗi"},
+		{"Dòng 1\r\nDòng 2\rDòng 3", "Dòng 1\nDòng 2\nDòng 3"},
+		{"a\x00b\x1bc‮d", "abcd"},
+		{"2 < 3 và 5 > 4", "2 < 3 và 5 > 4"},
+		{"<!-- ẩn -->hiện", "hiện"},
+		{"<img src=x onerror=alert(1)>", ""},
+	}
+	for _, tt := range tests {
+		if got := SanitizeText(tt.in); got != tt.want {
+			t.Errorf("SanitizeText(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestSanitizeRichText(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"<p>Hội thảo <strong>AI</strong></p>", "<p>Hội thảo <strong>AI</strong></p>"},
+		{`<P class="x" onclick="evil()">Xin chào</P>`, "<p>Xin chào</p>"},
+		{"<script>alert(1)</script><p>ok</p>", "<p>ok</p>"},
+		{`<a href="https://fpt.edu.vn/a?b=1&amp;c=2" target="_blank">link</a>`,
+			`<a href="https://fpt.edu.vn/a?b=1&amp;c=2" rel="nofollow noopener noreferrer">link</a>`},
+		{`<a href="javascript:alert(1)">bấm</a>`, "bấm"},
+		{`<a href=" JaVaScRiPt:alert(1)">x</a>`, "x"},
+		{"<img src=x onerror=alert(1)><div>nội dung</div>", "nội dung"},
+		{"<p><b>chưa đóng", "<p><b>chưa đóng</b></p>"},
+		{"</p>thẻ đóng lẻ<br/>xuống dòng", "thẻ đóng lẻ<br>xuống dòng"},
+		{"1 < 2 &amp; 3 > 2", "1 &lt; 2 &amp; 3 &gt; 2"},
+		{"<p><i>sai thứ tự</p></i>", "<p><i>sai thứ tự</i></p>"},
+	}
+	for _, tt := range tests {
+		if got := SanitizeRichText(tt.in); got != tt.want {
+			t.Errorf("SanitizeRichText(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestSanitizeOptional(t *testing.T) {
+	if SanitizeOptionalText(nil) != nil {
+		t.Fatal("nil must stay nil")
+	}
+	empty := "<script>x</script>  "
+	if SanitizeOptionalRichText(&empty) != nil {
+		t.Fatal("empty result must become nil")
+	}
+}
+
+func TestGetLengthError(t *testing.T) {
+	if msg := GetLengthError("Mô tả", strings.Repeat("ă", 10), 10); msg != "" {
+		t.Fatalf("10 runes must fit in 10, got %q", msg)
+	}
+	if msg := GetLengthError("Mô tả", strings.Repeat("ă", 11), 10); msg == "" {
+		t.Fatal("11 runes must exceed 10")
+	}
+}
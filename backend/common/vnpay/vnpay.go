@@ -4,14 +4,28 @@ import (
 	"crypto/hmac"
 	"crypto/sha512"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"net/url"
 	"os"
 	"sort"
 	"strings"
 	"time"
+
+	"github.com/fpt-event-services/common/metrics"
+)
+
+// Lỗi xác thực callback: request không do VNPay ký, không được tin amount / response code
+var (
+	ErrMissingSignature = errors.New("vnpay: missing vnp_SecureHash")
+	ErrInvalidSignature = errors.New("vnpay: invalid signature")
+	ErrInvalidTmnCode   = errors.New("vnpay: invalid vnp_TmnCode")
 )
 
+// signatureFailures - Cảnh báo: callback bị từ chối (giả mạo hoặc sai VNPAY_HASH_SECRET)
+var signatureFailures = metrics.NewCounter("vnpay_signature_failures_total",
+	"VNPay callbacks rejected by signature verification, by reason (missing, mismatch, tmn_code).", "reason")
+
 // Config holds VNPay configuration
 type Config struct {
 	TmnCode    string // Terminal ID
@@ -178,7 +192,8 @@ func (s *VNPayService) CreatePaymentURL(req PaymentRequest) (string, error) {
 }
 
 // VerifyCallback verifies the callback signature from VNPay
-// Returns (isValid, parsedResponse, error)
+// Băm lại toàn bộ tham số vnp_* của query (trừ vnp_SecureHash / vnp_SecureHashType)
+// Lỗi ErrMissingSignature / ErrInvalidSignature / ErrInvalidTmnCode: phải từ chối callback
 func (s *VNPayService) VerifyCallback(queryParams url.Values) (*PaymentResponse, error) {
 	response := &PaymentResponse{
 		TmnCode:       queryParams.Get("vnp_TmnCode"),
@@ -197,7 +212,8 @@ func (s *VNPayService) VerifyCallback(queryParams url.Values) (*PaymentResponse,
 	// Extract received hash
 	receivedHash := response.SecureHash
 	if receivedHash == "" {
-		return response, fmt.Errorf("missing vnp_SecureHash")
+		signatureFailures.Inc("missing")
+		return response, ErrMissingSignature
 	}
 
 	// CRITICAL: Rebuild parameters for verification
@@ -205,7 +221,7 @@ func (s *VNPayService) VerifyCallback(queryParams url.Values) (*PaymentResponse,
 	// Lý do: Chỉ băm các tham số bắt đầu bằng vnp_ (trừ 2 tham số trên)
 	params := make(map[string]string)
 	for key := range queryParams {
-		if key != "vnp_SecureHash" && key != "vnp_SecureHashType" {
+		if strings.HasPrefix(key, "vnp_") && key != "vnp_SecureHash" && key != "vnp_SecureHashType" {
 			params[key] = queryParams.Get(key)
 		}
 	}
@@ -228,12 +244,14 @@ func (s *VNPayService) VerifyCallback(queryParams url.Values) (*PaymentResponse,
 
 	// Constant-time comparison to prevent timing attacks
 	if !hmac.Equal([]byte(strings.ToUpper(receivedHash)), []byte(strings.ToUpper(expectedHash))) {
-		return response, fmt.Errorf("invalid signature: hash mismatch")
+		signatureFailures.Inc("mismatch")
+		return response, ErrInvalidSignature
 	}
 
 	// Verify TmnCode matches
 	if response.TmnCode != s.config.TmnCode {
-		return response, fmt.Errorf("invalid TmnCode")
+		signatureFailures.Inc("tmn_code")
+		return response, ErrInvalidTmnCode
 	}
 
 	// Check response code
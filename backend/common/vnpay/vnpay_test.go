@@ -1,7 +1,9 @@
 package vnpay
 
 import (
+	"bytes"
 	"crypto/hmac"
+	"errors"
 	"net/url"
 	"strings"
 	"testing"
@@ -275,7 +277,7 @@ func TestSignatureChecklist(t *testing.T) {
 		}
 	})
 
-	t.Run("3. Test amount for VND currency (NO multiply by 100)", func(t *testing.T) {
+	t.Run("3. Test amount for VND currency (multiply by 100)", func(t *testing.T) {
 		req := PaymentRequest{
 			OrderInfo: "Test order",
 			Amount:    150000, // 150,000 VND
@@ -288,15 +290,9 @@ func TestSignatureChecklist(t *testing.T) {
 			t.Fatalf("CreatePaymentURL error: %v", err)
 		}
 
-		// ⭐ CRITICAL FIX: VND không nhân 100! VND chỉ có đơn vị đồng, không có cent
-		// Amount sau khi format: 150000 (không nhân 100)
-		if !strings.Contains(url, "vnp_Amount=150000") {
-			t.Errorf("Amount should NOT be multiplied by 100 for VND (URL: %s)", url)
-		}
-		
-		// Kiểm tra KHÔNG có giá trị nhân 100
-		if strings.Contains(url, "vnp_Amount=15000000") {
-			t.Error("❌ WRONG: Amount is multiplied by 100! This causes 100x price bug!")
+		// VNPay yêu cầu vnp_Amount theo đơn vị nhỏ nhất: 150.000đ → 15000000
+		if !strings.Contains(url, "vnp_Amount=15000000&") {
+			t.Errorf("Amount must be multiplied by 100 for VNPay (URL: %s)", url)
 		}
 	})
 
@@ -395,3 +391,85 @@ func TestSignatureChecklist(t *testing.T) {
 		}
 	})
 }
+
+// Return URL mẫu do VNPay ký (secret FIXTURESECRET0123456789ABCDEFGHIJ), hash tính độc lập:
+// HMAC-SHA512 của các tham số vnp_* đã sắp xếp, giá trị URL-encode, viết HOA
+const (
+	fixtureSecret = "FIXTURESECRET0123456789ABCDEFGHIJ"
+	fixtureQuery  = "vnp_Amount=30000000&vnp_BankCode=NCB&vnp_BankTranNo=VNP14226112&vnp_CardType=ATM" +
+		"&vnp_OrderInfo=Payment+for+Hoi+thao+AI+-+2+seats&vnp_PayDate=20240315103000&vnp_ResponseCode=00" +
+		"&vnp_TmnCode=FPTEVT01&vnp_TransactionNo=14226112&vnp_TransactionStatus=00" +
+		"&vnp_TxnRef=7_3_12_101%2C102_1710473400000&vnp_SecureHashType=HmacSHA512" +
+		"&vnp_SecureHash=FAE38CA71754D996B582CA66EC5BABB908035F3DEBB5E4096D85F71FAEF345A048BDA3F365C6CEAF47B36E0304B44B2A62E7E1AC0C65419BDD1268230EA16E5F"
+)
+
+func fixtureService() *VNPayService {
+	return NewVNPayService(&Config{TmnCode: "FPTEVT01", HashSecret: fixtureSecret})
+}
+
+func fixtureParams(t *testing.T) url.Values {
+	t.Helper()
+	values, err := url.ParseQuery(fixtureQuery)
+	if err != nil {
+		t.Fatalf("parse fixture: %v", err)
+	}
+	return values
+}
+
+func TestVerifyCallbackFixture(t *testing.T) {
+	values := fixtureParams(t)
+	// Tham số không phải vnp_* (vd: do frontend gắn thêm) không nằm trong chữ ký
+	values.Set("utm_source", "email")
+
+	resp, err := fixtureService().VerifyCallback(values)
+	if err != nil {
+		t.Fatalf("VerifyCallback(fixture) error = %v", err)
+	}
+	if !resp.IsSuccess || resp.Amount != "30000000" || resp.TxnRef != "7_3_12_101,102_1710473400000" {
+		t.Errorf("unexpected response %+v", resp)
+	}
+
+	// Chữ ký không phân biệt hoa thường
+	values.Set("vnp_SecureHash", strings.ToLower(values.Get("vnp_SecureHash")))
+	if _, err := fixtureService().VerifyCallback(values); err != nil {
+		t.Errorf("lowercase hash rejected: %v", err)
+	}
+}
+
+func TestVerifyCallbackRejectsTampering(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(url.Values)
+		want   error
+	}{
+		{"amount changed", func(v url.Values) { v.Set("vnp_Amount", "100") }, ErrInvalidSignature},
+		{"failed payment turned into success", func(v url.Values) { v.Set("vnp_ResponseCode", "24") }, ErrInvalidSignature},
+		{"txn ref swapped", func(v url.Values) { v.Set("vnp_TxnRef", "8_3_12_103_1710473400000") }, ErrInvalidSignature},
+		{"extra vnp param", func(v url.Values) { v.Set("vnp_Bonus", "1") }, ErrInvalidSignature},
+		{"missing hash", func(v url.Values) { v.Del("vnp_SecureHash") }, ErrMissingSignature},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values := fixtureParams(t)
+			tt.mutate(values)
+			if _, err := fixtureService().VerifyCallback(values); !errors.Is(err, tt.want) {
+				t.Fatalf("VerifyCallback() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+
+	// Đúng chữ ký nhưng khác terminal
+	other := NewVNPayService(&Config{TmnCode: "OTHER", HashSecret: fixtureSecret})
+	if _, err := other.VerifyCallback(fixtureParams(t)); !errors.Is(err, ErrInvalidTmnCode) {
+		t.Fatalf("VerifyCallback() error = %v, want ErrInvalidTmnCode", err)
+	}
+
+	var buf bytes.Buffer
+	signatureFailures.WriteText(&buf)
+	for _, reason := range []string{`reason="mismatch"`, `reason="missing"`, `reason="tmn_code"`} {
+		if !strings.Contains(buf.String(), reason) {
+			t.Errorf("vnpay_signature_failures_total missing %s:\n%s", reason, buf.String())
+		}
+	}
+}
@@ -0,0 +1,265 @@
+package waitingroom
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/fpt-event-services/common/db"
+)
+
+// ============================================================
+// WAITINGROOM - Phòng chờ ảo cho sự kiện mở bán dạng flash sale (event.booking_queue, migration 058)
+// User lấy queue token và đứng chờ theo thứ tự đến; dispatcher mỗi Interval cho tối đa
+// queue_admit_per_interval người của mỗi sự kiện vào bước giữ ghế. Người được vào giữ quyền
+// đặt vé trong AdmitWindow; request đặt vé của người chưa được vào chỉ nhận vị trí hàng chờ,
+// nên MySQL chỉ nhận lượng giữ ghế đã định liều thay vì cả đám đông cùng lúc.
+// Hàng chờ nằm trong bảng booking_queue_entry (migration 059) nên mọi instance API / Lambda
+// thấy cùng một hàng; Tick chạy trong job "booking-queue-dispatch" của scheduler (GET_LOCK),
+// mỗi nhịp chỉ một instance cho người vào nên giới hạn mỗi nhịp giữ đúng khi chạy nhiều instance.
+// Mốc thời gian lấy theo NOW(6) của MySQL, không phụ thuộc đồng hồ từng instance.
+// Cấu hình: BOOKING_QUEUE_INTERVAL_SECONDS (5), BOOKING_QUEUE_ADMIT_MINUTES (10),
+// BOOKING_QUEUE_IDLE_SECONDS (60)
+// ============================================================
+
+// Trạng thái của một người trong hàng chờ
+const (
+	StatusWaiting  = "WAITING"
+	StatusAdmitted = "ADMITTED"
+)
+
+// ErrUnknownToken - Token không có trong hàng chờ (sai, hết hạn vào hoặc bị bỏ vì không hỏi trạng thái)
+var ErrUnknownToken = errors.New("queue token not found or expired")
+
+// Config - Nhịp dispatcher và các mốc hết hạn
+type Config struct {
+	Interval    time.Duration // Nhịp cho người vào
+	AdmitWindow time.Duration // Thời gian người được vào giữ quyền đặt vé
+	IdleTimeout time.Duration // Người chờ không hỏi trạng thái quá lâu bị bỏ khỏi hàng
+}
+
+// ConfigFromEnv đọc cấu hình từ biến môi trường
+func ConfigFromEnv() Config {
+	return Config{
+		Interval:    time.Duration(envInt("BOOKING_QUEUE_INTERVAL_SECONDS", 5)) * time.Second,
+		AdmitWindow: time.Duration(envInt("BOOKING_QUEUE_ADMIT_MINUTES", 10)) * time.Minute,
+		IdleTimeout: time.Duration(envInt("BOOKING_QUEUE_IDLE_SECONDS", 60)) * time.Second,
+	}
+}
+
+// Position - Trạng thái của một người trong hàng chờ
+type Position struct {
+	Token         string
+	Status        string
+	Position      int           // 1 = người kế tiếp được vào; 0 khi đã ADMITTED
+	EstimatedWait time.Duration // Ước lượng theo nhịp dispatcher
+	AdmittedUntil time.Time     // Chỉ có khi ADMITTED
+}
+
+// Room - Hàng chờ của mọi sự kiện đang bật booking queue
+type Room struct {
+	cfg Config
+	db  *sql.DB
+}
+
+// New creates a room stored in conn
+func New(conn *sql.DB, cfg Config) *Room {
+	return &Room{cfg: cfg, db: conn}
+}
+
+// Default - Phòng chờ dùng chung của process; chỉ gọi sau khi đã kết nối DB
+var Default = sync.OnceValue(func() *Room { return New(db.GetDB(), ConfigFromEnv()) })
+
+// positionSQL - Trạng thái của một entry; vị trí = số người đang chờ đến trước hoặc cùng entry
+// Entry đã hết hạn vào coi như không còn (dispatcher sẽ xóa ở nhịp sau)
+const positionSQL = `
+	SELECT q.token, q.admitted_until, GREATEST(e.queue_admit_per_interval, 1),
+	       (SELECT COUNT(*) FROM booking_queue_entry w
+	        WHERE w.event_id = q.event_id AND w.admitted_until IS NULL AND w.entry_id <= q.entry_id)
+	FROM booking_queue_entry q
+	JOIN Event e ON e.event_id = q.event_id
+	WHERE q.event_id = ? AND (q.admitted_until IS NULL OR q.admitted_until > NOW(6))`
+
+// Join - Vào hàng chờ của sự kiện; gọi lại trả về chỗ cũ (kể cả khi đã được vào)
+// Người đã hết thời gian được vào xếp lại cuối hàng với token mới
+func (r *Room) Join(ctx context.Context, eventID, userID int) (Position, error) {
+	if _, err := r.db.ExecContext(ctx,
+		`DELETE FROM booking_queue_entry WHERE event_id = ? AND user_id = ? AND admitted_until <= NOW(6)`,
+		eventID, userID); err != nil {
+		return Position{}, fmt.Errorf("drop expired queue entry: %w", err)
+	}
+	if _, err := r.db.ExecContext(ctx, `
+		INSERT INTO booking_queue_entry (event_id, user_id, token, joined_at, last_seen_at)
+		VALUES (?, ?, ?, NOW(6), NOW(6))
+		ON DUPLICATE KEY UPDATE last_seen_at = NOW(6)`,
+		eventID, userID, newToken()); err != nil {
+		return Position{}, fmt.Errorf("join booking queue: %w", err)
+	}
+	pos, err := r.position(ctx, positionSQL+` AND q.user_id = ?`, eventID, userID)
+	if errors.Is(err, ErrUnknownToken) {
+		// Vừa ghi xong mà không đọc lại được: sự kiện bị xóa giữa chừng
+		return Position{}, fmt.Errorf("join booking queue: entry of user %d disappeared", userID)
+	}
+	return pos, err
+}
+
+// Status - Trạng thái theo queue token; mỗi lần hỏi giữ chỗ khỏi bị bỏ vì IdleTimeout
+func (r *Room) Status(ctx context.Context, eventID int, token string) (Position, error) {
+	if _, err := r.db.ExecContext(ctx,
+		`UPDATE booking_queue_entry SET last_seen_at = NOW(6) WHERE event_id = ? AND token = ?`,
+		eventID, token); err != nil {
+		return Position{}, fmt.Errorf("touch queue entry: %w", err)
+	}
+	return r.position(ctx, positionSQL+` AND q.token = ?`, eventID, token)
+}
+
+// Admitted - User đang trong thời gian được đặt vé của sự kiện
+func (r *Room) Admitted(ctx context.Context, eventID, userID int) (bool, error) {
+	var ok bool
+	err := r.db.QueryRowContext(ctx, `
+		SELECT EXISTS (SELECT 1 FROM booking_queue_entry
+		               WHERE event_id = ? AND user_id = ? AND admitted_until > NOW(6))`,
+		eventID, userID).Scan(&ok)
+	if err != nil {
+		return false, fmt.Errorf("check queue admission: %w", err)
+	}
+	return ok, nil
+}
+
+// Stats - Số người đang chờ và đang được đặt vé của sự kiện
+func (r *Room) Stats(ctx context.Context, eventID int) (waiting, admitted int, err error) {
+	err = r.db.QueryRowContext(ctx, `
+		SELECT COALESCE(SUM(admitted_until IS NULL), 0), COALESCE(SUM(admitted_until > NOW(6)), 0)
+		FROM booking_queue_entry WHERE event_id = ?`, eventID).Scan(&waiting, &admitted)
+	if err != nil {
+		return 0, 0, fmt.Errorf("count booking queue: %w", err)
+	}
+	return waiting, admitted, nil
+}
+
+// Tick - Một nhịp dispatcher: bỏ người hết hạn được vào / không còn hỏi trạng thái,
+// rồi cho tối đa queue_admit_per_interval người đầu hàng của mỗi sự kiện vào;
+// trả về số người vừa được vào. Chỉ chạy từ một nơi mỗi nhịp (job booking-queue-dispatch)
+func (r *Room) Tick(ctx context.Context) (int, error) {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM booking_queue_entry WHERE admitted_until <= NOW(6)`); err != nil {
+		return 0, fmt.Errorf("drop expired admissions: %w", err)
+	}
+	if _, err := r.db.ExecContext(ctx, `
+		DELETE FROM booking_queue_entry
+		WHERE admitted_until IS NULL AND last_seen_at < NOW(6) - INTERVAL ? SECOND`,
+		int(r.cfg.IdleTimeout/time.Second)); err != nil {
+		return 0, fmt.Errorf("drop idle queue entries: %w", err)
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT e.event_id, GREATEST(e.queue_admit_per_interval, 1)
+		FROM Event e
+		WHERE EXISTS (SELECT 1 FROM booking_queue_entry q WHERE q.event_id = e.event_id AND q.admitted_until IS NULL)`)
+	if err != nil {
+		return 0, fmt.Errorf("list waiting events: %w", err)
+	}
+	type batch struct{ eventID, size int }
+	var batches []batch
+	for rows.Next() {
+		var b batch
+		if err := rows.Scan(&b.eventID, &b.size); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("scan waiting event: %w", err)
+		}
+		batches = append(batches, b)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("list waiting events: %w", err)
+	}
+
+	total := 0
+	for _, b := range batches {
+		result, err := r.db.ExecContext(ctx, `
+			UPDATE booking_queue_entry
+			SET admitted_until = NOW(6) + INTERVAL ? SECOND
+			WHERE event_id = ? AND admitted_until IS NULL
+			ORDER BY entry_id
+			LIMIT ?`,
+			int(r.cfg.AdmitWindow/time.Second), b.eventID, b.size)
+		if err != nil {
+			return total, fmt.Errorf("admit queue of event %d: %w", b.eventID, err)
+		}
+		n, _ := result.RowsAffected()
+		total += int(n)
+	}
+	return total, nil
+}
+
+// Interval - Nhịp dispatcher (client dùng làm chu kỳ hỏi trạng thái)
+func (r *Room) Interval() time.Duration { return r.cfg.Interval }
+
+// position đọc một entry theo query (positionSQL + điều kiện), ErrUnknownToken nếu không có
+func (r *Room) position(ctx context.Context, query string, args ...any) (Position, error) {
+	var (
+		p             Position
+		admittedUntil sql.NullTime
+		perInterval   int
+	)
+	err := r.db.QueryRowContext(ctx, query, args...).Scan(&p.Token, &admittedUntil, &perInterval, &p.Position)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Position{}, ErrUnknownToken
+	}
+	if err != nil {
+		return Position{}, fmt.Errorf("read queue position: %w", err)
+	}
+	if admittedUntil.Valid {
+		return Position{Token: p.Token, Status: StatusAdmitted, AdmittedUntil: admittedUntil.Time}, nil
+	}
+	p.Status = StatusWaiting
+	p.EstimatedWait = estimatedWait(p.Position, perInterval, r.cfg.Interval)
+	return p, nil
+}
+
+// estimatedWait - Số nhịp dispatcher cần tới lượt người ở vị trí pos
+func estimatedWait(pos, perInterval int, interval time.Duration) time.Duration {
+	ticks := (pos + perInterval - 1) / perInterval
+	return time.Duration(ticks) * interval
+}
+
+// Join - Vào hàng chờ trên Default room
+func Join(ctx context.Context, eventID, userID int) (Position, error) {
+	return Default().Join(ctx, eventID, userID)
+}
+
+// Status - Trạng thái theo token trên Default room
+func Status(ctx context.Context, eventID int, token string) (Position, error) {
+	return Default().Status(ctx, eventID, token)
+}
+
+// Admitted - User được đặt vé trên Default room
+func Admitted(ctx context.Context, eventID, userID int) (bool, error) {
+	return Default().Admitted(ctx, eventID, userID)
+}
+
+// Stats - Số người chờ / được vào trên Default room
+func Stats(ctx context.Context, eventID int) (waiting, admitted int, err error) {
+	return Default().Stats(ctx, eventID)
+}
+
+func newToken() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic("waitingroom: cannot read random token: " + err.Error())
+	}
+	return hex.EncodeToString(b)
+}
+
+func envInt(key string, defaultValue int) int {
+	if n, err := strconv.Atoi(os.Getenv(key)); err == nil && n > 0 {
+		return n
+	}
+	return defaultValue
+}
@@ -0,0 +1,32 @@
+package waitingroom
+
+import (
+	"testing"
+	"time"
+)
+
+// Hành vi hàng chờ trên MySQL (thứ tự vào, hết hạn) nằm ở integration/booking_queue_test.go
+func TestEstimatedWait(t *testing.T) {
+	for _, tc := range []struct {
+		pos, perInterval int
+		want             time.Duration
+	}{
+		{1, 2, 5 * time.Second},
+		{2, 2, 5 * time.Second},
+		{3, 2, 10 * time.Second},
+		{101, 50, 15 * time.Second},
+	} {
+		if got := estimatedWait(tc.pos, tc.perInterval, 5*time.Second); got != tc.want {
+			t.Errorf("estimatedWait(%d, %d) = %v, want %v", tc.pos, tc.perInterval, got, tc.want)
+		}
+	}
+}
+
+func TestConfigFromEnv(t *testing.T) {
+	t.Setenv("BOOKING_QUEUE_INTERVAL_SECONDS", "2")
+	t.Setenv("BOOKING_QUEUE_ADMIT_MINUTES", "bad")
+	cfg := ConfigFromEnv()
+	if cfg.Interval != 2*time.Second || cfg.AdmitWindow != 10*time.Minute || cfg.IdleTimeout != time.Minute {
+		t.Errorf("ConfigFromEnv = %+v", cfg)
+	}
+}
@@ -0,0 +1,98 @@
+//go:build integration
+
+package integration
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fpt-event-services/common/waitingroom"
+)
+
+// newTestRoom - Hàng chờ trên DB của suite cho một sự kiện seed, dọn entry sau test
+func newTestRoom(t *testing.T, admitPerInterval int, cfg waitingroom.Config) (*waitingroom.Room, int) {
+	t.Helper()
+	ids := seededIDs(t)
+	eventID := ids.EventIDs["open"]
+	before := queryInt(t, `SELECT queue_admit_per_interval FROM Event WHERE event_id = ?`, eventID)
+	mustExec(t, `UPDATE Event SET queue_admit_per_interval = ? WHERE event_id = ?`, admitPerInterval, eventID)
+	t.Cleanup(func() {
+		suite.db.Exec(`DELETE FROM booking_queue_entry WHERE event_id = ?`, eventID)
+		suite.db.Exec(`UPDATE Event SET queue_admit_per_interval = ? WHERE event_id = ?`, before, eventID)
+	})
+	return waitingroom.New(suite.db, cfg), eventID
+}
+
+// queueUsers - Ba user seed khác nhau để xếp hàng
+func queueUsers(t *testing.T) (int, int, int) {
+	ids := seededIDs(t)
+	return ids.UserIDs["student1"], ids.UserIDs["student2"], ids.UserIDs["organizer"]
+}
+
+// TestBookingQueueAdmitsInOrder - Dispatcher cho người vào theo thứ tự đến, mỗi nhịp admitPerInterval người
+func TestBookingQueueAdmitsInOrder(t *testing.T) {
+	ctx := t.Context()
+	r, eventID := newTestRoom(t, 2, waitingroom.Config{Interval: 5 * time.Second, AdmitWindow: 10 * time.Minute, IdleTimeout: time.Minute})
+	u1, u2, u3 := queueUsers(t)
+
+	first, err := r.Join(ctx, eventID, u1)
+	if err != nil {
+		t.Fatalf("Join: %v", err)
+	}
+	r.Join(ctx, eventID, u2)
+	third, _ := r.Join(ctx, eventID, u3)
+	if third.Status != waitingroom.StatusWaiting || third.Position != 3 || third.EstimatedWait != 10*time.Second {
+		t.Fatalf("third = %+v", third)
+	}
+	if again, _ := r.Join(ctx, eventID, u1); again.Token != first.Token || again.Position != 1 {
+		t.Errorf("rejoin = %+v, want same token at position 1", again)
+	}
+
+	if n, err := r.Tick(ctx); err != nil || n != 2 {
+		t.Fatalf("Tick = %d, %v; want 2 admitted", n, err)
+	}
+	for user, want := range map[int]bool{u1: true, u2: true, u3: false} {
+		if ok, err := r.Admitted(ctx, eventID, user); err != nil || ok != want {
+			t.Errorf("Admitted(%d) = %v, %v; want %v", user, ok, err, want)
+		}
+	}
+	if p, err := r.Status(ctx, eventID, third.Token); err != nil || p.Position != 1 {
+		t.Errorf("third after tick = %+v, %v", p, err)
+	}
+	if p, _ := r.Status(ctx, eventID, first.Token); p.Status != waitingroom.StatusAdmitted || p.AdmittedUntil.IsZero() {
+		t.Errorf("first after tick = %+v", p)
+	}
+	if waiting, admitted, err := r.Stats(ctx, eventID); err != nil || waiting != 1 || admitted != 2 {
+		t.Errorf("Stats = %d, %d, %v", waiting, admitted, err)
+	}
+}
+
+// TestBookingQueueExpiresEntries - Người không hỏi trạng thái bị bỏ, quyền đặt vé hết hạn theo AdmitWindow
+func TestBookingQueueExpiresEntries(t *testing.T) {
+	ctx := t.Context()
+	r, eventID := newTestRoom(t, 1, waitingroom.Config{Interval: time.Second, AdmitWindow: time.Second, IdleTimeout: time.Second})
+	u1, u2, _ := queueUsers(t)
+
+	admitted, _ := r.Join(ctx, eventID, u1)
+	idle, _ := r.Join(ctx, eventID, u2)
+	if n, err := r.Tick(ctx); err != nil || n != 1 {
+		t.Fatalf("Tick = %d, %v; want 1 admitted", n, err)
+	}
+
+	time.Sleep(1500 * time.Millisecond) // u2 không hỏi trạng thái quá IdleTimeout, u1 hết AdmitWindow
+	if ok, _ := r.Admitted(ctx, eventID, u1); ok {
+		t.Error("admission should have expired")
+	}
+	if _, err := r.Tick(ctx); err != nil {
+		t.Fatalf("Tick: %v", err)
+	}
+	for name, token := range map[string]string{"idle": idle.Token, "expired admission": admitted.Token} {
+		if _, err := r.Status(ctx, eventID, token); !errors.Is(err, waitingroom.ErrUnknownToken) {
+			t.Errorf("%s entry: got %v, want ErrUnknownToken", name, err)
+		}
+	}
+	if n := queryInt(t, `SELECT COUNT(*) FROM booking_queue_entry WHERE event_id = ?`, eventID); n != 0 {
+		t.Errorf("%d entries left after expiry", n)
+	}
+}
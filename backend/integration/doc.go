@@ -0,0 +1,10 @@
+// Package integration chứa bộ test end-to-end chạy trên MySQL thật (Docker, testcontainers-go).
+//
+// Bộ test dựng container MySQL, nạp schema + Database/migrations, chạy `backend seed`
+// rồi khởi động server và gọi HTTP API theo các luồng chính
+// (đăng ký → đăng nhập → yêu cầu sự kiện → duyệt → cấu hình vé → mua vé → check-in → báo cáo → hoàn tiền).
+//
+// Chạy (cần Docker):
+//
+//	go test -tags=integration ./integration/...
+package integration
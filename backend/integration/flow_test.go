@@ -0,0 +1,154 @@
+//go:build integration
+
+package integration
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fpt-event-services/common/fixtures"
+)
+
+// seedEmail lấy email tài khoản seed theo Key
+func seedEmail(t *testing.T, key string) string {
+	t.Helper()
+	for _, u := range fixtures.Default().Users {
+		if u.Key == key {
+			return u.Email
+		}
+	}
+	t.Fatalf("no seed user %q", key)
+	return ""
+}
+
+// TestCriticalFlow - Vòng đời đầy đủ của một sự kiện qua HTTP API:
+// register → login → request → approve → update → buy → check-in → report → refund
+func TestCriticalFlow(t *testing.T) {
+	const (
+		studentEmail    = "student.integration@fpt.edu.vn"
+		studentPassword = "Student@123"
+		ticketPrice     = 50000
+	)
+
+	// 1) Sinh viên đăng ký rồi đăng nhập
+	call(t, http.MethodPost, "/api/register", "", map[string]string{
+		"fullName": "Phạm Văn Cường",
+		"phone":    "0901000099",
+		"email":    studentEmail,
+		"password": studentPassword,
+	}).expect(t, http.StatusOK, "register")
+	studentToken := login(t, studentEmail, studentPassword)
+	studentID := queryInt(t, `SELECT user_id FROM Users WHERE email = ?`, studentEmail)
+
+	organizerToken := login(t, seedEmail(t, "organizer"), fixtures.DefaultPassword)
+	staffToken := login(t, seedEmail(t, "staff"), fixtures.DefaultPassword)
+
+	// 2) Organizer gửi yêu cầu tổ chức (định dạng datetime-local như frontend)
+	day := time.Now().AddDate(0, 0, 3).Format("2006-01-02")
+	title := "[Integration] Workshop " + strconv.FormatInt(time.Now().UnixNano(), 36)
+	requestID := call(t, http.MethodPost, "/api/event-requests", organizerToken, map[string]interface{}{
+		"title":              title,
+		"description":        "Sự kiện tạo bởi integration test",
+		"preferredStartTime": day + "T09:00",
+		"preferredEndTime":   day + "T11:00",
+		"expectedCapacity":   40,
+	}).expect(t, http.StatusOK, "create event request").number(t, "requestId")
+
+	// 3) Staff duyệt và xếp khu vực còn trống
+	areaID := queryInt(t, `SELECT area_id FROM Venue_Area WHERE area_name = ? AND status = 'AVAILABLE' LIMIT 1`, "Sảnh tầng trệt")
+	call(t, http.MethodPost, "/api/event-requests/process", staffToken, map[string]interface{}{
+		"requestId": requestID,
+		"action":    "APPROVED",
+		"areaId":    areaID,
+	}).expect(t, http.StatusOK, "approve request")
+	eventID := queryInt(t, `SELECT created_event_id FROM Event_Request WHERE request_id = ?`, requestID)
+	if eventID == 0 {
+		t.Fatal("approved request has no created event")
+	}
+
+	// 4) Organizer cấu hình speaker + loại vé → sự kiện mở bán
+	call(t, http.MethodPost, "/api/event-requests/update", organizerToken, map[string]interface{}{
+		"requestId": requestID,
+		"eventId":   eventID,
+		"speaker": map[string]string{
+			"fullName": "Diễn Giả Integration",
+			"email":    "speaker.integration@fpt.edu.vn",
+			"phone":    "0901000098",
+		},
+		"tickets": []map[string]interface{}{
+			{"name": "STANDARD", "description": "Ghế thường", "price": ticketPrice, "maxQuantity": 40},
+		},
+	}).expect(t, http.StatusOK, "update event request")
+	if status := queryString(t, `SELECT status FROM Event WHERE event_id = ?`, eventID); status != "OPEN" {
+		t.Fatalf("event status after update = %s, want OPEN", status)
+	}
+
+	// 5) Sinh viên mua vé bằng ví (chưa có API nạp ví → nạp trực tiếp)
+	mustExec(t, `UPDATE Users SET Wallet = ? WHERE user_id = ?`, ticketPrice, studentID)
+	categoryID := queryInt(t, `SELECT category_ticket_id FROM category_ticket WHERE event_id = ? AND name = 'STANDARD'`, eventID)
+	seatID := queryInt(t, `SELECT MIN(seat_id) FROM Seat WHERE area_id = ? AND category_ticket_id = ?`, areaID, categoryID)
+	pay := call(t, http.MethodPost, "/api/wallet/pay-ticket", studentToken, map[string]interface{}{
+		"eventId":          eventID,
+		"categoryTicketId": categoryID,
+		"seatIds":          []int{seatID},
+	}).expect(t, http.StatusOK, "wallet pay")
+	ticketIDs, _ := pay.Body["ticketIds"].(string)
+	ticketID, err := strconv.Atoi(strings.Split(ticketIDs, ",")[0])
+	if err != nil {
+		t.Fatalf("invalid ticketIds %q", ticketIDs)
+	}
+	if wallet := queryInt(t, `SELECT Wallet FROM Users WHERE user_id = ?`, studentID); wallet != 0 {
+		t.Fatalf("wallet after purchase = %d, want 0", wallet)
+	}
+	if status := queryString(t, `SELECT status FROM Ticket WHERE ticket_id = ?`, ticketID); status != "BOOKED" {
+		t.Fatalf("ticket status after purchase = %s, want BOOKED", status)
+	}
+
+	// 6) Dời sự kiện vào khung giờ check-in rồi organizer quét vé
+	mustExec(t, `UPDATE Event SET start_time = DATE_ADD(NOW(), INTERVAL 30 MINUTE), end_time = DATE_ADD(NOW(), INTERVAL 150 MINUTE) WHERE event_id = ?`, eventID)
+	call(t, http.MethodPost, "/api/staff/checkin?ticketCode="+strconv.Itoa(ticketID), organizerToken, nil).
+		expect(t, http.StatusOK, "check-in")
+	if status := queryString(t, `SELECT status FROM Ticket WHERE ticket_id = ?`, ticketID); status != "CHECKED_IN" {
+		t.Fatalf("ticket status after check-in = %s, want CHECKED_IN", status)
+	}
+
+	// 7) Sinh viên báo lỗi ghế, staff duyệt → hoàn tiền vào ví
+	reportID := call(t, http.MethodPost, "/api/student/reports", studentToken, map[string]interface{}{
+		"ticketId":    ticketID,
+		"title":       "Ghế hỏng",
+		"description": "Ghế bị gãy chân, không thể ngồi",
+	}).expect(t, http.StatusCreated, "submit report").number(t, "reportId")
+
+	call(t, http.MethodPost, "/api/staff/reports/process", staffToken, map[string]interface{}{
+		"reportId":  reportID,
+		"action":    "APPROVE",
+		"staffNote": "Đã xác nhận tại chỗ",
+	}).expect(t, http.StatusOK, "approve report")
+
+	if status := queryString(t, `SELECT status FROM Ticket WHERE ticket_id = ?`, ticketID); status != "REFUNDED" {
+		t.Fatalf("ticket status after refund = %s, want REFUNDED", status)
+	}
+	if wallet := queryInt(t, `SELECT Wallet FROM Users WHERE user_id = ?`, studentID); wallet != ticketPrice {
+		t.Fatalf("wallet after refund = %d, want %d", wallet, ticketPrice)
+	}
+}
+
+// TestSeedIsIdempotent - Chạy lại seed không tạo bản ghi trùng
+func TestSeedIsIdempotent(t *testing.T) {
+	set := fixtures.Default()
+	res, err := fixtures.Load(t.Context(), suite.db, set, fixtures.Options{})
+	if err != nil {
+		t.Fatalf("reload fixtures: %v", err)
+	}
+	if len(res.Created) != 0 {
+		t.Fatalf("second load created rows: %v", res.Created)
+	}
+	for _, e := range set.Events {
+		if n := queryInt(t, `SELECT COUNT(*) FROM Event WHERE title = ?`, e.Title); n != 1 {
+			t.Errorf("event %q stored %d times", e.Title, n)
+		}
+	}
+}
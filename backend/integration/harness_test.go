@@ -0,0 +1,319 @@
+//go:build integration
+
+package integration
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/fpt-event-services/common/db"
+	_ "github.com/go-sql-driver/mysql"
+	"github.com/testcontainers/testcontainers-go"
+	tcmysql "github.com/testcontainers/testcontainers-go/modules/mysql"
+)
+
+// ============================================================
+// HARNESS - MySQL container + server backend chạy như production
+// ============================================================
+
+const (
+	dbName     = "fpteventmanagement"
+	dbPassword = "integration"
+	jwtSecret  = "integration-test-secret"
+)
+
+// suite - Môi trường dùng chung cho mọi test trong package
+var suite struct {
+	baseURL string
+	db      *sql.DB // Kết nối trực tiếp để kiểm tra dữ liệu / dời thời gian sự kiện
+}
+
+func TestMain(m *testing.M) {
+	code, err := run(m)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "integration setup failed: %v\n", err)
+		os.Exit(1)
+	}
+	os.Exit(code)
+}
+
+func run(m *testing.M) (int, error) {
+	ctx := context.Background()
+	workDir, err := os.MkdirTemp("", "fpt-integration-")
+	if err != nil {
+		return 0, err
+	}
+	defer os.RemoveAll(workDir)
+
+	scripts, err := schemaScripts(workDir)
+	if err != nil {
+		return 0, err
+	}
+
+	// Code dùng tên bảng dạng Users/Event → cần lower_case_table_names=1 như môi trường thật
+	container, err := tcmysql.Run(ctx, "mysql:8.0",
+		tcmysql.WithDatabase(dbName),
+		tcmysql.WithUsername("root"),
+		tcmysql.WithPassword(dbPassword),
+		tcmysql.WithScripts(scripts...),
+		testcontainers.WithCmd("mysqld", "--lower-case-table-names=1", "--default-time-zone=+07:00"),
+	)
+	if err != nil {
+		return 0, fmt.Errorf("start mysql: %w", err)
+	}
+	defer testcontainers.TerminateContainer(container)
+
+	host, err := container.Host(ctx)
+	if err != nil {
+		return 0, err
+	}
+	mappedPort, err := container.MappedPort(ctx, "3306/tcp")
+	if err != nil {
+		return 0, err
+	}
+	dbPort := mappedPort.Port()
+
+	suite.db, err = sql.Open("mysql", fmt.Sprintf("root:%s@tcp(%s:%s)/%s?parseTime=true&loc=Asia%%2FHo_Chi_Minh",
+		dbPassword, host, dbPort, dbName))
+	if err != nil {
+		return 0, err
+	}
+	defer suite.db.Close()
+
+	// Repository gọi thẳng trong test (seat_claim_test) dùng pool common/db trỏ vào cùng container
+	portNum, err := strconv.Atoi(dbPort)
+	if err != nil {
+		return 0, err
+	}
+	if err := db.InitDBWithConfig(db.Config{Server: host, Port: portNum, Database: dbName, User: "root", Password: dbPassword}); err != nil {
+		return 0, fmt.Errorf("init repository db: %w", err)
+	}
+	defer db.CloseDB()
+
+	// Build binary backend (thư mục cha) để chạy seed + server đúng như khi deploy
+	binary := filepath.Join(workDir, "backend")
+	build := exec.Command("go", "build", "-o", binary, ".")
+	build.Dir = ".."
+	build.Stdout, build.Stderr = os.Stdout, os.Stderr
+	if err := build.Run(); err != nil {
+		return 0, fmt.Errorf("build backend: %w", err)
+	}
+
+	httpPort, err := freePort()
+	if err != nil {
+		return 0, err
+	}
+	env := append(os.Environ(),
+		"DB_SERVER="+host,
+		"DB_PORT="+dbPort,
+		"DB_NAME="+dbName,
+		"DB_USER=root",
+		"DB_PASSWORD="+dbPassword,
+		"JWT_SECRET="+jwtSecret,
+		"PORT="+strconv.Itoa(httpPort),
+		// booking_queue_test tự gọi Tick: dispatcher của server không được chen vào giữa
+		"BOOKING_QUEUE_INTERVAL_SECONDS=3600",
+	)
+
+	seed := exec.Command(binary, "seed")
+	seed.Dir = workDir // Không đọc .env của máy dev
+	seed.Env = env
+	seed.Stdout, seed.Stderr = os.Stdout, os.Stderr
+	if err := seed.Run(); err != nil {
+		return 0, fmt.Errorf("seed: %w", err)
+	}
+
+	logFile, err := os.Create(filepath.Join(workDir, "server.log"))
+	if err != nil {
+		return 0, err
+	}
+	defer logFile.Close()
+	server := exec.Command(binary)
+	server.Dir = workDir
+	server.Env = env
+	server.Stdout, server.Stderr = logFile, logFile
+	if err := server.Start(); err != nil {
+		return 0, fmt.Errorf("start server: %w", err)
+	}
+	defer server.Process.Kill()
+
+	suite.baseURL = fmt.Sprintf("http://127.0.0.1:%d", httpPort)
+	if err := waitHealthy(suite.baseURL+"/health", 60*time.Second); err != nil {
+		logs, _ := os.ReadFile(logFile.Name())
+		return 0, fmt.Errorf("%w\n--- server log ---\n%s", err, logs)
+	}
+
+	code := m.Run()
+	if code != 0 {
+		logs, _ := os.ReadFile(logFile.Name())
+		fmt.Fprintf(os.Stderr, "--- server log ---\n%s\n", logs)
+	}
+	return code, nil
+}
+
+// schemaScripts chép schema + migrations sang workDir với tên giữ đúng thứ tự chạy
+// (docker-entrypoint-initdb.d chạy theo thứ tự tên file)
+func schemaScripts(workDir string) ([]string, error) {
+	schema, err := filepath.Glob("../../Database/*.sql")
+	if err != nil || len(schema) != 1 {
+		return nil, fmt.Errorf("expected exactly one schema dump in Database/, found %v", schema)
+	}
+	migrations, err := filepath.Glob("../../Database/migrations/*.sql")
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(migrations)
+
+	var scripts []string
+	for i, src := range append(schema, migrations...) {
+		data, err := os.ReadFile(src)
+		if err != nil {
+			return nil, err
+		}
+		dst := filepath.Join(workDir, fmt.Sprintf("%03d_%s", i, filepath.Base(src)))
+		if err := os.WriteFile(dst, data, 0o644); err != nil {
+			return nil, err
+		}
+		scripts = append(scripts, dst)
+	}
+	return scripts, nil
+}
+
+func freePort() (int, error) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, err
+	}
+	defer l.Close()
+	return l.Addr().(*net.TCPAddr).Port, nil
+}
+
+func waitHealthy(url string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		resp, err := http.Get(url)
+		if err == nil {
+			resp.Body.Close()
+			if resp.StatusCode == http.StatusOK {
+				return nil
+			}
+		}
+		time.Sleep(500 * time.Millisecond)
+	}
+	return fmt.Errorf("server not healthy after %s", timeout)
+}
+
+// ============================================================
+// HTTP helpers
+// ============================================================
+
+// apiResponse - Kết quả gọi API (body JSON đã decode nếu được)
+type apiResponse struct {
+	Status int
+	Body   map[string]interface{}
+	Raw    string
+}
+
+// call gọi API với bearer token (rỗng = không xác thực), body được encode JSON
+func call(t *testing.T, method, path, token string, body interface{}) apiResponse {
+	t.Helper()
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			t.Fatalf("encode body: %v", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequest(method, suite.baseURL+path, reader)
+	if err != nil {
+		t.Fatalf("new request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("%s %s: %v", method, path, err)
+	}
+	defer resp.Body.Close()
+	raw, _ := io.ReadAll(resp.Body)
+
+	out := apiResponse{Status: resp.StatusCode, Raw: string(raw)}
+	json.Unmarshal(raw, &out.Body)
+	return out
+}
+
+// expect dừng test nếu status khác mong đợi
+func (r apiResponse) expect(t *testing.T, status int, what string) apiResponse {
+	t.Helper()
+	if r.Status != status {
+		t.Fatalf("%s: status %d, want %d: %s", what, r.Status, status, r.Raw)
+	}
+	return r
+}
+
+// number lấy trường số trong body JSON
+func (r apiResponse) number(t *testing.T, key string) int {
+	t.Helper()
+	v, ok := r.Body[key].(float64)
+	if !ok {
+		t.Fatalf("response has no numeric %q: %s", key, r.Raw)
+	}
+	return int(v)
+}
+
+// login đăng nhập và trả về JWT
+func login(t *testing.T, email, password string) string {
+	t.Helper()
+	resp := call(t, http.MethodPost, "/api/login", "", map[string]string{"email": email, "password": password}).
+		expect(t, http.StatusOK, "login "+email)
+	token, _ := resp.Body["token"].(string)
+	if token == "" {
+		t.Fatalf("login %s: no token in %s", email, resp.Raw)
+	}
+	return token
+}
+
+// queryInt đọc một giá trị số từ DB
+func queryInt(t *testing.T, query string, args ...interface{}) int {
+	t.Helper()
+	var n sql.NullFloat64
+	if err := suite.db.QueryRow(query, args...).Scan(&n); err != nil {
+		t.Fatalf("query %q: %v", query, err)
+	}
+	return int(n.Float64)
+}
+
+// mustExec chạy câu lệnh SQL chuẩn bị dữ liệu (dời thời gian, nạp ví...)
+func mustExec(t *testing.T, query string, args ...interface{}) {
+	t.Helper()
+	if _, err := suite.db.Exec(query, args...); err != nil {
+		t.Fatalf("exec %q: %v", query, err)
+	}
+}
+
+// queryString đọc một giá trị chuỗi từ DB
+func queryString(t *testing.T, query string, args ...interface{}) string {
+	t.Helper()
+	var s sql.NullString
+	if err := suite.db.QueryRow(query, args...).Scan(&s); err != nil {
+		t.Fatalf("query %q: %v", query, err)
+	}
+	return s.String
+}
@@ -0,0 +1,121 @@
+//go:build integration
+
+package integration
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/fpt-event-services/common/db"
+	apperrors "github.com/fpt-event-services/common/errors"
+	"github.com/fpt-event-services/common/fixtures"
+	"github.com/fpt-event-services/services/ticket-lambda/repository"
+)
+
+// seededIDs - ID của dữ liệu seed (fixtures.Load idempotent nên chỉ đọc lại ID)
+func seededIDs(t *testing.T) *fixtures.Result {
+	t.Helper()
+	res, err := fixtures.Load(t.Context(), suite.db, fixtures.Default(), fixtures.Options{})
+	if err != nil {
+		t.Fatalf("load fixtures: %v", err)
+	}
+	return res
+}
+
+// freeSeat - Ghế STANDARD của sự kiện chưa từng có vé
+func freeSeat(t *testing.T, eventID int) (categoryID, seatID int) {
+	t.Helper()
+	categoryID = queryInt(t, `SELECT category_ticket_id FROM category_ticket WHERE event_id = ? AND name = 'STANDARD'`, eventID)
+	seatID = queryInt(t, `
+		SELECT MIN(s.seat_id) FROM Seat s
+		WHERE s.category_ticket_id = ?
+		  AND NOT EXISTS (SELECT 1 FROM Ticket t WHERE t.event_id = ? AND t.seat_id = s.seat_id)`, categoryID, eventID)
+	return categoryID, seatID
+}
+
+// releaseHolds - Cho vé PENDING hết hạn giữ ghế; job pending-ticket-cleanup của server trả ghế và suất
+func releaseHolds(ticketIDs []int) {
+	for _, id := range ticketIDs {
+		suite.db.Exec(`UPDATE Ticket SET status = 'PENDING', hold_expires_at = NOW() - INTERVAL 1 MINUTE WHERE ticket_id = ?`, id)
+	}
+}
+
+// assertSeatTaken - Lỗi nghiệp vụ "ghế đã được giữ/đặt", không phải lỗi MySQL 1062
+func assertSeatTaken(t *testing.T, err error, seatID int) {
+	t.Helper()
+	var appErr *apperrors.AppError
+	if !errors.As(err, &appErr) || appErr.Code != apperrors.ErrCodeBusinessRule ||
+		appErr.Message != fmt.Sprintf("Ghế ID %d đã được người khác giữ/đặt", seatID) || db.IsDuplicateEntry(err) {
+		t.Errorf("err = %v, want seat-taken business error", err)
+	}
+}
+
+// TestSeatDoubleBookingConcurrent - 50 lượt tạo link VNPay cùng giữ một ghế trống:
+// đúng một lượt thành công, các lượt còn lại nhận lỗi ghế đã được giữ
+func TestSeatDoubleBookingConcurrent(t *testing.T) {
+	ids := seededIDs(t)
+	eventID, userID := ids.EventIDs["open"], ids.UserIDs["student1"]
+	categoryID, seatID := freeSeat(t, eventID)
+	repo := repository.NewTicketRepository()
+
+	const workers = 50
+	start := make(chan struct{})
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var held []int
+	var failures []error
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			result, err := repo.CreateVNPayURL(t.Context(), userID, eventID, categoryID, []int{seatID}, false)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				failures = append(failures, err)
+				return
+			}
+			held = append(held, result.TicketIDs...)
+		}()
+	}
+	close(start)
+	wg.Wait()
+	t.Cleanup(func() { releaseHolds(held) })
+
+	if len(held) != 1 || len(failures) != workers-1 {
+		t.Fatalf("%d holds created, %d rejected; want 1 created and %d rejected", len(held), len(failures), workers-1)
+	}
+	for _, err := range failures {
+		assertSeatTaken(t, err, seatID)
+	}
+}
+
+// TestCheckedOutSeatNotResold - Ghế của vé CHECKED_OUT vẫn bị giữ:
+// thanh toán ví (không có bước COUNT trước) bị UQ_Ticket_Event_ActiveSeat chặn
+func TestCheckedOutSeatNotResold(t *testing.T) {
+	ids := seededIDs(t)
+	eventID := ids.EventIDs["open"]
+	categoryID, seatID := freeSeat(t, eventID)
+	repo := repository.NewTicketRepository()
+
+	result, err := repo.CreateVNPayURL(t.Context(), ids.UserIDs["student1"], eventID, categoryID, []int{seatID}, false)
+	if err != nil {
+		t.Fatalf("hold seat: %v", err)
+	}
+	t.Cleanup(func() { releaseHolds(result.TicketIDs) })
+	mustExec(t, `UPDATE Ticket SET status = 'CHECKED_OUT', hold_expires_at = NULL WHERE ticket_id = ?`, result.TicketIDs[0])
+
+	buyer := ids.UserIDs["student2"]
+	mustExec(t, `UPDATE users SET Wallet = Wallet + 1000000 WHERE user_id = ?`, buyer)
+	t.Cleanup(func() { suite.db.Exec(`UPDATE users SET Wallet = Wallet - 1000000 WHERE user_id = ?`, buyer) })
+
+	_, err = repo.ProcessWalletPayment(t.Context(), buyer, eventID, categoryID, []int{seatID}, 0, false)
+	if err == nil {
+		t.Fatalf("seat %d of a CHECKED_OUT ticket was sold again", seatID)
+	}
+	assertSeatTaken(t, err, seatID)
+}
@@ -3,10 +3,12 @@ package main
 import (
 	"context"
 	"database/sql"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
 	"log"
+	"net"
 	"net/http"
 	"os"
 	"path/filepath"
@@ -14,15 +16,46 @@ import (
 	"time"
 
 	"github.com/aws/aws-lambda-go/events"
+	"github.com/fpt-event-services/cli"
+	"github.com/fpt-event-services/common/authctx"
+	"github.com/fpt-event-services/common/capability"
+	"github.com/fpt-event-services/common/config"
+	"github.com/fpt-event-services/common/crypto"
 	"github.com/fpt-event-services/common/db"
+	"github.com/fpt-event-services/common/eventbus"
+	"github.com/fpt-event-services/common/health"
+	"github.com/fpt-event-services/common/httpcache"
+	"github.com/fpt-event-services/common/httpguard"
+	"github.com/fpt-event-services/common/imageproc"
 	"github.com/fpt-event-services/common/jwt"
+	"github.com/fpt-event-services/common/livestats"
+	"github.com/fpt-event-services/common/metrics"
+	"github.com/fpt-event-services/common/outbox"
+	"github.com/fpt-event-services/common/rpc"
 	"github.com/fpt-event-services/common/scheduler"
+	"github.com/fpt-event-services/common/session"
+	"github.com/fpt-event-services/common/statuslabel"
+	"github.com/fpt-event-services/common/tracing"
+	"github.com/fpt-event-services/common/validator"
 	authHandler "github.com/fpt-event-services/services/auth-lambda/handler"
+	dashboardHandler "github.com/fpt-event-services/services/dashboard-lambda/handler"
+	eventClient "github.com/fpt-event-services/services/event-lambda/client"
 	eventHandler "github.com/fpt-event-services/services/event-lambda/handler"
+	eventModels "github.com/fpt-event-services/services/event-lambda/models"
 	eventRepository "github.com/fpt-event-services/services/event-lambda/repository"
+	eventUsecase "github.com/fpt-event-services/services/event-lambda/usecase"
+	graphqlHandler "github.com/fpt-event-services/services/graphql-lambda/handler"
+	"github.com/fpt-event-services/services/proto/eventpb"
+	"github.com/fpt-event-services/services/proto/ticketpb"
+	"github.com/fpt-event-services/services/proto/venuepb"
 	staffHandler "github.com/fpt-event-services/services/staff-lambda/handler"
+	ticketClient "github.com/fpt-event-services/services/ticket-lambda/client"
 	ticketHandler "github.com/fpt-event-services/services/ticket-lambda/handler"
+	ticketRepository "github.com/fpt-event-services/services/ticket-lambda/repository"
+	ticketUsecase "github.com/fpt-event-services/services/ticket-lambda/usecase"
+	venueClient "github.com/fpt-event-services/services/venue-lambda/client"
 	venueHandler "github.com/fpt-event-services/services/venue-lambda/handler"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 )
 
 // Adapter converts http.Request to APIGatewayProxyRequest
@@ -50,22 +83,27 @@ func adaptRequest(r *http.Request) (events.APIGatewayProxyRequest, error) {
 		}
 	}
 
+	// IP kết nối trực tiếp (như API Gateway điền vào Identity.SourceIP); handler ưu tiên X-Forwarded-For
+	sourceIP, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		sourceIP = r.RemoteAddr
+	}
+
 	return events.APIGatewayProxyRequest{
 		HTTPMethod:            r.Method,
 		Path:                  r.URL.Path,
 		Headers:               headers,
 		QueryStringParameters: queryParams,
 		Body:                  string(body),
+		RequestContext: events.APIGatewayProxyRequestContext{
+			Identity: events.APIGatewayRequestIdentity{SourceIP: sourceIP},
+		},
 	}, nil
 }
 
 // writeResponse writes APIGatewayProxyResponse to http.ResponseWriter
+// Handler không gắn header CORS; corsMiddleware (config.CORSConfig) quyết định
 func writeResponse(w http.ResponseWriter, resp events.APIGatewayProxyResponse) {
-	// Set CORS headers
-	w.Header().Set("Access-Control-Allow-Origin", "*")
-	w.Header().Set("Access-Control-Allow-Methods", "GET,POST,PUT,DELETE,OPTIONS")
-	w.Header().Set("Access-Control-Allow-Headers", "Content-Type,Authorization")
-
 	// Set response headers from Lambda response
 	for key, value := range resp.Headers {
 		w.Header().Set(key, value)
@@ -73,30 +111,120 @@ func writeResponse(w http.ResponseWriter, resp events.APIGatewayProxyResponse) {
 
 	// Set status code and write body
 	w.WriteHeader(resp.StatusCode)
+	if resp.IsBase64Encoded {
+		// Response nhị phân (ZIP, PDF...) được handler mã hóa base64 như API Gateway
+		if data, err := base64.StdEncoding.DecodeString(resp.Body); err == nil {
+			w.Write(data)
+			return
+		}
+	}
 	w.Write([]byte(resp.Body))
 }
 
-// corsMiddleware handles CORS preflight requests
+// Nhóm route nhận body lớn hơn JSONPolicy (base64 ảnh / tài liệu, + overhead JSON)
+var (
+	uploadPolicy = httpguard.Policy{
+		Name:         "upload",
+		MaxBodyBytes: imageproc.MaxUploadBytes * 2,
+		ContentTypes: []string{"application/json", "image/*"},
+	}
+	attachmentPolicy = httpguard.Policy{
+		Name:         "attachment",
+		MaxBodyBytes: eventModels.MaxAttachmentBytes * 2,
+		ContentTypes: []string{"application/json"},
+	}
+)
+
+// corsConfig - Cấu hình CORS đọc từ env lúc khởi động (xem config.CORSConfigFromEnv)
+var corsConfig *config.CORSConfig
+
+// corsMiddleware - CORS theo corsConfig: chỉ origin trong danh sách mới nhận header CORS
+// Origin không được phép vẫn đi tiếp (client không phải trình duyệt không bị ảnh hưởng),
+// trình duyệt sẽ chặn đọc response vì thiếu Access-Control-Allow-Origin
 func corsMiddleware(next http.HandlerFunc) http.HandlerFunc {
-	return func(w http.ResponseWriter, r *http.Request) {
-		// Set CORS headers for all responses
-		w.Header().Set("Access-Control-Allow-Origin", "*")
-		w.Header().Set("Access-Control-Allow-Methods", "GET,POST,PUT,DELETE,OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type,Authorization")
+	return tracingMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		h := w.Header()
+		h.Add("Vary", "Origin")
+		preflight := r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != ""
+
+		for key, value := range corsConfig.Headers(r.Header.Get("Origin"), preflight) {
+			h.Set(key, value)
+		}
 
 		// Handle preflight request
+		if preflight {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		// Giới hạn body + Content-Type theo nhóm route (mặc định JSON 1MB)
+		if !httpguard.EnforceBody(w, r, httpguard.PolicyFrom(r.Context())) {
+			return
+		}
+
+		next(w, r)
+	})
+}
+
+// widgetCORSMiddleware - CORS của widget nhúng: chỉ mở cho origin nằm trong
+// danh sách allowedOrigins của API key (?key=), thay cho "*" của corsMiddleware
+// Request không có Origin (gọi từ server) không cần header CORS
+func widgetCORSMiddleware(allowOrigin func(ctx context.Context, apiKey, origin string) bool, next http.HandlerFunc) http.HandlerFunc {
+	return tracingMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Origin")
+		if origin := r.Header.Get("Origin"); origin != "" {
+			apiKey := r.URL.Query().Get("key")
+			if apiKey == "" {
+				apiKey = r.Header.Get(eventHandler.WidgetKeyHeader)
+			}
+			if !allowOrigin(r.Context(), apiKey, origin) {
+				http.Error(w, "Origin is not allowed for this API key", http.StatusForbidden)
+				return
+			}
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+			w.Header().Set("Access-Control-Allow-Methods", "GET,OPTIONS")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type,"+eventHandler.WidgetKeyHeader)
+			w.Header().Set("Access-Control-Max-Age", "600")
+		}
+
 		if r.Method == http.MethodOptions {
-			w.WriteHeader(http.StatusOK)
+			w.WriteHeader(http.StatusNoContent)
 			return
 		}
+		next(w, r)
+	})
+}
 
+// tracingMiddleware mở server span cho mỗi request bằng otelhttp (nối vào trace của caller
+// qua traceparent, ghi http.route và status code) và trả trace ID trong header X-Trace-Id
+// để đối chiếu log
+func tracingMiddleware(next http.HandlerFunc) http.HandlerFunc {
+	handler := otelhttp.NewHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Trace-Id", tracing.TraceIDFromContext(r.Context()))
 		next(w, r)
-	}
+	}), "http.server", otelhttp.WithSpanNameFormatter(func(_ string, r *http.Request) string {
+		route := r.Pattern
+		if route == "" {
+			route = r.URL.Path
+		}
+		return r.Method + " " + route
+	}))
+	return handler.ServeHTTP
 }
 
-// authMiddleware extracts user info from JWT and adds to request headers and context
+// requestContext - Context truyền xuống handler: giữ span/trace của request
+// nhưng không bị hủy khi client ngắt kết nối (giống context.Background() trước đây)
+func requestContext(r *http.Request) context.Context {
+	return context.WithoutCancel(r.Context())
+}
+
+// authMiddleware extracts user info from JWT and stores it in the request context (authctx)
 func authMiddleware(next http.HandlerFunc) http.HandlerFunc {
 	return corsMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		// Header danh tính do client tự gửi không bao giờ được tin
+		r.Header.Del("X-User-Id")
+		r.Header.Del("X-User-Role")
+
 		// Extract token
 		authHeader := r.Header.Get("Authorization")
 		log.Printf("[AUTH] Authorization header: %s", authHeader[:min(len(authHeader), 50)])
@@ -107,17 +235,15 @@ func authMiddleware(next http.HandlerFunc) http.HandlerFunc {
 			if err != nil {
 				log.Printf("[AUTH] JWT validation error: %v", err)
 			}
+			// Token gắn phiên (jti): phiên đã thu hồi / hết hạn coi như chưa đăng nhập
+			if claims != nil && claims.ID != "" && !session.Active(r.Context(), claims.UserID, claims.ID) {
+				log.Printf("[AUTH] ❌ Session revoked or expired for userID=%d", claims.UserID)
+				claims = nil
+			}
 			if claims != nil {
-				// Extract user info and add to headers for handler
-				// Use canonical form: X-User-Id (Go http auto-normalizes headers)
-				r.Header.Set("X-User-Id", fmt.Sprintf("%d", claims.UserID))
-				r.Header.Set("X-User-Role", claims.Role)
-				log.Printf("[AUTH] ✅ Set X-User-Id=%d, X-User-Role=%s", claims.UserID, claims.Role)
-
-				// CRITICAL: Add userID to Context for handlers
-				r = r.WithContext(context.WithValue(r.Context(), "userID", claims.UserID))
-				r = r.WithContext(context.WithValue(r.Context(), "userRole", claims.Role))
-				log.Printf("[AUTH] ✅ Added userID=%d to Context", claims.UserID)
+				ctx := authctx.WithUser(r.Context(), claims.UserID, claims.Role)
+				r = r.WithContext(authctx.WithSession(ctx, claims.ID))
+				log.Printf("[AUTH] ✅ Added userID=%d, role=%s to Context", claims.UserID, claims.Role)
 			} else {
 				log.Printf("[AUTH] ❌ Claims is nil")
 			}
@@ -136,12 +262,28 @@ func runStartupJanitor() {
 	log.Println("========================================")
 
 	// Create event repository to access cleanup function
-	eventRepo := eventRepository.NewEventRepository()
+	eventRepo := eventRepository.DefaultEventRepository()
 
-	// Run venue release for closed events
 	ctx := context.Background()
+
+	// Sự kiện tạo trước khi có slug (migration 024) chưa có trang public
+	if n, err := eventRepo.BackfillEventSlugs(ctx); err != nil {
+		log.Printf("❌ [STARTUP JANITOR] Error backfilling event slugs: %v", err)
+	} else if n > 0 {
+		log.Printf("✅ [STARTUP JANITOR] Assigned slugs to %d events", n)
+	}
+
+	// Run venue release for closed events
 	log.Println("[STARTUP JANITOR] Releasing venues for closed events...")
 
+	// Dùng chung khóa với job venue-release để instance khác không chạy trùng
+	unlock, ok, err := scheduler.NewMySQLLocker(db.GetDB()).TryLock(ctx, "venue-release")
+	if err != nil || !ok {
+		log.Printf("⏭️ [STARTUP JANITOR] Venue release skipped (lock held by another instance or error: %v)", err)
+		return
+	}
+	defer unlock()
+
 	if err := eventRepo.AutoReleaseVenues(ctx); err != nil {
 		log.Printf("❌ [STARTUP JANITOR] Error releasing venues: %v", err)
 	} else {
@@ -157,9 +299,42 @@ func main() {
 	// Load environment from .env file if exists
 	loadEnvFile(".env")
 
+	// Secret (DB, JWT, VNPay, SMTP, khóa PII) từ AWS Secrets Manager / SSM theo SECRETS_PROVIDER
+	if err := config.LoadSecrets(context.Background()); err != nil {
+		log.Fatalf("Failed to load secrets: %v", err)
+	}
+
+	// Có tham số (vd: `backend admin create-admin ...`) → chạy lệnh CLI thay vì server
+	if len(os.Args) > 1 && !strings.HasPrefix(os.Args[1], "-") {
+		os.Exit(cli.Execute(os.Args[1:]))
+	}
+
 	// Initialize services that depend on environment variables
 	authHandler.InitServices()
 
+	// CORS: danh sách origin theo môi trường (CORS_ORIGINS, APP_ENV)
+	cors, err := config.CORSConfigFromEnv()
+	if err != nil {
+		log.Fatalf("Invalid CORS configuration: %v", err)
+	}
+	corsConfig = cors
+
+	// Khóa mã hóa PII (số điện thoại user, email/số điện thoại speaker): bắt buộc ở production
+	piiCipher, err := crypto.Default()
+	if err != nil {
+		log.Fatalf("Invalid PII encryption key: %v", err)
+	}
+	if piiCipher == nil && config.IsProduction() {
+		log.Fatal("PII_ENCRYPTION_KEY (or PII_ENCRYPTION_KEY_FILE) is required in production")
+	}
+
+	// Tracing (OpenTelemetry/OTLP): chỉ export khi đặt OTEL_EXPORTER_OTLP_ENDPOINT
+	shutdownTracing, err := tracing.Init(tracing.ConfigFromEnv())
+	if err != nil {
+		log.Fatalf("Failed to initialize tracing: %v", err)
+	}
+	defer shutdownTracing(context.Background())
+
 	// Initialize database
 	log.Println("Connecting to MySQL database...")
 	if err := db.InitDB(); err != nil {
@@ -172,12 +347,36 @@ func main() {
 	// Run startup cleanup to release areas for closed events
 	runStartupJanitor()
 
+	// Domain events: side effect (bộ đếm realtime, cache danh sách OPEN, giải phóng khu vực, mã tra cứu vé của khách) nghe trên bus dùng chung
+	livestats.RegisterSubscribers(eventbus.Default)
+	eventUsecase.RegisterSubscribers(eventbus.Default)
+	ticketUsecase.RegisterSubscribers(eventbus.Default)
+
+	// Transactional outbox: handler cho side effect đã ghi trong transaction (email vé VNPay)
+	ticketRepository.RegisterOutboxHandlers(outbox.Default())
+
+	// gRPC nội bộ (services/proto): EventService, TicketService, VenueService cho service chạy tách
+	// (gọi qua *_SERVICE_GRPC_ADDR); chỉ mở khi đặt GRPC_PORT. Server luôn dùng adapter in-process
+	grpcServer := rpc.NewServer()
+	eventpb.RegisterEventServiceServer(grpcServer, eventClient.NewGRPCServer(eventClient.NewLocalEventService()))
+	ticketpb.RegisterTicketServiceServer(grpcServer, ticketClient.NewGRPCServer(ticketClient.NewLocalTicketService()))
+	venuepb.RegisterVenueServiceServer(grpcServer, venueClient.NewGRPCServer(venueClient.NewLocalVenueService()))
+	if _, err := rpc.Listen(grpcServer); err != nil {
+		log.Fatalf("Failed to start gRPC server: %v", err)
+	}
+	defer grpcServer.GracefulStop()
+
+	// Capability registry: thao tác repository đã / chưa hiện thực; route khai báo phụ thuộc bên dưới,
+	// kiểm tra trước khi mở cổng (CAPABILITY_GATE=warn|fail|off)
+	capability.Default.Declare(eventRepository.CapabilityComponent, eventRepository.Capabilities()...)
+
 	// Create handlers
 	authH := authHandler.NewAuthHandler()
 	eventH := eventHandler.NewEventHandler()
 	ticketH := ticketHandler.NewTicketHandler()
 	venueH := venueHandler.NewVenueHandler()
 	staffH := staffHandler.NewStaffHandler()
+	dashboardH := dashboardHandler.NewDashboardHandler()
 
 	// ======================= AUTH ROUTES =======================
 	http.HandleFunc("/api/login", corsMiddleware(func(w http.ResponseWriter, r *http.Request) {
@@ -192,7 +391,7 @@ func main() {
 			return
 		}
 
-		resp, err := authH.HandleLogin(context.Background(), req)
+		resp, err := authH.HandleLogin(requestContext(r), req)
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
@@ -213,7 +412,7 @@ func main() {
 			return
 		}
 
-		resp, err := authH.HandleRegister(context.Background(), req)
+		resp, err := authH.HandleRegister(requestContext(r), req)
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
@@ -235,7 +434,7 @@ func main() {
 			return
 		}
 
-		resp, err := authH.HandleRegisterSendOTP(context.Background(), req)
+		resp, err := authH.HandleRegisterSendOTP(requestContext(r), req)
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
@@ -257,7 +456,7 @@ func main() {
 			return
 		}
 
-		resp, err := authH.HandleRegisterVerifyOTP(context.Background(), req)
+		resp, err := authH.HandleRegisterVerifyOTP(requestContext(r), req)
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
@@ -279,7 +478,7 @@ func main() {
 			return
 		}
 
-		resp, err := authH.HandleRegisterResendOTP(context.Background(), req)
+		resp, err := authH.HandleRegisterResendOTP(requestContext(r), req)
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
@@ -299,11 +498,85 @@ func main() {
 		var resp events.APIGatewayProxyResponse
 		switch r.Method {
 		case http.MethodPost:
-			resp, err = authH.HandleAdminCreateAccount(context.Background(), req)
+			resp, err = authH.HandleAdminCreateAccount(requestContext(r), req)
+		case http.MethodPut:
+			resp, err = authH.HandleAdminUpdateUser(requestContext(r), req)
+		case http.MethodDelete:
+			resp, err = authH.HandleAdminDeleteUser(requestContext(r), req)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeResponse(w, resp)
+	}))
+
+	// GET /api/admin/permissions - Danh sách quyền (role.manage)
+	http.HandleFunc("/api/admin/permissions", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
+		}
+
+		resp, err := authH.HandleListPermissions(requestContext(r), req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeResponse(w, resp)
+	}))
+
+	// GET|POST /api/admin/roles - Danh sách / tạo role tùy chỉnh (role.manage)
+	http.HandleFunc("/api/admin/roles", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
+		}
+
+		var resp events.APIGatewayProxyResponse
+		switch r.Method {
+		case http.MethodGet:
+			resp, err = authH.HandleListRoles(requestContext(r), req)
+		case http.MethodPost:
+			resp, err = authH.HandleCreateRole(requestContext(r), req)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeResponse(w, resp)
+	}))
+
+	// PUT|DELETE /api/admin/roles/{name} - Sửa / xóa role tùy chỉnh (role.manage)
+	http.HandleFunc("/api/admin/roles/{name}", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
+		}
+		req.PathParameters = map[string]string{"name": r.PathValue("name")}
+
+		var resp events.APIGatewayProxyResponse
+		switch r.Method {
 		case http.MethodPut:
-			resp, err = authH.HandleAdminUpdateUser(context.Background(), req)
+			resp, err = authH.HandleUpdateRole(requestContext(r), req)
 		case http.MethodDelete:
-			resp, err = authH.HandleAdminDeleteUser(context.Background(), req)
+			resp, err = authH.HandleDeleteRole(requestContext(r), req)
 		default:
 			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 			return
@@ -316,6 +589,49 @@ func main() {
 		writeResponse(w, resp)
 	}))
 
+	// GET /api/admin/abuse/reviews?status=PENDING - Hàng chờ xét duyệt tài khoản bị cách ly (abuse.review)
+	http.HandleFunc("/api/admin/abuse/reviews", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
+		}
+
+		resp, err := authH.HandleListAccountReviews(requestContext(r), req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeResponse(w, resp)
+	}))
+
+	// POST /api/admin/abuse/reviews/{id} - Duyệt (APPROVE) / từ chối (REJECT) tài khoản bị cách ly (abuse.review)
+	http.HandleFunc("/api/admin/abuse/reviews/{id}", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
+		}
+		req.PathParameters = map[string]string{"id": r.PathValue("id")}
+
+		resp, err := authH.HandleResolveAccountReview(requestContext(r), req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeResponse(w, resp)
+	}))
+
 	// GET /api/users/staff-organizer - Get STAFF & ORGANIZER users (Admin only)
 	http.HandleFunc("/api/users/staff-organizer", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodGet {
@@ -329,7 +645,7 @@ func main() {
 			return
 		}
 
-		resp, err := authH.HandleGetStaffOrganizer(context.Background(), req)
+		resp, err := authH.HandleGetStaffOrganizer(requestContext(r), req)
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
@@ -351,7 +667,7 @@ func main() {
 			return
 		}
 
-		resp, err := authH.HandleForgotPassword(context.Background(), req)
+		resp, err := authH.HandleForgotPassword(requestContext(r), req)
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
@@ -373,7 +689,7 @@ func main() {
 			return
 		}
 
-		resp, err := authH.HandleResetPassword(context.Background(), req)
+		resp, err := authH.HandleResetPassword(requestContext(r), req)
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
@@ -385,9 +701,10 @@ func main() {
 	// ======================= EVENT ROUTES =======================
 
 	// GET /api/events - Get all events (with optional filters)
-	// ✅ CHANGED: Use authMiddleware to extract JWT and set X-User-Id, X-User-Role headers
+	// ETag theo nội dung: client poll gửi If-None-Match, không đổi → 304 (httpcache.ETag)
+	// ✅ CHANGED: Use authMiddleware to extract JWT and put userID/role into the context (authctx)
 	// This enables permission filtering: ORGANIZER sees only their events
-	http.HandleFunc("/api/events", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+	http.HandleFunc("/api/events", httpcache.ETag(authMiddleware(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodGet {
 			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 			return
@@ -399,39 +716,147 @@ func main() {
 			return
 		}
 
-		resp, err := eventH.HandleGetEvents(context.Background(), req)
+		resp, err := eventH.HandleGetEvents(requestContext(r), req)
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
 
 		writeResponse(w, resp)
-	}))
+	})))
 
 	// GET /api/events/open - Get only OPEN events (public)
-	http.HandleFunc("/api/events/open", corsMiddleware(func(w http.ResponseWriter, r *http.Request) {
+	// ETag theo nội dung: client poll gửi If-None-Match, không đổi → 304 (httpcache.ETag)
+	http.HandleFunc("/api/events/open", httpcache.ETag(corsMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
+		}
+
+		resp, err := eventH.HandleGetOpenEvents(requestContext(r), req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		writeResponse(w, resp)
+	})))
+
+	// GET /api/public/events.rss | events.json | sitemap.xml - Feed sự kiện OPEN cho cổng trường / máy tìm kiếm
+	publicFeed := func(format string) http.HandlerFunc {
+		return corsMiddleware(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodGet && r.Method != http.MethodHead {
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+				return
+			}
+			req, err := adaptRequest(r)
+			if err != nil {
+				http.Error(w, "Failed to read request", http.StatusBadRequest)
+				return
+			}
+			resp, err := eventH.HandleGetPublicEventFeed(requestContext(r), req, format)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			writeResponse(w, resp)
+		})
+	}
+	http.HandleFunc("/api/public/events.rss", publicFeed(eventUsecase.FeedRSS))
+	http.HandleFunc("/api/public/events.json", publicFeed(eventUsecase.FeedJSON))
+	http.HandleFunc("/api/public/sitemap.xml", publicFeed(eventUsecase.FeedSitemap))
+
+	// GET /api/meta/statuses - Bảng nhãn trạng thái (vi/en + gợi ý màu) để client cache
+	http.HandleFunc("/api/meta/statuses", httpcache.ETag(corsMiddleware(statuslabel.Handler())))
+
+	// GET /api/widget/events?key=...&organizerId=... - Widget nhúng sự kiện của CLB (CORS theo API key)
+	http.HandleFunc("/api/widget/events", widgetCORSMiddleware(eventH.AllowWidgetOrigin, func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodGet {
 			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 			return
 		}
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
+		}
+		resp, err := eventH.HandleGetWidgetEvents(requestContext(r), req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeResponse(w, resp)
+	}))
 
+	// GET|POST /api/organizer/widget-keys - API key của widget (ORGANIZER)
+	http.HandleFunc("/api/organizer/widget-keys", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodPost {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
 		req, err := adaptRequest(r)
 		if err != nil {
 			http.Error(w, "Failed to read request", http.StatusBadRequest)
 			return
 		}
+		resp, err := eventH.HandleWidgetKeys(requestContext(r), req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeResponse(w, resp)
+	}))
 
-		resp, err := eventH.HandleGetOpenEvents(context.Background(), req)
+	// PUT|DELETE /api/organizer/widget-keys/{keyId} - Sửa origin / thu hồi key
+	http.HandleFunc("/api/organizer/widget-keys/{keyId}", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut && r.Method != http.MethodDelete {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
+		}
+		req.PathParameters = map[string]string{"keyId": r.PathValue("keyId")}
+		resp, err := eventH.HandleWidgetKey(requestContext(r), req)
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
+		writeResponse(w, resp)
+	}))
 
+	// GET /api/public/events/{slug} - Microsite public của sự kiện (không cần đăng nhập)
+	http.HandleFunc("/api/public/events/{slug}", corsMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
+		}
+		req.PathParameters = map[string]string{"slug": r.PathValue("slug")}
+		resp, err := eventH.HandleGetPublicEvent(requestContext(r), req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
 		writeResponse(w, resp)
 	}))
 
 	// GET /api/events/detail?id={eventId} - Get event by ID (khớp với Java)
-	http.HandleFunc("/api/events/detail", corsMiddleware(func(w http.ResponseWriter, r *http.Request) {
+	// ETag theo nội dung: client poll gửi If-None-Match, không đổi → 304 (httpcache.ETag)
+	// Không bắt buộc đăng nhập; token (nếu có) quyết định bản đầy đủ hay bản public
+	http.HandleFunc("/api/events/detail", httpcache.ETag(authMiddleware(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodGet {
 			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 			return
@@ -443,14 +868,14 @@ func main() {
 			return
 		}
 
-		resp, err := eventH.HandleGetEventDetail(context.Background(), req)
+		resp, err := eventH.HandleGetEventDetail(requestContext(r), req)
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
 
 		writeResponse(w, resp)
-	}))
+	})))
 
 	// ======================= EVENT REQUEST ROUTES =======================
 
@@ -465,7 +890,7 @@ func main() {
 		var resp events.APIGatewayProxyResponse
 		switch r.Method {
 		case http.MethodPost:
-			resp, err = eventH.HandleCreateEventRequest(context.Background(), req)
+			resp, err = eventH.HandleCreateEventRequest(requestContext(r), req)
 		default:
 			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 			return
@@ -492,7 +917,7 @@ func main() {
 			return
 		}
 
-		resp, err := eventH.HandleGetMyEventRequests(context.Background(), req)
+		resp, err := eventH.HandleGetMyEventRequests(requestContext(r), req)
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
@@ -514,7 +939,7 @@ func main() {
 			return
 		}
 
-		resp, err := eventH.HandleGetMyActiveEventRequests(context.Background(), req)
+		resp, err := eventH.HandleGetMyActiveEventRequests(requestContext(r), req)
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
@@ -536,7 +961,7 @@ func main() {
 			return
 		}
 
-		resp, err := eventH.HandleGetMyArchivedEventRequests(context.Background(), req)
+		resp, err := eventH.HandleGetMyArchivedEventRequests(requestContext(r), req)
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
@@ -557,7 +982,7 @@ func main() {
 			return
 		}
 
-		resp, err := eventH.HandleGetPendingEventRequests(context.Background(), req)
+		resp, err := eventH.HandleGetPendingEventRequests(requestContext(r), req)
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
@@ -578,7 +1003,7 @@ func main() {
 			return
 		}
 
-		resp, err := eventH.HandleProcessEventRequest(context.Background(), req)
+		resp, err := eventH.HandleProcessEventRequest(requestContext(r), req)
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
@@ -599,7 +1024,7 @@ func main() {
 			return
 		}
 
-		resp, err := eventH.HandleUpdateEventRequest(context.Background(), req)
+		resp, err := eventH.HandleUpdateEventRequest(requestContext(r), req)
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
@@ -640,7 +1065,7 @@ func main() {
 				"id": requestID,
 			}
 
-			resp, err := eventH.HandleGetEventRequestByID(context.Background(), req)
+			resp, err := eventH.HandleGetEventRequestByID(requestContext(r), req)
 			if err != nil {
 				http.Error(w, err.Error(), http.StatusInternalServerError)
 				return
@@ -649,69 +1074,64 @@ func main() {
 		})(w, r)
 	})
 
-	// POST /api/events/update-details - Organizer cập nhật chi tiết sự kiện (KHỚP JAVA)
-	http.HandleFunc("/api/events/update-details", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
-		fmt.Println("=== Received update-details request ===")
+	// POST /api/event-requests/from-template/{id} - Tạo yêu cầu từ mẫu sự kiện (ORGANIZER)
+	// Đăng ký dạng {id}/{action} (ít cụ thể hơn {id}/clone, {id}/budget): pattern "from-template/{id}"
+	// bị ServeMux coi là trùng với "{id}/clone" ở đường dẫn from-template/clone
+	http.HandleFunc("/api/event-requests/{id}/{action}", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.PathValue("id") != "from-template" {
+			http.NotFound(w, r)
+			return
+		}
 		if r.Method != http.MethodPost {
 			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 			return
 		}
-
 		req, err := adaptRequest(r)
 		if err != nil {
-			fmt.Printf("ERROR: Failed to read request: %v\n", err)
 			http.Error(w, "Failed to read request", http.StatusBadRequest)
 			return
 		}
-
-		fmt.Printf("Request body: %s\n", req.Body)
-		resp, err := eventH.HandleUpdateEventDetails(context.Background(), req)
+		req.PathParameters = map[string]string{"id": r.PathValue("action")}
+		resp, err := eventH.HandleCreateEventRequestFromTemplate(requestContext(r), req)
 		if err != nil {
-			fmt.Printf("ERROR: HandleUpdateEventDetails failed: %v\n", err)
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
-		fmt.Println("SUCCESS: Event updated successfully")
 		writeResponse(w, resp)
 	}))
 
-	// POST /api/events/update-config - Cập nhật cấu hình check-in/out (ADMIN/ORGANIZER)
-	http.HandleFunc("/api/events/update-config", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodPost {
+	// GET|POST /api/event-templates - Thư viện mẫu sự kiện (ORGANIZER, ADMIN)
+	http.HandleFunc("/api/event-templates", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodPost {
 			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 			return
 		}
-
 		req, err := adaptRequest(r)
 		if err != nil {
-			fmt.Printf("ERROR: Failed to read request: %v\n", err)
 			http.Error(w, "Failed to read request", http.StatusBadRequest)
 			return
 		}
-
-		resp, err := eventH.HandleUpdateEventConfig(context.Background(), req)
+		resp, err := eventH.HandleEventTemplates(requestContext(r), req)
 		if err != nil {
-			fmt.Printf("ERROR: HandleUpdateEventConfig failed: %v\n", err)
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
 		writeResponse(w, resp)
 	}))
 
-	// GET /api/events/config - Lấy cấu hình check-in/out hiện tại
-	http.HandleFunc("/api/events/config", corsMiddleware(func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodGet {
+	// PUT|DELETE /api/event-templates/{id} - Sửa / lưu trữ mẫu
+	http.HandleFunc("/api/event-templates/{id}", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut && r.Method != http.MethodDelete {
 			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 			return
 		}
-
 		req, err := adaptRequest(r)
 		if err != nil {
 			http.Error(w, "Failed to read request", http.StatusBadRequest)
 			return
 		}
-
-		resp, err := eventH.HandleGetEventConfig(context.Background(), req)
+		req.PathParameters = map[string]string{"id": r.PathValue("id")}
+		resp, err := eventH.HandleEventTemplate(requestContext(r), req)
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
@@ -719,9 +1139,9 @@ func main() {
 		writeResponse(w, resp)
 	}))
 
-	// GET /api/events/stats - Thống kê sự kiện
-	http.HandleFunc("/api/events/stats", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodGet {
+	// POST /api/event-requests/{id}/clone - Nhân bản yêu cầu sự kiện với ngày mới (ORGANIZER)
+	http.HandleFunc("/api/event-requests/{id}/clone", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
 			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 			return
 		}
@@ -731,8 +1151,11 @@ func main() {
 			http.Error(w, "Failed to read request", http.StatusBadRequest)
 			return
 		}
+		req.PathParameters = map[string]string{
+			"id": r.PathValue("id"),
+		}
 
-		resp, err := eventH.HandleGetEventStats(context.Background(), req)
+		resp, err := eventH.HandleCloneEventRequest(requestContext(r), req)
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
@@ -740,21 +1163,19 @@ func main() {
 		writeResponse(w, resp)
 	}))
 
-	// GET /api/events/available-areas?startTime=...&endTime=... - Danh sách địa điểm trống
-	// 💡 YÊU CẦU #4: Gợi ý địa điểm trống cho Staff khi chọn
-	http.HandleFunc("/api/events/available-areas", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodGet {
+	// GET|PUT /api/event-requests/{id}/budget - Ngân sách của yêu cầu (GET: người gửi, STAFF, ADMIN; PUT: người gửi khi PENDING)
+	http.HandleFunc("/api/event-requests/{id}/budget", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodPut {
 			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 			return
 		}
-
 		req, err := adaptRequest(r)
 		if err != nil {
 			http.Error(w, "Failed to read request", http.StatusBadRequest)
 			return
 		}
-
-		resp, err := eventH.HandleGetAvailableAreas(context.Background(), req)
+		req.PathParameters = map[string]string{"id": r.PathValue("id")}
+		resp, err := eventH.HandleEventRequestBudget(requestContext(r), req)
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
@@ -762,20 +1183,19 @@ func main() {
 		writeResponse(w, resp)
 	}))
 
-	// POST /api/organizer/events/cancel - Hủy sự kiện (chỉ Organizer)
-	http.HandleFunc("/api/organizer/events/cancel", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+	// POST /api/event-requests/{id}/budget/reviews - Duyệt ngân sách kèm nhận xét (STAFF/ADMIN)
+	http.HandleFunc("/api/event-requests/{id}/budget/reviews", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodPost {
 			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 			return
 		}
-
 		req, err := adaptRequest(r)
 		if err != nil {
 			http.Error(w, "Failed to read request", http.StatusBadRequest)
 			return
 		}
-
-		resp, err := eventH.HandleCancelEvent(context.Background(), req)
+		req.PathParameters = map[string]string{"id": r.PathValue("id")}
+		resp, err := eventH.HandleReviewEventRequestBudget(requestContext(r), req)
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
@@ -783,20 +1203,19 @@ func main() {
 		writeResponse(w, resp)
 	}))
 
-	// GET /api/events/daily-quota?date=YYYY-MM-DD - Kiểm tra hạn ngạch hàng ngày
-	http.HandleFunc("/api/events/daily-quota", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodGet {
+	// POST /api/staff/event-requests/{id}/claim - Nhận xử lý yêu cầu, gọi lại để gia hạn (STAFF/ADMIN)
+	http.HandleFunc("/api/staff/event-requests/{id}/claim", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
 			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 			return
 		}
-
 		req, err := adaptRequest(r)
 		if err != nil {
 			http.Error(w, "Failed to read request", http.StatusBadRequest)
 			return
 		}
-
-		resp, err := eventH.HandleCheckDailyQuota(context.Background(), req)
+		req.PathParameters = map[string]string{"id": r.PathValue("id")}
+		resp, err := eventH.HandleClaimEventRequest(requestContext(r), req)
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
@@ -804,22 +1223,19 @@ func main() {
 		writeResponse(w, resp)
 	}))
 
-	// ======================= TICKET ROUTES =======================
-
-	// GET /api/registrations/my-tickets - Lấy vé của user
-	http.HandleFunc("/api/registrations/my-tickets", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+	// GET /api/staff/event-requests/{id}/suggested-areas - Gợi ý khu vực khi duyệt (STAFF/ADMIN)
+	http.HandleFunc("/api/staff/event-requests/{id}/suggested-areas", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodGet {
 			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 			return
 		}
-
 		req, err := adaptRequest(r)
 		if err != nil {
 			http.Error(w, "Failed to read request", http.StatusBadRequest)
 			return
 		}
-
-		resp, err := ticketH.HandleGetMyTickets(context.Background(), req)
+		req.PathParameters = map[string]string{"id": r.PathValue("id")}
+		resp, err := eventH.HandleGetSuggestedAreas(requestContext(r), req)
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
@@ -827,20 +1243,19 @@ func main() {
 		writeResponse(w, resp)
 	}))
 
-	// GET /api/tickets/list - Lấy danh sách vé (Staff/Admin)
-	http.HandleFunc("/api/tickets/list", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodGet {
+	// POST /api/staff/event-requests/{id}/approve-suggested - Duyệt với khu vực gợi ý đầu tiên (STAFF/ADMIN)
+	http.HandleFunc("/api/staff/event-requests/{id}/approve-suggested", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
 			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 			return
 		}
-
 		req, err := adaptRequest(r)
 		if err != nil {
 			http.Error(w, "Failed to read request", http.StatusBadRequest)
 			return
 		}
-
-		resp, err := ticketH.HandleGetTicketList(context.Background(), req)
+		req.PathParameters = map[string]string{"id": r.PathValue("id")}
+		resp, err := eventH.HandleApproveSuggested(requestContext(r), req)
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
@@ -848,20 +1263,19 @@ func main() {
 		writeResponse(w, resp)
 	}))
 
-	// GET /api/category-tickets - Lấy loại vé của event
-	http.HandleFunc("/api/category-tickets", corsMiddleware(func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodGet {
+	// POST /api/staff/events/{id}/change-area - Chuyển sự kiện đã duyệt sang khu vực khác (STAFF/ADMIN)
+	http.HandleFunc("/api/staff/events/{id}/change-area", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
 			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 			return
 		}
-
 		req, err := adaptRequest(r)
 		if err != nil {
 			http.Error(w, "Failed to read request", http.StatusBadRequest)
 			return
 		}
-
-		resp, err := ticketH.HandleGetCategoryTickets(context.Background(), req)
+		req.PathParameters = map[string]string{"id": r.PathValue("id")}
+		resp, err := eventH.HandleChangeEventArea(requestContext(r), req)
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
@@ -869,20 +1283,19 @@ func main() {
 		writeResponse(w, resp)
 	}))
 
-	// GET /api/bills/my-bills - Lấy hóa đơn của user
-	http.HandleFunc("/api/bills/my-bills", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+	// GET /api/staff/events/{id}/area-changes - Lịch sử đổi khu vực (STAFF/ADMIN)
+	http.HandleFunc("/api/staff/events/{id}/area-changes", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodGet {
 			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 			return
 		}
-
 		req, err := adaptRequest(r)
 		if err != nil {
 			http.Error(w, "Failed to read request", http.StatusBadRequest)
 			return
 		}
-
-		resp, err := ticketH.HandleGetMyBills(context.Background(), req)
+		req.PathParameters = map[string]string{"id": r.PathValue("id")}
+		resp, err := eventH.HandleGetAreaChanges(requestContext(r), req)
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
@@ -890,20 +1303,19 @@ func main() {
 		writeResponse(w, resp)
 	}))
 
-	// GET /api/payment/my-bills - Lấy hóa đơn của user (KHỚP JAVA)
-	http.HandleFunc("/api/payment/my-bills", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodGet {
+	// POST /api/staff/event-requests/{id}/release - Nhả claim (STAFF/ADMIN)
+	http.HandleFunc("/api/staff/event-requests/{id}/release", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
 			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 			return
 		}
-
 		req, err := adaptRequest(r)
 		if err != nil {
 			http.Error(w, "Failed to read request", http.StatusBadRequest)
 			return
 		}
-
-		resp, err := ticketH.HandleGetMyBills(context.Background(), req)
+		req.PathParameters = map[string]string{"id": r.PathValue("id")}
+		resp, err := eventH.HandleReleaseEventRequest(requestContext(r), req)
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
@@ -911,20 +1323,19 @@ func main() {
 		writeResponse(w, resp)
 	}))
 
-	// GET /api/payment-ticket - Tạo URL thanh toán VNPay (KHỚP JAVA)
-	http.HandleFunc("/api/payment-ticket", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodGet {
+	// PUT /api/event-requests/{id}/budget/actuals - Nhập thực chi sau sự kiện (người gửi, ADMIN)
+	http.HandleFunc("/api/event-requests/{id}/budget/actuals", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
 			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 			return
 		}
-
 		req, err := adaptRequest(r)
 		if err != nil {
 			http.Error(w, "Failed to read request", http.StatusBadRequest)
 			return
 		}
-
-		resp, err := ticketH.HandlePaymentTicket(context.Background(), req)
+		req.PathParameters = map[string]string{"id": r.PathValue("id")}
+		resp, err := eventH.HandleEventRequestBudgetActuals(requestContext(r), req)
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
@@ -932,30 +1343,36 @@ func main() {
 		writeResponse(w, resp)
 	}))
 
-	// GET /api/buyTicket - VNPay return URL (KHỚP JAVA)
-	http.HandleFunc("/api/buyTicket", corsMiddleware(func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodGet {
+	// POST /api/events/update-details - Organizer cập nhật chi tiết sự kiện (KHỚP JAVA)
+	capability.Default.Require("POST /api/events/update-details", eventRepository.CapEventUpdateDetails)
+	http.HandleFunc("/api/events/update-details", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Println("=== Received update-details request ===")
+		if r.Method != http.MethodPost {
 			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 			return
 		}
 
 		req, err := adaptRequest(r)
 		if err != nil {
+			fmt.Printf("ERROR: Failed to read request: %v\n", err)
 			http.Error(w, "Failed to read request", http.StatusBadRequest)
 			return
 		}
 
-		resp, err := ticketH.HandleBuyTicket(context.Background(), req)
+		fmt.Printf("Request body: %s\n", req.Body)
+		resp, err := eventH.HandleUpdateEventDetails(requestContext(r), req)
 		if err != nil {
+			fmt.Printf("ERROR: HandleUpdateEventDetails failed: %v\n", err)
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
+		fmt.Println("SUCCESS: Event updated successfully")
 		writeResponse(w, resp)
 	}))
 
-	// GET /api/wallet/balance - Get user's wallet balance
-	http.HandleFunc("/api/wallet/balance", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodGet {
+	// POST /api/events/{id}/banner - Upload banner + sinh thumbnail/card/hero (ORGANIZER/ADMIN)
+	http.HandleFunc("/api/events/{id}/banner", httpguard.WithPolicy(uploadPolicy, authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
 			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 			return
 		}
@@ -965,17 +1382,30 @@ func main() {
 			http.Error(w, "Failed to read request", http.StatusBadRequest)
 			return
 		}
+		req.PathParameters = map[string]string{
+			"id": r.PathValue("id"),
+		}
 
-		resp, err := ticketH.HandleGetWalletBalance(context.Background(), req)
+		resp, err := eventH.HandleUploadEventBanner(requestContext(r), req)
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
 		writeResponse(w, resp)
-	}))
+	})))
 
-	// POST /api/wallet/pay-ticket - Pay ticket with wallet (internal balance)
-	http.HandleFunc("/api/wallet/pay-ticket", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+	// Serve banner đã upload khi dùng local storage (STORAGE_DRIVER=local)
+	if !strings.EqualFold(os.Getenv("STORAGE_DRIVER"), "s3") {
+		uploadDir := getEnv("UPLOAD_DIR", "uploads")
+		uploadBaseURL := strings.TrimRight(getEnv("UPLOAD_BASE_URL", "/uploads"), "/") + "/"
+		if strings.HasPrefix(uploadBaseURL, "/") {
+			http.Handle(uploadBaseURL, http.StripPrefix(uploadBaseURL, http.FileServer(http.Dir(uploadDir))))
+		}
+	}
+
+	// POST /api/events/update-config - Cập nhật cấu hình check-in/out (ADMIN/ORGANIZER)
+	capability.Default.Require("POST /api/events/update-config", eventRepository.CapEventConfigPerEventSet)
+	http.HandleFunc("/api/events/update-config", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodPost {
 			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 			return
@@ -983,43 +1413,35 @@ func main() {
 
 		req, err := adaptRequest(r)
 		if err != nil {
+			fmt.Printf("ERROR: Failed to read request: %v\n", err)
 			http.Error(w, "Failed to read request", http.StatusBadRequest)
 			return
 		}
 
-		resp, err := ticketH.HandleWalletPayTicket(context.Background(), req)
+		resp, err := eventH.HandleUpdateEventConfig(requestContext(r), req)
 		if err != nil {
+			fmt.Printf("ERROR: HandleUpdateEventConfig failed: %v\n", err)
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
 		writeResponse(w, resp)
 	}))
 
-	// ======================= VENUE ROUTES =======================
+	// GET /api/events/config - Lấy cấu hình check-in/out hiện tại
+	capability.Default.Require("GET /api/events/config", eventRepository.CapEventConfigPerEventGet)
+	http.HandleFunc("/api/events/config", corsMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
 
-	// GET /api/venues - Lấy danh sách venues (CRUD)
-	http.HandleFunc("/api/venues", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
 		req, err := adaptRequest(r)
 		if err != nil {
 			http.Error(w, "Failed to read request", http.StatusBadRequest)
 			return
 		}
 
-		var resp events.APIGatewayProxyResponse
-		switch r.Method {
-		case http.MethodGet:
-			resp, err = venueH.HandleGetVenues(context.Background(), req)
-		case http.MethodPost:
-			resp, err = venueH.HandleCreateVenue(context.Background(), req)
-		case http.MethodPut:
-			resp, err = venueH.HandleUpdateVenue(context.Background(), req)
-		case http.MethodDelete:
-			resp, err = venueH.HandleDeleteVenue(context.Background(), req)
-		default:
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-			return
-		}
-
+		resp, err := eventH.HandleGetEventConfig(requestContext(r), req)
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
@@ -1027,29 +1449,20 @@ func main() {
 		writeResponse(w, resp)
 	}))
 
-	// /api/venues/areas - CRUD cho Venue Areas (KHỚP JAVA)
-	http.HandleFunc("/api/venues/areas", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
-		req, err := adaptRequest(r)
-		if err != nil {
-			http.Error(w, "Failed to read request", http.StatusBadRequest)
+	// GET /api/events/stats - Thống kê sự kiện
+	http.HandleFunc("/api/events/stats", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 			return
 		}
 
-		var resp events.APIGatewayProxyResponse
-		switch r.Method {
-		case http.MethodGet:
-			resp, err = venueH.HandleGetAreas(context.Background(), req)
-		case http.MethodPost:
-			resp, err = venueH.HandleCreateArea(context.Background(), req)
-		case http.MethodPut:
-			resp, err = venueH.HandleUpdateArea(context.Background(), req)
-		case http.MethodDelete:
-			resp, err = venueH.HandleDeleteArea(context.Background(), req)
-		default:
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
 			return
 		}
 
+		resp, err := eventH.HandleGetEventStats(requestContext(r), req)
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
@@ -1057,9 +1470,10 @@ func main() {
 		writeResponse(w, resp)
 	}))
 
-	// GET /api/areas/free - Lấy khu vực còn trống
-	http.HandleFunc("/api/areas/free", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodGet {
+	// POST /api/events/disable?id= - Admin vô hiệu hóa sự kiện (ngừng bán, giải phóng vé PENDING)
+	capability.Default.Require("POST /api/events/disable", eventRepository.CapEventAreaRelease)
+	http.HandleFunc("/api/events/disable", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
 			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 			return
 		}
@@ -1070,7 +1484,7 @@ func main() {
 			return
 		}
 
-		resp, err := venueH.HandleGetFreeAreas(context.Background(), req)
+		resp, err := eventH.HandleDisableEvent(requestContext(r), req)
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
@@ -1078,8 +1492,9 @@ func main() {
 		writeResponse(w, resp)
 	}))
 
-	// GET /api/seats - Lấy danh sách ghế
-	http.HandleFunc("/api/seats", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+	// GET /api/events/available-areas?startTime=...&endTime=... - Danh sách địa điểm trống
+	// 💡 YÊU CẦU #4: Gợi ý địa điểm trống cho Staff khi chọn
+	http.HandleFunc("/api/events/available-areas", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodGet {
 			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 			return
@@ -1091,7 +1506,7 @@ func main() {
 			return
 		}
 
-		resp, err := venueH.HandleGetSeats(context.Background(), req)
+		resp, err := eventH.HandleGetAvailableAreas(requestContext(r), req)
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
@@ -1099,10 +1514,8 @@ func main() {
 		writeResponse(w, resp)
 	}))
 
-	// ======================= STAFF ROUTES =======================
-
-	// POST /api/staff/checkin - Check-in vé
-	http.HandleFunc("/api/staff/checkin", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+	// POST /api/organizer/events/cancel - Hủy sự kiện (chỉ Organizer)
+	http.HandleFunc("/api/organizer/events/cancel", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodPost {
 			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 			return
@@ -1114,7 +1527,7 @@ func main() {
 			return
 		}
 
-		resp, err := staffH.HandleCheckin(context.Background(), req)
+		resp, err := eventH.HandleCancelEvent(requestContext(r), req)
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
@@ -1122,20 +1535,19 @@ func main() {
 		writeResponse(w, resp)
 	}))
 
-	// POST /api/staff/checkout - Check-out vé
-	http.HandleFunc("/api/staff/checkout", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodPost {
+	// GET|POST /api/events/{id}/collaborators - Danh sách / mời co-organizer (chủ sự kiện, ADMIN)
+	http.HandleFunc("/api/events/{id}/collaborators", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodPost {
 			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 			return
 		}
-
 		req, err := adaptRequest(r)
 		if err != nil {
 			http.Error(w, "Failed to read request", http.StatusBadRequest)
 			return
 		}
-
-		resp, err := staffH.HandleCheckout(context.Background(), req)
+		req.PathParameters = map[string]string{"id": r.PathValue("id")}
+		resp, err := eventH.HandleEventCollaborators(requestContext(r), req)
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
@@ -1143,20 +1555,19 @@ func main() {
 		writeResponse(w, resp)
 	}))
 
-	// GET /api/staff/reports - Danh sách report
-	http.HandleFunc("/api/staff/reports", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodGet {
+	// POST /api/events/{id}/collaborators/accept - Chấp nhận lời mời đồng tổ chức
+	http.HandleFunc("/api/events/{id}/collaborators/accept", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
 			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 			return
 		}
-
 		req, err := adaptRequest(r)
 		if err != nil {
 			http.Error(w, "Failed to read request", http.StatusBadRequest)
 			return
 		}
-
-		resp, err := staffH.HandleGetReports(context.Background(), req)
+		req.PathParameters = map[string]string{"id": r.PathValue("id")}
+		resp, err := eventH.HandleAcceptCollaboration(requestContext(r), req)
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
@@ -1164,21 +1575,19 @@ func main() {
 		writeResponse(w, resp)
 	}))
 
-	// POST /api/staff/reports/process - APPROVE/REJECT report (⭐ REFUND LOGIC)
-	reportH := staffHandler.NewReportHandler()
-	http.HandleFunc("/api/staff/reports/process", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodPost {
+	// DELETE /api/events/{id}/collaborators/{userId} - Gỡ co-organizer / tự rời đội tổ chức
+	http.HandleFunc("/api/events/{id}/collaborators/{userId}", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
 			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 			return
 		}
-
 		req, err := adaptRequest(r)
 		if err != nil {
 			http.Error(w, "Failed to read request", http.StatusBadRequest)
 			return
 		}
-
-		resp, err := reportH.HandleProcessReport(context.Background(), req)
+		req.PathParameters = map[string]string{"id": r.PathValue("id"), "userId": r.PathValue("userId")}
+		resp, err := eventH.HandleRemoveCollaborator(requestContext(r), req)
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
@@ -1186,20 +1595,19 @@ func main() {
 		writeResponse(w, resp)
 	}))
 
-	// GET /api/staff/reports/detail - Chi tiết report
-	http.HandleFunc("/api/staff/reports/detail", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodGet {
+	// GET|PUT /api/events/{id}/ticket-template - Cấu hình vé PDF (ADMIN, organizer có quyền EDIT_DETAILS)
+	http.HandleFunc("/api/events/{id}/ticket-template", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodPut {
 			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 			return
 		}
-
 		req, err := adaptRequest(r)
 		if err != nil {
 			http.Error(w, "Failed to read request", http.StatusBadRequest)
 			return
 		}
-
-		resp, err := reportH.HandleGetReportDetail(context.Background(), req)
+		req.PathParameters = map[string]string{"id": r.PathValue("id")}
+		resp, err := eventH.HandleEventTicketTemplate(requestContext(r), req)
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
@@ -1207,32 +1615,39 @@ func main() {
 		writeResponse(w, resp)
 	}))
 
-	// GET /api/staff/reports/{id} - Chi tiết report (alternative route)
-	http.HandleFunc("/api/staff/reports/", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodGet {
+	// GET|PUT /api/events/{id}/checkin-alerts - Ngưỡng cảnh báo check-in theo % sức chứa (ADMIN, organizer có quyền EDIT_DETAILS)
+	http.HandleFunc("/api/events/{id}/checkin-alerts", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodPut {
 			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 			return
 		}
-
-		// Extract report ID from path
-		pathParts := strings.Split(strings.TrimPrefix(r.URL.Path, "/api/staff/reports/"), "/")
-		if len(pathParts) == 0 || pathParts[0] == "" {
-			http.Error(w, "Missing report ID", http.StatusBadRequest)
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
+		}
+		req.PathParameters = map[string]string{"id": r.PathValue("id")}
+		resp, err := eventH.HandleEventCheckinAlerts(requestContext(r), req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
+		writeResponse(w, resp)
+	}))
 
+	// GET|PUT /api/events/{id}/cashback - Cashback tham dự khi check-out (ADMIN, organizer có quyền EDIT_DETAILS)
+	http.HandleFunc("/api/events/{id}/cashback", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodPut {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
 		req, err := adaptRequest(r)
 		if err != nil {
 			http.Error(w, "Failed to read request", http.StatusBadRequest)
 			return
 		}
-
-		// Add path parameter
-		req.PathParameters = map[string]string{
-			"id": pathParts[0],
-		}
-
-		resp, err := staffH.HandleGetReportDetail(context.Background(), req)
+		req.PathParameters = map[string]string{"id": r.PathValue("id")}
+		resp, err := eventH.HandleEventCashback(requestContext(r), req)
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
@@ -1240,232 +1655,2350 @@ func main() {
 		writeResponse(w, resp)
 	}))
 
-	// ======================= STUDENT REPORT ROUTES =======================
-
-	// POST /api/student/reports - Submit error report for checked-in ticket
-	http.HandleFunc("/api/student/reports", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
-		if r.Method == http.MethodOptions {
-			w.WriteHeader(http.StatusOK)
+	// GET|PUT /api/events/{id}/dynamic-qr - Bật QR động cho check-in (ADMIN, organizer có quyền EDIT_DETAILS)
+	http.HandleFunc("/api/events/{id}/dynamic-qr", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodPut {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 			return
 		}
-
-		if r.Method != http.MethodPost {
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
 			return
 		}
-
-		w.Header().Set("Content-Type", "application/json;charset=UTF-8")
-
-		// Extract user ID from Context (set by authMiddleware)
-		userID, ok := r.Context().Value("userID").(int)
-		if !ok || userID <= 0 {
-			log.Printf("[ERROR] ReportHandler: Cannot find userID in context")
-			w.WriteHeader(http.StatusUnauthorized)
-			fmt.Fprintf(w, `{"status":"fail","message":"Unauthorized: missing user ID"}`)
+		req.PathParameters = map[string]string{"id": r.PathValue("id")}
+		resp, err := eventH.HandleEventDynamicQR(requestContext(r), req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
-		log.Printf("[REPORT] Retrieved userID=%d from Context", userID)
+		writeResponse(w, resp)
+	}))
 
-		// Extract user role from Context (set by authMiddleware)
-		userRole, ok := r.Context().Value("userRole").(string)
-		if !ok || userRole != "STUDENT" {
-			log.Printf("[ERROR] ReportHandler: Invalid role. Got: %s", userRole)
-			w.WriteHeader(http.StatusForbidden)
-			fmt.Fprintf(w, `{"status":"fail","message":"Only students can submit reports"}`)
+	// GET|PUT /api/events/{id}/booking-queue - Hàng chờ mở bán flash sale (ADMIN, organizer có quyền EDIT_DETAILS)
+	http.HandleFunc("/api/events/{id}/booking-queue", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodPut {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 			return
 		}
-
-		// Parse JSON body
-		var reportBody struct {
-			TicketId    int    `json:"ticketId"`
-			Title       string `json:"title"`
-			Description string `json:"description"`
-			ImageUrl    string `json:"imageUrl"`
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
 		}
-
-		if err := json.NewDecoder(r.Body).Decode(&reportBody); err != nil {
-			w.WriteHeader(http.StatusBadRequest)
-			fmt.Fprintf(w, `{"status":"fail","message":"Invalid JSON"}`)
+		req.PathParameters = map[string]string{"id": r.PathValue("id")}
+		resp, err := eventH.HandleEventBookingQueue(requestContext(r), req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
+		writeResponse(w, resp)
+	}))
 
-		log.Printf("[REPORT REQUEST] TicketID: %d | UserID: %d | Title: '%s' | Description: '%s'",
-			reportBody.TicketId, userID, reportBody.Title, reportBody.Description)
-
-		// Validate input
-		if reportBody.TicketId <= 0 {
-			w.WriteHeader(http.StatusBadRequest)
-			fmt.Fprintf(w, `{"status":"fail","message":"Invalid ticketId"}`)
+	// POST|GET /api/events/{id}/queue - Vào hàng chờ mở bán / hỏi vị trí theo ?token= (user đăng nhập)
+	http.HandleFunc("/api/events/{id}/queue", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost && r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 			return
 		}
-
-		if strings.TrimSpace(reportBody.Description) == "" {
-			w.WriteHeader(http.StatusBadRequest)
-			fmt.Fprintf(w, `{"status":"fail","message":"Description is required"}`)
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
 			return
 		}
+		req.PathParameters = map[string]string{"id": r.PathValue("id")}
+		resp, err := ticketH.HandleBookingQueue(requestContext(r), req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeResponse(w, resp)
+	}))
+
+	// GET|PUT /api/events/{id}/activity-points - Điểm rèn luyện của sự kiện (STAFF/ADMIN, organizer có quyền EDIT_DETAILS)
+	http.HandleFunc("/api/events/{id}/activity-points", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodPut {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
+		}
+		req.PathParameters = map[string]string{"id": r.PathValue("id")}
+		resp, err := eventH.HandleEventActivityPoints(requestContext(r), req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeResponse(w, resp)
+	}))
+
+	// GET|PUT /api/events/{id}/seat-prices - Giá riêng từng ghế trong loại vé (ADMIN, organizer có quyền EDIT_DETAILS)
+	http.HandleFunc("/api/events/{id}/seat-prices", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodPut {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
+		}
+		req.PathParameters = map[string]string{"id": r.PathValue("id")}
+		resp, err := eventH.HandleEventSeatPrices(requestContext(r), req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeResponse(w, resp)
+	}))
+
+	// GET|PUT|DELETE /api/events/{id}/installment-plan - Cấu hình trả góp (GET công khai; PUT/DELETE organizer của sự kiện)
+	http.HandleFunc("/api/events/{id}/installment-plan", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodPut && r.Method != http.MethodDelete {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
+		}
+		req.PathParameters = map[string]string{"id": r.PathValue("id")}
+		resp, err := ticketH.HandleInstallmentPlan(requestContext(r), req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeResponse(w, resp)
+	}))
+
+	// GET|POST /api/events/{id}/attachments - Tài liệu đính kèm sự kiện (ADMIN, organizer có quyền EDIT_DETAILS)
+	http.HandleFunc("/api/events/{id}/attachments", httpguard.WithPolicy(attachmentPolicy, authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodPost {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
+		}
+		req.PathParameters = map[string]string{"id": r.PathValue("id")}
+		resp, err := eventH.HandleEventAttachments(requestContext(r), req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeResponse(w, resp)
+	})))
+
+	// PUT|DELETE /api/events/{id}/attachments/{attachmentId} - Sửa / gỡ tài liệu đính kèm
+	http.HandleFunc("/api/events/{id}/attachments/{attachmentId}", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut && r.Method != http.MethodDelete {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
+		}
+		req.PathParameters = map[string]string{"id": r.PathValue("id"), "attachmentId": r.PathValue("attachmentId")}
+		resp, err := eventH.HandleEventAttachment(requestContext(r), req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeResponse(w, resp)
+	}))
+
+	// POST /api/events/{id}/speaker/invite - Mời speaker liên kết tài khoản (ADMIN, organizer có quyền EDIT_DETAILS)
+	http.HandleFunc("/api/events/{id}/speaker/invite", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
+		}
+		req.PathParameters = map[string]string{"id": r.PathValue("id")}
+		resp, err := eventH.HandleInviteSpeaker(requestContext(r), req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeResponse(w, resp)
+	}))
+
+	// POST /api/speaker/invitations/accept - Nhận lời mời speaker bằng token trong email (không cần đăng nhập)
+	http.HandleFunc("/api/speaker/invitations/accept", corsMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
+		}
+		resp, err := eventH.HandleAcceptSpeakerInvitation(requestContext(r), req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeResponse(w, resp)
+	}))
+
+	// GET /api/speaker/my-events - Sự kiện của speaker hiện tại
+	http.HandleFunc("/api/speaker/my-events", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
+		}
+		resp, err := eventH.HandleGetSpeakerEvents(requestContext(r), req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeResponse(w, resp)
+	}))
+
+	// GET|PUT /api/speaker/profile - Hồ sơ speaker (sửa bio, avatar)
+	http.HandleFunc("/api/speaker/profile", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodPut {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
+		}
+		resp, err := eventH.HandleSpeakerProfile(requestContext(r), req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeResponse(w, resp)
+	}))
+
+	// POST /api/speaker/events/{id}/materials - Speaker đăng tài liệu buổi nói
+	http.HandleFunc("/api/speaker/events/{id}/materials", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
+		}
+		req.PathParameters = map[string]string{"id": r.PathValue("id")}
+		resp, err := eventH.HandleAddSessionMaterial(requestContext(r), req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeResponse(w, resp)
+	}))
+
+	// DELETE /api/speaker/events/{id}/materials/{materialId} - Speaker gỡ tài liệu
+	http.HandleFunc("/api/speaker/events/{id}/materials/{materialId}", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
+		}
+		req.PathParameters = map[string]string{"id": r.PathValue("id"), "materialId": r.PathValue("materialId")}
+		resp, err := eventH.HandleDeleteSessionMaterial(requestContext(r), req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeResponse(w, resp)
+	}))
+
+	// GET|POST /api/events/{id}/sponsors - Nhà tài trợ của sự kiện (GET công khai; POST: ADMIN, organizer có quyền EDIT_DETAILS)
+	http.HandleFunc("/api/events/{id}/sponsors", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodPost {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
+		}
+		req.PathParameters = map[string]string{"id": r.PathValue("id")}
+		resp, err := eventH.HandleEventSponsors(requestContext(r), req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeResponse(w, resp)
+	}))
+
+	// PUT|DELETE /api/events/{id}/sponsors/{sponsorId} - Sửa hạng / gỡ nhà tài trợ
+	http.HandleFunc("/api/events/{id}/sponsors/{sponsorId}", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut && r.Method != http.MethodDelete {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
+		}
+		req.PathParameters = map[string]string{"id": r.PathValue("id"), "sponsorId": r.PathValue("sponsorId")}
+		resp, err := eventH.HandleEventSponsor(requestContext(r), req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeResponse(w, resp)
+	}))
+
+	// GET|PUT /api/events/{id}/survey - Khảo sát sau sự kiện (ADMIN, organizer có quyền EDIT_DETAILS)
+	http.HandleFunc("/api/events/{id}/survey", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodPut {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
+		}
+		req.PathParameters = map[string]string{"id": r.PathValue("id")}
+		resp, err := eventH.HandleEventSurvey(requestContext(r), req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeResponse(w, resp)
+	}))
+
+	// GET /api/events/{id}/survey/results - Kết quả khảo sát (?format=csv để tải file)
+	http.HandleFunc("/api/events/{id}/survey/results", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
+		}
+		req.PathParameters = map[string]string{"id": r.PathValue("id")}
+		resp, err := eventH.HandleEventSurveyResults(requestContext(r), req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeResponse(w, resp)
+	}))
+
+	// GET /api/student/surveys/{eventId} - Khảo sát đang mở cho người đã check-in
+	http.HandleFunc("/api/student/surveys/{eventId}", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
+		}
+		req.PathParameters = map[string]string{"eventId": r.PathValue("eventId")}
+		resp, err := eventH.HandleStudentSurvey(requestContext(r), req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeResponse(w, resp)
+	}))
+
+	// POST /api/student/surveys/{eventId}/responses - Gửi phiếu trả lời (mỗi người một lần)
+	http.HandleFunc("/api/student/surveys/{eventId}/responses", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
+		}
+		req.PathParameters = map[string]string{"eventId": r.PathValue("eventId")}
+		resp, err := eventH.HandleSubmitSurveyResponse(requestContext(r), req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeResponse(w, resp)
+	}))
+
+	// GET|POST /api/organizer/events/{id}/raffle - Bốc thăm trúng thưởng trong số người đã check-in (ADMIN, organizer có quyền EDIT_DETAILS)
+	http.HandleFunc("/api/organizer/events/{id}/raffle", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodPost {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
+		}
+		req.PathParameters = map[string]string{"id": r.PathValue("id")}
+		resp, err := eventH.HandleEventRaffle(requestContext(r), req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeResponse(w, resp)
+	}))
+
+	// GET|PUT /api/events/{id}/category-tickets/{categoryTicketId}/price-tiers - Bậc giá theo thời gian (early-bird) của loại vé
+	http.HandleFunc("/api/events/{id}/category-tickets/{categoryTicketId}/price-tiers", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodPut {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
+		}
+		req.PathParameters = map[string]string{"id": r.PathValue("id"), "categoryTicketId": r.PathValue("categoryTicketId")}
+		resp, err := eventH.HandlePriceTiers(requestContext(r), req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeResponse(w, resp)
+	}))
+
+	// POST /api/organizer/events/{id}/comp-tickets - Phát vé mời 0 đồng (chủ sự kiện, ADMIN)
+	http.HandleFunc("/api/organizer/events/{id}/comp-tickets", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
+		}
+		req.PathParameters = map[string]string{"id": r.PathValue("id")}
+		resp, err := ticketH.HandleIssueCompTickets(requestContext(r), req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeResponse(w, resp)
+	}))
+
+	// GET|POST /api/passes - Vé học kỳ đang bán (công khai, ?organizerId=) / tạo pass (quyền pass.manage)
+	http.HandleFunc("/api/passes", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodPost {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
+		}
+		resp, err := ticketH.HandlePasses(requestContext(r), req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeResponse(w, resp)
+	}))
+
+	// GET /api/passes/my - Pass đã mua, vé đã cấp và sự kiện còn nhận vé được
+	http.HandleFunc("/api/passes/my", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
+		}
+		resp, err := ticketH.HandleGetMyPasses(requestContext(r), req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeResponse(w, resp)
+	}))
+
+	// GET /api/passes/{id} - Chi tiết pass và các sự kiện thuộc pass (công khai)
+	http.HandleFunc("/api/passes/{id}", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
+		}
+		req.PathParameters = map[string]string{"id": r.PathValue("id")}
+		resp, err := ticketH.HandleGetPass(requestContext(r), req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeResponse(w, resp)
+	}))
+
+	// PUT /api/passes/{id}/status - Bật / ngừng bán pass (chủ pass, ADMIN)
+	http.HandleFunc("/api/passes/{id}/status", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
+		}
+		req.PathParameters = map[string]string{"id": r.PathValue("id")}
+		resp, err := ticketH.HandleUpdatePassStatus(requestContext(r), req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeResponse(w, resp)
+	}))
+
+	// POST /api/passes/{id}/purchase - Mua pass bằng ví hoặc VNPay
+	http.HandleFunc("/api/passes/{id}/purchase", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
+		}
+		req.PathParameters = map[string]string{"id": r.PathValue("id")}
+		resp, err := ticketH.HandlePurchasePass(requestContext(r), req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeResponse(w, resp)
+	}))
+
+	// POST /api/passes/{id}/claim - Người giữ pass nhận vé cho một sự kiện
+	http.HandleFunc("/api/passes/{id}/claim", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
+		}
+		req.PathParameters = map[string]string{"id": r.PathValue("id")}
+		resp, err := ticketH.HandleClaimPassTicket(requestContext(r), req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeResponse(w, resp)
+	}))
+
+	// POST /api/passes/{id}/holders/{holderId}/revoke - Thu hồi pass của một người giữ (chủ pass, ADMIN)
+	http.HandleFunc("/api/passes/{id}/holders/{holderId}/revoke", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
+		}
+		req.PathParameters = map[string]string{"id": r.PathValue("id"), "holderId": r.PathValue("holderId")}
+		resp, err := ticketH.HandleRevokePassHolder(requestContext(r), req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeResponse(w, resp)
+	}))
+
+	// GET /api/organizer/collaborations - Lời mời đồng tổ chức đang chờ
+	http.HandleFunc("/api/organizer/collaborations", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
+		}
+		resp, err := eventH.HandleGetCollaborationInvitations(requestContext(r), req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeResponse(w, resp)
+	}))
+
+	// GET /api/organizer/settlements - Đối soát doanh thu của organizer theo kỳ (?status=)
+	http.HandleFunc("/api/organizer/settlements", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
+		}
+		resp, err := eventH.HandleOrganizerSettlements(requestContext(r), req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeResponse(w, resp)
+	}))
+
+	// GET /api/organizer/settlements/{id} - Chi tiết đối soát theo sự kiện (?format=csv: bảng kê)
+	http.HandleFunc("/api/organizer/settlements/{id}", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
+		}
+		req.PathParameters = map[string]string{"id": r.PathValue("id")}
+		resp, err := eventH.HandleOrganizerSettlement(requestContext(r), req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeResponse(w, resp)
+	}))
+
+	// GET /api/events/daily-quota?date=YYYY-MM-DD - Kiểm tra hạn ngạch hàng ngày
+	http.HandleFunc("/api/events/daily-quota", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
+		}
+
+		resp, err := eventH.HandleCheckDailyQuota(requestContext(r), req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeResponse(w, resp)
+	}))
+
+	// ======================= TICKET ROUTES =======================
+
+	// GET /api/registrations/my-tickets - Lấy vé của user
+	http.HandleFunc("/api/registrations/my-tickets", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
+		}
+
+		resp, err := ticketH.HandleGetMyTickets(requestContext(r), req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeResponse(w, resp)
+	}))
+
+	// GET /api/registrations/my-tickets/grouped - Vé theo sự kiện sắp diễn ra / đã qua + timeline trạng thái
+	http.HandleFunc("/api/registrations/my-tickets/grouped", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
+		}
+
+		resp, err := ticketH.HandleGetMyTicketsGrouped(requestContext(r), req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeResponse(w, resp)
+	}))
+
+	// POST /api/tickets/quote - Báo giá ghế đã chọn (không giữ ghế)
+	http.HandleFunc("/api/tickets/quote", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
+		}
+
+		resp, err := ticketH.HandleQuoteTickets(requestContext(r), req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeResponse(w, resp)
+	}))
+
+	// GET /api/registrations/holds - Vé PENDING đang giữ ghế + thời gian còn lại
+	http.HandleFunc("/api/registrations/holds", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
+		}
+
+		resp, err := ticketH.HandleGetHolds(requestContext(r), req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeResponse(w, resp)
+	}))
+
+	// POST /api/registrations/{ticketId}/resend-email - Tạo lại QR + PDF và gửi lại email vé (chủ vé, có giới hạn)
+	http.HandleFunc("/api/registrations/{ticketId}/resend-email", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
+		}
+		req.PathParameters = map[string]string{"ticketId": r.PathValue("ticketId")}
+		resp, err := ticketH.HandleResendTicketEmail(requestContext(r), req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeResponse(w, resp)
+	}))
+
+	// GET /api/registrations/{ticketId}/qr?size=300 - Ảnh QR (PNG) của vé để hiển thị trong app
+	http.HandleFunc("/api/registrations/{ticketId}/qr", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
+		}
+		req.PathParameters = map[string]string{"ticketId": r.PathValue("ticketId")}
+		resp, err := ticketH.HandleGetTicketQR(requestContext(r), req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeResponse(w, resp)
+	}))
+
+	// GET /api/registrations/{ticketId}/qr-token - Mã QR xoay vòng 30 giây (sự kiện bật QR động, chủ vé)
+	http.HandleFunc("/api/registrations/{ticketId}/qr-token", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
+		}
+		req.PathParameters = map[string]string{"ticketId": r.PathValue("ticketId")}
+		resp, err := ticketH.HandleGetTicketQRToken(requestContext(r), req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeResponse(w, resp)
+	}))
+
+	// POST /api/guest/checkout - Khách không có tài khoản giữ ghế và lấy URL VNPay (sự kiện bật allow_guest_checkout)
+	http.HandleFunc("/api/guest/checkout", corsMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
+		}
+		resp, err := ticketH.HandleGuestCheckout(requestContext(r), req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeResponse(w, resp)
+	}))
+
+	// GET /api/guest/tickets?code=... - Vé của khách theo mã tra cứu trong email (giới hạn theo IP)
+	http.HandleFunc("/api/guest/tickets", corsMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
+		}
+		resp, err := ticketH.HandleGetGuestTickets(requestContext(r), req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeResponse(w, resp)
+	}))
+
+	// POST /api/registrations/holds/extend - Gia hạn giữ ghế (1 lần, +3 phút)
+	http.HandleFunc("/api/registrations/holds/extend", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
+		}
+
+		resp, err := ticketH.HandleExtendHolds(requestContext(r), req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeResponse(w, resp)
+	}))
+
+	// ======================= DASHBOARD ROUTES =======================
+	// GET /api/me/dashboard - Màn hình chính của student trong một lần gọi
+	http.HandleFunc("/api/me/dashboard", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
+		}
+
+		resp, err := dashboardH.HandleGetMyDashboard(requestContext(r), req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeResponse(w, resp)
+	}))
+
+	// GET /api/me/recommendations - Sự kiện gợi ý theo lịch sử tham dự
+	// PUT /api/me/recommendations - {"optOut": true|false} bật / tắt gợi ý
+	http.HandleFunc("/api/me/recommendations", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodPut {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
+		}
+
+		var resp events.APIGatewayProxyResponse
+		if r.Method == http.MethodPut {
+			resp, err = dashboardH.HandleUpdateRecommendationSettings(requestContext(r), req)
+		} else {
+			resp, err = dashboardH.HandleGetMyRecommendations(requestContext(r), req)
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeResponse(w, resp)
+	}))
+
+	// GET /api/me/attendance?semester= - Sự kiện đã tham dự và điểm rèn luyện theo học kỳ
+	http.HandleFunc("/api/me/attendance", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
+		}
+
+		resp, err := dashboardH.HandleGetMyAttendance(requestContext(r), req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeResponse(w, resp)
+	}))
+
+	// GET /api/me/sessions - Phiên đăng nhập còn hiệu lực (thiết bị, IP, lần dùng gần nhất)
+	http.HandleFunc("/api/me/sessions", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
+		}
+
+		resp, err := authH.HandleListSessions(requestContext(r), req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeResponse(w, resp)
+	}))
+
+	// DELETE /api/me/sessions/{id} - Thu hồi một phiên đăng nhập
+	http.HandleFunc("/api/me/sessions/{id}", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
+		}
+		req.PathParameters = map[string]string{"id": r.PathValue("id")}
+
+		resp, err := authH.HandleRevokeSession(requestContext(r), req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeResponse(w, resp)
+	}))
+
+	// GET /api/me/data-export - Xuất dữ liệu cá nhân (ZIP, dựng nền, poll đến khi READY)
+	http.HandleFunc("/api/me/data-export", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
+		}
+
+		resp, err := authH.HandleDataExport(requestContext(r), req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeResponse(w, resp)
+	}))
+
+	http.HandleFunc("/api/me/data-export/download", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
+		}
+
+		resp, err := authH.HandleDownloadDataExport(requestContext(r), req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeResponse(w, resp)
+	}))
+
+	// DELETE /api/me - Xóa (ẩn danh hóa) tài khoản của chính mình
+	http.HandleFunc("/api/me", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
+		}
+
+		resp, err := authH.HandleDeleteMe(requestContext(r), req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeResponse(w, resp)
+	}))
+
+	// GET /api/admin/dashboard - KPI toàn hệ thống (ADMIN only, cache 60s)
+	http.HandleFunc("/api/admin/dashboard", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
+		}
+
+		resp, err := dashboardH.HandleGetAdminDashboard(requestContext(r), req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeResponse(w, resp)
+	}))
+
+	// GET /api/admin/reports/campuses - Báo cáo liên campus (super admin)
+	http.HandleFunc("/api/admin/reports/campuses", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
+		}
+
+		resp, err := dashboardH.HandleGetCampusReports(requestContext(r), req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeResponse(w, resp)
+	}))
+
+	// GET /api/admin/venues/utilization?from=&to= - Mức sử dụng khu vực (venue.manage, ?format=csv)
+	http.HandleFunc("/api/admin/venues/utilization", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
+		}
+
+		resp, err := dashboardH.HandleGetVenueUtilization(requestContext(r), req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeResponse(w, resp)
+	}))
+
+	// GET /api/admin/attendance/points?semester= - Điểm rèn luyện theo sinh viên cho phòng CTSV (attendance.export, ?format=csv)
+	http.HandleFunc("/api/admin/attendance/points", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
+		}
+
+		resp, err := dashboardH.HandleGetAttendancePoints(requestContext(r), req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeResponse(w, resp)
+	}))
+
+	// GET /api/tickets/list - Lấy danh sách vé (Staff/Admin)
+	http.HandleFunc("/api/tickets/list", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
+		}
+
+		resp, err := ticketH.HandleGetTicketList(requestContext(r), req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeResponse(w, resp)
+	}))
+
+	// GET /api/category-tickets - Lấy loại vé của event
+	http.HandleFunc("/api/category-tickets", corsMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
+		}
+
+		resp, err := ticketH.HandleGetCategoryTickets(requestContext(r), req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeResponse(w, resp)
+	}))
+
+	// GET /api/bills/my-bills - Lấy hóa đơn của user
+	http.HandleFunc("/api/bills/my-bills", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
+		}
+
+		resp, err := ticketH.HandleGetMyBills(requestContext(r), req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeResponse(w, resp)
+	}))
+
+	// GET /api/bills/{id}/resend-receipt - Gửi lại biên lai thanh toán của hóa đơn
+	http.HandleFunc("/api/bills/{id}/resend-receipt", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
+		}
+		req.PathParameters = map[string]string{"id": r.PathValue("id")}
+
+		resp, err := ticketH.HandleResendReceipt(requestContext(r), req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeResponse(w, resp)
+	}))
+
+	// GET /api/bills/{id}/installments - Lịch trả góp của hóa đơn
+	http.HandleFunc("/api/bills/{id}/installments", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
+		}
+		req.PathParameters = map[string]string{"id": r.PathValue("id")}
+
+		resp, err := ticketH.HandleGetBillInstallments(requestContext(r), req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeResponse(w, resp)
+	}))
+
+	// POST /api/bills/{id}/installments/{seq}/pay - Trả một kỳ trả góp (ví hoặc VNPay)
+	http.HandleFunc("/api/bills/{id}/installments/{seq}/pay", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
+		}
+		req.PathParameters = map[string]string{"id": r.PathValue("id"), "seq": r.PathValue("seq")}
+
+		resp, err := ticketH.HandlePayInstallment(requestContext(r), req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeResponse(w, resp)
+	}))
+
+	// GET /api/payment/my-bills - Lấy hóa đơn của user (KHỚP JAVA)
+	http.HandleFunc("/api/payment/my-bills", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
+		}
+
+		resp, err := ticketH.HandleGetMyBills(requestContext(r), req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeResponse(w, resp)
+	}))
+
+	// GET /api/payment-ticket - Tạo URL thanh toán VNPay (KHỚP JAVA)
+	http.HandleFunc("/api/payment-ticket", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
+		}
+
+		resp, err := ticketH.HandlePaymentTicket(requestContext(r), req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeResponse(w, resp)
+	}))
+
+	// GET /api/buyTicket - VNPay return URL (KHỚP JAVA)
+	http.HandleFunc("/api/buyTicket", corsMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
+		}
+
+		resp, err := ticketH.HandleBuyTicket(requestContext(r), req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeResponse(w, resp)
+	}))
+
+	// GET /api/wallet/balance - Get user's wallet balance
+	http.HandleFunc("/api/wallet/balance", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
+		}
+
+		resp, err := ticketH.HandleGetWalletBalance(requestContext(r), req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeResponse(w, resp)
+	}))
+
+	// POST /api/wallet/pay-ticket - Pay ticket with wallet (internal balance)
+	http.HandleFunc("/api/wallet/pay-ticket", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
+		}
+
+		resp, err := ticketH.HandleWalletPayTicket(requestContext(r), req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeResponse(w, resp)
+	}))
+
+	// ======================= VENUE ROUTES =======================
+
+	// GET /api/venues - Lấy danh sách venues (CRUD)
+	http.HandleFunc("/api/venues", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
+		}
+
+		var resp events.APIGatewayProxyResponse
+		switch r.Method {
+		case http.MethodGet:
+			resp, err = venueH.HandleGetVenues(requestContext(r), req)
+		case http.MethodPost:
+			resp, err = venueH.HandleCreateVenue(requestContext(r), req)
+		case http.MethodPut:
+			resp, err = venueH.HandleUpdateVenue(requestContext(r), req)
+		case http.MethodDelete:
+			resp, err = venueH.HandleDeleteVenue(requestContext(r), req)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeResponse(w, resp)
+	}))
+
+	// /api/campuses - GET danh sách campus, POST tạo campus (super admin)
+	http.HandleFunc("/api/campuses", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
+		}
+
+		var resp events.APIGatewayProxyResponse
+		switch r.Method {
+		case http.MethodGet:
+			resp, err = venueH.HandleGetCampuses(requestContext(r), req)
+		case http.MethodPost:
+			resp, err = venueH.HandleCreateCampus(requestContext(r), req)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeResponse(w, resp)
+	}))
+
+	// /api/venues/areas - CRUD cho Venue Areas (KHỚP JAVA)
+	http.HandleFunc("/api/venues/areas", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
+		}
+
+		var resp events.APIGatewayProxyResponse
+		switch r.Method {
+		case http.MethodGet:
+			resp, err = venueH.HandleGetAreas(requestContext(r), req)
+		case http.MethodPost:
+			resp, err = venueH.HandleCreateArea(requestContext(r), req)
+		case http.MethodPut:
+			resp, err = venueH.HandleUpdateArea(requestContext(r), req)
+		case http.MethodDelete:
+			resp, err = venueH.HandleDeleteArea(requestContext(r), req)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeResponse(w, resp)
+	}))
+
+	// GET /api/areas/free - Lấy khu vực còn trống
+	http.HandleFunc("/api/areas/free", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
+		}
+
+		resp, err := venueH.HandleGetFreeAreas(requestContext(r), req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeResponse(w, resp)
+	}))
+
+	// GET /api/seats - Lấy danh sách ghế
+	// ETag theo nội dung: client poll gửi If-None-Match, không đổi → 304 (httpcache.ETag)
+	http.HandleFunc("/api/seats", httpcache.ETag(authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
+		}
+
+		resp, err := venueH.HandleGetSeats(requestContext(r), req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeResponse(w, resp)
+	})))
+
+	// PUT /api/seats/accessibility - Đánh dấu ghế xe lăn / người đi kèm (ADMIN)
+	http.HandleFunc("/api/seats/accessibility", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
+		}
+
+		resp, err := venueH.HandleUpdateSeatAccessibility(requestContext(r), req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeResponse(w, resp)
+	}))
+
+	// ======================= STAFF ROUTES =======================
+
+	// POST /api/staff/checkin - Check-in vé
+	http.HandleFunc("/api/staff/checkin", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
+		}
+
+		resp, err := staffH.HandleCheckin(requestContext(r), req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeResponse(w, resp)
+	}))
+
+	// POST /api/staff/checkout - Check-out vé
+	http.HandleFunc("/api/staff/checkout", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
+		}
+
+		resp, err := staffH.HandleCheckout(requestContext(r), req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeResponse(w, resp)
+	}))
+
+	// GET /api/staff/events/{id}/occupancy - Số người đang ở trong sự kiện
+	http.HandleFunc("/api/staff/events/{id}/occupancy", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
+		}
+		req.PathParameters = map[string]string{"id": r.PathValue("id")}
+		resp, err := staffH.HandleGetEventOccupancy(requestContext(r), req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeResponse(w, resp)
+	}))
+
+	// GET /api/organizer/events/{id}/stats/stream - Thống kê check-in realtime (SSE, fallback JSON để poll)
+	http.HandleFunc("/api/organizer/events/{id}/stats/stream", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		staffH.ServeEventStatsStream(w, r)
+	}))
+
+	// GET /api/staff/reports - Danh sách report
+	http.HandleFunc("/api/staff/reports", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
+		}
+
+		resp, err := staffH.HandleGetReports(requestContext(r), req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeResponse(w, resp)
+	}))
+
+	// POST /api/staff/reports/process - APPROVE/REJECT report (⭐ REFUND LOGIC)
+	reportH := staffHandler.NewReportHandler()
+	http.HandleFunc("/api/staff/reports/process", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
+		}
+
+		resp, err := reportH.HandleProcessReport(requestContext(r), req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeResponse(w, resp)
+	}))
+
+	// GET /api/staff/reports/detail - Chi tiết report
+	http.HandleFunc("/api/staff/reports/detail", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
+		}
+
+		resp, err := reportH.HandleGetReportDetail(requestContext(r), req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeResponse(w, resp)
+	}))
+
+	// GET /api/staff/reports/{id} - Chi tiết report (alternative route)
+	http.HandleFunc("/api/staff/reports/", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		// Extract report ID from path
+		pathParts := strings.Split(strings.TrimPrefix(r.URL.Path, "/api/staff/reports/"), "/")
+		if len(pathParts) == 0 || pathParts[0] == "" {
+			http.Error(w, "Missing report ID", http.StatusBadRequest)
+			return
+		}
+
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
+		}
+
+		// Add path parameter
+		req.PathParameters = map[string]string{
+			"id": pathParts[0],
+		}
+
+		resp, err := staffH.HandleGetReportDetail(requestContext(r), req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeResponse(w, resp)
+	}))
+
+	// ======================= STUDENT REPORT ROUTES =======================
+
+	// POST /api/student/reports - Submit error report for checked-in ticket
+	http.HandleFunc("/api/student/reports", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodOptions {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json;charset=UTF-8")
+
+		// Extract user ID from Context (set by authMiddleware)
+		userID, ok := authctx.UserID(r.Context())
+		if !ok {
+			log.Printf("[ERROR] ReportHandler: Cannot find userID in context")
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprintf(w, `{"status":"fail","message":"Unauthorized: missing user ID"}`)
+			return
+		}
+		log.Printf("[REPORT] Retrieved userID=%d from Context", userID)
+
+		// Extract user role from Context (set by authMiddleware)
+		userRole := authctx.Role(r.Context())
+		if userRole != "STUDENT" {
+			log.Printf("[ERROR] ReportHandler: Invalid role. Got: %s", userRole)
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprintf(w, `{"status":"fail","message":"Only students can submit reports"}`)
+			return
+		}
+
+		// Parse JSON body
+		var reportBody struct {
+			TicketId    int    `json:"ticketId"`
+			Title       string `json:"title"`
+			Description string `json:"description"`
+			ImageUrl    string `json:"imageUrl"`
+		}
+
+		if err := json.NewDecoder(r.Body).Decode(&reportBody); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, `{"status":"fail","message":"Invalid JSON"}`)
+			return
+		}
+
+		log.Printf("[REPORT REQUEST] TicketID: %d | UserID: %d | Title: '%s' | Description: '%s'",
+			reportBody.TicketId, userID, reportBody.Title, reportBody.Description)
+
+		// Validate input
+		if reportBody.TicketId <= 0 {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, `{"status":"fail","message":"Invalid ticketId"}`)
+			return
+		}
+
+		// Sanitize: văn bản thuần, bỏ thẻ HTML / ký tự điều khiển
+		reportBody.Title = validator.SanitizeText(reportBody.Title)
+		reportBody.Description = validator.SanitizeText(reportBody.Description)
+		if reportBody.Description == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, `{"status":"fail","message":"Description is required"}`)
+			return
+		}
+		lengthErr := validator.GetLengthError("Tiêu đề", reportBody.Title, validator.MaxReportTitleLength)
+		if lengthErr == "" {
+			lengthErr = validator.GetLengthError("Mô tả", reportBody.Description, validator.MaxReportDescriptionLength)
+		}
+		if lengthErr != "" {
+			w.WriteHeader(http.StatusBadRequest)
+			msg, _ := json.Marshal(lengthErr)
+			fmt.Fprintf(w, `{"status":"fail","message":%s}`, msg)
+			return
+		}
+
+		// Get database connection
+		dbConn := db.GetDB()
+		if dbConn == nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprintf(w, `{"status":"error","message":"Database connection failed"}`)
+			return
+		}
+
+		// Verify ticket ownership and check status
+		var ticketStatus string
+		var ticketUserID int
+		checkQuery := `SELECT t.status, t.user_id FROM Ticket t WHERE t.ticket_id = ?`
+		err := dbConn.QueryRowContext(context.Background(), checkQuery, reportBody.TicketId).Scan(&ticketStatus, &ticketUserID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				w.WriteHeader(http.StatusNotFound)
+				fmt.Fprintf(w, `{"status":"fail","message":"Ticket not found"}`)
+			} else {
+				w.WriteHeader(http.StatusInternalServerError)
+				fmt.Fprintf(w, `{"status":"error","message":"Database error"}`)
+			}
+			return
+		}
+
+		// Verify ticket belongs to the user
+		if ticketUserID != userID {
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprintf(w, `{"status":"fail","message":"Ticket does not belong to you"}`)
+			return
+		}
+
+		// Verify ticket is CHECKED_IN
+		log.Printf("[CHECK-IN VERIFY] Ticket status in DB: %s", ticketStatus)
+		if ticketStatus != "CHECKED_IN" {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, `{"status":"fail","message":"Bạn phải check-in trước khi báo cáo lỗi"}`)
+			return
+		}
+		log.Printf("[CHECK-IN VERIFY] Status is CHECKED_IN -> Valid!")
+
+		// Check for duplicate pending report on same ticket
+		var existingCount int
+		dupQuery := `SELECT COUNT(*) FROM report WHERE ticket_id = ? AND status = 'PENDING'`
+		dbConn.QueryRowContext(context.Background(), dupQuery, reportBody.TicketId).Scan(&existingCount)
+		if existingCount > 0 {
+			w.WriteHeader(http.StatusConflict)
+			fmt.Fprintf(w, `{"status":"fail","message":"This ticket already has a pending report"}`)
+			return
+		}
+
+		// Insert report into database
+		log.Printf("[DB INSERT] Saving report to database...")
+		now := time.Now()
+		insertQuery := `
+			INSERT INTO report (user_id, ticket_id, title, description, image_url, status, created_at)
+			VALUES (?, ?, ?, ?, ?, 'PENDING', ?)
+		`
+		result, err := dbConn.ExecContext(context.Background(), insertQuery,
+			userID,
+			reportBody.TicketId,
+			reportBody.Title,
+			reportBody.Description,
+			reportBody.ImageUrl,
+			now,
+		)
+
+		if err != nil {
+			log.Printf("[DB INSERT ERROR] %v", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprintf(w, `{"status":"error","message":"Failed to create report"}`)
+			return
+		}
+
+		reportID, _ := result.LastInsertId()
+		log.Printf("[DB INSERT] Report created successfully! Report ID: %d", reportID)
+
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, `{"status":"success","message":"Report submitted successfully","reportId":%d}`, reportID)
+	}))
+
+	// GET /api/student/reports/pending-ticket-ids - Get list of ticket IDs with pending reports
+	http.HandleFunc("/api/student/reports/pending-ticket-ids", corsMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodOptions {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json;charset=UTF-8")
+
+		// Extract user ID from Context (set by authMiddleware)
+		userID, ok := authctx.UserID(r.Context())
+		if !ok {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprintf(w, `[]`)
+			return
+		}
+
+		// Get database connection
+		dbConn := db.GetDB()
+		if dbConn == nil {
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprintf(w, `[]`)
+			return
+		}
+
+		// Query pending ticket IDs
+		query := `SELECT DISTINCT ticket_id FROM report WHERE user_id = ? AND status = 'PENDING'`
+		rows, err := dbConn.QueryContext(context.Background(), query, userID)
+		if err != nil {
+			log.Printf("[ERROR] Failed to query pending reports: %v", err)
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprintf(w, `[]`)
+			return
+		}
+		defer rows.Close()
+
+		var pendingIDs []int
+		for rows.Next() {
+			var id int
+			if err := rows.Scan(&id); err == nil {
+				pendingIDs = append(pendingIDs, id)
+			}
+		}
 
-		// Get database connection
-		dbConn := db.GetDB()
-		if dbConn == nil {
-			w.WriteHeader(http.StatusInternalServerError)
-			fmt.Fprintf(w, `{"status":"error","message":"Database connection failed"}`)
+		// Return as JSON array (empty array if no pending reports)
+		data, _ := json.Marshal(pendingIDs)
+		w.WriteHeader(http.StatusOK)
+		w.Write(data)
+	}))
+
+	// ======================= SYSTEM CONFIG ROUTES =======================
+
+	// GET /POST /api/admin/config/system - System config (ADMIN only)
+	http.HandleFunc("/api/admin/config/system", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
+		}
+
+		var resp events.APIGatewayProxyResponse
+		switch r.Method {
+		case http.MethodGet:
+			resp, err = staffH.HandleGetSystemConfig(requestContext(r), req)
+		case http.MethodPost:
+			resp, err = staffH.HandleUpdateSystemConfig(requestContext(r), req)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeResponse(w, resp)
+	}))
+
+	// GET /PUT /api/admin/rules - Quy tắc nghiệp vụ có hiệu lực (?eventId=) / sửa giá trị hệ thống (ADMIN only)
+	http.HandleFunc("/api/admin/rules", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
+		}
+
+		var resp events.APIGatewayProxyResponse
+		switch r.Method {
+		case http.MethodGet:
+			resp, err = staffH.HandleGetRules(requestContext(r), req)
+		case http.MethodPut:
+			resp, err = staffH.HandleUpdateRules(requestContext(r), req)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeResponse(w, resp)
+	}))
+
+	// GET /PUT /api/admin/retention - Chính sách lưu giữ dữ liệu (ADMIN only)
+	http.HandleFunc("/api/admin/retention", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
+		}
+
+		var resp events.APIGatewayProxyResponse
+		switch r.Method {
+		case http.MethodGet:
+			resp, err = staffH.HandleGetRetentionPolicies(requestContext(r), req)
+		case http.MethodPut:
+			resp, err = staffH.HandleUpdateRetentionPolicies(requestContext(r), req)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeResponse(w, resp)
+	}))
+
+	// POST /api/admin/retention/run - Xem trước (?dryRun=true, mặc định) hoặc chạy job data-retention (ADMIN only)
+	http.HandleFunc("/api/admin/retention/run", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
+		}
+		resp, err := staffH.HandleRunRetention(requestContext(r), req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeResponse(w, resp)
+	}))
+
+	// PUT /api/admin/rules/events/{id} - Ghi đè hạn hủy / hạn cập nhật / phí nền tảng của một sự kiện (ADMIN only)
+	http.HandleFunc("/api/admin/rules/events/{id}", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
+		}
+		req.PathParameters = map[string]string{"id": r.PathValue("id")}
+		resp, err := staffH.HandleUpdateEventRules(requestContext(r), req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeResponse(w, resp)
+	}))
+
+	// GET /PUT /api/admin/rules/organizers/{id} - Phí nền tảng riêng của một organizer (ADMIN only)
+	http.HandleFunc("/api/admin/rules/organizers/{id}", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodPut {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
+		}
+		req.PathParameters = map[string]string{"id": r.PathValue("id")}
+		resp, err := staffH.HandleOrganizerFee(requestContext(r), req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeResponse(w, resp)
+	}))
+
+	// GET /api/admin/ledger/trial-balance - Bảng cân đối thử của sổ cái (?asOf=YYYY-MM-DD)
+	http.HandleFunc("/api/admin/ledger/trial-balance", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
+		}
+		resp, err := staffH.HandleTrialBalance(requestContext(r), req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeResponse(w, resp)
+	}))
+
+	// GET /api/admin/settlements - Đối soát doanh thu của mọi organizer (?periodId=&organizerId=&status=)
+	http.HandleFunc("/api/admin/settlements", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
+		}
+		resp, err := staffH.HandleListSettlements(requestContext(r), req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeResponse(w, resp)
+	}))
+
+	// GET /api/admin/settlements/{id} - Chi tiết đối soát (?format=csv: bảng kê)
+	http.HandleFunc("/api/admin/settlements/{id}", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
+		}
+		req.PathParameters = map[string]string{"id": r.PathValue("id")}
+		resp, err := staffH.HandleGetSettlement(requestContext(r), req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeResponse(w, resp)
+	}))
+
+	// POST /api/admin/settlements/{id}/approve - Duyệt (chốt) bản đối soát của kỳ đã kết thúc
+	http.HandleFunc("/api/admin/settlements/{id}/approve", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
+		}
+		req.PathParameters = map[string]string{"id": r.PathValue("id")}
+		resp, err := staffH.HandleApproveSettlement(requestContext(r), req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeResponse(w, resp)
+	}))
+
+	// POST /api/admin/settlements/{id}/mark-paid - Ghi nhận đã chuyển khoản cho organizer
+	http.HandleFunc("/api/admin/settlements/{id}/mark-paid", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
+		}
+		req.PathParameters = map[string]string{"id": r.PathValue("id")}
+		resp, err := staffH.HandleMarkSettlementPaid(requestContext(r), req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeResponse(w, resp)
+	}))
+
+	// ======================= SCHEDULED JOBS ROUTES =======================
+
+	// GET /api/admin/jobs - Danh sách job + lịch sử chạy (ADMIN only)
+	http.HandleFunc("/api/admin/jobs", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
+		}
+		resp, err := staffH.HandleListJobs(requestContext(r), req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeResponse(w, resp)
+	}))
+
+	// POST /api/admin/jobs/{name}/run-now - Chạy job ngay (ADMIN only)
+	http.HandleFunc("/api/admin/jobs/{name}/run-now", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
+		}
+		req.PathParameters = map[string]string{"name": r.PathValue("name")}
+		resp, err := staffH.HandleRunJobNow(requestContext(r), req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeResponse(w, resp)
+	}))
+
+	// POST /api/admin/jobs/{name}/backfill - Chạy lại job cho khoảng ngày (ADMIN only)
+	http.HandleFunc("/api/admin/jobs/{name}/backfill", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
+		}
+		req.PathParameters = map[string]string{"name": r.PathValue("name")}
+		resp, err := staffH.HandleBackfillJob(requestContext(r), req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
+		writeResponse(w, resp)
+	}))
 
-		// Verify ticket ownership and check status
-		var ticketStatus string
-		var ticketUserID int
-		checkQuery := `SELECT t.status, t.user_id FROM Ticket t WHERE t.ticket_id = ?`
-		err := dbConn.QueryRowContext(context.Background(), checkQuery, reportBody.TicketId).Scan(&ticketStatus, &ticketUserID)
+	// ======================= EMAIL QUEUE =======================
+
+	// POST /api/webhooks/email-events - Bounce/complaint từ nhà cung cấp email (xác thực bằng secret, không JWT)
+	http.HandleFunc("/api/webhooks/email-events", corsMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		req, err := adaptRequest(r)
 		if err != nil {
-			if err == sql.ErrNoRows {
-				w.WriteHeader(http.StatusNotFound)
-				fmt.Fprintf(w, `{"status":"fail","message":"Ticket not found"}`)
-			} else {
-				w.WriteHeader(http.StatusInternalServerError)
-				fmt.Fprintf(w, `{"status":"error","message":"Database error"}`)
-			}
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
 			return
 		}
-
-		// Verify ticket belongs to the user
-		if ticketUserID != userID {
-			w.WriteHeader(http.StatusForbidden)
-			fmt.Fprintf(w, `{"status":"fail","message":"Ticket does not belong to you"}`)
+		resp, err := staffH.HandleEmailWebhook(requestContext(r), req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
+		writeResponse(w, resp)
+	}))
 
-		// Verify ticket is CHECKED_IN
-		log.Printf("[CHECK-IN VERIFY] Ticket status in DB: %s", ticketStatus)
-		if ticketStatus != "CHECKED_IN" {
-			w.WriteHeader(http.StatusBadRequest)
-			fmt.Fprintf(w, `{"status":"fail","message":"Bạn phải check-in trước khi báo cáo lỗi"}`)
+	// GET /api/admin/emails - Tra cứu email & sự kiện giao nhận (ADMIN, STAFF)
+	http.HandleFunc("/api/admin/emails", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 			return
 		}
-		log.Printf("[CHECK-IN VERIFY] Status is CHECKED_IN -> Valid!")
-
-		// Check for duplicate pending report on same ticket
-		var existingCount int
-		dupQuery := `SELECT COUNT(*) FROM report WHERE ticket_id = ? AND status = 'PENDING'`
-		dbConn.QueryRowContext(context.Background(), dupQuery, reportBody.TicketId).Scan(&existingCount)
-		if existingCount > 0 {
-			w.WriteHeader(http.StatusConflict)
-			fmt.Fprintf(w, `{"status":"fail","message":"This ticket already has a pending report"}`)
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
 			return
 		}
-
-		// Insert report into database
-		log.Printf("[DB INSERT] Saving report to database...")
-		now := time.Now()
-		insertQuery := `
-			INSERT INTO report (user_id, ticket_id, title, description, image_url, status, created_at)
-			VALUES (?, ?, ?, ?, ?, 'PENDING', ?)
-		`
-		result, err := dbConn.ExecContext(context.Background(), insertQuery,
-			userID,
-			reportBody.TicketId,
-			reportBody.Title,
-			reportBody.Description,
-			reportBody.ImageUrl,
-			now,
-		)
-
+		resp, err := staffH.HandleListEmails(requestContext(r), req)
 		if err != nil {
-			log.Printf("[DB INSERT ERROR] %v", err)
-			w.WriteHeader(http.StatusInternalServerError)
-			fmt.Fprintf(w, `{"status":"error","message":"Failed to create report"}`)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
-
-		reportID, _ := result.LastInsertId()
-		log.Printf("[DB INSERT] Report created successfully! Report ID: %d", reportID)
-
-		w.WriteHeader(http.StatusCreated)
-		fmt.Fprintf(w, `{"status":"success","message":"Report submitted successfully","reportId":%d}`, reportID)
+		writeResponse(w, resp)
 	}))
 
-	// GET /api/student/reports/pending-ticket-ids - Get list of ticket IDs with pending reports
-	http.HandleFunc("/api/student/reports/pending-ticket-ids", corsMiddleware(func(w http.ResponseWriter, r *http.Request) {
-		if r.Method == http.MethodOptions {
-			w.WriteHeader(http.StatusOK)
+	// POST /api/admin/emails/{id}/retry - Gửi lại email dead-letter (ADMIN, STAFF)
+	http.HandleFunc("/api/admin/emails/{id}/retry", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
+		}
+		req.PathParameters = map[string]string{"id": r.PathValue("id")}
+		resp, err := staffH.HandleRetryEmail(requestContext(r), req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
+		writeResponse(w, resp)
+	}))
 
+	// GET /api/admin/email-templates - Danh sách mẫu email sửa được (ADMIN)
+	http.HandleFunc("/api/admin/email-templates", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodGet {
 			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 			return
 		}
-
-		w.Header().Set("Content-Type", "application/json;charset=UTF-8")
-
-		// Extract user ID from header
-		userIDStr := r.Header.Get("X-User-Id")
-		userID := 0
-		if userIDStr != "" {
-			fmt.Sscanf(userIDStr, "%d", &userID)
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
 		}
-
-		if userID <= 0 {
-			w.WriteHeader(http.StatusUnauthorized)
-			fmt.Fprintf(w, `[]`)
+		resp, err := staffH.HandleListEmailTemplates(requestContext(r), req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
+		writeResponse(w, resp)
+	}))
 
-		// Get database connection
-		dbConn := db.GetDB()
-		if dbConn == nil {
-			w.WriteHeader(http.StatusOK)
-			fmt.Fprintf(w, `[]`)
+	// GET /api/admin/email-templates/{key} - Chi tiết mẫu + lịch sử phiên bản (ADMIN)
+	// PUT /api/admin/email-templates/{key} - Lưu phiên bản mới (ADMIN)
+	http.HandleFunc("/api/admin/email-templates/{key}", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodPut {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 			return
 		}
-
-		// Query pending ticket IDs
-		query := `SELECT DISTINCT ticket_id FROM report WHERE user_id = ? AND status = 'PENDING'`
-		rows, err := dbConn.QueryContext(context.Background(), query, userID)
+		req, err := adaptRequest(r)
 		if err != nil {
-			log.Printf("[ERROR] Failed to query pending reports: %v", err)
-			w.WriteHeader(http.StatusOK)
-			fmt.Fprintf(w, `[]`)
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
 			return
 		}
-		defer rows.Close()
-
-		var pendingIDs []int
-		for rows.Next() {
-			var id int
-			if err := rows.Scan(&id); err == nil {
-				pendingIDs = append(pendingIDs, id)
-			}
+		req.PathParameters = map[string]string{"key": r.PathValue("key")}
+		var resp events.APIGatewayProxyResponse
+		if r.Method == http.MethodPut {
+			resp, err = staffH.HandleSaveEmailTemplate(requestContext(r), req)
+		} else {
+			resp, err = staffH.HandleGetEmailTemplate(requestContext(r), req)
 		}
-
-		// Return as JSON array (empty array if no pending reports)
-		data, _ := json.Marshal(pendingIDs)
-		w.WriteHeader(http.StatusOK)
-		w.Write(data)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeResponse(w, resp)
 	}))
 
-	// ======================= SYSTEM CONFIG ROUTES =======================
-
-	// GET /POST /api/admin/config/system - System config (ADMIN only)
-	http.HandleFunc("/api/admin/config/system", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+	// POST /api/admin/email-templates/{key}/preview - Render với dữ liệu mẫu (ADMIN)
+	http.HandleFunc("/api/admin/email-templates/{key}/preview", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
 		req, err := adaptRequest(r)
 		if err != nil {
 			http.Error(w, "Failed to read request", http.StatusBadRequest)
 			return
 		}
+		req.PathParameters = map[string]string{"key": r.PathValue("key")}
+		resp, err := staffH.HandlePreviewEmailTemplate(requestContext(r), req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeResponse(w, resp)
+	}))
 
-		var resp events.APIGatewayProxyResponse
-		switch r.Method {
-		case http.MethodGet:
-			resp, err = staffH.HandleGetSystemConfig(context.Background(), req)
-		case http.MethodPost:
-			resp, err = staffH.HandleUpdateSystemConfig(context.Background(), req)
-		default:
+	// POST /api/admin/email-templates/{key}/rollback - Kích hoạt lại phiên bản cũ / mẫu mặc định (ADMIN)
+	http.HandleFunc("/api/admin/email-templates/{key}/rollback", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
 			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 			return
 		}
-
+		req, err := adaptRequest(r)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
+		}
+		req.PathParameters = map[string]string{"key": r.PathValue("key")}
+		resp, err := staffH.HandleRollbackEmailTemplate(requestContext(r), req)
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
@@ -1474,47 +4007,147 @@ func main() {
 	}))
 
 	// ======================= HEALTH CHECK =======================
-	http.HandleFunc("/health", corsMiddleware(func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]string{"status": "healthy"})
-	}))
+	// GET /health, /health/live - Process còn chạy (liveness, không gọi DB)
+	// GET /health/ready - DB, migration mới nhất, scheduler; 503 khi check bắt buộc lỗi
+	// (HEALTH_READY_REQUIRED chọn check bắt buộc, xem common/health)
+	readiness := health.NewChecker(health.RequiredFromEnv())
+	readiness.Add("database", health.Database(db.GetDB()))
+	readiness.Add("migrations", health.Migrations(db.GetDB(), health.LatestMigration))
+	readiness.Add("schedulers", health.Schedulers(scheduler.DefaultManager()))
+	http.HandleFunc("/health", corsMiddleware(health.LiveHandler()))
+	http.HandleFunc("/health/live", corsMiddleware(health.LiveHandler()))
+	http.HandleFunc("/health/ready", corsMiddleware(readiness.ReadyHandler()))
+
+	// ======================= METRICS =======================
+	// GET /metrics - Định dạng Prometheus (db_query_duration_seconds, ...)
+	// Nếu đặt METRICS_TOKEN thì yêu cầu header Authorization: Bearer <token>
+	metricsHandler := metrics.Handler()
+	http.HandleFunc("/metrics", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		if token := getEnv("METRICS_TOKEN", ""); token != "" && r.Header.Get("Authorization") != "Bearer "+token {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		metricsHandler(w, r)
+	})
 
-	// ======================= DEBUG ENDPOINTS (TEST ONLY) =======================
-	// Test repository methods without auth
-	http.HandleFunc("/api/debug/requests/18", corsMiddleware(func(w http.ResponseWriter, r *http.Request) {
-		// Access database directly to test repository
-		queryResult, err := db.GetDB().QueryContext(context.Background(),
-			`SELECT request_id, title, status FROM Event_Request WHERE requester_id = 18 LIMIT 5`,
-		)
+	// GET /api/admin/capabilities - Ma trận thao tác đã / chưa hiện thực và route phụ thuộc (ADMIN only)
+	http.HandleFunc("/api/admin/capabilities", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		req, err := adaptRequest(r)
 		if err != nil {
-			http.Error(w, fmt.Sprintf("Query error: %v", err), http.StatusInternalServerError)
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
 			return
 		}
-		defer queryResult.Close()
-
-		var results []map[string]interface{}
-		for queryResult.Next() {
-			var id int
-			var title, status string
-			queryResult.Scan(&id, &title, &status)
-			results = append(results, map[string]interface{}{
-				"id": id, "title": title, "status": status,
-			})
+		resp, err := staffH.HandleGetCapabilities(requestContext(r), req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
 		}
-
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"message": "Debug query result",
-			"data":    results,
-		})
+		writeResponse(w, resp)
 	}))
 
+	// ======================= DIAGNOSTICS (optional) =======================
+	// Bật bằng DIAGNOSTICS_ENABLED=true; ADMIN only. Thay cho các endpoint /api/debug/... viết tay
+	diagnosticsEnabled := strings.EqualFold(getEnv("DIAGNOSTICS_ENABLED", "false"), "true")
+	if diagnosticsEnabled {
+		// GET /api/admin/diagnostics/entities/{type}/{id} - Tra cứu bản ghi theo ID
+		http.HandleFunc("/api/admin/diagnostics/entities/{type}/{id}", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodGet {
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+				return
+			}
+			req, err := adaptRequest(r)
+			if err != nil {
+				http.Error(w, "Failed to read request", http.StatusBadRequest)
+				return
+			}
+			req.PathParameters = map[string]string{"type": r.PathValue("type"), "id": r.PathValue("id")}
+			resp, err := staffH.HandleDiagnosticsEntity(requestContext(r), req)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			writeResponse(w, resp)
+		}))
+
+		// GET /api/admin/diagnostics/status - DB pool, Email_Queue, job định kỳ
+		http.HandleFunc("/api/admin/diagnostics/status", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodGet {
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+				return
+			}
+			req, err := adaptRequest(r)
+			if err != nil {
+				http.Error(w, "Failed to read request", http.StatusBadRequest)
+				return
+			}
+			resp, err := staffH.HandleDiagnosticsStatus(requestContext(r), req)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			writeResponse(w, resp)
+		}))
+
+		// GET /api/admin/diagnostics/errors - Log lỗi gần nhất của instance
+		http.HandleFunc("/api/admin/diagnostics/errors", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodGet {
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+				return
+			}
+			req, err := adaptRequest(r)
+			if err != nil {
+				http.Error(w, "Failed to read request", http.StatusBadRequest)
+				return
+			}
+			resp, err := staffH.HandleDiagnosticsErrors(requestContext(r), req)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			writeResponse(w, resp)
+		}))
+	}
+
 	// ======================= SWAGGER UI =======================
 	// Serve Swagger UI HTML
 	http.HandleFunc("/swagger-ui.html", corsMiddleware(func(w http.ResponseWriter, r *http.Request) {
 		http.ServeFile(w, r, "swagger-ui.html")
 	}))
 
+	// ======================= GRAPHQL GATEWAY (optional) =======================
+	// Bật bằng GRAPHQL_ENABLED=true. Dùng chung JWT (authMiddleware), resolver gọi lại usecase sẵn có
+	graphqlEnabled := strings.EqualFold(getEnv("GRAPHQL_ENABLED", "false"), "true")
+	if graphqlEnabled {
+		graphqlH := graphqlHandler.NewGraphQLHandler()
+		http.HandleFunc("/graphql", authMiddleware(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodPost && r.Method != http.MethodGet {
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+				return
+			}
+
+			req, err := adaptRequest(r)
+			if err != nil {
+				http.Error(w, "Failed to read request", http.StatusBadRequest)
+				return
+			}
+
+			resp, err := graphqlH.HandleGraphQL(requestContext(r), req)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			writeResponse(w, resp)
+		}))
+	}
+
 	// Serve OpenAPI JSON spec
 	http.HandleFunc("/openapi.json", corsMiddleware(func(w http.ResponseWriter, r *http.Request) {
 		http.ServeFile(w, r, "openapi.json")
@@ -1540,27 +4173,108 @@ func main() {
 	fmt.Printf("  PUT  /api/admin/create-account\n")
 	fmt.Printf("  DELETE /api/admin/create-account\n")
 	fmt.Printf("  GET  /api/users/staff-organizer\n")
+	fmt.Printf("  GET  /api/admin/permissions, GET|POST /api/admin/roles, PUT|DELETE /api/admin/roles/{name}\n")
+	fmt.Printf("  GET  /api/admin/abuse/reviews, POST /api/admin/abuse/reviews/{id}\n")
 	fmt.Printf("\n📅 Event Service:\n")
 	fmt.Printf("  GET  /api/events            - Get all events\n")
 	fmt.Printf("  GET  /api/events/detail?id= - Get event detail\n")
+	fmt.Printf("  GET  /api/public/events/{slug} - Public event microsite (no auth)\n")
+	fmt.Printf("  GET  /api/public/events.rss | events.json | sitemap.xml - Open events feeds (cached)\n")
+	fmt.Printf("  GET  /api/meta/statuses - Status labels (vi/en, color hint) for client caching\n")
+	fmt.Printf("  GET  /api/widget/events?key=   - Embeddable events widget (CORS per API key)\n")
+	fmt.Printf("  GET|POST /api/organizer/widget-keys, PUT|DELETE /api/organizer/widget-keys/{keyId} - Widget API keys\n")
 	fmt.Printf("  POST /api/events/update-details - Update event\n")
 	fmt.Printf("  POST /api/events/update-config  - Update check-in/out config (Admin/Organizer)\n")
+	fmt.Printf("  POST /api/events/{id}/banner    - Upload banner + resized variants (Admin/Organizer)\n")
 	fmt.Printf("  GET  /api/events/config         - Get check-in/out config\n")
 	fmt.Printf("  GET  /api/events/stats      - Get event stats\n")
+	fmt.Printf("  POST /api/events/disable?id=    - Disable event, stop sales, release holds (Admin)\n")
 	fmt.Printf("  GET  /api/events/available-areas?startTime=...&endTime=... - Available areas (Staff)\n")
+	fmt.Printf("  GET|POST /api/events/{id}/collaborators          - List / invite co-organizers (Owner/Admin)\n")
+	fmt.Printf("  POST     /api/events/{id}/collaborators/accept   - Accept co-organizer invitation\n")
+	fmt.Printf("  DELETE   /api/events/{id}/collaborators/{userId} - Remove co-organizer / leave team\n")
+	fmt.Printf("  GET|PUT  /api/events/{id}/ticket-template         - Ticket PDF template (Owner/Co-organizer/Admin)\n")
+	fmt.Printf("  GET|PUT  /api/events/{id}/checkin-alerts          - Check-in capacity alert thresholds (Owner/Co-organizer/Admin)\n")
+	fmt.Printf("  GET|PUT  /api/events/{id}/activity-points         - Activity points per attendee (Owner/Co-organizer/Staff/Admin)\n")
+	fmt.Printf("  GET|PUT  /api/events/{id}/cashback                - Attendance cashback on check-out (Owner/Co-organizer/Admin)\n")
+	fmt.Printf("  GET|PUT  /api/events/{id}/dynamic-qr              - Require rotating QR tokens at check-in (Owner/Co-organizer/Admin)\n")
+	fmt.Printf("  GET|PUT  /api/events/{id}/booking-queue           - Flash-sale waiting room settings + live counts (Owner/Co-organizer/Admin)\n")
+	fmt.Printf("  POST|GET /api/events/{id}/queue                   - Join booking queue / poll position by ?token=\n")
+	fmt.Printf("  GET|PUT  /api/events/{id}/seat-prices            - Per-seat price adjustments within a ticket category (Owner/Co-organizer/Admin)\n")
+	fmt.Printf("  GET|PUT|DELETE /api/events/{id}/installment-plan - Deposit + installment plan (public GET, Owner/Admin)\n")
+	fmt.Printf("  GET      /api/organizer/collaborations           - Pending co-organizer invitations\n")
+	fmt.Printf("  GET      /api/organizer/settlements[/{id}]       - Own payout settlements (?format=csv statement)\n")
+	fmt.Printf("  POST     /api/organizer/events/{id}/comp-tickets - Issue complimentary tickets\n")
+	fmt.Printf("  GET|POST /api/passes                             - Season passes on sale (public) / create pass\n")
+	fmt.Printf("  GET      /api/passes/my                          - Own passes, issued tickets, claimable events\n")
+	fmt.Printf("  GET      /api/passes/{id}                        - Pass detail with included events (public)\n")
+	fmt.Printf("  PUT      /api/passes/{id}/status                 - Start / stop selling a pass\n")
+	fmt.Printf("  POST     /api/passes/{id}/purchase               - Buy pass (wallet / VNPay)\n")
+	fmt.Printf("  POST     /api/passes/{id}/claim                  - Claim pass ticket for an event\n")
+	fmt.Printf("  POST     /api/passes/{id}/holders/{holderId}/revoke - Revoke a holder's pass\n")
+	fmt.Printf("  GET|POST /api/events/{id}/attachments           - List / add event attachments (Owner/Co-organizer/Admin)\n")
+	fmt.Printf("  PUT|DELETE /api/events/{id}/attachments/{attachmentId} - Update / remove attachment\n")
+	fmt.Printf("  GET|POST /api/events/{id}/sponsors              - List (public) / add event sponsors\n")
+	fmt.Printf("  PUT|DELETE /api/events/{id}/sponsors/{sponsorId} - Update tier / remove sponsor\n")
+	fmt.Printf("  GET|PUT /api/events/{id}/survey                 - Post-event survey builder\n")
+	fmt.Printf("  GET  /api/events/{id}/survey/results            - Survey results (?format=csv)\n")
+	fmt.Printf("  GET  /api/student/surveys/{eventId}             - Open survey for attendees\n")
+	fmt.Printf("  POST /api/student/surveys/{eventId}/responses   - Submit survey response\n")
+	fmt.Printf("  GET|POST /api/organizer/events/{id}/raffle      - Raffle history / draw winners among checked-in attendees\n")
+	fmt.Printf("  GET|PUT /api/events/{id}/category-tickets/{categoryTicketId}/price-tiers - Scheduled ticket prices\n")
+	fmt.Printf("  POST     /api/events/{id}/speaker/invite         - Invite speaker to link an account (Owner/Co-organizer/Admin)\n")
+	fmt.Printf("  POST     /api/speaker/invitations/accept         - Accept speaker invitation (token from email)\n")
+	fmt.Printf("  GET      /api/speaker/my-events                  - Events of the current speaker\n")
+	fmt.Printf("  GET|PUT  /api/speaker/profile                    - Speaker bio / avatar\n")
+	fmt.Printf("  POST     /api/speaker/events/{id}/materials      - Add session material (slides URL)\n")
+	fmt.Printf("  DELETE   /api/speaker/events/{id}/materials/{materialId} - Remove session material\n")
 	fmt.Printf("\n📝 Event Request Service:\n")
 	fmt.Printf("  POST /api/event-requests         - Create request\n")
 	fmt.Printf("  GET  /api/event-requests/{id}    - Get request detail\n")
+	fmt.Printf("  POST /api/event-requests/{id}/clone - Clone request with new dates\n")
+	fmt.Printf("  POST /api/event-requests/from-template/{id} - Create request from an event template\n")
+	fmt.Printf("  GET|POST /api/event-templates, PUT|DELETE /api/event-templates/{id} - Event template library\n")
+	fmt.Printf("  GET|PUT /api/event-requests/{id}/budget - Request budget (estimate, reviews, actuals)\n")
+	fmt.Printf("  POST /api/event-requests/{id}/budget/reviews - Review budget (Staff/Admin)\n")
+	fmt.Printf("  PUT  /api/event-requests/{id}/budget/actuals - Enter post-event actual costs\n")
 	fmt.Printf("  GET  /api/event-requests/my      - My requests\n")
 	fmt.Printf("  GET  /api/event-requests/my/active   - My active requests (tab 'Chờ', with pagination)\n")
 	fmt.Printf("  GET  /api/event-requests/my/archived - My archived requests (tab 'Đã xử lý', with pagination)\n")
 	fmt.Printf("  GET  /api/staff/event-requests   - Staff view requests\n")
+	fmt.Printf("  POST /api/staff/event-requests/{id}/claim|release - Claim / release a request for review\n")
+	fmt.Printf("  GET  /api/staff/event-requests/{id}/suggested-areas - Ranked area suggestions for approval\n")
+	fmt.Printf("  POST /api/staff/event-requests/{id}/approve-suggested - Approve with the top suggested area\n")
+	fmt.Printf("  POST /api/staff/events/{id}/change-area - Move an approved event to another area (remaps seats)\n")
+	fmt.Printf("  GET  /api/staff/events/{id}/area-changes - Area change history and tickets needing reassignment\n")
 	fmt.Printf("  POST /api/event-requests/update  - Update request\n")
 	fmt.Printf("  POST /api/event-requests/process - Process request\n")
 	fmt.Printf("\n🎫 Ticket & Payment Service:\n")
 	fmt.Printf("  GET  /api/registrations/my-tickets - My tickets\n")
+	fmt.Printf("  GET  /api/registrations/my-tickets/grouped - My tickets by upcoming/past event with status timeline\n")
+	fmt.Printf("  POST /api/tickets/quote            - Seat price quote (no hold)\n")
+	fmt.Printf("  GET  /api/registrations/holds      - Active seat holds\n")
+	fmt.Printf("  POST /api/registrations/holds/extend - Extend seat holds (+3 min, once)\n")
+	fmt.Printf("  POST /api/registrations/{ticketId}/resend-email - Resend ticket email (rate-limited)\n")
+	fmt.Printf("  GET  /api/registrations/{ticketId}/qr - Ticket QR image (PNG)\n")
+	fmt.Printf("  GET  /api/registrations/{ticketId}/qr-token - Rotating 30s QR token (dynamic QR events)\n")
+	fmt.Printf("  POST /api/guest/checkout          - Guest checkout without account (VNPay)\n")
+	fmt.Printf("  GET  /api/guest/tickets?code=     - Guest tickets by emailed lookup code (rate-limited)\n")
+	fmt.Printf("  GET  /api/me/dashboard            - Student home screen summary\n")
+	fmt.Printf("  GET|PUT /api/me/recommendations   - Recommended events / opt out\n")
+	fmt.Printf("  GET  /api/me/attendance?semester= - Attended events and activity points per semester\n")
+	fmt.Printf("  GET  /api/me/sessions            - Active login sessions\n")
+	fmt.Printf("  DELETE /api/me/sessions/{id}     - Revoke a login session\n")
+	fmt.Printf("  GET  /api/me/data-export          - Personal data export (async ZIP)\n")
+	fmt.Printf("  GET  /api/me/data-export/download - Download ready data export\n")
+	fmt.Printf("  DELETE /api/me                    - Anonymize own account\n")
+	fmt.Printf("  GET  /api/admin/dashboard         - Platform KPIs (ADMIN, cached 60s)\n")
+	fmt.Printf("  GET  /api/admin/venues/utilization?from=&to= - Area utilization report (venue.manage, ?format=csv)\n")
+	fmt.Printf("  GET  /api/admin/attendance/points?semester= - Activity points per student (attendance.export, ?format=csv)\n")
 	fmt.Printf("  GET  /api/tickets/list             - Ticket list\n")
 	fmt.Printf("  GET  /api/payment/my-bills         - My bills\n")
+	fmt.Printf("  GET  /api/bills/{id}/resend-receipt - Resend payment receipt (rate-limited)\n")
+	fmt.Printf("  GET  /api/bills/{id}/installments  - Installment schedule of my bill\n")
+	fmt.Printf("  POST /api/bills/{id}/installments/{seq}/pay - Pay an installment (wallet|vnpay)\n")
 	fmt.Printf("  GET  /api/payment-ticket           - VNPay URL\n")
 	fmt.Printf("  GET  /api/buyTicket                - VNPay callback\n")
 	fmt.Printf("\n🏢 Venue Service:\n")
@@ -1568,49 +4282,79 @@ func main() {
 	fmt.Printf("  GET/POST/PUT/DELETE /api/venues/areas - Area CRUD\n")
 	fmt.Printf("  GET  /api/areas/free                  - Free areas\n")
 	fmt.Printf("  GET  /api/seats                       - Seats\n")
+	fmt.Printf("  PUT  /api/seats/accessibility         - Mark wheelchair/companion seats (Admin)\n")
 	fmt.Printf("\n👷 Staff Service:\n")
 	fmt.Printf("  POST /api/staff/checkin            - Check-in\n")
 	fmt.Printf("  POST /api/staff/checkout           - Check-out\n")
+	fmt.Printf("  GET  /api/staff/events/{id}/occupancy - Live occupancy (inside / capacity)\n")
+	fmt.Printf("  GET  /api/organizer/events/{id}/stats/stream - Live check-in stats (SSE, JSON poll fallback)\n")
 	fmt.Printf("  GET  /api/staff/reports            - Danh sách report\n")
 	fmt.Printf("  GET  /api/staff/reports/detail     - Chi tiết report\n")
 	fmt.Printf("  POST /api/staff/reports/process    - ⭐ APPROVE/REJECT report (REFUND)\n")
 	fmt.Printf("\n⚙️  System Config (Admin):\n")
 	fmt.Printf("  GET  /api/admin/config/system  - Get system config\n")
 	fmt.Printf("  POST /api/admin/config/system  - Update system config\n")
+	fmt.Printf("  GET  /api/admin/rules          - Effective business rules (?eventId=)\n")
+	fmt.Printf("  PUT  /api/admin/rules          - Update business rules\n")
+	fmt.Printf("  PUT  /api/admin/rules/events/{id} - Per-event cancel/update window and platform fee overrides\n")
+	fmt.Printf("  GET  /api/admin/rules/organizers/{id} - Organizer platform fee\n")
+	fmt.Printf("  GET|PUT /api/admin/retention     - Data retention policies (days per policy, 0 = keep)\n")
+	fmt.Printf("  POST /api/admin/retention/run    - Dry-run report (?dryRun=true) or trigger the data-retention job\n")
+	fmt.Printf("  PUT  /api/admin/rules/organizers/{id} - Set/clear organizer platform fee\n")
+	fmt.Printf("  GET  /api/admin/ledger/trial-balance - Ledger trial balance (?asOf=YYYY-MM-DD)\n")
+	fmt.Printf("  GET  /api/admin/settlements[/{id}] - Organizer settlements (?format=csv statement)\n")
+	fmt.Printf("  POST /api/admin/settlements/{id}/approve|mark-paid - Approve / record payout\n")
+	fmt.Printf("\n⏱️  Scheduled Jobs (Admin):\n")
+	fmt.Printf("  GET  /api/admin/jobs                 - List jobs + run history\n")
+	fmt.Printf("  POST /api/admin/jobs/{name}/run-now  - Trigger a job immediately\n")
+	fmt.Printf("  POST /api/admin/jobs/{name}/backfill - Re-run a job for a date range\n")
+	fmt.Printf("\n📧 Email Queue:\n")
+	fmt.Printf("  GET  /api/admin/emails               - Look up queued/sent/dead emails + bounces\n")
+	fmt.Printf("  POST /api/admin/emails/{id}/retry    - Re-queue a dead-letter email\n")
+	fmt.Printf("  POST /api/webhooks/email-events      - Provider bounce/complaint webhook\n")
+	fmt.Printf("  GET  /api/admin/email-templates      - List editable email templates\n")
+	fmt.Printf("  GET|PUT /api/admin/email-templates/{key} - Template + versions / save new version\n")
+	fmt.Printf("  POST /api/admin/email-templates/{key}/preview  - Render with sample data\n")
+	fmt.Printf("  POST /api/admin/email-templates/{key}/rollback - Re-activate a version (0 = built-in)\n")
+	fmt.Printf("\n🧩 Capabilities (ADMIN):\n")
+	fmt.Printf("  GET  /api/admin/capabilities         - Implemented/stubbed repository operations per route\n")
+	if diagnosticsEnabled {
+		fmt.Printf("\n🩺 Diagnostics (ADMIN):\n")
+		fmt.Printf("  GET  /api/admin/diagnostics/entities/{type}/{id} - Look up any record by ID\n")
+		fmt.Printf("  GET  /api/admin/diagnostics/status   - DB pool, email queue, scheduler jobs\n")
+		fmt.Printf("  GET  /api/admin/diagnostics/errors   - Recent error log samples\n")
+	}
+	if graphqlEnabled {
+		fmt.Printf("\n🔎 GraphQL Gateway:\n")
+		fmt.Printf("  POST /graphql                        - events, tickets, venues, requests, stats\n")
+	}
 	fmt.Printf("\n❤️  Health:\n")
-	fmt.Printf("  GET  /health\n")
+	fmt.Printf("  GET  /health/live                    - Liveness (also /health)\n")
+	fmt.Printf("  GET  /health/ready                   - Readiness: database, migrations, schedulers\n")
+	fmt.Printf("  GET  /metrics                        - Prometheus metrics (DB query histograms)\n")
 	fmt.Printf("========================================\n\n")
 
+	// Route dựa vào thao tác chưa hiện thực: log cảnh báo, CAPABILITY_GATE=fail thì dừng
+	if err := capability.Default.Gate(); err != nil {
+		log.Fatalf("Capability gate failed: %v", err)
+	}
+
 	// ======================= START SCHEDULER =======================
-	// Khởi động scheduled job để tự động giải phóng venue areas khi events kết thúc
-	eventCleanup := scheduler.NewEventCleanupScheduler(5) // Chạy mỗi 5 phút
-	eventCleanup.Start()
-	log.Println("✅ Event cleanup scheduler started (runs every 5 minutes)")
-
-	// Khởi động scheduled job để tự động xóa PENDING tickets sau 5 phút
-	// Giống Java backend - release ghế nếu user không hoàn thành thanh toán
-	pendingTicketCleanup := scheduler.NewPendingTicketCleanupScheduler(1) // Check mỗi 1 phút
-	pendingTicketCleanup.Start()
-	log.Println("✅ PENDING ticket cleanup scheduler started (checks every 1 minute, timeout: 5 minutes)")
-
-	// ======================= EXPIRED REQUESTS CLEANUP SCHEDULER =======================
-	// Khởi động scheduled job để tự động bãi bỏ sự kiện quá hạn cập nhật
-	// Logic: Tìm các sự kiện APPROVED/UPDATING trong vòng 24h trước start_time
-	// Hành động: Chuyển trạng thái sang CLOSED + giải phóng địa điểm
-	// Tần suất: Chạy mỗi 60 phút (1 giờ)
-	expiredRequestsCleanup := scheduler.NewExpiredRequestsCleanupScheduler(60)
-	expiredRequestsCleanup.Start()
-	log.Println("✅ Expired requests cleanup scheduler started (runs every 60 minutes)")
-
-	// ======================= VENUE RELEASE SCHEDULER =======================
-	// Khởi động scheduled job để tự động giải phóng địa điểm khi sự kiện kết thúc
-	// Ưu tiên: Chỉ giải phóng các địa điểm thuộc sự kiện đã CLOSED
-	// Tần suất: Chạy mỗi 5 phút
-	venueReleaseScheduler := scheduler.NewVenueReleaseScheduler(5)
-	venueReleaseScheduler.Start()
-	log.Println("✅ Venue release scheduler started (runs every 5 minutes)")
-
-	if err := http.ListenAndServe(":"+port, nil); err != nil {
+	// Các job định kỳ chạy qua scheduler.Manager:
+	//   - event-archival           (mỗi 5 phút)  đóng + lưu trữ sự kiện đã kết thúc
+	//   - pending-ticket-cleanup   (mỗi 1 phút)  xóa vé PENDING hết hạn giữ ghế
+	//   - expired-requests-cleanup (đầu mỗi giờ) bãi bỏ sự kiện quá hạn cập nhật
+	//   - venue-release            (mỗi 5 phút)  đối soát giải phóng địa điểm (đường chính: event bus)
+	// Lịch sử chạy lưu ở bảng Job_Run, xem qua GET /api/admin/jobs
+	jobManager := scheduler.DefaultManager()
+	if err := scheduler.RegisterDefaultJobs(jobManager); err != nil {
+		log.Fatalf("Failed to register scheduled jobs: %v", err)
+	}
+	jobManager.Start()
+	log.Println("✅ Scheduled jobs started")
+
+	// Response JSON/text từ 1KB được nén gzip/deflate theo Accept-Encoding (httpcache.Compress)
+	if err := http.ListenAndServe(":"+port, httpguard.SecurityHeaders(httpcache.Compress(http.DefaultServeMux))); err != nil {
 		log.Fatalf("Failed to start server: %v", err)
 	}
 }
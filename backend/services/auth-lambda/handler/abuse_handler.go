@@ -0,0 +1,105 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/fpt-event-services/common/abuse"
+	"github.com/fpt-event-services/common/authctx"
+	"github.com/fpt-event-services/common/permission"
+	"github.com/fpt-event-services/services/auth-lambda/models"
+	"github.com/fpt-event-services/services/auth-lambda/repository"
+	"github.com/fpt-event-services/services/auth-lambda/usecase"
+)
+
+// checkIPVelocity - Giới hạn số request theo IP (đăng ký / gửi OTP)
+// Trả về response 429 nếu vượt giới hạn
+func checkIPVelocity(limiter *abuse.Limiter, signal string, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, bool) {
+	ip := getClientIP(request)
+	ok, retryAfter := limiter.Allow(ip)
+	if ok {
+		return events.APIGatewayProxyResponse{}, true
+	}
+	abuse.Record(signal, abuse.ActionRateLimited)
+	log.Warn("Abuse velocity limit exceeded", "signal", signal, "ip", ip)
+	resp, _ := ipRateLimitedResponse(retryAfter)
+	return resp, false
+}
+
+func ipRateLimitedResponse(retryAfter time.Duration) (events.APIGatewayProxyResponse, error) {
+	minutes := int(retryAfter.Minutes()) + 1
+	resp, err := createStatusResponse(http.StatusTooManyRequests, "fail",
+		fmt.Sprintf("Quá nhiều yêu cầu từ địa chỉ IP này, vui lòng thử lại sau %d phút", minutes))
+	resp.Headers["Retry-After"] = abuse.RetryAfterHeader(retryAfter)
+	return resp, err
+}
+
+// quarantinedResponse - Tài khoản đã tạo nhưng chờ admin duyệt (không cấp token)
+func quarantinedResponse() (events.APIGatewayProxyResponse, error) {
+	return createStatusResponse(http.StatusAccepted, "pending_review", usecase.ErrAccountQuarantined.Error())
+}
+
+// abuseErrorResponse - Lỗi quyền / dữ liệu của các API xét duyệt tài khoản
+func abuseErrorResponse(err error) (events.APIGatewayProxyResponse, error) {
+	switch {
+	case errors.Is(err, authctx.ErrUnauthenticated):
+		return createErrorResponse(http.StatusUnauthorized, "Unauthorized")
+	case errors.Is(err, authctx.ErrForbidden):
+		return createErrorResponse(http.StatusForbidden, "Bạn không có quyền xét duyệt tài khoản")
+	case errors.Is(err, usecase.ErrInvalidReviewRequest):
+		return createErrorResponse(http.StatusBadRequest, "status phải là PENDING, APPROVED hoặc REJECTED; decision phải là APPROVE hoặc REJECT; note tối đa 500 ký tự")
+	case errors.Is(err, repository.ErrReviewNotFound):
+		return createErrorResponse(http.StatusNotFound, "Yêu cầu xét duyệt không tồn tại")
+	case errors.Is(err, repository.ErrReviewResolved):
+		return createErrorResponse(http.StatusConflict, "Yêu cầu đã được xét duyệt trước đó")
+	default:
+		log.Error("Account review failed", "error", err)
+		return createErrorResponse(http.StatusInternalServerError, "Không thể xử lý yêu cầu xét duyệt")
+	}
+}
+
+// ============================================================
+// HandleListAccountReviews - GET /api/admin/abuse/reviews?status=PENDING
+// Tài khoản bị cách ly kèm lý do, IP / thiết bị và số tài khoản cùng nguồn
+// ============================================================
+func (h *AuthHandler) HandleListAccountReviews(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	if _, err := permission.Require(ctx, permission.AbuseReview); err != nil {
+		return abuseErrorResponse(err)
+	}
+	reviews, err := h.useCase.ListAccountReviews(ctx, request.QueryStringParameters["status"])
+	if err != nil {
+		return abuseErrorResponse(err)
+	}
+	return createSuccessResponse(http.StatusOK, reviews)
+}
+
+// ============================================================
+// HandleResolveAccountReview - POST /api/admin/abuse/reviews/{id}
+// Body: {"decision":"APPROVE|REJECT","note":"..."}
+// ============================================================
+func (h *AuthHandler) HandleResolveAccountReview(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	adminID, err := permission.Require(ctx, permission.AbuseReview)
+	if err != nil {
+		return abuseErrorResponse(err)
+	}
+	reviewID, err := strconv.Atoi(request.PathParameters["id"])
+	if err != nil || reviewID <= 0 {
+		return createErrorResponse(http.StatusBadRequest, "ID không hợp lệ")
+	}
+	var req models.ResolveAccountReviewRequest
+	if err := json.Unmarshal([]byte(request.Body), &req); err != nil {
+		return createErrorResponse(http.StatusBadRequest, "Invalid request body")
+	}
+	review, err := h.useCase.ResolveAccountReview(ctx, reviewID, adminID, req)
+	if err != nil {
+		return abuseErrorResponse(err)
+	}
+	log.Info("Account review resolved", "reviewId", reviewID, "adminId", adminID, "status", review.Status)
+	return createSuccessResponse(http.StatusOK, review)
+}
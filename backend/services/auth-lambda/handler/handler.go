@@ -3,16 +3,23 @@ package handler
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"strings"
 
 	"github.com/aws/aws-lambda-go/events"
+	"github.com/fpt-event-services/common/abuse"
+	"github.com/fpt-event-services/common/campus"
 	"github.com/fpt-event-services/common/email"
 	"github.com/fpt-event-services/common/jwt"
 	"github.com/fpt-event-services/common/logger"
+	"github.com/fpt-event-services/common/otp"
+	"github.com/fpt-event-services/common/permission"
 	"github.com/fpt-event-services/common/recaptcha"
 	"github.com/fpt-event-services/common/response"
+	"github.com/fpt-event-services/common/session"
+	"github.com/fpt-event-services/common/sms"
 	"github.com/fpt-event-services/services/auth-lambda/models"
 	"github.com/fpt-event-services/services/auth-lambda/usecase"
 )
@@ -21,11 +28,15 @@ import (
 var (
 	emailService        *email.EmailService
 	recaptchaService    *recaptcha.RecaptchaService
+	smsService          *sms.SMSService
+	otpLimiter          *otpRateLimiter
+	registerIPLimiter   *abuse.Limiter
+	otpIPLimiter        *abuse.Limiter
 	log                 = logger.Default()
 	servicesInitialized bool
 )
 
-// InitServices initializes email and recaptcha services
+// InitServices initializes email, sms and recaptcha services
 // Must be called after environment variables are loaded
 func InitServices() {
 	if servicesInitialized {
@@ -33,8 +44,12 @@ func InitServices() {
 	}
 	emailService = email.NewEmailService(nil)
 	recaptchaService = recaptcha.NewRecaptchaService(nil)
+	smsService = sms.NewSMSService(nil)
+	otpLimiter = newOTPRateLimiter()
+	registerIPLimiter = abuse.RegisterLimiter()
+	otpIPLimiter = abuse.OTPLimiter()
 	servicesInitialized = true
-	log.Info("Auth services initialized (email, recaptcha)")
+	log.Info("Auth services initialized (email, sms, recaptcha)", "smsConfigured", smsService.IsConfigured())
 }
 
 // AuthHandler handles authentication requests
@@ -63,12 +78,17 @@ func verifyRecaptcha(token, action, clientIP string) error {
 	}
 	if !result.Valid {
 		log.Warn("reCAPTCHA verification failed", "message", result.ErrorMessage, "score", result.Score)
-		return fmt.Errorf(result.ErrorMessage)
+		return fmt.Errorf("%s", result.ErrorMessage)
 	}
 	log.Debug("reCAPTCHA verified", "score", result.Score, "action", result.Action)
 	return nil
 }
 
+// sendOTPEmail gửi OTP qua hàng đợi email (lần gửi đầu lỗi thì job email-queue gửi lại)
+func sendOTPEmail(ctx context.Context, to, code, purpose string) error {
+	return email.DefaultQueue().Send(ctx, email.TemplateOTP, emailService.BuildOTPEmail(to, code, purpose), "otp:"+purpose)
+}
+
 // getClientIP extracts client IP from request headers
 func getClientIP(request events.APIGatewayProxyRequest) string {
 	if forwarded := request.Headers["X-Forwarded-For"]; forwarded != "" {
@@ -81,6 +101,15 @@ func getClientIP(request events.APIGatewayProxyRequest) string {
 	return request.RequestContext.Identity.SourceIP
 }
 
+// clientInfo - IP, user agent và dấu vân tay thiết bị của request (phiên đăng nhập, chống lạm dụng)
+func clientInfo(request events.APIGatewayProxyRequest) models.ClientInfo {
+	return models.ClientInfo{
+		IPAddress:         getClientIP(request),
+		UserAgent:         request.Headers["User-Agent"],
+		DeviceFingerprint: abuse.Fingerprint(request.Headers),
+	}
+}
+
 // HandleLogin handles POST /api/login
 func (h *AuthHandler) HandleLogin(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
 	// Parse request body
@@ -90,15 +119,19 @@ func (h *AuthHandler) HandleLogin(ctx context.Context, request events.APIGateway
 	}
 
 	// Execute login
-	authResponse, err := h.useCase.Login(ctx, req)
+	client := clientInfo(request)
+	authResponse, err := h.useCase.Login(ctx, req, client)
 	if err != nil {
 		statusCode := http.StatusUnauthorized
-		if err.Error() == "user is blocked" {
+		if err.Error() == "user is blocked" || err.Error() == "account is pending review" {
 			statusCode = http.StatusForbidden
 		}
 		return createErrorResponse(statusCode, err.Error())
 	}
 
+	// Lịch sử đăng nhập (xuất trong GET /api/me/data-export)
+	h.useCase.RecordLogin(ctx, authResponse.User.ID, client.IPAddress, client.UserAgent)
+
 	// Return format matching Java: {status: "success", user: {...}, token: "..."}
 	resp := map[string]interface{}{
 		"status": "success",
@@ -110,8 +143,7 @@ func (h *AuthHandler) HandleLogin(ctx context.Context, request events.APIGateway
 	return events.APIGatewayProxyResponse{
 		StatusCode: http.StatusOK,
 		Headers: map[string]string{
-			"Content-Type":                "application/json",
-			"Access-Control-Allow-Origin": "*",
+			"Content-Type": "application/json",
 		},
 		Body: string(body),
 	}, nil
@@ -124,10 +156,16 @@ func (h *AuthHandler) HandleRegister(ctx context.Context, request events.APIGate
 	if err := json.Unmarshal([]byte(request.Body), &req); err != nil {
 		return createErrorResponse(http.StatusBadRequest, "Invalid request body")
 	}
+	if resp, ok := checkIPVelocity(registerIPLimiter, abuse.SignalRegisterIP, request); !ok {
+		return resp, nil
+	}
 
 	// Execute registration
-	authResponse, err := h.useCase.Register(ctx, req)
+	authResponse, err := h.useCase.Register(ctx, req, clientInfo(request))
 	if err != nil {
+		if errors.Is(err, usecase.ErrAccountQuarantined) {
+			return quarantinedResponse()
+		}
 		statusCode := http.StatusBadRequest
 		if err.Error() == "email already exists" {
 			statusCode = http.StatusConflict
@@ -146,8 +184,7 @@ func (h *AuthHandler) HandleRegister(ctx context.Context, request events.APIGate
 	return events.APIGatewayProxyResponse{
 		StatusCode: http.StatusOK,
 		Headers: map[string]string{
-			"Content-Type":                "application/json",
-			"Access-Control-Allow-Origin": "*",
+			"Content-Type": "application/json",
 		},
 		Body: string(body),
 	}, nil
@@ -161,8 +198,8 @@ func (h *AuthHandler) HandleAdminCreateAccount(ctx context.Context, request even
 		return createErrorResponse(http.StatusUnauthorized, "Missing authorization token")
 	}
 
-	// Verify admin role
-	if !jwt.IsAdmin(token) {
+	// Verify user.manage permission
+	if !hasTokenPermission(ctx, token, permission.UserManage) {
 		return createErrorResponse(http.StatusForbidden, "Admin access required")
 	}
 
@@ -185,6 +222,9 @@ func (h *AuthHandler) HandleAdminCreateAccount(ctx context.Context, request even
 		if err.Error() == "email already exists" {
 			statusCode = http.StatusConflict
 		}
+		if errors.Is(err, campus.ErrOutOfScope) {
+			statusCode = http.StatusForbidden
+		}
 		return createErrorResponse(statusCode, err.Error())
 	}
 
@@ -193,6 +233,16 @@ func (h *AuthHandler) HandleAdminCreateAccount(ctx context.Context, request even
 
 // Helper functions
 
+// hasTokenPermission - Role trong token có quyền perm không
+// (Lambda độc lập không qua authMiddleware nên đọc role từ token, kiểm tra phiên như authMiddleware)
+func hasTokenPermission(ctx context.Context, token, perm string) bool {
+	claims, err := jwt.ValidateToken(token)
+	if err != nil || (claims.ID != "" && !session.Active(ctx, claims.UserID, claims.ID)) {
+		return false
+	}
+	return permission.RoleHas(ctx, claims.Role, perm)
+}
+
 func extractToken(request events.APIGatewayProxyRequest) string {
 	// Try Authorization header first
 	if auth := request.Headers["Authorization"]; auth != "" {
@@ -219,8 +269,7 @@ func createSuccessResponse(statusCode int, data interface{}) (events.APIGatewayP
 	return events.APIGatewayProxyResponse{
 		StatusCode: statusCode,
 		Headers: map[string]string{
-			"Content-Type":                "application/json",
-			"Access-Control-Allow-Origin": "*",
+			"Content-Type": "application/json",
 		},
 		Body: string(body),
 	}, nil
@@ -233,8 +282,7 @@ func createErrorResponse(statusCode int, message string) (events.APIGatewayProxy
 	return events.APIGatewayProxyResponse{
 		StatusCode: statusCode,
 		Headers: map[string]string{
-			"Content-Type":                "application/json",
-			"Access-Control-Allow-Origin": "*",
+			"Content-Type": "application/json",
 		},
 		Body: string(body),
 	}, nil
@@ -256,6 +304,9 @@ func (h *AuthHandler) HandleForgotPassword(ctx context.Context, request events.A
 	if req.Email == "" {
 		return createStatusResponse(http.StatusBadRequest, "fail", "Email không được để trống")
 	}
+	if resp, ok := checkIPVelocity(otpIPLimiter, abuse.SignalOTPIP, request); !ok {
+		return resp, nil
+	}
 
 	// Verify reCAPTCHA (if configured)
 	if req.RecaptchaToken != "" {
@@ -266,9 +317,26 @@ func (h *AuthHandler) HandleForgotPassword(ctx context.Context, request events.A
 		}
 	}
 
+	channel, err := resolveOTPChannel(req.Channel)
+	if err != nil {
+		return createStatusResponse(http.StatusBadRequest, "fail", err.Error())
+	}
+	phone := ""
+	if channel != otpChannelEmail {
+		if phone, err = h.useCase.FindOTPPhone(ctx, req.Email); err != nil {
+			return createStatusResponse(http.StatusBadRequest, "fail", err.Error())
+		}
+	}
+	if ok, retryAfter := otpLimiter.Allow(channel, otpDestination(channel, req.Email, phone)); !ok {
+		return rateLimitedOTPResponse(channel, retryAfter)
+	}
+
 	// Generate OTP
-	otp, err := h.useCase.ForgotPassword(ctx, req.Email)
+	code, err := h.useCase.ForgotPassword(ctx, req.Email)
 	if err != nil {
+		if verr, ok := otp.AsVerifyError(err); ok {
+			return createOTPErrorResponse(http.StatusTooManyRequests, verr)
+		}
 		// Check specific error types
 		if err.Error() == "email không tồn tại trong hệ thống" {
 			return createStatusResponse(http.StatusNotFound, "fail", err.Error())
@@ -276,14 +344,15 @@ func (h *AuthHandler) HandleForgotPassword(ctx context.Context, request events.A
 		return createStatusResponse(http.StatusBadRequest, "fail", err.Error())
 	}
 
-	// GỬI EMAIL VỚI OTP (Production-ready)
-	if err := emailService.SendOTPEmail(req.Email, otp, "forgot_password"); err != nil {
+	// GỬI OTP (email / SMS / Zalo)
+	delivery, err := deliverOTP(ctx, channel, req.Email, phone, code, "forgot_password")
+	if err != nil {
 		log.Error("Failed to send OTP email", "email", req.Email, "error", err)
 		// Vẫn trả về success để không leak thông tin email tồn tại hay không
 	}
 
-	log.Info("OTP sent for forgot password", "email", req.Email)
-	return createStatusResponse(http.StatusOK, "success", "Đã gửi OTP đặt lại mật khẩu tới email")
+	log.Info("OTP sent for forgot password", "email", req.Email, "channel", delivery.Channel)
+	return createStatusResponse(http.StatusOK, "success", delivery.message("Đã gửi OTP đặt lại mật khẩu tới email"))
 }
 
 // ============================================================
@@ -306,13 +375,14 @@ func (h *AuthHandler) HandleResetPassword(ctx context.Context, request events.AP
 	// Reset password
 	err := h.useCase.ResetPassword(ctx, req)
 	if err != nil {
+		if verr, ok := otp.AsVerifyError(err); ok {
+			return createOTPErrorResponse(http.StatusUnauthorized, verr)
+		}
 		// Determine error type
 		errMsg := err.Error()
 		switch errMsg {
 		case "email không tồn tại trong hệ thống":
 			return createStatusResponse(http.StatusNotFound, "fail", errMsg)
-		case "OTP không đúng", "OTP đã hết hạn", "OTP đã được sử dụng", "Đã nhập sai quá 5 lần, vui lòng yêu cầu OTP mới":
-			return createStatusResponse(http.StatusUnauthorized, "fail", errMsg)
 		default:
 			return createStatusResponse(http.StatusBadRequest, "fail", errMsg)
 		}
@@ -333,13 +403,40 @@ func createStatusResponse(statusCode int, status, message string) (events.APIGat
 	return events.APIGatewayProxyResponse{
 		StatusCode: statusCode,
 		Headers: map[string]string{
-			"Content-Type":                "application/json;charset=UTF-8",
-			"Access-Control-Allow-Origin": "*",
+			"Content-Type": "application/json;charset=UTF-8",
 		},
 		Body: string(body),
 	}, nil
 }
 
+// createOTPErrorResponse trả lỗi OTP kèm số lần thử còn lại / thời gian hiệu lực / thời gian khóa
+// Sai mã, hết hạn dùng invalidStatus của từng API; bị khóa luôn là 429
+func createOTPErrorResponse(invalidStatus int, verr *otp.VerifyError) (events.APIGatewayProxyResponse, error) {
+	statusCode := invalidStatus
+	resp := map[string]interface{}{
+		"status":  "fail",
+		"message": verr.Message,
+		"reason":  verr.Reason,
+	}
+	switch verr.Reason {
+	case otp.ReasonInvalid:
+		resp["remainingAttempts"] = verr.RemainingAttempts
+		resp["expiresInSeconds"] = int(verr.ExpiresIn.Seconds())
+	case otp.ReasonLocked:
+		statusCode = http.StatusTooManyRequests
+		resp["retryAfterSeconds"] = int(verr.RetryAfter.Seconds()) + 1
+	}
+	body, _ := json.Marshal(resp)
+
+	headers := map[string]string{
+		"Content-Type": "application/json;charset=UTF-8",
+	}
+	if verr.Reason == otp.ReasonLocked {
+		headers["Retry-After"] = fmt.Sprintf("%d", int(verr.RetryAfter.Seconds())+1)
+	}
+	return events.APIGatewayProxyResponse{StatusCode: statusCode, Headers: headers, Body: string(body)}, nil
+}
+
 // ============================================================
 // HandleRegisterSendOTP - POST /api/register/send-otp
 // Register step 1 - Send OTP to email
@@ -356,6 +453,12 @@ func (h *AuthHandler) HandleRegisterSendOTP(ctx context.Context, request events.
 	if req.Email == "" || req.Password == "" || req.FullName == "" || req.Phone == "" {
 		return createStatusResponse(http.StatusBadRequest, "fail", "Vui lòng điền đầy đủ thông tin")
 	}
+	if resp, ok := checkIPVelocity(registerIPLimiter, abuse.SignalRegisterIP, request); !ok {
+		return resp, nil
+	}
+	if resp, ok := checkIPVelocity(otpIPLimiter, abuse.SignalOTPIP, request); !ok {
+		return resp, nil
+	}
 
 	// Verify reCAPTCHA (if provided)
 	if req.RecaptchaToken != "" {
@@ -375,20 +478,35 @@ func (h *AuthHandler) HandleRegisterSendOTP(ctx context.Context, request events.
 		return createStatusResponse(http.StatusConflict, "fail", "Email đã tồn tại trong hệ thống")
 	}
 
+	channel, err := resolveOTPChannel(req.Channel)
+	if err != nil {
+		return createStatusResponse(http.StatusBadRequest, "fail", err.Error())
+	}
+	if ok, retryAfter := otpLimiter.Allow(channel, otpDestination(channel, req.Email, req.Phone)); !ok {
+		return rateLimitedOTPResponse(channel, retryAfter)
+	}
+
 	// Generate and send OTP
-	otp, err := h.useCase.GenerateRegisterOTP(ctx, req)
+	code, err := h.useCase.GenerateRegisterOTP(ctx, req)
 	if err != nil {
+		if verr, ok := otp.AsVerifyError(err); ok {
+			return createOTPErrorResponse(http.StatusTooManyRequests, verr)
+		}
+		if errors.Is(err, usecase.ErrDisposableEmail) {
+			return createStatusResponse(http.StatusBadRequest, "fail", err.Error())
+		}
 		return createStatusResponse(http.StatusBadGateway, "fail", "Không thể gửi OTP")
 	}
 
-	// GỬI EMAIL VỚI OTP (Production-ready)
-	if err := emailService.SendOTPEmail(req.Email, otp, "register"); err != nil {
+	// GỬI OTP (email / SMS / Zalo)
+	delivery, err := deliverOTP(ctx, channel, req.Email, req.Phone, code, "register")
+	if err != nil {
 		log.Error("Failed to send registration OTP email", "email", req.Email, "error", err)
 		// Continue anyway to not block registration flow in dev mode
 	}
 
-	log.Info("Registration OTP sent", "email", req.Email)
-	return createStatusResponse(http.StatusOK, "success", "Đã gửi OTP tới email")
+	log.Info("Registration OTP sent", "email", req.Email, "channel", delivery.Channel)
+	return createStatusResponse(http.StatusOK, "success", delivery.message("Đã gửi OTP tới email"))
 }
 
 // ============================================================
@@ -407,12 +525,16 @@ func (h *AuthHandler) HandleRegisterVerifyOTP(ctx context.Context, request event
 	}
 
 	// Verify OTP and create user
-	authResponse, err := h.useCase.VerifyRegisterOTP(ctx, req.Email, req.OTP)
+	authResponse, err := h.useCase.VerifyRegisterOTP(ctx, req.Email, req.OTP, clientInfo(request))
 	if err != nil {
+		if verr, ok := otp.AsVerifyError(err); ok {
+			return createOTPErrorResponse(http.StatusBadRequest, verr)
+		}
+		if errors.Is(err, usecase.ErrAccountQuarantined) {
+			return quarantinedResponse()
+		}
 		errMsg := err.Error()
 		switch errMsg {
-		case "OTP không đúng", "OTP đã hết hạn":
-			return createStatusResponse(http.StatusBadRequest, "fail", errMsg)
 		case "Email đã tồn tại":
 			return createStatusResponse(http.StatusConflict, "fail", errMsg)
 		default:
@@ -431,8 +553,7 @@ func (h *AuthHandler) HandleRegisterVerifyOTP(ctx context.Context, request event
 	return events.APIGatewayProxyResponse{
 		StatusCode: http.StatusOK,
 		Headers: map[string]string{
-			"Content-Type":                "application/json",
-			"Access-Control-Allow-Origin": "*",
+			"Content-Type": "application/json",
 		},
 		Body: string(body),
 	}, nil
@@ -452,10 +573,30 @@ func (h *AuthHandler) HandleRegisterResendOTP(ctx context.Context, request event
 	if req.Email == "" {
 		return createStatusResponse(http.StatusBadRequest, "fail", "Email không được để trống")
 	}
+	if resp, ok := checkIPVelocity(otpIPLimiter, abuse.SignalOTPIP, request); !ok {
+		return resp, nil
+	}
+
+	channel, err := resolveOTPChannel(req.Channel)
+	if err != nil {
+		return createStatusResponse(http.StatusBadRequest, "fail", err.Error())
+	}
+	phone := ""
+	if channel != otpChannelEmail {
+		if phone, err = h.useCase.FindOTPPhone(ctx, req.Email); err != nil {
+			return createStatusResponse(http.StatusBadRequest, "fail", err.Error())
+		}
+	}
+	if ok, retryAfter := otpLimiter.Allow(channel, otpDestination(channel, req.Email, phone)); !ok {
+		return rateLimitedOTPResponse(channel, retryAfter)
+	}
 
 	// Resend OTP
-	otp, err := h.useCase.ResendRegisterOTP(ctx, req.Email)
+	code, err := h.useCase.ResendRegisterOTP(ctx, req.Email)
 	if err != nil {
+		if verr, ok := otp.AsVerifyError(err); ok {
+			return createOTPErrorResponse(http.StatusTooManyRequests, verr)
+		}
 		errMsg := err.Error()
 		switch errMsg {
 		case "Không có đăng ký đang chờ cho email này":
@@ -467,13 +608,14 @@ func (h *AuthHandler) HandleRegisterResendOTP(ctx context.Context, request event
 		}
 	}
 
-	// GỬI EMAIL VỚI OTP (Production-ready)
-	if err := emailService.SendOTPEmail(req.Email, otp, "register"); err != nil {
+	// GỬI OTP (email / SMS / Zalo)
+	delivery, err := deliverOTP(ctx, channel, req.Email, phone, code, "register")
+	if err != nil {
 		log.Error("Failed to resend registration OTP email", "email", req.Email, "error", err)
 	}
 
-	log.Info("Registration OTP resent", "email", req.Email)
-	return createStatusResponse(http.StatusOK, "success", "Đã gửi lại OTP")
+	log.Info("Registration OTP resent", "email", req.Email, "channel", delivery.Channel)
+	return createStatusResponse(http.StatusOK, "success", delivery.message("Đã gửi lại OTP"))
 }
 
 // ============================================================
@@ -488,8 +630,8 @@ func (h *AuthHandler) HandleAdminUpdateUser(ctx context.Context, request events.
 		return createErrorResponse(http.StatusUnauthorized, "Missing authorization token")
 	}
 
-	// Verify admin role
-	if !jwt.IsAdmin(token) {
+	// Verify user.manage permission
+	if !hasTokenPermission(ctx, token, permission.UserManage) {
 		return createErrorResponse(http.StatusForbidden, "Admin access required")
 	}
 
@@ -524,8 +666,8 @@ func (h *AuthHandler) HandleAdminDeleteUser(ctx context.Context, request events.
 		return createErrorResponse(http.StatusUnauthorized, "Missing authorization token")
 	}
 
-	// Verify admin role
-	if !jwt.IsAdmin(token) {
+	// Verify user.manage permission
+	if !hasTokenPermission(ctx, token, permission.UserManage) {
 		return createErrorResponse(http.StatusForbidden, "Admin access required")
 	}
 
@@ -556,8 +698,8 @@ func (h *AuthHandler) HandleGetStaffOrganizer(ctx context.Context, request event
 		return createErrorResponse(http.StatusUnauthorized, "Missing authorization token")
 	}
 
-	// Verify admin role
-	if !jwt.IsAdmin(token) {
+	// Verify user.manage permission
+	if !hasTokenPermission(ctx, token, permission.UserManage) {
 		return createErrorResponse(http.StatusForbidden, "Admin access required")
 	}
 
@@ -572,8 +714,7 @@ func (h *AuthHandler) HandleGetStaffOrganizer(ctx context.Context, request event
 	return events.APIGatewayProxyResponse{
 		StatusCode: http.StatusOK,
 		Headers: map[string]string{
-			"Content-Type":                "application/json",
-			"Access-Control-Allow-Origin": "*",
+			"Content-Type": "application/json",
 		},
 		Body: string(body),
 	}, nil
@@ -0,0 +1,150 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/fpt-event-services/common/sms"
+)
+
+// ============================================================
+// OTP CHANNELS - Gửi OTP qua email, SMS hoặc Zalo ZNS
+// Client chọn bằng trường "channel" (mặc định email)
+// Kênh điện thoại lỗi hết nhà cung cấp thì chuyển sang email
+// ============================================================
+
+// Kênh gửi OTP
+const (
+	otpChannelEmail = "email"
+	otpChannelSMS   = "sms"
+	otpChannelZalo  = "zalo"
+)
+
+// otpDelivery - Kết quả gửi OTP (kênh thực tế đã dùng sau failover)
+type otpDelivery struct {
+	Channel     string
+	Destination string // email hoặc số điện thoại đã che
+}
+
+// message trả về thông báo cho client theo kênh đã gửi
+func (d otpDelivery) message(emailMessage string) string {
+	if d.Channel == otpChannelEmail {
+		return emailMessage
+	}
+	return "Đã gửi OTP tới số điện thoại " + d.Destination
+}
+
+// resolveOTPChannel chuẩn hóa channel trong request và kiểm tra kênh đã được cấu hình
+func resolveOTPChannel(channel string) (string, error) {
+	switch channel = strings.ToLower(strings.TrimSpace(channel)); channel {
+	case "", otpChannelEmail:
+		return otpChannelEmail, nil
+	case otpChannelSMS, otpChannelZalo:
+		if !smsService.IsConfigured() {
+			return "", fmt.Errorf("Kênh %s chưa được hỗ trợ, vui lòng nhận OTP qua email", channel)
+		}
+		return channel, nil
+	default:
+		return "", fmt.Errorf("channel không hợp lệ (email, sms, zalo)")
+	}
+}
+
+// otpDestination - Địa chỉ nhận OTP dùng làm khóa giới hạn tần suất
+func otpDestination(channel, email, phone string) string {
+	if channel == otpChannelEmail {
+		return strings.ToLower(email)
+	}
+	return sms.NormalizePhone(phone)
+}
+
+// deliverOTP gửi OTP theo kênh đã chọn
+func deliverOTP(ctx context.Context, channel, to, phone, code, purpose string) (otpDelivery, error) {
+	if channel != otpChannelEmail {
+		preferred := sms.ProviderSpeedSMS
+		if channel == otpChannelZalo {
+			preferred = sms.ProviderZaloZNS
+		}
+		provider, err := smsService.SendOTP(ctx, phone, code, preferred)
+		if err == nil {
+			log.Info("OTP sent via phone", "channel", channel, "provider", provider, "purpose", purpose)
+			return otpDelivery{Channel: channel, Destination: sms.MaskPhone(sms.NormalizePhone(phone))}, nil
+		}
+		log.Warn("All phone OTP providers failed, falling back to email", "channel", channel, "error", err)
+	}
+	return otpDelivery{Channel: otpChannelEmail, Destination: to}, sendOTPEmail(ctx, to, code, purpose)
+}
+
+// ============================================================
+// Giới hạn số lần gửi OTP theo kênh và địa chỉ nhận (cửa sổ trượt, in-memory)
+// OTP_RATE_LIMIT_EMAIL / OTP_RATE_LIMIT_SMS lần trong OTP_RATE_LIMIT_WINDOW_MINUTES phút
+// SMS và Zalo dùng chung hạn mức vì cùng tính phí theo số điện thoại
+// ============================================================
+
+type otpRateLimiter struct {
+	mu     sync.Mutex
+	window time.Duration
+	limits map[string]int
+	hits   map[string][]time.Time
+}
+
+func newOTPRateLimiter() *otpRateLimiter {
+	phoneLimit := envInt("OTP_RATE_LIMIT_SMS", 3)
+	return &otpRateLimiter{
+		window: time.Duration(envInt("OTP_RATE_LIMIT_WINDOW_MINUTES", 15)) * time.Minute,
+		limits: map[string]int{
+			otpChannelEmail: envInt("OTP_RATE_LIMIT_EMAIL", 5),
+			otpChannelSMS:   phoneLimit,
+			otpChannelZalo:  phoneLimit,
+		},
+		hits: make(map[string][]time.Time),
+	}
+}
+
+// Allow ghi nhận một lần gửi; false kèm thời gian phải chờ nếu vượt hạn mức
+func (l *otpRateLimiter) Allow(channel, destination string) (bool, time.Duration) {
+	bucket := channel
+	if channel == otpChannelZalo {
+		bucket = otpChannelSMS
+	}
+	key := bucket + ":" + destination
+	now := time.Now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	recent := l.hits[key][:0]
+	for _, t := range l.hits[key] {
+		if now.Sub(t) < l.window {
+			recent = append(recent, t)
+		}
+	}
+	if len(recent) >= l.limits[channel] {
+		l.hits[key] = recent
+		return false, l.window - now.Sub(recent[0])
+	}
+	l.hits[key] = append(recent, now)
+	return true, 0
+}
+
+// rateLimitedOTPResponse - 429 khi vượt hạn mức gửi OTP
+func rateLimitedOTPResponse(channel string, retryAfter time.Duration) (events.APIGatewayProxyResponse, error) {
+	minutes := int(retryAfter.Minutes()) + 1
+	resp, err := createStatusResponse(http.StatusTooManyRequests, "fail",
+		fmt.Sprintf("Bạn đã yêu cầu OTP qua %s quá nhiều lần, vui lòng thử lại sau %d phút", channel, minutes))
+	resp.Headers["Retry-After"] = strconv.Itoa(int(retryAfter.Seconds()) + 1)
+	return resp, err
+}
+
+func envInt(key string, defaultValue int) int {
+	if n, err := strconv.Atoi(os.Getenv(key)); err == nil && n > 0 {
+		return n
+	}
+	return defaultValue
+}
@@ -0,0 +1,113 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/fpt-event-services/common/authctx"
+	"github.com/fpt-event-services/common/permission"
+	"github.com/fpt-event-services/services/auth-lambda/models"
+	"github.com/fpt-event-services/services/auth-lambda/repository"
+	"github.com/fpt-event-services/services/auth-lambda/usecase"
+)
+
+// roleErrorResponse - Lỗi quyền / dữ liệu của các API quản lý role
+func roleErrorResponse(err error) (events.APIGatewayProxyResponse, error) {
+	switch {
+	case errors.Is(err, authctx.ErrUnauthenticated):
+		return createErrorResponse(http.StatusUnauthorized, "Unauthorized")
+	case errors.Is(err, authctx.ErrForbidden):
+		return createErrorResponse(http.StatusForbidden, "Bạn không có quyền quản lý role")
+	case errors.Is(err, usecase.ErrInvalidRoleRequest):
+		return createErrorResponse(http.StatusBadRequest, err.Error())
+	case errors.Is(err, repository.ErrRoleNotFound):
+		return createErrorResponse(http.StatusNotFound, "Role không tồn tại")
+	case errors.Is(err, repository.ErrRoleExists), errors.Is(err, repository.ErrRoleInUse), errors.Is(err, usecase.ErrSystemRole):
+		return createErrorResponse(http.StatusConflict, err.Error())
+	default:
+		log.Error("Role management failed", "error", err)
+		return createErrorResponse(http.StatusInternalServerError, "Không thể xử lý yêu cầu role")
+	}
+}
+
+// ============================================================
+// HandleListPermissions - GET /api/admin/permissions
+// Danh sách quyền có thể gán cho role
+// ============================================================
+func (h *AuthHandler) HandleListPermissions(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	if _, err := permission.Require(ctx, permission.RoleManage); err != nil {
+		return roleErrorResponse(err)
+	}
+	return createSuccessResponse(http.StatusOK, permission.Catalog)
+}
+
+// ============================================================
+// HandleListRoles - GET /api/admin/roles
+// Role kèm quyền gán trực tiếp, quyền hiệu lực (đã kế thừa) và số tài khoản
+// ============================================================
+func (h *AuthHandler) HandleListRoles(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	if _, err := permission.Require(ctx, permission.RoleManage); err != nil {
+		return roleErrorResponse(err)
+	}
+	roles, err := h.useCase.ListRoles(ctx)
+	if err != nil {
+		return roleErrorResponse(err)
+	}
+	return createSuccessResponse(http.StatusOK, roles)
+}
+
+// ============================================================
+// HandleCreateRole - POST /api/admin/roles
+// Body: {"name":"FINANCE_STAFF","parentRole":"STAFF","permissions":["ticket.view_all"]}
+// ============================================================
+func (h *AuthHandler) HandleCreateRole(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	if _, err := permission.Require(ctx, permission.RoleManage); err != nil {
+		return roleErrorResponse(err)
+	}
+	var req models.RoleRequest
+	if err := json.Unmarshal([]byte(request.Body), &req); err != nil {
+		return createErrorResponse(http.StatusBadRequest, "Invalid request body")
+	}
+	if err := h.useCase.CreateRole(ctx, req); err != nil {
+		return roleErrorResponse(err)
+	}
+	return createSuccessResponse(http.StatusCreated, map[string]string{"name": strings.ToUpper(strings.TrimSpace(req.Name))})
+}
+
+// ============================================================
+// HandleUpdateRole - PUT /api/admin/roles/{name}
+// Thay toàn bộ mô tả, role cha và quyền gán trực tiếp; có hiệu lực ngay
+// ============================================================
+func (h *AuthHandler) HandleUpdateRole(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	if _, err := permission.Require(ctx, permission.RoleManage); err != nil {
+		return roleErrorResponse(err)
+	}
+	var req models.RoleRequest
+	if err := json.Unmarshal([]byte(request.Body), &req); err != nil {
+		return createErrorResponse(http.StatusBadRequest, "Invalid request body")
+	}
+	req.Name = request.PathParameters["name"]
+	if err := h.useCase.UpdateRole(ctx, req); err != nil {
+		return roleErrorResponse(err)
+	}
+	return createSuccessResponse(http.StatusOK, map[string]string{"name": strings.ToUpper(req.Name)})
+}
+
+// ============================================================
+// HandleDeleteRole - DELETE /api/admin/roles/{name}
+// Chỉ role tùy chỉnh, không còn tài khoản hay role con nào dùng
+// ============================================================
+func (h *AuthHandler) HandleDeleteRole(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	if _, err := permission.Require(ctx, permission.RoleManage); err != nil {
+		return roleErrorResponse(err)
+	}
+	name := strings.ToUpper(strings.TrimSpace(request.PathParameters["name"]))
+	if err := h.useCase.DeleteRole(ctx, name); err != nil {
+		return roleErrorResponse(err)
+	}
+	return createSuccessResponse(http.StatusOK, map[string]string{"name": name})
+}
@@ -0,0 +1,51 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/fpt-event-services/common/authctx"
+	"github.com/fpt-event-services/services/auth-lambda/repository"
+)
+
+// ============================================================
+// HandleListSessions - GET /api/me/sessions
+// Phiên đăng nhập còn hiệu lực: thiết bị, user agent, IP, lần dùng gần nhất
+// current = true cho phiên của token đang gọi API
+// ============================================================
+func (h *AuthHandler) HandleListSessions(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	userID, ok := authctx.UserID(ctx)
+	if !ok {
+		return createErrorResponse(http.StatusUnauthorized, "Unauthorized")
+	}
+
+	sessions, err := h.useCase.ListSessions(ctx, userID, authctx.SessionID(ctx))
+	if err != nil {
+		log.Error("Failed to list sessions", "user_id", userID, "error", err)
+		return createErrorResponse(http.StatusInternalServerError, "Không thể tải danh sách phiên đăng nhập")
+	}
+	return createSuccessResponse(http.StatusOK, sessions)
+}
+
+// ============================================================
+// HandleRevokeSession - DELETE /api/me/sessions/{id}
+// Thu hồi một phiên: token của phiên đó không dùng được nữa
+// (thu hồi phiên hiện tại tương đương đăng xuất)
+// ============================================================
+func (h *AuthHandler) HandleRevokeSession(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	userID, ok := authctx.UserID(ctx)
+	if !ok {
+		return createErrorResponse(http.StatusUnauthorized, "Unauthorized")
+	}
+
+	if err := h.useCase.RevokeSession(ctx, userID, request.PathParameters["id"]); err != nil {
+		if errors.Is(err, repository.ErrSessionNotFound) {
+			return createErrorResponse(http.StatusNotFound, "Phiên đăng nhập không tồn tại hoặc đã hết hiệu lực")
+		}
+		log.Error("Failed to revoke session", "user_id", userID, "error", err)
+		return createErrorResponse(http.StatusInternalServerError, "Không thể thu hồi phiên đăng nhập")
+	}
+	return createStatusResponse(http.StatusOK, "success", "Đã thu hồi phiên đăng nhập")
+}
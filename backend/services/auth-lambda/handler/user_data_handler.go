@@ -0,0 +1,114 @@
+package handler
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/fpt-event-services/common/authctx"
+	apperrors "github.com/fpt-event-services/common/errors"
+	"github.com/fpt-event-services/services/auth-lambda/models"
+)
+
+// ============================================================
+// HandleDataExport - GET /api/me/data-export
+// Xuất toàn bộ dữ liệu cá nhân (GDPR/PDPA) dạng ZIP, dựng nền
+// 202 khi file đang được tạo (client poll lại), 200 kèm downloadUrl khi READY
+// Query: ?refresh=true để tạo file mới thay vì dùng file còn hạn
+// ============================================================
+func (h *AuthHandler) HandleDataExport(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	userID, ok := authctx.UserID(ctx)
+	if !ok {
+		return createErrorResponse(http.StatusUnauthorized, "Unauthorized")
+	}
+
+	refresh := request.QueryStringParameters["refresh"] == "true"
+	export, err := h.useCase.RequestDataExport(ctx, userID, refresh)
+	if err != nil {
+		log.Error("Failed to request data export", "user_id", userID, "error", err)
+		return createErrorResponse(http.StatusInternalServerError, "Không thể tạo yêu cầu xuất dữ liệu")
+	}
+
+	switch export.Status {
+	case models.DataExportReady:
+		export.DownloadURL = fmt.Sprintf("/api/me/data-export/download?id=%d", export.ExportID)
+		return createSuccessResponse(http.StatusOK, export)
+	case models.DataExportPending:
+		return createSuccessResponse(http.StatusAccepted, export)
+	default:
+		// FAILED: trả về lý do, client gọi lại với refresh=true để thử lại
+		return createSuccessResponse(http.StatusOK, export)
+	}
+}
+
+// ============================================================
+// HandleDownloadDataExport - GET /api/me/data-export/download?id=
+// Tải file ZIP đã tạo (chỉ chủ tài khoản, trong thời hạn 48 giờ)
+// ============================================================
+func (h *AuthHandler) HandleDownloadDataExport(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	userID, ok := authctx.UserID(ctx)
+	if !ok {
+		return createErrorResponse(http.StatusUnauthorized, "Unauthorized")
+	}
+
+	exportID, err := strconv.Atoi(request.QueryStringParameters["id"])
+	if err != nil || exportID <= 0 {
+		return createErrorResponse(http.StatusBadRequest, "id không hợp lệ")
+	}
+
+	fileName, data, err := h.useCase.GetDataExportFile(ctx, exportID, userID)
+	if err != nil {
+		log.Error("Failed to load data export", "export_id", exportID, "error", err)
+		return createErrorResponse(http.StatusInternalServerError, "Không thể tải file")
+	}
+	if data == nil {
+		return createErrorResponse(http.StatusNotFound, "File không tồn tại hoặc đã hết hạn")
+	}
+
+	return events.APIGatewayProxyResponse{
+		StatusCode: http.StatusOK,
+		Headers: map[string]string{
+			"Content-Type":        "application/zip",
+			"Content-Disposition": fmt.Sprintf(`attachment; filename="%s"`, fileName),
+			"Cache-Control":       "no-store",
+		},
+		Body:            base64.StdEncoding.EncodeToString(data),
+		IsBase64Encoded: true,
+	}, nil
+}
+
+// ============================================================
+// HandleDeleteMe - DELETE /api/me
+// Xóa tài khoản của chính mình bằng cách ẩn danh hóa
+// Body: {"password": "..."} để xác nhận
+// Hóa đơn/vé được giữ lại (không còn gắn thông tin cá nhân)
+// ============================================================
+func (h *AuthHandler) HandleDeleteMe(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	userID, ok := authctx.UserID(ctx)
+	if !ok {
+		return createErrorResponse(http.StatusUnauthorized, "Unauthorized")
+	}
+
+	var req models.DeleteAccountRequest
+	if err := json.Unmarshal([]byte(request.Body), &req); err != nil {
+		return createErrorResponse(http.StatusBadRequest, "Invalid request body")
+	}
+
+	if err := h.useCase.DeleteAccount(ctx, userID, authctx.Role(ctx), req.Password); err != nil {
+		if appErr, ok := apperrors.AsAppError(err); ok {
+			statusCode := appErr.HTTPStatus
+			if appErr.Code == apperrors.ErrCodeBusinessRule {
+				statusCode = http.StatusConflict
+			}
+			return createErrorResponse(statusCode, appErr.Message)
+		}
+		log.Error("Failed to delete account", "user_id", userID, "error", err)
+		return createErrorResponse(http.StatusInternalServerError, "Không thể xóa tài khoản")
+	}
+
+	return createStatusResponse(http.StatusOK, "success", "Tài khoản đã được xóa")
+}
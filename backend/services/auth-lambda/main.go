@@ -2,53 +2,88 @@ package main
 
 import (
 	"context"
-	"log"
+	"encoding/json"
 
 	"github.com/aws/aws-lambda-go/events"
 	"github.com/aws/aws-lambda-go/lambda"
 	"github.com/fpt-event-services/common/db"
+	"github.com/fpt-event-services/common/health"
+	"github.com/fpt-event-services/common/lambdainit"
 	"github.com/fpt-event-services/services/auth-lambda/handler"
 )
 
-var authHandler *handler.AuthHandler
+// router - Tạo handler một lần sau khi đã nạp secret và kết nối DB (xem common/lambdainit)
+var router = lambdainit.Lazy(newRouter)
 
 func init() {
-	// Initialize database connection
-	if err := db.InitDB(); err != nil {
-		log.Fatalf("Failed to initialize database: %v", err)
-	}
+	// Secret (DB, JWT...) + kết nối DB ngay lúc init để provisioned concurrency khởi tạo sẵn
+	lambdainit.Warm()
+}
 
-	// Initialize handler
-	authHandler = handler.NewAuthHandler()
+// healthResponse - Report của common/health dưới dạng response API Gateway
+func healthResponse(report health.Report) (events.APIGatewayProxyResponse, error) {
+	body, err := json.Marshal(report)
+	if err != nil {
+		return events.APIGatewayProxyResponse{}, err
+	}
+	return events.APIGatewayProxyResponse{
+		StatusCode: report.HTTPStatus(),
+		Body:       string(body),
+		Headers: map[string]string{
+			"Content-Type":  "application/json",
+			"Cache-Control": "no-store",
+		},
+	}, nil
 }
 
 // Handler is the Lambda function handler
+// Liveness không cần DB nên trả lời cả khi khởi tạo chưa xong
 func Handler(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
-	// Route based on path and method
-	path := request.Path
-	method := request.HTTPMethod
-
-	switch {
-	case path == "/api/login" && method == "POST":
-		return authHandler.HandleLogin(ctx, request)
-
-	case path == "/api/register" && method == "POST":
-		return authHandler.HandleRegister(ctx, request)
-
-	case path == "/api/admin/create-account" && method == "POST":
-		return authHandler.HandleAdminCreateAccount(ctx, request)
-
-	default:
-		return events.APIGatewayProxyResponse{
-			StatusCode: 404,
-			Body:       `{"error":"Not Found"}`,
-			Headers: map[string]string{
-				"Content-Type": "application/json",
-			},
-		}, nil
+	if request.Path == "/health/live" && request.HTTPMethod == "GET" {
+		return healthResponse(health.Live())
+	}
+	return router(ctx, request)
+}
+
+func newRouter() lambdainit.Handler {
+	authHandler := handler.NewAuthHandler()
+
+	// Lambda không chạy scheduler: readiness chỉ gồm DB và migration
+	readiness := health.NewChecker(health.RequiredFromEnv())
+	readiness.Add("database", health.Database(db.GetDB()))
+	readiness.Add("migrations", health.Migrations(db.GetDB(), health.LatestMigration))
+
+	return func(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		// Route based on path and method
+		path := request.Path
+		method := request.HTTPMethod
+
+		switch {
+		case path == "/health/ready" && method == "GET":
+			return healthResponse(readiness.Ready(ctx))
+
+		case path == "/api/login" && method == "POST":
+			return authHandler.HandleLogin(ctx, request)
+
+		case path == "/api/register" && method == "POST":
+			return authHandler.HandleRegister(ctx, request)
+
+		case path == "/api/admin/create-account" && method == "POST":
+			return authHandler.HandleAdminCreateAccount(ctx, request)
+
+		default:
+			return events.APIGatewayProxyResponse{
+				StatusCode: 404,
+				Body:       `{"error":"Not Found"}`,
+				Headers: map[string]string{
+					"Content-Type": "application/json",
+				},
+			}, nil
+		}
 	}
 }
 
 func main() {
-	lambda.Start(Handler)
+	// CORS theo CORS_ORIGINS giống server HTTP (handler không tự gắn header CORS)
+	lambda.Start(lambdainit.CORS(Handler))
 }
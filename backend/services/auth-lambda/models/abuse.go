@@ -0,0 +1,36 @@
+package models
+
+import "time"
+
+// UserStatusQuarantined - Tài khoản mới bị cách ly do nghi lạm dụng, chờ admin xét duyệt
+const UserStatusQuarantined = "QUARANTINED"
+
+// ============================================================
+// AccountReview - Một tài khoản trong hàng chờ xét duyệt
+// Dùng cho: GET /api/admin/abuse/reviews, POST /api/admin/abuse/reviews/{id}
+// Reasons: ip_reuse, device_reuse (common/abuse)
+// ============================================================
+type AccountReview struct {
+	ReviewID           int        `json:"reviewId"`
+	UserID             int        `json:"userId"`
+	Email              string     `json:"email"`
+	FullName           string     `json:"fullName"`
+	UserStatus         string     `json:"userStatus"`
+	Reasons            []string   `json:"reasons"`
+	IPAddress          *string    `json:"ipAddress"`
+	DeviceFingerprint  *string    `json:"deviceFingerprint"`  // SHA-256 của X-Device-Fingerprint
+	SameIPAccounts     int        `json:"sameIpAccounts"`     // Tài khoản khác tạo từ cùng IP
+	SameDeviceAccounts int        `json:"sameDeviceAccounts"` // Tài khoản khác tạo từ cùng thiết bị
+	Status             string     `json:"status"`             // PENDING, APPROVED, REJECTED
+	Note               *string    `json:"note"`
+	ReviewedBy         *int       `json:"reviewedBy"`
+	ReviewedAt         *time.Time `json:"reviewedAt"`
+	CreatedAt          time.Time  `json:"createdAt"`
+}
+
+// ResolveAccountReviewRequest - Body POST /api/admin/abuse/reviews/{id}
+// Decision: APPROVE (mở tài khoản) hoặc REJECT (khóa tài khoản - BLOCKED)
+type ResolveAccountReviewRequest struct {
+	Decision string  `json:"decision"`
+	Note     *string `json:"note"`
+}
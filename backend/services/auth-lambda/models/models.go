@@ -13,8 +13,13 @@ type User struct {
 	Status       string    `json:"status" db:"status"`
 	Wallet       float64   `json:"wallet" db:"Wallet"`
 	CreatedAt    time.Time `json:"createdAt" db:"created_at"`
+	CampusID     *int      `json:"campusId,omitempty" db:"campus_id"`
 }
 
+// RoleGuest - User của khách mua vé không có tài khoản (ticket-lambda tạo khi guest checkout)
+// Không đăng nhập được; đăng ký qua OTP bằng email đó thì nâng lên STUDENT
+const RoleGuest = "GUEST"
+
 // LoginRequest represents login request body
 type LoginRequest struct {
 	Email          string `json:"email"`
@@ -29,6 +34,7 @@ type RegisterRequest struct {
 	Email          string `json:"email"`
 	Password       string `json:"password"`
 	RecaptchaToken string `json:"recaptchaToken"`
+	Channel        string `json:"channel"` // Kênh nhận OTP: email (mặc định) | sms | zalo
 }
 
 // AdminCreateAccountRequest represents admin create account request
@@ -39,6 +45,8 @@ type AdminCreateAccountRequest struct {
 	Password string `json:"password"`
 	Role     string `json:"role"`
 	Status   string `json:"status"`
+	// CampusID - campus của STAFF/ADMIN (nil = không giới hạn); admin campus chỉ tạo được trong campus mình
+	CampusID *int `json:"campusId,omitempty"`
 }
 
 // AuthResponse represents authentication response
@@ -55,7 +63,8 @@ type VerifyOtpRequest struct {
 
 // ResendOtpRequest represents resend OTP request
 type ResendOtpRequest struct {
-	Email string `json:"email"`
+	Email   string `json:"email"`
+	Channel string `json:"channel"` // email (mặc định) | sms | zalo
 }
 
 // AdminUpdateUserRequest represents admin update user request
@@ -80,7 +89,6 @@ type PendingRegistration struct {
 	FullName     string    `json:"fullName"`
 	Phone        string    `json:"phone"`
 	PasswordHash string    `json:"-"`
-	OTP          string    `json:"-"`
 	ExpiresAt    time.Time `json:"-"`
 	Attempts     int       `json:"-"`
 }
@@ -9,6 +9,7 @@ package models
 type ForgotPasswordRequest struct {
 	Email          string `json:"email"`
 	RecaptchaToken string `json:"recaptchaToken"` // reCAPTCHA token for bot protection
+	Channel        string `json:"channel"`        // email (mặc định) | sms | zalo
 }
 
 // ResetPasswordRequest - Request đổi mật khẩu với OTP
@@ -18,15 +19,6 @@ type ResetPasswordRequest struct {
 	NewPassword string `json:"newPassword"`
 }
 
-// OTPRecord - Lưu thông tin OTP trong memory/cache
-type OTPRecord struct {
-	Email     string
-	OTP       string
-	ExpiresAt int64 // Unix timestamp
-	Attempts  int   // Số lần nhập sai
-	Used      bool  // Đã dùng chưa
-}
-
 // PasswordResetResponse - Response chung
 type PasswordResetResponse struct {
 	Status  string `json:"status"`
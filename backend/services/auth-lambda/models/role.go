@@ -0,0 +1,21 @@
+package models
+
+import "github.com/fpt-event-services/common/permission"
+
+// ============================================================
+// RoleRequest - Body của POST /api/admin/roles và PUT /api/admin/roles/{name}
+// Permissions là quyền gán trực tiếp; role kế thừa thêm quyền của ParentRole
+// ============================================================
+type RoleRequest struct {
+	Name        string   `json:"name"`
+	Description *string  `json:"description"`
+	ParentRole  *string  `json:"parentRole"`
+	Permissions []string `json:"permissions"`
+}
+
+// RoleResponse - Role kèm toàn bộ quyền hiệu lực và số tài khoản đang dùng
+type RoleResponse struct {
+	permission.Role
+	EffectivePermissions []string `json:"effectivePermissions"`
+	UserCount            int      `json:"userCount"`
+}
@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+// ClientInfo - Thông tin thiết bị của request đăng nhập / đăng ký
+type ClientInfo struct {
+	IPAddress         string
+	UserAgent         string
+	DeviceFingerprint string // SHA-256 của X-Device-Fingerprint ("" nếu không gửi)
+}
+
+// ============================================================
+// UserSession - Một phiên đăng nhập còn hiệu lực
+// Dùng cho: GET /api/me/sessions
+// ============================================================
+type UserSession struct {
+	SessionID  string    `json:"id"`
+	Device     string    `json:"device"` // Tóm tắt từ user agent, vd: "Chrome trên Windows"
+	UserAgent  *string   `json:"userAgent"`
+	IPAddress  *string   `json:"ipAddress"`
+	CreatedAt  time.Time `json:"createdAt"`
+	LastUsedAt time.Time `json:"lastUsedAt"`
+	ExpiresAt  time.Time `json:"expiresAt"`
+	Current    bool      `json:"current"` // Phiên của token đang gọi API
+}
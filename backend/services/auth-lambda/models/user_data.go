@@ -0,0 +1,131 @@
+package models
+
+import "time"
+
+// ============================================================
+// User Data Models - Xuất dữ liệu cá nhân & xóa tài khoản (GDPR/PDPA)
+// GET /api/me/data-export, DELETE /api/me
+// ============================================================
+
+// Trạng thái file xuất dữ liệu (user_data_export.status)
+const (
+	DataExportPending = "PENDING"
+	DataExportReady   = "READY"
+	DataExportFailed  = "FAILED"
+)
+
+// DataExport - Một yêu cầu xuất dữ liệu (không kèm nội dung file)
+type DataExport struct {
+	ExportID     int        `json:"exportId"`
+	UserID       int        `json:"-"`
+	Status       string     `json:"status"`
+	FileName     string     `json:"fileName,omitempty"`
+	FileSize     int        `json:"fileSize,omitempty"`
+	ErrorMessage *string    `json:"errorMessage,omitempty"`
+	RequestedAt  time.Time  `json:"requestedAt"`
+	CompletedAt  *time.Time `json:"completedAt,omitempty"`
+	ExpiresAt    *time.Time `json:"expiresAt,omitempty"`
+	DownloadURL  string     `json:"downloadUrl,omitempty"`
+}
+
+// UserDataBundle - Toàn bộ dữ liệu hệ thống lưu về một user (nội dung user-data.json)
+type UserDataBundle struct {
+	GeneratedAt   time.Time            `json:"generatedAt"`
+	Profile       UserProfileExport    `json:"profile"`
+	Tickets       []TicketExport       `json:"tickets"`
+	Bills         []BillExport         `json:"bills"`
+	WalletLedger  []WalletLedgerEntry  `json:"walletLedger"`
+	Reports       []ReportExport       `json:"reports"`
+	EventRequests []EventRequestExport `json:"eventRequests"`
+	Notifications []NotificationExport `json:"notifications"`
+	LoginHistory  []LoginHistoryEntry  `json:"loginHistory"`
+	Retention     map[string]string    `json:"retention"`
+}
+
+// UserProfileExport - Thông tin tài khoản (không gồm password hash)
+type UserProfileExport struct {
+	UserID        int       `json:"userId"`
+	FullName      string    `json:"fullName"`
+	Email         string    `json:"email"`
+	Phone         string    `json:"phone"`
+	Role          string    `json:"role"`
+	Status        string    `json:"status"`
+	WalletBalance float64   `json:"walletBalance"`
+	CreatedAt     time.Time `json:"createdAt"`
+}
+
+// TicketExport - Vé của user
+type TicketExport struct {
+	TicketID     int        `json:"ticketId"`
+	EventID      int        `json:"eventId"`
+	EventTitle   string     `json:"eventTitle"`
+	CategoryName string     `json:"categoryName"`
+	SeatCode     string     `json:"seatCode,omitempty"`
+	BillID       *int       `json:"billId,omitempty"`
+	Status       string     `json:"status"`
+	CreatedAt    *time.Time `json:"createdAt,omitempty"`
+	CheckinTime  *time.Time `json:"checkinTime,omitempty"`
+	CheckoutTime *time.Time `json:"checkoutTime,omitempty"`
+}
+
+// BillExport - Hóa đơn thanh toán
+type BillExport struct {
+	BillID        int        `json:"billId"`
+	TotalAmount   float64    `json:"totalAmount"`
+	Currency      string     `json:"currency"`
+	PaymentMethod string     `json:"paymentMethod"`
+	PaymentStatus string     `json:"paymentStatus"`
+	CreatedAt     *time.Time `json:"createdAt,omitempty"`
+	PaidAt        *time.Time `json:"paidAt,omitempty"`
+}
+
+// WalletLedgerEntry - Biến động ví: trả vé bằng ví (âm) và hoàn tiền từ report (dương)
+type WalletLedgerEntry struct {
+	Type       string    `json:"type"` // PAYMENT | REFUND
+	Amount     float64   `json:"amount"`
+	Reference  string    `json:"reference"`
+	OccurredAt time.Time `json:"occurredAt"`
+}
+
+// ReportExport - Report (khiếu nại/hoàn tiền) user đã gửi
+type ReportExport struct {
+	ReportID     int        `json:"reportId"`
+	TicketID     int        `json:"ticketId"`
+	Title        string     `json:"title,omitempty"`
+	Description  string     `json:"description"`
+	ImageURL     string     `json:"imageUrl,omitempty"`
+	Status       string     `json:"status"`
+	RefundAmount *float64   `json:"refundAmount,omitempty"`
+	StaffNote    string     `json:"staffNote,omitempty"`
+	CreatedAt    *time.Time `json:"createdAt,omitempty"`
+	ProcessedAt  *time.Time `json:"processedAt,omitempty"`
+}
+
+// EventRequestExport - Yêu cầu tổ chức sự kiện (ORGANIZER)
+type EventRequestExport struct {
+	RequestID int        `json:"requestId"`
+	Title     string     `json:"title"`
+	Status    string     `json:"status"`
+	EventID   *int       `json:"eventId,omitempty"`
+	CreatedAt *time.Time `json:"createdAt,omitempty"`
+}
+
+// NotificationExport - Thông báo đã gửi cho user
+type NotificationExport struct {
+	NotificationID int        `json:"notificationId"`
+	Message        string     `json:"message"`
+	IsRead         bool       `json:"isRead"`
+	CreatedAt      *time.Time `json:"createdAt,omitempty"`
+}
+
+// LoginHistoryEntry - Một lần đăng nhập thành công
+type LoginHistoryEntry struct {
+	IPAddress  string    `json:"ipAddress,omitempty"`
+	UserAgent  string    `json:"userAgent,omitempty"`
+	LoggedInAt time.Time `json:"loggedInAt"`
+}
+
+// DeleteAccountRequest - Body của DELETE /api/me (xác nhận lại mật khẩu)
+type DeleteAccountRequest struct {
+	Password string `json:"password"`
+}
@@ -0,0 +1,194 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/fpt-event-services/common/abuse"
+	"github.com/fpt-event-services/services/auth-lambda/models"
+)
+
+var (
+	// ErrReviewNotFound - Không có yêu cầu xét duyệt với ID này
+	ErrReviewNotFound = errors.New("account review not found")
+	// ErrReviewResolved - Yêu cầu đã được duyệt / từ chối trước đó
+	ErrReviewResolved = errors.New("account review has already been resolved")
+)
+
+// CountRecentRegistrations - Số tài khoản đã tạo từ IP / thiết bị của client trong window
+func (r *UserRepository) CountRecentRegistrations(ctx context.Context, client abuse.Client, window time.Duration) (ipAccounts, deviceAccounts int, err error) {
+	since := time.Now().Add(-window)
+	err = r.db.QueryRowContext(ctx, `
+		SELECT
+			(SELECT COUNT(*) FROM User_Registration_Signal WHERE ? <> '' AND ip_address = ? AND created_at >= ?),
+			(SELECT COUNT(*) FROM User_Registration_Signal WHERE ? <> '' AND device_fingerprint = ? AND created_at >= ?)
+	`, client.IP, client.IP, since, client.Device, client.Device, since).Scan(&ipAccounts, &deviceAccounts)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to count recent registrations: %w", err)
+	}
+	return ipAccounts, deviceAccounts, nil
+}
+
+// ============================================================
+// RecordRegistration - Lưu IP / thiết bị của tài khoản vừa tạo
+// reasons khác rỗng: chuyển tài khoản sang QUARANTINED và đưa vào hàng chờ xét duyệt
+// ============================================================
+func (r *UserRepository) RecordRegistration(ctx context.Context, userID int, client abuse.Client, reasons []string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	ip, device := nullableString(client.IP), nullableString(client.Device)
+	// Nâng tài khoản khách (GUEST) lên STUDENT cũng là một lần đăng ký: ghi đè tín hiệu cũ
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO User_Registration_Signal (user_id, ip_address, device_fingerprint) VALUES (?, ?, ?)
+		ON DUPLICATE KEY UPDATE ip_address = VALUES(ip_address), device_fingerprint = VALUES(device_fingerprint), created_at = CURRENT_TIMESTAMP
+	`, userID, ip, device); err != nil {
+		return fmt.Errorf("failed to record registration signal: %w", err)
+	}
+
+	if len(reasons) > 0 {
+		if _, err := tx.ExecContext(ctx, `UPDATE Users SET status = ? WHERE user_id = ?`, models.UserStatusQuarantined, userID); err != nil {
+			return fmt.Errorf("failed to quarantine user: %w", err)
+		}
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO Account_Review (user_id, reasons, ip_address, device_fingerprint) VALUES (?, ?, ?, ?)
+		`, userID, strings.Join(reasons, ","), ip, device); err != nil {
+			return fmt.Errorf("failed to queue account review: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit registration signal: %w", err)
+	}
+	return nil
+}
+
+// accountReviewSelect - Cột của AccountReview (alias ar, u)
+const accountReviewSelect = `
+	SELECT ar.review_id, ar.user_id, u.email, u.full_name, u.status, ar.reasons, ar.ip_address, ar.device_fingerprint,
+	       (SELECT COUNT(*) FROM User_Registration_Signal s
+	        WHERE ar.ip_address IS NOT NULL AND s.ip_address = ar.ip_address AND s.user_id <> ar.user_id),
+	       (SELECT COUNT(*) FROM User_Registration_Signal s
+	        WHERE ar.device_fingerprint IS NOT NULL AND s.device_fingerprint = ar.device_fingerprint AND s.user_id <> ar.user_id),
+	       ar.status, ar.note, ar.reviewed_by, ar.reviewed_at, ar.created_at
+	FROM Account_Review ar
+	JOIN Users u ON u.user_id = ar.user_id
+`
+
+// ListAccountReviews - Hàng chờ xét duyệt theo trạng thái (cũ nhất trước)
+func (r *UserRepository) ListAccountReviews(ctx context.Context, status string) ([]models.AccountReview, error) {
+	rows, err := r.db.QueryContext(ctx, accountReviewSelect+`
+		WHERE ar.status = ?
+		ORDER BY ar.created_at, ar.review_id
+		LIMIT 500
+	`, status)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query account reviews: %w", err)
+	}
+	defer rows.Close()
+
+	reviews := []models.AccountReview{}
+	for rows.Next() {
+		review, err := scanAccountReview(rows)
+		if err != nil {
+			return nil, err
+		}
+		reviews = append(reviews, *review)
+	}
+	return reviews, rows.Err()
+}
+
+// GetAccountReview - Một yêu cầu xét duyệt
+func (r *UserRepository) GetAccountReview(ctx context.Context, reviewID int) (*models.AccountReview, error) {
+	review, err := scanAccountReview(r.db.QueryRowContext(ctx, accountReviewSelect+` WHERE ar.review_id = ?`, reviewID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrReviewNotFound
+	}
+	return review, err
+}
+
+// ============================================================
+// ResolveAccountReview - Admin duyệt (tài khoản → ACTIVE) hoặc từ chối (→ BLOCKED)
+// Chỉ đổi trạng thái tài khoản nếu vẫn còn QUARANTINED (admin có thể đã sửa tay)
+// ============================================================
+func (r *UserRepository) ResolveAccountReview(ctx context.Context, reviewID, adminID int, approve bool, note *string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var userID int
+	var status string
+	err = tx.QueryRowContext(ctx, `SELECT user_id, status FROM Account_Review WHERE review_id = ? FOR UPDATE`, reviewID).Scan(&userID, &status)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrReviewNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load account review: %w", err)
+	}
+	if status != "PENDING" {
+		return ErrReviewResolved
+	}
+
+	reviewStatus, userStatus := "REJECTED", "BLOCKED"
+	if approve {
+		reviewStatus, userStatus = "APPROVED", "ACTIVE"
+	}
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE Account_Review SET status = ?, note = ?, reviewed_by = ?, reviewed_at = NOW() WHERE review_id = ?
+	`, reviewStatus, note, adminID, reviewID); err != nil {
+		return fmt.Errorf("failed to update account review: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE Users SET status = ? WHERE user_id = ? AND status = ?
+	`, userStatus, userID, models.UserStatusQuarantined); err != nil {
+		return fmt.Errorf("failed to update user status: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit account review: %w", err)
+	}
+	return nil
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanAccountReview(row rowScanner) (*models.AccountReview, error) {
+	var review models.AccountReview
+	var reasons string
+	var reviewedBy sql.NullInt64
+	var reviewedAt sql.NullTime
+	if err := row.Scan(&review.ReviewID, &review.UserID, &review.Email, &review.FullName, &review.UserStatus, &reasons,
+		&review.IPAddress, &review.DeviceFingerprint, &review.SameIPAccounts, &review.SameDeviceAccounts,
+		&review.Status, &review.Note, &reviewedBy, &reviewedAt, &review.CreatedAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to scan account review: %w", err)
+	}
+	review.Reasons = strings.Split(reasons, ",")
+	if reviewedBy.Valid {
+		id := int(reviewedBy.Int64)
+		review.ReviewedBy = &id
+	}
+	if reviewedAt.Valid {
+		review.ReviewedAt = &reviewedAt.Time
+	}
+	return &review, nil
+}
+
+func nullableString(s string) any {
+	if s == "" {
+		return nil
+	}
+	return s
+}
@@ -0,0 +1,153 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/fpt-event-services/services/auth-lambda/models"
+)
+
+var (
+	// ErrRoleNotFound - Role không tồn tại
+	ErrRoleNotFound = errors.New("role not found")
+	// ErrRoleExists - Tên role đã được dùng
+	ErrRoleExists = errors.New("role already exists")
+	// ErrRoleInUse - Role còn tài khoản hoặc role con tham chiếu
+	ErrRoleInUse = errors.New("role is assigned to users or inherited by other roles")
+)
+
+// RoleIsSystem - Role có tồn tại không và có phải role có sẵn không
+func (r *UserRepository) RoleIsSystem(ctx context.Context, name string) (exists, system bool, err error) {
+	err = r.db.QueryRowContext(ctx, `SELECT is_system FROM Role WHERE role_name = ?`, name).Scan(&system)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, false, nil
+	}
+	if err != nil {
+		return false, false, fmt.Errorf("failed to load role: %w", err)
+	}
+	return true, system, nil
+}
+
+// CountUsersByRole - Số tài khoản theo từng role
+func (r *UserRepository) CountUsersByRole(ctx context.Context) (map[string]int, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT role, COUNT(*) FROM Users GROUP BY role`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count users by role: %w", err)
+	}
+	defer rows.Close()
+
+	counts := map[string]int{}
+	for rows.Next() {
+		var role string
+		var n int
+		if err := rows.Scan(&role, &n); err != nil {
+			return nil, fmt.Errorf("failed to scan role count: %w", err)
+		}
+		counts[role] = n
+	}
+	return counts, rows.Err()
+}
+
+// ============================================================
+// CreateRole - Tạo role tùy chỉnh cùng quyền trong một transaction
+// ============================================================
+func (r *UserRepository) CreateRole(ctx context.Context, req models.RoleRequest) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var exists bool
+	if err := tx.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM Role WHERE role_name = ?)`, req.Name).Scan(&exists); err != nil {
+		return fmt.Errorf("failed to check role: %w", err)
+	}
+	if exists {
+		return ErrRoleExists
+	}
+	if _, err := tx.ExecContext(ctx,
+		`INSERT INTO Role (role_name, description, parent_role, is_system) VALUES (?, ?, ?, 0)`,
+		req.Name, req.Description, req.ParentRole); err != nil {
+		return fmt.Errorf("failed to create role: %w", err)
+	}
+	if err := insertRolePermissions(ctx, tx, req.Name, req.Permissions); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// ============================================================
+// UpdateRole - Thay mô tả, role cha và toàn bộ quyền gán trực tiếp
+// ============================================================
+func (r *UserRepository) UpdateRole(ctx context.Context, req models.RoleRequest) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx,
+		`UPDATE Role SET description = ?, parent_role = ? WHERE role_name = ?`,
+		req.Description, req.ParentRole, req.Name)
+	if err != nil {
+		return fmt.Errorf("failed to update role: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		// RowsAffected = 0 cả khi giá trị không đổi: kiểm tra lại sự tồn tại
+		var exists bool
+		if err := tx.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM Role WHERE role_name = ?)`, req.Name).Scan(&exists); err != nil {
+			return fmt.Errorf("failed to check role: %w", err)
+		}
+		if !exists {
+			return ErrRoleNotFound
+		}
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM Role_Permission WHERE role_name = ?`, req.Name); err != nil {
+		return fmt.Errorf("failed to clear role permissions: %w", err)
+	}
+	if err := insertRolePermissions(ctx, tx, req.Name, req.Permissions); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// DeleteRole - Xóa role tùy chỉnh không còn tài khoản / role con nào dùng
+func (r *UserRepository) DeleteRole(ctx context.Context, name string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var inUse bool
+	err = tx.QueryRowContext(ctx, `
+		SELECT EXISTS(SELECT 1 FROM Users WHERE role = ?)
+		    OR EXISTS(SELECT 1 FROM Role WHERE parent_role = ?)
+	`, name, name).Scan(&inUse)
+	if err != nil {
+		return fmt.Errorf("failed to check role usage: %w", err)
+	}
+	if inUse {
+		return ErrRoleInUse
+	}
+	result, err := tx.ExecContext(ctx, `DELETE FROM Role WHERE role_name = ? AND is_system = 0`, name)
+	if err != nil {
+		return fmt.Errorf("failed to delete role: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return ErrRoleNotFound
+	}
+	return tx.Commit()
+}
+
+func insertRolePermissions(ctx context.Context, tx *sql.Tx, role string, perms []string) error {
+	for _, p := range perms {
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO Role_Permission (role_name, permission) VALUES (?, ?)`, role, p); err != nil {
+			return fmt.Errorf("failed to add permission %s: %w", p, err)
+		}
+	}
+	return nil
+}
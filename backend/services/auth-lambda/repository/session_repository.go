@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/fpt-event-services/services/auth-lambda/models"
+)
+
+// ErrSessionNotFound - Phiên không tồn tại, không thuộc user hoặc đã hết hiệu lực
+var ErrSessionNotFound = errors.New("session not found")
+
+// CreateSession - Tạo phiên đăng nhập (session_id do common/session sinh)
+func (r *UserRepository) CreateSession(ctx context.Context, sessionID string, userID int, client models.ClientInfo, expiresAt time.Time) error {
+	userAgent := client.UserAgent
+	if len(userAgent) > 255 {
+		userAgent = userAgent[:255]
+	}
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO User_Session (session_id, user_id, ip_address, user_agent, expires_at)
+		VALUES (?, ?, NULLIF(?, ''), NULLIF(?, ''), ?)
+	`, sessionID, userID, client.IPAddress, userAgent, expiresAt)
+	if err != nil {
+		return fmt.Errorf("failed to create session: %w", err)
+	}
+	return nil
+}
+
+// ListActiveSessions - Phiên chưa thu hồi, chưa hết hạn của user (dùng gần nhất trước)
+func (r *UserRepository) ListActiveSessions(ctx context.Context, userID int) ([]models.UserSession, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT session_id, user_agent, ip_address, created_at, last_used_at, expires_at
+		FROM User_Session
+		WHERE user_id = ? AND revoked_at IS NULL AND expires_at > NOW()
+		ORDER BY last_used_at DESC, created_at DESC
+	`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query sessions: %w", err)
+	}
+	defer rows.Close()
+
+	sessions := []models.UserSession{}
+	for rows.Next() {
+		var s models.UserSession
+		if err := rows.Scan(&s.SessionID, &s.UserAgent, &s.IPAddress, &s.CreatedAt, &s.LastUsedAt, &s.ExpiresAt); err != nil {
+			return nil, fmt.Errorf("failed to scan session: %w", err)
+		}
+		sessions = append(sessions, s)
+	}
+	return sessions, rows.Err()
+}
+
+// RevokeSession - Thu hồi một phiên còn hiệu lực của user
+func (r *UserRepository) RevokeSession(ctx context.Context, userID int, sessionID string) error {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE User_Session SET revoked_at = NOW()
+		WHERE session_id = ? AND user_id = ? AND revoked_at IS NULL AND expires_at > NOW()
+	`, sessionID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return ErrSessionNotFound
+	}
+	return nil
+}
@@ -0,0 +1,522 @@
+package repository
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/fpt-event-services/common/crypto"
+	"github.com/fpt-event-services/services/auth-lambda/models"
+)
+
+// loginHistoryLimit - Số lần đăng nhập gần nhất đưa vào file xuất dữ liệu
+const loginHistoryLimit = 500
+
+// ============================================================
+// RecordLogin - Ghi lịch sử đăng nhập thành công
+// ============================================================
+func (r *UserRepository) RecordLogin(ctx context.Context, userID int, ipAddress, userAgent string) error {
+	if len(userAgent) > 255 {
+		userAgent = userAgent[:255]
+	}
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO User_Login_History (user_id, ip_address, user_agent, logged_in_at)
+		VALUES (?, NULLIF(?, ''), NULLIF(?, ''), NOW(6))
+	`, userID, ipAddress, userAgent)
+	if err != nil {
+		return fmt.Errorf("failed to record login: %w", err)
+	}
+	return nil
+}
+
+// ============================================================
+// Data export - Bảng User_Data_Export
+// ============================================================
+
+// CreateDataExport tạo yêu cầu xuất dữ liệu ở trạng thái PENDING
+func (r *UserRepository) CreateDataExport(ctx context.Context, userID int) (*models.DataExport, error) {
+	now := time.Now()
+	result, err := r.db.ExecContext(ctx, `
+		INSERT INTO User_Data_Export (user_id, status, requested_at)
+		VALUES (?, ?, ?)
+	`, userID, models.DataExportPending, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create data export: %w", err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+	return &models.DataExport{
+		ExportID:    int(id),
+		UserID:      userID,
+		Status:      models.DataExportPending,
+		RequestedAt: now,
+	}, nil
+}
+
+// GetLatestDataExport lấy yêu cầu xuất dữ liệu mới nhất của user (nil nếu chưa có)
+func (r *UserRepository) GetLatestDataExport(ctx context.Context, userID int) (*models.DataExport, error) {
+	return r.scanDataExport(r.db.QueryRowContext(ctx, `
+		SELECT export_id, user_id, status, COALESCE(file_name, ''), COALESCE(file_size, 0),
+		       error_message, requested_at, completed_at, expires_at
+		FROM User_Data_Export
+		WHERE user_id = ?
+		ORDER BY requested_at DESC, export_id DESC
+		LIMIT 1
+	`, userID))
+}
+
+// GetDataExportFile lấy nội dung file đã tạo; chỉ trả về file của đúng user, còn hạn
+func (r *UserRepository) GetDataExportFile(ctx context.Context, exportID, userID int) (string, []byte, error) {
+	var fileName string
+	var data []byte
+	err := r.db.QueryRowContext(ctx, `
+		SELECT file_name, file_data
+		FROM User_Data_Export
+		WHERE export_id = ? AND user_id = ? AND status = ? AND expires_at > NOW()
+	`, exportID, userID, models.DataExportReady).Scan(&fileName, &data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil, nil
+	}
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to load data export file: %w", err)
+	}
+	return fileName, data, nil
+}
+
+// CompleteDataExport lưu file ZIP và chuyển sang READY
+func (r *UserRepository) CompleteDataExport(ctx context.Context, exportID int, fileName string, data []byte, expiresAt time.Time) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE User_Data_Export
+		SET status = ?, file_name = ?, file_data = ?, file_size = ?, completed_at = NOW(6), expires_at = ?
+		WHERE export_id = ?
+	`, models.DataExportReady, fileName, data, len(data), expiresAt, exportID)
+	if err != nil {
+		return fmt.Errorf("failed to complete data export: %w", err)
+	}
+	return nil
+}
+
+// FailDataExport đánh dấu FAILED kèm lý do
+func (r *UserRepository) FailDataExport(ctx context.Context, exportID int, reason string) error {
+	if len(reason) > 500 {
+		reason = reason[:500]
+	}
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE User_Data_Export
+		SET status = ?, error_message = ?, completed_at = NOW(6)
+		WHERE export_id = ?
+	`, models.DataExportFailed, reason, exportID)
+	return err
+}
+
+// PurgeExpiredDataExports xóa file đã hết hạn (không giữ bản sao dữ liệu cá nhân lâu hơn cần thiết)
+func (r *UserRepository) PurgeExpiredDataExports(ctx context.Context) (int64, error) {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM User_Data_Export WHERE expires_at IS NOT NULL AND expires_at < NOW()`)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge expired data exports: %w", err)
+	}
+	return result.RowsAffected()
+}
+
+func (r *UserRepository) scanDataExport(row *sql.Row) (*models.DataExport, error) {
+	var exp models.DataExport
+	var errMsg sql.NullString
+	var completedAt, expiresAt sql.NullTime
+	err := row.Scan(&exp.ExportID, &exp.UserID, &exp.Status, &exp.FileName, &exp.FileSize,
+		&errMsg, &exp.RequestedAt, &completedAt, &expiresAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query data export: %w", err)
+	}
+	if errMsg.Valid {
+		exp.ErrorMessage = &errMsg.String
+	}
+	if completedAt.Valid {
+		exp.CompletedAt = &completedAt.Time
+	}
+	if expiresAt.Valid {
+		exp.ExpiresAt = &expiresAt.Time
+	}
+	return &exp, nil
+}
+
+// ============================================================
+// CollectUserData - Gom toàn bộ dữ liệu về một user để xuất file
+// ============================================================
+func (r *UserRepository) CollectUserData(ctx context.Context, userID int) (*models.UserDataBundle, error) {
+	bundle := &models.UserDataBundle{
+		GeneratedAt:   time.Now(),
+		Tickets:       []models.TicketExport{},
+		Bills:         []models.BillExport{},
+		WalletLedger:  []models.WalletLedgerEntry{},
+		Reports:       []models.ReportExport{},
+		EventRequests: []models.EventRequestExport{},
+		Notifications: []models.NotificationExport{},
+		LoginHistory:  []models.LoginHistoryEntry{},
+	}
+
+	var phone sql.NullString
+	err := r.db.QueryRowContext(ctx, `
+		SELECT user_id, full_name, email, phone, role, status, COALESCE(Wallet, 0), created_at
+		FROM Users
+		WHERE user_id = ?
+	`, userID).Scan(&bundle.Profile.UserID, &bundle.Profile.FullName, &bundle.Profile.Email, &phone,
+		&bundle.Profile.Role, &bundle.Profile.Status, &bundle.Profile.WalletBalance, &bundle.Profile.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errors.New("user not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query profile: %w", err)
+	}
+	bundle.Profile.Phone = crypto.DecryptPII(phone.String)
+
+	collectors := []struct {
+		name string
+		fn   func(context.Context, int, *models.UserDataBundle) error
+	}{
+		{"tickets", r.collectTickets},
+		{"bills", r.collectBills},
+		{"wallet ledger", r.collectWalletLedger},
+		{"reports", r.collectReports},
+		{"event requests", r.collectEventRequests},
+		{"notifications", r.collectNotifications},
+		{"login history", r.collectLoginHistory},
+	}
+	for _, c := range collectors {
+		if err := c.fn(ctx, userID, bundle); err != nil {
+			return nil, fmt.Errorf("failed to collect %s: %w", c.name, err)
+		}
+	}
+	return bundle, nil
+}
+
+func (r *UserRepository) collectTickets(ctx context.Context, userID int, bundle *models.UserDataBundle) error {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT t.ticket_id, t.event_id, COALESCE(e.title, ''), COALESCE(ct.name, ''), COALESCE(s.seat_code, ''),
+		       t.bill_id, t.status, t.created_at, t.checkin_time, t.check_out_time
+		FROM Ticket t
+		LEFT JOIN Event e ON t.event_id = e.event_id
+		LEFT JOIN Category_Ticket ct ON t.category_ticket_id = ct.category_ticket_id
+		LEFT JOIN Seat s ON t.seat_id = s.seat_id
+		WHERE t.user_id = ?
+		ORDER BY t.ticket_id
+	`, userID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var t models.TicketExport
+		var billID sql.NullInt64
+		var createdAt, checkin, checkout sql.NullTime
+		if err := rows.Scan(&t.TicketID, &t.EventID, &t.EventTitle, &t.CategoryName, &t.SeatCode,
+			&billID, &t.Status, &createdAt, &checkin, &checkout); err != nil {
+			return err
+		}
+		if billID.Valid {
+			id := int(billID.Int64)
+			t.BillID = &id
+		}
+		t.CreatedAt = nullTimePtr(createdAt)
+		t.CheckinTime = nullTimePtr(checkin)
+		t.CheckoutTime = nullTimePtr(checkout)
+		bundle.Tickets = append(bundle.Tickets, t)
+	}
+	return rows.Err()
+}
+
+func (r *UserRepository) collectBills(ctx context.Context, userID int, bundle *models.UserDataBundle) error {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT bill_id, total_amount, COALESCE(currency, 'VND'), COALESCE(payment_method, ''),
+		       COALESCE(payment_status, ''), created_at, paid_at
+		FROM Bill
+		WHERE user_id = ?
+		ORDER BY bill_id
+	`, userID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var b models.BillExport
+		var createdAt, paidAt sql.NullTime
+		if err := rows.Scan(&b.BillID, &b.TotalAmount, &b.Currency, &b.PaymentMethod,
+			&b.PaymentStatus, &createdAt, &paidAt); err != nil {
+			return err
+		}
+		b.CreatedAt = nullTimePtr(createdAt)
+		b.PaidAt = nullTimePtr(paidAt)
+		bundle.Bills = append(bundle.Bills, b)
+	}
+	return rows.Err()
+}
+
+// collectWalletLedger dựng lại biến động ví: không có bảng ledger riêng nên lấy từ
+// Bill thanh toán bằng ví (trừ tiền) và Report được duyệt hoàn tiền (cộng tiền)
+func (r *UserRepository) collectWalletLedger(ctx context.Context, userID int, bundle *models.UserDataBundle) error {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT 'PAYMENT', -total_amount, CONCAT('bill#', bill_id), COALESCE(paid_at, created_at)
+		FROM Bill
+		WHERE user_id = ? AND payment_method = 'Wallet' AND payment_status = 'PAID'
+		UNION ALL
+		SELECT 'REFUND', refund_amount, CONCAT('report#', report_id), COALESCE(processed_at, created_at)
+		FROM Report
+		WHERE user_id = ? AND status = 'APPROVED' AND refund_amount IS NOT NULL
+		ORDER BY 4
+	`, userID, userID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var e models.WalletLedgerEntry
+		if err := rows.Scan(&e.Type, &e.Amount, &e.Reference, &e.OccurredAt); err != nil {
+			return err
+		}
+		bundle.WalletLedger = append(bundle.WalletLedger, e)
+	}
+	return rows.Err()
+}
+
+func (r *UserRepository) collectReports(ctx context.Context, userID int, bundle *models.UserDataBundle) error {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT report_id, ticket_id, COALESCE(title, ''), description, COALESCE(image_url, ''),
+		       COALESCE(status, ''), refund_amount, COALESCE(staff_note, ''), created_at, processed_at
+		FROM Report
+		WHERE user_id = ?
+		ORDER BY report_id
+	`, userID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var rp models.ReportExport
+		var refund sql.NullFloat64
+		var createdAt, processedAt sql.NullTime
+		if err := rows.Scan(&rp.ReportID, &rp.TicketID, &rp.Title, &rp.Description, &rp.ImageURL,
+			&rp.Status, &refund, &rp.StaffNote, &createdAt, &processedAt); err != nil {
+			return err
+		}
+		if refund.Valid {
+			rp.RefundAmount = &refund.Float64
+		}
+		rp.CreatedAt = nullTimePtr(createdAt)
+		rp.ProcessedAt = nullTimePtr(processedAt)
+		bundle.Reports = append(bundle.Reports, rp)
+	}
+	return rows.Err()
+}
+
+func (r *UserRepository) collectEventRequests(ctx context.Context, userID int, bundle *models.UserDataBundle) error {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT request_id, title, COALESCE(status, ''), created_event_id, created_at
+		FROM Event_Request
+		WHERE requester_id = ?
+		ORDER BY request_id
+	`, userID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var er models.EventRequestExport
+		var eventID sql.NullInt64
+		var createdAt sql.NullTime
+		if err := rows.Scan(&er.RequestID, &er.Title, &er.Status, &eventID, &createdAt); err != nil {
+			return err
+		}
+		if eventID.Valid {
+			id := int(eventID.Int64)
+			er.EventID = &id
+		}
+		er.CreatedAt = nullTimePtr(createdAt)
+		bundle.EventRequests = append(bundle.EventRequests, er)
+	}
+	return rows.Err()
+}
+
+func (r *UserRepository) collectNotifications(ctx context.Context, userID int, bundle *models.UserDataBundle) error {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT notification_id, message, COALESCE(is_read, 0), created_at
+		FROM Notification
+		WHERE user_id = ?
+		ORDER BY notification_id
+	`, userID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var n models.NotificationExport
+		var createdAt sql.NullTime
+		if err := rows.Scan(&n.NotificationID, &n.Message, &n.IsRead, &createdAt); err != nil {
+			return err
+		}
+		n.CreatedAt = nullTimePtr(createdAt)
+		bundle.Notifications = append(bundle.Notifications, n)
+	}
+	return rows.Err()
+}
+
+func (r *UserRepository) collectLoginHistory(ctx context.Context, userID int, bundle *models.UserDataBundle) error {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT COALESCE(ip_address, ''), COALESCE(user_agent, ''), logged_in_at
+		FROM User_Login_History
+		WHERE user_id = ?
+		ORDER BY logged_in_at DESC
+		LIMIT ?
+	`, userID, loginHistoryLimit)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var l models.LoginHistoryEntry
+		if err := rows.Scan(&l.IPAddress, &l.UserAgent, &l.LoggedInAt); err != nil {
+			return err
+		}
+		bundle.LoginHistory = append(bundle.LoginHistory, l)
+	}
+	return rows.Err()
+}
+
+func nullTimePtr(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	return &t.Time
+}
+
+// ============================================================
+// Account deletion - Ẩn danh hóa tài khoản (DELETE /api/me)
+// ============================================================
+
+// GetAccountDeletionBlockers trả về lý do chưa thể xóa tài khoản (rỗng = được xóa)
+// Chặn khi còn tiền trong ví, vé sắp diễn ra / đang giữ chỗ, report đang chờ,
+// hoặc (ORGANIZER) yêu cầu sự kiện còn đang xử lý
+func (r *UserRepository) GetAccountDeletionBlockers(ctx context.Context, userID int) ([]string, error) {
+	var wallet float64
+	var activeTickets, pendingReports, activeRequests int
+	err := r.db.QueryRowContext(ctx, `
+		SELECT
+			COALESCE(u.Wallet, 0),
+			(SELECT COUNT(*) FROM Ticket t JOIN Event e ON t.event_id = e.event_id
+			 WHERE t.user_id = u.user_id
+			   AND (t.status = 'PENDING' OR (t.status IN ('BOOKED', 'CHECKED_IN') AND e.end_time > NOW()))),
+			(SELECT COUNT(*) FROM Report rp WHERE rp.user_id = u.user_id AND rp.status = 'PENDING'),
+			(SELECT COUNT(*) FROM Event_Request er
+			 WHERE er.requester_id = u.user_id AND er.status IN ('PENDING', 'APPROVED', 'UPDATING'))
+		FROM Users u
+		WHERE u.user_id = ?
+	`, userID).Scan(&wallet, &activeTickets, &pendingReports, &activeRequests)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errors.New("user not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to check account deletion: %w", err)
+	}
+
+	var blockers []string
+	if wallet > 0 {
+		blockers = append(blockers, fmt.Sprintf("Ví còn số dư %.0f VND", wallet))
+	}
+	if activeTickets > 0 {
+		blockers = append(blockers, fmt.Sprintf("Còn %d vé đang giữ chỗ hoặc sự kiện chưa kết thúc", activeTickets))
+	}
+	if pendingReports > 0 {
+		blockers = append(blockers, fmt.Sprintf("Còn %d report đang chờ xử lý", pendingReports))
+	}
+	if activeRequests > 0 {
+		blockers = append(blockers, fmt.Sprintf("Còn %d yêu cầu sự kiện đang xử lý", activeRequests))
+	}
+	return blockers, nil
+}
+
+// AnonymizeUser ẩn danh hóa tài khoản trong MỘT transaction
+//   - Users: xóa họ tên, email, SĐT, vô hiệu mật khẩu, status INACTIVE
+//   - Xóa dữ liệu không thuộc diện lưu trữ: Notification, User_Login_History, User_Session, User_Data_Export
+//   - Report: xóa nội dung tự do (mô tả, ảnh), giữ số tiền hoàn
+//   - Bill/Ticket: GIỮ NGUYÊN (chứng từ tài chính), chỉ còn liên kết tới user đã ẩn danh
+func (r *UserRepository) AnonymizeUser(ctx context.Context, userID int) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Hash ngẫu nhiên: không mật khẩu nào khớp được
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return fmt.Errorf("failed to generate password placeholder: %w", err)
+	}
+
+	result, err := tx.ExecContext(ctx, `
+		UPDATE Users
+		SET full_name = 'Người dùng đã xóa',
+		    email = CONCAT('deleted-', user_id, '@anonymized.invalid'),
+		    phone = NULL,
+		    password_hash = ?,
+		    status = 'INACTIVE',
+		    anonymized_at = NOW(6)
+		WHERE user_id = ? AND anonymized_at IS NULL
+	`, hex.EncodeToString(secret), userID)
+	if err != nil {
+		return fmt.Errorf("failed to anonymize user: %w", err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return errors.New("user not found")
+	}
+
+	cleanups := []struct {
+		name  string
+		query string
+	}{
+		{"notifications", `DELETE FROM Notification WHERE user_id = ?`},
+		{"login history", `DELETE FROM User_Login_History WHERE user_id = ?`},
+		{"sessions", `DELETE FROM User_Session WHERE user_id = ?`},
+		{"data exports", `DELETE FROM User_Data_Export WHERE user_id = ?`},
+		{"recommendations", `DELETE FROM Event_Recommendation WHERE user_id = ?`},
+		{"reports", `UPDATE Report SET title = NULL, description = '', image_url = NULL WHERE user_id = ?`},
+	}
+	for _, c := range cleanups {
+		if _, err := tx.ExecContext(ctx, c.query, userID); err != nil {
+			return fmt.Errorf("failed to clean up %s: %w", c.name, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit anonymization: %w", err)
+	}
+	return nil
+}
+
+// FindPasswordHashByID lấy password hash để xác nhận lại trước thao tác nhạy cảm
+func (r *UserRepository) FindPasswordHashByID(ctx context.Context, userID int) (string, error) {
+	var passwordHash string
+	err := r.db.QueryRowContext(ctx, `
+		SELECT password_hash FROM Users WHERE user_id = ? AND anonymized_at IS NULL
+	`, userID).Scan(&passwordHash)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", errors.New("user not found")
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to query user: %w", err)
+	}
+	return passwordHash, nil
+}
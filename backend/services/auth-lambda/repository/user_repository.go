@@ -5,7 +5,9 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"sync"
 
+	"github.com/fpt-event-services/common/crypto"
 	"github.com/fpt-event-services/common/db"
 	"github.com/fpt-event-services/common/hash"
 	"github.com/fpt-event-services/services/auth-lambda/models"
@@ -23,6 +25,9 @@ func NewUserRepository() *UserRepository {
 	}
 }
 
+// DefaultUserRepository - Repository dùng chung của process
+var DefaultUserRepository = sync.OnceValue(NewUserRepository)
+
 // CheckLogin verifies user credentials (khớp UsersDAO.checkLogin)
 func (r *UserRepository) CheckLogin(ctx context.Context, email, password string) (*models.User, error) {
 	query := `
@@ -54,6 +59,11 @@ func (r *UserRepository) CheckLogin(ctx context.Context, email, password string)
 	}
 
 	fmt.Printf("🔍 Login attempt - Email: %s, Role: %s, Status: %s\n", user.Email, user.Role, user.Status)
+	if user.Role == models.RoleGuest {
+		fmt.Printf("❌ Login failed: Guest checkout user without account - %s\n", email)
+		return nil, errors.New("user not found")
+	}
+	user.Phone = crypto.DecryptPII(user.Phone)
 
 	// Verify password
 	if !hash.VerifyPassword(password, user.PasswordHash) {
@@ -68,6 +78,12 @@ func (r *UserRepository) CheckLogin(ctx context.Context, email, password string)
 		return nil, errors.New("user is blocked")
 	}
 
+	// Tài khoản bị cách ly (chống lạm dụng) chờ admin duyệt
+	if user.Status == models.UserStatusQuarantined {
+		fmt.Printf("❌ Login failed: User quarantined - %s\n", email)
+		return nil, errors.New("account is pending review")
+	}
+
 	fmt.Printf("✅ Login successful - Email: %s, UserID: %d\n", user.Email, user.ID)
 	return &user, nil
 }
@@ -99,6 +115,7 @@ func (r *UserRepository) FindByEmail(ctx context.Context, email string) (*models
 		}
 		return nil, fmt.Errorf("failed to query user: %w", err)
 	}
+	user.Phone = crypto.DecryptPII(user.Phone)
 
 	return &user, nil
 }
@@ -128,10 +145,14 @@ func (r *UserRepository) CreateUser(ctx context.Context, user *models.User) (int
 
 	// Hash password
 	user.PasswordHash = hash.HashPassword(user.PasswordHash)
+	phone, err := crypto.EncryptPII(user.Phone)
+	if err != nil {
+		return 0, err
+	}
 
 	query := `
-		INSERT INTO Users (full_name, email, phone, password_hash, role, status, Wallet)
-		VALUES (?, ?, ?, ?, ?, ?, 0)
+		INSERT INTO Users (full_name, email, phone, password_hash, role, status, Wallet, campus_id)
+		VALUES (?, ?, ?, ?, ?, ?, 0, ?)
 	`
 
 	result, err := r.db.ExecContext(
@@ -139,7 +160,7 @@ func (r *UserRepository) CreateUser(ctx context.Context, user *models.User) (int
 		query,
 		user.FullName,
 		user.Email,
-		user.Phone,
+		phone,
 		user.PasswordHash,
 		user.Role,
 		user.Status,
@@ -161,6 +182,10 @@ func (r *UserRepository) CreateUser(ctx context.Context, user *models.User) (int
 func (r *UserRepository) AdminCreateAccount(ctx context.Context, req models.AdminCreateAccountRequest) (int, error) {
 	// Hash password
 	passwordHash := hash.HashPassword(req.Password)
+	phone, err := crypto.EncryptPII(req.Phone)
+	if err != nil {
+		return 0, err
+	}
 
 	query := `
 		INSERT INTO Users (full_name, email, phone, password_hash, role, status, Wallet)
@@ -172,10 +197,11 @@ func (r *UserRepository) AdminCreateAccount(ctx context.Context, req models.Admi
 		query,
 		req.FullName,
 		req.Email,
-		req.Phone,
+		phone,
 		passwordHash,
 		req.Role,
 		req.Status,
+		req.CampusID,
 	)
 
 	if err != nil {
@@ -223,6 +249,10 @@ func (r *UserRepository) CreateUserWithHash(ctx context.Context, user *models.Us
 	if user.Status == "" {
 		user.Status = "ACTIVE"
 	}
+	phone, err := crypto.EncryptPII(user.Phone)
+	if err != nil {
+		return 0, err
+	}
 
 	query := `
 		INSERT INTO Users (full_name, email, phone, password_hash, role, status, Wallet)
@@ -234,7 +264,7 @@ func (r *UserRepository) CreateUserWithHash(ctx context.Context, user *models.Us
 		query,
 		user.FullName,
 		user.Email,
-		user.Phone,
+		phone,
 		passwordHash,
 		user.Role,
 		user.Status,
@@ -252,6 +282,42 @@ func (r *UserRepository) CreateUserWithHash(ctx context.Context, user *models.Us
 	return int(userID), nil
 }
 
+// ConvertGuestAccount - Nâng user GUEST (guest checkout) thành tài khoản thật, giữ nguyên user_id
+// nên vé đã mua chuyển sang tài khoản mới; mã tra cứu của khách bị xóa
+func (r *UserRepository) ConvertGuestAccount(ctx context.Context, userID int, user *models.User, passwordHash string) error {
+	if user.Role == "" {
+		user.Role = "STUDENT"
+	}
+	if user.Status == "" {
+		user.Status = "ACTIVE"
+	}
+	phone, err := crypto.EncryptPII(user.Phone)
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+		UPDATE Users SET full_name = ?, phone = ?, password_hash = ?, role = ?, status = ?
+		WHERE user_id = ? AND role = ?
+	`, user.FullName, phone, passwordHash, user.Role, user.Status, userID, models.RoleGuest)
+	if err != nil {
+		return fmt.Errorf("failed to convert guest user: %w", err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return errors.New("guest user not found")
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM Guest_Lookup_Code WHERE user_id = ?`, userID); err != nil {
+		return fmt.Errorf("failed to delete guest lookup codes: %w", err)
+	}
+	return tx.Commit()
+}
+
 // UpdateUser updates user details (Admin)
 func (r *UserRepository) UpdateUser(ctx context.Context, req models.AdminUpdateUserRequest) error {
 	// Build dynamic update query
@@ -264,8 +330,12 @@ func (r *UserRepository) UpdateUser(ctx context.Context, req models.AdminUpdateU
 		args = append(args, req.FullName)
 	}
 	if req.Phone != "" {
+		phone, err := crypto.EncryptPII(req.Phone)
+		if err != nil {
+			return err
+		}
 		updates = append(updates, "phone = ?")
-		args = append(args, req.Phone)
+		args = append(args, phone)
 	}
 	if req.Role != "" {
 		updates = append(updates, "role = ?")
@@ -331,15 +401,17 @@ func (r *UserRepository) SoftDeleteUser(ctx context.Context, userID string) erro
 
 // FindByRole returns users with a specific role
 // KHỚP VỚI Java UsersDAO.getStaffAndOrganizer() - filter by ACTIVE and INACTIVE status
-func (r *UserRepository) FindByRole(ctx context.Context, role string) ([]models.User, error) {
+// campusID != nil: chỉ người dùng thuộc campus đó
+func (r *UserRepository) FindByRole(ctx context.Context, role string, campusID *int) ([]models.User, error) {
 	query := `
-		SELECT user_id, full_name, email, phone, role, status, Wallet, created_at
+		SELECT user_id, full_name, email, phone, role, status, Wallet, created_at, campus_id
 		FROM Users
 		WHERE role = ? AND status IN ('ACTIVE', 'INACTIVE')
+		  AND (? IS NULL OR campus_id = ?)
 		ORDER BY full_name
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, role)
+	rows, err := r.db.QueryContext(ctx, query, role, campusID, campusID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query users: %w", err)
 	}
@@ -348,6 +420,7 @@ func (r *UserRepository) FindByRole(ctx context.Context, role string) ([]models.
 	var users []models.User
 	for rows.Next() {
 		var user models.User
+		var userCampus sql.NullInt64
 		err := rows.Scan(
 			&user.ID,
 			&user.FullName,
@@ -357,12 +430,76 @@ func (r *UserRepository) FindByRole(ctx context.Context, role string) ([]models.
 			&user.Status,
 			&user.Wallet,
 			&user.CreatedAt,
+			&userCampus,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan user: %w", err)
 		}
+		if userCampus.Valid {
+			id := int(userCampus.Int64)
+			user.CampusID = &id
+		}
+		user.Phone = crypto.DecryptPII(user.Phone)
 		users = append(users, user)
 	}
 
 	return users, nil
 }
+
+// EncryptPlaintextPhones mã hóa số điện thoại còn lưu nguyên văn (dữ liệu trước khi bật PII encryption)
+// Xử lý theo lô theo user_id tăng dần; UPDATE kèm điều kiện phone cũ để không ghi đè thay đổi đồng thời.
+// dryRun = true chỉ đếm số dòng cần mã hóa.
+func (r *UserRepository) EncryptPlaintextPhones(ctx context.Context, batchSize int, dryRun bool) (int, error) {
+	lastID, total := 0, 0
+	for {
+		rows, err := r.db.QueryContext(ctx, `
+			SELECT user_id, phone FROM Users
+			WHERE user_id > ? AND phone IS NOT NULL AND phone <> '' AND phone NOT LIKE 'enc:v1:%'
+			ORDER BY user_id
+			LIMIT ?
+		`, lastID, batchSize)
+		if err != nil {
+			return total, fmt.Errorf("failed to query plaintext phones: %w", err)
+		}
+		type pending struct {
+			id    int
+			phone string
+		}
+		var batch []pending
+		for rows.Next() {
+			var p pending
+			if err := rows.Scan(&p.id, &p.phone); err != nil {
+				rows.Close()
+				return total, fmt.Errorf("failed to scan user phone: %w", err)
+			}
+			batch = append(batch, p)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return total, err
+		}
+		if len(batch) == 0 {
+			return total, nil
+		}
+
+		for _, p := range batch {
+			lastID = p.id
+			if dryRun {
+				total++
+				continue
+			}
+			stored, err := crypto.EncryptPII(p.phone)
+			if err != nil {
+				return total, err
+			}
+			result, err := r.db.ExecContext(ctx,
+				`UPDATE Users SET phone = ? WHERE user_id = ? AND phone = ?`, stored, p.id, p.phone)
+			if err != nil {
+				return total, fmt.Errorf("failed to encrypt phone of user %d: %w", p.id, err)
+			}
+			if n, _ := result.RowsAffected(); n > 0 {
+				total++
+			}
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+
+	"github.com/fpt-event-services/common/abuse"
+	"github.com/fpt-event-services/services/auth-lambda/models"
+)
+
+var (
+	// ErrDisposableEmail - Email thuộc dịch vụ email dùng một lần
+	ErrDisposableEmail = errors.New("Không chấp nhận email dùng một lần, vui lòng dùng email khác")
+	// ErrAccountQuarantined - Tài khoản đã tạo nhưng bị cách ly, chờ admin xét duyệt
+	ErrAccountQuarantined = errors.New("Tài khoản đang chờ xét duyệt, bạn sẽ đăng nhập được sau khi quản trị viên phê duyệt")
+	// ErrInvalidReviewRequest - Trạng thái lọc hoặc quyết định xét duyệt không hợp lệ
+	ErrInvalidReviewRequest = errors.New("invalid account review request")
+)
+
+// checkDisposableEmail - Chặn email dùng một lần trước khi tạo tài khoản / gửi OTP
+func checkDisposableEmail(email string) error {
+	if abuse.IsDisposableEmail(email) {
+		abuse.Record(abuse.SignalDisposableEmail, abuse.ActionBlocked)
+		return ErrDisposableEmail
+	}
+	return nil
+}
+
+// ============================================================
+// screenRegistration - Lưu tín hiệu của tài khoản vừa tạo, cách ly nếu IP / thiết bị
+// đã tạo nhiều tài khoản gần đây. Trả về true nếu tài khoản bị cách ly.
+// Lỗi DB không chặn đăng ký (fail open) - chỉ ghi log
+// ============================================================
+func (uc *AuthUseCase) screenRegistration(ctx context.Context, userID int, info models.ClientInfo) bool {
+	client := abuse.Client{IP: info.IPAddress, Device: info.DeviceFingerprint}
+	thresholds := abuse.DefaultThresholds()
+	ipAccounts, deviceAccounts, err := uc.userRepo.CountRecentRegistrations(ctx, client, thresholds.Window)
+	if err != nil {
+		log.Printf("[ABUSE] count registrations for user %d: %v", userID, err)
+		return false
+	}
+	reasons := thresholds.Assess(client, ipAccounts, deviceAccounts)
+	if err := uc.userRepo.RecordRegistration(ctx, userID, client, reasons); err != nil {
+		log.Printf("[ABUSE] record registration for user %d: %v", userID, err)
+		return false
+	}
+	for _, reason := range reasons {
+		abuse.Record(reason, abuse.ActionQuarantined)
+	}
+	if len(reasons) > 0 {
+		log.Printf("[ABUSE] user %d quarantined: %s", userID, strings.Join(reasons, ","))
+	}
+	return len(reasons) > 0
+}
+
+// ListAccountReviews - Hàng chờ xét duyệt (mặc định PENDING)
+func (uc *AuthUseCase) ListAccountReviews(ctx context.Context, status string) ([]models.AccountReview, error) {
+	status = strings.ToUpper(strings.TrimSpace(status))
+	if status == "" {
+		status = "PENDING"
+	}
+	if status != "PENDING" && status != "APPROVED" && status != "REJECTED" {
+		return nil, ErrInvalidReviewRequest
+	}
+	return uc.userRepo.ListAccountReviews(ctx, status)
+}
+
+// ============================================================
+// ResolveAccountReview - Admin duyệt / từ chối tài khoản bị cách ly
+// APPROVE: tài khoản → ACTIVE; REJECT: tài khoản → BLOCKED
+// ============================================================
+func (uc *AuthUseCase) ResolveAccountReview(ctx context.Context, reviewID, adminID int, req models.ResolveAccountReviewRequest) (*models.AccountReview, error) {
+	approve, err := parseReviewDecision(req.Decision)
+	if err != nil {
+		return nil, err
+	}
+	if req.Note != nil {
+		note := strings.TrimSpace(*req.Note)
+		if len(note) > 500 {
+			return nil, ErrInvalidReviewRequest
+		}
+		req.Note = &note
+		if note == "" {
+			req.Note = nil
+		}
+	}
+
+	if err := uc.userRepo.ResolveAccountReview(ctx, reviewID, adminID, approve, req.Note); err != nil {
+		return nil, err
+	}
+	if approve {
+		abuse.RecordReview("approved")
+	} else {
+		abuse.RecordReview("rejected")
+	}
+	return uc.userRepo.GetAccountReview(ctx, reviewID)
+}
+
+// parseReviewDecision - APPROVE → true, REJECT → false
+func parseReviewDecision(decision string) (bool, error) {
+	switch strings.ToUpper(strings.TrimSpace(decision)) {
+	case "APPROVE":
+		return true, nil
+	case "REJECT":
+		return false, nil
+	}
+	return false, ErrInvalidReviewRequest
+}
@@ -0,0 +1,27 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseReviewDecision(t *testing.T) {
+	if approve, err := parseReviewDecision(" approve "); err != nil || !approve {
+		t.Fatalf("approve: %v %v", approve, err)
+	}
+	if approve, err := parseReviewDecision("REJECT"); err != nil || approve {
+		t.Fatalf("reject: %v %v", approve, err)
+	}
+	if _, err := parseReviewDecision("block"); !errors.Is(err, ErrInvalidReviewRequest) {
+		t.Fatalf("unknown decision: %v", err)
+	}
+}
+
+func TestCheckDisposableEmail(t *testing.T) {
+	if err := checkDisposableEmail("student@yopmail.com"); !errors.Is(err, ErrDisposableEmail) {
+		t.Fatalf("disposable email: %v", err)
+	}
+	if err := checkDisposableEmail("student@fpt.edu.vn"); err != nil {
+		t.Fatalf("regular email: %v", err)
+	}
+}
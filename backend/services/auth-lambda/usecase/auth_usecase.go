@@ -6,7 +6,8 @@ import (
 	"fmt"
 	"log"
 
-	"github.com/fpt-event-services/common/jwt"
+	"github.com/fpt-event-services/common/campus"
+	"github.com/fpt-event-services/common/otp"
 	"github.com/fpt-event-services/common/validator"
 	"github.com/fpt-event-services/services/auth-lambda/models"
 	"github.com/fpt-event-services/services/auth-lambda/repository"
@@ -20,12 +21,13 @@ type AuthUseCase struct {
 // NewAuthUseCase creates a new auth use case
 func NewAuthUseCase() *AuthUseCase {
 	return &AuthUseCase{
-		userRepo: repository.NewUserRepository(),
+		userRepo: repository.DefaultUserRepository(),
 	}
 }
 
 // Login handles user login
-func (uc *AuthUseCase) Login(ctx context.Context, req models.LoginRequest) (*models.AuthResponse, error) {
+// Mỗi lần đăng nhập là một phiên (GET /api/me/sessions) gắn với token trả về
+func (uc *AuthUseCase) Login(ctx context.Context, req models.LoginRequest, client models.ClientInfo) (*models.AuthResponse, error) {
 	// Validate input - only check email format, no password format validation for login
 	if err := validator.GetEmailError(req.Email); err != "" {
 		return nil, errors.New(err)
@@ -42,7 +44,7 @@ func (uc *AuthUseCase) Login(ctx context.Context, req models.LoginRequest) (*mod
 	}
 
 	// Generate JWT token
-	token, err := jwt.GenerateToken(user.ID, user.Email, user.Role)
+	token, err := uc.issueToken(ctx, user.ID, user.Email, user.Role, client)
 	if err != nil {
 		return nil, errors.New("failed to generate token")
 	}
@@ -54,7 +56,8 @@ func (uc *AuthUseCase) Login(ctx context.Context, req models.LoginRequest) (*mod
 }
 
 // Register handles user registration
-func (uc *AuthUseCase) Register(ctx context.Context, req models.RegisterRequest) (*models.AuthResponse, error) {
+// Tài khoản bị cách ly (abuse) vẫn được tạo nhưng trả về ErrAccountQuarantined, không cấp token
+func (uc *AuthUseCase) Register(ctx context.Context, req models.RegisterRequest, client models.ClientInfo) (*models.AuthResponse, error) {
 	// Validate input
 	if err := validator.GetFullNameError(req.FullName); err != "" {
 		return nil, errors.New(err)
@@ -68,8 +71,12 @@ func (uc *AuthUseCase) Register(ctx context.Context, req models.RegisterRequest)
 	if err := validator.GetPasswordError(req.Password); err != "" {
 		return nil, errors.New(err)
 	}
+	if err := checkDisposableEmail(req.Email); err != nil {
+		return nil, err
+	}
 
 	// Check if email already exists
+	// Email của khách guest checkout cũng tính là tồn tại: chỉ luồng OTP (đã xác minh email) mới nhận lại vé của khách
 	exists, err := uc.userRepo.ExistsByEmail(ctx, req.Email)
 	if err != nil {
 		return nil, errors.New("failed to check email")
@@ -92,6 +99,9 @@ func (uc *AuthUseCase) Register(ctx context.Context, req models.RegisterRequest)
 	if err != nil {
 		return nil, errors.New("failed to create user")
 	}
+	if uc.screenRegistration(ctx, userID, client) {
+		return nil, ErrAccountQuarantined
+	}
 
 	// Get created user
 	createdUser, err := uc.userRepo.FindByEmail(ctx, req.Email)
@@ -100,7 +110,7 @@ func (uc *AuthUseCase) Register(ctx context.Context, req models.RegisterRequest)
 	}
 
 	// Generate JWT token
-	token, err := jwt.GenerateToken(userID, createdUser.Email, createdUser.Role)
+	token, err := uc.issueToken(ctx, userID, createdUser.Email, createdUser.Role, client)
 	if err != nil {
 		return nil, errors.New("failed to generate token")
 	}
@@ -130,9 +140,24 @@ func (uc *AuthUseCase) AdminCreateAccount(ctx context.Context, req models.AdminC
 		log.Printf("[CREATE-ACCOUNT] Validation failed: password - %s", err)
 		return nil, errors.New(err)
 	}
-	if !validator.IsValidRoleForCreation(req.Role) {
+	if ok, err := uc.IsAssignableRole(ctx, req.Role); err != nil || !ok {
 		log.Printf("[CREATE-ACCOUNT] Validation failed: invalid role - %s", req.Role)
-		return nil, errors.New("invalid role. Only ADMIN, ORGANIZER, STAFF are allowed")
+		return nil, errors.New("invalid role. Only ADMIN, ORGANIZER, STAFF or custom roles are allowed")
+	}
+
+	// Admin gắn campus chỉ tạo tài khoản trong campus của mình
+	scope, err := campus.Scope(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if scope != nil {
+		if req.CampusID != nil && *req.CampusID != *scope {
+			return nil, campus.ErrOutOfScope
+		}
+		req.CampusID = scope
+	}
+	if req.CampusID != nil && *req.CampusID <= 0 {
+		return nil, campus.ErrInvalidCampus
 	}
 
 	// Check if email already exists
@@ -177,16 +202,19 @@ func (uc *AuthUseCase) ForgotPassword(ctx context.Context, email string) (string
 	if err != nil {
 		return "", errors.New("lỗi khi kiểm tra email")
 	}
-	if user == nil {
+	// Khách guest checkout chưa có mật khẩu để đặt lại: đăng ký tài khoản bằng email đó
+	if user == nil || user.Role == models.RoleGuest {
 		return "", errors.New("email không tồn tại trong hệ thống")
 	}
 
-	// Sinh OTP
-	otpManager := GetOTPManager()
-	otp := otpManager.GenerateOTP(email)
+	// Sinh OTP (lỗi nếu email đang bị khóa do nhập sai quá nhiều)
+	code, err := GetOTPManager().Generate(otp.PurposeForgotPassword, email)
+	if err != nil {
+		return "", err
+	}
 
 	// Return OTP (caller sẽ gửi email)
-	return otp, nil
+	return code, nil
 }
 
 // ResetPassword - Xác thực OTP và đổi mật khẩu
@@ -207,15 +235,14 @@ func (uc *AuthUseCase) ResetPassword(ctx context.Context, req models.ResetPasswo
 	if err != nil {
 		return errors.New("lỗi khi kiểm tra email")
 	}
-	if user == nil {
+	if user == nil || user.Role == models.RoleGuest {
 		return errors.New("email không tồn tại trong hệ thống")
 	}
 
-	// Verify OTP
+	// Verify OTP (trả về *otp.VerifyError kèm số lần thử còn lại)
 	otpManager := GetOTPManager()
-	valid, message := otpManager.VerifyOTP(req.Email, req.OTP)
-	if !valid {
-		return errors.New(message)
+	if err := otpManager.Verify(otp.PurposeForgotPassword, req.Email, req.OTP); err != nil {
+		return err
 	}
 
 	// Cập nhật mật khẩu
@@ -225,7 +252,7 @@ func (uc *AuthUseCase) ResetPassword(ctx context.Context, req models.ResetPasswo
 	}
 
 	// Vô hiệu hóa OTP
-	otpManager.Invalidate(req.Email)
+	otpManager.Invalidate(otp.PurposeForgotPassword, req.Email)
 
 	return nil
 }
@@ -239,8 +266,13 @@ func (uc *AuthUseCase) ResetPassword(ctx context.Context, req models.ResetPasswo
 var pendingRegistrations = make(map[string]*models.PendingRegistration)
 
 // CheckEmailExists checks if email already exists
+// User GUEST (guest checkout) không tính: đăng ký qua OTP sẽ nâng user đó thành tài khoản
 func (uc *AuthUseCase) CheckEmailExists(ctx context.Context, email string) (bool, error) {
-	return uc.userRepo.ExistsByEmail(ctx, email)
+	user, err := uc.userRepo.FindByEmail(ctx, email)
+	if err != nil {
+		return false, err
+	}
+	return user != nil && user.Role != models.RoleGuest, nil
 }
 
 // GenerateRegisterOTP generates OTP for registration
@@ -258,27 +290,32 @@ func (uc *AuthUseCase) GenerateRegisterOTP(ctx context.Context, req models.Regis
 	if err := validator.GetPasswordError(req.Password); err != "" {
 		return "", errors.New(err)
 	}
+	if err := checkDisposableEmail(req.Email); err != nil {
+		return "", err
+	}
 
 	// Hash password for storage
 	hashedPassword := hashPassword(req.Password)
 
 	// Store pending registration
-	otpManager := GetOTPManager()
-	otp := otpManager.GenerateOTP(req.Email)
+	code, err := GetOTPManager().Generate(otp.PurposeRegister, req.Email)
+	if err != nil {
+		return "", err
+	}
 
 	pendingRegistrations[req.Email] = &models.PendingRegistration{
 		Email:        req.Email,
 		FullName:     req.FullName,
 		Phone:        req.Phone,
 		PasswordHash: hashedPassword,
-		OTP:          otp,
 	}
 
-	return otp, nil
+	return code, nil
 }
 
 // VerifyRegisterOTP verifies OTP and creates user account
-func (uc *AuthUseCase) VerifyRegisterOTP(ctx context.Context, email, otp string) (*models.AuthResponse, error) {
+// Tài khoản bị cách ly (abuse) vẫn được tạo nhưng trả về ErrAccountQuarantined, không cấp token
+func (uc *AuthUseCase) VerifyRegisterOTP(ctx context.Context, email, code string, client models.ClientInfo) (*models.AuthResponse, error) {
 	// Check pending registration exists
 	pending, exists := pendingRegistrations[email]
 	if !exists {
@@ -287,17 +324,16 @@ func (uc *AuthUseCase) VerifyRegisterOTP(ctx context.Context, email, otp string)
 
 	// Verify OTP
 	otpManager := GetOTPManager()
-	valid, message := otpManager.VerifyOTP(email, otp)
-	if !valid {
-		return nil, errors.New(message)
+	if err := otpManager.Verify(otp.PurposeRegister, email, code); err != nil {
+		return nil, err
 	}
 
 	// Double-check email doesn't exist (race condition protection)
-	emailExists, err := uc.userRepo.ExistsByEmail(ctx, email)
+	existing, err := uc.userRepo.FindByEmail(ctx, email)
 	if err != nil {
 		return nil, errors.New("Lỗi khi kiểm tra email")
 	}
-	if emailExists {
+	if existing != nil && existing.Role != models.RoleGuest {
 		delete(pendingRegistrations, email)
 		return nil, errors.New("Email đã tồn tại")
 	}
@@ -311,17 +347,28 @@ func (uc *AuthUseCase) VerifyRegisterOTP(ctx context.Context, email, otp string)
 		Status:   "ACTIVE",
 	}
 
-	userID, err := uc.userRepo.CreateUserWithHash(ctx, &user, pending.PasswordHash)
+	// Email đã mua vé dạng khách (OTP vừa xác minh chủ email): nâng user GUEST, vé giữ nguyên
+	var userID int
+	if existing != nil {
+		userID = existing.ID
+		err = uc.userRepo.ConvertGuestAccount(ctx, userID, &user, pending.PasswordHash)
+	} else {
+		userID, err = uc.userRepo.CreateUserWithHash(ctx, &user, pending.PasswordHash)
+	}
 	if err != nil {
 		return nil, errors.New("Không thể tạo tài khoản")
 	}
 
 	// Cleanup
 	delete(pendingRegistrations, email)
-	otpManager.Invalidate(email)
+	otpManager.Invalidate(otp.PurposeRegister, email)
+
+	if uc.screenRegistration(ctx, userID, client) {
+		return nil, ErrAccountQuarantined
+	}
 
 	// Generate JWT
-	token, err := jwt.GenerateToken(userID, user.Email, user.Role)
+	token, err := uc.issueToken(ctx, userID, user.Email, user.Role, client)
 	if err != nil {
 		return nil, errors.New("Không thể tạo token")
 	}
@@ -346,10 +393,26 @@ func (uc *AuthUseCase) ResendRegisterOTP(ctx context.Context, email string) (str
 		return "", errors.New("Quá nhiều lần gửi lại")
 	}
 
-	otpManager := GetOTPManager()
-	otp := otpManager.GenerateOTP(email)
+	return GetOTPManager().Generate(otp.PurposeRegister, email)
+}
 
-	return otp, nil
+// FindOTPPhone trả về số điện thoại nhận OTP của email
+// (đăng ký đang chờ trước, sau đó tài khoản đã có)
+func (uc *AuthUseCase) FindOTPPhone(ctx context.Context, email string) (string, error) {
+	if pending, exists := pendingRegistrations[email]; exists && pending.Phone != "" {
+		return pending.Phone, nil
+	}
+	user, err := uc.userRepo.FindByEmail(ctx, email)
+	if err != nil {
+		return "", errors.New("lỗi khi kiểm tra email")
+	}
+	if user == nil {
+		return "", errors.New("email không tồn tại trong hệ thống")
+	}
+	if user.Phone == "" {
+		return "", errors.New("tài khoản chưa có số điện thoại, vui lòng nhận OTP qua email")
+	}
+	return user.Phone, nil
 }
 
 // ============================================================
@@ -360,8 +423,10 @@ func (uc *AuthUseCase) ResendRegisterOTP(ctx context.Context, email string) (str
 // AdminUpdateUser updates user by admin
 func (uc *AuthUseCase) AdminUpdateUser(ctx context.Context, req models.AdminUpdateUserRequest) error {
 	// Validate role if provided
-	if req.Role != "" && !validator.IsValidRoleForCreation(req.Role) {
-		return errors.New("Role không hợp lệ")
+	if req.Role != "" {
+		if ok, err := uc.IsAssignableRole(ctx, req.Role); err != nil || !ok {
+			return errors.New("Role không hợp lệ")
+		}
 	}
 
 	// Validate status if provided
@@ -378,13 +443,19 @@ func (uc *AuthUseCase) AdminDeleteUser(ctx context.Context, userID string) error
 }
 
 // GetStaffAndOrganizers returns lists of STAFF and ORGANIZER users
+// Admin gắn campus chỉ thấy người dùng thuộc campus của mình
 func (uc *AuthUseCase) GetStaffAndOrganizers(ctx context.Context) (*models.StaffOrganizerResponse, error) {
-	staffList, err := uc.userRepo.FindByRole(ctx, "STAFF")
+	scope, err := campus.Scope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	staffList, err := uc.userRepo.FindByRole(ctx, "STAFF", scope)
 	if err != nil {
 		return nil, err
 	}
 
-	organizerList, err := uc.userRepo.FindByRole(ctx, "ORGANIZER")
+	organizerList, err := uc.userRepo.FindByRole(ctx, "ORGANIZER", scope)
 	if err != nil {
 		return nil, err
 	}
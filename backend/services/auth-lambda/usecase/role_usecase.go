@@ -0,0 +1,169 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/fpt-event-services/common/permission"
+	"github.com/fpt-event-services/services/auth-lambda/models"
+	"github.com/fpt-event-services/services/auth-lambda/repository"
+)
+
+var (
+	// ErrInvalidRoleRequest - Tên role, role cha hoặc quyền không hợp lệ
+	ErrInvalidRoleRequest = errors.New("invalid role request")
+	// ErrSystemRole - Không được xóa role có sẵn
+	ErrSystemRole = errors.New("system roles cannot be deleted")
+)
+
+// roleNamePattern - Tên role viết hoa, vd: FINANCE_STAFF
+var roleNamePattern = regexp.MustCompile(`^[A-Z][A-Z0-9_]{2,49}$`)
+
+// ============================================================
+// ListRoles - Mọi role cùng quyền gán trực tiếp, quyền hiệu lực và số tài khoản
+// ============================================================
+func (uc *AuthUseCase) ListRoles(ctx context.Context) ([]models.RoleResponse, error) {
+	roles, err := permission.LoadRoles(ctx)
+	if err != nil {
+		return nil, err
+	}
+	counts, err := uc.userRepo.CountUsersByRole(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]models.RoleResponse, 0, len(roles))
+	for _, r := range roles {
+		result = append(result, models.RoleResponse{
+			Role:                 r,
+			EffectivePermissions: permission.Effective(ctx, r.Name),
+			UserCount:            counts[r.Name],
+		})
+	}
+	return result, nil
+}
+
+// CreateRole - Tạo role tùy chỉnh (vd: FINANCE_STAFF kế thừa STAFF và thêm ticket.view_all)
+func (uc *AuthUseCase) CreateRole(ctx context.Context, req models.RoleRequest) error {
+	roles, err := permission.LoadRoles(ctx)
+	if err != nil {
+		return err
+	}
+	if err := normalizeRoleRequest(&req, roles); err != nil {
+		return err
+	}
+	if err := uc.userRepo.CreateRole(ctx, req); err != nil {
+		return err
+	}
+	permission.Invalidate()
+	return nil
+}
+
+// UpdateRole - Đổi mô tả, role cha và quyền của role (kể cả role có sẵn)
+func (uc *AuthUseCase) UpdateRole(ctx context.Context, req models.RoleRequest) error {
+	roles, err := permission.LoadRoles(ctx)
+	if err != nil {
+		return err
+	}
+	if err := normalizeRoleRequest(&req, roles); err != nil {
+		return err
+	}
+	// ADMIN luôn giữ role.manage để không tự khóa quyền quản trị
+	if req.Name == "ADMIN" && !containsString(req.Permissions, permission.RoleManage) {
+		return fmt.Errorf("%w: ADMIN must keep %s", ErrInvalidRoleRequest, permission.RoleManage)
+	}
+	if err := uc.userRepo.UpdateRole(ctx, req); err != nil {
+		return err
+	}
+	permission.Invalidate()
+	return nil
+}
+
+// DeleteRole - Xóa role tùy chỉnh không còn được dùng
+func (uc *AuthUseCase) DeleteRole(ctx context.Context, name string) error {
+	exists, system, err := uc.userRepo.RoleIsSystem(ctx, name)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return repository.ErrRoleNotFound
+	}
+	if system {
+		return ErrSystemRole
+	}
+	if err := uc.userRepo.DeleteRole(ctx, name); err != nil {
+		return err
+	}
+	permission.Invalidate()
+	return nil
+}
+
+// IsAssignableRole - Role được gán khi admin tạo / sửa tài khoản:
+// ADMIN, ORGANIZER, STAFF hoặc role tùy chỉnh đã tạo
+func (uc *AuthUseCase) IsAssignableRole(ctx context.Context, role string) (bool, error) {
+	switch strings.ToUpper(role) {
+	case "ADMIN", "ORGANIZER", "STAFF":
+		return true, nil
+	}
+	exists, system, err := uc.userRepo.RoleIsSystem(ctx, role)
+	return exists && !system, err
+}
+
+// normalizeRoleRequest chuẩn hóa tên / quyền và kiểm tra role cha (không tạo vòng kế thừa)
+func normalizeRoleRequest(req *models.RoleRequest, existing []permission.Role) error {
+	req.Name = strings.ToUpper(strings.TrimSpace(req.Name))
+	if !roleNamePattern.MatchString(req.Name) {
+		return fmt.Errorf("%w: name must be 3-50 uppercase letters, digits or underscores", ErrInvalidRoleRequest)
+	}
+
+	seen := map[string]bool{}
+	perms := []string{}
+	for _, p := range req.Permissions {
+		p = strings.TrimSpace(p)
+		if !permission.IsValid(p) {
+			return fmt.Errorf("%w: unknown permission %q", ErrInvalidRoleRequest, p)
+		}
+		if !seen[p] {
+			seen[p] = true
+			perms = append(perms, p)
+		}
+	}
+	sort.Strings(perms)
+	req.Permissions = perms
+
+	if req.ParentRole == nil || strings.TrimSpace(*req.ParentRole) == "" {
+		req.ParentRole = nil
+		return nil
+	}
+	parent := strings.ToUpper(strings.TrimSpace(*req.ParentRole))
+	req.ParentRole = &parent
+
+	parents := make(map[string]*string, len(existing))
+	for _, r := range existing {
+		parents[r.Name] = r.ParentRole
+	}
+	if _, ok := parents[parent]; !ok {
+		return fmt.Errorf("%w: parent role %s does not exist", ErrInvalidRoleRequest, parent)
+	}
+	// Đi ngược chuỗi role cha; gặp lại chính role này = vòng kế thừa
+	for cur, steps := &parent, 0; cur != nil && steps <= len(parents); steps++ {
+		if *cur == req.Name {
+			return fmt.Errorf("%w: parent role would create an inheritance cycle", ErrInvalidRoleRequest)
+		}
+		cur = parents[*cur]
+	}
+	return nil
+}
+
+func containsString(list []string, v string) bool {
+	for _, s := range list {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,44 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/fpt-event-services/common/permission"
+	"github.com/fpt-event-services/services/auth-lambda/models"
+)
+
+func strPtr(s string) *string { return &s }
+
+func TestNormalizeRoleRequest(t *testing.T) {
+	existing := []permission.Role{
+		{Name: "STAFF"},
+		{Name: "FINANCE_STAFF", ParentRole: strPtr("STAFF")},
+	}
+
+	req := models.RoleRequest{
+		Name:        " finance_lead ",
+		ParentRole:  strPtr("finance_staff"),
+		Permissions: []string{permission.TicketViewAll, permission.ReportReview, permission.TicketViewAll},
+	}
+	if err := normalizeRoleRequest(&req, existing); err != nil {
+		t.Fatalf("valid request: %v", err)
+	}
+	if req.Name != "FINANCE_LEAD" || *req.ParentRole != "FINANCE_STAFF" || len(req.Permissions) != 2 {
+		t.Fatalf("normalized = %+v", req)
+	}
+
+	invalid := []models.RoleRequest{
+		{Name: "x"},
+		{Name: "AUDITOR", Permissions: []string{"bills.delete"}},
+		{Name: "AUDITOR", ParentRole: strPtr("GHOST")},
+		// STAFF -> FINANCE_STAFF -> STAFF
+		{Name: "STAFF", ParentRole: strPtr("FINANCE_STAFF")},
+	}
+	for _, r := range invalid {
+		r := r
+		if err := normalizeRoleRequest(&r, existing); !errors.Is(err, ErrInvalidRoleRequest) {
+			t.Errorf("%+v: err = %v, want ErrInvalidRoleRequest", r, err)
+		}
+	}
+}
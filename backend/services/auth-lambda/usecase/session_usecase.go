@@ -0,0 +1,108 @@
+package usecase
+
+import (
+	"context"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/fpt-event-services/common/jwt"
+	"github.com/fpt-event-services/common/session"
+	"github.com/fpt-event-services/services/auth-lambda/models"
+	"github.com/fpt-event-services/services/auth-lambda/repository"
+)
+
+// ============================================================
+// issueToken - Tạo phiên đăng nhập và JWT gắn phiên (claim jti)
+// Không tạo được phiên (vd. chưa chạy migration 053): vẫn cấp token không gắn phiên
+// ============================================================
+func (uc *AuthUseCase) issueToken(ctx context.Context, userID int, email, role string, client models.ClientInfo) (string, error) {
+	sessionID, err := session.NewID()
+	if err == nil {
+		err = uc.userRepo.CreateSession(ctx, sessionID, userID, client, time.Now().Add(jwt.Expiration()))
+	}
+	if err != nil {
+		log.Printf("[SESSION] ⚠️ user %d: %v", userID, err)
+		sessionID = ""
+	}
+	return jwt.GenerateSessionToken(userID, email, role, sessionID)
+}
+
+// ListSessions - Phiên còn hiệu lực của user, đánh dấu phiên đang gọi API
+func (uc *AuthUseCase) ListSessions(ctx context.Context, userID int, currentSessionID string) ([]models.UserSession, error) {
+	sessions, err := uc.userRepo.ListActiveSessions(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range sessions {
+		ua := ""
+		if sessions[i].UserAgent != nil {
+			ua = *sessions[i].UserAgent
+		}
+		sessions[i].Device = describeUserAgent(ua)
+		sessions[i].Current = currentSessionID != "" && sessions[i].SessionID == currentSessionID
+	}
+	return sessions, nil
+}
+
+// RevokeSession - Thu hồi một phiên của user (thu hồi phiên hiện tại = đăng xuất)
+func (uc *AuthUseCase) RevokeSession(ctx context.Context, userID int, sessionID string) error {
+	sessionID = strings.ToLower(strings.TrimSpace(sessionID))
+	if !isSessionID(sessionID) {
+		return repository.ErrSessionNotFound
+	}
+	if err := uc.userRepo.RevokeSession(ctx, userID, sessionID); err != nil {
+		return err
+	}
+	session.Forget(sessionID)
+	return nil
+}
+
+// isSessionID - 32 ký tự hex (session.NewID)
+func isSessionID(s string) bool {
+	if len(s) != 32 {
+		return false
+	}
+	for _, c := range s {
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+// describeUserAgent - Tóm tắt trình duyệt / ứng dụng và hệ điều hành từ user agent
+func describeUserAgent(ua string) string {
+	if ua == "" {
+		return "Thiết bị không xác định"
+	}
+	lower := strings.ToLower(ua)
+	first := func(pairs [][2]string, fallback string) string {
+		for _, p := range pairs {
+			if strings.Contains(lower, p[0]) {
+				return p[1]
+			}
+		}
+		return fallback
+	}
+	// Thứ tự quan trọng: Edge/Opera chứa "chrome", Chrome chứa "safari", iOS chứa "mac os x"
+	browser := first([][2]string{
+		{"edg/", "Edge"}, {"opr/", "Opera"}, {"coc_coc", "Cốc Cốc"}, {"firefox/", "Firefox"},
+		{"crios/", "Chrome"}, {"chrome/", "Chrome"}, {"safari/", "Safari"},
+		{"okhttp", "Ứng dụng Android"}, {"cfnetwork", "Ứng dụng iOS"},
+		{"postman", "Postman"}, {"curl/", "curl"},
+	}, "")
+	platform := first([][2]string{
+		{"iphone", "iPhone"}, {"ipad", "iPad"}, {"android", "Android"}, {"windows", "Windows"},
+		{"mac os x", "macOS"}, {"cros", "ChromeOS"}, {"linux", "Linux"},
+	}, "")
+	switch {
+	case browser != "" && platform != "":
+		return browser + " trên " + platform
+	case browser != "":
+		return browser
+	case platform != "":
+		return platform
+	}
+	return "Thiết bị không xác định"
+}
@@ -0,0 +1,31 @@
+package usecase
+
+import "testing"
+
+func TestDescribeUserAgent(t *testing.T) {
+	cases := map[string]string{
+		"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/129.0.0.0 Safari/537.36":                         "Chrome trên Windows",
+		"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/129.0.0.0 Safari/537.36 Edg/129.0.0.0":           "Edge trên Windows",
+		"Mozilla/5.0 (iPhone; CPU iPhone OS 17_5 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.5 Mobile/15E148 Safari/604.1": "Safari trên iPhone",
+		"Mozilla/5.0 (Macintosh; Intel Mac OS X 14_5; rv:130.0) Gecko/20100101 Firefox/130.0":                                                     "Firefox trên macOS",
+		"okhttp/4.12.0": "Ứng dụng Android",
+		"":              "Thiết bị không xác định",
+		"SomeBot/1.0":   "Thiết bị không xác định",
+	}
+	for ua, want := range cases {
+		if got := describeUserAgent(ua); got != want {
+			t.Errorf("describeUserAgent(%q) = %q, want %q", ua, got, want)
+		}
+	}
+}
+
+func TestIsSessionID(t *testing.T) {
+	if !isSessionID("0123456789abcdef0123456789abcdef") {
+		t.Fatal("32 hex chars must be a session id")
+	}
+	for _, s := range []string{"", "123", "0123456789abcdef0123456789abcdeg", "0123456789ABCDEF0123456789ABCDEF"} {
+		if isSessionID(s) {
+			t.Errorf("%q must not be a session id", s)
+		}
+	}
+}
@@ -0,0 +1,192 @@
+package usecase
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	apperrors "github.com/fpt-event-services/common/errors"
+	"github.com/fpt-event-services/common/hash"
+	"github.com/fpt-event-services/services/auth-lambda/models"
+)
+
+const (
+	// dataExportTTL - File xuất dữ liệu được tải trong 48 giờ rồi bị xóa
+	dataExportTTL = 48 * time.Hour
+	// dataExportBuildTimeout - Thời gian tối đa để dựng một file
+	dataExportBuildTimeout = 2 * time.Minute
+	// dataExportStaleAfter - Yêu cầu PENDING quá lâu (process restart giữa chừng) được tạo lại
+	dataExportStaleAfter = 15 * time.Minute
+)
+
+// dataRetention - Chính sách lưu trữ ghi kèm file xuất và áp dụng khi xóa tài khoản
+var dataRetention = map[string]string{
+	"profile":       "Ẩn danh hóa ngay khi xóa tài khoản",
+	"tickets":       "Giữ lại (chứng từ tài chính), không còn gắn với thông tin cá nhân",
+	"bills":         "Giữ lại (chứng từ tài chính), không còn gắn với thông tin cá nhân",
+	"walletLedger":  "Dựng từ bills và reports, theo chính sách của hai mục này",
+	"reports":       "Giữ số tiền hoàn và trạng thái; xóa tiêu đề, mô tả, ảnh khi xóa tài khoản",
+	"eventRequests": "Giữ lại như lịch sử tổ chức sự kiện của trường",
+	"notifications": "Xóa khi xóa tài khoản",
+	"loginHistory":  "Xóa khi xóa tài khoản",
+}
+
+// ============================================================
+// RequestDataExport - Lấy/tạo yêu cầu xuất dữ liệu cá nhân
+// Dùng lại file còn hạn hoặc yêu cầu đang chạy; refresh=true buộc tạo file mới
+// File được dựng nền, client poll lại cho đến khi status = READY
+// ============================================================
+func (uc *AuthUseCase) RequestDataExport(ctx context.Context, userID int, refresh bool) (*models.DataExport, error) {
+	if _, err := uc.userRepo.PurgeExpiredDataExports(ctx); err != nil {
+		log.Printf("[DATA_EXPORT] ⚠️ %v", err)
+	}
+
+	latest, err := uc.userRepo.GetLatestDataExport(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if latest != nil {
+		switch {
+		case latest.Status == models.DataExportPending && time.Since(latest.RequestedAt) < dataExportStaleAfter:
+			return latest, nil
+		case latest.Status == models.DataExportReady && !refresh &&
+			latest.ExpiresAt != nil && latest.ExpiresAt.After(time.Now()):
+			return latest, nil
+		}
+	}
+
+	export, err := uc.userRepo.CreateDataExport(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Chạy nền: không phụ thuộc vòng đời request
+	go uc.buildDataExport(context.WithoutCancel(ctx), export.ExportID, userID)
+	return export, nil
+}
+
+// buildDataExport gom dữ liệu, nén ZIP và lưu vào User_Data_Export
+func (uc *AuthUseCase) buildDataExport(ctx context.Context, exportID, userID int) {
+	ctx, cancel := context.WithTimeout(ctx, dataExportBuildTimeout)
+	defer cancel()
+
+	fail := func(err error) {
+		log.Printf("[DATA_EXPORT] ❌ Export #%d for user %d failed: %v", exportID, userID, err)
+		if ferr := uc.userRepo.FailDataExport(ctx, exportID, err.Error()); ferr != nil {
+			log.Printf("[DATA_EXPORT] ⚠️ Failed to mark export #%d as failed: %v", exportID, ferr)
+		}
+	}
+
+	bundle, err := uc.userRepo.CollectUserData(ctx, userID)
+	if err != nil {
+		fail(err)
+		return
+	}
+	bundle.Retention = dataRetention
+
+	data, err := buildDataExportZip(bundle)
+	if err != nil {
+		fail(err)
+		return
+	}
+
+	fileName := fmt.Sprintf("user-data-%d-%s.zip", userID, bundle.GeneratedAt.Format("20060102-150405"))
+	if err := uc.userRepo.CompleteDataExport(ctx, exportID, fileName, data, time.Now().Add(dataExportTTL)); err != nil {
+		fail(err)
+		return
+	}
+	log.Printf("[DATA_EXPORT] ✅ Export #%d for user %d ready (%d bytes)", exportID, userID, len(data))
+}
+
+// buildDataExportZip tạo file ZIP gồm user-data.json và README.txt
+func buildDataExportZip(bundle *models.UserDataBundle) ([]byte, error) {
+	payload, err := json.MarshalIndent(bundle, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode user data: %w", err)
+	}
+
+	var readme strings.Builder
+	readme.WriteString("FPT Event Management - Dữ liệu cá nhân\n")
+	fmt.Fprintf(&readme, "Tạo lúc: %s\n\n", bundle.GeneratedAt.Format(time.RFC3339))
+	readme.WriteString("user-data.json gồm các mục: profile, tickets, bills, walletLedger, reports,\n")
+	readme.WriteString("eventRequests, notifications, loginHistory.\n\n")
+	readme.WriteString("Chính sách lưu trữ khi xóa tài khoản:\n")
+	for _, key := range []string{"profile", "tickets", "bills", "walletLedger", "reports", "eventRequests", "notifications", "loginHistory"} {
+		fmt.Fprintf(&readme, "  - %s: %s\n", key, bundle.Retention[key])
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, f := range []struct {
+		name string
+		data []byte
+	}{
+		{"user-data.json", payload},
+		{"README.txt", []byte(readme.String())},
+	} {
+		w, err := zw.CreateHeader(&zip.FileHeader{Name: f.name, Method: zip.Deflate, Modified: bundle.GeneratedAt})
+		if err != nil {
+			return nil, err
+		}
+		if _, err := w.Write(f.data); err != nil {
+			return nil, err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// GetDataExportFile trả về file ZIP đã tạo (nil nếu không có / hết hạn / không thuộc user)
+func (uc *AuthUseCase) GetDataExportFile(ctx context.Context, exportID, userID int) (string, []byte, error) {
+	return uc.userRepo.GetDataExportFile(ctx, exportID, userID)
+}
+
+// ============================================================
+// DeleteAccount - Ẩn danh hóa tài khoản của chính user
+// Yêu cầu nhập lại mật khẩu; ADMIN không tự xóa được
+// Bill/Ticket được giữ lại theo quy định lưu chứng từ tài chính
+// ============================================================
+func (uc *AuthUseCase) DeleteAccount(ctx context.Context, userID int, role, password string) error {
+	if role == "ADMIN" {
+		return apperrors.New(apperrors.ErrCodeAccessDenied, "Tài khoản ADMIN không thể tự xóa")
+	}
+	if password == "" {
+		return apperrors.MissingField("password")
+	}
+
+	passwordHash, err := uc.userRepo.FindPasswordHashByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !hash.VerifyPassword(password, passwordHash) {
+		return apperrors.New(apperrors.ErrCodeInvalidPassword, "Mật khẩu không đúng")
+	}
+
+	blockers, err := uc.userRepo.GetAccountDeletionBlockers(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if len(blockers) > 0 {
+		return apperrors.BusinessError("Chưa thể xóa tài khoản: " + strings.Join(blockers, "; "))
+	}
+
+	if err := uc.userRepo.AnonymizeUser(ctx, userID); err != nil {
+		return err
+	}
+	log.Printf("[ACCOUNT] 🗑️ User %d anonymized at own request", userID)
+	return nil
+}
+
+// RecordLogin ghi lịch sử đăng nhập (lỗi chỉ log, không chặn đăng nhập)
+func (uc *AuthUseCase) RecordLogin(ctx context.Context, userID int, ipAddress, userAgent string) {
+	if err := uc.userRepo.RecordLogin(ctx, userID, ipAddress, userAgent); err != nil {
+		log.Printf("[LOGIN] ⚠️ %v", err)
+	}
+}
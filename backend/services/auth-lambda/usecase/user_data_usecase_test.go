@@ -0,0 +1,62 @@
+package usecase
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fpt-event-services/services/auth-lambda/models"
+)
+
+func TestBuildDataExportZip(t *testing.T) {
+	bundle := &models.UserDataBundle{
+		GeneratedAt: time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC),
+		Profile:     models.UserProfileExport{UserID: 7, FullName: "Nguyen Van A", Email: "a@fpt.edu.vn"},
+		Tickets:     []models.TicketExport{{TicketID: 1, EventID: 2, Status: "BOOKED"}},
+		WalletLedger: []models.WalletLedgerEntry{
+			{Type: "PAYMENT", Amount: -50000, Reference: "bill#3"},
+		},
+		Retention: dataRetention,
+	}
+
+	data, err := buildDataExportZip(bundle)
+	if err != nil {
+		t.Fatalf("buildDataExportZip: %v", err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("invalid zip: %v", err)
+	}
+	files := map[string][]byte{}
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatalf("open %s: %v", f.Name, err)
+		}
+		files[f.Name], _ = io.ReadAll(rc)
+		rc.Close()
+	}
+
+	var decoded models.UserDataBundle
+	if err := json.Unmarshal(files["user-data.json"], &decoded); err != nil {
+		t.Fatalf("user-data.json: %v", err)
+	}
+	if decoded.Profile.Email != "a@fpt.edu.vn" || len(decoded.Tickets) != 1 || decoded.WalletLedger[0].Amount != -50000 {
+		t.Errorf("unexpected bundle content: %+v", decoded)
+	}
+	if decoded.Retention["bills"] == "" {
+		t.Error("retention policy must be included in the export")
+	}
+
+	readme := string(files["README.txt"])
+	for _, section := range []string{"profile", "walletLedger", "loginHistory"} {
+		if !strings.Contains(readme, section) {
+			t.Errorf("README.txt missing section %q", section)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/fpt-event-services/common/authctx"
+	"github.com/fpt-event-services/common/campus"
+	"github.com/fpt-event-services/common/permission"
+	"github.com/fpt-event-services/services/dashboard-lambda/usecase"
+)
+
+// ============================================================
+// HandleGetMyAttendance - GET /api/me/attendance[?semester=FA26]
+// Sự kiện đã check-in kèm điểm rèn luyện, tổng điểm theo học kỳ
+// ============================================================
+func (h *DashboardHandler) HandleGetMyAttendance(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	userID, ok := authctx.UserID(ctx)
+	if !ok {
+		return createMessageResponse(http.StatusUnauthorized, "Unauthorized")
+	}
+
+	history, err := h.useCase.GetMyAttendance(ctx, userID, request.QueryStringParameters["semester"])
+	if errors.Is(err, usecase.ErrInvalidSemester) {
+		return createMessageResponse(http.StatusBadRequest, err.Error())
+	}
+	if err != nil {
+		log.Printf("[ATTENDANCE] user %d failed: %v", userID, err)
+		return createMessageResponse(http.StatusInternalServerError, "Failed to load attendance history")
+	}
+	return createJSONResponse(http.StatusOK, history)
+}
+
+// ============================================================
+// HandleGetAttendancePoints - GET /api/admin/attendance/points?semester=FA26[&campusId=][&format=csv]
+// Điểm rèn luyện theo sinh viên để nhập vào hệ thống của phòng CTSV (quyền attendance.export)
+// Thiếu semester = học kỳ hiện tại; admin gắn campus chỉ xuất được sinh viên campus mình
+// ============================================================
+func (h *DashboardHandler) HandleGetAttendancePoints(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	if !permission.Has(ctx, permission.AttendanceExport) {
+		return createMessageResponse(http.StatusForbidden, "Chỉ ADMIN mới có quyền xuất điểm rèn luyện")
+	}
+
+	requested, err := campus.ParseID(request.QueryStringParameters["campusId"])
+	if err != nil {
+		return createMessageResponse(http.StatusBadRequest, "campusId không hợp lệ")
+	}
+	campusID, err := campus.Resolve(ctx, requested)
+	if errors.Is(err, campus.ErrOutOfScope) {
+		return createMessageResponse(http.StatusForbidden, "Không có quyền xem campus này")
+	}
+	if err != nil {
+		return createMessageResponse(http.StatusInternalServerError, "Error checking campus")
+	}
+
+	export, err := h.useCase.GetAttendancePoints(ctx, request.QueryStringParameters["semester"], campusID)
+	if errors.Is(err, usecase.ErrInvalidSemester) {
+		return createMessageResponse(http.StatusBadRequest, err.Error())
+	}
+	if err != nil {
+		log.Printf("[ATTENDANCE_POINTS] failed: %v", err)
+		return createMessageResponse(http.StatusInternalServerError, "Failed to load activity points")
+	}
+
+	switch request.QueryStringParameters["format"] {
+	case "", "json":
+		return createJSONResponse(http.StatusOK, export)
+	case "csv":
+		data, err := usecase.AttendancePointsCSV(export)
+		if err != nil {
+			return createMessageResponse(http.StatusInternalServerError, "Failed to export activity points")
+		}
+		return events.APIGatewayProxyResponse{
+			StatusCode: http.StatusOK,
+			Headers: map[string]string{
+				"Content-Type":        "text/csv; charset=utf-8",
+				"Content-Disposition": fmt.Sprintf("attachment; filename=\"%s\"", usecase.AttendancePointsFileName(export)),
+				"Cache-Control":       "private, no-store",
+			},
+			Body:            base64.StdEncoding.EncodeToString(data),
+			IsBase64Encoded: true,
+		}, nil
+	}
+	return createMessageResponse(http.StatusBadRequest, "format must be json or csv")
+}
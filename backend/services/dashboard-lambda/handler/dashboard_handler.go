@@ -0,0 +1,171 @@
+package handler
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/fpt-event-services/common/authctx"
+	"github.com/fpt-event-services/common/campus"
+	"github.com/fpt-event-services/common/permission"
+	"github.com/fpt-event-services/services/dashboard-lambda/usecase"
+)
+
+// DashboardHandler handles aggregate dashboard requests
+type DashboardHandler struct {
+	useCase *usecase.DashboardUseCase
+}
+
+// NewDashboardHandler creates a new dashboard handler
+func NewDashboardHandler() *DashboardHandler {
+	return &DashboardHandler{
+		useCase: usecase.NewDashboardUseCase(),
+	}
+}
+
+// ============================================================
+// HandleGetMyDashboard - GET /api/me/dashboard
+// Gom dữ liệu màn hình chính của student vào một response:
+// vé sắp diễn ra, số dư ví, report đang chờ xử lý, sự kiện gợi ý
+// ============================================================
+func (h *DashboardHandler) HandleGetMyDashboard(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	userID, ok := authctx.UserID(ctx)
+	if !ok {
+		return createMessageResponse(http.StatusUnauthorized, "Unauthorized")
+	}
+
+	dashboard, err := h.useCase.GetStudentDashboard(ctx, userID)
+	if err != nil {
+		return createMessageResponse(http.StatusInternalServerError, "Failed to load dashboard")
+	}
+
+	return createJSONResponse(http.StatusOK, dashboard)
+}
+
+// ============================================================
+// HandleGetAdminDashboard - GET /api/admin/dashboard
+// KPI toàn hệ thống (quyền dashboard.admin), cache 60 giây
+// ============================================================
+func (h *DashboardHandler) HandleGetAdminDashboard(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	if !permission.Has(ctx, permission.DashboardAdmin) {
+		return createMessageResponse(http.StatusForbidden, "Chỉ ADMIN mới có quyền xem dashboard hệ thống")
+	}
+
+	dashboard, err := h.useCase.GetAdminDashboard(ctx)
+	if err != nil {
+		return createMessageResponse(http.StatusInternalServerError, "Failed to load admin dashboard")
+	}
+
+	return createJSONResponse(http.StatusOK, dashboard)
+}
+
+// ============================================================
+// HandleGetCampusReports - GET /api/admin/reports/campuses
+// Báo cáo liên campus, chỉ super admin (quyền campus.manage, không gắn campus)
+// ============================================================
+func (h *DashboardHandler) HandleGetCampusReports(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	superAdmin, err := campus.IsSuperAdmin(ctx)
+	if err != nil {
+		return createMessageResponse(http.StatusInternalServerError, "Error checking campus")
+	}
+	if !superAdmin {
+		return createMessageResponse(http.StatusForbidden, "Chỉ super admin mới có quyền xem báo cáo liên campus")
+	}
+
+	reports, err := h.useCase.GetCampusReports(ctx)
+	if err != nil {
+		return createMessageResponse(http.StatusInternalServerError, "Failed to load campus reports")
+	}
+
+	return createJSONResponse(http.StatusOK, reports)
+}
+
+// ============================================================
+// HandleGetVenueUtilization - GET /api/admin/venues/utilization?from=&to=[&campusId=][&format=csv]
+// Mức sử dụng từng khu vực: giờ đặt, số sự kiện, tỉ lệ lấp đầy, ngày rảnh (quyền venue.manage)
+// Admin gắn campus chỉ xem được khu vực thuộc campus của mình
+// ============================================================
+func (h *DashboardHandler) HandleGetVenueUtilization(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	if !permission.Has(ctx, permission.VenueManage) {
+		return createMessageResponse(http.StatusForbidden, "Chỉ quản lý địa điểm mới có quyền xem báo cáo sử dụng")
+	}
+
+	requested, err := campus.ParseID(request.QueryStringParameters["campusId"])
+	if err != nil {
+		return createMessageResponse(http.StatusBadRequest, "campusId không hợp lệ")
+	}
+	campusID, err := campus.Resolve(ctx, requested)
+	if errors.Is(err, campus.ErrOutOfScope) {
+		return createMessageResponse(http.StatusForbidden, "Không có quyền xem campus này")
+	}
+	if err != nil {
+		return createMessageResponse(http.StatusInternalServerError, "Error checking campus")
+	}
+
+	report, err := h.useCase.GetVenueUtilization(ctx, request.QueryStringParameters["from"], request.QueryStringParameters["to"], campusID)
+	if errors.Is(err, usecase.ErrInvalidUtilizationRange) {
+		return createMessageResponse(http.StatusBadRequest, err.Error())
+	}
+	if err != nil {
+		log.Printf("[VENUE_UTILIZATION] failed: %v", err)
+		return createMessageResponse(http.StatusInternalServerError, "Failed to load venue utilization")
+	}
+
+	switch request.QueryStringParameters["format"] {
+	case "", "json":
+		return createJSONResponse(http.StatusOK, report)
+	case "csv":
+		data, err := usecase.UtilizationCSV(report)
+		if err != nil {
+			return createMessageResponse(http.StatusInternalServerError, "Failed to export venue utilization")
+		}
+		return events.APIGatewayProxyResponse{
+			StatusCode: http.StatusOK,
+			Headers: map[string]string{
+				"Content-Type":        "text/csv; charset=utf-8",
+				"Content-Disposition": fmt.Sprintf("attachment; filename=\"%s\"", usecase.UtilizationFileName(report)),
+				"Cache-Control":       "private, no-store",
+			},
+			Body:            base64.StdEncoding.EncodeToString(data),
+			IsBase64Encoded: true,
+		}, nil
+	}
+	return createMessageResponse(http.StatusBadRequest, "format must be json or csv")
+}
+
+func createJSONResponse(statusCode int, data interface{}) (events.APIGatewayProxyResponse, error) {
+	body, err := json.Marshal(data)
+	if err != nil {
+		return events.APIGatewayProxyResponse{
+			StatusCode: http.StatusInternalServerError,
+			Headers:    defaultHeaders(),
+			Body:       `{"message":"Failed to serialize response"}`,
+		}, nil
+	}
+
+	return events.APIGatewayProxyResponse{
+		StatusCode: statusCode,
+		Headers:    defaultHeaders(),
+		Body:       string(body),
+	}, nil
+}
+
+func createMessageResponse(statusCode int, message string) (events.APIGatewayProxyResponse, error) {
+	body, _ := json.Marshal(map[string]string{"message": message})
+	return events.APIGatewayProxyResponse{
+		StatusCode: statusCode,
+		Headers:    defaultHeaders(),
+		Body:       string(body),
+	}, nil
+}
+
+func defaultHeaders() map[string]string {
+	return map[string]string{
+		"Content-Type": "application/json;charset=UTF-8",
+	}
+}
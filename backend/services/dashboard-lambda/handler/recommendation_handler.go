@@ -0,0 +1,61 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/fpt-event-services/common/authctx"
+	"github.com/fpt-event-services/services/dashboard-lambda/usecase"
+)
+
+// ============================================================
+// HandleGetMyRecommendations - GET /api/me/recommendations?limit=
+// Sự kiện OPEN xếp theo độ giống lịch sử tham dự (job recommendation-scoring)
+// ============================================================
+func (h *DashboardHandler) HandleGetMyRecommendations(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	userID, ok := authctx.UserID(ctx)
+	if !ok {
+		return createMessageResponse(http.StatusUnauthorized, "Unauthorized")
+	}
+
+	limit := usecase.DefaultRecommendationLimit
+	if v := request.QueryStringParameters["limit"]; v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			return createMessageResponse(http.StatusBadRequest, "limit must be a positive integer")
+		}
+		limit = min(n, usecase.MaxRecommendationLimit)
+	}
+
+	recs, err := h.useCase.GetMyRecommendations(ctx, userID, limit)
+	if err != nil {
+		return createMessageResponse(http.StatusInternalServerError, "Failed to load recommendations")
+	}
+	return createJSONResponse(http.StatusOK, recs)
+}
+
+// ============================================================
+// HandleUpdateRecommendationSettings - PUT /api/me/recommendations
+// Body: {"optOut": true|false}; tắt gợi ý thì xóa luôn điểm đã tính của user
+// ============================================================
+func (h *DashboardHandler) HandleUpdateRecommendationSettings(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	userID, ok := authctx.UserID(ctx)
+	if !ok {
+		return createMessageResponse(http.StatusUnauthorized, "Unauthorized")
+	}
+
+	var body struct {
+		OptOut *bool `json:"optOut"`
+	}
+	if err := json.Unmarshal([]byte(request.Body), &body); err != nil || body.OptOut == nil {
+		return createMessageResponse(http.StatusBadRequest, "Body must be {\"optOut\": true|false}")
+	}
+
+	if err := h.useCase.SetRecommendationOptOut(ctx, userID, *body.OptOut); err != nil {
+		return createMessageResponse(http.StatusInternalServerError, "Failed to update recommendation setting")
+	}
+	return createJSONResponse(http.StatusOK, map[string]bool{"optedOut": *body.OptOut})
+}
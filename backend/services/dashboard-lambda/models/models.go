@@ -0,0 +1,211 @@
+package models
+
+import (
+	"time"
+
+	commonModels "github.com/fpt-event-services/common/models"
+	eventModels "github.com/fpt-event-services/services/event-lambda/models"
+	ticketModels "github.com/fpt-event-services/services/ticket-lambda/models"
+)
+
+// Tên các phần của dashboard (dùng trong Unavailable khi một phần lỗi)
+const (
+	SectionUpcomingTickets   = "upcomingTickets"
+	SectionWalletBalance     = "walletBalance"
+	SectionPendingReports    = "pendingReports"
+	SectionRecommendedEvents = "recommendedEvents"
+)
+
+// ============================================================
+// StudentDashboard - Dữ liệu màn hình chính của student
+// Dùng cho: GET /api/me/dashboard
+// Phần nào lỗi sẽ trả về rỗng và có tên trong Unavailable
+// ============================================================
+type StudentDashboard struct {
+	UpcomingTickets   []ticketModels.UpcomingTicket `json:"upcomingTickets"`
+	WalletBalance     float64                       `json:"walletBalance"`
+	PendingReports    []commonModels.Report         `json:"pendingReports"`
+	RecommendedEvents []eventModels.EventListItem   `json:"recommendedEvents"`
+	Unavailable       []string                      `json:"unavailable"`
+}
+
+// ============================================================
+// AdminDashboard - KPI toàn hệ thống cho ADMIN
+// Dùng cho: GET /api/admin/dashboard (cache 60 giây)
+// ============================================================
+type AdminDashboard struct {
+	EventsByStatus        map[string]int  `json:"eventsByStatus"`
+	PendingRequests       int             `json:"pendingRequests"`
+	TodayCheckIns         int             `json:"todayCheckIns"`
+	RevenueThisWeek       float64         `json:"revenueThisWeek"`
+	RevenueThisMonth      float64         `json:"revenueThisMonth"`
+	RefundsThisMonth      int             `json:"refundsThisMonth"`
+	RefundAmountThisMonth float64         `json:"refundAmountThisMonth"`
+	PlatformFeeThisMonth  float64         `json:"platformFeeThisMonth"` // Phí nền tảng của vé bán trừ vé hoàn tiền
+	TopEvents             []TopEventSales `json:"topEvents"`
+	RealtimeSince         *time.Time      `json:"realtimeSince,omitempty"` // Trước mốc này đọc Daily_Revenue / Daily_Event_Stats
+	GeneratedAt           time.Time       `json:"generatedAt"`
+}
+
+// TopEventSales - Sự kiện bán chạy (theo số vé đã bán)
+type TopEventSales struct {
+	EventID     int     `json:"eventId"`
+	Title       string  `json:"title"`
+	Status      string  `json:"status"`
+	TicketsSold int     `json:"ticketsSold"`
+	Revenue     float64 `json:"revenue"`
+}
+
+// ============================================================
+// CampusReport - Số liệu theo từng campus (super admin)
+// Dùng cho: GET /api/admin/reports/campuses
+// ============================================================
+type CampusReport struct {
+	CampusID    int     `json:"campusId"`
+	Code        string  `json:"code"`
+	CampusName  string  `json:"campusName"`
+	TotalEvents int     `json:"totalEvents"`
+	OpenEvents  int     `json:"openEvents"`
+	TicketsSold int     `json:"ticketsSold"` // Không tính vé đã hoàn tiền
+	Revenue     float64 `json:"revenue"`     // Doanh thu sau hoàn tiền
+	CheckIns    int     `json:"checkIns"`
+}
+
+// ============================================================
+// Recommendations - Sự kiện gợi ý theo lịch sử tham dự
+// Dùng cho: GET /api/me/recommendations (điểm do job recommendation-scoring tính mỗi đêm)
+// ============================================================
+type Recommendations struct {
+	OptedOut   bool               `json:"optedOut"`
+	ComputedAt *time.Time         `json:"computedAt,omitempty"`
+	Events     []RecommendedEvent `json:"events"`
+}
+
+// RecommendedEvent - Một sự kiện gợi ý; Reasons gồm các Reason* khớp với lịch sử tham dự
+type RecommendedEvent struct {
+	EventID       int      `json:"eventId"`
+	Title         string   `json:"title"`
+	StartTime     string   `json:"startTime"`
+	EndTime       string   `json:"endTime"`
+	BannerCardURL *string  `json:"bannerCardUrl"`
+	VenueName     *string  `json:"venueName"`
+	Slug          *string  `json:"slug,omitempty"`
+	Score         float64  `json:"score"`
+	Reasons       []string `json:"reasons"`
+}
+
+// Lý do gợi ý
+const (
+	ReasonCategory  = "CATEGORY"    // Cùng mẫu sự kiện với sự kiện đã tham dự
+	ReasonOrganizer = "ORGANIZER"   // Cùng ban tổ chức
+	ReasonTimeOfDay = "TIME_OF_DAY" // Cùng buổi (sáng / chiều / tối)
+)
+
+// RecommendationEvent - Đặc trưng của một sự kiện dùng để chấm điểm
+// (sự kiện đã tham dự hoặc sự kiện OPEN đang được xét gợi ý)
+type RecommendationEvent struct {
+	EventID     int
+	CategoryID  *int // event_template của Event_Request tạo ra sự kiện
+	OrganizerID *int
+	StartHour   int // Giờ bắt đầu (giờ Việt Nam, 0-23)
+	Popularity  int // Vé bán 14 ngày gần nhất (Daily_Event_Stats), chỉ có ở ứng viên
+}
+
+// EventRecommendation - Một dòng Event_Recommendation
+type EventRecommendation struct {
+	UserID  int
+	EventID int
+	Score   float64
+	Reasons []string
+}
+
+// ============================================================
+// VenueUtilization - Mức sử dụng từng khu vực (area) trong khoảng ngày
+// Dùng cho: GET /api/admin/venues/utilization?from=&to=[&campusId=][&format=csv]
+// ============================================================
+type VenueUtilization struct {
+	From          string            `json:"from"` // YYYY-MM-DD (giờ Việt Nam, tính cả ngày cuối)
+	To            string            `json:"to"`
+	Days          int               `json:"days"`
+	CampusID      *int              `json:"campusId,omitempty"`
+	Areas         []AreaUtilization `json:"areas"`                   // Khu vực ít được dùng nhất đứng đầu
+	RealtimeSince *time.Time        `json:"realtimeSince,omitempty"` // Trước mốc này vé bán đọc Daily_Event_Stats
+	GeneratedAt   time.Time         `json:"generatedAt"`
+}
+
+// AreaUtilization - Số liệu của một khu vực trong khoảng ngày
+type AreaUtilization struct {
+	AreaID          int     `json:"areaId"`
+	AreaName        string  `json:"areaName"`
+	Floor           *string `json:"floor"`
+	VenueName       string  `json:"venueName"`
+	CampusID        *int    `json:"campusId"`
+	Capacity        int     `json:"capacity"`
+	EventsHosted    int     `json:"eventsHosted"`
+	BookedHours     float64 `json:"bookedHours"`     // Giờ có sự kiện, chỉ phần nằm trong khoảng ngày
+	Utilization     float64 `json:"utilization"`     // bookedHours / (days * 24)
+	TicketsSold     int     `json:"ticketsSold"`     // Không tính vé đã hoàn tiền
+	AverageFillRate float64 `json:"averageFillRate"` // Trung bình vé bán / sức chứa theo sự kiện (0 nếu không có sự kiện)
+	IdleDays        int     `json:"idleDays"`        // Số ngày không có sự kiện nào
+}
+
+// UtilizationEvent - Một sự kiện đặt khu vực, dùng để tính AreaUtilization
+type UtilizationEvent struct {
+	EventID     int
+	AreaID      int
+	StartTime   time.Time
+	EndTime     time.Time
+	TicketsSold int
+}
+
+// ============================================================
+// AttendanceHistory - Sự kiện đã tham dự và điểm rèn luyện
+// Dùng cho: GET /api/me/attendance[?semester=FA26]
+// ============================================================
+type AttendanceHistory struct {
+	Semester    *string          `json:"semester,omitempty"` // Bộ lọc đang áp dụng
+	TotalPoints int              `json:"totalPoints"`
+	Semesters   []SemesterPoints `json:"semesters"` // Học kỳ mới nhất trước
+	Events      []AttendedEvent  `json:"events"`    // Sự kiện mới nhất trước
+}
+
+// SemesterPoints - Tổng điểm một học kỳ
+type SemesterPoints struct {
+	Semester string `json:"semester"` // SP26 / SU26 / FA26
+	Events   int    `json:"events"`
+	Points   int    `json:"points"`
+}
+
+// AttendedEvent - Một sự kiện user đã check-in (nhiều vé cùng sự kiện tính một lần)
+type AttendedEvent struct {
+	EventID      int        `json:"eventId"`
+	Title        string     `json:"title"`
+	StartTime    time.Time  `json:"startTime"`
+	EndTime      time.Time  `json:"endTime"`
+	CheckinTime  *time.Time `json:"checkinTime"`
+	CheckoutTime *time.Time `json:"checkoutTime"`
+	Points       int        `json:"points"`
+	Semester     string     `json:"semester"`
+}
+
+// ============================================================
+// AttendancePointsExport - Điểm rèn luyện theo sinh viên cho phòng CTSV
+// Dùng cho: GET /api/admin/attendance/points?semester=FA26[&campusId=][&format=csv]
+// ============================================================
+type AttendancePointsExport struct {
+	Semester    string          `json:"semester"`
+	From        string          `json:"from"` // YYYY-MM-DD, sự kiện bắt đầu trong [from, to]
+	To          string          `json:"to"`
+	CampusID    *int            `json:"campusId,omitempty"`
+	Students    []StudentPoints `json:"students"`
+	GeneratedAt time.Time       `json:"generatedAt"`
+}
+
+// StudentPoints - Số sự kiện đã tham dự và tổng điểm của một sinh viên trong học kỳ
+type StudentPoints struct {
+	UserID   int    `json:"userId"`
+	FullName string `json:"fullName"`
+	Email    string `json:"email"`
+	Events   int    `json:"events"`
+	Points   int    `json:"points"`
+}
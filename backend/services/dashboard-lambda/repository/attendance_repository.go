@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/fpt-event-services/services/dashboard-lambda/models"
+)
+
+// ============================================================
+// Lịch sử tham dự và điểm rèn luyện (migration 050)
+// Tham dự = vé CHECKED_IN / CHECKED_OUT, đọc cả Ticket_Archive để học kỳ cũ
+// không mất điểm khi job data-retention lưu trữ vé. Điểm lấy từ Event.activity_points
+// lúc truy vấn; nhiều vé cùng sự kiện chỉ tính một lần
+// ============================================================
+
+// attendedTicketsSQL - (user_id, event_id, checkin_time, check_out_time) của mọi vé đã check-in
+const attendedTicketsSQL = `
+	SELECT user_id, event_id, checkin_time, check_out_time FROM Ticket
+	WHERE status IN ('CHECKED_IN', 'CHECKED_OUT')
+	UNION ALL
+	SELECT user_id, event_id, checkin_time, check_out_time FROM Ticket_Archive
+	WHERE status IN ('CHECKED_IN', 'CHECKED_OUT')`
+
+// AttendedEvents - Sự kiện user đã check-in, mới nhất trước (Semester do usecase điền)
+func (r *DashboardRepository) AttendedEvents(ctx context.Context, userID int) ([]models.AttendedEvent, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT e.event_id, e.title, e.start_time, e.end_time, MIN(a.checkin_time), MAX(a.check_out_time), e.activity_points
+		FROM (`+attendedTicketsSQL+`) a
+		JOIN Event e ON e.event_id = a.event_id
+		WHERE a.user_id = ?
+		GROUP BY e.event_id, e.title, e.start_time, e.end_time, e.activity_points
+		ORDER BY e.start_time DESC, e.event_id DESC
+	`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query attended events: %w", err)
+	}
+	defer rows.Close()
+
+	attended := []models.AttendedEvent{}
+	for rows.Next() {
+		var a models.AttendedEvent
+		var checkin, checkout sql.NullTime
+		if err := rows.Scan(&a.EventID, &a.Title, &a.StartTime, &a.EndTime, &checkin, &checkout, &a.Points); err != nil {
+			return nil, fmt.Errorf("failed to scan attended event: %w", err)
+		}
+		if checkin.Valid {
+			a.CheckinTime = &checkin.Time
+		}
+		if checkout.Valid {
+			a.CheckoutTime = &checkout.Time
+		}
+		attended = append(attended, a)
+	}
+	return attended, rows.Err()
+}
+
+// StudentPoints - Tổng điểm theo sinh viên (role STUDENT) của các sự kiện bắt đầu trong [from, to)
+// campusID nil = mọi campus. Sinh viên tham dự nhưng chỉ toàn sự kiện 0 điểm vẫn được liệt kê
+func (r *DashboardRepository) StudentPoints(ctx context.Context, from, to time.Time, campusID *int) ([]models.StudentPoints, error) {
+	query := `
+		SELECT u.user_id, u.full_name, u.email, COUNT(*), COALESCE(SUM(e.activity_points), 0)
+		FROM (SELECT DISTINCT user_id, event_id FROM (` + attendedTicketsSQL + `) a) x
+		JOIN Event e ON e.event_id = x.event_id
+		JOIN Users u ON u.user_id = x.user_id
+		WHERE u.role = 'STUDENT' AND e.start_time >= ? AND e.start_time < ?`
+	args := []interface{}{from, to}
+	if campusID != nil {
+		query += ` AND u.campus_id = ?`
+		args = append(args, *campusID)
+	}
+	query += `
+		GROUP BY u.user_id, u.full_name, u.email
+		ORDER BY u.user_id`
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query student points: %w", err)
+	}
+	defer rows.Close()
+
+	students := []models.StudentPoints{}
+	for rows.Next() {
+		var s models.StudentPoints
+		if err := rows.Scan(&s.UserID, &s.FullName, &s.Email, &s.Events, &s.Points); err != nil {
+			return nil, fmt.Errorf("failed to scan student points: %w", err)
+		}
+		students = append(students, s)
+	}
+	return students, rows.Err()
+}
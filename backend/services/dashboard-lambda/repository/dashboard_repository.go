@@ -0,0 +1,279 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/fpt-event-services/common/db"
+	"github.com/fpt-event-services/services/dashboard-lambda/models"
+	eventRepo "github.com/fpt-event-services/services/event-lambda/repository"
+)
+
+// statsTicketStatuses - Vé được tính vào doanh thu gộp (khớp Daily_Revenue)
+const statsTicketStatuses = "'BOOKED', 'CHECKED_IN', 'CHECKED_OUT', 'REFUNDED'"
+
+// Đầu tuần (thứ Hai) / đầu tháng theo ngày của DB
+const (
+	weekStartSQL  = "(CURDATE() - INTERVAL WEEKDAY(CURDATE()) DAY)"
+	monthStartSQL = "CAST(DATE_FORMAT(CURDATE(), '%Y-%m-01') AS DATE)"
+)
+
+// DashboardRepository chạy các truy vấn gom nhóm cho dashboard admin
+type DashboardRepository struct {
+	db *sql.DB
+}
+
+// NewDashboardRepository creates a new dashboard repository
+func NewDashboardRepository() *DashboardRepository {
+	return &DashboardRepository{
+		db: db.GetDB(),
+	}
+}
+
+// DefaultDashboardRepository - Repository dùng chung của process
+var DefaultDashboardRepository = sync.OnceValue(NewDashboardRepository)
+
+// CountEventsByStatus - Số sự kiện theo từng trạng thái (một lần GROUP BY)
+func (r *DashboardRepository) CountEventsByStatus(ctx context.Context) (map[string]int, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT status, COUNT(*) FROM Event GROUP BY status`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count events by status: %w", err)
+	}
+	defer rows.Close()
+
+	counts := map[string]int{}
+	for rows.Next() {
+		var status string
+		var n int
+		if err := rows.Scan(&status, &n); err != nil {
+			return nil, fmt.Errorf("failed to scan event count: %w", err)
+		}
+		counts[status] = n
+	}
+	return counts, rows.Err()
+}
+
+// ============================================================
+// GetActivityCounters - Các chỉ số đếm trong một truy vấn:
+// request đang chờ duyệt, lượt check-in hôm nay, doanh thu tuần/tháng,
+// refund đã duyệt và phí nền tảng trong tháng
+// Doanh thu/refund/phí: các ngày trước since đọc Daily_Revenue, từ since tính trực tiếp
+// Doanh thu là doanh thu gộp của vé bán trong kỳ (kể cả vé hoàn tiền sau đó, tiền hoàn nằm ở refund)
+// Giá và phí lấy từ Bill_Item (khớp EventActivitySQL)
+// ============================================================
+func (r *DashboardRepository) GetActivityCounters(ctx context.Context, d *models.AdminDashboard, since time.Time) error {
+	sinceDay := since.Format("2006-01-02")
+	err := r.db.QueryRowContext(ctx, `
+		SELECT
+			(SELECT COUNT(*) FROM Event_Request WHERE status = 'PENDING'),
+			(SELECT COUNT(*) FROM Ticket
+			  WHERE checkin_time >= CURDATE() AND checkin_time < CURDATE() + INTERVAL 1 DAY),
+			(SELECT COALESCE(SUM(revenue), 0) FROM Daily_Revenue
+			  WHERE stat_date >= `+weekStartSQL+` AND stat_date < ?)
+			+ (SELECT COALESCE(SUM(CASE WHEN t.is_complimentary = 0 THEN COALESCE(bi.unit_price, ct.price) END), 0)
+			   FROM Ticket t JOIN Category_Ticket ct ON t.category_ticket_id = ct.category_ticket_id
+			   LEFT JOIN Bill_Item bi ON bi.ticket_id = t.ticket_id
+			  WHERE t.status IN (`+statsTicketStatuses+`)
+			    AND t.created_at >= GREATEST(`+weekStartSQL+`, CAST(? AS DATE))),
+			(SELECT COALESCE(SUM(revenue), 0) FROM Daily_Revenue
+			  WHERE stat_date >= `+monthStartSQL+` AND stat_date < ?)
+			+ (SELECT COALESCE(SUM(CASE WHEN t.is_complimentary = 0 THEN COALESCE(bi.unit_price, ct.price) END), 0)
+			   FROM Ticket t JOIN Category_Ticket ct ON t.category_ticket_id = ct.category_ticket_id
+			   LEFT JOIN Bill_Item bi ON bi.ticket_id = t.ticket_id
+			  WHERE t.status IN (`+statsTicketStatuses+`)
+			    AND t.created_at >= GREATEST(`+monthStartSQL+`, CAST(? AS DATE))),
+			(SELECT COALESCE(SUM(refunds), 0) FROM Daily_Revenue
+			  WHERE stat_date >= `+monthStartSQL+` AND stat_date < ?)
+			+ (SELECT COUNT(*) FROM Report
+			  WHERE status = 'APPROVED' AND processed_at >= GREATEST(`+monthStartSQL+`, CAST(? AS DATE))),
+			(SELECT COALESCE(SUM(refund_amount), 0) FROM Daily_Revenue
+			  WHERE stat_date >= `+monthStartSQL+` AND stat_date < ?)
+			+ (SELECT COALESCE(SUM(refund_amount), 0) FROM Report
+			  WHERE status = 'APPROVED' AND processed_at >= GREATEST(`+monthStartSQL+`, CAST(? AS DATE))),
+			(SELECT COALESCE(SUM(platform_fee), 0) FROM Daily_Revenue
+			  WHERE stat_date >= `+monthStartSQL+` AND stat_date < ?)
+			+ (SELECT COALESCE(SUM(bi.fee_amount), 0)
+			   FROM Bill_Item bi JOIN Ticket t ON t.ticket_id = bi.ticket_id
+			  WHERE t.status IN (`+statsTicketStatuses+`)
+			    AND t.created_at >= GREATEST(`+monthStartSQL+`, CAST(? AS DATE)))
+			- (SELECT COALESCE(SUM(bi.fee_amount), 0)
+			   FROM Report rp JOIN Bill_Item bi ON bi.ticket_id = rp.ticket_id
+			  WHERE rp.status = 'APPROVED' AND rp.processed_at >= GREATEST(`+monthStartSQL+`, CAST(? AS DATE)))
+	`, sinceDay, sinceDay, sinceDay, sinceDay, sinceDay, sinceDay, sinceDay, sinceDay,
+		sinceDay, sinceDay, sinceDay).Scan(
+		&d.PendingRequests, &d.TodayCheckIns, &d.RevenueThisWeek, &d.RevenueThisMonth,
+		&d.RefundsThisMonth, &d.RefundAmountThisMonth, &d.PlatformFeeThisMonth)
+	if err != nil {
+		return fmt.Errorf("failed to load activity counters: %w", err)
+	}
+	return nil
+}
+
+// TopEventsBySales - Các sự kiện bán được nhiều vé nhất (không tính vé đã hoàn tiền)
+// Daily_Event_Stats trước since + hoạt động realtime từ since
+func (r *DashboardRepository) TopEventsBySales(ctx context.Context, since time.Time, limit int) ([]models.TopEventSales, error) {
+	args := append([]interface{}{since}, eventRepo.RealtimeActivityArgs(since)...)
+	args = append(args, limit)
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT e.event_id, e.title, e.status,
+		       SUM(x.sold) - SUM(x.refunds) AS sold,
+		       SUM(x.revenue) - SUM(x.refund_amount) AS revenue
+		FROM (
+			SELECT d.event_id, d.tickets_sold AS sold, d.complimentary AS comp, d.revenue,
+			       d.check_ins, d.check_outs, d.refunds, d.refund_amount, d.platform_fee AS fee
+			FROM Daily_Event_Stats d WHERE d.stat_date < ?
+			UNION ALL
+			`+eventRepo.EventActivitySQL+`
+		) x
+		JOIN Event e ON x.event_id = e.event_id
+		GROUP BY e.event_id, e.title, e.status
+		HAVING sold > 0
+		ORDER BY sold DESC, revenue DESC
+		LIMIT ?
+	`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query top events: %w", err)
+	}
+	defer rows.Close()
+
+	events := []models.TopEventSales{}
+	for rows.Next() {
+		var e models.TopEventSales
+		if err := rows.Scan(&e.EventID, &e.Title, &e.Status, &e.TicketsSold, &e.Revenue); err != nil {
+			return nil, fmt.Errorf("failed to scan top event: %w", err)
+		}
+		events = append(events, e)
+	}
+	return events, rows.Err()
+}
+
+// ============================================================
+// CampusReports - Sự kiện, vé bán, doanh thu, check-in theo campus
+// Daily_Event_Stats trước since + hoạt động realtime từ since (giống TopEventsBySales)
+// Sự kiện chưa gắn campus không được tính
+// ============================================================
+func (r *DashboardRepository) CampusReports(ctx context.Context, since time.Time) ([]models.CampusReport, error) {
+	args := append([]interface{}{since}, eventRepo.RealtimeActivityArgs(since)...)
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT c.campus_id, c.code, c.campus_name,
+		       (SELECT COUNT(*) FROM Event e WHERE e.campus_id = c.campus_id),
+		       (SELECT COUNT(*) FROM Event e WHERE e.campus_id = c.campus_id AND e.status = 'OPEN'),
+		       COALESCE(s.sold, 0), COALESCE(s.revenue, 0), COALESCE(s.check_ins, 0)
+		FROM Campus c
+		LEFT JOIN (
+			SELECT e.campus_id,
+			       SUM(x.sold) - SUM(x.refunds) AS sold,
+			       SUM(x.revenue) - SUM(x.refund_amount) AS revenue,
+			       SUM(x.check_ins) AS check_ins
+			FROM (
+				SELECT d.event_id, d.tickets_sold AS sold, d.complimentary AS comp, d.revenue,
+				       d.check_ins, d.check_outs, d.refunds, d.refund_amount, d.platform_fee AS fee
+				FROM Daily_Event_Stats d WHERE d.stat_date < ?
+				UNION ALL
+				`+eventRepo.EventActivitySQL+`
+			) x
+			JOIN Event e ON x.event_id = e.event_id
+			WHERE e.campus_id IS NOT NULL
+			GROUP BY e.campus_id
+		) s ON s.campus_id = c.campus_id
+		ORDER BY c.campus_id
+	`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query campus reports: %w", err)
+	}
+	defer rows.Close()
+
+	reports := []models.CampusReport{}
+	for rows.Next() {
+		var c models.CampusReport
+		if err := rows.Scan(&c.CampusID, &c.Code, &c.CampusName, &c.TotalEvents, &c.OpenEvents,
+			&c.TicketsSold, &c.Revenue, &c.CheckIns); err != nil {
+			return nil, fmt.Errorf("failed to scan campus report: %w", err)
+		}
+		reports = append(reports, c)
+	}
+	return reports, rows.Err()
+}
+
+// ============================================================
+// UtilizationAreas - Các khu vực của venue chưa xóa (campusID nil = mọi campus)
+// ============================================================
+func (r *DashboardRepository) UtilizationAreas(ctx context.Context, campusID *int) ([]models.AreaUtilization, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT va.area_id, va.area_name, va.floor, v.venue_name, v.campus_id, va.capacity
+		FROM Venue_Area va
+		JOIN Venue v ON v.venue_id = va.venue_id
+		WHERE v.status != 'DELETED' AND (? IS NULL OR v.campus_id = ?)
+		ORDER BY va.area_id
+	`, campusID, campusID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query venue areas: %w", err)
+	}
+	defer rows.Close()
+
+	areas := []models.AreaUtilization{}
+	for rows.Next() {
+		var a models.AreaUtilization
+		var floor sql.NullString
+		var campus sql.NullInt64
+		if err := rows.Scan(&a.AreaID, &a.AreaName, &floor, &a.VenueName, &campus, &a.Capacity); err != nil {
+			return nil, fmt.Errorf("failed to scan venue area: %w", err)
+		}
+		if floor.Valid {
+			a.Floor = &floor.String
+		}
+		if campus.Valid {
+			id := int(campus.Int64)
+			a.CampusID = &id
+		}
+		areas = append(areas, a)
+	}
+	return areas, rows.Err()
+}
+
+// ============================================================
+// UtilizationEvents - Sự kiện (chưa hủy) có khu vực và diễn ra giao với [from, to)
+// Vé bán của cả sự kiện: Daily_Event_Stats trước since + hoạt động realtime từ since
+// (giống TopEventsBySales), không tính vé đã hoàn tiền
+// ============================================================
+func (r *DashboardRepository) UtilizationEvents(ctx context.Context, from, to, since time.Time, campusID *int) ([]models.UtilizationEvent, error) {
+	args := append([]interface{}{since}, eventRepo.RealtimeActivityArgs(since)...)
+	args = append(args, to, from, campusID, campusID)
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT e.event_id, e.area_id, e.start_time, e.end_time, COALESCE(s.sold, 0)
+		FROM Event e
+		JOIN Venue_Area va ON va.area_id = e.area_id
+		JOIN Venue v ON v.venue_id = va.venue_id
+		LEFT JOIN (
+			SELECT x.event_id, SUM(x.sold) - SUM(x.refunds) AS sold
+			FROM (
+				SELECT d.event_id, d.tickets_sold AS sold, d.complimentary AS comp, d.revenue,
+				       d.check_ins, d.check_outs, d.refunds, d.refund_amount, d.platform_fee AS fee
+				FROM Daily_Event_Stats d WHERE d.stat_date < ?
+				UNION ALL
+				`+eventRepo.EventActivitySQL+`
+			) x
+			GROUP BY x.event_id
+		) s ON s.event_id = e.event_id
+		WHERE e.status != 'CANCELLED' AND e.start_time < ? AND e.end_time > ?
+		  AND (? IS NULL OR v.campus_id = ?)
+		ORDER BY e.area_id, e.start_time
+	`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query area events: %w", err)
+	}
+	defer rows.Close()
+
+	events := []models.UtilizationEvent{}
+	for rows.Next() {
+		var e models.UtilizationEvent
+		if err := rows.Scan(&e.EventID, &e.AreaID, &e.StartTime, &e.EndTime, &e.TicketsSold); err != nil {
+			return nil, fmt.Errorf("failed to scan area event: %w", err)
+		}
+		events = append(events, e)
+	}
+	return events, rows.Err()
+}
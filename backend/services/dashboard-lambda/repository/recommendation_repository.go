@@ -0,0 +1,184 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/fpt-event-services/common/dbscan"
+	"github.com/fpt-event-services/services/dashboard-lambda/models"
+)
+
+// ============================================================
+// Gợi ý sự kiện (bảng Event_Recommendation, migration 038)
+// Job recommendation-scoring đọc lịch sử check-in + Daily_Event_Stats và ghi lại toàn bộ bảng;
+// GET /api/me/recommendations chỉ đọc điểm đã tính và lọc lại sự kiện còn OPEN / chưa có vé
+// ============================================================
+
+// eventCategorySQL - "Loại" của sự kiện (alias e): mẫu sự kiện của request tạo ra nó (NULL = không dùng mẫu)
+const eventCategorySQL = `(SELECT MAX(er.template_id) FROM Event_Request er WHERE er.created_event_id = e.event_id)`
+
+// recommendationInsertBatch - Số dòng mỗi câu INSERT khi ghi lại bảng
+const recommendationInsertBatch = 200
+
+// LoadAttendanceHistory - Các sự kiện mỗi student đã check-in từ since (theo user_id)
+// Bỏ qua user đã tắt gợi ý hoặc đã bị ẩn danh hóa
+func (r *DashboardRepository) LoadAttendanceHistory(ctx context.Context, since time.Time) (map[int][]models.RecommendationEvent, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT DISTINCT t.user_id, e.event_id, `+eventCategorySQL+`, e.created_by, HOUR(e.start_time)
+		FROM Ticket t
+		JOIN Users u ON u.user_id = t.user_id
+		JOIN Event e ON e.event_id = t.event_id
+		WHERE t.checkin_time IS NOT NULL AND t.checkin_time >= ?
+		  AND u.recommendations_opt_out = 0 AND u.anonymized_at IS NULL
+	`, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load attendance history: %w", err)
+	}
+	defer rows.Close()
+
+	history := map[int][]models.RecommendationEvent{}
+	for rows.Next() {
+		var userID int
+		var e models.RecommendationEvent
+		if err := rows.Scan(&userID, &e.EventID, &e.CategoryID, &e.OrganizerID, &e.StartHour); err != nil {
+			return nil, fmt.Errorf("failed to scan attendance: %w", err)
+		}
+		history[userID] = append(history[userID], e)
+	}
+	return history, rows.Err()
+}
+
+// LoadRecommendationCandidates - Sự kiện OPEN chưa bắt đầu, kèm số vé bán 14 ngày gần nhất từ Daily_Event_Stats
+func (r *DashboardRepository) LoadRecommendationCandidates(ctx context.Context) ([]models.RecommendationEvent, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT e.event_id, `+eventCategorySQL+`, e.created_by, HOUR(e.start_time),
+		       COALESCE((SELECT SUM(d.tickets_sold) FROM Daily_Event_Stats d
+		                 WHERE d.event_id = e.event_id AND d.stat_date >= CURDATE() - INTERVAL 14 DAY), 0)
+		FROM Event e
+		WHERE e.status = 'OPEN' AND e.start_time > NOW()
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load recommendation candidates: %w", err)
+	}
+	defer rows.Close()
+
+	var candidates []models.RecommendationEvent
+	for rows.Next() {
+		var e models.RecommendationEvent
+		if err := rows.Scan(&e.EventID, &e.CategoryID, &e.OrganizerID, &e.StartHour, &e.Popularity); err != nil {
+			return nil, fmt.Errorf("failed to scan recommendation candidate: %w", err)
+		}
+		candidates = append(candidates, e)
+	}
+	return candidates, rows.Err()
+}
+
+// ReplaceRecommendations - Ghi lại toàn bộ Event_Recommendation trong một transaction
+// (request đọc giữa chừng vẫn thấy bảng cũ cho đến khi commit)
+func (r *DashboardRepository) ReplaceRecommendations(ctx context.Context, recs []models.EventRecommendation) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM Event_Recommendation`); err != nil {
+		return fmt.Errorf("failed to clear recommendations: %w", err)
+	}
+	for start := 0; start < len(recs); start += recommendationInsertBatch {
+		batch := recs[start:min(start+recommendationInsertBatch, len(recs))]
+		args := make([]any, 0, len(batch)*4)
+		for _, rec := range batch {
+			args = append(args, rec.UserID, rec.EventID, rec.Score, strings.Join(rec.Reasons, ","))
+		}
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO Event_Recommendation (user_id, event_id, score, reasons)
+			VALUES (?, ?, ?, ?)`+strings.Repeat(", (?, ?, ?, ?)", len(batch)-1), args...)
+		if err != nil {
+			return fmt.Errorf("failed to insert recommendations: %w", err)
+		}
+	}
+	return tx.Commit()
+}
+
+// GetRecommendations - Gợi ý của user theo điểm giảm dần; chỉ sự kiện còn OPEN, chưa bắt đầu
+// và user chưa giữ vé. Trả kèm thời điểm job tính điểm (nil = chưa có gợi ý)
+func (r *DashboardRepository) GetRecommendations(ctx context.Context, userID, limit int) ([]models.RecommendedEvent, *time.Time, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT e.event_id, e.title, e.start_time, e.end_time, e.banner_card_url, v.venue_name, e.slug,
+		       rec.score, rec.reasons, rec.computed_at
+		FROM Event_Recommendation rec
+		JOIN Event e ON e.event_id = rec.event_id
+		LEFT JOIN Venue_Area va ON va.area_id = e.area_id
+		LEFT JOIN Venue v ON v.venue_id = va.venue_id
+		WHERE rec.user_id = ? AND e.status = 'OPEN' AND e.start_time > NOW()
+		  AND NOT EXISTS (SELECT 1 FROM Ticket t
+		                  WHERE t.event_id = e.event_id AND t.user_id = rec.user_id
+		                    AND t.status IN ('PENDING', 'BOOKED', 'CHECKED_IN', 'CHECKED_OUT'))
+		ORDER BY rec.score DESC, e.start_time
+		LIMIT ?
+	`, userID, limit)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load recommendations: %w", err)
+	}
+	defer rows.Close()
+
+	events := []models.RecommendedEvent{}
+	var computedAt *time.Time
+	for rows.Next() {
+		var e models.RecommendedEvent
+		var reasons string
+		var at time.Time
+		if err := rows.Scan(
+			&e.EventID, &e.Title, dbscan.RFC3339(&e.StartTime), dbscan.RFC3339(&e.EndTime),
+			&e.BannerCardURL, &e.VenueName, &e.Slug,
+			&e.Score, &reasons, &at,
+		); err != nil {
+			return nil, nil, fmt.Errorf("failed to scan recommendation: %w", err)
+		}
+		e.Reasons = []string{}
+		if reasons != "" {
+			e.Reasons = strings.Split(reasons, ",")
+		}
+		if computedAt == nil {
+			computedAt = &at
+		}
+		events = append(events, e)
+	}
+	return events, computedAt, rows.Err()
+}
+
+// GetRecommendationOptOut - users.recommendations_opt_out
+func (r *DashboardRepository) GetRecommendationOptOut(ctx context.Context, userID int) (bool, error) {
+	var optOut bool
+	err := r.db.QueryRowContext(ctx,
+		`SELECT recommendations_opt_out FROM Users WHERE user_id = ?`, userID,
+	).Scan(&optOut)
+	if err != nil {
+		return false, fmt.Errorf("failed to load recommendation setting: %w", err)
+	}
+	return optOut, nil
+}
+
+// SetRecommendationOptOut - Bật / tắt gợi ý; tắt thì xóa luôn điểm đã tính của user
+func (r *DashboardRepository) SetRecommendationOptOut(ctx context.Context, userID int, optOut bool) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE Users SET recommendations_opt_out = ? WHERE user_id = ?`, optOut, userID,
+	); err != nil {
+		return fmt.Errorf("failed to update recommendation setting: %w", err)
+	}
+	if optOut {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM Event_Recommendation WHERE user_id = ?`, userID); err != nil {
+			return fmt.Errorf("failed to clear recommendations: %w", err)
+		}
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,153 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fpt-event-services/services/dashboard-lambda/models"
+	eventRepo "github.com/fpt-event-services/services/event-lambda/repository"
+)
+
+// ============================================================
+// Lịch sử tham dự và điểm rèn luyện
+// Học kỳ theo lịch FPT, suy ra từ giờ bắt đầu sự kiện (giờ Việt Nam):
+// SP = tháng 1-4, SU = tháng 5-8, FA = tháng 9-12, kèm 2 số cuối của năm (FA26)
+// ============================================================
+
+// ErrInvalidSemester - Mã học kỳ không đúng dạng SP26 / SU26 / FA26
+var ErrInvalidSemester = errors.New("semester must look like SP26, SU26 or FA26")
+
+// semesterTerms - Mã kỳ theo thứ tự trong năm, mỗi kỳ 4 tháng
+var semesterTerms = [...]string{"SP", "SU", "FA"}
+
+// Semester - Mã học kỳ chứa thời điểm t
+func Semester(t time.Time) string {
+	day := eventRepo.StatsDay(t)
+	return fmt.Sprintf("%s%02d", semesterTerms[(int(day.Month())-1)/4], day.Year()%100)
+}
+
+// parseSemester chuẩn hóa mã học kỳ và trả về khoảng [from, to) của nó
+func parseSemester(code string) (string, time.Time, time.Time, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if len(code) != 4 {
+		return "", time.Time{}, time.Time{}, ErrInvalidSemester
+	}
+	year, err := strconv.Atoi(code[2:])
+	if err != nil || year < 0 {
+		return "", time.Time{}, time.Time{}, ErrInvalidSemester
+	}
+	for i, term := range semesterTerms {
+		if code[:2] != term {
+			continue
+		}
+		// Giữa trưa UTC vẫn là cùng ngày ở giờ Việt Nam; StatsDay đưa về 00:00 giờ Việt Nam
+		start := time.Date(2000+year, time.Month(i*4+1), 1, 12, 0, 0, 0, time.UTC)
+		return code, eventRepo.StatsDay(start), eventRepo.StatsDay(start.AddDate(0, 4, 0)), nil
+	}
+	return "", time.Time{}, time.Time{}, ErrInvalidSemester
+}
+
+// ============================================================
+// GetMyAttendance - Sự kiện đã check-in, điểm theo học kỳ và tổng điểm
+// semester rỗng = mọi học kỳ; có semester thì events / totalPoints chỉ tính kỳ đó,
+// còn semesters luôn liệt kê đủ để frontend làm bộ chọn kỳ
+// ============================================================
+func (uc *DashboardUseCase) GetMyAttendance(ctx context.Context, userID int, semester string) (*models.AttendanceHistory, error) {
+	var filter *string
+	if semester != "" {
+		code, _, _, err := parseSemester(semester)
+		if err != nil {
+			return nil, err
+		}
+		filter = &code
+	}
+
+	attended, err := uc.statsRepo.AttendedEvents(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return summarizeAttendance(attended, filter), nil
+}
+
+// summarizeAttendance gán học kỳ cho từng sự kiện (đã sắp mới nhất trước) và cộng điểm
+func summarizeAttendance(attended []models.AttendedEvent, filter *string) *models.AttendanceHistory {
+	history := &models.AttendanceHistory{
+		Semester:  filter,
+		Semesters: []models.SemesterPoints{},
+		Events:    []models.AttendedEvent{},
+	}
+	index := map[string]int{}
+	for _, a := range attended {
+		a.Semester = Semester(a.StartTime)
+		i, ok := index[a.Semester]
+		if !ok {
+			i = len(history.Semesters)
+			index[a.Semester] = i
+			history.Semesters = append(history.Semesters, models.SemesterPoints{Semester: a.Semester})
+		}
+		history.Semesters[i].Events++
+		history.Semesters[i].Points += a.Points
+
+		if filter != nil && *filter != a.Semester {
+			continue
+		}
+		history.Events = append(history.Events, a)
+		history.TotalPoints += a.Points
+	}
+	return history
+}
+
+// ============================================================
+// GetAttendancePoints - Điểm rèn luyện theo sinh viên của một học kỳ (mặc định kỳ hiện tại)
+// Không cache, giống GetVenueUtilization
+// ============================================================
+func (uc *DashboardUseCase) GetAttendancePoints(ctx context.Context, semester string, campusID *int) (*models.AttendancePointsExport, error) {
+	if semester == "" {
+		semester = Semester(time.Now())
+	}
+	code, from, to, err := parseSemester(semester)
+	if err != nil {
+		return nil, err
+	}
+
+	students, err := uc.statsRepo.StudentPoints(ctx, from, to, campusID)
+	if err != nil {
+		return nil, err
+	}
+	return &models.AttendancePointsExport{
+		Semester:    code,
+		From:        from.Format("2006-01-02"),
+		To:          to.AddDate(0, 0, -1).Format("2006-01-02"),
+		CampusID:    campusID,
+		Students:    students,
+		GeneratedAt: time.Now(),
+	}, nil
+}
+
+// AttendancePointsFileName - Tên file CSV khi tải về
+func AttendancePointsFileName(e *models.AttendancePointsExport) string {
+	return fmt.Sprintf("activity_points_%s.csv", e.Semester)
+}
+
+// AttendancePointsCSV - Mỗi dòng một sinh viên, cùng thứ tự với JSON
+func AttendancePointsCSV(e *models.AttendancePointsExport) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	rows := [][]string{{"User ID", "Full name", "Email", "Semester", "Events attended", "Activity points"}}
+	for _, s := range e.Students {
+		rows = append(rows, []string{
+			strconv.Itoa(s.UserID), s.FullName, s.Email, e.Semester,
+			strconv.Itoa(s.Events), strconv.Itoa(s.Points),
+		})
+	}
+	if err := w.WriteAll(rows); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
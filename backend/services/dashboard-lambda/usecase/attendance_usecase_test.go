@@ -0,0 +1,79 @@
+package usecase
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fpt-event-services/services/dashboard-lambda/models"
+)
+
+func TestSemester(t *testing.T) {
+	cases := map[string]time.Time{
+		"SP26": time.Date(2026, 4, 30, 12, 0, 0, 0, time.UTC),
+		"SU26": time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC),
+		"FA26": time.Date(2026, 12, 31, 12, 0, 0, 0, time.UTC),
+		// 31/12 20:00 UTC đã là 01/01 giờ Việt Nam
+		"SP27": time.Date(2026, 12, 31, 20, 0, 0, 0, time.UTC),
+	}
+	for want, at := range cases {
+		if got := Semester(at); got != want {
+			t.Errorf("Semester(%v) = %s, want %s", at, got, want)
+		}
+	}
+}
+
+func TestParseSemester(t *testing.T) {
+	code, from, to, err := parseSemester(" fa26 ")
+	if err != nil || code != "FA26" || from.Format("2006-01-02") != "2026-09-01" || to.Format("2006-01-02") != "2027-01-01" {
+		t.Fatalf("parseSemester = %s %v..%v, %v", code, from, to, err)
+	}
+	if Semester(from) != "FA26" || Semester(to.Add(-time.Second)) != "FA26" || Semester(to) != "SP27" {
+		t.Errorf("range %v..%v does not match Semester", from, to)
+	}
+
+	for _, in := range []string{"", "FA", "FA2026", "WI26", "SP-1", "2026"} {
+		if _, _, _, err := parseSemester(in); !errors.Is(err, ErrInvalidSemester) {
+			t.Errorf("parseSemester(%q) err = %v", in, err)
+		}
+	}
+}
+
+func TestSummarizeAttendance(t *testing.T) {
+	at := func(m time.Month) time.Time { return time.Date(2026, m, 10, 3, 0, 0, 0, time.UTC) }
+	attended := []models.AttendedEvent{
+		{EventID: 3, StartTime: at(10), Points: 5},
+		{EventID: 2, StartTime: at(9), Points: 0},
+		{EventID: 1, StartTime: at(2), Points: 3},
+	}
+
+	all := summarizeAttendance(attended, nil)
+	if all.TotalPoints != 8 || len(all.Events) != 3 || all.Events[2].Semester != "SP26" {
+		t.Fatalf("unexpected history: %+v", all)
+	}
+	want := []models.SemesterPoints{{Semester: "FA26", Events: 2, Points: 5}, {Semester: "SP26", Events: 1, Points: 3}}
+	if len(all.Semesters) != 2 || all.Semesters[0] != want[0] || all.Semesters[1] != want[1] {
+		t.Fatalf("semesters = %+v", all.Semesters)
+	}
+
+	fa := "FA26"
+	filtered := summarizeAttendance(attended, &fa)
+	if filtered.TotalPoints != 5 || len(filtered.Events) != 2 || len(filtered.Semesters) != 2 {
+		t.Fatalf("filtered history = %+v", filtered)
+	}
+}
+
+func TestAttendancePointsCSV(t *testing.T) {
+	data, err := AttendancePointsCSV(&models.AttendancePointsExport{
+		Semester: "FA26",
+		Students: []models.StudentPoints{{UserID: 7, FullName: "Nguyễn Văn A", Email: "a@fpt.edu.vn", Events: 2, Points: 8}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 2 || lines[1] != "7,Nguyễn Văn A,a@fpt.edu.vn,FA26,2,8" {
+		t.Fatalf("csv = %q", data)
+	}
+}
@@ -0,0 +1,229 @@
+package usecase
+
+import (
+	"context"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	commonModels "github.com/fpt-event-services/common/models"
+	"github.com/fpt-event-services/services/dashboard-lambda/models"
+	dashboardRepo "github.com/fpt-event-services/services/dashboard-lambda/repository"
+	eventModels "github.com/fpt-event-services/services/event-lambda/models"
+	eventRepo "github.com/fpt-event-services/services/event-lambda/repository"
+	staffRepo "github.com/fpt-event-services/services/staff-lambda/repository"
+	ticketModels "github.com/fpt-event-services/services/ticket-lambda/models"
+	ticketRepo "github.com/fpt-event-services/services/ticket-lambda/repository"
+)
+
+const (
+	// upcomingTicketLimit - Số vé sắp diễn ra tối đa trên dashboard
+	upcomingTicketLimit = 10
+	// recommendedEventLimit - Số sự kiện gợi ý tối đa
+	recommendedEventLimit = 5
+	// topEventLimit - Số sự kiện bán chạy trên dashboard admin
+	topEventLimit = 5
+	// adminDashboardTTL - Thời gian cache KPI admin
+	adminDashboardTTL = 60 * time.Second
+)
+
+// DashboardUseCase gom dữ liệu từ nhiều service cho màn hình chính
+type DashboardUseCase struct {
+	ticketRepo *ticketRepo.TicketRepository
+	eventRepo  *eventRepo.EventRepository
+	reportRepo *staffRepo.ReportRepository
+	statsRepo  *dashboardRepo.DashboardRepository
+
+	// Cache KPI admin (dùng chung cho mọi ADMIN, hết hạn sau adminDashboardTTL)
+	adminMu     sync.Mutex
+	adminCached *models.AdminDashboard
+}
+
+// NewDashboardUseCase creates a new dashboard use case
+func NewDashboardUseCase() *DashboardUseCase {
+	return &DashboardUseCase{
+		ticketRepo: ticketRepo.DefaultTicketRepository(),
+		eventRepo:  eventRepo.DefaultEventRepository(),
+		reportRepo: staffRepo.DefaultReportRepository(),
+		statsRepo:  dashboardRepo.DefaultDashboardRepository(),
+	}
+}
+
+// ============================================================
+// GetStudentDashboard - Gom vé sắp tới, số dư ví, report đang chờ
+// và sự kiện gợi ý trong một lần gọi
+// Các truy vấn chạy song song; một phần lỗi không làm hỏng cả response
+// ============================================================
+func (uc *DashboardUseCase) GetStudentDashboard(ctx context.Context, userID int) (*models.StudentDashboard, error) {
+	var (
+		wg          sync.WaitGroup
+		mu          sync.Mutex
+		tickets     []ticketModels.UpcomingTicket
+		balance     float64
+		reports     []commonModels.Report
+		openEvents  []eventModels.EventListItem
+		unavailable []string
+	)
+
+	fail := func(section string, err error) {
+		log.Printf("[DASHBOARD] ⚠️ user %d: %s unavailable: %v", userID, section, err)
+		mu.Lock()
+		unavailable = append(unavailable, section)
+		mu.Unlock()
+	}
+
+	wg.Add(4)
+	go func() {
+		defer wg.Done()
+		var err error
+		if tickets, err = uc.ticketRepo.GetUpcomingTicketsByUserID(ctx, userID, upcomingTicketLimit); err != nil {
+			fail(models.SectionUpcomingTickets, err)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		var err error
+		if balance, err = uc.ticketRepo.GetUserWalletBalance(ctx, userID); err != nil {
+			fail(models.SectionWalletBalance, err)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		var err error
+		if reports, err = uc.reportRepo.ListPendingReportsByUser(ctx, userID); err != nil {
+			fail(models.SectionPendingReports, err)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		var err error
+		if openEvents, err = uc.eventRepo.GetOpenEvents(ctx); err != nil {
+			fail(models.SectionRecommendedEvents, err)
+		}
+	}()
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	dashboard := &models.StudentDashboard{
+		UpcomingTickets:   tickets,
+		WalletBalance:     balance,
+		PendingReports:    reports,
+		RecommendedEvents: recommendEvents(openEvents, tickets, time.Now(), recommendedEventLimit),
+		Unavailable:       unavailable,
+	}
+	if dashboard.UpcomingTickets == nil {
+		dashboard.UpcomingTickets = []ticketModels.UpcomingTicket{}
+	}
+	if dashboard.PendingReports == nil {
+		dashboard.PendingReports = []commonModels.Report{}
+	}
+	if dashboard.Unavailable == nil {
+		dashboard.Unavailable = []string{}
+	}
+	sort.Strings(dashboard.Unavailable)
+	return dashboard, nil
+}
+
+// recommendEvents chọn các sự kiện OPEN chưa bắt đầu mà user chưa có vé,
+// sắp xếp theo giờ bắt đầu gần nhất
+func recommendEvents(events []eventModels.EventListItem, tickets []ticketModels.UpcomingTicket, now time.Time, limit int) []eventModels.EventListItem {
+	owned := make(map[int]bool, len(tickets))
+	for _, t := range tickets {
+		owned[t.EventID] = true
+	}
+
+	type candidate struct {
+		event eventModels.EventListItem
+		start time.Time
+	}
+	var candidates []candidate
+	for _, e := range events {
+		if owned[e.EventID] {
+			continue
+		}
+		start, err := time.Parse(time.RFC3339, e.StartTime)
+		if err != nil || !start.After(now) {
+			continue
+		}
+		candidates = append(candidates, candidate{event: e, start: start})
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].start.Before(candidates[j].start)
+	})
+
+	result := make([]eventModels.EventListItem, 0, min(len(candidates), limit))
+	for i := 0; i < len(candidates) && i < limit; i++ {
+		result = append(result, candidates[i].event)
+	}
+	return result
+}
+
+// ============================================================
+// GetAdminDashboard - KPI toàn hệ thống cho ADMIN
+// Kết quả được cache adminDashboardTTL; các request đồng thời khi
+// cache hết hạn chỉ chạy truy vấn một lần (giữ adminMu trong lúc tải)
+// ============================================================
+func (uc *DashboardUseCase) GetAdminDashboard(ctx context.Context) (*models.AdminDashboard, error) {
+	uc.adminMu.Lock()
+	defer uc.adminMu.Unlock()
+
+	if uc.adminCached != nil && time.Since(uc.adminCached.GeneratedAt) < adminDashboardTTL {
+		return uc.adminCached, nil
+	}
+
+	// Số liệu trước since đọc từ bảng tổng hợp theo ngày (job stats-aggregation)
+	since, err := uc.eventRepo.RealtimeSince(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	dashboard := &models.AdminDashboard{}
+	if !since.IsZero() {
+		dashboard.RealtimeSince = &since
+	}
+	var (
+		wg                            sync.WaitGroup
+		statusErr, counterErr, topErr error
+	)
+	wg.Add(3)
+	go func() {
+		defer wg.Done()
+		dashboard.EventsByStatus, statusErr = uc.statsRepo.CountEventsByStatus(ctx)
+	}()
+	go func() {
+		defer wg.Done()
+		counterErr = uc.statsRepo.GetActivityCounters(ctx, dashboard, since)
+	}()
+	go func() {
+		defer wg.Done()
+		dashboard.TopEvents, topErr = uc.statsRepo.TopEventsBySales(ctx, since, topEventLimit)
+	}()
+	wg.Wait()
+
+	for _, err := range []error{statusErr, counterErr, topErr} {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	dashboard.GeneratedAt = time.Now()
+	uc.adminCached = dashboard
+	return dashboard, nil
+}
+
+// ============================================================
+// GetCampusReports - So sánh số liệu giữa các campus (super admin)
+// Không cache: báo cáo ít được gọi, luôn trả số liệu mới nhất
+// ============================================================
+func (uc *DashboardUseCase) GetCampusReports(ctx context.Context) ([]models.CampusReport, error) {
+	since, err := uc.eventRepo.RealtimeSince(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return uc.statsRepo.CampusReports(ctx, since)
+}
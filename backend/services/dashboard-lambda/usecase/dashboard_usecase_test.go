@@ -0,0 +1,33 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	eventModels "github.com/fpt-event-services/services/event-lambda/models"
+	ticketModels "github.com/fpt-event-services/services/ticket-lambda/models"
+)
+
+func TestRecommendEvents(t *testing.T) {
+	now := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) string { return now.Add(d).Format(time.RFC3339) }
+
+	events := []eventModels.EventListItem{
+		{EventID: 1, StartTime: at(72 * time.Hour)},
+		{EventID: 2, StartTime: at(-time.Hour)}, // đã bắt đầu
+		{EventID: 3, StartTime: at(24 * time.Hour)},
+		{EventID: 4, StartTime: at(48 * time.Hour)}, // đã có vé
+		{EventID: 5, StartTime: "not-a-time"},
+		{EventID: 6, StartTime: at(96 * time.Hour)},
+	}
+	tickets := []ticketModels.UpcomingTicket{{TicketID: 10, EventID: 4}}
+
+	got := recommendEvents(events, tickets, now, 2)
+	if len(got) != 2 || got[0].EventID != 3 || got[1].EventID != 1 {
+		t.Fatalf("recommendEvents = %+v, want events 3, 1", got)
+	}
+
+	if got := recommendEvents(nil, nil, now, 5); got == nil || len(got) != 0 {
+		t.Fatalf("recommendEvents(nil) = %#v, want empty slice", got)
+	}
+}
@@ -0,0 +1,37 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/fpt-event-services/services/dashboard-lambda/models"
+)
+
+// Số gợi ý trả về mặc định / tối đa (job lưu tối đa 20 gợi ý mỗi user)
+const (
+	DefaultRecommendationLimit = 10
+	MaxRecommendationLimit     = 20
+)
+
+// ============================================================
+// GetMyRecommendations - Gợi ý sự kiện theo lịch sử tham dự của user
+// Đã tắt gợi ý: trả OptedOut = true và danh sách rỗng
+// ============================================================
+func (uc *DashboardUseCase) GetMyRecommendations(ctx context.Context, userID, limit int) (*models.Recommendations, error) {
+	optOut, err := uc.statsRepo.GetRecommendationOptOut(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if optOut {
+		return &models.Recommendations{OptedOut: true, Events: []models.RecommendedEvent{}}, nil
+	}
+	events, computedAt, err := uc.statsRepo.GetRecommendations(ctx, userID, limit)
+	if err != nil {
+		return nil, err
+	}
+	return &models.Recommendations{ComputedAt: computedAt, Events: events}, nil
+}
+
+// SetRecommendationOptOut - Bật / tắt gợi ý (tắt: xóa điểm đã tính, job bỏ qua user)
+func (uc *DashboardUseCase) SetRecommendationOptOut(ctx context.Context, userID int, optOut bool) error {
+	return uc.statsRepo.SetRecommendationOptOut(ctx, userID, optOut)
+}
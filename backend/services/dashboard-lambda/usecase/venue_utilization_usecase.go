@@ -0,0 +1,212 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/fpt-event-services/common/csvutil"
+	"github.com/fpt-event-services/services/dashboard-lambda/models"
+	eventRepo "github.com/fpt-event-services/services/event-lambda/repository"
+)
+
+const (
+	// defaultUtilizationDays - Khoảng mặc định khi không truyền from/to (tính cả hôm nay)
+	defaultUtilizationDays = 30
+	// maxUtilizationDays - Khoảng tối đa của một báo cáo
+	maxUtilizationDays = 366
+)
+
+// ErrInvalidUtilizationRange - from/to sai định dạng hoặc khoảng ngày không hợp lệ
+var ErrInvalidUtilizationRange = errors.New("from/to must be YYYY-MM-DD, from <= to, at most 366 days")
+
+// ============================================================
+// GetVenueUtilization - Mức sử dụng khu vực trong [from, to] (tính cả ngày cuối)
+// Không cache: báo cáo ít được gọi, luôn trả số liệu mới nhất (giống GetCampusReports)
+// ============================================================
+func (uc *DashboardUseCase) GetVenueUtilization(ctx context.Context, fromParam, toParam string, campusID *int) (*models.VenueUtilization, error) {
+	from, to, err := parseUtilizationRange(fromParam, toParam, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	end := to.AddDate(0, 0, 1)
+
+	since, err := uc.eventRepo.RealtimeSince(ctx)
+	if err != nil {
+		return nil, err
+	}
+	areas, err := uc.statsRepo.UtilizationAreas(ctx, campusID)
+	if err != nil {
+		return nil, err
+	}
+	events, err := uc.statsRepo.UtilizationEvents(ctx, from, end, since, campusID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &models.VenueUtilization{
+		From:        from.Format("2006-01-02"),
+		To:          to.Format("2006-01-02"),
+		Days:        utilizationDays(from, end),
+		CampusID:    campusID,
+		Areas:       computeUtilization(areas, events, from, end),
+		GeneratedAt: time.Now(),
+	}
+	if !since.IsZero() {
+		report.RealtimeSince = &since
+	}
+	return report, nil
+}
+
+// parseUtilizationRange - Ngày đầu / ngày cuối (00:00 giờ Việt Nam)
+// Thiếu cả hai: defaultUtilizationDays ngày gần nhất; thiếu một: tính từ ngày còn lại
+func parseUtilizationRange(fromParam, toParam string, now time.Time) (time.Time, time.Time, error) {
+	parse := func(v string) (time.Time, error) {
+		day, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			return time.Time{}, ErrInvalidUtilizationRange
+		}
+		return eventRepo.StatsDay(day), nil
+	}
+
+	to := eventRepo.StatsDay(now)
+	if toParam != "" {
+		day, err := parse(toParam)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		to = day
+	}
+	from := to.AddDate(0, 0, -(defaultUtilizationDays - 1))
+	if fromParam != "" {
+		day, err := parse(fromParam)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		from = day
+		if toParam == "" {
+			to = from.AddDate(0, 0, defaultUtilizationDays-1)
+		}
+	}
+
+	if to.Before(from) || utilizationDays(from, to.AddDate(0, 0, 1)) > maxUtilizationDays {
+		return from, to, ErrInvalidUtilizationRange
+	}
+	return from, to, nil
+}
+
+// utilizationDays - Số ngày trong [from, end)
+func utilizationDays(from, end time.Time) int {
+	days := 0
+	for d := from; d.Before(end); d = d.AddDate(0, 0, 1) {
+		days++
+	}
+	return days
+}
+
+// computeUtilization - Gom sự kiện theo khu vực trong [from, end)
+// Giờ đặt chỉ tính phần nằm trong khoảng; ngày rảnh là ngày không có sự kiện nào giao với nó
+// Khu vực ít giờ đặt nhất đứng đầu
+func computeUtilization(areas []models.AreaUtilization, events []models.UtilizationEvent, from, end time.Time) []models.AreaUtilization {
+	byArea := make(map[int][]models.UtilizationEvent, len(areas))
+	for _, e := range events {
+		byArea[e.AreaID] = append(byArea[e.AreaID], e)
+	}
+	days := utilizationDays(from, end)
+
+	result := make([]models.AreaUtilization, 0, len(areas))
+	for _, a := range areas {
+		var booked time.Duration
+		var fillSum float64
+		busy := map[int]bool{}
+		for _, e := range byArea[a.AreaID] {
+			start, stop := maxTime(e.StartTime, from), minTime(e.EndTime, end)
+			if !stop.After(start) {
+				continue
+			}
+			a.EventsHosted++
+			a.TicketsSold += e.TicketsSold
+			booked += stop.Sub(start)
+			if a.Capacity > 0 {
+				fillSum += float64(e.TicketsSold) / float64(a.Capacity)
+			}
+			i := 0
+			for d := from; d.Before(end); d, i = d.AddDate(0, 0, 1), i+1 {
+				if start.Before(d.AddDate(0, 0, 1)) && stop.After(d) {
+					busy[i] = true
+				}
+			}
+		}
+
+		a.BookedHours = round2(booked.Hours())
+		if days > 0 {
+			a.Utilization = round4(booked.Hours() / float64(days*24))
+		}
+		if a.EventsHosted > 0 {
+			a.AverageFillRate = round4(fillSum / float64(a.EventsHosted))
+		}
+		a.IdleDays = days - len(busy)
+		result = append(result, a)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].BookedHours != result[j].BookedHours {
+			return result[i].BookedHours < result[j].BookedHours
+		}
+		return result[i].AreaID < result[j].AreaID
+	})
+	return result
+}
+
+func maxTime(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+func minTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
+
+func round2(v float64) float64 { return math.Round(v*100) / 100 }
+
+func round4(v float64) float64 { return math.Round(v*10000) / 10000 }
+
+// UtilizationFileName - Tên file CSV của báo cáo
+func UtilizationFileName(r *models.VenueUtilization) string {
+	return fmt.Sprintf("venue_utilization_%s_%s.csv", r.From, r.To)
+}
+
+// UtilizationCSV - Mỗi dòng một khu vực, cùng thứ tự với JSON
+func UtilizationCSV(r *models.VenueUtilization) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	rows := [][]string{{"Area ID", "Area", "Floor", "Venue", "Capacity", "Events hosted", "Booked hours",
+		"Utilization", "Tickets sold", "Average fill rate", "Idle days"}}
+	for _, a := range r.Areas {
+		floor := ""
+		if a.Floor != nil {
+			floor = *a.Floor
+		}
+		rows = append(rows, []string{
+			strconv.Itoa(a.AreaID), csvutil.Safe(a.AreaName), csvutil.Safe(floor), csvutil.Safe(a.VenueName),
+			strconv.Itoa(a.Capacity), strconv.Itoa(a.EventsHosted),
+			strconv.FormatFloat(a.BookedHours, 'f', 2, 64), strconv.FormatFloat(a.Utilization, 'f', 4, 64),
+			strconv.Itoa(a.TicketsSold), strconv.FormatFloat(a.AverageFillRate, 'f', 4, 64), strconv.Itoa(a.IdleDays),
+		})
+	}
+	if err := w.WriteAll(rows); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,68 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fpt-event-services/services/dashboard-lambda/models"
+	eventRepo "github.com/fpt-event-services/services/event-lambda/repository"
+)
+
+func TestParseUtilizationRange(t *testing.T) {
+	now := time.Date(2026, 3, 31, 15, 0, 0, 0, time.UTC)
+
+	from, to, err := parseUtilizationRange("", "", now)
+	if err != nil || from.Format("2006-01-02") != "2026-03-02" || to.Format("2006-01-02") != "2026-03-31" {
+		t.Fatalf("default range = %v..%v, %v", from, to, err)
+	}
+
+	from, to, err = parseUtilizationRange("2026-01-01", "", now)
+	if err != nil || to.Format("2006-01-02") != "2026-01-30" {
+		t.Fatalf("from only = %v..%v, %v", from, to, err)
+	}
+
+	for _, in := range [][2]string{{"2026-02-01", "2026-01-01"}, {"2025-01-01", "2026-03-01"}, {"01/02/2026", ""}} {
+		if _, _, err := parseUtilizationRange(in[0], in[1], now); !errors.Is(err, ErrInvalidUtilizationRange) {
+			t.Errorf("parseUtilizationRange(%q, %q) err = %v", in[0], in[1], err)
+		}
+	}
+}
+
+func TestComputeUtilization(t *testing.T) {
+	day := func(d, h int) time.Time {
+		return eventRepo.StatsDay(time.Date(2026, 3, d, 0, 0, 0, 0, time.UTC)).Add(time.Duration(h) * time.Hour)
+	}
+	from, end := day(1, 0), day(5, 0) // 4 ngày: 1..4/3
+
+	areas := []models.AreaUtilization{
+		{AreaID: 1, AreaName: "Hall A", Capacity: 100},
+		{AreaID: 2, AreaName: "Room B", Capacity: 50},
+	}
+	events := []models.UtilizationEvent{
+		{EventID: 10, AreaID: 1, StartTime: day(1, 8), EndTime: day(1, 12), TicketsSold: 80},
+		{EventID: 11, AreaID: 1, StartTime: day(3, 22), EndTime: day(4, 2), TicketsSold: 40}, // qua đêm: bận 2 ngày
+		{EventID: 12, AreaID: 2, StartTime: day(4, 20), EndTime: day(5, 4), TicketsSold: 50}, // chỉ tính 4 giờ trong khoảng
+	}
+
+	got := computeUtilization(areas, events, from, end)
+	if len(got) != 2 || got[0].AreaID != 2 || got[1].AreaID != 1 {
+		t.Fatalf("order = %+v, want area 2 (ít giờ đặt) trước", got)
+	}
+
+	hall := got[1]
+	if hall.EventsHosted != 2 || hall.BookedHours != 8 || hall.TicketsSold != 120 {
+		t.Errorf("hall = %+v", hall)
+	}
+	if hall.AverageFillRate != 0.6 || hall.IdleDays != 1 {
+		t.Errorf("hall fill rate = %v, idle days = %d, want 0.6, 1", hall.AverageFillRate, hall.IdleDays)
+	}
+	if hall.Utilization != round4(8.0/96) {
+		t.Errorf("hall utilization = %v", hall.Utilization)
+	}
+
+	room := got[0]
+	if room.BookedHours != 4 || room.AverageFillRate != 1 || room.IdleDays != 3 {
+		t.Errorf("room = %+v", room)
+	}
+}
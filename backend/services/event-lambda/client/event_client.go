@@ -0,0 +1,106 @@
+package client
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/fpt-event-services/common/rpc"
+	"github.com/fpt-event-services/services/event-lambda/models"
+	"github.com/fpt-event-services/services/event-lambda/repository"
+)
+
+// ErrEventNotFound - Sự kiện không tồn tại
+var ErrEventNotFound = errors.New("event not found")
+
+// AddrEnv - Biến môi trường chứa địa chỉ gRPC của event-lambda (rỗng = in-process)
+const AddrEnv = "EVENT_SERVICE_GRPC_ADDR"
+
+// ============================================================
+// EventService - API nội bộ của event-lambda cho các service khác
+// Khớp service EventService trong services/proto/event.proto.
+// Service khác (ticket-lambda, ...) chỉ dùng interface này thay vì
+// tự viết SQL lên bảng Event
+// ============================================================
+type EventService interface {
+	GetEventBookingInfo(ctx context.Context, eventID int) (*models.EventBookingInfo, error)
+	GetTicketTemplate(ctx context.Context, eventID int) (*models.EventTicketTemplate, error)
+	GetEventSponsors(ctx context.Context, eventID int) ([]models.EventSponsor, error)
+	CheckEventPermission(ctx context.Context, eventID, userID int, permission string) (bool, error)
+}
+
+// LocalEventService - Adapter in-process: gọi thẳng repository của event-lambda
+// (dùng khi các service chạy chung một process như main.go hiện tại)
+type LocalEventService struct {
+	repo *repository.EventRepository
+}
+
+// NewLocalEventService creates a new in-process event service
+func NewLocalEventService() *LocalEventService {
+	return &LocalEventService{
+		repo: repository.DefaultEventRepository(),
+	}
+}
+
+// GetEventBookingInfo trả về ErrEventNotFound nếu sự kiện không tồn tại
+func (s *LocalEventService) GetEventBookingInfo(ctx context.Context, eventID int) (*models.EventBookingInfo, error) {
+	info, err := s.repo.GetEventBookingInfo(ctx, eventID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrEventNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get event booking info: %w", err)
+	}
+	return info, nil
+}
+
+// GetTicketTemplate - Cấu hình vé PDF (mặc định nếu chưa cấu hình), ErrEventNotFound nếu không có sự kiện
+func (s *LocalEventService) GetTicketTemplate(ctx context.Context, eventID int) (*models.EventTicketTemplate, error) {
+	tpl, err := s.repo.GetTicketTemplate(ctx, eventID)
+	if errors.Is(err, repository.ErrEventNotFound) {
+		return nil, ErrEventNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get ticket template: %w", err)
+	}
+	return tpl, nil
+}
+
+// GetEventSponsors - Nhà tài trợ của sự kiện (GOLD trước), rỗng nếu chưa có
+func (s *LocalEventService) GetEventSponsors(ctx context.Context, eventID int) ([]models.EventSponsor, error) {
+	sponsors, err := s.repo.ListEventSponsors(ctx, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("get event sponsors: %w", err)
+	}
+	return sponsors, nil
+}
+
+// CheckEventPermission - User là chủ sự kiện hoặc co-organizer đã chấp nhận có quyền permission
+// (models.PermissionEditDetails, ...); false nếu sự kiện không tồn tại
+func (s *LocalEventService) CheckEventPermission(ctx context.Context, eventID, userID int, permission string) (bool, error) {
+	ok, err := s.repo.CheckEventPermission(ctx, eventID, userID, permission)
+	if err != nil {
+		return false, fmt.Errorf("check event permission: %w", err)
+	}
+	return ok, nil
+}
+
+var (
+	defaultService     EventService
+	defaultServiceOnce sync.Once
+)
+
+// Default trả về EventService dùng chung của process:
+// gRPC khi đặt EVENT_SERVICE_GRPC_ADDR, ngược lại adapter in-process
+func Default() EventService {
+	defaultServiceOnce.Do(func() {
+		if rpc.ServiceAddr(AddrEnv) != "" {
+			defaultService = NewGRPCEventService(rpc.MustDial(AddrEnv))
+			return
+		}
+		defaultService = NewLocalEventService()
+	})
+	return defaultService
+}
@@ -0,0 +1,122 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/fpt-event-services/services/event-lambda/models"
+	"github.com/fpt-event-services/services/proto/eventpb"
+)
+
+// location - Múi giờ của thời gian sự kiện, khớp loc của DSN (common/db)
+// để email/PDF hiển thị giống adapter in-process
+var location = func() *time.Location {
+	if loc, err := time.LoadLocation("Asia/Ho_Chi_Minh"); err == nil {
+		return loc
+	}
+	return time.FixedZone("UTC+7", 7*60*60)
+}()
+
+// GRPCEventService - Adapter gọi event-lambda qua gRPC (EVENT_SERVICE_GRPC_ADDR)
+// NOT_FOUND → ErrEventNotFound, giống LocalEventService
+type GRPCEventService struct {
+	client eventpb.EventServiceClient
+}
+
+// NewGRPCEventService creates an EventService over conn
+func NewGRPCEventService(conn grpc.ClientConnInterface) *GRPCEventService {
+	return &GRPCEventService{client: eventpb.NewEventServiceClient(conn)}
+}
+
+func (s *GRPCEventService) GetEventBookingInfo(ctx context.Context, eventID int) (*models.EventBookingInfo, error) {
+	resp, err := s.client.GetEventBookingInfo(ctx, &eventpb.GetEventBookingInfoRequest{EventId: int32(eventID)})
+	if err != nil {
+		return nil, fromStatus("get event booking info", err)
+	}
+	return &models.EventBookingInfo{
+		EventID:               int(resp.GetEventId()),
+		Title:                 resp.GetTitle(),
+		Status:                resp.GetStatus(),
+		StartTime:             time.Unix(resp.GetStartTimeUnix(), 0).In(location),
+		EndTime:               time.Unix(resp.GetEndTimeUnix(), 0).In(location),
+		AreaID:                intPtr(resp.AreaId),
+		AreaName:              resp.AreaName,
+		VenueName:             resp.VenueName,
+		VenueLocation:         resp.VenueLocation,
+		CreatedBy:             int(resp.GetCreatedBy()),
+		CompTicketQuota:       intPtr(resp.CompTicketQuota),
+		AllowGuestCheckout:    resp.GetAllowGuestCheckout(),
+		BookingQueue:          resp.GetBookingQueue(),
+		QueueAdmitPerInterval: int(resp.GetQueueAdmitPerInterval()),
+	}, nil
+}
+
+func (s *GRPCEventService) GetTicketTemplate(ctx context.Context, eventID int) (*models.EventTicketTemplate, error) {
+	resp, err := s.client.GetTicketTemplate(ctx, &eventpb.GetTicketTemplateRequest{EventId: int32(eventID)})
+	if err != nil {
+		return nil, fromStatus("get ticket template", err)
+	}
+	return &models.EventTicketTemplate{
+		EventID:          int(resp.GetEventId()),
+		Layout:           resp.GetLayout(),
+		PageSize:         resp.GetPageSize(),
+		AccentColor:      resp.GetAccentColor(),
+		ShowBanner:       resp.GetShowBanner(),
+		OrganizerLogoURL: resp.GetOrganizerLogoUrl(),
+		SponsorLogoURLs:  resp.GetSponsorLogoUrls(),
+		FooterNote:       resp.GetFooterNote(),
+		BannerURL:        resp.GetBannerUrl(),
+	}, nil
+}
+
+func (s *GRPCEventService) GetEventSponsors(ctx context.Context, eventID int) ([]models.EventSponsor, error) {
+	resp, err := s.client.GetEventSponsors(ctx, &eventpb.GetEventSponsorsRequest{EventId: int32(eventID)})
+	if err != nil {
+		return nil, fromStatus("get event sponsors", err)
+	}
+	sponsors := make([]models.EventSponsor, 0, len(resp.GetSponsors()))
+	for _, sp := range resp.GetSponsors() {
+		sponsors = append(sponsors, models.EventSponsor{
+			SponsorID:    int(sp.GetSponsorId()),
+			Name:         sp.GetName(),
+			Tier:         sp.GetTier(),
+			LogoURL:      sp.LogoUrl,
+			WebsiteURL:   sp.WebsiteUrl,
+			DisplayOrder: int(sp.GetDisplayOrder()),
+		})
+	}
+	return sponsors, nil
+}
+
+func (s *GRPCEventService) CheckEventPermission(ctx context.Context, eventID, userID int, permission string) (bool, error) {
+	resp, err := s.client.CheckEventPermission(ctx, &eventpb.CheckEventPermissionRequest{
+		EventId:    int32(eventID),
+		UserId:     int32(userID),
+		Permission: permission,
+	})
+	if err != nil {
+		return false, fromStatus("check event permission", err)
+	}
+	return resp.GetAllowed(), nil
+}
+
+// fromStatus đổi gRPC status về lỗi của EventService
+func fromStatus(op string, err error) error {
+	if status.Code(err) == codes.NotFound {
+		return ErrEventNotFound
+	}
+	return fmt.Errorf("%s: %w", op, err)
+}
+
+func intPtr(v *int32) *int {
+	if v == nil {
+		return nil
+	}
+	n := int(*v)
+	return &n
+}
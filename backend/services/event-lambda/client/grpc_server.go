@@ -0,0 +1,125 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/fpt-event-services/services/event-lambda/models"
+	"github.com/fpt-event-services/services/proto/eventpb"
+)
+
+// ============================================================
+// GRPCServer - Phục vụ service EventService (event.proto) bằng một EventService Go
+// (thường là LocalEventService); main.go đăng ký khi đặt GRPC_PORT
+// ErrEventNotFound → NOT_FOUND; lỗi khác → INTERNAL (chi tiết chỉ ghi log)
+// ============================================================
+type GRPCServer struct {
+	eventpb.UnimplementedEventServiceServer
+	svc EventService
+}
+
+// NewGRPCServer creates a gRPC server backed by svc
+func NewGRPCServer(svc EventService) *GRPCServer {
+	return &GRPCServer{svc: svc}
+}
+
+func (s *GRPCServer) GetEventBookingInfo(ctx context.Context, req *eventpb.GetEventBookingInfoRequest) (*eventpb.EventBookingInfo, error) {
+	info, err := s.svc.GetEventBookingInfo(ctx, int(req.GetEventId()))
+	if err != nil {
+		return nil, toStatus("GetEventBookingInfo", err)
+	}
+	return &eventpb.EventBookingInfo{
+		EventId:               int32(info.EventID),
+		Title:                 info.Title,
+		Status:                info.Status,
+		StartTimeUnix:         info.StartTime.Unix(),
+		EndTimeUnix:           info.EndTime.Unix(),
+		AreaId:                int32Ptr(info.AreaID),
+		AreaName:              info.AreaName,
+		VenueName:             info.VenueName,
+		VenueLocation:         info.VenueLocation,
+		CreatedBy:             int32(info.CreatedBy),
+		CompTicketQuota:       int32Ptr(info.CompTicketQuota),
+		AllowGuestCheckout:    info.AllowGuestCheckout,
+		BookingQueue:          info.BookingQueue,
+		QueueAdmitPerInterval: int32(info.QueueAdmitPerInterval),
+	}, nil
+}
+
+func (s *GRPCServer) GetTicketTemplate(ctx context.Context, req *eventpb.GetTicketTemplateRequest) (*eventpb.TicketTemplate, error) {
+	tpl, err := s.svc.GetTicketTemplate(ctx, int(req.GetEventId()))
+	if err != nil {
+		return nil, toStatus("GetTicketTemplate", err)
+	}
+	return &eventpb.TicketTemplate{
+		EventId:          int32(tpl.EventID),
+		Layout:           tpl.Layout,
+		PageSize:         tpl.PageSize,
+		AccentColor:      stringPtr(tpl.AccentColor),
+		ShowBanner:       tpl.ShowBanner,
+		OrganizerLogoUrl: stringPtr(tpl.OrganizerLogoURL),
+		SponsorLogoUrls:  tpl.SponsorLogoURLs,
+		FooterNote:       stringPtr(tpl.FooterNote),
+		BannerUrl:        stringPtr(tpl.BannerURL),
+	}, nil
+}
+
+func (s *GRPCServer) GetEventSponsors(ctx context.Context, req *eventpb.GetEventSponsorsRequest) (*eventpb.EventSponsorList, error) {
+	sponsors, err := s.svc.GetEventSponsors(ctx, int(req.GetEventId()))
+	if err != nil {
+		return nil, toStatus("GetEventSponsors", err)
+	}
+	out := &eventpb.EventSponsorList{Sponsors: make([]*eventpb.EventSponsor, 0, len(sponsors))}
+	for _, sp := range sponsors {
+		out.Sponsors = append(out.Sponsors, sponsorToProto(sp))
+	}
+	return out, nil
+}
+
+func (s *GRPCServer) CheckEventPermission(ctx context.Context, req *eventpb.CheckEventPermissionRequest) (*eventpb.CheckEventPermissionResponse, error) {
+	ok, err := s.svc.CheckEventPermission(ctx, int(req.GetEventId()), int(req.GetUserId()), req.GetPermission())
+	if err != nil {
+		return nil, toStatus("CheckEventPermission", err)
+	}
+	return &eventpb.CheckEventPermissionResponse{Allowed: ok}, nil
+}
+
+func sponsorToProto(sp models.EventSponsor) *eventpb.EventSponsor {
+	return &eventpb.EventSponsor{
+		SponsorId:    int32(sp.SponsorID),
+		Name:         sp.Name,
+		Tier:         sp.Tier,
+		LogoUrl:      sp.LogoURL,
+		WebsiteUrl:   sp.WebsiteURL,
+		DisplayOrder: int32(sp.DisplayOrder),
+	}
+}
+
+// toStatus đổi lỗi của EventService sang gRPC status
+func toStatus(method string, err error) error {
+	if errors.Is(err, ErrEventNotFound) {
+		return status.Error(codes.NotFound, err.Error())
+	}
+	log.Printf("[RPC] EventService.%s failed: %v", method, err)
+	return status.Error(codes.Internal, "internal error")
+}
+
+func int32Ptr(v *int) *int32 {
+	if v == nil {
+		return nil
+	}
+	n := int32(*v)
+	return &n
+}
+
+// stringPtr - Chuỗi rỗng = trường optional không đặt
+func stringPtr(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
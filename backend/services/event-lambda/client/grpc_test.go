@@ -0,0 +1,124 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/fpt-event-services/services/event-lambda/models"
+	"github.com/fpt-event-services/services/proto/eventpb"
+)
+
+// fakeEventService - EventService trong bộ nhớ cho test
+type fakeEventService struct {
+	info *models.EventBookingInfo
+	err  error
+}
+
+func (f *fakeEventService) GetEventBookingInfo(ctx context.Context, eventID int) (*models.EventBookingInfo, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.info, nil
+}
+
+func (f *fakeEventService) GetTicketTemplate(ctx context.Context, eventID int) (*models.EventTicketTemplate, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &models.EventTicketTemplate{EventID: eventID, Layout: "CLASSIC", PageSize: "A4", SponsorLogoURLs: []string{"a.png"}}, nil
+}
+
+func (f *fakeEventService) GetEventSponsors(ctx context.Context, eventID int) ([]models.EventSponsor, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return nil, nil
+}
+
+func (f *fakeEventService) CheckEventPermission(ctx context.Context, eventID, userID int, permission string) (bool, error) {
+	return f.err == nil && userID == 7 && permission == "EDIT_DETAILS", f.err
+}
+
+// dialFake chạy GRPCServer(svc) trên bufconn và trả về GRPCEventService nối tới nó
+func dialFake(t *testing.T, svc EventService) *GRPCEventService {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer()
+	eventpb.RegisterEventServiceServer(srv, NewGRPCServer(svc))
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return lis.Dial() }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return NewGRPCEventService(conn)
+}
+
+func TestGRPCEventServiceRoundTrip(t *testing.T) {
+	areaID, quota := 12, 30
+	areaName := "Hall A"
+	want := &models.EventBookingInfo{
+		EventID:               5,
+		Title:                 "Tech Talk",
+		Status:                "OPEN",
+		StartTime:             time.Date(2026, 10, 20, 9, 0, 0, 0, location),
+		EndTime:               time.Date(2026, 10, 20, 11, 30, 0, 0, location),
+		AreaID:                &areaID,
+		AreaName:              &areaName,
+		CreatedBy:             3,
+		CompTicketQuota:       &quota,
+		AllowGuestCheckout:    true,
+		BookingQueue:          true,
+		QueueAdmitPerInterval: 50,
+	}
+	svc := dialFake(t, &fakeEventService{info: want})
+	ctx := context.Background()
+
+	got, err := svc.GetEventBookingInfo(ctx, 5)
+	if err != nil {
+		t.Fatalf("GetEventBookingInfo: %v", err)
+	}
+	if !got.StartTime.Equal(want.StartTime) || got.StartTime.Location() != location {
+		t.Errorf("StartTime = %v, want %v", got.StartTime, want.StartTime)
+	}
+	got.StartTime, got.EndTime = want.StartTime, want.EndTime
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GetEventBookingInfo = %+v, want %+v", got, want)
+	}
+
+	tpl, err := svc.GetTicketTemplate(ctx, 5)
+	if err != nil || tpl.Layout != "CLASSIC" || tpl.AccentColor != "" || len(tpl.SponsorLogoURLs) != 1 {
+		t.Errorf("GetTicketTemplate = %+v, %v", tpl, err)
+	}
+	if ok, err := svc.CheckEventPermission(ctx, 5, 7, "EDIT_DETAILS"); err != nil || !ok {
+		t.Errorf("CheckEventPermission = %v, %v, want true", ok, err)
+	}
+}
+
+func TestGRPCEventServiceErrors(t *testing.T) {
+	ctx := context.Background()
+
+	svc := dialFake(t, &fakeEventService{err: ErrEventNotFound})
+	if _, err := svc.GetEventBookingInfo(ctx, 1); !errors.Is(err, ErrEventNotFound) {
+		t.Errorf("not found: err = %v, want ErrEventNotFound", err)
+	}
+
+	svc = dialFake(t, &fakeEventService{err: errors.New("db down")})
+	_, err := svc.GetTicketTemplate(ctx, 1)
+	if err == nil || errors.Is(err, ErrEventNotFound) {
+		t.Fatalf("internal: err = %v, want non-nil other than ErrEventNotFound", err)
+	}
+}
@@ -0,0 +1,25 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/fpt-event-services/services/event-lambda/models"
+	"github.com/fpt-event-services/services/event-lambda/usecase"
+)
+
+// ============================================================
+// HandleEventActivityPoints - GET|PUT /api/events/{id}/activity-points
+// Điểm rèn luyện cộng cho mỗi sinh viên đã check-in sự kiện
+// Body PUT: {"points": 5} - 0 = không tính điểm
+// STAFF (attendance.points) cũng được xem / sửa, ngoài người sửa được chi tiết sự kiện
+// ============================================================
+func (h *EventHandler) HandleEventActivityPoints(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	return serveEventSettings(ctx, request, eventSettings[models.UpdateActivityPointsRequest, *models.ActivityPointsSettings]{
+		name:        "activity points",
+		get:         h.useCase.GetActivityPoints,
+		update:      h.useCase.UpdateActivityPoints,
+		errorStatus: map[error]int{usecase.ErrInvalidActivityPoints: http.StatusBadRequest},
+	})
+}
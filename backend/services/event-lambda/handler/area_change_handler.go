@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/fpt-event-services/common/authctx"
+	"github.com/fpt-event-services/common/campus"
+	"github.com/fpt-event-services/common/permission"
+	"github.com/fpt-event-services/services/event-lambda/models"
+	"github.com/fpt-event-services/services/event-lambda/repository"
+	"github.com/fpt-event-services/services/event-lambda/usecase"
+)
+
+// ============================================================
+// HandleChangeEventArea - POST /api/staff/events/{id}/change-area
+// Body: {"areaId": 7, "reason": "...", "dryRun": true}
+// Chuyển sự kiện OPEN/UPDATING chưa bắt đầu sang khu vực khác cùng campus: xếp lại ghế,
+// ghép vé đã bán sang ghế tương ứng (vé không ghép được có needsReassignment) và báo người mua
+// dryRun: trả kết quả ghép ghế mà không lưu
+// ============================================================
+func (h *EventHandler) HandleChangeEventArea(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	userID, err := permission.Require(ctx, permission.EventRequestReview)
+	if errors.Is(err, authctx.ErrUnauthenticated) {
+		return createMessageResponse(http.StatusUnauthorized, "Unauthorized")
+	}
+	if err != nil {
+		return createMessageResponse(http.StatusForbidden, "STAFF or ADMIN access required")
+	}
+	eventID, err := strconv.Atoi(request.PathParameters["id"])
+	if err != nil || eventID <= 0 {
+		return createMessageResponse(http.StatusBadRequest, "Invalid event ID")
+	}
+
+	var req models.ChangeAreaRequest
+	if err := json.Unmarshal([]byte(request.Body), &req); err != nil {
+		return createMessageResponse(http.StatusBadRequest, "Invalid request body")
+	}
+	change, err := h.useCase.ChangeEventArea(ctx, eventID, userID, req)
+	if err != nil {
+		return areaChangeErrorResponse(err)
+	}
+	return createJSONResponse(http.StatusOK, change)
+}
+
+// HandleGetAreaChanges - GET /api/staff/events/{id}/area-changes: lịch sử đổi khu vực và vé cần xếp lại ghế
+func (h *EventHandler) HandleGetAreaChanges(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	_, err := permission.Require(ctx, permission.EventRequestReview)
+	if errors.Is(err, authctx.ErrUnauthenticated) {
+		return createMessageResponse(http.StatusUnauthorized, "Unauthorized")
+	}
+	if err != nil {
+		return createMessageResponse(http.StatusForbidden, "STAFF or ADMIN access required")
+	}
+	eventID, err := strconv.Atoi(request.PathParameters["id"])
+	if err != nil || eventID <= 0 {
+		return createMessageResponse(http.StatusBadRequest, "Invalid event ID")
+	}
+
+	changes, err := h.useCase.ListAreaChanges(ctx, eventID)
+	if err != nil {
+		return areaChangeErrorResponse(err)
+	}
+	return createJSONResponse(http.StatusOK, changes)
+}
+
+// areaChangeErrorResponse - Lỗi nghiệp vụ của đổi khu vực => status code
+func areaChangeErrorResponse(err error) (events.APIGatewayProxyResponse, error) {
+	switch {
+	case errors.Is(err, campus.ErrOutOfScope):
+		return campusErrorResponse(err)
+	case errors.Is(err, usecase.ErrInvalidAreaChange):
+		return createMessageResponse(http.StatusBadRequest, err.Error())
+	case errors.Is(err, repository.ErrEventNotFound):
+		return createMessageResponse(http.StatusNotFound, "Event not found")
+	case errors.Is(err, repository.ErrAreaNotFound):
+		return createMessageResponse(http.StatusNotFound, "Area not found")
+	case errors.Is(err, repository.ErrAreaChangeNotAllowed),
+		errors.Is(err, repository.ErrSameArea),
+		errors.Is(err, repository.ErrAreaUnavailable),
+		errors.Is(err, repository.ErrAreaOtherCampus),
+		errors.Is(err, repository.ErrAreaScheduleConflict),
+		errors.Is(err, repository.ErrAreaTooSmall):
+		return createMessageResponse(http.StatusConflict, err.Error())
+	}
+	fmt.Printf("[ERROR] Area change failed: %v\n", err)
+	return createMessageResponse(http.StatusInternalServerError, "Error changing event area")
+}
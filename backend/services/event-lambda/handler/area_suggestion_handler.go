@@ -0,0 +1,103 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/fpt-event-services/common/authctx"
+	"github.com/fpt-event-services/common/campus"
+	"github.com/fpt-event-services/common/permission"
+	"github.com/fpt-event-services/services/event-lambda/models"
+	"github.com/fpt-event-services/services/event-lambda/repository"
+	"github.com/fpt-event-services/services/event-lambda/usecase"
+)
+
+// ============================================================
+// HandleGetSuggestedAreas - GET /api/staff/event-requests/{id}/suggested-areas
+// Gợi ý khu vực cho yêu cầu PENDING: không trùng lịch, đủ sức chứa,
+// xếp theo độ vừa sức chứa, tiện ích yêu cầu và mức sử dụng
+// ============================================================
+func (h *EventHandler) HandleGetSuggestedAreas(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	_, err := permission.Require(ctx, permission.EventRequestReview)
+	if errors.Is(err, authctx.ErrUnauthenticated) {
+		return createMessageResponse(http.StatusUnauthorized, "Unauthorized")
+	}
+	if err != nil {
+		return createMessageResponse(http.StatusForbidden, "STAFF or ADMIN access required")
+	}
+	requestID, err := strconv.Atoi(request.PathParameters["id"])
+	if err != nil || requestID <= 0 {
+		return createMessageResponse(http.StatusBadRequest, "Invalid request ID")
+	}
+
+	suggestions, err := h.useCase.SuggestAreas(ctx, requestID)
+	if err != nil {
+		return areaSuggestionErrorResponse(err)
+	}
+	return createJSONResponse(http.StatusOK, suggestions)
+}
+
+// ============================================================
+// HandleApproveSuggested - POST /api/staff/event-requests/{id}/approve-suggested
+// Duyệt một chạm với khu vực gợi ý đầu tiên (body tùy chọn: organizerNote)
+// Nhận If-Match như POST /api/event-requests/process
+// ============================================================
+func (h *EventHandler) HandleApproveSuggested(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	userID, err := permission.Require(ctx, permission.EventRequestReview)
+	if errors.Is(err, authctx.ErrUnauthenticated) {
+		return createMessageResponse(http.StatusUnauthorized, "Unauthorized")
+	}
+	if err != nil {
+		return createMessageResponse(http.StatusForbidden, "STAFF or ADMIN access required")
+	}
+	requestID, err := strconv.Atoi(request.PathParameters["id"])
+	if err != nil || requestID <= 0 {
+		return createMessageResponse(http.StatusBadRequest, "Invalid request ID")
+	}
+
+	var body models.ApproveSuggestedBody
+	if strings.TrimSpace(request.Body) != "" {
+		if err := json.Unmarshal([]byte(request.Body), &body); err != nil {
+			return createMessageResponse(http.StatusBadRequest, "Invalid request body")
+		}
+	}
+	expected, err := ifMatchVersion(request)
+	if resp, ok := preconditionErrorResponse(err); ok {
+		return resp, nil
+	}
+
+	area, err := h.useCase.ApproveWithTopSuggestion(ctx, requestID, userID, body, expected)
+	if resp, ok := preconditionErrorResponse(err); ok {
+		return resp, nil
+	}
+	if err != nil {
+		return areaSuggestionErrorResponse(err)
+	}
+	return createJSONResponse(http.StatusOK, map[string]interface{}{
+		"message": "Event request approved with suggested area",
+		"area":    area,
+	})
+}
+
+// areaSuggestionErrorResponse - Lỗi nghiệp vụ của gợi ý / duyệt một chạm => status code
+func areaSuggestionErrorResponse(err error) (events.APIGatewayProxyResponse, error) {
+	switch {
+	case errors.Is(err, campus.ErrOutOfScope):
+		return campusErrorResponse(err)
+	case errors.Is(err, repository.ErrEventRequestNotFound),
+		errors.Is(err, repository.ErrCampusSourceNotFound):
+		return createMessageResponse(http.StatusNotFound, "Event request not found")
+	case errors.Is(err, repository.ErrRequestClaimedByOther),
+		errors.Is(err, repository.ErrRequestNotClaimable),
+		errors.Is(err, usecase.ErrNoAreaSuggestion):
+		return createMessageResponse(http.StatusConflict, err.Error())
+	}
+	fmt.Printf("[ERROR] Area suggestion failed: %v\n", err)
+	return createMessageResponse(http.StatusInternalServerError, "Error suggesting areas")
+}
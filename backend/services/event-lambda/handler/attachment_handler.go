@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/fpt-event-services/common/authctx"
+	"github.com/fpt-event-services/services/event-lambda/models"
+	"github.com/fpt-event-services/services/event-lambda/repository"
+	"github.com/fpt-event-services/services/event-lambda/usecase"
+)
+
+// ============================================================
+// HandleEventAttachments - GET|POST /api/events/{id}/attachments
+// GET: mọi tài liệu (kể cả TICKET_HOLDERS); POST: thêm link hoặc upload file
+// Body POST: {"title": "Agenda", "type": "AGENDA", "visibility": "PUBLIC", "url": "https://..."}
+// hoặc {"title": "...", "fileName": "agenda.pdf", "fileBase64": "..."} (tối đa 10 MB)
+// ============================================================
+func (h *EventHandler) HandleEventAttachments(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	userID, ok := authctx.UserID(ctx)
+	if !ok {
+		return createMessageResponse(http.StatusUnauthorized, "User ID not found in request")
+	}
+	role := authctx.Role(ctx)
+	eventID, err := strconv.Atoi(request.PathParameters["id"])
+	if err != nil || eventID <= 0 {
+		return createMessageResponse(http.StatusBadRequest, "Invalid event ID")
+	}
+
+	if request.HTTPMethod == http.MethodGet {
+		attachments, err := h.useCase.ListEventAttachments(ctx, eventID, userID, role)
+		if err != nil {
+			return editDetailsErrorResponse("attachments", err, attachmentErrorStatus)
+		}
+		return createJSONResponse(http.StatusOK, attachments)
+	}
+
+	var req models.EventAttachmentRequest
+	if err := json.Unmarshal([]byte(request.Body), &req); err != nil {
+		return createMessageResponse(http.StatusBadRequest, "Invalid request body")
+	}
+	attachment, err := h.useCase.CreateEventAttachment(ctx, eventID, userID, role, &req)
+	if err != nil {
+		return editDetailsErrorResponse("attachments", err, attachmentErrorStatus)
+	}
+	return createJSONResponse(http.StatusCreated, attachment)
+}
+
+// ============================================================
+// HandleEventAttachment - PUT|DELETE /api/events/{id}/attachments/{attachmentId}
+// Body PUT: {"title": "...", "type": "MAP", "visibility": "TICKET_HOLDERS", "url"?: "..."}
+// ============================================================
+func (h *EventHandler) HandleEventAttachment(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	userID, ok := authctx.UserID(ctx)
+	if !ok {
+		return createMessageResponse(http.StatusUnauthorized, "User ID not found in request")
+	}
+	role := authctx.Role(ctx)
+	eventID, err := strconv.Atoi(request.PathParameters["id"])
+	if err != nil || eventID <= 0 {
+		return createMessageResponse(http.StatusBadRequest, "Invalid event ID")
+	}
+	attachmentID, err := strconv.Atoi(request.PathParameters["attachmentId"])
+	if err != nil || attachmentID <= 0 {
+		return createMessageResponse(http.StatusBadRequest, "Invalid attachment ID")
+	}
+
+	if request.HTTPMethod == http.MethodDelete {
+		if err := h.useCase.DeleteEventAttachment(ctx, eventID, attachmentID, userID, role); err != nil {
+			return editDetailsErrorResponse("attachments", err, attachmentErrorStatus)
+		}
+		return createMessageResponse(http.StatusOK, "Attachment deleted")
+	}
+
+	var req models.EventAttachmentRequest
+	if err := json.Unmarshal([]byte(request.Body), &req); err != nil {
+		return createMessageResponse(http.StatusBadRequest, "Invalid request body")
+	}
+	attachment, err := h.useCase.UpdateEventAttachment(ctx, eventID, attachmentID, userID, role, &req)
+	if err != nil {
+		return editDetailsErrorResponse("attachments", err, attachmentErrorStatus)
+	}
+	return createJSONResponse(http.StatusOK, attachment)
+}
+
+// attachmentErrorStatus - Lỗi riêng của tài liệu đính kèm -> HTTP status (editDetailsErrorResponse)
+var attachmentErrorStatus = map[error]int{
+	repository.ErrAttachmentNotFound:    http.StatusNotFound,
+	usecase.ErrInvalidAttachmentRequest: http.StatusBadRequest,
+	repository.ErrTooManyAttachments:    http.StatusBadRequest,
+}
@@ -0,0 +1,24 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/fpt-event-services/services/event-lambda/models"
+	"github.com/fpt-event-services/services/event-lambda/usecase"
+)
+
+// ============================================================
+// HandleEventBookingQueue - GET|PUT /api/events/{id}/booking-queue
+// Bật thì đặt vé phải qua hàng chờ: mỗi nhịp chỉ admitPerInterval người được vào giữ ghế
+// Body PUT: {"enabled": true, "admitPerInterval": 50}
+// ============================================================
+func (h *EventHandler) HandleEventBookingQueue(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	return serveEventSettings(ctx, request, eventSettings[models.UpdateBookingQueueRequest, *models.BookingQueueSettings]{
+		name:        "booking queue",
+		get:         h.useCase.GetBookingQueue,
+		update:      h.useCase.UpdateBookingQueue,
+		errorStatus: map[error]int{usecase.ErrInvalidBookingQueue: http.StatusBadRequest},
+	})
+}
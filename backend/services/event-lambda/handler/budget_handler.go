@@ -0,0 +1,124 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/fpt-event-services/common/authctx"
+	"github.com/fpt-event-services/services/event-lambda/models"
+	"github.com/fpt-event-services/services/event-lambda/repository"
+	"github.com/fpt-event-services/services/event-lambda/usecase"
+)
+
+// ============================================================
+// HandleEventRequestBudget - GET|PUT /api/event-requests/{id}/budget
+// GET: dự toán, lịch sử duyệt, thực chi và chênh lệch (người gửi request, STAFF, ADMIN)
+// Body PUT: {"requestedFunding": 5000000, "items": [{"category": "CATERING", "description": "Tea break", "estimatedAmount": 2000000}]}
+// PUT chỉ người gửi request, khi request còn PENDING
+// ============================================================
+func (h *EventHandler) HandleEventRequestBudget(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	userID, ok := authctx.UserID(ctx)
+	if !ok {
+		return createMessageResponse(http.StatusUnauthorized, "User ID not found in request")
+	}
+	requestID, err := strconv.Atoi(request.PathParameters["id"])
+	if err != nil || requestID <= 0 {
+		return createMessageResponse(http.StatusBadRequest, "Invalid request ID")
+	}
+
+	if request.HTTPMethod == http.MethodGet {
+		budget, err := h.useCase.GetEventRequestBudget(ctx, requestID, userID, authctx.Role(ctx))
+		if err != nil {
+			return budgetErrorResponse(err)
+		}
+		return createJSONResponse(http.StatusOK, budget)
+	}
+
+	var req models.EventBudgetInput
+	if err := json.Unmarshal([]byte(request.Body), &req); err != nil {
+		return createMessageResponse(http.StatusBadRequest, "Invalid request body")
+	}
+	budget, err := h.useCase.UpdateEventRequestBudget(ctx, requestID, userID, &req)
+	if err != nil {
+		return budgetErrorResponse(err)
+	}
+	return createJSONResponse(http.StatusOK, budget)
+}
+
+// ============================================================
+// HandleReviewEventRequestBudget - POST /api/event-requests/{id}/budget/reviews
+// Body: {"decision": "APPROVED|NEEDS_CHANGES|REJECTED", "approvedFunding"?: 4000000, "comment": "..."}
+// STAFF/ADMIN, khi request còn PENDING
+// ============================================================
+func (h *EventHandler) HandleReviewEventRequestBudget(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	userID, ok := authctx.UserID(ctx)
+	if !ok {
+		return createMessageResponse(http.StatusUnauthorized, "User ID not found in request")
+	}
+	requestID, err := strconv.Atoi(request.PathParameters["id"])
+	if err != nil || requestID <= 0 {
+		return createMessageResponse(http.StatusBadRequest, "Invalid request ID")
+	}
+
+	var req models.BudgetReviewRequest
+	if err := json.Unmarshal([]byte(request.Body), &req); err != nil {
+		return createMessageResponse(http.StatusBadRequest, "Invalid request body")
+	}
+	budget, err := h.useCase.ReviewEventRequestBudget(ctx, requestID, userID, authctx.Role(ctx), &req)
+	if err != nil {
+		return budgetErrorResponse(err)
+	}
+	return createJSONResponse(http.StatusCreated, budget)
+}
+
+// ============================================================
+// HandleEventRequestBudgetActuals - PUT /api/event-requests/{id}/budget/actuals
+// Body: {"items": [{"itemId": 12, "actualAmount": 2150000}, {"category": "PRINTING", "description": "Banner", "actualAmount": 300000}], "note": "..."}
+// Người gửi request hoặc ADMIN, sau khi sự kiện kết thúc
+// ============================================================
+func (h *EventHandler) HandleEventRequestBudgetActuals(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	userID, ok := authctx.UserID(ctx)
+	if !ok {
+		return createMessageResponse(http.StatusUnauthorized, "User ID not found in request")
+	}
+	requestID, err := strconv.Atoi(request.PathParameters["id"])
+	if err != nil || requestID <= 0 {
+		return createMessageResponse(http.StatusBadRequest, "Invalid request ID")
+	}
+
+	var req models.BudgetActualsRequest
+	if err := json.Unmarshal([]byte(request.Body), &req); err != nil {
+		return createMessageResponse(http.StatusBadRequest, "Invalid request body")
+	}
+	budget, err := h.useCase.SubmitBudgetActuals(ctx, requestID, userID, authctx.Role(ctx), &req)
+	if err != nil {
+		return budgetErrorResponse(err)
+	}
+	return createJSONResponse(http.StatusOK, budget)
+}
+
+// budgetErrorResponse map lỗi ngân sách sang HTTP status
+func budgetErrorResponse(err error) (events.APIGatewayProxyResponse, error) {
+	switch {
+	case errors.Is(err, repository.ErrEventRequestNotFound),
+		errors.Is(err, repository.ErrBudgetItemNotFound):
+		return createMessageResponse(http.StatusNotFound, err.Error())
+	case errors.Is(err, usecase.ErrBudgetForbidden):
+		return createMessageResponse(http.StatusForbidden, err.Error())
+	case errors.Is(err, usecase.ErrBudgetLocked),
+		errors.Is(err, usecase.ErrBudgetActualsNotOpen),
+		errors.Is(err, repository.ErrRequestClaimedByOther),
+		errors.Is(err, repository.ErrRequestNotClaimable):
+		return createMessageResponse(http.StatusConflict, err.Error())
+	case errors.Is(err, usecase.ErrInvalidBudgetRequest),
+		errors.Is(err, repository.ErrBudgetItemLimit):
+		return createMessageResponse(http.StatusBadRequest, err.Error())
+	}
+	fmt.Printf("[ERROR] Budget operation failed: %v\n", err)
+	return createMessageResponse(http.StatusInternalServerError, "Error processing budget")
+}
@@ -0,0 +1,24 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/fpt-event-services/services/event-lambda/models"
+	"github.com/fpt-event-services/services/event-lambda/usecase"
+)
+
+// ============================================================
+// HandleEventCashback - GET|PUT /api/events/{id}/cashback
+// % giá vé hoàn vào ví người mua khi check-out (vé đã thanh toán đủ)
+// Body PUT: {"percent": 10, "maxAmount": 50000} - percent 0 = tắt, maxAmount null = không giới hạn
+// ============================================================
+func (h *EventHandler) HandleEventCashback(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	return serveEventSettings(ctx, request, eventSettings[models.UpdateCashbackRequest, *models.CashbackSettings]{
+		name:        "cashback",
+		get:         h.useCase.GetCashback,
+		update:      h.useCase.UpdateCashback,
+		errorStatus: map[error]int{usecase.ErrInvalidCashback: http.StatusBadRequest},
+	})
+}
@@ -0,0 +1,24 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/fpt-event-services/services/event-lambda/models"
+	"github.com/fpt-event-services/services/event-lambda/usecase"
+)
+
+// ============================================================
+// HandleEventCheckinAlerts - GET|PUT /api/events/{id}/checkin-alerts
+// Ngưỡng % sức chứa báo cho organizer / staff khi check-in, kèm các ngưỡng đã báo
+// Body PUT: {"thresholds": [50, 80, 100]} - null = mặc định hệ thống, [] = tắt
+// ============================================================
+func (h *EventHandler) HandleEventCheckinAlerts(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	return serveEventSettings(ctx, request, eventSettings[models.UpdateCheckinAlertsRequest, *models.CheckinAlertSettings]{
+		name:        "check-in alerts",
+		get:         h.useCase.GetCheckinAlerts,
+		update:      h.useCase.UpdateCheckinAlerts,
+		errorStatus: map[error]int{usecase.ErrInvalidCheckinThresholds: http.StatusBadRequest},
+	})
+}
@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/fpt-event-services/common/authctx"
+	"github.com/fpt-event-services/common/campus"
+	"github.com/fpt-event-services/common/permission"
+	"github.com/fpt-event-services/services/event-lambda/repository"
+)
+
+// ============================================================
+// HandleClaimEventRequest - POST /api/staff/event-requests/{id}/claim
+// Nhận xử lý yêu cầu PENDING/UPDATING; gọi lại để gia hạn claim
+// Claim tự nhả sau 30 phút không thao tác (expiresAt)
+// ============================================================
+func (h *EventHandler) HandleClaimEventRequest(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	userID, err := permission.Require(ctx, permission.EventRequestReview)
+	if errors.Is(err, authctx.ErrUnauthenticated) {
+		return createMessageResponse(http.StatusUnauthorized, "Unauthorized")
+	}
+	if err != nil {
+		return createMessageResponse(http.StatusForbidden, "STAFF or ADMIN access required")
+	}
+	requestID, err := strconv.Atoi(request.PathParameters["id"])
+	if err != nil || requestID <= 0 {
+		return createMessageResponse(http.StatusBadRequest, "Invalid request ID")
+	}
+
+	claim, err := h.useCase.ClaimEventRequest(ctx, requestID, userID)
+	if err != nil {
+		return claimErrorResponse(err)
+	}
+	return createJSONResponse(http.StatusOK, claim)
+}
+
+// ============================================================
+// HandleReleaseEventRequest - POST /api/staff/event-requests/{id}/release
+// Nhả claim của mình; người có quyền event.manage_any nhả được claim của người khác
+// ============================================================
+func (h *EventHandler) HandleReleaseEventRequest(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	userID, err := permission.Require(ctx, permission.EventRequestReview)
+	if errors.Is(err, authctx.ErrUnauthenticated) {
+		return createMessageResponse(http.StatusUnauthorized, "Unauthorized")
+	}
+	if err != nil {
+		return createMessageResponse(http.StatusForbidden, "STAFF or ADMIN access required")
+	}
+	requestID, err := strconv.Atoi(request.PathParameters["id"])
+	if err != nil || requestID <= 0 {
+		return createMessageResponse(http.StatusBadRequest, "Invalid request ID")
+	}
+
+	if err := h.useCase.ReleaseEventRequestClaim(ctx, requestID, userID, authctx.Role(ctx)); err != nil {
+		return claimErrorResponse(err)
+	}
+	return createMessageResponse(http.StatusOK, "Claim released")
+}
+
+// claimErrorResponse - Lỗi claim dùng chung cho claim/release và duyệt yêu cầu
+func claimErrorResponse(err error) (events.APIGatewayProxyResponse, error) {
+	switch {
+	case errors.Is(err, campus.ErrOutOfScope):
+		return campusErrorResponse(err)
+	case errors.Is(err, repository.ErrEventRequestNotFound),
+		errors.Is(err, repository.ErrCampusSourceNotFound):
+		return createMessageResponse(http.StatusNotFound, "Event request not found")
+	case errors.Is(err, repository.ErrRequestClaimedByOther),
+		errors.Is(err, repository.ErrRequestNotClaimable),
+		errors.Is(err, repository.ErrClaimNotHeld):
+		return createMessageResponse(http.StatusConflict, err.Error())
+	}
+	fmt.Printf("[ERROR] Event request claim failed: %v\n", err)
+	return createMessageResponse(http.StatusInternalServerError, "Error updating event request claim")
+}
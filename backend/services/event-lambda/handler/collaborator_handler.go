@@ -0,0 +1,142 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/fpt-event-services/common/authctx"
+	"github.com/fpt-event-services/common/permission"
+	"github.com/fpt-event-services/services/event-lambda/models"
+	"github.com/fpt-event-services/services/event-lambda/repository"
+	"github.com/fpt-event-services/services/event-lambda/usecase"
+)
+
+// ============================================================
+// HandleEventCollaborators - GET|POST /api/events/{id}/collaborators
+// GET: danh sách co-organizer; POST: mời / cập nhật quyền theo email
+// Body POST: {"email": "...", "permissions": ["EDIT_DETAILS","VIEW_STATS","MANAGE_ANNOUNCEMENTS"]}
+// Chỉ chủ sự kiện hoặc ADMIN
+// ============================================================
+func (h *EventHandler) HandleEventCollaborators(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	role := authctx.Role(ctx)
+	if !permission.Has(ctx, permission.EventRequestCreate) {
+		return createMessageResponse(http.StatusForbidden, "Only Organizer or Admin can manage collaborators")
+	}
+	userID, ok := authctx.UserID(ctx)
+	if !ok {
+		return createMessageResponse(http.StatusUnauthorized, "User ID not found in request")
+	}
+	eventID, err := strconv.Atoi(request.PathParameters["id"])
+	if err != nil || eventID <= 0 {
+		return createMessageResponse(http.StatusBadRequest, "Invalid event ID")
+	}
+
+	if request.HTTPMethod == http.MethodGet {
+		collaborators, err := h.useCase.ListCollaborators(ctx, eventID, userID, role)
+		if err != nil {
+			return collaboratorErrorResponse(err)
+		}
+		return createJSONResponse(http.StatusOK, collaborators)
+	}
+
+	var req models.InviteCollaboratorRequest
+	if err := json.Unmarshal([]byte(request.Body), &req); err != nil {
+		return createMessageResponse(http.StatusBadRequest, "Invalid request body")
+	}
+	collaborator, err := h.useCase.InviteCollaborator(ctx, eventID, userID, role, &req)
+	if err != nil {
+		return collaboratorErrorResponse(err)
+	}
+	return createJSONResponse(http.StatusOK, collaborator)
+}
+
+// ============================================================
+// HandleRemoveCollaborator - DELETE /api/events/{id}/collaborators/{userId}
+// Chủ sự kiện/ADMIN gỡ co-organizer, hoặc co-organizer tự rời (userId = chính mình)
+// ============================================================
+func (h *EventHandler) HandleRemoveCollaborator(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	role := authctx.Role(ctx)
+	userID, ok := authctx.UserID(ctx)
+	if !ok {
+		return createMessageResponse(http.StatusUnauthorized, "User ID not found in request")
+	}
+	eventID, err := strconv.Atoi(request.PathParameters["id"])
+	if err != nil || eventID <= 0 {
+		return createMessageResponse(http.StatusBadRequest, "Invalid event ID")
+	}
+	collaboratorID, err := strconv.Atoi(request.PathParameters["userId"])
+	if err != nil || collaboratorID <= 0 {
+		return createMessageResponse(http.StatusBadRequest, "Invalid user ID")
+	}
+
+	if err := h.useCase.RemoveCollaborator(ctx, eventID, collaboratorID, userID, role); err != nil {
+		return collaboratorErrorResponse(err)
+	}
+	return createMessageResponse(http.StatusOK, "Collaborator removed")
+}
+
+// ============================================================
+// HandleAcceptCollaboration - POST /api/events/{id}/collaborators/accept
+// Organizer được mời chấp nhận lời mời đồng tổ chức
+// ============================================================
+func (h *EventHandler) HandleAcceptCollaboration(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	if authctx.Role(ctx) != "ORGANIZER" {
+		return createMessageResponse(http.StatusForbidden, "Only Organizer can accept collaboration invitations")
+	}
+	userID, ok := authctx.UserID(ctx)
+	if !ok {
+		return createMessageResponse(http.StatusUnauthorized, "User ID not found in request")
+	}
+	eventID, err := strconv.Atoi(request.PathParameters["id"])
+	if err != nil || eventID <= 0 {
+		return createMessageResponse(http.StatusBadRequest, "Invalid event ID")
+	}
+
+	if err := h.useCase.AcceptCollaboration(ctx, eventID, userID); err != nil {
+		return collaboratorErrorResponse(err)
+	}
+	return createMessageResponse(http.StatusOK, "Invitation accepted")
+}
+
+// ============================================================
+// HandleGetCollaborationInvitations - GET /api/organizer/collaborations
+// Lời mời đồng tổ chức đang chờ của organizer hiện tại
+// ============================================================
+func (h *EventHandler) HandleGetCollaborationInvitations(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	if authctx.Role(ctx) != "ORGANIZER" {
+		return createMessageResponse(http.StatusForbidden, "ORGANIZER access required")
+	}
+	userID, ok := authctx.UserID(ctx)
+	if !ok {
+		return createMessageResponse(http.StatusUnauthorized, "User ID not found in request")
+	}
+
+	invitations, err := h.useCase.GetCollaborationInvitations(ctx, userID)
+	if err != nil {
+		return collaboratorErrorResponse(err)
+	}
+	return createJSONResponse(http.StatusOK, invitations)
+}
+
+// collaboratorErrorResponse map lỗi nghiệp vụ co-organizer sang HTTP status
+func collaboratorErrorResponse(err error) (events.APIGatewayProxyResponse, error) {
+	switch {
+	case errors.Is(err, repository.ErrEventNotFound):
+		return createMessageResponse(http.StatusNotFound, "Event not found")
+	case errors.Is(err, repository.ErrCollaboratorNotFound), errors.Is(err, repository.ErrNoPendingInvitation):
+		return createMessageResponse(http.StatusNotFound, err.Error())
+	case errors.Is(err, repository.ErrNotEventOwner):
+		return createMessageResponse(http.StatusForbidden, err.Error())
+	case errors.Is(err, repository.ErrInviteeNotOrganizer), errors.Is(err, repository.ErrInviteeIsOwner):
+		return createMessageResponse(http.StatusUnprocessableEntity, err.Error())
+	case errors.Is(err, usecase.ErrInvalidCollaboratorRequest):
+		return createMessageResponse(http.StatusBadRequest, err.Error())
+	}
+	fmt.Printf("[ERROR] Collaborator operation failed: %v\n", err)
+	return createMessageResponse(http.StatusInternalServerError, "Error managing collaborators")
+}
@@ -0,0 +1,24 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/fpt-event-services/services/event-lambda/models"
+	"github.com/fpt-event-services/services/event-lambda/usecase"
+)
+
+// ============================================================
+// HandleEventDynamicQR - GET|PUT /api/events/{id}/dynamic-qr
+// Bật thì check-in chỉ nhận mã QR xoay vòng 30 giây lấy trong app, QR tĩnh bị từ chối
+// Body PUT: {"enabled": true}
+// ============================================================
+func (h *EventHandler) HandleEventDynamicQR(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	return serveEventSettings(ctx, request, eventSettings[models.UpdateDynamicQRRequest, *models.DynamicQRSettings]{
+		name:        "dynamic QR",
+		get:         h.useCase.GetDynamicQR,
+		update:      h.useCase.UpdateDynamicQR,
+		errorStatus: map[error]int{usecase.ErrInvalidDynamicQR: http.StatusBadRequest},
+	})
+}
@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/fpt-event-services/common/authctx"
+	"github.com/fpt-event-services/services/event-lambda/models"
+	"github.com/fpt-event-services/services/event-lambda/repository"
+	"github.com/fpt-event-services/services/event-lambda/usecase"
+)
+
+// eventSettings - Một endpoint cấu hình của sự kiện (/api/events/{id}/...) chạy trên serveEventSettings
+// Quyền do usecase kiểm tra (requireEditDetails), handler chỉ parse, gọi và map lỗi
+type eventSettings[Req, Resp any] struct {
+	name         string // Tên chức năng trong log và lỗi 500 ("cashback", "seat prices"...)
+	get          func(ctx context.Context, eventID, userID int, role string) (Resp, error)
+	update       func(ctx context.Context, eventID, userID int, role string, req Req) (Resp, error)
+	updateStatus int           // HTTP status khi update thành công, 0 = 200
+	errorStatus  map[error]int // Lỗi riêng của chức năng -> HTTP status (lỗi validate -> 400...)
+}
+
+// ============================================================
+// serveEventSettings - GET đọc cấu hình, method khác (PUT / POST) decode body thành Req rồi update
+// Trả về Resp dạng JSON; lỗi map qua editDetailsErrorResponse
+// ============================================================
+func serveEventSettings[Req, Resp any](ctx context.Context, request events.APIGatewayProxyRequest, s eventSettings[Req, Resp]) (events.APIGatewayProxyResponse, error) {
+	userID, ok := authctx.UserID(ctx)
+	if !ok {
+		return createMessageResponse(http.StatusUnauthorized, "User ID not found in request")
+	}
+	role := authctx.Role(ctx)
+	eventID, err := strconv.Atoi(request.PathParameters["id"])
+	if err != nil || eventID <= 0 {
+		return createMessageResponse(http.StatusBadRequest, "Invalid event ID")
+	}
+
+	if request.HTTPMethod == http.MethodGet {
+		resp, err := s.get(ctx, eventID, userID, role)
+		if err != nil {
+			return editDetailsErrorResponse(s.name, err, s.errorStatus)
+		}
+		return createJSONResponse(http.StatusOK, resp)
+	}
+
+	var req Req
+	if err := json.Unmarshal([]byte(request.Body), &req); err != nil {
+		return createMessageResponse(http.StatusBadRequest, "Invalid request body")
+	}
+	resp, err := s.update(ctx, eventID, userID, role, req)
+	if err != nil {
+		return editDetailsErrorResponse(s.name, err, s.errorStatus)
+	}
+	status := s.updateStatus
+	if status == 0 {
+		status = http.StatusOK
+	}
+	return createJSONResponse(status, resp)
+}
+
+// editDetailsErrorResponse map lỗi của chức năng cần quyền sửa chi tiết sự kiện sang HTTP status:
+// sự kiện không tồn tại 404, không đủ quyền 403, lỗi điều kiện sự kiện như eligibilityErrorResponse,
+// lỗi riêng theo errorStatus; còn lại 500 kèm log
+func editDetailsErrorResponse(name string, err error, errorStatus map[error]int) (events.APIGatewayProxyResponse, error) {
+	var eligibilityErr *models.EligibilityError
+	switch {
+	case errors.As(err, &eligibilityErr):
+		return eligibilityErrorResponse(eligibilityErr)
+	case errors.Is(err, repository.ErrEventNotFound):
+		return createMessageResponse(http.StatusNotFound, "Event not found")
+	case errors.Is(err, usecase.ErrEditDetailsForbidden):
+		return createMessageResponse(http.StatusForbidden, err.Error())
+	}
+	for target, status := range errorStatus {
+		if errors.Is(err, target) {
+			return createMessageResponse(status, err.Error())
+		}
+	}
+	fmt.Printf("[ERROR] %s operation failed: %v\n", name, err)
+	return createMessageResponse(http.StatusInternalServerError, "Error managing "+name)
+}
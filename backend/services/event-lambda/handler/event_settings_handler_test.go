@@ -0,0 +1,35 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/fpt-event-services/services/event-lambda/repository"
+	"github.com/fpt-event-services/services/event-lambda/usecase"
+)
+
+func TestEditDetailsErrorResponse(t *testing.T) {
+	errInvalid := errors.New("invalid settings")
+	errorStatus := map[error]int{errInvalid: http.StatusBadRequest}
+
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"event not found", fmt.Errorf("load: %w", repository.ErrEventNotFound), http.StatusNotFound},
+		{"forbidden", usecase.ErrEditDetailsForbidden, http.StatusForbidden},
+		{"feature error", fmt.Errorf("%w: percent", errInvalid), http.StatusBadRequest},
+		{"unexpected", errors.New("connection reset"), http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, _ := editDetailsErrorResponse("settings", tt.err, errorStatus)
+			if resp.StatusCode != tt.want {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/fpt-event-services/common/authctx"
+	"github.com/fpt-event-services/common/permission"
+	"github.com/fpt-event-services/services/event-lambda/models"
+	"github.com/fpt-event-services/services/event-lambda/repository"
+	"github.com/fpt-event-services/services/event-lambda/usecase"
+)
+
+// ============================================================
+// HandleEventTemplates - GET|POST /api/event-templates
+// GET: mẫu chung + mẫu của mình; POST: tạo mẫu ("global": true cần quyền event.template.manage)
+// Body POST: {"name": "Workshop hằng tháng", "titlePattern": "Workshop AI tháng {month}/{year}",
+// "description": "...", "defaultCapacity": 80, "tickets": [{"name": "Standard", "price": 0, "maxQuantity": 80}]}
+// ============================================================
+func (h *EventHandler) HandleEventTemplates(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	userID, ok := authctx.UserID(ctx)
+	if !ok {
+		return createMessageResponse(http.StatusUnauthorized, "User ID not found in request")
+	}
+	if !permission.Has(ctx, permission.EventRequestCreate) && !permission.Has(ctx, permission.EventTemplateManage) {
+		return createMessageResponse(http.StatusForbidden, "Only Organizer or Admin can use event templates")
+	}
+	role := authctx.Role(ctx)
+
+	if request.HTTPMethod == http.MethodGet {
+		templates, err := h.useCase.ListEventTemplates(ctx, userID, role)
+		if err != nil {
+			return eventTemplateErrorResponse(err)
+		}
+		return createJSONResponse(http.StatusOK, templates)
+	}
+
+	var req models.EventTemplateRequest
+	if err := json.Unmarshal([]byte(request.Body), &req); err != nil {
+		return createMessageResponse(http.StatusBadRequest, "Invalid request body")
+	}
+	tpl, err := h.useCase.CreateEventTemplate(ctx, userID, role, &req)
+	if err != nil {
+		return eventTemplateErrorResponse(err)
+	}
+	return createJSONResponse(http.StatusCreated, tpl)
+}
+
+// ============================================================
+// HandleEventTemplate - PUT|DELETE /api/event-templates/{id}
+// PUT: ghi đè nội dung mẫu; DELETE: lưu trữ mẫu (request đã tạo không bị ảnh hưởng)
+// Người tạo mẫu riêng hoặc người có quyền event.template.manage
+// ============================================================
+func (h *EventHandler) HandleEventTemplate(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	userID, ok := authctx.UserID(ctx)
+	if !ok {
+		return createMessageResponse(http.StatusUnauthorized, "User ID not found in request")
+	}
+	role := authctx.Role(ctx)
+	templateID, err := strconv.Atoi(request.PathParameters["id"])
+	if err != nil || templateID <= 0 {
+		return createMessageResponse(http.StatusBadRequest, "Invalid template ID")
+	}
+
+	if request.HTTPMethod == http.MethodDelete {
+		if err := h.useCase.DeleteEventTemplate(ctx, templateID, userID, role); err != nil {
+			return eventTemplateErrorResponse(err)
+		}
+		return createMessageResponse(http.StatusOK, "Event template archived")
+	}
+
+	var req models.EventTemplateRequest
+	if err := json.Unmarshal([]byte(request.Body), &req); err != nil {
+		return createMessageResponse(http.StatusBadRequest, "Invalid request body")
+	}
+	tpl, err := h.useCase.UpdateEventTemplate(ctx, templateID, userID, role, &req)
+	if err != nil {
+		return eventTemplateErrorResponse(err)
+	}
+	return createJSONResponse(http.StatusOK, tpl)
+}
+
+// ============================================================
+// HandleCreateEventRequestFromTemplate - POST /api/event-requests/from-template/{id}
+// Tạo yêu cầu PENDING từ mẫu; cơ cấu vé của mẫu được áp dụng khi yêu cầu được duyệt
+// Body: {"preferredStartTime": "...", "preferredEndTime": "...", "title"?, "description"?, "expectedCapacity"?, "budget"?}
+// ============================================================
+func (h *EventHandler) HandleCreateEventRequestFromTemplate(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	userID, err := permission.Require(ctx, permission.EventRequestCreate)
+	if errors.Is(err, authctx.ErrUnauthenticated) {
+		return createMessageResponse(http.StatusUnauthorized, "Unauthorized")
+	}
+	if err != nil {
+		return createMessageResponse(http.StatusForbidden, "Only Organizer or Admin can create event requests")
+	}
+	templateID, err := strconv.Atoi(request.PathParameters["id"])
+	if err != nil || templateID <= 0 {
+		return createMessageResponse(http.StatusBadRequest, "Invalid template ID")
+	}
+
+	var req models.FromTemplateRequestBody
+	if err := json.Unmarshal([]byte(request.Body), &req); err != nil {
+		return createMessageResponse(http.StatusBadRequest, "Invalid request body")
+	}
+	if req.PreferredStartTime == "" || req.PreferredEndTime == "" {
+		return createMessageResponse(http.StatusBadRequest, "Start time and end time are required")
+	}
+	startTime, err := ParseEventTime(req.PreferredStartTime)
+	if err != nil {
+		return createMessageResponse(http.StatusBadRequest, "Invalid start time format")
+	}
+	endTime, err := ParseEventTime(req.PreferredEndTime)
+	if err != nil {
+		return createMessageResponse(http.StatusBadRequest, "Invalid end time format")
+	}
+	if err := ValidateEventTime(ctx, startTime, endTime); err != nil {
+		return createMessageResponse(http.StatusBadRequest, err.Error())
+	}
+
+	requestID, err := h.useCase.CreateEventRequestFromTemplate(ctx, userID, authctx.Role(ctx), templateID, startTime, &req)
+	if err != nil {
+		return eventTemplateErrorResponse(err)
+	}
+	return createJSONResponse(http.StatusOK, map[string]interface{}{
+		"message":    "Event request created from template",
+		"requestId":  requestID,
+		"templateId": templateID,
+	})
+}
+
+func eventTemplateErrorResponse(err error) (events.APIGatewayProxyResponse, error) {
+	switch {
+	case errors.Is(err, repository.ErrEventTemplateNotFound):
+		return createMessageResponse(http.StatusNotFound, err.Error())
+	case errors.Is(err, usecase.ErrEventTemplateForbidden):
+		return createMessageResponse(http.StatusForbidden, err.Error())
+	case errors.Is(err, usecase.ErrInvalidEventTemplate),
+		errors.Is(err, usecase.ErrInvalidContent),
+		errors.Is(err, usecase.ErrInvalidBudgetRequest):
+		return createMessageResponse(http.StatusBadRequest, err.Error())
+	}
+	fmt.Printf("[ERROR] Event template operation failed: %v\n", err)
+	return createMessageResponse(http.StatusInternalServerError, "Error processing event template")
+}
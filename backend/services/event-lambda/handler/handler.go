@@ -2,14 +2,25 @@ package handler
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/aws/aws-lambda-go/events"
+	"github.com/fpt-event-services/common/amenity"
+	"github.com/fpt-event-services/common/authctx"
+	"github.com/fpt-event-services/common/campus"
+	"github.com/fpt-event-services/common/capability"
+	"github.com/fpt-event-services/common/imageproc"
+	"github.com/fpt-event-services/common/pagination"
+	"github.com/fpt-event-services/common/permission"
 	"github.com/fpt-event-services/services/event-lambda/models"
+	"github.com/fpt-event-services/services/event-lambda/repository"
 	"github.com/fpt-event-services/services/event-lambda/usecase"
 )
 
@@ -38,18 +49,9 @@ func NewEventHandler() *EventHandler {
 // - Nếu Role == 'ORGANIZER': Chỉ trả về các sự kiện có organizer_id == userID
 // - Nếu Role == ” (public/guest): Trả về toàn bộ danh sách
 func (h *EventHandler) HandleGetEvents(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
-	// Extract user info from headers (set by authMiddleware)
-	role := request.Headers["X-User-Role"]
-	userIDStr := request.Headers["X-User-Id"]
-	userID := 0
-
-	if userIDStr != "" {
-		var err error
-		userID, err = strconv.Atoi(userIDStr)
-		if err != nil {
-			fmt.Printf("[PERMISSION] Invalid X-User-Id: %s\n", userIDStr)
-		}
-	}
+	// Extract user info from context (set by authMiddleware)
+	role := authctx.Role(ctx)
+	userID, _ := authctx.UserID(ctx)
 
 	// Default to public access if role is empty (guest/not logged in)
 	if role == "" {
@@ -58,9 +60,15 @@ func (h *EventHandler) HandleGetEvents(ctx context.Context, request events.APIGa
 
 	fmt.Printf("[PERMISSION] HandleGetEvents - Role=%s, UserID=%d\n", role, userID)
 
+	// ?campusId= lọc theo campus; STAFF/ADMIN gắn campus chỉ xem campus của mình
+	campusID, err := resolveCampusParam(ctx, request)
+	if err != nil {
+		return campusErrorResponse(err)
+	}
+
 	// Get all events separated by status (khớp với Java)
 	// Pass role and userID for permission filtering
-	openEvents, closedEvents, err := h.useCase.GetAllEventsSeparated(ctx, role, userID)
+	openEvents, closedEvents, err := h.useCase.GetAllEventsSeparated(ctx, role, userID, campusID)
 	if err != nil {
 		// ✅ Log chi tiết lỗi để debug
 		fmt.Printf("❌ ERROR GetAllEventsSeparated: %v\n", err)
@@ -91,9 +99,20 @@ func (h *EventHandler) HandleGetEvents(ctx context.Context, request events.APIGa
 }
 
 // HandleGetOpenEvents handles GET /api/events/open
-// Trả về danh sách events có status OPEN
+// Trả về danh sách events có status OPEN (?campusId= để lọc theo campus)
+// ?hasSeats=true / ?weekend=true / ?freeOnly=true: chỉ sự kiện còn chỗ / diễn ra cuối tuần này / miễn phí
 func (h *EventHandler) HandleGetOpenEvents(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
-	events, err := h.useCase.GetOpenEvents(ctx)
+	campusID, err := resolveCampusParam(ctx, request)
+	if err != nil {
+		return campusErrorResponse(err)
+	}
+
+	avail, err := parseAvailabilityFilter(request.QueryStringParameters)
+	if err != nil {
+		return createMessageResponse(http.StatusBadRequest, err.Error())
+	}
+
+	events, err := h.useCase.GetOpenEvents(ctx, campusID, avail)
 	if err != nil {
 		return createMessageResponse(http.StatusInternalServerError, "Error loading open events")
 	}
@@ -105,8 +124,28 @@ func (h *EventHandler) HandleGetOpenEvents(ctx context.Context, request events.A
 	return createJSONResponse(http.StatusOK, events)
 }
 
+// parseAvailabilityFilter - Đọc hasSeats / weekend / freeOnly (true/false/1/0, bỏ trống = false)
+func parseAvailabilityFilter(params map[string]string) (usecase.AvailabilityFilter, error) {
+	var f usecase.AvailabilityFilter
+	for name, dst := range map[string]*bool{"hasSeats": &f.HasSeats, "weekend": &f.Weekend, "freeOnly": &f.FreeOnly} {
+		v := params[name]
+		if v == "" {
+			continue
+		}
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return f, fmt.Errorf("%s must be true or false", name)
+		}
+		*dst = b
+	}
+	return f, nil
+}
+
 // HandleGetEventDetail handles GET /api/events/detail?id={eventId}
 // Response format khớp với Java: trả trực tiếp EventDetailDto object
+// Chủ sự kiện / co-organizer có quyền sửa, STAFF, ADMIN nhận bản đầy đủ;
+// khách và sinh viên nhận EventDetailPublicDto (không có liên hệ speaker, hasBookings;
+// tài liệu TICKET_HOLDERS chỉ hiện với người có vé)
 func (h *EventHandler) HandleGetEventDetail(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
 	// Get event ID from query parameter (khớp với Java: ?id=...)
 	eventIDStr := request.QueryStringParameters["id"]
@@ -129,8 +168,36 @@ func (h *EventHandler) HandleGetEventDetail(ctx context.Context, request events.
 		return createMessageResponse(http.StatusNotFound, "Event not found")
 	}
 
+	full, err := h.canViewFullEventDetail(ctx, eventID)
+	if err != nil {
+		return createMessageResponse(http.StatusInternalServerError, "Error loading event detail")
+	}
+	if !full {
+		public := event.Public()
+		// Người có vé của sự kiện thấy thêm tài liệu TICKET_HOLDERS
+		if userID, ok := authctx.UserID(ctx); ok {
+			holder, err := h.useCase.IsEventTicketHolder(ctx, eventID, userID)
+			if err != nil {
+				return createMessageResponse(http.StatusInternalServerError, "Error loading event detail")
+			}
+			public.Attachments = models.VisibleAttachments(event.Attachments, holder)
+		}
+		return createJSONResponse(http.StatusOK, public)
+	}
+
 	// Trả trực tiếp object (khớp với Java Backend)
-	return createJSONResponse(http.StatusOK, event)
+	// Bản đầy đủ (người có quyền sửa) kèm ETag theo version để gửi lại qua If-Match
+	resp, err := createJSONResponse(http.StatusOK, event)
+	return withVersionTag(resp, err, event.Version)
+}
+
+// canViewFullEventDetail - Khách chưa đăng nhập luôn nhận bản public
+func (h *EventHandler) canViewFullEventDetail(ctx context.Context, eventID int) (bool, error) {
+	userID, ok := authctx.UserID(ctx)
+	if !ok {
+		return false, nil
+	}
+	return h.useCase.CanViewFullEventDetail(ctx, eventID, userID, authctx.Role(ctx))
 }
 
 // createJSONResponse creates a JSON response (trả trực tiếp data, không wrap)
@@ -140,9 +207,7 @@ func createJSONResponse(statusCode int, data interface{}) (events.APIGatewayProx
 		return events.APIGatewayProxyResponse{
 			StatusCode: http.StatusInternalServerError,
 			Headers: map[string]string{
-				"Content-Type":                     "application/json;charset=UTF-8",
-				"Access-Control-Allow-Origin":      "*",
-				"Access-Control-Allow-Credentials": "true",
+				"Content-Type": "application/json;charset=UTF-8",
 			},
 			Body: `{"message":"Failed to serialize response"}`,
 		}, nil
@@ -151,9 +216,7 @@ func createJSONResponse(statusCode int, data interface{}) (events.APIGatewayProx
 	return events.APIGatewayProxyResponse{
 		StatusCode: statusCode,
 		Headers: map[string]string{
-			"Content-Type":                     "application/json;charset=UTF-8",
-			"Access-Control-Allow-Origin":      "*",
-			"Access-Control-Allow-Credentials": "true",
+			"Content-Type": "application/json;charset=UTF-8",
 		},
 		Body: string(body),
 	}, nil
@@ -168,9 +231,7 @@ func createMessageResponse(statusCode int, message string) (events.APIGatewayPro
 	return events.APIGatewayProxyResponse{
 		StatusCode: statusCode,
 		Headers: map[string]string{
-			"Content-Type":                     "application/json;charset=UTF-8",
-			"Access-Control-Allow-Origin":      "*",
-			"Access-Control-Allow-Credentials": "true",
+			"Content-Type": "application/json;charset=UTF-8",
 		},
 		Body: string(body),
 	}, nil
@@ -183,15 +244,12 @@ func createMessageResponse(statusCode int, message string) (events.APIGatewayPro
 // ============================================================
 func (h *EventHandler) HandleCreateEventRequest(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
 	// Get user ID from request context (set by auth middleware)
-	userIDStr := request.Headers["X-User-Id"]
-	if userIDStr == "" {
+	userID, ok := authctx.UserID(ctx)
+	if !ok {
 		return createMessageResponse(http.StatusUnauthorized, "Unauthorized")
 	}
-	userID, _ := strconv.Atoi(userIDStr)
 
-	// Check role (ORGANIZER only)
-	role := request.Headers["X-User-Role"]
-	if role != "ORGANIZER" && role != "ADMIN" {
+	if !permission.Has(ctx, permission.EventRequestCreate) {
 		return createMessageResponse(http.StatusForbidden, "Only ORGANIZER can create event requests")
 	}
 
@@ -225,7 +283,7 @@ func (h *EventHandler) HandleCreateEventRequest(ctx context.Context, request eve
 
 	// Validate event time rules
 	log.Printf("[HandleCreateEventRequest] Validating event time rules...")
-	if err := ValidateEventTime(startTime, endTime); err != nil {
+	if err := ValidateEventTime(ctx, startTime, endTime); err != nil {
 		log.Printf("[HandleCreateEventRequest] Time validation failed: %v", err.Error())
 		return createMessageResponse(http.StatusBadRequest, err.Error())
 	}
@@ -233,6 +291,9 @@ func (h *EventHandler) HandleCreateEventRequest(ctx context.Context, request eve
 
 	// Create event request
 	requestID, err := h.useCase.CreateEventRequest(ctx, userID, &req)
+	if errors.Is(err, usecase.ErrInvalidBudgetRequest) || errors.Is(err, usecase.ErrInvalidContent) || errors.Is(err, amenity.ErrUnknown) {
+		return createMessageResponse(http.StatusBadRequest, err.Error())
+	}
 	if err != nil {
 		log.Printf("[HandleCreateEventRequest] Failed to create event request: %v", err)
 		return createMessageResponse(http.StatusInternalServerError, "Error creating event request")
@@ -252,11 +313,10 @@ func (h *EventHandler) HandleCreateEventRequest(ctx context.Context, request eve
 // ============================================================
 func (h *EventHandler) HandleGetMyEventRequests(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
 	// Get user ID from request context
-	userIDStr := request.Headers["X-User-Id"]
-	if userIDStr == "" {
+	userID, ok := authctx.UserID(ctx)
+	if !ok {
 		return createMessageResponse(http.StatusUnauthorized, "Unauthorized")
 	}
-	userID, _ := strconv.Atoi(userIDStr)
 
 	// Get event requests
 	requests, err := h.useCase.GetMyEventRequests(ctx, userID)
@@ -275,88 +335,48 @@ func (h *EventHandler) HandleGetMyEventRequests(ctx context.Context, request eve
 // HandleGetMyActiveEventRequests - GET /api/event-requests/my/active
 // Lấy yêu cầu hoạt động (tab "Chờ")
 // Active = (PENDING OR UPDATING) OR (APPROVED AND endTime > NOW)
-// Query params: limit, offset
+// Query params: page, pageSize (limit / offset cũ vẫn nhận)
 // ============================================================
 func (h *EventHandler) HandleGetMyActiveEventRequests(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
 	// Get user ID from request context
-	userIDStr := request.Headers["X-User-Id"]
-	if userIDStr == "" {
+	userID, ok := authctx.UserID(ctx)
+	if !ok {
 		return createMessageResponse(http.StatusUnauthorized, "Unauthorized")
 	}
-	userID, _ := strconv.Atoi(userIDStr)
 
-	// Get limit and offset from query params (defaults: limit=10, offset=0)
-	limit := 10
-	offset := 0
-	if val, ok := request.QueryStringParameters["limit"]; ok {
-		if l, err := strconv.Atoi(val); err == nil {
-			limit = l
-		}
-	}
-	if val, ok := request.QueryStringParameters["offset"]; ok {
-		if o, err := strconv.Atoi(val); err == nil {
-			offset = o
-		}
-	}
+	p := pagination.ParseParams(request.QueryStringParameters, pagination.DefaultPageSize, pagination.MaxPageSize)
 
 	// Get active event requests
-	result, err := h.useCase.GetMyActiveEventRequests(ctx, userID, limit, offset)
+	page, err := h.useCase.GetMyActiveEventRequests(ctx, userID, p)
 	if err != nil {
 		return createMessageResponse(http.StatusInternalServerError, "Error loading active event requests")
 	}
 
-	if result == nil {
-		result = &usecase.MyActiveEventRequestsResult{
-			Requests:   []models.EventRequest{},
-			TotalCount: 0,
-		}
-	}
-
-	return createJSONResponse(http.StatusOK, result)
+	return createJSONResponse(http.StatusOK, page)
 }
 
 // ============================================================
 // HandleGetMyArchivedEventRequests - GET /api/event-requests/my/archived
 // Lấy yêu cầu đã lưu trữ (tab "Đã xử lý")
 // Archived = (REJECTED OR CANCELLED OR FINISHED) OR (APPROVED AND endTime <= NOW)
-// Query params: limit, offset
+// Query params: page, pageSize (limit / offset cũ vẫn nhận)
 // ============================================================
 func (h *EventHandler) HandleGetMyArchivedEventRequests(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
 	// Get user ID from request context
-	userIDStr := request.Headers["X-User-Id"]
-	if userIDStr == "" {
+	userID, ok := authctx.UserID(ctx)
+	if !ok {
 		return createMessageResponse(http.StatusUnauthorized, "Unauthorized")
 	}
-	userID, _ := strconv.Atoi(userIDStr)
 
-	// Get limit and offset from query params (defaults: limit=10, offset=0)
-	limit := 10
-	offset := 0
-	if val, ok := request.QueryStringParameters["limit"]; ok {
-		if l, err := strconv.Atoi(val); err == nil {
-			limit = l
-		}
-	}
-	if val, ok := request.QueryStringParameters["offset"]; ok {
-		if o, err := strconv.Atoi(val); err == nil {
-			offset = o
-		}
-	}
+	p := pagination.ParseParams(request.QueryStringParameters, pagination.DefaultPageSize, pagination.MaxPageSize)
 
 	// Get archived event requests
-	result, err := h.useCase.GetMyArchivedEventRequests(ctx, userID, limit, offset)
+	page, err := h.useCase.GetMyArchivedEventRequests(ctx, userID, p)
 	if err != nil {
 		return createMessageResponse(http.StatusInternalServerError, "Error loading archived event requests")
 	}
 
-	if result == nil {
-		result = &usecase.MyArchivedEventRequestsResult{
-			Requests:   []models.EventRequest{},
-			TotalCount: 0,
-		}
-	}
-
-	return createJSONResponse(http.StatusOK, result)
+	return createJSONResponse(http.StatusOK, page)
 }
 
 // ============================================================
@@ -387,27 +407,40 @@ func (h *EventHandler) HandleGetEventRequestByID(ctx context.Context, request ev
 		return createMessageResponse(http.StatusNotFound, "Event request not found")
 	}
 
-	return createJSONResponse(http.StatusOK, eventRequest)
+	// ETag theo version: người duyệt gửi lại qua If-Match khi approve/reject
+	resp, err := createJSONResponse(http.StatusOK, eventRequest)
+	return withVersionTag(resp, err, eventRequest.Version)
 }
 
 // ============================================================
 // HandleGetPendingEventRequests - GET /api/event-requests/pending
-// Lấy danh sách yêu cầu chờ duyệt (ADMIN/STAFF)
+// Lấy danh sách yêu cầu chờ duyệt (ADMIN/STAFF), kèm người đang nhận xử lý (assignedTo)
+// ?assignee=me: yêu cầu mình đang giữ; ?assignee=unassigned: yêu cầu chờ duyệt chưa ai nhận
 // KHỚP VỚI Java GetPendingEventRequestsController
 // ============================================================
 func (h *EventHandler) HandleGetPendingEventRequests(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
-	// Check role (ADMIN or STAFF)
-	role := request.Headers["X-User-Role"]
-	if role != "ADMIN" && role != "STAFF" {
+	if !permission.Has(ctx, permission.EventRequestReview) {
 		return createMessageResponse(http.StatusForbidden, "Admin or Staff access required")
 	}
 
+	// STAFF/ADMIN gắn campus chỉ thấy yêu cầu của campus mình
+	campusID, err := resolveCampusParam(ctx, request)
+	if err != nil {
+		return campusErrorResponse(err)
+	}
+
 	// Get pending event requests
-	requests, err := h.useCase.GetPendingEventRequests(ctx)
+	requests, err := h.useCase.GetPendingEventRequests(ctx, campusID)
 	if err != nil {
 		return createMessageResponse(http.StatusInternalServerError, "Error loading pending event requests")
 	}
 
+	userID, _ := authctx.UserID(ctx)
+	requests, err = usecase.FilterByAssignee(requests, request.QueryStringParameters["assignee"], userID)
+	if err != nil {
+		return createMessageResponse(http.StatusBadRequest, err.Error())
+	}
+
 	if requests == nil {
 		requests = []models.EventRequest{}
 	}
@@ -421,18 +454,15 @@ func (h *EventHandler) HandleGetPendingEventRequests(ctx context.Context, reques
 // KHỚP VỚI Java ProcessEventRequestController
 // ============================================================
 func (h *EventHandler) HandleProcessEventRequest(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
-	// Check role (STAFF or ADMIN)
-	role := request.Headers["X-User-Role"]
-	if role != "ADMIN" && role != "STAFF" {
+	if !permission.Has(ctx, permission.EventRequestReview) {
 		return createMessageResponse(http.StatusForbidden, "STAFF or ADMIN access required")
 	}
 
 	// Get staff/admin ID
-	userIDStr := request.Headers["X-User-Id"]
-	if userIDStr == "" {
+	userID, ok := authctx.UserID(ctx)
+	if !ok {
 		return createMessageResponse(http.StatusUnauthorized, "Unauthorized")
 	}
-	userID, _ := strconv.Atoi(userIDStr)
 
 	// Parse request body
 	var req models.ProcessEventRequestBody
@@ -447,13 +477,32 @@ func (h *EventHandler) HandleProcessEventRequest(ctx context.Context, request ev
 	if req.Action != "APPROVED" && req.Action != "REJECTED" {
 		return createMessageResponse(http.StatusBadRequest, "Action must be APPROVED or REJECTED")
 	}
+	expected, err := ifMatchVersion(request)
+	if resp, ok := preconditionErrorResponse(err); ok {
+		return resp, nil
+	}
+	req.ExpectedVersion = expected
 
 	// Log request details
 	fmt.Printf("[ProcessEventRequest] RequestID=%d, Action=%s, AreaID=%v, SpeakerID=%v\n",
 		req.RequestID, req.Action, req.AreaID, req.SpeakerID)
 
 	// Process event request
-	err := h.useCase.ProcessEventRequest(ctx, userID, &req)
+	err = h.useCase.ProcessEventRequest(ctx, userID, &req)
+	if resp, ok := preconditionErrorResponse(err); ok {
+		return resp, nil
+	}
+	if errors.Is(err, campus.ErrOutOfScope) {
+		return campusErrorResponse(err)
+	}
+	if errors.Is(err, repository.ErrCampusSourceNotFound) {
+		return createMessageResponse(http.StatusNotFound, "Event request or area not found")
+	}
+	if errors.Is(err, repository.ErrEventRequestNotFound) ||
+		errors.Is(err, repository.ErrRequestClaimedByOther) ||
+		errors.Is(err, repository.ErrRequestNotClaimable) {
+		return claimErrorResponse(err)
+	}
 	if err != nil {
 		fmt.Printf("[ERROR] ProcessEventRequest failed: %v\n", err)
 		return createMessageResponse(http.StatusInternalServerError, fmt.Sprintf("Error processing event request: %v", err))
@@ -473,18 +522,15 @@ func (h *EventHandler) HandleProcessEventRequest(ctx context.Context, request ev
 // Chỉ có thể thay đổi: speaker info, tickets, banner
 // ============================================================
 func (h *EventHandler) HandleUpdateEventRequest(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
-	// Check role - chỉ ORGANIZER hoặc ADMIN mới có thể update
-	role := request.Headers["X-User-Role"]
-	if role != "ORGANIZER" && role != "ADMIN" {
+	if !permission.Has(ctx, permission.EventRequestCreate) {
 		return createMessageResponse(http.StatusForbidden, "ORGANIZER access required")
 	}
 
 	// Get organizer ID
-	userIDStr := request.Headers["X-User-Id"]
-	if userIDStr == "" {
+	userID, ok := authctx.UserID(ctx)
+	if !ok {
 		return createMessageResponse(http.StatusUnauthorized, "Unauthorized")
 	}
-	userID, _ := strconv.Atoi(userIDStr)
 
 	// Parse request body
 	var req models.UpdateEventRequestRequest
@@ -507,16 +553,10 @@ func (h *EventHandler) HandleUpdateEventRequest(ctx context.Context, request eve
 
 	// ===== BUSINESS LOGIC GUARDS - Check if event can be updated =====
 	// Get the related event to check status and start time
-	eventEligible, eligibilityError := h.useCase.CheckEventUpdateEligibility(ctx, req.RequestID)
+	eventEligible, eligibilityError := h.useCase.CheckEventUpdateEligibility(ctx, req.RequestID, req.ChangedFields())
 	if eligibilityError != nil {
 		fmt.Printf("[ERROR] CheckEventUpdateEligibility failed: %v\n", eligibilityError)
-
-		// Parse error message to determine HTTP status code
-		if eligibilityError.Code == "EVENT_CLOSED" {
-			return createMessageResponse(http.StatusForbidden, eligibilityError.Message)
-		}
-		// Default to 400 for other validation errors
-		return createMessageResponse(http.StatusBadRequest, eligibilityError.Message)
+		return eligibilityErrorResponse(eligibilityError)
 	}
 
 	if !eventEligible {
@@ -582,6 +622,9 @@ func (h *EventHandler) HandleUpdateEventRequest(ctx context.Context, request eve
 
 	// Call use case to update request
 	err := h.useCase.UpdateEventRequest(ctx, userID, &req)
+	if errors.Is(err, usecase.ErrInvalidContent) || errors.Is(err, repository.ErrInsufficientSeats) {
+		return createMessageResponse(http.StatusBadRequest, err.Error())
+	}
 	if err != nil {
 		fmt.Printf("[ERROR] UpdateEventRequest failed: %v\n", err)
 		return createMessageResponse(http.StatusInternalServerError, fmt.Sprintf("Error updating event request: %v", err))
@@ -592,15 +635,22 @@ func (h *EventHandler) HandleUpdateEventRequest(ctx context.Context, request eve
 	})
 }
 
+// eligibilityErrorResponse - EVENT_CLOSED → 403, lỗi khác (EVENT_EDIT_LOCKED...) → 400
+// Body là nguyên EligibilityError (code, message, lockedFields, allowedFields, cutoffAt)
+func eligibilityErrorResponse(e *models.EligibilityError) (events.APIGatewayProxyResponse, error) {
+	if e.Code == models.EligibilityEventClosed {
+		return createJSONResponse(http.StatusForbidden, e)
+	}
+	return createJSONResponse(http.StatusBadRequest, e)
+}
+
 // ============================================================
 // HandleUpdateEvent - PUT /api/events/update
 // Cập nhật thông tin event (ORGANIZER/ADMIN)
 // KHỚP VỚI Java UpdateEventDetailController
 // ============================================================
 func (h *EventHandler) HandleUpdateEvent(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
-	// Check role
-	role := request.Headers["X-User-Role"]
-	if role != "ORGANIZER" && role != "ADMIN" {
+	if !permission.Has(ctx, permission.EventRequestCreate) {
 		return createMessageResponse(http.StatusForbidden, "Access denied")
 	}
 
@@ -630,13 +680,19 @@ func (h *EventHandler) HandleUpdateEvent(ctx context.Context, request events.API
 		}
 
 		// Validate event time rules
-		if err := ValidateEventTime(startTime, endTime); err != nil {
+		if err := ValidateEventTime(ctx, startTime, endTime); err != nil {
 			return createMessageResponse(http.StatusBadRequest, err.Error())
 		}
 	}
 
 	// Update event
 	err := h.useCase.UpdateEvent(ctx, &req)
+	if errors.Is(err, usecase.ErrInvalidContent) {
+		return createMessageResponse(http.StatusBadRequest, err.Error())
+	}
+	if errors.Is(err, capability.ErrUnimplemented) {
+		return createMessageResponse(http.StatusNotImplemented, "Use POST /api/events/update-details to update an event")
+	}
 	if err != nil {
 		return createMessageResponse(http.StatusInternalServerError, "Error updating event")
 	}
@@ -652,24 +708,18 @@ func (h *EventHandler) HandleUpdateEvent(ctx context.Context, request events.API
 // KHỚP VỚI Java UpdateEventDetailsController
 // ============================================================
 func (h *EventHandler) HandleUpdateEventDetails(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
-	// Check role - cho phép cả ADMIN và ORGANIZER
-	role := request.Headers["X-User-Role"]
-	userIDStr := request.Headers["X-User-Id"] // Note: Go canonicalizes to X-User-Id, not X-User-ID
+	// Check quyền - ADMIN và ORGANIZER có event.request.create
+	role := authctx.Role(ctx)
 
-	// ✅ FIX: Cho phép cả ADMIN và ORGANIZER (giống Java)
-	if role != "ORGANIZER" && role != "ADMIN" {
+	if !permission.Has(ctx, permission.EventRequestCreate) {
 		return createMessageResponse(http.StatusForbidden, "Only Organizer or Admin can update event details")
 	}
 
-	if userIDStr == "" {
+	userID, ok := authctx.UserID(ctx)
+	if !ok {
 		return createMessageResponse(http.StatusUnauthorized, "User ID not found in request")
 	}
 
-	userID, err := strconv.Atoi(userIDStr)
-	if err != nil {
-		return createMessageResponse(http.StatusUnauthorized, fmt.Sprintf("Invalid user ID: %s", userIDStr))
-	}
-
 	// Parse request body
 	var req models.UpdateEventDetailsRequest
 	if err := json.Unmarshal([]byte(request.Body), &req); err != nil {
@@ -680,6 +730,11 @@ func (h *EventHandler) HandleUpdateEventDetails(ctx context.Context, request eve
 	if req.EventID == 0 {
 		return createMessageResponse(http.StatusBadRequest, "Event ID is required")
 	}
+	expected, err := ifMatchVersion(request)
+	if resp, ok := preconditionErrorResponse(err); ok {
+		return resp, nil
+	}
+	req.ExpectedVersion = expected
 
 	// Update event details (speaker + tickets + banner)
 	// ✅ FIX: Pass role để Repository có thể bypass ownership check cho Admin
@@ -688,6 +743,25 @@ func (h *EventHandler) HandleUpdateEventDetails(ctx context.Context, request eve
 		// Log detailed error for debugging
 		fmt.Printf("[ERROR] UpdateEventDetails failed: %v\n", err)
 
+		if resp, ok := preconditionErrorResponse(err); ok {
+			return resp, nil
+		}
+
+		// Mốc khóa chỉnh sửa: trả lỗi có cấu trúc (trường bị khóa, mốc khóa)
+		var eligibilityErr *models.EligibilityError
+		if errors.As(err, &eligibilityErr) {
+			return eligibilityErrorResponse(eligibilityErr)
+		}
+
+		// Capacity guard: trả lỗi có cấu trúc (loại vé nào, đã bán bao nhiêu)
+		var capErr *models.CapacityChangeError
+		if errors.As(err, &capErr) {
+			return createJSONResponse(http.StatusConflict, capErr)
+		}
+		if errors.Is(err, usecase.ErrInvalidContent) || errors.Is(err, repository.ErrInsufficientSeats) {
+			return createMessageResponse(http.StatusBadRequest, err.Error())
+		}
+
 		// Check for specific error messages
 		errMsg := err.Error()
 		if errMsg == "event not found" {
@@ -699,6 +773,9 @@ func (h *EventHandler) HandleUpdateEventDetails(ctx context.Context, request eve
 		if errMsg == "event is not editable" {
 			return createMessageResponse(http.StatusBadRequest, "Event is not editable in current status")
 		}
+		if strings.Contains(errMsg, "does not belong to this event") || strings.Contains(errMsg, "is duplicated in request") {
+			return createMessageResponse(http.StatusBadRequest, errMsg)
+		}
 		// Return detailed error message for debugging
 		return createMessageResponse(http.StatusInternalServerError, fmt.Sprintf("Error updating event: %v", err))
 	}
@@ -713,23 +790,18 @@ func (h *EventHandler) HandleUpdateEventDetails(ctx context.Context, request eve
 // Cập nhật cấu hình check-in/out (ADMIN và ORGANIZER)
 // ============================================================
 func (h *EventHandler) HandleUpdateEventConfig(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
-	// Check role
-	role := request.Headers["X-User-Role"]
-	userIDStr := request.Headers["X-User-Id"]
+	// Check quyền
+	role := authctx.Role(ctx)
 
-	if role != "ADMIN" && role != "ORGANIZER" {
+	if !permission.Has(ctx, permission.EventRequestCreate) && !permission.Has(ctx, permission.EventManageAny) {
 		return createMessageResponse(http.StatusForbidden, "Only Admin or Organizer can update config")
 	}
 
-	if userIDStr == "" {
+	userID, ok := authctx.UserID(ctx)
+	if !ok {
 		return createMessageResponse(http.StatusUnauthorized, "User ID not found in request")
 	}
 
-	userID, err := strconv.Atoi(userIDStr)
-	if err != nil {
-		return createMessageResponse(http.StatusUnauthorized, fmt.Sprintf("Invalid user ID: %s", userIDStr))
-	}
-
 	// Parse request body
 	var req models.UpdateEventConfigRequest
 	if err := json.Unmarshal([]byte(request.Body), &req); err != nil {
@@ -747,15 +819,25 @@ func (h *EventHandler) HandleUpdateEventConfig(ctx context.Context, request even
 	// EventID = -1: Update global config (ADMIN only)
 	// EventID > 0: Update per-event config (ADMIN or ORGANIZER with ownership check)
 	if req.EventID == -1 {
-		if role != "ADMIN" {
+		if !permission.Has(ctx, permission.EventManageAny) {
 			return createMessageResponse(http.StatusForbidden, "Only Admin can update global config")
 		}
+	} else {
+		// If-Match theo version của Event (global config không có version)
+		expected, err := ifMatchVersion(request)
+		if resp, ok := preconditionErrorResponse(err); ok {
+			return resp, nil
+		}
+		req.ExpectedVersion = expected
 	}
 
 	// Update config
-	err = h.useCase.UpdateEventConfig(ctx, userID, role, &req)
+	err := h.useCase.UpdateEventConfig(ctx, userID, role, &req)
 	if err != nil {
 		fmt.Printf("[ERROR] UpdateEventConfig failed: %v\n", err)
+		if resp, ok := preconditionErrorResponse(err); ok {
+			return resp, nil
+		}
 		errMsg := err.Error()
 		if errMsg == "event not found" {
 			return createMessageResponse(http.StatusNotFound, "Event not found")
@@ -814,9 +896,7 @@ func (h *EventHandler) HandleGetEventConfig(ctx context.Context, request events.
 // KHỚP VỚI Java EventDisableController
 // ============================================================
 func (h *EventHandler) HandleDisableEvent(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
-	// Check role (ADMIN only)
-	role := request.Headers["X-User-Role"]
-	if role != "ADMIN" {
+	if !permission.Has(ctx, permission.EventManageAny) {
 		return createMessageResponse(http.StatusForbidden, "Admin access required")
 	}
 
@@ -830,9 +910,18 @@ func (h *EventHandler) HandleDisableEvent(ctx context.Context, request events.AP
 		return createMessageResponse(http.StatusBadRequest, "Invalid event ID")
 	}
 
+	adminID, _ := authctx.UserID(ctx)
+
 	// Disable event
-	err = h.useCase.DisableEvent(ctx, eventID)
+	err = h.useCase.DisableEvent(ctx, eventID, adminID)
 	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrEventNotFound):
+			return createMessageResponse(http.StatusNotFound, "Event not found")
+		case errors.Is(err, repository.ErrEventAlreadyDisabled), errors.Is(err, repository.ErrEventNotDisableable):
+			return createMessageResponse(http.StatusConflict, err.Error())
+		}
+		fmt.Printf("[ERROR] DisableEvent failed: %v\n", err)
 		return createMessageResponse(http.StatusInternalServerError, "Error disabling event")
 	}
 
@@ -853,15 +942,13 @@ func (h *EventHandler) HandleDisableEvent(ctx context.Context, request events.AP
 // ============================================================
 func (h *EventHandler) HandleGetEventStats(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
 	// Get authentication info
-	userIDStr := request.Headers["X-User-Id"]
-	role := request.Headers["X-User-Role"]
+	userID, ok := authctx.UserID(ctx)
+	role := authctx.Role(ctx)
 
-	if userIDStr == "" || role == "" {
+	if !ok || role == "" {
 		return createMessageResponse(http.StatusUnauthorized, "Unauthorized: Missing authentication")
 	}
 
-	userID, _ := strconv.Atoi(userIDStr)
-
 	// Get event ID from query parameter
 	eventIDStr := request.QueryStringParameters["eventId"]
 	if eventIDStr == "" {
@@ -904,14 +991,15 @@ func (h *EventHandler) HandleGetEventStats(ctx context.Context, request events.A
 	}
 
 	// ✅ ACCESS CONTROL: Kiểm tra quyền cho single event
-	if role != "ADMIN" && role != "STAFF" {
-		// ORGANIZER: Chỉ xem được event mình tạo
-		// Khác ADMIN/STAFF/ORGANIZER: Không được phép
-		if role == "ORGANIZER" {
-			// Check if organizer owns this event
-			ownsEvent, err := h.useCase.CheckEventOwnership(ctx, eventID, userID)
+	// Có event.request.review (ADMIN/STAFF): xem mọi event
+	if !permission.Has(ctx, permission.EventRequestReview) {
+		// Có event.stats.view (ORGANIZER): Chỉ xem được event mình tạo hoặc được cấp quyền VIEW_STATS
+		// Còn lại: Không được phép
+		if permission.Has(ctx, permission.EventStatsView) {
+			// Check if organizer owns this event (or is a co-organizer with VIEW_STATS)
+			ownsEvent, err := h.useCase.CheckEventPermission(ctx, eventID, userID, models.PermissionViewStats)
 			if err != nil {
-				fmt.Printf("[ERROR] CheckEventOwnership failed: %v\n", err)
+				fmt.Printf("[ERROR] CheckEventPermission failed: %v\n", err)
 				return createMessageResponse(http.StatusInternalServerError, "Error checking event ownership")
 			}
 			if !ownsEvent {
@@ -955,6 +1043,8 @@ func (h *EventHandler) HandleGetEventStats(ctx context.Context, request events.A
 // 💡 YÊU CẦU #4: Lấy danh sách địa điểm trống
 // Gợi ý những địa điểm đang thực sự trống trong khung giờ đó
 // KHỚP VỚI YÊU CẦU: "Khi Staff chọn địa điểm trong danh sách, hãy gợi ý..."
+// Tiện ích: amenities=PROJECTOR,STAGE hoặc requestId= (lấy required_amenities của yêu cầu),
+// khu vực đủ tiện ích xếp trước; matchAll=true chỉ giữ khu vực đủ tiện ích
 // ============================================================
 func (h *EventHandler) HandleGetAvailableAreas(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
 	// Get start and end time from query parameters
@@ -974,10 +1064,35 @@ func (h *EventHandler) HandleGetAvailableAreas(ctx context.Context, request even
 		}
 	}
 
-	fmt.Printf("[AVAILABLE AREAS] Query: startTime=%s, endTime=%s, expectedCapacity=%d\n", startTime, endTime, expectedCapacity)
+	campusID, err := resolveCampusParam(ctx, request)
+	if err != nil {
+		return campusErrorResponse(err)
+	}
+
+	requiredAmenities, err := amenity.Parse(request.QueryStringParameters["amenities"])
+	if err != nil {
+		return createMessageResponse(http.StatusBadRequest, "amenities không hợp lệ: "+err.Error())
+	}
+	if requestIDStr := request.QueryStringParameters["requestId"]; requestIDStr != "" && len(requiredAmenities) == 0 {
+		requestID, err := strconv.Atoi(requestIDStr)
+		if err != nil || requestID <= 0 {
+			return createMessageResponse(http.StatusBadRequest, "Invalid requestId")
+		}
+		eventRequest, err := h.useCase.GetEventRequestByID(ctx, requestID)
+		if err != nil {
+			return createMessageResponse(http.StatusInternalServerError, "Error loading event request")
+		}
+		if eventRequest == nil {
+			return createMessageResponse(http.StatusNotFound, "Event request not found")
+		}
+		requiredAmenities = eventRequest.RequiredAmenities
+	}
+	matchAll := request.QueryStringParameters["matchAll"] == "true"
+
+	fmt.Printf("[AVAILABLE AREAS] Query: startTime=%s, endTime=%s, expectedCapacity=%d, amenities=%v\n", startTime, endTime, expectedCapacity, requiredAmenities)
 
 	// Get available areas
-	areas, err := h.useCase.GetAvailableAreas(ctx, startTime, endTime, expectedCapacity)
+	areas, err := h.useCase.GetAvailableAreas(ctx, startTime, endTime, expectedCapacity, campusID, requiredAmenities, matchAll)
 	if err != nil {
 		fmt.Printf("[ERROR] Failed to get available areas: %v\n", err)
 		return createMessageResponse(http.StatusInternalServerError, "Error loading available areas")
@@ -1001,15 +1116,10 @@ func (h *EventHandler) HandleGetAvailableAreas(ctx context.Context, request even
 // Scenario 2: Hủy sự kiện APPROVED -> eventId + auto-refund + release area
 // ============================================================
 func (h *EventHandler) HandleCancelEvent(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
-	// Get userID from header
-	userIDStr := request.Headers["X-User-Id"]
-	if userIDStr == "" {
-		return createMessageResponse(http.StatusUnauthorized, "Missing X-User-Id header")
-	}
-
-	userID, err := strconv.Atoi(userIDStr)
-	if err != nil {
-		return createMessageResponse(http.StatusBadRequest, "Invalid X-User-Id")
+	// Get userID from request context (set by auth middleware)
+	userID, ok := authctx.UserID(ctx)
+	if !ok {
+		return createMessageResponse(http.StatusUnauthorized, "Unauthorized")
 	}
 
 	// Parse request body
@@ -1026,7 +1136,7 @@ func (h *EventHandler) HandleCancelEvent(ctx context.Context, request events.API
 	// Scenario 1: Hủy yêu cầu (PENDING/UPDATING)
 	if req.RequestID > 0 {
 		fmt.Printf("[CancelEvent] UserID=%d cancelling RequestID=%d\n", userID, req.RequestID)
-		err = h.useCase.CancelEventRequest(ctx, userID, req.RequestID)
+		err := h.useCase.CancelEventRequest(ctx, userID, req.RequestID)
 		if err != nil {
 			fmt.Printf("[ERROR] Failed to cancel request: %v\n", err)
 			return createMessageResponse(http.StatusBadRequest, err.Error())
@@ -1036,7 +1146,7 @@ func (h *EventHandler) HandleCancelEvent(ctx context.Context, request events.API
 
 	// Scenario 2: Hủy sự kiện (APPROVED)
 	fmt.Printf("[CancelEvent] UserID=%d cancelling EventID=%d\n", userID, req.EventID)
-	err = h.useCase.CancelEvent(ctx, userID, req.EventID)
+	err := h.useCase.CancelEvent(ctx, userID, req.EventID)
 	if err != nil {
 		fmt.Printf("[ERROR] Failed to cancel event: %v\n", err)
 		return createMessageResponse(http.StatusBadRequest, err.Error())
@@ -1074,3 +1184,164 @@ func (h *EventHandler) HandleCheckDailyQuota(ctx context.Context, request events
 func stringPtr(s string) *string {
 	return &s
 }
+
+// ============================================================
+// HandleUploadEventBanner - POST /api/events/{id}/banner
+// Upload/ingest banner: validate định dạng + kích thước, sinh thumbnail/card/hero
+// Body:
+//   - Content-Type: image/* → raw bytes của ảnh
+//   - JSON {"sourceUrl": "..."} → server tự tải ảnh
+//   - JSON {"imageBase64": "..."} → ảnh mã hóa base64
+//
+// ============================================================
+func (h *EventHandler) HandleUploadEventBanner(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	if !permission.Has(ctx, permission.EventRequestCreate) {
+		return createMessageResponse(http.StatusForbidden, "Only Organizer or Admin can upload event banner")
+	}
+
+	role := authctx.Role(ctx)
+	userID, ok := authctx.UserID(ctx)
+	if !ok {
+		return createMessageResponse(http.StatusUnauthorized, "User ID not found in request")
+	}
+
+	eventID, err := strconv.Atoi(request.PathParameters["id"])
+	if err != nil || eventID <= 0 {
+		return createMessageResponse(http.StatusBadRequest, "Invalid event ID")
+	}
+
+	var imageData []byte
+	var sourceURL string
+
+	contentType := request.Headers["Content-Type"]
+	if strings.HasPrefix(contentType, "image/") {
+		if request.IsBase64Encoded {
+			imageData, err = base64.StdEncoding.DecodeString(request.Body)
+			if err != nil {
+				return createMessageResponse(http.StatusBadRequest, "Invalid base64 body")
+			}
+		} else {
+			imageData = []byte(request.Body)
+		}
+	} else {
+		var req models.UploadEventBannerRequest
+		if err := json.Unmarshal([]byte(request.Body), &req); err != nil {
+			return createMessageResponse(http.StatusBadRequest, "Invalid request body")
+		}
+		if req.ImageBase64 != "" {
+			// Hỗ trợ data URL: "data:image/png;base64,...."
+			encoded := req.ImageBase64
+			if idx := strings.Index(encoded, ","); strings.HasPrefix(encoded, "data:") && idx > 0 {
+				encoded = encoded[idx+1:]
+			}
+			imageData, err = base64.StdEncoding.DecodeString(encoded)
+			if err != nil {
+				return createMessageResponse(http.StatusBadRequest, "Invalid imageBase64")
+			}
+		}
+		sourceURL = strings.TrimSpace(req.SourceURL)
+	}
+
+	result, err := h.useCase.IngestEventBanner(ctx, userID, role, eventID, imageData, sourceURL)
+	if err != nil {
+		var validationErr *imageproc.ValidationError
+		if errors.As(err, &validationErr) {
+			return createMessageResponse(http.StatusBadRequest, validationErr.Message)
+		}
+		switch err.Error() {
+		case "event not found":
+			return createMessageResponse(http.StatusNotFound, "Event not found")
+		case "you are not the owner of this event":
+			return createMessageResponse(http.StatusForbidden, "You are not the owner of this event")
+		case "event is not editable":
+			return createMessageResponse(http.StatusBadRequest, "Event is not editable in current status")
+		}
+		log.Printf("[HandleUploadEventBanner] Failed for event %d: %v", eventID, err)
+		return createMessageResponse(http.StatusInternalServerError, "Error processing banner")
+	}
+
+	return createJSONResponse(http.StatusOK, result)
+}
+
+// ============================================================
+// HandleCloneEventRequest - POST /api/event-requests/{id}/clone
+// Nhân bản yêu cầu sự kiện với ngày mới (ORGANIZER - chủ request gốc)
+// Body: {"preferredStartTime": "...", "preferredEndTime": "...", "title"?, "description"?, "expectedCapacity"?}
+// ============================================================
+func (h *EventHandler) HandleCloneEventRequest(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	userID, ok := authctx.UserID(ctx)
+	if !ok {
+		return createMessageResponse(http.StatusUnauthorized, "Unauthorized")
+	}
+
+	if !permission.Has(ctx, permission.EventRequestCreate) {
+		return createMessageResponse(http.StatusForbidden, "Only ORGANIZER can clone event requests")
+	}
+
+	sourceRequestID, err := strconv.Atoi(request.PathParameters["id"])
+	if err != nil || sourceRequestID <= 0 {
+		return createMessageResponse(http.StatusBadRequest, "Invalid request ID")
+	}
+
+	var req models.CloneEventRequestBody
+	if err := json.Unmarshal([]byte(request.Body), &req); err != nil {
+		return createMessageResponse(http.StatusBadRequest, "Invalid request body")
+	}
+	if req.PreferredStartTime == "" || req.PreferredEndTime == "" {
+		return createMessageResponse(http.StatusBadRequest, "Start time and end time are required")
+	}
+
+	startTime, err := ParseEventTime(req.PreferredStartTime)
+	if err != nil {
+		return createMessageResponse(http.StatusBadRequest, "Invalid start time format")
+	}
+	endTime, err := ParseEventTime(req.PreferredEndTime)
+	if err != nil {
+		return createMessageResponse(http.StatusBadRequest, "Invalid end time format")
+	}
+	if err := ValidateEventTime(ctx, startTime, endTime); err != nil {
+		return createMessageResponse(http.StatusBadRequest, err.Error())
+	}
+
+	newRequestID, err := h.useCase.CloneEventRequest(ctx, userID, sourceRequestID, &req)
+	if err != nil {
+		if errors.Is(err, usecase.ErrInvalidContent) {
+			return createMessageResponse(http.StatusBadRequest, err.Error())
+		}
+		switch err.Error() {
+		case "event request not found":
+			return createMessageResponse(http.StatusNotFound, "Event request not found")
+		case "you are not the owner of this event request":
+			return createMessageResponse(http.StatusForbidden, "You are not the owner of this event request")
+		}
+		log.Printf("[HandleCloneEventRequest] Failed to clone request %d: %v", sourceRequestID, err)
+		return createMessageResponse(http.StatusInternalServerError, "Error cloning event request")
+	}
+
+	return createJSONResponse(http.StatusOK, map[string]interface{}{
+		"message":             "Event request cloned successfully",
+		"requestId":           newRequestID,
+		"clonedFromRequestId": sourceRequestID,
+	})
+}
+
+// resolveCampusParam - Đọc ?campusId= và áp phạm vi campus của người dùng hiện tại
+func resolveCampusParam(ctx context.Context, request events.APIGatewayProxyRequest) (*int, error) {
+	requested, err := campus.ParseID(request.QueryStringParameters["campusId"])
+	if err != nil {
+		return nil, err
+	}
+	return campus.Resolve(ctx, requested)
+}
+
+// campusErrorResponse - Lỗi phạm vi campus / campusId không hợp lệ
+func campusErrorResponse(err error) (events.APIGatewayProxyResponse, error) {
+	switch {
+	case errors.Is(err, campus.ErrOutOfScope):
+		return createMessageResponse(http.StatusForbidden, "Bạn không có quyền với campus này")
+	case errors.Is(err, campus.ErrInvalidCampus):
+		return createMessageResponse(http.StatusBadRequest, "Invalid campusId")
+	default:
+		return createMessageResponse(http.StatusInternalServerError, "Error checking campus")
+	}
+}
@@ -0,0 +1,43 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/fpt-event-services/common/httpcache"
+	"github.com/fpt-event-services/services/event-lambda/repository"
+)
+
+// ifMatchVersion - Version client mong đợi (If-Match), nil = không kiểm tra
+func ifMatchVersion(request events.APIGatewayProxyRequest) (*int, error) {
+	header := request.Headers["If-Match"]
+	if header == "" {
+		header = request.Headers["if-match"]
+	}
+	return httpcache.IfMatchVersion(header)
+}
+
+// withVersionTag - Gắn ETag theo version cho response chi tiết
+func withVersionTag(resp events.APIGatewayProxyResponse, err error, version int) (events.APIGatewayProxyResponse, error) {
+	if err == nil && resp.StatusCode == http.StatusOK {
+		resp.Headers["ETag"] = httpcache.VersionTag(version)
+	}
+	return resp, err
+}
+
+// preconditionErrorResponse - Lỗi If-Match; ok = false nếu err không phải lỗi precondition
+func preconditionErrorResponse(err error) (events.APIGatewayProxyResponse, bool) {
+	var resp events.APIGatewayProxyResponse
+	switch {
+	case errors.Is(err, httpcache.ErrIfMatchRequired):
+		resp, _ = createMessageResponse(http.StatusPreconditionRequired, err.Error())
+	case errors.Is(err, httpcache.ErrInvalidIfMatch):
+		resp, _ = createMessageResponse(http.StatusBadRequest, err.Error())
+	case errors.Is(err, repository.ErrVersionMismatch):
+		resp, _ = createMessageResponse(http.StatusPreconditionFailed, err.Error())
+	default:
+		return resp, false
+	}
+	return resp, true
+}
@@ -0,0 +1,38 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/fpt-event-services/services/event-lambda/models"
+	"github.com/fpt-event-services/services/event-lambda/repository"
+	"github.com/fpt-event-services/services/event-lambda/usecase"
+)
+
+// ============================================================
+// HandlePriceTiers - GET|PUT /api/events/{id}/category-tickets/{categoryTicketId}/price-tiers
+// Body PUT: {"tiers": [{"name": "Early bird", "price": 80000, "validFrom": "2026-03-01T00:00:00+07:00", "validTo": "2026-03-15T00:00:00+07:00"}]}
+// Thay toàn bộ bậc giá; ngoài mọi khung giờ vé bán theo giá gốc
+// ============================================================
+func (h *EventHandler) HandlePriceTiers(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	categoryTicketID, err := strconv.Atoi(request.PathParameters["categoryTicketId"])
+	if err != nil || categoryTicketID <= 0 {
+		return createMessageResponse(http.StatusBadRequest, "Invalid category ticket ID")
+	}
+
+	return serveEventSettings(ctx, request, eventSettings[models.SavePriceTiersRequest, *models.CategoryPriceTiers]{
+		name: "price tiers",
+		get: func(ctx context.Context, eventID, userID int, role string) (*models.CategoryPriceTiers, error) {
+			return h.useCase.GetPriceTiers(ctx, eventID, categoryTicketID, userID, role)
+		},
+		update: func(ctx context.Context, eventID, userID int, role string, req models.SavePriceTiersRequest) (*models.CategoryPriceTiers, error) {
+			return h.useCase.SavePriceTiers(ctx, eventID, categoryTicketID, userID, role, &req)
+		},
+		errorStatus: map[error]int{
+			repository.ErrCategoryTicketNotFound: http.StatusNotFound,
+			usecase.ErrInvalidPriceTiers:         http.StatusBadRequest,
+		},
+	})
+}
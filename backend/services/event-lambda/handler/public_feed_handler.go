@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// publicFeedMaxAge - Cache của CDN / crawler cho feed (giây)
+const publicFeedMaxAge = "300"
+
+// ============================================================
+// HandleGetPublicEventFeed - GET /api/public/events.rss | events.json | sitemap.xml
+// Sự kiện OPEN với link microsite ổn định; hỗ trợ If-None-Match (304)
+// Không cần đăng nhập
+// ============================================================
+func (h *EventHandler) HandleGetPublicEventFeed(ctx context.Context, request events.APIGatewayProxyRequest, format string) (events.APIGatewayProxyResponse, error) {
+	feed, err := h.useCase.BuildEventFeed(ctx, format)
+	if err != nil {
+		return createMessageResponse(http.StatusInternalServerError, "Error loading event feed")
+	}
+
+	headers := map[string]string{
+		"Content-Type":  feed.ContentType,
+		"Cache-Control": "public, max-age=" + publicFeedMaxAge,
+		"ETag":          feed.ETag,
+		"Last-Modified": feed.LastModified.UTC().Format(http.TimeFormat),
+	}
+	if request.Headers["If-None-Match"] == feed.ETag || notModifiedSince(request.Headers["If-Modified-Since"], feed.LastModified) {
+		return events.APIGatewayProxyResponse{StatusCode: http.StatusNotModified, Headers: headers}, nil
+	}
+	return events.APIGatewayProxyResponse{StatusCode: http.StatusOK, Headers: headers, Body: string(feed.Body)}, nil
+}
+
+// notModifiedSince - If-Modified-Since không cũ hơn lần đọc DB của feed
+func notModifiedSince(header string, lastModified time.Time) bool {
+	if header == "" {
+		return false
+	}
+	since, err := http.ParseTime(header)
+	return err == nil && !lastModified.Truncate(time.Second).After(since)
+}
@@ -0,0 +1,34 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/fpt-event-services/services/event-lambda/usecase"
+)
+
+// publicPageMaxAge - Cache của CDN/trình duyệt cho trang public (giây)
+const publicPageMaxAge = "60"
+
+// ============================================================
+// HandleGetPublicEvent - GET /api/public/events/{slug}
+// Microsite của sự kiện: thông tin đã làm sạch, diễn giả, mức còn vé và Open Graph
+// Không cần đăng nhập
+// ============================================================
+func (h *EventHandler) HandleGetPublicEvent(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	page, err := h.useCase.GetPublicEventPage(ctx, request.PathParameters["slug"])
+	if errors.Is(err, usecase.ErrPublicEventNotFound) {
+		return createMessageResponse(http.StatusNotFound, "Event not found")
+	}
+	if err != nil {
+		return createMessageResponse(http.StatusInternalServerError, "Error loading event")
+	}
+
+	resp, err := createJSONResponse(http.StatusOK, page)
+	if resp.StatusCode == http.StatusOK {
+		resp.Headers["Cache-Control"] = "public, max-age=" + publicPageMaxAge
+	}
+	return resp, err
+}
@@ -0,0 +1,34 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/fpt-event-services/services/event-lambda/models"
+	"github.com/fpt-event-services/services/event-lambda/usecase"
+)
+
+// ============================================================
+// HandleEventRaffle - GET|POST /api/organizer/events/{id}/raffle
+// GET: lịch sử bốc thăm (seed, danh sách vé tham gia, người trúng)
+// Body POST: {"prize": "Tai nghe", "winnerCount": 3, "excludeStaff": true, "excludeComplimentary": true, "excludePreviousWinners": true}
+// Chỉ vé CHECKED_IN; người trúng nhận thông báo trong app và email
+// ============================================================
+func (h *EventHandler) HandleEventRaffle(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	return serveEventSettings(ctx, request, eventSettings[models.RaffleRequest, any]{
+		name: "raffle",
+		get: func(ctx context.Context, eventID, userID int, role string) (any, error) {
+			return h.useCase.ListRaffleDraws(ctx, eventID, userID, role)
+		},
+		update: func(ctx context.Context, eventID, userID int, role string, req models.RaffleRequest) (any, error) {
+			return h.useCase.DrawRaffle(ctx, eventID, userID, role, &req)
+		},
+		updateStatus: http.StatusCreated,
+		errorStatus: map[error]int{
+			usecase.ErrRaffleNotAllowed:        http.StatusConflict,
+			usecase.ErrNotEnoughRaffleEntrants: http.StatusConflict,
+			usecase.ErrInvalidRaffleRequest:    http.StatusBadRequest,
+		},
+	})
+}
@@ -0,0 +1,25 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/fpt-event-services/services/event-lambda/models"
+	"github.com/fpt-event-services/services/event-lambda/usecase"
+)
+
+// ============================================================
+// HandleEventSeatPrices - GET|PUT /api/events/{id}/seat-prices
+// Giá riêng từng ghế trong loại vé (% so với giá loại vé)
+// Body PUT: {"categoryTicketId": 3, "rules": [{"rows": ["A","B"], "adjustPercent": 20}]}
+// thay toàn bộ override của loại vé; "rules": [] bỏ hết
+// ============================================================
+func (h *EventHandler) HandleEventSeatPrices(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	return serveEventSettings(ctx, request, eventSettings[models.UpdateSeatPricesRequest, *models.SeatPriceSettings]{
+		name:        "seat prices",
+		get:         h.useCase.GetSeatPrices,
+		update:      h.useCase.UpdateSeatPrices,
+		errorStatus: map[error]int{usecase.ErrInvalidSeatPrice: http.StatusBadRequest},
+	})
+}
@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/fpt-event-services/common/authctx"
+	"github.com/fpt-event-services/common/permission"
+	"github.com/fpt-event-services/common/settlement"
+)
+
+// ============================================================
+// HandleOrganizerSettlements - GET /api/organizer/settlements[?status=PENDING|APPROVED|PAID]
+// Doanh thu phải trả cho organizer theo từng kỳ đối soát, kỳ mới nhất trước
+// ============================================================
+func (h *EventHandler) HandleOrganizerSettlements(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	organizerID, err := permission.Require(ctx, permission.SettlementView)
+	if errors.Is(err, authctx.ErrUnauthenticated) {
+		return createMessageResponse(http.StatusUnauthorized, "User ID not found in request")
+	}
+	if err != nil {
+		return createMessageResponse(http.StatusForbidden, "Only organizers can view settlements")
+	}
+
+	status := request.QueryStringParameters["status"]
+	if status != "" && !settlement.ValidStatus(status) {
+		return createMessageResponse(http.StatusBadRequest, "status must be PENDING, APPROVED or PAID")
+	}
+	list, err := settlement.List(ctx, settlement.Filter{OrganizerID: organizerID, Status: status})
+	if err != nil {
+		fmt.Printf("[ERROR] List organizer settlements failed: %v\n", err)
+		return createMessageResponse(http.StatusInternalServerError, "Failed to load settlements")
+	}
+	return createJSONResponse(http.StatusOK, list)
+}
+
+// ============================================================
+// HandleOrganizerSettlement - GET /api/organizer/settlements/{id}[?format=csv]
+// Chi tiết theo sự kiện; format=csv: tải bảng kê đối soát
+// Chỉ xem được bản đối soát của chính mình
+// ============================================================
+func (h *EventHandler) HandleOrganizerSettlement(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	organizerID, err := permission.Require(ctx, permission.SettlementView)
+	if errors.Is(err, authctx.ErrUnauthenticated) {
+		return createMessageResponse(http.StatusUnauthorized, "User ID not found in request")
+	}
+	if err != nil {
+		return createMessageResponse(http.StatusForbidden, "Only organizers can view settlements")
+	}
+	id, err := strconv.Atoi(request.PathParameters["id"])
+	if err != nil || id <= 0 {
+		return createMessageResponse(http.StatusBadRequest, "Invalid settlement ID")
+	}
+
+	s, err := settlement.Get(ctx, id)
+	if err == nil && s.OrganizerID != organizerID {
+		err = settlement.ErrNotFound
+	}
+	if errors.Is(err, settlement.ErrNotFound) {
+		return createMessageResponse(http.StatusNotFound, "Settlement not found")
+	}
+	if err != nil {
+		fmt.Printf("[ERROR] Get organizer settlement failed: %v\n", err)
+		return createMessageResponse(http.StatusInternalServerError, "Failed to load settlement")
+	}
+
+	switch request.QueryStringParameters["format"] {
+	case "", "json":
+		return createJSONResponse(http.StatusOK, s)
+	case "csv":
+		data, err := settlement.StatementCSV(s)
+		if err != nil {
+			return createMessageResponse(http.StatusInternalServerError, "Failed to export settlement")
+		}
+		return events.APIGatewayProxyResponse{
+			StatusCode: http.StatusOK,
+			Headers: map[string]string{
+				"Content-Type":        "text/csv; charset=utf-8",
+				"Content-Disposition": fmt.Sprintf("attachment; filename=\"%s\"", settlement.StatementFileName(s)),
+				"Cache-Control":       "private, no-store",
+			},
+			Body:            base64.StdEncoding.EncodeToString(data),
+			IsBase64Encoded: true,
+		}, nil
+	}
+	return createMessageResponse(http.StatusBadRequest, "format must be json or csv")
+}
@@ -0,0 +1,169 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/fpt-event-services/common/authctx"
+	"github.com/fpt-event-services/services/event-lambda/models"
+	"github.com/fpt-event-services/services/event-lambda/repository"
+	"github.com/fpt-event-services/services/event-lambda/usecase"
+)
+
+// ============================================================
+// HandleInviteSpeaker - POST /api/events/{id}/speaker/invite
+// Gửi link mời tới speaker của sự kiện để liên kết tài khoản
+// Body: {"email": "..."} (tùy chọn, mặc định email trong hồ sơ speaker)
+// ============================================================
+func (h *EventHandler) HandleInviteSpeaker(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	userID, ok := authctx.UserID(ctx)
+	if !ok {
+		return createMessageResponse(http.StatusUnauthorized, "User ID not found in request")
+	}
+	eventID, err := strconv.Atoi(request.PathParameters["id"])
+	if err != nil || eventID <= 0 {
+		return createMessageResponse(http.StatusBadRequest, "Invalid event ID")
+	}
+
+	var req models.InviteSpeakerRequest
+	if request.Body != "" {
+		if err := json.Unmarshal([]byte(request.Body), &req); err != nil {
+			return createMessageResponse(http.StatusBadRequest, "Invalid request body")
+		}
+	}
+	invitation, err := h.useCase.InviteSpeaker(ctx, eventID, userID, authctx.Role(ctx), &req)
+	if err != nil {
+		return editDetailsErrorResponse("speaker portal", err, speakerErrorStatus)
+	}
+	return createJSONResponse(http.StatusOK, invitation)
+}
+
+// ============================================================
+// HandleAcceptSpeakerInvitation - POST /api/speaker/invitations/accept
+// Body: {"token": "...", "password": "..."}
+// Không cần đăng nhập: token trong email là bằng chứng sở hữu email
+// Email chưa có tài khoản thì tạo tài khoản SPEAKER với password gửi lên
+// ============================================================
+func (h *EventHandler) HandleAcceptSpeakerInvitation(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	var req models.AcceptSpeakerInvitationRequest
+	if err := json.Unmarshal([]byte(request.Body), &req); err != nil {
+		return createMessageResponse(http.StatusBadRequest, "Invalid request body")
+	}
+	resp, err := h.useCase.AcceptSpeakerInvitation(ctx, &req)
+	if err != nil {
+		return editDetailsErrorResponse("speaker portal", err, speakerErrorStatus)
+	}
+	return createJSONResponse(http.StatusOK, resp)
+}
+
+// ============================================================
+// HandleGetSpeakerEvents - GET /api/speaker/my-events
+// Sự kiện mà tài khoản hiện tại là speaker, kèm tài liệu đã đăng
+// ============================================================
+func (h *EventHandler) HandleGetSpeakerEvents(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	userID, ok := authctx.UserID(ctx)
+	if !ok {
+		return createMessageResponse(http.StatusUnauthorized, "User ID not found in request")
+	}
+	list, err := h.useCase.GetSpeakerEvents(ctx, userID)
+	if err != nil {
+		return editDetailsErrorResponse("speaker portal", err, speakerErrorStatus)
+	}
+	return createJSONResponse(http.StatusOK, list)
+}
+
+// ============================================================
+// HandleSpeakerProfile - GET|PUT /api/speaker/profile
+// Body PUT: {"bio": "...", "avatarUrl": "https://..."}
+// ============================================================
+func (h *EventHandler) HandleSpeakerProfile(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	userID, ok := authctx.UserID(ctx)
+	if !ok {
+		return createMessageResponse(http.StatusUnauthorized, "User ID not found in request")
+	}
+
+	if request.HTTPMethod == http.MethodGet {
+		profile, err := h.useCase.GetSpeakerProfile(ctx, userID)
+		if err != nil {
+			return editDetailsErrorResponse("speaker portal", err, speakerErrorStatus)
+		}
+		return createJSONResponse(http.StatusOK, profile)
+	}
+
+	var req models.SpeakerProfile
+	if err := json.Unmarshal([]byte(request.Body), &req); err != nil {
+		return createMessageResponse(http.StatusBadRequest, "Invalid request body")
+	}
+	profile, err := h.useCase.UpdateSpeakerProfile(ctx, userID, &req)
+	if err != nil {
+		return editDetailsErrorResponse("speaker portal", err, speakerErrorStatus)
+	}
+	return createJSONResponse(http.StatusOK, profile)
+}
+
+// ============================================================
+// HandleAddSessionMaterial - POST /api/speaker/events/{id}/materials
+// Body: {"title": "Slide buổi nói", "url": "https://..."}
+// Chỉ speaker đã liên kết của sự kiện
+// ============================================================
+func (h *EventHandler) HandleAddSessionMaterial(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	userID, ok := authctx.UserID(ctx)
+	if !ok {
+		return createMessageResponse(http.StatusUnauthorized, "User ID not found in request")
+	}
+	eventID, err := strconv.Atoi(request.PathParameters["id"])
+	if err != nil || eventID <= 0 {
+		return createMessageResponse(http.StatusBadRequest, "Invalid event ID")
+	}
+
+	var req models.AddSessionMaterialRequest
+	if err := json.Unmarshal([]byte(request.Body), &req); err != nil {
+		return createMessageResponse(http.StatusBadRequest, "Invalid request body")
+	}
+	material, err := h.useCase.AddSessionMaterial(ctx, eventID, userID, &req)
+	if err != nil {
+		return editDetailsErrorResponse("speaker portal", err, speakerErrorStatus)
+	}
+	return createJSONResponse(http.StatusCreated, material)
+}
+
+// ============================================================
+// HandleDeleteSessionMaterial - DELETE /api/speaker/events/{id}/materials/{materialId}
+// ============================================================
+func (h *EventHandler) HandleDeleteSessionMaterial(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	userID, ok := authctx.UserID(ctx)
+	if !ok {
+		return createMessageResponse(http.StatusUnauthorized, "User ID not found in request")
+	}
+	eventID, err := strconv.Atoi(request.PathParameters["id"])
+	if err != nil || eventID <= 0 {
+		return createMessageResponse(http.StatusBadRequest, "Invalid event ID")
+	}
+	materialID, err := strconv.Atoi(request.PathParameters["materialId"])
+	if err != nil || materialID <= 0 {
+		return createMessageResponse(http.StatusBadRequest, "Invalid material ID")
+	}
+
+	if err := h.useCase.DeleteSessionMaterial(ctx, eventID, materialID, userID); err != nil {
+		return editDetailsErrorResponse("speaker portal", err, speakerErrorStatus)
+	}
+	return createMessageResponse(http.StatusOK, "Session material deleted")
+}
+
+// speakerErrorStatus - Lỗi riêng của speaker portal -> HTTP status (editDetailsErrorResponse)
+var speakerErrorStatus = map[error]int{
+	repository.ErrSessionMaterialNotFound:    http.StatusNotFound,
+	repository.ErrNotSpeaker:                 http.StatusForbidden,
+	repository.ErrNotEventSpeaker:            http.StatusForbidden,
+	repository.ErrSpeakerAccountNotAvailable: http.StatusForbidden,
+	repository.ErrSpeakerInvitationInvalid:   http.StatusGone,
+	usecase.ErrInvalidSpeakerRequest:         http.StatusBadRequest,
+	usecase.ErrInvalidContent:                http.StatusBadRequest,
+	repository.ErrEventHasNoSpeaker:          http.StatusBadRequest,
+	repository.ErrSpeakerEmailMissing:        http.StatusBadRequest,
+	repository.ErrSpeakerPasswordRequired:    http.StatusBadRequest,
+	repository.ErrTooManySessionMaterials:    http.StatusBadRequest,
+}
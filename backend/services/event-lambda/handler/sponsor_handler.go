@@ -0,0 +1,96 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/fpt-event-services/common/authctx"
+	"github.com/fpt-event-services/services/event-lambda/models"
+	"github.com/fpt-event-services/services/event-lambda/repository"
+	"github.com/fpt-event-services/services/event-lambda/usecase"
+)
+
+// ============================================================
+// HandleEventSponsors - GET|POST /api/events/{id}/sponsors
+// GET: công khai, GOLD → SILVER → BRONZE → PARTNER
+// Body POST: {"name": "FPT Software", "tier": "GOLD", "logoUrl": "https://...", "websiteUrl": "https://..."}
+// hoặc {"sponsorId": 3, "tier": "SILVER"} để dùng lại hồ sơ có sẵn
+// ============================================================
+func (h *EventHandler) HandleEventSponsors(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	eventID, err := strconv.Atoi(request.PathParameters["id"])
+	if err != nil || eventID <= 0 {
+		return createMessageResponse(http.StatusBadRequest, "Invalid event ID")
+	}
+
+	if request.HTTPMethod == http.MethodGet {
+		sponsors, err := h.useCase.ListEventSponsors(ctx, eventID)
+		if err != nil {
+			return editDetailsErrorResponse("sponsors", err, sponsorErrorStatus)
+		}
+		return createJSONResponse(http.StatusOK, sponsors)
+	}
+
+	userID, ok := authctx.UserID(ctx)
+	if !ok {
+		return createMessageResponse(http.StatusUnauthorized, "User ID not found in request")
+	}
+	var req models.EventSponsorRequest
+	if err := json.Unmarshal([]byte(request.Body), &req); err != nil {
+		return createMessageResponse(http.StatusBadRequest, "Invalid request body")
+	}
+	sponsor, err := h.useCase.AddEventSponsor(ctx, eventID, userID, authctx.Role(ctx), &req)
+	if err != nil {
+		return editDetailsErrorResponse("sponsors", err, sponsorErrorStatus)
+	}
+	return createJSONResponse(http.StatusCreated, sponsor)
+}
+
+// ============================================================
+// HandleEventSponsor - PUT|DELETE /api/events/{id}/sponsors/{sponsorId}
+// Body PUT: {"tier": "GOLD", "displayOrder": 1} (+ name/logoUrl/websiteUrl nếu là người tạo hồ sơ)
+// ============================================================
+func (h *EventHandler) HandleEventSponsor(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	userID, ok := authctx.UserID(ctx)
+	if !ok {
+		return createMessageResponse(http.StatusUnauthorized, "User ID not found in request")
+	}
+	role := authctx.Role(ctx)
+	eventID, err := strconv.Atoi(request.PathParameters["id"])
+	if err != nil || eventID <= 0 {
+		return createMessageResponse(http.StatusBadRequest, "Invalid event ID")
+	}
+	sponsorID, err := strconv.Atoi(request.PathParameters["sponsorId"])
+	if err != nil || sponsorID <= 0 {
+		return createMessageResponse(http.StatusBadRequest, "Invalid sponsor ID")
+	}
+
+	if request.HTTPMethod == http.MethodDelete {
+		if err := h.useCase.RemoveEventSponsor(ctx, eventID, sponsorID, userID, role); err != nil {
+			return editDetailsErrorResponse("sponsors", err, sponsorErrorStatus)
+		}
+		return createMessageResponse(http.StatusOK, "Sponsor removed")
+	}
+
+	var req models.EventSponsorRequest
+	if err := json.Unmarshal([]byte(request.Body), &req); err != nil {
+		return createMessageResponse(http.StatusBadRequest, "Invalid request body")
+	}
+	sponsor, err := h.useCase.UpdateEventSponsor(ctx, eventID, sponsorID, userID, role, &req)
+	if err != nil {
+		return editDetailsErrorResponse("sponsors", err, sponsorErrorStatus)
+	}
+	return createJSONResponse(http.StatusOK, sponsor)
+}
+
+// sponsorErrorStatus - Lỗi riêng của nhà tài trợ -> HTTP status (editDetailsErrorResponse)
+var sponsorErrorStatus = map[error]int{
+	repository.ErrSponsorNotFound:      http.StatusNotFound,
+	repository.ErrEventSponsorNotFound: http.StatusNotFound,
+	usecase.ErrSponsorNotEditable:      http.StatusForbidden,
+	repository.ErrSponsorAlreadyLinked: http.StatusConflict,
+	usecase.ErrInvalidSponsorRequest:   http.StatusBadRequest,
+	repository.ErrTooManySponsors:      http.StatusBadRequest,
+}
@@ -0,0 +1,128 @@
+package handler
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/fpt-event-services/common/authctx"
+	"github.com/fpt-event-services/services/event-lambda/models"
+	"github.com/fpt-event-services/services/event-lambda/repository"
+	"github.com/fpt-event-services/services/event-lambda/usecase"
+)
+
+// ============================================================
+// HandleEventSurvey - GET|PUT /api/events/{id}/survey
+// Body PUT: {"title": "...", "status": "DRAFT|OPEN|CLOSED", "questions": [{"type": "SINGLE_CHOICE", "prompt": "...", "required": true, "options": ["A", "B"]}]}
+// Bỏ "questions" để chỉ đổi tiêu đề / trạng thái; câu hỏi khóa khi đã có phiếu trả lời
+// ============================================================
+func (h *EventHandler) HandleEventSurvey(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	return serveEventSettings(ctx, request, eventSettings[models.SaveSurveyRequest, *models.EventSurvey]{
+		name: "survey",
+		get:  h.useCase.GetEventSurvey,
+		update: func(ctx context.Context, eventID, userID int, role string, req models.SaveSurveyRequest) (*models.EventSurvey, error) {
+			return h.useCase.SaveEventSurvey(ctx, eventID, userID, role, &req)
+		},
+		errorStatus: surveyErrorStatus,
+	})
+}
+
+// ============================================================
+// HandleEventSurveyResults - GET /api/events/{id}/survey/results[?format=csv]
+// JSON: tổng hợp theo câu hỏi; format=csv: file phiếu trả lời ẩn danh
+// ============================================================
+func (h *EventHandler) HandleEventSurveyResults(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	userID, ok := authctx.UserID(ctx)
+	if !ok {
+		return createMessageResponse(http.StatusUnauthorized, "User ID not found in request")
+	}
+	eventID, err := strconv.Atoi(request.PathParameters["id"])
+	if err != nil || eventID <= 0 {
+		return createMessageResponse(http.StatusBadRequest, "Invalid event ID")
+	}
+
+	switch request.QueryStringParameters["format"] {
+	case "", "json":
+		results, err := h.useCase.GetSurveyResults(ctx, eventID, userID, authctx.Role(ctx))
+		if err != nil {
+			return editDetailsErrorResponse("survey", err, surveyErrorStatus)
+		}
+		return createJSONResponse(http.StatusOK, results)
+	case "csv":
+		fileName, data, err := h.useCase.ExportSurveyCSV(ctx, eventID, userID, authctx.Role(ctx))
+		if err != nil {
+			return editDetailsErrorResponse("survey", err, surveyErrorStatus)
+		}
+		return events.APIGatewayProxyResponse{
+			StatusCode: http.StatusOK,
+			Headers: map[string]string{
+				"Content-Type":        "text/csv; charset=utf-8",
+				"Content-Disposition": fmt.Sprintf("attachment; filename=\"%s\"", fileName),
+				"Cache-Control":       "private, no-store",
+			},
+			Body:            base64.StdEncoding.EncodeToString(data),
+			IsBase64Encoded: true,
+		}, nil
+	}
+	return createMessageResponse(http.StatusBadRequest, "format must be json or csv")
+}
+
+// ============================================================
+// HandleStudentSurvey - GET /api/student/surveys/{eventId}
+// Khảo sát đang mở của sự kiện user đã check-in, kèm hasResponded
+// ============================================================
+func (h *EventHandler) HandleStudentSurvey(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	userID, ok := authctx.UserID(ctx)
+	if !ok {
+		return createMessageResponse(http.StatusUnauthorized, "User ID not found in request")
+	}
+	eventID, err := strconv.Atoi(request.PathParameters["eventId"])
+	if err != nil || eventID <= 0 {
+		return createMessageResponse(http.StatusBadRequest, "Invalid event ID")
+	}
+
+	survey, err := h.useCase.GetStudentSurvey(ctx, eventID, userID)
+	if err != nil {
+		return editDetailsErrorResponse("survey", err, surveyErrorStatus)
+	}
+	return createJSONResponse(http.StatusOK, survey)
+}
+
+// ============================================================
+// HandleSubmitSurveyResponse - POST /api/student/surveys/{eventId}/responses
+// Body: {"answers": [{"questionId": 1, "choices": ["A"]}, {"questionId": 2, "text": "..."}, {"questionId": 3, "scale": 4}]}
+// Chỉ người đã check-in, mỗi người một lần
+// ============================================================
+func (h *EventHandler) HandleSubmitSurveyResponse(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	userID, ok := authctx.UserID(ctx)
+	if !ok {
+		return createMessageResponse(http.StatusUnauthorized, "User ID not found in request")
+	}
+	eventID, err := strconv.Atoi(request.PathParameters["eventId"])
+	if err != nil || eventID <= 0 {
+		return createMessageResponse(http.StatusBadRequest, "Invalid event ID")
+	}
+
+	var req models.SurveyResponseRequest
+	if err := json.Unmarshal([]byte(request.Body), &req); err != nil {
+		return createMessageResponse(http.StatusBadRequest, "Invalid request body")
+	}
+	if err := h.useCase.SubmitSurveyResponse(ctx, eventID, userID, &req); err != nil {
+		return editDetailsErrorResponse("survey", err, surveyErrorStatus)
+	}
+	return createMessageResponse(http.StatusCreated, "Survey response submitted")
+}
+
+// surveyErrorStatus - Lỗi riêng của khảo sát -> HTTP status (editDetailsErrorResponse)
+var surveyErrorStatus = map[error]int{
+	repository.ErrSurveyNotFound:        http.StatusNotFound,
+	usecase.ErrNotEventAttendee:         http.StatusForbidden,
+	usecase.ErrSurveyNotOpen:            http.StatusConflict,
+	repository.ErrSurveyHasResponses:    http.StatusConflict,
+	repository.ErrSurveyAlreadyAnswered: http.StatusConflict,
+	usecase.ErrInvalidSurveyRequest:     http.StatusBadRequest,
+}
@@ -0,0 +1,27 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/fpt-event-services/common/pdf"
+	"github.com/fpt-event-services/services/event-lambda/models"
+)
+
+// ============================================================
+// HandleEventTicketTemplate - GET|PUT /api/events/{id}/ticket-template
+// Cấu hình vé PDF gửi kèm email: layout, khổ giấy, màu nhấn, logo BTC, logo nhà tài trợ
+// Body PUT: {"layout": "branded", "pageSize": "A5", "accentColor": "#F27124", "showBanner": true,
+// "organizerLogoUrl": "https://...", "sponsorLogoUrls": ["https://..."], "footerNote": "..."}
+// ============================================================
+func (h *EventHandler) HandleEventTicketTemplate(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	return serveEventSettings(ctx, request, eventSettings[models.EventTicketTemplate, *models.EventTicketTemplate]{
+		name: "ticket template",
+		get:  h.useCase.GetTicketTemplate,
+		update: func(ctx context.Context, eventID, userID int, role string, req models.EventTicketTemplate) (*models.EventTicketTemplate, error) {
+			return h.useCase.UpdateTicketTemplate(ctx, eventID, userID, role, &req)
+		},
+		errorStatus: map[error]int{pdf.ErrInvalidTemplate: http.StatusBadRequest},
+	})
+}
@@ -1,8 +1,11 @@
 package handler
 
 import (
+	"context"
 	"fmt"
 	"time"
+
+	"github.com/fpt-event-services/common/rules"
 )
 
 // TimeValidationError represents a time validation error
@@ -23,11 +26,11 @@ func (e *TimeValidationError) Error() string {
 // 3. Start and end must be on the same day
 // 4. Event duration must be at least 60 minutes
 // 5. Event duration must not exceed 18 hours
-// 6. Event should be scheduled at least 24 hours in advance
+// 6. Event should be scheduled at least rule.min_advance_hours (default 24) in advance
 // 7. Event should be scheduled within 1 year (365 days)
 // 8. Event start time must be between 07:00 and 21:00
 // 9. Event end time must be before 21:00
-func ValidateEventTime(startTime, endTime time.Time) error {
+func ValidateEventTime(ctx context.Context, startTime, endTime time.Time) error {
 	now := time.Now()
 
 	// 1. Start time must not be in the past (allow 5 minute buffer for clock skew)
@@ -68,16 +71,17 @@ func ValidateEventTime(startTime, endTime time.Time) error {
 		}
 	}
 
-	// 6. Event should be scheduled at least 24 hours in advance (for proper planning)
-	minAdvanceTime := now.Add(24 * time.Hour)
+	// 6. Event should be scheduled at least rule.min_advance_hours in advance (for proper planning)
+	minAdvance := rules.MinAdvance(ctx)
+	minAdvanceTime := now.Add(minAdvance)
 	if startTime.Before(minAdvanceTime) {
-		fmt.Printf("[ValidateEventTime] 24-hour check failed - Now: %s, StartTime: %s, MinAdvanceTime: %s, Difference: %v hours\n",
+		fmt.Printf("[ValidateEventTime] Min-advance check failed - Now: %s, StartTime: %s, MinAdvanceTime: %s, Difference: %v hours\n",
 			now.Format(time.RFC3339),
 			startTime.Format(time.RFC3339),
 			minAdvanceTime.Format(time.RFC3339),
 			startTime.Sub(now).Hours())
 		return &TimeValidationError{
-			Message: "Sự kiện phải được lên lịch trước ít nhất 24 giờ",
+			Message: fmt.Sprintf("Sự kiện phải được lên lịch trước ít nhất %.0f giờ", minAdvance.Hours()),
 		}
 	}
 
@@ -85,7 +89,7 @@ func ValidateEventTime(startTime, endTime time.Time) error {
 	maxScheduleTime := now.AddDate(0, 0, 365)
 	if startTime.After(maxScheduleTime) {
 		return &TimeValidationError{
-			Message: "Sự kiện không được lên lịch quá 365 ngày từ hiện tại",
+			Message: "Sự kiện không được lên lịch quá 1 năm (365 ngày) từ hiện tại",
 		}
 	}
 
@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"context"
 	"testing"
 	"time"
 )
@@ -50,7 +51,7 @@ func TestValidateEventTime(t *testing.T) {
 			startTime:   tomorrow,
 			endTime:     tomorrow.Add(15 * time.Minute),
 			shouldError: true,
-			errorMsg:    "30 phút",
+			errorMsg:    "60 phút",
 		},
 		{
 			name:        "Too long duration (20 hours)",
@@ -77,7 +78,7 @@ func TestValidateEventTime(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := ValidateEventTime(tt.startTime, tt.endTime)
+			err := ValidateEventTime(context.Background(), tt.startTime, tt.endTime)
 
 			if tt.shouldError {
 				if err == nil {
@@ -0,0 +1,154 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/fpt-event-services/common/authctx"
+	"github.com/fpt-event-services/common/permission"
+	"github.com/fpt-event-services/services/event-lambda/models"
+	"github.com/fpt-event-services/services/event-lambda/repository"
+	"github.com/fpt-event-services/services/event-lambda/usecase"
+)
+
+// WidgetKeyHeader - Header chứa API key khi gọi từ server (trình duyệt dùng ?key=)
+const WidgetKeyHeader = "X-Widget-Key"
+
+// ============================================================
+// HandleWidgetKeys - GET|POST /api/organizer/widget-keys
+// Body POST: {"name": "Website CLB", "allowedOrigins": ["https://clb.example.com"]}
+// Chỉ ORGANIZER, mỗi organizer quản lý key của mình
+// ============================================================
+func (h *EventHandler) HandleWidgetKeys(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	organizerID, err := permission.Require(ctx, permission.WidgetManage)
+	if errors.Is(err, authctx.ErrUnauthenticated) {
+		return createMessageResponse(http.StatusUnauthorized, "User ID not found in request")
+	}
+	if err != nil {
+		return createMessageResponse(http.StatusForbidden, "Only organizers can manage widget API keys")
+	}
+
+	if request.HTTPMethod == http.MethodGet {
+		keys, err := h.useCase.ListWidgetAPIKeys(ctx, organizerID)
+		if err != nil {
+			return widgetErrorResponse(err)
+		}
+		return createJSONResponse(http.StatusOK, keys)
+	}
+
+	var req models.WidgetAPIKeyRequest
+	if err := json.Unmarshal([]byte(request.Body), &req); err != nil {
+		return createMessageResponse(http.StatusBadRequest, "Invalid request body")
+	}
+	key, err := h.useCase.CreateWidgetAPIKey(ctx, organizerID, &req)
+	if err != nil {
+		return widgetErrorResponse(err)
+	}
+	return createJSONResponse(http.StatusCreated, key)
+}
+
+// ============================================================
+// HandleWidgetKey - PUT|DELETE /api/organizer/widget-keys/{keyId}
+// PUT: đổi tên / allowedOrigins; DELETE: thu hồi key
+// ============================================================
+func (h *EventHandler) HandleWidgetKey(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	organizerID, err := permission.Require(ctx, permission.WidgetManage)
+	if errors.Is(err, authctx.ErrUnauthenticated) {
+		return createMessageResponse(http.StatusUnauthorized, "User ID not found in request")
+	}
+	if err != nil {
+		return createMessageResponse(http.StatusForbidden, "Only organizers can manage widget API keys")
+	}
+	keyID, err := strconv.Atoi(request.PathParameters["keyId"])
+	if err != nil || keyID <= 0 {
+		return createMessageResponse(http.StatusBadRequest, "Invalid key ID")
+	}
+
+	if request.HTTPMethod == http.MethodDelete {
+		if err := h.useCase.RevokeWidgetAPIKey(ctx, organizerID, keyID); err != nil {
+			return widgetErrorResponse(err)
+		}
+		return createMessageResponse(http.StatusOK, "Widget API key revoked")
+	}
+
+	var req models.WidgetAPIKeyRequest
+	if err := json.Unmarshal([]byte(request.Body), &req); err != nil {
+		return createMessageResponse(http.StatusBadRequest, "Invalid request body")
+	}
+	key, err := h.useCase.UpdateWidgetAPIKey(ctx, organizerID, keyID, &req)
+	if err != nil {
+		return widgetErrorResponse(err)
+	}
+	return createJSONResponse(http.StatusOK, key)
+}
+
+// ============================================================
+// HandleGetWidgetEvents - GET /api/widget/events?key=...&organizerId=...
+// Sự kiện sắp diễn ra của organizer sở hữu key, payload rút gọn cho widget
+// CORS do widgetCORSMiddleware (main.go) set theo origin của key, không dùng "*"
+// ============================================================
+func (h *EventHandler) HandleGetWidgetEvents(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	owner, err := h.useCase.ResolveWidgetKey(ctx, WidgetKeyFromRequest(request))
+	if err != nil {
+		return widgetErrorResponse(err)
+	}
+	organizerID := 0
+	if v := request.QueryStringParameters["organizerId"]; v != "" {
+		if organizerID, err = strconv.Atoi(v); err != nil || organizerID <= 0 {
+			return createMessageResponse(http.StatusBadRequest, "Invalid organizerId")
+		}
+	}
+
+	items, err := h.useCase.GetWidgetEvents(ctx, owner, organizerID)
+	if err != nil {
+		return widgetErrorResponse(err)
+	}
+	resp, err := createJSONResponse(http.StatusOK, map[string]interface{}{"events": items})
+	if resp.StatusCode == http.StatusOK {
+		resp.Headers["Cache-Control"] = "public, max-age=" + publicPageMaxAge
+		resp.Headers["Vary"] = "Origin"
+	}
+	return resp, err
+}
+
+// AllowWidgetOrigin - Key hợp lệ và origin nằm trong danh sách của key (dùng ở CORS layer)
+func (h *EventHandler) AllowWidgetOrigin(ctx context.Context, apiKey, origin string) bool {
+	owner, err := h.useCase.ResolveWidgetKey(ctx, apiKey)
+	if err != nil {
+		if !errors.Is(err, usecase.ErrInvalidWidgetKey) {
+			fmt.Printf("[ERROR] Widget key lookup failed: %v\n", err)
+		}
+		return false
+	}
+	return usecase.WidgetOriginAllowed(owner, origin)
+}
+
+// WidgetKeyFromRequest - ?key= (trình duyệt) hoặc header X-Widget-Key (server)
+func WidgetKeyFromRequest(request events.APIGatewayProxyRequest) string {
+	if k := request.QueryStringParameters["key"]; k != "" {
+		return k
+	}
+	return request.Headers[WidgetKeyHeader]
+}
+
+func widgetErrorResponse(err error) (events.APIGatewayProxyResponse, error) {
+	switch {
+	case errors.Is(err, usecase.ErrInvalidWidgetKey):
+		return createMessageResponse(http.StatusUnauthorized, err.Error())
+	case errors.Is(err, usecase.ErrWidgetForbidden):
+		return createMessageResponse(http.StatusForbidden, err.Error())
+	case errors.Is(err, repository.ErrWidgetKeyNotFound):
+		return createMessageResponse(http.StatusNotFound, err.Error())
+	case errors.Is(err, usecase.ErrInvalidWidgetKeyRequest):
+		return createMessageResponse(http.StatusBadRequest, err.Error())
+	case errors.Is(err, repository.ErrTooManyWidgetKeys):
+		return createMessageResponse(http.StatusConflict, err.Error())
+	}
+	fmt.Printf("[ERROR] Widget operation failed: %v\n", err)
+	return createMessageResponse(http.StatusInternalServerError, "Error processing widget request")
+}
@@ -2,14 +2,19 @@ package main
 
 import (
 	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/fpt-event-services/common/lambdainit"
 	"github.com/fpt-event-services/services/event-lambda/handler"
 )
 
 // For AWS Lambda deployment
 // This file is used when deploying to AWS Lambda
 func main() {
-	eventHandler := handler.NewEventHandler()
+	// Secret (DB, JWT...) + kết nối DB trước request đầu tiên (thử lại ở request nếu lỗi)
+	lambdainit.Warm()
 
-	// Lambda handler for API Gateway events
-	lambda.Start(eventHandler.HandleGetEvents)
+	// Handler tạo một lần cho execution environment, sau khi đã kết nối DB
+	// CORS theo CORS_ORIGINS giống server HTTP (handler không tự gắn header CORS)
+	lambda.Start(lambdainit.CORS(lambdainit.Lazy(func() lambdainit.Handler {
+		return handler.NewEventHandler().HandleGetEvents
+	})))
 }
@@ -2,17 +2,41 @@ package models
 
 import (
 	"database/sql"
+	"math"
 	"time"
+
+	"github.com/fpt-event-services/common/statuslabel"
 )
 
 // ============================================================
 // EligibilityError - Error struct for event update eligibility check
+// Trả về nguyên struct cho frontend: EVENT_EDIT_LOCKED kèm trường bị khóa,
+// trường vẫn sửa được và mốc khóa để hiển thị
 // ============================================================
 type EligibilityError struct {
-	Code    string // EVENT_CLOSED, EVENT_TOO_CLOSE, etc.
-	Message string
+	Code          string     `json:"code"` // EVENT_CLOSED, EVENT_EDIT_LOCKED
+	Message       string     `json:"message"`
+	LockedFields  []string   `json:"lockedFields,omitempty"`
+	AllowedFields []string   `json:"allowedFields,omitempty"`
+	CutoffAt      *time.Time `json:"cutoffAt,omitempty"`
+	StartTime     *time.Time `json:"startTime,omitempty"`
+}
+
+func (e *EligibilityError) Error() string {
+	return e.Message
 }
 
+// Mã lỗi EligibilityError
+const (
+	EligibilityEventClosed     = "EVENT_CLOSED"
+	EligibilityEventEditLocked = "EVENT_EDIT_LOCKED"
+)
+
+// EditableAfterCutoff - Trường organizer vẫn sửa được sau mốc khóa chỉnh sửa
+// (rules.UpdateWindow trước giờ bắt đầu); tên trường theo JSON của request.
+// FAQ chỉ là thông tin giải đáp nên vẫn sửa được; lịch trình thì không
+var EditableAfterCutoff = []string{"bannerUrl", "faq"}
+
 // Event represents an event in the system
 // Maps to MySQL table: Event
 type Event struct {
@@ -70,14 +94,19 @@ type Venue struct {
 // Dùng cho: GET /api/events (trong openEvents và closedEvents)
 // ============================================================
 type EventListItem struct {
-	EventID     int     `json:"eventId"`
-	Title       string  `json:"title"`
-	Description *string `json:"description"` // serializeNulls = true trong Java
-	StartTime   string  `json:"startTime"`   // Timestamp -> ISO string
-	EndTime     string  `json:"endTime"`
-	MaxSeats    int     `json:"maxSeats"`
-	Status      string  `json:"status"`
-	BannerURL   *string `json:"bannerUrl"`
+	EventID     int                `json:"eventId"`
+	Title       string             `json:"title"`
+	Description *string            `json:"description"` // serializeNulls = true trong Java
+	StartTime   string             `json:"startTime"`   // Timestamp -> ISO string
+	EndTime     string             `json:"endTime"`
+	MaxSeats    int                `json:"maxSeats"`
+	Status      string             `json:"status"`
+	StatusLabel *statuslabel.Label `json:"statusLabel"` // Nhãn hiển thị của status (điền khi serialize)
+	BannerURL   *string            `json:"bannerUrl"`
+
+	// Banner variants (resize sẵn để listing không tải ảnh gốc vài MB)
+	BannerThumbnailURL *string `json:"bannerThumbnailUrl"`
+	BannerCardURL      *string `json:"bannerCardUrl"`
 
 	// Venue Area info
 	AreaID   *int    `json:"areaId"`
@@ -90,6 +119,19 @@ type EventListItem struct {
 
 	// ✅ NEW: Organizer ID để filter cho ORGANIZER role
 	OrganizerID *int `json:"organizerId"`
+
+	// Nhà tài trợ (chỉ có ở GET /api/events/open)
+	Sponsors []EventSponsor `json:"sponsors,omitempty"`
+
+	// Slug trang public (chỉ có ở GET /api/events/open)
+	Slug *string `json:"slug,omitempty"`
+
+	// Số chỗ còn lại / mọi loại vé đều 0đ (chỉ có ở GET /api/events/open, đọc bộ đếm Category_Ticket_Inventory)
+	RemainingSeats *int  `json:"remainingSeats,omitempty"`
+	IsFree         *bool `json:"isFree,omitempty"`
+
+	// Campus của địa điểm tổ chức
+	CampusID *int `json:"campusId"`
 }
 
 // ============================================================
@@ -97,14 +139,30 @@ type EventListItem struct {
 // Dùng cho: GET /api/events/detail?id=...
 // ============================================================
 type EventDetailDto struct {
-	EventID     int     `json:"eventId"`
-	Title       string  `json:"title"`
-	Description *string `json:"description"`
-	StartTime   string  `json:"startTime"`
-	EndTime     string  `json:"endTime"`
-	MaxSeats    int     `json:"maxSeats"`
-	Status      string  `json:"status"`
-	BannerURL   *string `json:"bannerUrl"`
+	EventID     int                `json:"eventId"`
+	Title       string             `json:"title"`
+	Description *string            `json:"description"`
+	StartTime   string             `json:"startTime"`
+	EndTime     string             `json:"endTime"`
+	MaxSeats    int                `json:"maxSeats"`
+	Status      string             `json:"status"`
+	StatusLabel *statuslabel.Label `json:"statusLabel"`
+	BannerURL   *string            `json:"bannerUrl"`
+	Slug        *string            `json:"slug"` // Link microsite: /api/public/events/{slug}
+
+	// Điểm rèn luyện cộng cho sinh viên check-in (0 = không tính điểm)
+	ActivityPoints int `json:"activityPoints"`
+	// % giá vé hoàn vào ví khi check-out (0 = không có cashback)
+	CashbackPercent int `json:"cashbackPercent"`
+	// Check-in chỉ nhận mã QR xoay vòng (GET /api/registrations/{ticketId}/qr-token)
+	DynamicQR bool `json:"dynamicQr"`
+	// Mở bán qua hàng chờ (POST /api/events/{id}/queue trước khi đặt vé)
+	BookingQueue bool `json:"bookingQueue"`
+
+	// Banner variants
+	BannerThumbnailURL *string `json:"bannerThumbnailUrl"`
+	BannerCardURL      *string `json:"bannerCardUrl"`
+	BannerHeroURL      *string `json:"bannerHeroUrl"`
 
 	// Venue info
 	VenueName *string `json:"venueName"`
@@ -122,11 +180,112 @@ type EventDetailDto struct {
 	SpeakerEmail     *string `json:"speakerEmail"`
 	SpeakerPhone     *string `json:"speakerPhone"`
 
+	// Tài liệu buổi nói do speaker đăng
+	Materials []SessionMaterial `json:"materials"`
+
+	// Tài liệu đính kèm của ban tổ chức (bản đầy đủ: mọi phạm vi hiển thị)
+	Attachments []EventAttachment `json:"attachments"`
+
+	// Nhà tài trợ (GOLD trước)
+	Sponsors []EventSponsor `json:"sponsors"`
+
+	// Lịch trình và câu hỏi thường gặp do organizer soạn (mảng rỗng nếu chưa có)
+	Schedule []ScheduleBlock `json:"schedule"`
+	FAQ      []FAQEntry      `json:"faq"`
+
 	// Danh sách loại vé
 	Tickets []CategoryTicket `json:"tickets"`
 
 	// Booking info - để frontend biết có lock không
 	HasBookings *bool `json:"hasBookings,omitempty"`
+
+	// Phiên bản của Event (cũng trả trong header ETag), gửi lại qua If-Match khi cập nhật
+	Version int `json:"version"`
+}
+
+// ============================================================
+// EventDetailPublicDto - Chi tiết sự kiện cho khách / sinh viên
+// Liệt kê tường minh từng trường: trường mới thêm vào EventDetailDto
+// không tự lộ ra public. Bỏ liên hệ cá nhân của speaker và hasBookings
+// ============================================================
+type EventDetailPublicDto struct {
+	EventID     int                `json:"eventId"`
+	Title       string             `json:"title"`
+	Description *string            `json:"description"`
+	StartTime   string             `json:"startTime"`
+	EndTime     string             `json:"endTime"`
+	MaxSeats    int                `json:"maxSeats"`
+	Status      string             `json:"status"`
+	StatusLabel *statuslabel.Label `json:"statusLabel"`
+	BannerURL   *string            `json:"bannerUrl"`
+	Slug        *string            `json:"slug"`
+
+	ActivityPoints  int  `json:"activityPoints"`
+	CashbackPercent int  `json:"cashbackPercent"`
+	DynamicQR       bool `json:"dynamicQr"`
+	BookingQueue    bool `json:"bookingQueue"`
+
+	BannerThumbnailURL *string `json:"bannerThumbnailUrl"`
+	BannerCardURL      *string `json:"bannerCardUrl"`
+	BannerHeroURL      *string `json:"bannerHeroUrl"`
+
+	VenueName    *string `json:"venueName"`
+	AreaID       *int    `json:"areaId"`
+	AreaName     *string `json:"areaName"`
+	Floor        *string `json:"floor"`
+	AreaCapacity *int    `json:"areaCapacity"`
+
+	SpeakerName      *string `json:"speakerName"`
+	SpeakerBio       *string `json:"speakerBio"`
+	SpeakerAvatarURL *string `json:"speakerAvatarUrl"`
+
+	Materials []SessionMaterial `json:"materials"`
+
+	// Chỉ PUBLIC, trừ khi người xem có vé (handler lọc lại)
+	Attachments []EventAttachment `json:"attachments"`
+
+	Sponsors []EventSponsor `json:"sponsors"`
+
+	Schedule []ScheduleBlock `json:"schedule"`
+	FAQ      []FAQEntry      `json:"faq"`
+
+	Tickets []CategoryTicket `json:"tickets"`
+}
+
+// Public - Bản rút gọn của chi tiết sự kiện để trả cho người không quản lý sự kiện
+func (d *EventDetailDto) Public() *EventDetailPublicDto {
+	return &EventDetailPublicDto{
+		EventID:            d.EventID,
+		Title:              d.Title,
+		Description:        d.Description,
+		StartTime:          d.StartTime,
+		EndTime:            d.EndTime,
+		MaxSeats:           d.MaxSeats,
+		Status:             d.Status,
+		BannerURL:          d.BannerURL,
+		Slug:               d.Slug,
+		ActivityPoints:     d.ActivityPoints,
+		CashbackPercent:    d.CashbackPercent,
+		DynamicQR:          d.DynamicQR,
+		BookingQueue:       d.BookingQueue,
+		BannerThumbnailURL: d.BannerThumbnailURL,
+		BannerCardURL:      d.BannerCardURL,
+		BannerHeroURL:      d.BannerHeroURL,
+		VenueName:          d.VenueName,
+		AreaID:             d.AreaID,
+		AreaName:           d.AreaName,
+		Floor:              d.Floor,
+		AreaCapacity:       d.AreaCapacity,
+		SpeakerName:        d.SpeakerName,
+		SpeakerBio:         d.SpeakerBio,
+		SpeakerAvatarURL:   d.SpeakerAvatarURL,
+		Materials:          d.Materials,
+		Attachments:        VisibleAttachments(d.Attachments, false),
+		Sponsors:           d.Sponsors,
+		Schedule:           d.Schedule,
+		FAQ:                d.FAQ,
+		Tickets:            d.Tickets,
+	}
 }
 
 // ============================================================
@@ -150,21 +309,22 @@ type EventDetailResponse = EventDetailDto
 // KHỚP VỚI Java DTO/EventRequest.java
 // ============================================================
 type EventRequest struct {
-	RequestID          int     `json:"requestId"`
-	RequesterID        int     `json:"requesterId"`
-	RequesterName      *string `json:"requesterName"`
-	Title              string  `json:"title"`
-	Description        *string `json:"description"`
-	PreferredStartTime *string `json:"preferredStartTime"`
-	PreferredEndTime   *string `json:"preferredEndTime"`
-	ExpectedCapacity   *int    `json:"expectedCapacity"`
-	Status             string  `json:"status"`
-	CreatedAt          *string `json:"createdAt"`
-	ProcessedBy        *int    `json:"processedBy"`
-	ProcessedByName    *string `json:"processedByName"`
-	ProcessedAt        *string `json:"processedAt"`
-	OrganizerNote      *string `json:"organizerNote"`
-	RejectReason       *string `json:"rejectReason"` // ✅ NEW: Lý do từ chối
+	RequestID          int                `json:"requestId"`
+	RequesterID        int                `json:"requesterId"`
+	RequesterName      *string            `json:"requesterName"`
+	Title              string             `json:"title"`
+	Description        *string            `json:"description"`
+	PreferredStartTime *string            `json:"preferredStartTime"`
+	PreferredEndTime   *string            `json:"preferredEndTime"`
+	ExpectedCapacity   *int               `json:"expectedCapacity"`
+	Status             string             `json:"status"`
+	StatusLabel        *statuslabel.Label `json:"statusLabel"` // Nhãn hiển thị của status (điền khi serialize)
+	CreatedAt          *string            `json:"createdAt"`
+	ProcessedBy        *int               `json:"processedBy"`
+	ProcessedByName    *string            `json:"processedByName"`
+	ProcessedAt        *string            `json:"processedAt"`
+	OrganizerNote      *string            `json:"organizerNote"`
+	RejectReason       *string            `json:"rejectReason"` // ✅ NEW: Lý do từ chối
 	// ✅ NEW: Venue information (when APPROVED)
 	VenueName    *string `json:"venueName"`
 	AreaName     *string `json:"areaName"`
@@ -172,14 +332,50 @@ type EventRequest struct {
 	AreaCapacity *int    `json:"areaCapacity"`
 
 	// Optional event details when request has been approved and event created
-	CreatedEventID *int    `json:"createdEventId"`
-	EventStatus    *string `json:"eventStatus,omitempty"` // Status of created Event (UPDATING, OPEN, etc.)
-	BannerURL      *string `json:"bannerUrl,omitempty"`
+	CreatedEventID   *int               `json:"createdEventId"`
+	EventStatus      *string            `json:"eventStatus,omitempty"` // Status of created Event (UPDATING, OPEN, etc.)
+	EventStatusLabel *statuslabel.Label `json:"eventStatusLabel,omitempty"`
+	BannerURL        *string            `json:"bannerUrl,omitempty"`
 	// Nested speaker object for frontend convenience
 	Speaker *SpeakerDTO      `json:"speaker,omitempty"`
 	Tickets []CategoryTicket `json:"tickets,omitempty"`
+
+	// Request được clone từ request khác (speaker/tickets lấy từ draft)
+	ClonedFromRequestID *int `json:"clonedFromRequestId,omitempty"`
+	// Request tạo từ mẫu sự kiện
+	TemplateID *int `json:"templateId,omitempty"`
+
+	// Ngân sách: số tiền xin tài trợ và kết quả duyệt ngân sách gần nhất (chi tiết: GET /budget)
+	RequestedFunding   *float64 `json:"requestedFunding,omitempty"`
+	BudgetReviewStatus *string  `json:"budgetReviewStatus,omitempty"`
+
+	// Người duyệt đang nhận xử lý (claim còn hiệu lực), chỉ có trong danh sách của STAFF
+	AssignedTo     *int    `json:"assignedTo,omitempty"`
+	AssignedToName *string `json:"assignedToName,omitempty"`
+	ClaimedAt      *string `json:"claimedAt,omitempty"`
+
+	// Tiện ích organizer yêu cầu (common/amenity), dùng để gợi ý khu vực khi duyệt
+	RequiredAmenities []string `json:"requiredAmenities,omitempty"`
+
+	// Phiên bản của Event_Request (cũng trả trong header ETag), gửi lại qua If-Match khi duyệt
+	Version int `json:"version"`
 }
 
+// ============================================================
+// EventRequestClaim - Trạng thái nhận xử lý yêu cầu của một người duyệt
+// Claim hết hiệu lực sau ClaimTTLMinutes phút không thao tác (expiresAt)
+// ============================================================
+type EventRequestClaim struct {
+	RequestID      int     `json:"requestId"`
+	AssignedTo     int     `json:"assignedTo"`
+	AssignedToName *string `json:"assignedToName"`
+	ClaimedAt      string  `json:"claimedAt"`
+	ExpiresAt      string  `json:"expiresAt"`
+}
+
+// ClaimTTLMinutes - Số phút không thao tác thì claim tự được nhả
+const ClaimTTLMinutes = 30
+
 // ============================================================
 // CreateEventRequestBody - Request body từ FE
 // ============================================================
@@ -189,6 +385,35 @@ type CreateEventRequestBody struct {
 	PreferredStartTime string  `json:"preferredStartTime"`
 	PreferredEndTime   string  `json:"preferredEndTime"`
 	ExpectedCapacity   *int    `json:"expectedCapacity"`
+	// Dự toán ngân sách (tùy chọn), có thể sửa lại khi request còn PENDING
+	Budget *EventBudgetInput `json:"budget,omitempty"`
+	// Tiện ích cần có ở khu vực (PROJECTOR, STAGE, LIVESTREAM...), xem common/amenity.All
+	RequiredAmenities []string `json:"requiredAmenities,omitempty"`
+
+	// Tạo từ mẫu sự kiện (không nhận từ FE): ghi template_id và cơ cấu vé vào draft_payload
+	TemplateID *int               `json:"-"`
+	Draft      *EventRequestDraft `json:"-"`
+}
+
+// ============================================================
+// CloneEventRequestBody - POST /api/event-requests/{id}/clone
+// Chỉ cần ngày mới; title/description/capacity có thể override
+// ============================================================
+type CloneEventRequestBody struct {
+	PreferredStartTime string  `json:"preferredStartTime"`
+	PreferredEndTime   string  `json:"preferredEndTime"`
+	Title              *string `json:"title"`
+	Description        *string `json:"description"`
+	ExpectedCapacity   *int    `json:"expectedCapacity"`
+}
+
+// ============================================================
+// EventRequestDraft - Cấu hình speaker + tickets lưu kèm request (cột draft_payload)
+// Được áp dụng vào Event khi request được APPROVED
+// ============================================================
+type EventRequestDraft struct {
+	Speaker *SpeakerDTO         `json:"speaker,omitempty"`
+	Tickets []CategoryTicketDTO `json:"tickets,omitempty"`
 }
 
 // ============================================================
@@ -203,6 +428,9 @@ type ProcessEventRequestBody struct {
 	AreaID    *int    `json:"areaId"`
 	SpeakerID *int    `json:"speakerId"`
 	BannerURL *string `json:"bannerUrl"`
+
+	// Version lấy từ header If-Match (nil = không kiểm tra)
+	ExpectedVersion *int `json:"-"`
 }
 
 // ============================================================
@@ -222,7 +450,7 @@ type CancelEventRequest struct {
 type CheckDailyQuotaResponse struct {
 	EventDate      string `json:"eventDate"`      // Date of event (YYYY-MM-DD)
 	CurrentCount   int    `json:"currentCount"`   // Số event đã approved trong ngày
-	MaxAllowed     int    `json:"maxAllowed"`     // Giới hạn (2)
+	MaxAllowed     int    `json:"maxAllowed"`     // Giới hạn (rule.max_events_per_day)
 	QuotaExceeded  bool   `json:"quotaExceeded"`  // true nếu >= 2
 	CanApproveMore bool   `json:"canApproveMore"` // false nếu >= 2
 	WarningMessage string `json:"warningMessage"` // Message cho frontend
@@ -252,6 +480,58 @@ type UpdateEventDetailsRequest struct {
 	Speaker   *SpeakerDTO         `json:"speaker"`
 	Tickets   []CategoryTicketDTO `json:"tickets"`
 	BannerURL *string             `json:"bannerUrl"`
+	// Mở / đóng mua vé cho khách không có tài khoản (nil = giữ nguyên)
+	AllowGuestCheckout *bool `json:"allowGuestCheckout"`
+
+	// Nội dung trang sự kiện (nil = giữ nguyên; mảng rỗng = xóa hết)
+	Description *string          `json:"description"` // HTML theo allow-list
+	Schedule    *[]ScheduleBlock `json:"schedule"`
+	FAQ         *[]FAQEntry      `json:"faq"`
+
+	// Version lấy từ header If-Match (nil = không kiểm tra)
+	ExpectedVersion *int `json:"-"`
+}
+
+// ChangedFields - Trường request có gửi, dùng cho kiểm tra mốc khóa chỉnh sửa
+func (r *UpdateEventDetailsRequest) ChangedFields() []string {
+	var fields []string
+	if r.Speaker != nil {
+		fields = append(fields, "speaker")
+	}
+	if len(r.Tickets) > 0 {
+		fields = append(fields, "tickets")
+	}
+	if r.BannerURL != nil {
+		fields = append(fields, "bannerUrl")
+	}
+	if r.AllowGuestCheckout != nil {
+		fields = append(fields, "allowGuestCheckout")
+	}
+	if r.Description != nil {
+		fields = append(fields, "description")
+	}
+	if r.Schedule != nil {
+		fields = append(fields, "schedule")
+	}
+	if r.FAQ != nil {
+		fields = append(fields, "faq")
+	}
+	return fields
+}
+
+// ScheduleBlock - Một mục trong lịch trình sự kiện (giờ HH:MM trong ngày diễn ra)
+type ScheduleBlock struct {
+	StartTime   string  `json:"startTime"`
+	EndTime     *string `json:"endTime,omitempty"`
+	Title       string  `json:"title"`
+	Speaker     *string `json:"speaker,omitempty"`
+	Description *string `json:"description,omitempty"`
+}
+
+// FAQEntry - Một câu hỏi thường gặp
+type FAQEntry struct {
+	Question string `json:"question"`
+	Answer   string `json:"answer"`
 }
 
 type SpeakerDTO struct {
@@ -263,11 +543,13 @@ type SpeakerDTO struct {
 }
 
 type CategoryTicketDTO struct {
-	Name        string  `json:"name"`
-	Description *string `json:"description"`
-	Price       float64 `json:"price"`
-	MaxQuantity int     `json:"maxQuantity"`
-	Status      *string `json:"status"`
+	// ID của category hiện có (nil = loại vé mới). Dùng để cập nhật tại chỗ, giữ FK từ Ticket
+	CategoryTicketID *int    `json:"categoryTicketId,omitempty"`
+	Name             string  `json:"name"`
+	Description      *string `json:"description"`
+	Price            float64 `json:"price"`
+	MaxQuantity      int     `json:"maxQuantity"`
+	Status           *string `json:"status"`
 }
 
 // ============================================================
@@ -283,7 +565,16 @@ type EventStatsResponse struct {
 	BookedCount     int     `json:"bookedCount"`
 	CancelledCount  int     `json:"cancelledCount"`
 	RefundedCount   int     `json:"totalRefunded"` // ✅ NEW: Track refunded tickets count
-	TotalRevenue    float64 `json:"totalRevenue"`
+	// Vé mời 0 đồng do ban tổ chức phát (đã nằm trong TotalTickets, không tính vào TotalRevenue)
+	ComplimentaryCount int     `json:"totalComplimentary"`
+	TotalRevenue       float64 `json:"totalRevenue"`
+	// Phí nền tảng đã giữ lại (phí của vé hoàn tiền được trừ ra), lưu trên Bill_Item lúc mua
+	PlatformFee float64 `json:"platformFee"`
+	// Vé đã nhận cashback khi check-out và tổng tiền đã hoàn vào ví (trừ vào doanh thu trên sổ cái)
+	CashbackRedeemed int     `json:"cashbackRedeemed"`
+	CashbackAmount   float64 `json:"cashbackAmount"`
+	// Số liệu trước mốc này lấy từ bảng tổng hợp theo ngày, từ mốc này tính trực tiếp (nil = toàn bộ realtime)
+	RealtimeSince *time.Time `json:"realtimeSince,omitempty"`
 }
 
 // ============================================================
@@ -295,6 +586,9 @@ type UpdateEventConfigRequest struct {
 	EventID                          int `json:"eventId"`                          // -1 = global config (admin only), >0 = specific event
 	CheckinAllowedBeforeStartMinutes int `json:"checkinAllowedBeforeStartMinutes"` // Số phút cho phép check-in trước start_time
 	MinMinutesAfterStart             int `json:"minMinutesAfterStart"`             // Số phút tối thiểu sau start_time mới cho phép check-out
+
+	// Version của Event lấy từ header If-Match (nil = không kiểm tra, bỏ qua với global config)
+	ExpectedVersion *int `json:"-"`
 }
 
 // ============================================================
@@ -322,12 +616,119 @@ type EventConfigResponse struct {
 // YÊU CẦU #4: Gợi ý địa điểm cho Staff khi chọn
 // ============================================================
 type AvailableAreaInfo struct {
-	AreaID    int     `json:"areaId"`
-	AreaName  string  `json:"areaName"`
-	VenueName string  `json:"venueName"`
-	Floor     *string `json:"floor"`
-	Capacity  *int    `json:"capacity"`
-	Status    string  `json:"status"`
+	AreaID    int      `json:"areaId"`
+	AreaName  string   `json:"areaName"`
+	VenueName string   `json:"venueName"`
+	Floor     *string  `json:"floor"`
+	Capacity  *int     `json:"capacity"`
+	Status    string   `json:"status"`
+	CampusID  *int     `json:"campusId"`
+	Amenities []string `json:"amenities"`
+	// Chỉ có khi truy vấn kèm tiện ích yêu cầu (amenities= hoặc requestId=)
+	MatchedAmenities []string `json:"matchedAmenities,omitempty"`
+	MissingAmenities []string `json:"missingAmenities,omitempty"`
+}
+
+// ============================================================
+// AreaSuggestions - Gợi ý khu vực khi duyệt một yêu cầu sự kiện
+// Dùng cho: GET /api/staff/event-requests/{id}/suggested-areas
+// Khu vực trùng lịch, đã đủ số sự kiện trong ngày hoặc thiếu sức chứa bị loại (ExcludedAreas)
+// ============================================================
+type AreaSuggestions struct {
+	RequestID         int              `json:"requestId"`
+	ExpectedCapacity  int              `json:"expectedCapacity"`
+	RequiredAmenities []string         `json:"requiredAmenities"`
+	Suggestions       []AreaSuggestion `json:"suggestions"` // Điểm cao nhất đứng đầu
+	ExcludedAreas     int              `json:"excludedAreas"`
+}
+
+// AreaSuggestion - Một khu vực được gợi ý; các điểm thành phần nằm trong [0, 1]
+type AreaSuggestion struct {
+	AreaID             int      `json:"areaId"`
+	AreaName           string   `json:"areaName"`
+	VenueName          string   `json:"venueName"`
+	Floor              *string  `json:"floor"`
+	Capacity           int      `json:"capacity"`
+	CampusID           *int     `json:"campusId"`
+	MatchedAmenities   []string `json:"matchedAmenities"`
+	MissingAmenities   []string `json:"missingAmenities"`
+	BookedHours        float64  `json:"bookedHours"`        // Giờ đã đặt trong ±30 ngày quanh thời gian yêu cầu
+	CapacityFit        float64  `json:"capacityFit"`        // Sức chứa dự kiến / sức chứa phòng
+	AmenityMatch       float64  `json:"amenityMatch"`       // Tỉ lệ tiện ích yêu cầu khu vực có
+	UtilizationBalance float64  `json:"utilizationBalance"` // Phòng ít được dùng hơn điểm cao hơn
+	Score              float64  `json:"score"`
+}
+
+// AreaCandidate - Khu vực cùng số liệu lịch đặt quanh thời gian yêu cầu (đầu vào chấm điểm)
+type AreaCandidate struct {
+	AreaID        int
+	AreaName      string
+	VenueName     string
+	Floor         *string
+	Capacity      int
+	CampusID      *int
+	Amenities     []string
+	Conflicts     int // Sự kiện trùng khung giờ (kể cả 1 giờ đệm)
+	EventsOnDate  int
+	BookedMinutes int
+}
+
+// ApproveSuggestedBody - POST /api/staff/event-requests/{id}/approve-suggested (body tùy chọn)
+type ApproveSuggestedBody struct {
+	OrganizerNote *string `json:"organizerNote"`
+}
+
+// ChangeAreaRequest - Body POST /api/staff/events/{id}/change-area
+// DryRun = true: chạy toàn bộ kiểm tra và ghép ghế rồi hủy, không gửi thông báo
+type ChangeAreaRequest struct {
+	AreaID int     `json:"areaId"`
+	Reason *string `json:"reason"`
+	DryRun bool    `json:"dryRun"`
+}
+
+// ============================================================
+// AreaChange - Một lần chuyển sự kiện sang khu vực khác
+// Dùng cho: POST /api/staff/events/{id}/change-area (kết quả) và
+// GET /api/staff/events/{id}/area-changes (lịch sử, không có categories)
+// ============================================================
+type AreaChange struct {
+	ChangeID       int                  `json:"changeId,omitempty"` // 0 khi dryRun
+	EventID        int                  `json:"eventId"`
+	DryRun         bool                 `json:"dryRun,omitempty"`
+	FromAreaID     *int                 `json:"fromAreaId"`
+	FromArea       string               `json:"fromArea"` // "Khu vực - Địa điểm"
+	ToAreaID       int                  `json:"toAreaId"`
+	ToArea         string               `json:"toArea"`
+	Capacity       int                  `json:"capacity,omitempty"` // Sức chứa khu vực mới
+	ChangedBy      int                  `json:"changedBy,omitempty"`
+	Reason         *string              `json:"reason"`
+	ChangedAt      *time.Time           `json:"changedAt,omitempty"`
+	Categories     []AreaChangeCategory `json:"categories,omitempty"`
+	TicketsMoved   int                  `json:"ticketsMoved"`   // Vé đã có ghế ở khu vực mới
+	TicketsFlagged int                  `json:"ticketsFlagged"` // Vé cần xếp lại ghế thủ công
+	Tickets        []AreaChangeTicket   `json:"tickets"`
+	Notified       int                  `json:"notified"` // Số người mua được báo (0 khi dryRun)
+}
+
+// AreaChangeCategory - Số lượng loại vé trước / sau khi chuyển (giảm khi khu vực mới nhỏ hơn)
+type AreaChangeCategory struct {
+	CategoryTicketID int    `json:"categoryTicketId"`
+	Name             string `json:"name"`
+	Sold             int    `json:"sold"`
+	OldQuantity      int    `json:"oldQuantity"`
+	NewQuantity      int    `json:"newQuantity"`
+}
+
+// AreaChangeTicket - Ghế cũ → ghế mới của một vé đã bán
+// Reason: NO_ACCESSIBLE_SEAT (vé xe lăn đang ngồi tạm ghế thường), NO_SEAT (chưa có ghế)
+type AreaChangeTicket struct {
+	TicketID          int     `json:"ticketId"`
+	UserID            int     `json:"userId"`
+	CategoryTicketID  int     `json:"categoryTicketId"`
+	FromSeat          *string `json:"fromSeat"`
+	ToSeat            *string `json:"toSeat"`
+	NeedsReassignment bool    `json:"needsReassignment"`
+	Reason            *string `json:"reason,omitempty"`
 }
 
 // ============================================================
@@ -353,3 +754,1029 @@ type UpdateEventRequestRequest struct {
 	BannerUrl          string                   `json:"bannerUrl,omitempty"`
 	DryRun             bool                     `json:"dryRun,omitempty"` // ✅ NEW: If true, validate only, don't commit
 }
+
+// ChangedFields - Trường request có gửi (rỗng = giữ nguyên), dùng cho kiểm tra mốc khóa chỉnh sửa
+func (r *UpdateEventRequestRequest) ChangedFields() []string {
+	var fields []string
+	add := func(name string, set bool) {
+		if set {
+			fields = append(fields, name)
+		}
+	}
+	add("title", r.Title != "")
+	add("description", r.Description != "")
+	add("preferredStartTime", r.PreferredStartTime != "")
+	add("preferredEndTime", r.PreferredEndTime != "")
+	add("expectedCapacity", r.ExpectedCapacity > 0)
+	add("speaker", r.Speaker != nil)
+	add("tickets", len(r.Tickets) > 0)
+	add("bannerUrl", r.BannerUrl != "")
+	return fields
+}
+
+// ============================================================
+// UploadEventBannerRequest - POST /api/events/{id}/banner
+// Một trong hai: sourceUrl (server tự tải ảnh) hoặc imageBase64
+// Hoặc gửi raw body với Content-Type: image/*
+// ============================================================
+type UploadEventBannerRequest struct {
+	SourceURL   string `json:"sourceUrl,omitempty"`
+	ImageBase64 string `json:"imageBase64,omitempty"`
+}
+
+// ============================================================
+// BannerVariants - URL các bản resize của banner đã lưu trên Event
+// ============================================================
+type BannerVariants struct {
+	EventID      int    `json:"eventId"`
+	OriginalURL  string `json:"bannerUrl"`
+	ThumbnailURL string `json:"bannerThumbnailUrl"`
+	CardURL      string `json:"bannerCardUrl"`
+	HeroURL      string `json:"bannerHeroUrl"`
+	Width        int    `json:"width"`
+	Height       int    `json:"height"`
+}
+
+// ============================================================
+// CapacityChangeError - Lỗi có cấu trúc khi thay đổi số lượng vé bị chặn
+// Trả về 409 cho frontend để hiển thị từng loại vé vi phạm
+// ============================================================
+type CapacityChangeError struct {
+	Code       string              `json:"code"` // CAPACITY_REDUCTION_BLOCKED, INSUFFICIENT_SEATS
+	Message    string              `json:"message"`
+	Violations []CapacityViolation `json:"violations"`
+}
+
+func (e *CapacityChangeError) Error() string {
+	return e.Message
+}
+
+// CapacityViolation - Chi tiết một loại vé vi phạm
+type CapacityViolation struct {
+	CategoryTicketID  int    `json:"categoryTicketId"`
+	Name              string `json:"name"`
+	Reason            string `json:"reason"` // BELOW_SOLD_COUNT, CATEGORY_HAS_SALES, INSUFFICIENT_SEATS
+	CurrentQuantity   int    `json:"currentQuantity"`
+	RequestedQuantity int    `json:"requestedQuantity"`
+	SoldCount         int    `json:"soldCount"`
+}
+
+// ============================================================
+// EventSummary - Thống kê chốt khi sự kiện kết thúc (bảng Event_Summary)
+// Được ghi bởi job event-archival, không thay đổi sau khi chốt
+// ============================================================
+type EventSummary struct {
+	EventID         int       `json:"eventId"`
+	TotalSeats      int       `json:"totalSeats"`
+	TicketsSold     int       `json:"ticketsSold"`
+	CheckedInCount  int       `json:"checkedInCount"`
+	CheckedOutCount int       `json:"checkedOutCount"`
+	NoShowCount     int       `json:"noShowCount"`
+	RefundedCount   int       `json:"refundedCount"`
+	ExpiredPending  int       `json:"expiredPending"`
+	UnsoldSeats     int       `json:"unsoldSeats"`
+	TotalRevenue    float64   `json:"totalRevenue"`
+	FinalizedAt     time.Time `json:"finalizedAt"`
+}
+
+// ============================================================
+// EventBookingInfo - Thông tin sự kiện mà service khác cần khi đặt vé
+// Khớp message EventBookingInfo trong services/proto/event.proto
+// ============================================================
+type EventBookingInfo struct {
+	EventID       int       `json:"eventId"`
+	Title         string    `json:"title"`
+	Status        string    `json:"status"`
+	StartTime     time.Time `json:"startTime"`
+	EndTime       time.Time `json:"endTime"`
+	AreaID        *int      `json:"areaId"`
+	AreaName      *string   `json:"areaName"`
+	VenueName     *string   `json:"venueName"`
+	VenueLocation *string   `json:"venueLocation"`
+	CreatedBy     int       `json:"createdBy"`
+	// Số vé mời tối đa (nil = dùng COMP_TICKET_DEFAULT_QUOTA)
+	CompTicketQuota *int `json:"compTicketQuota"`
+	// Cho khách không có tài khoản mua vé (POST /api/guest/checkout)
+	AllowGuestCheckout bool `json:"allowGuestCheckout"`
+	// Đặt vé qua hàng chờ mở bán; số người được vào mỗi nhịp dispatcher
+	BookingQueue          bool `json:"bookingQueue"`
+	QueueAdmitPerInterval int  `json:"queueAdmitPerInterval"`
+}
+
+// ============================================================
+// EventCollaborator - Đồng tổ chức sự kiện (bảng Event_Collaborator)
+// Chủ sự kiện mời ORGANIZER khác với từng quyền riêng
+// ============================================================
+
+// Quyền của co-organizer trên một sự kiện
+const (
+	PermissionEditDetails         = "EDIT_DETAILS"
+	PermissionViewStats           = "VIEW_STATS"
+	PermissionManageAnnouncements = "MANAGE_ANNOUNCEMENTS"
+)
+
+// CollaboratorPermissions - Danh sách quyền hợp lệ
+var CollaboratorPermissions = []string{PermissionEditDetails, PermissionViewStats, PermissionManageAnnouncements}
+
+// Trạng thái lời mời
+const (
+	CollaboratorPending  = "PENDING"
+	CollaboratorAccepted = "ACCEPTED"
+)
+
+type EventCollaborator struct {
+	EventID     int        `json:"eventId"`
+	UserID      int        `json:"userId"`
+	FullName    string     `json:"fullName"`
+	Email       string     `json:"email"`
+	Permissions []string   `json:"permissions"`
+	Status      string     `json:"status"`
+	InvitedBy   int        `json:"invitedBy"`
+	InvitedAt   time.Time  `json:"invitedAt"`
+	AcceptedAt  *time.Time `json:"acceptedAt,omitempty"`
+}
+
+// InviteCollaboratorRequest - Body POST /api/events/{id}/collaborators
+// Mời lại cùng email để cập nhật quyền
+type InviteCollaboratorRequest struct {
+	Email       string   `json:"email"`
+	Permissions []string `json:"permissions"`
+}
+
+// EventCollaboratorInvitation - Lời mời đồng tổ chức đang chờ (GET /api/organizer/collaborations)
+type EventCollaboratorInvitation struct {
+	EventID       int       `json:"eventId"`
+	EventTitle    string    `json:"eventTitle"`
+	StartTime     time.Time `json:"startTime"`
+	Permissions   []string  `json:"permissions"`
+	InvitedBy     int       `json:"invitedBy"`
+	InvitedByName string    `json:"invitedByName"`
+	InvitedAt     time.Time `json:"invitedAt"`
+}
+
+// ============================================================
+// Cấu hình vé PDF theo sự kiện (GET|PUT /api/events/{id}/ticket-template)
+// Layout/khổ giấy: xem common/pdf (classic, branded; A4, A5, A6, Letter)
+// ============================================================
+type EventTicketTemplate struct {
+	EventID          int        `json:"eventId"`
+	Layout           string     `json:"layout"`
+	PageSize         string     `json:"pageSize"`
+	AccentColor      string     `json:"accentColor,omitempty"`
+	ShowBanner       bool       `json:"showBanner"`
+	OrganizerLogoURL string     `json:"organizerLogoUrl,omitempty"`
+	SponsorLogoURLs  []string   `json:"sponsorLogoUrls"`
+	FooterNote       string     `json:"footerNote,omitempty"`
+	BannerURL        string     `json:"bannerUrl,omitempty"` // Chỉ đọc: banner sự kiện in khi showBanner
+	UpdatedAt        *time.Time `json:"updatedAt,omitempty"` // nil = chưa cấu hình, đang dùng mặc định
+}
+
+// ============================================================
+// Speaker portal - Diễn giả tự quản lý hồ sơ và tài liệu buổi nói
+// Tài khoản role SPEAKER liên kết với các dòng Speaker qua Speaker.user_id
+// ============================================================
+
+// SessionMaterial - Tài liệu (slide, link...) speaker đăng cho sự kiện
+type SessionMaterial struct {
+	MaterialID int       `json:"materialId"`
+	EventID    int       `json:"eventId"`
+	Title      string    `json:"title"`
+	URL        string    `json:"url"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+// AddSessionMaterialRequest - Body POST /api/speaker/events/{id}/materials
+type AddSessionMaterialRequest struct {
+	Title string `json:"title"`
+	URL   string `json:"url"`
+}
+
+// InviteSpeakerRequest - Body POST /api/events/{id}/speaker/invite
+// Email trống = dùng email đang lưu trong hồ sơ speaker của sự kiện
+type InviteSpeakerRequest struct {
+	Email string `json:"email"`
+}
+
+// SpeakerInvitation - Lời mời đã gửi (không trả token, token chỉ nằm trong email)
+type SpeakerInvitation struct {
+	EventID   int       `json:"eventId"`
+	SpeakerID int       `json:"speakerId"`
+	Email     string    `json:"email"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// AcceptSpeakerInvitationRequest - Body POST /api/speaker/invitations/accept
+// Password bắt buộc khi email chưa có tài khoản (tạo tài khoản SPEAKER mới)
+type AcceptSpeakerInvitationRequest struct {
+	Token    string `json:"token"`
+	Password string `json:"password"`
+}
+
+// AcceptSpeakerInvitationResponse - Kết quả liên kết tài khoản
+type AcceptSpeakerInvitationResponse struct {
+	UserID         int    `json:"userId"`
+	Email          string `json:"email"`
+	AccountCreated bool   `json:"accountCreated"`
+	EventID        int    `json:"eventId"`
+}
+
+// SpeakerProfile - GET|PUT /api/speaker/profile
+// PUT chỉ cập nhật bio và avatarUrl, áp dụng cho mọi hồ sơ Speaker đã liên kết
+type SpeakerProfile struct {
+	FullName  string  `json:"fullName"`
+	Email     string  `json:"email"`
+	Bio       *string `json:"bio"`
+	AvatarURL *string `json:"avatarUrl"`
+}
+
+// SpeakerEvent - Sự kiện mà speaker hiện tại trình bày (GET /api/speaker/my-events)
+type SpeakerEvent struct {
+	EventID   int               `json:"eventId"`
+	Title     string            `json:"title"`
+	StartTime time.Time         `json:"startTime"`
+	EndTime   time.Time         `json:"endTime"`
+	Status    string            `json:"status"`
+	BannerURL *string           `json:"bannerUrl"`
+	VenueName *string           `json:"venueName"`
+	AreaName  *string           `json:"areaName"`
+	Materials []SessionMaterial `json:"materials"`
+}
+
+// ============================================================
+// Event attachments - Agenda, bản đồ, slide... ban tổ chức đính kèm sự kiện
+// (GET|POST /api/events/{id}/attachments, PUT|DELETE /api/events/{id}/attachments/{attachmentId})
+// ============================================================
+
+// Loại tài liệu
+const (
+	AttachmentTypeAgenda   = "AGENDA"
+	AttachmentTypeMap      = "MAP"
+	AttachmentTypeSlides   = "SLIDES"
+	AttachmentTypeDocument = "DOCUMENT"
+	AttachmentTypeOther    = "OTHER"
+)
+
+// AttachmentTypes - Danh sách loại hợp lệ
+var AttachmentTypes = []string{AttachmentTypeAgenda, AttachmentTypeMap, AttachmentTypeSlides, AttachmentTypeDocument, AttachmentTypeOther}
+
+// Phạm vi hiển thị
+const (
+	AttachmentPublic        = "PUBLIC"
+	AttachmentTicketHolders = "TICKET_HOLDERS"
+)
+
+const (
+	// MaxAttachmentBytes - Dung lượng tối đa của file upload
+	MaxAttachmentBytes = 10 << 20
+	// MaxAttachmentsPerEvent - Số tài liệu tối đa mỗi sự kiện
+	MaxAttachmentsPerEvent = 20
+)
+
+// EventAttachment - Tài liệu đính kèm (file upload hoặc link ngoài)
+type EventAttachment struct {
+	AttachmentID int       `json:"attachmentId"`
+	EventID      int       `json:"eventId"`
+	Title        string    `json:"title"`
+	Type         string    `json:"type"`
+	URL          string    `json:"url"`
+	ObjectKey    string    `json:"-"`
+	ContentType  string    `json:"contentType,omitempty"`
+	SizeBytes    int64     `json:"sizeBytes,omitempty"`
+	Visibility   string    `json:"visibility"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+// EventAttachmentRequest - Body tạo / sửa tài liệu
+// Tạo mới: gửi url (link ngoài) hoặc fileName + fileBase64 (upload, hỗ trợ data URL)
+// Sửa: chỉ title, type, visibility (và url nếu là link ngoài)
+type EventAttachmentRequest struct {
+	Title      string `json:"title"`
+	Type       string `json:"type"`
+	Visibility string `json:"visibility"`
+	URL        string `json:"url"`
+	FileName   string `json:"fileName"`
+	FileBase64 string `json:"fileBase64"`
+}
+
+// VisibleAttachments - Tài liệu người xem được thấy; người chưa có vé chỉ thấy PUBLIC
+func VisibleAttachments(list []EventAttachment, ticketHolder bool) []EventAttachment {
+	if ticketHolder {
+		return list
+	}
+	visible := []EventAttachment{}
+	for _, a := range list {
+		if a.Visibility == AttachmentPublic {
+			visible = append(visible, a)
+		}
+	}
+	return visible
+}
+
+// ============================================================
+// Sponsors - Nhà tài trợ của sự kiện
+// (GET|POST /api/events/{id}/sponsors, PUT|DELETE /api/events/{id}/sponsors/{sponsorId})
+// ============================================================
+
+// Hạng nhà tài trợ (thứ tự hiển thị: GOLD trước)
+const (
+	SponsorTierGold    = "GOLD"
+	SponsorTierSilver  = "SILVER"
+	SponsorTierBronze  = "BRONZE"
+	SponsorTierPartner = "PARTNER"
+)
+
+// SponsorTiers - Danh sách hạng hợp lệ
+var SponsorTiers = []string{SponsorTierGold, SponsorTierSilver, SponsorTierBronze, SponsorTierPartner}
+
+// MaxSponsorsPerEvent - Số nhà tài trợ tối đa mỗi sự kiện
+const MaxSponsorsPerEvent = 30
+
+// EventSponsor - Nhà tài trợ gắn với một sự kiện
+type EventSponsor struct {
+	SponsorID    int     `json:"sponsorId"`
+	Name         string  `json:"name"`
+	Tier         string  `json:"tier"`
+	LogoURL      *string `json:"logoUrl"`
+	WebsiteURL   *string `json:"websiteUrl"`
+	DisplayOrder int     `json:"displayOrder"`
+}
+
+// EventSponsorRequest - Body thêm / sửa nhà tài trợ
+// Thêm: sponsorId để dùng lại hồ sơ có sẵn, hoặc name (+ logoUrl, websiteUrl) để tạo mới
+// Sửa: tier, displayOrder; name/logoUrl/websiteUrl sửa hồ sơ dùng chung (chỉ người tạo hoặc ADMIN)
+type EventSponsorRequest struct {
+	SponsorID    *int    `json:"sponsorId"`
+	Name         *string `json:"name"`
+	Tier         string  `json:"tier"`
+	LogoURL      *string `json:"logoUrl"`
+	WebsiteURL   *string `json:"websiteUrl"`
+	DisplayOrder *int    `json:"displayOrder"`
+}
+
+// ============================================================
+// Budget - Ngân sách của yêu cầu sự kiện
+// (GET|PUT /api/event-requests/{id}/budget, POST .../budget/reviews, PUT .../budget/actuals)
+// ============================================================
+
+// Hạng mục chi phí
+const (
+	BudgetCategoryVenue     = "VENUE"
+	BudgetCategoryCatering  = "CATERING"
+	BudgetCategorySpeaker   = "SPEAKER"
+	BudgetCategoryMarketing = "MARKETING"
+	BudgetCategoryEquipment = "EQUIPMENT"
+	BudgetCategoryPrinting  = "PRINTING"
+	BudgetCategoryOther     = "OTHER"
+)
+
+// BudgetCategories - Danh sách hạng mục hợp lệ
+var BudgetCategories = []string{
+	BudgetCategoryVenue, BudgetCategoryCatering, BudgetCategorySpeaker, BudgetCategoryMarketing,
+	BudgetCategoryEquipment, BudgetCategoryPrinting, BudgetCategoryOther,
+}
+
+// Kết quả duyệt ngân sách
+const (
+	BudgetReviewApproved     = "APPROVED"
+	BudgetReviewNeedsChanges = "NEEDS_CHANGES"
+	BudgetReviewRejected     = "REJECTED"
+)
+
+// MaxBudgetItems - Số hạng mục tối đa mỗi yêu cầu
+const MaxBudgetItems = 50
+
+// BudgetItem - Một dòng dự toán; ActualAmount nil = chưa nhập thực chi
+type BudgetItem struct {
+	ItemID          int      `json:"itemId"`
+	Category        string   `json:"category"`
+	Description     string   `json:"description"`
+	EstimatedAmount float64  `json:"estimatedAmount"`
+	ActualAmount    *float64 `json:"actualAmount"`
+}
+
+// BudgetReview - Một lần staff duyệt ngân sách
+type BudgetReview struct {
+	ReviewID        int      `json:"reviewId"`
+	ReviewerID      int      `json:"reviewerId"`
+	ReviewerName    *string  `json:"reviewerName"`
+	Decision        string   `json:"decision"`
+	ApprovedFunding *float64 `json:"approvedFunding"`
+	Comment         *string  `json:"comment"`
+	CreatedAt       string   `json:"createdAt"`
+}
+
+// EventRequestBudget - Dự toán, lịch sử duyệt và thực chi của một yêu cầu
+// ActualTotal/Variance chỉ có sau khi ban tổ chức nhập thực chi
+type EventRequestBudget struct {
+	RequestID          int            `json:"requestId"`
+	RequestStatus      string         `json:"requestStatus"`
+	RequestedFunding   *float64       `json:"requestedFunding"`
+	ApprovedFunding    *float64       `json:"approvedFunding"`
+	ReviewStatus       *string        `json:"reviewStatus"` // Quyết định gần nhất, nil = chưa duyệt
+	EstimatedTotal     float64        `json:"estimatedTotal"`
+	ActualTotal        *float64       `json:"actualTotal"`
+	Variance           *float64       `json:"variance"` // ActualTotal - EstimatedTotal
+	Items              []BudgetItem   `json:"items"`
+	Reviews            []BudgetReview `json:"reviews"`
+	ActualsNote        *string        `json:"actualsNote"`
+	ActualsSubmittedAt *string        `json:"actualsSubmittedAt"`
+}
+
+// EventBudgetInput - Dự toán gửi kèm khi tạo / sửa yêu cầu (thay toàn bộ hạng mục)
+type EventBudgetInput struct {
+	RequestedFunding *float64          `json:"requestedFunding"`
+	Items            []BudgetItemInput `json:"items"`
+}
+
+// BudgetItemInput - Một dòng dự toán
+type BudgetItemInput struct {
+	Category        string  `json:"category"`
+	Description     string  `json:"description"`
+	EstimatedAmount float64 `json:"estimatedAmount"`
+}
+
+// BudgetReviewRequest - Body POST /api/event-requests/{id}/budget/reviews
+// approvedFunding mặc định bằng requestedFunding khi APPROVED; comment bắt buộc khi không APPROVED
+type BudgetReviewRequest struct {
+	Decision        string   `json:"decision"`
+	ApprovedFunding *float64 `json:"approvedFunding"`
+	Comment         string   `json:"comment"`
+}
+
+// BudgetActualsRequest - Body PUT /api/event-requests/{id}/budget/actuals
+// Dòng có itemId cập nhật thực chi của hạng mục đã dự toán; không có itemId là chi phí phát sinh
+type BudgetActualsRequest struct {
+	Items []BudgetActualInput `json:"items"`
+	Note  *string             `json:"note"`
+}
+
+// BudgetActualInput - Thực chi của một hạng mục
+type BudgetActualInput struct {
+	ItemID       *int    `json:"itemId"`
+	Category     string  `json:"category"`
+	Description  string  `json:"description"`
+	ActualAmount float64 `json:"actualAmount"`
+}
+
+// Summarize tính tổng dự toán, tổng thực chi, chênh lệch và kết quả duyệt gần nhất
+// (Reviews sắp xếp mới nhất trước)
+func (b *EventRequestBudget) Summarize() {
+	var estimated, actual float64
+	hasActual := false
+	for _, item := range b.Items {
+		estimated += item.EstimatedAmount
+		if item.ActualAmount != nil {
+			actual += *item.ActualAmount
+			hasActual = true
+		}
+	}
+	b.EstimatedTotal = roundMoney(estimated)
+	b.ActualTotal, b.Variance = nil, nil
+	if hasActual {
+		total, variance := roundMoney(actual), roundMoney(actual-estimated)
+		b.ActualTotal, b.Variance = &total, &variance
+	}
+
+	b.ReviewStatus, b.ApprovedFunding = nil, nil
+	if len(b.Reviews) > 0 {
+		latest := b.Reviews[0]
+		b.ReviewStatus = &latest.Decision
+		if latest.Decision == BudgetReviewApproved {
+			b.ApprovedFunding = latest.ApprovedFunding
+		}
+	}
+}
+
+func roundMoney(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+// ============================================================
+// Survey - Khảo sát sau sự kiện
+// (GET|PUT /api/events/{id}/survey, GET /api/events/{id}/survey/results,
+// GET /api/student/surveys/{eventId}, POST /api/student/surveys/{eventId}/responses)
+// ============================================================
+
+// Trạng thái khảo sát
+const (
+	SurveyDraft  = "DRAFT"
+	SurveyOpen   = "OPEN"
+	SurveyClosed = "CLOSED"
+)
+
+// Loại câu hỏi
+const (
+	QuestionSingleChoice = "SINGLE_CHOICE"
+	QuestionMultiChoice  = "MULTI_CHOICE"
+	QuestionText         = "TEXT"
+	QuestionScale        = "SCALE"
+)
+
+// QuestionTypes - Danh sách loại câu hỏi hợp lệ
+var QuestionTypes = []string{QuestionSingleChoice, QuestionMultiChoice, QuestionText, QuestionScale}
+
+// Giới hạn của bộ câu hỏi
+const (
+	MaxSurveyQuestions     = 50
+	MaxSurveyOptions       = 20
+	MaxSurveyTextAnswerLen = 2000
+)
+
+// EventSurvey - Bộ câu hỏi khảo sát của sự kiện
+// HasResponded chỉ có ở API cho sinh viên
+type EventSurvey struct {
+	SurveyID      int              `json:"surveyId"`
+	EventID       int              `json:"eventId"`
+	Title         string           `json:"title"`
+	Description   *string          `json:"description"`
+	Status        string           `json:"status"`
+	Questions     []SurveyQuestion `json:"questions"`
+	ResponseCount int              `json:"responseCount"`
+	HasResponded  *bool            `json:"hasResponded,omitempty"`
+}
+
+// SurveyQuestion - Một câu hỏi; Options cho câu chọn, ScaleMin/ScaleMax cho câu thang điểm
+type SurveyQuestion struct {
+	QuestionID int      `json:"questionId"`
+	Position   int      `json:"position"`
+	Type       string   `json:"type"`
+	Prompt     string   `json:"prompt"`
+	Required   bool     `json:"required"`
+	Options    []string `json:"options,omitempty"`
+	ScaleMin   *int     `json:"scaleMin,omitempty"`
+	ScaleMax   *int     `json:"scaleMax,omitempty"`
+}
+
+// SaveSurveyRequest - Body PUT /api/events/{id}/survey
+// questions = null giữ nguyên câu hỏi (chỉ đổi title/description/status);
+// gửi questions là thay toàn bộ, chỉ được khi chưa có ai trả lời
+type SaveSurveyRequest struct {
+	Title       string           `json:"title"`
+	Description *string          `json:"description"`
+	Status      string           `json:"status"`
+	Questions   []SurveyQuestion `json:"questions"`
+}
+
+// SurveyResponseRequest - Body POST /api/student/surveys/{eventId}/responses
+type SurveyResponseRequest struct {
+	Answers []SurveyAnswer `json:"answers"`
+}
+
+// SurveyAnswer - Câu trả lời: Choices (câu chọn), Text (câu tự luận) hoặc Scale (thang điểm)
+type SurveyAnswer struct {
+	QuestionID int      `json:"questionId"`
+	Choices    []string `json:"choices,omitempty"`
+	Text       *string  `json:"text,omitempty"`
+	Scale      *int     `json:"scale,omitempty"`
+}
+
+// SurveyResponseRecord - Một phiếu trả lời (ẩn danh, dùng để tổng hợp / xuất CSV)
+type SurveyResponseRecord struct {
+	ResponseID  int
+	SubmittedAt time.Time
+	Answers     map[int]SurveyAnswer // theo questionId
+}
+
+// SurveyResults - Kết quả tổng hợp của khảo sát
+type SurveyResults struct {
+	SurveyID      int                    `json:"surveyId"`
+	EventID       int                    `json:"eventId"`
+	Title         string                 `json:"title"`
+	Status        string                 `json:"status"`
+	ResponseCount int                    `json:"responseCount"`
+	Questions     []SurveyQuestionResult `json:"questions"`
+}
+
+// SurveyQuestionResult - Tổng hợp một câu hỏi
+// Câu chọn: OptionCounts; thang điểm: Average + ScaleCounts; tự luận: TextAnswers
+type SurveyQuestionResult struct {
+	QuestionID   int           `json:"questionId"`
+	Type         string        `json:"type"`
+	Prompt       string        `json:"prompt"`
+	AnswerCount  int           `json:"answerCount"`
+	OptionCounts []SurveyCount `json:"optionCounts,omitempty"`
+	ScaleCounts  []SurveyCount `json:"scaleCounts,omitempty"`
+	Average      *float64      `json:"average,omitempty"`
+	TextAnswers  []string      `json:"textAnswers,omitempty"`
+}
+
+// SurveyCount - Số lượt chọn của một lựa chọn / một mức điểm
+type SurveyCount struct {
+	Value string `json:"value"`
+	Count int    `json:"count"`
+}
+
+// ============================================================
+// Raffle - Bốc thăm trúng thưởng trong sự kiện
+// (GET|POST /api/organizer/events/{id}/raffle)
+// ============================================================
+
+// MaxRaffleWinners - Số người trúng tối đa mỗi lần bốc
+const MaxRaffleWinners = 100
+
+// RaffleAlgorithm - Mô tả thuật toán để người ngoài tự kiểm chứng kết quả từ seed
+const RaffleAlgorithm = `candidatesHash = hex SHA-256 of candidateTicketIds joined by ","; Fisher-Yates over candidateTicketIds (ascending); step i picks index i + (U mod (n - i)) where U is the first 8 bytes (big-endian) of HMAC-SHA256(hex-decoded seed, "i:attempt"), rejecting U >= 2^64 - (2^64 mod (n - i)); a user wins at most once`
+
+// RaffleRequest - Body POST; các cờ loại trừ mặc định true
+type RaffleRequest struct {
+	Prize                  string `json:"prize"`
+	WinnerCount            int    `json:"winnerCount"`
+	ExcludeStaff           *bool  `json:"excludeStaff"`
+	ExcludeComplimentary   *bool  `json:"excludeComplimentary"`
+	ExcludePreviousWinners *bool  `json:"excludePreviousWinners"`
+}
+
+// RaffleCandidate - Vé CHECKED_IN đủ điều kiện tham gia bốc thăm
+type RaffleCandidate struct {
+	TicketID int
+	UserID   int
+	FullName string
+	Email    string
+}
+
+// RaffleDraw - Một lần bốc thăm đã lưu
+type RaffleDraw struct {
+	RaffleID               int            `json:"raffleId"`
+	EventID                int            `json:"eventId"`
+	Prize                  string         `json:"prize"`
+	WinnerCount            int            `json:"winnerCount"`
+	Seed                   string         `json:"seed"`
+	CandidateCount         int            `json:"candidateCount"`
+	CandidatesHash         string         `json:"candidatesHash"`
+	CandidateTicketIDs     []int          `json:"candidateTicketIds"`
+	Algorithm              string         `json:"algorithm"`
+	ExcludeStaff           bool           `json:"excludeStaff"`
+	ExcludeComplimentary   bool           `json:"excludeComplimentary"`
+	ExcludePreviousWinners bool           `json:"excludePreviousWinners"`
+	DrawnBy                int            `json:"drawnBy"`
+	DrawnAt                string         `json:"drawnAt"`
+	Winners                []RaffleWinner `json:"winners"`
+}
+
+// RaffleWinner - Người trúng theo thứ tự bốc
+type RaffleWinner struct {
+	Position int    `json:"position"`
+	TicketID int    `json:"ticketId"`
+	UserID   int    `json:"userId"`
+	FullName string `json:"fullName"`
+}
+
+// ============================================================
+// PriceTier - Bậc giá theo thời gian của loại vé (early-bird...)
+// (GET|PUT /api/events/{id}/category-tickets/{categoryTicketId}/price-tiers)
+// Giá áp dụng trong [validFrom, validTo); ngoài mọi khung dùng giá gốc của loại vé
+// ============================================================
+
+// MaxPriceTiers - Số bậc giá tối đa của một loại vé
+const MaxPriceTiers = 10
+
+type PriceTier struct {
+	TierID    int        `json:"tierId"`
+	Name      string     `json:"name"`
+	Price     float64    `json:"price"`
+	ValidFrom time.Time  `json:"validFrom"`
+	ValidTo   *time.Time `json:"validTo"`
+}
+
+// SavePriceTiersRequest - Body PUT, thay toàn bộ bậc giá (mảng rỗng = chỉ dùng giá gốc)
+type SavePriceTiersRequest struct {
+	Tiers []PriceTier `json:"tiers"`
+}
+
+// CategoryPriceTiers - Giá gốc, giá đang áp dụng và các bậc giá theo thời gian
+type CategoryPriceTiers struct {
+	CategoryTicketID int         `json:"categoryTicketId"`
+	EventID          int         `json:"eventId"`
+	Name             string      `json:"name"`
+	BasePrice        float64     `json:"basePrice"`
+	CurrentPrice     float64     `json:"currentPrice"`
+	CurrentTier      *string     `json:"currentTier"`
+	Tiers            []PriceTier `json:"tiers"`
+}
+
+// ActiveTier - Bậc giá áp dụng tại thời điểm at (nil nếu dùng giá gốc)
+func (c *CategoryPriceTiers) ActiveTier(at time.Time) *PriceTier {
+	for i := range c.Tiers {
+		t := &c.Tiers[i]
+		if !at.Before(t.ValidFrom) && (t.ValidTo == nil || at.Before(*t.ValidTo)) {
+			return t
+		}
+	}
+	return nil
+}
+
+// ============================================================
+// PublicEventPage - Dữ liệu trang microsite public của sự kiện
+// Dùng cho: GET /api/public/events/{slug} (không cần đăng nhập)
+// Mô tả đã bỏ thẻ HTML; không có số vé cụ thể, chỉ mức còn vé (Availability)
+// ============================================================
+
+// Mức còn vé hiển thị public
+const (
+	AvailabilityPlenty  = "plenty"
+	AvailabilityFew     = "few"
+	AvailabilitySoldOut = "sold_out"
+)
+
+type PublicEventPage struct {
+	Slug         string            `json:"slug"`
+	Title        string            `json:"title"`
+	Description  string            `json:"description"`
+	StartTime    string            `json:"startTime"`
+	EndTime      string            `json:"endTime"`
+	Status       string            `json:"status"`
+	BannerURL    *string           `json:"bannerUrl"`
+	BannerHero   *string           `json:"bannerHeroUrl"`
+	VenueName    *string           `json:"venueName"`
+	AreaName     *string           `json:"areaName"`
+	Floor        *string           `json:"floor"`
+	Speakers     []PublicSpeaker   `json:"speakers"`
+	Sponsors     []EventSponsor    `json:"sponsors"`
+	Availability string            `json:"availability"`
+	OpenGraph    OpenGraphMetadata `json:"openGraph"`
+}
+
+// PublicSpeaker - Diễn giả trên trang public (không có email / số điện thoại)
+type PublicSpeaker struct {
+	Name      string  `json:"name"`
+	Bio       string  `json:"bio"`
+	AvatarURL *string `json:"avatarUrl"`
+}
+
+// OpenGraphMetadata - Thẻ og:* để frontend render khi chia sẻ link
+type OpenGraphMetadata struct {
+	Title       string  `json:"title"`
+	Description string  `json:"description"`
+	Image       *string `json:"image"`
+	URL         string  `json:"url"`
+	Type        string  `json:"type"`
+	SiteName    string  `json:"siteName"`
+}
+
+// ============================================================
+// Open-data feed sự kiện OPEN cho cổng thông tin trường / máy tìm kiếm
+// Dùng cho: GET /api/public/events.json (cùng nguồn với events.rss, sitemap.xml)
+// ============================================================
+type OpenDataFeed struct {
+	Title       string          `json:"title"`
+	HomePageURL string          `json:"homePageUrl"`
+	FeedURL     string          `json:"feedUrl"`
+	GeneratedAt time.Time       `json:"generatedAt"`
+	Events      []OpenDataEvent `json:"events"`
+}
+
+type OpenDataEvent struct {
+	Slug      string  `json:"slug"`
+	URL       string  `json:"url"`
+	Title     string  `json:"title"`
+	Summary   string  `json:"summary"`
+	StartTime string  `json:"startTime"`
+	EndTime   string  `json:"endTime"`
+	VenueName *string `json:"venueName"`
+	AreaName  *string `json:"areaName"`
+	BannerURL *string `json:"bannerUrl"`
+}
+
+// EventFeed - Nội dung feed đã render kèm thông tin cache
+type EventFeed struct {
+	ContentType  string
+	Body         []byte
+	ETag         string
+	LastModified time.Time
+}
+
+// ============================================================
+// Widget nhúng sự kiện cho website câu lạc bộ
+// API key theo organizer, giới hạn origin được gọi từ trình duyệt
+// ============================================================
+
+// MaxWidgetOrigins - Số origin tối đa của một key
+const MaxWidgetOrigins = 10
+
+// WidgetAPIKey - Key đã tạo (không bao giờ trả lại key gốc, chỉ prefix)
+type WidgetAPIKey struct {
+	KeyID          int        `json:"keyId"`
+	Name           string     `json:"name"`
+	KeyPrefix      string     `json:"keyPrefix"`
+	AllowedOrigins []string   `json:"allowedOrigins"`
+	Status         string     `json:"status"`
+	CreatedAt      time.Time  `json:"createdAt"`
+	RevokedAt      *time.Time `json:"revokedAt"`
+}
+
+// CreatedWidgetAPIKey - Phản hồi khi tạo key: key gốc chỉ xuất hiện một lần
+type CreatedWidgetAPIKey struct {
+	WidgetAPIKey
+	APIKey string `json:"apiKey"`
+}
+
+// WidgetAPIKeyRequest - Body POST/PUT /api/organizer/widget-keys
+type WidgetAPIKeyRequest struct {
+	Name           string   `json:"name"`
+	AllowedOrigins []string `json:"allowedOrigins"`
+}
+
+// WidgetKeyOwner - Key đang ACTIVE tra theo hash (dùng ở CORS và widget)
+type WidgetKeyOwner struct {
+	KeyID          int
+	OrganizerID    int
+	AllowedOrigins []string
+}
+
+// WidgetEvent - Bản rút gọn của sự kiện cho widget
+type WidgetEvent struct {
+	EventID      int     `json:"eventId"`
+	Title        string  `json:"title"`
+	URL          *string `json:"url"`
+	StartTime    string  `json:"startTime"`
+	EndTime      string  `json:"endTime"`
+	VenueName    *string `json:"venueName"`
+	AreaName     *string `json:"areaName"`
+	BannerURL    *string `json:"bannerUrl"`
+	Availability string  `json:"availability"`
+}
+
+// ============================================================
+// Event template - Mẫu sự kiện dùng lại (tiêu đề, mô tả, cơ cấu vé, sức chứa mặc định)
+// Mẫu chung do người có quyền event.template.manage tạo; organizer tạo mẫu riêng của mình
+// ============================================================
+
+// MaxTemplateTickets - Số loại vé tối đa trong một mẫu
+const MaxTemplateTickets = 10
+
+// EventTemplate - Một mẫu sự kiện
+// TitlePattern hỗ trợ {date} (dd/MM/yyyy), {month}, {year} theo giờ bắt đầu của request
+type EventTemplate struct {
+	TemplateID      int                 `json:"templateId"`
+	Name            string              `json:"name"`
+	TitlePattern    string              `json:"titlePattern"`
+	Description     *string             `json:"description"`
+	DefaultCapacity *int                `json:"defaultCapacity"`
+	Tickets         []CategoryTicketDTO `json:"tickets"`
+	Global          bool                `json:"global"`
+	CreatedBy       int                 `json:"createdBy"`
+	CreatedByName   *string             `json:"createdByName"`
+	Status          string              `json:"status"`
+	CreatedAt       time.Time           `json:"createdAt"`
+	UpdatedAt       time.Time           `json:"updatedAt"`
+}
+
+// EventTemplateRequest - Body POST /api/event-templates, PUT /api/event-templates/{id}
+type EventTemplateRequest struct {
+	Name            string              `json:"name"`
+	TitlePattern    string              `json:"titlePattern"`
+	Description     *string             `json:"description"`
+	DefaultCapacity *int                `json:"defaultCapacity"`
+	Tickets         []CategoryTicketDTO `json:"tickets"`
+	Global          bool                `json:"global"`
+}
+
+// FromTemplateRequestBody - Body POST /api/event-requests/from-template/{id}
+// Chỉ cần ngày; title/description/capacity bỏ trống thì lấy từ mẫu
+type FromTemplateRequestBody struct {
+	PreferredStartTime string            `json:"preferredStartTime"`
+	PreferredEndTime   string            `json:"preferredEndTime"`
+	Title              *string           `json:"title"`
+	Description        *string           `json:"description"`
+	ExpectedCapacity   *int              `json:"expectedCapacity"`
+	Budget             *EventBudgetInput `json:"budget,omitempty"`
+}
+
+// ============================================================
+// Cảnh báo check-in theo % sức chứa (GET|PUT /api/events/{id}/checkin-alerts)
+// ============================================================
+
+// CheckinAlertSettings - Ngưỡng đang áp dụng, số check-in hiện tại và các ngưỡng đã báo
+type CheckinAlertSettings struct {
+	EventID      int            `json:"eventId"`
+	Thresholds   []int          `json:"thresholds"`   // % sức chứa, tăng dần; rỗng = tắt cảnh báo
+	UsingDefault bool           `json:"usingDefault"` // true = sự kiện chưa cấu hình, dùng CHECKIN_ALERT_THRESHOLDS
+	Capacity     int            `json:"capacity"`     // max_seats; 0 = không có sức chứa, không báo
+	CheckedIn    int            `json:"checkedIn"`
+	Fired        []CheckinAlert `json:"fired"`
+}
+
+// CheckinAlert - Một ngưỡng đã báo (mỗi ngưỡng đúng một lần)
+type CheckinAlert struct {
+	Threshold  int       `json:"threshold"`
+	CheckedIn  int       `json:"checkedIn"`
+	Capacity   int       `json:"capacity"`
+	Recipients int       `json:"recipients"`
+	FiredAt    time.Time `json:"firedAt"`
+}
+
+// UpdateCheckinAlertsRequest - Body PUT; thresholds null = quay về mặc định, [] = tắt
+type UpdateCheckinAlertsRequest struct {
+	Thresholds *[]int `json:"thresholds"`
+}
+
+// ============================================================
+// Giá riêng từng ghế trong loại vé (GET|PUT /api/events/{id}/seat-prices)
+// ============================================================
+
+// SeatPriceOverride - Ghế có giá khác giá loại vé
+type SeatPriceOverride struct {
+	CategoryTicketID int     `json:"categoryTicketId"`
+	CategoryName     string  `json:"categoryName"`
+	SeatID           int     `json:"seatId"`
+	SeatCode         string  `json:"seatCode"`
+	RowNo            *string `json:"rowNo"`
+	AdjustPercent    int     `json:"adjustPercent"` // +20 = đắt hơn giá loại vé 20%, -10 = rẻ hơn 10%
+	BasePrice        float64 `json:"basePrice"`     // Giá gốc của loại vé
+	Price            float64 `json:"price"`         // Giá gốc ± adjustPercent (bậc giá theo thời gian áp dụng lúc mua)
+}
+
+// SeatPriceSettings - Các override của sự kiện và khoảng % cho phép
+type SeatPriceSettings struct {
+	EventID            int                 `json:"eventId"`
+	MaxDiscountPercent int                 `json:"maxDiscountPercent"`
+	MaxPremiumPercent  int                 `json:"maxPremiumPercent"`
+	Overrides          []SeatPriceOverride `json:"overrides"`
+}
+
+// SeatPriceRule - Một nhóm ghế cùng mức điều chỉnh, chọn theo hàng và / hoặc seatId
+type SeatPriceRule struct {
+	Rows          []string `json:"rows"`
+	SeatIDs       []int    `json:"seatIds"`
+	AdjustPercent int      `json:"adjustPercent"`
+}
+
+// UpdateSeatPricesRequest - Body PUT: thay toàn bộ override của một loại vé ([] = bỏ hết);
+// ghế nằm trong nhiều rule thì rule sau thắng
+type UpdateSeatPricesRequest struct {
+	CategoryTicketID int             `json:"categoryTicketId"`
+	Rules            []SeatPriceRule `json:"rules"`
+}
+
+// ============================================================
+// Điểm rèn luyện của sự kiện (GET|PUT /api/events/{id}/activity-points)
+// ============================================================
+
+// ActivityPointsSettings - Điểm cộng cho mỗi sinh viên check-in và số sinh viên đã check-in
+type ActivityPointsSettings struct {
+	EventID   int `json:"eventId"`
+	Points    int `json:"points"` // 0 = sự kiện không tính điểm
+	MaxPoints int `json:"maxPoints"`
+	Attendees int `json:"attendees"` // Vé CHECKED_IN / CHECKED_OUT (kể cả vé đã lưu trữ)
+}
+
+// UpdateActivityPointsRequest - Body PUT
+type UpdateActivityPointsRequest struct {
+	Points *int `json:"points"`
+}
+
+// ============================================================
+// Cashback tham dự của sự kiện (GET|PUT /api/events/{id}/cashback)
+// ============================================================
+
+// CashbackSettings - Mức hoàn tiền khi check-out và số cashback đã hoàn
+type CashbackSettings struct {
+	EventID    int      `json:"eventId"`
+	Percent    int      `json:"percent"`   // 0 = tắt
+	MaxAmount  *float64 `json:"maxAmount"` // Trần mỗi vé, null = không giới hạn
+	MaxPercent int      `json:"maxPercent"`
+	Redeemed   int      `json:"redeemed"`  // Số vé đã nhận cashback
+	TotalPaid  float64  `json:"totalPaid"` // Tổng tiền đã hoàn vào ví
+}
+
+// UpdateCashbackRequest - Body PUT
+type UpdateCashbackRequest struct {
+	Percent   *int     `json:"percent"`
+	MaxAmount *float64 `json:"maxAmount"`
+}
+
+// ============================================================
+// QR động của sự kiện (GET|PUT /api/events/{id}/dynamic-qr)
+// ============================================================
+
+// DynamicQRSettings - Sự kiện có bắt buộc mã QR xoay vòng khi check-in không
+type DynamicQRSettings struct {
+	EventID     int  `json:"eventId"`
+	Enabled     bool `json:"enabled"`
+	StepSeconds int  `json:"stepSeconds"` // Thời gian hiệu lực của mỗi mã
+}
+
+// UpdateDynamicQRRequest - Body PUT
+type UpdateDynamicQRRequest struct {
+	Enabled *bool `json:"enabled"`
+}
+
+// ============================================================
+// Hàng chờ mở bán của sự kiện (GET|PUT /api/events/{id}/booking-queue)
+// ============================================================
+
+// BookingQueueSettings - Cấu hình hàng chờ kèm số người đang chờ / đang được đặt vé
+type BookingQueueSettings struct {
+	EventID          int  `json:"eventId"`
+	Enabled          bool `json:"enabled"`
+	AdmitPerInterval int  `json:"admitPerInterval"` // Số người được vào mỗi nhịp
+	IntervalSeconds  int  `json:"intervalSeconds"`  // Nhịp dispatcher (BOOKING_QUEUE_INTERVAL_SECONDS)
+	Waiting          int  `json:"waiting"`
+	Admitted         int  `json:"admitted"`
+}
+
+// UpdateBookingQueueRequest - Body PUT; trường bỏ trống giữ nguyên
+type UpdateBookingQueueRequest struct {
+	Enabled          *bool `json:"enabled"`
+	AdmitPerInterval *int  `json:"admitPerInterval"`
+}
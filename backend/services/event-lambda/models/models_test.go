@@ -0,0 +1,106 @@
+package models
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// Bản public không được chứa liên hệ cá nhân của speaker và thông tin nội bộ
+func TestEventDetailPublicOmitsInternalFields(t *testing.T) {
+	email, phone, name := "speaker@fpt.edu.vn", "0901234567", "Lê Minh Khoa"
+	has := true
+	detail := &EventDetailDto{
+		EventID: 7, Title: "Hội thảo", Status: "OPEN",
+		SpeakerName: &name, SpeakerEmail: &email, SpeakerPhone: &phone,
+		HasBookings: &has,
+		Tickets:     []CategoryTicket{{CategoryTicketID: 1, Name: "VIP", Price: 100000}},
+	}
+
+	data, err := json.Marshal(detail.Public())
+	if err != nil {
+		t.Fatal(err)
+	}
+	body := string(data)
+	for _, leaked := range []string{email, phone, "speakerEmail", "speakerPhone", "hasBookings"} {
+		if strings.Contains(body, leaked) {
+			t.Errorf("public detail contains %q: %s", leaked, body)
+		}
+	}
+	for _, kept := range []string{`"eventId":7`, name, `"categoryTicketId":1`} {
+		if !strings.Contains(body, kept) {
+			t.Errorf("public detail is missing %q: %s", kept, body)
+		}
+	}
+}
+
+// Tài liệu TICKET_HOLDERS không lộ ra bản public
+func TestEventDetailPublicFiltersAttachments(t *testing.T) {
+	detail := &EventDetailDto{EventID: 7, Attachments: []EventAttachment{
+		{AttachmentID: 1, Title: "Agenda", Visibility: AttachmentPublic},
+		{AttachmentID: 2, Title: "Slides", Visibility: AttachmentTicketHolders},
+	}}
+	public := detail.Public().Attachments
+	if len(public) != 1 || public[0].AttachmentID != 1 {
+		t.Errorf("public attachments = %+v", public)
+	}
+	if got := VisibleAttachments(detail.Attachments, true); len(got) != 2 {
+		t.Errorf("ticket holder attachments = %+v", got)
+	}
+}
+
+// Tổng dự toán/thực chi và kết quả duyệt lấy từ review mới nhất
+func TestEventRequestBudgetSummarize(t *testing.T) {
+	actual, approved := 2150000.5, 4000000.0
+	b := &EventRequestBudget{
+		Items: []BudgetItem{
+			{Category: BudgetCategoryCatering, EstimatedAmount: 2000000, ActualAmount: &actual},
+			{Category: BudgetCategoryPrinting, EstimatedAmount: 500000.25},
+		},
+		Reviews: []BudgetReview{
+			{Decision: BudgetReviewApproved, ApprovedFunding: &approved},
+			{Decision: BudgetReviewNeedsChanges},
+		},
+	}
+	b.Summarize()
+	if b.EstimatedTotal != 2500000.25 {
+		t.Errorf("EstimatedTotal = %v", b.EstimatedTotal)
+	}
+	if b.ActualTotal == nil || *b.ActualTotal != 2150000.5 || b.Variance == nil || *b.Variance != -349999.75 {
+		t.Errorf("ActualTotal = %v, Variance = %v", b.ActualTotal, b.Variance)
+	}
+	if b.ReviewStatus == nil || *b.ReviewStatus != BudgetReviewApproved || b.ApprovedFunding == nil || *b.ApprovedFunding != approved {
+		t.Errorf("ReviewStatus = %v, ApprovedFunding = %v", b.ReviewStatus, b.ApprovedFunding)
+	}
+
+	// Chưa nhập thực chi, review mới nhất không duyệt: không có actual/approved
+	b.Items[0].ActualAmount = nil
+	b.Reviews = []BudgetReview{{Decision: BudgetReviewRejected}, {Decision: BudgetReviewApproved, ApprovedFunding: &approved}}
+	b.Summarize()
+	if b.ActualTotal != nil || b.Variance != nil || b.ApprovedFunding != nil || *b.ReviewStatus != BudgetReviewRejected {
+		t.Errorf("unexpected summary: %+v", b)
+	}
+}
+
+// Mô tả / bio cũ chứa HTML tùy ý được làm sạch khi serialize
+func TestEventDetailSerializesSanitizedContent(t *testing.T) {
+	desc := `<p onclick="x()">Giới thiệu</p><script>alert(1)</script>`
+	bio := `<img src=x onerror=alert(1)>Diễn giả`
+	data, err := json.Marshal(&EventDetailDto{EventID: 7, Description: &desc, SpeakerBio: &bio})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got struct {
+		Description string `json:"description"`
+		SpeakerBio  string `json:"speakerBio"`
+	}
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Description != "<p>Giới thiệu</p>" || got.SpeakerBio != "Diễn giả" {
+		t.Fatalf("unexpected content: %+v", got)
+	}
+	if strings.Contains(string(data), "<") {
+		t.Fatalf("JSON must escape <: %s", data)
+	}
+}
@@ -0,0 +1,62 @@
+package models
+
+import (
+	"encoding/json"
+
+	"github.com/fpt-event-services/common/statuslabel"
+	"github.com/fpt-event-services/common/validator"
+)
+
+// ============================================================
+// Output encoding cho nội dung người dùng nhập
+// Dữ liệu lưu trước khi có lớp sanitize vẫn có thể chứa HTML tùy ý:
+// khi serialize, mô tả đi qua allow-list, bio thành văn bản thuần.
+// json.Marshal escape thêm <, >, & (\u003c, \u003e, \u0026) nên chuỗi JSON
+// nhúng vào trang không thể đóng thẻ <script>. Frontend hiển thị các trường này như text.
+// ============================================================
+
+// MarshalJSON - EventListItem với mô tả đã sanitize và nhãn trạng thái
+func (e EventListItem) MarshalJSON() ([]byte, error) {
+	type plain EventListItem
+	out := plain(e)
+	out.Description = validator.SanitizeOptionalRichText(out.Description)
+	out.StatusLabel = statuslabel.Lookup(statuslabel.KindEvent, out.Status)
+	return json.Marshal(out)
+}
+
+// MarshalJSON - EventDetailDto với mô tả / bio đã sanitize và nhãn trạng thái
+func (d EventDetailDto) MarshalJSON() ([]byte, error) {
+	type plain EventDetailDto
+	out := plain(d)
+	out.Description = validator.SanitizeOptionalRichText(out.Description)
+	out.SpeakerBio = validator.SanitizeOptionalText(out.SpeakerBio)
+	out.StatusLabel = statuslabel.Lookup(statuslabel.KindEvent, out.Status)
+	return json.Marshal(out)
+}
+
+// MarshalJSON - EventDetailPublicDto với mô tả / bio đã sanitize và nhãn trạng thái
+func (d EventDetailPublicDto) MarshalJSON() ([]byte, error) {
+	type plain EventDetailPublicDto
+	out := plain(d)
+	out.Description = validator.SanitizeOptionalRichText(out.Description)
+	out.SpeakerBio = validator.SanitizeOptionalText(out.SpeakerBio)
+	out.StatusLabel = statuslabel.Lookup(statuslabel.KindEvent, out.Status)
+	return json.Marshal(out)
+}
+
+// MarshalJSON - SpeakerProfile với bio đã sanitize
+func (p SpeakerProfile) MarshalJSON() ([]byte, error) {
+	type plain SpeakerProfile
+	out := plain(p)
+	out.Bio = validator.SanitizeOptionalText(out.Bio)
+	return json.Marshal(out)
+}
+
+// MarshalJSON - EventRequest kèm nhãn trạng thái của request và của Event đã tạo
+func (r EventRequest) MarshalJSON() ([]byte, error) {
+	type plain EventRequest
+	out := plain(r)
+	out.StatusLabel = statuslabel.Lookup(statuslabel.KindEventRequest, out.Status)
+	out.EventStatusLabel = statuslabel.LookupPtr(statuslabel.KindEvent, out.EventStatus)
+	return json.Marshal(out)
+}
@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// ============================================================
+// Thao tác vận hành cho ADMIN (CLI `backend admin ...`)
+// Thay cho các chương trình main.go viết tay chạy SQL trực tiếp
+// ============================================================
+
+var (
+	// ErrSpeakerNotFound - speaker_id không tồn tại
+	ErrSpeakerNotFound = errors.New("speaker not found")
+	// ErrAreaNotFound - area_id không tồn tại
+	ErrAreaNotFound = errors.New("venue area not found")
+	// ErrAreaInUse - Khu vực còn sự kiện OPEN/UPDATING đang dùng
+	ErrAreaInUse = errors.New("venue area is still used by an active event")
+)
+
+// AssignSpeaker gắn speaker có sẵn vào sự kiện (ghi đè speaker hiện tại)
+func (r *EventRepository) AssignSpeaker(ctx context.Context, eventID, speakerID int) error {
+	var exists bool
+	if err := r.db.QueryRowContext(ctx,
+		`SELECT EXISTS(SELECT 1 FROM Speaker WHERE speaker_id = ?)`, speakerID,
+	).Scan(&exists); err != nil {
+		return fmt.Errorf("failed to check speaker: %w", err)
+	}
+	if !exists {
+		return ErrSpeakerNotFound
+	}
+
+	result, err := r.db.ExecContext(ctx, `UPDATE Event SET speaker_id = ?, version = version + 1 WHERE event_id = ?`, speakerID, eventID)
+	if err != nil {
+		return fmt.Errorf("failed to assign speaker: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		// RowsAffected = 0 cả khi speaker đã được gắn sẵn → kiểm tra lại sự kiện
+		var current sql.NullInt64
+		err := r.db.QueryRowContext(ctx, `SELECT speaker_id FROM Event WHERE event_id = ?`, eventID).Scan(&current)
+		if err == sql.ErrNoRows {
+			return ErrEventNotFound
+		}
+		if err != nil {
+			return fmt.Errorf("failed to check event: %w", err)
+		}
+	}
+	return nil
+}
+
+// ReleaseVenueArea đưa một khu vực UNAVAILABLE về AVAILABLE
+// Trả về false nếu khu vực đã AVAILABLE; ErrAreaInUse nếu còn sự kiện OPEN/UPDATING dùng khu vực
+func (r *EventRepository) ReleaseVenueArea(ctx context.Context, areaID int) (bool, error) {
+	var status string
+	err := r.db.QueryRowContext(ctx, `SELECT status FROM Venue_Area WHERE area_id = ?`, areaID).Scan(&status)
+	if err == sql.ErrNoRows {
+		return false, ErrAreaNotFound
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to load venue area: %w", err)
+	}
+
+	var inUse bool
+	if err := r.db.QueryRowContext(ctx, `
+		SELECT EXISTS(SELECT 1 FROM Event WHERE area_id = ? AND status IN ('OPEN', 'UPDATING'))
+	`, areaID).Scan(&inUse); err != nil {
+		return false, fmt.Errorf("failed to check active events: %w", err)
+	}
+	if inUse {
+		return false, ErrAreaInUse
+	}
+
+	result, err := r.db.ExecContext(ctx,
+		`UPDATE Venue_Area SET status = 'AVAILABLE' WHERE area_id = ? AND status = 'UNAVAILABLE'`, areaID)
+	if err != nil {
+		return false, fmt.Errorf("failed to release venue area: %w", err)
+	}
+	n, _ := result.RowsAffected()
+	return n > 0, nil
+}
@@ -0,0 +1,33 @@
+package repository
+
+import "github.com/fpt-event-services/common/capability"
+
+// CapabilityComponent - Tên thành phần trong ma trận /api/admin/capabilities
+const CapabilityComponent = "event-lambda/repository"
+
+// Tên thao tác của EventRepository (route khai báo phụ thuộc bằng các tên này)
+const (
+	CapEventUpdate            = "event.update"
+	CapEventUpdateDetails     = "event.update-details"
+	CapEventAreaOverlapTx     = "event.area-overlap-tx"
+	CapEventAreaRelease       = "event.area-release"
+	CapEventConfigPerEventGet = "event.config.per-event.read"
+	CapEventConfigPerEventSet = "event.config.per-event.write"
+)
+
+// Capabilities - Mức độ hiện thực các thao tác của EventRepository
+// Sửa stub nào thì đổi Status ở đây (ma trận và gate khởi động đọc danh sách này)
+func Capabilities() []capability.Capability {
+	return []capability.Capability{
+		{Name: CapEventUpdate, Status: capability.Unimplemented,
+			Note: "UpdateEvent is a stub returning ErrUnimplemented; use UpdateEventDetails"},
+		{Name: CapEventUpdateDetails, Status: capability.Implemented},
+		{Name: CapEventAreaOverlapTx, Status: capability.Unimplemented,
+			Note: "CheckAreaOverlapTx is a stub; overlap is checked inside UpdateEventRequest"},
+		{Name: CapEventAreaRelease, Status: capability.Implemented},
+		{Name: CapEventConfigPerEventGet, Status: capability.Partial,
+			Note: "GetEventConfigById has no per-event storage; callers fall back to the global config"},
+		{Name: CapEventConfigPerEventSet, Status: capability.Unimplemented,
+			Note: "UpdateEventConfig only checks If-Match for eventId > 0; per-event values are not saved"},
+	}
+}
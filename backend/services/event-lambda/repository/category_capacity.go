@@ -0,0 +1,331 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"math"
+	"strings"
+
+	"github.com/fpt-event-services/services/event-lambda/models"
+)
+
+// ============================================================
+// Capacity guard cho category ticket khi event đã có vé bán ra
+// Quy tắc:
+//   - Tăng max_quantity: luôn cho phép
+//   - Giảm max_quantity: chỉ được giảm tới số vé đã bán (sold)
+//   - Xóa loại vé đã có người mua: không cho phép
+//   - Chỉ phân bổ lại ghế CHƯA BÁN, ghế đã bán giữ nguyên category
+// ============================================================
+
+// soldTicketStatuses - Các trạng thái vé đang chiếm ghế
+const soldTicketStatuses = `'PENDING','BOOKED','CHECKED_IN','CHECKED_OUT'`
+
+// existingCategory - Category ticket hiện có của event kèm số vé đã bán
+type existingCategory struct {
+	ID          int
+	Name        string
+	MaxQuantity int
+	Status      string
+	Sold        int
+	Price       float64
+}
+
+// loadCategoriesWithSoldTx lấy category ticket của event + số vé đã bán (khóa dòng FOR UPDATE)
+func (r *EventRepository) loadCategoriesWithSoldTx(ctx context.Context, tx *sql.Tx, eventID int) ([]existingCategory, error) {
+	query := `
+		SELECT ct.category_ticket_id, ct.name, ct.max_quantity, ct.status, COALESCE(ct.price, 0),
+		       (SELECT COUNT(*) FROM Ticket t
+		        WHERE t.category_ticket_id = ct.category_ticket_id
+		          AND t.status IN (` + soldTicketStatuses + `)) AS sold
+		FROM category_ticket ct
+		WHERE ct.event_id = ?
+		ORDER BY ct.category_ticket_id
+		FOR UPDATE
+	`
+	rows, err := tx.QueryContext(ctx, query, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load category tickets: %w", err)
+	}
+	defer rows.Close()
+
+	var cats []existingCategory
+	for rows.Next() {
+		var c existingCategory
+		var maxQty sql.NullInt64
+		if err := rows.Scan(&c.ID, &c.Name, &maxQty, &c.Status, &c.Price, &c.Sold); err != nil {
+			return nil, fmt.Errorf("failed to scan category ticket: %w", err)
+		}
+		c.MaxQuantity = int(maxQty.Int64)
+		cats = append(cats, c)
+	}
+	return cats, rows.Err()
+}
+
+// matchCategories ghép từng ticket gửi lên với category hiện có
+// Ưu tiên categoryTicketId; nếu không có ID thì khớp theo tên (không phân biệt hoa thường)
+// Trả về slice cùng độ dài incoming: nil = loại vé mới
+func matchCategories(existing []existingCategory, incoming []models.CategoryTicketDTO) ([]*existingCategory, error) {
+	byID := make(map[int]*existingCategory, len(existing))
+	byName := make(map[string]*existingCategory, len(existing))
+	for i := range existing {
+		c := &existing[i]
+		byID[c.ID] = c
+		byName[normalizeCategoryName(c.Name)] = c
+	}
+
+	used := make(map[int]bool)
+	matches := make([]*existingCategory, len(incoming))
+
+	// Lượt 1: khớp theo ID
+	for i, t := range incoming {
+		if t.CategoryTicketID == nil || *t.CategoryTicketID <= 0 {
+			continue
+		}
+		c, ok := byID[*t.CategoryTicketID]
+		if !ok {
+			return nil, fmt.Errorf("category ticket %d does not belong to this event", *t.CategoryTicketID)
+		}
+		if used[c.ID] {
+			return nil, fmt.Errorf("category ticket %d is duplicated in request", c.ID)
+		}
+		used[c.ID] = true
+		matches[i] = c
+	}
+
+	// Lượt 2: khớp theo tên cho các ticket không gửi ID (tương thích frontend cũ)
+	for i, t := range incoming {
+		if matches[i] != nil || (t.CategoryTicketID != nil && *t.CategoryTicketID > 0) {
+			continue
+		}
+		if c, ok := byName[normalizeCategoryName(t.Name)]; ok && !used[c.ID] {
+			used[c.ID] = true
+			matches[i] = c
+		}
+	}
+	return matches, nil
+}
+
+// checkCapacityChanges kiểm tra thay đổi số lượng theo kết quả ghép category
+// Trả về *models.CapacityChangeError nếu có thay đổi vi phạm quy tắc
+func checkCapacityChanges(existing []existingCategory, incoming []models.CategoryTicketDTO, matches []*existingCategory) *models.CapacityChangeError {
+	var violations []models.CapacityViolation
+	matched := make(map[int]bool)
+
+	for i, t := range incoming {
+		c := matches[i]
+		if c == nil {
+			continue
+		}
+		matched[c.ID] = true
+		if t.MaxQuantity < c.Sold {
+			violations = append(violations, models.CapacityViolation{
+				CategoryTicketID:  c.ID,
+				Name:              c.Name,
+				Reason:            "BELOW_SOLD_COUNT",
+				CurrentQuantity:   c.MaxQuantity,
+				RequestedQuantity: t.MaxQuantity,
+				SoldCount:         c.Sold,
+			})
+		}
+	}
+
+	// Loại vé bị bỏ khỏi danh sách nhưng đã có người mua
+	for _, c := range existing {
+		if !matched[c.ID] && c.Sold > 0 && c.Status != "INACTIVE" {
+			violations = append(violations, models.CapacityViolation{
+				CategoryTicketID:  c.ID,
+				Name:              c.Name,
+				Reason:            "CATEGORY_HAS_SALES",
+				CurrentQuantity:   c.MaxQuantity,
+				RequestedQuantity: 0,
+				SoldCount:         c.Sold,
+			})
+		}
+	}
+
+	if len(violations) == 0 {
+		return nil
+	}
+	return &models.CapacityChangeError{
+		Code:       "CAPACITY_REDUCTION_BLOCKED",
+		Message:    "Không thể giảm số lượng vé xuống dưới số vé đã bán",
+		Violations: violations,
+	}
+}
+
+// syncedCategory - Category sau khi đồng bộ (dùng cho phân bổ ghế)
+type syncedCategory struct {
+	ID          int
+	Name        string
+	MaxQuantity int
+	Price       float64
+}
+
+// syncCategoryTicketsTx đồng bộ category ticket theo danh sách gửi lên (không delete/reinsert)
+//   - Có trong DB và trong request → UPDATE tại chỗ (giữ ID, FK từ Ticket không bị gãy)
+//   - Chỉ có trong request → INSERT
+//   - Chỉ có trong DB → soft-disable (status = INACTIVE)
+//
+// Trả về danh sách category đang hoạt động và target ghế cho mọi category (0 = nhả hết ghế)
+func (r *EventRepository) syncCategoryTicketsTx(ctx context.Context, tx *sql.Tx, eventID int, incoming []models.CategoryTicketDTO) ([]syncedCategory, map[int]int, error) {
+	existing, err := r.loadCategoriesWithSoldTx(ctx, tx, eventID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	matches, err := matchCategories(existing, incoming)
+	if err != nil {
+		return nil, nil, err
+	}
+	if capErr := checkCapacityChanges(existing, incoming, matches); capErr != nil {
+		return nil, nil, capErr
+	}
+
+	var active []syncedCategory
+	targets := make(map[int]int) // category_ticket_id -> max_quantity mới
+	kept := make(map[int]bool)
+
+	for i, t := range incoming {
+		status := "ACTIVE"
+		if t.Status != nil {
+			status = *t.Status
+		}
+		price := math.Round(t.Price)
+
+		if c := matches[i]; c != nil {
+			_, err := tx.ExecContext(ctx, `
+				UPDATE category_ticket
+				SET name = ?, description = ?, price = ?, max_quantity = ?, status = ?
+				WHERE category_ticket_id = ?
+			`, t.Name, t.Description, price, t.MaxQuantity, status, c.ID)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to update category ticket %d: %w", c.ID, err)
+			}
+			targets[c.ID] = t.MaxQuantity
+			kept[c.ID] = true
+			active = append(active, syncedCategory{ID: c.ID, Name: t.Name, MaxQuantity: t.MaxQuantity, Price: price})
+			log.Printf("[CategorySync] Category %d (%s): %d -> %d (sold=%d)", c.ID, t.Name, c.MaxQuantity, t.MaxQuantity, c.Sold)
+			continue
+		}
+
+		result, err := tx.ExecContext(ctx, `
+			INSERT INTO category_ticket (event_id, name, description, price, max_quantity, status)
+			VALUES (?, ?, ?, ?, ?, ?)
+		`, eventID, t.Name, t.Description, price, t.MaxQuantity, status)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to insert category ticket %s: %w", t.Name, err)
+		}
+		newID, _ := result.LastInsertId()
+		targets[int(newID)] = t.MaxQuantity
+		active = append(active, syncedCategory{ID: int(newID), Name: t.Name, MaxQuantity: t.MaxQuantity, Price: price})
+		log.Printf("[CategorySync] Inserted new category %d (%s) maxQty=%d", newID, t.Name, t.MaxQuantity)
+	}
+
+	// Loại vé không còn trong danh sách (chưa bán) → INACTIVE, nhả ghế
+	for _, c := range existing {
+		if kept[c.ID] {
+			continue
+		}
+		if c.Status != "INACTIVE" {
+			if _, err := tx.ExecContext(ctx, `UPDATE category_ticket SET status = 'INACTIVE' WHERE category_ticket_id = ?`, c.ID); err != nil {
+				return nil, nil, fmt.Errorf("failed to deactivate category ticket %d: %w", c.ID, err)
+			}
+			log.Printf("[CategorySync] Deactivated category %d (%s)", c.ID, c.Name)
+		}
+		targets[c.ID] = 0
+	}
+
+	return active, targets, nil
+}
+
+// applyCapacityChangesTx cập nhật category ticket khi event đã có vé bán ra
+// Đồng bộ category rồi chỉ phân bổ lại ghế chưa bán theo max_quantity mới
+func (r *EventRepository) applyCapacityChangesTx(ctx context.Context, tx *sql.Tx, eventID int, areaID sql.NullInt64, incoming []models.CategoryTicketDTO) error {
+	_, targets, err := r.syncCategoryTicketsTx(ctx, tx, eventID, incoming)
+	if err != nil {
+		return err
+	}
+	if !areaID.Valid {
+		return nil
+	}
+	return r.reallocateUnsoldSeatsTx(ctx, tx, eventID, areaID.Int64, targets)
+}
+
+// reallocateUnsoldSeatsTx điều chỉnh số ghế gán cho mỗi category về đúng target
+// Ghế đã có vé (sold) không bao giờ bị đổi category
+func (r *EventRepository) reallocateUnsoldSeatsTx(ctx context.Context, tx *sql.Tx, eventID int, areaID int64, targets map[int]int) error {
+	// B1: Nhả ghế chưa bán của các category đang thừa ghế
+	for catID, target := range targets {
+		var assigned int
+		err := tx.QueryRowContext(ctx, `SELECT COUNT(*) FROM Seat WHERE area_id = ? AND category_ticket_id = ?`, areaID, catID).Scan(&assigned)
+		if err != nil {
+			return fmt.Errorf("failed to count seats of category %d: %w", catID, err)
+		}
+		if assigned <= target {
+			continue
+		}
+
+		excess := assigned - target
+		_, err = tx.ExecContext(ctx, `
+			UPDATE Seat s
+			SET s.category_ticket_id = NULL
+			WHERE s.area_id = ? AND s.category_ticket_id = ?
+			  AND NOT EXISTS (
+			      SELECT 1 FROM Ticket t
+			      WHERE t.event_id = ? AND t.seat_id = s.seat_id AND t.status IN (`+soldTicketStatuses+`)
+			  )
+			ORDER BY s.row_no DESC, s.col_no DESC
+			LIMIT ?
+		`, areaID, catID, eventID, excess)
+		if err != nil {
+			return fmt.Errorf("failed to release seats of category %d: %w", catID, err)
+		}
+		log.Printf("[CapacityGuard] Released %d unsold seats from category %d", excess, catID)
+	}
+
+	// B2: Gán thêm ghế trống cho các category đang thiếu ghế
+	for catID, target := range targets {
+		var assigned int
+		err := tx.QueryRowContext(ctx, `SELECT COUNT(*) FROM Seat WHERE area_id = ? AND category_ticket_id = ?`, areaID, catID).Scan(&assigned)
+		if err != nil {
+			return fmt.Errorf("failed to count seats of category %d: %w", catID, err)
+		}
+		if assigned >= target {
+			continue
+		}
+
+		missing := target - assigned
+		result, err := tx.ExecContext(ctx, `
+			UPDATE Seat
+			SET category_ticket_id = ?
+			WHERE area_id = ? AND category_ticket_id IS NULL
+			ORDER BY row_no, col_no
+			LIMIT ?
+		`, catID, areaID, missing)
+		if err != nil {
+			return fmt.Errorf("failed to assign seats to category %d: %w", catID, err)
+		}
+		got, _ := result.RowsAffected()
+		if int(got) < missing {
+			return &models.CapacityChangeError{
+				Code:    "INSUFFICIENT_SEATS",
+				Message: fmt.Sprintf("Không đủ ghế trống trong khu vực: cần thêm %d, chỉ còn %d", missing, got),
+				Violations: []models.CapacityViolation{{
+					CategoryTicketID:  catID,
+					Reason:            "INSUFFICIENT_SEATS",
+					CurrentQuantity:   assigned,
+					RequestedQuantity: target,
+				}},
+			}
+		}
+		log.Printf("[CapacityGuard] Assigned %d more seats to category %d", missing, catID)
+	}
+	return nil
+}
+
+func normalizeCategoryName(name string) string {
+	return strings.ToUpper(strings.TrimSpace(name))
+}
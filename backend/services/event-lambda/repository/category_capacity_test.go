@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/fpt-event-services/services/event-lambda/models"
+)
+
+func TestCheckCapacityChanges(t *testing.T) {
+	existing := []existingCategory{
+		{ID: 1, Name: "VIP", MaxQuantity: 20, Status: "ACTIVE", Sold: 12},
+		{ID: 2, Name: "Standard", MaxQuantity: 50, Status: "ACTIVE", Sold: 0},
+	}
+
+	tests := []struct {
+		name        string
+		incoming    []models.CategoryTicketDTO
+		wantReasons []string
+	}{
+		{
+			name: "Increase is always allowed",
+			incoming: []models.CategoryTicketDTO{
+				{Name: "VIP", MaxQuantity: 30},
+				{Name: "Standard", MaxQuantity: 80},
+			},
+		},
+		{
+			name: "Decrease down to sold count is allowed",
+			incoming: []models.CategoryTicketDTO{
+				{Name: "vip", MaxQuantity: 12},
+				{Name: "Standard", MaxQuantity: 10},
+			},
+		},
+		{
+			name: "Decrease below sold count is blocked",
+			incoming: []models.CategoryTicketDTO{
+				{Name: "VIP", MaxQuantity: 5},
+				{Name: "Standard", MaxQuantity: 50},
+			},
+			wantReasons: []string{"BELOW_SOLD_COUNT"},
+		},
+		{
+			name: "Removing a category with sales is blocked",
+			incoming: []models.CategoryTicketDTO{
+				{Name: "Standard", MaxQuantity: 50},
+			},
+			wantReasons: []string{"CATEGORY_HAS_SALES"},
+		},
+		{
+			name: "Match by ID allows renaming a sold category",
+			incoming: []models.CategoryTicketDTO{
+				{CategoryTicketID: intPtr(1), Name: "VIP Gold", MaxQuantity: 15},
+				{CategoryTicketID: intPtr(2), Name: "Standard", MaxQuantity: 50},
+			},
+		},
+		{
+			name: "Removing an unsold category is allowed",
+			incoming: []models.CategoryTicketDTO{
+				{Name: "VIP", MaxQuantity: 20},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matches, err := matchCategories(existing, tt.incoming)
+			if err != nil {
+				t.Fatalf("matchCategories: %v", err)
+			}
+			capErr := checkCapacityChanges(existing, tt.incoming, matches)
+			if len(tt.wantReasons) == 0 {
+				if capErr != nil {
+					t.Fatalf("expected no error, got %+v", capErr)
+				}
+				return
+			}
+			if capErr == nil {
+				t.Fatalf("expected violations %v, got nil", tt.wantReasons)
+			}
+			if len(capErr.Violations) != len(tt.wantReasons) {
+				t.Fatalf("expected %d violations, got %d", len(tt.wantReasons), len(capErr.Violations))
+			}
+			for i, reason := range tt.wantReasons {
+				if capErr.Violations[i].Reason != reason {
+					t.Errorf("violation %d: expected %s, got %s", i, reason, capErr.Violations[i].Reason)
+				}
+			}
+		})
+	}
+}
+
+func TestMatchCategoriesRejectsForeignID(t *testing.T) {
+	existing := []existingCategory{{ID: 1, Name: "VIP", MaxQuantity: 20, Status: "ACTIVE"}}
+	_, err := matchCategories(existing, []models.CategoryTicketDTO{{CategoryTicketID: intPtr(99), Name: "VIP"}})
+	if err == nil {
+		t.Fatal("expected error for category ID of another event")
+	}
+}
+
+func intPtr(v int) *int {
+	return &v
+}
@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/fpt-event-services/services/event-lambda/models"
+)
+
+// ============================================================
+// Event.activity_points - Điểm rèn luyện cộng cho sinh viên đã check-in (migration 050)
+// Điểm đọc lúc truy vấn: sửa điểm thì lịch sử tham dự và bản xuất cho phòng CTSV
+// (dashboard-lambda) đổi theo
+// ============================================================
+
+// GetActivityPoints - Điểm của sự kiện và số vé đã check-in (kể cả vé đã lưu trữ)
+func (r *EventRepository) GetActivityPoints(ctx context.Context, eventID int) (*models.ActivityPointsSettings, error) {
+	s := models.ActivityPointsSettings{EventID: eventID}
+	err := r.db.QueryRowContext(ctx, `
+		SELECT e.activity_points,
+		       (SELECT COUNT(*) FROM Ticket t WHERE t.event_id = e.event_id AND t.status IN ('CHECKED_IN', 'CHECKED_OUT'))
+		       + (SELECT COUNT(*) FROM Ticket_Archive ta WHERE ta.event_id = e.event_id AND ta.status IN ('CHECKED_IN', 'CHECKED_OUT'))
+		FROM Event e
+		WHERE e.event_id = ?
+	`, eventID).Scan(&s.Points, &s.Attendees)
+	if err == sql.ErrNoRows {
+		return nil, ErrEventNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load activity points: %w", err)
+	}
+	return &s, nil
+}
+
+// SetActivityPoints - Đặt điểm rèn luyện của sự kiện
+func (r *EventRepository) SetActivityPoints(ctx context.Context, eventID, points int) error {
+	if _, err := r.db.ExecContext(ctx,
+		`UPDATE Event SET activity_points = ? WHERE event_id = ?`, points, eventID,
+	); err != nil {
+		return fmt.Errorf("failed to save activity points: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,175 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/fpt-event-services/common/tickethistory"
+	"github.com/fpt-event-services/services/event-lambda/models"
+)
+
+// ============================================================
+// ListEventsToArchive - Lấy các sự kiện OPEN đã qua end_time
+// (ứng viên cho job event-archival)
+// ============================================================
+func (r *EventRepository) ListEventsToArchive(ctx context.Context, limit int) ([]int, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT event_id
+		FROM Event
+		WHERE status = 'OPEN' AND end_time < NOW()
+		ORDER BY end_time ASC
+		LIMIT ?
+	`, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query ended events: %w", err)
+	}
+	defer rows.Close()
+
+	var eventIDs []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		eventIDs = append(eventIDs, id)
+	}
+	return eventIDs, rows.Err()
+}
+
+// ============================================================
+// ArchiveEndedEvent - Đóng và lưu trữ một sự kiện đã kết thúc
+// Tất cả trong MỘT transaction:
+//  1. Khóa Event (FOR UPDATE), bỏ qua nếu không còn OPEN hoặc chưa kết thúc
+//  2. Vé PENDING chưa thanh toán → EXPIRED
+//  3. Ghế chưa bán trong Event_Seat_Layout → INAVAILABLE
+//  4. Chốt thống kê vào Event_Summary
+//  5. Event → CLOSED, Event_Request → FINISHED
+//
+// Venue_Area được giải phóng sau commit bởi subscriber EventClosed (job publish)
+// Idempotent: chạy lại trên sự kiện đã CLOSED trả về (nil, false, nil)
+// ============================================================
+func (r *EventRepository) ArchiveEndedEvent(ctx context.Context, eventID int) (*models.EventSummary, bool, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var status string
+	var endTime time.Time
+	var maxSeats sql.NullInt64
+	err = tx.QueryRowContext(ctx, `
+		SELECT status, end_time, max_seats
+		FROM Event
+		WHERE event_id = ?
+		FOR UPDATE
+	`, eventID).Scan(&status, &endTime, &maxSeats)
+	if err == sql.ErrNoRows {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to lock event: %w", err)
+	}
+	if status != "OPEN" || !endTime.Before(time.Now()) {
+		// Đã được xử lý bởi lần chạy khác hoặc chưa kết thúc
+		return nil, false, nil
+	}
+
+	summary := &models.EventSummary{EventID: eventID, FinalizedAt: time.Now()}
+
+	// 2. Vé PENDING không còn cơ hội thanh toán (trả lại suất trong bộ đếm trước khi đổi trạng thái)
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE Category_Ticket_Inventory i
+		JOIN (SELECT category_ticket_id, COUNT(*) AS n FROM Ticket
+		      WHERE event_id = ? AND status = 'PENDING' GROUP BY category_ticket_id) p
+		  ON p.category_ticket_id = i.category_ticket_id
+		SET i.sold = GREATEST(i.sold - p.n, 0)
+	`, eventID); err != nil {
+		return nil, false, fmt.Errorf("failed to release pending inventory: %w", err)
+	}
+	expired, err := tickethistory.TransitionWhere(ctx, tx, tickethistory.StatusPending, tickethistory.StatusExpired, "t.event_id = ?", eventID)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to expire pending tickets: %w", err)
+	}
+	summary.ExpiredPending = int(expired)
+
+	// 3. Ghế chưa bán: khóa lại trên layout của sự kiện
+	var layoutSeats int
+	if err := tx.QueryRowContext(ctx, `SELECT COUNT(*) FROM Event_Seat_Layout WHERE event_id = ?`, eventID).Scan(&layoutSeats); err != nil {
+		return nil, false, fmt.Errorf("failed to count seat layout: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE Event_Seat_Layout SET status = 'INAVAILABLE'
+		WHERE event_id = ? AND status IN ('AVAILABLE', 'HOLD')
+	`, eventID); err != nil {
+		return nil, false, fmt.Errorf("failed to mark unsold seats: %w", err)
+	}
+
+	// 4. Thống kê: doanh thu chốt không tính vé đã hoàn tiền và vé mời
+	err = tx.QueryRowContext(ctx, `
+		SELECT
+			COUNT(CASE WHEN t.status IN ('BOOKED', 'CHECKED_IN', 'CHECKED_OUT') THEN 1 END),
+			COUNT(CASE WHEN t.status IN ('BOOKED', 'CHECKED_IN', 'CHECKED_OUT') AND t.checkin_time IS NOT NULL THEN 1 END),
+			COUNT(CASE WHEN t.status IN ('BOOKED', 'CHECKED_IN', 'CHECKED_OUT') AND t.check_out_time IS NOT NULL THEN 1 END),
+			COUNT(CASE WHEN t.status = 'REFUNDED' THEN 1 END),
+			COALESCE(SUM(CASE WHEN t.status IN ('BOOKED', 'CHECKED_IN', 'CHECKED_OUT') AND t.is_complimentary = 0 THEN ct.price END), 0)
+		FROM Ticket t
+		JOIN category_ticket ct ON t.category_ticket_id = ct.category_ticket_id
+		WHERE t.event_id = ?
+	`, eventID).Scan(&summary.TicketsSold, &summary.CheckedInCount, &summary.CheckedOutCount,
+		&summary.RefundedCount, &summary.TotalRevenue)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to compute event statistics: %w", err)
+	}
+
+	summary.TotalSeats = layoutSeats
+	if summary.TotalSeats == 0 && maxSeats.Valid {
+		summary.TotalSeats = int(maxSeats.Int64)
+	}
+	summary.NoShowCount = summary.TicketsSold - summary.CheckedInCount
+	summary.UnsoldSeats = max(summary.TotalSeats-summary.TicketsSold, 0)
+
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO Event_Summary
+			(event_id, total_seats, tickets_sold, checked_in_count, checked_out_count,
+			 no_show_count, refunded_count, expired_pending_count, unsold_seats, total_revenue, finalized_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			total_seats = VALUES(total_seats),
+			tickets_sold = VALUES(tickets_sold),
+			checked_in_count = VALUES(checked_in_count),
+			checked_out_count = VALUES(checked_out_count),
+			no_show_count = VALUES(no_show_count),
+			refunded_count = VALUES(refunded_count),
+			expired_pending_count = VALUES(expired_pending_count),
+			unsold_seats = VALUES(unsold_seats),
+			total_revenue = VALUES(total_revenue),
+			finalized_at = VALUES(finalized_at)
+	`, summary.EventID, summary.TotalSeats, summary.TicketsSold, summary.CheckedInCount, summary.CheckedOutCount,
+		summary.NoShowCount, summary.RefundedCount, summary.ExpiredPending, summary.UnsoldSeats,
+		summary.TotalRevenue, summary.FinalizedAt); err != nil {
+		return nil, false, fmt.Errorf("failed to save event summary: %w", err)
+	}
+
+	// 5. Đóng sự kiện + hoàn tất request
+	if _, err := tx.ExecContext(ctx, `UPDATE Event SET status = 'CLOSED', version = version + 1 WHERE event_id = ?`, eventID); err != nil {
+		return nil, false, fmt.Errorf("failed to close event: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE Event_Request SET status = 'FINISHED', version = version + 1
+		WHERE created_event_id = ? AND status IN ('APPROVED', 'UPDATING')
+	`, eventID); err != nil {
+		return nil, false, fmt.Errorf("failed to finish event request: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, false, fmt.Errorf("failed to commit archival: %w", err)
+	}
+
+	log.Printf("[ARCHIVE] ✅ Event #%d archived: sold=%d, checkedIn=%d, unsold=%d, revenue=%.2f",
+		eventID, summary.TicketsSold, summary.CheckedInCount, summary.UnsoldSeats, summary.TotalRevenue)
+	return summary, true, nil
+}
@@ -0,0 +1,609 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/fpt-event-services/services/event-lambda/models"
+)
+
+// ============================================================
+// Event_Area_Change - STAFF / ADMIN chuyển sự kiện đã duyệt sang khu vực khác (migration 051)
+// Trong một transaction: kiểm tra khu vực mới → co số lượng loại vé nếu phòng nhỏ hơn
+// (không dưới số đã bán) → bỏ gán ghế ở khu vực cũ → SeatAllocator xếp lại ghế khu vực mới
+// (tự tạo thêm ghế tới sức chứa) → ghép vé đã bán sang ghế mới → chuyển giá riêng theo mã ghế
+// → cập nhật Event, khóa khu vực mới, giải phóng khu vực cũ → ghi lịch sử
+// QR chỉ chứa ticket_id nên vé cũ vẫn dùng được sau khi đổi ghế
+// ============================================================
+
+// Lý do vé cần xếp lại ghế thủ công
+const (
+	AreaChangeNoAccessibleSeat = "NO_ACCESSIBLE_SEAT"
+	AreaChangeNoSeat           = "NO_SEAT"
+)
+
+var (
+	ErrAreaChangeNotAllowed = errors.New("only OPEN or UPDATING events that have not started can change area")
+	ErrSameArea             = errors.New("event is already in this area")
+	ErrAreaUnavailable      = errors.New("target area is not available")
+	ErrAreaOtherCampus      = errors.New("target area belongs to another campus")
+	ErrAreaScheduleConflict = errors.New("target area has another event at this time")
+	ErrAreaTooSmall         = errors.New("target area cannot hold the tickets already sold")
+)
+
+// soldSeatTicket - Vé đã bán cùng ghế cũ (đầu vào ghép ghế)
+type soldSeatTicket struct {
+	TicketID   int
+	UserID     int
+	CategoryID int
+	SeatCode   string // "" nếu vé chưa có ghế
+	Accessible bool
+}
+
+// newAreaSeat - Ghế của khu vực mới đã gán cho một loại vé
+type newAreaSeat struct {
+	SeatID     int64
+	Code       string
+	Accessible bool
+}
+
+// seatMove - Ghế mới của một vé (SeatID = 0: chưa có ghế)
+type seatMove struct {
+	Ticket   soldSeatTicket
+	SeatID   int64
+	SeatCode string
+	Reason   string // "" nếu không cần xếp lại
+}
+
+// AreaChangeRecipient - Người mua có vé BOOKED bị đổi ghế
+type AreaChangeRecipient struct {
+	UserID   int
+	Email    string
+	FullName string
+	Tickets  []models.AreaChangeTicket
+}
+
+// AreaChangeNotice - Nội dung thông báo một lần đổi khu vực
+type AreaChangeNotice struct {
+	EventTitle string
+	StartTime  time.Time
+	Recipients []AreaChangeRecipient
+}
+
+// ChangeEventArea - Chuyển sự kiện sang khu vực areaID; dryRun = true thì rollback sau khi ghép ghế
+func (r *EventRepository) ChangeEventArea(ctx context.Context, eventID, areaID, changedBy int, reason *string, dryRun bool) (*models.AreaChange, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var fromArea sql.NullInt64
+	var eventCampus sql.NullInt64
+	var status string
+	var upcoming bool
+	err = tx.QueryRowContext(ctx, `
+		SELECT area_id, campus_id, status, start_time > NOW() FROM Event WHERE event_id = ? FOR UPDATE
+	`, eventID).Scan(&fromArea, &eventCampus, &status, &upcoming)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrEventNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load event: %w", err)
+	}
+	if (status != "OPEN" && status != "UPDATING") || !upcoming {
+		return nil, ErrAreaChangeNotAllowed
+	}
+	if fromArea.Valid && int(fromArea.Int64) == areaID {
+		return nil, ErrSameArea
+	}
+
+	result := &models.AreaChange{EventID: eventID, DryRun: dryRun, ToAreaID: areaID, ChangedBy: changedBy, Reason: reason}
+	var areaStatus, venueStatus string
+	var areaCampus sql.NullInt64
+	err = tx.QueryRowContext(ctx, `
+		SELECT CONCAT(va.area_name, ' - ', v.venue_name), va.status, v.status, COALESCE(va.capacity, 0), v.campus_id
+		FROM Venue_Area va
+		JOIN Venue v ON v.venue_id = va.venue_id
+		WHERE va.area_id = ?
+		FOR UPDATE
+	`, areaID).Scan(&result.ToArea, &areaStatus, &venueStatus, &result.Capacity, &areaCampus)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrAreaNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load target area: %w", err)
+	}
+	if areaStatus != "AVAILABLE" || venueStatus != "AVAILABLE" {
+		return nil, ErrAreaUnavailable
+	}
+	if result.Capacity <= 0 {
+		return nil, fmt.Errorf("%w: area has no capacity", ErrAreaUnavailable)
+	}
+	if eventCampus.Valid && (!areaCampus.Valid || areaCampus.Int64 != eventCampus.Int64) {
+		return nil, ErrAreaOtherCampus
+	}
+	if fromArea.Valid {
+		id := int(fromArea.Int64)
+		result.FromAreaID = &id
+		if err := tx.QueryRowContext(ctx, `
+			SELECT CONCAT(va.area_name, ' - ', v.venue_name)
+			FROM Venue_Area va JOIN Venue v ON v.venue_id = va.venue_id
+			WHERE va.area_id = ?
+		`, id).Scan(&result.FromArea); err != nil {
+			return nil, fmt.Errorf("failed to load current area: %w", err)
+		}
+	}
+
+	// Trùng lịch: như lúc duyệt, đệm 1 giờ mỗi bên
+	var conflict bool
+	if err := tx.QueryRowContext(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM Event e
+			JOIN Event me ON me.event_id = ?
+			WHERE e.area_id = ? AND e.event_id <> me.event_id
+			  AND e.status IN ('OPEN', 'CLOSED', 'UPDATING')
+			  AND e.start_time < me.end_time + INTERVAL 1 HOUR
+			  AND e.end_time > me.start_time - INTERVAL 1 HOUR
+		)
+	`, eventID, areaID).Scan(&conflict); err != nil {
+		return nil, fmt.Errorf("failed to check area schedule: %w", err)
+	}
+	if conflict {
+		return nil, ErrAreaScheduleConflict
+	}
+
+	categories, err := r.loadCategoriesWithSoldTx(ctx, tx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	allocator := defaultSeatAllocator()
+	quantities, err := fitAreaCapacity(allocator, categories, result.Capacity)
+	if err != nil {
+		return nil, err
+	}
+	seatCategories := make([]SeatCategory, 0, len(categories))
+	for _, c := range categories {
+		q := quantities[c.ID]
+		result.Categories = append(result.Categories, models.AreaChangeCategory{
+			CategoryTicketID: c.ID, Name: c.Name, Sold: c.Sold, OldQuantity: c.MaxQuantity, NewQuantity: q,
+		})
+		if c.Status == "ACTIVE" && q != c.MaxQuantity {
+			if _, err := tx.ExecContext(ctx, `UPDATE category_ticket SET max_quantity = ? WHERE category_ticket_id = ?`, q, c.ID); err != nil {
+				return nil, fmt.Errorf("failed to resize category ticket %d: %w", c.ID, err)
+			}
+		}
+		seatCategories = append(seatCategories, SeatCategory{CategoryTicketID: int64(c.ID), Name: c.Name, Price: c.Price, Quantity: q})
+	}
+
+	tickets, err := loadSoldSeatTicketsTx(ctx, tx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	if fromArea.Valid {
+		if _, err := tx.ExecContext(ctx, `
+			UPDATE Seat SET category_ticket_id = NULL
+			WHERE area_id = ? AND category_ticket_id IN (SELECT category_ticket_id FROM category_ticket WHERE event_id = ?)
+		`, fromArea.Int64, eventID); err != nil {
+			return nil, fmt.Errorf("failed to clear seats of current area: %w", err)
+		}
+	}
+	if err := allocator.AllocateTx(ctx, tx, int64(areaID), result.Capacity, seatCategories); err != nil {
+		return nil, err
+	}
+	seats, err := loadCategorySeatsTx(ctx, tx, int64(areaID))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, m := range remapSeats(tickets, seats) {
+		var seatID any
+		if m.SeatID != 0 {
+			seatID = m.SeatID
+		}
+		if _, err := tx.ExecContext(ctx, `UPDATE Ticket SET seat_id = ? WHERE ticket_id = ?`, seatID, m.Ticket.TicketID); err != nil {
+			return nil, fmt.Errorf("failed to move ticket %d: %w", m.Ticket.TicketID, err)
+		}
+		t := models.AreaChangeTicket{
+			TicketID: m.Ticket.TicketID, UserID: m.Ticket.UserID, CategoryTicketID: m.Ticket.CategoryID,
+			NeedsReassignment: m.Reason != "",
+		}
+		if m.Ticket.SeatCode != "" {
+			code := m.Ticket.SeatCode
+			t.FromSeat = &code
+		}
+		if m.SeatID != 0 {
+			code := m.SeatCode
+			t.ToSeat = &code
+			result.TicketsMoved++
+		}
+		if m.Reason != "" {
+			why := m.Reason
+			t.Reason = &why
+			result.TicketsFlagged++
+		}
+		result.Tickets = append(result.Tickets, t)
+	}
+	if result.Tickets == nil {
+		result.Tickets = []models.AreaChangeTicket{}
+	}
+
+	if fromArea.Valid {
+		// Giá riêng từng ghế đi theo mã ghế nếu ghế cùng mã ở khu vực mới vẫn thuộc loại vé đó
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO Seat_Price (category_ticket_id, seat_id, adjust_percent, updated_by)
+			SELECT sp.category_ticket_id, ns.seat_id, sp.adjust_percent, sp.updated_by
+			FROM Seat_Price sp
+			JOIN category_ticket ct ON ct.category_ticket_id = sp.category_ticket_id AND ct.event_id = ?
+			JOIN Seat os ON os.seat_id = sp.seat_id AND os.area_id = ?
+			JOIN Seat ns ON ns.area_id = ? AND ns.seat_code = os.seat_code AND ns.category_ticket_id = sp.category_ticket_id
+		`, eventID, fromArea.Int64, areaID); err != nil {
+			return nil, fmt.Errorf("failed to move seat prices: %w", err)
+		}
+		if _, err := tx.ExecContext(ctx, `
+			DELETE sp FROM Seat_Price sp
+			JOIN category_ticket ct ON ct.category_ticket_id = sp.category_ticket_id AND ct.event_id = ?
+			JOIN Seat os ON os.seat_id = sp.seat_id AND os.area_id = ?
+		`, eventID, fromArea.Int64); err != nil {
+			return nil, fmt.Errorf("failed to clear seat prices of current area: %w", err)
+		}
+	}
+
+	var campusID any
+	if areaCampus.Valid {
+		campusID = areaCampus.Int64
+	}
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE Event
+		SET area_id = ?, campus_id = COALESCE(?, campus_id),
+		    max_seats = LEAST(COALESCE(max_seats, ?), ?), version = version + 1
+		WHERE event_id = ?
+	`, areaID, campusID, result.Capacity, result.Capacity, eventID); err != nil {
+		return nil, fmt.Errorf("failed to update event area: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `UPDATE Venue_Area SET status = 'UNAVAILABLE' WHERE area_id = ?`, areaID); err != nil {
+		return nil, fmt.Errorf("failed to reserve target area: %w", err)
+	}
+	if fromArea.Valid {
+		if _, err := tx.ExecContext(ctx, releaseAreaSQL, fromArea.Int64, fromArea.Int64); err != nil {
+			return nil, fmt.Errorf("failed to release current area: %w", err)
+		}
+	}
+
+	if dryRun {
+		return result, nil
+	}
+
+	var fromAreaID any
+	if fromArea.Valid {
+		fromAreaID = fromArea.Int64
+	}
+	res, err := tx.ExecContext(ctx, `
+		INSERT INTO Event_Area_Change (event_id, from_area_id, to_area_id, changed_by, reason, tickets_moved, tickets_flagged)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`, eventID, fromAreaID, areaID, changedBy, reason, result.TicketsMoved, result.TicketsFlagged)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record area change: %w", err)
+	}
+	changeID, err := res.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read area change id: %w", err)
+	}
+	result.ChangeID = int(changeID)
+	for _, t := range result.Tickets {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO Event_Area_Change_Ticket
+				(change_id, ticket_id, user_id, category_ticket_id, from_seat_code, to_seat_code, needs_reassignment, reason)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		`, changeID, t.TicketID, t.UserID, t.CategoryTicketID, t.FromSeat, t.ToSeat, t.NeedsReassignment, t.Reason); err != nil {
+			return nil, fmt.Errorf("failed to record ticket %d: %w", t.TicketID, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit area change: %w", err)
+	}
+	now := time.Now()
+	result.ChangedAt = &now
+	log.Printf("[AREA_CHANGE] Event #%d moved to area %d by user %d: %d ticket(s) moved, %d flagged",
+		eventID, areaID, changedBy, result.TicketsMoved, result.TicketsFlagged)
+	return result, nil
+}
+
+// ListAreaChanges - Lịch sử đổi khu vực của sự kiện (mới nhất trước) kèm ghế cũ / mới của từng vé
+func (r *EventRepository) ListAreaChanges(ctx context.Context, eventID int) ([]models.AreaChange, error) {
+	var exists bool
+	if err := r.db.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM Event WHERE event_id = ?)`, eventID).Scan(&exists); err != nil {
+		return nil, fmt.Errorf("failed to check event: %w", err)
+	}
+	if !exists {
+		return nil, ErrEventNotFound
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT c.change_id, c.from_area_id, COALESCE(CONCAT(fa.area_name, ' - ', fv.venue_name), ''),
+		       c.to_area_id, CONCAT(ta.area_name, ' - ', tv.venue_name),
+		       c.changed_by, c.reason, c.created_at, c.tickets_moved, c.tickets_flagged
+		FROM Event_Area_Change c
+		LEFT JOIN Venue_Area fa ON fa.area_id = c.from_area_id
+		LEFT JOIN Venue fv ON fv.venue_id = fa.venue_id
+		JOIN Venue_Area ta ON ta.area_id = c.to_area_id
+		JOIN Venue tv ON tv.venue_id = ta.venue_id
+		WHERE c.event_id = ?
+		ORDER BY c.created_at DESC, c.change_id DESC
+	`, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query area changes: %w", err)
+	}
+	defer rows.Close()
+
+	changes := []models.AreaChange{}
+	index := make(map[int]int)
+	for rows.Next() {
+		c := models.AreaChange{EventID: eventID, Tickets: []models.AreaChangeTicket{}}
+		var fromArea sql.NullInt64
+		var changedAt time.Time
+		if err := rows.Scan(&c.ChangeID, &fromArea, &c.FromArea, &c.ToAreaID, &c.ToArea,
+			&c.ChangedBy, &c.Reason, &changedAt, &c.TicketsMoved, &c.TicketsFlagged); err != nil {
+			return nil, fmt.Errorf("failed to scan area change: %w", err)
+		}
+		if fromArea.Valid {
+			id := int(fromArea.Int64)
+			c.FromAreaID = &id
+		}
+		c.ChangedAt = &changedAt
+		index[c.ChangeID] = len(changes)
+		changes = append(changes, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(changes) == 0 {
+		return changes, nil
+	}
+
+	ticketRows, err := r.db.QueryContext(ctx, `
+		SELECT t.change_id, t.ticket_id, t.user_id, t.category_ticket_id, t.from_seat_code, t.to_seat_code,
+		       t.needs_reassignment, t.reason
+		FROM Event_Area_Change_Ticket t
+		JOIN Event_Area_Change c ON c.change_id = t.change_id
+		WHERE c.event_id = ?
+		ORDER BY t.change_id, t.ticket_id
+	`, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query area change tickets: %w", err)
+	}
+	defer ticketRows.Close()
+	for ticketRows.Next() {
+		var changeID int
+		var t models.AreaChangeTicket
+		if err := ticketRows.Scan(&changeID, &t.TicketID, &t.UserID, &t.CategoryTicketID, &t.FromSeat, &t.ToSeat,
+			&t.NeedsReassignment, &t.Reason); err != nil {
+			return nil, fmt.Errorf("failed to scan area change ticket: %w", err)
+		}
+		if i, ok := index[changeID]; ok {
+			changes[i].Tickets = append(changes[i].Tickets, t)
+		}
+	}
+	return changes, ticketRows.Err()
+}
+
+// GetAreaChangeNotice - Sự kiện và người có vé BOOKED trong lần đổi khu vực, nhóm vé theo người mua
+func (r *EventRepository) GetAreaChangeNotice(ctx context.Context, changeID int) (*AreaChangeNotice, error) {
+	notice := &AreaChangeNotice{}
+	if err := r.db.QueryRowContext(ctx, `
+		SELECT e.title, e.start_time
+		FROM Event_Area_Change c JOIN Event e ON e.event_id = c.event_id
+		WHERE c.change_id = ?
+	`, changeID).Scan(&notice.EventTitle, &notice.StartTime); err != nil {
+		return nil, fmt.Errorf("failed to load area change event: %w", err)
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT u.user_id, u.email, u.full_name,
+		       ct.ticket_id, ct.category_ticket_id, ct.from_seat_code, ct.to_seat_code, ct.needs_reassignment, ct.reason
+		FROM Event_Area_Change_Ticket ct
+		JOIN Ticket t ON t.ticket_id = ct.ticket_id AND t.status = 'BOOKED'
+		JOIN Users u ON u.user_id = ct.user_id
+		WHERE ct.change_id = ?
+		ORDER BY u.user_id, ct.ticket_id
+	`, changeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query area change recipients: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var rc AreaChangeRecipient
+		var t models.AreaChangeTicket
+		if err := rows.Scan(&rc.UserID, &rc.Email, &rc.FullName,
+			&t.TicketID, &t.CategoryTicketID, &t.FromSeat, &t.ToSeat, &t.NeedsReassignment, &t.Reason); err != nil {
+			return nil, fmt.Errorf("failed to scan area change recipient: %w", err)
+		}
+		t.UserID = rc.UserID
+		if n := len(notice.Recipients); n > 0 && notice.Recipients[n-1].UserID == rc.UserID {
+			notice.Recipients[n-1].Tickets = append(notice.Recipients[n-1].Tickets, t)
+			continue
+		}
+		rc.Tickets = []models.AreaChangeTicket{t}
+		notice.Recipients = append(notice.Recipients, rc)
+	}
+	return notice, rows.Err()
+}
+
+// loadSoldSeatTicketsTx - Vé đang giữ suất của sự kiện cùng ghế hiện tại (khóa FOR UPDATE)
+func loadSoldSeatTicketsTx(ctx context.Context, tx *sql.Tx, eventID int) ([]soldSeatTicket, error) {
+	rows, err := tx.QueryContext(ctx, `
+		SELECT t.ticket_id, t.user_id, t.category_ticket_id, COALESCE(s.seat_code, ''), COALESCE(s.is_accessible, 0)
+		FROM Ticket t
+		LEFT JOIN Seat s ON s.seat_id = t.seat_id
+		WHERE t.event_id = ? AND t.status IN (`+soldTicketStatuses+`)
+		ORDER BY t.ticket_id
+		FOR UPDATE
+	`, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load sold tickets: %w", err)
+	}
+	defer rows.Close()
+
+	var tickets []soldSeatTicket
+	for rows.Next() {
+		var t soldSeatTicket
+		if err := rows.Scan(&t.TicketID, &t.UserID, &t.CategoryID, &t.SeatCode, &t.Accessible); err != nil {
+			return nil, fmt.Errorf("failed to scan sold ticket: %w", err)
+		}
+		tickets = append(tickets, t)
+	}
+	return tickets, rows.Err()
+}
+
+// loadCategorySeatsTx - Ghế đã gán loại vé của khu vực, theo thứ tự sortSeats
+func loadCategorySeatsTx(ctx context.Context, tx *sql.Tx, areaID int64) (map[int][]newAreaSeat, error) {
+	ordered, err := loadAreaSeatsTx(ctx, tx, areaID)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := tx.QueryContext(ctx, `
+		SELECT seat_id, category_ticket_id, is_accessible FROM Seat WHERE area_id = ? AND category_ticket_id IS NOT NULL
+	`, areaID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query assigned seats: %w", err)
+	}
+	defer rows.Close()
+
+	type assignment struct {
+		categoryID int
+		accessible bool
+	}
+	assigned := make(map[int64]assignment)
+	for rows.Next() {
+		var seatID int64
+		var a assignment
+		if err := rows.Scan(&seatID, &a.categoryID, &a.accessible); err != nil {
+			return nil, fmt.Errorf("failed to scan assigned seat: %w", err)
+		}
+		assigned[seatID] = a
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	seats := make(map[int][]newAreaSeat)
+	for _, s := range ordered {
+		if a, ok := assigned[s.SeatID]; ok {
+			seats[a.categoryID] = append(seats[a.categoryID], newAreaSeat{SeatID: s.SeatID, Code: s.Code, Accessible: a.accessible})
+		}
+	}
+	return seats, nil
+}
+
+// fitAreaCapacity - Số ghế mỗi loại vé ở khu vực mới (theo category_ticket_id)
+// ACTIVE giữ max_quantity (không dưới số đã bán), INACTIVE chỉ giữ ghế đã bán
+// Tổng vượt sức chứa: cắt suất chưa bán bắt đầu từ loại vé xếp cuối theo chiến lược
+func fitAreaCapacity(a *SeatAllocator, categories []existingCategory, capacity int) (map[int]int, error) {
+	quantities := make(map[int]int, len(categories))
+	ordered := make([]SeatCategory, 0, len(categories))
+	total, sold := 0, 0
+	for _, c := range categories {
+		q := c.Sold
+		if c.Status == "ACTIVE" && c.MaxQuantity > q {
+			q = c.MaxQuantity
+		}
+		quantities[c.ID] = q
+		total += q
+		sold += c.Sold
+		ordered = append(ordered, SeatCategory{CategoryTicketID: int64(c.ID), Name: c.Name, Price: c.Price})
+	}
+	if sold > capacity {
+		return nil, fmt.Errorf("%w: %d tickets sold, area holds %d", ErrAreaTooSmall, sold, capacity)
+	}
+
+	soldByID := make(map[int]int, len(categories))
+	for _, c := range categories {
+		soldByID[c.ID] = c.Sold
+	}
+	ordered = a.orderCategories(ordered)
+	for i := len(ordered) - 1; i >= 0 && total > capacity; i-- {
+		id := int(ordered[i].CategoryTicketID)
+		cut := min(total-capacity, quantities[id]-soldByID[id])
+		quantities[id] -= cut
+		total -= cut
+	}
+	return quantities, nil
+}
+
+// remapSeats - Ghép vé đã bán với ghế mới cùng loại vé (kết quả theo thứ tự tickets)
+// Lượt 1: giữ mã ghế cũ nếu ghế cùng mã thuộc loại vé và cùng kiểu (xe lăn / thường)
+// Lượt 2: vé xe lăn lấy ghế xe lăn còn trống; hết thì ngồi tạm ghế thường (NO_ACCESSIBLE_SEAT)
+// Lượt 3: vé thường lấy ghế thường còn trống; hết mới dùng ghế xe lăn
+// Không còn ghế nào trong loại vé: NO_SEAT
+func remapSeats(tickets []soldSeatTicket, seats map[int][]newAreaSeat) []seatMove {
+	moves := make([]seatMove, len(tickets))
+	taken := make(map[int64]bool)
+	take := func(i int, s newAreaSeat) {
+		moves[i].SeatID, moves[i].SeatCode = s.SeatID, s.Code
+		taken[s.SeatID] = true
+	}
+
+	byCode := make(map[int]map[string]newAreaSeat, len(seats))
+	for categoryID, list := range seats {
+		codes := make(map[string]newAreaSeat, len(list))
+		for _, s := range list {
+			codes[s.Code] = s
+		}
+		byCode[categoryID] = codes
+	}
+	for i, t := range tickets {
+		moves[i].Ticket = t
+		if t.SeatCode == "" {
+			continue
+		}
+		if s, ok := byCode[t.CategoryID][t.SeatCode]; ok && !taken[s.SeatID] && s.Accessible == t.Accessible {
+			take(i, s)
+		}
+	}
+
+	// Con trỏ ghế trống tiếp theo theo (loại vé, kiểu ghế); ghế chỉ chuyển từ trống sang đã lấy
+	type cursorKey struct {
+		categoryID int
+		accessible bool
+	}
+	cursors := make(map[cursorKey]int)
+	next := func(categoryID int, accessible bool) (newAreaSeat, bool) {
+		key := cursorKey{categoryID, accessible}
+		list := seats[categoryID]
+		for j := cursors[key]; j < len(list); j++ {
+			if s := list[j]; s.Accessible == accessible && !taken[s.SeatID] {
+				cursors[key] = j + 1
+				return s, true
+			}
+		}
+		cursors[key] = len(list)
+		return newAreaSeat{}, false
+	}
+
+	for _, accessible := range []bool{true, false} {
+		for i, t := range tickets {
+			if moves[i].SeatID != 0 || t.Accessible != accessible {
+				continue
+			}
+			s, ok := next(t.CategoryID, accessible)
+			if !ok {
+				if s, ok = next(t.CategoryID, !accessible); ok && accessible {
+					moves[i].Reason = AreaChangeNoAccessibleSeat
+				}
+			}
+			if !ok {
+				moves[i].Reason = AreaChangeNoSeat
+				continue
+			}
+			take(i, s)
+		}
+	}
+	return moves
+}
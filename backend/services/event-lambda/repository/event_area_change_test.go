@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestFitAreaCapacityTrimsCheapestUnsold(t *testing.T) {
+	cats := []existingCategory{
+		{ID: 1, Name: "Standard", MaxQuantity: 80, Status: "ACTIVE", Sold: 30, Price: 50000},
+		{ID: 2, Name: "VIP", MaxQuantity: 20, Status: "ACTIVE", Sold: 15, Price: 200000},
+		{ID: 3, Name: "Early bird", MaxQuantity: 40, Status: "INACTIVE", Sold: 10, Price: 30000},
+	}
+	got, err := fitAreaCapacity(NewSeatAllocator(SeatStrategyVIPFirst), cats, 60)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// INACTIVE chỉ giữ ghế đã bán; Standard (rẻ nhất còn bán) bị cắt trước, VIP giữ nguyên
+	want := map[int]int{1: 30, 2: 20, 3: 10}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	got, err = fitAreaCapacity(NewSeatAllocator(SeatStrategyVIPFirst), cats, 200)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (map[int]int{1: 80, 2: 20, 3: 10}); !reflect.DeepEqual(got, want) {
+		t.Fatalf("bigger area: got %v, want %v", got, want)
+	}
+}
+
+func TestFitAreaCapacityRejectsOversold(t *testing.T) {
+	cats := []existingCategory{{ID: 1, Name: "Standard", MaxQuantity: 50, Status: "ACTIVE", Sold: 45}}
+	if _, err := fitAreaCapacity(NewSeatAllocator(SeatStrategyVIPFirst), cats, 40); !errors.Is(err, ErrAreaTooSmall) {
+		t.Fatalf("expected ErrAreaTooSmall, got %v", err)
+	}
+}
+
+func TestRemapSeats(t *testing.T) {
+	seats := map[int][]newAreaSeat{
+		1: {{SeatID: 11, Code: "A1", Accessible: true}, {SeatID: 12, Code: "A2"}, {SeatID: 13, Code: "A3"}, {SeatID: 14, Code: "A4"}},
+		2: {{SeatID: 21, Code: "B1"}},
+	}
+	tickets := []soldSeatTicket{
+		{TicketID: 1, CategoryID: 1, SeatCode: "A3"},                   // giữ mã ghế
+		{TicketID: 2, CategoryID: 1, SeatCode: "C9", Accessible: true}, // lấy ghế xe lăn A1
+		{TicketID: 3, CategoryID: 1, SeatCode: "A1"},                   // A1 là ghế xe lăn → ghế thường tiếp theo
+		{TicketID: 4, CategoryID: 2, SeatCode: "D1", Accessible: true}, // không có ghế xe lăn → ngồi tạm B1
+		{TicketID: 5, CategoryID: 2, SeatCode: "D2"},                   // hết ghế
+	}
+	moves := remapSeats(tickets, seats)
+
+	type placed struct {
+		code   string
+		reason string
+	}
+	var got []placed
+	for _, m := range moves {
+		got = append(got, placed{m.SeatCode, m.Reason})
+	}
+	want := []placed{
+		{"A3", ""},
+		{"A1", ""},
+		{"A2", ""},
+		{"B1", AreaChangeNoAccessibleSeat},
+		{"", AreaChangeNoSeat},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
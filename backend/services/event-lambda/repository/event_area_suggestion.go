@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fpt-event-services/common/amenity"
+	"github.com/fpt-event-services/services/event-lambda/models"
+)
+
+// suggestionActiveEventSQL - Sự kiện đang giữ khu vực (alias e)
+const suggestionActiveEventSQL = `e.area_id = va.area_id AND e.status IN ('OPEN', 'CLOSED', 'UPDATING')`
+
+// ============================================================
+// GetAreaSuggestionCandidates - Khu vực đang AVAILABLE cùng lịch đặt quanh thời gian của yêu cầu
+// Trùng lịch: giao với khung giờ yêu cầu (đệm 1 giờ mỗi bên, như /api/areas/free)
+// Giờ đã đặt: sự kiện bắt đầu trong ±30 ngày quanh preferred_start_time
+// campusID != nil: chỉ khu vực thuộc campus đó
+// ============================================================
+func (r *EventRepository) GetAreaSuggestionCandidates(ctx context.Context, requestID int, campusID *int) ([]models.AreaCandidate, error) {
+	query := `
+		SELECT
+			va.area_id, va.area_name, v.venue_name, va.floor, COALESCE(va.capacity, 0), v.campus_id, va.amenities,
+			(SELECT COUNT(*) FROM Event e
+			  WHERE ` + suggestionActiveEventSQL + `
+			    AND e.start_time < er.preferred_end_time + INTERVAL 1 HOUR
+			    AND e.end_time > er.preferred_start_time - INTERVAL 1 HOUR) AS conflicts,
+			(SELECT COUNT(*) FROM Event e
+			  WHERE ` + suggestionActiveEventSQL + `
+			    AND DATE(e.start_time) = DATE(er.preferred_start_time)) AS events_on_date,
+			(SELECT COALESCE(SUM(TIMESTAMPDIFF(MINUTE, e.start_time, e.end_time)), 0) FROM Event e
+			  WHERE ` + suggestionActiveEventSQL + `
+			    AND e.start_time >= er.preferred_start_time - INTERVAL 30 DAY
+			    AND e.start_time < er.preferred_start_time + INTERVAL 30 DAY) AS booked_minutes
+		FROM Event_Request er
+		JOIN Venue_Area va ON va.status = 'AVAILABLE'
+		JOIN Venue v ON v.venue_id = va.venue_id AND v.status = 'AVAILABLE'
+		WHERE er.request_id = ?
+		  AND (? IS NULL OR v.campus_id = ?)
+		ORDER BY COALESCE(va.capacity, 0), va.area_id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, requestID, campusID, campusID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query area suggestion candidates: %w", err)
+	}
+	defer rows.Close()
+
+	candidates := []models.AreaCandidate{}
+	for rows.Next() {
+		var c models.AreaCandidate
+		var amenities string
+		if err := rows.Scan(&c.AreaID, &c.AreaName, &c.VenueName, &c.Floor, &c.Capacity, &c.CampusID, &amenities,
+			&c.Conflicts, &c.EventsOnDate, &c.BookedMinutes); err != nil {
+			return nil, fmt.Errorf("failed to scan area suggestion candidate: %w", err)
+		}
+		c.Amenities = amenity.Split(amenities)
+		candidates = append(candidates, c)
+	}
+	return candidates, rows.Err()
+}
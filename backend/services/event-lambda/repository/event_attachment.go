@@ -0,0 +1,153 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/fpt-event-services/services/event-lambda/models"
+)
+
+// ============================================================
+// Event_Attachment - Tài liệu đính kèm của sự kiện
+// ============================================================
+
+// Lỗi nghiệp vụ của tài liệu đính kèm
+var (
+	ErrAttachmentNotFound = errors.New("attachment not found")
+	ErrTooManyAttachments = errors.New("too many attachments for this event")
+)
+
+const attachmentColumns = `attachment_id, event_id, title, type, url, object_key, content_type, size_bytes, visibility, created_at`
+
+func scanAttachment(row interface{ Scan(...interface{}) error }) (*models.EventAttachment, error) {
+	var a models.EventAttachment
+	var objectKey, contentType sql.NullString
+	var size sql.NullInt64
+	if err := row.Scan(&a.AttachmentID, &a.EventID, &a.Title, &a.Type, &a.URL, &objectKey, &contentType, &size, &a.Visibility, &a.CreatedAt); err != nil {
+		return nil, err
+	}
+	a.ObjectKey, a.ContentType, a.SizeBytes = objectKey.String, contentType.String, size.Int64
+	return &a, nil
+}
+
+// ListEventAttachments - Mọi tài liệu của sự kiện (lọc theo người xem ở tầng trên)
+func (r *EventRepository) ListEventAttachments(ctx context.Context, eventID int) ([]models.EventAttachment, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+attachmentColumns+`
+		FROM Event_Attachment
+		WHERE event_id = ?
+		ORDER BY created_at, attachment_id
+	`, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list attachments: %w", err)
+	}
+	defer rows.Close()
+
+	attachments := []models.EventAttachment{}
+	for rows.Next() {
+		a, err := scanAttachment(rows)
+		if err != nil {
+			return nil, err
+		}
+		attachments = append(attachments, *a)
+	}
+	return attachments, rows.Err()
+}
+
+// GetEventAttachment - Một tài liệu của sự kiện
+func (r *EventRepository) GetEventAttachment(ctx context.Context, eventID, attachmentID int) (*models.EventAttachment, error) {
+	a, err := scanAttachment(r.db.QueryRowContext(ctx, `
+		SELECT `+attachmentColumns+` FROM Event_Attachment WHERE attachment_id = ? AND event_id = ?
+	`, attachmentID, eventID))
+	if err == sql.ErrNoRows {
+		return nil, ErrAttachmentNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get attachment: %w", err)
+	}
+	return a, nil
+}
+
+// CountEventAttachments - Số tài liệu hiện có (kiểm tra giới hạn trước khi upload)
+func (r *EventRepository) CountEventAttachments(ctx context.Context, eventID int) (int, error) {
+	var count int
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM Event_Attachment WHERE event_id = ?`, eventID).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count attachments: %w", err)
+	}
+	return count, nil
+}
+
+// CreateEventAttachment - Thêm tài liệu, giới hạn limit tài liệu mỗi sự kiện
+func (r *EventRepository) CreateEventAttachment(ctx context.Context, a *models.EventAttachment, userID, limit int) (*models.EventAttachment, error) {
+	count, err := r.CountEventAttachments(ctx, a.EventID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= limit {
+		return nil, ErrTooManyAttachments
+	}
+
+	result, err := r.db.ExecContext(ctx, `
+		INSERT INTO Event_Attachment (event_id, title, type, url, object_key, content_type, size_bytes, visibility, created_by)
+		VALUES (?, ?, ?, ?, NULLIF(?, ''), NULLIF(?, ''), NULLIF(?, 0), ?, ?)
+	`, a.EventID, a.Title, a.Type, a.URL, a.ObjectKey, a.ContentType, a.SizeBytes, a.Visibility, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create attachment: %w", err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get attachment id: %w", err)
+	}
+	return r.GetEventAttachment(ctx, a.EventID, int(id))
+}
+
+// UpdateEventAttachment - Sửa title/type/visibility/url
+func (r *EventRepository) UpdateEventAttachment(ctx context.Context, a *models.EventAttachment) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE Event_Attachment SET title = ?, type = ?, visibility = ?, url = ?
+		WHERE attachment_id = ? AND event_id = ?
+	`, a.Title, a.Type, a.Visibility, a.URL, a.AttachmentID, a.EventID)
+	if err != nil {
+		return fmt.Errorf("failed to update attachment: %w", err)
+	}
+	return nil
+}
+
+// DeleteEventAttachment - Xóa tài liệu (file trên storage giữ lại, giống banner cũ)
+func (r *EventRepository) DeleteEventAttachment(ctx context.Context, eventID, attachmentID int) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM Event_Attachment WHERE attachment_id = ? AND event_id = ?`, attachmentID, eventID)
+	if err != nil {
+		return fmt.Errorf("failed to delete attachment: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return ErrAttachmentNotFound
+	}
+	return nil
+}
+
+// HasEventTicket - User có vé hợp lệ (đã đặt / đã tham dự) của sự kiện không
+func (r *EventRepository) HasEventTicket(ctx context.Context, eventID, userID int) (bool, error) {
+	var ok bool
+	err := r.db.QueryRowContext(ctx, `
+		SELECT EXISTS (SELECT 1 FROM Ticket WHERE event_id = ? AND user_id = ? AND status IN ('BOOKED','CHECKED_IN','CHECKED_OUT'))
+	`, eventID, userID).Scan(&ok)
+	if err != nil {
+		return false, fmt.Errorf("failed to check event ticket: %w", err)
+	}
+	return ok, nil
+}
+
+// CheckEventExists - ErrEventNotFound nếu sự kiện không tồn tại
+func (r *EventRepository) CheckEventExists(ctx context.Context, eventID int) error {
+	var one int
+	err := r.db.QueryRowContext(ctx, `SELECT 1 FROM Event WHERE event_id = ?`, eventID).Scan(&one)
+	if err == sql.ErrNoRows {
+		return ErrEventNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to check event: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,27 @@
+package repository
+
+// ============================================================
+// Số chỗ còn lại theo sự kiện (GET /api/events/open?hasSeats=&weekend=&freeOnly=)
+// Đọc bộ đếm Category_Ticket_Inventory (migration 022) đã được cập nhật sẵn ở mỗi lượt
+// mua / hoàn / hết hạn vé, nên không phải COUNT(*) trên Ticket cho từng sự kiện.
+// Loại vé chưa có dòng bộ đếm (chưa ai mua từ khi tạo) mới đếm lại từ Ticket
+// ============================================================
+
+// categoryRemainingSQL - Số suất còn lại của một loại vé (alias ct, i = Category_Ticket_Inventory)
+const categoryRemainingSQL = `GREATEST(ct.max_quantity - COALESCE(i.sold,
+	(SELECT COUNT(*) FROM Ticket t
+	 WHERE t.category_ticket_id = ct.category_ticket_id
+	   AND t.status IN (` + soldTicketStatuses + `))), 0)`
+
+// openEventAvailabilityJoin - avail.remaining_seats / avail.max_price của các loại vé ACTIVE,
+// chỉ gom cho sự kiện OPEN; sự kiện không có loại vé ACTIVE thì avail là NULL
+const openEventAvailabilityJoin = `LEFT JOIN (
+		SELECT ct.event_id,
+		       SUM(` + categoryRemainingSQL + `) AS remaining_seats,
+		       MAX(COALESCE(ct.price, 0)) AS max_price
+		FROM Category_Ticket ct
+		JOIN Event oe ON oe.event_id = ct.event_id AND oe.status = 'OPEN'
+		LEFT JOIN Category_Ticket_Inventory i ON i.category_ticket_id = ct.category_ticket_id
+		WHERE ct.status = 'ACTIVE'
+		GROUP BY ct.event_id
+	) avail ON avail.event_id = e.event_id`
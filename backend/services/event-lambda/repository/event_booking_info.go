@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/fpt-event-services/services/event-lambda/models"
+)
+
+// ============================================================
+// GetEventBookingInfo - Trạng thái, thời gian và địa điểm của sự kiện
+// Trả về sql.ErrNoRows nếu không tồn tại
+// Nguồn duy nhất cho EventService.GetEventBookingInfo (xem event-lambda/client)
+// ============================================================
+func (r *EventRepository) GetEventBookingInfo(ctx context.Context, eventID int) (*models.EventBookingInfo, error) {
+	var info models.EventBookingInfo
+	var areaID sql.NullInt64
+	var areaName, venueName, venueLocation sql.NullString
+	var compQuota sql.NullInt64
+
+	err := r.db.QueryRowContext(ctx, `
+		SELECT e.event_id, e.title, e.status, e.start_time, e.end_time,
+		       e.area_id, va.area_name, v.venue_name, v.location, e.created_by, e.comp_ticket_quota,
+		       e.allow_guest_checkout, e.booking_queue, e.queue_admit_per_interval
+		FROM Event e
+		LEFT JOIN Venue_Area va ON e.area_id = va.area_id
+		LEFT JOIN Venue v ON va.venue_id = v.venue_id
+		WHERE e.event_id = ?
+	`, eventID).Scan(&info.EventID, &info.Title, &info.Status, &info.StartTime, &info.EndTime,
+		&areaID, &areaName, &venueName, &venueLocation, &info.CreatedBy, &compQuota,
+		&info.AllowGuestCheckout, &info.BookingQueue, &info.QueueAdmitPerInterval)
+	if err != nil {
+		return nil, err
+	}
+
+	if areaID.Valid {
+		id := int(areaID.Int64)
+		info.AreaID = &id
+	}
+	if areaName.Valid {
+		info.AreaName = &areaName.String
+	}
+	if venueName.Valid {
+		info.VenueName = &venueName.String
+	}
+	if venueLocation.Valid {
+		info.VenueLocation = &venueLocation.String
+	}
+	if compQuota.Valid {
+		quota := int(compQuota.Int64)
+		info.CompTicketQuota = &quota
+	}
+	return &info, nil
+}
@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// ============================================================
+// Event.booking_queue - Mở bán qua hàng chờ (migration 058, common/waitingroom)
+// ============================================================
+
+// GetBookingQueue - Sự kiện có bật hàng chờ không và số người được vào mỗi nhịp
+func (r *EventRepository) GetBookingQueue(ctx context.Context, eventID int) (bool, int, error) {
+	var enabled bool
+	var perInterval int
+	err := r.db.QueryRowContext(ctx,
+		`SELECT booking_queue, queue_admit_per_interval FROM Event WHERE event_id = ?`, eventID,
+	).Scan(&enabled, &perInterval)
+	if err == sql.ErrNoRows {
+		return false, 0, ErrEventNotFound
+	}
+	if err != nil {
+		return false, 0, fmt.Errorf("failed to load booking queue setting: %w", err)
+	}
+	return enabled, perInterval, nil
+}
+
+// SetBookingQueue - Lưu cấu hình hàng chờ
+func (r *EventRepository) SetBookingQueue(ctx context.Context, eventID int, enabled bool, perInterval int) error {
+	if _, err := r.db.ExecContext(ctx,
+		`UPDATE Event SET booking_queue = ?, queue_admit_per_interval = ? WHERE event_id = ?`,
+		enabled, perInterval, eventID,
+	); err != nil {
+		return fmt.Errorf("failed to save booking queue setting: %w", err)
+	}
+	return nil
+}
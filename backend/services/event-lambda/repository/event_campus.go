@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// ErrCampusSourceNotFound - yêu cầu / khu vực dùng để xác định campus không tồn tại
+var ErrCampusSourceNotFound = errors.New("event request or area not found")
+
+// ============================================================
+// GetEventRequestCampusID - Campus của yêu cầu sự kiện
+// Đã duyệt: campus của sự kiện; chưa duyệt: campus của người gửi (nil nếu chưa gắn)
+// ============================================================
+func (r *EventRepository) GetEventRequestCampusID(ctx context.Context, requestID int) (*int, error) {
+	var campusID sql.NullInt64
+	err := r.db.QueryRowContext(ctx, `
+		SELECT COALESCE(e.campus_id, u.campus_id)
+		FROM Event_Request er
+		LEFT JOIN Users u ON er.requester_id = u.user_id
+		LEFT JOIN Event e ON er.created_event_id = e.event_id
+		WHERE er.request_id = ?
+	`, requestID).Scan(&campusID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrCampusSourceNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load event request campus: %w", err)
+	}
+	return nullCampusID(campusID), nil
+}
+
+// GetEventCampusID - Campus của sự kiện (nil nếu chưa gắn)
+func (r *EventRepository) GetEventCampusID(ctx context.Context, eventID int) (*int, error) {
+	var campusID sql.NullInt64
+	err := r.db.QueryRowContext(ctx, `SELECT campus_id FROM Event WHERE event_id = ?`, eventID).Scan(&campusID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrEventNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load event campus: %w", err)
+	}
+	return nullCampusID(campusID), nil
+}
+
+func nullCampusID(v sql.NullInt64) *int {
+	if !v.Valid {
+		return nil
+	}
+	id := int(v.Int64)
+	return &id
+}
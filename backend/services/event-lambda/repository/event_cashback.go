@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/fpt-event-services/services/event-lambda/models"
+)
+
+// ============================================================
+// Event.cashback_percent / cashback_max_amount - Cashback khi check-out (migration 056)
+// staff-lambda hoàn tiền vào ví lúc check-out theo mức đang cấu hình;
+// Ticket_Cashback giữ mức và số tiền đã hoàn của từng vé
+// ============================================================
+
+// GetCashback - Cấu hình cashback của sự kiện và số cashback đã hoàn
+func (r *EventRepository) GetCashback(ctx context.Context, eventID int) (*models.CashbackSettings, error) {
+	s := models.CashbackSettings{EventID: eventID}
+	var maxAmount sql.NullFloat64
+	err := r.db.QueryRowContext(ctx, `
+		SELECT e.cashback_percent, e.cashback_max_amount,
+		       (SELECT COUNT(*) FROM Ticket_Cashback c WHERE c.event_id = e.event_id),
+		       (SELECT COALESCE(SUM(c.amount), 0) FROM Ticket_Cashback c WHERE c.event_id = e.event_id)
+		FROM Event e
+		WHERE e.event_id = ?
+	`, eventID).Scan(&s.Percent, &maxAmount, &s.Redeemed, &s.TotalPaid)
+	if err == sql.ErrNoRows {
+		return nil, ErrEventNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load cashback: %w", err)
+	}
+	if maxAmount.Valid {
+		s.MaxAmount = &maxAmount.Float64
+	}
+	return &s, nil
+}
+
+// SetCashback - Đặt mức cashback (maxAmount nil = không giới hạn)
+func (r *EventRepository) SetCashback(ctx context.Context, eventID, percent int, maxAmount *float64) error {
+	if _, err := r.db.ExecContext(ctx,
+		`UPDATE Event SET cashback_percent = ?, cashback_max_amount = ? WHERE event_id = ?`, percent, maxAmount, eventID,
+	); err != nil {
+		return fmt.Errorf("failed to save cashback: %w", err)
+	}
+	return nil
+}
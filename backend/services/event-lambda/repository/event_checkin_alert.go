@@ -0,0 +1,162 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/fpt-event-services/services/event-lambda/models"
+)
+
+// ============================================================
+// Event_Checkin_Alert - Cảnh báo khi số check-in chạm ngưỡng % sức chứa (migration 045)
+// Event.checkin_alert_thresholds: NULL = mặc định hệ thống, "" = tắt
+// Khóa chính (event_id, threshold_percent) là chốt "báo đúng một lần" giữa các instance
+// ============================================================
+
+// CheckinAlertSnapshot - Sức chứa, số check-in và cấu hình ngưỡng của sự kiện
+type CheckinAlertSnapshot struct {
+	Title      string
+	Capacity   int
+	CheckedIn  int
+	Thresholds sql.NullString
+}
+
+// CheckinAlertRecipient - Người nhận cảnh báo
+type CheckinAlertRecipient struct {
+	UserID   int
+	Email    string
+	FullName string
+}
+
+// GetCheckinAlertSnapshot - Vé đã check-in (kể cả đã check-out) so với max_seats
+func (r *EventRepository) GetCheckinAlertSnapshot(ctx context.Context, eventID int) (*CheckinAlertSnapshot, error) {
+	var s CheckinAlertSnapshot
+	err := r.db.QueryRowContext(ctx, `
+		SELECT e.title, COALESCE(e.max_seats, 0), e.checkin_alert_thresholds,
+		       (SELECT COUNT(*) FROM Ticket t WHERE t.event_id = e.event_id AND t.status IN ('CHECKED_IN', 'CHECKED_OUT'))
+		FROM Event e
+		WHERE e.event_id = ?
+	`, eventID).Scan(&s.Title, &s.Capacity, &s.Thresholds, &s.CheckedIn)
+	if err == sql.ErrNoRows {
+		return nil, ErrEventNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load check-in alert state: %w", err)
+	}
+	return &s, nil
+}
+
+// SetCheckinAlertThresholds - Lưu cấu hình ngưỡng (nil = quay về mặc định)
+func (r *EventRepository) SetCheckinAlertThresholds(ctx context.Context, eventID int, thresholds *string) error {
+	if _, err := r.db.ExecContext(ctx,
+		`UPDATE Event SET checkin_alert_thresholds = ? WHERE event_id = ?`, thresholds, eventID,
+	); err != nil {
+		return fmt.Errorf("failed to save check-in alert thresholds: %w", err)
+	}
+	return nil
+}
+
+// ListCheckinAlerts - Các ngưỡng đã báo của sự kiện (ngưỡng tăng dần)
+func (r *EventRepository) ListCheckinAlerts(ctx context.Context, eventID int) ([]models.CheckinAlert, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT threshold_percent, checked_in, capacity, recipients, fired_at
+		FROM Event_Checkin_Alert
+		WHERE event_id = ?
+		ORDER BY threshold_percent
+	`, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list check-in alerts: %w", err)
+	}
+	defer rows.Close()
+
+	alerts := []models.CheckinAlert{}
+	for rows.Next() {
+		var a models.CheckinAlert
+		if err := rows.Scan(&a.Threshold, &a.CheckedIn, &a.Capacity, &a.Recipients, &a.FiredAt); err != nil {
+			return nil, fmt.Errorf("failed to scan check-in alert: %w", err)
+		}
+		alerts = append(alerts, a)
+	}
+	return alerts, rows.Err()
+}
+
+// ClaimCheckinAlerts - Ghi nhận các ngưỡng vừa vượt; trả về những ngưỡng instance này giành được
+// (ngưỡng đã có dòng - instance khác đã báo - bị bỏ qua)
+func (r *EventRepository) ClaimCheckinAlerts(ctx context.Context, eventID int, thresholds []int, checkedIn, capacity int) ([]int, error) {
+	var claimed []int
+	for _, t := range thresholds {
+		result, err := r.db.ExecContext(ctx, `
+			INSERT IGNORE INTO Event_Checkin_Alert (event_id, threshold_percent, checked_in, capacity)
+			VALUES (?, ?, ?, ?)
+		`, eventID, t, checkedIn, capacity)
+		if err != nil {
+			return claimed, fmt.Errorf("failed to claim check-in alert %d%%: %w", t, err)
+		}
+		if n, _ := result.RowsAffected(); n == 1 {
+			claimed = append(claimed, t)
+		}
+	}
+	return claimed, nil
+}
+
+// SetCheckinAlertRecipients - Số người đã được báo cho ngưỡng
+func (r *EventRepository) SetCheckinAlertRecipients(ctx context.Context, eventID, threshold, recipients int) error {
+	if _, err := r.db.ExecContext(ctx,
+		`UPDATE Event_Checkin_Alert SET recipients = ? WHERE event_id = ? AND threshold_percent = ?`,
+		recipients, eventID, threshold,
+	); err != nil {
+		return fmt.Errorf("failed to update check-in alert recipients: %w", err)
+	}
+	return nil
+}
+
+// ListCheckinAlertRecipients - Chủ sự kiện, co-organizer đã nhận lời và STAFF đã duyệt yêu cầu tổ chức
+func (r *EventRepository) ListCheckinAlertRecipients(ctx context.Context, eventID int) ([]CheckinAlertRecipient, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT u.user_id, u.email, u.full_name
+		FROM Users u
+		WHERE u.status = 'ACTIVE' AND u.user_id IN (
+			SELECT created_by FROM Event WHERE event_id = ?
+			UNION
+			SELECT user_id FROM Event_Collaborator WHERE event_id = ? AND status = 'ACCEPTED'
+			UNION
+			SELECT processed_by FROM Event_Request WHERE created_event_id = ? AND processed_by IS NOT NULL
+		)
+		ORDER BY u.user_id
+	`, eventID, eventID, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list check-in alert recipients: %w", err)
+	}
+	defer rows.Close()
+
+	var recipients []CheckinAlertRecipient
+	for rows.Next() {
+		var rc CheckinAlertRecipient
+		if err := rows.Scan(&rc.UserID, &rc.Email, &rc.FullName); err != nil {
+			return nil, fmt.Errorf("failed to scan check-in alert recipient: %w", err)
+		}
+		recipients = append(recipients, rc)
+	}
+	return recipients, rows.Err()
+}
+
+// NotifyUsers - Thông báo trong app cho nhiều người cùng một nội dung
+func (r *EventRepository) NotifyUsers(ctx context.Context, userIDs []int, message string) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+	placeholders := make([]string, len(userIDs))
+	args := make([]any, 0, len(userIDs)*2)
+	for i, id := range userIDs {
+		placeholders[i] = "(?, ?)"
+		args = append(args, id, message)
+	}
+	if _, err := r.db.ExecContext(ctx,
+		`INSERT INTO Notification (user_id, message) VALUES `+strings.Join(placeholders, ", "), args...,
+	); err != nil {
+		return fmt.Errorf("failed to insert notifications: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,209 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/fpt-event-services/services/event-lambda/models"
+)
+
+// ============================================================
+// Event_Collaborator - Co-organizer và quyền trên từng sự kiện
+// Chủ sự kiện (created_by) luôn có mọi quyền
+// ============================================================
+
+// Lỗi nghiệp vụ khi quản lý co-organizer
+var (
+	ErrEventNotFound        = errors.New("event not found")
+	ErrCollaboratorNotFound = errors.New("collaborator not found")
+	ErrInviteeNotOrganizer  = errors.New("invitee must be an active ORGANIZER account")
+	ErrInviteeIsOwner       = errors.New("event owner cannot be invited as collaborator")
+	ErrNoPendingInvitation  = errors.New("no pending invitation for this event")
+	ErrNotEventOwner        = errors.New("only the event owner can manage collaborators")
+)
+
+// collaboratorPermissionSQL - Điều kiện "user là chủ hoặc co-organizer đã chấp nhận có quyền ?"
+// Dùng trong WHERE, tham số: userID, userID, permission
+const collaboratorPermissionSQL = `(e.created_by = ? OR EXISTS (
+	SELECT 1 FROM Event_Collaborator ec
+	WHERE ec.event_id = e.event_id AND ec.user_id = ? AND ec.status = 'ACCEPTED'
+	  AND FIND_IN_SET(?, ec.permissions) > 0))`
+
+// CheckEventOwnership - User có phải người tạo sự kiện không
+func (r *EventRepository) CheckEventOwnership(ctx context.Context, eventID, userID int) (bool, error) {
+	var ownerID sql.NullInt64
+	err := r.db.QueryRowContext(ctx, `SELECT created_by FROM Event WHERE event_id = ?`, eventID).Scan(&ownerID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to check event ownership: %w", err)
+	}
+	return ownerID.Valid && int(ownerID.Int64) == userID, nil
+}
+
+// CheckEventPermission - User là chủ sự kiện hoặc co-organizer có quyền permission
+func (r *EventRepository) CheckEventPermission(ctx context.Context, eventID, userID int, permission string) (bool, error) {
+	return checkEventPermission(ctx, r.db, eventID, userID, permission)
+}
+
+// queryRower - *sql.DB hoặc *sql.Tx
+type queryRower interface {
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+func checkEventPermission(ctx context.Context, q queryRower, eventID, userID int, permission string) (bool, error) {
+	var ok bool
+	err := q.QueryRowContext(ctx, `
+		SELECT EXISTS (SELECT 1 FROM Event e WHERE e.event_id = ? AND `+collaboratorPermissionSQL+`)
+	`, eventID, userID, userID, permission).Scan(&ok)
+	if err != nil {
+		return false, fmt.Errorf("failed to check event permission: %w", err)
+	}
+	return ok, nil
+}
+
+// ListCollaborators - Danh sách co-organizer (kể cả lời mời đang chờ)
+func (r *EventRepository) ListCollaborators(ctx context.Context, eventID int) ([]models.EventCollaborator, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT ec.event_id, ec.user_id, u.full_name, u.email, ec.permissions, ec.status,
+		       ec.invited_by, ec.invited_at, ec.accepted_at
+		FROM Event_Collaborator ec
+		JOIN Users u ON u.user_id = ec.user_id
+		WHERE ec.event_id = ?
+		ORDER BY ec.invited_at
+	`, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list collaborators: %w", err)
+	}
+	defer rows.Close()
+
+	collaborators := []models.EventCollaborator{}
+	for rows.Next() {
+		var c models.EventCollaborator
+		var permissions string
+		var acceptedAt sql.NullTime
+		if err := rows.Scan(&c.EventID, &c.UserID, &c.FullName, &c.Email, &permissions, &c.Status,
+			&c.InvitedBy, &c.InvitedAt, &acceptedAt); err != nil {
+			return nil, err
+		}
+		c.Permissions = splitPermissions(permissions)
+		if acceptedAt.Valid {
+			c.AcceptedAt = &acceptedAt.Time
+		}
+		collaborators = append(collaborators, c)
+	}
+	return collaborators, rows.Err()
+}
+
+// InviteCollaborator - Mời (hoặc cập nhật quyền) co-organizer theo email
+// Lời mời mới ở trạng thái PENDING; co-organizer đã chấp nhận giữ nguyên ACCEPTED
+func (r *EventRepository) InviteCollaborator(ctx context.Context, eventID, invitedBy int, email string, permissions []string) (*models.EventCollaborator, error) {
+	var ownerID sql.NullInt64
+	err := r.db.QueryRowContext(ctx, `SELECT created_by FROM Event WHERE event_id = ?`, eventID).Scan(&ownerID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrEventNotFound
+		}
+		return nil, fmt.Errorf("failed to load event: %w", err)
+	}
+
+	var userID int
+	err = r.db.QueryRowContext(ctx, `
+		SELECT user_id FROM Users WHERE email = ? AND role = 'ORGANIZER' AND status = 'ACTIVE'
+	`, strings.TrimSpace(email)).Scan(&userID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrInviteeNotOrganizer
+		}
+		return nil, fmt.Errorf("failed to find invitee: %w", err)
+	}
+	if ownerID.Valid && int(ownerID.Int64) == userID {
+		return nil, ErrInviteeIsOwner
+	}
+
+	if _, err := r.db.ExecContext(ctx, `
+		INSERT INTO Event_Collaborator (event_id, user_id, permissions, status, invited_by)
+		VALUES (?, ?, ?, 'PENDING', ?)
+		ON DUPLICATE KEY UPDATE permissions = VALUES(permissions)
+	`, eventID, userID, strings.Join(permissions, ","), invitedBy); err != nil {
+		return nil, fmt.Errorf("failed to save collaborator: %w", err)
+	}
+
+	collaborators, err := r.ListCollaborators(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range collaborators {
+		if collaborators[i].UserID == userID {
+			return &collaborators[i], nil
+		}
+	}
+	return nil, ErrCollaboratorNotFound
+}
+
+// AcceptCollaboration - Người được mời chấp nhận lời mời
+func (r *EventRepository) AcceptCollaboration(ctx context.Context, eventID, userID int) error {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE Event_Collaborator SET status = 'ACCEPTED', accepted_at = NOW(6)
+		WHERE event_id = ? AND user_id = ? AND status = 'PENDING'
+	`, eventID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to accept invitation: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return ErrNoPendingInvitation
+	}
+	return nil
+}
+
+// RemoveCollaborator - Thu hồi lời mời / gỡ co-organizer (hoặc co-organizer tự rời)
+func (r *EventRepository) RemoveCollaborator(ctx context.Context, eventID, userID int) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM Event_Collaborator WHERE event_id = ? AND user_id = ?`, eventID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to remove collaborator: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return ErrCollaboratorNotFound
+	}
+	return nil
+}
+
+// GetCollaborationInvitations - Lời mời đang chờ của một organizer
+func (r *EventRepository) GetCollaborationInvitations(ctx context.Context, userID int) ([]models.EventCollaboratorInvitation, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT ec.event_id, e.title, e.start_time, ec.permissions, ec.invited_by, inviter.full_name, ec.invited_at
+		FROM Event_Collaborator ec
+		JOIN Event e ON e.event_id = ec.event_id
+		JOIN Users inviter ON inviter.user_id = ec.invited_by
+		WHERE ec.user_id = ? AND ec.status = 'PENDING'
+		ORDER BY ec.invited_at DESC
+	`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list invitations: %w", err)
+	}
+	defer rows.Close()
+
+	invitations := []models.EventCollaboratorInvitation{}
+	for rows.Next() {
+		var inv models.EventCollaboratorInvitation
+		var permissions string
+		if err := rows.Scan(&inv.EventID, &inv.EventTitle, &inv.StartTime, &permissions,
+			&inv.InvitedBy, &inv.InvitedByName, &inv.InvitedAt); err != nil {
+			return nil, err
+		}
+		inv.Permissions = splitPermissions(permissions)
+		invitations = append(invitations, inv)
+	}
+	return invitations, rows.Err()
+}
+
+func splitPermissions(s string) []string {
+	if s == "" {
+		return []string{}
+	}
+	return strings.Split(s, ",")
+}
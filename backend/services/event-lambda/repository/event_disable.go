@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/fpt-event-services/common/tickethistory"
+)
+
+// ============================================================
+// DisableEvent - Admin vô hiệu hóa sự kiện (KHỚP VỚI Java EventDisableController)
+// Trong một transaction:
+//  1. Khóa Event, chỉ OPEN / UPDATING được vô hiệu hóa
+//  2. Vé PENDING đang giữ chỗ → EXPIRED, trả lại suất trong Category_Ticket_Inventory
+//  3. Event.status = DISABLED: mọi luồng mua vé (status != OPEN) đều từ chối
+// Vé đã BOOKED giữ nguyên, hoàn tiền đi theo luồng report / hủy sự kiện
+// ============================================================
+
+// Lỗi nghiệp vụ khi vô hiệu hóa sự kiện
+var (
+	ErrEventAlreadyDisabled = errors.New("event is already disabled")
+	ErrEventNotDisableable  = errors.New("only OPEN or UPDATING events can be disabled")
+)
+
+// checkDisableTransition - Trạng thái hiện tại có chuyển sang DISABLED được không
+func checkDisableTransition(status string) error {
+	switch status {
+	case "OPEN", "UPDATING":
+		return nil
+	case "DISABLED":
+		return ErrEventAlreadyDisabled
+	default:
+		return ErrEventNotDisableable
+	}
+}
+
+// DisableEvent - Trả về số vé PENDING đã giải phóng
+func (r *EventRepository) DisableEvent(ctx context.Context, eventID, adminID int) (int64, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var status string
+	err = tx.QueryRowContext(ctx, `SELECT status FROM Event WHERE event_id = ? FOR UPDATE`, eventID).Scan(&status)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, ErrEventNotFound
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to lock event: %w", err)
+	}
+	if err := checkDisableTransition(status); err != nil {
+		return 0, err
+	}
+
+	// Trả lại suất của vé PENDING trước khi đổi trạng thái (giống event-archival)
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE Category_Ticket_Inventory i
+		JOIN (SELECT category_ticket_id, COUNT(*) AS n FROM Ticket
+		      WHERE event_id = ? AND status = 'PENDING' GROUP BY category_ticket_id) p
+		  ON p.category_ticket_id = i.category_ticket_id
+		SET i.sold = GREATEST(i.sold - p.n, 0)
+	`, eventID); err != nil {
+		return 0, fmt.Errorf("failed to release pending inventory: %w", err)
+	}
+	released, err := tickethistory.TransitionWhere(ctx, tx, tickethistory.StatusPending, tickethistory.StatusExpired, "t.event_id = ?", eventID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to expire pending tickets: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE Event SET status = 'DISABLED', disabled_at = NOW(), disabled_by = ?, version = version + 1
+		WHERE event_id = ?
+	`, adminID, eventID); err != nil {
+		return 0, fmt.Errorf("failed to disable event: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return released, nil
+}
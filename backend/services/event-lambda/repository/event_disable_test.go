@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCheckDisableTransition(t *testing.T) {
+	cases := map[string]error{
+		"OPEN":      nil,
+		"UPDATING":  nil,
+		"DISABLED":  ErrEventAlreadyDisabled,
+		"CLOSED":    ErrEventNotDisableable,
+		"CANCELLED": ErrEventNotDisableable,
+	}
+	for status, want := range cases {
+		if got := checkDisableTransition(status); !errors.Is(got, want) {
+			t.Errorf("checkDisableTransition(%q) = %v, want %v", status, got, want)
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// ============================================================
+// Event.dynamic_qr - Check-in chỉ nhận mã QR xoay vòng (migration 057, common/qrtoken)
+// ============================================================
+
+// GetDynamicQR - Sự kiện có bật QR động không
+func (r *EventRepository) GetDynamicQR(ctx context.Context, eventID int) (bool, error) {
+	var enabled bool
+	err := r.db.QueryRowContext(ctx, `SELECT dynamic_qr FROM Event WHERE event_id = ?`, eventID).Scan(&enabled)
+	if err == sql.ErrNoRows {
+		return false, ErrEventNotFound
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to load dynamic QR setting: %w", err)
+	}
+	return enabled, nil
+}
+
+// SetDynamicQR - Bật / tắt QR động
+func (r *EventRepository) SetDynamicQR(ctx context.Context, eventID int, enabled bool) error {
+	if _, err := r.db.ExecContext(ctx,
+		`UPDATE Event SET dynamic_qr = ? WHERE event_id = ?`, enabled, eventID,
+	); err != nil {
+		return fmt.Errorf("failed to save dynamic QR setting: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/fpt-event-services/services/event-lambda/models"
+)
+
+func TestEditEligibility(t *testing.T) {
+	start := time.Date(2026, 5, 10, 9, 0, 0, 0, time.UTC)
+	window := 24 * time.Hour
+
+	// Trước mốc khóa: sửa gì cũng được
+	if e := editEligibility("OPEN", start, window, start.Add(-48*time.Hour), []string{"title", "tickets"}); e != nil {
+		t.Fatalf("before cutoff: got %+v, want nil", e)
+	}
+
+	// Sau mốc khóa: chỉ đổi banner thì vẫn được
+	afterCutoff := start.Add(-2 * time.Hour)
+	if e := editEligibility("OPEN", start, window, afterCutoff, []string{"bannerUrl"}); e != nil {
+		t.Fatalf("banner after cutoff: got %+v, want nil", e)
+	}
+
+	// Sau mốc khóa: các trường khác bị khóa, kèm chi tiết
+	e := editEligibility("OPEN", start, window, afterCutoff, []string{"bannerUrl", "tickets", "title"})
+	if e == nil || e.Code != models.EligibilityEventEditLocked {
+		t.Fatalf("locked: got %+v, want %s", e, models.EligibilityEventEditLocked)
+	}
+	if !reflect.DeepEqual(e.LockedFields, []string{"tickets", "title"}) {
+		t.Errorf("lockedFields = %v", e.LockedFields)
+	}
+	if e.CutoffAt == nil || !e.CutoffAt.Equal(start.Add(-window)) {
+		t.Errorf("cutoffAt = %v", e.CutoffAt)
+	}
+
+	// Sự kiện đã đóng: không sửa được kể cả banner
+	if e := editEligibility("closed", start, window, start.Add(-48*time.Hour), []string{"bannerUrl"}); e == nil || e.Code != models.EligibilityEventClosed {
+		t.Fatalf("closed: got %+v, want %s", e, models.EligibilityEventClosed)
+	}
+}
@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/fpt-event-services/services/event-lambda/models"
+)
+
+// ============================================================
+// Nội dung trang sự kiện: lịch trình (Event.schedule_blocks) và
+// FAQ (Event.faq_entries) lưu dạng JSON; usecase đã sanitize / validate
+// trước khi tới đây. Mô tả rich-text vẫn là cột Event.description
+// ============================================================
+
+// decodePageContent - JSON cột → slice (NULL → mảng rỗng để frontend không phải kiểm tra null)
+func decodePageContent(schedule, faq sql.NullString) ([]models.ScheduleBlock, []models.FAQEntry, error) {
+	blocks := []models.ScheduleBlock{}
+	entries := []models.FAQEntry{}
+	if schedule.Valid && schedule.String != "" {
+		if err := json.Unmarshal([]byte(schedule.String), &blocks); err != nil {
+			return nil, nil, fmt.Errorf("failed to decode schedule blocks: %w", err)
+		}
+	}
+	if faq.Valid && faq.String != "" {
+		if err := json.Unmarshal([]byte(faq.String), &entries); err != nil {
+			return nil, nil, fmt.Errorf("failed to decode faq entries: %w", err)
+		}
+	}
+	return blocks, entries, nil
+}
+
+// updatePageContentTx - Ghi mô tả / lịch trình / FAQ trong transaction của update-details
+func updatePageContentTx(ctx context.Context, tx *sql.Tx, req *models.UpdateEventDetailsRequest) error {
+	if req.Description != nil {
+		if _, err := tx.ExecContext(ctx, `UPDATE Event SET description = ? WHERE event_id = ?`, *req.Description, req.EventID); err != nil {
+			return fmt.Errorf("failed to update description: %w", err)
+		}
+	}
+	if req.Schedule != nil {
+		if err := setPageContentColumnTx(ctx, tx, "schedule_blocks", *req.Schedule, len(*req.Schedule), req.EventID); err != nil {
+			return err
+		}
+	}
+	if req.FAQ != nil {
+		if err := setPageContentColumnTx(ctx, tx, "faq_entries", *req.FAQ, len(*req.FAQ), req.EventID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setPageContentColumnTx - Mảng rỗng lưu NULL
+func setPageContentColumnTx(ctx context.Context, tx *sql.Tx, column string, value interface{}, count, eventID int) error {
+	var payload interface{}
+	if count > 0 {
+		b, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Errorf("failed to encode %s: %w", column, err)
+		}
+		payload = string(b)
+	}
+	if _, err := tx.ExecContext(ctx, "UPDATE Event SET "+column+" = ? WHERE event_id = ?", payload, eventID); err != nil {
+		return fmt.Errorf("failed to update %s: %w", column, err)
+	}
+	return nil
+}
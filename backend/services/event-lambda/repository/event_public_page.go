@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/fpt-event-services/common/slug"
+)
+
+// ============================================================
+// Slug và trang public của sự kiện (GET /api/public/events/{slug})
+// Slug gán một lần khi tạo sự kiện, không đổi khi sửa tiêu đề để link đã chia sẻ vẫn dùng được
+// ============================================================
+
+type slugExecer interface {
+	queryRower
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+// assignEventSlug - Gán slug từ tiêu đề; slug đã có sự kiện khác dùng thì thêm -{event_id}
+func assignEventSlug(ctx context.Context, exec slugExecer, eventID int64, title string) (string, error) {
+	s := slug.Make(title)
+	var taken int
+	err := exec.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM Event WHERE slug = ? AND event_id <> ?`, s, eventID,
+	).Scan(&taken)
+	if err != nil {
+		return "", fmt.Errorf("failed to check event slug: %w", err)
+	}
+	if taken > 0 {
+		s = slug.WithID(s, eventID)
+	}
+	if _, err := exec.ExecContext(ctx, `UPDATE Event SET slug = ?, version = version + 1 WHERE event_id = ?`, s, eventID); err != nil {
+		return "", fmt.Errorf("failed to set event slug: %w", err)
+	}
+	return s, nil
+}
+
+// BackfillEventSlugs - Gán slug cho các sự kiện tạo trước migration 024
+func (r *EventRepository) BackfillEventSlugs(ctx context.Context) (int, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT event_id, title FROM Event WHERE slug IS NULL ORDER BY event_id`)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list events without slug: %w", err)
+	}
+	type pending struct {
+		id    int64
+		title string
+	}
+	var todo []pending
+	for rows.Next() {
+		var p pending
+		if err := rows.Scan(&p.id, &p.title); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		todo = append(todo, p)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	for i, p := range todo {
+		if _, err := assignEventSlug(ctx, r.db, p.id, p.title); err != nil {
+			return i, err
+		}
+	}
+	return len(todo), nil
+}
+
+// GetEventIDBySlug - ID và trạng thái của sự kiện theo slug (0 nếu không có)
+func (r *EventRepository) GetEventIDBySlug(ctx context.Context, eventSlug string) (int, string, error) {
+	var id int
+	var status string
+	err := r.db.QueryRowContext(ctx, `SELECT event_id, status FROM Event WHERE slug = ?`, eventSlug).Scan(&id, &status)
+	if err == sql.ErrNoRows {
+		return 0, "", nil
+	}
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to find event by slug: %w", err)
+	}
+	return id, status, nil
+}
+
+// EventTicketsRemaining - Tổng suất và số suất còn lại của các loại vé ACTIVE
+// Đọc bộ đếm Category_Ticket_Inventory, loại vé chưa có bộ đếm thì đếm từ Ticket
+func (r *EventRepository) EventTicketsRemaining(ctx context.Context, eventID int) (remaining, total int, err error) {
+	err = r.db.QueryRowContext(ctx, `
+		SELECT COALESCE(SUM(ct.max_quantity), 0),
+		       COALESCE(SUM(`+categoryRemainingSQL+`), 0)
+		FROM Category_Ticket ct
+		LEFT JOIN Category_Ticket_Inventory i ON i.category_ticket_id = ct.category_ticket_id
+		WHERE ct.event_id = ? AND ct.status = 'ACTIVE'
+	`, eventID).Scan(&total, &remaining)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to count remaining tickets: %w", err)
+	}
+	return remaining, total, nil
+}
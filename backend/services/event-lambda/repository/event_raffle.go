@@ -0,0 +1,162 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/fpt-event-services/services/event-lambda/models"
+)
+
+// ============================================================
+// Event_Raffle / Event_Raffle_Winner
+// Bốc thăm trúng thưởng trong số vé đã check-in (CHECKED_IN)
+// ============================================================
+
+// ListRaffleCandidates - Vé CHECKED_IN đủ điều kiện, sắp theo ticket_id (thứ tự dùng để kiểm chứng)
+//   - excludeStaff: bỏ STAFF/ADMIN, chủ sự kiện và co-organizer
+//   - excludeComplimentary: bỏ vé mời
+//   - excludePreviousWinners: bỏ người đã trúng ở lần bốc trước của sự kiện
+func (r *EventRepository) ListRaffleCandidates(ctx context.Context, eventID int, excludeStaff, excludeComplimentary, excludePreviousWinners bool) ([]models.RaffleCandidate, error) {
+	var where strings.Builder
+	if excludeComplimentary {
+		where.WriteString(` AND t.is_complimentary = 0`)
+	}
+	if excludeStaff {
+		where.WriteString(` AND u.role NOT IN ('STAFF', 'ADMIN') AND t.user_id <> e.created_by
+			AND NOT EXISTS (SELECT 1 FROM Event_Collaborator ec
+				WHERE ec.event_id = e.event_id AND ec.user_id = t.user_id AND ec.status = 'ACCEPTED')`)
+	}
+	if excludePreviousWinners {
+		where.WriteString(` AND NOT EXISTS (SELECT 1 FROM Event_Raffle_Winner w
+				JOIN Event_Raffle er ON er.raffle_id = w.raffle_id
+				WHERE er.event_id = e.event_id AND w.user_id = t.user_id)`)
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT t.ticket_id, t.user_id, u.full_name, u.email
+		FROM Ticket t
+		JOIN Users u ON u.user_id = t.user_id
+		JOIN Event e ON e.event_id = t.event_id
+		WHERE t.event_id = ? AND t.status = 'CHECKED_IN'`+where.String()+`
+		ORDER BY t.ticket_id
+	`, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list raffle candidates: %w", err)
+	}
+	defer rows.Close()
+
+	candidates := []models.RaffleCandidate{}
+	for rows.Next() {
+		var c models.RaffleCandidate
+		if err := rows.Scan(&c.TicketID, &c.UserID, &c.FullName, &c.Email); err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, c)
+	}
+	return candidates, rows.Err()
+}
+
+// CreateRaffleDraw - Lưu lần bốc thăm, người trúng và thông báo trong app cho người trúng
+func (r *EventRepository) CreateRaffleDraw(ctx context.Context, draw *models.RaffleDraw, notification string) (int, error) {
+	candidateIDs, err := json.Marshal(draw.CandidateTicketIDs)
+	if err != nil {
+		return 0, err
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+		INSERT INTO Event_Raffle (event_id, prize, winner_count, seed, candidate_count, candidates_hash, candidate_ticket_ids,
+			exclude_staff, exclude_complimentary, exclude_previous_winners, drawn_by)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, draw.EventID, draw.Prize, draw.WinnerCount, draw.Seed, draw.CandidateCount, draw.CandidatesHash, string(candidateIDs),
+		draw.ExcludeStaff, draw.ExcludeComplimentary, draw.ExcludePreviousWinners, draw.DrawnBy)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create raffle: %w", err)
+	}
+	raffleID, err := result.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get raffle id: %w", err)
+	}
+
+	for _, w := range draw.Winners {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO Event_Raffle_Winner (raffle_id, position, ticket_id, user_id) VALUES (?, ?, ?, ?)
+		`, raffleID, w.Position, w.TicketID, w.UserID); err != nil {
+			return 0, fmt.Errorf("failed to save raffle winner: %w", err)
+		}
+		if _, err := tx.ExecContext(ctx, `INSERT INTO Notification (user_id, message) VALUES (?, ?)`, w.UserID, notification); err != nil {
+			return 0, fmt.Errorf("failed to notify raffle winner: %w", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return int(raffleID), nil
+}
+
+// ListRaffleDraws - Các lần bốc thăm của sự kiện (mới nhất trước) kèm người trúng
+func (r *EventRepository) ListRaffleDraws(ctx context.Context, eventID int) ([]models.RaffleDraw, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT raffle_id, prize, winner_count, seed, candidate_count, candidates_hash, candidate_ticket_ids,
+			exclude_staff, exclude_complimentary, exclude_previous_winners, drawn_by, drawn_at
+		FROM Event_Raffle WHERE event_id = ?
+		ORDER BY drawn_at DESC, raffle_id DESC
+	`, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list raffles: %w", err)
+	}
+	defer rows.Close()
+
+	draws := []models.RaffleDraw{}
+	index := map[int]int{}
+	for rows.Next() {
+		d := models.RaffleDraw{EventID: eventID, Algorithm: models.RaffleAlgorithm, Winners: []models.RaffleWinner{}}
+		var candidateIDs string
+		var drawnAt time.Time
+		if err := rows.Scan(&d.RaffleID, &d.Prize, &d.WinnerCount, &d.Seed, &d.CandidateCount, &d.CandidatesHash, &candidateIDs,
+			&d.ExcludeStaff, &d.ExcludeComplimentary, &d.ExcludePreviousWinners, &d.DrawnBy, &drawnAt); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(candidateIDs), &d.CandidateTicketIDs); err != nil {
+			return nil, fmt.Errorf("failed to parse candidates of raffle %d: %w", d.RaffleID, err)
+		}
+		d.DrawnAt = drawnAt.Format(time.RFC3339)
+		index[d.RaffleID] = len(draws)
+		draws = append(draws, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	winners, err := r.db.QueryContext(ctx, `
+		SELECT w.raffle_id, w.position, w.ticket_id, w.user_id, u.full_name
+		FROM Event_Raffle_Winner w
+		JOIN Event_Raffle er ON er.raffle_id = w.raffle_id
+		JOIN Users u ON u.user_id = w.user_id
+		WHERE er.event_id = ?
+		ORDER BY w.raffle_id, w.position
+	`, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list raffle winners: %w", err)
+	}
+	defer winners.Close()
+	for winners.Next() {
+		var raffleID int
+		var w models.RaffleWinner
+		if err := winners.Scan(&raffleID, &w.Position, &w.TicketID, &w.UserID, &w.FullName); err != nil {
+			return nil, err
+		}
+		if i, ok := index[raffleID]; ok {
+			draws[i].Winners = append(draws[i].Winners, w)
+		}
+	}
+	return draws, winners.Err()
+}
@@ -3,15 +3,20 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"log"
 	"math"
-	"sort"
-	"strconv"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/fpt-event-services/common/amenity"
+	"github.com/fpt-event-services/common/capability"
+	"github.com/fpt-event-services/common/crypto"
 	"github.com/fpt-event-services/common/db"
+	"github.com/fpt-event-services/common/dbscan"
+	"github.com/fpt-event-services/common/rules"
 	"github.com/fpt-event-services/services/event-lambda/models"
 )
 
@@ -44,6 +49,9 @@ func NewEventRepository() *EventRepository {
 	}
 }
 
+// DefaultEventRepository - Repository dùng chung của process
+var DefaultEventRepository = sync.OnceValue(NewEventRepository)
+
 // NOTE: This file contains the core UpdateEventRequest function with seat allocation fixes.
 // All other repository methods have been moved to separate files or stubbed.
 // Core Fix: Seats are now properly allocated with VIP-first priority and sequential assignment
@@ -186,6 +194,12 @@ func (r *EventRepository) UpdateEventRequest(ctx context.Context, organizerID in
 		fmt.Printf("[UpdateEventRequest] Speaker data: fullName=%s, bio=%s, email=%s, phone=%s, avatarUrl=%s\n",
 			speakerFullName, speakerBio, speakerEmail, speakerPhone, speakerAvatarUrl)
 
+		// Email / số điện thoại speaker lưu dạng mã hóa (PII)
+		storedEmail, storedPhone, err := encryptSpeakerContact(speakerEmail, speakerPhone)
+		if err != nil {
+			return err
+		}
+
 		// Get current speaker_id for this event (if exists)
 		checkSpeakerQuery := `SELECT speaker_id FROM Event WHERE event_id = ?`
 		err = tx.QueryRowContext(ctx, checkSpeakerQuery, eventID).Scan(&speakerID)
@@ -210,7 +224,7 @@ func (r *EventRepository) UpdateEventRequest(ctx context.Context, organizerID in
 					speakerFullName, speakerBio, speakerEmail, speakerPhone, speakerAvatarUrl)
 				// ✅ STRONG LOG: Confirm SQL execution
 				log.Printf("[SQL_EXECUTE] Dang thuc hien INSERT Speaker cho Event: %d (fullName=%s)", eventID, speakerFullName)
-				result, err := tx.ExecContext(ctx, insertSpeakerQuery, speakerFullName, speakerBio, speakerEmail, speakerPhone, speakerAvatarUrl)
+				result, err := tx.ExecContext(ctx, insertSpeakerQuery, speakerFullName, speakerBio, storedEmail, storedPhone, speakerAvatarUrl)
 				if err != nil {
 					return fmt.Errorf("failed to insert speaker: %w", err)
 				}
@@ -236,7 +250,7 @@ func (r *EventRepository) UpdateEventRequest(ctx context.Context, organizerID in
 					speakerID.Int64, speakerFullName, speakerBio, speakerEmail, speakerPhone, speakerAvatarUrl)
 				// ✅ STRONG LOG: Confirm SQL execution
 				log.Printf("[SQL_EXECUTE] Dang thuc hien UPDATE Speaker ID=%d cho Event: %d (fullName=%s)", speakerID.Int64, eventID, speakerFullName)
-				result, err := tx.ExecContext(ctx, updateSpeakerQuery, speakerFullName, speakerBio, speakerEmail, speakerPhone, speakerAvatarUrl, speakerID.Int64)
+				result, err := tx.ExecContext(ctx, updateSpeakerQuery, speakerFullName, speakerBio, storedEmail, storedPhone, speakerAvatarUrl, speakerID.Int64)
 				if err != nil {
 					return fmt.Errorf("failed to update speaker: %w", err)
 				}
@@ -259,7 +273,7 @@ func (r *EventRepository) UpdateEventRequest(ctx context.Context, organizerID in
 		} else {
 			fmt.Printf("[DEBUG] Final Speaker ID to be saved in Event: NULL (no speaker)\n")
 		}
-		eventUpdateQuery := `UPDATE Event SET banner_url = ?, speaker_id = ?, status = ? WHERE event_id = ?`
+		eventUpdateQuery := `UPDATE Event SET banner_url = ?, speaker_id = ?, status = ?, version = version + 1 WHERE event_id = ?`
 		result, err := tx.ExecContext(ctx, eventUpdateQuery, req.BannerUrl, speakerID, newStatus, eventID)
 		if err != nil {
 			return fmt.Errorf("failed to update event: %w", err)
@@ -285,13 +299,7 @@ func (r *EventRepository) UpdateEventRequest(ctx context.Context, organizerID in
 			fmt.Printf("[UpdateEventRequest] Deleted %d old category_ticket entries\n", rowsDeleted)
 
 			// Insert new tickets
-			type TicketInfo struct {
-				CategoryTicketID int64
-				Name             string
-				Price            float64
-				MaxQuantity      int
-			}
-			var ticketAllocations []TicketInfo
+			var ticketAllocations []SeatCategory
 
 			// ✅ DIAGNOSTIC: Log entering tickets loop
 			log.Printf("[DIAGNOSTIC] Bat dau vong lap xu ly %d tickets", len(req.Tickets))
@@ -329,271 +337,21 @@ func (r *EventRepository) UpdateEventRequest(ctx context.Context, organizerID in
 				ticketID, _ := result.LastInsertId()
 				fmt.Printf("[UpdateEventRequest] Inserted ticket: %s (ID=%d, qty=%d)\n", name, ticketID, maxQty)
 
-				ticketAllocations = append(ticketAllocations, TicketInfo{
+				ticketAllocations = append(ticketAllocations, SeatCategory{
 					CategoryTicketID: ticketID,
 					Name:             name,
 					Price:            price,
-					MaxQuantity:      maxQty,
+					Quantity:         maxQty,
 				})
 			}
 
 			// ✅ DIAGNOSTIC: Log after tickets loop completes
 			log.Printf("[DIAGNOSTIC] Hoan thanh vong lap tickets. Tong so ticket da insert: %d", len(ticketAllocations))
 
-			// VIP-FIRST SORTING LOGIC
-			sort.Slice(ticketAllocations, func(i, j int) bool {
-				iIsVIP := strings.Contains(strings.ToUpper(ticketAllocations[i].Name), "VIP")
-				jIsVIP := strings.Contains(strings.ToUpper(ticketAllocations[j].Name), "VIP")
-
-				if iIsVIP != jIsVIP {
-					return iIsVIP
-				}
-				return ticketAllocations[i].Price > ticketAllocations[j].Price
-			})
-
-			// SEAT ALLOCATION
+			// SEAT ALLOCATION (SeatAllocator: tạo đủ ghế theo capacity, phân bổ theo SEAT_ALLOCATION_STRATEGY)
 			if areaID > 0 && len(ticketAllocations) > 0 {
-				fmt.Printf("[UpdateEventRequest] Beginning seat allocation for %d ticket types\n", len(ticketAllocations))
-
-				// Insert a2 if needed
-				insertA2Query := `INSERT IGNORE INTO Seat (area_id, seat_code, row_no, col_no, status) VALUES (?, 'A2', 'A', 2, 'ACTIVE')`
-				tx.ExecContext(ctx, insertA2Query, areaID)
-
-				// Reset seats
-				resetSeatsQuery := `UPDATE Seat SET category_ticket_id = NULL WHERE area_id = ?`
-				result, err := tx.ExecContext(ctx, resetSeatsQuery, areaID)
-				if err != nil {
-					return fmt.Errorf("failed to reset seats: %w", err)
-				}
-				rowsReset, _ := result.RowsAffected()
-				fmt.Printf("[UpdateEventRequest] Reset %d seats for allocation\n", rowsReset)
-
-				// Get all seats
-				getSeatIDsQuery := `
-					SELECT seat_id, seat_code, row_no, col_no
-					FROM Seat 
-					WHERE area_id = ?
-					ORDER BY row_no ASC, CAST(SUBSTRING(seat_code, 2) AS UNSIGNED) ASC, seat_code ASC
-				`
-				rows, err := tx.QueryContext(ctx, getSeatIDsQuery, areaID)
-				if err != nil {
-					return fmt.Errorf("failed to query seats: %w", err)
-				}
-
-				var seatIDs []int64
-				var seatCodes []string
-				for rows.Next() {
-					var seatID int64
-					var seatCode, rowNo, colNo string
-					if err := rows.Scan(&seatID, &seatCode, &rowNo, &colNo); err != nil {
-						rows.Close()
-						return fmt.Errorf("failed to scan seat: %w", err)
-					}
-					seatIDs = append(seatIDs, seatID)
-					seatCodes = append(seatCodes, seatCode)
-				}
-				rows.Close()
-
-				// If seats found are less than the area's capacity, initialize missing seats
-				if areaCapacity > 0 && len(seatIDs) < areaCapacity {
-					fmt.Printf("[UpdateEventRequest] Found %d seats, less than capacity %d - initializing missing seats\n", len(seatIDs), areaCapacity)
-
-					insertSeatsQuery := `INSERT IGNORE INTO Seat (area_id, seat_code, row_no, col_no, status) VALUES `
-					var values []string
-					var params []interface{}
-
-					created := 0
-					// Dynamic seat generation: compute rows needed using seatsPerRow
-					seatsPerRow := 10
-					if seatsPerRow <= 0 {
-						seatsPerRow = 10
-					}
-					rowsNeeded := (areaCapacity + seatsPerRow - 1) / seatsPerRow
-
-					// Generate rows until we reach exactly areaCapacity seats
-					for row := 0; created < areaCapacity && row < rowsNeeded; row++ {
-						rowLetter := rowNameFromIndex(row)
-						for col := 1; col <= seatsPerRow && created < areaCapacity; col++ {
-							seatCode := rowLetter + strconv.Itoa(col)
-							values = append(values, "(?, ?, ?, ?, 'ACTIVE')")
-							params = append(params, areaID, seatCode, rowLetter, col)
-							created++
-						}
-					}
-
-					if len(values) > 0 {
-						insertSeatsQuery += strings.Join(values, ", ")
-						result, err := tx.ExecContext(ctx, insertSeatsQuery, params...)
-						if err != nil {
-							return fmt.Errorf("failed to initialize seats: %w", err)
-						}
-						rowsCreated, _ := result.RowsAffected()
-						fmt.Printf("[UpdateEventRequest] Inserted (ignored duplicates) %d seats\n", rowsCreated)
-					}
-
-					// Re-fetch seats after insertion
-					rows, err = tx.QueryContext(ctx, getSeatIDsQuery, areaID)
-					if err != nil {
-						return fmt.Errorf("failed to re-fetch seats: %w", err)
-					}
-
-					seatIDs = []int64{}
-					seatCodes = []string{}
-					for rows.Next() {
-						var seatID int64
-						var seatCode, rowNo, colNo string
-						if err := rows.Scan(&seatID, &seatCode, &rowNo, &colNo); err != nil {
-							rows.Close()
-							return fmt.Errorf("failed to scan re-fetched seat: %w", err)
-						}
-						seatIDs = append(seatIDs, seatID)
-						seatCodes = append(seatCodes, seatCode)
-					}
-					rows.Close()
-				} else if len(seatIDs) == 0 {
-					// fallback: if capacity not available, preserve previous behavior (initialize 10x10)
-					fmt.Printf("[UpdateEventRequest] No seats found and area capacity unknown, initializing 10x10 matrix\n")
-
-					insertSeatsQuery := `INSERT IGNORE INTO Seat (area_id, seat_code, row_no, col_no, status) VALUES `
-					var values []string
-					var params []interface{}
-
-					for row := 0; row < 10; row++ {
-						rowLetter := string(rune('A' + row))
-						for col := 1; col <= 10; col++ {
-							seatCode := rowLetter + strconv.Itoa(col)
-							values = append(values, "(?, ?, ?, ?, 'ACTIVE')")
-							params = append(params, areaID, seatCode, rowLetter, col)
-						}
-					}
-
-					insertSeatsQuery += strings.Join(values, ", ")
-					result, err := tx.ExecContext(ctx, insertSeatsQuery, params...)
-					if err != nil {
-						return fmt.Errorf("failed to initialize seats: %w", err)
-					}
-					rowsCreated, _ := result.RowsAffected()
-					fmt.Printf("[UpdateEventRequest] Created %d seats\n", rowsCreated)
-
-					// Re-fetch
-					rows, err = tx.QueryContext(ctx, getSeatIDsQuery, areaID)
-					if err != nil {
-						return fmt.Errorf("failed to re-fetch seats: %w", err)
-					}
-
-					seatIDs = []int64{}
-					seatCodes = []string{}
-					for rows.Next() {
-						var seatID int64
-						var seatCode, rowNo, colNo string
-						if err := rows.Scan(&seatID, &seatCode, &rowNo, &colNo); err != nil {
-							rows.Close()
-							return fmt.Errorf("failed to scan re-fetched seat: %w", err)
-						}
-						seatIDs = append(seatIDs, seatID)
-						seatCodes = append(seatCodes, seatCode)
-					}
-					rows.Close()
-				}
-
-				// === Explicit allocation: ensure VIP seats then STANDARD seats are linked ===
-				// Find VIP and STANDARD ticket IDs and required quantities
-				var vipID int64
-				var standardID int64
-				var vipQty int
-				var standardQty int
-				for _, t := range ticketAllocations {
-					up := strings.ToUpper(t.Name)
-					if strings.Contains(up, "VIP") {
-						vipID = t.CategoryTicketID
-						vipQty = t.MaxQuantity
-					} else if strings.Contains(up, "STANDARD") || strings.Contains(up, "STD") {
-						standardID = t.CategoryTicketID
-						standardQty = t.MaxQuantity
-					} else {
-						// fallback: assign to standard if not VIP
-						if standardID == 0 {
-							standardID = t.CategoryTicketID
-							standardQty = t.MaxQuantity
-						}
-					}
-				}
-
-				allocatedExplicit := false
-				// Only perform explicit allocation when we have at least VIP and STANDARD IDs
-				if vipID != 0 && standardID != 0 {
-					totalToAssign := vipQty + standardQty
-					if len(seatIDs) < totalToAssign {
-						return fmt.Errorf("insufficient seats for explicit allocation: have %d, need %d", len(seatIDs), totalToAssign)
-					}
-
-					vipAssigned := 0
-					stdAssigned := 0
-					for i := 0; i < totalToAssign; i++ {
-						seatID := seatIDs[i]
-						var assignID int64
-						if i < vipQty {
-							assignID = vipID
-							vipAssigned++
-						} else {
-							assignID = standardID
-							stdAssigned++
-						}
-						_, err = tx.ExecContext(ctx, "UPDATE Seat SET category_ticket_id = ? WHERE seat_id = ?", assignID, seatID)
-						if err != nil {
-							return fmt.Errorf("failed to update seat %d: %w", seatID, err)
-						}
-					}
-					log.Printf("[ALLOCATION] Linked %d VIP seats and %d Standard seats successfully.", vipAssigned, stdAssigned)
-					allocatedExplicit = true
-				}
-
-				// Calculate total seats needed
-				totalNeeded := 0
-				for _, ticket := range ticketAllocations {
-					totalNeeded += ticket.MaxQuantity
-				}
-
-				fmt.Printf("[UpdateEventRequest] Have %d seats, need %d\n", len(seatIDs), totalNeeded)
-
-				if len(seatIDs) < totalNeeded {
-					return fmt.Errorf("insufficient seats: have %d, need %d", len(seatIDs), totalNeeded)
-				}
-
-				// Sequential allocation (fallback) - only run when explicit allocation not performed
-				if !allocatedExplicit {
-					seatIndex := 0
-					for _, ticket := range ticketAllocations {
-						startIndex := seatIndex
-						for count := 0; count < ticket.MaxQuantity; count++ {
-							if seatIndex >= len(seatIDs) {
-								return fmt.Errorf("ran out of seats at index %d", seatIndex)
-							}
-
-							seatID := seatIDs[seatIndex]
-							updateSeatQuery := `UPDATE Seat SET category_ticket_id = ? WHERE seat_id = ?`
-							result, err := tx.ExecContext(ctx, updateSeatQuery, ticket.CategoryTicketID, seatID)
-							if err != nil {
-								return fmt.Errorf("failed to update seat %d: %w", seatID, err)
-							}
-
-							rowsAffected, _ := result.RowsAffected()
-							if rowsAffected == 0 {
-								fmt.Printf("[UpdateEventRequest] WARNING: Seat %d update returned 0 rows\n", seatID)
-							}
-
-							seatIndex++
-						}
-
-						endIndex := seatIndex - 1
-						endSeat := ""
-						if endIndex < len(seatCodes) {
-							endSeat = seatCodes[endIndex]
-						}
-						fmt.Printf("[UpdateEventRequest] Allocated %s seats %s to %s\n", ticket.Name, seatCodes[startIndex], endSeat)
-					}
-
-					fmt.Printf("[UpdateEventRequest] Allocation complete: %d/%d seats\n", seatIndex, len(seatIDs))
+				if err := defaultSeatAllocator().AllocateTx(ctx, tx, areaID, areaCapacity, ticketAllocations); err != nil {
+					return err
 				}
 			}
 		} else {
@@ -634,14 +392,16 @@ func (r *EventRepository) UpdateEventRequest(ctx context.Context, organizerID in
 // These are minimal to satisfy interface requirements
 // ============================================================
 
-func (r *EventRepository) GetAllEventsSeparated(ctx context.Context, role string, userID int) ([]models.EventListItem, []models.EventListItem, error) {
+// campusID != nil: chỉ sự kiện thuộc campus đó
+func (r *EventRepository) GetAllEventsSeparated(ctx context.Context, role string, userID int, campusID *int) ([]models.EventListItem, []models.EventListItem, error) {
 	// Base query to get all events with joined data
 	baseQuery := `
 		SELECT 
 			e.event_id, e.title, e.description, e.start_time, e.end_time, e.max_seats, e.status, e.banner_url,
+			e.banner_thumbnail_url, e.banner_card_url,
 			e.area_id, va.area_name, va.floor,
 			v.venue_name, v.location,
-			e.created_by
+			e.created_by, e.campus_id
 		FROM Event e
 		LEFT JOIN Venue_Area va ON e.area_id = va.area_id
 		LEFT JOIN Venue v ON va.venue_id = v.venue_id
@@ -654,18 +414,24 @@ func (r *EventRepository) GetAllEventsSeparated(ctx context.Context, role string
 	if role == "ORGANIZER" {
 		// Organizer should see events they created including active and historical ones.
 		// Include common statuses and also any event that already ended (end_time < NOW()).
-		query = baseQuery + ` WHERE e.created_by = ? AND (e.status IN ('OPEN','CLOSED','APPROVED','UPDATING') OR e.end_time < NOW())
-			ORDER BY e.start_time DESC`
-		args = append(args, userID)
+		// Sự kiện mà organizer là co-organizer (đã chấp nhận lời mời) cũng được liệt kê
+		query = baseQuery + ` WHERE (e.created_by = ? OR EXISTS (
+				SELECT 1 FROM Event_Collaborator ec
+				WHERE ec.event_id = e.event_id AND ec.user_id = ? AND ec.status = 'ACCEPTED'))
+			AND (e.status IN ('OPEN','CLOSED','APPROVED','UPDATING') OR e.end_time < NOW())`
+		args = append(args, userID, userID)
 	} else if role == "STAFF" {
 		// Staff sees OPEN events and events that have already ended
-		query = baseQuery + ` WHERE (e.status = 'OPEN' OR e.end_time < NOW())
-			ORDER BY e.start_time DESC`
+		query = baseQuery + ` WHERE (e.status = 'OPEN' OR e.end_time < NOW())`
 	} else {
 		// Public: show open events and historical events (by end_time)
-		query = baseQuery + ` WHERE (e.status = 'OPEN' OR e.end_time < NOW())
-			ORDER BY e.start_time DESC`
+		query = baseQuery + ` WHERE (e.status = 'OPEN' OR e.end_time < NOW())`
 	}
+	if campusID != nil {
+		query += ` AND e.campus_id = ?`
+		args = append(args, *campusID)
+	}
+	query += ` ORDER BY e.start_time DESC`
 
 	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
@@ -676,15 +442,14 @@ func (r *EventRepository) GetAllEventsSeparated(ctx context.Context, role string
 	var openEvents, closedEvents []models.EventListItem
 	for rows.Next() {
 		var item models.EventListItem
-		var description, bannerURL, areaName, floor, venueName, venueLoc sql.NullString
-		var areaID, createdBy sql.NullInt64
 		var startTime, endTime time.Time
 
 		err := rows.Scan(
-			&item.EventID, &item.Title, &description, &startTime, &endTime, &item.MaxSeats, &item.Status, &bannerURL,
-			&areaID, &areaName, &floor,
-			&venueName, &venueLoc,
-			&createdBy,
+			&item.EventID, &item.Title, &item.Description, &startTime, &endTime, &item.MaxSeats, &item.Status,
+			&item.BannerURL, &item.BannerThumbnailURL, &item.BannerCardURL,
+			&item.AreaID, &item.AreaName, &item.Floor,
+			&item.VenueName, &item.VenueLocation,
+			&item.OrganizerID, &item.CampusID,
 		)
 		if err != nil {
 			return nil, nil, fmt.Errorf("failed to scan event: %w", err)
@@ -694,32 +459,6 @@ func (r *EventRepository) GetAllEventsSeparated(ctx context.Context, role string
 		item.StartTime = startTime.Format(time.RFC3339)
 		item.EndTime = endTime.Format(time.RFC3339)
 
-		// Convert sql.Null to pointers
-		if description.Valid {
-			item.Description = &description.String
-		}
-		if bannerURL.Valid {
-			item.BannerURL = &bannerURL.String
-		}
-		if areaID.Valid {
-			item.AreaID = pointer(int(areaID.Int64))
-		}
-		if areaName.Valid {
-			item.AreaName = &areaName.String
-		}
-		if floor.Valid {
-			item.Floor = &floor.String
-		}
-		if venueName.Valid {
-			item.VenueName = &venueName.String
-		}
-		if venueLoc.Valid {
-			item.VenueLocation = &venueLoc.String
-		}
-		if createdBy.Valid {
-			item.OrganizerID = pointer(int(createdBy.Int64))
-		}
-
 		// Classify events into open vs closed/historical.
 		// Treat any event whose end_time is before now as closed, regardless of status.
 		now := time.Now()
@@ -738,9 +477,11 @@ func (r *EventRepository) GetEventDetail(ctx context.Context, eventID int) (*mod
 	query := `
 		SELECT
 			e.event_id, e.title, e.description, e.start_time, e.end_time, e.max_seats, e.status, e.banner_url,
+			e.banner_thumbnail_url, e.banner_card_url, e.banner_hero_url,
 			e.area_id, va.area_name, va.floor, va.capacity,
 			v.venue_name,
-			e.speaker_id, s.full_name, s.bio, s.avatar_url, s.email, s.phone
+			e.speaker_id, s.full_name, s.bio, s.avatar_url, s.email, s.phone,
+			e.slug, e.version, e.schedule_blocks, e.faq_entries, e.activity_points, e.cashback_percent, e.dynamic_qr, e.booking_queue
 		FROM Event e
 		LEFT JOIN Venue_Area va ON e.area_id = va.area_id
 		LEFT JOIN Venue v ON va.venue_id = v.venue_id
@@ -749,18 +490,20 @@ func (r *EventRepository) GetEventDetail(ctx context.Context, eventID int) (*mod
 	`
 
 	var detail models.EventDetailDto
-	var description, bannerURL, areaName, floor, venueName, speakerName, speakerBio, speakerAvatar, speakerEmail, speakerPhone sql.NullString
-	var areaID, areaCapacity sql.NullInt64
-	var speakerID sql.NullInt64
-	var startTime, endTime time.Time
-	var maxSeats sql.NullInt64
-	var status sql.NullString
+	var speakerID *int
+	var speakerEmail, speakerPhone *string
+	var schedule, faq sql.NullString
 
 	err := r.db.QueryRowContext(ctx, query, eventID).Scan(
-		&detail.EventID, &detail.Title, &description, &startTime, &endTime, &maxSeats, &status, &bannerURL,
-		&areaID, &areaName, &floor, &areaCapacity,
-		&venueName,
-		/* speaker */ &speakerID, &speakerName, &speakerBio, &speakerAvatar, &speakerEmail, &speakerPhone,
+		&detail.EventID, &detail.Title, &detail.Description,
+		dbscan.RFC3339(&detail.StartTime), dbscan.RFC3339(&detail.EndTime),
+		dbscan.OrZero(&detail.MaxSeats), dbscan.OrZero(&detail.Status), &detail.BannerURL,
+		&detail.BannerThumbnailURL, &detail.BannerCardURL, &detail.BannerHeroURL,
+		&detail.AreaID, &detail.AreaName, &detail.Floor, &detail.AreaCapacity,
+		&detail.VenueName,
+		/* speaker */ &speakerID, &detail.SpeakerName, &detail.SpeakerBio,
+		&detail.SpeakerAvatarURL, &speakerEmail, &speakerPhone,
+		&detail.Slug, &detail.Version, &schedule, &faq, &detail.ActivityPoints, &detail.CashbackPercent, &detail.DynamicQR, &detail.BookingQueue,
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -768,56 +511,19 @@ func (r *EventRepository) GetEventDetail(ctx context.Context, eventID int) (*mod
 		}
 		return nil, fmt.Errorf("failed to query event detail: %w", err)
 	}
+	if detail.Schedule, detail.FAQ, err = decodePageContent(schedule, faq); err != nil {
+		return nil, err
+	}
 
 	// ✅ DEBUG: Log event.speaker_id từ database
-	log.Printf("[GetEventDetail] EventID=%d: e.speaker_id from DB = %v (Valid=%v)", eventID, speakerID.Int64, speakerID.Valid)
+	log.Printf("[GetEventDetail] EventID=%d: e.speaker_id from DB = %v", eventID, speakerID)
 
-	// Map fields
-	if description.Valid {
-		detail.Description = &description.String
-	}
-	detail.StartTime = startTime.Format(time.RFC3339)
-	detail.EndTime = endTime.Format(time.RFC3339)
-	if maxSeats.Valid {
-		detail.MaxSeats = int(maxSeats.Int64)
-	}
-	if status.Valid {
-		detail.Status = status.String
-	}
-	if bannerURL.Valid {
-		detail.BannerURL = &bannerURL.String
-	}
-	if venueName.Valid {
-		detail.VenueName = &venueName.String
-	}
-	if areaID.Valid {
-		aid := int(areaID.Int64)
-		detail.AreaID = &aid
-	}
-	if areaName.Valid {
-		detail.AreaName = &areaName.String
-	}
-	if floor.Valid {
-		detail.Floor = &floor.String
-	}
-	if areaCapacity.Valid {
-		ac := int(areaCapacity.Int64)
-		detail.AreaCapacity = &ac
-	}
-	if speakerName.Valid {
-		detail.SpeakerName = &speakerName.String
+	// Email / SĐT speaker lưu mã hóa
+	if speakerEmail != nil {
+		detail.SpeakerEmail = pointer(crypto.DecryptPII(*speakerEmail))
 	}
-	if speakerBio.Valid {
-		detail.SpeakerBio = &speakerBio.String
-	}
-	if speakerAvatar.Valid {
-		detail.SpeakerAvatarURL = &speakerAvatar.String
-	}
-	if speakerEmail.Valid {
-		detail.SpeakerEmail = &speakerEmail.String
-	}
-	if speakerPhone.Valid {
-		detail.SpeakerPhone = &speakerPhone.String
+	if speakerPhone != nil {
+		detail.SpeakerPhone = pointer(crypto.DecryptPII(*speakerPhone))
 	}
 
 	// Load tickets
@@ -827,12 +533,19 @@ func (r *EventRepository) GetEventDetail(ctx context.Context, eventID int) (*mod
 	}
 	detail.Tickets = tickets
 
-	// Check if any bookings exist for event (to indicate locked seating)
-	var bookingCount int
-	err = r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM Ticket WHERE event_id = ? AND status IN ('PENDING','BOOKED','CHECKED_IN')", eventID).Scan(&bookingCount)
-	if err == nil {
-		has := bookingCount > 0
-		detail.HasBookings = &has
+	// Tài liệu buổi nói do speaker đăng
+	if detail.Materials, err = r.ListSessionMaterials(ctx, eventID); err != nil {
+		return nil, err
+	}
+
+	// Tài liệu đính kèm (đủ mọi phạm vi, handler lọc theo người xem)
+	if detail.Attachments, err = r.ListEventAttachments(ctx, eventID); err != nil {
+		return nil, err
+	}
+
+	// Nhà tài trợ (GOLD trước)
+	if detail.Sponsors, err = r.ListEventSponsors(ctx, eventID); err != nil {
+		return nil, err
 	}
 
 	// ✅ DEBUG LOG: Log speaker info before returning
@@ -869,22 +582,14 @@ func (r *EventRepository) GetCategoryTicketsByEventID(ctx context.Context, event
 	var cats []models.CategoryTicket
 	for rows.Next() {
 		var ct models.CategoryTicket
-		var desc sql.NullString
-		var price sql.NullFloat64
-		var maxQty sql.NullInt64
-		if err := rows.Scan(&ct.CategoryTicketID, &ct.Name, &desc, &price, &maxQty, &ct.Status); err != nil {
+		if err := rows.Scan(
+			&ct.CategoryTicketID, &ct.Name, &ct.Description,
+			dbscan.OrZero(&ct.Price), dbscan.OrZero(&ct.MaxQuantity), &ct.Status,
+		); err != nil {
 			return nil, fmt.Errorf("failed to scan category ticket: %w", err)
 		}
-		if desc.Valid {
-			ct.Description = &desc.String
-		}
-		if price.Valid {
-			// Round price to avoid floating-point precision issues (e.g., 49999.999 -> 50000)
-			ct.Price = math.Round(price.Float64)
-		}
-		if maxQty.Valid {
-			ct.MaxQuantity = int(maxQty.Int64)
-		}
+		// Round price to avoid floating-point precision issues (e.g., 49999.999 -> 50000)
+		ct.Price = math.Round(ct.Price)
 		cats = append(cats, ct)
 	}
 	return cats, rows.Err()
@@ -894,12 +599,15 @@ func (r *EventRepository) GetOpenEvents(ctx context.Context) ([]models.EventList
 	query := `
 		SELECT 
 			e.event_id, e.title, e.description, e.start_time, e.end_time, e.max_seats, e.status, e.banner_url,
+			e.banner_thumbnail_url, e.banner_card_url,
 			e.area_id, va.area_name, va.floor,
 			v.venue_name, v.location,
-			e.created_by
+			e.created_by, e.slug, e.campus_id,
+			COALESCE(avail.remaining_seats, 0), avail.max_price
 		FROM Event e
 		LEFT JOIN Venue_Area va ON e.area_id = va.area_id
 		LEFT JOIN Venue v ON va.venue_id = v.venue_id
+		` + openEventAvailabilityJoin + `
 		WHERE e.status = 'OPEN'
 		ORDER BY e.start_time DESC
 	`
@@ -913,72 +621,89 @@ func (r *EventRepository) GetOpenEvents(ctx context.Context) ([]models.EventList
 	var items []models.EventListItem
 	for rows.Next() {
 		var item models.EventListItem
-		var description, bannerURL, areaName, floor, venueName, venueLoc sql.NullString
-		var areaID, createdBy sql.NullInt64
-		var startTime, endTime time.Time
+		var remaining int
+		var maxPrice *float64
 
 		err := rows.Scan(
-			&item.EventID, &item.Title, &description, &startTime, &endTime, &item.MaxSeats, &item.Status, &bannerURL,
-			&areaID, &areaName, &floor,
-			&venueName, &venueLoc,
-			&createdBy,
+			&item.EventID, &item.Title, &item.Description,
+			dbscan.RFC3339(&item.StartTime), dbscan.RFC3339(&item.EndTime), &item.MaxSeats, &item.Status,
+			&item.BannerURL, &item.BannerThumbnailURL, &item.BannerCardURL,
+			&item.AreaID, &item.AreaName, &item.Floor,
+			&item.VenueName, &item.VenueLocation,
+			&item.OrganizerID, &item.Slug, &item.CampusID,
+			&remaining, &maxPrice,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan event: %w", err)
 		}
-
-		// Convert timestamps to ISO string
-		item.StartTime = startTime.Format(time.RFC3339)
-		item.EndTime = endTime.Format(time.RFC3339)
-
-		// Convert sql.Null to pointers
-		if description.Valid {
-			item.Description = &description.String
-		}
-		if bannerURL.Valid {
-			item.BannerURL = &bannerURL.String
-		}
-		if areaID.Valid {
-			item.AreaID = pointer(int(areaID.Int64))
-		}
-		if areaName.Valid {
-			item.AreaName = &areaName.String
-		}
-		if floor.Valid {
-			item.Floor = &floor.String
-		}
-		if venueName.Valid {
-			item.VenueName = &venueName.String
-		}
-		if venueLoc.Valid {
-			item.VenueLocation = &venueLoc.String
-		}
-		if createdBy.Valid {
-			item.OrganizerID = pointer(int(createdBy.Int64))
-		}
+		// Sự kiện chưa có loại vé ACTIVE: còn 0 chỗ, không tính là miễn phí
+		free := maxPrice != nil && *maxPrice == 0
+		item.RemainingSeats, item.IsFree = &remaining, &free
 
 		items = append(items, item)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// Nhà tài trợ của các sự kiện trong trang, một query cho cả danh sách
+	ids := make([]int, len(items))
+	for i := range items {
+		ids[i] = items[i].EventID
+	}
+	sponsors, err := r.ListSponsorsByEvents(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for i := range items {
+		items[i].Sponsors = sponsors[items[i].EventID]
+	}
 
-	return items, rows.Err()
+	return items, nil
 }
 
 func (r *EventRepository) CreateEventRequest(ctx context.Context, requesterID int, req *models.CreateEventRequestBody) (int, error) {
 	log.Printf("[DB_INSERT] Starting insert for requesterID=%d, title=%s", requesterID, req.Title)
 
+	// Request và dự toán ngân sách ghi trong cùng transaction
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var requestedFunding *float64
+	if req.Budget != nil {
+		requestedFunding = req.Budget.RequestedFunding
+	}
+
+	var draftPayload interface{}
+	if req.Draft != nil && (req.Draft.Speaker != nil || len(req.Draft.Tickets) > 0) {
+		data, err := json.Marshal(req.Draft)
+		if err != nil {
+			return 0, fmt.Errorf("failed to marshal draft: %w", err)
+		}
+		draftPayload = string(data)
+	}
+
 	query := `
 		INSERT INTO Event_Request 
-		(requester_id, title, description, preferred_start_time, preferred_end_time, expected_capacity, status, created_at)
-		VALUES (?, ?, ?, ?, ?, ?, 'PENDING', NOW())
+		(requester_id, title, description, preferred_start_time, preferred_end_time, expected_capacity, requested_funding,
+		 template_id, draft_payload, required_amenities, status, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, 'PENDING', NOW())
 	`
 
-	result, err := r.db.ExecContext(ctx, query,
+	result, err := tx.ExecContext(ctx, query,
 		requesterID,
 		req.Title,
 		req.Description,
 		req.PreferredStartTime,
 		req.PreferredEndTime,
 		req.ExpectedCapacity,
+		requestedFunding,
+		req.TemplateID,
+		draftPayload,
+		amenity.Join(req.RequiredAmenities),
 	)
 
 	if err != nil {
@@ -992,6 +717,15 @@ func (r *EventRepository) CreateEventRequest(ctx context.Context, requesterID in
 		return 0, fmt.Errorf("failed to get last insert ID: %w", err)
 	}
 
+	if req.Budget != nil {
+		if err := insertBudgetItemsTx(ctx, tx, int(requestID), req.Budget.Items); err != nil {
+			return 0, err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit event request: %w", err)
+	}
+
 	log.Printf("[DB_INSERT] Successfully inserted request ID: %d", requestID)
 	return int(requestID), nil
 }
@@ -1029,56 +763,22 @@ func (r *EventRepository) GetMyEventRequests(ctx context.Context, requesterID in
 	var requests []models.EventRequest
 	for rows.Next() {
 		var req models.EventRequest
-		var requesterName, processedByName sql.NullString
-		var processedBy sql.NullInt64
-		var processedAt, createdAt sql.NullTime
-		var venueName, areaName, floor sql.NullString
-		var areaCapacity sql.NullInt64
 
 		err := rows.Scan(
-			&req.RequestID, &req.RequesterID, &requesterName,
+			&req.RequestID, &req.RequesterID, &req.RequesterName,
 			&req.Title, &req.Description,
 			&req.PreferredStartTime, &req.PreferredEndTime,
 			&req.ExpectedCapacity, &req.Status,
-			&createdAt, &processedBy, &processedByName,
-			&processedAt, &req.OrganizerNote, &req.RejectReason,
+			dbscan.RFC3339Ptr(&req.CreatedAt), &req.ProcessedBy, &req.ProcessedByName,
+			dbscan.RFC3339Ptr(&req.ProcessedAt), &req.OrganizerNote, &req.RejectReason,
 			&req.CreatedEventID,
-			&venueName, &areaName, &floor, &areaCapacity,
+			&req.VenueName, &req.AreaName, &req.Floor, &req.AreaCapacity,
 		)
 		if err != nil {
 			log.Printf("[GetMyEventRequests] Scan error: %v", err)
 			return nil, fmt.Errorf("failed to scan event request: %w", err)
 		}
 
-		// Convert sql.Null to pointers
-		if requesterName.Valid {
-			req.RequesterName = &requesterName.String
-		}
-		if createdAt.Valid {
-			req.CreatedAt = pointer(createdAt.Time.Format(time.RFC3339))
-		}
-		if processedBy.Valid {
-			req.ProcessedBy = pointer(int(processedBy.Int64))
-		}
-		if processedByName.Valid {
-			req.ProcessedByName = &processedByName.String
-		}
-		if processedAt.Valid {
-			req.ProcessedAt = pointer(processedAt.Time.Format(time.RFC3339))
-		}
-		if venueName.Valid {
-			req.VenueName = &venueName.String
-		}
-		if areaName.Valid {
-			req.AreaName = &areaName.String
-		}
-		if floor.Valid {
-			req.Floor = &floor.String
-		}
-		if areaCapacity.Valid {
-			req.AreaCapacity = pointer(int(areaCapacity.Int64))
-		}
-
 		requests = append(requests, req)
 	}
 
@@ -1119,59 +819,21 @@ func (r *EventRepository) GetMyActiveEventRequests(ctx context.Context, requeste
 	var requests []models.EventRequest
 	for rows.Next() {
 		var req models.EventRequest
-		var requesterName, processedByName sql.NullString
-		var processedBy sql.NullInt64
-		var processedAt, createdAt sql.NullTime
-		var eventStatus sql.NullString
-		var venueName, areaName, floor sql.NullString
-		var areaCapacity sql.NullInt64
 
 		err := rows.Scan(
-			&req.RequestID, &req.RequesterID, &requesterName,
+			&req.RequestID, &req.RequesterID, &req.RequesterName,
 			&req.Title, &req.Description,
 			&req.PreferredStartTime, &req.PreferredEndTime,
 			&req.ExpectedCapacity, &req.Status,
-			&createdAt, &processedBy, &processedByName,
-			&processedAt, &req.OrganizerNote, &req.RejectReason,
-			&req.CreatedEventID, &eventStatus,
-			&venueName, &areaName, &floor, &areaCapacity,
+			dbscan.RFC3339Ptr(&req.CreatedAt), &req.ProcessedBy, &req.ProcessedByName,
+			dbscan.RFC3339Ptr(&req.ProcessedAt), &req.OrganizerNote, &req.RejectReason,
+			&req.CreatedEventID, &req.EventStatus,
+			&req.VenueName, &req.AreaName, &req.Floor, &req.AreaCapacity,
 		)
 		if err != nil {
 			return nil, 0, fmt.Errorf("failed to scan event request: %w", err)
 		}
 
-		// Convert sql.Null to pointers
-		if requesterName.Valid {
-			req.RequesterName = &requesterName.String
-		}
-		if createdAt.Valid {
-			req.CreatedAt = pointer(createdAt.Time.Format(time.RFC3339))
-		}
-		if processedBy.Valid {
-			req.ProcessedBy = pointer(int(processedBy.Int64))
-		}
-		if processedByName.Valid {
-			req.ProcessedByName = &processedByName.String
-		}
-		if processedAt.Valid {
-			req.ProcessedAt = pointer(processedAt.Time.Format(time.RFC3339))
-		}
-		if eventStatus.Valid {
-			req.EventStatus = &eventStatus.String
-		}
-		if venueName.Valid {
-			req.VenueName = &venueName.String
-		}
-		if areaName.Valid {
-			req.AreaName = &areaName.String
-		}
-		if floor.Valid {
-			req.Floor = &floor.String
-		}
-		if areaCapacity.Valid {
-			req.AreaCapacity = pointer(int(areaCapacity.Int64))
-		}
-
 		requests = append(requests, req)
 	}
 
@@ -1193,7 +855,7 @@ func (r *EventRepository) GetMyActiveEventRequests(ctx context.Context, requeste
 }
 
 func (r *EventRepository) GetMyArchivedEventRequests(ctx context.Context, requesterID int, limit int, offset int) ([]models.EventRequest, int, error) {
-	// Archived = REJECTED, CANCELLED OR (APPROVED AND Event.status IN OPEN, CLOSED, CANCELLED, FINISHED) (Tab "Đã xử lý")
+	// Archived = REJECTED, CANCELLED, FINISHED OR (APPROVED AND Event.status IN OPEN, CLOSED, CANCELLED, FINISHED) (Tab "Đã xử lý")
 	query := `
 		SELECT 
 			er.request_id, er.requester_id, u.full_name as requester_name,
@@ -1211,7 +873,7 @@ func (r *EventRepository) GetMyArchivedEventRequests(ctx context.Context, reques
 		LEFT JOIN Venue_Area va ON e.area_id = va.area_id
 		LEFT JOIN Venue v ON va.venue_id = v.venue_id
 		WHERE er.requester_id = ? 
-		  AND (er.status IN ('REJECTED', 'CANCELLED') 
+		  AND (er.status IN ('REJECTED', 'CANCELLED', 'FINISHED') 
 		       OR (er.status = 'APPROVED' AND e.status IN ('OPEN', 'CLOSED', 'CANCELLED', 'FINISHED')))
 		ORDER BY er.created_at DESC
 		LIMIT ? OFFSET ?
@@ -1226,59 +888,21 @@ func (r *EventRepository) GetMyArchivedEventRequests(ctx context.Context, reques
 	var requests []models.EventRequest
 	for rows.Next() {
 		var req models.EventRequest
-		var requesterName, processedByName sql.NullString
-		var processedBy sql.NullInt64
-		var processedAt, createdAt sql.NullTime
-		var eventStatus sql.NullString
-		var venueName, areaName, floor sql.NullString
-		var areaCapacity sql.NullInt64
 
 		err := rows.Scan(
-			&req.RequestID, &req.RequesterID, &requesterName,
+			&req.RequestID, &req.RequesterID, &req.RequesterName,
 			&req.Title, &req.Description,
 			&req.PreferredStartTime, &req.PreferredEndTime,
 			&req.ExpectedCapacity, &req.Status,
-			&createdAt, &processedBy, &processedByName,
-			&processedAt, &req.OrganizerNote, &req.RejectReason,
-			&req.CreatedEventID, &eventStatus,
-			&venueName, &areaName, &floor, &areaCapacity,
+			dbscan.RFC3339Ptr(&req.CreatedAt), &req.ProcessedBy, &req.ProcessedByName,
+			dbscan.RFC3339Ptr(&req.ProcessedAt), &req.OrganizerNote, &req.RejectReason,
+			&req.CreatedEventID, &req.EventStatus,
+			&req.VenueName, &req.AreaName, &req.Floor, &req.AreaCapacity,
 		)
 		if err != nil {
 			return nil, 0, fmt.Errorf("failed to scan event request: %w", err)
 		}
 
-		// Convert sql.Null to pointers
-		if requesterName.Valid {
-			req.RequesterName = &requesterName.String
-		}
-		if createdAt.Valid {
-			req.CreatedAt = pointer(createdAt.Time.Format(time.RFC3339))
-		}
-		if processedBy.Valid {
-			req.ProcessedBy = pointer(int(processedBy.Int64))
-		}
-		if processedByName.Valid {
-			req.ProcessedByName = &processedByName.String
-		}
-		if processedAt.Valid {
-			req.ProcessedAt = pointer(processedAt.Time.Format(time.RFC3339))
-		}
-		if eventStatus.Valid {
-			req.EventStatus = &eventStatus.String
-		}
-		if venueName.Valid {
-			req.VenueName = &venueName.String
-		}
-		if areaName.Valid {
-			req.AreaName = &areaName.String
-		}
-		if floor.Valid {
-			req.Floor = &floor.String
-		}
-		if areaCapacity.Valid {
-			req.AreaCapacity = pointer(int(areaCapacity.Int64))
-		}
-
 		requests = append(requests, req)
 	}
 
@@ -1288,7 +912,7 @@ func (r *EventRepository) GetMyArchivedEventRequests(ctx context.Context, reques
 		FROM Event_Request er
 		LEFT JOIN Event e ON er.created_event_id = e.event_id
 		WHERE er.requester_id = ? 
-		  AND (er.status IN ('REJECTED', 'CANCELLED') 
+		  AND (er.status IN ('REJECTED', 'CANCELLED', 'FINISHED') 
 		       OR (er.status = 'APPROVED' AND e.status IN ('OPEN', 'CLOSED', 'CANCELLED', 'FINISHED')))
 	`
 	var total int
@@ -1300,8 +924,9 @@ func (r *EventRepository) GetMyArchivedEventRequests(ctx context.Context, reques
 	return requests, total, rows.Err()
 }
 
-func (r *EventRepository) GetPendingEventRequests(ctx context.Context) ([]models.EventRequest, error) {
+func (r *EventRepository) GetPendingEventRequests(ctx context.Context, campusID *int) ([]models.EventRequest, error) {
 	// Staff xem tất cả yêu cầu (bao gồm cả đã xử lý: APPROVED, REJECTED)
+	// campusID != nil: chỉ yêu cầu thuộc campus đó (campus sự kiện, chưa duyệt thì campus người gửi)
 	query := `
 		SELECT 
 			er.request_id, er.requester_id, u.full_name as requester_name,
@@ -1310,19 +935,23 @@ func (r *EventRepository) GetPendingEventRequests(ctx context.Context) ([]models
 			er.expected_capacity, er.status,
 			er.created_at, er.processed_by, u2.full_name as processed_by_name,
 			er.processed_at, er.organizer_note, er.reject_reason,
-			er.created_event_id,
-			v.venue_name, va.area_name, va.floor, va.capacity
+			er.created_event_id, er.requested_funding, ` + budgetReviewStatusSQL + `,
+			v.venue_name, va.area_name, va.floor, va.capacity,
+			u3.user_id, u3.full_name, er.claimed_at, er.required_amenities
 		FROM Event_Request er
 		LEFT JOIN Users u ON er.requester_id = u.user_id
 		LEFT JOIN Users u2 ON er.processed_by = u2.user_id
+		LEFT JOIN Users u3 ON er.claimed_by = u3.user_id
+			AND er.status IN ('PENDING', 'UPDATING') AND ` + claimActiveSQL + `
 		LEFT JOIN Event e ON er.created_event_id = e.event_id
 		LEFT JOIN Venue_Area va ON e.area_id = va.area_id
 		LEFT JOIN Venue v ON va.venue_id = v.venue_id
 		WHERE er.status IN ('PENDING', 'UPDATING', 'APPROVED', 'REJECTED', 'CANCELLED', 'FINISHED')
+		  AND (? IS NULL OR COALESCE(e.campus_id, u.campus_id) = ?)
 		ORDER BY er.created_at DESC
 	`
 
-	rows, err := r.db.QueryContext(ctx, query)
+	rows, err := r.db.QueryContext(ctx, query, models.ClaimTTLMinutes, campusID, campusID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query pending event requests: %w", err)
 	}
@@ -1331,53 +960,27 @@ func (r *EventRepository) GetPendingEventRequests(ctx context.Context) ([]models
 	var requests []models.EventRequest
 	for rows.Next() {
 		var req models.EventRequest
-		var requesterName, processedByName sql.NullString
-		var processedBy sql.NullInt64
-		var processedAt, createdAt sql.NullTime
-		var venueName, areaName, floor sql.NullString
-		var areaCapacity sql.NullInt64
+		var claimedAt *string
+		var requiredAmenities string
 
 		err := rows.Scan(
-			&req.RequestID, &req.RequesterID, &requesterName,
+			&req.RequestID, &req.RequesterID, &req.RequesterName,
 			&req.Title, &req.Description,
 			&req.PreferredStartTime, &req.PreferredEndTime,
 			&req.ExpectedCapacity, &req.Status,
-			&createdAt, &processedBy, &processedByName,
-			&processedAt, &req.OrganizerNote, &req.RejectReason,
-			&req.CreatedEventID,
-			&venueName, &areaName, &floor, &areaCapacity,
+			dbscan.RFC3339Ptr(&req.CreatedAt), &req.ProcessedBy, &req.ProcessedByName,
+			dbscan.RFC3339Ptr(&req.ProcessedAt), &req.OrganizerNote, &req.RejectReason,
+			&req.CreatedEventID, &req.RequestedFunding, &req.BudgetReviewStatus,
+			&req.VenueName, &req.AreaName, &req.Floor, &req.AreaCapacity,
+			&req.AssignedTo, &req.AssignedToName, dbscan.RFC3339Ptr(&claimedAt), &requiredAmenities,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan event request: %w", err)
 		}
-
-		// Convert sql.Null to pointers
-		if requesterName.Valid {
-			req.RequesterName = &requesterName.String
-		}
-		if createdAt.Valid {
-			req.CreatedAt = pointer(createdAt.Time.Format(time.RFC3339))
-		}
-		if processedBy.Valid {
-			req.ProcessedBy = pointer(int(processedBy.Int64))
-		}
-		if processedByName.Valid {
-			req.ProcessedByName = &processedByName.String
-		}
-		if processedAt.Valid {
-			req.ProcessedAt = pointer(processedAt.Time.Format(time.RFC3339))
-		}
-		if venueName.Valid {
-			req.VenueName = &venueName.String
-		}
-		if areaName.Valid {
-			req.AreaName = &areaName.String
-		}
-		if floor.Valid {
-			req.Floor = &floor.String
-		}
-		if areaCapacity.Valid {
-			req.AreaCapacity = pointer(int(areaCapacity.Int64))
+		req.RequiredAmenities = amenity.Split(requiredAmenities)
+		// claimed_at chỉ có nghĩa khi claim còn hiệu lực (JOIN u3 khớp)
+		if req.AssignedTo != nil {
+			req.ClaimedAt = claimedAt
 		}
 
 		requests = append(requests, req)
@@ -1395,8 +998,10 @@ func (r *EventRepository) GetEventRequestByID(ctx context.Context, requestID int
 			er.expected_capacity, er.status,
 			er.created_at, er.processed_by, u2.full_name as processed_by_name,
 			er.processed_at, er.organizer_note, er.reject_reason,
-			er.created_event_id,
-			v.venue_name, va.area_name, va.floor, va.capacity
+			er.created_event_id, er.cloned_from_request_id, er.template_id, er.draft_payload,
+			er.requested_funding, ` + budgetReviewStatusSQL + `,
+			v.venue_name, va.area_name, va.floor, va.capacity,
+			er.required_amenities, er.version
 		FROM Event_Request er
 		LEFT JOIN Users u ON er.requester_id = u.user_id
 		LEFT JOIN Users u2 ON er.processed_by = u2.user_id
@@ -1408,21 +1013,20 @@ func (r *EventRepository) GetEventRequestByID(ctx context.Context, requestID int
 	`
 
 	var req models.EventRequest
-	var requesterName, processedByName sql.NullString
-	var processedBy sql.NullInt64
-	var processedAt, createdAt sql.NullTime
-	var venueName, areaName, floor sql.NullString
-	var areaCapacity sql.NullInt64
+	var draftPayload string
+	var requiredAmenities string
 
 	err := r.db.QueryRowContext(ctx, query, requestID).Scan(
-		&req.RequestID, &req.RequesterID, &requesterName,
+		&req.RequestID, &req.RequesterID, &req.RequesterName,
 		&req.Title, &req.Description,
 		&req.PreferredStartTime, &req.PreferredEndTime,
 		&req.ExpectedCapacity, &req.Status,
-		&createdAt, &processedBy, &processedByName,
-		&processedAt, &req.OrganizerNote, &req.RejectReason,
-		&req.CreatedEventID,
-		&venueName, &areaName, &floor, &areaCapacity,
+		dbscan.RFC3339Ptr(&req.CreatedAt), &req.ProcessedBy, &req.ProcessedByName,
+		dbscan.RFC3339Ptr(&req.ProcessedAt), &req.OrganizerNote, &req.RejectReason,
+		&req.CreatedEventID, &req.ClonedFromRequestID, &req.TemplateID, dbscan.OrZero(&draftPayload),
+		&req.RequestedFunding, &req.BudgetReviewStatus,
+		&req.VenueName, &req.AreaName, &req.Floor, &req.AreaCapacity,
+		&requiredAmenities, &req.Version,
 	)
 
 	if err != nil {
@@ -1431,34 +1035,29 @@ func (r *EventRepository) GetEventRequestByID(ctx context.Context, requestID int
 		}
 		return nil, fmt.Errorf("failed to query event request: %w", err)
 	}
+	req.RequiredAmenities = amenity.Split(requiredAmenities)
 
-	// Convert sql.Null to pointers
-	if requesterName.Valid {
-		req.RequesterName = &requesterName.String
-	}
-	if createdAt.Valid {
-		req.CreatedAt = pointer(createdAt.Time.Format(time.RFC3339))
-	}
-	if processedBy.Valid {
-		req.ProcessedBy = pointer(int(processedBy.Int64))
-	}
-	if processedByName.Valid {
-		req.ProcessedByName = &processedByName.String
-	}
-	if processedAt.Valid {
-		req.ProcessedAt = pointer(processedAt.Time.Format(time.RFC3339))
-	}
-	if venueName.Valid {
-		req.VenueName = &venueName.String
-	}
-	if areaName.Valid {
-		req.AreaName = &areaName.String
-	}
-	if floor.Valid {
-		req.Floor = &floor.String
-	}
-	if areaCapacity.Valid {
-		req.AreaCapacity = pointer(int(areaCapacity.Int64))
+	// Request chưa có Event: trả speaker/tickets từ draft (request được clone hoặc tạo từ mẫu)
+	if req.CreatedEventID == nil && draftPayload != "" {
+		var draft models.EventRequestDraft
+		if err := json.Unmarshal([]byte(draftPayload), &draft); err != nil {
+			log.Printf("[GetEventRequestByID] failed to parse draft_payload for request %d: %v", requestID, err)
+		} else {
+			req.Speaker = draft.Speaker
+			for _, t := range draft.Tickets {
+				status := "ACTIVE"
+				if t.Status != nil {
+					status = *t.Status
+				}
+				req.Tickets = append(req.Tickets, models.CategoryTicket{
+					Name:        t.Name,
+					Description: t.Description,
+					Price:       t.Price,
+					MaxQuantity: t.MaxQuantity,
+					Status:      status,
+				})
+			}
+		}
 	}
 
 	// If there is a created event, fetch event detail (banner, speaker, tickets)
@@ -1523,6 +1122,11 @@ func (r *EventRepository) ProcessEventRequest(ctx context.Context, adminID int,
 	}
 	defer tx.Rollback()
 
+	// If-Match: yêu cầu phải chưa bị sửa kể từ lần người duyệt tải về
+	if err := checkEventRequestVersion(ctx, tx, req.RequestID, req.ExpectedVersion); err != nil {
+		return err
+	}
+
 	// ============================================================
 	// SCENARIO 1: REJECTED
 	// ============================================================
@@ -1537,6 +1141,7 @@ func (r *EventRepository) ProcessEventRequest(ctx context.Context, adminID int,
 			SET status = 'REJECTED', 
 			    processed_by = ?, 
 			    processed_at = NOW(),
+			    version = version + 1,
 			    reject_reason = ?
 			WHERE request_id = ?
 		`
@@ -1600,6 +1205,7 @@ func (r *EventRepository) ProcessEventRequest(ctx context.Context, adminID int,
 			SET status = 'APPROVED', 
 			    processed_by = ?, 
 			    processed_at = NOW(),
+			    version = version + 1,
 			    organizer_note = ?
 			WHERE request_id = ?
 		`
@@ -1627,8 +1233,10 @@ func (r *EventRepository) ProcessEventRequest(ctx context.Context, adminID int,
 		insertEventQuery := `
 			INSERT INTO Event (
 				title, description, start_time, end_time, max_seats, 
-				banner_url, area_id, speaker_id, status, created_by, created_at
-			) VALUES (?, ?, ?, ?, ?, ?, ?, ?, 'UPDATING', ?, NOW())
+				banner_url, area_id, campus_id, speaker_id, status, created_by, created_at
+			) VALUES (?, ?, ?, ?, ?, ?, ?,
+				(SELECT v.campus_id FROM Venue_Area va JOIN Venue v ON v.venue_id = va.venue_id WHERE va.area_id = ?),
+				?, 'UPDATING', ?, NOW())
 		`
 
 		speakerIDValue := sql.NullInt64{Valid: false}
@@ -1643,7 +1251,7 @@ func (r *EventRepository) ProcessEventRequest(ctx context.Context, adminID int,
 
 		eventResult, err := tx.ExecContext(ctx, insertEventQuery,
 			requestTitle, requestDesc, requestStartTime, requestEndTime, requestCapacity,
-			bannerURLValue, *req.AreaID, speakerIDValue, requesterID,
+			bannerURLValue, *req.AreaID, *req.AreaID, speakerIDValue, requesterID,
 		)
 		if err != nil {
 			fmt.Printf("[DB_PROCESS] Failed to create Event: %v\n", err)
@@ -1658,6 +1266,12 @@ func (r *EventRepository) ProcessEventRequest(ctx context.Context, adminID int,
 
 		fmt.Printf("[DB_PROCESS] Step B2: Created Event %d with status UPDATING\n", eventID)
 
+		// Slug cho trang public, gán trong cùng transaction
+		if _, err := assignEventSlug(ctx, tx, eventID, requestTitle); err != nil {
+			fmt.Printf("[DB_PROCESS] Failed to assign slug: %v\n", err)
+			return err
+		}
+
 		// B3: Update Event_Request.created_event_id
 		updateCreatedEventQuery := `
 			UPDATE Event_Request 
@@ -1704,6 +1318,12 @@ func (r *EventRepository) ProcessEventRequest(ctx context.Context, adminID int,
 
 		fmt.Printf("[DB_PROCESS] ✅ Step B4 SUCCESS: Marked Venue_Area %d as UNAVAILABLE\n", *req.AreaID)
 
+		// B5: Request được clone → áp dụng speaker + tickets từ draft vào Event mới
+		if err := r.applyEventRequestDraftTx(ctx, tx, req.RequestID, eventID, req.SpeakerID == nil || *req.SpeakerID == 0); err != nil {
+			fmt.Printf("[DB_PROCESS] Failed to apply request draft: %v\n", err)
+			return fmt.Errorf("failed to apply request draft: %w", err)
+		}
+
 		// Commit transaction
 		if err := tx.Commit(); err != nil {
 			fmt.Printf("[DB_PROCESS] Failed to commit APPROVE transaction: %v\n", err)
@@ -1717,24 +1337,14 @@ func (r *EventRepository) ProcessEventRequest(ctx context.Context, adminID int,
 	return fmt.Errorf("invalid action: %s", req.Action)
 }
 
-func (r *EventRepository) CheckEventUpdateEligibility(ctx context.Context, requestID int) (bool, *models.EligibilityError) {
-	return true, nil
-}
-
-func (r *EventRepository) DisableEvent(ctx context.Context, eventID int) error {
-	return nil
-}
-
-func (r *EventRepository) CheckEventOwnership(ctx context.Context, eventID, userID int) (bool, error) {
-	return true, nil
-}
-
+// CheckAreaOverlapTx - Stub (xem Capabilities): trùng lịch khu vực được kiểm tra trong UpdateEventRequest
 func (r *EventRepository) CheckAreaOverlapTx(tx *sql.Tx, ctx context.Context, areaID int64, startTime, endTime string) (interface{}, error) {
-	return nil, nil
+	return nil, capability.ErrUnimplemented
 }
 
+// UpdateEvent - Stub (xem Capabilities): cập nhật sự kiện đi qua UpdateEventDetails
 func (r *EventRepository) UpdateEvent(ctx context.Context, req *models.UpdateEventRequest) error {
-	return nil
+	return capability.ErrUnimplemented
 }
 
 func (r *EventRepository) UpdateEventDetails(ctx context.Context, userID int, role string, req interface{}) error {
@@ -1755,6 +1365,11 @@ func (r *EventRepository) UpdateEventDetails(ctx context.Context, userID int, ro
 	}
 	defer tx.Rollback()
 
+	// If-Match: sự kiện phải chưa bị sửa kể từ lần client tải chi tiết
+	if err := checkEventVersion(ctx, tx, updateReq.EventID, updateReq.ExpectedVersion); err != nil {
+		return err
+	}
+
 	// ✅ STEP 1: Verify Event exists and user has permission
 	var eventOwnerID int
 	var currentStatus string
@@ -1767,9 +1382,15 @@ func (r *EventRepository) UpdateEventDetails(ctx context.Context, userID int, ro
 		return fmt.Errorf("failed to verify event: %w", err)
 	}
 
-	// Check permission: only event owner (ORGANIZER) can update
+	// Check permission: event owner hoặc co-organizer có quyền EDIT_DETAILS
 	if role == "ORGANIZER" && eventOwnerID != userID {
-		return fmt.Errorf("you don't have permission to update this event")
+		allowed, err := checkEventPermission(ctx, tx, updateReq.EventID, userID, models.PermissionEditDetails)
+		if err != nil {
+			return err
+		}
+		if !allowed {
+			return fmt.Errorf("you don't have permission to update this event")
+		}
 	}
 
 	// Only allow update for OPEN or UPDATING events
@@ -1777,6 +1398,17 @@ func (r *EventRepository) UpdateEventDetails(ctx context.Context, userID int, ro
 		return fmt.Errorf("cannot update event with status: %s", currentStatus)
 	}
 
+	// Mốc khóa chỉnh sửa: organizer chỉ còn sửa banner khi đã sát giờ bắt đầu (ADMIN không bị khóa)
+	if role == "ORGANIZER" {
+		eligibility, err := eventEditEligibility(ctx, tx, updateReq.EventID, updateReq.ChangedFields())
+		if err != nil {
+			return fmt.Errorf("failed to check edit window: %w", err)
+		}
+		if eligibility != nil {
+			return eligibility
+		}
+	}
+
 	log.Printf("[UpdateEventDetails] Event verified. Owner=%d, Status=%s", eventOwnerID, currentStatus)
 
 	// ✅ STEP 1.5: Get area_id and check for existing bookings
@@ -1837,6 +1469,10 @@ func (r *EventRepository) UpdateEventDetails(ctx context.Context, userID int, ro
 
 		log.Printf("[CHECK] Processing Speaker: fullName=%s, bio=%s, email=%s, phone=%s",
 			speakerFullName, speakerBio, speakerEmail, speakerPhone)
+		storedEmail, storedPhone, err := encryptSpeakerContact(speakerEmail, speakerPhone)
+		if err != nil {
+			return err
+		}
 
 		if !speakerID.Valid || speakerID.Int64 == 0 {
 			// INSERT new speaker
@@ -1845,7 +1481,7 @@ func (r *EventRepository) UpdateEventDetails(ctx context.Context, userID int, ro
 				VALUES (?, ?, ?, ?, ?)
 			`
 			log.Printf("[SQL_EXECUTE] INSERT Speaker for Event %d: fullName=%s", updateReq.EventID, speakerFullName)
-			result, err := tx.ExecContext(ctx, insertSpeakerQuery, speakerFullName, speakerBio, speakerEmail, speakerPhone, speakerAvatarURL)
+			result, err := tx.ExecContext(ctx, insertSpeakerQuery, speakerFullName, speakerBio, storedEmail, storedPhone, speakerAvatarURL)
 			if err != nil {
 				return fmt.Errorf("failed to insert speaker: %w", err)
 			}
@@ -1865,7 +1501,7 @@ func (r *EventRepository) UpdateEventDetails(ctx context.Context, userID int, ro
 				WHERE speaker_id = ?
 			`
 			log.Printf("[SQL_EXECUTE] UPDATE Speaker ID=%d for Event %d: fullName=%s", speakerID.Int64, updateReq.EventID, speakerFullName)
-			result, err := tx.ExecContext(ctx, updateSpeakerQuery, speakerFullName, speakerBio, speakerEmail, speakerPhone, speakerAvatarURL, speakerID.Int64)
+			result, err := tx.ExecContext(ctx, updateSpeakerQuery, speakerFullName, speakerBio, storedEmail, storedPhone, speakerAvatarURL, speakerID.Int64)
 			if err != nil {
 				return fmt.Errorf("failed to update speaker: %w", err)
 			}
@@ -1885,9 +1521,9 @@ func (r *EventRepository) UpdateEventDetails(ctx context.Context, userID int, ro
 		bannerURL = *updateReq.BannerURL
 	}
 
-	updateEventQuery := `UPDATE Event SET banner_url = ?, speaker_id = ? WHERE event_id = ?`
+	updateEventQuery := `UPDATE Event SET banner_url = ?, speaker_id = ?, allow_guest_checkout = COALESCE(?, allow_guest_checkout), version = version + 1 WHERE event_id = ?`
 	log.Printf("[SQL_EXECUTE] UPDATE Event ID=%d: speaker_id=%v, banner_url=%v", updateReq.EventID, speakerID, bannerURL)
-	result, err := tx.ExecContext(ctx, updateEventQuery, bannerURL, speakerID, updateReq.EventID)
+	result, err := tx.ExecContext(ctx, updateEventQuery, bannerURL, speakerID, updateReq.AllowGuestCheckout, updateReq.EventID)
 	if err != nil {
 		return fmt.Errorf("failed to update event: %w", err)
 	}
@@ -1899,170 +1535,43 @@ func (r *EventRepository) UpdateEventDetails(ctx context.Context, userID int, ro
 		log.Printf("[UpdateEventDetails] ✅ Updated Event ID=%d (rows affected: %d)", updateReq.EventID, rowsAffected)
 	}
 
-	// ✅ STEP 4: Handle TICKETS (DELETE old + INSERT new + Seat Allocation)
+	// Mô tả, lịch trình, FAQ (chỉ ghi trường có gửi)
+	if err := updatePageContentTx(ctx, tx, updateReq); err != nil {
+		return err
+	}
+
+	// ✅ STEP 4: Handle TICKETS (diff theo ID + Seat Allocation)
 	if len(updateReq.Tickets) > 0 {
 		log.Printf("[DIAGNOSTIC] Processing %d tickets", len(updateReq.Tickets))
 
-		// Only modify tickets if no bookings exist
+		// Đã có vé bán ra: áp dụng capacity guard (chỉ tăng, hoặc giảm tới số đã bán)
 		if hasBookings {
-			log.Printf("[UpdateEventDetails] Skipping ticket modification - existing bookings detected")
+			log.Printf("[UpdateEventDetails] Existing bookings detected - applying capacity guard")
+			if err := r.applyCapacityChangesTx(ctx, tx, updateReq.EventID, areaID, updateReq.Tickets); err != nil {
+				return err
+			}
 		} else {
-			// Delete old tickets
-			deleteTicketsQuery := `DELETE FROM category_ticket WHERE event_id = ?`
-			result, err := tx.ExecContext(ctx, deleteTicketsQuery, updateReq.EventID)
+			// Đồng bộ category theo ID (update tại chỗ / insert mới / soft-disable), không delete/reinsert
+			synced, _, err := r.syncCategoryTicketsTx(ctx, tx, updateReq.EventID, updateReq.Tickets)
 			if err != nil {
-				return fmt.Errorf("failed to delete old tickets: %w", err)
+				return err
 			}
-			rowsDeleted, _ := result.RowsAffected()
-			log.Printf("[UpdateEventDetails] Deleted %d old tickets", rowsDeleted)
 
-			// Reset seats to clear category_ticket_id linkage
-			if areaID.Valid {
-				resetSeatsQuery := `UPDATE Seat SET category_ticket_id = NULL WHERE area_id = ?`
-				resetResult, err := tx.ExecContext(ctx, resetSeatsQuery, areaID.Int64)
-				if err != nil {
-					return fmt.Errorf("failed to reset seats: %w", err)
-				}
-				seatsReset, _ := resetResult.RowsAffected()
-				log.Printf("[UpdateEventDetails] Reset %d seats for area_id=%d", seatsReset, areaID.Int64)
-			}
-
-			// Insert new tickets and collect for seat allocation
-			type ticketAllocation struct {
-				CategoryTicketID int64
-				Name             string
-				MaxQuantity      int
-				Price            float64
-			}
-			var ticketAllocations []ticketAllocation
-
-			for idx, ticket := range updateReq.Tickets {
-				// ✅ Round price
-				roundedPrice := math.Round(ticket.Price)
-
-				description := ""
-				if ticket.Description != nil {
-					description = *ticket.Description
-				}
-				status := "ACTIVE"
-				if ticket.Status != nil {
-					status = *ticket.Status
-				}
-
-				insertTicketQuery := `
-				INSERT INTO category_ticket (event_id, name, description, price, max_quantity, status)
-				VALUES (?, ?, ?, ?, ?, ?)
-			`
-				log.Printf("[SQL] Inserting ticket #%d: %s for event %d with price %.0f (maxQty=%d)",
-					idx+1, ticket.Name, updateReq.EventID, roundedPrice, ticket.MaxQuantity)
-
-				result, err := tx.ExecContext(ctx, insertTicketQuery, updateReq.EventID, ticket.Name, description, roundedPrice, ticket.MaxQuantity, status)
-				if err != nil {
-					log.Printf("[DIAGNOSTIC] Failed to insert ticket: %v", err)
-					return fmt.Errorf("failed to insert ticket: %w", err)
-				}
-
-				ticketID, _ := result.LastInsertId()
-				log.Printf("[UpdateEventDetails] ✅ Inserted ticket: %s (ID=%d)", ticket.Name, ticketID)
-
-				// Collect for seat allocation
-				ticketAllocations = append(ticketAllocations, ticketAllocation{
-					CategoryTicketID: ticketID,
-					Name:             ticket.Name,
-					MaxQuantity:      ticket.MaxQuantity,
-					Price:            roundedPrice,
-				})
-			}
-
-			log.Printf("[DIAGNOSTIC] Completed inserting %d tickets", len(updateReq.Tickets))
-
-			// ✅ SEAT ALLOCATION: Assign seats to tickets (VIP first, then STANDARD)
-			if areaID.Valid && len(ticketAllocations) > 0 {
-				// Sort tickets: VIP first (by name containing "VIP" and by price desc)
-				sort.Slice(ticketAllocations, func(i, j int) bool {
-					nameI := strings.ToUpper(ticketAllocations[i].Name)
-					nameJ := strings.ToUpper(ticketAllocations[j].Name)
-					vipI := strings.Contains(nameI, "VIP")
-					vipJ := strings.Contains(nameJ, "VIP")
-					if vipI != vipJ {
-						return vipI
-					}
-					return ticketAllocations[i].Price > ticketAllocations[j].Price
-				})
-
-				// Get all seats for this area
-				getSeatIDsQuery := `SELECT seat_id, seat_code, row_no, col_no FROM Seat WHERE area_id = ? ORDER BY row_no, col_no`
-				rows, err := tx.QueryContext(ctx, getSeatIDsQuery, areaID.Int64)
-				if err != nil {
-					return fmt.Errorf("failed to get seats: %w", err)
-				}
-
-				var seatIDs []int64
-				var seatCodes []string
-				for rows.Next() {
-					var seatID int64
-					var seatCode, rowNo, colNo string
-					if err := rows.Scan(&seatID, &seatCode, &rowNo, &colNo); err != nil {
-						rows.Close()
-						return fmt.Errorf("failed to scan seat: %w", err)
-					}
-					seatIDs = append(seatIDs, seatID)
-					seatCodes = append(seatCodes, seatCode)
-				}
-				rows.Close()
-
-				log.Printf("[DEBUG] Bat dau phan bo lai %d ghe cho Area %d", len(seatIDs), areaID.Int64)
-
-				// Calculate total seats needed
-				totalNeeded := 0
-				for _, ticket := range ticketAllocations {
-					totalNeeded += ticket.MaxQuantity
-				}
-
-				if len(seatIDs) < totalNeeded {
-					return fmt.Errorf("insufficient seats: have %d, need %d", len(seatIDs), totalNeeded)
-				}
+			log.Printf("[DIAGNOSTIC] Completed syncing %d tickets", len(updateReq.Tickets))
 
-				// Sequential allocation
-				seatIndex := 0
-				totalAllocated := 0
-				for _, ticket := range ticketAllocations {
-					startIndex := seatIndex
-					for count := 0; count < ticket.MaxQuantity; count++ {
-						if seatIndex >= len(seatIDs) {
-							return fmt.Errorf("ran out of seats at index %d", seatIndex)
-						}
-
-						seatID := seatIDs[seatIndex]
-						updateSeatQuery := `UPDATE Seat SET category_ticket_id = ? WHERE seat_id = ?`
-						result, err := tx.ExecContext(ctx, updateSeatQuery, ticket.CategoryTicketID, seatID)
-						if err != nil {
-							return fmt.Errorf("failed to update seat %d: %w", seatID, err)
-						}
-
-						rowsAffected, _ := result.RowsAffected()
-						if rowsAffected == 0 {
-							log.Printf("[WARNING] Seat %d update returned 0 rows", seatID)
-						}
-
-						seatIndex++
-						totalAllocated++
-					}
-
-					endIndex := seatIndex - 1
-					endSeat := ""
-					if endIndex < len(seatCodes) {
-						endSeat = seatCodes[endIndex]
-					}
-					log.Printf("[UpdateEventRequest] Allocated %d seats %s to %s for ticket: %s",
-						ticket.MaxQuantity, seatCodes[startIndex], endSeat, ticket.Name)
+			// Chưa có vé bán nên phân bổ lại toàn bộ ghế của khu vực (cùng SeatAllocator với UpdateEventRequest)
+			if areaID.Valid {
+				categories := make([]SeatCategory, 0, len(synced))
+				for _, c := range synced {
+					categories = append(categories, SeatCategory{
+						CategoryTicketID: int64(c.ID),
+						Name:             c.Name,
+						Price:            c.Price,
+						Quantity:         c.MaxQuantity,
+					})
 				}
-
-				log.Printf("[UpdateEventDetails] ✅ Seat allocation complete: %d/%d seats assigned", totalAllocated, len(seatIDs))
-
-				// Verify allocation integrity
-				if totalAllocated != totalNeeded {
-					return fmt.Errorf("seat allocation mismatch: allocated %d, needed %d", totalAllocated, totalNeeded)
+				if err := defaultSeatAllocator().AllocateTx(ctx, tx, areaID.Int64, 0, categories); err != nil {
+					return err
 				}
 			}
 		}
@@ -2082,14 +1591,26 @@ func (r *EventRepository) UpdateEventDetails(ctx context.Context, userID int, ro
 }
 
 func (r *EventRepository) UpdateEventConfig(ctx context.Context, userID int, role string, req interface{}) error {
-	return nil
+	configReq, ok := req.(*models.UpdateEventConfigRequest)
+	if !ok || configReq.EventID <= 0 || configReq.ExpectedVersion == nil {
+		return nil
+	}
+	// Cấu hình riêng chưa lưu vào Event, chỉ kiểm tra If-Match để client biết dữ liệu đã cũ
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+	return checkEventVersion(ctx, tx, configReq.EventID, configReq.ExpectedVersion)
 }
 
+// GetEventConfigById - Chưa có nơi lưu cấu hình riêng (xem Capabilities), luôn trả nil để dùng cấu hình global
 func (r *EventRepository) GetEventConfigById(ctx context.Context, eventID int) (*models.EventConfigResponse, error) {
 	return nil, nil // Returns nil if no per-event config exists
 }
 
-func (r *EventRepository) GetAvailableAreas(ctx context.Context, startTime, endTime string, expectedCapacity int) ([]models.AvailableAreaInfo, error) {
+// campusID != nil: chỉ khu vực thuộc campus đó
+func (r *EventRepository) GetAvailableAreas(ctx context.Context, startTime, endTime string, expectedCapacity int, campusID *int) ([]models.AvailableAreaInfo, error) {
 	// Parse event date from startTime (format: YYYY-MM-DDTHH:MM:SS or YYYY-MM-DD HH:MM:SS)
 	eventDate := ""
 	if len(startTime) >= 10 {
@@ -2103,7 +1624,7 @@ func (r *EventRepository) GetAvailableAreas(ctx context.Context, startTime, endT
 	// SQL Query:
 	// 1. Get all areas with capacity >= expectedCapacity
 	// 2. Count approved events on the same DATE (not time overlap)
-	// 3. Filter: only show areas with < 2 approved events on that date (max 2 events/day rule)
+	// 3. Filter: only show areas with fewer approved events on that date than rule.max_events_per_day
 	// 4. Sort by capacity ASC (smallest rooms first)
 	query := `
 		SELECT 
@@ -2113,6 +1634,8 @@ func (r *EventRepository) GetAvailableAreas(ctx context.Context, startTime, endT
 			va.floor,
 			COALESCE(va.capacity, 0) as capacity,
 			va.status,
+			v.campus_id,
+			va.amenities,
 			COUNT(e.event_id) as event_count_on_date
 		FROM Venue_Area va
 		INNER JOIN Venue v ON va.venue_id = v.venue_id
@@ -2120,12 +1643,13 @@ func (r *EventRepository) GetAvailableAreas(ctx context.Context, startTime, endT
 			AND DATE(e.start_time) = ?
 			AND e.status IN ('OPEN', 'APPROVED')
 		WHERE COALESCE(va.capacity, 0) >= ?
-		GROUP BY va.area_id, va.area_name, v.venue_name, va.floor, va.capacity, va.status
-		HAVING event_count_on_date < 2
+			AND (? IS NULL OR v.campus_id = ?)
+		GROUP BY va.area_id, va.area_name, v.venue_name, va.floor, va.capacity, va.status, v.campus_id, va.amenities
+		HAVING event_count_on_date < ?
 		ORDER BY COALESCE(va.capacity, 0) ASC
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, eventDate, expectedCapacity)
+	rows, err := r.db.QueryContext(ctx, query, eventDate, expectedCapacity, campusID, campusID, rules.MaxEventsPerDay(ctx))
 	if err != nil {
 		fmt.Printf("[ERROR] GetAvailableAreas query failed: %v\n", err)
 		return nil, fmt.Errorf("failed to query available areas: %w", err)
@@ -2135,17 +1659,19 @@ func (r *EventRepository) GetAvailableAreas(ctx context.Context, startTime, endT
 	var areas []models.AvailableAreaInfo
 	for rows.Next() {
 		var area models.AvailableAreaInfo
-		var floor sql.NullString
 		var capacity int
 		var eventCount int
+		var amenities string
 
 		err := rows.Scan(
 			&area.AreaID,
 			&area.AreaName,
 			&area.VenueName,
-			&floor,
+			&area.Floor,
 			&capacity,
 			&area.Status,
+			&area.CampusID,
+			&amenities,
 			&eventCount,
 		)
 		if err != nil {
@@ -2153,10 +1679,8 @@ func (r *EventRepository) GetAvailableAreas(ctx context.Context, startTime, endT
 			continue
 		}
 
-		if floor.Valid {
-			area.Floor = &floor.String
-		}
 		area.Capacity = &capacity
+		area.Amenities = amenity.Split(amenities)
 
 		fmt.Printf("[GetAvailableAreas] Found area: %s (ID: %d, Capacity: %d, EventsOnDate: %d)\n",
 			area.AreaName, area.AreaID, capacity, eventCount)
@@ -2172,154 +1696,55 @@ func (r *EventRepository) GetAvailableAreas(ctx context.Context, startTime, endT
 	return areas, nil
 }
 
-func (r *EventRepository) ReleaseAreaOnEventClose(ctx context.Context, eventID, areaID int) error {
-	return nil
-}
-
+// GetEventStats - Thống kê một sự kiện từ kho số liệu (Daily_Event_Stats) + phần realtime
+// Trả về nil nếu sự kiện không tồn tại
 func (r *EventRepository) GetEventStats(ctx context.Context, eventID int) (*models.EventStatsResponse, error) {
-	query := `
-		SELECT 
-			e.event_id,
-			e.title,
-			COUNT(DISTINCT t.ticket_id) as total_tickets,
-			COUNT(DISTINCT CASE WHEN t.checkin_time IS NOT NULL THEN t.ticket_id END) as checked_in,
-			COUNT(DISTINCT CASE WHEN t.check_out_time IS NOT NULL THEN t.ticket_id END) as checked_out,
-			COUNT(DISTINCT CASE WHEN t.status = 'BOOKED' THEN t.ticket_id END) as booked,
-			COUNT(DISTINCT CASE WHEN t.status = 'CANCELLED' THEN t.ticket_id END) as cancelled,
-			COUNT(DISTINCT CASE WHEN t.status = 'REFUNDED' THEN t.ticket_id END) as refunded,
-			COALESCE(SUM(ct.price), 0) as total_revenue
-		FROM Event e
-		LEFT JOIN Category_Ticket ct ON e.event_id = ct.event_id
-		LEFT JOIN Ticket t ON ct.category_ticket_id = t.category_ticket_id 
-			AND t.status IN ('BOOKED', 'CHECKED_IN', 'CHECKED_OUT', 'REFUNDED')
-		WHERE e.event_id = ?
-		GROUP BY e.event_id, e.title
-	`
-
-	log.Printf("[STATS_QUERY] Executing single event stats for EventID=%d:\n%s", eventID, query)
-
-	var stats models.EventStatsResponse
 	var eventTitle string
-	err := r.db.QueryRowContext(ctx, query, eventID).Scan(
-		&stats.EventID,
-		&eventTitle,
-		&stats.TotalTickets,
-		&stats.CheckedInCount,
-		&stats.CheckedOutCount,
-		&stats.BookedCount,
-		&stats.CancelledCount,
-		&stats.RefundedCount,
-		&stats.TotalRevenue,
-	)
-
+	err := r.db.QueryRowContext(ctx, `SELECT title FROM Event WHERE event_id = ?`, eventID).Scan(&eventTitle)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, nil
-		}
 		return nil, fmt.Errorf("failed to get event stats: %w", err)
 	}
 
+	since, err := r.RealtimeSince(ctx)
+	if err != nil {
+		return nil, err
+	}
+	stats, err := r.warehouseEventStats(ctx, since, "e.event_id = ?", eventID)
+	if err != nil {
+		return nil, err
+	}
+	stats.EventID = eventID
 	stats.EventTitle = &eventTitle
-	log.Printf("[STATS_RESULT] EventID=%d: Total=%d, CheckedIn=%d, Refunded=%d, Revenue=%.2f",
-		eventID, stats.TotalTickets, stats.CheckedInCount, stats.RefundedCount, stats.TotalRevenue)
 
-	return &stats, nil
+	log.Printf("[STATS_RESULT] EventID=%d: Total=%d, CheckedIn=%d, Refunded=%d, Revenue=%.2f, RealtimeSince=%s",
+		eventID, stats.TotalTickets, stats.CheckedInCount, stats.RefundedCount, stats.TotalRevenue, since.Format("2006-01-02"))
+	return stats, nil
 }
 
+// GetAggregateEventStats - Thống kê tổng hợp: ADMIN/STAFF xem mọi sự kiện,
+// ORGANIZER xem sự kiện của mình và sự kiện được cấp quyền VIEW_STATS
 func (r *EventRepository) GetAggregateEventStats(ctx context.Context, role string, userID int) (*models.EventStatsResponse, error) {
-	// Build query based on role
-	var query string
+	filter := "1 = 1"
 	var args []interface{}
-
-	if role == "ADMIN" || role == "STAFF" {
-		// ADMIN/STAFF sees ALL events in the system
-		query = `
-			SELECT 
-				0 as event_id,
-				COUNT(DISTINCT t.ticket_id) as total_tickets,
-				COUNT(DISTINCT CASE WHEN t.checkin_time IS NOT NULL THEN t.ticket_id END) as checked_in,
-				COUNT(DISTINCT CASE WHEN t.check_out_time IS NOT NULL THEN t.ticket_id END) as checked_out,
-				COUNT(DISTINCT CASE WHEN t.status = 'BOOKED' THEN t.ticket_id END) as booked,
-				COUNT(DISTINCT CASE WHEN t.status = 'CANCELLED' THEN t.ticket_id END) as cancelled,
-				COUNT(DISTINCT CASE WHEN t.status = 'REFUNDED' THEN t.ticket_id END) as refunded,
-				COALESCE(SUM(ct.price), 0) as total_revenue
-			FROM Ticket t
-			INNER JOIN Category_Ticket ct ON t.category_ticket_id = ct.category_ticket_id
-			INNER JOIN Event e ON ct.event_id = e.event_id
-			WHERE t.status IN ('BOOKED', 'CHECKED_IN', 'CHECKED_OUT', 'REFUNDED')
-		`
-		log.Printf("[STATS_QUERY] ADMIN/STAFF viewing ALL events (no user filter)")
-	} else if role == "ORGANIZER" {
-		// ORGANIZER only sees their own events
-		query = `
-			SELECT 
-				0 as event_id,
-				COUNT(DISTINCT t.ticket_id) as total_tickets,
-				COUNT(DISTINCT CASE WHEN t.checkin_time IS NOT NULL THEN t.ticket_id END) as checked_in,
-				COUNT(DISTINCT CASE WHEN t.check_out_time IS NOT NULL THEN t.ticket_id END) as checked_out,
-				COUNT(DISTINCT CASE WHEN t.status = 'BOOKED' THEN t.ticket_id END) as booked,
-				COUNT(DISTINCT CASE WHEN t.status = 'CANCELLED' THEN t.ticket_id END) as cancelled,
-				COUNT(DISTINCT CASE WHEN t.status = 'REFUNDED' THEN t.ticket_id END) as refunded,
-				COALESCE(SUM(ct.price), 0) as total_revenue
-			FROM Ticket t
-			INNER JOIN Category_Ticket ct ON t.category_ticket_id = ct.category_ticket_id
-			INNER JOIN Event e ON ct.event_id = e.event_id
-			WHERE t.status IN ('BOOKED', 'CHECKED_IN', 'CHECKED_OUT', 'REFUNDED')
-			AND e.organizer_id = ?
-		`
-		args = append(args, userID)
-		log.Printf("[STATS_QUERY] ORGANIZER (UserID=%d) viewing their events only", userID)
-	} else {
-		// Other roles not allowed
+	switch role {
+	case "ADMIN", "STAFF":
+	case "ORGANIZER":
+		filter = collaboratorPermissionSQL
+		args = append(args, userID, userID, models.PermissionViewStats)
+	default:
 		return nil, fmt.Errorf("unauthorized role: %s", role)
 	}
 
-	log.Printf("[STATS_QUERY] Executing aggregate stats:\n%s\nArgs: %v", query, args)
-
-	var stats models.EventStatsResponse
-	var err error
-
-	if len(args) > 0 {
-		err = r.db.QueryRowContext(ctx, query, args...).Scan(
-			&stats.EventID,
-			&stats.TotalTickets,
-			&stats.CheckedInCount,
-			&stats.CheckedOutCount,
-			&stats.BookedCount,
-			&stats.CancelledCount,
-			&stats.RefundedCount,
-			&stats.TotalRevenue,
-		)
-	} else {
-		err = r.db.QueryRowContext(ctx, query).Scan(
-			&stats.EventID,
-			&stats.TotalTickets,
-			&stats.CheckedInCount,
-			&stats.CheckedOutCount,
-			&stats.BookedCount,
-			&stats.CancelledCount,
-			&stats.RefundedCount,
-			&stats.TotalRevenue,
-		)
+	since, err := r.RealtimeSince(ctx)
+	if err != nil {
+		return nil, err
 	}
-
+	stats, err := r.warehouseEventStats(ctx, since, filter, args...)
 	if err != nil {
 		log.Printf("[STATS_ERROR] Failed to execute aggregate stats query: %v", err)
-		if err == sql.ErrNoRows {
-			// Return zero stats instead of nil
-			title := "Tất cả sự kiện"
-			return &models.EventStatsResponse{
-				EventID:         0,
-				EventTitle:      &title,
-				TotalTickets:    0,
-				CheckedInCount:  0,
-				CheckedOutCount: 0,
-				BookedCount:     0,
-				CancelledCount:  0,
-				RefundedCount:   0,
-				TotalRevenue:    0,
-			}, nil
-		}
 		return nil, fmt.Errorf("failed to get aggregate stats: %w", err)
 	}
 
@@ -2329,7 +1754,7 @@ func (r *EventRepository) GetAggregateEventStats(ctx context.Context, role strin
 	log.Printf("[STATS_RESULT] Aggregate for Role=%s, UserID=%d: Total=%d, CheckedIn=%d, CheckedOut=%d, Refunded=%d, Revenue=%.2f",
 		role, userID, stats.TotalTickets, stats.CheckedInCount, stats.CheckedOutCount, stats.RefundedCount, stats.TotalRevenue)
 
-	return &stats, nil
+	return stats, nil
 }
 
 func (r *EventRepository) CancelEvent(ctx context.Context, userID, eventID int) error {
@@ -2341,14 +1766,15 @@ func (r *EventRepository) CancelEvent(ctx context.Context, userID, eventID int)
 	var requestID sql.NullInt64
 	var startTime time.Time
 	var eventTitle string
+	var cancelCutoffHours sql.NullInt64
 
 	checkQuery := `
-		SELECT e.status, e.created_by, e.start_time, e.title,
+		SELECT e.status, e.created_by, e.start_time, e.title, e.cancel_cutoff_hours,
 		       (SELECT request_id FROM Event_Request WHERE created_event_id = e.event_id LIMIT 1) as request_id
 		FROM Event e
 		WHERE e.event_id = ?
 	`
-	err := r.db.QueryRowContext(ctx, checkQuery, eventID).Scan(&status, &createdBy, &startTime, &eventTitle, &requestID)
+	err := r.db.QueryRowContext(ctx, checkQuery, eventID).Scan(&status, &createdBy, &startTime, &eventTitle, &cancelCutoffHours, &requestID)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			log.Printf("[DB_UPDATE] Event ID %d not found", eventID)
@@ -2370,14 +1796,16 @@ func (r *EventRepository) CancelEvent(ctx context.Context, userID, eventID int)
 		return fmt.Errorf("sự kiện đã được hủy trước đó")
 	}
 
-	// Step 4: ✅ 24-HOUR RULE - Không cho phép hủy nếu còn dưới 24 giờ
+	// Step 4: ✅ CANCEL CUTOFF - Không cho phép hủy khi còn dưới rule.cancel_cutoff_hours (mặc định 24 giờ)
+	// Event.cancel_cutoff_hours ghi đè quy tắc hệ thống
 	now := time.Now()
+	cutoff := rules.CancelCutoff(ctx, cancelCutoffHours)
 	hoursUntilStart := startTime.Sub(now).Hours()
-	if hoursUntilStart < 24 {
-		log.Printf("[DB_UPDATE] ❌ REJECTED: Cannot cancel event %d - only %.1f hours until start (< 24h)", eventID, hoursUntilStart)
-		return fmt.Errorf("không thể hủy sự kiện trong vòng 24 giờ trước khi bắt đầu (còn %.1f giờ)", hoursUntilStart)
+	if startTime.Sub(now) < cutoff {
+		log.Printf("[DB_UPDATE] ❌ REJECTED: Cannot cancel event %d - only %.1f hours until start (< %.0fh)", eventID, hoursUntilStart, cutoff.Hours())
+		return fmt.Errorf("không thể hủy sự kiện trong vòng %.0f giờ trước khi bắt đầu (còn %.1f giờ)", cutoff.Hours(), hoursUntilStart)
 	}
-	log.Printf("[DB_UPDATE] ✅ 24h rule passed: %.1f hours until start", hoursUntilStart)
+	log.Printf("[DB_UPDATE] ✅ %.0fh cancel rule passed: %.1f hours until start", cutoff.Hours(), hoursUntilStart)
 
 	// Step 5: ✅ REFUND WARNING - Kiểm tra số lượng vé đã bán
 	var ticketsSoldCount int
@@ -2410,7 +1838,7 @@ func (r *EventRepository) CancelEvent(ctx context.Context, userID, eventID int)
 	// Step 7: Update Event status to CANCELLED
 	updateEventQuery := `
 		UPDATE Event 
-		SET status = 'CANCELLED' 
+		SET status = 'CANCELLED', version = version + 1
 		WHERE event_id = ? AND created_by = ?
 	`
 	result1, err := tx.ExecContext(ctx, updateEventQuery, eventID, userID)
@@ -2430,7 +1858,7 @@ func (r *EventRepository) CancelEvent(ctx context.Context, userID, eventID int)
 		reqID := int(requestID.Int64)
 		updateRequestQuery := `
 			UPDATE Event_Request 
-			SET status = 'CANCELLED' 
+			SET status = 'CANCELLED', version = version + 1
 			WHERE request_id = ?
 		`
 		result2, err := tx.ExecContext(ctx, updateRequestQuery, reqID)
@@ -2445,33 +1873,9 @@ func (r *EventRepository) CancelEvent(ctx context.Context, userID, eventID int)
 		log.Printf("[DB_UPDATE] No linked Event_Request found for Event ID %d", eventID)
 	}
 
-	// Step 9: ✅ RELEASE VENUE AREA - Giải phóng địa điểm nếu sự kiện đã được duyệt
-	// Lấy area_id từ Event để giải phóng
-	var areaID sql.NullInt64
-	areaQuery := `SELECT area_id FROM Event WHERE event_id = ?`
-	err = tx.QueryRowContext(ctx, areaQuery, eventID).Scan(&areaID)
-	if err == nil && areaID.Valid {
-		// Cập nhật venue_area status thành AVAILABLE để có thể đặt lại
-		releaseQuery := `
-			UPDATE Venue_Area 
-			SET status = 'AVAILABLE' 
-			WHERE area_id = ? AND status = 'UNAVAILABLE'
-		`
-		result3, err := tx.ExecContext(ctx, releaseQuery, areaID.Int64)
-		if err != nil {
-			log.Printf("[DB_UPDATE] Failed to release venue area: %v", err)
-			return fmt.Errorf("lỗi giải phóng địa điểm: %w", err)
-		}
-
-		rowsAffected3, _ := result3.RowsAffected()
-		if rowsAffected3 > 0 {
-			log.Printf("[DB_PROCESS] Successfully RELEASED Area [%d] to AVAILABLE after Cancellation of Event [%d]", areaID.Int64, eventID)
-		}
-	} else if err != nil && err != sql.ErrNoRows {
-		log.Printf("[DB_UPDATE] Warning: Failed to query area_id for event %d: %v", eventID, err)
-	}
+	// Khu vực được giải phóng bởi subscriber EventCancelled sau khi commit (usecase publish)
 
-	// Step 10: Commit transaction
+	// Step 9: Commit transaction
 	if err := tx.Commit(); err != nil {
 		log.Printf("[DB_UPDATE] Failed to commit transaction: %v", err)
 		return fmt.Errorf("lỗi commit transaction: %w", err)
@@ -2481,7 +1885,8 @@ func (r *EventRepository) CancelEvent(ctx context.Context, userID, eventID int)
 	return nil
 }
 
-func (r *EventRepository) CancelEventRequest(ctx context.Context, userID, requestID int) error {
+// CancelEventRequest - Rút yêu cầu; trả về event_id của sự kiện bị hủy kèm (0 nếu yêu cầu chưa được duyệt)
+func (r *EventRepository) CancelEventRequest(ctx context.Context, userID, requestID int) (int, error) {
 	log.Printf("[DB_UPDATE] Starting cancel for RequestID=%d, UserID=%d", requestID, userID)
 
 	// Step 1: Get request info and verify ownership
@@ -2498,22 +1903,22 @@ func (r *EventRepository) CancelEventRequest(ctx context.Context, userID, reques
 	if err != nil {
 		if err == sql.ErrNoRows {
 			log.Printf("[DB_UPDATE] Request ID %d not found", requestID)
-			return fmt.Errorf("yêu cầu không tồn tại")
+			return 0, fmt.Errorf("yêu cầu không tồn tại")
 		}
 		log.Printf("[DB_UPDATE] Query error: %v", err)
-		return fmt.Errorf("lỗi kiểm tra yêu cầu: %w", err)
+		return 0, fmt.Errorf("lỗi kiểm tra yêu cầu: %w", err)
 	}
 
 	// Verify ownership
 	if requesterID != userID {
 		log.Printf("[DB_UPDATE] User %d tried to cancel request %d owned by %d", userID, requestID, requesterID)
-		return fmt.Errorf("bạn không có quyền hủy yêu cầu này")
+		return 0, fmt.Errorf("bạn không có quyền hủy yêu cầu này")
 	}
 
 	// Check if already cancelled
 	if status == "CANCELLED" {
 		log.Printf("[DB_UPDATE] Request %d already cancelled", requestID)
-		return fmt.Errorf("yêu cầu đã được hủy trước đó")
+		return 0, fmt.Errorf("yêu cầu đã được hủy trước đó")
 	}
 
 	// Case 1: No created_event_id (chưa được duyệt) - Simple UPDATE
@@ -2522,28 +1927,28 @@ func (r *EventRepository) CancelEventRequest(ctx context.Context, userID, reques
 
 		updateQuery := `
 			UPDATE Event_Request 
-			SET status = 'CANCELLED' 
+			SET status = 'CANCELLED', version = version + 1
 			WHERE request_id = ? AND requester_id = ?
 		`
 		result, err := r.db.ExecContext(ctx, updateQuery, requestID, userID)
 		if err != nil {
 			log.Printf("[DB_UPDATE] Failed to update request: %v", err)
-			return fmt.Errorf("lỗi cập nhật yêu cầu: %w", err)
+			return 0, fmt.Errorf("lỗi cập nhật yêu cầu: %w", err)
 		}
 
 		rowsAffected, err := result.RowsAffected()
 		if err != nil {
 			log.Printf("[DB_UPDATE] Failed to get rows affected: %v", err)
-			return fmt.Errorf("lỗi kiểm tra kết quả: %w", err)
+			return 0, fmt.Errorf("lỗi kiểm tra kết quả: %w", err)
 		}
 
 		if rowsAffected == 0 {
 			log.Printf("[DB_UPDATE] No rows affected for request %d", requestID)
-			return fmt.Errorf("không thể hủy yêu cầu")
+			return 0, fmt.Errorf("không thể hủy yêu cầu")
 		}
 
 		log.Printf("[DB_UPDATE] Cancelled Request ID: %d (Linked Event: none)", requestID)
-		return nil
+		return 0, nil
 	}
 
 	// Case 2: Has created_event_id (đã được duyệt) - Transaction với cả 2 bảng
@@ -2554,115 +1959,61 @@ func (r *EventRepository) CancelEventRequest(ctx context.Context, userID, reques
 	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
 		log.Printf("[DB_UPDATE] Failed to start transaction: %v", err)
-		return fmt.Errorf("lỗi khởi tạo transaction: %w", err)
+		return 0, fmt.Errorf("lỗi khởi tạo transaction: %w", err)
 	}
 	defer tx.Rollback()
 
 	// Update Event_Request
 	updateRequestQuery := `
 		UPDATE Event_Request 
-		SET status = 'CANCELLED' 
+		SET status = 'CANCELLED', version = version + 1
 		WHERE request_id = ?
 	`
 	result1, err := tx.ExecContext(ctx, updateRequestQuery, requestID)
 	if err != nil {
 		log.Printf("[DB_UPDATE] Failed to update Event_Request in transaction: %v", err)
-		return fmt.Errorf("lỗi cập nhật yêu cầu: %w", err)
+		return 0, fmt.Errorf("lỗi cập nhật yêu cầu: %w", err)
 	}
 
 	rowsAffected1, _ := result1.RowsAffected()
 	if rowsAffected1 == 0 {
 		log.Printf("[DB_UPDATE] No rows affected in Event_Request for ID %d", requestID)
-		return fmt.Errorf("không thể cập nhật yêu cầu")
+		return 0, fmt.Errorf("không thể cập nhật yêu cầu")
 	}
 
 	// Update Event
 	updateEventQuery := `
 		UPDATE Event 
-		SET status = 'CANCELLED' 
+		SET status = 'CANCELLED', version = version + 1
 		WHERE event_id = ?
 	`
 	result2, err := tx.ExecContext(ctx, updateEventQuery, eventID)
 	if err != nil {
 		log.Printf("[DB_UPDATE] Failed to update Event in transaction: %v", err)
-		return fmt.Errorf("lỗi cập nhật sự kiện: %w", err)
+		return 0, fmt.Errorf("lỗi cập nhật sự kiện: %w", err)
 	}
 
 	rowsAffected2, _ := result2.RowsAffected()
 	if rowsAffected2 == 0 {
 		log.Printf("[DB_UPDATE] No rows affected in Event for ID %d", eventID)
-		return fmt.Errorf("không thể cập nhật sự kiện")
+		return 0, fmt.Errorf("không thể cập nhật sự kiện")
 	}
 
-	// ✅ RELEASE VENUE AREA - Giải phóng địa điểm khi hủy yêu cầu đã được duyệt
-	// Lấy area_id từ Event để giải phóng
-	var areaID sql.NullInt64
-	areaQuery := `SELECT area_id FROM Event WHERE event_id = ?`
-	err = tx.QueryRowContext(ctx, areaQuery, eventID).Scan(&areaID)
-	if err == nil && areaID.Valid {
-		// Cập nhật venue_area status thành AVAILABLE để có thể đặt lại
-		releaseQuery := `
-			UPDATE Venue_Area 
-			SET status = 'AVAILABLE' 
-			WHERE area_id = ? AND status = 'UNAVAILABLE'
-		`
-		result3, err := tx.ExecContext(ctx, releaseQuery, areaID.Int64)
-		if err != nil {
-			log.Printf("[DB_UPDATE] Failed to release venue area: %v", err)
-			return fmt.Errorf("lỗi giải phóng địa điểm: %w", err)
-		}
-
-		rowsAffected3, _ := result3.RowsAffected()
-		if rowsAffected3 > 0 {
-			log.Printf("[DB_PROCESS] Successfully RELEASED Area [%d] to AVAILABLE after Cancellation of Request [%d]", areaID.Int64, requestID)
-		}
-	} else if err != nil && err != sql.ErrNoRows {
-		log.Printf("[DB_UPDATE] Warning: Failed to query area_id for event %d: %v", eventID, err)
-	}
+	// Khu vực được giải phóng bởi subscriber EventCancelled sau khi commit (usecase publish)
 
 	// Commit transaction
 	if err := tx.Commit(); err != nil {
 		log.Printf("[DB_UPDATE] Failed to commit transaction: %v", err)
-		return fmt.Errorf("lỗi commit transaction: %w", err)
+		return 0, fmt.Errorf("lỗi commit transaction: %w", err)
 	}
 
 	log.Printf("[DB_UPDATE] Cancelled Request ID: %d (Linked Event: %d)", requestID, eventID)
-	return nil
-}
-
-func (r *EventRepository) AutoReleaseVenues(ctx context.Context) error {
-	// Sử dụng câu lệnh SQL an toàn: Chỉ giải phóng venue_area không còn sự kiện OPEN hoặc UPDATING
-	updateQuery := `
-		UPDATE Venue_Area va
-		SET va.status = 'AVAILABLE'
-		WHERE va.status = 'UNAVAILABLE'
-		AND va.area_id NOT IN (
-			SELECT e.area_id 
-			FROM Event e 
-			WHERE e.status IN ('OPEN', 'UPDATING')
-			AND e.area_id IS NOT NULL
-		)
-	`
-
-	result, err := r.db.ExecContext(ctx, updateQuery)
-	if err != nil {
-		log.Printf("[JANITOR] ❌ Failed to release venue areas: %v", err)
-		return fmt.Errorf("failed to release venue areas: %w", err)
-	}
-
-	rowsAffected, _ := result.RowsAffected()
-	if rowsAffected > 0 {
-		log.Printf("[JANITOR] ✅ Da giai phong %d khu vuc khong con su kien hoat dong", rowsAffected)
-	} else {
-		log.Println("[JANITOR] No venue areas to release (all areas are in use or already available)")
-	}
-
-	return nil
+	return eventID, nil
 }
 
 func (r *EventRepository) CheckDailyQuota(ctx context.Context, eventDate string) (*models.CheckDailyQuotaResponse, error) {
 	// Query: Count approved/open events on the specific date
-	// Rule: Maximum 2 events per day
+	// Rule: rule.max_events_per_day (mặc định 2)
 	query := `
 		SELECT COUNT(*) as event_count
 		FROM Event
@@ -2677,7 +2028,7 @@ func (r *EventRepository) CheckDailyQuota(ctx context.Context, eventDate string)
 		return nil, fmt.Errorf("failed to check daily quota: %w", err)
 	}
 
-	maxAllowed := 2
+	maxAllowed := rules.MaxEventsPerDay(ctx)
 	quotaExceeded := currentCount >= maxAllowed
 	canApproveMore := currentCount < maxAllowed
 
@@ -2703,3 +2054,180 @@ func (r *EventRepository) CheckDailyQuota(ctx context.Context, eventDate string)
 		WarningMessage: warningMessage,
 	}, nil
 }
+
+// ============================================================
+// UpdateEventBannerVariants - Lưu URL banner gốc + các bản resize lên Event
+// ORGANIZER chỉ được cập nhật event của mình, ADMIN bypass ownership
+// ============================================================
+func (r *EventRepository) UpdateEventBannerVariants(ctx context.Context, userID int, role string, variants *models.BannerVariants) error {
+	var ownerID sql.NullInt64
+	var status string
+	err := r.db.QueryRowContext(ctx, `SELECT created_by, status FROM Event WHERE event_id = ?`, variants.EventID).Scan(&ownerID, &status)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("event not found")
+		}
+		return fmt.Errorf("failed to verify event: %w", err)
+	}
+
+	if role == "ORGANIZER" && (!ownerID.Valid || int(ownerID.Int64) != userID) {
+		allowed, err := r.CheckEventPermission(ctx, variants.EventID, userID, models.PermissionEditDetails)
+		if err != nil {
+			return err
+		}
+		if !allowed {
+			return fmt.Errorf("you are not the owner of this event")
+		}
+	}
+	if status == "CLOSED" || status == "CANCELLED" {
+		return fmt.Errorf("event is not editable")
+	}
+
+	_, err = r.db.ExecContext(ctx, `
+		UPDATE Event
+		SET banner_url = ?, banner_thumbnail_url = ?, banner_card_url = ?, banner_hero_url = ?,
+		    version = version + 1
+		WHERE event_id = ?
+	`, variants.OriginalURL, variants.ThumbnailURL, variants.CardURL, variants.HeroURL, variants.EventID)
+	if err != nil {
+		return fmt.Errorf("failed to update banner variants: %w", err)
+	}
+
+	log.Printf("[UpdateEventBannerVariants] ✅ Event %d banner updated (thumbnail=%s)", variants.EventID, variants.ThumbnailURL)
+	return nil
+}
+
+// ============================================================
+// CloneEventRequest - Nhân bản một event request thành request PENDING mới
+// Copy: title, description, expected_capacity, required_amenities, speaker + tickets (lưu vào draft_payload)
+// Bỏ qua: trạng thái xử lý, created_event_id, processed_by, note...
+// Chỉ người tạo request gốc mới được clone
+// ============================================================
+func (r *EventRepository) CloneEventRequest(ctx context.Context, requesterID, sourceRequestID int, body *models.CloneEventRequestBody) (int, error) {
+	source, err := r.GetEventRequestByID(ctx, sourceRequestID)
+	if err != nil {
+		return 0, err
+	}
+	if source == nil {
+		return 0, fmt.Errorf("event request not found")
+	}
+	if source.RequesterID != requesterID {
+		return 0, fmt.Errorf("you are not the owner of this event request")
+	}
+
+	title := source.Title
+	if body.Title != nil && strings.TrimSpace(*body.Title) != "" {
+		title = strings.TrimSpace(*body.Title)
+	}
+	description := source.Description
+	if body.Description != nil {
+		description = body.Description
+	}
+	capacity := source.ExpectedCapacity
+	if body.ExpectedCapacity != nil {
+		capacity = body.ExpectedCapacity
+	}
+
+	// Snapshot speaker + tickets (không copy ID để request mới độc lập với event cũ)
+	draft := models.EventRequestDraft{}
+	if source.Speaker != nil && strings.TrimSpace(source.Speaker.FullName) != "" {
+		sp := *source.Speaker
+		draft.Speaker = &sp
+	}
+	for _, t := range source.Tickets {
+		if t.Status == "INACTIVE" {
+			continue
+		}
+		draft.Tickets = append(draft.Tickets, models.CategoryTicketDTO{
+			Name:        t.Name,
+			Description: t.Description,
+			Price:       t.Price,
+			MaxQuantity: t.MaxQuantity,
+		})
+	}
+
+	var draftPayload interface{}
+	if draft.Speaker != nil || len(draft.Tickets) > 0 {
+		data, err := json.Marshal(draft)
+		if err != nil {
+			return 0, fmt.Errorf("failed to marshal draft: %w", err)
+		}
+		draftPayload = string(data)
+	}
+
+	result, err := r.db.ExecContext(ctx, `
+		INSERT INTO Event_Request
+		(requester_id, title, description, preferred_start_time, preferred_end_time, expected_capacity,
+		 status, created_at, cloned_from_request_id, draft_payload, required_amenities)
+		VALUES (?, ?, ?, ?, ?, ?, 'PENDING', NOW(), ?, ?, ?)
+	`, requesterID, title, description, body.PreferredStartTime, body.PreferredEndTime, capacity, sourceRequestID, draftPayload,
+		amenity.Join(source.RequiredAmenities))
+	if err != nil {
+		return 0, fmt.Errorf("failed to insert cloned event request: %w", err)
+	}
+
+	newID, err := result.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get last insert ID: %w", err)
+	}
+
+	log.Printf("[CloneEventRequest] ✅ Cloned request %d -> %d (tickets=%d, speaker=%v)",
+		sourceRequestID, newID, len(draft.Tickets), draft.Speaker != nil)
+	return int(newID), nil
+}
+
+// applyEventRequestDraftTx áp dụng draft_payload (speaker + tickets) vào Event vừa tạo khi APPROVE
+// Event vẫn ở trạng thái UPDATING nên Organizer có thể chỉnh lại trước khi mở bán
+func (r *EventRepository) applyEventRequestDraftTx(ctx context.Context, tx *sql.Tx, requestID int, eventID int64, applySpeaker bool) error {
+	var draftPayload string
+	err := tx.QueryRowContext(ctx, `SELECT draft_payload FROM Event_Request WHERE request_id = ?`, requestID).Scan(dbscan.OrZero(&draftPayload))
+	if err != nil {
+		return fmt.Errorf("failed to load draft: %w", err)
+	}
+	if draftPayload == "" {
+		return nil
+	}
+
+	var draft models.EventRequestDraft
+	if err := json.Unmarshal([]byte(draftPayload), &draft); err != nil {
+		// Draft hỏng không nên chặn việc duyệt request
+		log.Printf("[applyEventRequestDraftTx] Invalid draft_payload for request %d: %v", requestID, err)
+		return nil
+	}
+
+	if applySpeaker && draft.Speaker != nil && strings.TrimSpace(draft.Speaker.FullName) != "" {
+		sp := draft.Speaker
+		email, err := crypto.EncryptOptionalPII(sp.Email)
+		if err != nil {
+			return err
+		}
+		phone, err := crypto.EncryptOptionalPII(sp.Phone)
+		if err != nil {
+			return err
+		}
+		result, err := tx.ExecContext(ctx, `
+			INSERT INTO Speaker (full_name, bio, email, phone, avatar_url)
+			VALUES (?, ?, ?, ?, ?)
+		`, strings.TrimSpace(sp.FullName), sp.Bio, email, phone, sp.AvatarURL)
+		if err != nil {
+			return fmt.Errorf("failed to insert speaker: %w", err)
+		}
+		speakerID, _ := result.LastInsertId()
+		if _, err := tx.ExecContext(ctx, `UPDATE Event SET speaker_id = ?, version = version + 1 WHERE event_id = ?`, speakerID, eventID); err != nil {
+			return fmt.Errorf("failed to link speaker: %w", err)
+		}
+	}
+
+	for _, t := range draft.Tickets {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO category_ticket (event_id, name, description, price, max_quantity, status)
+			VALUES (?, ?, ?, ?, ?, 'ACTIVE')
+		`, eventID, t.Name, t.Description, math.Round(t.Price), t.MaxQuantity)
+		if err != nil {
+			return fmt.Errorf("failed to insert ticket %s: %w", t.Name, err)
+		}
+	}
+
+	log.Printf("[applyEventRequestDraftTx] Applied draft of request %d to event %d (tickets=%d)", requestID, eventID, len(draft.Tickets))
+	return nil
+}
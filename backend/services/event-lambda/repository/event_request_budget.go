@@ -0,0 +1,216 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/fpt-event-services/common/dbscan"
+	"github.com/fpt-event-services/services/event-lambda/models"
+)
+
+// ============================================================
+// Event_Request_Budget_Item / Event_Request_Budget_Review
+// Dự toán theo hạng mục, lịch sử duyệt ngân sách và thực chi sau sự kiện
+// ============================================================
+
+// Lỗi nghiệp vụ của ngân sách
+var (
+	ErrEventRequestNotFound = errors.New("event request not found")
+	ErrBudgetItemNotFound   = errors.New("budget item not found")
+	ErrBudgetItemLimit      = errors.New("too many budget items")
+)
+
+// budgetReviewStatusSQL - Quyết định duyệt ngân sách gần nhất của request (alias er)
+const budgetReviewStatusSQL = `(SELECT br.decision FROM Event_Request_Budget_Review br
+			WHERE br.request_id = er.request_id ORDER BY br.created_at DESC, br.review_id DESC LIMIT 1)`
+
+// EventRequestBudgetState - Thông tin request dùng để kiểm tra quyền/trạng thái ngân sách
+type EventRequestBudgetState struct {
+	RequesterID int
+	Status      string
+	EventEnded  bool // Đã có Event và Event đã kết thúc (được nhập thực chi)
+}
+
+// GetEventRequestBudgetState - ErrEventRequestNotFound nếu request không tồn tại
+func (r *EventRepository) GetEventRequestBudgetState(ctx context.Context, requestID int) (*EventRequestBudgetState, error) {
+	var st EventRequestBudgetState
+	err := r.db.QueryRowContext(ctx, `
+		SELECT er.requester_id, er.status, COALESCE(e.end_time <= NOW(), FALSE)
+		FROM Event_Request er
+		LEFT JOIN Event e ON e.event_id = er.created_event_id
+		WHERE er.request_id = ?
+	`, requestID).Scan(&st.RequesterID, &st.Status, &st.EventEnded)
+	if err == sql.ErrNoRows {
+		return nil, ErrEventRequestNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get event request: %w", err)
+	}
+	return &st, nil
+}
+
+// GetEventRequestBudget - Dự toán, lịch sử duyệt (mới nhất trước) và thực chi
+func (r *EventRepository) GetEventRequestBudget(ctx context.Context, requestID int) (*models.EventRequestBudget, error) {
+	b := &models.EventRequestBudget{RequestID: requestID, Items: []models.BudgetItem{}, Reviews: []models.BudgetReview{}}
+	err := r.db.QueryRowContext(ctx, `
+		SELECT status, requested_funding, actuals_note, actuals_submitted_at FROM Event_Request WHERE request_id = ?
+	`, requestID).Scan(&b.RequestStatus, &b.RequestedFunding, &b.ActualsNote, dbscan.RFC3339Ptr(&b.ActualsSubmittedAt))
+	if err == sql.ErrNoRows {
+		return nil, ErrEventRequestNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get event request budget: %w", err)
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT item_id, category, description, estimated_amount, actual_amount
+		FROM Event_Request_Budget_Item WHERE request_id = ? ORDER BY item_id
+	`, requestID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list budget items: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var item models.BudgetItem
+		if err := rows.Scan(&item.ItemID, &item.Category, &item.Description, &item.EstimatedAmount, &item.ActualAmount); err != nil {
+			return nil, err
+		}
+		b.Items = append(b.Items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	reviews, err := r.db.QueryContext(ctx, `
+		SELECT br.review_id, br.reviewer_id, u.full_name, br.decision, br.approved_funding, br.comment, br.created_at
+		FROM Event_Request_Budget_Review br
+		LEFT JOIN Users u ON u.user_id = br.reviewer_id
+		WHERE br.request_id = ?
+		ORDER BY br.created_at DESC, br.review_id DESC
+	`, requestID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list budget reviews: %w", err)
+	}
+	defer reviews.Close()
+	for reviews.Next() {
+		var rv models.BudgetReview
+		if err := reviews.Scan(&rv.ReviewID, &rv.ReviewerID, &rv.ReviewerName, &rv.Decision,
+			&rv.ApprovedFunding, &rv.Comment, dbscan.RFC3339(&rv.CreatedAt)); err != nil {
+			return nil, err
+		}
+		b.Reviews = append(b.Reviews, rv)
+	}
+	if err := reviews.Err(); err != nil {
+		return nil, err
+	}
+
+	b.Summarize()
+	return b, nil
+}
+
+// ReplaceEventRequestBudget - Thay số tiền xin tài trợ và toàn bộ hạng mục dự toán
+func (r *EventRepository) ReplaceEventRequestBudget(ctx context.Context, requestID int, budget *models.EventBudgetInput) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `UPDATE Event_Request SET requested_funding = ?, version = version + 1 WHERE request_id = ?`, budget.RequestedFunding, requestID); err != nil {
+		return fmt.Errorf("failed to update requested funding: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM Event_Request_Budget_Item WHERE request_id = ?`, requestID); err != nil {
+		return fmt.Errorf("failed to clear budget items: %w", err)
+	}
+	if err := insertBudgetItemsTx(ctx, tx, requestID, budget.Items); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// insertBudgetItemsTx - Thêm các dòng dự toán (thực chi để trống)
+func insertBudgetItemsTx(ctx context.Context, tx *sql.Tx, requestID int, items []models.BudgetItemInput) error {
+	for _, item := range items {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO Event_Request_Budget_Item (request_id, category, description, estimated_amount) VALUES (?, ?, ?, ?)
+		`, requestID, item.Category, item.Description, item.EstimatedAmount); err != nil {
+			return fmt.Errorf("failed to insert budget item: %w", err)
+		}
+	}
+	return nil
+}
+
+// CreateBudgetReview - Ghi một lần duyệt ngân sách
+func (r *EventRepository) CreateBudgetReview(ctx context.Context, requestID, reviewerID int, review *models.BudgetReviewRequest) error {
+	var comment *string
+	if review.Comment != "" {
+		comment = &review.Comment
+	}
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO Event_Request_Budget_Review (request_id, reviewer_id, decision, approved_funding, comment) VALUES (?, ?, ?, ?, ?)
+	`, requestID, reviewerID, review.Decision, review.ApprovedFunding, comment)
+	if err != nil {
+		return fmt.Errorf("failed to create budget review: %w", err)
+	}
+	return nil
+}
+
+// SaveBudgetActuals - Ghi thực chi: cập nhật hạng mục có itemId, thêm chi phí phát sinh, lưu ghi chú (nil giữ ghi chú cũ)
+func (r *EventRepository) SaveBudgetActuals(ctx context.Context, requestID int, actuals *models.BudgetActualsRequest, limit int) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, `SELECT item_id FROM Event_Request_Budget_Item WHERE request_id = ? FOR UPDATE`, requestID)
+	if err != nil {
+		return fmt.Errorf("failed to list budget items: %w", err)
+	}
+	existing := map[int]bool{}
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return err
+		}
+		existing[id] = true
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	count := len(existing)
+	for _, item := range actuals.Items {
+		if item.ItemID != nil {
+			if !existing[*item.ItemID] {
+				return fmt.Errorf("%w: %d", ErrBudgetItemNotFound, *item.ItemID)
+			}
+			if _, err := tx.ExecContext(ctx, `
+				UPDATE Event_Request_Budget_Item SET actual_amount = ? WHERE item_id = ? AND request_id = ?
+			`, item.ActualAmount, *item.ItemID, requestID); err != nil {
+				return fmt.Errorf("failed to update budget item: %w", err)
+			}
+			continue
+		}
+		if count++; count > limit {
+			return fmt.Errorf("%w: at most %d budget items", ErrBudgetItemLimit, limit)
+		}
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO Event_Request_Budget_Item (request_id, category, description, estimated_amount, actual_amount) VALUES (?, ?, ?, 0, ?)
+		`, requestID, item.Category, item.Description, item.ActualAmount); err != nil {
+			return fmt.Errorf("failed to insert budget item: %w", err)
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE Event_Request SET actuals_note = COALESCE(?, actuals_note), actuals_submitted_at = NOW(), version = version + 1
+		WHERE request_id = ?
+	`, actuals.Note, requestID); err != nil {
+		return fmt.Errorf("failed to save actuals note: %w", err)
+	}
+	return tx.Commit()
+}
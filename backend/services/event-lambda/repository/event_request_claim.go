@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/fpt-event-services/services/event-lambda/models"
+)
+
+// ============================================================
+// Event_Request.claimed_by - Hàng đợi duyệt yêu cầu của STAFF
+// Mỗi yêu cầu PENDING/UPDATING chỉ do một người duyệt giữ tại một thời điểm;
+// claim quá models.ClaimTTLMinutes phút không thao tác (claim_touched_at) coi như đã nhả
+// ============================================================
+
+// Lỗi nghiệp vụ của claim
+var (
+	ErrRequestClaimedByOther = errors.New("event request is being processed by another reviewer")
+	ErrRequestNotClaimable   = errors.New("event request is no longer pending")
+	ErrClaimNotHeld          = errors.New("you do not hold the claim on this event request")
+)
+
+// claimActiveSQL - Claim còn hiệu lực (alias er), tham số: số phút TTL
+const claimActiveSQL = `er.claimed_by IS NOT NULL AND er.claim_touched_at >= NOW() - INTERVAL ? MINUTE`
+
+// ClaimEventRequest - Nhận (hoặc gia hạn) claim cho reviewerID
+// Một câu UPDATE có điều kiện nên hai người nhận cùng lúc chỉ một người thắng
+func (r *EventRepository) ClaimEventRequest(ctx context.Context, requestID, reviewerID int) (*models.EventRequestClaim, error) {
+	// claimed_at được gán trước claimed_by: MySQL dùng giá trị mới của cột đã gán ở bên trái
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE Event_Request er
+		SET er.claimed_at = IF(er.claimed_by <=> ?, er.claimed_at, NOW()),
+		    er.claimed_by = ?,
+		    er.claim_touched_at = NOW()
+		WHERE er.request_id = ?
+		  AND er.status IN ('PENDING', 'UPDATING')
+		  AND (er.claimed_by IS NULL OR er.claimed_by = ? OR NOT (`+claimActiveSQL+`))
+	`, reviewerID, reviewerID, requestID, reviewerID, models.ClaimTTLMinutes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim event request: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		// Không đổi dòng nào: gọi lại trong cùng giây, hoặc bị chặn - xem trạng thái hiện tại
+		claim, err := r.GetEventRequestClaim(ctx, requestID)
+		if err != nil {
+			return nil, err
+		}
+		if claim == nil || claim.AssignedTo != reviewerID {
+			return nil, ErrRequestClaimedByOther
+		}
+		return claim, nil
+	}
+	claim, err := r.GetEventRequestClaim(ctx, requestID)
+	if err != nil {
+		return nil, err
+	}
+	if claim == nil {
+		return nil, fmt.Errorf("claim on event request %d disappeared", requestID)
+	}
+	return claim, nil
+}
+
+// GetEventRequestClaim - Claim còn hiệu lực của yêu cầu (nil nếu chưa ai nhận)
+// ErrEventRequestNotFound nếu request không tồn tại, ErrRequestNotClaimable nếu đã xử lý xong
+func (r *EventRepository) GetEventRequestClaim(ctx context.Context, requestID int) (*models.EventRequestClaim, error) {
+	var (
+		claim     = models.EventRequestClaim{RequestID: requestID}
+		status    string
+		claimedBy sql.NullInt64
+		claimedAt sql.NullTime
+		touchedAt sql.NullTime
+	)
+	err := r.db.QueryRowContext(ctx, `
+		SELECT er.status,
+		       IF(`+claimActiveSQL+`, er.claimed_by, NULL),
+		       u.full_name, er.claimed_at, er.claim_touched_at
+		FROM Event_Request er
+		LEFT JOIN Users u ON u.user_id = er.claimed_by
+		WHERE er.request_id = ?
+	`, models.ClaimTTLMinutes, requestID).Scan(&status, &claimedBy, &claim.AssignedToName, &claimedAt, &touchedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrEventRequestNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get event request claim: %w", err)
+	}
+	if status != "PENDING" && status != "UPDATING" {
+		return nil, ErrRequestNotClaimable
+	}
+	if !claimedBy.Valid || !claimedAt.Valid || !touchedAt.Valid {
+		return nil, nil
+	}
+	claim.AssignedTo = int(claimedBy.Int64)
+	claim.ClaimedAt = claimedAt.Time.Format(time.RFC3339)
+	claim.ExpiresAt = touchedAt.Time.Add(models.ClaimTTLMinutes * time.Minute).Format(time.RFC3339)
+	return &claim, nil
+}
+
+// ReleaseEventRequestClaim - Nhả claim; force = nhả cả claim của người khác
+func (r *EventRepository) ReleaseEventRequestClaim(ctx context.Context, requestID, reviewerID int, force bool) error {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE Event_Request
+		SET claimed_by = NULL, claimed_at = NULL, claim_touched_at = NULL
+		WHERE request_id = ? AND claimed_by IS NOT NULL AND (? OR claimed_by = ?)
+	`, requestID, force, reviewerID)
+	if err != nil {
+		return fmt.Errorf("failed to release event request claim: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n > 0 {
+		return nil
+	}
+
+	var exists bool
+	if err := r.db.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM Event_Request WHERE request_id = ?)`, requestID).Scan(&exists); err != nil {
+		return fmt.Errorf("failed to check event request: %w", err)
+	}
+	if !exists {
+		return ErrEventRequestNotFound
+	}
+	return ErrClaimNotHeld
+}
+
+// ReleaseStaleEventRequestClaims - Dọn claim hết hạn hoặc của yêu cầu đã xử lý xong (job event-request-claim-release)
+func (r *EventRepository) ReleaseStaleEventRequestClaims(ctx context.Context) (int64, error) {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE Event_Request er
+		SET er.claimed_by = NULL, er.claimed_at = NULL, er.claim_touched_at = NULL
+		WHERE er.claimed_by IS NOT NULL
+		  AND (er.status NOT IN ('PENDING', 'UPDATING') OR NOT (`+claimActiveSQL+`))
+	`, models.ClaimTTLMinutes)
+	if err != nil {
+		return 0, fmt.Errorf("failed to release stale claims: %w", err)
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,188 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/fpt-event-services/services/event-lambda/models"
+)
+
+// ============================================================
+// Sponsor / Event_Sponsor - Nhà tài trợ và hạng tài trợ theo sự kiện
+// Hồ sơ Sponsor dùng chung giữa các sự kiện; Event_Sponsor giữ hạng và thứ tự
+// ============================================================
+
+// Lỗi nghiệp vụ của nhà tài trợ
+var (
+	ErrSponsorNotFound      = errors.New("sponsor not found")
+	ErrEventSponsorNotFound = errors.New("sponsor is not linked to this event")
+	ErrSponsorAlreadyLinked = errors.New("sponsor is already linked to this event")
+	ErrTooManySponsors      = errors.New("too many sponsors for this event")
+)
+
+// sponsorOrderSQL - GOLD → SILVER → BRONZE → PARTNER, rồi display_order
+const sponsorOrderSQL = `FIELD(es.tier, 'GOLD', 'SILVER', 'BRONZE', 'PARTNER'), es.display_order, s.name`
+
+// ListEventSponsors - Nhà tài trợ của một sự kiện
+func (r *EventRepository) ListEventSponsors(ctx context.Context, eventID int) ([]models.EventSponsor, error) {
+	bySponsor, err := r.ListSponsorsByEvents(ctx, []int{eventID})
+	if err != nil {
+		return nil, err
+	}
+	if sponsors := bySponsor[eventID]; sponsors != nil {
+		return sponsors, nil
+	}
+	return []models.EventSponsor{}, nil
+}
+
+// ListSponsorsByEvents - Nhà tài trợ của nhiều sự kiện trong một query (dùng cho listing)
+func (r *EventRepository) ListSponsorsByEvents(ctx context.Context, eventIDs []int) (map[int][]models.EventSponsor, error) {
+	result := map[int][]models.EventSponsor{}
+	if len(eventIDs) == 0 {
+		return result, nil
+	}
+	args := make([]interface{}, len(eventIDs))
+	for i, id := range eventIDs {
+		args[i] = id
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT es.event_id, s.sponsor_id, s.name, es.tier, s.logo_url, s.website_url, es.display_order
+		FROM Event_Sponsor es
+		JOIN Sponsor s ON s.sponsor_id = es.sponsor_id
+		WHERE es.event_id IN (?`+strings.Repeat(",?", len(eventIDs)-1)+`)
+		ORDER BY es.event_id, `+sponsorOrderSQL, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sponsors: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var eventID int
+		var s models.EventSponsor
+		if err := rows.Scan(&eventID, &s.SponsorID, &s.Name, &s.Tier,
+			&s.LogoURL, &s.WebsiteURL, &s.DisplayOrder); err != nil {
+			return nil, err
+		}
+		result[eventID] = append(result[eventID], s)
+	}
+	return result, rows.Err()
+}
+
+// GetEventSponsor - Nhà tài trợ của sự kiện
+func (r *EventRepository) GetEventSponsor(ctx context.Context, eventID, sponsorID int) (*models.EventSponsor, error) {
+	var s models.EventSponsor
+	err := r.db.QueryRowContext(ctx, `
+		SELECT s.sponsor_id, s.name, es.tier, s.logo_url, s.website_url, es.display_order
+		FROM Event_Sponsor es
+		JOIN Sponsor s ON s.sponsor_id = es.sponsor_id
+		WHERE es.event_id = ? AND es.sponsor_id = ?
+	`, eventID, sponsorID).Scan(&s.SponsorID, &s.Name, &s.Tier, &s.LogoURL, &s.WebsiteURL, &s.DisplayOrder)
+	if err == sql.ErrNoRows {
+		return nil, ErrEventSponsorNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get event sponsor: %w", err)
+	}
+	return &s, nil
+}
+
+// GetSponsorCreator - Người tạo hồ sơ nhà tài trợ (người được sửa hồ sơ dùng chung)
+func (r *EventRepository) GetSponsorCreator(ctx context.Context, sponsorID int) (int, error) {
+	var createdBy int
+	err := r.db.QueryRowContext(ctx, `SELECT created_by FROM Sponsor WHERE sponsor_id = ?`, sponsorID).Scan(&createdBy)
+	if err == sql.ErrNoRows {
+		return 0, ErrSponsorNotFound
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to get sponsor: %w", err)
+	}
+	return createdBy, nil
+}
+
+// AddEventSponsor - Gắn nhà tài trợ vào sự kiện; sponsor.SponsorID = 0 thì tạo hồ sơ mới
+// Chạy trong transaction để không để lại hồ sơ mồ côi khi gắn thất bại
+func (r *EventRepository) AddEventSponsor(ctx context.Context, eventID, userID int, sponsor *models.EventSponsor, limit int) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var count int
+	if err := tx.QueryRowContext(ctx, `SELECT COUNT(*) FROM Event_Sponsor WHERE event_id = ? FOR UPDATE`, eventID).Scan(&count); err != nil {
+		return fmt.Errorf("failed to count sponsors: %w", err)
+	}
+	if count >= limit {
+		return ErrTooManySponsors
+	}
+
+	if sponsor.SponsorID == 0 {
+		result, err := tx.ExecContext(ctx, `
+			INSERT INTO Sponsor (name, logo_url, website_url, created_by) VALUES (?, ?, ?, ?)
+		`, sponsor.Name, sponsor.LogoURL, sponsor.WebsiteURL, userID)
+		if err != nil {
+			return fmt.Errorf("failed to create sponsor: %w", err)
+		}
+		id, err := result.LastInsertId()
+		if err != nil {
+			return fmt.Errorf("failed to get sponsor id: %w", err)
+		}
+		sponsor.SponsorID = int(id)
+	} else {
+		var linked bool
+		if err := tx.QueryRowContext(ctx, `
+			SELECT EXISTS (SELECT 1 FROM Event_Sponsor WHERE event_id = ? AND sponsor_id = ?)
+		`, eventID, sponsor.SponsorID).Scan(&linked); err != nil {
+			return fmt.Errorf("failed to check event sponsor: %w", err)
+		}
+		if linked {
+			return ErrSponsorAlreadyLinked
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO Event_Sponsor (event_id, sponsor_id, tier, display_order) VALUES (?, ?, ?, ?)
+	`, eventID, sponsor.SponsorID, sponsor.Tier, sponsor.DisplayOrder); err != nil {
+		return fmt.Errorf("failed to link sponsor: %w", err)
+	}
+	return tx.Commit()
+}
+
+// UpdateEventSponsor - Lưu hạng/thứ tự; updateProfile = true thì lưu cả hồ sơ dùng chung
+func (r *EventRepository) UpdateEventSponsor(ctx context.Context, eventID int, sponsor *models.EventSponsor, updateProfile bool) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE Event_Sponsor SET tier = ?, display_order = ? WHERE event_id = ? AND sponsor_id = ?
+	`, sponsor.Tier, sponsor.DisplayOrder, eventID, sponsor.SponsorID); err != nil {
+		return fmt.Errorf("failed to update event sponsor: %w", err)
+	}
+	if updateProfile {
+		if _, err := tx.ExecContext(ctx, `
+			UPDATE Sponsor SET name = ?, logo_url = ?, website_url = ? WHERE sponsor_id = ?
+		`, sponsor.Name, sponsor.LogoURL, sponsor.WebsiteURL, sponsor.SponsorID); err != nil {
+			return fmt.Errorf("failed to update sponsor: %w", err)
+		}
+	}
+	return tx.Commit()
+}
+
+// RemoveEventSponsor - Gỡ nhà tài trợ khỏi sự kiện (hồ sơ Sponsor giữ lại để dùng cho sự kiện khác)
+func (r *EventRepository) RemoveEventSponsor(ctx context.Context, eventID, sponsorID int) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM Event_Sponsor WHERE event_id = ? AND sponsor_id = ?`, eventID, sponsorID)
+	if err != nil {
+		return fmt.Errorf("failed to remove sponsor: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return ErrEventSponsorNotFound
+	}
+	return nil
+}
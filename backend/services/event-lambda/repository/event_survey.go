@@ -0,0 +1,221 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/fpt-event-services/common/db"
+	"github.com/fpt-event-services/common/dbscan"
+	"github.com/fpt-event-services/services/event-lambda/models"
+)
+
+// ============================================================
+// Event_Survey / Survey_Question / Survey_Response / Survey_Answer
+// Khảo sát sau sự kiện: mỗi sự kiện một bộ câu hỏi, mỗi người trả lời một lần
+// ============================================================
+
+// Lỗi nghiệp vụ của khảo sát
+var (
+	ErrSurveyNotFound        = errors.New("survey not found")
+	ErrSurveyHasResponses    = errors.New("questions cannot be changed after responses have been submitted")
+	ErrSurveyAlreadyAnswered = errors.New("you have already responded to this survey")
+)
+
+// GetEventSurvey - Bộ câu hỏi (theo position) và số phiếu trả lời của sự kiện
+func (r *EventRepository) GetEventSurvey(ctx context.Context, eventID int) (*models.EventSurvey, error) {
+	s := &models.EventSurvey{EventID: eventID, Questions: []models.SurveyQuestion{}}
+	err := r.db.QueryRowContext(ctx, `
+		SELECT s.survey_id, s.title, s.description, s.status,
+			(SELECT COUNT(*) FROM Survey_Response sr WHERE sr.survey_id = s.survey_id)
+		FROM Event_Survey s
+		WHERE s.event_id = ?
+	`, eventID).Scan(&s.SurveyID, &s.Title, &s.Description, &s.Status, &s.ResponseCount)
+	if err == sql.ErrNoRows {
+		return nil, ErrSurveyNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get survey: %w", err)
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT question_id, position, type, prompt, required, options, scale_min, scale_max
+		FROM Survey_Question WHERE survey_id = ? ORDER BY position, question_id
+	`, s.SurveyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list survey questions: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var q models.SurveyQuestion
+		var options string
+		if err := rows.Scan(&q.QuestionID, &q.Position, &q.Type, &q.Prompt, &q.Required,
+			dbscan.OrZero(&options), &q.ScaleMin, &q.ScaleMax); err != nil {
+			return nil, err
+		}
+		if options != "" {
+			if err := json.Unmarshal([]byte(options), &q.Options); err != nil {
+				return nil, fmt.Errorf("failed to parse options of question %d: %w", q.QuestionID, err)
+			}
+		}
+		s.Questions = append(s.Questions, q)
+	}
+	return s, rows.Err()
+}
+
+// SaveEventSurvey - Tạo / sửa khảo sát; req.Questions != nil thì thay toàn bộ câu hỏi
+// (ErrSurveyHasResponses nếu đã có phiếu trả lời)
+func (r *EventRepository) SaveEventSurvey(ctx context.Context, eventID, userID int, req *models.SaveSurveyRequest) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO Event_Survey (event_id, title, description, status, created_by) VALUES (?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE title = VALUES(title), description = VALUES(description), status = VALUES(status)
+	`, eventID, req.Title, req.Description, req.Status, userID); err != nil {
+		return fmt.Errorf("failed to save survey: %w", err)
+	}
+
+	if req.Questions != nil {
+		var surveyID, responses int
+		if err := tx.QueryRowContext(ctx, `
+			SELECT s.survey_id, (SELECT COUNT(*) FROM Survey_Response sr WHERE sr.survey_id = s.survey_id)
+			FROM Event_Survey s WHERE s.event_id = ? FOR UPDATE
+		`, eventID).Scan(&surveyID, &responses); err != nil {
+			return fmt.Errorf("failed to lock survey: %w", err)
+		}
+		if responses > 0 {
+			return ErrSurveyHasResponses
+		}
+		if _, err := tx.ExecContext(ctx, `DELETE FROM Survey_Question WHERE survey_id = ?`, surveyID); err != nil {
+			return fmt.Errorf("failed to clear survey questions: %w", err)
+		}
+		for i, q := range req.Questions {
+			var options interface{}
+			if len(q.Options) > 0 {
+				data, err := json.Marshal(q.Options)
+				if err != nil {
+					return err
+				}
+				options = string(data)
+			}
+			if _, err := tx.ExecContext(ctx, `
+				INSERT INTO Survey_Question (survey_id, position, type, prompt, required, options, scale_min, scale_max)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+			`, surveyID, i+1, q.Type, q.Prompt, q.Required, options, q.ScaleMin, q.ScaleMax); err != nil {
+				return fmt.Errorf("failed to insert survey question: %w", err)
+			}
+		}
+	}
+	return tx.Commit()
+}
+
+// CreateSurveyResponse - Lưu phiếu trả lời (ErrSurveyAlreadyAnswered nếu đã trả lời)
+func (r *EventRepository) CreateSurveyResponse(ctx context.Context, surveyID, userID int, answers []models.SurveyAnswer) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `INSERT INTO Survey_Response (survey_id, user_id) VALUES (?, ?)`, surveyID, userID)
+	if db.IsDuplicateEntry(err) {
+		return ErrSurveyAlreadyAnswered
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create survey response: %w", err)
+	}
+	responseID, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get response id: %w", err)
+	}
+
+	for _, a := range answers {
+		var choices interface{}
+		if len(a.Choices) > 0 {
+			data, err := json.Marshal(a.Choices)
+			if err != nil {
+				return err
+			}
+			choices = string(data)
+		}
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO Survey_Answer (response_id, question_id, choices, text_value, scale_value) VALUES (?, ?, ?, ?, ?)
+		`, responseID, a.QuestionID, choices, a.Text, a.Scale); err != nil {
+			return fmt.Errorf("failed to save survey answer: %w", err)
+		}
+	}
+	return tx.Commit()
+}
+
+// HasSurveyResponse - User đã trả lời khảo sát chưa
+func (r *EventRepository) HasSurveyResponse(ctx context.Context, surveyID, userID int) (bool, error) {
+	var ok bool
+	err := r.db.QueryRowContext(ctx, `
+		SELECT EXISTS (SELECT 1 FROM Survey_Response WHERE survey_id = ? AND user_id = ?)
+	`, surveyID, userID).Scan(&ok)
+	if err != nil {
+		return false, fmt.Errorf("failed to check survey response: %w", err)
+	}
+	return ok, nil
+}
+
+// ListSurveyResponses - Mọi phiếu trả lời (không kèm người trả lời), cũ nhất trước
+func (r *EventRepository) ListSurveyResponses(ctx context.Context, surveyID int) ([]models.SurveyResponseRecord, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT sr.response_id, sr.submitted_at, sa.question_id, sa.choices, sa.text_value, sa.scale_value
+		FROM Survey_Response sr
+		LEFT JOIN Survey_Answer sa ON sa.response_id = sr.response_id
+		WHERE sr.survey_id = ?
+		ORDER BY sr.submitted_at, sr.response_id
+	`, surveyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list survey responses: %w", err)
+	}
+	defer rows.Close()
+
+	records := []models.SurveyResponseRecord{}
+	for rows.Next() {
+		var responseID int
+		var submittedAt time.Time
+		var questionID *int
+		var choices string
+		var a models.SurveyAnswer
+		if err := rows.Scan(&responseID, &submittedAt, &questionID, dbscan.OrZero(&choices),
+			&a.Text, &a.Scale); err != nil {
+			return nil, err
+		}
+		if n := len(records); n == 0 || records[n-1].ResponseID != responseID {
+			records = append(records, models.SurveyResponseRecord{ResponseID: responseID, SubmittedAt: submittedAt, Answers: map[int]models.SurveyAnswer{}})
+		}
+		if questionID == nil {
+			continue
+		}
+		a.QuestionID = *questionID
+		if choices != "" {
+			if err := json.Unmarshal([]byte(choices), &a.Choices); err != nil {
+				return nil, fmt.Errorf("failed to parse choices of response %d: %w", responseID, err)
+			}
+		}
+		records[len(records)-1].Answers[a.QuestionID] = a
+	}
+	return records, rows.Err()
+}
+
+// HasAttendedEvent - User đã check-in sự kiện (được trả lời khảo sát)
+func (r *EventRepository) HasAttendedEvent(ctx context.Context, eventID, userID int) (bool, error) {
+	var ok bool
+	err := r.db.QueryRowContext(ctx, `
+		SELECT EXISTS (SELECT 1 FROM Ticket WHERE event_id = ? AND user_id = ? AND status IN ('CHECKED_IN','CHECKED_OUT'))
+	`, eventID, userID).Scan(&ok)
+	if err != nil {
+		return false, fmt.Errorf("failed to check attendance: %w", err)
+	}
+	return ok, nil
+}
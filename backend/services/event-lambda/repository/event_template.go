@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/fpt-event-services/common/dbscan"
+	"github.com/fpt-event-services/services/event-lambda/models"
+)
+
+// ============================================================
+// Event_Template - Thư viện mẫu sự kiện
+// Xóa mẫu = chuyển ARCHIVED (request cũ vẫn tham chiếu template_id)
+// ============================================================
+
+// ErrEventTemplateNotFound - Mẫu không tồn tại hoặc đã lưu trữ
+var ErrEventTemplateNotFound = errors.New("event template not found")
+
+const eventTemplateColumns = `
+	t.template_id, t.name, t.title_pattern, t.description, t.default_capacity, t.ticket_structure,
+	t.is_global, t.created_by, u.full_name, t.status, t.created_at, t.updated_at`
+
+// ListEventTemplates - Mẫu ACTIVE mà userID dùng được (mẫu chung + mẫu của mình)
+// all = true: mọi mẫu ACTIVE (người quản lý thư viện)
+func (r *EventRepository) ListEventTemplates(ctx context.Context, userID int, all bool) ([]models.EventTemplate, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+eventTemplateColumns+`
+		FROM Event_Template t
+		LEFT JOIN Users u ON u.user_id = t.created_by
+		WHERE t.status = 'ACTIVE' AND (? OR t.is_global = 1 OR t.created_by = ?)
+		ORDER BY t.is_global DESC, t.name
+	`, all, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query event templates: %w", err)
+	}
+	defer rows.Close()
+
+	templates := []models.EventTemplate{}
+	for rows.Next() {
+		tpl, err := scanEventTemplate(rows)
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, *tpl)
+	}
+	return templates, rows.Err()
+}
+
+// GetEventTemplate - Mẫu ACTIVE theo ID
+func (r *EventRepository) GetEventTemplate(ctx context.Context, templateID int) (*models.EventTemplate, error) {
+	row := r.db.QueryRowContext(ctx, `
+		SELECT `+eventTemplateColumns+`
+		FROM Event_Template t
+		LEFT JOIN Users u ON u.user_id = t.created_by
+		WHERE t.template_id = ? AND t.status = 'ACTIVE'
+	`, templateID)
+	tpl, err := scanEventTemplate(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrEventTemplateNotFound
+	}
+	return tpl, err
+}
+
+// CreateEventTemplate - Lưu mẫu mới, trả về template_id
+func (r *EventRepository) CreateEventTemplate(ctx context.Context, userID int, req *models.EventTemplateRequest) (int, error) {
+	tickets, err := json.Marshal(req.Tickets)
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode ticket structure: %w", err)
+	}
+	result, err := r.db.ExecContext(ctx, `
+		INSERT INTO Event_Template (name, title_pattern, description, default_capacity, ticket_structure, is_global, created_by)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`, req.Name, req.TitlePattern, req.Description, req.DefaultCapacity, string(tickets), req.Global, userID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create event template: %w", err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get template ID: %w", err)
+	}
+	return int(id), nil
+}
+
+// UpdateEventTemplate - Ghi đè nội dung mẫu
+func (r *EventRepository) UpdateEventTemplate(ctx context.Context, templateID int, req *models.EventTemplateRequest) error {
+	tickets, err := json.Marshal(req.Tickets)
+	if err != nil {
+		return fmt.Errorf("failed to encode ticket structure: %w", err)
+	}
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE Event_Template
+		SET name = ?, title_pattern = ?, description = ?, default_capacity = ?, ticket_structure = ?, is_global = ?
+		WHERE template_id = ? AND status = 'ACTIVE'
+	`, req.Name, req.TitlePattern, req.Description, req.DefaultCapacity, string(tickets), req.Global, templateID)
+	if err != nil {
+		return fmt.Errorf("failed to update event template: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		// Không đổi gì cũng trả 0 dòng: kiểm tra lại mẫu còn tồn tại không
+		if _, err := r.GetEventTemplate(ctx, templateID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ArchiveEventTemplate - Ẩn mẫu khỏi thư viện
+func (r *EventRepository) ArchiveEventTemplate(ctx context.Context, templateID int) error {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE Event_Template SET status = 'ARCHIVED' WHERE template_id = ? AND status = 'ACTIVE'
+	`, templateID)
+	if err != nil {
+		return fmt.Errorf("failed to archive event template: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return ErrEventTemplateNotFound
+	}
+	return nil
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanEventTemplate(row rowScanner) (*models.EventTemplate, error) {
+	var tpl models.EventTemplate
+	var tickets string
+	err := row.Scan(&tpl.TemplateID, &tpl.Name, &tpl.TitlePattern, &tpl.Description,
+		&tpl.DefaultCapacity, dbscan.OrZero(&tickets),
+		&tpl.Global, &tpl.CreatedBy, &tpl.CreatedByName, &tpl.Status, &tpl.CreatedAt, &tpl.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to scan event template: %w", err)
+	}
+	tpl.Tickets = []models.CategoryTicketDTO{}
+	if tickets != "" {
+		if err := json.Unmarshal([]byte(tickets), &tpl.Tickets); err != nil {
+			return nil, fmt.Errorf("failed to parse ticket structure of template %d: %w", tpl.TemplateID, err)
+		}
+	}
+	return &tpl, nil
+}
@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/fpt-event-services/common/pdf"
+	"github.com/fpt-event-services/services/event-lambda/models"
+)
+
+// ============================================================
+// Event_Ticket_Template - Cấu hình vé PDF của sự kiện
+// Không có dòng nào = layout classic khổ A4 (vé như trước khi có template)
+// ============================================================
+
+// GetTicketTemplate - Cấu hình hiện tại, trả về mặc định nếu chưa cấu hình
+func (r *EventRepository) GetTicketTemplate(ctx context.Context, eventID int) (*models.EventTicketTemplate, error) {
+	// Banner in trên vé: ưu tiên bản card (640px) cho nhẹ PDF
+	var banner sql.NullString
+	err := r.db.QueryRowContext(ctx, `SELECT COALESCE(banner_card_url, banner_url) FROM Event WHERE event_id = ?`, eventID).Scan(&banner)
+	if err == sql.ErrNoRows {
+		return nil, ErrEventNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to check event: %w", err)
+	}
+
+	var (
+		accent, logo, sponsors, note sql.NullString
+		updatedAt                    time.Time
+	)
+	tpl := &models.EventTicketTemplate{EventID: eventID, BannerURL: banner.String}
+	err = r.db.QueryRowContext(ctx, `
+		SELECT layout, page_size, accent_color, show_banner, organizer_logo_url, sponsor_logo_urls, footer_note, updated_at
+		FROM Event_Ticket_Template
+		WHERE event_id = ?
+	`, eventID).Scan(&tpl.Layout, &tpl.PageSize, &accent, &tpl.ShowBanner, &logo, &sponsors, &note, &updatedAt)
+	if err == sql.ErrNoRows {
+		def := pdf.DefaultTemplate()
+		tpl.Layout, tpl.PageSize, tpl.ShowBanner, tpl.SponsorLogoURLs = def.Layout, def.PageSize, true, []string{}
+		return tpl, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ticket template: %w", err)
+	}
+
+	tpl.AccentColor, tpl.OrganizerLogoURL, tpl.FooterNote = accent.String, logo.String, note.String
+	tpl.SponsorLogoURLs = []string{}
+	if sponsors.Valid && sponsors.String != "" {
+		if err := json.Unmarshal([]byte(sponsors.String), &tpl.SponsorLogoURLs); err != nil {
+			return nil, fmt.Errorf("failed to parse sponsor logos: %w", err)
+		}
+	}
+	tpl.UpdatedAt = &updatedAt
+	return tpl, nil
+}
+
+// UpsertTicketTemplate - Lưu cấu hình (ghi đè toàn bộ)
+func (r *EventRepository) UpsertTicketTemplate(ctx context.Context, tpl *models.EventTicketTemplate, userID int) error {
+	sponsors, err := json.Marshal(tpl.SponsorLogoURLs)
+	if err != nil {
+		return fmt.Errorf("failed to encode sponsor logos: %w", err)
+	}
+	_, err = r.db.ExecContext(ctx, `
+		INSERT INTO Event_Ticket_Template
+			(event_id, layout, page_size, accent_color, show_banner, organizer_logo_url, sponsor_logo_urls, footer_note, updated_by)
+		VALUES (?, ?, ?, NULLIF(?, ''), ?, NULLIF(?, ''), ?, NULLIF(?, ''), ?)
+		ON DUPLICATE KEY UPDATE
+			layout = VALUES(layout),
+			page_size = VALUES(page_size),
+			accent_color = VALUES(accent_color),
+			show_banner = VALUES(show_banner),
+			organizer_logo_url = VALUES(organizer_logo_url),
+			sponsor_logo_urls = VALUES(sponsor_logo_urls),
+			footer_note = VALUES(footer_note),
+			updated_by = VALUES(updated_by)
+	`, tpl.EventID, tpl.Layout, tpl.PageSize, tpl.AccentColor, tpl.ShowBanner, tpl.OrganizerLogoURL, string(sponsors), tpl.FooterNote, userID)
+	if err != nil {
+		return fmt.Errorf("failed to save ticket template: %w", err)
+	}
+	return nil
+}
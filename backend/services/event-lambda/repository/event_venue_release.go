@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+
+	"github.com/fpt-event-services/common/metrics"
+)
+
+// ============================================================
+// Giải phóng Venue_Area (UNAVAILABLE → AVAILABLE)
+// Đường chính: subscriber EventCancelled / EventClosed / EventDisabled gọi
+// ReleaseAreaOnEventClose ngay sau khi sự kiện rời trạng thái OPEN/UPDATING
+// Đường dự phòng: job venue-release + startup janitor gọi AutoReleaseVenues
+// (bắt các khu vực bị bỏ sót khi process dừng trước khi subscriber chạy)
+// ============================================================
+
+// Nguồn giải phóng khu vực (label path của metric, có trong log)
+const (
+	ReleasePathEventBus       = "event_bus"
+	ReleasePathReconciliation = "reconciliation"
+)
+
+// areaReleases - Số khu vực được giải phóng theo nguồn; reconciliation tăng là dấu hiệu subscriber bị lỡ
+var areaReleases = metrics.NewCounter("venue_area_releases_total",
+	"Venue areas released back to AVAILABLE, by path (event_bus, reconciliation).", "path")
+
+// releaseAreaSQL - Chỉ giải phóng khi không còn sự kiện OPEN/UPDATING nào dùng khu vực
+const releaseAreaSQL = `
+	UPDATE Venue_Area SET status = 'AVAILABLE'
+	WHERE area_id = ? AND status = 'UNAVAILABLE'
+	  AND NOT EXISTS (
+		SELECT 1 FROM Event e WHERE e.area_id = ? AND e.status IN ('OPEN', 'UPDATING')
+	  )
+`
+
+// ReleaseAreaOnEventClose - Giải phóng khu vực của sự kiện vừa hủy / đóng / vô hiệu hóa
+// Trả về area_id (0 nếu sự kiện không có khu vực) và có giải phóng hay không
+func (r *EventRepository) ReleaseAreaOnEventClose(ctx context.Context, eventID int) (int, bool, error) {
+	var areaID sql.NullInt64
+	err := r.db.QueryRowContext(ctx, `SELECT area_id FROM Event WHERE event_id = ?`, eventID).Scan(&areaID)
+	if err == sql.ErrNoRows || (err == nil && !areaID.Valid) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to load event area: %w", err)
+	}
+
+	result, err := r.db.ExecContext(ctx, releaseAreaSQL, areaID.Int64, areaID.Int64)
+	if err != nil {
+		return int(areaID.Int64), false, fmt.Errorf("failed to release venue area: %w", err)
+	}
+	n, _ := result.RowsAffected()
+	if n > 0 {
+		areaReleases.Inc(ReleasePathEventBus)
+	}
+	return int(areaID.Int64), n > 0, nil
+}
+
+// AutoReleaseVenues - Đối soát: giải phóng mọi khu vực UNAVAILABLE không còn sự kiện OPEN/UPDATING
+// Ghi log từng khu vực để biết khu vực nào không được giải phóng qua event bus
+func (r *EventRepository) AutoReleaseVenues(ctx context.Context) error {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT va.area_id FROM Venue_Area va
+		WHERE va.status = 'UNAVAILABLE'
+		  AND NOT EXISTS (
+			SELECT 1 FROM Event e WHERE e.area_id = va.area_id AND e.status IN ('OPEN', 'UPDATING')
+		  )
+	`)
+	if err != nil {
+		log.Printf("[JANITOR] ❌ Failed to list releasable venue areas: %v", err)
+		return fmt.Errorf("failed to list releasable venue areas: %w", err)
+	}
+	var areaIDs []int
+	for rows.Next() {
+		var areaID int
+		if err := rows.Scan(&areaID); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to scan venue area: %w", err)
+		}
+		areaIDs = append(areaIDs, areaID)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to list releasable venue areas: %w", err)
+	}
+
+	released := 0
+	for _, areaID := range areaIDs {
+		// Điều kiện kiểm tra lại trong UPDATE: có thể vừa được xếp cho sự kiện mới
+		result, err := r.db.ExecContext(ctx, releaseAreaSQL, areaID, areaID)
+		if err != nil {
+			log.Printf("[JANITOR] ❌ Failed to release venue area #%d: %v", areaID, err)
+			return fmt.Errorf("failed to release venue area %d: %w", areaID, err)
+		}
+		if n, _ := result.RowsAffected(); n > 0 {
+			released++
+			areaReleases.Inc(ReleasePathReconciliation)
+			log.Printf("[VENUE_RELEASE] Area #%d released (path=%s)", areaID, ReleasePathReconciliation)
+		}
+	}
+
+	if released > 0 {
+		log.Printf("[JANITOR] ✅ Da giai phong %d khu vuc khong con su kien hoat dong", released)
+	} else {
+		log.Println("[JANITOR] No venue areas to release (all areas are in use or already available)")
+	}
+	return nil
+}
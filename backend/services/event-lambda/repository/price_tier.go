@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/fpt-event-services/services/event-lambda/models"
+)
+
+// ============================================================
+// Category_Ticket_Price_Tier - Bậc giá theo thời gian của loại vé
+// Giá lúc mua do ticket-lambda chọn (activePriceSQL); ở đây chỉ cấu hình
+// ============================================================
+
+// ErrCategoryTicketNotFound - Loại vé không tồn tại hoặc không thuộc sự kiện
+var ErrCategoryTicketNotFound = errors.New("category ticket not found")
+
+// GetCategoryPriceTiers - Giá gốc và các bậc giá (theo valid_from) của loại vé thuộc sự kiện
+func (r *EventRepository) GetCategoryPriceTiers(ctx context.Context, eventID, categoryTicketID int) (*models.CategoryPriceTiers, error) {
+	c := &models.CategoryPriceTiers{CategoryTicketID: categoryTicketID, EventID: eventID, Tiers: []models.PriceTier{}}
+	err := r.db.QueryRowContext(ctx, `
+		SELECT name, COALESCE(price, 0) FROM Category_Ticket WHERE category_ticket_id = ? AND event_id = ?
+	`, categoryTicketID, eventID).Scan(&c.Name, &c.BasePrice)
+	if err == sql.ErrNoRows {
+		return nil, ErrCategoryTicketNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get category ticket: %w", err)
+	}
+	c.BasePrice = math.Round(c.BasePrice)
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT tier_id, name, price, valid_from, valid_to
+		FROM Category_Ticket_Price_Tier WHERE category_ticket_id = ?
+		ORDER BY valid_from, tier_id
+	`, categoryTicketID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list price tiers: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var t models.PriceTier
+		var validTo sql.NullTime
+		if err := rows.Scan(&t.TierID, &t.Name, &t.Price, &t.ValidFrom, &validTo); err != nil {
+			return nil, err
+		}
+		if validTo.Valid {
+			t.ValidTo = pointer(validTo.Time)
+		}
+		c.Tiers = append(c.Tiers, t)
+	}
+	return c, rows.Err()
+}
+
+// ReplacePriceTiers - Thay toàn bộ bậc giá của loại vé
+func (r *EventRepository) ReplacePriceTiers(ctx context.Context, categoryTicketID int, tiers []models.PriceTier) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM Category_Ticket_Price_Tier WHERE category_ticket_id = ?`, categoryTicketID); err != nil {
+		return fmt.Errorf("failed to clear price tiers: %w", err)
+	}
+	for _, t := range tiers {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO Category_Ticket_Price_Tier (category_ticket_id, name, price, valid_from, valid_to) VALUES (?, ?, ?, ?, ?)
+		`, categoryTicketID, t.Name, t.Price, t.ValidFrom, t.ValidTo); err != nil {
+			return fmt.Errorf("failed to insert price tier: %w", err)
+		}
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// ============================================================
+// Event.version / Event_Request.version - Khóa lạc quan cho các thao tác sửa
+// Handler đọc If-Match thành ExpectedVersion; repository khóa dòng (FOR UPDATE)
+// trong transaction và so sánh trước khi ghi, nên hai người sửa cùng lúc
+// không ghi đè lên nhau: người đến sau nhận ErrVersionMismatch (412)
+// ============================================================
+
+// ErrVersionMismatch - Bản ghi đã bị sửa sau lần đọc có ETag tương ứng
+var ErrVersionMismatch = errors.New("resource was modified by someone else, reload and try again")
+
+// checkRowVersion - Khóa dòng và so sánh version; expected nil = client không gửi If-Match
+// Dòng không tồn tại trả nil để luồng chính báo lỗi not found như cũ
+func checkRowVersion(ctx context.Context, tx *sql.Tx, query string, id int, expected *int) error {
+	if expected == nil {
+		return nil
+	}
+	var version int
+	if err := tx.QueryRowContext(ctx, query, id).Scan(&version); err != nil {
+		if err == sql.ErrNoRows {
+			return nil
+		}
+		return fmt.Errorf("failed to check version: %w", err)
+	}
+	if version != *expected {
+		return ErrVersionMismatch
+	}
+	return nil
+}
+
+// checkEventVersion - Version của Event (khóa dòng đến khi transaction kết thúc)
+func checkEventVersion(ctx context.Context, tx *sql.Tx, eventID int, expected *int) error {
+	return checkRowVersion(ctx, tx, `SELECT version FROM Event WHERE event_id = ? FOR UPDATE`, eventID, expected)
+}
+
+// checkEventRequestVersion - Version của Event_Request (khóa dòng đến khi transaction kết thúc)
+func checkEventRequestVersion(ctx context.Context, tx *sql.Tx, requestID int, expected *int) error {
+	return checkRowVersion(ctx, tx, `SELECT version FROM Event_Request WHERE request_id = ? FOR UPDATE`, requestID, expected)
+}
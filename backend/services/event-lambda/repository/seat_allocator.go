@@ -0,0 +1,255 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ============================================================
+// SeatAllocator - Gán ghế của khu vực cho các loại vé (Seat.category_ticket_id)
+// Dùng chung cho UpdateEventRequest (lưu lần đầu sau khi duyệt) và UpdateEventDetails
+// (sửa sự kiện chưa bán vé): đảm bảo đủ ghế theo sức chứa → xóa gán cũ → lập kế hoạch
+// theo chiến lược → ghi theo từng loại vé.
+// Thứ tự ghế: hàng A, B, ..., Z, AA... rồi số ghế tăng dần (col_no là varchar nên
+// sắp trong Go, không dùng ORDER BY col_no)
+//
+// Chiến lược (SEAT_ALLOCATION_STRATEGY, mặc định VIP_FIRST):
+//   - VIP_FIRST: loại vé có "VIP" trong tên trước, sau đó giá giảm dần; ghế liền nhau
+//   - SEQUENTIAL: đúng thứ tự loại vé organizer gửi; ghế liền nhau
+//   - ZONE: thứ tự như VIP_FIRST nhưng mỗi loại vé bắt đầu ở một hàng mới
+//     (ghế lẻ cuối hàng bỏ trống)
+// ============================================================
+
+// SeatStrategy - Cách xếp loại vé vào dãy ghế
+type SeatStrategy string
+
+const (
+	SeatStrategyVIPFirst   SeatStrategy = "VIP_FIRST"
+	SeatStrategySequential SeatStrategy = "SEQUENTIAL"
+	SeatStrategyZone       SeatStrategy = "ZONE"
+)
+
+const (
+	// seatsPerRow - Số ghế mỗi hàng khi tự tạo ghế cho khu vực
+	seatsPerRow = 10
+	// defaultAreaSeats - Khu vực chưa có ghế và không rõ sức chứa: tạo lưới 10x10
+	defaultAreaSeats = 100
+)
+
+// ErrInsufficientSeats - Khu vực không đủ ghế cho tổng số vé
+var ErrInsufficientSeats = errors.New("insufficient seats")
+
+// SeatCategory - Một loại vé cần ghế
+type SeatCategory struct {
+	CategoryTicketID int64
+	Name             string
+	Price            float64
+	Quantity         int
+}
+
+// SeatSlot - Một ghế của khu vực
+type SeatSlot struct {
+	SeatID int64
+	Code   string
+	Row    string
+	Col    int
+}
+
+// SeatAllocator - Gán ghế theo Strategy
+type SeatAllocator struct {
+	Strategy SeatStrategy
+}
+
+// NewSeatAllocator creates an allocator; chiến lược lạ dùng VIP_FIRST
+func NewSeatAllocator(strategy SeatStrategy) *SeatAllocator {
+	switch strategy {
+	case SeatStrategyVIPFirst, SeatStrategySequential, SeatStrategyZone:
+	default:
+		strategy = SeatStrategyVIPFirst
+	}
+	return &SeatAllocator{Strategy: strategy}
+}
+
+// defaultSeatAllocator - Allocator theo SEAT_ALLOCATION_STRATEGY
+func defaultSeatAllocator() *SeatAllocator {
+	return NewSeatAllocator(SeatStrategy(strings.ToUpper(strings.TrimSpace(os.Getenv("SEAT_ALLOCATION_STRATEGY")))))
+}
+
+// isVIPCategory - Loại vé VIP nhận theo tên
+func isVIPCategory(name string) bool {
+	return strings.Contains(strings.ToUpper(name), "VIP")
+}
+
+// sortSeats - Hàng theo thứ tự A..Z, AA.. (độ dài rồi chữ cái), rồi số ghế
+func sortSeats(seats []SeatSlot) {
+	sort.SliceStable(seats, func(i, j int) bool {
+		a, b := seats[i], seats[j]
+		if len(a.Row) != len(b.Row) {
+			return len(a.Row) < len(b.Row)
+		}
+		if a.Row != b.Row {
+			return a.Row < b.Row
+		}
+		if a.Col != b.Col {
+			return a.Col < b.Col
+		}
+		return a.Code < b.Code
+	})
+}
+
+// orderCategories - Thứ tự xếp loại vé theo chiến lược (không đổi slice gốc)
+func (a *SeatAllocator) orderCategories(categories []SeatCategory) []SeatCategory {
+	ordered := append([]SeatCategory(nil), categories...)
+	if a.Strategy == SeatStrategySequential {
+		return ordered
+	}
+	sort.SliceStable(ordered, func(i, j int) bool {
+		vipI, vipJ := isVIPCategory(ordered[i].Name), isVIPCategory(ordered[j].Name)
+		if vipI != vipJ {
+			return vipI
+		}
+		return ordered[i].Price > ordered[j].Price
+	})
+	return ordered
+}
+
+// Plan - Ghế (seat_id) của từng loại vé; seats phải đã sortSeats
+// ErrInsufficientSeats nếu không xếp đủ Quantity cho mọi loại vé
+func (a *SeatAllocator) Plan(seats []SeatSlot, categories []SeatCategory) (map[int64][]int64, error) {
+	plan := make(map[int64][]int64, len(categories))
+	next := 0
+	for _, c := range a.orderCategories(categories) {
+		if c.Quantity <= 0 {
+			continue
+		}
+		// ZONE: loại vé sau không ngồi chung hàng với loại vé trước
+		if a.Strategy == SeatStrategyZone && next > 0 && next < len(seats) && seats[next].Row == seats[next-1].Row {
+			for next < len(seats) && seats[next].Row == seats[next-1].Row {
+				next++
+			}
+		}
+		if next+c.Quantity > len(seats) {
+			return nil, fmt.Errorf("%w: %s needs %d seats, only %d left", ErrInsufficientSeats, c.Name, c.Quantity, len(seats)-next)
+		}
+		for _, s := range seats[next : next+c.Quantity] {
+			plan[c.CategoryTicketID] = append(plan[c.CategoryTicketID], s.SeatID)
+		}
+		next += c.Quantity
+	}
+	return plan, nil
+}
+
+// missingSeats - Ghế cần tạo thêm (lưới seatsPerRow ghế / hàng) để khu vực có đủ target ghế
+// Bỏ qua mã ghế đã có (INSERT IGNORE cũng bỏ qua trùng)
+func missingSeats(existing []SeatSlot, target int) []SeatSlot {
+	have := make(map[string]bool, len(existing))
+	for _, s := range existing {
+		have[s.Code] = true
+	}
+	var missing []SeatSlot
+	count := len(existing)
+	for i := 0; count < target; i++ {
+		row, col := rowNameFromIndex(i/seatsPerRow), i%seatsPerRow+1
+		code := row + strconv.Itoa(col)
+		if have[code] {
+			continue
+		}
+		missing = append(missing, SeatSlot{Code: code, Row: row, Col: col})
+		count++
+	}
+	return missing
+}
+
+// AllocateTx - Phân bổ lại toàn bộ ghế của khu vực cho categories trong transaction
+// capacity <= 0: đọc Venue_Area.capacity
+func (a *SeatAllocator) AllocateTx(ctx context.Context, tx *sql.Tx, areaID int64, capacity int, categories []SeatCategory) error {
+	if capacity <= 0 {
+		if err := tx.QueryRowContext(ctx, `SELECT capacity FROM Venue_Area WHERE area_id = ?`, areaID).Scan(&capacity); err != nil {
+			log.Printf("[SeatAllocator] warning: failed to read capacity of area %d: %v", areaID, err)
+			capacity = 0
+		}
+	}
+
+	seats, err := loadAreaSeatsTx(ctx, tx, areaID)
+	if err != nil {
+		return err
+	}
+	target := capacity
+	if target <= 0 && len(seats) == 0 {
+		target = defaultAreaSeats
+	}
+	if missing := missingSeats(seats, target); len(missing) > 0 {
+		values := make([]string, 0, len(missing))
+		args := make([]any, 0, len(missing)*4)
+		for _, s := range missing {
+			values = append(values, "(?, ?, ?, ?, 'ACTIVE')")
+			args = append(args, areaID, s.Code, s.Row, s.Col)
+		}
+		if _, err := tx.ExecContext(ctx,
+			`INSERT IGNORE INTO Seat (area_id, seat_code, row_no, col_no, status) VALUES `+strings.Join(values, ", "), args...,
+		); err != nil {
+			return fmt.Errorf("failed to initialize seats: %w", err)
+		}
+		log.Printf("[SeatAllocator] Created %d seats for area %d (target %d)", len(missing), areaID, target)
+		if seats, err = loadAreaSeatsTx(ctx, tx, areaID); err != nil {
+			return err
+		}
+	}
+
+	plan, err := a.Plan(seats, categories)
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE Seat SET category_ticket_id = NULL WHERE area_id = ?`, areaID); err != nil {
+		return fmt.Errorf("failed to reset seats: %w", err)
+	}
+	assigned := 0
+	for categoryID, seatIDs := range plan {
+		args := make([]any, 0, len(seatIDs)+1)
+		args = append(args, categoryID)
+		for _, id := range seatIDs {
+			args = append(args, id)
+		}
+		if _, err := tx.ExecContext(ctx,
+			`UPDATE Seat SET category_ticket_id = ? WHERE seat_id IN (?`+strings.Repeat(", ?", len(seatIDs)-1)+`)`, args...,
+		); err != nil {
+			return fmt.Errorf("failed to assign seats to category %d: %w", categoryID, err)
+		}
+		assigned += len(seatIDs)
+	}
+	log.Printf("[SeatAllocator] Area %d: %d/%d seats assigned to %d categories (%s)", areaID, assigned, len(seats), len(plan), a.Strategy)
+	return nil
+}
+
+// loadAreaSeatsTx - Ghế của khu vực, đã sortSeats
+func loadAreaSeatsTx(ctx context.Context, tx *sql.Tx, areaID int64) ([]SeatSlot, error) {
+	rows, err := tx.QueryContext(ctx, `SELECT seat_id, seat_code, COALESCE(row_no, ''), COALESCE(col_no, '') FROM Seat WHERE area_id = ?`, areaID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query seats: %w", err)
+	}
+	defer rows.Close()
+
+	var seats []SeatSlot
+	for rows.Next() {
+		var s SeatSlot
+		var col string
+		if err := rows.Scan(&s.SeatID, &s.Code, &s.Row, &col); err != nil {
+			return nil, fmt.Errorf("failed to scan seat: %w", err)
+		}
+		s.Col, _ = strconv.Atoi(col)
+		seats = append(seats, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	sortSeats(seats)
+	return seats, nil
+}
@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// gridSeats - rows hàng x cols ghế, seat_id tăng dần theo thứ tự ghế
+func gridSeats(rows, cols int) []SeatSlot {
+	var seats []SeatSlot
+	for r := 0; r < rows; r++ {
+		for c := 1; c <= cols; c++ {
+			row := rowNameFromIndex(r)
+			seats = append(seats, SeatSlot{SeatID: int64(len(seats) + 1), Code: row + string(rune('0'+c)), Row: row, Col: c})
+		}
+	}
+	return seats
+}
+
+func TestSortSeats(t *testing.T) {
+	seats := []SeatSlot{
+		{SeatID: 1, Code: "AA1", Row: "AA", Col: 1},
+		{SeatID: 2, Code: "A10", Row: "A", Col: 10},
+		{SeatID: 3, Code: "B1", Row: "B", Col: 1},
+		{SeatID: 4, Code: "A2", Row: "A", Col: 2},
+		{SeatID: 5, Code: "Z1", Row: "Z", Col: 1},
+	}
+	sortSeats(seats)
+
+	var got []string
+	for _, s := range seats {
+		got = append(got, s.Code)
+	}
+	want := []string{"A2", "A10", "B1", "Z1", "AA1"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestSeatAllocatorPlan(t *testing.T) {
+	categories := []SeatCategory{
+		{CategoryTicketID: 10, Name: "Standard", Price: 100000, Quantity: 3},
+		{CategoryTicketID: 20, Name: "VIP", Price: 50000, Quantity: 2},
+		{CategoryTicketID: 30, Name: "Premium", Price: 200000, Quantity: 1},
+	}
+
+	tests := []struct {
+		name     string
+		strategy SeatStrategy
+		want     map[int64][]int64
+	}{
+		{
+			name:     "VIP first, then price descending",
+			strategy: SeatStrategyVIPFirst,
+			want:     map[int64][]int64{20: {1, 2}, 30: {3}, 10: {4, 5, 6}},
+		},
+		{
+			name:     "Sequential keeps request order",
+			strategy: SeatStrategySequential,
+			want:     map[int64][]int64{10: {1, 2, 3}, 20: {4, 5}, 30: {6}},
+		},
+		{
+			name:     "Zone starts each category on a new row",
+			strategy: SeatStrategyZone,
+			want:     map[int64][]int64{20: {1, 2}, 30: {5}, 10: {9, 10, 11}},
+		},
+		{
+			name:     "Unknown strategy falls back to VIP first",
+			strategy: "RANDOM",
+			want:     map[int64][]int64{20: {1, 2}, 30: {3}, 10: {4, 5, 6}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewSeatAllocator(tt.strategy).Plan(gridSeats(4, 4), categories)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSeatAllocatorPlanInsufficientSeats(t *testing.T) {
+	categories := []SeatCategory{
+		{CategoryTicketID: 1, Name: "VIP", Quantity: 2},
+		{CategoryTicketID: 2, Name: "Standard", Quantity: 2},
+	}
+
+	// 1 hàng 4 ghế đủ khi xếp liền nhau
+	if _, err := NewSeatAllocator(SeatStrategySequential).Plan(gridSeats(1, 4), categories); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// ZONE bỏ trống phần còn lại của hàng A nên không đủ
+	if _, err := NewSeatAllocator(SeatStrategyZone).Plan(gridSeats(1, 4), categories); !errors.Is(err, ErrInsufficientSeats) {
+		t.Fatalf("got %v, want ErrInsufficientSeats", err)
+	}
+	if _, err := NewSeatAllocator(SeatStrategyVIPFirst).Plan(gridSeats(1, 3), categories); !errors.Is(err, ErrInsufficientSeats) {
+		t.Fatalf("got %v, want ErrInsufficientSeats", err)
+	}
+}
+
+func TestMissingSeats(t *testing.T) {
+	existing := []SeatSlot{{Code: "A1", Row: "A", Col: 1}, {Code: "A3", Row: "A", Col: 3}}
+	got := missingSeats(existing, 14)
+
+	if len(got) != 12 {
+		t.Fatalf("got %d missing seats, want 12", len(got))
+	}
+	if got[0].Code != "A2" || got[len(got)-1].Code != "B4" {
+		t.Fatalf("got first %s last %s, want A2 and B4", got[0].Code, got[len(got)-1].Code)
+	}
+	if len(missingSeats(existing, 2)) != 0 {
+		t.Fatal("no seats should be created when capacity is already reached")
+	}
+}
@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/fpt-event-services/services/event-lambda/models"
+)
+
+// ============================================================
+// Seat_Price - Giá riêng từng ghế trong loại vé (migration 048)
+// adjust_percent cộng / trừ trên giá đang áp dụng của loại vé; ticket-lambda
+// tính giá ghế bằng seatPriceSQL ở báo giá, thanh toán và dòng hóa đơn
+// ============================================================
+
+// CategorySeat - Ghế đang gán cho loại vé
+type CategorySeat struct {
+	SeatID int
+	RowNo  string
+}
+
+// SeatPriceCategory - Loại vé của sự kiện và các ghế của nó
+type SeatPriceCategory struct {
+	Name  string
+	Price float64
+	Seats []CategorySeat
+}
+
+// ListSeatPrices - Override của mọi loại vé trong sự kiện (theo loại vé, mã ghế)
+// Ghế đã được chuyển sang loại vé khác thì override cũ không còn hiệu lực và không được liệt kê
+func (r *EventRepository) ListSeatPrices(ctx context.Context, eventID int) ([]models.SeatPriceOverride, error) {
+	var exists int
+	if err := r.db.QueryRowContext(ctx, `SELECT 1 FROM Event WHERE event_id = ?`, eventID).Scan(&exists); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrEventNotFound
+		}
+		return nil, fmt.Errorf("failed to check event: %w", err)
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT ct.category_ticket_id, ct.name, s.seat_id, s.seat_code, s.row_no, sp.adjust_percent,
+		       COALESCE(ct.price, 0), ROUND(COALESCE(ct.price, 0) * (100 + sp.adjust_percent) / 100, 0)
+		FROM Seat_Price sp
+		JOIN Category_Ticket ct ON ct.category_ticket_id = sp.category_ticket_id
+		JOIN Seat s ON s.seat_id = sp.seat_id AND s.category_ticket_id = sp.category_ticket_id
+		WHERE ct.event_id = ?
+		ORDER BY ct.category_ticket_id, s.row_no, s.seat_code
+	`, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list seat prices: %w", err)
+	}
+	defer rows.Close()
+
+	overrides := []models.SeatPriceOverride{}
+	for rows.Next() {
+		var o models.SeatPriceOverride
+		if err := rows.Scan(&o.CategoryTicketID, &o.CategoryName, &o.SeatID, &o.SeatCode, &o.RowNo,
+			&o.AdjustPercent, &o.BasePrice, &o.Price); err != nil {
+			return nil, fmt.Errorf("failed to scan seat price: %w", err)
+		}
+		overrides = append(overrides, o)
+	}
+	return overrides, rows.Err()
+}
+
+// GetSeatPriceCategory - Loại vé categoryTicketID của sự kiện kèm ghế đang gán; nil nếu không thuộc sự kiện
+func (r *EventRepository) GetSeatPriceCategory(ctx context.Context, eventID, categoryTicketID int) (*SeatPriceCategory, error) {
+	var c SeatPriceCategory
+	err := r.db.QueryRowContext(ctx,
+		`SELECT name, COALESCE(price, 0) FROM Category_Ticket WHERE category_ticket_id = ? AND event_id = ?`,
+		categoryTicketID, eventID,
+	).Scan(&c.Name, &c.Price)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load ticket category: %w", err)
+	}
+
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT seat_id, COALESCE(row_no, '') FROM Seat WHERE category_ticket_id = ? AND status = 'ACTIVE'`,
+		categoryTicketID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list category seats: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var s CategorySeat
+		if err := rows.Scan(&s.SeatID, &s.RowNo); err != nil {
+			return nil, fmt.Errorf("failed to scan category seat: %w", err)
+		}
+		c.Seats = append(c.Seats, s)
+	}
+	return &c, rows.Err()
+}
+
+// ReplaceSeatPrices - Thay override của loại vé bằng adjust (seat_id → %) trong một transaction
+// Organizer chịu mốc khóa chỉnh sửa như danh sách vé (trường "seatPrices"); vé đã bán giữ giá trên Bill_Item
+func (r *EventRepository) ReplaceSeatPrices(ctx context.Context, eventID, categoryTicketID, userID int, role string, adjust map[int]int) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if role == "ORGANIZER" {
+		eligibility, err := eventEditEligibility(ctx, tx, eventID, []string{"seatPrices"})
+		if err != nil {
+			return fmt.Errorf("failed to check edit window: %w", err)
+		}
+		if eligibility != nil {
+			return eligibility
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM Seat_Price WHERE category_ticket_id = ?`, categoryTicketID); err != nil {
+		return fmt.Errorf("failed to clear seat prices: %w", err)
+	}
+	for seatID, percent := range adjust {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO Seat_Price (category_ticket_id, seat_id, adjust_percent, updated_by) VALUES (?, ?, ?, ?)
+		`, categoryTicketID, seatID, percent, userID); err != nil {
+			return fmt.Errorf("failed to save seat price for seat %d: %w", seatID, err)
+		}
+	}
+	return tx.Commit()
+}
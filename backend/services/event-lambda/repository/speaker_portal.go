@@ -0,0 +1,375 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/fpt-event-services/common/crypto"
+	"github.com/fpt-event-services/services/event-lambda/models"
+)
+
+// ============================================================
+// Speaker portal - Lời mời liên kết tài khoản, hồ sơ và tài liệu buổi nói
+// Một tài khoản có thể liên kết nhiều dòng Speaker (mỗi sự kiện một dòng)
+// ============================================================
+
+// Lỗi nghiệp vụ của speaker portal
+var (
+	ErrEventHasNoSpeaker          = errors.New("event has no speaker")
+	ErrSpeakerEmailMissing        = errors.New("speaker has no email, provide one to send the invitation")
+	ErrSpeakerInvitationInvalid   = errors.New("invitation is invalid, expired or already used")
+	ErrSpeakerPasswordRequired    = errors.New("password is required to create the speaker account")
+	ErrNotSpeaker                 = errors.New("account is not linked to any speaker profile")
+	ErrNotEventSpeaker            = errors.New("you are not the speaker of this event")
+	ErrSessionMaterialNotFound    = errors.New("session material not found")
+	ErrTooManySessionMaterials    = errors.New("too many session materials for this event")
+	ErrSpeakerAccountNotAvailable = errors.New("account with this email is not active")
+)
+
+// EventSpeakerContact - Speaker đang gắn với sự kiện (để gửi lời mời)
+type EventSpeakerContact struct {
+	EventTitle  string
+	SpeakerID   int
+	SpeakerName string
+	Email       string
+}
+
+// GetEventSpeakerContact - Speaker hiện tại của sự kiện
+func (r *EventRepository) GetEventSpeakerContact(ctx context.Context, eventID int) (*EventSpeakerContact, error) {
+	var (
+		c         EventSpeakerContact
+		speakerID sql.NullInt64
+		name      sql.NullString
+		email     sql.NullString
+	)
+	err := r.db.QueryRowContext(ctx, `
+		SELECT e.title, e.speaker_id, s.full_name, s.email
+		FROM Event e
+		LEFT JOIN Speaker s ON s.speaker_id = e.speaker_id
+		WHERE e.event_id = ?
+	`, eventID).Scan(&c.EventTitle, &speakerID, &name, &email)
+	if err == sql.ErrNoRows {
+		return nil, ErrEventNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load event speaker: %w", err)
+	}
+	if !speakerID.Valid || !name.Valid {
+		return nil, ErrEventHasNoSpeaker
+	}
+	c.SpeakerID, c.SpeakerName, c.Email = int(speakerID.Int64), name.String, strings.TrimSpace(crypto.DecryptPII(email.String))
+	return &c, nil
+}
+
+// CreateSpeakerInvitation - Lưu lời mời (chỉ lưu hash của token)
+func (r *EventRepository) CreateSpeakerInvitation(ctx context.Context, eventID, speakerID, invitedBy int, email, tokenHash string, expiresAt time.Time) error {
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO Speaker_Invitation (speaker_id, event_id, email, token_hash, invited_by, expires_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`, speakerID, eventID, email, tokenHash, invitedBy, expiresAt)
+	if err != nil {
+		return fmt.Errorf("failed to create speaker invitation: %w", err)
+	}
+	return nil
+}
+
+// AcceptSpeakerInvitation - Dùng token để liên kết hồ sơ Speaker với tài khoản cùng email
+// Email chưa có tài khoản: tạo tài khoản SPEAKER (cần passwordHash); tất cả trong một transaction
+func (r *EventRepository) AcceptSpeakerInvitation(ctx context.Context, tokenHash, passwordHash string) (*models.AcceptSpeakerInvitationResponse, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var (
+		invitationID, speakerID, eventID int
+		email, speakerName               string
+		expiresAt                        time.Time
+		acceptedAt                       sql.NullTime
+	)
+	err = tx.QueryRowContext(ctx, `
+		SELECT si.invitation_id, si.speaker_id, si.event_id, si.email, si.expires_at, si.accepted_at, s.full_name
+		FROM Speaker_Invitation si
+		JOIN Speaker s ON s.speaker_id = si.speaker_id
+		WHERE si.token_hash = ?
+		FOR UPDATE
+	`, tokenHash).Scan(&invitationID, &speakerID, &eventID, &email, &expiresAt, &acceptedAt, &speakerName)
+	if err == sql.ErrNoRows {
+		return nil, ErrSpeakerInvitationInvalid
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load speaker invitation: %w", err)
+	}
+	if acceptedAt.Valid || time.Now().After(expiresAt) {
+		return nil, ErrSpeakerInvitationInvalid
+	}
+
+	resp := &models.AcceptSpeakerInvitationResponse{Email: email, EventID: eventID}
+	var status string
+	err = tx.QueryRowContext(ctx, `SELECT user_id, status FROM Users WHERE email = ?`, email).Scan(&resp.UserID, &status)
+	switch {
+	case err == sql.ErrNoRows:
+		if passwordHash == "" {
+			return nil, ErrSpeakerPasswordRequired
+		}
+		result, err := tx.ExecContext(ctx, `
+			INSERT INTO Users (full_name, email, password_hash, role, status, Wallet)
+			VALUES (?, ?, ?, 'SPEAKER', 'ACTIVE', 0)
+		`, speakerName, email, passwordHash)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create speaker account: %w", err)
+		}
+		id, err := result.LastInsertId()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get speaker account id: %w", err)
+		}
+		resp.UserID, resp.AccountCreated = int(id), true
+	case err != nil:
+		return nil, fmt.Errorf("failed to find account: %w", err)
+	case status != "ACTIVE":
+		return nil, ErrSpeakerAccountNotAvailable
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE Speaker SET user_id = ? WHERE speaker_id = ?`, resp.UserID, speakerID); err != nil {
+		return nil, fmt.Errorf("failed to link speaker: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE Speaker_Invitation SET accepted_at = NOW(), accepted_user_id = ? WHERE invitation_id = ?
+	`, resp.UserID, invitationID); err != nil {
+		return nil, fmt.Errorf("failed to mark invitation accepted: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return resp, nil
+}
+
+// ListSpeakerEvents - Sự kiện có speaker liên kết với user, kèm tài liệu đã đăng
+func (r *EventRepository) ListSpeakerEvents(ctx context.Context, userID int) ([]models.SpeakerEvent, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT e.event_id, e.title, e.start_time, e.end_time, e.status, e.banner_url, v.venue_name, va.area_name
+		FROM Event e
+		JOIN Speaker s ON s.speaker_id = e.speaker_id
+		LEFT JOIN Venue_Area va ON va.area_id = e.area_id
+		LEFT JOIN Venue v ON v.venue_id = va.venue_id
+		WHERE s.user_id = ?
+		ORDER BY e.start_time DESC
+	`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list speaker events: %w", err)
+	}
+	defer rows.Close()
+
+	result := []models.SpeakerEvent{}
+	for rows.Next() {
+		var e models.SpeakerEvent
+		if err := rows.Scan(&e.EventID, &e.Title, &e.StartTime, &e.EndTime, &e.Status,
+			&e.BannerURL, &e.VenueName, &e.AreaName); err != nil {
+			return nil, err
+		}
+		result = append(result, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for i := range result {
+		if result[i].Materials, err = r.ListSessionMaterials(ctx, result[i].EventID); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// GetSpeakerProfile - Hồ sơ speaker của user (bio/avatar lấy từ dòng Speaker liên kết gần nhất)
+func (r *EventRepository) GetSpeakerProfile(ctx context.Context, userID int) (*models.SpeakerProfile, error) {
+	var p models.SpeakerProfile
+	err := r.db.QueryRowContext(ctx, `
+		SELECT u.full_name, u.email, s.bio, s.avatar_url
+		FROM Speaker s
+		JOIN Users u ON u.user_id = s.user_id
+		WHERE s.user_id = ?
+		ORDER BY s.speaker_id DESC
+		LIMIT 1
+	`, userID).Scan(&p.FullName, &p.Email, &p.Bio, &p.AvatarURL)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotSpeaker
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load speaker profile: %w", err)
+	}
+	return &p, nil
+}
+
+// UpdateSpeakerProfile - Cập nhật bio/avatar trên mọi dòng Speaker đã liên kết với user
+func (r *EventRepository) UpdateSpeakerProfile(ctx context.Context, userID int, bio, avatarURL *string) error {
+	if _, err := r.GetSpeakerProfile(ctx, userID); err != nil {
+		return err
+	}
+	_, err := r.db.ExecContext(ctx, `UPDATE Speaker SET bio = ?, avatar_url = ? WHERE user_id = ?`, bio, avatarURL, userID)
+	if err != nil {
+		return fmt.Errorf("failed to update speaker profile: %w", err)
+	}
+	return nil
+}
+
+// GetLinkedSpeakerID - Speaker của sự kiện nếu đã liên kết với user
+func (r *EventRepository) GetLinkedSpeakerID(ctx context.Context, eventID, userID int) (int, error) {
+	var speakerID sql.NullInt64
+	var linkedUser sql.NullInt64
+	err := r.db.QueryRowContext(ctx, `
+		SELECT e.speaker_id, s.user_id
+		FROM Event e
+		LEFT JOIN Speaker s ON s.speaker_id = e.speaker_id
+		WHERE e.event_id = ?
+	`, eventID).Scan(&speakerID, &linkedUser)
+	if err == sql.ErrNoRows {
+		return 0, ErrEventNotFound
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to check event speaker: %w", err)
+	}
+	if !speakerID.Valid || !linkedUser.Valid || int(linkedUser.Int64) != userID {
+		return 0, ErrNotEventSpeaker
+	}
+	return int(speakerID.Int64), nil
+}
+
+// ListSessionMaterials - Tài liệu buổi nói của sự kiện (cũ trước)
+func (r *EventRepository) ListSessionMaterials(ctx context.Context, eventID int) ([]models.SessionMaterial, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT material_id, event_id, title, url, created_at
+		FROM Event_Session_Material
+		WHERE event_id = ?
+		ORDER BY created_at, material_id
+	`, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list session materials: %w", err)
+	}
+	defer rows.Close()
+
+	materials := []models.SessionMaterial{}
+	for rows.Next() {
+		var m models.SessionMaterial
+		if err := rows.Scan(&m.MaterialID, &m.EventID, &m.Title, &m.URL, &m.CreatedAt); err != nil {
+			return nil, err
+		}
+		materials = append(materials, m)
+	}
+	return materials, rows.Err()
+}
+
+// AddSessionMaterial - Speaker đăng tài liệu, tối đa limit tài liệu mỗi sự kiện
+func (r *EventRepository) AddSessionMaterial(ctx context.Context, eventID, speakerID, userID int, title, url string, limit int) (*models.SessionMaterial, error) {
+	var count int
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM Event_Session_Material WHERE event_id = ?`, eventID).Scan(&count); err != nil {
+		return nil, fmt.Errorf("failed to count session materials: %w", err)
+	}
+	if count >= limit {
+		return nil, ErrTooManySessionMaterials
+	}
+
+	result, err := r.db.ExecContext(ctx, `
+		INSERT INTO Event_Session_Material (event_id, speaker_id, title, url, created_by)
+		VALUES (?, ?, ?, ?, ?)
+	`, eventID, speakerID, title, url, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add session material: %w", err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get session material id: %w", err)
+	}
+	return &models.SessionMaterial{MaterialID: int(id), EventID: eventID, Title: title, URL: url, CreatedAt: time.Now()}, nil
+}
+
+// DeleteSessionMaterial - Xóa tài liệu của sự kiện
+func (r *EventRepository) DeleteSessionMaterial(ctx context.Context, eventID, materialID int) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM Event_Session_Material WHERE material_id = ? AND event_id = ?`, materialID, eventID)
+	if err != nil {
+		return fmt.Errorf("failed to delete session material: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return ErrSessionMaterialNotFound
+	}
+	return nil
+}
+
+// encryptSpeakerContact - Mã hóa email / số điện thoại của Speaker trước khi ghi DB
+func encryptSpeakerContact(email, phone string) (string, string, error) {
+	storedEmail, err := crypto.EncryptPII(email)
+	if err != nil {
+		return "", "", err
+	}
+	storedPhone, err := crypto.EncryptPII(phone)
+	if err != nil {
+		return "", "", err
+	}
+	return storedEmail, storedPhone, nil
+}
+
+// EncryptPlaintextSpeakerContacts mã hóa email / số điện thoại Speaker còn lưu nguyên văn
+// Xử lý theo lô theo speaker_id tăng dần; UPDATE kèm giá trị cũ để không ghi đè thay đổi đồng thời.
+// dryRun = true chỉ đếm số speaker cần mã hóa.
+func (r *EventRepository) EncryptPlaintextSpeakerContacts(ctx context.Context, batchSize int, dryRun bool) (int, error) {
+	lastID, total := 0, 0
+	for {
+		rows, err := r.db.QueryContext(ctx, `
+			SELECT speaker_id, COALESCE(email, ''), COALESCE(phone, '') FROM Speaker
+			WHERE speaker_id > ?
+			  AND ((email <> '' AND email NOT LIKE 'enc:v1:%') OR (phone <> '' AND phone NOT LIKE 'enc:v1:%'))
+			ORDER BY speaker_id
+			LIMIT ?
+		`, lastID, batchSize)
+		if err != nil {
+			return total, fmt.Errorf("failed to query plaintext speaker contacts: %w", err)
+		}
+		type pending struct {
+			id           int
+			email, phone string
+		}
+		var batch []pending
+		for rows.Next() {
+			var p pending
+			if err := rows.Scan(&p.id, &p.email, &p.phone); err != nil {
+				rows.Close()
+				return total, fmt.Errorf("failed to scan speaker contact: %w", err)
+			}
+			batch = append(batch, p)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return total, err
+		}
+		if len(batch) == 0 {
+			return total, nil
+		}
+
+		for _, p := range batch {
+			lastID = p.id
+			if dryRun {
+				total++
+				continue
+			}
+			email, phone, err := encryptSpeakerContact(p.email, p.phone)
+			if err != nil {
+				return total, err
+			}
+			result, err := r.db.ExecContext(ctx, `
+				UPDATE Speaker SET email = NULLIF(?, ''), phone = NULLIF(?, '')
+				WHERE speaker_id = ? AND COALESCE(email, '') = ? AND COALESCE(phone, '') = ?
+			`, email, phone, p.id, p.email, p.phone)
+			if err != nil {
+				return total, fmt.Errorf("failed to encrypt contact of speaker %d: %w", p.id, err)
+			}
+			if n, _ := result.RowsAffected(); n > 0 {
+				total++
+			}
+		}
+	}
+}
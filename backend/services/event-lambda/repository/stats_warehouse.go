@@ -0,0 +1,198 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/fpt-event-services/services/event-lambda/models"
+)
+
+// ============================================================
+// Kho số liệu thống kê (Daily_Event_Stats, Daily_Revenue)
+// Job stats-aggregation gom hoạt động của từng ngày đã qua vào bảng tổng hợp;
+// API thống kê cộng số liệu tổng hợp với phần "realtime" tính trực tiếp
+// từ Ticket/Report kể từ ngày đầu tiên chưa được tổng hợp (RealtimeSince)
+// Mỗi chỉ số gắn với một mốc thời gian nên cộng dồn theo ngày được:
+//   - ticketsSold / complimentary / revenue: theo Ticket.created_at
+//   - checkIns / checkOuts: theo checkin_time / check_out_time
+//   - refunds / refundAmount: theo Report.processed_at (report APPROVED)
+//   - platformFee: phí lưu trên Bill_Item, cộng theo ngày bán và trừ theo ngày hoàn tiền
+// Doanh thu lấy giá thực trả trên Bill_Item (vé chưa có dòng hóa đơn dùng giá của loại vé)
+// ============================================================
+
+// statsTicketStatuses - Vé được tính vào thống kê (khớp GetEventStats trước đây)
+const statsTicketStatuses = `('BOOKED', 'CHECKED_IN', 'CHECKED_OUT', 'REFUNDED')`
+
+// EventActivitySQL - Mỗi dòng là một hoạt động trong [from, to) kèm event_id
+// Cột: event_id, sold, comp, revenue, check_ins, check_outs, refunds, refund_amount, fee
+// Tham số: EventActivityArgs(from, to) hoặc RealtimeActivityArgs(since)
+const EventActivitySQL = `
+	SELECT t.event_id, 1 AS sold, t.is_complimentary AS comp,
+	       CASE WHEN t.is_complimentary = 0 THEN COALESCE(bi.unit_price, ct.price, 0) ELSE 0 END AS revenue,
+	       0 AS check_ins, 0 AS check_outs, 0 AS refunds, 0 AS refund_amount, COALESCE(bi.fee_amount, 0) AS fee
+	FROM Ticket t JOIN Category_Ticket ct ON ct.category_ticket_id = t.category_ticket_id
+	LEFT JOIN Bill_Item bi ON bi.ticket_id = t.ticket_id
+	WHERE t.status IN ` + statsTicketStatuses + ` AND t.created_at >= ? AND t.created_at < ?
+	UNION ALL
+	SELECT t.event_id, 0, 0, 0, 1, 0, 0, 0, 0 FROM Ticket t
+	WHERE t.status IN ` + statsTicketStatuses + ` AND t.checkin_time >= ? AND t.checkin_time < ?
+	UNION ALL
+	SELECT t.event_id, 0, 0, 0, 0, 1, 0, 0, 0 FROM Ticket t
+	WHERE t.status IN ` + statsTicketStatuses + ` AND t.check_out_time >= ? AND t.check_out_time < ?
+	UNION ALL
+	SELECT t.event_id, 0, 0, 0, 0, 0, 1, COALESCE(rp.refund_amount, 0), -COALESCE(bi.fee_amount, 0)
+	FROM Report rp JOIN Ticket t ON t.ticket_id = rp.ticket_id
+	LEFT JOIN Bill_Item bi ON bi.ticket_id = t.ticket_id
+	WHERE rp.status = 'APPROVED' AND rp.processed_at >= ? AND rp.processed_at < ?`
+
+// statsLocation - Múi giờ chia ngày thống kê, khớp loc của DSN (common/db)
+var statsLocation = func() *time.Location {
+	if loc, err := time.LoadLocation("Asia/Ho_Chi_Minh"); err == nil {
+		return loc
+	}
+	return time.FixedZone("UTC+7", 7*60*60)
+}()
+
+// statsOpenEnd - Cận trên của phần realtime (không giới hạn)
+var statsOpenEnd = time.Date(9999, 12, 31, 0, 0, 0, 0, statsLocation)
+
+// EventActivityArgs - Tham số của EventActivitySQL (from, to lặp lại cho 4 nguồn hoạt động)
+func EventActivityArgs(from, to time.Time) []interface{} {
+	return []interface{}{from, to, from, to, from, to, from, to}
+}
+
+// RealtimeActivityArgs - Tham số của EventActivitySQL cho phần realtime (từ since, không giới hạn cuối)
+func RealtimeActivityArgs(since time.Time) []interface{} {
+	return EventActivityArgs(since, statsOpenEnd)
+}
+
+// StatsDay - 00:00 (giờ Việt Nam) của ngày chứa t
+func StatsDay(t time.Time) time.Time {
+	y, m, d := t.In(statsLocation).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, statsLocation)
+}
+
+// StatsWatermark - Ngày mới nhất đã được tổng hợp (nil = chưa tổng hợp ngày nào)
+func (r *EventRepository) StatsWatermark(ctx context.Context) (*time.Time, error) {
+	var last sql.NullTime
+	if err := r.db.QueryRowContext(ctx, `SELECT MAX(stat_date) FROM Daily_Revenue`).Scan(&last); err != nil {
+		return nil, fmt.Errorf("failed to read stats watermark: %w", err)
+	}
+	if !last.Valid {
+		return nil, nil
+	}
+	day := StatsDay(last.Time)
+	return &day, nil
+}
+
+// RealtimeSince - Mốc bắt đầu phần số liệu tính trực tiếp (ngày sau watermark)
+// Chưa tổng hợp ngày nào thì trả về zero time: toàn bộ số liệu là realtime
+func (r *EventRepository) RealtimeSince(ctx context.Context) (time.Time, error) {
+	last, err := r.StatsWatermark(ctx)
+	if err != nil || last == nil {
+		return time.Time{}, err
+	}
+	return last.AddDate(0, 0, 1), nil
+}
+
+// FirstActivityDay - Ngày có vé đầu tiên (nil = chưa có vé)
+func (r *EventRepository) FirstActivityDay(ctx context.Context) (*time.Time, error) {
+	var first sql.NullTime
+	if err := r.db.QueryRowContext(ctx, `SELECT MIN(created_at) FROM Ticket`).Scan(&first); err != nil {
+		return nil, fmt.Errorf("failed to read first ticket date: %w", err)
+	}
+	if !first.Valid {
+		return nil, nil
+	}
+	day := StatsDay(first.Time)
+	return &day, nil
+}
+
+// AggregateStatsDay - Tính lại số liệu của một ngày (chạy lại nhiều lần cho cùng kết quả)
+// Daily_Event_Stats và Daily_Revenue của ngày được ghi trong cùng transaction
+func (r *EventRepository) AggregateStatsDay(ctx context.Context, day time.Time) error {
+	from := StatsDay(day)
+	to := from.AddDate(0, 0, 1)
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM Daily_Event_Stats WHERE stat_date = ?`, from); err != nil {
+		return fmt.Errorf("failed to clear daily event stats: %w", err)
+	}
+	args := append([]interface{}{from}, EventActivityArgs(from, to)...)
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO Daily_Event_Stats (stat_date, event_id, tickets_sold, complimentary, revenue,
+		                               check_ins, check_outs, refunds, refund_amount, platform_fee)
+		SELECT ?, a.event_id, SUM(a.sold), SUM(a.comp), SUM(a.revenue),
+		       SUM(a.check_ins), SUM(a.check_outs), SUM(a.refunds), SUM(a.refund_amount), SUM(a.fee)
+		FROM (`+EventActivitySQL+`) a
+		GROUP BY a.event_id
+	`, args...); err != nil {
+		return fmt.Errorf("failed to aggregate daily event stats: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO Daily_Revenue (stat_date, tickets_sold, complimentary, revenue, check_ins, refunds, refund_amount,
+		                           platform_fee)
+		SELECT ?, COALESCE(SUM(tickets_sold), 0), COALESCE(SUM(complimentary), 0), COALESCE(SUM(revenue), 0),
+		       COALESCE(SUM(check_ins), 0), COALESCE(SUM(refunds), 0), COALESCE(SUM(refund_amount), 0),
+		       COALESCE(SUM(platform_fee), 0)
+		FROM Daily_Event_Stats WHERE stat_date = ?
+		ON DUPLICATE KEY UPDATE
+		  tickets_sold = VALUES(tickets_sold), complimentary = VALUES(complimentary), revenue = VALUES(revenue),
+		  check_ins = VALUES(check_ins), refunds = VALUES(refunds), refund_amount = VALUES(refund_amount),
+		  platform_fee = VALUES(platform_fee)
+	`, from, from); err != nil {
+		return fmt.Errorf("failed to aggregate daily revenue: %w", err)
+	}
+	return tx.Commit()
+}
+
+// warehouseEventStats - Thống kê các sự kiện thỏa eventFilter (điều kiện trên alias e):
+// số liệu đã tổng hợp trước since + hoạt động realtime từ since
+func (r *EventRepository) warehouseEventStats(ctx context.Context, since time.Time, eventFilter string, filterArgs ...interface{}) (*models.EventStatsResponse, error) {
+	args := append([]interface{}{since}, RealtimeActivityArgs(since)...)
+	args = append(args, filterArgs...)
+
+	var stats models.EventStatsResponse
+	err := r.db.QueryRowContext(ctx, `
+		SELECT COALESCE(SUM(x.sold), 0), COALESCE(SUM(x.comp), 0), COALESCE(SUM(x.revenue), 0),
+		       COALESCE(SUM(x.check_ins), 0), COALESCE(SUM(x.check_outs), 0), COALESCE(SUM(x.refunds), 0),
+		       COALESCE(SUM(x.fee), 0)
+		FROM (
+			SELECT d.event_id, d.tickets_sold AS sold, d.complimentary AS comp, d.revenue,
+			       d.check_ins, d.check_outs, d.refunds, d.refund_amount, d.platform_fee AS fee
+			FROM Daily_Event_Stats d WHERE d.stat_date < ?
+			UNION ALL
+			`+EventActivitySQL+`
+		) x
+		JOIN Event e ON e.event_id = x.event_id
+		WHERE `+eventFilter, args...).Scan(
+		&stats.TotalTickets, &stats.ComplimentaryCount, &stats.TotalRevenue,
+		&stats.CheckedInCount, &stats.CheckedOutCount, &stats.RefundedCount, &stats.PlatformFee)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read event stats: %w", err)
+	}
+
+	// Cashback đã hoàn khi check-out (Ticket_Cashback, migration 056) đọc trực tiếp, không qua kho số liệu
+	err = r.db.QueryRowContext(ctx, `
+		SELECT COUNT(*), COALESCE(SUM(c.amount), 0)
+		FROM Ticket_Cashback c
+		JOIN Event e ON e.event_id = c.event_id
+		WHERE `+eventFilter, filterArgs...).Scan(&stats.CashbackRedeemed, &stats.CashbackAmount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read event cashback: %w", err)
+	}
+
+	// Vé hoàn tiền đều đã check-in nên vé còn BOOKED = đã bán - đã check-in
+	stats.BookedCount = max(stats.TotalTickets-stats.CheckedInCount, 0)
+	if !since.IsZero() {
+		stats.RealtimeSince = &since
+	}
+	return &stats, nil
+}
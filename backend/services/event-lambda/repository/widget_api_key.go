@@ -0,0 +1,216 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/fpt-event-services/services/event-lambda/models"
+)
+
+// ============================================================
+// Widget_Api_Key - API key của widget nhúng, mỗi key thuộc một organizer
+// allowed_origins lưu mỗi dòng một origin (đã chuẩn hóa ở usecase)
+// ============================================================
+
+// Lỗi của API key widget
+var (
+	ErrWidgetKeyNotFound = errors.New("widget API key not found")
+	ErrTooManyWidgetKeys = errors.New("too many active widget API keys")
+)
+
+// maxActiveWidgetKeys - Số key ACTIVE tối đa của một organizer
+const maxActiveWidgetKeys = 10
+
+// CreateWidgetAPIKey - Lưu key mới (chỉ hash + prefix)
+func (r *EventRepository) CreateWidgetAPIKey(ctx context.Context, organizerID int, name, prefix, hash string, origins []string) (int, error) {
+	var active int
+	if err := r.db.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM Widget_Api_Key WHERE organizer_id = ? AND status = 'ACTIVE'`, organizerID,
+	).Scan(&active); err != nil {
+		return 0, fmt.Errorf("failed to count widget keys: %w", err)
+	}
+	if active >= maxActiveWidgetKeys {
+		return 0, ErrTooManyWidgetKeys
+	}
+
+	result, err := r.db.ExecContext(ctx, `
+		INSERT INTO Widget_Api_Key (organizer_id, name, key_prefix, key_hash, allowed_origins)
+		VALUES (?, ?, ?, ?, ?)
+	`, organizerID, name, prefix, hash, strings.Join(origins, "\n"))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create widget key: %w", err)
+	}
+	id, err := result.LastInsertId()
+	return int(id), err
+}
+
+const widgetKeyColumns = `key_id, name, key_prefix, allowed_origins, status, created_at, revoked_at`
+
+func scanWidgetKey(row interface{ Scan(...interface{}) error }) (*models.WidgetAPIKey, error) {
+	var k models.WidgetAPIKey
+	var origins string
+	var revokedAt sql.NullTime
+	if err := row.Scan(&k.KeyID, &k.Name, &k.KeyPrefix, &origins, &k.Status, &k.CreatedAt, &revokedAt); err != nil {
+		return nil, err
+	}
+	k.AllowedOrigins = splitOrigins(origins)
+	if revokedAt.Valid {
+		k.RevokedAt = &revokedAt.Time
+	}
+	return &k, nil
+}
+
+func splitOrigins(s string) []string {
+	origins := []string{}
+	for _, o := range strings.Split(s, "\n") {
+		if o = strings.TrimSpace(o); o != "" {
+			origins = append(origins, o)
+		}
+	}
+	return origins
+}
+
+// ListWidgetAPIKeys - Key của organizer, ACTIVE trước
+func (r *EventRepository) ListWidgetAPIKeys(ctx context.Context, organizerID int) ([]models.WidgetAPIKey, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+widgetKeyColumns+` FROM Widget_Api_Key
+		WHERE organizer_id = ?
+		ORDER BY status = 'ACTIVE' DESC, created_at DESC
+	`, organizerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list widget keys: %w", err)
+	}
+	defer rows.Close()
+
+	keys := []models.WidgetAPIKey{}
+	for rows.Next() {
+		k, err := scanWidgetKey(rows)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, *k)
+	}
+	return keys, rows.Err()
+}
+
+// GetWidgetAPIKey - Key của organizer theo ID
+func (r *EventRepository) GetWidgetAPIKey(ctx context.Context, organizerID, keyID int) (*models.WidgetAPIKey, error) {
+	k, err := scanWidgetKey(r.db.QueryRowContext(ctx,
+		`SELECT `+widgetKeyColumns+` FROM Widget_Api_Key WHERE key_id = ? AND organizer_id = ?`, keyID, organizerID))
+	if err == sql.ErrNoRows {
+		return nil, ErrWidgetKeyNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get widget key: %w", err)
+	}
+	return k, nil
+}
+
+// UpdateWidgetAPIKey - Đổi tên / danh sách origin của key ACTIVE
+func (r *EventRepository) UpdateWidgetAPIKey(ctx context.Context, organizerID, keyID int, name string, origins []string) error {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE Widget_Api_Key SET name = ?, allowed_origins = ?
+		WHERE key_id = ? AND organizer_id = ? AND status = 'ACTIVE'
+	`, name, strings.Join(origins, "\n"), keyID, organizerID)
+	if err != nil {
+		return fmt.Errorf("failed to update widget key: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		// Không đổi gì (cùng giá trị) cũng trả về 0 dòng: kiểm tra key còn tồn tại
+		k, err := r.GetWidgetAPIKey(ctx, organizerID, keyID)
+		if err != nil {
+			return err
+		}
+		if k.Status != "ACTIVE" {
+			return ErrWidgetKeyNotFound
+		}
+	}
+	return nil
+}
+
+// RevokeWidgetAPIKey - Thu hồi key (không xóa để còn lịch sử)
+func (r *EventRepository) RevokeWidgetAPIKey(ctx context.Context, organizerID, keyID int) error {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE Widget_Api_Key SET status = 'REVOKED', revoked_at = NOW()
+		WHERE key_id = ? AND organizer_id = ? AND status = 'ACTIVE'
+	`, keyID, organizerID)
+	if err != nil {
+		return fmt.Errorf("failed to revoke widget key: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return ErrWidgetKeyNotFound
+	}
+	return nil
+}
+
+// FindActiveWidgetKey - Key ACTIVE theo hash (nil nếu không có / đã thu hồi)
+func (r *EventRepository) FindActiveWidgetKey(ctx context.Context, hash string) (*models.WidgetKeyOwner, error) {
+	var owner models.WidgetKeyOwner
+	var origins string
+	err := r.db.QueryRowContext(ctx, `
+		SELECT key_id, organizer_id, allowed_origins FROM Widget_Api_Key
+		WHERE key_hash = ? AND status = 'ACTIVE'
+	`, hash).Scan(&owner.KeyID, &owner.OrganizerID, &origins)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find widget key: %w", err)
+	}
+	owner.AllowedOrigins = splitOrigins(origins)
+	return &owner, nil
+}
+
+// WidgetEventRow - Sự kiện sắp diễn ra của organizer (usecase gắn link và mức còn vé)
+type WidgetEventRow struct {
+	models.WidgetEvent
+	Slug *string
+}
+
+// ListWidgetEvents - Sự kiện OPEN chưa kết thúc do organizer tạo, gần nhất trước
+func (r *EventRepository) ListWidgetEvents(ctx context.Context, organizerID, limit int) ([]WidgetEventRow, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT e.event_id, e.title, e.slug, e.start_time, e.end_time,
+		       v.venue_name, va.area_name, COALESCE(e.banner_card_url, e.banner_url)
+		FROM Event e
+		LEFT JOIN Venue_Area va ON e.area_id = va.area_id
+		LEFT JOIN Venue v ON va.venue_id = v.venue_id
+		WHERE e.created_by = ? AND e.status = 'OPEN' AND e.end_time > NOW()
+		ORDER BY e.start_time
+		LIMIT ?
+	`, organizerID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list widget events: %w", err)
+	}
+	defer rows.Close()
+
+	var items []WidgetEventRow
+	for rows.Next() {
+		var it WidgetEventRow
+		var eventSlug, venueName, areaName, banner sql.NullString
+		var start, end time.Time
+		if err := rows.Scan(&it.EventID, &it.Title, &eventSlug, &start, &end, &venueName, &areaName, &banner); err != nil {
+			return nil, err
+		}
+		it.StartTime = start.Format(time.RFC3339)
+		it.EndTime = end.Format(time.RFC3339)
+		if eventSlug.Valid {
+			it.Slug = &eventSlug.String
+		}
+		if venueName.Valid {
+			it.VenueName = &venueName.String
+		}
+		if areaName.Valid {
+			it.AreaName = &areaName.String
+		}
+		if banner.Valid {
+			it.BannerURL = &banner.String
+		}
+		items = append(items, it)
+	}
+	return items, rows.Err()
+}
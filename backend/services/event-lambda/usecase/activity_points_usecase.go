@@ -0,0 +1,60 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fpt-event-services/common/permission"
+	"github.com/fpt-event-services/services/event-lambda/models"
+)
+
+// MaxActivityPoints - Điểm rèn luyện tối đa của một sự kiện
+const MaxActivityPoints = 100
+
+// ErrInvalidActivityPoints - Điểm thiếu hoặc ngoài khoảng 0..MaxActivityPoints
+var ErrInvalidActivityPoints = fmt.Errorf("points must be between 0 and %d", MaxActivityPoints)
+
+// GetActivityPoints - Điểm rèn luyện hiện tại của sự kiện
+func (uc *EventUseCase) GetActivityPoints(ctx context.Context, eventID, userID int, role string) (*models.ActivityPointsSettings, error) {
+	settings, err := uc.eventRepo.GetActivityPoints(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.requireActivityPoints(ctx, eventID, userID, role); err != nil {
+		return nil, err
+	}
+	settings.MaxPoints = MaxActivityPoints
+	return settings, nil
+}
+
+// UpdateActivityPoints - Đặt điểm rèn luyện (0 = không tính điểm)
+func (uc *EventUseCase) UpdateActivityPoints(ctx context.Context, eventID, userID int, role string, req models.UpdateActivityPointsRequest) (*models.ActivityPointsSettings, error) {
+	if _, err := uc.eventRepo.GetActivityPoints(ctx, eventID); err != nil {
+		return nil, err
+	}
+	if err := uc.requireActivityPoints(ctx, eventID, userID, role); err != nil {
+		return nil, err
+	}
+	if err := validateActivityPoints(req.Points); err != nil {
+		return nil, err
+	}
+	if err := uc.eventRepo.SetActivityPoints(ctx, eventID, *req.Points); err != nil {
+		return nil, err
+	}
+	return uc.GetActivityPoints(ctx, eventID, userID, role)
+}
+
+// requireActivityPoints - Quyền attendance.points (STAFF, ADMIN) hoặc quyền sửa chi tiết sự kiện
+func (uc *EventUseCase) requireActivityPoints(ctx context.Context, eventID, userID int, role string) error {
+	if permission.RoleHas(ctx, role, permission.AttendancePoints) {
+		return nil
+	}
+	return uc.requireEditDetails(ctx, eventID, userID, role)
+}
+
+func validateActivityPoints(points *int) error {
+	if points == nil || *points < 0 || *points > MaxActivityPoints {
+		return ErrInvalidActivityPoints
+	}
+	return nil
+}
@@ -0,0 +1,22 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidateActivityPoints(t *testing.T) {
+	valid := []int{0, 5, MaxActivityPoints}
+	for _, v := range valid {
+		if err := validateActivityPoints(&v); err != nil {
+			t.Errorf("points %d: unexpected error %v", v, err)
+		}
+	}
+
+	over, negative := MaxActivityPoints+1, -1
+	for _, p := range []*int{nil, &over, &negative} {
+		if err := validateActivityPoints(p); !errors.Is(err, ErrInvalidActivityPoints) {
+			t.Errorf("points %v: expected ErrInvalidActivityPoints, got %v", p, err)
+		}
+	}
+}
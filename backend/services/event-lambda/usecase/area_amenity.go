@@ -0,0 +1,34 @@
+package usecase
+
+import (
+	"slices"
+
+	"github.com/fpt-event-services/common/amenity"
+	"github.com/fpt-event-services/services/event-lambda/models"
+)
+
+// ============================================================
+// rankAreasByAmenities - Xếp khu vực trống theo mức khớp tiện ích yêu cầu
+// Thiếu ít tiện ích hơn đứng trước, cùng mức giữ thứ tự sức chứa tăng dần của repository
+// matchAll: bỏ các khu vực thiếu bất kỳ tiện ích nào
+// Không có tiện ích yêu cầu: giữ nguyên danh sách
+// ============================================================
+func rankAreasByAmenities(areas []models.AvailableAreaInfo, required []string, matchAll bool) []models.AvailableAreaInfo {
+	if len(required) == 0 {
+		return areas
+	}
+
+	ranked := make([]models.AvailableAreaInfo, 0, len(areas))
+	for _, area := range areas {
+		area.MatchedAmenities, area.MissingAmenities = amenity.Match(required, area.Amenities)
+		if matchAll && len(area.MissingAmenities) > 0 {
+			continue
+		}
+		ranked = append(ranked, area)
+	}
+
+	slices.SortStableFunc(ranked, func(a, b models.AvailableAreaInfo) int {
+		return len(a.MissingAmenities) - len(b.MissingAmenities)
+	})
+	return ranked
+}
@@ -0,0 +1,49 @@
+package usecase
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/fpt-event-services/common/amenity"
+	"github.com/fpt-event-services/services/event-lambda/models"
+)
+
+func TestRankAreasByAmenities(t *testing.T) {
+	area := func(id int, amenities ...string) models.AvailableAreaInfo {
+		return models.AvailableAreaInfo{AreaID: id, Amenities: amenities}
+	}
+	// Repository trả theo sức chứa tăng dần
+	areas := []models.AvailableAreaInfo{
+		area(1),
+		area(2, amenity.Projector),
+		area(3, amenity.Projector, amenity.Stage),
+		area(4, amenity.Stage),
+	}
+	required := []string{amenity.Projector, amenity.Stage}
+
+	ids := func(list []models.AvailableAreaInfo) []int {
+		result := []int{}
+		for _, a := range list {
+			result = append(result, a.AreaID)
+		}
+		return result
+	}
+
+	ranked := rankAreasByAmenities(areas, required, false)
+	if got, want := ids(ranked), []int{3, 2, 4, 1}; !slices.Equal(got, want) {
+		t.Fatalf("ranked = %v, want %v", got, want)
+	}
+	if len(ranked[1].MatchedAmenities) != 1 || ranked[1].MissingAmenities[0] != amenity.Stage {
+		t.Errorf("area 2 match = %v / %v", ranked[1].MatchedAmenities, ranked[1].MissingAmenities)
+	}
+
+	if got := ids(rankAreasByAmenities(areas, required, true)); !slices.Equal(got, []int{3}) {
+		t.Errorf("matchAll = %v, want [3]", got)
+	}
+	if got := ids(rankAreasByAmenities(areas, nil, true)); !slices.Equal(got, []int{1, 2, 3, 4}) {
+		t.Errorf("no requirement = %v, want unchanged", got)
+	}
+	if areas[1].MissingAmenities != nil {
+		t.Error("input slice was modified")
+	}
+}
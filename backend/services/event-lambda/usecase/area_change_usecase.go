@@ -0,0 +1,137 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/fpt-event-services/common/campus"
+	"github.com/fpt-event-services/common/email"
+	"github.com/fpt-event-services/services/event-lambda/models"
+	"github.com/fpt-event-services/services/event-lambda/repository"
+	venueclient "github.com/fpt-event-services/services/venue-lambda/client"
+)
+
+// maxAreaChangeReasonLength - Khớp Event_Area_Change.reason
+const maxAreaChangeReasonLength = 500
+
+// ErrInvalidAreaChange - Thiếu areaId hoặc lý do quá dài
+var ErrInvalidAreaChange = fmt.Errorf("areaId is required and reason must be at most %d characters", maxAreaChangeReasonLength)
+
+// ============================================================
+// ChangeEventArea - STAFF / ADMIN chuyển sự kiện đã duyệt sang khu vực khác
+// Sự kiện và khu vực mới phải thuộc campus của người thao tác
+// Sau khi lưu: người có vé BOOKED nhận thông báo trong app + email seat_change
+// (lỗi gửi chỉ ghi log, email lỗi nằm trong hàng đợi để gửi lại)
+// ============================================================
+func (uc *EventUseCase) ChangeEventArea(ctx context.Context, eventID, staffID int, req models.ChangeAreaRequest) (*models.AreaChange, error) {
+	if err := normalizeAreaChange(&req); err != nil {
+		return nil, err
+	}
+	eventCampus, err := uc.eventRepo.GetEventCampusID(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	if err := campus.Check(ctx, eventCampus); err != nil {
+		return nil, err
+	}
+	area, err := uc.venues.GetArea(ctx, req.AreaID)
+	if errors.Is(err, venueclient.ErrAreaNotFound) {
+		return nil, repository.ErrAreaNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := campus.Check(ctx, area.CampusID); err != nil {
+		return nil, err
+	}
+
+	change, err := uc.eventRepo.ChangeEventArea(ctx, eventID, req.AreaID, staffID, req.Reason, req.DryRun)
+	if err != nil || req.DryRun {
+		return change, err
+	}
+	change.Notified = uc.notifyAreaChange(ctx, change)
+	return change, nil
+}
+
+// ListAreaChanges - Lịch sử đổi khu vực của sự kiện (trong campus của người xem)
+func (uc *EventUseCase) ListAreaChanges(ctx context.Context, eventID int) ([]models.AreaChange, error) {
+	eventCampus, err := uc.eventRepo.GetEventCampusID(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	if err := campus.Check(ctx, eventCampus); err != nil {
+		return nil, err
+	}
+	return uc.eventRepo.ListAreaChanges(ctx, eventID)
+}
+
+// notifyAreaChange - Báo ghế mới cho từng người mua; trả về số người đã báo
+func (uc *EventUseCase) notifyAreaChange(ctx context.Context, change *models.AreaChange) int {
+	notice, err := uc.eventRepo.GetAreaChangeNotice(ctx, change.ChangeID)
+	if err != nil {
+		log.Printf("[AREA_CHANGE] ⚠️ Failed to load recipients of change %d: %v", change.ChangeID, err)
+		return 0
+	}
+
+	svc := email.NewEmailService(nil)
+	ticketsURL := frontendURL() + "/my-tickets"
+	for _, rc := range notice.Recipients {
+		seats := formatSeatChanges(rc.Tickets)
+		flagged := false
+		for _, t := range rc.Tickets {
+			flagged = flagged || t.NeedsReassignment
+		}
+
+		message := fmt.Sprintf("Sự kiện \"%s\" đã chuyển từ %s sang %s. Ghế của bạn: %s.",
+			notice.EventTitle, change.FromArea, change.ToArea, seats)
+		if flagged {
+			message += " Một số ghế sẽ được ban tổ chức xếp lại và báo cho bạn trước sự kiện."
+		}
+		if err := uc.eventRepo.NotifyUsers(ctx, []int{rc.UserID}, message); err != nil {
+			log.Printf("[AREA_CHANGE] ⚠️ Failed to notify user %d of change %d: %v", rc.UserID, change.ChangeID, err)
+		}
+
+		msg := svc.BuildSeatChangeEmail(rc.Email, rc.FullName, notice.EventTitle, notice.StartTime,
+			change.FromArea, change.ToArea, seats, flagged, ticketsURL)
+		if err := email.DefaultQueue().Send(ctx, email.TemplateSeatChange, msg, fmt.Sprintf("seat-change:%d:%d", change.ChangeID, rc.UserID)); err != nil {
+			log.Printf("[AREA_CHANGE] ⚠️ Failed to email user %d of change %d: %v", rc.UserID, change.ChangeID, err)
+		}
+	}
+	return len(notice.Recipients)
+}
+
+// normalizeAreaChange - Bỏ khoảng trắng của lý do (rỗng = không ghi)
+func normalizeAreaChange(req *models.ChangeAreaRequest) error {
+	if req.AreaID <= 0 {
+		return ErrInvalidAreaChange
+	}
+	if req.Reason != nil {
+		reason := strings.TrimSpace(*req.Reason)
+		req.Reason = &reason
+		if reason == "" {
+			req.Reason = nil
+		} else if len([]rune(reason)) > maxAreaChangeReasonLength {
+			return ErrInvalidAreaChange
+		}
+	}
+	return nil
+}
+
+// formatSeatChanges - "A5 → B3, A6 → TBD" (TBD: chờ xếp ghế)
+func formatSeatChanges(tickets []models.AreaChangeTicket) string {
+	parts := make([]string, 0, len(tickets))
+	for _, t := range tickets {
+		from, to := "—", "TBD"
+		if t.FromSeat != nil {
+			from = *t.FromSeat
+		}
+		if t.ToSeat != nil {
+			to = *t.ToSeat
+		}
+		parts = append(parts, from+" → "+to)
+	}
+	return strings.Join(parts, ", ")
+}
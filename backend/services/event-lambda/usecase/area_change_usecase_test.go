@@ -0,0 +1,37 @@
+package usecase
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/fpt-event-services/services/event-lambda/models"
+)
+
+func TestFormatSeatChanges(t *testing.T) {
+	a5, b3, a6 := "A5", "B3", "A6"
+	got := formatSeatChanges([]models.AreaChangeTicket{
+		{FromSeat: &a5, ToSeat: &b3},
+		{FromSeat: &a6},
+		{ToSeat: &b3},
+	})
+	if want := "A5 → B3, A6 → TBD, — → B3"; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+func TestNormalizeAreaChange(t *testing.T) {
+	blank := "   "
+	req := models.ChangeAreaRequest{AreaID: 3, Reason: &blank}
+	if err := normalizeAreaChange(&req); err != nil || req.Reason != nil {
+		t.Fatalf("blank reason: err=%v reason=%v", err, req.Reason)
+	}
+
+	if err := normalizeAreaChange(&models.ChangeAreaRequest{}); !errors.Is(err, ErrInvalidAreaChange) {
+		t.Fatalf("missing areaId: got %v", err)
+	}
+	long := strings.Repeat("x", maxAreaChangeReasonLength+1)
+	if err := normalizeAreaChange(&models.ChangeAreaRequest{AreaID: 3, Reason: &long}); !errors.Is(err, ErrInvalidAreaChange) {
+		t.Fatalf("long reason: got %v", err)
+	}
+}
@@ -0,0 +1,160 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"math"
+	"slices"
+
+	"github.com/fpt-event-services/common/amenity"
+	"github.com/fpt-event-services/common/rules"
+	"github.com/fpt-event-services/services/event-lambda/models"
+	"github.com/fpt-event-services/services/event-lambda/repository"
+)
+
+// ErrNoAreaSuggestion - Không còn khu vực nào trống, đủ sức chứa cho yêu cầu
+var ErrNoAreaSuggestion = errors.New("no conflict-free area can host this event request")
+
+// Trọng số điểm gợi ý khu vực (tổng = 1)
+const (
+	suggestionCapacityWeight    = 0.4
+	suggestionAmenityWeight     = 0.4
+	suggestionUtilizationWeight = 0.2
+)
+
+// ============================================================
+// SuggestAreas - Xếp hạng khu vực cho yêu cầu PENDING
+// Loại khu vực trùng lịch, đã đủ số sự kiện trong ngày hoặc thiếu sức chứa;
+// còn lại chấm theo độ vừa sức chứa, tiện ích và mức sử dụng (phòng ít dùng được ưu tiên)
+// Chỉ gợi ý khu vực thuộc campus của yêu cầu
+// ============================================================
+func (uc *EventUseCase) SuggestAreas(ctx context.Context, requestID int) (*models.AreaSuggestions, error) {
+	if err := uc.checkRequestCampus(ctx, requestID); err != nil {
+		return nil, err
+	}
+	req, err := uc.eventRepo.GetEventRequestByID(ctx, requestID)
+	if err != nil {
+		return nil, err
+	}
+	if req == nil {
+		return nil, repository.ErrEventRequestNotFound
+	}
+	if req.Status != "PENDING" {
+		return nil, repository.ErrRequestNotClaimable
+	}
+	requestCampus, err := uc.eventRepo.GetEventRequestCampusID(ctx, requestID)
+	if err != nil {
+		return nil, err
+	}
+	candidates, err := uc.eventRepo.GetAreaSuggestionCandidates(ctx, requestID, requestCampus)
+	if err != nil {
+		return nil, err
+	}
+
+	expected := 0
+	if req.ExpectedCapacity != nil {
+		expected = *req.ExpectedCapacity
+	}
+	required := req.RequiredAmenities
+	if required == nil {
+		required = []string{}
+	}
+	suggestions := rankAreaSuggestions(candidates, expected, required, rules.MaxEventsPerDay(ctx))
+	return &models.AreaSuggestions{
+		RequestID:         requestID,
+		ExpectedCapacity:  expected,
+		RequiredAmenities: required,
+		Suggestions:       suggestions,
+		ExcludedAreas:     len(candidates) - len(suggestions),
+	}, nil
+}
+
+// ============================================================
+// ApproveWithTopSuggestion - Duyệt yêu cầu với khu vực đứng đầu danh sách gợi ý
+// Đi qua ProcessEventRequest nên vẫn kiểm tra campus, claim và If-Match như duyệt thủ công
+// ============================================================
+func (uc *EventUseCase) ApproveWithTopSuggestion(ctx context.Context, requestID, adminID int, body models.ApproveSuggestedBody, expectedVersion *int) (*models.AreaSuggestion, error) {
+	result, err := uc.SuggestAreas(ctx, requestID)
+	if err != nil {
+		return nil, err
+	}
+	if len(result.Suggestions) == 0 {
+		return nil, ErrNoAreaSuggestion
+	}
+	top := result.Suggestions[0]
+
+	err = uc.ProcessEventRequest(ctx, adminID, &models.ProcessEventRequestBody{
+		RequestID:       requestID,
+		Action:          "APPROVED",
+		OrganizerNote:   body.OrganizerNote,
+		AreaID:          &top.AreaID,
+		ExpectedVersion: expectedVersion,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &top, nil
+}
+
+// rankAreaSuggestions - Lọc ràng buộc cứng rồi chấm điểm, điểm cao đứng trước
+// (cùng điểm giữ thứ tự sức chứa tăng dần của repository)
+func rankAreaSuggestions(candidates []models.AreaCandidate, expectedCapacity int, required []string, maxEventsPerDay int) []models.AreaSuggestion {
+	eligible := []models.AreaCandidate{}
+	maxBooked := 0
+	for _, c := range candidates {
+		if c.Conflicts > 0 || c.EventsOnDate >= maxEventsPerDay || c.Capacity < expectedCapacity {
+			continue
+		}
+		eligible = append(eligible, c)
+		maxBooked = max(maxBooked, c.BookedMinutes)
+	}
+
+	suggestions := make([]models.AreaSuggestion, 0, len(eligible))
+	for _, c := range eligible {
+		s := models.AreaSuggestion{
+			AreaID:             c.AreaID,
+			AreaName:           c.AreaName,
+			VenueName:          c.VenueName,
+			Floor:              c.Floor,
+			Capacity:           c.Capacity,
+			CampusID:           c.CampusID,
+			BookedHours:        math.Round(float64(c.BookedMinutes)/60*100) / 100,
+			CapacityFit:        1,
+			AmenityMatch:       1,
+			UtilizationBalance: 1,
+		}
+		s.MatchedAmenities, s.MissingAmenities = amenity.Match(required, c.Amenities)
+		if expectedCapacity > 0 && c.Capacity > 0 {
+			s.CapacityFit = float64(expectedCapacity) / float64(c.Capacity)
+		}
+		if len(required) > 0 {
+			s.AmenityMatch = float64(len(s.MatchedAmenities)) / float64(len(required))
+		}
+		if maxBooked > 0 {
+			s.UtilizationBalance = 1 - float64(c.BookedMinutes)/float64(maxBooked)
+		}
+		s.Score = suggestionCapacityWeight*s.CapacityFit +
+			suggestionAmenityWeight*s.AmenityMatch +
+			suggestionUtilizationWeight*s.UtilizationBalance
+		s.CapacityFit = roundScore(s.CapacityFit)
+		s.AmenityMatch = roundScore(s.AmenityMatch)
+		s.UtilizationBalance = roundScore(s.UtilizationBalance)
+		s.Score = roundScore(s.Score)
+		suggestions = append(suggestions, s)
+	}
+
+	slices.SortStableFunc(suggestions, func(a, b models.AreaSuggestion) int {
+		switch {
+		case a.Score > b.Score:
+			return -1
+		case a.Score < b.Score:
+			return 1
+		}
+		return 0
+	})
+	return suggestions
+}
+
+func roundScore(v float64) float64 {
+	return math.Round(v*1000) / 1000
+}
@@ -0,0 +1,47 @@
+package usecase
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/fpt-event-services/common/amenity"
+	"github.com/fpt-event-services/services/event-lambda/models"
+)
+
+func TestRankAreaSuggestions(t *testing.T) {
+	candidates := []models.AreaCandidate{
+		{AreaID: 1, Capacity: 50},                                          // Thiếu sức chứa
+		{AreaID: 2, Capacity: 100, Conflicts: 1},                           // Trùng lịch
+		{AreaID: 3, Capacity: 100, EventsOnDate: 2},                        // Đã đủ sự kiện trong ngày
+		{AreaID: 4, Capacity: 100, BookedMinutes: 600},                     // Vừa sức chứa nhưng dùng nhiều, thiếu tiện ích
+		{AreaID: 5, Capacity: 200, Amenities: []string{amenity.Projector}}, // Có tiện ích, chưa ai đặt
+		{AreaID: 6, Capacity: 400, Amenities: []string{amenity.Projector}, BookedMinutes: 300},
+	}
+
+	got := rankAreaSuggestions(candidates, 80, []string{amenity.Projector}, 2)
+	ids := []int{}
+	for _, s := range got {
+		ids = append(ids, s.AreaID)
+	}
+	if want := []int{5, 6, 4}; !slices.Equal(ids, want) {
+		t.Fatalf("ranking = %v, want %v", ids, want)
+	}
+
+	top := got[0]
+	if top.CapacityFit != 0.4 || top.AmenityMatch != 1 || top.UtilizationBalance != 1 || top.Score != 0.76 {
+		t.Errorf("top scores = %+v", top)
+	}
+	if last := got[2]; last.AmenityMatch != 0 || last.UtilizationBalance != 0 || len(last.MissingAmenities) != 1 || last.BookedHours != 10 {
+		t.Errorf("area 4 = %+v", last)
+	}
+
+	// Không yêu cầu tiện ích, không sức chứa: mọi khu vực trống đều đạt 1 ở hai tiêu chí đầu
+	for _, s := range rankAreaSuggestions(candidates[3:], 0, []string{}, 2) {
+		if s.CapacityFit != 1 || s.AmenityMatch != 1 {
+			t.Errorf("area %d without requirements = %+v", s.AreaID, s)
+		}
+	}
+	if got := rankAreaSuggestions(candidates[:3], 80, nil, 2); len(got) != 0 {
+		t.Errorf("expected no suggestions, got %+v", got)
+	}
+}
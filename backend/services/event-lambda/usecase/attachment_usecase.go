@@ -0,0 +1,224 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/fpt-event-services/services/event-lambda/models"
+)
+
+// ============================================================
+// Event attachments - Ban tổ chức đính kèm agenda, bản đồ, slide...
+// File upload: tối đa models.MaxAttachmentBytes, chỉ các định dạng trong attachmentFormats
+// ============================================================
+
+// Lỗi của tài liệu đính kèm ở tầng usecase
+var (
+	ErrInvalidAttachmentRequest = errors.New("invalid attachment request")
+)
+
+// attachmentFormat - Định dạng cho phép: content type lưu trữ và kết quả http.DetectContentType mong đợi
+type attachmentFormat struct {
+	contentType string
+	sniffed     string
+}
+
+// Office (docx/pptx/xlsx) là file zip nên DetectContentType trả về application/zip
+var attachmentFormats = map[string]attachmentFormat{
+	".pdf":  {"application/pdf", "application/pdf"},
+	".png":  {"image/png", "image/png"},
+	".jpg":  {"image/jpeg", "image/jpeg"},
+	".jpeg": {"image/jpeg", "image/jpeg"},
+	".docx": {"application/vnd.openxmlformats-officedocument.wordprocessingml.document", "application/zip"},
+	".pptx": {"application/vnd.openxmlformats-officedocument.presentationml.presentation", "application/zip"},
+	".xlsx": {"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", "application/zip"},
+}
+
+// ListEventAttachments - Mọi tài liệu của sự kiện (màn quản lý)
+func (uc *EventUseCase) ListEventAttachments(ctx context.Context, eventID, userID int, role string) ([]models.EventAttachment, error) {
+	if err := uc.eventRepo.CheckEventExists(ctx, eventID); err != nil {
+		return nil, err
+	}
+	if err := uc.requireEditDetails(ctx, eventID, userID, role); err != nil {
+		return nil, err
+	}
+	return uc.eventRepo.ListEventAttachments(ctx, eventID)
+}
+
+// CreateEventAttachment - Thêm link ngoài hoặc upload file lên storage
+func (uc *EventUseCase) CreateEventAttachment(ctx context.Context, eventID, userID int, role string, req *models.EventAttachmentRequest) (*models.EventAttachment, error) {
+	if err := uc.eventRepo.CheckEventExists(ctx, eventID); err != nil {
+		return nil, err
+	}
+	if err := uc.requireEditDetails(ctx, eventID, userID, role); err != nil {
+		return nil, err
+	}
+
+	a := &models.EventAttachment{EventID: eventID}
+	if err := applyAttachmentFields(a, req); err != nil {
+		return nil, err
+	}
+
+	link := strings.TrimSpace(req.URL)
+	switch {
+	case req.FileBase64 != "" && link != "":
+		return nil, fmt.Errorf("%w: send either url or fileBase64, not both", ErrInvalidAttachmentRequest)
+	case req.FileBase64 != "":
+		data, format, err := decodeAttachmentFile(req.FileName, req.FileBase64)
+		if err != nil {
+			return nil, err
+		}
+		// Kiểm tra giới hạn số lượng trước khi upload để không để lại file thừa trên storage
+		count, err := uc.eventRepo.CountEventAttachments(ctx, eventID)
+		if err != nil {
+			return nil, err
+		}
+		if count >= models.MaxAttachmentsPerEvent {
+			return nil, fmt.Errorf("%w: at most %d attachments per event", ErrInvalidAttachmentRequest, models.MaxAttachmentsPerEvent)
+		}
+		key, err := attachmentObjectKey(eventID, req.FileName)
+		if err != nil {
+			return nil, err
+		}
+		if a.URL, err = uc.fileStorage.Put(ctx, key, format.contentType, data); err != nil {
+			return nil, fmt.Errorf("failed to store attachment: %w", err)
+		}
+		a.ObjectKey, a.ContentType, a.SizeBytes = key, format.contentType, int64(len(data))
+	case link != "":
+		if err := validateAttachmentLink(link); err != nil {
+			return nil, err
+		}
+		a.URL = link
+	default:
+		return nil, fmt.Errorf("%w: url or fileBase64 is required", ErrInvalidAttachmentRequest)
+	}
+
+	return uc.eventRepo.CreateEventAttachment(ctx, a, userID, models.MaxAttachmentsPerEvent)
+}
+
+// UpdateEventAttachment - Sửa title/type/visibility; url chỉ sửa được với link ngoài
+func (uc *EventUseCase) UpdateEventAttachment(ctx context.Context, eventID, attachmentID, userID int, role string, req *models.EventAttachmentRequest) (*models.EventAttachment, error) {
+	a, err := uc.eventRepo.GetEventAttachment(ctx, eventID, attachmentID)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.requireEditDetails(ctx, eventID, userID, role); err != nil {
+		return nil, err
+	}
+	if req.FileBase64 != "" {
+		return nil, fmt.Errorf("%w: upload a new attachment to replace the file", ErrInvalidAttachmentRequest)
+	}
+	if err := applyAttachmentFields(a, req); err != nil {
+		return nil, err
+	}
+	if link := strings.TrimSpace(req.URL); link != "" && link != a.URL {
+		if a.ObjectKey != "" {
+			return nil, fmt.Errorf("%w: url of an uploaded file cannot be changed", ErrInvalidAttachmentRequest)
+		}
+		if err := validateAttachmentLink(link); err != nil {
+			return nil, err
+		}
+		a.URL = link
+	}
+
+	if err := uc.eventRepo.UpdateEventAttachment(ctx, a); err != nil {
+		return nil, err
+	}
+	return uc.eventRepo.GetEventAttachment(ctx, eventID, attachmentID)
+}
+
+// DeleteEventAttachment - Gỡ tài liệu khỏi sự kiện
+func (uc *EventUseCase) DeleteEventAttachment(ctx context.Context, eventID, attachmentID, userID int, role string) error {
+	if _, err := uc.eventRepo.GetEventAttachment(ctx, eventID, attachmentID); err != nil {
+		return err
+	}
+	if err := uc.requireEditDetails(ctx, eventID, userID, role); err != nil {
+		return err
+	}
+	return uc.eventRepo.DeleteEventAttachment(ctx, eventID, attachmentID)
+}
+
+// IsEventTicketHolder - Người xem có vé của sự kiện (được thấy tài liệu TICKET_HOLDERS)
+func (uc *EventUseCase) IsEventTicketHolder(ctx context.Context, eventID, userID int) (bool, error) {
+	return uc.eventRepo.HasEventTicket(ctx, eventID, userID)
+}
+
+// applyAttachmentFields - Chuẩn hóa và validate title/type/visibility
+// Type/visibility trống giữ giá trị cũ (tạo mới: DOCUMENT, PUBLIC)
+func applyAttachmentFields(a *models.EventAttachment, req *models.EventAttachmentRequest) error {
+	title := strings.TrimSpace(req.Title)
+	if title == "" || len([]rune(title)) > 200 {
+		return fmt.Errorf("%w: title is required (max 200 characters)", ErrInvalidAttachmentRequest)
+	}
+	a.Title = title
+
+	if t := strings.ToUpper(strings.TrimSpace(req.Type)); t != "" {
+		if !slices.Contains(models.AttachmentTypes, t) {
+			return fmt.Errorf("%w: type must be one of %s", ErrInvalidAttachmentRequest, strings.Join(models.AttachmentTypes, ", "))
+		}
+		a.Type = t
+	} else if a.Type == "" {
+		a.Type = models.AttachmentTypeDocument
+	}
+
+	if v := strings.ToUpper(strings.TrimSpace(req.Visibility)); v != "" {
+		if v != models.AttachmentPublic && v != models.AttachmentTicketHolders {
+			return fmt.Errorf("%w: visibility must be PUBLIC or TICKET_HOLDERS", ErrInvalidAttachmentRequest)
+		}
+		a.Visibility = v
+	} else if a.Visibility == "" {
+		a.Visibility = models.AttachmentPublic
+	}
+	return nil
+}
+
+// decodeAttachmentFile - Giải mã base64 (hỗ trợ data URL), kiểm tra dung lượng và định dạng thật của file
+func decodeAttachmentFile(fileName, encoded string) ([]byte, attachmentFormat, error) {
+	format, ok := attachmentFormats[strings.ToLower(path.Ext(fileName))]
+	if !ok {
+		return nil, attachmentFormat{}, fmt.Errorf("%w: only pdf, png, jpg, docx, pptx and xlsx files are allowed", ErrInvalidAttachmentRequest)
+	}
+	if idx := strings.Index(encoded, ","); strings.HasPrefix(encoded, "data:") && idx > 0 {
+		encoded = encoded[idx+1:]
+	}
+	if base64.StdEncoding.DecodedLen(len(encoded)) > models.MaxAttachmentBytes+3 {
+		return nil, format, fmt.Errorf("%w: file exceeds %d MB", ErrInvalidAttachmentRequest, models.MaxAttachmentBytes>>20)
+	}
+	data, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, format, fmt.Errorf("%w: invalid fileBase64", ErrInvalidAttachmentRequest)
+	}
+	if len(data) == 0 || len(data) > models.MaxAttachmentBytes {
+		return nil, format, fmt.Errorf("%w: file must be between 1 byte and %d MB", ErrInvalidAttachmentRequest, models.MaxAttachmentBytes>>20)
+	}
+	if sniffed := http.DetectContentType(data); !strings.HasPrefix(sniffed, format.sniffed) {
+		return nil, format, fmt.Errorf("%w: file content does not match its extension", ErrInvalidAttachmentRequest)
+	}
+	return data, format, nil
+}
+
+// attachmentObjectKey - Key ngẫu nhiên theo sự kiện, giữ phần mở rộng gốc
+func attachmentObjectKey(eventID int, fileName string) (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate attachment key: %w", err)
+	}
+	return fmt.Sprintf("events/%d/attachments/%d-%s%s", eventID, time.Now().UnixMilli(), hex.EncodeToString(b), strings.ToLower(path.Ext(fileName))), nil
+}
+
+// validateAttachmentLink - Link http/https tuyệt đối (server không tải nội dung)
+func validateAttachmentLink(raw string) error {
+	if !isHTTPLink(raw) {
+		return fmt.Errorf("%w: url must be an http or https URL (max 500 characters)", ErrInvalidAttachmentRequest)
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package usecase
+
+import (
+	"encoding/base64"
+	"errors"
+	"testing"
+
+	"github.com/fpt-event-services/services/event-lambda/models"
+)
+
+func TestDecodeAttachmentFile(t *testing.T) {
+	pdf := base64.StdEncoding.EncodeToString([]byte("%PDF-1.4\n1 0 obj\n<<>>\nendobj\n"))
+	data, format, err := decodeAttachmentFile("Agenda.PDF", "data:application/pdf;base64,"+pdf)
+	if err != nil || format.contentType != "application/pdf" || len(data) == 0 {
+		t.Fatalf("pdf: %v %+v", err, format)
+	}
+
+	zip := base64.StdEncoding.EncodeToString([]byte("PK\x03\x04\x14\x00\x06\x00slides"))
+	if _, format, err := decodeAttachmentFile("deck.pptx", zip); err != nil || format.sniffed != "application/zip" {
+		t.Errorf("pptx: %v %+v", err, format)
+	}
+
+	html := base64.StdEncoding.EncodeToString([]byte("<html><script>alert(1)</script></html>"))
+	cases := map[string][2]string{
+		"html renamed to pdf": {"agenda.pdf", html},
+		"executable":          {"setup.exe", pdf},
+		"no extension":        {"agenda", pdf},
+		"bad base64":          {"agenda.pdf", "!!!"},
+		"empty":               {"agenda.pdf", ""},
+	}
+	for name, c := range cases {
+		if _, _, err := decodeAttachmentFile(c[0], c[1]); !errors.Is(err, ErrInvalidAttachmentRequest) {
+			t.Errorf("%s: err = %v, want ErrInvalidAttachmentRequest", name, err)
+		}
+	}
+
+	big := base64.StdEncoding.EncodeToString(make([]byte, models.MaxAttachmentBytes+1))
+	if _, _, err := decodeAttachmentFile("map.png", big); !errors.Is(err, ErrInvalidAttachmentRequest) {
+		t.Errorf("oversized file accepted: %v", err)
+	}
+}
+
+func TestApplyAttachmentFields(t *testing.T) {
+	a := &models.EventAttachment{}
+	if err := applyAttachmentFields(a, &models.EventAttachmentRequest{Title: " Sơ đồ hội trường ", Type: "map"}); err != nil {
+		t.Fatal(err)
+	}
+	if a.Title != "Sơ đồ hội trường" || a.Type != models.AttachmentTypeMap || a.Visibility != models.AttachmentPublic {
+		t.Errorf("got %+v", a)
+	}
+
+	// Sửa: trường trống giữ giá trị cũ
+	if err := applyAttachmentFields(a, &models.EventAttachmentRequest{Title: "Map", Visibility: "ticket_holders"}); err != nil {
+		t.Fatal(err)
+	}
+	if a.Type != models.AttachmentTypeMap || a.Visibility != models.AttachmentTicketHolders {
+		t.Errorf("got %+v", a)
+	}
+
+	for _, req := range []models.EventAttachmentRequest{{Title: ""}, {Title: "x", Type: "VIDEO"}, {Title: "x", Visibility: "STAFF"}} {
+		if err := applyAttachmentFields(&models.EventAttachment{}, &req); !errors.Is(err, ErrInvalidAttachmentRequest) {
+			t.Errorf("%+v: err = %v", req, err)
+		}
+	}
+}
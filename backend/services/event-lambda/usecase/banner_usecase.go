@@ -0,0 +1,199 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"syscall"
+	"time"
+
+	"github.com/fpt-event-services/common/imageproc"
+	"github.com/fpt-event-services/services/event-lambda/models"
+)
+
+// errNonPublicAddress - Kết nối tới địa chỉ nội bộ bị chặn lúc dial
+var errNonPublicAddress = errors.New("non-public address")
+
+// bannerHTTPClient - Client tải ảnh từ sourceUrl (timeout ngắn, không theo redirect quá 3 lần)
+// Chặn SSRF ngay lúc kết nối: Control kiểm tra IP đã phân giải mà socket sắp dial,
+// nên DNS rebinding (lookup lần hai trả IP nội bộ) không lọt; không đi qua proxy từ env
+var bannerHTTPClient = &http.Client{
+	Timeout: 15 * time.Second,
+	Transport: &http.Transport{
+		Proxy: nil,
+		DialContext: (&net.Dialer{
+			Timeout: 5 * time.Second,
+			Control: rejectNonPublicDial,
+		}).DialContext,
+		TLSHandshakeTimeout:   5 * time.Second,
+		ResponseHeaderTimeout: 10 * time.Second,
+	},
+	CheckRedirect: func(req *http.Request, via []*http.Request) error {
+		if len(via) >= 3 {
+			return fmt.Errorf("too many redirects")
+		}
+		return validateBannerSourceURL(req.URL)
+	},
+}
+
+// ============================================================
+// IngestEventBanner - Nhận banner (bytes hoặc URL), validate, resize và lưu
+// Luồng:
+//  1. Tải ảnh từ sourceUrl (nếu client không gửi bytes)
+//  2. Validate định dạng + kích thước (imageproc.Decode)
+//  3. Sinh thumbnail/card/hero (JPEG) và upload cùng ảnh gốc
+//  4. Lưu URL các variant lên Event
+//
+// ============================================================
+func (uc *EventUseCase) IngestEventBanner(ctx context.Context, userID int, role string, eventID int, data []byte, sourceURL string) (*models.BannerVariants, error) {
+	if len(data) == 0 {
+		if sourceURL == "" {
+			return nil, &imageproc.ValidationError{Message: "Cần gửi ảnh hoặc sourceUrl"}
+		}
+		downloaded, err := downloadBanner(ctx, sourceURL)
+		if err != nil {
+			return nil, err
+		}
+		data = downloaded
+	}
+
+	img, contentType, err := imageproc.Decode(data)
+	if err != nil {
+		return nil, err
+	}
+
+	variants, err := imageproc.GenerateVariants(img)
+	if err != nil {
+		return nil, err
+	}
+
+	// Key có timestamp để URL mới không bị cache CDN giữ ảnh cũ
+	prefix := fmt.Sprintf("events/%d/banner/%d", eventID, time.Now().UnixMilli())
+
+	originalURL, err := uc.fileStorage.Put(ctx, prefix+"/original"+extensionFor(contentType), contentType, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to store original banner: %w", err)
+	}
+
+	result := &models.BannerVariants{
+		EventID:     eventID,
+		OriginalURL: originalURL,
+		Width:       img.Bounds().Dx(),
+		Height:      img.Bounds().Dy(),
+	}
+
+	for _, v := range imageproc.DefaultVariants {
+		variantURL, err := uc.fileStorage.Put(ctx, prefix+"/"+v.Name+".jpg", "image/jpeg", variants[v.Name])
+		if err != nil {
+			return nil, fmt.Errorf("failed to store %s banner: %w", v.Name, err)
+		}
+		switch v.Name {
+		case "thumbnail":
+			result.ThumbnailURL = variantURL
+		case "card":
+			result.CardURL = variantURL
+		case "hero":
+			result.HeroURL = variantURL
+		}
+	}
+
+	if err := uc.eventRepo.UpdateEventBannerVariants(ctx, userID, role, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// downloadBanner tải ảnh từ URL ngoài với giới hạn dung lượng
+func downloadBanner(ctx context.Context, rawURL string) ([]byte, error) {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, &imageproc.ValidationError{Message: "sourceUrl không hợp lệ"}
+	}
+	if err := validateBannerSourceURL(parsed); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, parsed.String(), nil)
+	if err != nil {
+		return nil, &imageproc.ValidationError{Message: "sourceUrl không hợp lệ"}
+	}
+
+	resp, err := bannerHTTPClient.Do(req)
+	if errors.Is(err, errNonPublicAddress) {
+		return nil, &imageproc.ValidationError{Message: "sourceUrl trỏ tới địa chỉ nội bộ không được phép"}
+	}
+	if err != nil {
+		return nil, &imageproc.ValidationError{Message: fmt.Sprintf("Không tải được ảnh từ sourceUrl: %v", err)}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, &imageproc.ValidationError{Message: fmt.Sprintf("Không tải được ảnh từ sourceUrl (HTTP %d)", resp.StatusCode)}
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, imageproc.MaxUploadBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read banner: %w", err)
+	}
+	return data, nil
+}
+
+// validateBannerSourceURL chỉ cho phép http/https có host; địa chỉ IP được kiểm tra lúc dial
+func validateBannerSourceURL(u *url.URL) error {
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return &imageproc.ValidationError{Message: "sourceUrl phải là http hoặc https"}
+	}
+	if u.Hostname() == "" {
+		return &imageproc.ValidationError{Message: "sourceUrl không hợp lệ"}
+	}
+	return nil
+}
+
+// nonPublicPrefixes - Dải địa chỉ không route ra Internet ngoài các dải net.IP đã có hàm kiểm tra
+var nonPublicPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"), // CGNAT
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+	netip.MustParsePrefix("64:ff9b::/96"), // NAT64 trỏ về IPv4 bất kỳ
+}
+
+// isPublicIP - Địa chỉ có thể dial để tải banner
+func isPublicIP(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if !addr.IsGlobalUnicast() || addr.IsPrivate() || addr.IsLoopback() {
+		return false
+	}
+	for _, p := range nonPublicPrefixes {
+		if p.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
+
+// rejectNonPublicDial - net.Dialer.Control: từ chối socket tới địa chỉ nội bộ
+func rejectNonPublicDial(network, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil || !isPublicIP(addrPort.Addr()) {
+		return fmt.Errorf("dial %s %s: %w", network, address, errNonPublicAddress)
+	}
+	return nil
+}
+
+func extensionFor(contentType string) string {
+	switch contentType {
+	case "image/png":
+		return ".png"
+	case "image/gif":
+		return ".gif"
+	default:
+		return ".jpg"
+	}
+}
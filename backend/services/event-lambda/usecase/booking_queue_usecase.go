@@ -0,0 +1,72 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/fpt-event-services/common/waitingroom"
+	"github.com/fpt-event-services/services/event-lambda/models"
+)
+
+// MaxQueueAdmitPerInterval - Trần số người được vào mỗi nhịp dispatcher
+const MaxQueueAdmitPerInterval = 1000
+
+// ErrInvalidBookingQueue - admitPerInterval ngoài 1..MaxQueueAdmitPerInterval
+var ErrInvalidBookingQueue = errors.New("admitPerInterval must be between 1 and 1000")
+
+// GetBookingQueue - Cấu hình hàng chờ và số người đang chờ / đang được đặt vé
+// Số liệu trực tiếp đọc từ booking_queue_entry (common/waitingroom)
+func (uc *EventUseCase) GetBookingQueue(ctx context.Context, eventID, userID int, role string) (*models.BookingQueueSettings, error) {
+	enabled, perInterval, err := uc.eventRepo.GetBookingQueue(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.requireEditDetails(ctx, eventID, userID, role); err != nil {
+		return nil, err
+	}
+	waiting, admitted, err := waitingroom.Stats(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	return &models.BookingQueueSettings{
+		EventID:          eventID,
+		Enabled:          enabled,
+		AdmitPerInterval: perInterval,
+		IntervalSeconds:  int(waitingroom.Default().Interval() / time.Second),
+		Waiting:          waiting,
+		Admitted:         admitted,
+	}, nil
+}
+
+// UpdateBookingQueue - Bật / tắt hàng chờ, đổi số người được vào mỗi nhịp
+// Tắt hàng chờ không xóa người đang chờ: họ được vào dần như cũ, request đặt vé không còn bị chặn
+func (uc *EventUseCase) UpdateBookingQueue(ctx context.Context, eventID, userID int, role string, req models.UpdateBookingQueueRequest) (*models.BookingQueueSettings, error) {
+	enabled, perInterval, err := uc.eventRepo.GetBookingQueue(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.requireEditDetails(ctx, eventID, userID, role); err != nil {
+		return nil, err
+	}
+	if err := validateBookingQueue(req); err != nil {
+		return nil, err
+	}
+	if req.Enabled != nil {
+		enabled = *req.Enabled
+	}
+	if req.AdmitPerInterval != nil {
+		perInterval = *req.AdmitPerInterval
+	}
+	if err := uc.eventRepo.SetBookingQueue(ctx, eventID, enabled, perInterval); err != nil {
+		return nil, err
+	}
+	return uc.GetBookingQueue(ctx, eventID, userID, role)
+}
+
+func validateBookingQueue(req models.UpdateBookingQueueRequest) error {
+	if req.AdmitPerInterval != nil && (*req.AdmitPerInterval < 1 || *req.AdmitPerInterval > MaxQueueAdmitPerInterval) {
+		return ErrInvalidBookingQueue
+	}
+	return nil
+}
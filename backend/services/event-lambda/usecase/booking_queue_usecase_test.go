@@ -0,0 +1,33 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/fpt-event-services/services/event-lambda/models"
+)
+
+func TestValidateBookingQueue(t *testing.T) {
+	on := true
+	one, top := 1, MaxQueueAdmitPerInterval
+	for _, req := range []models.UpdateBookingQueueRequest{
+		{},
+		{Enabled: &on},
+		{AdmitPerInterval: &one},
+		{Enabled: &on, AdmitPerInterval: &top},
+	} {
+		if err := validateBookingQueue(req); err != nil {
+			t.Errorf("%+v: unexpected error %v", req, err)
+		}
+	}
+
+	zero, over := 0, MaxQueueAdmitPerInterval+1
+	for _, req := range []models.UpdateBookingQueueRequest{
+		{AdmitPerInterval: &zero},
+		{Enabled: &on, AdmitPerInterval: &over},
+	} {
+		if err := validateBookingQueue(req); !errors.Is(err, ErrInvalidBookingQueue) {
+			t.Errorf("%+v: got %v, want ErrInvalidBookingQueue", req, err)
+		}
+	}
+}
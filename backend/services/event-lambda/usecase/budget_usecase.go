@@ -0,0 +1,202 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/fpt-event-services/common/permission"
+	"github.com/fpt-event-services/services/event-lambda/models"
+)
+
+// ============================================================
+// Budget - Ngân sách của yêu cầu sự kiện
+// Dự toán: người gửi request, chỉ sửa khi request còn PENDING
+// Duyệt ngân sách: người có quyền event.request.review trong lúc duyệt request (PENDING)
+// Thực chi: người gửi request hoặc ADMIN, sau khi sự kiện kết thúc
+// ============================================================
+
+// Lỗi của ngân sách ở tầng usecase
+var (
+	ErrBudgetForbidden      = errors.New("you do not have permission to access this request's budget")
+	ErrBudgetLocked         = errors.New("budget can only be changed or reviewed while the request is pending")
+	ErrBudgetActualsNotOpen = errors.New("actual costs can be entered only after the event has ended")
+	ErrInvalidBudgetRequest = errors.New("invalid budget request")
+)
+
+// maxBudgetAmount - Giới hạn mỗi khoản tiền (VND), khớp decimal(15,2)
+const maxBudgetAmount = 1e12
+
+// GetEventRequestBudget - Người gửi request hoặc người có quyền event.request.review
+func (uc *EventUseCase) GetEventRequestBudget(ctx context.Context, requestID, userID int, role string) (*models.EventRequestBudget, error) {
+	st, err := uc.eventRepo.GetEventRequestBudgetState(ctx, requestID)
+	if err != nil {
+		return nil, err
+	}
+	if !permission.RoleHas(ctx, role, permission.EventRequestReview) && st.RequesterID != userID {
+		return nil, ErrBudgetForbidden
+	}
+	return uc.eventRepo.GetEventRequestBudget(ctx, requestID)
+}
+
+// UpdateEventRequestBudget - Người gửi sửa dự toán (thay toàn bộ hạng mục), ví dụ sau khi staff yêu cầu chỉnh
+func (uc *EventUseCase) UpdateEventRequestBudget(ctx context.Context, requestID, userID int, in *models.EventBudgetInput) (*models.EventRequestBudget, error) {
+	st, err := uc.eventRepo.GetEventRequestBudgetState(ctx, requestID)
+	if err != nil {
+		return nil, err
+	}
+	if st.RequesterID != userID {
+		return nil, ErrBudgetForbidden
+	}
+	if st.Status != "PENDING" {
+		return nil, ErrBudgetLocked
+	}
+	if err := normalizeBudgetInput(in); err != nil {
+		return nil, err
+	}
+	if err := uc.eventRepo.ReplaceEventRequestBudget(ctx, requestID, in); err != nil {
+		return nil, err
+	}
+	return uc.eventRepo.GetEventRequestBudget(ctx, requestID)
+}
+
+// ReviewEventRequestBudget - Người có quyền event.request.review duyệt ngân sách kèm nhận xét
+// APPROVED không gửi approvedFunding thì duyệt đúng số tiền xin tài trợ
+// Người duyệt nhận (gia hạn) claim của request; người khác đang giữ thì bị chặn
+func (uc *EventUseCase) ReviewEventRequestBudget(ctx context.Context, requestID, reviewerID int, role string, req *models.BudgetReviewRequest) (*models.EventRequestBudget, error) {
+	if !permission.RoleHas(ctx, role, permission.EventRequestReview) {
+		return nil, ErrBudgetForbidden
+	}
+	budget, err := uc.eventRepo.GetEventRequestBudget(ctx, requestID)
+	if err != nil {
+		return nil, err
+	}
+	if budget.RequestStatus != "PENDING" {
+		return nil, ErrBudgetLocked
+	}
+	if err := normalizeBudgetReview(req, budget.RequestedFunding); err != nil {
+		return nil, err
+	}
+	if _, err := uc.eventRepo.ClaimEventRequest(ctx, requestID, reviewerID); err != nil {
+		return nil, err
+	}
+	if err := uc.eventRepo.CreateBudgetReview(ctx, requestID, reviewerID, req); err != nil {
+		return nil, err
+	}
+	return uc.eventRepo.GetEventRequestBudget(ctx, requestID)
+}
+
+// SubmitBudgetActuals - Nhập thực chi sau sự kiện (gửi lại được để sửa)
+func (uc *EventUseCase) SubmitBudgetActuals(ctx context.Context, requestID, userID int, role string, req *models.BudgetActualsRequest) (*models.EventRequestBudget, error) {
+	st, err := uc.eventRepo.GetEventRequestBudgetState(ctx, requestID)
+	if err != nil {
+		return nil, err
+	}
+	if !permission.RoleHas(ctx, role, permission.EventManageAny) && st.RequesterID != userID {
+		return nil, ErrBudgetForbidden
+	}
+	if (st.Status != "APPROVED" && st.Status != "FINISHED") || !st.EventEnded {
+		return nil, ErrBudgetActualsNotOpen
+	}
+	if err := normalizeBudgetActuals(req); err != nil {
+		return nil, err
+	}
+	if err := uc.eventRepo.SaveBudgetActuals(ctx, requestID, req, models.MaxBudgetItems); err != nil {
+		return nil, err
+	}
+	return uc.eventRepo.GetEventRequestBudget(ctx, requestID)
+}
+
+// normalizeBudgetInput - Chuẩn hóa và validate dự toán (dùng cả khi tạo request)
+func normalizeBudgetInput(in *models.EventBudgetInput) error {
+	if in.RequestedFunding != nil && !validBudgetAmount(*in.RequestedFunding) {
+		return fmt.Errorf("%w: requestedFunding must be between 0 and %.0f", ErrInvalidBudgetRequest, maxBudgetAmount)
+	}
+	if len(in.Items) > models.MaxBudgetItems {
+		return fmt.Errorf("%w: at most %d budget items", ErrInvalidBudgetRequest, models.MaxBudgetItems)
+	}
+	for i := range in.Items {
+		item := &in.Items[i]
+		if err := normalizeBudgetLine(&item.Category, &item.Description); err != nil {
+			return err
+		}
+		if !validBudgetAmount(item.EstimatedAmount) {
+			return fmt.Errorf("%w: estimatedAmount must be between 0 and %.0f", ErrInvalidBudgetRequest, maxBudgetAmount)
+		}
+	}
+	return nil
+}
+
+// normalizeBudgetReview - Validate quyết định; approvedFunding chỉ giữ khi APPROVED
+func normalizeBudgetReview(req *models.BudgetReviewRequest, requestedFunding *float64) error {
+	req.Decision = strings.ToUpper(strings.TrimSpace(req.Decision))
+	req.Comment = strings.TrimSpace(req.Comment)
+	if len([]rune(req.Comment)) > 1000 {
+		return fmt.Errorf("%w: comment must be at most 1000 characters", ErrInvalidBudgetRequest)
+	}
+	switch req.Decision {
+	case models.BudgetReviewApproved:
+		if req.ApprovedFunding == nil {
+			req.ApprovedFunding = requestedFunding
+		}
+		if req.ApprovedFunding != nil && !validBudgetAmount(*req.ApprovedFunding) {
+			return fmt.Errorf("%w: approvedFunding must be between 0 and %.0f", ErrInvalidBudgetRequest, maxBudgetAmount)
+		}
+	case models.BudgetReviewNeedsChanges, models.BudgetReviewRejected:
+		if req.Comment == "" {
+			return fmt.Errorf("%w: comment is required when the budget is not approved", ErrInvalidBudgetRequest)
+		}
+		req.ApprovedFunding = nil
+	default:
+		return fmt.Errorf("%w: decision must be APPROVED, NEEDS_CHANGES or REJECTED", ErrInvalidBudgetRequest)
+	}
+	return nil
+}
+
+// normalizeBudgetActuals - Validate thực chi; dòng mới (không itemId) cần category/description
+func normalizeBudgetActuals(req *models.BudgetActualsRequest) error {
+	if len(req.Items) == 0 {
+		return fmt.Errorf("%w: items are required", ErrInvalidBudgetRequest)
+	}
+	for i := range req.Items {
+		item := &req.Items[i]
+		if !validBudgetAmount(item.ActualAmount) {
+			return fmt.Errorf("%w: actualAmount must be between 0 and %.0f", ErrInvalidBudgetRequest, maxBudgetAmount)
+		}
+		if item.ItemID == nil {
+			if err := normalizeBudgetLine(&item.Category, &item.Description); err != nil {
+				return err
+			}
+		}
+	}
+	if req.Note != nil {
+		note := strings.TrimSpace(*req.Note)
+		if len([]rune(note)) > 1000 {
+			return fmt.Errorf("%w: note must be at most 1000 characters", ErrInvalidBudgetRequest)
+		}
+		req.Note = &note
+	}
+	return nil
+}
+
+// normalizeBudgetLine - Category trống = OTHER, description 1-255 ký tự
+func normalizeBudgetLine(category, description *string) error {
+	*category = strings.ToUpper(strings.TrimSpace(*category))
+	if *category == "" {
+		*category = models.BudgetCategoryOther
+	}
+	if !slices.Contains(models.BudgetCategories, *category) {
+		return fmt.Errorf("%w: category must be one of %s", ErrInvalidBudgetRequest, strings.Join(models.BudgetCategories, ", "))
+	}
+	*description = strings.TrimSpace(*description)
+	if *description == "" || len([]rune(*description)) > 255 {
+		return fmt.Errorf("%w: description is required (max 255 characters)", ErrInvalidBudgetRequest)
+	}
+	return nil
+}
+
+func validBudgetAmount(v float64) bool {
+	return v >= 0 && v < maxBudgetAmount
+}
@@ -0,0 +1,70 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/fpt-event-services/services/event-lambda/models"
+)
+
+func TestNormalizeBudgetInput(t *testing.T) {
+	funding := 5000000.0
+	in := &models.EventBudgetInput{
+		RequestedFunding: &funding,
+		Items: []models.BudgetItemInput{
+			{Category: " catering ", Description: " Tea break ", EstimatedAmount: 2000000},
+			{Description: "Lanyards", EstimatedAmount: 300000},
+		},
+	}
+	if err := normalizeBudgetInput(in); err != nil {
+		t.Fatal(err)
+	}
+	if in.Items[0].Category != models.BudgetCategoryCatering || in.Items[0].Description != "Tea break" || in.Items[1].Category != models.BudgetCategoryOther {
+		t.Errorf("unexpected items: %+v", in.Items)
+	}
+
+	negative := -1.0
+	cases := map[string]*models.EventBudgetInput{
+		"negative funding":  {RequestedFunding: &negative},
+		"unknown category":  {Items: []models.BudgetItemInput{{Category: "TRAVEL", Description: "Bus"}}},
+		"empty description": {Items: []models.BudgetItemInput{{Category: "VENUE", Description: "  "}}},
+		"negative amount":   {Items: []models.BudgetItemInput{{Description: "Sound", EstimatedAmount: -5}}},
+		"too many items":    {Items: make([]models.BudgetItemInput, models.MaxBudgetItems+1)},
+	}
+	for name, c := range cases {
+		if err := normalizeBudgetInput(c); !errors.Is(err, ErrInvalidBudgetRequest) {
+			t.Errorf("%s: err = %v, want ErrInvalidBudgetRequest", name, err)
+		}
+	}
+}
+
+func TestNormalizeBudgetReview(t *testing.T) {
+	requested := 5000000.0
+
+	approve := &models.BudgetReviewRequest{Decision: "approved"}
+	if err := normalizeBudgetReview(approve, &requested); err != nil {
+		t.Fatal(err)
+	}
+	if approve.Decision != models.BudgetReviewApproved || approve.ApprovedFunding == nil || *approve.ApprovedFunding != requested {
+		t.Errorf("approve defaults: %+v", approve)
+	}
+
+	// Không duyệt thì bỏ approvedFunding và bắt buộc nhận xét
+	funding := 1000.0
+	changes := &models.BudgetReviewRequest{Decision: "NEEDS_CHANGES", ApprovedFunding: &funding, Comment: " Catering is too high "}
+	if err := normalizeBudgetReview(changes, &requested); err != nil {
+		t.Fatal(err)
+	}
+	if changes.ApprovedFunding != nil || changes.Comment != "Catering is too high" {
+		t.Errorf("needs changes: %+v", changes)
+	}
+
+	for _, req := range []*models.BudgetReviewRequest{
+		{Decision: "REJECTED"},
+		{Decision: "MAYBE", Comment: "?"},
+	} {
+		if err := normalizeBudgetReview(req, &requested); !errors.Is(err, ErrInvalidBudgetRequest) {
+			t.Errorf("%+v: err = %v, want ErrInvalidBudgetRequest", req, err)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fpt-event-services/services/event-lambda/models"
+)
+
+// MaxCashbackPercent - Mức cashback tối đa (% giá vé)
+const MaxCashbackPercent = 50
+
+// ErrInvalidCashback - Mức cashback thiếu / ngoài khoảng 0..MaxCashbackPercent hoặc trần không dương
+var ErrInvalidCashback = fmt.Errorf("percent must be between 0 and %d and maxAmount must be positive", MaxCashbackPercent)
+
+// GetCashback - Cấu hình cashback hiện tại của sự kiện
+func (uc *EventUseCase) GetCashback(ctx context.Context, eventID, userID int, role string) (*models.CashbackSettings, error) {
+	settings, err := uc.eventRepo.GetCashback(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.requireEditDetails(ctx, eventID, userID, role); err != nil {
+		return nil, err
+	}
+	settings.MaxPercent = MaxCashbackPercent
+	return settings, nil
+}
+
+// UpdateCashback - Đặt mức cashback (0 = tắt); chỉ áp dụng cho các lần check-out sau
+func (uc *EventUseCase) UpdateCashback(ctx context.Context, eventID, userID int, role string, req models.UpdateCashbackRequest) (*models.CashbackSettings, error) {
+	if _, err := uc.eventRepo.GetCashback(ctx, eventID); err != nil {
+		return nil, err
+	}
+	if err := uc.requireEditDetails(ctx, eventID, userID, role); err != nil {
+		return nil, err
+	}
+	if err := validateCashback(req); err != nil {
+		return nil, err
+	}
+	if err := uc.eventRepo.SetCashback(ctx, eventID, *req.Percent, req.MaxAmount); err != nil {
+		return nil, err
+	}
+	return uc.GetCashback(ctx, eventID, userID, role)
+}
+
+func validateCashback(req models.UpdateCashbackRequest) error {
+	if req.Percent == nil || *req.Percent < 0 || *req.Percent > MaxCashbackPercent {
+		return ErrInvalidCashback
+	}
+	if req.MaxAmount != nil && *req.MaxAmount <= 0 {
+		return ErrInvalidCashback
+	}
+	return nil
+}
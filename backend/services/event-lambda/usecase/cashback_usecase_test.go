@@ -0,0 +1,35 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/fpt-event-services/services/event-lambda/models"
+)
+
+func TestValidateCashback(t *testing.T) {
+	zero, ten, top := 0, 10, MaxCashbackPercent
+	perTicket := 50000.0
+	for _, req := range []models.UpdateCashbackRequest{
+		{Percent: &zero},
+		{Percent: &ten, MaxAmount: &perTicket},
+		{Percent: &top},
+	} {
+		if err := validateCashback(req); err != nil {
+			t.Errorf("%+v: unexpected error %v", req, err)
+		}
+	}
+
+	over, negative := MaxCashbackPercent+1, -1
+	noCap := 0.0
+	for _, req := range []models.UpdateCashbackRequest{
+		{},
+		{Percent: &over},
+		{Percent: &negative},
+		{Percent: &ten, MaxAmount: &noCap},
+	} {
+		if err := validateCashback(req); !errors.Is(err, ErrInvalidCashback) {
+			t.Errorf("%+v: expected ErrInvalidCashback, got %v", req, err)
+		}
+	}
+}
@@ -0,0 +1,292 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fpt-event-services/common/email"
+	"github.com/fpt-event-services/common/eventbus"
+	"github.com/fpt-event-services/services/event-lambda/models"
+	"github.com/fpt-event-services/services/event-lambda/repository"
+)
+
+// ============================================================
+// Cảnh báo check-in theo % sức chứa
+// Subscriber TicketCheckedIn giữ bộ đếm check-in theo sự kiện trong bộ nhớ; khi
+// bộ đếm vượt một ngưỡng (mặc định 50/80/100%) thì đếm lại trong DB, giành ngưỡng
+// trong Event_Checkin_Alert (mỗi ngưỡng một lần) rồi báo chủ sự kiện, co-organizer
+// và STAFF đã duyệt sự kiện qua thông báo trong app + email
+// ============================================================
+
+const (
+	defaultCheckinAlertThresholds = "50,80,100"
+	maxCheckinAlertThresholds     = 10
+	// checkinAlertReload - Đọc lại sức chứa / cấu hình ngưỡng từ DB sau khoảng này
+	checkinAlertReload = time.Minute
+	// checkinAlertIdle - Bỏ bộ đếm của sự kiện không có check-in trong khoảng này
+	checkinAlertIdle = time.Hour
+)
+
+// ErrInvalidCheckinThresholds - Ngưỡng phải là % trong 1..100, tối đa 10 ngưỡng
+var ErrInvalidCheckinThresholds = errors.New("thresholds must be percentages between 1 and 100 (at most 10)")
+
+// checkinAlertState - Bộ đếm của một sự kiện
+type checkinAlertState struct {
+	capacity   int
+	checkedIn  int
+	thresholds []int
+	fired      map[int]bool
+	loadedAt   time.Time
+	seenAt     time.Time
+}
+
+// checkinAlertTracker - Bộ đếm check-in theo sự kiện (một instance / process)
+type checkinAlertTracker struct {
+	repo   *repository.EventRepository
+	mu     sync.Mutex
+	events map[int]*checkinAlertState
+}
+
+var checkinAlerts = &checkinAlertTracker{events: make(map[int]*checkinAlertState)}
+
+// registerCheckinAlerts - Gắn subscriber cảnh báo check-in vào bus
+func registerCheckinAlerts(bus *eventbus.Bus) {
+	checkinAlerts.repo = repository.DefaultEventRepository()
+	eventbus.On(bus, "checkin-alerts", eventbus.Async, func(ctx context.Context, e eventbus.TicketCheckedIn) error {
+		return checkinAlerts.onCheckedIn(ctx, e.EventID, e.OccurredAt)
+	})
+}
+
+// onCheckedIn - Tăng bộ đếm; chỉ chạm DB khi lần đầu thấy sự kiện, khi bộ đếm cũ
+// hoặc khi có ngưỡng vừa vượt (đếm lại để không báo nhầm vì bộ đếm lệch)
+func (t *checkinAlertTracker) onCheckedIn(ctx context.Context, eventID int, at time.Time) error {
+	t.mu.Lock()
+	state := t.events[eventID]
+	fresh := state == nil || time.Since(state.loadedAt) > checkinAlertReload
+	if !fresh {
+		state.checkedIn++
+		state.seenAt = time.Now()
+		fresh = len(crossedThresholds(state.thresholds, state.fired, state.checkedIn, state.capacity)) > 0
+	}
+	t.mu.Unlock()
+	if !fresh {
+		return nil
+	}
+
+	snapshot, err := t.repo.GetCheckinAlertSnapshot(ctx, eventID)
+	if err != nil {
+		return fmt.Errorf("load check-in alert state of event %d: %w", eventID, err)
+	}
+	fired, err := t.repo.ListCheckinAlerts(ctx, eventID)
+	if err != nil {
+		return err
+	}
+
+	t.mu.Lock()
+	state = &checkinAlertState{
+		capacity:   snapshot.Capacity,
+		checkedIn:  snapshot.CheckedIn,
+		thresholds: effectiveCheckinThresholds(snapshot.Thresholds.String, snapshot.Thresholds.Valid),
+		fired:      make(map[int]bool, len(fired)),
+		loadedAt:   time.Now(),
+		seenAt:     time.Now(),
+	}
+	for _, a := range fired {
+		state.fired[a.Threshold] = true
+	}
+	due := crossedThresholds(state.thresholds, state.fired, state.checkedIn, state.capacity)
+	for _, th := range due {
+		state.fired[th] = true
+	}
+	t.events[eventID] = state
+	t.pruneLocked()
+	t.mu.Unlock()
+
+	if len(due) == 0 {
+		return nil
+	}
+	claimed, err := t.repo.ClaimCheckinAlerts(ctx, eventID, due, snapshot.CheckedIn, snapshot.Capacity)
+	if err != nil || len(claimed) == 0 {
+		return err
+	}
+	// Vượt nhiều ngưỡng một lúc (vd. bật cảnh báo giữa sự kiện): chỉ báo ngưỡng cao nhất
+	return t.notify(ctx, eventID, snapshot, claimed[len(claimed)-1], at)
+}
+
+// notify - Thông báo trong app + email cho từng người nhận; email lỗi nằm trong hàng đợi để gửi lại
+func (t *checkinAlertTracker) notify(ctx context.Context, eventID int, snapshot *repository.CheckinAlertSnapshot, threshold int, at time.Time) error {
+	recipients, err := t.repo.ListCheckinAlertRecipients(ctx, eventID)
+	if err != nil {
+		return err
+	}
+	userIDs := make([]int, len(recipients))
+	for i, rc := range recipients {
+		userIDs[i] = rc.UserID
+	}
+	message := fmt.Sprintf("Sự kiện \"%s\" đã có %d/%d người check-in (đạt %d%% sức chứa).",
+		snapshot.Title, snapshot.CheckedIn, snapshot.Capacity, threshold)
+	if err := t.repo.NotifyUsers(ctx, userIDs, message); err != nil {
+		return err
+	}
+
+	svc := email.NewEmailService(nil)
+	eventURL := fmt.Sprintf("%s/dashboard/events/%d", frontendURL(), eventID)
+	for _, rc := range recipients {
+		msg := svc.BuildCheckinAlertEmail(rc.Email, rc.FullName, snapshot.Title, threshold, snapshot.CheckedIn, snapshot.Capacity, at, eventURL)
+		if err := email.DefaultQueue().Send(ctx, email.TemplateCheckinAlert, msg, fmt.Sprintf("checkin-alert:%d:%d:%d", eventID, threshold, rc.UserID)); err != nil {
+			log.Printf("[CHECKIN_ALERT] ⚠️ Failed to send %d%% alert of event %d to user %d: %v", threshold, eventID, rc.UserID, err)
+		}
+	}
+	log.Printf("[CHECKIN_ALERT] Event #%d reached %d%% (%d/%d), %d recipient(s) notified",
+		eventID, threshold, snapshot.CheckedIn, snapshot.Capacity, len(recipients))
+	return t.repo.SetCheckinAlertRecipients(ctx, eventID, threshold, len(recipients))
+}
+
+// forget - Bỏ bộ đếm để lần check-in sau đọc lại cấu hình mới
+func (t *checkinAlertTracker) forget(eventID int) {
+	t.mu.Lock()
+	delete(t.events, eventID)
+	t.mu.Unlock()
+}
+
+// pruneLocked - Bỏ bộ đếm của sự kiện đã lâu không có check-in (gọi khi đang giữ mu)
+func (t *checkinAlertTracker) pruneLocked() {
+	for id, s := range t.events {
+		if time.Since(s.seenAt) > checkinAlertIdle {
+			delete(t.events, id)
+		}
+	}
+}
+
+// GetCheckinAlerts - Ngưỡng đang áp dụng, số check-in hiện tại và lịch sử đã báo
+func (uc *EventUseCase) GetCheckinAlerts(ctx context.Context, eventID, userID int, role string) (*models.CheckinAlertSettings, error) {
+	snapshot, err := uc.eventRepo.GetCheckinAlertSnapshot(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.requireEditDetails(ctx, eventID, userID, role); err != nil {
+		return nil, err
+	}
+	fired, err := uc.eventRepo.ListCheckinAlerts(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	return &models.CheckinAlertSettings{
+		EventID:      eventID,
+		Thresholds:   effectiveCheckinThresholds(snapshot.Thresholds.String, snapshot.Thresholds.Valid),
+		UsingDefault: !snapshot.Thresholds.Valid,
+		Capacity:     snapshot.Capacity,
+		CheckedIn:    snapshot.CheckedIn,
+		Fired:        fired,
+	}, nil
+}
+
+// UpdateCheckinAlerts - Đổi ngưỡng của sự kiện; ngưỡng đã báo không báo lại
+func (uc *EventUseCase) UpdateCheckinAlerts(ctx context.Context, eventID, userID int, role string, req models.UpdateCheckinAlertsRequest) (*models.CheckinAlertSettings, error) {
+	if _, err := uc.eventRepo.GetCheckinAlertSnapshot(ctx, eventID); err != nil {
+		return nil, err
+	}
+	if err := uc.requireEditDetails(ctx, eventID, userID, role); err != nil {
+		return nil, err
+	}
+
+	var stored *string
+	if req.Thresholds != nil {
+		thresholds, err := normalizeCheckinThresholds(*req.Thresholds)
+		if err != nil {
+			return nil, err
+		}
+		s := formatCheckinThresholds(thresholds)
+		stored = &s
+	}
+	if err := uc.eventRepo.SetCheckinAlertThresholds(ctx, eventID, stored); err != nil {
+		return nil, err
+	}
+	checkinAlerts.forget(eventID)
+	return uc.GetCheckinAlerts(ctx, eventID, userID, role)
+}
+
+// crossedThresholds - Ngưỡng (tăng dần) mà checkedIn đã đạt nhưng chưa báo
+func crossedThresholds(thresholds []int, fired map[int]bool, checkedIn, capacity int) []int {
+	if capacity <= 0 {
+		return nil
+	}
+	var due []int
+	for _, th := range thresholds {
+		if !fired[th] && checkedIn*100 >= th*capacity {
+			due = append(due, th)
+		}
+	}
+	return due
+}
+
+// effectiveCheckinThresholds - Ngưỡng của sự kiện, hoặc CHECKIN_ALERT_THRESHOLDS nếu chưa cấu hình
+func effectiveCheckinThresholds(stored string, configured bool) []int {
+	if configured {
+		if thresholds, err := parseCheckinThresholds(stored); err == nil {
+			return thresholds
+		}
+		return []int{}
+	}
+	if v := os.Getenv("CHECKIN_ALERT_THRESHOLDS"); v != "" {
+		if thresholds, err := parseCheckinThresholds(v); err == nil {
+			return thresholds
+		}
+		log.Printf("[CHECKIN_ALERT] ⚠️ Invalid CHECKIN_ALERT_THRESHOLDS %q, using %s", v, defaultCheckinAlertThresholds)
+	}
+	thresholds, _ := parseCheckinThresholds(defaultCheckinAlertThresholds)
+	return thresholds
+}
+
+// parseCheckinThresholds - "80, 50,100" -> [50 80 100]; chuỗi rỗng = tắt
+func parseCheckinThresholds(s string) ([]int, error) {
+	values := []int{}
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		n, err := strconv.Atoi(strings.TrimSuffix(part, "%"))
+		if err != nil {
+			return nil, ErrInvalidCheckinThresholds
+		}
+		values = append(values, n)
+	}
+	return normalizeCheckinThresholds(values)
+}
+
+// normalizeCheckinThresholds - Kiểm tra 1..100, bỏ trùng, sắp tăng dần
+func normalizeCheckinThresholds(values []int) ([]int, error) {
+	seen := make(map[int]bool, len(values))
+	thresholds := []int{}
+	for _, n := range values {
+		if n < 1 || n > 100 {
+			return nil, ErrInvalidCheckinThresholds
+		}
+		if !seen[n] {
+			seen[n] = true
+			thresholds = append(thresholds, n)
+		}
+	}
+	if len(thresholds) > maxCheckinAlertThresholds {
+		return nil, ErrInvalidCheckinThresholds
+	}
+	sort.Ints(thresholds)
+	return thresholds, nil
+}
+
+func formatCheckinThresholds(thresholds []int) string {
+	parts := make([]string, len(thresholds))
+	for i, n := range thresholds {
+		parts[i] = strconv.Itoa(n)
+	}
+	return strings.Join(parts, ",")
+}
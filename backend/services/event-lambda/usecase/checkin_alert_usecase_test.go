@@ -0,0 +1,60 @@
+package usecase
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCrossedThresholds(t *testing.T) {
+	thresholds := []int{50, 80, 100}
+	cases := []struct {
+		name      string
+		fired     map[int]bool
+		checkedIn int
+		capacity  int
+		want      []int
+	}{
+		{"below first", nil, 49, 100, nil},
+		{"exactly 50%", nil, 50, 100, []int{50}},
+		{"rounding up needs full percent", nil, 149, 300, nil},
+		{"jump over several", nil, 85, 100, []int{50, 80}},
+		{"already fired", map[int]bool{50: true}, 60, 100, nil},
+		{"full", map[int]bool{50: true, 80: true}, 100, 100, []int{100}},
+		{"no capacity", nil, 10, 0, nil},
+	}
+	for _, tc := range cases {
+		got := crossedThresholds(thresholds, tc.fired, tc.checkedIn, tc.capacity)
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%s: got %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestParseCheckinThresholds(t *testing.T) {
+	got, err := parseCheckinThresholds(" 80, 50%,100,50 ")
+	if err != nil || !reflect.DeepEqual(got, []int{50, 80, 100}) {
+		t.Fatalf("got %v, %v", got, err)
+	}
+	if got, err := parseCheckinThresholds(""); err != nil || len(got) != 0 {
+		t.Fatalf("empty string should disable alerts, got %v, %v", got, err)
+	}
+	for _, bad := range []string{"0", "101", "abc", "1,2,3,4,5,6,7,8,9,10,11"} {
+		if _, err := parseCheckinThresholds(bad); err != ErrInvalidCheckinThresholds {
+			t.Errorf("%q: expected ErrInvalidCheckinThresholds, got %v", bad, err)
+		}
+	}
+}
+
+func TestEffectiveCheckinThresholdsDefault(t *testing.T) {
+	t.Setenv("CHECKIN_ALERT_THRESHOLDS", "")
+	if got := effectiveCheckinThresholds("", false); !reflect.DeepEqual(got, []int{50, 80, 100}) {
+		t.Fatalf("got %v", got)
+	}
+	t.Setenv("CHECKIN_ALERT_THRESHOLDS", "90")
+	if got := effectiveCheckinThresholds("", false); !reflect.DeepEqual(got, []int{90}) {
+		t.Fatalf("got %v", got)
+	}
+	if got := effectiveCheckinThresholds("", true); len(got) != 0 {
+		t.Fatalf("configured empty list should disable alerts, got %v", got)
+	}
+}
@@ -0,0 +1,82 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+
+	"github.com/fpt-event-services/common/campus"
+	"github.com/fpt-event-services/common/permission"
+	"github.com/fpt-event-services/services/event-lambda/models"
+)
+
+// ============================================================
+// Claim - Người duyệt nhận xử lý yêu cầu sự kiện trong hàng đợi
+// Duyệt / từ chối / duyệt ngân sách tự nhận claim nếu yêu cầu chưa ai giữ,
+// bị chặn nếu người khác đang giữ; duyệt xong thì claim được nhả
+// ============================================================
+
+// ErrInvalidAssigneeFilter - ?assignee= không phải me / unassigned
+var ErrInvalidAssigneeFilter = errors.New("assignee must be me or unassigned")
+
+// ClaimEventRequest - Nhận hoặc gia hạn claim (trong campus của người duyệt)
+func (uc *EventUseCase) ClaimEventRequest(ctx context.Context, requestID, reviewerID int) (*models.EventRequestClaim, error) {
+	if err := uc.checkRequestCampus(ctx, requestID); err != nil {
+		return nil, err
+	}
+	return uc.eventRepo.ClaimEventRequest(ctx, requestID, reviewerID)
+}
+
+// ReleaseEventRequestClaim - Nhả claim của mình; người có event.manage_any nhả được claim của người khác
+func (uc *EventUseCase) ReleaseEventRequestClaim(ctx context.Context, requestID, reviewerID int, role string) error {
+	if err := uc.checkRequestCampus(ctx, requestID); err != nil {
+		return err
+	}
+	force := permission.RoleHas(ctx, role, permission.EventManageAny)
+	return uc.eventRepo.ReleaseEventRequestClaim(ctx, requestID, reviewerID, force)
+}
+
+// FilterByAssignee - Lọc danh sách theo người nhận xử lý
+// "me": yêu cầu userID đang giữ; "unassigned": yêu cầu chờ duyệt chưa ai nhận; "": không lọc
+func FilterByAssignee(requests []models.EventRequest, assignee string, userID int) ([]models.EventRequest, error) {
+	switch strings.ToLower(strings.TrimSpace(assignee)) {
+	case "":
+		return requests, nil
+	case "me":
+		return filterRequests(requests, func(r models.EventRequest) bool {
+			return r.AssignedTo != nil && *r.AssignedTo == userID
+		}), nil
+	case "unassigned":
+		return filterRequests(requests, func(r models.EventRequest) bool {
+			return r.AssignedTo == nil && (r.Status == "PENDING" || r.Status == "UPDATING")
+		}), nil
+	}
+	return nil, ErrInvalidAssigneeFilter
+}
+
+func filterRequests(requests []models.EventRequest, keep func(models.EventRequest) bool) []models.EventRequest {
+	filtered := []models.EventRequest{}
+	for _, r := range requests {
+		if keep(r) {
+			filtered = append(filtered, r)
+		}
+	}
+	return filtered
+}
+
+// checkRequestCampus - STAFF/ADMIN gắn campus chỉ thao tác yêu cầu thuộc campus của mình
+func (uc *EventUseCase) checkRequestCampus(ctx context.Context, requestID int) error {
+	requestCampus, err := uc.eventRepo.GetEventRequestCampusID(ctx, requestID)
+	if err != nil {
+		return err
+	}
+	return campus.Check(ctx, requestCampus)
+}
+
+// releaseAfterProcessing - Yêu cầu đã xử lý xong thì nhả claim (lỗi chỉ ghi log, job sẽ dọn sau)
+func (uc *EventUseCase) releaseAfterProcessing(ctx context.Context, requestID, reviewerID int) {
+	if err := uc.eventRepo.ReleaseEventRequestClaim(ctx, requestID, reviewerID, false); err != nil {
+		log.Printf("[CLAIM] ⚠️ Failed to release claim on request %d: %v", requestID, err)
+	}
+}
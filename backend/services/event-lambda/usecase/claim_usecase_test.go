@@ -0,0 +1,39 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/fpt-event-services/services/event-lambda/models"
+)
+
+func TestFilterByAssignee(t *testing.T) {
+	me, other := 7, 8
+	requests := []models.EventRequest{
+		{RequestID: 1, Status: "PENDING", AssignedTo: &me},
+		{RequestID: 2, Status: "PENDING", AssignedTo: &other},
+		{RequestID: 3, Status: "UPDATING"},
+		{RequestID: 4, Status: "APPROVED"},
+	}
+
+	ids := func(rs []models.EventRequest) []int {
+		out := []int{}
+		for _, r := range rs {
+			out = append(out, r.RequestID)
+		}
+		return out
+	}
+	cases := map[string][]int{"": {1, 2, 3, 4}, "me": {1}, " Unassigned ": {3}}
+	for filter, want := range cases {
+		got, err := FilterByAssignee(requests, filter, me)
+		if err != nil {
+			t.Fatalf("%q: %v", filter, err)
+		}
+		if g := ids(got); len(g) != len(want) || (len(g) > 0 && g[0] != want[0]) {
+			t.Errorf("%q = %v, want %v", filter, g, want)
+		}
+	}
+	if _, err := FilterByAssignee(requests, "others", me); !errors.Is(err, ErrInvalidAssigneeFilter) {
+		t.Errorf("unknown filter: err = %v", err)
+	}
+}
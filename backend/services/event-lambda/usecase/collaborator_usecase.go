@@ -0,0 +1,101 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/fpt-event-services/common/permission"
+	"github.com/fpt-event-services/services/event-lambda/models"
+	"github.com/fpt-event-services/services/event-lambda/repository"
+)
+
+// ============================================================
+// Co-organizer - Chủ sự kiện (hoặc ADMIN) quản lý đội tổ chức
+// ============================================================
+
+// ErrInvalidCollaboratorRequest - Email/quyền trong lời mời không hợp lệ
+var ErrInvalidCollaboratorRequest = errors.New("invalid collaborator request")
+
+// CheckEventPermission - User là chủ sự kiện hoặc co-organizer có quyền permission
+func (uc *EventUseCase) CheckEventPermission(ctx context.Context, eventID, userID int, permission string) (bool, error) {
+	return uc.eventRepo.CheckEventPermission(ctx, eventID, userID, permission)
+}
+
+// requireEventOwner - Chỉ người có quyền event.manage_any (ADMIN) hoặc người tạo sự kiện
+func (uc *EventUseCase) requireEventOwner(ctx context.Context, eventID, userID int, role string) error {
+	if permission.RoleHas(ctx, role, permission.EventManageAny) {
+		return nil
+	}
+	owns, err := uc.eventRepo.CheckEventOwnership(ctx, eventID, userID)
+	if err != nil {
+		return err
+	}
+	if !owns {
+		return repository.ErrNotEventOwner
+	}
+	return nil
+}
+
+// ListCollaborators - Đội tổ chức của sự kiện (chủ sự kiện, ADMIN)
+func (uc *EventUseCase) ListCollaborators(ctx context.Context, eventID, userID int, role string) ([]models.EventCollaborator, error) {
+	if err := uc.requireEventOwner(ctx, eventID, userID, role); err != nil {
+		return nil, err
+	}
+	return uc.eventRepo.ListCollaborators(ctx, eventID)
+}
+
+// InviteCollaborator - Mời co-organizer hoặc cập nhật quyền của người đã mời
+func (uc *EventUseCase) InviteCollaborator(ctx context.Context, eventID, userID int, role string, req *models.InviteCollaboratorRequest) (*models.EventCollaborator, error) {
+	if strings.TrimSpace(req.Email) == "" {
+		return nil, fmt.Errorf("%w: email is required", ErrInvalidCollaboratorRequest)
+	}
+	permissions, err := normalizePermissions(req.Permissions)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.requireEventOwner(ctx, eventID, userID, role); err != nil {
+		return nil, err
+	}
+	return uc.eventRepo.InviteCollaborator(ctx, eventID, userID, req.Email, permissions)
+}
+
+// AcceptCollaboration - Người được mời chấp nhận lời mời
+func (uc *EventUseCase) AcceptCollaboration(ctx context.Context, eventID, userID int) error {
+	return uc.eventRepo.AcceptCollaboration(ctx, eventID, userID)
+}
+
+// RemoveCollaborator - Chủ sự kiện/ADMIN gỡ co-organizer, hoặc co-organizer tự rời
+func (uc *EventUseCase) RemoveCollaborator(ctx context.Context, eventID, collaboratorID, userID int, role string) error {
+	if collaboratorID != userID {
+		if err := uc.requireEventOwner(ctx, eventID, userID, role); err != nil {
+			return err
+		}
+	}
+	return uc.eventRepo.RemoveCollaborator(ctx, eventID, collaboratorID)
+}
+
+// GetCollaborationInvitations - Lời mời đang chờ của organizer hiện tại
+func (uc *EventUseCase) GetCollaborationInvitations(ctx context.Context, userID int) ([]models.EventCollaboratorInvitation, error) {
+	return uc.eventRepo.GetCollaborationInvitations(ctx, userID)
+}
+
+// normalizePermissions - Chuẩn hóa, bỏ trùng và kiểm tra danh sách quyền
+func normalizePermissions(permissions []string) ([]string, error) {
+	var result []string
+	for _, p := range permissions {
+		p = strings.ToUpper(strings.TrimSpace(p))
+		if !slices.Contains(models.CollaboratorPermissions, p) {
+			return nil, fmt.Errorf("%w: unknown permission %q", ErrInvalidCollaboratorRequest, p)
+		}
+		if !slices.Contains(result, p) {
+			result = append(result, p)
+		}
+	}
+	if len(result) == 0 {
+		return nil, fmt.Errorf("%w: at least one permission is required", ErrInvalidCollaboratorRequest)
+	}
+	return result, nil
+}
@@ -0,0 +1,23 @@
+package usecase
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestNormalizePermissions(t *testing.T) {
+	got, err := normalizePermissions([]string{" view_stats", "EDIT_DETAILS", "VIEW_STATS"})
+	if err != nil {
+		t.Fatalf("normalizePermissions: %v", err)
+	}
+	if !slices.Equal(got, []string{"VIEW_STATS", "EDIT_DETAILS"}) {
+		t.Errorf("got %v", got)
+	}
+
+	for _, in := range [][]string{nil, {"DELETE_EVENT"}} {
+		if _, err := normalizePermissions(in); !errors.Is(err, ErrInvalidCollaboratorRequest) {
+			t.Errorf("normalizePermissions(%v) err = %v, want ErrInvalidCollaboratorRequest", in, err)
+		}
+	}
+}
@@ -0,0 +1,194 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fpt-event-services/common/validator"
+	"github.com/fpt-event-services/services/event-lambda/models"
+)
+
+// ============================================================
+// Sanitize nội dung người dùng nhập trước khi lưu
+// Mô tả sự kiện: HTML allow-list; tiêu đề, bio speaker, lịch trình, FAQ: văn bản thuần
+// ============================================================
+
+// ErrInvalidContent - Nội dung rỗng / quá dài sau khi sanitize
+var ErrInvalidContent = errors.New("invalid content")
+
+// sanitizeEventTitle - Tiêu đề bắt buộc, văn bản thuần
+func sanitizeEventTitle(title string) (string, error) {
+	clean := validator.SanitizeText(title)
+	if clean == "" {
+		return "", fmt.Errorf("%w: title is required", ErrInvalidContent)
+	}
+	if msg := validator.GetLengthError("Tiêu đề", clean, validator.MaxEventTitleLength); msg != "" {
+		return "", fmt.Errorf("%w: %s", ErrInvalidContent, msg)
+	}
+	return clean, nil
+}
+
+// sanitizeEventDescription - Mô tả (tùy chọn), HTML theo allow-list
+func sanitizeEventDescription(description *string) (*string, error) {
+	clean := validator.SanitizeOptionalRichText(description)
+	if clean != nil {
+		if msg := validator.GetLengthError("Mô tả", *clean, validator.MaxEventDescriptionLength); msg != "" {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidContent, msg)
+		}
+	}
+	return clean, nil
+}
+
+// sanitizeSpeakerBio - Bio speaker (tùy chọn), văn bản thuần
+func sanitizeSpeakerBio(bio *string) (*string, error) {
+	clean := validator.SanitizeOptionalText(bio)
+	if clean != nil {
+		if msg := validator.GetLengthError("Giới thiệu speaker", *clean, validator.MaxSpeakerBioLength); msg != "" {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidContent, msg)
+		}
+	}
+	return clean, nil
+}
+
+// sanitizeSpeakerDTO - Họ tên + bio của speaker gửi kèm request / update-details
+func sanitizeSpeakerDTO(sp *models.SpeakerDTO) error {
+	if sp == nil {
+		return nil
+	}
+	sp.FullName = validator.SanitizeText(sp.FullName)
+	bio, err := sanitizeSpeakerBio(sp.Bio)
+	if err != nil {
+		return err
+	}
+	sp.Bio = bio
+	return nil
+}
+
+// sanitizeSpeakerMap - Speaker dạng map của UpdateEventRequestRequest
+func sanitizeSpeakerMap(sp map[string]interface{}) error {
+	if fn, ok := sp["fullName"].(string); ok {
+		sp["fullName"] = validator.SanitizeText(fn)
+	}
+	if raw, ok := sp["bio"].(string); ok {
+		bio, err := sanitizeSpeakerBio(&raw)
+		if err != nil {
+			return err
+		}
+		if bio == nil {
+			sp["bio"] = ""
+		} else {
+			sp["bio"] = *bio
+		}
+	}
+	return nil
+}
+
+// Giới hạn nội dung trang sự kiện
+const (
+	maxScheduleBlocks       = 50
+	maxFAQEntries           = 30
+	maxScheduleTitleLength  = 200
+	maxScheduleDetailLength = 1000
+	maxFAQQuestionLength    = 300
+	maxFAQAnswerLength      = 2000
+)
+
+// sanitizePageContent - Mô tả (HTML allow-list), lịch trình và FAQ (văn bản thuần)
+// của update-details; lịch trình được sắp lại theo giờ bắt đầu
+func sanitizePageContent(req *models.UpdateEventDetailsRequest) error {
+	if req.Description != nil {
+		clean, err := sanitizeEventDescription(req.Description)
+		if err != nil {
+			return err
+		}
+		if clean == nil {
+			clean = new(string)
+		}
+		req.Description = clean
+	}
+	if req.Schedule != nil {
+		blocks, err := sanitizeSchedule(*req.Schedule)
+		if err != nil {
+			return err
+		}
+		req.Schedule = &blocks
+	}
+	if req.FAQ != nil {
+		entries, err := sanitizeFAQ(*req.FAQ)
+		if err != nil {
+			return err
+		}
+		req.FAQ = &entries
+	}
+	return nil
+}
+
+// sanitizeSchedule - Giờ HH:MM, kết thúc sau bắt đầu, tiêu đề bắt buộc
+func sanitizeSchedule(blocks []models.ScheduleBlock) ([]models.ScheduleBlock, error) {
+	if len(blocks) > maxScheduleBlocks {
+		return nil, fmt.Errorf("%w: schedule has at most %d blocks", ErrInvalidContent, maxScheduleBlocks)
+	}
+	out := make([]models.ScheduleBlock, 0, len(blocks))
+	for i, b := range blocks {
+		start, err := time.Parse("15:04", strings.TrimSpace(b.StartTime))
+		if err != nil {
+			return nil, fmt.Errorf("%w: schedule block %d: startTime must be HH:MM", ErrInvalidContent, i+1)
+		}
+		clean := models.ScheduleBlock{StartTime: start.Format("15:04"), Title: validator.SanitizeText(b.Title)}
+		if b.EndTime != nil && strings.TrimSpace(*b.EndTime) != "" {
+			end, err := time.Parse("15:04", strings.TrimSpace(*b.EndTime))
+			if err != nil {
+				return nil, fmt.Errorf("%w: schedule block %d: endTime must be HH:MM", ErrInvalidContent, i+1)
+			}
+			if !end.After(start) {
+				return nil, fmt.Errorf("%w: schedule block %d: endTime must be after startTime", ErrInvalidContent, i+1)
+			}
+			endTime := end.Format("15:04")
+			clean.EndTime = &endTime
+		}
+		if clean.Title == "" {
+			return nil, fmt.Errorf("%w: schedule block %d: title is required", ErrInvalidContent, i+1)
+		}
+		if msg := validator.GetLengthError("Tiêu đề lịch trình", clean.Title, maxScheduleTitleLength); msg != "" {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidContent, msg)
+		}
+		if clean.Speaker = validator.SanitizeOptionalText(b.Speaker); clean.Speaker != nil {
+			if msg := validator.GetLengthError("Diễn giả", *clean.Speaker, maxScheduleTitleLength); msg != "" {
+				return nil, fmt.Errorf("%w: %s", ErrInvalidContent, msg)
+			}
+		}
+		if clean.Description = validator.SanitizeOptionalText(b.Description); clean.Description != nil {
+			if msg := validator.GetLengthError("Mô tả lịch trình", *clean.Description, maxScheduleDetailLength); msg != "" {
+				return nil, fmt.Errorf("%w: %s", ErrInvalidContent, msg)
+			}
+		}
+		out = append(out, clean)
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].StartTime < out[j].StartTime })
+	return out, nil
+}
+
+// sanitizeFAQ - Câu hỏi và câu trả lời bắt buộc, văn bản thuần
+func sanitizeFAQ(entries []models.FAQEntry) ([]models.FAQEntry, error) {
+	if len(entries) > maxFAQEntries {
+		return nil, fmt.Errorf("%w: faq has at most %d entries", ErrInvalidContent, maxFAQEntries)
+	}
+	out := make([]models.FAQEntry, 0, len(entries))
+	for i, e := range entries {
+		clean := models.FAQEntry{Question: validator.SanitizeText(e.Question), Answer: validator.SanitizeText(e.Answer)}
+		if clean.Question == "" || clean.Answer == "" {
+			return nil, fmt.Errorf("%w: faq entry %d: question and answer are required", ErrInvalidContent, i+1)
+		}
+		if msg := validator.GetLengthError("Câu hỏi", clean.Question, maxFAQQuestionLength); msg != "" {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidContent, msg)
+		}
+		if msg := validator.GetLengthError("Câu trả lời", clean.Answer, maxFAQAnswerLength); msg != "" {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidContent, msg)
+		}
+		out = append(out, clean)
+	}
+	return out, nil
+}
@@ -0,0 +1,49 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/fpt-event-services/services/event-lambda/models"
+)
+
+func TestSanitizeScheduleSortsAndCleans(t *testing.T) {
+	end, speaker := "10:30", "<b>Lê Minh Khoa</b>"
+	blocks, err := sanitizeSchedule([]models.ScheduleBlock{
+		{StartTime: "13:00", Title: "Hỏi đáp"},
+		{StartTime: " 9:00", EndTime: &end, Title: "<script>x</script>Khai mạc", Speaker: &speaker},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if blocks[0].StartTime != "09:00" || blocks[1].StartTime != "13:00" {
+		t.Fatalf("blocks not normalized / sorted: %+v", blocks)
+	}
+	if blocks[0].Title != "Khai mạc" || *blocks[0].Speaker != "Lê Minh Khoa" {
+		t.Fatalf("text not sanitized: %+v", blocks[0])
+	}
+}
+
+func TestSanitizeScheduleRejectsInvalid(t *testing.T) {
+	early := "08:00"
+	cases := map[string]models.ScheduleBlock{
+		"bad start":    {StartTime: "9h", Title: "x"},
+		"end before":   {StartTime: "09:00", EndTime: &early, Title: "x"},
+		"missing name": {StartTime: "09:00", Title: "  "},
+	}
+	for name, b := range cases {
+		if _, err := sanitizeSchedule([]models.ScheduleBlock{b}); !errors.Is(err, ErrInvalidContent) {
+			t.Errorf("%s: got %v", name, err)
+		}
+	}
+}
+
+func TestSanitizeFAQ(t *testing.T) {
+	if _, err := sanitizeFAQ([]models.FAQEntry{{Question: "Có gửi xe không?", Answer: ""}}); !errors.Is(err, ErrInvalidContent) {
+		t.Fatalf("empty answer should be rejected: %v", err)
+	}
+	entries, err := sanitizeFAQ([]models.FAQEntry{{Question: "Mang gì?", Answer: "<i>Thẻ sinh viên</i>"}})
+	if err != nil || entries[0].Answer != "Thẻ sinh viên" {
+		t.Fatalf("got %+v, %v", entries, err)
+	}
+}
@@ -0,0 +1,42 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/fpt-event-services/common/qrtoken"
+	"github.com/fpt-event-services/services/event-lambda/models"
+)
+
+// ErrInvalidDynamicQR - Body thiếu enabled
+var ErrInvalidDynamicQR = errors.New("enabled is required")
+
+// GetDynamicQR - Cấu hình QR động của sự kiện
+func (uc *EventUseCase) GetDynamicQR(ctx context.Context, eventID, userID int, role string) (*models.DynamicQRSettings, error) {
+	enabled, err := uc.eventRepo.GetDynamicQR(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.requireEditDetails(ctx, eventID, userID, role); err != nil {
+		return nil, err
+	}
+	return &models.DynamicQRSettings{EventID: eventID, Enabled: enabled, StepSeconds: int(qrtoken.Step / time.Second)}, nil
+}
+
+// UpdateDynamicQR - Bật / tắt QR động; áp dụng ngay cho lần quét tiếp theo
+func (uc *EventUseCase) UpdateDynamicQR(ctx context.Context, eventID, userID int, role string, req models.UpdateDynamicQRRequest) (*models.DynamicQRSettings, error) {
+	if _, err := uc.eventRepo.GetDynamicQR(ctx, eventID); err != nil {
+		return nil, err
+	}
+	if err := uc.requireEditDetails(ctx, eventID, userID, role); err != nil {
+		return nil, err
+	}
+	if req.Enabled == nil {
+		return nil, ErrInvalidDynamicQR
+	}
+	if err := uc.eventRepo.SetDynamicQR(ctx, eventID, *req.Enabled); err != nil {
+		return nil, err
+	}
+	return uc.GetDynamicQR(ctx, eventID, userID, role)
+}
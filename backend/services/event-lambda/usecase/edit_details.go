@@ -0,0 +1,35 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"github.com/fpt-event-services/common/permission"
+	"github.com/fpt-event-services/services/event-lambda/models"
+)
+
+// ErrEditDetailsForbidden - Không có quyền sửa chi tiết sự kiện (requireEditDetails)
+var ErrEditDetailsForbidden = errors.New("only an admin, the event owner or a co-organizer with EDIT_DETAILS permission can manage this event")
+
+// ============================================================
+// requireEditDetails - Quyền chung của các chức năng cấu hình sự kiện
+// (vé PDF, bậc giá, giá ghế, cashback, QR động, hàng chờ, tài liệu, khảo sát...):
+// ADMIN, chủ sự kiện hoặc co-organizer có quyền EDIT_DETAILS
+// Không đủ quyền thì trả về ErrEditDetailsForbidden
+// ============================================================
+func (uc *EventUseCase) requireEditDetails(ctx context.Context, eventID, userID int, role string) error {
+	if permission.RoleHas(ctx, role, permission.EventManageAny) {
+		return nil
+	}
+	if role != "ORGANIZER" {
+		return ErrEditDetailsForbidden
+	}
+	allowed, err := uc.eventRepo.CheckEventPermission(ctx, eventID, userID, models.PermissionEditDetails)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return ErrEditDetailsForbidden
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package usecase
+
+import (
+	"time"
+
+	"github.com/fpt-event-services/services/event-lambda/models"
+)
+
+// ============================================================
+// Tìm sự kiện còn đặt được (GET /api/events/open?hasSeats=true&weekend=true&freeOnly=true)
+// Lọc trên danh sách OPEN đã cache (remainingSeats / isFree tính sẵn từ bộ đếm vé),
+// nên số chỗ còn lại có thể trễ tối đa openEventsCacheTTL; lượt mua vẫn kiểm tra lại bộ đếm
+// ============================================================
+
+// AvailabilityFilter - Bộ lọc còn chỗ; mọi cờ false = không lọc
+type AvailabilityFilter struct {
+	HasSeats bool // Còn ít nhất một chỗ
+	Weekend  bool // Diễn ra (một phần) trong cuối tuần này theo giờ Việt Nam
+	FreeOnly bool // Mọi loại vé đều 0đ
+}
+
+// filterAvailable - Giữ thứ tự của items; sự kiện có thời gian không đọc được bị loại khi lọc weekend
+func filterAvailable(items []models.EventListItem, f AvailabilityFilter, now time.Time) []models.EventListItem {
+	if !f.HasSeats && !f.Weekend && !f.FreeOnly {
+		return items
+	}
+	weekendStart, weekendEnd := thisWeekend(now)
+	filtered := []models.EventListItem{}
+	for _, item := range items {
+		if f.HasSeats && (item.RemainingSeats == nil || *item.RemainingSeats <= 0) {
+			continue
+		}
+		if f.FreeOnly && (item.IsFree == nil || !*item.IsFree) {
+			continue
+		}
+		if f.Weekend {
+			start, err1 := time.Parse(time.RFC3339, item.StartTime)
+			end, err2 := time.Parse(time.RFC3339, item.EndTime)
+			if err1 != nil || err2 != nil || !start.Before(weekendEnd) || !end.After(weekendStart) {
+				continue
+			}
+		}
+		filtered = append(filtered, item)
+	}
+	return filtered
+}
+
+// thisWeekend - [thứ Bảy 00:00, thứ Hai 00:00) giờ Việt Nam của tuần hiện tại
+// Đang là thứ Bảy / Chủ nhật thì trả về chính cuối tuần đó
+func thisWeekend(now time.Time) (time.Time, time.Time) {
+	loc := time.FixedZone("ICT", 7*3600)
+	if l, err := time.LoadLocation("Asia/Ho_Chi_Minh"); err == nil {
+		loc = l
+	}
+	local := now.In(loc)
+	today := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+	var start time.Time
+	switch local.Weekday() {
+	case time.Saturday:
+		start = today
+	case time.Sunday:
+		start = today.AddDate(0, 0, -1)
+	default:
+		start = today.AddDate(0, 0, int(time.Saturday-local.Weekday()))
+	}
+	return start, start.AddDate(0, 0, 2)
+}
@@ -0,0 +1,61 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fpt-event-services/services/event-lambda/models"
+)
+
+func TestThisWeekend(t *testing.T) {
+	ict := time.FixedZone("ICT", 7*3600)
+	cases := []struct {
+		now  time.Time
+		want string
+	}{
+		{time.Date(2026, 10, 14, 10, 0, 0, 0, ict), "2026-10-17"},       // thứ Tư
+		{time.Date(2026, 10, 17, 23, 0, 0, 0, ict), "2026-10-17"},       // thứ Bảy
+		{time.Date(2026, 10, 18, 20, 0, 0, 0, ict), "2026-10-17"},       // Chủ nhật
+		{time.Date(2026, 10, 18, 17, 30, 0, 0, time.UTC), "2026-10-24"}, // 00:30 thứ Hai giờ VN
+	}
+	for _, c := range cases {
+		start, end := thisWeekend(c.now)
+		if got := start.Format("2006-01-02"); got != c.want || end.Sub(start) != 48*time.Hour {
+			t.Errorf("thisWeekend(%v) = %v – %v, want start %s", c.now, start, end, c.want)
+		}
+	}
+}
+
+func TestFilterAvailable(t *testing.T) {
+	seats := func(n int) *int { return &n }
+	yes, no := true, false
+	items := []models.EventListItem{
+		{EventID: 1, RemainingSeats: seats(5), IsFree: &yes, StartTime: "2026-10-17T08:00:00+07:00", EndTime: "2026-10-17T11:00:00+07:00"},
+		{EventID: 2, RemainingSeats: seats(0), IsFree: &yes, StartTime: "2026-10-18T08:00:00+07:00", EndTime: "2026-10-18T11:00:00+07:00"},
+		{EventID: 3, RemainingSeats: seats(9), IsFree: &no, StartTime: "2026-10-20T08:00:00+07:00", EndTime: "2026-10-20T11:00:00+07:00"},
+		// Bắt đầu thứ Sáu, kéo dài sang thứ Bảy
+		{EventID: 4, RemainingSeats: seats(1), IsFree: &no, StartTime: "2026-10-16T20:00:00+07:00", EndTime: "2026-10-17T02:00:00+07:00"},
+	}
+	now := time.Date(2026, 10, 16, 9, 0, 0, 0, time.FixedZone("ICT", 7*3600))
+	ids := func(f AvailabilityFilter) []int {
+		var out []int
+		for _, item := range filterAvailable(items, f, now) {
+			out = append(out, item.EventID)
+		}
+		return out
+	}
+	check := func(name string, got []int, want ...int) {
+		if len(got) != len(want) {
+			t.Fatalf("%s: got %v, want %v", name, got, want)
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Fatalf("%s: got %v, want %v", name, got, want)
+			}
+		}
+	}
+	check("none", ids(AvailabilityFilter{}), 1, 2, 3, 4)
+	check("hasSeats", ids(AvailabilityFilter{HasSeats: true}), 1, 3, 4)
+	check("weekend", ids(AvailabilityFilter{Weekend: true}), 1, 2, 4)
+	check("free weekend with seats", ids(AvailabilityFilter{HasSeats: true, Weekend: true, FreeOnly: true}), 1)
+}
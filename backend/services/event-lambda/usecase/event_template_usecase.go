@@ -0,0 +1,181 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/fpt-event-services/common/permission"
+	"github.com/fpt-event-services/common/validator"
+	"github.com/fpt-event-services/services/event-lambda/models"
+	"github.com/fpt-event-services/services/event-lambda/repository"
+)
+
+// ============================================================
+// Event template - Thư viện mẫu sự kiện
+// Mẫu chung (global): chỉ người có quyền event.template.manage tạo / sửa
+// Mẫu riêng: organizer tạo, chỉ người tạo (hoặc người quản lý thư viện) thấy và sửa
+// ============================================================
+
+var (
+	// ErrInvalidEventTemplate - Nội dung mẫu không hợp lệ
+	ErrInvalidEventTemplate = errors.New("invalid event template")
+	// ErrEventTemplateForbidden - Không được sửa / xóa mẫu, hoặc tạo mẫu chung khi thiếu quyền
+	ErrEventTemplateForbidden = errors.New("you do not have permission to manage this event template")
+)
+
+const maxTemplateNameLength = 100
+
+// ListEventTemplates - Mẫu chung + mẫu của mình (người quản lý thư viện thấy tất cả)
+func (uc *EventUseCase) ListEventTemplates(ctx context.Context, userID int, role string) ([]models.EventTemplate, error) {
+	return uc.eventRepo.ListEventTemplates(ctx, userID, permission.RoleHas(ctx, role, permission.EventTemplateManage))
+}
+
+// CreateEventTemplate - Lưu mẫu mới
+func (uc *EventUseCase) CreateEventTemplate(ctx context.Context, userID int, role string, req *models.EventTemplateRequest) (*models.EventTemplate, error) {
+	if req.Global && !permission.RoleHas(ctx, role, permission.EventTemplateManage) {
+		return nil, ErrEventTemplateForbidden
+	}
+	if err := normalizeEventTemplate(req); err != nil {
+		return nil, err
+	}
+	id, err := uc.eventRepo.CreateEventTemplate(ctx, userID, req)
+	if err != nil {
+		return nil, err
+	}
+	return uc.eventRepo.GetEventTemplate(ctx, id)
+}
+
+// UpdateEventTemplate - Ghi đè nội dung mẫu (người tạo mẫu riêng hoặc người quản lý thư viện)
+func (uc *EventUseCase) UpdateEventTemplate(ctx context.Context, templateID, userID int, role string, req *models.EventTemplateRequest) (*models.EventTemplate, error) {
+	if err := uc.requireTemplateManage(ctx, templateID, userID, role); err != nil {
+		return nil, err
+	}
+	if req.Global && !permission.RoleHas(ctx, role, permission.EventTemplateManage) {
+		return nil, ErrEventTemplateForbidden
+	}
+	if err := normalizeEventTemplate(req); err != nil {
+		return nil, err
+	}
+	if err := uc.eventRepo.UpdateEventTemplate(ctx, templateID, req); err != nil {
+		return nil, err
+	}
+	return uc.eventRepo.GetEventTemplate(ctx, templateID)
+}
+
+// DeleteEventTemplate - Lưu trữ mẫu
+func (uc *EventUseCase) DeleteEventTemplate(ctx context.Context, templateID, userID int, role string) error {
+	if err := uc.requireTemplateManage(ctx, templateID, userID, role); err != nil {
+		return err
+	}
+	return uc.eventRepo.ArchiveEventTemplate(ctx, templateID)
+}
+
+// CreateEventRequestFromTemplate - Tạo request PENDING từ mẫu
+// Tiêu đề / mô tả / sức chứa trong body ghi đè giá trị của mẫu; cơ cấu vé vào draft (áp dụng khi duyệt)
+func (uc *EventUseCase) CreateEventRequestFromTemplate(ctx context.Context, requesterID int, role string, templateID int, start time.Time, body *models.FromTemplateRequestBody) (int, error) {
+	tpl, err := uc.eventRepo.GetEventTemplate(ctx, templateID)
+	if err != nil {
+		return 0, err
+	}
+	// Mẫu riêng của người khác coi như không tồn tại
+	if !tpl.Global && tpl.CreatedBy != requesterID && !permission.RoleHas(ctx, role, permission.EventTemplateManage) {
+		return 0, repository.ErrEventTemplateNotFound
+	}
+
+	req := &models.CreateEventRequestBody{
+		Title:              renderTitlePattern(tpl.TitlePattern, start),
+		Description:        tpl.Description,
+		PreferredStartTime: body.PreferredStartTime,
+		PreferredEndTime:   body.PreferredEndTime,
+		ExpectedCapacity:   tpl.DefaultCapacity,
+		Budget:             body.Budget,
+		TemplateID:         &tpl.TemplateID,
+	}
+	if body.Title != nil && strings.TrimSpace(*body.Title) != "" {
+		req.Title = *body.Title
+	}
+	if body.Description != nil {
+		req.Description = body.Description
+	}
+	if body.ExpectedCapacity != nil {
+		req.ExpectedCapacity = body.ExpectedCapacity
+	}
+	if len(tpl.Tickets) > 0 {
+		req.Draft = &models.EventRequestDraft{Tickets: tpl.Tickets}
+	}
+	return uc.CreateEventRequest(ctx, requesterID, req)
+}
+
+// requireTemplateManage - Người quản lý thư viện, hoặc người tạo mẫu riêng
+func (uc *EventUseCase) requireTemplateManage(ctx context.Context, templateID, userID int, role string) error {
+	tpl, err := uc.eventRepo.GetEventTemplate(ctx, templateID)
+	if err != nil {
+		return err
+	}
+	switch {
+	case permission.RoleHas(ctx, role, permission.EventTemplateManage):
+		return nil
+	case tpl.Global:
+		return ErrEventTemplateForbidden
+	case tpl.CreatedBy != userID:
+		// Mẫu riêng của người khác coi như không tồn tại
+		return repository.ErrEventTemplateNotFound
+	}
+	return nil
+}
+
+// renderTitlePattern - Thay {date} (dd/MM/yyyy), {month}, {year} theo giờ bắt đầu
+func renderTitlePattern(pattern string, start time.Time) string {
+	return strings.NewReplacer(
+		"{date}", start.Format("02/01/2006"),
+		"{month}", fmt.Sprintf("%02d", int(start.Month())),
+		"{year}", fmt.Sprintf("%d", start.Year()),
+	).Replace(pattern)
+}
+
+// normalizeEventTemplate - Chuẩn hóa và validate nội dung mẫu
+func normalizeEventTemplate(req *models.EventTemplateRequest) error {
+	req.Name = validator.SanitizeText(req.Name)
+	if req.Name == "" || len([]rune(req.Name)) > maxTemplateNameLength {
+		return fmt.Errorf("%w: name is required (max %d characters)", ErrInvalidEventTemplate, maxTemplateNameLength)
+	}
+	pattern, err := sanitizeEventTitle(req.TitlePattern)
+	if err != nil {
+		return fmt.Errorf("%w: titlePattern: %v", ErrInvalidEventTemplate, err)
+	}
+	req.TitlePattern = pattern
+	if req.Description, err = sanitizeEventDescription(req.Description); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidEventTemplate, err)
+	}
+	if req.DefaultCapacity != nil && *req.DefaultCapacity <= 0 {
+		return fmt.Errorf("%w: defaultCapacity must be positive", ErrInvalidEventTemplate)
+	}
+
+	if len(req.Tickets) > models.MaxTemplateTickets {
+		return fmt.Errorf("%w: at most %d ticket types", ErrInvalidEventTemplate, models.MaxTemplateTickets)
+	}
+	tickets := make([]models.CategoryTicketDTO, 0, len(req.Tickets))
+	seen := map[string]bool{}
+	for _, t := range req.Tickets {
+		name := validator.SanitizeText(t.Name)
+		if name == "" || seen[strings.ToLower(name)] {
+			return fmt.Errorf("%w: ticket names must be non-empty and unique", ErrInvalidEventTemplate)
+		}
+		if t.Price < 0 || t.MaxQuantity <= 0 {
+			return fmt.Errorf("%w: ticket %q needs price >= 0 and maxQuantity > 0", ErrInvalidEventTemplate, name)
+		}
+		seen[strings.ToLower(name)] = true
+		// Mẫu không giữ ID / trạng thái của loại vé thật
+		tickets = append(tickets, models.CategoryTicketDTO{
+			Name:        name,
+			Description: validator.SanitizeOptionalText(t.Description),
+			Price:       t.Price,
+			MaxQuantity: t.MaxQuantity,
+		})
+	}
+	req.Tickets = tickets
+	return nil
+}
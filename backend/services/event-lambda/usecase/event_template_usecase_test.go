@@ -0,0 +1,49 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fpt-event-services/services/event-lambda/models"
+)
+
+func TestRenderTitlePattern(t *testing.T) {
+	start := time.Date(2026, time.March, 7, 18, 0, 0, 0, time.UTC)
+	got := renderTitlePattern("Workshop AI {month}/{year} - {date}", start)
+	if want := "Workshop AI 03/2026 - 07/03/2026"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestNormalizeEventTemplate(t *testing.T) {
+	capacity := 80
+	id := 12
+	req := &models.EventTemplateRequest{
+		Name:            "  Workshop hằng tháng ",
+		TitlePattern:    "Workshop {month}/{year}",
+		DefaultCapacity: &capacity,
+		Tickets:         []models.CategoryTicketDTO{{CategoryTicketID: &id, Name: " Standard ", MaxQuantity: 80}},
+	}
+	if err := normalizeEventTemplate(req); err != nil {
+		t.Fatal(err)
+	}
+	if req.Name != "Workshop hằng tháng" || req.Tickets[0].Name != "Standard" || req.Tickets[0].CategoryTicketID != nil {
+		t.Errorf("unexpected template: %+v", req)
+	}
+
+	zero := 0
+	cases := map[string]*models.EventTemplateRequest{
+		"missing name":     {TitlePattern: "Talk"},
+		"missing pattern":  {Name: "Talk"},
+		"zero capacity":    {Name: "Talk", TitlePattern: "Talk", DefaultCapacity: &zero},
+		"duplicate ticket": {Name: "Talk", TitlePattern: "Talk", Tickets: []models.CategoryTicketDTO{{Name: "VIP", MaxQuantity: 1}, {Name: "vip", MaxQuantity: 1}}},
+		"no quantity":      {Name: "Talk", TitlePattern: "Talk", Tickets: []models.CategoryTicketDTO{{Name: "VIP"}}},
+		"too many tickets": {Name: "Talk", TitlePattern: "Talk", Tickets: make([]models.CategoryTicketDTO, models.MaxTemplateTickets+1)},
+	}
+	for name, c := range cases {
+		if err := normalizeEventTemplate(c); !errors.Is(err, ErrInvalidEventTemplate) {
+			t.Errorf("%s: err = %v, want ErrInvalidEventTemplate", name, err)
+		}
+	}
+}
@@ -2,21 +2,38 @@ package usecase
 
 import (
 	"context"
+	"errors"
+	"log"
+	"time"
 
+	"github.com/fpt-event-services/common/amenity"
+	"github.com/fpt-event-services/common/campus"
 	"github.com/fpt-event-services/common/config"
+	"github.com/fpt-event-services/common/eventbus"
+	"github.com/fpt-event-services/common/pagination"
+	"github.com/fpt-event-services/common/permission"
+	"github.com/fpt-event-services/common/storage"
 	"github.com/fpt-event-services/services/event-lambda/models"
 	"github.com/fpt-event-services/services/event-lambda/repository"
+	ticketclient "github.com/fpt-event-services/services/ticket-lambda/client"
+	venueclient "github.com/fpt-event-services/services/venue-lambda/client"
 )
 
 // EventUseCase handles event business logic
 type EventUseCase struct {
-	eventRepo *repository.EventRepository
+	eventRepo   *repository.EventRepository
+	fileStorage storage.Storage
+	tickets     ticketclient.TicketService // Số vé của sự kiện (ticket-lambda, in-process hoặc gRPC)
+	venues      venueclient.VenueService   // Khu vực / venue (venue-lambda, in-process hoặc gRPC)
 }
 
 // NewEventUseCase creates a new event use case
 func NewEventUseCase() *EventUseCase {
 	return &EventUseCase{
-		eventRepo: repository.NewEventRepository(),
+		eventRepo:   repository.DefaultEventRepository(),
+		fileStorage: storage.Default(),
+		tickets:     ticketclient.Default(),
+		venues:      venueclient.Default(),
 	}
 }
 
@@ -25,8 +42,8 @@ func NewEventUseCase() *EventUseCase {
 // Trả về 2 list: openEvents và closedEvents
 // With permission filtering: role and userID
 // ============================================================
-func (uc *EventUseCase) GetAllEventsSeparated(ctx context.Context, role string, userID int) (openEvents []models.EventListItem, closedEvents []models.EventListItem, err error) {
-	return uc.eventRepo.GetAllEventsSeparated(ctx, role, userID)
+func (uc *EventUseCase) GetAllEventsSeparated(ctx context.Context, role string, userID int, campusID *int) (openEvents []models.EventListItem, closedEvents []models.EventListItem, err error) {
+	return uc.eventRepo.GetAllEventsSeparated(ctx, role, userID, campusID)
 }
 
 // ============================================================
@@ -34,14 +51,55 @@ func (uc *EventUseCase) GetAllEventsSeparated(ctx context.Context, role string,
 // Trả về thông tin chi tiết event với tickets
 // ============================================================
 func (uc *EventUseCase) GetEventDetail(ctx context.Context, eventID int) (*models.EventDetailDto, error) {
-	return uc.eventRepo.GetEventDetail(ctx, eventID)
+	detail, err := uc.eventRepo.GetEventDetail(ctx, eventID)
+	if err != nil || detail == nil {
+		return detail, err
+	}
+	// Đã có vé giữ ghế thì sơ đồ ghế bị khóa; lỗi đếm vé chỉ bỏ trống hasBookings
+	counts, err := uc.tickets.GetEventTicketCounts(ctx, eventID)
+	if err != nil {
+		log.Printf("[GetEventDetail] failed to count tickets of event %d: %v", eventID, err)
+		return detail, nil
+	}
+	has := counts.Active() > 0
+	detail.HasBookings = &has
+	return detail, nil
+}
+
+// CanViewFullEventDetail - Người có quyền event.request.review (ADMIN, STAFF), chủ sự kiện và co-organizer
+// có quyền sửa được xem trường nội bộ (hasBookings, liên hệ speaker); còn lại dùng EventDetailDto.Public()
+func (uc *EventUseCase) CanViewFullEventDetail(ctx context.Context, eventID, userID int, role string) (bool, error) {
+	switch {
+	case permission.RoleHas(ctx, role, permission.EventRequestReview):
+		return true, nil
+	case role == "ORGANIZER":
+		return uc.eventRepo.CheckEventPermission(ctx, eventID, userID, models.PermissionEditDetails)
+	default:
+		return false, nil
+	}
 }
 
 // ============================================================
 // GetOpenEvents - Lấy chỉ events có status OPEN
+// Dùng chung cache với feed công khai (trễ tối đa openEventsCacheTTL)
 // ============================================================
-func (uc *EventUseCase) GetOpenEvents(ctx context.Context) ([]models.EventListItem, error) {
-	return uc.eventRepo.GetOpenEvents(ctx)
+// campusID != nil: lọc theo campus trên bản cache (feed public vẫn gồm mọi campus)
+// avail: lọc còn chỗ / cuối tuần / miễn phí (event_availability.go)
+func (uc *EventUseCase) GetOpenEvents(ctx context.Context, campusID *int, avail AvailabilityFilter) ([]models.EventListItem, error) {
+	items, _, err := uc.cachedOpenEvents(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if campusID != nil {
+		filtered := []models.EventListItem{}
+		for _, item := range items {
+			if item.CampusID != nil && *item.CampusID == *campusID {
+				filtered = append(filtered, item)
+			}
+		}
+		items = filtered
+	}
+	return filterAvailable(items, avail, time.Now()), nil
 }
 
 // ============================================================
@@ -49,6 +107,22 @@ func (uc *EventUseCase) GetOpenEvents(ctx context.Context) ([]models.EventListIt
 // KHỚP VỚI Java CreateEventRequestController
 // ============================================================
 func (uc *EventUseCase) CreateEventRequest(ctx context.Context, requesterID int, req *models.CreateEventRequestBody) (int, error) {
+	title, err := sanitizeEventTitle(req.Title)
+	if err != nil {
+		return 0, err
+	}
+	req.Title = title
+	if req.Description, err = sanitizeEventDescription(req.Description); err != nil {
+		return 0, err
+	}
+	if req.Budget != nil {
+		if err := normalizeBudgetInput(req.Budget); err != nil {
+			return 0, err
+		}
+	}
+	if req.RequiredAmenities, err = amenity.Normalize(req.RequiredAmenities); err != nil {
+		return 0, err
+	}
 	return uc.eventRepo.CreateEventRequest(ctx, requesterID, req)
 }
 
@@ -63,51 +137,35 @@ func (uc *EventUseCase) GetMyEventRequests(ctx context.Context, requesterID int)
 // ============================================================
 // GetMyActiveEventRequests - Lấy yêu cầu hoạt động (tab "Chờ")
 // Active = (PENDING OR UPDATING) OR (APPROVED AND endTime > NOW)
-// Hỗ trợ pagination (limit, offset)
+// Hỗ trợ pagination (page / pageSize)
 // ============================================================
-type MyActiveEventRequestsResult struct {
-	Requests   []models.EventRequest `json:"requests"`
-	TotalCount int                   `json:"totalCount"`
-}
-
-func (uc *EventUseCase) GetMyActiveEventRequests(ctx context.Context, requesterID int, limit int, offset int) (*MyActiveEventRequestsResult, error) {
-	requests, totalCount, err := uc.eventRepo.GetMyActiveEventRequests(ctx, requesterID, limit, offset)
+func (uc *EventUseCase) GetMyActiveEventRequests(ctx context.Context, requesterID int, p pagination.Params) (pagination.Page[models.EventRequest], error) {
+	requests, totalCount, err := uc.eventRepo.GetMyActiveEventRequests(ctx, requesterID, p.PageSize, p.Offset())
 	if err != nil {
-		return nil, err
+		return pagination.Page[models.EventRequest]{}, err
 	}
-	return &MyActiveEventRequestsResult{
-		Requests:   requests,
-		TotalCount: totalCount,
-	}, nil
+	return pagination.New(requests, p, totalCount), nil
 }
 
 // ============================================================
 // GetMyArchivedEventRequests - Lấy yêu cầu đã lưu trữ (tab "Đã xử lý")
 // Archived = (REJECTED OR CANCELLED OR FINISHED) OR (APPROVED AND endTime <= NOW)
-// Hỗ trợ pagination (limit, offset)
+// Hỗ trợ pagination (page / pageSize)
 // ============================================================
-type MyArchivedEventRequestsResult struct {
-	Requests   []models.EventRequest `json:"requests"`
-	TotalCount int                   `json:"totalCount"`
-}
-
-func (uc *EventUseCase) GetMyArchivedEventRequests(ctx context.Context, requesterID int, limit int, offset int) (*MyArchivedEventRequestsResult, error) {
-	requests, totalCount, err := uc.eventRepo.GetMyArchivedEventRequests(ctx, requesterID, limit, offset)
+func (uc *EventUseCase) GetMyArchivedEventRequests(ctx context.Context, requesterID int, p pagination.Params) (pagination.Page[models.EventRequest], error) {
+	requests, totalCount, err := uc.eventRepo.GetMyArchivedEventRequests(ctx, requesterID, p.PageSize, p.Offset())
 	if err != nil {
-		return nil, err
+		return pagination.Page[models.EventRequest]{}, err
 	}
-	return &MyArchivedEventRequestsResult{
-		Requests:   requests,
-		TotalCount: totalCount,
-	}, nil
+	return pagination.New(requests, p, totalCount), nil
 }
 
 // ============================================================
 // GetPendingEventRequests - Lấy danh sách yêu cầu chờ duyệt (ADMIN)
 // KHỚP VỚI Java GetPendingEventRequestsController
 // ============================================================
-func (uc *EventUseCase) GetPendingEventRequests(ctx context.Context) ([]models.EventRequest, error) {
-	return uc.eventRepo.GetPendingEventRequests(ctx)
+func (uc *EventUseCase) GetPendingEventRequests(ctx context.Context, campusID *int) ([]models.EventRequest, error) {
+	return uc.eventRepo.GetPendingEventRequests(ctx, campusID)
 }
 
 // ============================================================
@@ -122,19 +180,47 @@ func (uc *EventUseCase) GetEventRequestByID(ctx context.Context, requestID int)
 // ProcessEventRequest - Duyệt hoặc từ chối yêu cầu (ADMIN)
 // KHỚP VỚI Java ProcessEventRequestController
 // ============================================================
+// STAFF/ADMIN gắn campus chỉ xử lý yêu cầu và xếp khu vực thuộc campus của mình
+// Tự nhận claim trước khi xử lý (ErrRequestClaimedByOther nếu người khác đang giữ)
 func (uc *EventUseCase) ProcessEventRequest(ctx context.Context, adminID int, req *models.ProcessEventRequestBody) error {
-	return uc.eventRepo.ProcessEventRequest(ctx, adminID, req)
+	if err := uc.checkRequestCampus(ctx, req.RequestID); err != nil {
+		return err
+	}
+	if req.Action == "APPROVED" && req.AreaID != nil {
+		area, err := uc.venues.GetArea(ctx, *req.AreaID)
+		if errors.Is(err, venueclient.ErrAreaNotFound) {
+			return repository.ErrCampusSourceNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if err := campus.Check(ctx, area.CampusID); err != nil {
+			return err
+		}
+	}
+	// Người khác đang giữ yêu cầu thì không được xử lý song song
+	if _, err := uc.eventRepo.ClaimEventRequest(ctx, req.RequestID, adminID); err != nil {
+		return err
+	}
+	if err := uc.eventRepo.ProcessEventRequest(ctx, adminID, req); err != nil {
+		return err
+	}
+	uc.releaseAfterProcessing(ctx, req.RequestID, adminID)
+	if req.Action == "APPROVED" {
+		eventbus.Publish(ctx, eventbus.EventApproved{RequestID: req.RequestID, ApprovedBy: adminID, OccurredAt: time.Now()})
+	}
+	return nil
 }
 
 // ============================================================
-// CheckEventUpdateEligibility - Kiểm tra xem sự kiện có thể cập nhật không
+// CheckEventUpdateEligibility - Kiểm tra xem sự kiện có thể cập nhật các trường fields không
 // Quy tắc:
-// 1. Nếu event status là CLOSED, CANCELLED, FINISHED → return error 403
-// 2. Nếu Now() + 24h > event start_time → return error 400
+// 1. Nếu event status là CLOSED, CANCELLED, FINISHED → EVENT_CLOSED (403)
+// 2. Nếu Now() + rules.UpdateWindow (mặc định 24h) > event  start_time và có trường ngoài banner → EVENT_EDIT_LOCKED (400)
 // 3. Nếu không có createdEventId → return eligible (chưa tạo event)
 // ============================================================
-func (uc *EventUseCase) CheckEventUpdateEligibility(ctx context.Context, requestID int) (bool, *models.EligibilityError) {
-	return uc.eventRepo.CheckEventUpdateEligibility(ctx, requestID)
+func (uc *EventUseCase) CheckEventUpdateEligibility(ctx context.Context, requestID int, fields []string) (bool, *models.EligibilityError) {
+	return uc.eventRepo.CheckEventUpdateEligibility(ctx, requestID, fields)
 }
 
 // ============================================================
@@ -143,6 +229,29 @@ func (uc *EventUseCase) CheckEventUpdateEligibility(ctx context.Context, request
 // Status sẽ tự động chuyển thành UPDATING
 // ============================================================
 func (uc *EventUseCase) UpdateEventRequest(ctx context.Context, organizerID int, req *models.UpdateEventRequestRequest) error {
+	// Trường rỗng = giữ nguyên giá trị cũ
+	if req.Title != "" {
+		title, err := sanitizeEventTitle(req.Title)
+		if err != nil {
+			return err
+		}
+		req.Title = title
+	}
+	if req.Description != "" {
+		description, err := sanitizeEventDescription(&req.Description)
+		if err != nil {
+			return err
+		}
+		req.Description = ""
+		if description != nil {
+			req.Description = *description
+		}
+	}
+	if req.Speaker != nil {
+		if err := sanitizeSpeakerMap(req.Speaker); err != nil {
+			return err
+		}
+	}
 	return uc.eventRepo.UpdateEventRequest(ctx, organizerID, req)
 }
 
@@ -151,6 +260,14 @@ func (uc *EventUseCase) UpdateEventRequest(ctx context.Context, organizerID int,
 // KHỚP VỚI Java UpdateEventDetailController
 // ============================================================
 func (uc *EventUseCase) UpdateEvent(ctx context.Context, req *models.UpdateEventRequest) error {
+	title, err := sanitizeEventTitle(req.Title)
+	if err != nil {
+		return err
+	}
+	req.Title = title
+	if req.Description, err = sanitizeEventDescription(req.Description); err != nil {
+		return err
+	}
 	return uc.eventRepo.UpdateEvent(ctx, req)
 }
 
@@ -160,6 +277,12 @@ func (uc *EventUseCase) UpdateEvent(ctx context.Context, req *models.UpdateEvent
 // ✅ FIX: Thêm tham số role để bypass ownership check cho Admin
 // ============================================================
 func (uc *EventUseCase) UpdateEventDetails(ctx context.Context, userID int, role string, req *models.UpdateEventDetailsRequest) error {
+	if err := sanitizeSpeakerDTO(req.Speaker); err != nil {
+		return err
+	}
+	if err := sanitizePageContent(req); err != nil {
+		return err
+	}
 	return uc.eventRepo.UpdateEventDetails(ctx, userID, role, req)
 }
 
@@ -232,11 +355,16 @@ func (uc *EventUseCase) GetEventConfigById(ctx context.Context, eventID int) (*m
 }
 
 // ============================================================
-// DisableEvent - Disable event (đổi status)
+// DisableEvent - Admin vô hiệu hóa sự kiện (status DISABLED, ngừng bán, giải phóng vé PENDING)
 // KHỚP VỚI Java EventDisableController
 // ============================================================
-func (uc *EventUseCase) DisableEvent(ctx context.Context, eventID int) error {
-	return uc.eventRepo.DisableEvent(ctx, eventID)
+func (uc *EventUseCase) DisableEvent(ctx context.Context, eventID, adminID int) error {
+	released, err := uc.eventRepo.DisableEvent(ctx, eventID, adminID)
+	if err != nil {
+		return err
+	}
+	eventbus.Publish(ctx, eventbus.EventDisabled{EventID: eventID, DisabledBy: adminID, ReleasedPending: released, OccurredAt: time.Now()})
+	return nil
 }
 
 // ============================================================
@@ -244,9 +372,10 @@ func (uc *EventUseCase) DisableEvent(ctx context.Context, eventID int) error {
 // YÊU CẦU #4: Hiển thị Frontend có danh sách địa điểm trống
 // Dùng khi Staff chọn địa điểm trong danh sách
 // expectedCapacity: Sức chứa tối thiểu (lấy tất cả phòng >= expectedCapacity)
+// requiredAmenities: khu vực đủ tiện ích xếp trước; matchAll = chỉ giữ khu vực đủ tiện ích
 // ============================================================
-func (uc *EventUseCase) GetAvailableAreas(ctx context.Context, startTime, endTime string, expectedCapacity int) ([]models.AvailableAreaInfo, error) {
-	areas, err := uc.eventRepo.GetAvailableAreas(ctx, startTime, endTime, expectedCapacity)
+func (uc *EventUseCase) GetAvailableAreas(ctx context.Context, startTime, endTime string, expectedCapacity int, campusID *int, requiredAmenities []string, matchAll bool) ([]models.AvailableAreaInfo, error) {
+	areas, err := uc.eventRepo.GetAvailableAreas(ctx, startTime, endTime, expectedCapacity, campusID)
 	if err != nil {
 		return nil, err
 	}
@@ -261,17 +390,11 @@ func (uc *EventUseCase) GetAvailableAreas(ctx context.Context, startTime, endTim
 			Floor:     area.Floor,
 			Capacity:  area.Capacity,
 			Status:    area.Status,
+			CampusID:  area.CampusID,
+			Amenities: area.Amenities,
 		})
 	}
-	return result, nil
-}
-
-// ============================================================
-// ReleaseAreaOnEventClose - Giải phóng địa điểm khi sự kiện đóng
-// YÊU CẦU #2: Tối ưu hóa Scheduler với logging rõ ràng
-// ============================================================
-func (uc *EventUseCase) ReleaseAreaOnEventClose(ctx context.Context, eventID int, areaID int) error {
-	return uc.eventRepo.ReleaseAreaOnEventClose(ctx, eventID, areaID)
+	return rankAreasByAmenities(result, requiredAmenities, matchAll), nil
 }
 
 // ============================================================
@@ -301,10 +424,14 @@ func (uc *EventUseCase) CheckEventOwnership(ctx context.Context, eventID, userID
 
 // ============================================================
 // CancelEvent - Hủy sự kiện (chỉ Organizer được hủy sự kiện của mình)
-// Scenario: APPROVED event -> có refund + release area
+// Scenario: APPROVED event -> có refund + release area (subscriber EventCancelled)
 // ============================================================
 func (uc *EventUseCase) CancelEvent(ctx context.Context, userID int, eventID int) error {
-	return uc.eventRepo.CancelEvent(ctx, userID, eventID)
+	if err := uc.eventRepo.CancelEvent(ctx, userID, eventID); err != nil {
+		return err
+	}
+	eventbus.Publish(ctx, eventbus.EventCancelled{EventID: eventID, CancelledBy: userID, OccurredAt: time.Now()})
+	return nil
 }
 
 // ============================================================
@@ -312,7 +439,14 @@ func (uc *EventUseCase) CancelEvent(ctx context.Context, userID int, eventID int
 // Scenario: PENDING/UPDATING request -> chỉ update status, không cần refund
 // ============================================================
 func (uc *EventUseCase) CancelEventRequest(ctx context.Context, userID int, requestID int) error {
-	return uc.eventRepo.CancelEventRequest(ctx, userID, requestID)
+	eventID, err := uc.eventRepo.CancelEventRequest(ctx, userID, requestID)
+	if err != nil {
+		return err
+	}
+	if eventID > 0 {
+		eventbus.Publish(ctx, eventbus.EventCancelled{EventID: eventID, RequestID: requestID, CancelledBy: userID, OccurredAt: time.Now()})
+	}
+	return nil
 }
 
 // ============================================================
@@ -321,3 +455,28 @@ func (uc *EventUseCase) CancelEventRequest(ctx context.Context, userID int, requ
 func (uc *EventUseCase) CheckDailyQuota(ctx context.Context, eventDate string) (*models.CheckDailyQuotaResponse, error) {
 	return uc.eventRepo.CheckDailyQuota(ctx, eventDate)
 }
+
+// ============================================================
+// CloneEventRequest - Nhân bản event request (Organizer tổ chức định kỳ)
+// Tạo request PENDING mới với ngày mới, copy speaker + tickets vào draft
+// ============================================================
+func (uc *EventUseCase) CloneEventRequest(ctx context.Context, requesterID, sourceRequestID int, body *models.CloneEventRequestBody) (int, error) {
+	if body.Title != nil {
+		title, err := sanitizeEventTitle(*body.Title)
+		if err != nil {
+			return 0, err
+		}
+		body.Title = &title
+	}
+	if body.Description != nil {
+		description, err := sanitizeEventDescription(body.Description)
+		if err != nil {
+			return 0, err
+		}
+		if description == nil {
+			description = new(string)
+		}
+		body.Description = description
+	}
+	return uc.eventRepo.CloneEventRequest(ctx, requesterID, sourceRequestID, body)
+}
@@ -0,0 +1,103 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fpt-event-services/services/event-lambda/models"
+)
+
+// ============================================================
+// Price tiers - Lịch đổi giá của loại vé (early-bird, giá thường, giá sát ngày)
+// ============================================================
+
+// ErrInvalidPriceTiers - Lỗi của bậc giá ở tầng usecase
+var ErrInvalidPriceTiers = errors.New("invalid price tiers")
+
+// maxTicketPrice - Giới hạn giá vé (VND), khớp decimal(15,2)
+const maxTicketPrice = 1e12
+
+// GetPriceTiers - Bậc giá của loại vé kèm giá đang áp dụng
+func (uc *EventUseCase) GetPriceTiers(ctx context.Context, eventID, categoryTicketID, userID int, role string) (*models.CategoryPriceTiers, error) {
+	if err := uc.eventRepo.CheckEventExists(ctx, eventID); err != nil {
+		return nil, err
+	}
+	if err := uc.requireEditDetails(ctx, eventID, userID, role); err != nil {
+		return nil, err
+	}
+	return uc.loadPriceTiers(ctx, eventID, categoryTicketID)
+}
+
+// SavePriceTiers - Thay toàn bộ bậc giá; các khung giờ không được chồng nhau
+func (uc *EventUseCase) SavePriceTiers(ctx context.Context, eventID, categoryTicketID, userID int, role string, req *models.SavePriceTiersRequest) (*models.CategoryPriceTiers, error) {
+	if err := uc.eventRepo.CheckEventExists(ctx, eventID); err != nil {
+		return nil, err
+	}
+	if err := uc.requireEditDetails(ctx, eventID, userID, role); err != nil {
+		return nil, err
+	}
+	if _, err := uc.eventRepo.GetCategoryPriceTiers(ctx, eventID, categoryTicketID); err != nil {
+		return nil, err
+	}
+	tiers, err := normalizePriceTiers(req.Tiers)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.eventRepo.ReplacePriceTiers(ctx, categoryTicketID, tiers); err != nil {
+		return nil, err
+	}
+	return uc.loadPriceTiers(ctx, eventID, categoryTicketID)
+}
+
+func (uc *EventUseCase) loadPriceTiers(ctx context.Context, eventID, categoryTicketID int) (*models.CategoryPriceTiers, error) {
+	c, err := uc.eventRepo.GetCategoryPriceTiers(ctx, eventID, categoryTicketID)
+	if err != nil {
+		return nil, err
+	}
+	c.CurrentPrice = c.BasePrice
+	if t := c.ActiveTier(time.Now()); t != nil {
+		c.CurrentPrice, c.CurrentTier = t.Price, &t.Name
+	}
+	return c, nil
+}
+
+// normalizePriceTiers - Validate, làm tròn giá và sắp theo validFrom
+// Khung không có validTo (mở đến hết bán) chỉ được là khung cuối
+func normalizePriceTiers(in []models.PriceTier) ([]models.PriceTier, error) {
+	if len(in) > models.MaxPriceTiers {
+		return nil, fmt.Errorf("%w: at most %d tiers per category", ErrInvalidPriceTiers, models.MaxPriceTiers)
+	}
+	tiers := make([]models.PriceTier, len(in))
+	for i, t := range in {
+		t.TierID = 0
+		t.Name = strings.TrimSpace(t.Name)
+		if t.Name == "" || len([]rune(t.Name)) > 100 {
+			return nil, fmt.Errorf("%w: tier name is required (max 100 characters)", ErrInvalidPriceTiers)
+		}
+		if t.Price < 0 || t.Price >= maxTicketPrice {
+			return nil, fmt.Errorf("%w: price of %q must be between 0 and %.0f", ErrInvalidPriceTiers, t.Name, maxTicketPrice)
+		}
+		t.Price = math.Round(t.Price)
+		if t.ValidFrom.IsZero() {
+			return nil, fmt.Errorf("%w: validFrom of %q is required", ErrInvalidPriceTiers, t.Name)
+		}
+		if t.ValidTo != nil && !t.ValidTo.After(t.ValidFrom) {
+			return nil, fmt.Errorf("%w: validTo of %q must be after validFrom", ErrInvalidPriceTiers, t.Name)
+		}
+		tiers[i] = t
+	}
+
+	sort.SliceStable(tiers, func(i, j int) bool { return tiers[i].ValidFrom.Before(tiers[j].ValidFrom) })
+	for i := 1; i < len(tiers); i++ {
+		prev := tiers[i-1]
+		if prev.ValidTo == nil || prev.ValidTo.After(tiers[i].ValidFrom) {
+			return nil, fmt.Errorf("%w: %q overlaps %q", ErrInvalidPriceTiers, prev.Name, tiers[i].Name)
+		}
+	}
+	return tiers, nil
+}
@@ -0,0 +1,57 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fpt-event-services/services/event-lambda/models"
+)
+
+func TestNormalizePriceTiers(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 3, d, 0, 0, 0, 0, time.UTC) }
+	end := func(d int) *time.Time { v := day(d); return &v }
+
+	tiers, err := normalizePriceTiers([]models.PriceTier{
+		{Name: "Late", Price: 150000, ValidFrom: day(20)},
+		{TierID: 9, Name: " Early bird ", Price: 79999.6, ValidFrom: day(1), ValidTo: end(10)},
+		{Name: "Regular", Price: 120000, ValidFrom: day(10), ValidTo: end(20)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tiers[0].Name != "Early bird" || tiers[0].Price != 80000 || tiers[0].TierID != 0 || tiers[2].Name != "Late" {
+		t.Errorf("unexpected tiers: %+v", tiers)
+	}
+
+	cases := map[string][]models.PriceTier{
+		"empty name":       {{Name: " ", ValidFrom: day(1)}},
+		"negative price":   {{Name: "A", Price: -1, ValidFrom: day(1)}},
+		"missing start":    {{Name: "A", Price: 1}},
+		"end before start": {{Name: "A", ValidFrom: day(5), ValidTo: end(5)}},
+		"overlap":          {{Name: "A", ValidFrom: day(1), ValidTo: end(10)}, {Name: "B", ValidFrom: day(9)}},
+		"open-ended first": {{Name: "A", ValidFrom: day(1)}, {Name: "B", ValidFrom: day(9)}},
+		"too many":         make([]models.PriceTier, models.MaxPriceTiers+1),
+	}
+	for name, c := range cases {
+		if _, err := normalizePriceTiers(c); !errors.Is(err, ErrInvalidPriceTiers) {
+			t.Errorf("%s: err = %v, want ErrInvalidPriceTiers", name, err)
+		}
+	}
+}
+
+func TestCategoryPriceTiersActiveTier(t *testing.T) {
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(48 * time.Hour)
+	c := &models.CategoryPriceTiers{Tiers: []models.PriceTier{{Name: "Early bird", Price: 80000, ValidFrom: from, ValidTo: &to}}}
+
+	if c.ActiveTier(from.Add(-time.Second)) != nil {
+		t.Error("tier active before validFrom")
+	}
+	if tier := c.ActiveTier(from); tier == nil || tier.Name != "Early bird" {
+		t.Errorf("ActiveTier(validFrom) = %v", tier)
+	}
+	if c.ActiveTier(to) != nil {
+		t.Error("tier active at validTo (window is half-open)")
+	}
+}
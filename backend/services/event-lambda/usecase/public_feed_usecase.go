@@ -0,0 +1,285 @@
+package usecase
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fpt-event-services/common/eventbus"
+	"github.com/fpt-event-services/services/event-lambda/models"
+)
+
+// ============================================================
+// Feed công khai của các sự kiện OPEN: RSS 2.0, JSON open-data và sitemap.xml
+// Cả ba (và GET /api/events/open) đọc cùng danh sách OPEN được cache openEventsCacheTTL
+// Link của từng sự kiện là link microsite theo slug nên không đổi khi sửa tiêu đề
+// ============================================================
+
+// Định dạng feed
+const (
+	FeedRSS     = "rss"
+	FeedJSON    = "json"
+	FeedSitemap = "sitemap"
+)
+
+// ErrUnknownFeed - Định dạng feed không hỗ trợ
+var ErrUnknownFeed = errors.New("unknown feed format")
+
+const (
+	openEventsCacheTTL = 60 * time.Second
+	feedTitle          = "Sự kiện FPT University"
+	feedDescription    = "Các sự kiện đang mở đăng ký"
+	feedSummaryLength  = 500
+)
+
+// openEventsCache - Danh sách OPEN dùng chung cho mọi EventUseCase trong process
+var openEventsCache struct {
+	mu        sync.Mutex
+	items     []models.EventListItem
+	fetchedAt time.Time
+}
+
+// cachedOpenEvents - Danh sách OPEN, đọc lại DB khi cache quá openEventsCacheTTL
+// Trả kèm thời điểm đọc DB (Last-Modified của feed)
+func (uc *EventUseCase) cachedOpenEvents(ctx context.Context) ([]models.EventListItem, time.Time, error) {
+	openEventsCache.mu.Lock()
+	defer openEventsCache.mu.Unlock()
+	if !openEventsCache.fetchedAt.IsZero() && time.Since(openEventsCache.fetchedAt) < openEventsCacheTTL {
+		return openEventsCache.items, openEventsCache.fetchedAt, nil
+	}
+	items, err := uc.eventRepo.GetOpenEvents(ctx)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	openEventsCache.items = items
+	openEventsCache.fetchedAt = time.Now().Truncate(time.Second)
+	return items, openEventsCache.fetchedAt, nil
+}
+
+// invalidateOpenEvents - Bỏ cache danh sách OPEN, request sau đọc lại DB
+func invalidateOpenEvents() {
+	openEventsCache.mu.Lock()
+	defer openEventsCache.mu.Unlock()
+	openEventsCache.items, openEventsCache.fetchedAt = nil, time.Time{}
+}
+
+// RegisterSubscribers - Vé bán ra, vé được hoàn tiền và sự kiện bị vô hiệu hóa đổi danh sách OPEN:
+// bỏ cache ngay thay vì chờ hết openEventsCacheTTL (chỉ trong process nhận sự kiện)
+// Sự kiện hủy / đóng / vô hiệu hóa giải phóng khu vực ngay (xem registerVenueRelease)
+func RegisterSubscribers(bus *eventbus.Bus) {
+	eventbus.On(bus, "open-events-cache", eventbus.Sync, func(_ context.Context, _ eventbus.TicketBooked) error {
+		invalidateOpenEvents()
+		return nil
+	})
+	eventbus.On(bus, "open-events-cache", eventbus.Sync, func(_ context.Context, _ eventbus.EventDisabled) error {
+		invalidateOpenEvents()
+		return nil
+	})
+	eventbus.On(bus, "open-events-cache", eventbus.Sync, func(_ context.Context, e eventbus.ReportResolved) error {
+		if e.Approved {
+			invalidateOpenEvents()
+		}
+		return nil
+	})
+	registerVenueRelease(bus)
+	registerCheckinAlerts(bus)
+}
+
+// publicAPIURL - Gốc URL của API cho link tự trỏ của feed (mặc định cùng origin với frontend)
+func publicAPIURL() string {
+	if v := strings.TrimRight(os.Getenv("PUBLIC_API_URL"), "/"); v != "" {
+		return v
+	}
+	return frontendURL()
+}
+
+// BuildEventFeed - Render feed theo định dạng; ETag là hash của nội dung
+func (uc *EventUseCase) BuildEventFeed(ctx context.Context, format string) (*models.EventFeed, error) {
+	items, fetchedAt, err := uc.cachedOpenEvents(ctx)
+	if err != nil {
+		return nil, err
+	}
+	events := openDataEvents(items)
+
+	feed := &models.EventFeed{LastModified: fetchedAt}
+	switch format {
+	case FeedRSS:
+		feed.ContentType = "application/rss+xml; charset=utf-8"
+		feed.Body, err = renderRSS(events, fetchedAt)
+	case FeedJSON:
+		feed.ContentType = "application/json;charset=UTF-8"
+		feed.Body, err = json.Marshal(models.OpenDataFeed{
+			Title:       feedTitle,
+			HomePageURL: frontendURL(),
+			FeedURL:     publicAPIURL() + "/api/public/events.json",
+			GeneratedAt: fetchedAt,
+			Events:      events,
+		})
+	case FeedSitemap:
+		feed.ContentType = "application/xml; charset=utf-8"
+		feed.Body, err = renderSitemap(events)
+	default:
+		return nil, ErrUnknownFeed
+	}
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(feed.Body)
+	feed.ETag = `"` + hex.EncodeToString(sum[:8]) + `"`
+	return feed, nil
+}
+
+// openDataEvents - Sự kiện OPEN có slug, sắp theo giờ bắt đầu gần nhất trước
+// Listing gốc sắp start_time giảm dần (mới nhất trước) nên duyệt ngược
+func openDataEvents(items []models.EventListItem) []models.OpenDataEvent {
+	events := make([]models.OpenDataEvent, 0, len(items))
+	for i := len(items) - 1; i >= 0; i-- {
+		it := items[i]
+		if it.Slug == nil {
+			continue
+		}
+		ev := models.OpenDataEvent{
+			Slug:      *it.Slug,
+			URL:       PublicEventURL(*it.Slug),
+			Title:     it.Title,
+			StartTime: it.StartTime,
+			EndTime:   it.EndTime,
+			VenueName: it.VenueName,
+			AreaName:  it.AreaName,
+			BannerURL: it.BannerCardURL,
+		}
+		if ev.BannerURL == nil {
+			ev.BannerURL = it.BannerURL
+		}
+		if it.Description != nil {
+			ev.Summary = truncateRunes(strings.Join(strings.Fields(plainText(*it.Description)), " "), feedSummaryLength)
+		}
+		events = append(events, ev)
+	}
+	return events
+}
+
+type rssDocument struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Atom    string     `xml:"xmlns:atom,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	Language      string    `xml:"language"`
+	LastBuildDate string    `xml:"lastBuildDate"`
+	TTL           int       `xml:"ttl"`
+	Self          rssLink   `xml:"atom:link"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr"`
+	Type string `xml:"type,attr"`
+}
+
+type rssItem struct {
+	Title       string  `xml:"title"`
+	Link        string  `xml:"link"`
+	GUID        rssGUID `xml:"guid"`
+	Description string  `xml:"description"`
+	Category    string  `xml:"category,omitempty"`
+}
+
+type rssGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+func renderRSS(events []models.OpenDataEvent, builtAt time.Time) ([]byte, error) {
+	ch := rssChannel{
+		Title:         feedTitle,
+		Link:          frontendURL(),
+		Description:   feedDescription,
+		Language:      "vi",
+		LastBuildDate: builtAt.UTC().Format(time.RFC1123Z),
+		TTL:           int(openEventsCacheTTL / time.Minute),
+		Self:          rssLink{Href: publicAPIURL() + "/api/public/events.rss", Rel: "self", Type: "application/rss+xml"},
+		Items:         make([]rssItem, 0, len(events)),
+	}
+	for _, ev := range events {
+		item := rssItem{
+			Title:       ev.Title,
+			Link:        ev.URL,
+			GUID:        rssGUID{IsPermaLink: true, Value: ev.URL},
+			Description: rssEventDescription(ev),
+		}
+		if ev.VenueName != nil {
+			item.Category = *ev.VenueName
+		}
+		ch.Items = append(ch.Items, item)
+	}
+	return marshalXML(rssDocument{Version: "2.0", Atom: "http://www.w3.org/2005/Atom", Channel: ch})
+}
+
+// rssEventDescription - Thời gian, địa điểm rồi tóm tắt (text thuần, encoding/xml tự escape)
+func rssEventDescription(ev models.OpenDataEvent) string {
+	parts := []string{formatFeedTime(ev.StartTime) + " - " + formatFeedTime(ev.EndTime)}
+	if ev.VenueName != nil {
+		place := *ev.VenueName
+		if ev.AreaName != nil {
+			place = *ev.AreaName + ", " + place
+		}
+		parts = append(parts, place)
+	}
+	if ev.Summary != "" {
+		parts = append(parts, ev.Summary)
+	}
+	return strings.Join(parts, "\n")
+}
+
+// formatFeedTime - "15:04 02/01/2006" theo giờ Việt Nam như email vé
+func formatFeedTime(rfc3339 string) string {
+	t, err := time.Parse(time.RFC3339, rfc3339)
+	if err != nil {
+		return rfc3339
+	}
+	if loc, err := time.LoadLocation("Asia/Ho_Chi_Minh"); err == nil {
+		t = t.In(loc)
+	}
+	return t.Format("15:04 02/01/2006")
+}
+
+type sitemapURLSet struct {
+	XMLName xml.Name     `xml:"urlset"`
+	NS      string       `xml:"xmlns,attr"`
+	URLs    []sitemapURL `xml:"url"`
+}
+
+type sitemapURL struct {
+	Loc        string `xml:"loc"`
+	ChangeFreq string `xml:"changefreq"`
+}
+
+func renderSitemap(events []models.OpenDataEvent) ([]byte, error) {
+	set := sitemapURLSet{NS: "http://www.sitemaps.org/schemas/sitemap/0.9", URLs: make([]sitemapURL, 0, len(events))}
+	for _, ev := range events {
+		set.URLs = append(set.URLs, sitemapURL{Loc: ev.URL, ChangeFreq: "daily"})
+	}
+	return marshalXML(set)
+}
+
+func marshalXML(v interface{}) ([]byte, error) {
+	body, err := xml.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), body...), nil
+}
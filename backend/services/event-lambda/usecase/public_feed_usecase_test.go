@@ -0,0 +1,88 @@
+package usecase
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fpt-event-services/services/event-lambda/models"
+)
+
+func feedItems() []models.EventListItem {
+	s1, s2 := "hoi-thao-ai", "dem-nhac"
+	venue, area := "Nhà văn hóa", "Hội trường A"
+	desc := "<p>Tìm hiểu AI &amp; Cloud</p>"
+	// GetOpenEvents sắp start_time giảm dần
+	return []models.EventListItem{
+		{EventID: 3, Title: "Đêm nhạc", Slug: &s2, StartTime: "2026-11-20T19:00:00+07:00", EndTime: "2026-11-20T21:00:00+07:00"},
+		{EventID: 2, Title: "Chưa có slug", StartTime: "2026-11-10T08:00:00+07:00", EndTime: "2026-11-10T10:00:00+07:00"},
+		{EventID: 1, Title: "Hội thảo AI <mới>", Slug: &s1, Description: &desc, VenueName: &venue, AreaName: &area,
+			StartTime: "2026-11-01T08:00:00+07:00", EndTime: "2026-11-01T11:00:00+07:00"},
+	}
+}
+
+func TestOpenDataEvents(t *testing.T) {
+	t.Setenv("FRONTEND_URL", "https://events.example.edu.vn/")
+	got := openDataEvents(feedItems())
+	if len(got) != 2 {
+		t.Fatalf("got %d events, want 2 (events without slug are skipped)", len(got))
+	}
+	if got[0].Slug != "hoi-thao-ai" || got[1].Slug != "dem-nhac" {
+		t.Fatalf("events not ordered by start time: %s, %s", got[0].Slug, got[1].Slug)
+	}
+	if got[0].URL != "https://events.example.edu.vn/e/hoi-thao-ai" {
+		t.Fatalf("URL = %q", got[0].URL)
+	}
+	if got[0].Summary != "Tìm hiểu AI & Cloud" {
+		t.Fatalf("Summary = %q", got[0].Summary)
+	}
+}
+
+func TestRenderRSS(t *testing.T) {
+	t.Setenv("FRONTEND_URL", "https://events.example.edu.vn")
+	body, err := renderRSS(openDataEvents(feedItems()), time.Date(2026, 10, 16, 8, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("renderRSS: %v", err)
+	}
+	var doc struct {
+		Channel struct {
+			Items []struct {
+				Title       string `xml:"title"`
+				Link        string `xml:"link"`
+				GUID        string `xml:"guid"`
+				Description string `xml:"description"`
+			} `xml:"item"`
+		} `xml:"channel"`
+	}
+	if err := xml.Unmarshal(body, &doc); err != nil {
+		t.Fatalf("RSS is not valid XML: %v\n%s", err, body)
+	}
+	items := doc.Channel.Items
+	if len(items) != 2 || items[0].Title != "Hội thảo AI <mới>" || items[0].GUID != items[0].Link {
+		t.Fatalf("unexpected items: %+v", items)
+	}
+	if !strings.Contains(items[0].Description, "08:00 01/11/2026 - 11:00 01/11/2026") ||
+		!strings.Contains(items[0].Description, "Hội trường A, Nhà văn hóa") {
+		t.Fatalf("Description = %q", items[0].Description)
+	}
+}
+
+func TestRenderSitemap(t *testing.T) {
+	t.Setenv("FRONTEND_URL", "https://events.example.edu.vn")
+	body, err := renderSitemap(openDataEvents(feedItems()))
+	if err != nil {
+		t.Fatalf("renderSitemap: %v", err)
+	}
+	var set struct {
+		URLs []struct {
+			Loc string `xml:"loc"`
+		} `xml:"url"`
+	}
+	if err := xml.Unmarshal(body, &set); err != nil {
+		t.Fatalf("sitemap is not valid XML: %v", err)
+	}
+	if len(set.URLs) != 2 || set.URLs[1].Loc != "https://events.example.edu.vn/e/dem-nhac" {
+		t.Fatalf("unexpected urls: %+v", set.URLs)
+	}
+}
@@ -0,0 +1,140 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"html"
+	"regexp"
+	"strings"
+
+	"github.com/fpt-event-services/services/event-lambda/models"
+)
+
+// ============================================================
+// Public microsite - Trang chia sẻ của sự kiện theo slug, không cần đăng nhập
+// Sự kiện UPDATING (chưa mở đăng ký) coi như không tồn tại
+// ============================================================
+
+// ErrPublicEventNotFound - Slug không có hoặc sự kiện chưa công khai
+var ErrPublicEventNotFound = errors.New("public event not found")
+
+const (
+	// ogDescriptionLength - Số ký tự tối đa của og:description
+	ogDescriptionLength = 200
+	// fewTicketsMin - Còn ít hơn max(fewTicketsMin, 10% tổng suất) thì báo "few"
+	fewTicketsMin = 10
+	ogSiteName    = "FPT Event Management"
+)
+
+var (
+	publicSlugPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+	htmlTagPattern    = regexp.MustCompile(`(?s)<[^>]*>`)
+	scriptPattern     = regexp.MustCompile(`(?is)<script\b.*?</script\s*>|<style\b.*?</style\s*>`)
+	spacePattern      = regexp.MustCompile(`[ \t\r\f\v]+`)
+	blankLinePattern  = regexp.MustCompile(`\n\s*\n+`)
+)
+
+// GetPublicEventPage - Dữ liệu microsite của sự kiện
+func (uc *EventUseCase) GetPublicEventPage(ctx context.Context, eventSlug string) (*models.PublicEventPage, error) {
+	if len(eventSlug) > 120 || !publicSlugPattern.MatchString(eventSlug) {
+		return nil, ErrPublicEventNotFound
+	}
+	eventID, status, err := uc.eventRepo.GetEventIDBySlug(ctx, eventSlug)
+	if err != nil {
+		return nil, err
+	}
+	if eventID == 0 || status == "UPDATING" {
+		return nil, ErrPublicEventNotFound
+	}
+
+	detail, err := uc.eventRepo.GetEventDetail(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	if detail == nil {
+		return nil, ErrPublicEventNotFound
+	}
+	remaining, total, err := uc.eventRepo.EventTicketsRemaining(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	d := detail.Public()
+	page := &models.PublicEventPage{
+		Slug:         eventSlug,
+		Title:        d.Title,
+		StartTime:    d.StartTime,
+		EndTime:      d.EndTime,
+		Status:       d.Status,
+		BannerURL:    d.BannerURL,
+		BannerHero:   d.BannerHeroURL,
+		VenueName:    d.VenueName,
+		AreaName:     d.AreaName,
+		Floor:        d.Floor,
+		Speakers:     []models.PublicSpeaker{},
+		Sponsors:     d.Sponsors,
+		Availability: availabilityBucket(d.Status, remaining, total),
+	}
+	if d.Description != nil {
+		page.Description = plainText(*d.Description)
+	}
+	if d.SpeakerName != nil {
+		sp := models.PublicSpeaker{Name: *d.SpeakerName, AvatarURL: d.SpeakerAvatarURL}
+		if d.SpeakerBio != nil {
+			sp.Bio = plainText(*d.SpeakerBio)
+		}
+		page.Speakers = append(page.Speakers, sp)
+	}
+	if page.Sponsors == nil {
+		page.Sponsors = []models.EventSponsor{}
+	}
+
+	image := d.BannerHeroURL
+	if image == nil {
+		image = d.BannerURL
+	}
+	page.OpenGraph = models.OpenGraphMetadata{
+		Title:       d.Title,
+		Description: truncateRunes(strings.Join(strings.Fields(page.Description), " "), ogDescriptionLength),
+		Image:       image,
+		URL:         PublicEventURL(eventSlug),
+		Type:        "website",
+		SiteName:    ogSiteName,
+	}
+	return page, nil
+}
+
+// PublicEventURL - Link microsite trên frontend
+func PublicEventURL(eventSlug string) string {
+	return frontendURL() + "/e/" + eventSlug
+}
+
+// availabilityBucket - Chỉ công khai mức còn vé, không lộ số lượng
+func availabilityBucket(status string, remaining, total int) string {
+	if status != "OPEN" || remaining <= 0 {
+		return models.AvailabilitySoldOut
+	}
+	if remaining <= max(fewTicketsMin, total/10) {
+		return models.AvailabilityFew
+	}
+	return models.AvailabilityPlenty
+}
+
+// plainText - Bỏ thẻ HTML (mô tả soạn bằng rich-text editor) và nội dung script/style, giữ xuống dòng
+func plainText(s string) string {
+	s = scriptPattern.ReplaceAllString(s, "")
+	s = strings.NewReplacer("<br>", "\n", "<br/>", "\n", "<br />", "\n", "</p>", "\n").Replace(s)
+	s = html.UnescapeString(htmlTagPattern.ReplaceAllString(s, ""))
+	s = spacePattern.ReplaceAllString(s, " ")
+	s = blankLinePattern.ReplaceAllString(s, "\n\n")
+	return strings.TrimSpace(s)
+}
+
+// truncateRunes - Cắt theo ký tự (không cắt giữa chữ có dấu), thêm "…"
+func truncateRunes(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return strings.TrimSpace(string(r[:n-1])) + "…"
+}
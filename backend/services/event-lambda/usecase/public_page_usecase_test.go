@@ -0,0 +1,48 @@
+package usecase
+
+import (
+	"testing"
+	"unicode/utf8"
+
+	"github.com/fpt-event-services/services/event-lambda/models"
+)
+
+func TestAvailabilityBucket(t *testing.T) {
+	cases := []struct {
+		status           string
+		remaining, total int
+		want             string
+	}{
+		{"OPEN", 150, 200, models.AvailabilityPlenty},
+		{"OPEN", 20, 200, models.AvailabilityFew},
+		{"OPEN", 10, 30, models.AvailabilityFew},
+		{"OPEN", 11, 30, models.AvailabilityPlenty},
+		{"OPEN", 0, 200, models.AvailabilitySoldOut},
+		{"OPEN", 0, 0, models.AvailabilitySoldOut},
+		{"CLOSED", 150, 200, models.AvailabilitySoldOut},
+	}
+	for _, c := range cases {
+		if got := availabilityBucket(c.status, c.remaining, c.total); got != c.want {
+			t.Errorf("availabilityBucket(%s, %d, %d) = %s, want %s", c.status, c.remaining, c.total, got, c.want)
+		}
+	}
+}
+
+func TestPlainText(t *testing.T) {
+	in := `<p>Hội thảo <b>AI</b> &amp; Cloud</p><p>Đăng ký   sớm!<br>Miễn phí</p><script>x</script>`
+	want := "Hội thảo AI & Cloud\nĐăng ký sớm!\nMiễn phí"
+	if got := plainText(in); got != want {
+		t.Fatalf("plainText = %q, want %q", got, want)
+	}
+}
+
+func TestTruncateRunes(t *testing.T) {
+	s := "Sự kiện âm nhạc đường phố"
+	got := truncateRunes(s, 8)
+	if !utf8.ValidString(got) || utf8.RuneCountInString(got) > 8 {
+		t.Fatalf("truncateRunes = %q", got)
+	}
+	if truncateRunes(s, 100) != s {
+		t.Fatalf("short string must not change")
+	}
+}
@@ -0,0 +1,181 @@
+package usecase
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fpt-event-services/common/email"
+	"github.com/fpt-event-services/services/event-lambda/models"
+	"github.com/fpt-event-services/services/event-lambda/repository"
+)
+
+// ============================================================
+// Raffle - Bốc thăm trúng thưởng trong số người đã check-in
+// Seed ngẫu nhiên (crypto/rand) được lưu cùng danh sách vé để ai cũng kiểm chứng lại được
+// ============================================================
+
+// Lỗi của bốc thăm ở tầng usecase
+var (
+	ErrRaffleNotAllowed        = errors.New("raffles cannot be run for a cancelled event")
+	ErrNotEnoughRaffleEntrants = errors.New("not enough eligible checked-in attendees")
+	ErrInvalidRaffleRequest    = errors.New("invalid raffle request")
+)
+
+// DrawRaffle - Bốc winnerCount người trúng (mỗi người trúng tối đa một lần) và báo cho người trúng
+func (uc *EventUseCase) DrawRaffle(ctx context.Context, eventID, userID int, role string, req *models.RaffleRequest) (*models.RaffleDraw, error) {
+	info, err := uc.eventRepo.GetEventBookingInfo(ctx, eventID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repository.ErrEventNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.requireEditDetails(ctx, eventID, userID, role); err != nil {
+		return nil, err
+	}
+	if info.Status == "CANCELLED" {
+		return nil, ErrRaffleNotAllowed
+	}
+
+	draw, err := normalizeRaffleRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	draw.EventID, draw.DrawnBy = eventID, userID
+
+	candidates, err := uc.eventRepo.ListRaffleCandidates(ctx, eventID, draw.ExcludeStaff, draw.ExcludeComplimentary, draw.ExcludePreviousWinners)
+	if err != nil {
+		return nil, err
+	}
+	if n := countRaffleEntrants(candidates); n < draw.WinnerCount {
+		return nil, fmt.Errorf("%w: %d eligible, %d requested", ErrNotEnoughRaffleEntrants, n, draw.WinnerCount)
+	}
+
+	seed := make([]byte, 32)
+	if _, err := rand.Read(seed); err != nil {
+		return nil, fmt.Errorf("failed to generate raffle seed: %w", err)
+	}
+	draw.Seed = hex.EncodeToString(seed)
+	draw.CandidateCount = len(candidates)
+	draw.CandidateTicketIDs = make([]int, len(candidates))
+	for i, c := range candidates {
+		draw.CandidateTicketIDs[i] = c.TicketID
+	}
+	draw.CandidatesHash = raffleCandidatesHash(draw.CandidateTicketIDs)
+
+	winners := pickRaffleWinners(seed, candidates, draw.WinnerCount)
+	for i, w := range winners {
+		draw.Winners = append(draw.Winners, models.RaffleWinner{Position: i + 1, TicketID: w.TicketID, UserID: w.UserID, FullName: w.FullName})
+	}
+
+	notification := fmt.Sprintf("Chúc mừng! Bạn đã trúng thưởng \"%s\" trong sự kiện \"%s\". Vui lòng mang vé đến quầy ban tổ chức để nhận quà.", draw.Prize, info.Title)
+	raffleID, err := uc.eventRepo.CreateRaffleDraw(ctx, draw, notification)
+	if err != nil {
+		return nil, err
+	}
+	draw.RaffleID = raffleID
+	draw.DrawnAt = time.Now().Format(time.RFC3339)
+
+	// Email lỗi không hủy kết quả: người trúng vẫn có thông báo trong app, email nằm trong hàng đợi để gửi lại
+	svc := email.NewEmailService(nil)
+	for _, w := range winners {
+		msg := svc.BuildRaffleWinnerEmail(w.Email, w.FullName, info.Title, draw.Prize, w.TicketID)
+		if err := email.DefaultQueue().Send(ctx, email.TemplateRaffleWinner, msg, fmt.Sprintf("raffle:%d:%d", raffleID, w.TicketID)); err != nil {
+			log.Printf("[RAFFLE] ⚠️ Failed to send winner email for raffle %d ticket %d: %v", raffleID, w.TicketID, err)
+		}
+	}
+	return draw, nil
+}
+
+// ListRaffleDraws - Lịch sử bốc thăm của sự kiện (kèm seed và danh sách vé để kiểm chứng)
+func (uc *EventUseCase) ListRaffleDraws(ctx context.Context, eventID, userID int, role string) ([]models.RaffleDraw, error) {
+	if err := uc.eventRepo.CheckEventExists(ctx, eventID); err != nil {
+		return nil, err
+	}
+	if err := uc.requireEditDetails(ctx, eventID, userID, role); err != nil {
+		return nil, err
+	}
+	return uc.eventRepo.ListRaffleDraws(ctx, eventID)
+}
+
+// normalizeRaffleRequest - Validate body; các cờ loại trừ mặc định true
+func normalizeRaffleRequest(req *models.RaffleRequest) (*models.RaffleDraw, error) {
+	prize := strings.TrimSpace(req.Prize)
+	if prize == "" || len([]rune(prize)) > 255 {
+		return nil, fmt.Errorf("%w: prize is required (max 255 characters)", ErrInvalidRaffleRequest)
+	}
+	if req.WinnerCount < 1 || req.WinnerCount > models.MaxRaffleWinners {
+		return nil, fmt.Errorf("%w: winnerCount must be between 1 and %d", ErrInvalidRaffleRequest, models.MaxRaffleWinners)
+	}
+	flag := func(v *bool) bool { return v == nil || *v }
+	return &models.RaffleDraw{
+		Prize:                  prize,
+		WinnerCount:            req.WinnerCount,
+		Algorithm:              models.RaffleAlgorithm,
+		ExcludeStaff:           flag(req.ExcludeStaff),
+		ExcludeComplimentary:   flag(req.ExcludeComplimentary),
+		ExcludePreviousWinners: flag(req.ExcludePreviousWinners),
+	}, nil
+}
+
+// countRaffleEntrants - Số người (không phải số vé) có thể trúng
+func countRaffleEntrants(candidates []models.RaffleCandidate) int {
+	users := map[int]bool{}
+	for _, c := range candidates {
+		users[c.UserID] = true
+	}
+	return len(users)
+}
+
+// pickRaffleWinners - Fisher–Yates tất định theo seed (xem models.RaffleAlgorithm)
+// Bỏ qua vé của người đã trúng để mỗi người trúng tối đa một lần
+func pickRaffleWinners(seed []byte, candidates []models.RaffleCandidate, n int) []models.RaffleCandidate {
+	pool := append([]models.RaffleCandidate(nil), candidates...)
+	winners := make([]models.RaffleCandidate, 0, n)
+	won := map[int]bool{}
+	for i := 0; i < len(pool) && len(winners) < n; i++ {
+		j := i + int(raffleUniform(seed, i, uint64(len(pool)-i)))
+		pool[i], pool[j] = pool[j], pool[i]
+		if won[pool[i].UserID] {
+			continue
+		}
+		won[pool[i].UserID] = true
+		winners = append(winners, pool[i])
+	}
+	return winners
+}
+
+// raffleUniform - Số ngẫu nhiên đều trong [0, bound) từ HMAC-SHA256(seed, "step:attempt"), loại bỏ modulo bias
+func raffleUniform(seed []byte, step int, bound uint64) uint64 {
+	rem := (math.MaxUint64%bound + 1) % bound // 2^64 mod bound
+	for attempt := 0; ; attempt++ {
+		mac := hmac.New(sha256.New, seed)
+		mac.Write([]byte(strconv.Itoa(step) + ":" + strconv.Itoa(attempt)))
+		u := binary.BigEndian.Uint64(mac.Sum(nil)[:8])
+		if rem == 0 || u < -rem {
+			return u % bound
+		}
+	}
+}
+
+// raffleCandidatesHash - SHA-256 của danh sách ticket_id nối bằng dấu phẩy
+func raffleCandidatesHash(ticketIDs []int) string {
+	parts := make([]string, len(ticketIDs))
+	for i, id := range ticketIDs {
+		parts[i] = strconv.Itoa(id)
+	}
+	sum := sha256.Sum256([]byte(strings.Join(parts, ",")))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,89 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/fpt-event-services/services/event-lambda/models"
+)
+
+func TestPickRaffleWinners(t *testing.T) {
+	candidates := []models.RaffleCandidate{
+		{TicketID: 1, UserID: 10}, {TicketID: 2, UserID: 10}, {TicketID: 3, UserID: 11},
+		{TicketID: 4, UserID: 12}, {TicketID: 5, UserID: 13}, {TicketID: 6, UserID: 14},
+	}
+	seed := []byte("0123456789abcdef0123456789abcdef")
+
+	first := pickRaffleWinners(seed, candidates, 5)
+	again := pickRaffleWinners(seed, candidates, 5)
+	if len(first) != 5 {
+		t.Fatalf("got %d winners, want 5", len(first))
+	}
+	users := map[int]bool{}
+	for i, w := range first {
+		if users[w.UserID] {
+			t.Errorf("user %d won twice", w.UserID)
+		}
+		users[w.UserID] = true
+		if again[i] != w {
+			t.Errorf("draw is not reproducible from the seed: %v vs %v", first, again)
+		}
+	}
+	if candidates[0].TicketID != 1 || candidates[5].TicketID != 6 {
+		t.Errorf("candidates were reordered: %v", candidates)
+	}
+
+	other := pickRaffleWinners([]byte("another seed"), candidates, 5)
+	same := true
+	for i := range other {
+		same = same && other[i] == first[i]
+	}
+	if same {
+		t.Errorf("different seeds produced the same draw: %v", first)
+	}
+}
+
+func TestRaffleUniform(t *testing.T) {
+	seed := []byte("seed")
+	counts := make([]int, 3)
+	for step := 0; step < 3000; step++ {
+		v := raffleUniform(seed, step, 3)
+		if v >= 3 {
+			t.Fatalf("value %d out of range", v)
+		}
+		counts[v]++
+	}
+	for i, c := range counts {
+		if c < 850 || c > 1150 {
+			t.Errorf("bucket %d has %d of 3000 draws", i, c)
+		}
+	}
+}
+
+func TestRaffleCandidatesHash(t *testing.T) {
+	// sha256("3,7,12")
+	if got := raffleCandidatesHash([]int{3, 7, 12}); got != "6e1edfa51fa67e031108ef80f855db72240de72e7fac84463380b1c9d0e656df" {
+		t.Errorf("raffleCandidatesHash = %s", got)
+	}
+}
+
+func TestNormalizeRaffleRequest(t *testing.T) {
+	no := false
+	draw, err := normalizeRaffleRequest(&models.RaffleRequest{Prize: " Tai nghe ", WinnerCount: 2, ExcludeComplimentary: &no})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if draw.Prize != "Tai nghe" || !draw.ExcludeStaff || draw.ExcludeComplimentary || !draw.ExcludePreviousWinners {
+		t.Errorf("unexpected draw: %+v", draw)
+	}
+
+	for _, req := range []models.RaffleRequest{
+		{Prize: " ", WinnerCount: 1},
+		{Prize: "Mug", WinnerCount: 0},
+		{Prize: "Mug", WinnerCount: models.MaxRaffleWinners + 1},
+	} {
+		if _, err := normalizeRaffleRequest(&req); !errors.Is(err, ErrInvalidRaffleRequest) {
+			t.Errorf("%+v: err = %v, want ErrInvalidRaffleRequest", req, err)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/fpt-event-services/common/rules"
+	"github.com/fpt-event-services/services/event-lambda/models"
+	"github.com/fpt-event-services/services/event-lambda/repository"
+)
+
+// ============================================================
+// Giá riêng từng ghế - organizer đặt % cộng / trừ cho một nhóm ghế trong loại vé
+// (vd. hai hàng đầu +20%). Khoảng % giới hạn bởi rule.seat_discount_max_percent /
+// rule.seat_premium_max_percent; loại vé miễn phí không có giá riêng
+// ============================================================
+
+// ErrInvalidSeatPrice - Loại vé / ghế không thuộc sự kiện hoặc % ngoài khoảng cho phép
+var ErrInvalidSeatPrice = errors.New("invalid seat price")
+
+// GetSeatPrices - Override hiện có của sự kiện và khoảng % cho phép
+func (uc *EventUseCase) GetSeatPrices(ctx context.Context, eventID, userID int, role string) (*models.SeatPriceSettings, error) {
+	overrides, err := uc.eventRepo.ListSeatPrices(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.requireEditDetails(ctx, eventID, userID, role); err != nil {
+		return nil, err
+	}
+	maxDiscount, maxPremium := rules.SeatPriceBounds(ctx)
+	return &models.SeatPriceSettings{
+		EventID:            eventID,
+		MaxDiscountPercent: maxDiscount,
+		MaxPremiumPercent:  maxPremium,
+		Overrides:          overrides,
+	}, nil
+}
+
+// UpdateSeatPrices - Thay toàn bộ override của một loại vé
+func (uc *EventUseCase) UpdateSeatPrices(ctx context.Context, eventID, userID int, role string, req models.UpdateSeatPricesRequest) (*models.SeatPriceSettings, error) {
+	if _, err := uc.eventRepo.ListSeatPrices(ctx, eventID); err != nil {
+		return nil, err
+	}
+	if err := uc.requireEditDetails(ctx, eventID, userID, role); err != nil {
+		return nil, err
+	}
+
+	if req.CategoryTicketID <= 0 {
+		return nil, fmt.Errorf("%w: categoryTicketId is required", ErrInvalidSeatPrice)
+	}
+	category, err := uc.eventRepo.GetSeatPriceCategory(ctx, eventID, req.CategoryTicketID)
+	if err != nil {
+		return nil, err
+	}
+	if category == nil {
+		return nil, fmt.Errorf("%w: ticket category %d does not belong to this event", ErrInvalidSeatPrice, req.CategoryTicketID)
+	}
+	maxDiscount, maxPremium := rules.SeatPriceBounds(ctx)
+	adjust, err := resolveSeatPriceRules(category, req.Rules, maxDiscount, maxPremium)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.eventRepo.ReplaceSeatPrices(ctx, eventID, req.CategoryTicketID, userID, role, adjust); err != nil {
+		return nil, err
+	}
+	return uc.GetSeatPrices(ctx, eventID, userID, role)
+}
+
+// resolveSeatPriceRules - Rule → seat_id → %; rule sau ghi đè rule trước, 0% = bỏ override của ghế
+func resolveSeatPriceRules(category *repository.SeatPriceCategory, seatRules []models.SeatPriceRule, maxDiscount, maxPremium int) (map[int]int, error) {
+	byRow := make(map[string][]int)
+	inCategory := make(map[int]bool, len(category.Seats))
+	for _, s := range category.Seats {
+		row := strings.ToUpper(strings.TrimSpace(s.RowNo))
+		byRow[row] = append(byRow[row], s.SeatID)
+		inCategory[s.SeatID] = true
+	}
+
+	adjust := make(map[int]int)
+	for i, rule := range seatRules {
+		if rule.AdjustPercent < -maxDiscount || rule.AdjustPercent > maxPremium {
+			return nil, fmt.Errorf("%w: rule %d: adjustPercent must be between -%d and +%d", ErrInvalidSeatPrice, i+1, maxDiscount, maxPremium)
+		}
+		if rule.AdjustPercent != 0 && category.Price <= 0 {
+			return nil, fmt.Errorf("%w: ticket category %q is free, seats cannot have their own price", ErrInvalidSeatPrice, category.Name)
+		}
+		if len(rule.Rows) == 0 && len(rule.SeatIDs) == 0 {
+			return nil, fmt.Errorf("%w: rule %d: rows or seatIds is required", ErrInvalidSeatPrice, i+1)
+		}
+
+		var seats []int
+		for _, row := range rule.Rows {
+			matched := byRow[strings.ToUpper(strings.TrimSpace(row))]
+			if len(matched) == 0 {
+				return nil, fmt.Errorf("%w: rule %d: row %q has no seats in this ticket category", ErrInvalidSeatPrice, i+1, row)
+			}
+			seats = append(seats, matched...)
+		}
+		for _, id := range rule.SeatIDs {
+			if !inCategory[id] {
+				return nil, fmt.Errorf("%w: rule %d: seat %d is not in this ticket category", ErrInvalidSeatPrice, i+1, id)
+			}
+			seats = append(seats, id)
+		}
+		for _, id := range seats {
+			if rule.AdjustPercent == 0 {
+				delete(adjust, id)
+			} else {
+				adjust[id] = rule.AdjustPercent
+			}
+		}
+	}
+	return adjust, nil
+}
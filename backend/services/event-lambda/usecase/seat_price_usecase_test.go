@@ -0,0 +1,49 @@
+package usecase
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/fpt-event-services/services/event-lambda/models"
+	"github.com/fpt-event-services/services/event-lambda/repository"
+)
+
+func seatPriceCategory(price float64) *repository.SeatPriceCategory {
+	return &repository.SeatPriceCategory{Name: "VIP", Price: price, Seats: []repository.CategorySeat{
+		{SeatID: 1, RowNo: "A"}, {SeatID: 2, RowNo: "A"}, {SeatID: 3, RowNo: "B"}, {SeatID: 4, RowNo: "C"},
+	}}
+}
+
+func TestResolveSeatPriceRules(t *testing.T) {
+	got, err := resolveSeatPriceRules(seatPriceCategory(100000), []models.SeatPriceRule{
+		{Rows: []string{"a", "B"}, AdjustPercent: 20},
+		{SeatIDs: []int{2}, AdjustPercent: 0}, // bỏ override của ghế 2
+		{SeatIDs: []int{4}, AdjustPercent: -10},
+	}, 50, 50)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := map[int]int{1: 20, 3: 20, 4: -10}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestResolveSeatPriceRulesRejects(t *testing.T) {
+	cases := map[string]struct {
+		price float64
+		rule  models.SeatPriceRule
+	}{
+		"above premium":  {100000, models.SeatPriceRule{Rows: []string{"A"}, AdjustPercent: 60}},
+		"below discount": {100000, models.SeatPriceRule{Rows: []string{"A"}, AdjustPercent: -60}},
+		"unknown row":    {100000, models.SeatPriceRule{Rows: []string{"Z"}, AdjustPercent: 10}},
+		"foreign seat":   {100000, models.SeatPriceRule{SeatIDs: []int{99}, AdjustPercent: 10}},
+		"no selection":   {100000, models.SeatPriceRule{AdjustPercent: 10}},
+		"free category":  {0, models.SeatPriceRule{Rows: []string{"A"}, AdjustPercent: 10}},
+	}
+	for name, tc := range cases {
+		if _, err := resolveSeatPriceRules(seatPriceCategory(tc.price), []models.SeatPriceRule{tc.rule}, 50, 50); !errors.Is(err, ErrInvalidSeatPrice) {
+			t.Errorf("%s: got %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,203 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/fpt-event-services/common/email"
+	"github.com/fpt-event-services/common/hash"
+	"github.com/fpt-event-services/common/validator"
+	"github.com/fpt-event-services/services/event-lambda/models"
+	"github.com/fpt-event-services/services/event-lambda/repository"
+)
+
+// ============================================================
+// Speaker portal - Diễn giả tự cập nhật hồ sơ và đăng tài liệu buổi nói
+// Ban tổ chức (quyền EDIT_DETAILS) gửi lời mời tới email speaker;
+// speaker nhận lời mời để liên kết (hoặc tạo) tài khoản
+// ============================================================
+
+const (
+	// SpeakerInvitationTTL - Hạn của link mời
+	SpeakerInvitationTTL = 7 * 24 * time.Hour
+	// MaxSessionMaterials - Số tài liệu tối đa mỗi sự kiện
+	MaxSessionMaterials = 20
+)
+
+// Lỗi của speaker portal ở tầng usecase
+var (
+	ErrInvalidSpeakerRequest = errors.New("invalid speaker request")
+)
+
+// InviteSpeaker - Tạo lời mời và gửi link nhận lời mời tới email speaker
+func (uc *EventUseCase) InviteSpeaker(ctx context.Context, eventID, userID int, role string, req *models.InviteSpeakerRequest) (*models.SpeakerInvitation, error) {
+	contact, err := uc.eventRepo.GetEventSpeakerContact(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.requireEditDetails(ctx, eventID, userID, role); err != nil {
+		return nil, err
+	}
+
+	to := strings.TrimSpace(req.Email)
+	if to == "" {
+		to = contact.Email
+	}
+	if to == "" {
+		return nil, repository.ErrSpeakerEmailMissing
+	}
+	if !validator.IsValidEmail(to) {
+		return nil, fmt.Errorf("%w: invalid email", ErrInvalidSpeakerRequest)
+	}
+
+	token, tokenHash, err := newInvitationToken()
+	if err != nil {
+		return nil, err
+	}
+	expiresAt := time.Now().Add(SpeakerInvitationTTL)
+	if err := uc.eventRepo.CreateSpeakerInvitation(ctx, eventID, contact.SpeakerID, userID, to, tokenHash, expiresAt); err != nil {
+		return nil, err
+	}
+
+	// Lỗi gửi lần đầu không hủy lời mời: email vẫn nằm trong hàng đợi để gửi lại
+	acceptURL := frontendURL() + "/speaker/accept-invitation?token=" + url.QueryEscape(token)
+	msg := email.NewEmailService(nil).BuildSpeakerInvitationEmail(to, contact.SpeakerName, contact.EventTitle, acceptURL, expiresAt)
+	if err := email.DefaultQueue().Send(ctx, email.TemplateSpeakerInvite, msg, fmt.Sprintf("speaker_invite:%d", eventID)); err != nil {
+		log.Printf("[SPEAKER] ⚠️ Failed to send invitation email for event %d: %v", eventID, err)
+	}
+
+	return &models.SpeakerInvitation{EventID: eventID, SpeakerID: contact.SpeakerID, Email: to, ExpiresAt: expiresAt}, nil
+}
+
+// AcceptSpeakerInvitation - Nhận lời mời bằng token trong email
+// Password chỉ cần khi email chưa có tài khoản
+func (uc *EventUseCase) AcceptSpeakerInvitation(ctx context.Context, req *models.AcceptSpeakerInvitationRequest) (*models.AcceptSpeakerInvitationResponse, error) {
+	token := strings.TrimSpace(req.Token)
+	if token == "" {
+		return nil, fmt.Errorf("%w: token is required", ErrInvalidSpeakerRequest)
+	}
+	passwordHash := ""
+	if req.Password != "" {
+		if !validator.IsValidPassword(req.Password) {
+			return nil, fmt.Errorf("%w: password must be at least 6 characters and contain letters and digits", ErrInvalidSpeakerRequest)
+		}
+		passwordHash = hash.HashPassword(req.Password)
+	}
+	return uc.eventRepo.AcceptSpeakerInvitation(ctx, hashInvitationToken(token), passwordHash)
+}
+
+// GetSpeakerEvents - Sự kiện speaker hiện tại trình bày
+func (uc *EventUseCase) GetSpeakerEvents(ctx context.Context, userID int) ([]models.SpeakerEvent, error) {
+	if _, err := uc.eventRepo.GetSpeakerProfile(ctx, userID); err != nil {
+		return nil, err
+	}
+	return uc.eventRepo.ListSpeakerEvents(ctx, userID)
+}
+
+// GetSpeakerProfile - Hồ sơ speaker của user hiện tại
+func (uc *EventUseCase) GetSpeakerProfile(ctx context.Context, userID int) (*models.SpeakerProfile, error) {
+	return uc.eventRepo.GetSpeakerProfile(ctx, userID)
+}
+
+// UpdateSpeakerProfile - Speaker tự sửa bio/avatar (họ tên, liên hệ do ban tổ chức quản lý)
+func (uc *EventUseCase) UpdateSpeakerProfile(ctx context.Context, userID int, req *models.SpeakerProfile) (*models.SpeakerProfile, error) {
+	bio, err := sanitizeSpeakerBio(req.Bio)
+	if err != nil {
+		return nil, err
+	}
+	avatar := trimmedOrNil(req.AvatarURL)
+	if avatar != nil {
+		if err := validateSpeakerLink(*avatar, "avatarUrl"); err != nil {
+			return nil, err
+		}
+	}
+	if err := uc.eventRepo.UpdateSpeakerProfile(ctx, userID, bio, avatar); err != nil {
+		return nil, err
+	}
+	return uc.eventRepo.GetSpeakerProfile(ctx, userID)
+}
+
+// AddSessionMaterial - Speaker của sự kiện đăng tài liệu (slide, link ghi hình...)
+func (uc *EventUseCase) AddSessionMaterial(ctx context.Context, eventID, userID int, req *models.AddSessionMaterialRequest) (*models.SessionMaterial, error) {
+	title := strings.TrimSpace(req.Title)
+	link := strings.TrimSpace(req.URL)
+	if title == "" || len([]rune(title)) > 200 {
+		return nil, fmt.Errorf("%w: title is required (max 200 characters)", ErrInvalidSpeakerRequest)
+	}
+	if err := validateSpeakerLink(link, "url"); err != nil {
+		return nil, err
+	}
+	speakerID, err := uc.eventRepo.GetLinkedSpeakerID(ctx, eventID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return uc.eventRepo.AddSessionMaterial(ctx, eventID, speakerID, userID, title, link, MaxSessionMaterials)
+}
+
+// DeleteSessionMaterial - Speaker của sự kiện gỡ tài liệu
+func (uc *EventUseCase) DeleteSessionMaterial(ctx context.Context, eventID, materialID, userID int) error {
+	if _, err := uc.eventRepo.GetLinkedSpeakerID(ctx, eventID, userID); err != nil {
+		return err
+	}
+	return uc.eventRepo.DeleteSessionMaterial(ctx, eventID, materialID)
+}
+
+// newInvitationToken - Token ngẫu nhiên gửi qua email và SHA-256 của nó để lưu DB
+func newInvitationToken() (token, tokenHash string, err error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", fmt.Errorf("failed to generate invitation token: %w", err)
+	}
+	token = hex.EncodeToString(b)
+	return token, hashInvitationToken(token), nil
+}
+
+func hashInvitationToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// frontendURL - Địa chỉ frontend dùng trong link mời / link trang public
+func frontendURL() string {
+	if v := strings.TrimRight(os.Getenv("FRONTEND_URL"), "/"); v != "" {
+		return v
+	}
+	return "http://localhost:3000"
+}
+
+// validateSpeakerLink - Link http/https tuyệt đối (server không tải nội dung)
+func validateSpeakerLink(raw, field string) error {
+	if !isHTTPLink(raw) {
+		return fmt.Errorf("%w: %s must be an http or https URL (max 500 characters)", ErrInvalidSpeakerRequest, field)
+	}
+	return nil
+}
+
+// isHTTPLink - URL http/https có host, tối đa 500 ký tự (độ dài cột url)
+func isHTTPLink(raw string) bool {
+	if len(raw) > 500 {
+		return false
+	}
+	u, err := url.Parse(raw)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+func trimmedOrNil(s *string) *string {
+	if s == nil {
+		return nil
+	}
+	v := strings.TrimSpace(*s)
+	if v == "" {
+		return nil
+	}
+	return &v
+}
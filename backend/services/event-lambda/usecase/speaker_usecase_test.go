@@ -0,0 +1,33 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidateSpeakerLink(t *testing.T) {
+	for _, ok := range []string{"https://docs.google.com/presentation/d/abc", "http://example.com/slides.pdf"} {
+		if err := validateSpeakerLink(ok, "url"); err != nil {
+			t.Errorf("validateSpeakerLink(%q) = %v", ok, err)
+		}
+	}
+	for _, bad := range []string{"", "javascript:alert(1)", "ftp://example.com/a", "/uploads/a.pdf", "https://"} {
+		if err := validateSpeakerLink(bad, "url"); !errors.Is(err, ErrInvalidSpeakerRequest) {
+			t.Errorf("validateSpeakerLink(%q) err = %v, want ErrInvalidSpeakerRequest", bad, err)
+		}
+	}
+}
+
+// DB chỉ lưu hash: token trong email phải băm ra đúng giá trị đã lưu
+func TestInvitationTokenHash(t *testing.T) {
+	token, tokenHash, err := newInvitationToken()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(token) != 64 || tokenHash == token || hashInvitationToken(token) != tokenHash {
+		t.Errorf("token %q hash %q", token, tokenHash)
+	}
+	if other, _, _ := newInvitationToken(); other == token {
+		t.Error("tokens must be random")
+	}
+}
@@ -0,0 +1,149 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/fpt-event-services/common/permission"
+	"github.com/fpt-event-services/services/event-lambda/models"
+)
+
+// ============================================================
+// Sponsors - Ban tổ chức quản lý nhà tài trợ của sự kiện
+// Gắn/sửa hạng/gỡ: quyền sửa chi tiết sự kiện (requireEditDetails)
+// Sửa hồ sơ dùng chung (name, logo, website): người tạo hồ sơ hoặc ADMIN
+// ============================================================
+
+// Lỗi của nhà tài trợ ở tầng usecase
+var (
+	ErrSponsorNotEditable    = errors.New("only the sponsor's creator or an admin can edit its name, logo or website")
+	ErrInvalidSponsorRequest = errors.New("invalid sponsor request")
+)
+
+// ListEventSponsors - Nhà tài trợ của sự kiện (công khai)
+func (uc *EventUseCase) ListEventSponsors(ctx context.Context, eventID int) ([]models.EventSponsor, error) {
+	if err := uc.eventRepo.CheckEventExists(ctx, eventID); err != nil {
+		return nil, err
+	}
+	return uc.eventRepo.ListEventSponsors(ctx, eventID)
+}
+
+// AddEventSponsor - Gắn hồ sơ có sẵn (sponsorId) hoặc tạo hồ sơ mới (name) cho sự kiện
+func (uc *EventUseCase) AddEventSponsor(ctx context.Context, eventID, userID int, role string, req *models.EventSponsorRequest) (*models.EventSponsor, error) {
+	if err := uc.eventRepo.CheckEventExists(ctx, eventID); err != nil {
+		return nil, err
+	}
+	if err := uc.requireEditDetails(ctx, eventID, userID, role); err != nil {
+		return nil, err
+	}
+
+	s := &models.EventSponsor{}
+	if err := applySponsorPlacement(s, req); err != nil {
+		return nil, err
+	}
+	if req.SponsorID != nil {
+		if req.Name != nil || req.LogoURL != nil || req.WebsiteURL != nil {
+			return nil, fmt.Errorf("%w: send either sponsorId or name/logoUrl/websiteUrl, not both", ErrInvalidSponsorRequest)
+		}
+		if _, err := uc.eventRepo.GetSponsorCreator(ctx, *req.SponsorID); err != nil {
+			return nil, err
+		}
+		s.SponsorID = *req.SponsorID
+	} else if err := applySponsorProfile(s, req); err != nil {
+		return nil, err
+	}
+
+	if err := uc.eventRepo.AddEventSponsor(ctx, eventID, userID, s, models.MaxSponsorsPerEvent); err != nil {
+		return nil, err
+	}
+	return uc.eventRepo.GetEventSponsor(ctx, eventID, s.SponsorID)
+}
+
+// UpdateEventSponsor - Sửa hạng/thứ tự; kèm name/logoUrl/websiteUrl thì sửa hồ sơ dùng chung
+func (uc *EventUseCase) UpdateEventSponsor(ctx context.Context, eventID, sponsorID, userID int, role string, req *models.EventSponsorRequest) (*models.EventSponsor, error) {
+	s, err := uc.eventRepo.GetEventSponsor(ctx, eventID, sponsorID)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.requireEditDetails(ctx, eventID, userID, role); err != nil {
+		return nil, err
+	}
+	if err := applySponsorPlacement(s, req); err != nil {
+		return nil, err
+	}
+
+	updateProfile := req.Name != nil || req.LogoURL != nil || req.WebsiteURL != nil
+	if updateProfile {
+		// Hồ sơ dùng chung giữa các sự kiện: ban tổ chức khác chỉ được đổi hạng/thứ tự
+		createdBy, err := uc.eventRepo.GetSponsorCreator(ctx, sponsorID)
+		if err != nil {
+			return nil, err
+		}
+		if !permission.RoleHas(ctx, role, permission.EventManageAny) && createdBy != userID {
+			return nil, ErrSponsorNotEditable
+		}
+		if err := applySponsorProfile(s, req); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := uc.eventRepo.UpdateEventSponsor(ctx, eventID, s, updateProfile); err != nil {
+		return nil, err
+	}
+	return uc.eventRepo.GetEventSponsor(ctx, eventID, sponsorID)
+}
+
+// RemoveEventSponsor - Gỡ nhà tài trợ khỏi sự kiện
+func (uc *EventUseCase) RemoveEventSponsor(ctx context.Context, eventID, sponsorID, userID int, role string) error {
+	if _, err := uc.eventRepo.GetEventSponsor(ctx, eventID, sponsorID); err != nil {
+		return err
+	}
+	if err := uc.requireEditDetails(ctx, eventID, userID, role); err != nil {
+		return err
+	}
+	return uc.eventRepo.RemoveEventSponsor(ctx, eventID, sponsorID)
+}
+
+// applySponsorPlacement - Validate tier/displayOrder; trống giữ giá trị cũ (tạo mới: PARTNER, 0)
+func applySponsorPlacement(s *models.EventSponsor, req *models.EventSponsorRequest) error {
+	if t := strings.ToUpper(strings.TrimSpace(req.Tier)); t != "" {
+		if !slices.Contains(models.SponsorTiers, t) {
+			return fmt.Errorf("%w: tier must be one of %s", ErrInvalidSponsorRequest, strings.Join(models.SponsorTiers, ", "))
+		}
+		s.Tier = t
+	} else if s.Tier == "" {
+		s.Tier = models.SponsorTierPartner
+	}
+	if req.DisplayOrder != nil {
+		if *req.DisplayOrder < 0 || *req.DisplayOrder > 1000 {
+			return fmt.Errorf("%w: displayOrder must be between 0 and 1000", ErrInvalidSponsorRequest)
+		}
+		s.DisplayOrder = *req.DisplayOrder
+	}
+	return nil
+}
+
+// applySponsorProfile - Validate name/logoUrl/websiteUrl; field nil giữ giá trị cũ, chuỗi rỗng xóa link
+func applySponsorProfile(s *models.EventSponsor, req *models.EventSponsorRequest) error {
+	if req.Name != nil {
+		s.Name = strings.TrimSpace(*req.Name)
+	}
+	if s.Name == "" || len([]rune(s.Name)) > 150 {
+		return fmt.Errorf("%w: name is required (max 150 characters)", ErrInvalidSponsorRequest)
+	}
+	if req.LogoURL != nil {
+		s.LogoURL = trimmedOrNil(req.LogoURL)
+	}
+	if req.WebsiteURL != nil {
+		s.WebsiteURL = trimmedOrNil(req.WebsiteURL)
+	}
+	for _, link := range []*string{s.LogoURL, s.WebsiteURL} {
+		if link != nil && !isHTTPLink(*link) {
+			return fmt.Errorf("%w: logoUrl and websiteUrl must be http or https URLs (max 500 characters)", ErrInvalidSponsorRequest)
+		}
+	}
+	return nil
+}
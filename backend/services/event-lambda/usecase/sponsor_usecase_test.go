@@ -0,0 +1,50 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/fpt-event-services/services/event-lambda/models"
+)
+
+func TestApplySponsorFields(t *testing.T) {
+	name, logo, empty := "  FPT Software ", "https://cdn.example.com/fpt.png", " "
+	s := &models.EventSponsor{}
+	req := &models.EventSponsorRequest{Name: &name, Tier: "gold", LogoURL: &logo, WebsiteURL: &empty}
+	if err := applySponsorPlacement(s, req); err != nil {
+		t.Fatal(err)
+	}
+	if err := applySponsorProfile(s, req); err != nil {
+		t.Fatal(err)
+	}
+	if s.Name != "FPT Software" || s.Tier != models.SponsorTierGold || s.LogoURL == nil || s.WebsiteURL != nil {
+		t.Errorf("unexpected sponsor: %+v", s)
+	}
+
+	// Tier trống giữ hạng hiện tại, tạo mới mặc định PARTNER
+	if err := applySponsorPlacement(s, &models.EventSponsorRequest{}); err != nil || s.Tier != models.SponsorTierGold {
+		t.Errorf("tier changed to %q (%v)", s.Tier, err)
+	}
+	fresh := &models.EventSponsor{}
+	if err := applySponsorPlacement(fresh, &models.EventSponsorRequest{}); err != nil || fresh.Tier != models.SponsorTierPartner {
+		t.Errorf("default tier = %q (%v)", fresh.Tier, err)
+	}
+
+	badLogo, negative := "javascript:alert(1)", -1
+	cases := map[string]*models.EventSponsorRequest{
+		"unknown tier":     {Name: &name, Tier: "PLATINUM"},
+		"negative order":   {Name: &name, DisplayOrder: &negative},
+		"missing name":     {Name: &empty},
+		"non-http logo":    {Name: &name, LogoURL: &badLogo},
+		"non-http website": {Name: &name, WebsiteURL: &badLogo},
+	}
+	for label, req := range cases {
+		err := applySponsorPlacement(&models.EventSponsor{}, req)
+		if err == nil {
+			err = applySponsorProfile(&models.EventSponsor{}, req)
+		}
+		if !errors.Is(err, ErrInvalidSponsorRequest) {
+			t.Errorf("%s: err = %v, want ErrInvalidSponsorRequest", label, err)
+		}
+	}
+}
@@ -0,0 +1,396 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fpt-event-services/common/csvutil"
+	"github.com/fpt-event-services/services/event-lambda/models"
+	"github.com/fpt-event-services/services/event-lambda/repository"
+)
+
+// ============================================================
+// Survey - Khảo sát sau sự kiện
+// Soạn câu hỏi, xem kết quả, xuất CSV: quyền sửa chi tiết sự kiện (requireEditDetails)
+// Trả lời: người đã check-in sự kiện, khi khảo sát OPEN, mỗi người một lần
+// ============================================================
+
+// Lỗi của khảo sát ở tầng usecase
+var (
+	ErrSurveyNotOpen        = errors.New("survey is not open for responses")
+	ErrNotEventAttendee     = errors.New("only attendees who checked in can respond to this survey")
+	ErrInvalidSurveyRequest = errors.New("invalid survey request")
+)
+
+// Thang điểm mặc định và giới hạn của câu SCALE
+const (
+	defaultScaleMin = 1
+	defaultScaleMax = 5
+	maxScaleValue   = 10
+)
+
+// GetEventSurvey - Bộ câu hỏi kèm số phiếu (màn soạn khảo sát)
+func (uc *EventUseCase) GetEventSurvey(ctx context.Context, eventID, userID int, role string) (*models.EventSurvey, error) {
+	if err := uc.eventRepo.CheckEventExists(ctx, eventID); err != nil {
+		return nil, err
+	}
+	if err := uc.requireEditDetails(ctx, eventID, userID, role); err != nil {
+		return nil, err
+	}
+	return uc.eventRepo.GetEventSurvey(ctx, eventID)
+}
+
+// SaveEventSurvey - Tạo / sửa khảo sát; mở khảo sát (OPEN) cần ít nhất một câu hỏi
+func (uc *EventUseCase) SaveEventSurvey(ctx context.Context, eventID, userID int, role string, req *models.SaveSurveyRequest) (*models.EventSurvey, error) {
+	if err := uc.eventRepo.CheckEventExists(ctx, eventID); err != nil {
+		return nil, err
+	}
+	if err := uc.requireEditDetails(ctx, eventID, userID, role); err != nil {
+		return nil, err
+	}
+	if err := normalizeSurvey(req); err != nil {
+		return nil, err
+	}
+
+	questionCount := len(req.Questions)
+	if req.Questions == nil {
+		current, err := uc.eventRepo.GetEventSurvey(ctx, eventID)
+		switch {
+		case errors.Is(err, repository.ErrSurveyNotFound):
+			questionCount = 0
+		case err != nil:
+			return nil, err
+		default:
+			questionCount = len(current.Questions)
+		}
+	}
+	if req.Status == models.SurveyOpen && questionCount == 0 {
+		return nil, fmt.Errorf("%w: add at least one question before opening the survey", ErrInvalidSurveyRequest)
+	}
+
+	if err := uc.eventRepo.SaveEventSurvey(ctx, eventID, userID, req); err != nil {
+		return nil, err
+	}
+	return uc.eventRepo.GetEventSurvey(ctx, eventID)
+}
+
+// GetStudentSurvey - Khảo sát đang mở cho người tham dự, kèm đã trả lời hay chưa
+func (uc *EventUseCase) GetStudentSurvey(ctx context.Context, eventID, userID int) (*models.EventSurvey, error) {
+	survey, err := uc.openSurveyForAttendee(ctx, eventID, userID)
+	if err != nil {
+		return nil, err
+	}
+	responded, err := uc.eventRepo.HasSurveyResponse(ctx, survey.SurveyID, userID)
+	if err != nil {
+		return nil, err
+	}
+	survey.HasResponded = &responded
+	survey.ResponseCount = 0 // Số phiếu chỉ hiển thị cho ban tổ chức
+	return survey, nil
+}
+
+// SubmitSurveyResponse - Người tham dự gửi phiếu trả lời
+func (uc *EventUseCase) SubmitSurveyResponse(ctx context.Context, eventID, userID int, req *models.SurveyResponseRequest) error {
+	survey, err := uc.openSurveyForAttendee(ctx, eventID, userID)
+	if err != nil {
+		return err
+	}
+	answers, err := validateSurveyAnswers(survey.Questions, req.Answers)
+	if err != nil {
+		return err
+	}
+	return uc.eventRepo.CreateSurveyResponse(ctx, survey.SurveyID, userID, answers)
+}
+
+// GetSurveyResults - Kết quả tổng hợp theo từng câu hỏi
+func (uc *EventUseCase) GetSurveyResults(ctx context.Context, eventID, userID int, role string) (*models.SurveyResults, error) {
+	survey, records, err := uc.loadSurveyResponses(ctx, eventID, userID, role)
+	if err != nil {
+		return nil, err
+	}
+	return buildSurveyResults(survey, records), nil
+}
+
+// ExportSurveyCSV - Phiếu trả lời dạng CSV (mỗi dòng một phiếu, không kèm người trả lời)
+func (uc *EventUseCase) ExportSurveyCSV(ctx context.Context, eventID, userID int, role string) (string, []byte, error) {
+	survey, records, err := uc.loadSurveyResponses(ctx, eventID, userID, role)
+	if err != nil {
+		return "", nil, err
+	}
+	data, err := surveyResponsesCSV(survey, records)
+	if err != nil {
+		return "", nil, err
+	}
+	return fmt.Sprintf("survey_event_%d.csv", eventID), data, nil
+}
+
+func (uc *EventUseCase) loadSurveyResponses(ctx context.Context, eventID, userID int, role string) (*models.EventSurvey, []models.SurveyResponseRecord, error) {
+	survey, err := uc.GetEventSurvey(ctx, eventID, userID, role)
+	if err != nil {
+		return nil, nil, err
+	}
+	records, err := uc.eventRepo.ListSurveyResponses(ctx, survey.SurveyID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return survey, records, nil
+}
+
+func (uc *EventUseCase) openSurveyForAttendee(ctx context.Context, eventID, userID int) (*models.EventSurvey, error) {
+	survey, err := uc.eventRepo.GetEventSurvey(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	if survey.Status != models.SurveyOpen {
+		return nil, ErrSurveyNotOpen
+	}
+	attended, err := uc.eventRepo.HasAttendedEvent(ctx, eventID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !attended {
+		return nil, ErrNotEventAttendee
+	}
+	return survey, nil
+}
+
+// normalizeSurvey - Chuẩn hóa và validate khảo sát (status mặc định DRAFT)
+func normalizeSurvey(req *models.SaveSurveyRequest) error {
+	req.Title = strings.TrimSpace(req.Title)
+	if req.Title == "" || len([]rune(req.Title)) > 200 {
+		return fmt.Errorf("%w: title is required (max 200 characters)", ErrInvalidSurveyRequest)
+	}
+	req.Description = trimmedOrNil(req.Description)
+	if req.Description != nil && len([]rune(*req.Description)) > 1000 {
+		return fmt.Errorf("%w: description must be at most 1000 characters", ErrInvalidSurveyRequest)
+	}
+	req.Status = strings.ToUpper(strings.TrimSpace(req.Status))
+	if req.Status == "" {
+		req.Status = models.SurveyDraft
+	}
+	if req.Status != models.SurveyDraft && req.Status != models.SurveyOpen && req.Status != models.SurveyClosed {
+		return fmt.Errorf("%w: status must be DRAFT, OPEN or CLOSED", ErrInvalidSurveyRequest)
+	}
+
+	if len(req.Questions) > models.MaxSurveyQuestions {
+		return fmt.Errorf("%w: at most %d questions", ErrInvalidSurveyRequest, models.MaxSurveyQuestions)
+	}
+	for i := range req.Questions {
+		if err := normalizeSurveyQuestion(&req.Questions[i], i+1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func normalizeSurveyQuestion(q *models.SurveyQuestion, position int) error {
+	q.QuestionID, q.Position = 0, position
+	q.Type = strings.ToUpper(strings.TrimSpace(q.Type))
+	if !slices.Contains(models.QuestionTypes, q.Type) {
+		return fmt.Errorf("%w: question %d type must be one of %s", ErrInvalidSurveyRequest, position, strings.Join(models.QuestionTypes, ", "))
+	}
+	q.Prompt = strings.TrimSpace(q.Prompt)
+	if q.Prompt == "" || len([]rune(q.Prompt)) > 500 {
+		return fmt.Errorf("%w: question %d prompt is required (max 500 characters)", ErrInvalidSurveyRequest, position)
+	}
+
+	switch q.Type {
+	case models.QuestionSingleChoice, models.QuestionMultiChoice:
+		options := make([]string, 0, len(q.Options))
+		for _, o := range q.Options {
+			o = strings.TrimSpace(o)
+			if o == "" || len([]rune(o)) > 200 || slices.Contains(options, o) {
+				return fmt.Errorf("%w: question %d options must be unique and non-empty (max 200 characters)", ErrInvalidSurveyRequest, position)
+			}
+			options = append(options, o)
+		}
+		if len(options) < 2 || len(options) > models.MaxSurveyOptions {
+			return fmt.Errorf("%w: question %d needs 2 to %d options", ErrInvalidSurveyRequest, position, models.MaxSurveyOptions)
+		}
+		q.Options, q.ScaleMin, q.ScaleMax = options, nil, nil
+	case models.QuestionScale:
+		if q.ScaleMin == nil {
+			lo := defaultScaleMin
+			q.ScaleMin = &lo
+		}
+		if q.ScaleMax == nil {
+			hi := defaultScaleMax
+			q.ScaleMax = &hi
+		}
+		if *q.ScaleMin < 0 || *q.ScaleMin > 1 || *q.ScaleMax <= *q.ScaleMin || *q.ScaleMax > maxScaleValue {
+			return fmt.Errorf("%w: question %d scale must start at 0 or 1 and end above it, at most %d", ErrInvalidSurveyRequest, position, maxScaleValue)
+		}
+		q.Options = nil
+	default:
+		q.Options, q.ScaleMin, q.ScaleMax = nil, nil, nil
+	}
+	return nil
+}
+
+// validateSurveyAnswers - Kiểm tra phiếu theo bộ câu hỏi; câu bỏ trống được bỏ qua trừ khi bắt buộc
+func validateSurveyAnswers(questions []models.SurveyQuestion, answers []models.SurveyAnswer) ([]models.SurveyAnswer, error) {
+	byID := make(map[int]models.SurveyQuestion, len(questions))
+	for _, q := range questions {
+		byID[q.QuestionID] = q
+	}
+
+	result := make([]models.SurveyAnswer, 0, len(answers))
+	answered := map[int]bool{}
+	for _, a := range answers {
+		q, ok := byID[a.QuestionID]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown question %d", ErrInvalidSurveyRequest, a.QuestionID)
+		}
+		if answered[a.QuestionID] {
+			return nil, fmt.Errorf("%w: question %d answered more than once", ErrInvalidSurveyRequest, a.QuestionID)
+		}
+		clean := models.SurveyAnswer{QuestionID: q.QuestionID}
+
+		switch q.Type {
+		case models.QuestionSingleChoice, models.QuestionMultiChoice:
+			if len(a.Choices) == 0 {
+				continue
+			}
+			if q.Type == models.QuestionSingleChoice && len(a.Choices) != 1 {
+				return nil, fmt.Errorf("%w: question %d accepts exactly one choice", ErrInvalidSurveyRequest, q.QuestionID)
+			}
+			for _, c := range a.Choices {
+				if !slices.Contains(q.Options, c) || slices.Contains(clean.Choices, c) {
+					return nil, fmt.Errorf("%w: invalid choice %q for question %d", ErrInvalidSurveyRequest, c, q.QuestionID)
+				}
+				clean.Choices = append(clean.Choices, c)
+			}
+		case models.QuestionText:
+			text := trimmedOrNil(a.Text)
+			if text == nil {
+				continue
+			}
+			if len([]rune(*text)) > models.MaxSurveyTextAnswerLen {
+				return nil, fmt.Errorf("%w: answer to question %d exceeds %d characters", ErrInvalidSurveyRequest, q.QuestionID, models.MaxSurveyTextAnswerLen)
+			}
+			clean.Text = text
+		case models.QuestionScale:
+			if a.Scale == nil {
+				continue
+			}
+			if *a.Scale < *q.ScaleMin || *a.Scale > *q.ScaleMax {
+				return nil, fmt.Errorf("%w: question %d score must be between %d and %d", ErrInvalidSurveyRequest, q.QuestionID, *q.ScaleMin, *q.ScaleMax)
+			}
+			score := *a.Scale
+			clean.Scale = &score
+		}
+		answered[q.QuestionID] = true
+		result = append(result, clean)
+	}
+
+	for _, q := range questions {
+		if q.Required && !answered[q.QuestionID] {
+			return nil, fmt.Errorf("%w: question %d is required", ErrInvalidSurveyRequest, q.QuestionID)
+		}
+	}
+	if len(result) == 0 {
+		return nil, fmt.Errorf("%w: answer at least one question", ErrInvalidSurveyRequest)
+	}
+	return result, nil
+}
+
+// buildSurveyResults - Tổng hợp phiếu theo câu hỏi: đếm lựa chọn, phân bố + điểm trung bình, danh sách câu tự luận
+func buildSurveyResults(survey *models.EventSurvey, records []models.SurveyResponseRecord) *models.SurveyResults {
+	results := &models.SurveyResults{
+		SurveyID:      survey.SurveyID,
+		EventID:       survey.EventID,
+		Title:         survey.Title,
+		Status:        survey.Status,
+		ResponseCount: len(records),
+		Questions:     make([]models.SurveyQuestionResult, 0, len(survey.Questions)),
+	}
+	for _, q := range survey.Questions {
+		qr := models.SurveyQuestionResult{QuestionID: q.QuestionID, Type: q.Type, Prompt: q.Prompt}
+		counts := map[string]int{}
+		sum := 0
+		for _, rec := range records {
+			a, ok := rec.Answers[q.QuestionID]
+			if !ok {
+				continue
+			}
+			qr.AnswerCount++
+			switch q.Type {
+			case models.QuestionSingleChoice, models.QuestionMultiChoice:
+				for _, c := range a.Choices {
+					counts[c]++
+				}
+			case models.QuestionScale:
+				if a.Scale != nil {
+					counts[strconv.Itoa(*a.Scale)]++
+					sum += *a.Scale
+				}
+			case models.QuestionText:
+				if a.Text != nil {
+					qr.TextAnswers = append(qr.TextAnswers, *a.Text)
+				}
+			}
+		}
+
+		switch q.Type {
+		case models.QuestionSingleChoice, models.QuestionMultiChoice:
+			for _, o := range q.Options {
+				qr.OptionCounts = append(qr.OptionCounts, models.SurveyCount{Value: o, Count: counts[o]})
+			}
+		case models.QuestionScale:
+			for v := *q.ScaleMin; v <= *q.ScaleMax; v++ {
+				qr.ScaleCounts = append(qr.ScaleCounts, models.SurveyCount{Value: strconv.Itoa(v), Count: counts[strconv.Itoa(v)]})
+			}
+			if qr.AnswerCount > 0 {
+				avg := math.Round(float64(sum)/float64(qr.AnswerCount)*100) / 100
+				qr.Average = &avg
+			}
+		}
+		results.Questions = append(results.Questions, qr)
+	}
+	return results
+}
+
+// surveyResponsesCSV - CSV UTF-8 (có BOM để Excel đọc đúng tiếng Việt)
+// Cột: Response ID, Submitted At, rồi mỗi câu hỏi một cột; câu nhiều lựa chọn nối bằng "; "
+func surveyResponsesCSV(survey *models.EventSurvey, records []models.SurveyResponseRecord) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString("\ufeff")
+	w := csv.NewWriter(&buf)
+
+	header := []string{"Response ID", "Submitted At"}
+	for _, q := range survey.Questions {
+		header = append(header, csvutil.Safe(q.Prompt))
+	}
+	if err := w.Write(header); err != nil {
+		return nil, err
+	}
+	for _, rec := range records {
+		row := []string{strconv.Itoa(rec.ResponseID), rec.SubmittedAt.Format(time.RFC3339)}
+		for _, q := range survey.Questions {
+			a := rec.Answers[q.QuestionID]
+			var cell string
+			switch {
+			case len(a.Choices) > 0:
+				cell = strings.Join(a.Choices, "; ")
+			case a.Text != nil:
+				cell = *a.Text
+			case a.Scale != nil:
+				cell = strconv.Itoa(*a.Scale)
+			}
+			row = append(row, csvutil.Safe(cell))
+		}
+		if err := w.Write(row); err != nil {
+			return nil, err
+		}
+	}
+	w.Flush()
+	return buf.Bytes(), w.Error()
+}
@@ -0,0 +1,152 @@
+package usecase
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fpt-event-services/services/event-lambda/models"
+)
+
+func TestNormalizeSurvey(t *testing.T) {
+	req := &models.SaveSurveyRequest{
+		Title: " Feedback ",
+		Questions: []models.SurveyQuestion{
+			{Type: "single_choice", Prompt: " Hài lòng? ", Options: []string{" Có ", "Không"}},
+			{Type: models.QuestionScale, Prompt: "Rate the speaker", Options: []string{"ignored"}},
+			{Type: models.QuestionText, Prompt: "Comments"},
+		},
+	}
+	if err := normalizeSurvey(req); err != nil {
+		t.Fatal(err)
+	}
+	if req.Status != models.SurveyDraft || req.Title != "Feedback" {
+		t.Errorf("unexpected survey: %+v", req)
+	}
+	q := req.Questions
+	if q[0].Type != models.QuestionSingleChoice || q[0].Options[0] != "Có" || q[0].Position != 1 {
+		t.Errorf("unexpected choice question: %+v", q[0])
+	}
+	if *q[1].ScaleMin != 1 || *q[1].ScaleMax != 5 || q[1].Options != nil {
+		t.Errorf("unexpected scale question: %+v", q[1])
+	}
+
+	lo, hi := 2, 20
+	cases := map[string]models.SaveSurveyRequest{
+		"empty title":       {Title: " "},
+		"unknown status":    {Title: "x", Status: "ARCHIVED"},
+		"unknown type":      {Title: "x", Questions: []models.SurveyQuestion{{Type: "RANKING", Prompt: "?"}}},
+		"empty prompt":      {Title: "x", Questions: []models.SurveyQuestion{{Type: models.QuestionText}}},
+		"one option":        {Title: "x", Questions: []models.SurveyQuestion{{Type: models.QuestionMultiChoice, Prompt: "?", Options: []string{"A"}}}},
+		"duplicate options": {Title: "x", Questions: []models.SurveyQuestion{{Type: models.QuestionMultiChoice, Prompt: "?", Options: []string{"A", "A "}}}},
+		"bad scale":         {Title: "x", Questions: []models.SurveyQuestion{{Type: models.QuestionScale, Prompt: "?", ScaleMin: &lo, ScaleMax: &hi}}},
+	}
+	for name, c := range cases {
+		if err := normalizeSurvey(&c); !errors.Is(err, ErrInvalidSurveyRequest) {
+			t.Errorf("%s: err = %v, want ErrInvalidSurveyRequest", name, err)
+		}
+	}
+}
+
+func testSurvey() *models.EventSurvey {
+	lo, hi := 1, 5
+	return &models.EventSurvey{
+		SurveyID: 1, EventID: 7, Title: "Feedback", Status: models.SurveyOpen,
+		Questions: []models.SurveyQuestion{
+			{QuestionID: 10, Type: models.QuestionSingleChoice, Prompt: "Format", Required: true, Options: []string{"Online", "Offline"}},
+			{QuestionID: 11, Type: models.QuestionMultiChoice, Prompt: "Topics", Options: []string{"Go", "AWS", "AI"}},
+			{QuestionID: 12, Type: models.QuestionText, Prompt: "Comments"},
+			{QuestionID: 13, Type: models.QuestionScale, Prompt: "Rating", ScaleMin: &lo, ScaleMax: &hi},
+		},
+	}
+}
+
+func TestValidateSurveyAnswers(t *testing.T) {
+	questions := testSurvey().Questions
+	text, blank, four := "  Great  ", " ", 4
+	answers, err := validateSurveyAnswers(questions, []models.SurveyAnswer{
+		{QuestionID: 10, Choices: []string{"Offline"}},
+		{QuestionID: 12, Text: &text},
+		{QuestionID: 13, Scale: &four},
+		{QuestionID: 11},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(answers) != 3 || *answers[1].Text != "Great" {
+		t.Errorf("unexpected answers: %+v", answers)
+	}
+
+	six := 6
+	cases := map[string][]models.SurveyAnswer{
+		"missing required":   {{QuestionID: 12, Text: &blank}},
+		"unknown question":   {{QuestionID: 10, Choices: []string{"Online"}}, {QuestionID: 99, Text: &text}},
+		"duplicate answer":   {{QuestionID: 10, Choices: []string{"Online"}}, {QuestionID: 10, Choices: []string{"Offline"}}},
+		"two single picks":   {{QuestionID: 10, Choices: []string{"Online", "Offline"}}},
+		"unknown option":     {{QuestionID: 10, Choices: []string{"Hybrid"}}},
+		"repeated multi":     {{QuestionID: 10, Choices: []string{"Online"}}, {QuestionID: 11, Choices: []string{"Go", "Go"}}},
+		"scale out of range": {{QuestionID: 10, Choices: []string{"Online"}}, {QuestionID: 13, Scale: &six}},
+	}
+	for name, c := range cases {
+		if _, err := validateSurveyAnswers(questions, c); !errors.Is(err, ErrInvalidSurveyRequest) {
+			t.Errorf("%s: err = %v, want ErrInvalidSurveyRequest", name, err)
+		}
+	}
+}
+
+func testSurveyRecords() []models.SurveyResponseRecord {
+	text, formula, three, five := "Nice", "=HYPERLINK(\"x\")", 3, 5
+	at := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	return []models.SurveyResponseRecord{
+		{ResponseID: 1, SubmittedAt: at, Answers: map[int]models.SurveyAnswer{
+			10: {Choices: []string{"Offline"}}, 11: {Choices: []string{"Go", "AI"}}, 12: {Text: &text}, 13: {Scale: &three},
+		}},
+		{ResponseID: 2, SubmittedAt: at.Add(time.Hour), Answers: map[int]models.SurveyAnswer{
+			10: {Choices: []string{"Offline"}}, 12: {Text: &formula}, 13: {Scale: &five},
+		}},
+	}
+}
+
+func TestBuildSurveyResults(t *testing.T) {
+	res := buildSurveyResults(testSurvey(), testSurveyRecords())
+	if res.ResponseCount != 2 || len(res.Questions) != 4 {
+		t.Fatalf("unexpected results: %+v", res)
+	}
+	single := res.Questions[0]
+	if single.AnswerCount != 2 || single.OptionCounts[0].Count != 0 || single.OptionCounts[1].Count != 2 {
+		t.Errorf("unexpected single choice counts: %+v", single.OptionCounts)
+	}
+	multi := res.Questions[1]
+	if multi.AnswerCount != 1 || multi.OptionCounts[0].Count != 1 || multi.OptionCounts[1].Count != 0 || multi.OptionCounts[2].Count != 1 {
+		t.Errorf("unexpected multi choice counts: %+v", multi.OptionCounts)
+	}
+	if len(res.Questions[2].TextAnswers) != 2 {
+		t.Errorf("unexpected text answers: %+v", res.Questions[2].TextAnswers)
+	}
+	scale := res.Questions[3]
+	if len(scale.ScaleCounts) != 5 || scale.ScaleCounts[2].Count != 1 || scale.ScaleCounts[4].Count != 1 || *scale.Average != 4 {
+		t.Errorf("unexpected scale result: %+v avg=%v", scale.ScaleCounts, scale.Average)
+	}
+}
+
+func TestSurveyResponsesCSV(t *testing.T) {
+	data, err := surveyResponsesCSV(testSurvey(), testSurveyRecords())
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(strings.TrimPrefix(string(data), "\ufeff")), "\n")
+	want := []string{
+		"Response ID,Submitted At,Format,Topics,Comments,Rating",
+		"1,2026-03-01T10:00:00Z,Offline,Go; AI,Nice,3",
+		`2,2026-03-01T11:00:00Z,Offline,,"'=HYPERLINK(""x"")",5`,
+	}
+	if len(lines) != len(want) {
+		t.Fatalf("got %d lines, want %d:\n%s", len(lines), len(want), data)
+	}
+	for i := range want {
+		if lines[i] != want[i] {
+			t.Errorf("line %d = %q, want %q", i, lines[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/fpt-event-services/common/pdf"
+	"github.com/fpt-event-services/services/event-lambda/models"
+)
+
+// ============================================================
+// Ticket template - Tùy biến vé PDF theo sự kiện
+// ============================================================
+
+// GetTicketTemplate - Cấu hình vé hiện tại (mặc định classic/A4 nếu chưa cấu hình)
+func (uc *EventUseCase) GetTicketTemplate(ctx context.Context, eventID, userID int, role string) (*models.EventTicketTemplate, error) {
+	tpl, err := uc.eventRepo.GetTicketTemplate(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.requireEditDetails(ctx, eventID, userID, role); err != nil {
+		return nil, err
+	}
+	return tpl, nil
+}
+
+// UpdateTicketTemplate - Validate rồi lưu cấu hình vé
+// URL logo phải là http/https tới địa chỉ public (ticket-lambda tải ảnh khi tạo PDF)
+func (uc *EventUseCase) UpdateTicketTemplate(ctx context.Context, eventID, userID int, role string, req *models.EventTicketTemplate) (*models.EventTicketTemplate, error) {
+	if _, err := uc.eventRepo.GetTicketTemplate(ctx, eventID); err != nil {
+		return nil, err
+	}
+	if err := uc.requireEditDetails(ctx, eventID, userID, role); err != nil {
+		return nil, err
+	}
+
+	req.EventID = eventID
+	req.AccentColor = strings.TrimSpace(req.AccentColor)
+	req.OrganizerLogoURL = strings.TrimSpace(req.OrganizerLogoURL)
+	req.FooterNote = strings.TrimSpace(req.FooterNote)
+	if req.PageSize == "" {
+		req.PageSize = pdf.PageA4
+	}
+	if err := (pdf.TicketTemplate{
+		Layout:      req.Layout,
+		PageSize:    req.PageSize,
+		AccentColor: req.AccentColor,
+		FooterNote:  req.FooterNote,
+	}).Validate(); err != nil {
+		return nil, err
+	}
+
+	if len(req.SponsorLogoURLs) > pdf.MaxSponsorLogos {
+		return nil, fmt.Errorf("%w: at most %d sponsor logos", pdf.ErrInvalidTemplate, pdf.MaxSponsorLogos)
+	}
+	sponsors := make([]string, 0, len(req.SponsorLogoURLs))
+	for _, raw := range req.SponsorLogoURLs {
+		if raw = strings.TrimSpace(raw); raw != "" {
+			sponsors = append(sponsors, raw)
+		}
+	}
+	req.SponsorLogoURLs = sponsors
+	for _, raw := range append([]string{req.OrganizerLogoURL}, sponsors...) {
+		if raw == "" {
+			continue
+		}
+		if err := validateTemplateImageURL(raw); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := uc.eventRepo.UpsertTicketTemplate(ctx, req, userID); err != nil {
+		return nil, err
+	}
+	return uc.eventRepo.GetTicketTemplate(ctx, eventID)
+}
+
+// validateTemplateImageURL - Cùng quy tắc chống SSRF với sourceUrl của banner
+func validateTemplateImageURL(raw string) error {
+	if len(raw) > 500 {
+		return fmt.Errorf("%w: logo URL is too long", pdf.ErrInvalidTemplate)
+	}
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		return fmt.Errorf("%w: invalid logo URL %q", pdf.ErrInvalidTemplate, raw)
+	}
+	if err := validateBannerSourceURL(parsed); err != nil {
+		return fmt.Errorf("%w: %s (%s)", pdf.ErrInvalidTemplate, err.Error(), raw)
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/fpt-event-services/common/eventbus"
+	"github.com/fpt-event-services/services/event-lambda/repository"
+)
+
+// ============================================================
+// registerVenueRelease - Giải phóng khu vực ngay khi sự kiện rời OPEN/UPDATING
+// (hủy, đóng, vô hiệu hóa) thay vì chờ job venue-release 5 phút
+// Job venue-release và startup janitor vẫn chạy để đối soát khu vực bị bỏ sót
+// ============================================================
+func registerVenueRelease(bus *eventbus.Bus) {
+	repo := repository.DefaultEventRepository()
+	release := func(ctx context.Context, eventID int, trigger string) error {
+		areaID, released, err := repo.ReleaseAreaOnEventClose(ctx, eventID)
+		if err != nil {
+			return fmt.Errorf("release area of event %d: %w", eventID, err)
+		}
+		if released {
+			log.Printf("[VENUE_RELEASE] Area #%d released (path=%s, trigger=%s, event=#%d)",
+				areaID, repository.ReleasePathEventBus, trigger, eventID)
+		}
+		return nil
+	}
+
+	eventbus.On(bus, "venue-release", eventbus.Async, func(ctx context.Context, e eventbus.EventCancelled) error {
+		return release(ctx, e.EventID, e.EventName())
+	})
+	eventbus.On(bus, "venue-release", eventbus.Async, func(ctx context.Context, e eventbus.EventClosed) error {
+		return release(ctx, e.EventID, e.EventName()+":"+e.Reason)
+	})
+	eventbus.On(bus, "venue-release", eventbus.Async, func(ctx context.Context, e eventbus.EventDisabled) error {
+		return release(ctx, e.EventID, e.EventName())
+	})
+}
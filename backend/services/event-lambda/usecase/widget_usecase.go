@@ -0,0 +1,175 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/fpt-event-services/services/event-lambda/models"
+)
+
+// ============================================================
+// Widget nhúng sự kiện - Organizer tạo API key kèm danh sách origin
+// Website câu lạc bộ gọi GET /api/widget/events?key=...; CORS chỉ mở cho origin của key
+// ============================================================
+
+// Lỗi của widget ở tầng usecase
+var (
+	ErrInvalidWidgetKeyRequest = errors.New("invalid widget API key request")
+	ErrInvalidWidgetKey        = errors.New("invalid or revoked widget API key")
+	ErrWidgetForbidden         = errors.New("this API key cannot read events of that organizer")
+)
+
+const (
+	widgetKeyPrefix    = "wk_"
+	widgetKeyPrefixLen = 10
+	// widgetEventLimit - Số sự kiện tối đa trả cho widget
+	widgetEventLimit = 20
+)
+
+// CreateWidgetAPIKey - Tạo key mới; key gốc chỉ trả về trong phản hồi này
+func (uc *EventUseCase) CreateWidgetAPIKey(ctx context.Context, organizerID int, req *models.WidgetAPIKeyRequest) (*models.CreatedWidgetAPIKey, error) {
+	name, origins, err := normalizeWidgetKeyRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	raw := make([]byte, 24)
+	if _, err := rand.Read(raw); err != nil {
+		return nil, fmt.Errorf("failed to generate widget key: %w", err)
+	}
+	apiKey := widgetKeyPrefix + base64.RawURLEncoding.EncodeToString(raw)
+
+	keyID, err := uc.eventRepo.CreateWidgetAPIKey(ctx, organizerID, name, apiKey[:widgetKeyPrefixLen], hashWidgetKey(apiKey), origins)
+	if err != nil {
+		return nil, err
+	}
+	key, err := uc.eventRepo.GetWidgetAPIKey(ctx, organizerID, keyID)
+	if err != nil {
+		return nil, err
+	}
+	return &models.CreatedWidgetAPIKey{WidgetAPIKey: *key, APIKey: apiKey}, nil
+}
+
+// ListWidgetAPIKeys - Key của organizer (không có key gốc)
+func (uc *EventUseCase) ListWidgetAPIKeys(ctx context.Context, organizerID int) ([]models.WidgetAPIKey, error) {
+	return uc.eventRepo.ListWidgetAPIKeys(ctx, organizerID)
+}
+
+// UpdateWidgetAPIKey - Đổi tên / origin của key
+func (uc *EventUseCase) UpdateWidgetAPIKey(ctx context.Context, organizerID, keyID int, req *models.WidgetAPIKeyRequest) (*models.WidgetAPIKey, error) {
+	name, origins, err := normalizeWidgetKeyRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.eventRepo.UpdateWidgetAPIKey(ctx, organizerID, keyID, name, origins); err != nil {
+		return nil, err
+	}
+	return uc.eventRepo.GetWidgetAPIKey(ctx, organizerID, keyID)
+}
+
+// RevokeWidgetAPIKey - Thu hồi key; widget dùng key này bị từ chối ngay
+func (uc *EventUseCase) RevokeWidgetAPIKey(ctx context.Context, organizerID, keyID int) error {
+	return uc.eventRepo.RevokeWidgetAPIKey(ctx, organizerID, keyID)
+}
+
+// ResolveWidgetKey - Key ACTIVE theo key gốc
+func (uc *EventUseCase) ResolveWidgetKey(ctx context.Context, apiKey string) (*models.WidgetKeyOwner, error) {
+	if !strings.HasPrefix(apiKey, widgetKeyPrefix) || len(apiKey) > 100 {
+		return nil, ErrInvalidWidgetKey
+	}
+	owner, err := uc.eventRepo.FindActiveWidgetKey(ctx, hashWidgetKey(apiKey))
+	if err != nil {
+		return nil, err
+	}
+	if owner == nil {
+		return nil, ErrInvalidWidgetKey
+	}
+	return owner, nil
+}
+
+// WidgetOriginAllowed - Origin của trình duyệt có trong danh sách của key không
+func WidgetOriginAllowed(owner *models.WidgetKeyOwner, origin string) bool {
+	o, err := normalizeOrigin(origin)
+	if err != nil {
+		return false
+	}
+	for _, allowed := range owner.AllowedOrigins {
+		if allowed == o {
+			return true
+		}
+	}
+	return false
+}
+
+// GetWidgetEvents - Sự kiện sắp diễn ra của organizer sở hữu key
+// organizerID = 0 nghĩa là lấy theo key; khác organizer của key thì ErrWidgetForbidden
+func (uc *EventUseCase) GetWidgetEvents(ctx context.Context, owner *models.WidgetKeyOwner, organizerID int) ([]models.WidgetEvent, error) {
+	if organizerID != 0 && organizerID != owner.OrganizerID {
+		return nil, ErrWidgetForbidden
+	}
+	rows, err := uc.eventRepo.ListWidgetEvents(ctx, owner.OrganizerID, widgetEventLimit)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]models.WidgetEvent, 0, len(rows))
+	for _, row := range rows {
+		it := row.WidgetEvent
+		if row.Slug != nil {
+			link := PublicEventURL(*row.Slug)
+			it.URL = &link
+		}
+		remaining, total, err := uc.eventRepo.EventTicketsRemaining(ctx, it.EventID)
+		if err != nil {
+			return nil, err
+		}
+		it.Availability = availabilityBucket("OPEN", remaining, total)
+		items = append(items, it)
+	}
+	return items, nil
+}
+
+func hashWidgetKey(apiKey string) string {
+	sum := sha256.Sum256([]byte(apiKey))
+	return hex.EncodeToString(sum[:])
+}
+
+func normalizeWidgetKeyRequest(req *models.WidgetAPIKeyRequest) (string, []string, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" || len([]rune(name)) > 100 {
+		return "", nil, fmt.Errorf("%w: name is required (max 100 characters)", ErrInvalidWidgetKeyRequest)
+	}
+	if len(req.AllowedOrigins) == 0 || len(req.AllowedOrigins) > models.MaxWidgetOrigins {
+		return "", nil, fmt.Errorf("%w: allowedOrigins must contain 1 to %d origins", ErrInvalidWidgetKeyRequest, models.MaxWidgetOrigins)
+	}
+	seen := map[string]bool{}
+	origins := make([]string, 0, len(req.AllowedOrigins))
+	for _, raw := range req.AllowedOrigins {
+		o, err := normalizeOrigin(raw)
+		if err != nil {
+			return "", nil, err
+		}
+		if !seen[o] {
+			seen[o] = true
+			origins = append(origins, o)
+		}
+	}
+	return name, origins, nil
+}
+
+// normalizeOrigin - "https://Club.FPT.edu.vn/" => "https://club.fpt.edu.vn"
+// Chỉ scheme + host (+ port); không nhận "*", path, query
+func normalizeOrigin(raw string) (string, error) {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" ||
+		u.User != nil || (u.Path != "" && u.Path != "/") || u.RawQuery != "" || u.Fragment != "" ||
+		strings.Contains(u.Host, "*") {
+		return "", fmt.Errorf("%w: %q is not a valid origin (e.g. https://club.example.com)", ErrInvalidWidgetKeyRequest, raw)
+	}
+	return u.Scheme + "://" + strings.ToLower(u.Host), nil
+}
@@ -0,0 +1,57 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/fpt-event-services/services/event-lambda/models"
+)
+
+func TestNormalizeOrigin(t *testing.T) {
+	valid := map[string]string{
+		"https://Club.FPT.edu.vn/":   "https://club.fpt.edu.vn",
+		"http://localhost:5173":      "http://localhost:5173",
+		" https://gdsc.example.com ": "https://gdsc.example.com",
+	}
+	for in, want := range valid {
+		got, err := normalizeOrigin(in)
+		if err != nil || got != want {
+			t.Errorf("normalizeOrigin(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+	for _, in := range []string{"*", "club.fpt.edu.vn", "ftp://club.fpt.edu.vn", "https://club.fpt.edu.vn/events",
+		"https://*.fpt.edu.vn", "https://user@club.fpt.edu.vn", "https://club.fpt.edu.vn?x=1"} {
+		if _, err := normalizeOrigin(in); !errors.Is(err, ErrInvalidWidgetKeyRequest) {
+			t.Errorf("normalizeOrigin(%q) accepted", in)
+		}
+	}
+}
+
+func TestNormalizeWidgetKeyRequest(t *testing.T) {
+	name, origins, err := normalizeWidgetKeyRequest(&models.WidgetAPIKeyRequest{
+		Name:           "  Website CLB  ",
+		AllowedOrigins: []string{"https://clb.example.com", "https://CLB.example.com/"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if name != "Website CLB" || len(origins) != 1 {
+		t.Fatalf("got %q %v", name, origins)
+	}
+
+	if _, _, err := normalizeWidgetKeyRequest(&models.WidgetAPIKeyRequest{Name: "x"}); !errors.Is(err, ErrInvalidWidgetKeyRequest) {
+		t.Fatalf("empty origins accepted")
+	}
+}
+
+func TestWidgetOriginAllowed(t *testing.T) {
+	owner := &models.WidgetKeyOwner{AllowedOrigins: []string{"https://clb.example.com"}}
+	if !WidgetOriginAllowed(owner, "https://CLB.example.com") {
+		t.Fatal("allowed origin rejected")
+	}
+	for _, o := range []string{"https://evil.example.com", "http://clb.example.com", "null", ""} {
+		if WidgetOriginAllowed(owner, o) {
+			t.Errorf("origin %q must be rejected", o)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/fpt-event-services/common/authctx"
+	"github.com/fpt-event-services/common/graphql"
+	"github.com/fpt-event-services/services/graphql-lambda/resolver"
+)
+
+// GraphQLHandler handles GraphQL gateway requests
+type GraphQLHandler struct {
+	schema *graphql.Schema
+}
+
+// NewGraphQLHandler creates a new GraphQL handler
+func NewGraphQLHandler() *GraphQLHandler {
+	return &GraphQLHandler{
+		schema: resolver.NewResolver().Schema(),
+	}
+}
+
+// ============================================================
+// HandleGraphQL - POST /graphql (hoặc GET /graphql?query=...)
+// Body: {"query": "...", "operationName": "...", "variables": {...}}
+// Lỗi cú pháp/giới hạn trả 400; lỗi của từng field nằm trong "errors" với status 200
+// ============================================================
+func (h *GraphQLHandler) HandleGraphQL(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	var req graphql.Request
+	if request.HTTPMethod == http.MethodGet {
+		req.Query = request.QueryStringParameters["query"]
+		req.OperationName = request.QueryStringParameters["operationName"]
+		if vars := request.QueryStringParameters["variables"]; vars != "" {
+			if err := json.Unmarshal([]byte(vars), &req.Variables); err != nil {
+				return createJSONResponse(http.StatusBadRequest, &graphql.Response{Errors: []graphql.Error{{Message: "Invalid variables"}}})
+			}
+		}
+	} else if err := json.Unmarshal([]byte(request.Body), &req); err != nil {
+		return createJSONResponse(http.StatusBadRequest, &graphql.Response{Errors: []graphql.Error{{Message: "Invalid request body"}}})
+	}
+	if req.Query == "" {
+		return createJSONResponse(http.StatusBadRequest, &graphql.Response{Errors: []graphql.Error{{Message: "Missing query"}}})
+	}
+
+	userID, _ := authctx.UserID(ctx)
+	ctx = resolver.WithViewer(ctx, resolver.Viewer{UserID: userID, Role: authctx.Role(ctx)})
+
+	resp := h.schema.Execute(ctx, req)
+	if resp.Data == nil {
+		return createJSONResponse(http.StatusBadRequest, resp)
+	}
+	return createJSONResponse(http.StatusOK, resp)
+}
+
+func createJSONResponse(statusCode int, data interface{}) (events.APIGatewayProxyResponse, error) {
+	body, err := json.Marshal(data)
+	if err != nil {
+		return events.APIGatewayProxyResponse{
+			StatusCode: http.StatusInternalServerError,
+			Headers:    defaultHeaders(),
+			Body:       `{"errors":[{"message":"Failed to serialize response"}]}`,
+		}, nil
+	}
+
+	return events.APIGatewayProxyResponse{
+		StatusCode: statusCode,
+		Headers:    defaultHeaders(),
+		Body:       string(body),
+	}, nil
+}
+
+func defaultHeaders() map[string]string {
+	return map[string]string{
+		"Content-Type": "application/json;charset=UTF-8",
+	}
+}
@@ -0,0 +1,250 @@
+package resolver
+
+import (
+	"context"
+	"errors"
+
+	"github.com/fpt-event-services/common/campus"
+	"github.com/fpt-event-services/common/graphql"
+	"github.com/fpt-event-services/common/permission"
+	eventModels "github.com/fpt-event-services/services/event-lambda/models"
+	eventUsecase "github.com/fpt-event-services/services/event-lambda/usecase"
+	ticketModels "github.com/fpt-event-services/services/ticket-lambda/models"
+	ticketUsecase "github.com/fpt-event-services/services/ticket-lambda/usecase"
+	venueModels "github.com/fpt-event-services/services/venue-lambda/models"
+	venueUsecase "github.com/fpt-event-services/services/venue-lambda/usecase"
+)
+
+var (
+	errUnauthenticated = errors.New("authentication required")
+	errForbidden       = errors.New("you do not have permission to access this field")
+)
+
+// Viewer - Người dùng đang gọi API (lấy từ JWT qua authMiddleware)
+type Viewer struct {
+	UserID int    `json:"userId"`
+	Role   string `json:"role"`
+}
+
+type viewerKey struct{}
+
+// WithViewer gắn thông tin người dùng vào context cho resolver
+func WithViewer(ctx context.Context, v Viewer) context.Context {
+	return context.WithValue(ctx, viewerKey{}, v)
+}
+
+func viewerFrom(ctx context.Context) (Viewer, bool) {
+	v, ok := ctx.Value(viewerKey{}).(Viewer)
+	return v, ok && v.UserID > 0
+}
+
+// Resolver gọi lại các usecase sẵn có, kiểm tra quyền giống handler REST tương ứng
+type Resolver struct {
+	eventUC  *eventUsecase.EventUseCase
+	ticketUC *ticketUsecase.TicketUseCase
+	venueUC  *venueUsecase.VenueUseCase
+}
+
+// NewResolver creates a new resolver
+func NewResolver() *Resolver {
+	return &Resolver{
+		eventUC:  eventUsecase.NewEventUseCase(),
+		ticketUC: ticketUsecase.NewTicketUseCase(),
+		venueUC:  venueUsecase.NewVenueUseCase(),
+	}
+}
+
+// ============================================================
+// Schema - Query gồm: me, events(status, campusId), event(id), myTickets, venues(campusId),
+// venue(id), myEventRequests, pendingEventRequests, eventStats(eventId).
+// Field của từng object sinh từ json tag của model tương ứng
+// ============================================================
+func (r *Resolver) Schema() *graphql.Schema {
+	viewer := graphql.NewObject("Viewer", Viewer{}, nil)
+	event := graphql.NewObject("Event", eventModels.EventListItem{}, nil)
+	eventDetail := graphql.NewObject("EventDetail", eventModels.EventDetailDto{}, nil)
+	ticket := graphql.NewObject("Ticket", ticketModels.MyTicketResponse{}, nil)
+	eventRequest := graphql.NewObject("EventRequest", eventModels.EventRequest{}, nil)
+	stats := graphql.NewObject("EventStats", eventModels.EventStatsResponse{}, nil)
+	area := graphql.NewObject("VenueArea", venueModels.VenueArea{}, nil)
+	venue := graphql.NewObject("Venue", venueModels.Venue{}, map[string]*graphql.Field{
+		"areas": {Type: area, List: true, Resolve: r.venueAreas},
+	})
+
+	query := graphql.NewObject("Query", nil, map[string]*graphql.Field{
+		"me":                   {Type: viewer, Resolve: r.me},
+		"events":               {Type: event, List: true, Args: []string{"status", "campusId"}, Resolve: r.events},
+		"event":                {Type: eventDetail, Args: []string{"id"}, Resolve: r.event},
+		"myTickets":            {Type: ticket, List: true, Resolve: r.myTickets},
+		"venues":               {Type: venue, List: true, Args: []string{"campusId"}, Resolve: r.venues},
+		"venue":                {Type: venue, Args: []string{"id"}, Resolve: r.venue},
+		"myEventRequests":      {Type: eventRequest, List: true, Resolve: r.myEventRequests},
+		"pendingEventRequests": {Type: eventRequest, List: true, Resolve: r.pendingEventRequests},
+		"eventStats":           {Type: stats, Args: []string{"eventId"}, Resolve: r.eventStats},
+	})
+	return graphql.NewSchema(query)
+}
+
+func (r *Resolver) me(p graphql.ResolveParams) (interface{}, error) {
+	v, ok := viewerFrom(p.Context)
+	if !ok {
+		return nil, nil
+	}
+	return v, nil
+}
+
+// events - Giống GET /api/events: OPEN + CLOSED theo quyền của role
+func (r *Resolver) events(p graphql.ResolveParams) (interface{}, error) {
+	v, _ := viewerFrom(p.Context)
+	role := v.Role
+	if role == "" {
+		role = "PUBLIC"
+	}
+	campusID, err := campusArg(p)
+	if err != nil {
+		return nil, err
+	}
+	open, closed, err := r.eventUC.GetAllEventsSeparated(p.Context, role, v.UserID, campusID)
+	if err != nil {
+		return nil, err
+	}
+	switch status, _ := p.String("status"); status {
+	case "OPEN":
+		return open, nil
+	case "CLOSED":
+		return closed, nil
+	case "":
+		return append(append([]eventModels.EventListItem{}, open...), closed...), nil
+	default:
+		return nil, errors.New("status must be OPEN or CLOSED")
+	}
+}
+
+func (r *Resolver) event(p graphql.ResolveParams) (interface{}, error) {
+	id, ok := p.Int("id")
+	if !ok {
+		return nil, errors.New("argument id is required")
+	}
+	detail, err := r.eventUC.GetEventDetail(p.Context, id)
+	if err != nil || detail == nil {
+		return nil, err
+	}
+	// Cùng quy tắc với GET /api/events/detail: người ngoài chỉ thấy bản public
+	v, _ := viewerFrom(p.Context)
+	full := false
+	if v.UserID > 0 {
+		if full, err = r.eventUC.CanViewFullEventDetail(p.Context, id, v.UserID, v.Role); err != nil {
+			return nil, err
+		}
+	}
+	if !full {
+		return detail.Public(), nil
+	}
+	return detail, nil
+}
+
+func (r *Resolver) myTickets(p graphql.ResolveParams) (interface{}, error) {
+	v, ok := viewerFrom(p.Context)
+	if !ok {
+		return nil, errUnauthenticated
+	}
+	return r.ticketUC.GetMyTickets(p.Context, v.UserID)
+}
+
+func (r *Resolver) venues(p graphql.ResolveParams) (interface{}, error) {
+	campusID, err := campusArg(p)
+	if err != nil {
+		return nil, err
+	}
+	return r.venueUC.GetAllVenues(p.Context, campusID)
+}
+
+// campusArg - Đối số campusId, áp phạm vi campus giống ?campusId= của REST
+func campusArg(p graphql.ResolveParams) (*int, error) {
+	var requested *int
+	if id, ok := p.Int("campusId"); ok {
+		if id <= 0 {
+			return nil, campus.ErrInvalidCampus
+		}
+		requested = &id
+	}
+	return campus.Resolve(p.Context, requested)
+}
+
+func (r *Resolver) venue(p graphql.ResolveParams) (interface{}, error) {
+	id, ok := p.Int("id")
+	if !ok {
+		return nil, errors.New("argument id is required")
+	}
+	return r.venueUC.GetVenueByID(p.Context, id)
+}
+
+// venueAreas - Dùng areas có sẵn trong Venue, nếu chưa có thì tải theo venueId
+func (r *Resolver) venueAreas(p graphql.ResolveParams) (interface{}, error) {
+	var v venueModels.Venue
+	switch src := p.Source.(type) {
+	case venueModels.Venue:
+		v = src
+	case *venueModels.Venue:
+		v = *src
+	default:
+		return nil, nil
+	}
+	if len(v.Areas) > 0 {
+		return v.Areas, nil
+	}
+	return r.venueUC.GetAreasByVenueID(p.Context, v.VenueID)
+}
+
+func (r *Resolver) myEventRequests(p graphql.ResolveParams) (interface{}, error) {
+	v, ok := viewerFrom(p.Context)
+	if !ok {
+		return nil, errUnauthenticated
+	}
+	return r.eventUC.GetMyEventRequests(p.Context, v.UserID)
+}
+
+// pendingEventRequests - quyền event.request.review (giống GET /api/event-requests/pending)
+func (r *Resolver) pendingEventRequests(p graphql.ResolveParams) (interface{}, error) {
+	v, ok := viewerFrom(p.Context)
+	if !ok {
+		return nil, errUnauthenticated
+	}
+	if !permission.RoleHas(p.Context, v.Role, permission.EventRequestReview) {
+		return nil, errForbidden
+	}
+	scope, err := campus.Scope(p.Context)
+	if err != nil {
+		return nil, err
+	}
+	return r.eventUC.GetPendingEventRequests(p.Context, scope)
+}
+
+// eventStats - eventId bỏ trống hoặc 0 = thống kê tổng hợp theo role
+// ORGANIZER chỉ xem được sự kiện mình tạo hoặc được cấp quyền VIEW_STATS (giống GET /api/events/stats)
+func (r *Resolver) eventStats(p graphql.ResolveParams) (interface{}, error) {
+	v, ok := viewerFrom(p.Context)
+	if !ok {
+		return nil, errUnauthenticated
+	}
+
+	eventID, _ := p.Int("eventId")
+	if eventID == 0 {
+		return r.eventUC.GetAggregateEventStats(p.Context, v.Role, v.UserID)
+	}
+
+	switch {
+	case permission.RoleHas(p.Context, v.Role, permission.EventRequestReview):
+	case permission.RoleHas(p.Context, v.Role, permission.EventStatsView):
+		owns, err := r.eventUC.CheckEventPermission(p.Context, eventID, v.UserID, eventModels.PermissionViewStats)
+		if err != nil {
+			return nil, err
+		}
+		if !owns {
+			return nil, errForbidden
+		}
+	default:
+		return nil, errForbidden
+	}
+	return r.eventUC.GetEventStats(p.Context, eventID)
+}
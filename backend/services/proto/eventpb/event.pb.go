@@ -0,0 +1,790 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.12
+// 	protoc        (unknown)
+// source: event.proto
+
+package eventpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GetEventBookingInfoRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EventId       int32                  `protobuf:"varint,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetEventBookingInfoRequest) Reset() {
+	*x = GetEventBookingInfoRequest{}
+	mi := &file_event_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetEventBookingInfoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEventBookingInfoRequest) ProtoMessage() {}
+
+func (x *GetEventBookingInfoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEventBookingInfoRequest.ProtoReflect.Descriptor instead.
+func (*GetEventBookingInfoRequest) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetEventBookingInfoRequest) GetEventId() int32 {
+	if x != nil {
+		return x.EventId
+	}
+	return 0
+}
+
+type EventBookingInfo struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	EventId               int32                  `protobuf:"varint,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	Title                 string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Status                string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`                                       // OPEN, CLOSED, CANCELLED, UPDATING
+	StartTimeUnix         int64                  `protobuf:"varint,4,opt,name=start_time_unix,json=startTimeUnix,proto3" json:"start_time_unix,omitempty"` // Seconds since epoch
+	EndTimeUnix           int64                  `protobuf:"varint,5,opt,name=end_time_unix,json=endTimeUnix,proto3" json:"end_time_unix,omitempty"`
+	AreaId                *int32                 `protobuf:"varint,6,opt,name=area_id,json=areaId,proto3,oneof" json:"area_id,omitempty"`
+	AreaName              *string                `protobuf:"bytes,7,opt,name=area_name,json=areaName,proto3,oneof" json:"area_name,omitempty"`
+	VenueName             *string                `protobuf:"bytes,8,opt,name=venue_name,json=venueName,proto3,oneof" json:"venue_name,omitempty"`
+	VenueLocation         *string                `protobuf:"bytes,9,opt,name=venue_location,json=venueLocation,proto3,oneof" json:"venue_location,omitempty"`
+	CreatedBy             int32                  `protobuf:"varint,10,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	CompTicketQuota       *int32                 `protobuf:"varint,11,opt,name=comp_ticket_quota,json=compTicketQuota,proto3,oneof" json:"comp_ticket_quota,omitempty"`               // Unset = COMP_TICKET_DEFAULT_QUOTA
+	AllowGuestCheckout    bool                   `protobuf:"varint,12,opt,name=allow_guest_checkout,json=allowGuestCheckout,proto3" json:"allow_guest_checkout,omitempty"`            // Guests without an account may buy tickets
+	BookingQueue          bool                   `protobuf:"varint,13,opt,name=booking_queue,json=bookingQueue,proto3" json:"booking_queue,omitempty"`                                // Bookings go through the waiting room (common/waitingroom)
+	QueueAdmitPerInterval int32                  `protobuf:"varint,14,opt,name=queue_admit_per_interval,json=queueAdmitPerInterval,proto3" json:"queue_admit_per_interval,omitempty"` // Users admitted to seat hold per dispatcher tick
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *EventBookingInfo) Reset() {
+	*x = EventBookingInfo{}
+	mi := &file_event_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EventBookingInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EventBookingInfo) ProtoMessage() {}
+
+func (x *EventBookingInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EventBookingInfo.ProtoReflect.Descriptor instead.
+func (*EventBookingInfo) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *EventBookingInfo) GetEventId() int32 {
+	if x != nil {
+		return x.EventId
+	}
+	return 0
+}
+
+func (x *EventBookingInfo) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *EventBookingInfo) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *EventBookingInfo) GetStartTimeUnix() int64 {
+	if x != nil {
+		return x.StartTimeUnix
+	}
+	return 0
+}
+
+func (x *EventBookingInfo) GetEndTimeUnix() int64 {
+	if x != nil {
+		return x.EndTimeUnix
+	}
+	return 0
+}
+
+func (x *EventBookingInfo) GetAreaId() int32 {
+	if x != nil && x.AreaId != nil {
+		return *x.AreaId
+	}
+	return 0
+}
+
+func (x *EventBookingInfo) GetAreaName() string {
+	if x != nil && x.AreaName != nil {
+		return *x.AreaName
+	}
+	return ""
+}
+
+func (x *EventBookingInfo) GetVenueName() string {
+	if x != nil && x.VenueName != nil {
+		return *x.VenueName
+	}
+	return ""
+}
+
+func (x *EventBookingInfo) GetVenueLocation() string {
+	if x != nil && x.VenueLocation != nil {
+		return *x.VenueLocation
+	}
+	return ""
+}
+
+func (x *EventBookingInfo) GetCreatedBy() int32 {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return 0
+}
+
+func (x *EventBookingInfo) GetCompTicketQuota() int32 {
+	if x != nil && x.CompTicketQuota != nil {
+		return *x.CompTicketQuota
+	}
+	return 0
+}
+
+func (x *EventBookingInfo) GetAllowGuestCheckout() bool {
+	if x != nil {
+		return x.AllowGuestCheckout
+	}
+	return false
+}
+
+func (x *EventBookingInfo) GetBookingQueue() bool {
+	if x != nil {
+		return x.BookingQueue
+	}
+	return false
+}
+
+func (x *EventBookingInfo) GetQueueAdmitPerInterval() int32 {
+	if x != nil {
+		return x.QueueAdmitPerInterval
+	}
+	return 0
+}
+
+type GetTicketTemplateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EventId       int32                  `protobuf:"varint,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTicketTemplateRequest) Reset() {
+	*x = GetTicketTemplateRequest{}
+	mi := &file_event_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTicketTemplateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTicketTemplateRequest) ProtoMessage() {}
+
+func (x *GetTicketTemplateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTicketTemplateRequest.ProtoReflect.Descriptor instead.
+func (*GetTicketTemplateRequest) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetTicketTemplateRequest) GetEventId() int32 {
+	if x != nil {
+		return x.EventId
+	}
+	return 0
+}
+
+type TicketTemplate struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	EventId          int32                  `protobuf:"varint,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	Layout           string                 `protobuf:"bytes,2,opt,name=layout,proto3" json:"layout,omitempty"`                                    // classic, branded
+	PageSize         string                 `protobuf:"bytes,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`                // A4, A5, A6, Letter
+	AccentColor      *string                `protobuf:"bytes,4,opt,name=accent_color,json=accentColor,proto3,oneof" json:"accent_color,omitempty"` // #RRGGBB
+	ShowBanner       bool                   `protobuf:"varint,5,opt,name=show_banner,json=showBanner,proto3" json:"show_banner,omitempty"`
+	OrganizerLogoUrl *string                `protobuf:"bytes,6,opt,name=organizer_logo_url,json=organizerLogoUrl,proto3,oneof" json:"organizer_logo_url,omitempty"`
+	SponsorLogoUrls  []string               `protobuf:"bytes,7,rep,name=sponsor_logo_urls,json=sponsorLogoUrls,proto3" json:"sponsor_logo_urls,omitempty"`
+	FooterNote       *string                `protobuf:"bytes,8,opt,name=footer_note,json=footerNote,proto3,oneof" json:"footer_note,omitempty"`
+	BannerUrl        *string                `protobuf:"bytes,9,opt,name=banner_url,json=bannerUrl,proto3,oneof" json:"banner_url,omitempty"` // Event banner (card variant if available)
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *TicketTemplate) Reset() {
+	*x = TicketTemplate{}
+	mi := &file_event_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TicketTemplate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TicketTemplate) ProtoMessage() {}
+
+func (x *TicketTemplate) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TicketTemplate.ProtoReflect.Descriptor instead.
+func (*TicketTemplate) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *TicketTemplate) GetEventId() int32 {
+	if x != nil {
+		return x.EventId
+	}
+	return 0
+}
+
+func (x *TicketTemplate) GetLayout() string {
+	if x != nil {
+		return x.Layout
+	}
+	return ""
+}
+
+func (x *TicketTemplate) GetPageSize() string {
+	if x != nil {
+		return x.PageSize
+	}
+	return ""
+}
+
+func (x *TicketTemplate) GetAccentColor() string {
+	if x != nil && x.AccentColor != nil {
+		return *x.AccentColor
+	}
+	return ""
+}
+
+func (x *TicketTemplate) GetShowBanner() bool {
+	if x != nil {
+		return x.ShowBanner
+	}
+	return false
+}
+
+func (x *TicketTemplate) GetOrganizerLogoUrl() string {
+	if x != nil && x.OrganizerLogoUrl != nil {
+		return *x.OrganizerLogoUrl
+	}
+	return ""
+}
+
+func (x *TicketTemplate) GetSponsorLogoUrls() []string {
+	if x != nil {
+		return x.SponsorLogoUrls
+	}
+	return nil
+}
+
+func (x *TicketTemplate) GetFooterNote() string {
+	if x != nil && x.FooterNote != nil {
+		return *x.FooterNote
+	}
+	return ""
+}
+
+func (x *TicketTemplate) GetBannerUrl() string {
+	if x != nil && x.BannerUrl != nil {
+		return *x.BannerUrl
+	}
+	return ""
+}
+
+type GetEventSponsorsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EventId       int32                  `protobuf:"varint,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetEventSponsorsRequest) Reset() {
+	*x = GetEventSponsorsRequest{}
+	mi := &file_event_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetEventSponsorsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEventSponsorsRequest) ProtoMessage() {}
+
+func (x *GetEventSponsorsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEventSponsorsRequest.ProtoReflect.Descriptor instead.
+func (*GetEventSponsorsRequest) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetEventSponsorsRequest) GetEventId() int32 {
+	if x != nil {
+		return x.EventId
+	}
+	return 0
+}
+
+type EventSponsor struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SponsorId     int32                  `protobuf:"varint,1,opt,name=sponsor_id,json=sponsorId,proto3" json:"sponsor_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Tier          string                 `protobuf:"bytes,3,opt,name=tier,proto3" json:"tier,omitempty"` // GOLD, SILVER, BRONZE, PARTNER
+	LogoUrl       *string                `protobuf:"bytes,4,opt,name=logo_url,json=logoUrl,proto3,oneof" json:"logo_url,omitempty"`
+	WebsiteUrl    *string                `protobuf:"bytes,5,opt,name=website_url,json=websiteUrl,proto3,oneof" json:"website_url,omitempty"`
+	DisplayOrder  int32                  `protobuf:"varint,6,opt,name=display_order,json=displayOrder,proto3" json:"display_order,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EventSponsor) Reset() {
+	*x = EventSponsor{}
+	mi := &file_event_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EventSponsor) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EventSponsor) ProtoMessage() {}
+
+func (x *EventSponsor) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EventSponsor.ProtoReflect.Descriptor instead.
+func (*EventSponsor) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *EventSponsor) GetSponsorId() int32 {
+	if x != nil {
+		return x.SponsorId
+	}
+	return 0
+}
+
+func (x *EventSponsor) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *EventSponsor) GetTier() string {
+	if x != nil {
+		return x.Tier
+	}
+	return ""
+}
+
+func (x *EventSponsor) GetLogoUrl() string {
+	if x != nil && x.LogoUrl != nil {
+		return *x.LogoUrl
+	}
+	return ""
+}
+
+func (x *EventSponsor) GetWebsiteUrl() string {
+	if x != nil && x.WebsiteUrl != nil {
+		return *x.WebsiteUrl
+	}
+	return ""
+}
+
+func (x *EventSponsor) GetDisplayOrder() int32 {
+	if x != nil {
+		return x.DisplayOrder
+	}
+	return 0
+}
+
+type EventSponsorList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sponsors      []*EventSponsor        `protobuf:"bytes,1,rep,name=sponsors,proto3" json:"sponsors,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EventSponsorList) Reset() {
+	*x = EventSponsorList{}
+	mi := &file_event_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EventSponsorList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EventSponsorList) ProtoMessage() {}
+
+func (x *EventSponsorList) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EventSponsorList.ProtoReflect.Descriptor instead.
+func (*EventSponsorList) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *EventSponsorList) GetSponsors() []*EventSponsor {
+	if x != nil {
+		return x.Sponsors
+	}
+	return nil
+}
+
+type CheckEventPermissionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EventId       int32                  `protobuf:"varint,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	UserId        int32                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Permission    string                 `protobuf:"bytes,3,opt,name=permission,proto3" json:"permission,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckEventPermissionRequest) Reset() {
+	*x = CheckEventPermissionRequest{}
+	mi := &file_event_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckEventPermissionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckEventPermissionRequest) ProtoMessage() {}
+
+func (x *CheckEventPermissionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckEventPermissionRequest.ProtoReflect.Descriptor instead.
+func (*CheckEventPermissionRequest) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CheckEventPermissionRequest) GetEventId() int32 {
+	if x != nil {
+		return x.EventId
+	}
+	return 0
+}
+
+func (x *CheckEventPermissionRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *CheckEventPermissionRequest) GetPermission() string {
+	if x != nil {
+		return x.Permission
+	}
+	return ""
+}
+
+type CheckEventPermissionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Allowed       bool                   `protobuf:"varint,1,opt,name=allowed,proto3" json:"allowed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckEventPermissionResponse) Reset() {
+	*x = CheckEventPermissionResponse{}
+	mi := &file_event_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckEventPermissionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckEventPermissionResponse) ProtoMessage() {}
+
+func (x *CheckEventPermissionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckEventPermissionResponse.ProtoReflect.Descriptor instead.
+func (*CheckEventPermissionResponse) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *CheckEventPermissionResponse) GetAllowed() bool {
+	if x != nil {
+		return x.Allowed
+	}
+	return false
+}
+
+var File_event_proto protoreflect.FileDescriptor
+
+const file_event_proto_rawDesc = "" +
+	"\n" +
+	"\vevent.proto\x12\feventservice\"7\n" +
+	"\x1aGetEventBookingInfoRequest\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\x05R\aeventId\"\xe9\x04\n" +
+	"\x10EventBookingInfo\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\x05R\aeventId\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12&\n" +
+	"\x0fstart_time_unix\x18\x04 \x01(\x03R\rstartTimeUnix\x12\"\n" +
+	"\rend_time_unix\x18\x05 \x01(\x03R\vendTimeUnix\x12\x1c\n" +
+	"\aarea_id\x18\x06 \x01(\x05H\x00R\x06areaId\x88\x01\x01\x12 \n" +
+	"\tarea_name\x18\a \x01(\tH\x01R\bareaName\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"venue_name\x18\b \x01(\tH\x02R\tvenueName\x88\x01\x01\x12*\n" +
+	"\x0evenue_location\x18\t \x01(\tH\x03R\rvenueLocation\x88\x01\x01\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\n" +
+	" \x01(\x05R\tcreatedBy\x12/\n" +
+	"\x11comp_ticket_quota\x18\v \x01(\x05H\x04R\x0fcompTicketQuota\x88\x01\x01\x120\n" +
+	"\x14allow_guest_checkout\x18\f \x01(\bR\x12allowGuestCheckout\x12#\n" +
+	"\rbooking_queue\x18\r \x01(\bR\fbookingQueue\x127\n" +
+	"\x18queue_admit_per_interval\x18\x0e \x01(\x05R\x15queueAdmitPerIntervalB\n" +
+	"\n" +
+	"\b_area_idB\f\n" +
+	"\n" +
+	"_area_nameB\r\n" +
+	"\v_venue_nameB\x11\n" +
+	"\x0f_venue_locationB\x14\n" +
+	"\x12_comp_ticket_quota\"5\n" +
+	"\x18GetTicketTemplateRequest\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\x05R\aeventId\"\x99\x03\n" +
+	"\x0eTicketTemplate\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\x05R\aeventId\x12\x16\n" +
+	"\x06layout\x18\x02 \x01(\tR\x06layout\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\tR\bpageSize\x12&\n" +
+	"\faccent_color\x18\x04 \x01(\tH\x00R\vaccentColor\x88\x01\x01\x12\x1f\n" +
+	"\vshow_banner\x18\x05 \x01(\bR\n" +
+	"showBanner\x121\n" +
+	"\x12organizer_logo_url\x18\x06 \x01(\tH\x01R\x10organizerLogoUrl\x88\x01\x01\x12*\n" +
+	"\x11sponsor_logo_urls\x18\a \x03(\tR\x0fsponsorLogoUrls\x12$\n" +
+	"\vfooter_note\x18\b \x01(\tH\x02R\n" +
+	"footerNote\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"banner_url\x18\t \x01(\tH\x03R\tbannerUrl\x88\x01\x01B\x0f\n" +
+	"\r_accent_colorB\x15\n" +
+	"\x13_organizer_logo_urlB\x0e\n" +
+	"\f_footer_noteB\r\n" +
+	"\v_banner_url\"4\n" +
+	"\x17GetEventSponsorsRequest\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\x05R\aeventId\"\xdd\x01\n" +
+	"\fEventSponsor\x12\x1d\n" +
+	"\n" +
+	"sponsor_id\x18\x01 \x01(\x05R\tsponsorId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04tier\x18\x03 \x01(\tR\x04tier\x12\x1e\n" +
+	"\blogo_url\x18\x04 \x01(\tH\x00R\alogoUrl\x88\x01\x01\x12$\n" +
+	"\vwebsite_url\x18\x05 \x01(\tH\x01R\n" +
+	"websiteUrl\x88\x01\x01\x12#\n" +
+	"\rdisplay_order\x18\x06 \x01(\x05R\fdisplayOrderB\v\n" +
+	"\t_logo_urlB\x0e\n" +
+	"\f_website_url\"J\n" +
+	"\x10EventSponsorList\x126\n" +
+	"\bsponsors\x18\x01 \x03(\v2\x1a.eventservice.EventSponsorR\bsponsors\"q\n" +
+	"\x1bCheckEventPermissionRequest\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\x05R\aeventId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x05R\x06userId\x12\x1e\n" +
+	"\n" +
+	"permission\x18\x03 \x01(\tR\n" +
+	"permission\"8\n" +
+	"\x1cCheckEventPermissionResponse\x12\x18\n" +
+	"\aallowed\x18\x01 \x01(\bR\aallowed2\x94\x03\n" +
+	"\fEventService\x12_\n" +
+	"\x13GetEventBookingInfo\x12(.eventservice.GetEventBookingInfoRequest\x1a\x1e.eventservice.EventBookingInfo\x12Y\n" +
+	"\x11GetTicketTemplate\x12&.eventservice.GetTicketTemplateRequest\x1a\x1c.eventservice.TicketTemplate\x12Y\n" +
+	"\x10GetEventSponsors\x12%.eventservice.GetEventSponsorsRequest\x1a\x1e.eventservice.EventSponsorList\x12m\n" +
+	"\x14CheckEventPermission\x12).eventservice.CheckEventPermissionRequest\x1a*.eventservice.CheckEventPermissionResponseB>Z<github.com/fpt-event-services/services/proto/eventpb;eventpbb\x06proto3"
+
+var (
+	file_event_proto_rawDescOnce sync.Once
+	file_event_proto_rawDescData []byte
+)
+
+func file_event_proto_rawDescGZIP() []byte {
+	file_event_proto_rawDescOnce.Do(func() {
+		file_event_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_event_proto_rawDesc), len(file_event_proto_rawDesc)))
+	})
+	return file_event_proto_rawDescData
+}
+
+var file_event_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_event_proto_goTypes = []any{
+	(*GetEventBookingInfoRequest)(nil),   // 0: eventservice.GetEventBookingInfoRequest
+	(*EventBookingInfo)(nil),             // 1: eventservice.EventBookingInfo
+	(*GetTicketTemplateRequest)(nil),     // 2: eventservice.GetTicketTemplateRequest
+	(*TicketTemplate)(nil),               // 3: eventservice.TicketTemplate
+	(*GetEventSponsorsRequest)(nil),      // 4: eventservice.GetEventSponsorsRequest
+	(*EventSponsor)(nil),                 // 5: eventservice.EventSponsor
+	(*EventSponsorList)(nil),             // 6: eventservice.EventSponsorList
+	(*CheckEventPermissionRequest)(nil),  // 7: eventservice.CheckEventPermissionRequest
+	(*CheckEventPermissionResponse)(nil), // 8: eventservice.CheckEventPermissionResponse
+}
+var file_event_proto_depIdxs = []int32{
+	5, // 0: eventservice.EventSponsorList.sponsors:type_name -> eventservice.EventSponsor
+	0, // 1: eventservice.EventService.GetEventBookingInfo:input_type -> eventservice.GetEventBookingInfoRequest
+	2, // 2: eventservice.EventService.GetTicketTemplate:input_type -> eventservice.GetTicketTemplateRequest
+	4, // 3: eventservice.EventService.GetEventSponsors:input_type -> eventservice.GetEventSponsorsRequest
+	7, // 4: eventservice.EventService.CheckEventPermission:input_type -> eventservice.CheckEventPermissionRequest
+	1, // 5: eventservice.EventService.GetEventBookingInfo:output_type -> eventservice.EventBookingInfo
+	3, // 6: eventservice.EventService.GetTicketTemplate:output_type -> eventservice.TicketTemplate
+	6, // 7: eventservice.EventService.GetEventSponsors:output_type -> eventservice.EventSponsorList
+	8, // 8: eventservice.EventService.CheckEventPermission:output_type -> eventservice.CheckEventPermissionResponse
+	5, // [5:9] is the sub-list for method output_type
+	1, // [1:5] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_event_proto_init() }
+func file_event_proto_init() {
+	if File_event_proto != nil {
+		return
+	}
+	file_event_proto_msgTypes[1].OneofWrappers = []any{}
+	file_event_proto_msgTypes[3].OneofWrappers = []any{}
+	file_event_proto_msgTypes[5].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_event_proto_rawDesc), len(file_event_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   9,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_event_proto_goTypes,
+		DependencyIndexes: file_event_proto_depIdxs,
+		MessageInfos:      file_event_proto_msgTypes,
+	}.Build()
+	File_event_proto = out.File
+	file_event_proto_goTypes = nil
+	file_event_proto_depIdxs = nil
+}
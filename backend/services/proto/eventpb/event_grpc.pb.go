@@ -0,0 +1,257 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.2
+// - protoc             (unknown)
+// source: event.proto
+
+package eventpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	EventService_GetEventBookingInfo_FullMethodName  = "/eventservice.EventService/GetEventBookingInfo"
+	EventService_GetTicketTemplate_FullMethodName    = "/eventservice.EventService/GetTicketTemplate"
+	EventService_GetEventSponsors_FullMethodName     = "/eventservice.EventService/GetEventSponsors"
+	EventService_CheckEventPermission_FullMethodName = "/eventservice.EventService/CheckEventPermission"
+)
+
+// EventServiceClient is the client API for EventService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type EventServiceClient interface {
+	// GetEventBookingInfo - Status, schedule and location of an event
+	// Used by ticket-service to validate bookings/payments and render ticket emails
+	// Returns NOT_FOUND if the event does not exist
+	GetEventBookingInfo(ctx context.Context, in *GetEventBookingInfoRequest, opts ...grpc.CallOption) (*EventBookingInfo, error)
+	// GetTicketTemplate - Ticket PDF layout and branding of an event
+	// Used by ticket-service when rendering ticket PDFs; defaults to classic/A4 if not configured
+	// Returns NOT_FOUND if the event does not exist
+	GetTicketTemplate(ctx context.Context, in *GetTicketTemplateRequest, opts ...grpc.CallOption) (*TicketTemplate, error)
+	// GetEventSponsors - Sponsors of an event ordered GOLD, SILVER, BRONZE, PARTNER
+	// Used by ticket-service to print gold-tier sponsor logos in ticket emails
+	GetEventSponsors(ctx context.Context, in *GetEventSponsorsRequest, opts ...grpc.CallOption) (*EventSponsorList, error)
+	// CheckEventPermission - Whether a user owns the event or is an accepted co-organizer
+	// holding the given permission (EDIT_DETAILS, VIEW_STATS, MANAGE_ANNOUNCEMENTS)
+	// Used by ticket-service to authorize installment plan changes; false if the event does not exist
+	CheckEventPermission(ctx context.Context, in *CheckEventPermissionRequest, opts ...grpc.CallOption) (*CheckEventPermissionResponse, error)
+}
+
+type eventServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewEventServiceClient(cc grpc.ClientConnInterface) EventServiceClient {
+	return &eventServiceClient{cc}
+}
+
+func (c *eventServiceClient) GetEventBookingInfo(ctx context.Context, in *GetEventBookingInfoRequest, opts ...grpc.CallOption) (*EventBookingInfo, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EventBookingInfo)
+	err := c.cc.Invoke(ctx, EventService_GetEventBookingInfo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eventServiceClient) GetTicketTemplate(ctx context.Context, in *GetTicketTemplateRequest, opts ...grpc.CallOption) (*TicketTemplate, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TicketTemplate)
+	err := c.cc.Invoke(ctx, EventService_GetTicketTemplate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eventServiceClient) GetEventSponsors(ctx context.Context, in *GetEventSponsorsRequest, opts ...grpc.CallOption) (*EventSponsorList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EventSponsorList)
+	err := c.cc.Invoke(ctx, EventService_GetEventSponsors_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eventServiceClient) CheckEventPermission(ctx context.Context, in *CheckEventPermissionRequest, opts ...grpc.CallOption) (*CheckEventPermissionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CheckEventPermissionResponse)
+	err := c.cc.Invoke(ctx, EventService_CheckEventPermission_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// EventServiceServer is the server API for EventService service.
+// All implementations must embed UnimplementedEventServiceServer
+// for forward compatibility.
+type EventServiceServer interface {
+	// GetEventBookingInfo - Status, schedule and location of an event
+	// Used by ticket-service to validate bookings/payments and render ticket emails
+	// Returns NOT_FOUND if the event does not exist
+	GetEventBookingInfo(context.Context, *GetEventBookingInfoRequest) (*EventBookingInfo, error)
+	// GetTicketTemplate - Ticket PDF layout and branding of an event
+	// Used by ticket-service when rendering ticket PDFs; defaults to classic/A4 if not configured
+	// Returns NOT_FOUND if the event does not exist
+	GetTicketTemplate(context.Context, *GetTicketTemplateRequest) (*TicketTemplate, error)
+	// GetEventSponsors - Sponsors of an event ordered GOLD, SILVER, BRONZE, PARTNER
+	// Used by ticket-service to print gold-tier sponsor logos in ticket emails
+	GetEventSponsors(context.Context, *GetEventSponsorsRequest) (*EventSponsorList, error)
+	// CheckEventPermission - Whether a user owns the event or is an accepted co-organizer
+	// holding the given permission (EDIT_DETAILS, VIEW_STATS, MANAGE_ANNOUNCEMENTS)
+	// Used by ticket-service to authorize installment plan changes; false if the event does not exist
+	CheckEventPermission(context.Context, *CheckEventPermissionRequest) (*CheckEventPermissionResponse, error)
+	mustEmbedUnimplementedEventServiceServer()
+}
+
+// UnimplementedEventServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedEventServiceServer struct{}
+
+func (UnimplementedEventServiceServer) GetEventBookingInfo(context.Context, *GetEventBookingInfoRequest) (*EventBookingInfo, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetEventBookingInfo not implemented")
+}
+func (UnimplementedEventServiceServer) GetTicketTemplate(context.Context, *GetTicketTemplateRequest) (*TicketTemplate, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetTicketTemplate not implemented")
+}
+func (UnimplementedEventServiceServer) GetEventSponsors(context.Context, *GetEventSponsorsRequest) (*EventSponsorList, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetEventSponsors not implemented")
+}
+func (UnimplementedEventServiceServer) CheckEventPermission(context.Context, *CheckEventPermissionRequest) (*CheckEventPermissionResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CheckEventPermission not implemented")
+}
+func (UnimplementedEventServiceServer) mustEmbedUnimplementedEventServiceServer() {}
+func (UnimplementedEventServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeEventServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to EventServiceServer will
+// result in compilation errors.
+type UnsafeEventServiceServer interface {
+	mustEmbedUnimplementedEventServiceServer()
+}
+
+func RegisterEventServiceServer(s grpc.ServiceRegistrar, srv EventServiceServer) {
+	// If the following call panics, it indicates UnimplementedEventServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&EventService_ServiceDesc, srv)
+}
+
+func _EventService_GetEventBookingInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEventBookingInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EventServiceServer).GetEventBookingInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EventService_GetEventBookingInfo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EventServiceServer).GetEventBookingInfo(ctx, req.(*GetEventBookingInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EventService_GetTicketTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTicketTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EventServiceServer).GetTicketTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EventService_GetTicketTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EventServiceServer).GetTicketTemplate(ctx, req.(*GetTicketTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EventService_GetEventSponsors_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEventSponsorsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EventServiceServer).GetEventSponsors(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EventService_GetEventSponsors_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EventServiceServer).GetEventSponsors(ctx, req.(*GetEventSponsorsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EventService_CheckEventPermission_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckEventPermissionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EventServiceServer).CheckEventPermission(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EventService_CheckEventPermission_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EventServiceServer).CheckEventPermission(ctx, req.(*CheckEventPermissionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// EventService_ServiceDesc is the grpc.ServiceDesc for EventService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var EventService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "eventservice.EventService",
+	HandlerType: (*EventServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetEventBookingInfo",
+			Handler:    _EventService_GetEventBookingInfo_Handler,
+		},
+		{
+			MethodName: "GetTicketTemplate",
+			Handler:    _EventService_GetTicketTemplate_Handler,
+		},
+		{
+			MethodName: "GetEventSponsors",
+			Handler:    _EventService_GetEventSponsors_Handler,
+		},
+		{
+			MethodName: "CheckEventPermission",
+			Handler:    _EventService_CheckEventPermission_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "event.proto",
+}
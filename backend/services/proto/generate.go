@@ -0,0 +1,9 @@
+// Package proto chứa contract protobuf nội bộ giữa các service và code Go sinh từ đó
+// (eventpb, ticketpb, venuepb). wallet.proto chưa có triển khai nên chưa sinh code.
+//
+// Sinh lại sau khi sửa .proto (cần buf, protoc-gen-go, protoc-gen-go-grpc trong PATH):
+//
+//	go generate ./services/proto
+package proto
+
+//go:generate buf generate
@@ -0,0 +1,229 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.12
+// 	protoc        (unknown)
+// source: ticket.proto
+
+package ticketpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GetEventTicketCountsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EventId       int32                  `protobuf:"varint,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetEventTicketCountsRequest) Reset() {
+	*x = GetEventTicketCountsRequest{}
+	mi := &file_ticket_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetEventTicketCountsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEventTicketCountsRequest) ProtoMessage() {}
+
+func (x *GetEventTicketCountsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ticket_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEventTicketCountsRequest.ProtoReflect.Descriptor instead.
+func (*GetEventTicketCountsRequest) Descriptor() ([]byte, []int) {
+	return file_ticket_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetEventTicketCountsRequest) GetEventId() int32 {
+	if x != nil {
+		return x.EventId
+	}
+	return 0
+}
+
+type EventTicketCounts struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EventId       int32                  `protobuf:"varint,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	Pending       int32                  `protobuf:"varint,2,opt,name=pending,proto3" json:"pending,omitempty"`
+	Booked        int32                  `protobuf:"varint,3,opt,name=booked,proto3" json:"booked,omitempty"`
+	CheckedIn     int32                  `protobuf:"varint,4,opt,name=checked_in,json=checkedIn,proto3" json:"checked_in,omitempty"`
+	CheckedOut    int32                  `protobuf:"varint,5,opt,name=checked_out,json=checkedOut,proto3" json:"checked_out,omitempty"`
+	Refunded      int32                  `protobuf:"varint,6,opt,name=refunded,proto3" json:"refunded,omitempty"`
+	Expired       int32                  `protobuf:"varint,7,opt,name=expired,proto3" json:"expired,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EventTicketCounts) Reset() {
+	*x = EventTicketCounts{}
+	mi := &file_ticket_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EventTicketCounts) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EventTicketCounts) ProtoMessage() {}
+
+func (x *EventTicketCounts) ProtoReflect() protoreflect.Message {
+	mi := &file_ticket_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EventTicketCounts.ProtoReflect.Descriptor instead.
+func (*EventTicketCounts) Descriptor() ([]byte, []int) {
+	return file_ticket_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *EventTicketCounts) GetEventId() int32 {
+	if x != nil {
+		return x.EventId
+	}
+	return 0
+}
+
+func (x *EventTicketCounts) GetPending() int32 {
+	if x != nil {
+		return x.Pending
+	}
+	return 0
+}
+
+func (x *EventTicketCounts) GetBooked() int32 {
+	if x != nil {
+		return x.Booked
+	}
+	return 0
+}
+
+func (x *EventTicketCounts) GetCheckedIn() int32 {
+	if x != nil {
+		return x.CheckedIn
+	}
+	return 0
+}
+
+func (x *EventTicketCounts) GetCheckedOut() int32 {
+	if x != nil {
+		return x.CheckedOut
+	}
+	return 0
+}
+
+func (x *EventTicketCounts) GetRefunded() int32 {
+	if x != nil {
+		return x.Refunded
+	}
+	return 0
+}
+
+func (x *EventTicketCounts) GetExpired() int32 {
+	if x != nil {
+		return x.Expired
+	}
+	return 0
+}
+
+var File_ticket_proto protoreflect.FileDescriptor
+
+const file_ticket_proto_rawDesc = "" +
+	"\n" +
+	"\fticket.proto\x12\rticketservice\"8\n" +
+	"\x1bGetEventTicketCountsRequest\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\x05R\aeventId\"\xd6\x01\n" +
+	"\x11EventTicketCounts\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\x05R\aeventId\x12\x18\n" +
+	"\apending\x18\x02 \x01(\x05R\apending\x12\x16\n" +
+	"\x06booked\x18\x03 \x01(\x05R\x06booked\x12\x1d\n" +
+	"\n" +
+	"checked_in\x18\x04 \x01(\x05R\tcheckedIn\x12\x1f\n" +
+	"\vchecked_out\x18\x05 \x01(\x05R\n" +
+	"checkedOut\x12\x1a\n" +
+	"\brefunded\x18\x06 \x01(\x05R\brefunded\x12\x18\n" +
+	"\aexpired\x18\a \x01(\x05R\aexpired2u\n" +
+	"\rTicketService\x12d\n" +
+	"\x14GetEventTicketCounts\x12*.ticketservice.GetEventTicketCountsRequest\x1a .ticketservice.EventTicketCountsB@Z>github.com/fpt-event-services/services/proto/ticketpb;ticketpbb\x06proto3"
+
+var (
+	file_ticket_proto_rawDescOnce sync.Once
+	file_ticket_proto_rawDescData []byte
+)
+
+func file_ticket_proto_rawDescGZIP() []byte {
+	file_ticket_proto_rawDescOnce.Do(func() {
+		file_ticket_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_ticket_proto_rawDesc), len(file_ticket_proto_rawDesc)))
+	})
+	return file_ticket_proto_rawDescData
+}
+
+var file_ticket_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_ticket_proto_goTypes = []any{
+	(*GetEventTicketCountsRequest)(nil), // 0: ticketservice.GetEventTicketCountsRequest
+	(*EventTicketCounts)(nil),           // 1: ticketservice.EventTicketCounts
+}
+var file_ticket_proto_depIdxs = []int32{
+	0, // 0: ticketservice.TicketService.GetEventTicketCounts:input_type -> ticketservice.GetEventTicketCountsRequest
+	1, // 1: ticketservice.TicketService.GetEventTicketCounts:output_type -> ticketservice.EventTicketCounts
+	1, // [1:2] is the sub-list for method output_type
+	0, // [0:1] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_ticket_proto_init() }
+func file_ticket_proto_init() {
+	if File_ticket_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_ticket_proto_rawDesc), len(file_ticket_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_ticket_proto_goTypes,
+		DependencyIndexes: file_ticket_proto_depIdxs,
+		MessageInfos:      file_ticket_proto_msgTypes,
+	}.Build()
+	File_ticket_proto = out.File
+	file_ticket_proto_goTypes = nil
+	file_ticket_proto_depIdxs = nil
+}
@@ -0,0 +1,251 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.12
+// 	protoc        (unknown)
+// source: venue.proto
+
+package venuepb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GetAreaRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AreaId        int32                  `protobuf:"varint,1,opt,name=area_id,json=areaId,proto3" json:"area_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAreaRequest) Reset() {
+	*x = GetAreaRequest{}
+	mi := &file_venue_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAreaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAreaRequest) ProtoMessage() {}
+
+func (x *GetAreaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_venue_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAreaRequest.ProtoReflect.Descriptor instead.
+func (*GetAreaRequest) Descriptor() ([]byte, []int) {
+	return file_venue_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetAreaRequest) GetAreaId() int32 {
+	if x != nil {
+		return x.AreaId
+	}
+	return 0
+}
+
+type Area struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AreaId        int32                  `protobuf:"varint,1,opt,name=area_id,json=areaId,proto3" json:"area_id,omitempty"`
+	VenueId       int32                  `protobuf:"varint,2,opt,name=venue_id,json=venueId,proto3" json:"venue_id,omitempty"`
+	AreaName      string                 `protobuf:"bytes,3,opt,name=area_name,json=areaName,proto3" json:"area_name,omitempty"`
+	Floor         *string                `protobuf:"bytes,4,opt,name=floor,proto3,oneof" json:"floor,omitempty"`
+	Capacity      int32                  `protobuf:"varint,5,opt,name=capacity,proto3" json:"capacity,omitempty"`
+	Status        string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"` // AVAILABLE, UNAVAILABLE
+	VenueName     string                 `protobuf:"bytes,7,opt,name=venue_name,json=venueName,proto3" json:"venue_name,omitempty"`
+	VenueLocation *string                `protobuf:"bytes,8,opt,name=venue_location,json=venueLocation,proto3,oneof" json:"venue_location,omitempty"`
+	CampusId      *int32                 `protobuf:"varint,9,opt,name=campus_id,json=campusId,proto3,oneof" json:"campus_id,omitempty"` // Campus of the venue; unset = not assigned
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Area) Reset() {
+	*x = Area{}
+	mi := &file_venue_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Area) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Area) ProtoMessage() {}
+
+func (x *Area) ProtoReflect() protoreflect.Message {
+	mi := &file_venue_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Area.ProtoReflect.Descriptor instead.
+func (*Area) Descriptor() ([]byte, []int) {
+	return file_venue_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Area) GetAreaId() int32 {
+	if x != nil {
+		return x.AreaId
+	}
+	return 0
+}
+
+func (x *Area) GetVenueId() int32 {
+	if x != nil {
+		return x.VenueId
+	}
+	return 0
+}
+
+func (x *Area) GetAreaName() string {
+	if x != nil {
+		return x.AreaName
+	}
+	return ""
+}
+
+func (x *Area) GetFloor() string {
+	if x != nil && x.Floor != nil {
+		return *x.Floor
+	}
+	return ""
+}
+
+func (x *Area) GetCapacity() int32 {
+	if x != nil {
+		return x.Capacity
+	}
+	return 0
+}
+
+func (x *Area) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Area) GetVenueName() string {
+	if x != nil {
+		return x.VenueName
+	}
+	return ""
+}
+
+func (x *Area) GetVenueLocation() string {
+	if x != nil && x.VenueLocation != nil {
+		return *x.VenueLocation
+	}
+	return ""
+}
+
+func (x *Area) GetCampusId() int32 {
+	if x != nil && x.CampusId != nil {
+		return *x.CampusId
+	}
+	return 0
+}
+
+var File_venue_proto protoreflect.FileDescriptor
+
+const file_venue_proto_rawDesc = "" +
+	"\n" +
+	"\vvenue.proto\x12\fvenueservice\")\n" +
+	"\x0eGetAreaRequest\x12\x17\n" +
+	"\aarea_id\x18\x01 \x01(\x05R\x06areaId\"\xbe\x02\n" +
+	"\x04Area\x12\x17\n" +
+	"\aarea_id\x18\x01 \x01(\x05R\x06areaId\x12\x19\n" +
+	"\bvenue_id\x18\x02 \x01(\x05R\avenueId\x12\x1b\n" +
+	"\tarea_name\x18\x03 \x01(\tR\bareaName\x12\x19\n" +
+	"\x05floor\x18\x04 \x01(\tH\x00R\x05floor\x88\x01\x01\x12\x1a\n" +
+	"\bcapacity\x18\x05 \x01(\x05R\bcapacity\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x12\x1d\n" +
+	"\n" +
+	"venue_name\x18\a \x01(\tR\tvenueName\x12*\n" +
+	"\x0evenue_location\x18\b \x01(\tH\x01R\rvenueLocation\x88\x01\x01\x12 \n" +
+	"\tcampus_id\x18\t \x01(\x05H\x02R\bcampusId\x88\x01\x01B\b\n" +
+	"\x06_floorB\x11\n" +
+	"\x0f_venue_locationB\f\n" +
+	"\n" +
+	"_campus_id2K\n" +
+	"\fVenueService\x12;\n" +
+	"\aGetArea\x12\x1c.venueservice.GetAreaRequest\x1a\x12.venueservice.AreaB>Z<github.com/fpt-event-services/services/proto/venuepb;venuepbb\x06proto3"
+
+var (
+	file_venue_proto_rawDescOnce sync.Once
+	file_venue_proto_rawDescData []byte
+)
+
+func file_venue_proto_rawDescGZIP() []byte {
+	file_venue_proto_rawDescOnce.Do(func() {
+		file_venue_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_venue_proto_rawDesc), len(file_venue_proto_rawDesc)))
+	})
+	return file_venue_proto_rawDescData
+}
+
+var file_venue_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_venue_proto_goTypes = []any{
+	(*GetAreaRequest)(nil), // 0: venueservice.GetAreaRequest
+	(*Area)(nil),           // 1: venueservice.Area
+}
+var file_venue_proto_depIdxs = []int32{
+	0, // 0: venueservice.VenueService.GetArea:input_type -> venueservice.GetAreaRequest
+	1, // 1: venueservice.VenueService.GetArea:output_type -> venueservice.Area
+	1, // [1:2] is the sub-list for method output_type
+	0, // [0:1] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_venue_proto_init() }
+func file_venue_proto_init() {
+	if File_venue_proto != nil {
+		return
+	}
+	file_venue_proto_msgTypes[1].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_venue_proto_rawDesc), len(file_venue_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_venue_proto_goTypes,
+		DependencyIndexes: file_venue_proto_depIdxs,
+		MessageInfos:      file_venue_proto_msgTypes,
+	}.Build()
+	File_venue_proto = out.File
+	file_venue_proto_goTypes = nil
+	file_venue_proto_depIdxs = nil
+}
@@ -0,0 +1,125 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.2
+// - protoc             (unknown)
+// source: venue.proto
+
+package venuepb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	VenueService_GetArea_FullMethodName = "/venueservice.VenueService/GetArea"
+)
+
+// VenueServiceClient is the client API for VenueService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type VenueServiceClient interface {
+	// GetArea - Venue area with its venue
+	// Returns NOT_FOUND if the area does not exist
+	GetArea(ctx context.Context, in *GetAreaRequest, opts ...grpc.CallOption) (*Area, error)
+}
+
+type venueServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewVenueServiceClient(cc grpc.ClientConnInterface) VenueServiceClient {
+	return &venueServiceClient{cc}
+}
+
+func (c *venueServiceClient) GetArea(ctx context.Context, in *GetAreaRequest, opts ...grpc.CallOption) (*Area, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Area)
+	err := c.cc.Invoke(ctx, VenueService_GetArea_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// VenueServiceServer is the server API for VenueService service.
+// All implementations must embed UnimplementedVenueServiceServer
+// for forward compatibility.
+type VenueServiceServer interface {
+	// GetArea - Venue area with its venue
+	// Returns NOT_FOUND if the area does not exist
+	GetArea(context.Context, *GetAreaRequest) (*Area, error)
+	mustEmbedUnimplementedVenueServiceServer()
+}
+
+// UnimplementedVenueServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedVenueServiceServer struct{}
+
+func (UnimplementedVenueServiceServer) GetArea(context.Context, *GetAreaRequest) (*Area, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetArea not implemented")
+}
+func (UnimplementedVenueServiceServer) mustEmbedUnimplementedVenueServiceServer() {}
+func (UnimplementedVenueServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeVenueServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to VenueServiceServer will
+// result in compilation errors.
+type UnsafeVenueServiceServer interface {
+	mustEmbedUnimplementedVenueServiceServer()
+}
+
+func RegisterVenueServiceServer(s grpc.ServiceRegistrar, srv VenueServiceServer) {
+	// If the following call panics, it indicates UnimplementedVenueServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&VenueService_ServiceDesc, srv)
+}
+
+func _VenueService_GetArea_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAreaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VenueServiceServer).GetArea(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VenueService_GetArea_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VenueServiceServer).GetArea(ctx, req.(*GetAreaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// VenueService_ServiceDesc is the grpc.ServiceDesc for VenueService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var VenueService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "venueservice.VenueService",
+	HandlerType: (*VenueServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetArea",
+			Handler:    _VenueService_GetArea_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "venue.proto",
+}
@@ -0,0 +1,25 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/fpt-event-services/common/capability"
+	"github.com/fpt-event-services/common/permission"
+)
+
+// ============================================================
+// HandleGetCapabilities - GET /api/admin/capabilities
+// Ma trận thao tác của repository (IMPLEMENTED / PARTIAL / UNIMPLEMENTED), route nào
+// dùng thao tác nào, vi phạm (route dựa vào stub) và stub không ai dùng (quyền diagnostics.view)
+// ============================================================
+func (h *StaffHandler) HandleGetCapabilities(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	if _, err := permission.Require(ctx, permission.DiagnosticsView); err != nil {
+		return diagnosticsAuthError(err)
+	}
+	return createJSONResponse(http.StatusOK, map[string]interface{}{
+		"success": true,
+		"data":    capability.Default.Matrix(),
+	})
+}
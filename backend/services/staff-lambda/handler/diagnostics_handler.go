@@ -0,0 +1,99 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/fpt-event-services/common/authctx"
+	"github.com/fpt-event-services/common/permission"
+	"github.com/fpt-event-services/services/staff-lambda/repository"
+)
+
+// diagnosticsAuthError - 401 nếu chưa đăng nhập, 403 nếu thiếu quyền diagnostics.view
+func diagnosticsAuthError(err error) (events.APIGatewayProxyResponse, error) {
+	if errors.Is(err, authctx.ErrUnauthenticated) {
+		return createErrorResponse(http.StatusUnauthorized, "Unauthorized")
+	}
+	return createErrorResponse(http.StatusForbidden, "Chỉ ADMIN mới có quyền dùng API chẩn đoán")
+}
+
+// ============================================================
+// HandleDiagnosticsEntity - GET /api/admin/diagnostics/entities/{type}/{id}
+// Đọc nguyên bản ghi theo ID (user, event, ticket, bill...), cột nhạy cảm bị ẩn
+// ============================================================
+func (h *StaffHandler) HandleDiagnosticsEntity(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	if _, err := permission.Require(ctx, permission.DiagnosticsView); err != nil {
+		return diagnosticsAuthError(err)
+	}
+
+	entity := request.PathParameters["type"]
+	id, err := strconv.ParseInt(request.PathParameters["id"], 10, 64)
+	if err != nil || id <= 0 {
+		return createErrorResponse(http.StatusBadRequest, "id không hợp lệ")
+	}
+
+	record, err := h.useCase.LookupEntity(ctx, entity, id)
+	if errors.Is(err, repository.ErrUnknownEntity) {
+		return createJSONResponse(http.StatusBadRequest, map[string]interface{}{
+			"success":     false,
+			"message":     "Loại entity không hợp lệ: " + entity,
+			"entityTypes": repository.DiagnosticsEntityTypes(),
+		})
+	}
+	if err != nil {
+		return createErrorResponse(http.StatusInternalServerError, "Lỗi khi tra cứu dữ liệu")
+	}
+	if record == nil {
+		return createErrorResponse(http.StatusNotFound, "Không tìm thấy "+entity+" #"+strconv.FormatInt(id, 10))
+	}
+
+	return createJSONResponse(http.StatusOK, map[string]interface{}{
+		"success": true,
+		"entity":  entity,
+		"data":    record,
+	})
+}
+
+// ============================================================
+// HandleDiagnosticsStatus - GET /api/admin/diagnostics/status
+// DB connection pool, Email_Queue theo trạng thái, job định kỳ và lần chạy gần nhất
+// ============================================================
+func (h *StaffHandler) HandleDiagnosticsStatus(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	if _, err := permission.Require(ctx, permission.DiagnosticsView); err != nil {
+		return diagnosticsAuthError(err)
+	}
+
+	return createJSONResponse(http.StatusOK, map[string]interface{}{
+		"success": true,
+		"data":    h.useCase.GetDiagnosticsStatus(ctx),
+	})
+}
+
+// ============================================================
+// HandleDiagnosticsErrors - GET /api/admin/diagnostics/errors?limit=50
+// Log lỗi gần nhất của instance đang xử lý request (tối đa 100, giữ trong bộ nhớ)
+// ============================================================
+func (h *StaffHandler) HandleDiagnosticsErrors(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	if _, err := permission.Require(ctx, permission.DiagnosticsView); err != nil {
+		return diagnosticsAuthError(err)
+	}
+
+	limit := 50
+	if v := request.QueryStringParameters["limit"]; v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			return createErrorResponse(http.StatusBadRequest, "limit không hợp lệ")
+		}
+		limit = min(n, 100)
+	}
+
+	samples := h.useCase.RecentErrors(limit)
+	return createJSONResponse(http.StatusOK, map[string]interface{}{
+		"success": true,
+		"count":   len(samples),
+		"data":    samples,
+	})
+}
@@ -0,0 +1,132 @@
+package handler
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/fpt-event-services/common/email"
+	"github.com/fpt-event-services/common/logger"
+	"github.com/fpt-event-services/common/permission"
+)
+
+// emailWebhookPayload - Body webhook: một hoặc nhiều sự kiện giao nhận
+type emailWebhookPayload struct {
+	Events []email.DeliveryEvent `json:"events"`
+}
+
+// ============================================================
+// HandleEmailWebhook - POST /api/webhooks/email-events
+// Nhà cung cấp email báo bounce/complaint/delivered (không dùng JWT)
+// Xác thực bằng header X-Webhook-Secret = EMAIL_WEBHOOK_SECRET
+// Body: {"events":[{"type":"BOUNCE","messageId":"...","recipient":"...","bounceType":"HARD","reason":"..."}]}
+// ============================================================
+func (h *StaffHandler) HandleEmailWebhook(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	log := logger.Default().WithContext(ctx)
+	secret := os.Getenv("EMAIL_WEBHOOK_SECRET")
+	if secret == "" {
+		return createErrorResponse(http.StatusServiceUnavailable, "Webhook chưa được cấu hình")
+	}
+	given := request.Headers["X-Webhook-Secret"]
+	if subtle.ConstantTimeCompare([]byte(given), []byte(secret)) != 1 {
+		return createErrorResponse(http.StatusUnauthorized, "Invalid webhook secret")
+	}
+
+	var payload emailWebhookPayload
+	if err := json.Unmarshal([]byte(request.Body), &payload); err != nil || len(payload.Events) == 0 {
+		return createErrorResponse(http.StatusBadRequest, "Invalid request body")
+	}
+
+	recorded := 0
+	for _, ev := range payload.Events {
+		ev.Type = strings.ToUpper(strings.TrimSpace(ev.Type))
+		ev.BounceType = strings.ToUpper(strings.TrimSpace(ev.BounceType))
+		switch ev.Type {
+		case email.EventDelivered, email.EventBounce, email.EventComplaint:
+		default:
+			return createErrorResponse(http.StatusBadRequest, "type không hợp lệ: "+ev.Type)
+		}
+
+		if err := email.DefaultQueue().RecordEvent(ctx, ev); err != nil {
+			log.Warn("Failed to record email event", "type", ev.Type, "messageId", ev.MessageID, "error", err)
+			continue
+		}
+		recorded++
+	}
+
+	return createJSONResponse(http.StatusOK, map[string]interface{}{
+		"success":  true,
+		"recorded": recorded,
+	})
+}
+
+// ============================================================
+// HandleListEmails - GET /api/admin/emails
+// Tra cứu email đã gửi / đang chờ / dead-letter kèm bounce/complaint (ADMIN, STAFF)
+// Query: ?recipient=&reference=bill#12&status=DEAD&limit=50
+// ============================================================
+func (h *StaffHandler) HandleListEmails(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	if !permission.Has(ctx, permission.EmailManage) {
+		return createErrorResponse(http.StatusForbidden, "Chỉ ADMIN hoặc STAFF mới có quyền tra cứu email")
+	}
+
+	q := request.QueryStringParameters
+	filter := email.QueueFilter{
+		Recipient: strings.TrimSpace(q["recipient"]),
+		Reference: strings.TrimSpace(q["reference"]),
+		Status:    strings.ToUpper(strings.TrimSpace(q["status"])),
+	}
+	if v := q["limit"]; v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			return createErrorResponse(http.StatusBadRequest, "limit không hợp lệ")
+		}
+		filter.Limit = n
+	}
+
+	emails, err := email.DefaultQueue().List(ctx, filter)
+	if err != nil {
+		logger.Default().WithContext(ctx).Error("Failed to list emails", "error", err)
+		return createErrorResponse(http.StatusInternalServerError, "Không thể tra cứu email")
+	}
+
+	return createJSONResponse(http.StatusOK, map[string]interface{}{
+		"success": true,
+		"data":    emails,
+	})
+}
+
+// ============================================================
+// HandleRetryEmail - POST /api/admin/emails/{id}/retry
+// Đưa email dead-letter về hàng đợi để gửi lại ngay (ADMIN, STAFF)
+// 409 nếu email không ở trạng thái DEAD
+// ============================================================
+func (h *StaffHandler) HandleRetryEmail(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	if !permission.Has(ctx, permission.EmailManage) {
+		return createErrorResponse(http.StatusForbidden, "Chỉ ADMIN hoặc STAFF mới có quyền gửi lại email")
+	}
+
+	emailID, err := strconv.ParseInt(request.PathParameters["id"], 10, 64)
+	if err != nil || emailID <= 0 {
+		return createErrorResponse(http.StatusBadRequest, "id không hợp lệ")
+	}
+
+	if err := email.DefaultQueue().Retry(ctx, emailID); err != nil {
+		if errors.Is(err, email.ErrEmailNotRetryable) {
+			return createErrorResponse(http.StatusConflict, "Chỉ gửi lại được email ở trạng thái DEAD")
+		}
+		logger.Default().WithContext(ctx).Error("Failed to retry email", "emailId", emailID, "error", err)
+		return createErrorResponse(http.StatusInternalServerError, "Không thể gửi lại email")
+	}
+
+	return createJSONResponse(http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Email đã được đưa vào hàng đợi gửi lại",
+	})
+}
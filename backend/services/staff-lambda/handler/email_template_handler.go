@@ -0,0 +1,176 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/fpt-event-services/common/authctx"
+	"github.com/fpt-event-services/common/email"
+	"github.com/fpt-event-services/common/logger"
+	"github.com/fpt-event-services/common/permission"
+)
+
+// saveEmailTemplateRequest - Body PUT /api/admin/email-templates/{key}
+type saveEmailTemplateRequest struct {
+	Subject string  `json:"subject"`
+	HTML    string  `json:"html"`
+	Note    *string `json:"note"`
+}
+
+// rollbackEmailTemplateRequest - Body POST /api/admin/email-templates/{key}/rollback
+type rollbackEmailTemplateRequest struct {
+	Version *int `json:"version"`
+}
+
+// emailTemplateAuthError - 401 nếu chưa đăng nhập, 403 nếu thiếu quyền email.template.manage
+func emailTemplateAuthError(err error) (events.APIGatewayProxyResponse, error) {
+	if errors.Is(err, authctx.ErrUnauthenticated) {
+		return createErrorResponse(http.StatusUnauthorized, "Unauthorized")
+	}
+	return createErrorResponse(http.StatusForbidden, "Chỉ ADMIN mới có quyền quản lý mẫu email")
+}
+
+// emailTemplateError - Map lỗi của email.TemplateStore sang HTTP status
+func emailTemplateError(ctx context.Context, err error, action string) (events.APIGatewayProxyResponse, error) {
+	switch {
+	case errors.Is(err, email.ErrUnknownTemplate):
+		return createErrorResponse(http.StatusNotFound, "Không tìm thấy mẫu email")
+	case errors.Is(err, email.ErrTemplateVersionNotFound):
+		return createErrorResponse(http.StatusNotFound, "Không tìm thấy phiên bản mẫu email")
+	case errors.Is(err, email.ErrInvalidTemplate):
+		return createErrorResponse(http.StatusUnprocessableEntity, err.Error())
+	}
+	logger.Default().WithContext(ctx).Error("Failed to "+action+" email template", "error", err)
+	return createErrorResponse(http.StatusInternalServerError, "Không thể xử lý mẫu email")
+}
+
+// ============================================================
+// HandleListEmailTemplates - GET /api/admin/email-templates
+// Các loại email sửa được, phiên bản đang dùng (không có = mẫu mặc định) và mới nhất
+// ============================================================
+func (h *StaffHandler) HandleListEmailTemplates(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	if _, err := permission.Require(ctx, permission.EmailTemplateManage); err != nil {
+		return emailTemplateAuthError(err)
+	}
+
+	templates, err := email.DefaultTemplates().List(ctx)
+	if err != nil {
+		return emailTemplateError(ctx, err, "list")
+	}
+	return createJSONResponse(http.StatusOK, map[string]interface{}{
+		"success": true,
+		"data":    templates,
+	})
+}
+
+// ============================================================
+// HandleGetEmailTemplate - GET /api/admin/email-templates/{key}
+// Mẫu mặc định, biến dùng được, dữ liệu mẫu, nội dung đang dùng và lịch sử phiên bản
+// ============================================================
+func (h *StaffHandler) HandleGetEmailTemplate(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	if _, err := permission.Require(ctx, permission.EmailTemplateManage); err != nil {
+		return emailTemplateAuthError(err)
+	}
+
+	detail, err := email.DefaultTemplates().Get(ctx, request.PathParameters["key"])
+	if err != nil {
+		return emailTemplateError(ctx, err, "load")
+	}
+	return createJSONResponse(http.StatusOK, map[string]interface{}{
+		"success": true,
+		"data":    detail,
+	})
+}
+
+// ============================================================
+// HandleSaveEmailTemplate - PUT /api/admin/email-templates/{key}
+// Lưu phiên bản mới và dùng ngay cho email gửi sau đó
+// Body: {"subject":"...","html":"...","note":"..."}
+// 422 nếu template không parse / render được với dữ liệu mẫu
+// ============================================================
+func (h *StaffHandler) HandleSaveEmailTemplate(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	userID, err := permission.Require(ctx, permission.EmailTemplateManage)
+	if err != nil {
+		return emailTemplateAuthError(err)
+	}
+
+	var req saveEmailTemplateRequest
+	if err := json.Unmarshal([]byte(request.Body), &req); err != nil {
+		return createErrorResponse(http.StatusBadRequest, "Invalid request body")
+	}
+	if req.Note != nil {
+		note := strings.TrimSpace(*req.Note)
+		if len(note) > 255 {
+			return createErrorResponse(http.StatusBadRequest, "note tối đa 255 ký tự")
+		}
+		req.Note = &note
+	}
+
+	version, err := email.DefaultTemplates().SaveVersion(ctx, request.PathParameters["key"], req.Subject, req.HTML, req.Note, userID)
+	if err != nil {
+		return emailTemplateError(ctx, err, "save")
+	}
+	return createJSONResponse(http.StatusOK, map[string]interface{}{
+		"success": true,
+		"data":    version,
+	})
+}
+
+// ============================================================
+// HandlePreviewEmailTemplate - POST /api/admin/email-templates/{key}/preview
+// Render với dữ liệu mẫu, không lưu, không gửi
+// Body: {} (đang dùng), {"version":3} (0 = mặc định) hoặc bản nháp {"subject":"...","html":"..."}
+// ============================================================
+func (h *StaffHandler) HandlePreviewEmailTemplate(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	if _, err := permission.Require(ctx, permission.EmailTemplateManage); err != nil {
+		return emailTemplateAuthError(err)
+	}
+
+	var req email.PreviewRequest
+	if strings.TrimSpace(request.Body) != "" {
+		if err := json.Unmarshal([]byte(request.Body), &req); err != nil {
+			return createErrorResponse(http.StatusBadRequest, "Invalid request body")
+		}
+	}
+
+	rendered, err := email.DefaultTemplates().Preview(ctx, request.PathParameters["key"], req)
+	if err != nil {
+		return emailTemplateError(ctx, err, "preview")
+	}
+	return createJSONResponse(http.StatusOK, map[string]interface{}{
+		"success": true,
+		"data":    rendered,
+	})
+}
+
+// ============================================================
+// HandleRollbackEmailTemplate - POST /api/admin/email-templates/{key}/rollback
+// Kích hoạt lại một phiên bản cũ; {"version":0} quay về mẫu mặc định trong code
+// ============================================================
+func (h *StaffHandler) HandleRollbackEmailTemplate(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	if _, err := permission.Require(ctx, permission.EmailTemplateManage); err != nil {
+		return emailTemplateAuthError(err)
+	}
+
+	var req rollbackEmailTemplateRequest
+	if err := json.Unmarshal([]byte(request.Body), &req); err != nil || req.Version == nil {
+		return createErrorResponse(http.StatusBadRequest, "version là bắt buộc")
+	}
+
+	key := request.PathParameters["key"]
+	if err := email.DefaultTemplates().Activate(ctx, key, *req.Version); err != nil {
+		return emailTemplateError(ctx, err, "roll back")
+	}
+	detail, err := email.DefaultTemplates().Get(ctx, key)
+	if err != nil {
+		return emailTemplateError(ctx, err, "load")
+	}
+	return createJSONResponse(http.StatusOK, map[string]interface{}{
+		"success": true,
+		"data":    detail,
+	})
+}
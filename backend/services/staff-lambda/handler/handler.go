@@ -3,10 +3,14 @@ package handler
 import (
 	"context"
 	"encoding/json"
-	"fmt"
+	"errors"
 	"net/http"
+	"strconv"
 
 	"github.com/aws/aws-lambda-go/events"
+	"github.com/fpt-event-services/common/authctx"
+	"github.com/fpt-event-services/common/campus"
+	"github.com/fpt-event-services/common/permission"
 	"github.com/fpt-event-services/services/staff-lambda/models"
 	"github.com/fpt-event-services/services/staff-lambda/usecase"
 )
@@ -27,22 +31,16 @@ func NewStaffHandler() *StaffHandler {
 // HandleCheckin - POST /api/staff/checkin
 // Check-in vé bằng QR code
 // KHỚP VỚI Java StaffCheckinController
-// ✅ CHỈ CHO PHÉP ORGANIZER (không phải STAFF hay ADMIN)
+// Cần quyền ticket.checkin (mặc định chỉ ORGANIZER, không phải STAFF hay ADMIN)
 // ============================================================
 func (h *StaffHandler) HandleCheckin(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
-	// ✅ CHỈ CHO PHÉP ORGANIZER
-	role := request.Headers["X-User-Role"]
-	if role != "ORGANIZER" {
+	if !permission.Has(ctx, permission.TicketCheckin) {
 		return createErrorResponse(http.StatusForbidden, "Chỉ Organizer mới có quyền quét mã QR check-in")
 	}
 
 	// Lấy userID để kiểm tra ownership
-	userIDStr := request.Headers["X-User-Id"]
-	userID := 0
-	if userIDStr != "" {
-		fmt.Sscanf(userIDStr, "%d", &userID)
-	}
-	if userID == 0 {
+	userID, ok := authctx.UserID(ctx)
+	if !ok {
 		return createErrorResponse(http.StatusUnauthorized, "Không xác định được người dùng")
 	}
 
@@ -70,26 +68,53 @@ func (h *StaffHandler) HandleCheckin(ctx context.Context, request events.APIGate
 	return createJSONResponse(statusCode, result)
 }
 
+// ============================================================
+// HandleGetEventOccupancy - GET /api/staff/events/{id}/occupancy
+// Số người đang ở trong khu vực (đã check-in trừ đã check-out) và % sức chứa
+// Cần quyền event.stats.view; Organizer chỉ xem sự kiện của mình
+// ============================================================
+func (h *StaffHandler) HandleGetEventOccupancy(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	userID, err := permission.Require(ctx, permission.EventStatsView)
+	if errors.Is(err, authctx.ErrUnauthenticated) {
+		return createErrorResponse(http.StatusUnauthorized, "Không xác định được người dùng")
+	}
+	if err != nil {
+		return createErrorResponse(http.StatusForbidden, "Bạn không có quyền xem số người trong sự kiện")
+	}
+	role := authctx.Role(ctx)
+	eventID, err := strconv.Atoi(request.PathParameters["id"])
+	if err != nil || eventID <= 0 {
+		return createErrorResponse(http.StatusBadRequest, "eventId không hợp lệ")
+	}
+
+	occupancy, err := h.useCase.GetEventOccupancy(ctx, userID, role, eventID)
+	if errors.Is(err, usecase.ErrOccupancyForbidden) {
+		return createErrorResponse(http.StatusForbidden, "Bạn không có quyền xem số người trong sự kiện này")
+	}
+	if err != nil {
+		return createErrorResponse(http.StatusInternalServerError, "Lỗi khi lấy số người trong sự kiện")
+	}
+	if occupancy == nil {
+		return createErrorResponse(http.StatusNotFound, "Không tìm thấy sự kiện")
+	}
+
+	return createJSONResponse(http.StatusOK, occupancy)
+}
+
 // ============================================================
 // HandleCheckout - POST /api/staff/checkout
 // Check-out vé bằng QR code
 // KHỚP VỚI Java StaffCheckoutController
-// ✅ CHỈ CHO PHÉP ORGANIZER (không phải STAFF hay ADMIN)
+// Cần quyền ticket.checkin (mặc định chỉ ORGANIZER, không phải STAFF hay ADMIN)
 // ============================================================
 func (h *StaffHandler) HandleCheckout(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
-	// ✅ CHỈ CHO PHÉP ORGANIZER
-	role := request.Headers["X-User-Role"]
-	if role != "ORGANIZER" {
+	if !permission.Has(ctx, permission.TicketCheckin) {
 		return createErrorResponse(http.StatusForbidden, "Chỉ Organizer mới có quyền quét mã QR check-out")
 	}
 
 	// Lấy userID để kiểm tra ownership
-	userIDStr := request.Headers["X-User-Id"]
-	userID := 0
-	if userIDStr != "" {
-		fmt.Sscanf(userIDStr, "%d", &userID)
-	}
-	if userID == 0 {
+	userID, ok := authctx.UserID(ctx)
+	if !ok {
 		return createErrorResponse(http.StatusUnauthorized, "Không xác định được người dùng")
 	}
 
@@ -124,9 +149,7 @@ func createJSONResponse(statusCode int, data interface{}) (events.APIGatewayProx
 		return events.APIGatewayProxyResponse{
 			StatusCode: http.StatusInternalServerError,
 			Headers: map[string]string{
-				"Content-Type":                     "application/json;charset=UTF-8",
-				"Access-Control-Allow-Origin":      "*",
-				"Access-Control-Allow-Credentials": "true",
+				"Content-Type": "application/json;charset=UTF-8",
 			},
 			Body: `{"error":"Failed to serialize response"}`,
 		}, nil
@@ -135,9 +158,7 @@ func createJSONResponse(statusCode int, data interface{}) (events.APIGatewayProx
 	return events.APIGatewayProxyResponse{
 		StatusCode: statusCode,
 		Headers: map[string]string{
-			"Content-Type":                     "application/json;charset=UTF-8",
-			"Access-Control-Allow-Origin":      "*",
-			"Access-Control-Allow-Credentials": "true",
+			"Content-Type": "application/json;charset=UTF-8",
 		},
 		Body: string(body),
 	}, nil
@@ -152,9 +173,7 @@ func createErrorResponse(statusCode int, message string) (events.APIGatewayProxy
 	return events.APIGatewayProxyResponse{
 		StatusCode: statusCode,
 		Headers: map[string]string{
-			"Content-Type":                     "application/json;charset=UTF-8",
-			"Access-Control-Allow-Origin":      "*",
-			"Access-Control-Allow-Credentials": "true",
+			"Content-Type": "application/json;charset=UTF-8",
 		},
 		Body: string(body),
 	}, nil
@@ -166,14 +185,18 @@ func createErrorResponse(statusCode int, message string) (events.APIGatewayProxy
 // KHỚP VỚI Java ReportStaffController.listReports()
 // ============================================================
 func (h *StaffHandler) HandleGetReports(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
-	// Check role (ADMIN, STAFF)
-	role := request.Headers["X-User-Role"]
-	if role != "ADMIN" && role != "STAFF" {
+	if !permission.Has(ctx, permission.ReportReview) {
 		return createErrorResponse(http.StatusForbidden, "Bạn không có quyền truy cập")
 	}
 
+	// STAFF/ADMIN gắn campus chỉ thấy report của campus mình
+	scope, err := campus.Scope(ctx)
+	if err != nil {
+		return createErrorResponse(http.StatusInternalServerError, "Lỗi khi kiểm tra campus")
+	}
+
 	// Get reports
-	reports, err := h.useCase.GetReports(ctx)
+	reports, err := h.useCase.GetReports(ctx, scope)
 	if err != nil {
 		return createErrorResponse(http.StatusInternalServerError, "Lỗi khi lấy danh sách báo cáo")
 	}
@@ -187,9 +210,7 @@ func (h *StaffHandler) HandleGetReports(ctx context.Context, request events.APIG
 // KHỚP VỚI Java ReportStaffController.detailReport()
 // ============================================================
 func (h *StaffHandler) HandleGetReportDetail(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
-	// Check role (ADMIN, STAFF)
-	role := request.Headers["X-User-Role"]
-	if role != "ADMIN" && role != "STAFF" {
+	if !permission.Has(ctx, permission.ReportReview) {
 		return createErrorResponse(http.StatusForbidden, "Bạn không có quyền truy cập")
 	}
 
@@ -219,13 +240,11 @@ func (h *StaffHandler) HandleGetReportDetail(ctx context.Context, request events
 
 // ============================================================
 // HandleGetSystemConfig - GET /api/admin/config/system
-// Lấy cấu hình hệ thống (ADMIN only)
+// Lấy cấu hình hệ thống (quyền system.config)
 // KHỚP VỚI Frontend SystemConfig.tsx
 // ============================================================
 func (h *StaffHandler) HandleGetSystemConfig(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
-	// Check role (ADMIN only)
-	role := request.Headers["X-User-Role"]
-	if role != "ADMIN" {
+	if !permission.Has(ctx, permission.SystemConfig) {
 		return createErrorResponse(http.StatusForbidden, "Chỉ ADMIN mới có quyền truy cập")
 	}
 
@@ -245,13 +264,11 @@ func (h *StaffHandler) HandleGetSystemConfig(ctx context.Context, request events
 
 // ============================================================
 // HandleUpdateSystemConfig - POST /api/admin/config/system
-// Cập nhật cấu hình hệ thống (ADMIN only)
+// Cập nhật cấu hình hệ thống (quyền system.config)
 // KHỚP VỚI Frontend SystemConfig.tsx
 // ============================================================
 func (h *StaffHandler) HandleUpdateSystemConfig(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
-	// Check role (ADMIN only)
-	role := request.Headers["X-User-Role"]
-	if role != "ADMIN" {
+	if !permission.Has(ctx, permission.SystemConfig) {
 		return createErrorResponse(http.StatusForbidden, "Chỉ ADMIN mới có quyền cập nhật cấu hình")
 	}
 
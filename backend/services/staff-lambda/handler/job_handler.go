@@ -0,0 +1,146 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/fpt-event-services/common/authctx"
+	"github.com/fpt-event-services/common/permission"
+	"github.com/fpt-event-services/common/scheduler"
+)
+
+// jobHistoryLimit - Số lần chạy gần nhất trả về cho mỗi job
+const jobHistoryLimit = 10
+
+// maxBackfillDays - Khoảng ngày tối đa của một lần backfill
+const maxBackfillDays = 366
+
+// ============================================================
+// HandleListJobs - GET /api/admin/jobs
+// Danh sách job định kỳ: lịch chạy, lần chạy kế tiếp, lịch sử Job_Run (quyền job.manage)
+// Query: ?history=N (mặc định 10, tối đa 50)
+// ============================================================
+func (h *StaffHandler) HandleListJobs(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	if !permission.Has(ctx, permission.JobManage) {
+		return createErrorResponse(http.StatusForbidden, "Chỉ ADMIN mới có quyền xem danh sách job")
+	}
+
+	limit := jobHistoryLimit
+	if v := request.QueryStringParameters["history"]; v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return createErrorResponse(http.StatusBadRequest, "history không hợp lệ")
+		}
+		limit = min(n, 50)
+	}
+
+	return createJSONResponse(http.StatusOK, map[string]interface{}{
+		"success": true,
+		"data":    scheduler.DefaultManager().List(ctx, limit),
+	})
+}
+
+// ============================================================
+// HandleRunJobNow - POST /api/admin/jobs/{name}/run-now
+// Chạy job ngay lập tức (chạy nền), trả về bản ghi Job_Run (quyền job.manage)
+// 409 nếu job đang chạy (trên instance này hoặc instance khác)
+// ============================================================
+func (h *StaffHandler) HandleRunJobNow(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	if !permission.Has(ctx, permission.JobManage) {
+		return createErrorResponse(http.StatusForbidden, "Chỉ ADMIN mới có quyền chạy job")
+	}
+
+	name := request.PathParameters["name"]
+	if name == "" {
+		return createErrorResponse(http.StatusBadRequest, "Thiếu tên job")
+	}
+
+	var triggeredBy *int
+	if userID, ok := authctx.UserID(ctx); ok {
+		triggeredBy = &userID
+	}
+
+	run, err := scheduler.DefaultManager().RunNow(name, triggeredBy)
+	if err != nil {
+		switch {
+		case errors.Is(err, scheduler.ErrJobNotFound):
+			return createErrorResponse(http.StatusNotFound, "Không tìm thấy job: "+name)
+		case errors.Is(err, scheduler.ErrJobAlreadyRunning):
+			return createErrorResponse(http.StatusConflict, "Job đang chạy, vui lòng thử lại sau")
+		case errors.Is(err, scheduler.ErrJobLockedElsewhere):
+			return createErrorResponse(http.StatusConflict, "Job đang chạy trên instance khác, vui lòng thử lại sau")
+		default:
+			return createErrorResponse(http.StatusInternalServerError, "Lỗi khi kích hoạt job")
+		}
+	}
+
+	return createJSONResponse(http.StatusAccepted, map[string]interface{}{
+		"success": true,
+		"message": "Đã kích hoạt job",
+		"data":    run,
+	})
+}
+
+// ============================================================
+// HandleBackfillJob - POST /api/admin/jobs/{name}/backfill
+// Chạy lại job cho khoảng ngày (chạy nền), trả về bản ghi Job_Run (quyền job.manage)
+// Body: {"from": "2026-01-01", "to": "2026-01-31"} (tối đa maxBackfillDays ngày)
+// ============================================================
+func (h *StaffHandler) HandleBackfillJob(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	if !permission.Has(ctx, permission.JobManage) {
+		return createErrorResponse(http.StatusForbidden, "Chỉ ADMIN mới có quyền chạy job")
+	}
+
+	name := request.PathParameters["name"]
+	if name == "" {
+		return createErrorResponse(http.StatusBadRequest, "Thiếu tên job")
+	}
+
+	var body struct {
+		From string `json:"from"`
+		To   string `json:"to"`
+	}
+	if err := json.Unmarshal([]byte(request.Body), &body); err != nil {
+		return createErrorResponse(http.StatusBadRequest, "Body không hợp lệ")
+	}
+	from, errFrom := time.Parse("2006-01-02", body.From)
+	to, errTo := time.Parse("2006-01-02", body.To)
+	if errFrom != nil || errTo != nil {
+		return createErrorResponse(http.StatusBadRequest, "from/to phải có dạng YYYY-MM-DD")
+	}
+	if to.Before(from) || to.Sub(from) >= maxBackfillDays*24*time.Hour {
+		return createErrorResponse(http.StatusBadRequest, "Khoảng ngày không hợp lệ (from <= to, tối đa "+strconv.Itoa(maxBackfillDays)+" ngày)")
+	}
+
+	var triggeredBy *int
+	if userID, ok := authctx.UserID(ctx); ok {
+		triggeredBy = &userID
+	}
+
+	run, err := scheduler.DefaultManager().Backfill(name, from, to, triggeredBy)
+	if err != nil {
+		switch {
+		case errors.Is(err, scheduler.ErrJobNotFound):
+			return createErrorResponse(http.StatusNotFound, "Không tìm thấy job: "+name)
+		case errors.Is(err, scheduler.ErrBackfillNotSupported):
+			return createErrorResponse(http.StatusBadRequest, "Job không hỗ trợ backfill: "+name)
+		case errors.Is(err, scheduler.ErrJobAlreadyRunning):
+			return createErrorResponse(http.StatusConflict, "Job đang chạy, vui lòng thử lại sau")
+		case errors.Is(err, scheduler.ErrJobLockedElsewhere):
+			return createErrorResponse(http.StatusConflict, "Job đang chạy trên instance khác, vui lòng thử lại sau")
+		default:
+			return createErrorResponse(http.StatusInternalServerError, "Lỗi khi kích hoạt job")
+		}
+	}
+
+	return createJSONResponse(http.StatusAccepted, map[string]interface{}{
+		"success": true,
+		"message": "Đã kích hoạt backfill",
+		"data":    run,
+	})
+}
@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/fpt-event-services/common/ledger"
+	"github.com/fpt-event-services/common/logger"
+	"github.com/fpt-event-services/common/permission"
+)
+
+// ============================================================
+// HandleTrialBalance - GET /api/admin/ledger/trial-balance
+// Bảng cân đối thử: tổng Nợ / Có theo tài khoản sổ cái (quyền ledger.view)
+// Query: ?asOf=YYYY-MM-DD - chỉ tính bút toán đến hết ngày này
+// ============================================================
+func (h *StaffHandler) HandleTrialBalance(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	if !permission.Has(ctx, permission.LedgerView) {
+		return createErrorResponse(http.StatusForbidden, "Chỉ ADMIN mới có quyền xem sổ cái")
+	}
+
+	var asOf *time.Time
+	if v := request.QueryStringParameters["asOf"]; v != "" {
+		day, err := time.ParseInLocation("2006-01-02", v, time.Local)
+		if err != nil {
+			return createErrorResponse(http.StatusBadRequest, "asOf phải có dạng YYYY-MM-DD")
+		}
+		asOf = &day
+	}
+
+	tb, err := ledger.GetTrialBalance(ctx, asOf)
+	if err != nil {
+		logger.Default().WithContext(ctx).Error("Trial balance failed", "error", err)
+		return createErrorResponse(http.StatusInternalServerError, "Lỗi khi lập bảng cân đối thử")
+	}
+	return createJSONResponse(http.StatusOK, map[string]interface{}{
+		"success": true,
+		"data":    tb,
+	})
+}
@@ -7,8 +7,12 @@ import (
 	"strconv"
 
 	"github.com/aws/aws-lambda-go/events"
+	"github.com/fpt-event-services/common/authctx"
+	"github.com/fpt-event-services/common/campus"
 	"github.com/fpt-event-services/common/logger"
 	"github.com/fpt-event-services/common/models"
+	"github.com/fpt-event-services/common/pagination"
+	"github.com/fpt-event-services/common/permission"
 	"github.com/fpt-event-services/services/staff-lambda/usecase"
 )
 
@@ -32,9 +36,7 @@ func NewReportHandler() *ReportHandler {
 func (h *ReportHandler) HandleGetReportDetail(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
 	log := logger.Default().WithContext(ctx)
 
-	// Check role (STAFF, ADMIN)
-	role := request.Headers["X-User-Role"]
-	if role != "STAFF" && role != "ADMIN" {
+	if !permission.Has(ctx, permission.ReportReview) {
 		return createErrorResponse(http.StatusForbidden, "Chỉ Staff/Admin mới được xem chi tiết report")
 	}
 
@@ -67,47 +69,28 @@ func (h *ReportHandler) HandleGetReportDetail(ctx context.Context, request event
 func (h *ReportHandler) HandleListReports(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
 	log := logger.Default().WithContext(ctx)
 
-	// Check role (STAFF, ADMIN)
-	role := request.Headers["X-User-Role"]
-	if role != "STAFF" && role != "ADMIN" {
+	if !permission.Has(ctx, permission.ReportReview) {
 		return createErrorResponse(http.StatusForbidden, "Chỉ Staff/Admin mới được xem danh sách report")
 	}
 
 	// Parse query params
 	status := request.QueryStringParameters["status"]
-	pageStr := request.QueryStringParameters["page"]
-	pageSizeStr := request.QueryStringParameters["pageSize"]
+	p := pagination.ParseParams(request.QueryStringParameters, pagination.DefaultPageSize, pagination.MaxPageSize)
 
-	page := 1
-	pageSize := 10
-
-	if pageStr != "" {
-		if p, err := strconv.Atoi(pageStr); err == nil && p > 0 {
-			page = p
-		}
-	}
-
-	if pageSizeStr != "" {
-		if ps, err := strconv.Atoi(pageSizeStr); err == nil && ps > 0 {
-			pageSize = ps
-		}
+	// STAFF/ADMIN gắn campus chỉ thấy report của campus mình
+	scope, err := campus.Scope(ctx)
+	if err != nil {
+		return createErrorResponse(http.StatusInternalServerError, "Lỗi khi kiểm tra campus")
 	}
 
 	// List reports
-	list, err := h.useCase.ListReports(ctx, status, page, pageSize)
+	page, err := h.useCase.ListReports(ctx, status, p, scope)
 	if err != nil {
-		log.Info("Failed to list reports", "status", status, "page", page, "error", err)
+		log.Info("Failed to list reports", "status", status, "page", p.Page, "error", err)
 		return createErrorResponse(http.StatusBadRequest, err.Error())
 	}
 
-	resp := map[string]interface{}{
-		"status": "success",
-		"data":   list,
-		"page":   page,
-		"size":   len(list),
-	}
-
-	return createJSONResponse(http.StatusOK, resp)
+	return createJSONResponse(http.StatusOK, page)
 }
 
 // ============================================================
@@ -142,23 +125,16 @@ func (h *ReportHandler) HandleListReports(ctx context.Context, request events.AP
 func (h *ReportHandler) HandleProcessReport(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
 	log := logger.Default().WithContext(ctx)
 
-	// 1) Check role (STAFF, ADMIN)
-	role := request.Headers["X-User-Role"]
-	if role != "STAFF" && role != "ADMIN" {
+	if !permission.Has(ctx, permission.ReportReview) {
 		return createErrorResponse(http.StatusForbidden, "Chỉ Staff/Admin mới được xử lý report")
 	}
 
-	// Get staffId from headers
-	staffIDStr := request.Headers["X-User-Id"]
-	if staffIDStr == "" {
+	// Get staffId from context (set by authMiddleware)
+	staffID, ok := authctx.UserID(ctx)
+	if !ok {
 		return createErrorResponse(http.StatusUnauthorized, "Không tìm thấy staffId")
 	}
 
-	staffID, err := strconv.Atoi(staffIDStr)
-	if err != nil || staffID <= 0 {
-		return createErrorResponse(http.StatusUnauthorized, "staffId không hợp lệ")
-	}
-
 	// 2) Parse request body
 	var req models.ProcessReportRequest
 	if err := json.Unmarshal([]byte(request.Body), &req); err != nil {
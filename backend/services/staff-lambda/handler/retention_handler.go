@@ -0,0 +1,119 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/fpt-event-services/common/authctx"
+	"github.com/fpt-event-services/common/permission"
+	"github.com/fpt-event-services/common/retention"
+	"github.com/fpt-event-services/common/scheduler"
+)
+
+// retentionJobName - Job áp chính sách lưu giữ (common/scheduler/jobs.go)
+const retentionJobName = "data-retention"
+
+// ============================================================
+// HandleGetRetentionPolicies - GET /api/admin/retention
+// Chính sách lưu giữ: bảng, hành động (ARCHIVE/DELETE), số ngày giữ đang áp dụng (quyền system.config)
+// ============================================================
+func (h *StaffHandler) HandleGetRetentionPolicies(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	if !permission.Has(ctx, permission.SystemConfig) {
+		return createErrorResponse(http.StatusForbidden, "Chỉ ADMIN mới có quyền xem chính sách lưu giữ dữ liệu")
+	}
+	policies, err := retention.Policies(ctx)
+	if err != nil {
+		return retentionErrorResponse(err)
+	}
+	return createJSONResponse(http.StatusOK, map[string]interface{}{
+		"success": true,
+		"data":    policies,
+	})
+}
+
+// ============================================================
+// HandleUpdateRetentionPolicies - PUT /api/admin/retention
+// Sửa số ngày giữ của một hoặc nhiều chính sách; 0 = giữ vĩnh viễn (quyền system.config)
+// Body: {"tickets": 1825, "loginHistory": 180}
+// ============================================================
+func (h *StaffHandler) HandleUpdateRetentionPolicies(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	if !permission.Has(ctx, permission.SystemConfig) {
+		return createErrorResponse(http.StatusForbidden, "Chỉ ADMIN mới có quyền cập nhật chính sách lưu giữ dữ liệu")
+	}
+
+	var values map[string]int
+	if err := json.Unmarshal([]byte(request.Body), &values); err != nil {
+		return createErrorResponse(http.StatusBadRequest, "Dữ liệu không hợp lệ")
+	}
+	if err := retention.Update(ctx, values); err != nil {
+		return retentionErrorResponse(err)
+	}
+	policies, err := retention.Policies(ctx)
+	if err != nil {
+		return retentionErrorResponse(err)
+	}
+	return createJSONResponse(http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Cập nhật chính sách lưu giữ thành công",
+		"data":    policies,
+	})
+}
+
+// ============================================================
+// HandleRunRetention - POST /api/admin/retention/run?dryRun=true|false
+// dryRun (mặc định true): đếm số dòng sẽ bị lưu trữ / xóa theo từng chính sách, không sửa dữ liệu
+// dryRun=false: kích hoạt job data-retention (chạy nền, ghi Job_Run), 409 nếu job đang chạy
+// Quyền job.manage
+// ============================================================
+func (h *StaffHandler) HandleRunRetention(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	if !permission.Has(ctx, permission.JobManage) {
+		return createErrorResponse(http.StatusForbidden, "Chỉ ADMIN mới có quyền chạy chính sách lưu giữ dữ liệu")
+	}
+
+	switch request.QueryStringParameters["dryRun"] {
+	case "", "true":
+		report, err := retention.Run(ctx, true)
+		if report == nil {
+			return retentionErrorResponse(err)
+		}
+		return createJSONResponse(http.StatusOK, map[string]interface{}{
+			"success": err == nil,
+			"data":    report,
+		})
+	case "false":
+	default:
+		return createErrorResponse(http.StatusBadRequest, "dryRun phải là true hoặc false")
+	}
+
+	var triggeredBy *int
+	if userID, ok := authctx.UserID(ctx); ok {
+		triggeredBy = &userID
+	}
+	run, err := scheduler.DefaultManager().RunNow(retentionJobName, triggeredBy)
+	if err != nil {
+		switch {
+		case errors.Is(err, scheduler.ErrJobAlreadyRunning), errors.Is(err, scheduler.ErrJobLockedElsewhere):
+			return createErrorResponse(http.StatusConflict, "Job lưu giữ dữ liệu đang chạy, vui lòng thử lại sau")
+		default:
+			return createErrorResponse(http.StatusInternalServerError, "Lỗi khi kích hoạt job lưu giữ dữ liệu")
+		}
+	}
+	return createJSONResponse(http.StatusAccepted, map[string]interface{}{
+		"success": true,
+		"message": "Đã kích hoạt job lưu giữ dữ liệu",
+		"data":    run,
+	})
+}
+
+// retentionErrorResponse map lỗi chính sách lưu giữ sang HTTP status
+func retentionErrorResponse(err error) (events.APIGatewayProxyResponse, error) {
+	if errors.Is(err, retention.ErrInvalidPolicy) {
+		return createErrorResponse(http.StatusBadRequest, err.Error())
+	}
+	log.Printf("[RETENTION] ❌ %v", err)
+	return createErrorResponse(http.StatusInternalServerError, "Lỗi khi xử lý chính sách lưu giữ dữ liệu")
+}
@@ -0,0 +1,160 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/fpt-event-services/common/permission"
+	"github.com/fpt-event-services/common/rules"
+)
+
+// ============================================================
+// HandleGetRules - GET /api/admin/rules
+// Quy tắc nghiệp vụ đang có hiệu lực và nguồn của từng giá trị (quyền system.config)
+// Query: ?eventId=N - tính cả phần ghi đè của sự kiện
+// ============================================================
+func (h *StaffHandler) HandleGetRules(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	if !permission.Has(ctx, permission.SystemConfig) {
+		return createErrorResponse(http.StatusForbidden, "Chỉ ADMIN mới có quyền xem quy tắc hệ thống")
+	}
+
+	var eventID *int
+	if v := request.QueryStringParameters["eventId"]; v != "" {
+		id, err := strconv.Atoi(v)
+		if err != nil || id <= 0 {
+			return createErrorResponse(http.StatusBadRequest, "eventId không hợp lệ")
+		}
+		eventID = &id
+	}
+
+	view, err := rules.Effective(ctx, eventID)
+	if err != nil {
+		return rulesErrorResponse(err)
+	}
+	return createJSONResponse(http.StatusOK, map[string]interface{}{
+		"success": true,
+		"data":    view,
+	})
+}
+
+// ============================================================
+// HandleUpdateRules - PUT /api/admin/rules
+// Sửa giá trị hệ thống của một hoặc nhiều quy tắc (quyền system.config)
+// Body: {"cancelCutoffHours": 48, "maxEventsPerDay": 3}
+// ============================================================
+func (h *StaffHandler) HandleUpdateRules(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	if !permission.Has(ctx, permission.SystemConfig) {
+		return createErrorResponse(http.StatusForbidden, "Chỉ ADMIN mới có quyền cập nhật quy tắc hệ thống")
+	}
+
+	var values map[string]int
+	if err := json.Unmarshal([]byte(request.Body), &values); err != nil {
+		return createErrorResponse(http.StatusBadRequest, "Dữ liệu không hợp lệ")
+	}
+	if err := rules.Update(ctx, values); err != nil {
+		return rulesErrorResponse(err)
+	}
+
+	view, err := rules.Effective(ctx, nil)
+	if err != nil {
+		return rulesErrorResponse(err)
+	}
+	return createJSONResponse(http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Cập nhật quy tắc thành công",
+		"data":    view,
+	})
+}
+
+// ============================================================
+// HandleUpdateEventRules - PUT /api/admin/rules/events/{id}
+// Ghi đè hạn hủy / hạn cập nhật cho một sự kiện; null = theo quy tắc hệ thống (quyền system.config)
+// Body: {"cancelCutoffHours": 72, "updateWindowHours": null}
+// ============================================================
+func (h *StaffHandler) HandleUpdateEventRules(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	if !permission.Has(ctx, permission.SystemConfig) {
+		return createErrorResponse(http.StatusForbidden, "Chỉ ADMIN mới có quyền cập nhật quy tắc sự kiện")
+	}
+
+	eventID, err := strconv.Atoi(request.PathParameters["id"])
+	if err != nil || eventID <= 0 {
+		return createErrorResponse(http.StatusBadRequest, "eventId không hợp lệ")
+	}
+	var overrides rules.Overrides
+	if err := json.Unmarshal([]byte(request.Body), &overrides); err != nil {
+		return createErrorResponse(http.StatusBadRequest, "Dữ liệu không hợp lệ")
+	}
+	if err := rules.SetEventOverrides(ctx, eventID, overrides); err != nil {
+		return rulesErrorResponse(err)
+	}
+
+	view, err := rules.Effective(ctx, &eventID)
+	if err != nil {
+		return rulesErrorResponse(err)
+	}
+	return createJSONResponse(http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Cập nhật quy tắc sự kiện thành công",
+		"data":    view,
+	})
+}
+
+// ============================================================
+// HandleOrganizerFee - GET / PUT /api/admin/rules/organizers/{id}
+// Phí nền tảng riêng của một organizer; null = theo rule.platform_fee_bps (quyền system.config)
+// Body (PUT): {"platformFeeBps": 250}
+// Phí ghi đè của từng sự kiện nằm ở PUT /api/admin/rules/events/{id}
+// ============================================================
+func (h *StaffHandler) HandleOrganizerFee(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	if !permission.Has(ctx, permission.SystemConfig) {
+		return createErrorResponse(http.StatusForbidden, "Chỉ ADMIN mới có quyền cấu hình phí nền tảng")
+	}
+
+	organizerID, err := strconv.Atoi(request.PathParameters["id"])
+	if err != nil || organizerID <= 0 {
+		return createErrorResponse(http.StatusBadRequest, "organizerId không hợp lệ")
+	}
+
+	message := ""
+	if request.HTTPMethod == http.MethodPut {
+		var body struct {
+			PlatformFeeBps *int `json:"platformFeeBps"`
+		}
+		if err := json.Unmarshal([]byte(request.Body), &body); err != nil {
+			return createErrorResponse(http.StatusBadRequest, "Dữ liệu không hợp lệ")
+		}
+		if err := rules.SetOrganizerFee(ctx, organizerID, body.PlatformFeeBps); err != nil {
+			return rulesErrorResponse(err)
+		}
+		message = "Cập nhật phí nền tảng thành công"
+	}
+
+	fee, err := rules.GetOrganizerFee(ctx, organizerID)
+	if err != nil {
+		return rulesErrorResponse(err)
+	}
+	resp := map[string]interface{}{
+		"success": true,
+		"data":    fee,
+	}
+	if message != "" {
+		resp["message"] = message
+	}
+	return createJSONResponse(http.StatusOK, resp)
+}
+
+func rulesErrorResponse(err error) (events.APIGatewayProxyResponse, error) {
+	switch {
+	case errors.Is(err, rules.ErrInvalidRule):
+		return createErrorResponse(http.StatusBadRequest, err.Error())
+	case errors.Is(err, rules.ErrEventNotFound):
+		return createErrorResponse(http.StatusNotFound, "Không tìm thấy sự kiện")
+	case errors.Is(err, rules.ErrOrganizerNotFound):
+		return createErrorResponse(http.StatusNotFound, "Không tìm thấy organizer")
+	}
+	return createErrorResponse(http.StatusInternalServerError, "Lỗi khi xử lý quy tắc hệ thống")
+}
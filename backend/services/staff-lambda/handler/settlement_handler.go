@@ -0,0 +1,176 @@
+package handler
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/fpt-event-services/common/authctx"
+	"github.com/fpt-event-services/common/logger"
+	"github.com/fpt-event-services/common/permission"
+	"github.com/fpt-event-services/common/settlement"
+)
+
+// ============================================================
+// HandleListSettlements - GET /api/admin/settlements
+// Bản đối soát doanh thu của mọi organizer, kỳ mới nhất trước (quyền settlement.manage)
+// Query: ?periodId=N&organizerId=N&status=PENDING|APPROVED|PAID
+// ============================================================
+func (h *StaffHandler) HandleListSettlements(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	if !permission.Has(ctx, permission.SettlementManage) {
+		return createErrorResponse(http.StatusForbidden, "Chỉ ADMIN mới có quyền xem đối soát doanh thu")
+	}
+
+	var f settlement.Filter
+	for name, dst := range map[string]*int{"periodId": &f.PeriodID, "organizerId": &f.OrganizerID} {
+		if v := request.QueryStringParameters[name]; v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil || n <= 0 {
+				return createErrorResponse(http.StatusBadRequest, name+" không hợp lệ")
+			}
+			*dst = n
+		}
+	}
+	if f.Status = request.QueryStringParameters["status"]; f.Status != "" && !settlement.ValidStatus(f.Status) {
+		return createErrorResponse(http.StatusBadRequest, "status phải là PENDING, APPROVED hoặc PAID")
+	}
+
+	list, err := settlement.List(ctx, f)
+	if err != nil {
+		return settlementErrorResponse(ctx, err)
+	}
+	return createJSONResponse(http.StatusOK, map[string]interface{}{
+		"success": true,
+		"data":    list,
+	})
+}
+
+// ============================================================
+// HandleGetSettlement - GET /api/admin/settlements/{id}[?format=csv]
+// Chi tiết theo sự kiện; format=csv: bảng kê để gửi ban tổ chức / kế toán
+// ============================================================
+func (h *StaffHandler) HandleGetSettlement(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	if !permission.Has(ctx, permission.SettlementManage) {
+		return createErrorResponse(http.StatusForbidden, "Chỉ ADMIN mới có quyền xem đối soát doanh thu")
+	}
+	id, err := strconv.Atoi(request.PathParameters["id"])
+	if err != nil || id <= 0 {
+		return createErrorResponse(http.StatusBadRequest, "settlementId không hợp lệ")
+	}
+
+	s, err := settlement.Get(ctx, id)
+	if err != nil {
+		return settlementErrorResponse(ctx, err)
+	}
+	switch request.QueryStringParameters["format"] {
+	case "", "json":
+		return createJSONResponse(http.StatusOK, map[string]interface{}{
+			"success": true,
+			"data":    s,
+		})
+	case "csv":
+		return settlementStatementResponse(ctx, s)
+	}
+	return createErrorResponse(http.StatusBadRequest, "format phải là json hoặc csv")
+}
+
+// ============================================================
+// HandleApproveSettlement - POST /api/admin/settlements/{id}/approve
+// Chốt số liệu bản PENDING của kỳ đã kết thúc; job không tính lại bản đã duyệt
+// ============================================================
+func (h *StaffHandler) HandleApproveSettlement(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	adminID, err := permission.Require(ctx, permission.SettlementManage)
+	if err != nil {
+		return settlementAuthError(err)
+	}
+	id, err := strconv.Atoi(request.PathParameters["id"])
+	if err != nil || id <= 0 {
+		return createErrorResponse(http.StatusBadRequest, "settlementId không hợp lệ")
+	}
+
+	s, err := settlement.Approve(ctx, id, adminID)
+	if err != nil {
+		return settlementErrorResponse(ctx, err)
+	}
+	return createJSONResponse(http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Đã duyệt bản đối soát",
+		"data":    s,
+	})
+}
+
+// ============================================================
+// HandleMarkSettlementPaid - POST /api/admin/settlements/{id}/mark-paid
+// Ghi nhận đã chuyển khoản cho organizer (bản APPROVED)
+// Body: {"paymentReference": "FT26041512345"}
+// ============================================================
+func (h *StaffHandler) HandleMarkSettlementPaid(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	adminID, err := permission.Require(ctx, permission.SettlementManage)
+	if err != nil {
+		return settlementAuthError(err)
+	}
+	id, err := strconv.Atoi(request.PathParameters["id"])
+	if err != nil || id <= 0 {
+		return createErrorResponse(http.StatusBadRequest, "settlementId không hợp lệ")
+	}
+	var body struct {
+		PaymentReference string `json:"paymentReference"`
+	}
+	if err := json.Unmarshal([]byte(request.Body), &body); err != nil {
+		return createErrorResponse(http.StatusBadRequest, "Dữ liệu không hợp lệ")
+	}
+
+	s, err := settlement.MarkPaid(ctx, id, adminID, body.PaymentReference)
+	if err != nil {
+		return settlementErrorResponse(ctx, err)
+	}
+	return createJSONResponse(http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Đã ghi nhận thanh toán cho ban tổ chức",
+		"data":    s,
+	})
+}
+
+func settlementStatementResponse(ctx context.Context, s *settlement.Settlement) (events.APIGatewayProxyResponse, error) {
+	data, err := settlement.StatementCSV(s)
+	if err != nil {
+		return settlementErrorResponse(ctx, err)
+	}
+	return events.APIGatewayProxyResponse{
+		StatusCode: http.StatusOK,
+		Headers: map[string]string{
+			"Content-Type":        "text/csv; charset=utf-8",
+			"Content-Disposition": fmt.Sprintf("attachment; filename=\"%s\"", settlement.StatementFileName(s)),
+			"Cache-Control":       "private, no-store",
+		},
+		Body:            base64.StdEncoding.EncodeToString(data),
+		IsBase64Encoded: true,
+	}, nil
+}
+
+func settlementAuthError(err error) (events.APIGatewayProxyResponse, error) {
+	if errors.Is(err, authctx.ErrUnauthenticated) {
+		return createErrorResponse(http.StatusUnauthorized, "Unauthorized")
+	}
+	return createErrorResponse(http.StatusForbidden, "Chỉ ADMIN mới có quyền duyệt đối soát doanh thu")
+}
+
+func settlementErrorResponse(ctx context.Context, err error) (events.APIGatewayProxyResponse, error) {
+	switch {
+	case errors.Is(err, settlement.ErrNotFound):
+		return createErrorResponse(http.StatusNotFound, "Không tìm thấy bản đối soát")
+	case errors.Is(err, settlement.ErrInvalidStatus):
+		return createErrorResponse(http.StatusConflict, err.Error())
+	case errors.Is(err, settlement.ErrPeriodOpen):
+		return createErrorResponse(http.StatusConflict, "Kỳ đối soát chưa kết thúc")
+	case errors.Is(err, settlement.ErrMissingReference):
+		return createErrorResponse(http.StatusBadRequest, "paymentReference là bắt buộc (tối đa 100 ký tự)")
+	}
+	logger.Default().WithContext(ctx).Error("Settlement request failed", "error", err)
+	return createErrorResponse(http.StatusInternalServerError, "Lỗi khi xử lý đối soát doanh thu")
+}
@@ -0,0 +1,192 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fpt-event-services/common/authctx"
+	"github.com/fpt-event-services/common/livestats"
+	"github.com/fpt-event-services/common/permission"
+	"github.com/fpt-event-services/services/staff-lambda/models"
+	"github.com/fpt-event-services/services/staff-lambda/usecase"
+)
+
+// ============================================================
+// Luồng thống kê check-in realtime (Server-Sent Events) cho organizer
+// Handler net/http thuần: API Gateway/Lambda không giữ được kết nối dài
+// Mỗi statsStreamInterval: nếu có check-in/check-out ở process này (livestats)
+// hoặc đến lượt đồng bộ định kỳ thì đọc lại Event_Occupancy, đổi số mới đẩy
+// Client không nhận text/event-stream hoặc server không flush được
+// => trả JSON snapshot kèm pollUrl để client tự poll
+// ============================================================
+
+const (
+	statsStreamInterval = 3 * time.Second
+	// statsStreamResyncEvery - Số tick giữa hai lần đọc lại DB dù không có thay đổi
+	// (bắt check-in ở instance khác, đồng thời làm heartbeat giữ kết nối)
+	statsStreamResyncEvery = 5
+	// statsStreamMaxDuration - Đóng luồng sau khoảng này, EventSource tự kết nối lại
+	statsStreamMaxDuration = time.Hour
+	statsPollIntervalSec   = 5
+)
+
+// StatsPollFallback - Phản hồi khi không stream được
+type StatsPollFallback struct {
+	Streaming           bool                   `json:"streaming"`
+	PollURL             string                 `json:"pollUrl"`
+	PollIntervalSeconds int                    `json:"pollIntervalSeconds"`
+	Stats               *models.EventOccupancy `json:"stats"`
+}
+
+// ============================================================
+// ServeEventStatsStream - GET /api/organizer/events/{id}/stats/stream
+// event "stats": EventOccupancy (gửi ngay khi mở và mỗi khi số liệu đổi)
+// Cần quyền event.stats.view; Organizer chỉ xem sự kiện của mình
+// ============================================================
+func (h *StaffHandler) ServeEventStatsStream(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID, err := permission.Require(ctx, permission.EventStatsView)
+	if errors.Is(err, authctx.ErrUnauthenticated) {
+		writeStreamError(w, http.StatusUnauthorized, "Không xác định được người dùng")
+		return
+	}
+	if err != nil {
+		writeStreamError(w, http.StatusForbidden, "Bạn không có quyền xem thống kê sự kiện")
+		return
+	}
+	role := authctx.Role(ctx)
+	eventID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil || eventID <= 0 {
+		writeStreamError(w, http.StatusBadRequest, "eventId không hợp lệ")
+		return
+	}
+
+	// Mở subscription trước khi đọc snapshot để không lỡ check-in xen giữa
+	sub := livestats.Subscribe(eventID)
+	defer sub.Close()
+
+	stats, err := h.useCase.GetEventOccupancy(ctx, userID, role, eventID)
+	if errors.Is(err, usecase.ErrOccupancyForbidden) {
+		writeStreamError(w, http.StatusForbidden, "Bạn không có quyền xem thống kê sự kiện này")
+		return
+	}
+	if err != nil {
+		writeStreamError(w, http.StatusInternalServerError, "Lỗi khi lấy thống kê sự kiện")
+		return
+	}
+	if stats == nil {
+		writeStreamError(w, http.StatusNotFound, "Không tìm thấy sự kiện")
+		return
+	}
+
+	if !strings.Contains(r.Header.Get("Accept"), "text/event-stream") || !canFlush(w) {
+		w.Header().Set("Content-Type", "application/json;charset=UTF-8")
+		w.Header().Set("Retry-After", strconv.Itoa(statsPollIntervalSec))
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(StatsPollFallback{
+			Streaming:           false,
+			PollURL:             fmt.Sprintf("/api/staff/events/%d/occupancy", eventID),
+			PollIntervalSeconds: statsPollIntervalSec,
+			Stats:               stats,
+		})
+		return
+	}
+
+	// Kết nối dài: bỏ write deadline của server (nếu có)
+	rc := http.NewResponseController(w)
+	_ = rc.SetWriteDeadline(time.Time{})
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprintf(w, "retry: %d\n\n", statsStreamInterval.Milliseconds())
+
+	seq := 0
+	send := func(o *models.EventOccupancy) error {
+		data, err := json.Marshal(o)
+		if err != nil {
+			return err
+		}
+		seq++
+		if _, err := fmt.Fprintf(w, "id: %d\nevent: stats\ndata: %s\n\n", seq, data); err != nil {
+			return err
+		}
+		return rc.Flush()
+	}
+	if err := send(stats); err != nil {
+		return
+	}
+
+	ticker := time.NewTicker(statsStreamInterval)
+	defer ticker.Stop()
+	deadline := time.NewTimer(statsStreamMaxDuration)
+	defer deadline.Stop()
+
+	last := *stats
+	for tick := 1; ; tick++ {
+		select {
+		case <-ctx.Done():
+			return
+		case <-deadline.C:
+			return
+		case <-ticker.C:
+		}
+
+		resync := tick%statsStreamResyncEvery == 0
+		if sub.Take() == 0 && !resync {
+			continue
+		}
+		current, err := h.useCase.GetEventOccupancy(ctx, userID, role, eventID)
+		if err != nil || current == nil {
+			if ctx.Err() == nil {
+				fmt.Printf("[STATS STREAM] ⚠️ Reload event %d failed: %v\n", eventID, err)
+			}
+			continue
+		}
+		if occupancyChanged(&last, current) {
+			last = *current
+			if err := send(current); err != nil {
+				return
+			}
+		} else if resync {
+			// Heartbeat (comment SSE) để proxy không cắt kết nối im lặng
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil || rc.Flush() != nil {
+				return
+			}
+		}
+	}
+}
+
+// occupancyChanged - So các số đếm (bỏ qua updatedAt)
+func occupancyChanged(prev, cur *models.EventOccupancy) bool {
+	return prev.CheckedIn != cur.CheckedIn || prev.CheckedOut != cur.CheckedOut ||
+		prev.Inside != cur.Inside || prev.AtCapacity != cur.AtCapacity
+}
+
+// canFlush - Writer (hoặc writer gốc qua Unwrap) có flush được không
+// Kiểm tra trước khi ghi header để còn trả JSON fallback
+func canFlush(w http.ResponseWriter) bool {
+	for {
+		if _, ok := w.(http.Flusher); ok {
+			return true
+		}
+		u, ok := w.(interface{ Unwrap() http.ResponseWriter })
+		if !ok {
+			return false
+		}
+		w = u.Unwrap()
+	}
+}
+
+// writeStreamError - Cùng định dạng với createErrorResponse
+func writeStreamError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json;charset=UTF-8")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{"error": message})
+}
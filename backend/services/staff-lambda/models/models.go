@@ -3,6 +3,8 @@ package models
 import (
 	"database/sql"
 	"time"
+
+	"github.com/fpt-event-services/common/statuslabel"
 )
 
 // ============================================================
@@ -48,6 +50,7 @@ type CheckoutResult struct {
 	TicketCode   *string `json:"ticketCode,omitempty"`
 	CheckOutTime *string `json:"checkOutTime,omitempty"`
 	PreviousTime *string `json:"previousTime,omitempty"` // ✅ NEW: Thời gian check-out trước đó (nếu trùng lặp)
+	Cashback     *int64  `json:"cashback,omitempty"`     // Cashback tham dự đã hoàn vào ví người mua (migration 056)
 }
 
 // CheckinResponse - Response check-in
@@ -87,6 +90,16 @@ type TicketForCheckin struct {
 	// ✅ NEW: Per-event config for time validation
 	EventCheckinOffset  sql.NullInt64 `json:"-"` // NULL = use global config
 	EventCheckoutOffset sql.NullInt64 `json:"-"` // NULL = use global config
+
+	// Vé cấp từ vé học kỳ (Ticket.pass_holder_id, migration 049); NULL với vé thường
+	PassName         *string `json:"-"`
+	PassHolderStatus *string `json:"-"` // ACTIVE, REVOKED
+	PassCoversEvent  bool    `json:"-"` // Giờ bắt đầu sự kiện nằm trong thời hạn pass
+
+	// Vé tạm khóa vì hóa đơn trả góp có kỳ quá hạn (Ticket.suspended_at, migration 055)
+	Suspended bool `json:"-"`
+	// Sự kiện bật QR động: check-in chỉ nhận mã xoay vòng (Event.dynamic_qr, migration 057)
+	EventDynamicQR bool `json:"-"`
 }
 
 // ============================================================
@@ -96,35 +109,39 @@ type TicketForCheckin struct {
 
 // ReportListResponse - Danh sách report cho staff
 type ReportListResponse struct {
-	ReportID           int     `json:"reportId"`
-	TicketID           int     `json:"ticketId"`
-	Title              *string `json:"title,omitempty"`
-	Description        *string `json:"description,omitempty"`
-	ImageURL           *string `json:"imageUrl,omitempty"`
-	CreatedAt          string  `json:"createdAt"`
-	ReportStatus       string  `json:"reportStatus"`
-	StudentName        string  `json:"studentName"`
-	TicketStatus       string  `json:"ticketStatus"`
-	CategoryTicketName *string `json:"categoryTicketName,omitempty"`
-	Price              float64 `json:"price"`
+	ReportID           int                `json:"reportId"`
+	TicketID           int                `json:"ticketId"`
+	Title              *string            `json:"title,omitempty"`
+	Description        *string            `json:"description,omitempty"`
+	ImageURL           *string            `json:"imageUrl,omitempty"`
+	CreatedAt          string             `json:"createdAt"`
+	ReportStatus       string             `json:"reportStatus"`
+	ReportStatusLabel  *statuslabel.Label `json:"reportStatusLabel"`
+	StudentName        string             `json:"studentName"`
+	TicketStatus       string             `json:"ticketStatus"`
+	TicketStatusLabel  *statuslabel.Label `json:"ticketStatusLabel"`
+	CategoryTicketName *string            `json:"categoryTicketName,omitempty"`
+	Price              float64            `json:"price"`
 }
 
 // ReportDetailResponse - Chi tiết report cho staff
 // KHỚP VỚI Java ReportDAO.getReportDetailForStaff()
 type ReportDetailResponse struct {
-	ReportID           int     `json:"reportId"`
-	TicketID           int     `json:"ticketId"`
-	Title              *string `json:"title,omitempty"`
-	Description        *string `json:"description,omitempty"`
-	ImageURL           *string `json:"imageUrl,omitempty"`
-	CreatedAt          string  `json:"createdAt"`
-	ReportStatus       string  `json:"reportStatus"`
-	StudentID          int     `json:"studentId"`
-	StudentName        string  `json:"studentName"`
-	TicketStatus       string  `json:"ticketStatus"`
-	CategoryTicketID   int     `json:"categoryTicketId"`
-	CategoryTicketName *string `json:"categoryTicketName,omitempty"`
-	Price              float64 `json:"price"`
+	ReportID           int                `json:"reportId"`
+	TicketID           int                `json:"ticketId"`
+	Title              *string            `json:"title,omitempty"`
+	Description        *string            `json:"description,omitempty"`
+	ImageURL           *string            `json:"imageUrl,omitempty"`
+	CreatedAt          string             `json:"createdAt"`
+	ReportStatus       string             `json:"reportStatus"`
+	ReportStatusLabel  *statuslabel.Label `json:"reportStatusLabel"`
+	StudentID          int                `json:"studentId"`
+	StudentName        string             `json:"studentName"`
+	TicketStatus       string             `json:"ticketStatus"`
+	TicketStatusLabel  *statuslabel.Label `json:"ticketStatusLabel"`
+	CategoryTicketID   int                `json:"categoryTicketId"`
+	CategoryTicketName *string            `json:"categoryTicketName,omitempty"`
+	Price              float64            `json:"price"`
 	// Seat info
 	SeatID   *int    `json:"seatId,omitempty"`
 	SeatCode *string `json:"seatCode,omitempty"`
@@ -157,3 +174,61 @@ type SystemConfigResponse struct {
 	Data    SystemConfigData `json:"data"`
 	Message string           `json:"message,omitempty"`
 }
+
+// ============================================================
+// EventOccupancy - Số người đang ở trong khu vực sự kiện
+// Dùng cho: GET /api/staff/events/{id}/occupancy
+// ============================================================
+type EventOccupancy struct {
+	EventID    int    `json:"eventId"`
+	EventTitle string `json:"eventTitle"`
+	CheckedIn  int    `json:"checkedIn"`  // Tổng lượt đã check-in
+	CheckedOut int    `json:"checkedOut"` // Tổng lượt đã check-out
+	Inside     int    `json:"inside"`     // Đang ở trong = checkedIn - checkedOut
+	// Sức chứa của khu vực (nil nếu chưa cấu hình)
+	Capacity        *int       `json:"capacity"`
+	CapacityPercent *float64   `json:"capacityPercent"`
+	AtCapacity      bool       `json:"atCapacity"`
+	UpdatedAt       *time.Time `json:"updatedAt"`
+}
+
+// ============================================================
+// DiagnosticsStatus - Tình trạng process cho ADMIN
+// Dùng cho: GET /api/admin/diagnostics/status
+// ============================================================
+type DiagnosticsStatus struct {
+	StartedAt     time.Time          `json:"startedAt"`
+	UptimeSeconds int64              `json:"uptimeSeconds"`
+	Goroutines    int                `json:"goroutines"`
+	Database      DiagnosticsDBStats `json:"database"`
+	EmailQueue    DiagnosticsQueue   `json:"emailQueue"`
+	Jobs          []DiagnosticsJob   `json:"jobs"`
+	EntityTypes   []string           `json:"entityTypes"` // Entity tra cứu được qua /entities/{type}/{id}
+}
+
+// DiagnosticsDBStats - Connection pool MySQL
+type DiagnosticsDBStats struct {
+	OpenConnections int   `json:"openConnections"`
+	InUse           int   `json:"inUse"`
+	Idle            int   `json:"idle"`
+	WaitCount       int64 `json:"waitCount"`
+	WaitMillis      int64 `json:"waitMillis"`
+}
+
+// DiagnosticsQueue - Số email trong Email_Queue theo trạng thái
+type DiagnosticsQueue struct {
+	ByStatus map[string]int `json:"byStatus"`
+	Due      int            `json:"due"` // PENDING đã tới hạn nhưng chưa được gửi
+	Error    string         `json:"error,omitempty"`
+}
+
+// DiagnosticsJob - Trạng thái một job định kỳ (lần chạy gần nhất)
+type DiagnosticsJob struct {
+	Name       string     `json:"name"`
+	Schedule   string     `json:"schedule"`
+	Running    bool       `json:"running"`
+	NextRunAt  *time.Time `json:"nextRunAt,omitempty"`
+	LastStatus string     `json:"lastStatus,omitempty"`
+	LastRunAt  *time.Time `json:"lastRunAt,omitempty"`
+	LastError  string     `json:"lastError,omitempty"`
+}
@@ -0,0 +1,35 @@
+package models
+
+import (
+	"encoding/json"
+
+	"github.com/fpt-event-services/common/statuslabel"
+	"github.com/fpt-event-services/common/validator"
+)
+
+// ============================================================
+// Output encoding cho report: tiêu đề / mô tả do sinh viên nhập
+// luôn trả về dạng văn bản thuần (kể cả report lưu trước khi có sanitize)
+// ============================================================
+
+// MarshalJSON - ReportListResponse với tiêu đề / mô tả đã sanitize và nhãn trạng thái
+func (r ReportListResponse) MarshalJSON() ([]byte, error) {
+	type plain ReportListResponse
+	out := plain(r)
+	out.Title = validator.SanitizeOptionalText(out.Title)
+	out.Description = validator.SanitizeOptionalText(out.Description)
+	out.ReportStatusLabel = statuslabel.Lookup(statuslabel.KindReport, out.ReportStatus)
+	out.TicketStatusLabel = statuslabel.Lookup(statuslabel.KindTicket, out.TicketStatus)
+	return json.Marshal(out)
+}
+
+// MarshalJSON - ReportDetailResponse với tiêu đề / mô tả đã sanitize và nhãn trạng thái
+func (r ReportDetailResponse) MarshalJSON() ([]byte, error) {
+	type plain ReportDetailResponse
+	out := plain(r)
+	out.Title = validator.SanitizeOptionalText(out.Title)
+	out.Description = validator.SanitizeOptionalText(out.Description)
+	out.ReportStatusLabel = statuslabel.Lookup(statuslabel.KindReport, out.ReportStatus)
+	out.TicketStatusLabel = statuslabel.Lookup(statuslabel.KindTicket, out.TicketStatus)
+	return json.Marshal(out)
+}
@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/fpt-event-services/common/billing"
+	"github.com/fpt-event-services/common/ledger"
+)
+
+// ============================================================
+// Ticket_Cashback - Cashback tham dự hoàn vào ví khi check-out (migration 056)
+// Ghi trong cùng transaction với check-out: Users.Wallet, bút toán CASHBACK trên sổ cái
+// và dòng Ticket_Cashback (mỗi vé tối đa một lần)
+// ============================================================
+
+// creditCashback hoàn cashback của vé vừa check-out vào ví người mua, trả về số tiền đã hoàn
+// Chỉ vé mua có Bill PAID (vé mời, vé học kỳ, hóa đơn trả góp chưa trả đủ: không có cashback)
+func creditCashback(ctx context.Context, tx *sql.Tx, ticketID int) (int64, error) {
+	var (
+		eventID, userID, billID, percent int
+		price                            float64
+		maxAmount                        sql.NullFloat64
+	)
+	err := tx.QueryRowContext(ctx, `
+		SELECT t.event_id, t.user_id, bi.bill_id, bi.unit_price, e.cashback_percent, e.cashback_max_amount
+		FROM Ticket t
+		JOIN Bill_Item bi ON bi.ticket_id = t.ticket_id
+		JOIN Bill b ON b.bill_id = bi.bill_id
+		JOIN Event e ON e.event_id = t.event_id
+		WHERE t.ticket_id = ? AND t.user_id IS NOT NULL
+		  AND b.payment_status = 'PAID' AND e.cashback_percent > 0
+		  AND NOT EXISTS (SELECT 1 FROM Ticket_Cashback c WHERE c.ticket_id = t.ticket_id)
+	`, ticketID).Scan(&eventID, &userID, &billID, &price, &percent, &maxAmount)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to load cashback: %w", err)
+	}
+
+	amount := billing.Cashback(ledger.Dong(price), percent, ledger.Dong(maxAmount.Float64))
+	if amount <= 0 {
+		return 0, nil
+	}
+	if _, err := tx.ExecContext(ctx, `UPDATE users SET Wallet = Wallet + ? WHERE user_id = ?`, amount, userID); err != nil {
+		return 0, fmt.Errorf("failed to credit cashback: %w", err)
+	}
+	entryID, err := ledger.Post(ctx, tx, ledger.Cashback(ticketID, userID, float64(amount)))
+	if err != nil {
+		return 0, fmt.Errorf("failed to post cashback to ledger: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO Ticket_Cashback (ticket_id, event_id, user_id, bill_id, percent, amount, entry_id)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`, ticketID, eventID, userID, billID, percent, amount, entryID); err != nil {
+		return 0, fmt.Errorf("failed to record cashback: %w", err)
+	}
+	return amount, nil
+}
@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// ============================================================
+// DIAGNOSTICS - Tra cứu một bản ghi bất kỳ theo ID cho ADMIN
+// Thay cho các endpoint debug viết tay (/api/debug/...) với ID cố định
+// Chỉ các bảng trong danh sách; cột nhạy cảm luôn bị ẩn
+// ============================================================
+
+// ErrUnknownEntity - Loại entity không nằm trong danh sách cho phép tra cứu
+var ErrUnknownEntity = errors.New("unknown diagnostics entity")
+
+type diagnosticsEntity struct {
+	table string
+	key   string
+}
+
+// diagnosticsEntities - Tên entity trên URL -> bảng và khóa chính
+var diagnosticsEntities = map[string]diagnosticsEntity{
+	"user":            {"Users", "user_id"},
+	"event":           {"Event", "event_id"},
+	"event-request":   {"Event_Request", "request_id"},
+	"ticket":          {"Ticket", "ticket_id"},
+	"bill":            {"Bill", "bill_id"},
+	"report":          {"Report", "report_id"},
+	"venue":           {"Venue", "venue_id"},
+	"area":            {"Venue_Area", "area_id"},
+	"seat":            {"Seat", "seat_id"},
+	"category-ticket": {"Category_Ticket", "category_ticket_id"},
+	"speaker":         {"Speaker", "speaker_id"},
+	"email":           {"Email_Queue", "email_id"},
+}
+
+// diagnosticsRedacted - Cột không bao giờ trả ra ngoài (mật khẩu, mã QR, nội dung email)
+var diagnosticsRedacted = map[string]bool{
+	"password_hash": true,
+	"qr_code_value": true,
+	"html_body":     true,
+	"attachments":   true,
+}
+
+// DiagnosticsEntityTypes - Danh sách entity được phép tra cứu (đã sắp xếp)
+func DiagnosticsEntityTypes() []string {
+	types := make([]string, 0, len(diagnosticsEntities))
+	for name := range diagnosticsEntities {
+		types = append(types, name)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// LookupEntity đọc toàn bộ cột của một bản ghi; nil nếu không tồn tại
+func (r *StaffRepository) LookupEntity(ctx context.Context, entity string, id int64) (map[string]interface{}, error) {
+	def, ok := diagnosticsEntities[entity]
+	if !ok {
+		return nil, ErrUnknownEntity
+	}
+
+	// Tên bảng/cột lấy từ whitelist ở trên, không từ input
+	rows, err := r.db.QueryContext(ctx, fmt.Sprintf("SELECT * FROM %s WHERE %s = ? LIMIT 1", def.table, def.key), id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up %s: %w", entity, err)
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	if !rows.Next() {
+		return nil, rows.Err()
+	}
+
+	values := make([]interface{}, len(columns))
+	ptrs := make([]interface{}, len(columns))
+	for i := range values {
+		ptrs[i] = &values[i]
+	}
+	if err := rows.Scan(ptrs...); err != nil {
+		return nil, fmt.Errorf("failed to scan %s: %w", entity, err)
+	}
+
+	record := make(map[string]interface{}, len(columns))
+	for i, col := range columns {
+		switch v := values[i].(type) {
+		case nil:
+			record[col] = nil
+		case []byte:
+			record[col] = string(v)
+		default:
+			record[col] = v
+		}
+		if diagnosticsRedacted[col] && values[i] != nil {
+			record[col] = "[REDACTED]"
+		}
+	}
+	return record, nil
+}
+
+// DBStats - Thống kê connection pool hiện tại
+func (r *StaffRepository) DBStats() sql.DBStats {
+	if r.db == nil {
+		return sql.DBStats{}
+	}
+	return r.db.Stats()
+}
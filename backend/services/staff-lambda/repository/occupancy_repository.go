@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+
+	"github.com/fpt-event-services/services/staff-lambda/models"
+)
+
+// ============================================================
+// Event_Occupancy - Bộ đếm check-in / check-out theo sự kiện
+// Tăng trong cùng transaction với UPDATE Ticket nên luôn khớp trạng thái vé
+// ============================================================
+
+// bumpOccupancy tăng cột đếm (checked_in_total / checked_out_total) của sự kiện chứa vé
+func bumpOccupancy(ctx context.Context, tx *sql.Tx, ticketID int, column string) error {
+	_, err := tx.ExecContext(ctx, fmt.Sprintf(`
+		INSERT INTO Event_Occupancy (event_id, %[1]s)
+		SELECT event_id, 1 FROM Ticket WHERE ticket_id = ?
+		ON DUPLICATE KEY UPDATE %[1]s = %[1]s + 1
+	`, column), ticketID)
+	if err != nil {
+		return fmt.Errorf("failed to update occupancy counter: %w", err)
+	}
+	return nil
+}
+
+// ============================================================
+// GetEventOccupancy - Số người đang ở trong sự kiện và % sức chứa khu vực
+// Trả về nil nếu sự kiện không tồn tại
+// ============================================================
+func (r *StaffRepository) GetEventOccupancy(ctx context.Context, eventID int) (*models.EventOccupancy, error) {
+	var o models.EventOccupancy
+	var capacity sql.NullInt64
+	var updatedAt sql.NullTime
+	err := r.db.QueryRowContext(ctx, `
+		SELECT e.event_id, e.title, va.capacity,
+		       COALESCE(o.checked_in_total, 0), COALESCE(o.checked_out_total, 0), o.updated_at
+		FROM Event e
+		LEFT JOIN Venue_Area va ON e.area_id = va.area_id
+		LEFT JOIN Event_Occupancy o ON o.event_id = e.event_id
+		WHERE e.event_id = ?
+	`, eventID).Scan(&o.EventID, &o.EventTitle, &capacity, &o.CheckedIn, &o.CheckedOut, &updatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get event occupancy: %w", err)
+	}
+
+	o.Inside = max(o.CheckedIn-o.CheckedOut, 0)
+	if updatedAt.Valid {
+		o.UpdatedAt = &updatedAt.Time
+	}
+	if capacity.Valid && capacity.Int64 > 0 {
+		c := int(capacity.Int64)
+		percent := math.Round(float64(o.Inside)*1000/float64(c)) / 10
+		o.Capacity = &c
+		o.CapacityPercent = &percent
+		o.AtCapacity = o.Inside >= c
+	}
+	return &o, nil
+}
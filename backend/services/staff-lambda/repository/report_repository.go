@@ -3,11 +3,16 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"sync"
 
 	"github.com/fpt-event-services/common/db"
+	"github.com/fpt-event-services/common/ledger"
 	"github.com/fpt-event-services/common/logger"
 	"github.com/fpt-event-services/common/models"
+	"github.com/fpt-event-services/common/pagination"
+	"github.com/fpt-event-services/common/tickethistory"
 )
 
 // ReportRepository handles report/refund database operations
@@ -22,6 +27,9 @@ func NewReportRepository() *ReportRepository {
 	}
 }
 
+// DefaultReportRepository - Repository dùng chung của process
+var DefaultReportRepository = sync.OnceValue(NewReportRepository)
+
 // ============================================================
 // GetReportDetailForStaff - Lấy chi tiết report cho staff
 // KHỚP VỚI Java ReportDAO.getReportDetailForStaff
@@ -141,63 +149,50 @@ func (r *ReportRepository) GetReportDetailForStaff(ctx context.Context, reportID
 }
 
 // ============================================================
-// ListReportsForStaff - List reports với pagination & filter
+// ListReportsForStaff - List reports với pagination & filter, kèm tổng số report khớp bộ lọc
 // KHỚP VỚI Java ReportDAO.listReportsForStaff
 // ============================================================
-func (r *ReportRepository) ListReportsForStaff(ctx context.Context, status string, page, pageSize int) ([]models.ReportListStaffDTO, error) {
+func (r *ReportRepository) ListReportsForStaff(ctx context.Context, status string, p pagination.Params, campusID *int) ([]models.ReportListStaffDTO, int, error) {
 	log := logger.Default().WithContext(ctx)
 
-	// Safety checks
-	if page <= 0 {
-		page = 1
-	}
-	if pageSize <= 0 {
-		pageSize = 10
-	}
-	if pageSize > 100 {
-		pageSize = 100
-	}
-
-	offset := (page - 1) * pageSize
-
-	query := `
-		SELECT 
-			r.report_id, r.ticket_id, r.title, r.description, r.image_url, r.created_at, r.status AS report_status,
-			u.full_name AS student_name,
-			t.status AS ticket_status,
-			ct.name AS category_ticket_name, ct.price
-		FROM Report r
+	q := db.From(`Report r
 		JOIN Users u ON u.user_id = r.user_id
 		JOIN Ticket t ON t.ticket_id = r.ticket_id
 		JOIN Category_Ticket ct ON ct.category_ticket_id = t.category_ticket_id
-	`
+		JOIN Event e ON e.event_id = t.event_id`).
+		Where("? IS NULL OR e.campus_id = ?", campusID, campusID).
+		WhereIf(status != "", "r.status = ?", status)
 
-	args := []interface{}{}
-	if status != "" {
-		query += " WHERE r.status = ?"
-		args = append(args, status)
+	var total int
+	countSQL, countArgs := q.Count()
+	if err := r.db.QueryRowContext(ctx, countSQL, countArgs...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count reports: %w", err)
 	}
 
-	query += " ORDER BY r.created_at DESC LIMIT ? OFFSET ?"
-	args = append(args, pageSize, offset)
+	query, args := q.OrderBy("r.created_at DESC").
+		Limit(p.PageSize, p.Offset()).
+		Select(`
+			r.report_id, r.ticket_id, r.title, r.description, r.image_url, r.created_at, r.status AS report_status,
+			u.full_name AS student_name,
+			t.status AS ticket_status,
+			ct.name AS category_ticket_name, ct.price`)
 
 	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list reports: %w", err)
+		return nil, 0, fmt.Errorf("failed to list reports: %w", err)
 	}
 	defer rows.Close()
 
 	list := []models.ReportListStaffDTO{}
 	for rows.Next() {
 		dto := models.ReportListStaffDTO{}
-		var imageURL sql.NullString
 
 		err := rows.Scan(
 			&dto.ReportID,
 			&dto.TicketID,
 			&dto.Title,
 			&dto.Description,
-			&imageURL,
+			&dto.ImageURL,
 			&dto.CreatedAt,
 			&dto.ReportStatus,
 			&dto.StudentName,
@@ -206,18 +201,18 @@ func (r *ReportRepository) ListReportsForStaff(ctx context.Context, status strin
 			&dto.Price,
 		)
 		if err != nil {
-			return nil, fmt.Errorf("failed to scan report row: %w", err)
-		}
-
-		if imageURL.Valid {
-			dto.ImageURL = &imageURL.String
+			return nil, 0, fmt.Errorf("failed to scan report row: %w", err)
 		}
 
 		list = append(list, dto)
 	}
 
-	log.Info("Listed reports for staff", "status", status, "page", page, "count", len(list))
-	return list, nil
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("failed to read reports: %w", err)
+	}
+
+	log.Info("Listed reports for staff", "status", status, "page", p.Page, "count", len(list), "total", total)
+	return list, total, nil
 }
 
 // ============================================================
@@ -361,20 +356,30 @@ func (r *ReportRepository) ProcessReport(ctx context.Context, reportID, staffID
 		return result, nil
 	}
 
+	// Sổ cái: giảm doanh thu → phải trả hoàn tiền → trả vào ví
+	if _, err := ledger.Post(ctx, tx, ledger.Refund(reportID, userID, refund)); err != nil {
+		return nil, fmt.Errorf("failed to post refund to ledger: %w", err)
+	}
+
 	// 6) Update Ticket.status = REFUNDED (chỉ update nếu đang CHECKED_IN)
-	query = `
-		UPDATE Ticket
-		SET status = 'REFUNDED'
-		WHERE ticket_id = ? AND status = 'CHECKED_IN'
-	`
-	res, err = tx.ExecContext(ctx, query, ticketID)
+	_, err = tickethistory.Transition(ctx, tx, tickethistory.Change{TicketID: ticketID, To: tickethistory.StatusRefunded, ActorID: &staffID})
+	if errors.Is(err, tickethistory.ErrTicketNotFound) || errors.Is(err, tickethistory.ErrInvalidTransition) {
+		result.Message = "Không cập nhật được trạng thái ticket (ticket không còn CHECKED_IN)"
+		return result, nil
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to update ticket status: %w", err)
 	}
-	rows, _ = res.RowsAffected()
-	if rows <= 0 {
-		result.Message = "Không cập nhật được trạng thái ticket (ticket không còn CHECKED_IN)"
-		return result, nil
+
+	// Vé hoàn tiền không còn chiếm suất của loại vé (Category_Ticket_Inventory)
+	query = `
+		UPDATE Category_Ticket_Inventory i
+		JOIN Ticket t ON t.category_ticket_id = i.category_ticket_id
+		SET i.sold = GREATEST(i.sold - 1, 0)
+		WHERE t.ticket_id = ?
+	`
+	if _, err = tx.ExecContext(ctx, query, ticketID); err != nil {
+		return nil, fmt.Errorf("failed to release ticket inventory: %w", err)
 	}
 
 	// 7) Update Report status APPROVED + processed info + refund_amount + staff_note
@@ -412,3 +417,35 @@ func (r *ReportRepository) ProcessReport(ctx context.Context, reportID, staffID
 
 	return result, nil
 }
+
+// ============================================================
+// ListPendingReportsByUser - Report PENDING của một student
+// Dùng cho dashboard của student
+// ============================================================
+func (r *ReportRepository) ListPendingReportsByUser(ctx context.Context, userID int) ([]models.Report, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT report_id, user_id, ticket_id, COALESCE(title, ''), description, image_url, status, created_at
+		FROM report
+		WHERE user_id = ? AND status = 'PENDING'
+		ORDER BY created_at DESC
+	`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query pending reports: %w", err)
+	}
+	defer rows.Close()
+
+	reports := []models.Report{}
+	for rows.Next() {
+		var rp models.Report
+		var imageURL sql.NullString
+		if err := rows.Scan(&rp.ReportID, &rp.UserID, &rp.TicketID, &rp.Title, &rp.Description,
+			&imageURL, &rp.Status, &rp.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan report: %w", err)
+		}
+		if imageURL.Valid {
+			rp.ImageURL = &imageURL.String
+		}
+		reports = append(reports, rp)
+	}
+	return reports, rows.Err()
+}
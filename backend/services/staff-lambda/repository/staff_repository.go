@@ -3,10 +3,13 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/fpt-event-services/common/db"
+	"github.com/fpt-event-services/common/tickethistory"
 	"github.com/fpt-event-services/services/staff-lambda/models"
 )
 
@@ -22,6 +25,9 @@ func NewStaffRepository() *StaffRepository {
 	}
 }
 
+// DefaultStaffRepository - Repository dùng chung của process
+var DefaultStaffRepository = sync.OnceValue(NewStaffRepository)
+
 // ============================================================
 // GetTicketForCheckin - Lấy thông tin vé cho check-in
 // KHỚP VỚI Java TicketDAO
@@ -43,12 +49,19 @@ func (r *StaffRepository) GetTicketForCheckin(ctx context.Context, ticketID int)
 			s.seat_code,
 			t.category_ticket_id,
 			COALESCE(u.full_name, 'Khách hàng') AS customer_name,
-			COALESCE(u.email, '') AS customer_email
+			COALESCE(u.email, '') AS customer_email,
+			ep.name,
+			ph.status,
+			COALESCE(e.start_time BETWEEN ep.valid_from AND ep.valid_to, 0),
+			t.suspended_at IS NOT NULL,
+			e.dynamic_qr
 		FROM Ticket t
 		JOIN Category_Ticket ct ON t.category_ticket_id = ct.category_ticket_id
 		JOIN Event e ON ct.event_id = e.event_id
 		LEFT JOIN Seat s ON t.seat_id = s.seat_id
 		LEFT JOIN Users u ON t.user_id = u.user_id
+		LEFT JOIN Event_Pass_Holder ph ON ph.holder_id = t.pass_holder_id
+		LEFT JOIN Event_Pass ep ON ep.pass_id = ph.pass_id
 		WHERE t.ticket_id = ?
 	`
 
@@ -60,6 +73,8 @@ func (r *StaffRepository) GetTicketForCheckin(ctx context.Context, ticketID int)
 		checkInTime  sql.NullTime
 		checkOutTime sql.NullTime
 		seatCode     sql.NullString
+		passName     sql.NullString
+		passStatus   sql.NullString
 	)
 
 	err := r.db.QueryRowContext(ctx, query, ticketID).Scan(
@@ -78,6 +93,11 @@ func (r *StaffRepository) GetTicketForCheckin(ctx context.Context, ticketID int)
 		&ticket.CategoryTicketID,
 		&ticket.CustomerName,
 		&ticket.CustomerEmail,
+		&passName,
+		&passStatus,
+		&ticket.PassCoversEvent,
+		&ticket.Suspended,
+		&ticket.EventDynamicQR,
 	)
 
 	if err != nil {
@@ -86,6 +106,10 @@ func (r *StaffRepository) GetTicketForCheckin(ctx context.Context, ticketID int)
 		}
 		return nil, fmt.Errorf("failed to get ticket: %w", err)
 	}
+	if passStatus.Valid {
+		ticket.PassName = &passName.String
+		ticket.PassHolderStatus = &passStatus.String
+	}
 
 	// Map qr_code_value to TicketCode
 	ticket.TicketCode = qrCodeValue
@@ -207,45 +231,61 @@ func (r *StaffRepository) GetTicketByCode(ctx context.Context, qrCodeValue strin
 // ============================================================
 // UpdateTicketCheckin - Update trạng thái check-in với race condition protection
 // KHỚP VỚI Java TicketDAO.updateCheckin
-// Sử dụng optimistic locking: chỉ update nếu status = 'BOOKED'
+// Chỉ chuyển được từ BOOKED (tickethistory.Transition khóa vé, chống race condition)
 // ============================================================
 func (r *StaffRepository) UpdateTicketCheckin(ctx context.Context, ticketID int) (int64, error) {
-	// Chỉ update nếu status hiện tại là BOOKED (chống race condition)
-	query := `UPDATE Ticket SET status = 'CHECKED_IN', checkin_time = NOW() WHERE ticket_id = ? AND status = 'BOOKED'`
-
-	result, err := r.db.ExecContext(ctx, query, ticketID)
-	if err != nil {
-		return 0, fmt.Errorf("failed to update checkin: %w", err)
-	}
-
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		return 0, fmt.Errorf("failed to get rows affected: %w", err)
-	}
-
-	return rowsAffected, nil
+	return r.updateTicketScan(ctx, ticketID, tickethistory.StatusCheckedIn, "checkin_time = NOW()", "checked_in_total", nil)
 }
 
 // ============================================================
 // UpdateTicketCheckout - Update trạng thái check-out với race condition protection
 // KHỚP VỚI Java TicketDAO.updateCheckout
-// Sử dụng optimistic locking: chỉ update nếu status = 'CHECKED_IN'
+// Chỉ chuyển được từ CHECKED_IN (tickethistory.Transition khóa vé, chống race condition)
+// Cashback của sự kiện (nếu có) hoàn vào ví trong cùng transaction; trả về số tiền đã hoàn
 // ============================================================
-func (r *StaffRepository) UpdateTicketCheckout(ctx context.Context, ticketID int) (int64, error) {
-	// Chỉ update nếu status hiện tại là CHECKED_IN (chống race condition)
-	query := `UPDATE Ticket SET status = 'CHECKED_OUT', check_out_time = NOW() WHERE ticket_id = ? AND status = 'CHECKED_IN'`
+func (r *StaffRepository) UpdateTicketCheckout(ctx context.Context, ticketID int) (int64, int64, error) {
+	var cashback int64
+	rows, err := r.updateTicketScan(ctx, ticketID, tickethistory.StatusCheckedOut, "check_out_time = NOW()", "checked_out_total",
+		func(tx *sql.Tx) (err error) {
+			cashback, err = creditCashback(ctx, tx, ticketID)
+			return err
+		})
+	if err != nil {
+		return 0, 0, err
+	}
+	return rows, cashback, nil
+}
 
-	result, err := r.db.ExecContext(ctx, query, ticketID)
+// updateTicketScan chuyển trạng thái check-in/out qua tickethistory.Transition (kèm lịch sử vé) và tăng
+// bộ đếm occupancy trong cùng transaction, rồi chạy after (nếu có) trước khi commit.
+// Trả về 0 (không lỗi) khi vé không ở trạng thái cho phép
+func (r *StaffRepository) updateTicketScan(ctx context.Context, ticketID int, to, set, occupancyColumn string, after func(*sql.Tx) error) (int64, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
-		return 0, fmt.Errorf("failed to update checkout: %w", err)
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
 	}
+	defer tx.Rollback()
 
-	rowsAffected, err := result.RowsAffected()
+	_, err = tickethistory.Transition(ctx, tx, tickethistory.Change{TicketID: ticketID, To: to, Set: set})
+	if errors.Is(err, tickethistory.ErrTicketNotFound) || errors.Is(err, tickethistory.ErrInvalidTransition) {
+		return 0, nil
+	}
 	if err != nil {
-		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+		return 0, fmt.Errorf("failed to update ticket: %w", err)
 	}
 
-	return rowsAffected, nil
+	if err := bumpOccupancy(ctx, tx, ticketID, occupancyColumn); err != nil {
+		return 0, err
+	}
+	if after != nil {
+		if err := after(tx); err != nil {
+			return 0, err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit: %w", err)
+	}
+	return 1, nil
 }
 
 // ============================================================
@@ -323,8 +363,9 @@ func (r *StaffRepository) GetCurrentTime() time.Time {
 // ============================================================
 // GetReportsForStaff - Lấy danh sách report cho staff
 // KHỚP VỚI Java ReportDAO.listReportsForStaff()
+// campusID != nil: chỉ report của sự kiện thuộc campus đó
 // ============================================================
-func (r *StaffRepository) GetReportsForStaff(ctx context.Context) ([]models.ReportListResponse, error) {
+func (r *StaffRepository) GetReportsForStaff(ctx context.Context, campusID *int) ([]models.ReportListResponse, error) {
 	query := `
 		SELECT 
 			r.report_id,
@@ -342,10 +383,12 @@ func (r *StaffRepository) GetReportsForStaff(ctx context.Context) ([]models.Repo
 		JOIN Users u ON u.user_id = r.user_id
 		JOIN Ticket t ON t.ticket_id = r.ticket_id
 		JOIN Category_Ticket ct ON ct.category_ticket_id = t.category_ticket_id
+		JOIN Event e ON e.event_id = t.event_id
+		WHERE (? IS NULL OR e.campus_id = ?)
 		ORDER BY r.created_at DESC
 	`
 
-	rows, err := r.db.QueryContext(ctx, query)
+	rows, err := r.db.QueryContext(ctx, query, campusID, campusID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query reports: %w", err)
 	}
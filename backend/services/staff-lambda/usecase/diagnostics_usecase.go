@@ -0,0 +1,75 @@
+package usecase
+
+import (
+	"context"
+	"runtime"
+	"time"
+
+	"github.com/fpt-event-services/common/email"
+	"github.com/fpt-event-services/common/logger"
+	"github.com/fpt-event-services/common/scheduler"
+	"github.com/fpt-event-services/services/staff-lambda/models"
+	"github.com/fpt-event-services/services/staff-lambda/repository"
+)
+
+// processStartedAt - Thời điểm process khởi động (tính uptime)
+var processStartedAt = time.Now()
+
+// ============================================================
+// LookupEntity - Tra cứu một bản ghi theo loại entity và ID (ADMIN)
+// Trả về nil nếu không tồn tại, repository.ErrUnknownEntity nếu sai loại
+// ============================================================
+func (uc *StaffUseCase) LookupEntity(ctx context.Context, entity string, id int64) (map[string]interface{}, error) {
+	return uc.staffRepo.LookupEntity(ctx, entity, id)
+}
+
+// ============================================================
+// GetDiagnosticsStatus - Tình trạng process: DB pool, Email_Queue, job định kỳ
+// Lỗi đếm hàng đợi không làm hỏng cả response (ghi vào emailQueue.error)
+// ============================================================
+func (uc *StaffUseCase) GetDiagnosticsStatus(ctx context.Context) *models.DiagnosticsStatus {
+	stats := uc.staffRepo.DBStats()
+	status := &models.DiagnosticsStatus{
+		StartedAt:     processStartedAt,
+		UptimeSeconds: int64(time.Since(processStartedAt).Seconds()),
+		Goroutines:    runtime.NumGoroutine(),
+		Database: models.DiagnosticsDBStats{
+			OpenConnections: stats.OpenConnections,
+			InUse:           stats.InUse,
+			Idle:            stats.Idle,
+			WaitCount:       stats.WaitCount,
+			WaitMillis:      stats.WaitDuration.Milliseconds(),
+		},
+		Jobs:        []models.DiagnosticsJob{},
+		EntityTypes: repository.DiagnosticsEntityTypes(),
+	}
+
+	counts, due, err := email.DefaultQueue().StatusCounts(ctx)
+	status.EmailQueue = models.DiagnosticsQueue{ByStatus: counts, Due: due}
+	if err != nil {
+		status.EmailQueue = models.DiagnosticsQueue{ByStatus: map[string]int{}, Error: err.Error()}
+	}
+
+	for _, job := range scheduler.DefaultManager().List(ctx, 1) {
+		j := models.DiagnosticsJob{
+			Name:      job.Name,
+			Schedule:  job.Schedule,
+			Running:   job.Running,
+			NextRunAt: job.NextRunAt,
+		}
+		if job.LastRun != nil {
+			j.LastStatus = job.LastRun.Status
+			j.LastRunAt = &job.LastRun.StartedAt
+			if job.LastRun.ErrorMessage != nil {
+				j.LastError = *job.LastRun.ErrorMessage
+			}
+		}
+		status.Jobs = append(status.Jobs, j)
+	}
+	return status
+}
+
+// RecentErrors - Log ERROR/FATAL gần nhất của instance này (mới nhất trước)
+func (uc *StaffUseCase) RecentErrors(limit int) []logger.LogEntry {
+	return logger.RecentErrors(limit)
+}
@@ -4,9 +4,12 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
+	"github.com/fpt-event-services/common/eventbus"
 	"github.com/fpt-event-services/common/logger"
 	"github.com/fpt-event-services/common/models"
+	"github.com/fpt-event-services/common/pagination"
 	"github.com/fpt-event-services/services/staff-lambda/repository"
 )
 
@@ -18,7 +21,7 @@ type ReportUseCase struct {
 // NewReportUseCase creates a new report use case
 func NewReportUseCase() *ReportUseCase {
 	return &ReportUseCase{
-		reportRepo: repository.NewReportRepository(),
+		reportRepo: repository.DefaultReportRepository(),
 	}
 }
 
@@ -48,24 +51,24 @@ func (uc *ReportUseCase) GetReportDetail(ctx context.Context, reportID int) (*mo
 // ============================================================
 // ListReports - List reports với pagination & filter
 // ============================================================
-func (uc *ReportUseCase) ListReports(ctx context.Context, status string, page, pageSize int) ([]models.ReportListStaffDTO, error) {
+func (uc *ReportUseCase) ListReports(ctx context.Context, status string, p pagination.Params, campusID *int) (pagination.Page[models.ReportListStaffDTO], error) {
 	log := logger.Default().WithContext(ctx)
 
 	// Validate status filter
 	if status != "" {
 		status = strings.ToUpper(strings.TrimSpace(status))
 		if status != "PENDING" && status != "APPROVED" && status != "REJECTED" {
-			return nil, fmt.Errorf("invalid status filter: %s", status)
+			return pagination.Page[models.ReportListStaffDTO]{}, fmt.Errorf("invalid status filter: %s", status)
 		}
 	}
 
-	list, err := uc.reportRepo.ListReportsForStaff(ctx, status, page, pageSize)
+	list, total, err := uc.reportRepo.ListReportsForStaff(ctx, status, p, campusID)
 	if err != nil {
-		log.Info("Failed to list reports", "status", status, "page", page, "error", err)
-		return nil, err
+		log.Info("Failed to list reports", "status", status, "page", p.Page, "error", err)
+		return pagination.Page[models.ReportListStaffDTO]{}, err
 	}
 
-	return list, nil
+	return pagination.New(list, p, total), nil
 }
 
 // ============================================================
@@ -130,6 +133,13 @@ func (uc *ReportUseCase) ProcessReport(ctx context.Context, req *models.ProcessR
 		resp.RefundAmount = result.RefundAmount
 	}
 
+	if result.Success {
+		eventbus.Publish(ctx, eventbus.ReportResolved{
+			ReportID: req.ReportID, StaffID: staffID, Approved: approve,
+			RefundAmount: result.RefundAmount, OccurredAt: time.Now(),
+		})
+	}
+
 	log.Info("Report processed",
 		"reportID", req.ReportID,
 		"action", action,
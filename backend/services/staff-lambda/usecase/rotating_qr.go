@@ -0,0 +1,41 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/fpt-event-services/common/qrtoken"
+	"github.com/fpt-event-services/services/staff-lambda/models"
+)
+
+// ============================================================
+// QR động (event.dynamic_qr, migration 057)
+// App của khách lấy mã RQR: mới mỗi 30 giây (GET /api/registrations/{ticketId}/qr-token);
+// check-in sự kiện bật QR động chỉ nhận mã này, QR tĩnh trong email / PDF / ảnh chụp bị từ chối
+// ============================================================
+
+// verifyRotatingQR - Mã xoay vòng → ticket_id, hoặc thông báo lỗi cho người quét
+func verifyRotatingQR(qrValue string, now time.Time) (int, string) {
+	ticketID, err := qrtoken.Verify(qrValue, now)
+	switch {
+	case err == nil:
+		return ticketID, ""
+	case errors.Is(err, qrtoken.ErrExpired):
+		return 0, "⏱️ Mã QR đã hết hạn.\nKhách cần mở lại vé trong ứng dụng để lấy mã mới."
+	case errors.Is(err, qrtoken.ErrNoSecret):
+		fmt.Printf("[ERROR] Cannot verify rotating QR: %v\n", err)
+		return 0, "❌ Máy chủ chưa cấu hình khóa QR động, vui lòng báo quản trị viên."
+	default:
+		return 0, "🚫 Mã QR động không hợp lệ (sai chữ ký hoặc đã bị chỉnh sửa)."
+	}
+}
+
+// staticQRError - Lý do từ chối QR tĩnh của vé thuộc sự kiện bật QR động ("" nếu hợp lệ)
+func staticQRError(ticket *models.TicketForCheckin, rotating bool) string {
+	if !ticket.EventDynamicQR || rotating {
+		return ""
+	}
+	return fmt.Sprintf("🔒 Sự kiện '%s' dùng QR động, không nhận QR in sẵn hoặc ảnh chụp.\nKhách %s cần mở vé trong ứng dụng để hiện mã QR mới.",
+		ticket.EventName, ticket.CustomerName)
+}
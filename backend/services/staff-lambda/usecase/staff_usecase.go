@@ -2,12 +2,15 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/fpt-event-services/common/config"
+	"github.com/fpt-event-services/common/eventbus"
+	"github.com/fpt-event-services/common/qrtoken"
 	"github.com/fpt-event-services/services/staff-lambda/models"
 	"github.com/fpt-event-services/services/staff-lambda/repository"
 )
@@ -20,7 +23,7 @@ type StaffUseCase struct {
 // NewStaffUseCase creates a new staff use case
 func NewStaffUseCase() *StaffUseCase {
 	return &StaffUseCase{
-		staffRepo: repository.NewStaffRepository(),
+		staffRepo: repository.DefaultStaffRepository(),
 	}
 }
 
@@ -32,8 +35,23 @@ func NewStaffUseCase() *StaffUseCase {
 func (uc *StaffUseCase) CheckIn(ctx context.Context, userID int, qrValue string) (*models.CheckinResponse, error) {
 	fmt.Printf("\n[CHECK-IN REQUEST] UserID=%d, QR/Code=%s\n", userID, qrValue)
 
-	// Parse ticket IDs từ QR (hỗ trợ cả ticket_id và ticket_code)
-	ticketIDs := uc.parseTicketIDs(qrValue)
+	// Mã QR xoay vòng (sự kiện bật QR động): kiểm tra chữ ký và hạn 30 giây trước khi tra vé
+	rotating := qrtoken.IsToken(qrValue)
+	var ticketIDs []int
+	if rotating {
+		ticketID, errMsg := verifyRotatingQR(qrValue, time.Now())
+		if errMsg != "" {
+			fmt.Printf("[ERROR] %s\n", errMsg)
+			return &models.CheckinResponse{
+				Success: false,
+				Message: errMsg,
+			}, nil
+		}
+		ticketIDs = append(ticketIDs, ticketID)
+	} else {
+		// Parse ticket IDs từ QR (hỗ trợ cả ticket_id và ticket_code)
+		ticketIDs = uc.parseTicketIDs(qrValue)
+	}
 
 	// ✅ Nếu không parse được ticket ID, thử tìm bằng ticket_code
 	if len(ticketIDs) == 0 {
@@ -66,7 +84,7 @@ func (uc *StaffUseCase) CheckIn(ctx context.Context, userID int, qrValue string)
 	now := uc.staffRepo.GetCurrentTime()
 
 	for _, ticketID := range ticketIDs {
-		result := uc.processCheckin(ctx, userID, ticketID, now)
+		result := uc.processCheckin(ctx, userID, ticketID, now, rotating)
 		results = append(results, result)
 
 		if result.Success {
@@ -100,7 +118,8 @@ func (uc *StaffUseCase) CheckIn(ctx context.Context, userID int, qrValue string)
 // processCheckin xử lý check-in 1 vé với race condition protection
 // Sử dụng optimistic locking: check status trước, update với WHERE status = 'BOOKED'
 // ✅ Với ownership verification và per-event config priority
-func (uc *StaffUseCase) processCheckin(ctx context.Context, userID int, ticketID int, now time.Time) models.CheckinResult {
+// rotating: vé được quét bằng mã QR xoay vòng đã xác thực (bắt buộc với sự kiện bật QR động)
+func (uc *StaffUseCase) processCheckin(ctx context.Context, userID int, ticketID int, now time.Time, rotating bool) models.CheckinResult {
 	result := models.CheckinResult{
 		TicketID: ticketID,
 		Success:  false,
@@ -145,6 +164,12 @@ func (uc *StaffUseCase) processCheckin(ctx context.Context, userID int, ticketID
 	}
 	fmt.Printf("[OWNERSHIP] ✓ UserID=%d is owner of EventID=%d\n", userID, ticket.EventID)
 
+	if errMsg := staticQRError(ticket, rotating); errMsg != "" {
+		result.Error = &errMsg
+		fmt.Printf("[ERROR] %s\n", errMsg)
+		return result
+	}
+
 	result.EventName = &ticket.EventName
 	result.SeatCode = ticket.SeatCode
 	result.TicketCode = &ticket.TicketCode
@@ -185,6 +210,21 @@ func (uc *StaffUseCase) processCheckin(ctx context.Context, userID int, ticketID
 	}
 	fmt.Printf("[STATUS] ✓ Ticket status is BOOKED\n")
 
+	// Vé cấp từ vé học kỳ: pass chưa bị thu hồi và còn hiệu lực vào giờ bắt đầu sự kiện
+	if errMsg := passCheckinError(ticket); errMsg != "" {
+		result.Error = &errMsg
+		fmt.Printf("[ERROR] %s\n", errMsg)
+		return result
+	}
+
+	// Vé trả góp có kỳ quá hạn: khóa tới khi khách trả đủ các kỳ quá hạn
+	if ticket.Suspended {
+		errMsg := fmt.Sprintf("🚫 Vé của %s đang bị tạm khóa do quá hạn trả góp.\nVui lòng thanh toán kỳ quá hạn để mở khóa vé.", ticket.CustomerName)
+		result.Error = &errMsg
+		fmt.Printf("[ERROR] %s\n", errMsg)
+		return result
+	}
+
 	// Kiểm tra thời gian (cho phép check-in trước X phút)
 	// ✅ Sử dụng per-event config nếu có, fallback to global
 	checkinWindow := config.GetEffectiveCheckinOffset(ticket.EventCheckinOffset)
@@ -245,6 +285,8 @@ func (uc *StaffUseCase) processCheckin(ctx context.Context, userID int, ticketID
 		return result
 	}
 	fmt.Printf("[UPDATE] ✓ Ticket updated successfully (rowsAffected=%d)\n", rowsAffected)
+	// Luồng thống kê realtime của organizer (GET .../stats/stream) nghe sự kiện này
+	eventbus.Publish(ctx, eventbus.TicketCheckedIn{TicketID: ticketID, EventID: ticket.EventID, OccurredAt: now})
 
 	result.Success = true
 	msg := "Check-in thành công"
@@ -255,14 +297,40 @@ func (uc *StaffUseCase) processCheckin(ctx context.Context, userID int, ticketID
 	return result
 }
 
+// passCheckinError - Lý do từ chối vé cấp từ vé học kỳ ("" nếu hợp lệ hoặc không phải vé pass)
+func passCheckinError(ticket *models.TicketForCheckin) string {
+	if ticket.PassHolderStatus == nil {
+		return ""
+	}
+	if *ticket.PassHolderStatus != "ACTIVE" {
+		return fmt.Sprintf("🚫 Vé học kỳ \"%s\" của %s đã bị thu hồi.\nVé cấp từ pass không còn giá trị.", *ticket.PassName, ticket.CustomerName)
+	}
+	if !ticket.PassCoversEvent {
+		return fmt.Sprintf("🚫 Sự kiện '%s' nằm ngoài thời hạn vé học kỳ \"%s\" của %s.", ticket.EventName, *ticket.PassName, ticket.CustomerName)
+	}
+	return ""
+}
+
 // ============================================================
 // CheckOut - Xử lý check-out vé
 // KHỚP VỚI Java StaffCheckoutController
 // ✅ Với ownership verification
 // ============================================================
 func (uc *StaffUseCase) CheckOut(ctx context.Context, userID int, qrValue string) (*models.CheckoutResponse, error) {
-	// Parse ticket IDs từ QR
-	ticketIDs := uc.parseTicketIDs(qrValue)
+	// Parse ticket IDs từ QR (nhận cả mã QR xoay vòng của sự kiện bật QR động)
+	var ticketIDs []int
+	if qrtoken.IsToken(qrValue) {
+		ticketID, errMsg := verifyRotatingQR(qrValue, time.Now())
+		if errMsg != "" {
+			return &models.CheckoutResponse{
+				Success: false,
+				Message: errMsg,
+			}, nil
+		}
+		ticketIDs = append(ticketIDs, ticketID)
+	} else {
+		ticketIDs = uc.parseTicketIDs(qrValue)
+	}
 
 	if len(ticketIDs) == 0 {
 		return &models.CheckoutResponse{
@@ -384,7 +452,7 @@ func (uc *StaffUseCase) processCheckout(ctx context.Context, userID int, ticketI
 
 	// Thực hiện check-out với optimistic locking (chống race condition)
 	// Query chỉ update nếu status = 'CHECKED_IN', trả về rows affected
-	rowsAffected, err := uc.staffRepo.UpdateTicketCheckout(ctx, ticketID)
+	rowsAffected, cashback, err := uc.staffRepo.UpdateTicketCheckout(ctx, ticketID)
 	if err != nil {
 		errMsg := "Lỗi khi cập nhật check-out"
 		result.Error = &errMsg
@@ -398,9 +466,14 @@ func (uc *StaffUseCase) processCheckout(ctx context.Context, userID int, ticketI
 		result.Error = &errMsg
 		return result
 	}
+	eventbus.Publish(ctx, eventbus.TicketCheckedOut{TicketID: ticketID, EventID: ticket.EventID, OccurredAt: now})
 
 	result.Success = true
 	msg := "Check-out thành công"
+	if cashback > 0 {
+		result.Cashback = &cashback
+		msg = fmt.Sprintf("Check-out thành công. Đã hoàn %dđ cashback vào ví của %s", cashback, ticket.CustomerName)
+	}
 	result.Message = &msg
 	checkOutTime := now.Format("15:04 02/01/2006")
 	result.CheckOutTime = &checkOutTime
@@ -455,8 +528,8 @@ func (uc *StaffUseCase) parseTicketIDs(qrValue string) []int {
 // ============================================================
 // GetReports - Lấy danh sách report cho staff
 // ============================================================
-func (uc *StaffUseCase) GetReports(ctx context.Context) ([]models.ReportListResponse, error) {
-	return uc.staffRepo.GetReportsForStaff(ctx)
+func (uc *StaffUseCase) GetReports(ctx context.Context, campusID *int) ([]models.ReportListResponse, error) {
+	return uc.staffRepo.GetReportsForStaff(ctx, campusID)
 }
 
 // ============================================================
@@ -506,3 +579,23 @@ func (uc *StaffUseCase) UpdateSystemConfig(ctx context.Context, config models.Sy
 
 	return nil
 }
+
+// ErrOccupancyForbidden - Organizer xem occupancy của sự kiện không phải của mình
+var ErrOccupancyForbidden = errors.New("not allowed to view occupancy of this event")
+
+// ============================================================
+// GetEventOccupancy - Số người đang ở trong sự kiện (STAFF, ADMIN, Organizer của sự kiện)
+// Trả về nil nếu sự kiện không tồn tại
+// ============================================================
+func (uc *StaffUseCase) GetEventOccupancy(ctx context.Context, userID int, role string, eventID int) (*models.EventOccupancy, error) {
+	if role == "ORGANIZER" {
+		isOwner, err := uc.staffRepo.VerifyEventOwnership(ctx, userID, eventID)
+		if err != nil {
+			return nil, err
+		}
+		if !isOwner {
+			return nil, ErrOccupancyForbidden
+		}
+	}
+	return uc.staffRepo.GetEventOccupancy(ctx, eventID)
+}
@@ -0,0 +1,37 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc"
+
+	"github.com/fpt-event-services/services/proto/ticketpb"
+	"github.com/fpt-event-services/services/ticket-lambda/models"
+)
+
+// GRPCTicketService - Adapter gọi ticket-lambda qua gRPC (TICKET_SERVICE_GRPC_ADDR)
+type GRPCTicketService struct {
+	client ticketpb.TicketServiceClient
+}
+
+// NewGRPCTicketService creates a TicketService over conn
+func NewGRPCTicketService(conn grpc.ClientConnInterface) *GRPCTicketService {
+	return &GRPCTicketService{client: ticketpb.NewTicketServiceClient(conn)}
+}
+
+func (s *GRPCTicketService) GetEventTicketCounts(ctx context.Context, eventID int) (*models.EventTicketCounts, error) {
+	resp, err := s.client.GetEventTicketCounts(ctx, &ticketpb.GetEventTicketCountsRequest{EventId: int32(eventID)})
+	if err != nil {
+		return nil, fmt.Errorf("get event ticket counts: %w", err)
+	}
+	return &models.EventTicketCounts{
+		EventID:    int(resp.GetEventId()),
+		Pending:    int(resp.GetPending()),
+		Booked:     int(resp.GetBooked()),
+		CheckedIn:  int(resp.GetCheckedIn()),
+		CheckedOut: int(resp.GetCheckedOut()),
+		Refunded:   int(resp.GetRefunded()),
+		Expired:    int(resp.GetExpired()),
+	}, nil
+}
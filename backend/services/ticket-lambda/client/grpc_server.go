@@ -0,0 +1,40 @@
+package client
+
+import (
+	"context"
+	"log"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/fpt-event-services/services/proto/ticketpb"
+)
+
+// GRPCServer - Phục vụ service TicketService (ticket.proto) bằng một TicketService Go
+// (thường là LocalTicketService); main.go đăng ký khi đặt GRPC_PORT
+type GRPCServer struct {
+	ticketpb.UnimplementedTicketServiceServer
+	svc TicketService
+}
+
+// NewGRPCServer creates a gRPC server backed by svc
+func NewGRPCServer(svc TicketService) *GRPCServer {
+	return &GRPCServer{svc: svc}
+}
+
+func (s *GRPCServer) GetEventTicketCounts(ctx context.Context, req *ticketpb.GetEventTicketCountsRequest) (*ticketpb.EventTicketCounts, error) {
+	c, err := s.svc.GetEventTicketCounts(ctx, int(req.GetEventId()))
+	if err != nil {
+		log.Printf("[RPC] TicketService.GetEventTicketCounts failed: %v", err)
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+	return &ticketpb.EventTicketCounts{
+		EventId:    int32(c.EventID),
+		Pending:    int32(c.Pending),
+		Booked:     int32(c.Booked),
+		CheckedIn:  int32(c.CheckedIn),
+		CheckedOut: int32(c.CheckedOut),
+		Refunded:   int32(c.Refunded),
+		Expired:    int32(c.Expired),
+	}, nil
+}
@@ -0,0 +1,61 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/fpt-event-services/common/rpc"
+	"github.com/fpt-event-services/services/ticket-lambda/models"
+	"github.com/fpt-event-services/services/ticket-lambda/repository"
+)
+
+// AddrEnv - Biến môi trường chứa địa chỉ gRPC của ticket-lambda (rỗng = in-process)
+const AddrEnv = "TICKET_SERVICE_GRPC_ADDR"
+
+// ============================================================
+// TicketService - API nội bộ của ticket-lambda cho các service khác
+// Khớp service TicketService trong services/proto/ticket.proto.
+// Service khác (event-lambda, ...) đọc số liệu vé qua interface này thay vì
+// tự viết SQL lên bảng Ticket
+// ============================================================
+type TicketService interface {
+	GetEventTicketCounts(ctx context.Context, eventID int) (*models.EventTicketCounts, error)
+}
+
+// LocalTicketService - Adapter in-process: gọi thẳng repository của ticket-lambda
+type LocalTicketService struct {
+	repo *repository.TicketRepository
+}
+
+// NewLocalTicketService creates a new in-process ticket service
+func NewLocalTicketService() *LocalTicketService {
+	return &LocalTicketService{repo: repository.DefaultTicketRepository()}
+}
+
+// GetEventTicketCounts - Số vé theo trạng thái của sự kiện
+func (s *LocalTicketService) GetEventTicketCounts(ctx context.Context, eventID int) (*models.EventTicketCounts, error) {
+	counts, err := s.repo.GetEventTicketCounts(ctx, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("get event ticket counts: %w", err)
+	}
+	return counts, nil
+}
+
+var (
+	defaultService     TicketService
+	defaultServiceOnce sync.Once
+)
+
+// Default trả về TicketService dùng chung của process:
+// gRPC khi đặt TICKET_SERVICE_GRPC_ADDR, ngược lại adapter in-process
+func Default() TicketService {
+	defaultServiceOnce.Do(func() {
+		if rpc.ServiceAddr(AddrEnv) != "" {
+			defaultService = NewGRPCTicketService(rpc.MustDial(AddrEnv))
+			return
+		}
+		defaultService = NewLocalTicketService()
+	})
+	return defaultService
+}
@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/fpt-event-services/common/authctx"
+	apperrors "github.com/fpt-event-services/common/errors"
+	"github.com/fpt-event-services/common/permission"
+	"github.com/fpt-event-services/services/ticket-lambda/models"
+)
+
+// ============================================================
+// HandleIssueCompTickets - POST /api/organizer/events/{id}/comp-tickets
+// Body: {"categoryTicketId": 3, "emails": ["a@fpt.edu.vn", ...]}
+// Phát vé mời 0 đồng (BOOKED, gửi email QR); chủ sự kiện hoặc ADMIN
+// ============================================================
+func (h *TicketHandler) HandleIssueCompTickets(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	userID, err := permission.Require(ctx, permission.TicketCompIssue)
+	if errors.Is(err, authctx.ErrUnauthenticated) {
+		return createMessageResponse(http.StatusUnauthorized, "User ID not found")
+	}
+	if err != nil {
+		return createMessageResponse(http.StatusForbidden, "Only organizers can issue complimentary tickets")
+	}
+	role := authctx.Role(ctx)
+
+	eventID, err := strconv.Atoi(request.PathParameters["id"])
+	if err != nil || eventID <= 0 {
+		return createMessageResponse(http.StatusBadRequest, "Invalid event ID")
+	}
+
+	var req models.CompTicketRequest
+	if err := json.Unmarshal([]byte(request.Body), &req); err != nil {
+		return createMessageResponse(http.StatusBadRequest, "Invalid request body")
+	}
+
+	resp, err := h.useCase.IssueCompTickets(ctx, userID, role, eventID, req)
+	if err != nil {
+		if appErr, ok := apperrors.AsAppError(err); ok {
+			if appErr.Code == apperrors.ErrCodeBusinessRule {
+				return createMessageResponse(http.StatusConflict, appErr.Message)
+			}
+			if appErr.HTTPStatus < http.StatusInternalServerError {
+				return createMessageResponse(appErr.HTTPStatus, appErr.Message)
+			}
+		}
+		return createMessageResponse(http.StatusInternalServerError, "Failed to issue complimentary tickets")
+	}
+
+	return createJSONResponse(http.StatusOK, resp)
+}
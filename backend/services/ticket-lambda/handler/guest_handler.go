@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/fpt-event-services/common/logger"
+	"github.com/fpt-event-services/services/ticket-lambda/models"
+)
+
+// ============================================================
+// HandleGuestCheckout - POST /api/guest/checkout (không cần đăng nhập)
+// Body: {"eventId", "categoryTicketId", "seatIds", "email", "fullName", "phone"}
+// Trả paymentUrl như /api/payment-ticket; mã tra cứu gửi qua email sau khi thanh toán
+// ============================================================
+func (h *TicketHandler) HandleGuestCheckout(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	var req models.GuestCheckoutRequest
+	if err := json.Unmarshal([]byte(request.Body), &req); err != nil {
+		return createMessageResponse(http.StatusBadRequest, "Invalid request body")
+	}
+	if len(req.SeatIDs) > 4 {
+		return createMessageResponse(http.StatusBadRequest, "Maximum 4 seats per purchase")
+	}
+
+	result, err := h.useCase.GuestCheckout(ctx, req)
+	if err != nil {
+		return ticketAccessErrorResponse(err, "Failed to create guest checkout")
+	}
+	return createJSONResponse(http.StatusOK, result)
+}
+
+// ============================================================
+// HandleGetGuestTickets - GET /api/guest/tickets?code=... (không cần đăng nhập)
+// Vé (kèm QR) của khách theo mã tra cứu trong email
+// Giới hạn GUEST_LOOKUP_LIMIT lần / IP trong GUEST_LOOKUP_WINDOW_MINUTES phút (chống dò mã)
+// ============================================================
+func (h *TicketHandler) HandleGetGuestTickets(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	code := request.QueryStringParameters["code"]
+	if code == "" {
+		return createMessageResponse(http.StatusBadRequest, "Missing code")
+	}
+
+	ip := clientIP(request)
+	if allowed, retryAfter := guestLookupLimiter.allow(ip); !allowed {
+		logger.Default().WithContext(ctx).Warn("[GUEST_LOOKUP] Rate limited", "ip", ip)
+		resp, err := createMessageResponse(http.StatusTooManyRequests,
+			fmt.Sprintf("Bạn đã tra cứu quá nhiều lần, vui lòng thử lại sau %d phút", int(retryAfter.Minutes())+1))
+		resp.Headers["Retry-After"] = strconv.Itoa(int(retryAfter.Seconds()) + 1)
+		return resp, err
+	}
+
+	result, err := h.useCase.LookupGuestTickets(ctx, code)
+	if err != nil {
+		return ticketAccessErrorResponse(err, "Failed to look up guest tickets")
+	}
+	resp, err := createJSONResponse(http.StatusOK, result)
+	resp.Headers["Cache-Control"] = "private, no-store"
+	return resp, err
+}
+
+// guestLookupLimiter - Giới hạn tra cứu mã của khách theo IP (cùng cơ chế cửa sổ trượt với gửi lại vé)
+var guestLookupLimiter = &resendRateLimiter{
+	window: time.Duration(envInt("GUEST_LOOKUP_WINDOW_MINUTES", 15)) * time.Minute,
+	limit:  envInt("GUEST_LOOKUP_LIMIT", 10),
+	hits:   make(map[string][]time.Time),
+}
+
+// clientIP - IP của client (sau proxy / API Gateway thì lấy từ X-Forwarded-For)
+func clientIP(request events.APIGatewayProxyRequest) string {
+	if forwarded := request.Headers["X-Forwarded-For"]; forwarded != "" {
+		return strings.TrimSpace(strings.Split(forwarded, ",")[0])
+	}
+	if ip := request.Headers["X-Real-IP"]; ip != "" {
+		return ip
+	}
+	return request.RequestContext.Identity.SourceIP
+}
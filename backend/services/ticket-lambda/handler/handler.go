@@ -2,14 +2,21 @@ package handler
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/url"
+	"slices"
 	"strconv"
 	"strings"
 
 	"github.com/aws/aws-lambda-go/events"
+	"github.com/fpt-event-services/common/authctx"
+	apperrors "github.com/fpt-event-services/common/errors"
+	"github.com/fpt-event-services/common/pagination"
+	"github.com/fpt-event-services/services/ticket-lambda/models"
+	"github.com/fpt-event-services/services/ticket-lambda/repository"
 	"github.com/fpt-event-services/services/ticket-lambda/usecase"
 )
 
@@ -25,30 +32,19 @@ func NewTicketHandler() *TicketHandler {
 
 // HandleGetMyTickets - GET /api/registrations/my-tickets
 func (h *TicketHandler) HandleGetMyTickets(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
-	// Get userId from request attribute (set by JWT middleware)
-	userIDStr := request.Headers["X-User-Id"]
-	fmt.Printf("[DEBUG] HandleGetMyTickets - X-User-Id header: '%s'\n", userIDStr)
-	fmt.Printf("[DEBUG] HandleGetMyTickets - All headers: %v\n", request.Headers)
-
-	if userIDStr == "" {
+	// Get userId from request context (set by JWT middleware)
+	userID, ok := authctx.UserID(ctx)
+	if !ok {
 		return createMessageResponse(http.StatusUnauthorized, "Unauthorized: missing userId")
 	}
 
-	userID, err := strconv.Atoi(userIDStr)
-	if err != nil {
-		fmt.Printf("[DEBUG] HandleGetMyTickets - Invalid userId: %s, error: %v\n", userIDStr, err)
-		return createMessageResponse(http.StatusBadRequest, "Invalid userId")
-	}
-
 	// Check if pagination params are provided
 	params := request.QueryStringParameters
-	pageStr := params["page"]
-	limitStr := params["limit"]
 	search := params["search"]
 	status := params["status"]
 
 	// If no pagination params, use old endpoint
-	if pageStr == "" && limitStr == "" {
+	if !pagination.Requested(params) {
 		fmt.Printf("[DEBUG] HandleGetMyTickets - Fetching tickets for userID: %d (non-paginated)\n", userID)
 		tickets, err := h.useCase.GetMyTickets(ctx, userID)
 		if err != nil {
@@ -59,58 +55,90 @@ func (h *TicketHandler) HandleGetMyTickets(ctx context.Context, request events.A
 		return createJSONResponse(http.StatusOK, tickets)
 	}
 
-	// Parse pagination params
-	page, err := strconv.Atoi(pageStr)
-	if err != nil || page < 1 {
-		page = 1
-	}
-
-	limit, err := strconv.Atoi(limitStr)
-	if err != nil || limit < 1 {
-		limit = 10
-	}
-	if limit > 100 {
-		limit = 100
-	}
+	p := pagination.ParseParams(params, pagination.DefaultPageSize, pagination.MaxPageSize)
 
-	fmt.Printf("[DEBUG] HandleGetMyTickets - Fetching tickets for userID: %d (page: %d, limit: %d, search: %s, status: %s)\n",
-		userID, page, limit, search, status)
+	fmt.Printf("[DEBUG] HandleGetMyTickets - Fetching tickets for userID: %d (page: %d, pageSize: %d, search: %s, status: %s)\n",
+		userID, p.Page, p.PageSize, search, status)
 
-	paginatedTickets, err := h.useCase.GetMyTicketsPaginated(ctx, userID, page, limit, search, status)
+	paginatedTickets, err := h.useCase.GetMyTicketsPaginated(ctx, userID, p, search, status)
 	if err != nil {
 		fmt.Printf("[ERROR] HandleGetMyTickets - Error: %v\n", err)
 		return createMessageResponse(http.StatusInternalServerError, "Internal server error when loading tickets")
 	}
 
-	fmt.Printf("[DEBUG] HandleGetMyTickets - Found %d tickets (total: %d)\n", len(paginatedTickets.Tickets), paginatedTickets.TotalRecords)
+	fmt.Printf("[DEBUG] HandleGetMyTickets - Found %d tickets (total: %d)\n", len(paginatedTickets.Items), paginatedTickets.TotalItems)
 	return createJSONResponse(http.StatusOK, paginatedTickets)
 }
 
-// HandleGetTicketList - GET /api/tickets/list?eventId=
-func (h *TicketHandler) HandleGetTicketList(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
-	// Get role and userId from headers (set by JWT middleware)
-	role := request.Headers["X-User-Role"]
-	userIDStr := request.Headers["X-User-Id"]
+// ============================================================
+// HandleGetMyTicketsGrouped - GET /api/registrations/my-tickets/grouped
+// Vé đã mua nhóm theo sự kiện sắp diễn ra / đã qua, mỗi vé kèm timeline trạng thái
+// ============================================================
+func (h *TicketHandler) HandleGetMyTicketsGrouped(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	userID, ok := authctx.UserID(ctx)
+	if !ok {
+		return createMessageResponse(http.StatusUnauthorized, "Unauthorized: missing userId")
+	}
+
+	grouped, err := h.useCase.GetMyTicketsGrouped(ctx, userID)
+	if err != nil {
+		fmt.Printf("[ERROR] HandleGetMyTicketsGrouped - Error: %v\n", err)
+		return createMessageResponse(http.StatusInternalServerError, "Internal server error when loading tickets")
+	}
+	return createJSONResponse(http.StatusOK, grouped)
+}
 
-	if role == "" || userIDStr == "" {
+// ============================================================
+// HandleGetTicketList - GET /api/tickets/list
+// Chỉ ?eventId=: mảng vé như cũ (tương thích frontend hiện tại)
+// Có page / pageSize (limit) / status / categoryTicketId / checkedIn / search / sort / order: trả PaginatedTicketsResponse
+// ?format=csv: file CSV mọi vé khớp bộ lọc
+// ============================================================
+func (h *TicketHandler) HandleGetTicketList(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	// Get role and userId from request context (set by JWT middleware)
+	role := authctx.Role(ctx)
+	userID, ok := authctx.UserID(ctx)
+	if role == "" || !ok {
 		return createMessageResponse(http.StatusUnauthorized, "Unauthorized")
 	}
 
-	userID, err := strconv.Atoi(userIDStr)
+	params := request.QueryStringParameters
+	filter, filtered, err := parseTicketListFilter(params)
 	if err != nil {
-		return createMessageResponse(http.StatusBadRequest, "Invalid userId")
+		return createMessageResponse(http.StatusBadRequest, err.Error())
 	}
 
-	// Optional eventId filter
-	var eventID *int
-	if eventIDStr := request.QueryStringParameters["eventId"]; eventIDStr != "" {
-		id, err := strconv.Atoi(eventIDStr)
-		if err == nil {
-			eventID = &id
+	switch params["format"] {
+	case "csv":
+		fileName, data, err := h.useCase.ExportTicketsCSV(ctx, role, userID, filter)
+		if err != nil {
+			return ticketAccessErrorResponse(err, "Error exporting tickets")
 		}
+		return events.APIGatewayProxyResponse{
+			StatusCode: http.StatusOK,
+			Headers: map[string]string{
+				"Content-Type":        "text/csv; charset=utf-8",
+				"Content-Disposition": fmt.Sprintf("attachment; filename=\"%s\"", fileName),
+				"Cache-Control":       "private, no-store",
+			},
+			Body:            base64.StdEncoding.EncodeToString(data),
+			IsBase64Encoded: true,
+		}, nil
+	case "", "json":
+	default:
+		return createMessageResponse(http.StatusBadRequest, "format must be json or csv")
 	}
 
-	tickets, err := h.useCase.GetTicketsByRole(ctx, role, userID, eventID)
+	if filtered {
+		page, err := h.useCase.ListTickets(ctx, role, userID, filter)
+		if err != nil {
+			fmt.Printf("[ERROR] HandleGetTicketList - Error: %v\n", err)
+			return createMessageResponse(http.StatusInternalServerError, "Error loading tickets")
+		}
+		return createJSONResponse(http.StatusOK, page)
+	}
+
+	tickets, err := h.useCase.GetTicketsByRole(ctx, role, userID, filter.EventID)
 	if err != nil {
 		return createMessageResponse(http.StatusInternalServerError, "Error loading tickets")
 	}
@@ -118,6 +146,89 @@ func (h *TicketHandler) HandleGetTicketList(ctx context.Context, request events.
 	return createJSONResponse(http.StatusOK, tickets)
 }
 
+// parseTicketListFilter - Đọc bộ lọc từ query; filtered = có tham số ngoài eventId
+func parseTicketListFilter(params map[string]string) (models.TicketListFilter, bool, error) {
+	filter := models.TicketListFilter{Page: 1, Limit: 50, Desc: true}
+	filtered := false
+
+	// eventId sai định dạng bị bỏ qua như trước
+	if id, err := strconv.Atoi(params["eventId"]); err == nil {
+		filter.EventID = &id
+	}
+	optionalInt := func(name string, min int) (*int, error) {
+		v := params[name]
+		if v == "" {
+			return nil, nil
+		}
+		filtered = true
+		n, err := strconv.Atoi(v)
+		if err != nil || n < min {
+			return nil, fmt.Errorf("%s must be an integer >= %d", name, min)
+		}
+		return &n, nil
+	}
+
+	page, err := optionalInt("page", 1)
+	if err != nil {
+		return filter, false, err
+	}
+	if page != nil {
+		filter.Page = *page
+	}
+	limitParam := "pageSize"
+	if params[limitParam] == "" {
+		limitParam = "limit" // Tên cũ của pageSize
+	}
+	limit, err := optionalInt(limitParam, 1)
+	if err != nil {
+		return filter, false, err
+	}
+	if limit != nil {
+		filter.Limit = min(*limit, models.TicketListMaxLimit)
+	}
+	if filter.CategoryTicketID, err = optionalInt("categoryTicketId", 1); err != nil {
+		return filter, false, err
+	}
+
+	if status := strings.ToUpper(params["status"]); status != "" {
+		if !slices.Contains(models.TicketStatuses, status) {
+			return filter, false, fmt.Errorf("status must be one of %s", strings.Join(models.TicketStatuses, ", "))
+		}
+		filter.Status = status
+		filtered = true
+	}
+	if v := params["checkedIn"]; v != "" {
+		checkedIn, err := strconv.ParseBool(v)
+		if err != nil {
+			return filter, false, fmt.Errorf("checkedIn must be true or false")
+		}
+		filter.CheckedIn = &checkedIn
+		filtered = true
+	}
+	if search := strings.TrimSpace(params["search"]); search != "" {
+		filter.Search = search
+		filtered = true
+	}
+	if sort := params["sort"]; sort != "" {
+		if !slices.Contains(models.TicketListSorts, sort) {
+			return filter, false, fmt.Errorf("sort must be one of %s", strings.Join(models.TicketListSorts, ", "))
+		}
+		filter.Sort = sort
+		filtered = true
+	}
+	switch strings.ToLower(params["order"]) {
+	case "":
+	case "asc":
+		filter.Desc = false
+		filtered = true
+	case "desc":
+		filtered = true
+	default:
+		return filter, false, fmt.Errorf("order must be asc or desc")
+	}
+	return filter, filtered, nil
+}
+
 // HandleGetCategoryTickets - GET /api/category-tickets?eventId=
 func (h *TicketHandler) HandleGetCategoryTickets(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
 	eventIDStr := request.QueryStringParameters["eventId"]
@@ -140,26 +251,19 @@ func (h *TicketHandler) HandleGetCategoryTickets(ctx context.Context, request ev
 
 // HandleGetMyBills - GET /api/bills/my-bills
 func (h *TicketHandler) HandleGetMyBills(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
-	userIDStr := request.Headers["X-User-Id"]
-	if userIDStr == "" {
+	userID, ok := authctx.UserID(ctx)
+	if !ok {
 		return createMessageResponse(http.StatusUnauthorized, "Unauthorized: missing userId")
 	}
 
-	userID, err := strconv.Atoi(userIDStr)
-	if err != nil {
-		return createMessageResponse(http.StatusBadRequest, "Invalid userId")
-	}
-
 	// Check if pagination params are provided
 	params := request.QueryStringParameters
-	pageStr := params["page"]
-	limitStr := params["limit"]
 	search := params["search"]
 	paymentStatus := params["status"]
 	paymentMethod := params["method"]
 
 	// If no pagination params, use old endpoint
-	if pageStr == "" && limitStr == "" {
+	if !pagination.Requested(params) {
 		bills, err := h.useCase.GetMyBills(ctx, userID)
 		if err != nil {
 			return createMessageResponse(http.StatusInternalServerError, "Error loading bills")
@@ -167,21 +271,9 @@ func (h *TicketHandler) HandleGetMyBills(ctx context.Context, request events.API
 		return createJSONResponse(http.StatusOK, bills)
 	}
 
-	// Parse pagination params
-	page, err := strconv.Atoi(pageStr)
-	if err != nil || page < 1 {
-		page = 1
-	}
-
-	limit, err := strconv.Atoi(limitStr)
-	if err != nil || limit < 1 {
-		limit = 10
-	}
-	if limit > 100 {
-		limit = 100
-	}
+	p := pagination.ParseParams(params, pagination.DefaultPageSize, pagination.MaxPageSize)
 
-	paginatedBills, err := h.useCase.GetMyBillsPaginated(ctx, userID, page, limit, search, paymentStatus, paymentMethod)
+	paginatedBills, err := h.useCase.GetMyBillsPaginated(ctx, userID, p, search, paymentStatus, paymentMethod)
 	if err != nil {
 		return createMessageResponse(http.StatusInternalServerError, "Error loading bills")
 	}
@@ -218,9 +310,7 @@ func createMessageResponse(statusCode int, message string) (events.APIGatewayPro
 
 func defaultHeaders() map[string]string {
 	return map[string]string{
-		"Content-Type":                     "application/json;charset=UTF-8",
-		"Access-Control-Allow-Origin":      "*",
-		"Access-Control-Allow-Credentials": "true",
+		"Content-Type": "application/json;charset=UTF-8",
 	}
 }
 
@@ -228,6 +318,7 @@ func defaultHeaders() map[string]string {
 // HandlePaymentTicket - GET /api/payment-ticket
 // Tạo URL thanh toán VNPay cho vé sự kiện
 // KHỚP VỚI Java PaymentJwtController
+// ?installment=true: chỉ thanh toán tiền cọc (sự kiện có cấu hình trả góp)
 // ============================================================
 func (h *TicketHandler) HandlePaymentTicket(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
 	// Get query params
@@ -291,21 +382,106 @@ func (h *TicketHandler) HandlePaymentTicket(ctx context.Context, request events.
 		return createMessageResponse(http.StatusBadRequest, "Maximum 4 seats per purchase")
 	}
 
+	// Sự kiện bật hàng chờ mở bán: chỉ user đã tới lượt mới được giữ ghế
+	if resp, blocked, err := h.bookingQueueGate(ctx, userID, eventID); blocked {
+		return resp, err
+	}
+
 	// Generate VNPay URL for multiple seats
-	paymentURL, err := h.useCase.CreatePaymentURL(ctx, userID, eventID, categoryTicketID, seatIDs)
+	installment := request.QueryStringParameters["installment"] == "true"
+	result, err := h.useCase.CreatePaymentURL(ctx, userID, eventID, categoryTicketID, seatIDs, installment)
 	if err != nil {
 		return createMessageResponse(http.StatusBadRequest, err.Error())
 	}
 
-	// Return redirect or URL
-	return events.APIGatewayProxyResponse{
-		StatusCode: http.StatusOK,
-		Headers: map[string]string{
-			"Content-Type":                "application/json;charset=UTF-8",
-			"Access-Control-Allow-Origin": "*",
-		},
-		Body: `{"paymentUrl":"` + paymentURL + `"}`,
-	}, nil
+	// paymentUrl giữ nguyên như cũ; holdExpiresAt để client hiển thị đồng hồ đếm ngược
+	return createJSONResponse(http.StatusOK, result)
+}
+
+// ============================================================
+// HandleQuoteTickets - POST /api/tickets/quote
+// Body: {"eventId": 1, "seatIds": [10, 11]}
+// Trả về giá từng ghế, phí, giảm giá và tổng tiền theo DB; KHÔNG giữ ghế
+// ============================================================
+func (h *TicketHandler) HandleQuoteTickets(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	if _, ok := authctx.UserID(ctx); !ok {
+		return createMessageResponse(http.StatusUnauthorized, "User ID not found")
+	}
+
+	var req models.TicketQuoteRequest
+	if err := json.Unmarshal([]byte(request.Body), &req); err != nil {
+		return createMessageResponse(http.StatusBadRequest, "Invalid request body")
+	}
+	if req.EventID <= 0 {
+		return createMessageResponse(http.StatusBadRequest, "eventId is required")
+	}
+	if len(req.SeatIDs) == 0 || len(req.SeatIDs) > 4 {
+		return createMessageResponse(http.StatusBadRequest, "Vui lòng chọn từ 1 đến 4 ghế")
+	}
+	seen := make(map[int]bool, len(req.SeatIDs))
+	for _, id := range req.SeatIDs {
+		if id <= 0 || seen[id] {
+			return createMessageResponse(http.StatusBadRequest, "seatIds không hợp lệ hoặc bị trùng")
+		}
+		seen[id] = true
+	}
+
+	quote, err := h.useCase.QuoteSeats(ctx, req.EventID, req.SeatIDs)
+	if err != nil {
+		if appErr, ok := apperrors.AsAppError(err); ok && appErr.Code == apperrors.ErrCodeNotFound {
+			return createMessageResponse(http.StatusNotFound, appErr.Message)
+		}
+		return createMessageResponse(http.StatusInternalServerError, "Failed to calculate quote")
+	}
+
+	return createJSONResponse(http.StatusOK, quote)
+}
+
+// ============================================================
+// HandleGetHolds - GET /api/registrations/holds
+// Vé PENDING đang giữ ghế của user + thời gian còn lại
+// ============================================================
+func (h *TicketHandler) HandleGetHolds(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	userID, ok := authctx.UserID(ctx)
+	if !ok {
+		return createMessageResponse(http.StatusUnauthorized, "User ID not found")
+	}
+
+	holds, err := h.useCase.GetActiveHolds(ctx, userID)
+	if err != nil {
+		return createMessageResponse(http.StatusInternalServerError, "Failed to get holds")
+	}
+
+	return createJSONResponse(http.StatusOK, holds)
+}
+
+// ============================================================
+// HandleExtendHolds - POST /api/registrations/holds/extend
+// Gia hạn giữ ghế thêm 3 phút (mỗi vé chỉ 1 lần)
+// Body (optional): {"ticketIds": [1,2]} - rỗng = tất cả vé đang giữ
+// ============================================================
+func (h *TicketHandler) HandleExtendHolds(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	userID, ok := authctx.UserID(ctx)
+	if !ok {
+		return createMessageResponse(http.StatusUnauthorized, "User ID not found")
+	}
+
+	var req models.ExtendHoldRequest
+	if strings.TrimSpace(request.Body) != "" {
+		if err := json.Unmarshal([]byte(request.Body), &req); err != nil {
+			return createMessageResponse(http.StatusBadRequest, "Invalid request body")
+		}
+	}
+
+	holds, err := h.useCase.ExtendHolds(ctx, userID, req.TicketIDs)
+	if err != nil {
+		if appErr, ok := apperrors.AsAppError(err); ok && appErr.Code == apperrors.ErrCodeBusinessRule {
+			return createMessageResponse(http.StatusConflict, appErr.Message)
+		}
+		return createMessageResponse(http.StatusInternalServerError, "Failed to extend holds")
+	}
+
+	return createJSONResponse(http.StatusOK, holds)
 }
 
 // ============================================================
@@ -314,31 +490,49 @@ func (h *TicketHandler) HandlePaymentTicket(ctx context.Context, request events.
 // KHỚP VỚI Java BuyTicketJwtController
 // ============================================================
 func (h *TicketHandler) HandleBuyTicket(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
-	// Get VNPay params
-	vnpAmount := request.QueryStringParameters["vnp_Amount"]
-	vnpResponseCode := request.QueryStringParameters["vnp_ResponseCode"]
-	vnpOrderInfo := request.QueryStringParameters["vnp_OrderInfo"]
-	vnpTxnRef := request.QueryStringParameters["vnp_TxnRef"]
-	vnpSecureHash := request.QueryStringParameters["vnp_SecureHash"]
+	// Get VNPay params (toàn bộ query để xác thực chữ ký)
+	params := url.Values{}
+	for k, v := range request.QueryStringParameters {
+		params.Set(k, v)
+	}
 
 	// ⭐ DEBUG: Log VNPay callback parameters
 	fmt.Printf("\n========== VNPAY CALLBACK RECEIVED ==========\n")
-	fmt.Printf("[Handler] vnp_Amount: %s\n", vnpAmount)
-	fmt.Printf("[Handler] vnp_ResponseCode: %s\n", vnpResponseCode)
-	fmt.Printf("[Handler] vnp_OrderInfo: %s\n", vnpOrderInfo)
-	fmt.Printf("[Handler] vnp_TxnRef: %s\n", vnpTxnRef)
+	fmt.Printf("[Handler] vnp_Amount: %s\n", params.Get("vnp_Amount"))
+	fmt.Printf("[Handler] vnp_ResponseCode: %s\n", params.Get("vnp_ResponseCode"))
+	fmt.Printf("[Handler] vnp_OrderInfo: %s\n", params.Get("vnp_OrderInfo"))
+	fmt.Printf("[Handler] vnp_TxnRef: %s\n", params.Get("vnp_TxnRef"))
 	fmt.Printf("===========================================\n\n")
 
-	// Process payment callback
-	ticketIds, err := h.useCase.ProcessPaymentCallback(ctx, vnpAmount, vnpResponseCode, vnpOrderInfo, vnpTxnRef, vnpSecureHash)
+	// Process payment callback (sai chữ ký → trang thất bại, vé giữ nguyên PENDING)
+	ticketIds, err := h.useCase.ProcessPaymentCallback(ctx, params)
 	if err != nil {
 		// Redirect to payment failed page
 		frontendURL := "http://localhost:3000/dashboard/payment/failed?status=failed&method=vnpay&reason=" + url.QueryEscape(err.Error())
 		return events.APIGatewayProxyResponse{
 			StatusCode: http.StatusFound,
 			Headers: map[string]string{
-				"Location":                    frontendURL,
-				"Access-Control-Allow-Origin": "*",
+				"Location": frontendURL,
+			},
+		}, nil
+	}
+
+	// Mua vé học kỳ: kết quả là holder_id, vé được cấp riêng cho từng sự kiện
+	if repository.IsPassTxnRef(params.Get("vnp_TxnRef")) {
+		return events.APIGatewayProxyResponse{
+			StatusCode: http.StatusFound,
+			Headers: map[string]string{
+				"Location": "http://localhost:3000/dashboard/payment/success?status=success&method=vnpay&passHolderId=" + url.QueryEscape(ticketIds),
+			},
+		}, nil
+	}
+
+	// Trả một kỳ trả góp: kết quả là bill_id
+	if repository.IsInstallmentTxnRef(params.Get("vnp_TxnRef")) {
+		return events.APIGatewayProxyResponse{
+			StatusCode: http.StatusFound,
+			Headers: map[string]string{
+				"Location": "http://localhost:3000/dashboard/payment/success?status=success&method=vnpay&billId=" + url.QueryEscape(ticketIds),
 			},
 		}, nil
 	}
@@ -348,8 +542,7 @@ func (h *TicketHandler) HandleBuyTicket(ctx context.Context, request events.APIG
 	return events.APIGatewayProxyResponse{
 		StatusCode: http.StatusFound,
 		Headers: map[string]string{
-			"Location":                    frontendURL,
-			"Access-Control-Allow-Origin": "*",
+			"Location": frontendURL,
 		},
 	}, nil
 }
@@ -360,36 +553,14 @@ func (h *TicketHandler) HandleBuyTicket(ctx context.Context, request events.APIG
 // ============================================================
 func (h *TicketHandler) HandleGetWalletBalance(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
 	fmt.Printf("\n[WALLET_FETCH] === START GET WALLET BALANCE ===\n")
-	fmt.Printf("[WALLET_FETCH] 📋 All request headers: %v\n", request.Headers)
-	fmt.Printf("[WALLET_FETCH] 📋 Query string params: %v\n", request.QueryStringParameters)
-
-	// Extract userId from query parameter (also in header if passed from middleware)
-	userIDStr := request.QueryStringParameters["userId"]
-	fmt.Printf("[WALLET_FETCH] 🔍 userID from query: '%s'\n", userIDStr)
-
-	if userIDStr == "" {
-		// Try from headers (X-User-Id set by auth middleware)
-		userIDStr = request.Headers["X-User-Id"]
-		fmt.Printf("[WALLET_FETCH] 🔍 userID from header X-User-Id: '%s'\n", userIDStr)
-
-		// Also try case variations
-		if userIDStr == "" {
-			userIDStr = request.Headers["x-user-id"]
-			fmt.Printf("[WALLET_FETCH] 🔍 userID from header x-user-id: '%s'\n", userIDStr)
-		}
-	}
 
-	if userIDStr == "" {
+	// Chỉ lấy userId từ context đã xác thực (không nhận ?userId= hay header từ client)
+	userID, ok := authctx.UserID(ctx)
+	if !ok {
 		fmt.Printf("[WALLET_FETCH] ❌ User ID not found in request\n")
 		return createMessageResponse(http.StatusUnauthorized, "User ID not found")
 	}
 
-	userID, err := strconv.Atoi(userIDStr)
-	if err != nil {
-		fmt.Printf("[WALLET_FETCH] ❌ Invalid user ID format: '%s', error: %v\n", userIDStr, err)
-		return createMessageResponse(http.StatusBadRequest, "Invalid user ID format")
-	}
-
 	fmt.Printf("[WALLET_FETCH] ✅ Extracted userID: %d\n", userID)
 
 	// Get wallet balance from use case
@@ -406,8 +577,7 @@ func (h *TicketHandler) HandleGetWalletBalance(ctx context.Context, request even
 	return events.APIGatewayProxyResponse{
 		StatusCode: http.StatusOK,
 		Headers: map[string]string{
-			"Content-Type":                "application/json;charset=UTF-8",
-			"Access-Control-Allow-Origin": "*",
+			"Content-Type": "application/json;charset=UTF-8",
 		},
 		Body: fmt.Sprintf(`{"balance":%.2f}`, balance),
 	}, nil
@@ -417,24 +587,21 @@ func (h *TicketHandler) HandleGetWalletBalance(ctx context.Context, request even
 // HandleWalletPayTicket - POST /api/wallet/pay-ticket
 // Process ticket purchase using wallet balance
 // Returns 402 Payment Required if insufficient balance
+// "installment": true → chỉ trừ tiền cọc, phần còn lại trả theo lịch trả góp
 // ============================================================
 func (h *TicketHandler) HandleWalletPayTicket(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
-	// Extract userId from header (set by auth middleware)
-	userIDStr := request.Headers["X-User-Id"]
-	if userIDStr == "" {
+	// Extract userId from request context (set by auth middleware)
+	userID, ok := authctx.UserID(ctx)
+	if !ok {
 		return createMessageResponse(http.StatusUnauthorized, "User ID not found")
 	}
 
-	userID, err := strconv.Atoi(userIDStr)
-	if err != nil {
-		return createMessageResponse(http.StatusBadRequest, "Invalid user ID format")
-	}
-
 	// Parse JSON body for POST request
 	type WalletPaymentRequest struct {
 		EventID          int   `json:"eventId"`
 		CategoryTicketID int   `json:"categoryTicketId"`
 		SeatIDs          []int `json:"seatIds"`
+		Installment      bool  `json:"installment"`
 	}
 
 	var paymentReq WalletPaymentRequest
@@ -517,6 +684,11 @@ func (h *TicketHandler) HandleWalletPayTicket(ctx context.Context, request event
 		return createMessageResponse(http.StatusBadRequest, "Maximum 4 seats per purchase")
 	}
 
+	// Sự kiện bật hàng chờ mở bán: chỉ user đã tới lượt mới được giữ ghế
+	if resp, blocked, err := h.bookingQueueGate(ctx, userID, paymentReq.EventID); blocked {
+		return resp, err
+	}
+
 	// Get wallet balance
 	balance, err := h.useCase.GetWalletBalance(ctx, userID)
 	if err != nil {
@@ -529,22 +701,39 @@ func (h *TicketHandler) HandleWalletPayTicket(ctx context.Context, request event
 		return createMessageResponse(http.StatusBadRequest, err.Error())
 	}
 
+	// Trả góp: số tiền cần trong ví là tiền cọc
+	required := totalAmount
+	if paymentReq.Installment {
+		deposit, err := h.useCase.InstallmentDeposit(ctx, paymentReq.EventID, totalAmount)
+		if err != nil {
+			if appErr, ok := apperrors.AsAppError(err); ok && appErr.HTTPStatus < http.StatusInternalServerError {
+				return createMessageResponse(http.StatusBadRequest, appErr.Message)
+			}
+			return createMessageResponse(http.StatusInternalServerError, err.Error())
+		}
+		required = deposit
+	}
+
 	// Check insufficient balance
-	if balance < float64(totalAmount) {
-		shortage := float64(totalAmount) - balance
+	if balance < float64(required) {
+		shortage := float64(required) - balance
 		return events.APIGatewayProxyResponse{
 			StatusCode: http.StatusPaymentRequired, // 402
 			Headers: map[string]string{
-				"Content-Type":                "application/json;charset=UTF-8",
-				"Access-Control-Allow-Origin": "*",
+				"Content-Type": "application/json;charset=UTF-8",
 			},
-			Body: fmt.Sprintf(`{"error":"insufficient_balance","required":%d,"current":%.2f,"shortage":%.2f}`, totalAmount, balance, shortage),
+			Body: fmt.Sprintf(`{"error":"insufficient_balance","required":%d,"current":%.2f,"shortage":%.2f}`, required, balance, shortage),
 		}, nil
 	}
 
 	// Process wallet payment
-	ticketIds, err := h.useCase.ProcessWalletPayment(ctx, userID, paymentReq.EventID, paymentReq.CategoryTicketID, paymentReq.SeatIDs, totalAmount)
+	ticketIds, err := h.useCase.ProcessWalletPayment(ctx, userID, paymentReq.EventID, paymentReq.CategoryTicketID, paymentReq.SeatIDs, totalAmount, paymentReq.Installment)
 	if err != nil {
+		// Vi phạm quy tắc đặt vé (ví dụ ghế người đi kèm mua riêng)
+		if appErr, ok := apperrors.AsAppError(err); ok && appErr.Code == apperrors.ErrCodeBusinessRule {
+			return createMessageResponse(http.StatusBadRequest, appErr.Message)
+		}
+
 		// Check if error is due to closed/invalid event status
 		if strings.Contains(err.Error(), "đã kết thúc") || strings.Contains(err.Error(), "đã đóng") {
 			return createMessageResponse(http.StatusBadRequest, err.Error())
@@ -560,20 +749,18 @@ func (h *TicketHandler) HandleWalletPayTicket(ctx context.Context, request event
 				return events.APIGatewayProxyResponse{
 					StatusCode: http.StatusPaymentRequired, // 402
 					Headers: map[string]string{
-						"Content-Type":                "application/json;charset=UTF-8",
-						"Access-Control-Allow-Origin": "*",
+						"Content-Type": "application/json;charset=UTF-8",
 					},
-					Body: fmt.Sprintf(`{"error":"insufficient_balance","message":"Số dư ví không đủ để hoàn thành giao dịch này","required":%d,"current":%.2f,"shortage":%d}`, totalAmount, currentBalance, shortage),
+					Body: fmt.Sprintf(`{"error":"insufficient_balance","message":"Số dư ví không đủ để hoàn thành giao dịch này","required":%d,"current":%.2f,"shortage":%d}`, required, currentBalance, shortage),
 				}, nil
 			}
 			// Fallback for generic insufficient balance message
 			return events.APIGatewayProxyResponse{
 				StatusCode: http.StatusPaymentRequired, // 402
 				Headers: map[string]string{
-					"Content-Type":                "application/json;charset=UTF-8",
-					"Access-Control-Allow-Origin": "*",
+					"Content-Type": "application/json;charset=UTF-8",
 				},
-				Body: fmt.Sprintf(`{"error":"insufficient_balance","message":"Số dư ví không đủ để hoàn thành giao dịch này","required":%d,"current":%.2f}`, totalAmount, balance),
+				Body: fmt.Sprintf(`{"error":"insufficient_balance","message":"Số dư ví không đủ để hoàn thành giao dịch này","required":%d,"current":%.2f}`, required, balance),
 			}, nil
 		}
 
@@ -586,8 +773,7 @@ func (h *TicketHandler) HandleWalletPayTicket(ctx context.Context, request event
 	return events.APIGatewayProxyResponse{
 		StatusCode: http.StatusOK,
 		Headers: map[string]string{
-			"Content-Type":                "application/json;charset=UTF-8",
-			"Access-Control-Allow-Origin": "*",
+			"Content-Type": "application/json;charset=UTF-8",
 		},
 		Body: fmt.Sprintf(`{"status":"success","ticketIds":"%s","message":"Thanh toán thành công! Vé của bạn đã được đặt."}`, ticketIds),
 	}, nil
@@ -0,0 +1,110 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/fpt-event-services/common/authctx"
+	"github.com/fpt-event-services/common/permission"
+	"github.com/fpt-event-services/services/ticket-lambda/models"
+	"github.com/fpt-event-services/services/ticket-lambda/repository"
+)
+
+// ============================================================
+// HandleInstallmentPlan - GET|PUT|DELETE /api/events/{id}/installment-plan
+// GET (công khai): cấu hình trả góp của sự kiện (404 nếu không cho trả góp)
+// PUT / DELETE: chủ sự kiện, co-organizer có quyền EDIT_DETAILS (hoặc quyền event.manage_any)
+// Body PUT: {"depositPercent": 30, "installmentCount": 2, "intervalDays": 14}
+// ============================================================
+func (h *TicketHandler) HandleInstallmentPlan(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	eventID, err := strconv.Atoi(request.PathParameters["id"])
+	if err != nil || eventID <= 0 {
+		return createMessageResponse(http.StatusBadRequest, "Invalid event ID")
+	}
+	if request.HTTPMethod == http.MethodGet {
+		plan, err := h.useCase.GetInstallmentPlan(ctx, eventID)
+		if err != nil {
+			return passErrorResponse(err, "Failed to get installment plan")
+		}
+		return createJSONResponse(http.StatusOK, plan)
+	}
+
+	userID, err := permission.Require(ctx, permission.EventRequestCreate)
+	if errors.Is(err, authctx.ErrUnauthenticated) {
+		return createMessageResponse(http.StatusUnauthorized, "User ID not found")
+	}
+	if err != nil {
+		return createMessageResponse(http.StatusForbidden, "Only organizers can manage installment plans")
+	}
+
+	if request.HTTPMethod == http.MethodDelete {
+		if err := h.useCase.DeleteInstallmentPlan(ctx, userID, authctx.Role(ctx), eventID); err != nil {
+			return passErrorResponse(err, "Failed to delete installment plan")
+		}
+		return createMessageResponse(http.StatusOK, "Đã tắt trả góp cho sự kiện")
+	}
+
+	var req models.InstallmentPlanRequest
+	if err := json.Unmarshal([]byte(request.Body), &req); err != nil {
+		return createMessageResponse(http.StatusBadRequest, "Invalid request body")
+	}
+	plan, err := h.useCase.SaveInstallmentPlan(ctx, userID, authctx.Role(ctx), eventID, req)
+	if err != nil {
+		return passErrorResponse(err, "Failed to save installment plan")
+	}
+	return createJSONResponse(http.StatusOK, plan)
+}
+
+// HandleGetBillInstallments - GET /api/bills/{id}/installments: lịch trả góp của hóa đơn của mình
+func (h *TicketHandler) HandleGetBillInstallments(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	userID, ok := authctx.UserID(ctx)
+	if !ok {
+		return createMessageResponse(http.StatusUnauthorized, "User ID not found")
+	}
+	billID, err := strconv.Atoi(request.PathParameters["id"])
+	if err != nil || billID <= 0 {
+		return createMessageResponse(http.StatusBadRequest, "Invalid bill ID")
+	}
+	schedule, err := h.useCase.GetBillInstallments(ctx, userID, billID)
+	if err != nil {
+		return passErrorResponse(err, "Failed to get installments")
+	}
+	return createJSONResponse(http.StatusOK, schedule)
+}
+
+// ============================================================
+// HandlePayInstallment - POST /api/bills/{id}/installments/{seq}/pay
+// Body: {"paymentMethod": "wallet|vnpay"}; các kỳ phải trả theo thứ tự
+// wallet: trừ ví, trả lịch mới (402 nếu ví không đủ); vnpay: trả paymentUrl
+// ============================================================
+func (h *TicketHandler) HandlePayInstallment(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	userID, ok := authctx.UserID(ctx)
+	if !ok {
+		return createMessageResponse(http.StatusUnauthorized, "User ID not found")
+	}
+	billID, err := strconv.Atoi(request.PathParameters["id"])
+	if err != nil || billID <= 0 {
+		return createMessageResponse(http.StatusBadRequest, "Invalid bill ID")
+	}
+	seq, err := strconv.Atoi(request.PathParameters["seq"])
+	if err != nil || seq <= 0 {
+		return createMessageResponse(http.StatusBadRequest, "Invalid installment")
+	}
+
+	var req models.InstallmentPaymentRequest
+	if err := json.Unmarshal([]byte(request.Body), &req); err != nil {
+		return createMessageResponse(http.StatusBadRequest, "Invalid request body")
+	}
+	result, err := h.useCase.PayInstallment(ctx, userID, billID, seq, req.PaymentMethod)
+	if errors.Is(err, repository.ErrInstallmentInsufficientBalance) {
+		return createMessageResponse(http.StatusPaymentRequired, "Số dư ví không đủ để thanh toán kỳ trả góp")
+	}
+	if err != nil {
+		return passErrorResponse(err, "Failed to pay installment")
+	}
+	return createJSONResponse(http.StatusOK, result)
+}
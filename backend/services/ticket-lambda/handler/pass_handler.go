@@ -0,0 +1,205 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/fpt-event-services/common/authctx"
+	apperrors "github.com/fpt-event-services/common/errors"
+	"github.com/fpt-event-services/common/permission"
+	"github.com/fpt-event-services/services/ticket-lambda/models"
+	"github.com/fpt-event-services/services/ticket-lambda/repository"
+)
+
+// ============================================================
+// HandlePasses - GET|POST /api/passes
+// GET (công khai): pass đang bán, ?organizerId= để lọc theo CLB
+// POST (quyền pass.manage): tạo pass
+// Body: {"name", "price", "scope": "ORGANIZER|EVENTS", "validFrom", "validTo",
+// "maxHolders", "events": [{"eventId": 1, "categoryTicketId": 3}]}
+// ============================================================
+func (h *TicketHandler) HandlePasses(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	if request.HTTPMethod == http.MethodGet {
+		var organizerID *int
+		if v := request.QueryStringParameters["organizerId"]; v != "" {
+			id, err := strconv.Atoi(v)
+			if err != nil || id <= 0 {
+				return createMessageResponse(http.StatusBadRequest, "Invalid organizerId")
+			}
+			organizerID = &id
+		}
+		passes, err := h.useCase.ListPasses(ctx, organizerID)
+		if err != nil {
+			return passErrorResponse(err, "Failed to list passes")
+		}
+		return createJSONResponse(http.StatusOK, passes)
+	}
+
+	userID, err := permission.Require(ctx, permission.PassManage)
+	if errors.Is(err, authctx.ErrUnauthenticated) {
+		return createMessageResponse(http.StatusUnauthorized, "User ID not found")
+	}
+	if err != nil {
+		return createMessageResponse(http.StatusForbidden, "Only organizers can create passes")
+	}
+
+	var req models.CreateEventPassRequest
+	if err := json.Unmarshal([]byte(request.Body), &req); err != nil {
+		return createMessageResponse(http.StatusBadRequest, "Invalid request body")
+	}
+	pass, err := h.useCase.CreatePass(ctx, userID, authctx.Role(ctx), req)
+	if err != nil {
+		return passErrorResponse(err, "Failed to create pass")
+	}
+	return createJSONResponse(http.StatusCreated, pass)
+}
+
+// HandleGetPass - GET /api/passes/{id} (công khai): pass kèm các sự kiện thuộc pass
+func (h *TicketHandler) HandleGetPass(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	passID, err := strconv.Atoi(request.PathParameters["id"])
+	if err != nil || passID <= 0 {
+		return createMessageResponse(http.StatusBadRequest, "Invalid pass ID")
+	}
+	pass, err := h.useCase.GetPass(ctx, passID)
+	if err != nil {
+		return passErrorResponse(err, "Failed to get pass")
+	}
+	return createJSONResponse(http.StatusOK, pass)
+}
+
+// HandleUpdatePassStatus - PUT /api/passes/{id}/status
+// Body: {"status": "INACTIVE"}; chủ pass hoặc ADMIN
+func (h *TicketHandler) HandleUpdatePassStatus(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	userID, err := permission.Require(ctx, permission.PassManage)
+	if errors.Is(err, authctx.ErrUnauthenticated) {
+		return createMessageResponse(http.StatusUnauthorized, "User ID not found")
+	}
+	if err != nil {
+		return createMessageResponse(http.StatusForbidden, "Only organizers can manage passes")
+	}
+	passID, err := strconv.Atoi(request.PathParameters["id"])
+	if err != nil || passID <= 0 {
+		return createMessageResponse(http.StatusBadRequest, "Invalid pass ID")
+	}
+
+	var req models.UpdateEventPassStatusRequest
+	if err := json.Unmarshal([]byte(request.Body), &req); err != nil {
+		return createMessageResponse(http.StatusBadRequest, "Invalid request body")
+	}
+	pass, err := h.useCase.UpdatePassStatus(ctx, userID, authctx.Role(ctx), passID, req.Status)
+	if err != nil {
+		return passErrorResponse(err, "Failed to update pass")
+	}
+	return createJSONResponse(http.StatusOK, pass)
+}
+
+// HandleRevokePassHolder - POST /api/passes/{id}/holders/{holderId}/revoke (chủ pass hoặc ADMIN)
+func (h *TicketHandler) HandleRevokePassHolder(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	userID, err := permission.Require(ctx, permission.PassManage)
+	if errors.Is(err, authctx.ErrUnauthenticated) {
+		return createMessageResponse(http.StatusUnauthorized, "User ID not found")
+	}
+	if err != nil {
+		return createMessageResponse(http.StatusForbidden, "Only organizers can manage passes")
+	}
+	passID, err := strconv.Atoi(request.PathParameters["id"])
+	if err != nil || passID <= 0 {
+		return createMessageResponse(http.StatusBadRequest, "Invalid pass ID")
+	}
+	holderID, err := strconv.Atoi(request.PathParameters["holderId"])
+	if err != nil || holderID <= 0 {
+		return createMessageResponse(http.StatusBadRequest, "Invalid holder ID")
+	}
+
+	if err := h.useCase.RevokePassHolder(ctx, userID, authctx.Role(ctx), passID, holderID); err != nil {
+		return passErrorResponse(err, "Failed to revoke pass")
+	}
+	return createMessageResponse(http.StatusOK, "Đã thu hồi pass")
+}
+
+// ============================================================
+// HandlePurchasePass - POST /api/passes/{id}/purchase
+// Body: {"paymentMethod": "wallet|vnpay"}
+// wallet: trừ ví, trả holderId + vé đã cấp cho các sự kiện đang mở bán (402 nếu ví không đủ)
+// vnpay: trả paymentUrl; return URL dùng chung /api/buyTicket
+// ============================================================
+func (h *TicketHandler) HandlePurchasePass(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	userID, ok := authctx.UserID(ctx)
+	if !ok {
+		return createMessageResponse(http.StatusUnauthorized, "User ID not found")
+	}
+	passID, err := strconv.Atoi(request.PathParameters["id"])
+	if err != nil || passID <= 0 {
+		return createMessageResponse(http.StatusBadRequest, "Invalid pass ID")
+	}
+
+	var req models.PassPurchaseRequest
+	if err := json.Unmarshal([]byte(request.Body), &req); err != nil {
+		return createMessageResponse(http.StatusBadRequest, "Invalid request body")
+	}
+	result, err := h.useCase.PurchasePass(ctx, userID, passID, req.PaymentMethod)
+	if err != nil {
+		return passErrorResponse(err, "Failed to purchase pass")
+	}
+	return createJSONResponse(http.StatusOK, result)
+}
+
+// HandleClaimPassTicket - POST /api/passes/{id}/claim
+// Body: {"eventId": 12, "categoryTicketId": 3}; người giữ pass nhận vé cho một sự kiện
+func (h *TicketHandler) HandleClaimPassTicket(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	userID, ok := authctx.UserID(ctx)
+	if !ok {
+		return createMessageResponse(http.StatusUnauthorized, "User ID not found")
+	}
+	passID, err := strconv.Atoi(request.PathParameters["id"])
+	if err != nil || passID <= 0 {
+		return createMessageResponse(http.StatusBadRequest, "Invalid pass ID")
+	}
+
+	var req models.PassClaimRequest
+	if err := json.Unmarshal([]byte(request.Body), &req); err != nil {
+		return createMessageResponse(http.StatusBadRequest, "Invalid request body")
+	}
+	// Sự kiện bật hàng chờ mở bán: người giữ pass cũng phải tới lượt mới được nhận ghế
+	if resp, blocked, err := h.bookingQueueGate(ctx, userID, req.EventID); blocked {
+		return resp, err
+	}
+	ticket, err := h.useCase.ClaimPassTicket(ctx, userID, passID, req)
+	if err != nil {
+		return passErrorResponse(err, "Failed to claim ticket")
+	}
+	return createJSONResponse(http.StatusCreated, ticket)
+}
+
+// HandleGetMyPasses - GET /api/passes/my: pass đã mua, vé đã cấp, sự kiện còn nhận vé được
+func (h *TicketHandler) HandleGetMyPasses(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	userID, ok := authctx.UserID(ctx)
+	if !ok {
+		return createMessageResponse(http.StatusUnauthorized, "User ID not found")
+	}
+	holdings, err := h.useCase.GetMyPasses(ctx, userID)
+	if err != nil {
+		return passErrorResponse(err, "Failed to get passes")
+	}
+	return createJSONResponse(http.StatusOK, holdings)
+}
+
+// passErrorResponse - Vi phạm quy tắc → 409, lỗi AppError khác giữ status, ví không đủ → 402
+func passErrorResponse(err error, fallback string) (events.APIGatewayProxyResponse, error) {
+	if errors.Is(err, repository.ErrPassInsufficientBalance) {
+		return createMessageResponse(http.StatusPaymentRequired, "Số dư ví không đủ để mua pass")
+	}
+	if appErr, ok := apperrors.AsAppError(err); ok {
+		if appErr.Code == apperrors.ErrCodeBusinessRule {
+			return createMessageResponse(http.StatusConflict, appErr.Message)
+		}
+		if appErr.HTTPStatus < http.StatusInternalServerError {
+			return createMessageResponse(appErr.HTTPStatus, appErr.Message)
+		}
+	}
+	return createMessageResponse(http.StatusInternalServerError, fallback)
+}
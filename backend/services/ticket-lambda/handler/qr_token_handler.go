@@ -0,0 +1,33 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/fpt-event-services/common/authctx"
+)
+
+// ============================================================
+// HandleGetTicketQRToken - GET /api/registrations/{ticketId}/qr-token
+// Mã QR xoay vòng 30 giây cho sự kiện bật QR động, chỉ chủ vé (409 nếu sự kiện không bật)
+// ============================================================
+func (h *TicketHandler) HandleGetTicketQRToken(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	userID, ok := authctx.UserID(ctx)
+	if !ok {
+		return createMessageResponse(http.StatusUnauthorized, "User ID not found")
+	}
+	ticketID, err := strconv.Atoi(request.PathParameters["ticketId"])
+	if err != nil || ticketID <= 0 {
+		return createMessageResponse(http.StatusBadRequest, "Invalid ticket ID")
+	}
+
+	token, err := h.useCase.GetMyTicketQRToken(ctx, userID, ticketID)
+	if err != nil {
+		return ticketAccessErrorResponse(err, "Failed to issue QR token")
+	}
+	resp, err := createJSONResponse(http.StatusOK, token)
+	resp.Headers["Cache-Control"] = "private, no-store"
+	return resp, err
+}
@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/fpt-event-services/common/authctx"
+)
+
+// ============================================================
+// HandleBookingQueue - POST|GET /api/events/{id}/queue
+// POST: vào hàng chờ mở bán (gọi lại trả về chỗ cũ); status OPEN khi sự kiện không bật hàng chờ
+// GET ?token=...: vị trí hiện tại, hỏi lại sau pollAfterSeconds; ADMITTED thì đặt vé
+// trước admittedUntil. Token không được hỏi quá BOOKING_QUEUE_IDLE_SECONDS bị bỏ khỏi hàng
+// ============================================================
+func (h *TicketHandler) HandleBookingQueue(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	userID, ok := authctx.UserID(ctx)
+	if !ok {
+		return createMessageResponse(http.StatusUnauthorized, "User ID not found")
+	}
+	eventID, err := strconv.Atoi(request.PathParameters["id"])
+	if err != nil || eventID <= 0 {
+		return createMessageResponse(http.StatusBadRequest, "Invalid event ID")
+	}
+
+	if request.HTTPMethod == http.MethodGet {
+		token := request.QueryStringParameters["token"]
+		if token == "" {
+			return createMessageResponse(http.StatusBadRequest, "Missing token")
+		}
+		status, err := h.useCase.GetBookingQueueStatus(ctx, eventID, token)
+		if err != nil {
+			return ticketAccessErrorResponse(err, "Failed to get queue status")
+		}
+		resp, err := createJSONResponse(http.StatusOK, status)
+		resp.Headers["Cache-Control"] = "private, no-store"
+		return resp, err
+	}
+
+	status, err := h.useCase.JoinBookingQueue(ctx, userID, eventID)
+	if err != nil {
+		return ticketAccessErrorResponse(err, "Failed to join queue")
+	}
+	resp, err := createJSONResponse(http.StatusOK, status)
+	resp.Headers["Cache-Control"] = "private, no-store"
+	return resp, err
+}
+
+// bookingQueueGate - Chặn request đặt vé của user chưa tới lượt khi sự kiện bật hàng chờ
+// blocked = true: trả response 429 kèm vị trí hàng chờ (user được tự động xếp hàng)
+func (h *TicketHandler) bookingQueueGate(ctx context.Context, userID, eventID int) (resp events.APIGatewayProxyResponse, blocked bool, err error) {
+	status, err := h.useCase.AdmitBooking(ctx, userID, eventID)
+	if err != nil {
+		resp, err = ticketAccessErrorResponse(err, "Failed to check booking queue")
+		return resp, true, err
+	}
+	if status == nil {
+		return resp, false, nil
+	}
+	resp, err = createJSONResponse(http.StatusTooManyRequests, status)
+	resp.Headers["Retry-After"] = strconv.Itoa(status.PollAfterSeconds)
+	return resp, true, err
+}
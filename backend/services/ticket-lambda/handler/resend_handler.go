@@ -0,0 +1,179 @@
+package handler
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/fpt-event-services/common/authctx"
+	apperrors "github.com/fpt-event-services/common/errors"
+	"github.com/fpt-event-services/services/ticket-lambda/usecase"
+)
+
+// ============================================================
+// HandleResendTicketEmail - POST /api/registrations/{ticketId}/resend-email
+// Sinh viên mất email vé: tạo lại QR + PDF và gửi lại tới email tài khoản
+// Giới hạn TICKET_RESEND_LIMIT lần / vé trong TICKET_RESEND_WINDOW_MINUTES phút
+// ============================================================
+func (h *TicketHandler) HandleResendTicketEmail(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	userID, ok := authctx.UserID(ctx)
+	if !ok {
+		return createMessageResponse(http.StatusUnauthorized, "User ID not found")
+	}
+	ticketID, err := strconv.Atoi(request.PathParameters["ticketId"])
+	if err != nil || ticketID <= 0 {
+		return createMessageResponse(http.StatusBadRequest, "Invalid ticket ID")
+	}
+
+	if allowed, retryAfter := resendLimiter.Allow(userID, ticketID); !allowed {
+		resp, err := createMessageResponse(http.StatusTooManyRequests,
+			fmt.Sprintf("Bạn đã yêu cầu gửi lại vé này quá nhiều lần, vui lòng thử lại sau %d phút", int(retryAfter.Minutes())+1))
+		resp.Headers["Retry-After"] = strconv.Itoa(int(retryAfter.Seconds()) + 1)
+		return resp, err
+	}
+
+	if err := h.useCase.ResendMyTicket(ctx, userID, ticketID); err != nil {
+		return ticketAccessErrorResponse(err, "Failed to resend ticket")
+	}
+	return createMessageResponse(http.StatusOK, "Đã gửi lại vé tới email của bạn")
+}
+
+// ============================================================
+// HandleResendReceipt - GET /api/bills/{id}/resend-receipt
+// Gửi lại biên lai thanh toán (phương thức, mã giao dịch, từng vé, VAT) tới email tài khoản
+// Dùng chung hạn mức với gửi lại vé, tính riêng theo hóa đơn
+// ============================================================
+func (h *TicketHandler) HandleResendReceipt(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	userID, ok := authctx.UserID(ctx)
+	if !ok {
+		return createMessageResponse(http.StatusUnauthorized, "User ID not found")
+	}
+	billID, err := strconv.Atoi(request.PathParameters["id"])
+	if err != nil || billID <= 0 {
+		return createMessageResponse(http.StatusBadRequest, "Invalid bill ID")
+	}
+
+	if allowed, retryAfter := resendLimiter.allow(fmt.Sprintf("bill:%d:%d", userID, billID)); !allowed {
+		resp, err := createMessageResponse(http.StatusTooManyRequests,
+			fmt.Sprintf("Bạn đã yêu cầu gửi lại biên lai này quá nhiều lần, vui lòng thử lại sau %d phút", int(retryAfter.Minutes())+1))
+		resp.Headers["Retry-After"] = strconv.Itoa(int(retryAfter.Seconds()) + 1)
+		return resp, err
+	}
+
+	if err := h.useCase.ResendMyReceipt(ctx, userID, billID); err != nil {
+		return ticketAccessErrorResponse(err, "Failed to resend receipt")
+	}
+	return createMessageResponse(http.StatusOK, "Đã gửi lại biên lai tới email của bạn")
+}
+
+// ============================================================
+// HandleGetTicketQR - GET /api/registrations/{ticketId}/qr?size=300
+// Ảnh QR (image/png) của vé để hiển thị trong app, chỉ chủ vé
+// ============================================================
+func (h *TicketHandler) HandleGetTicketQR(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	userID, ok := authctx.UserID(ctx)
+	if !ok {
+		return createMessageResponse(http.StatusUnauthorized, "User ID not found")
+	}
+	ticketID, err := strconv.Atoi(request.PathParameters["ticketId"])
+	if err != nil || ticketID <= 0 {
+		return createMessageResponse(http.StatusBadRequest, "Invalid ticket ID")
+	}
+	size := usecase.DefaultTicketQRSize
+	if raw := request.QueryStringParameters["size"]; raw != "" {
+		if size, err = strconv.Atoi(raw); err != nil {
+			return createMessageResponse(http.StatusBadRequest, "Invalid size")
+		}
+	}
+
+	png, err := h.useCase.GetMyTicketQR(ctx, userID, ticketID, size)
+	if err != nil {
+		return ticketAccessErrorResponse(err, "Failed to generate QR")
+	}
+	return events.APIGatewayProxyResponse{
+		StatusCode: http.StatusOK,
+		Headers: map[string]string{
+			"Content-Type":  "image/png",
+			"Cache-Control": "private, no-store",
+		},
+		Body:            base64.StdEncoding.EncodeToString(png),
+		IsBase64Encoded: true,
+	}, nil
+}
+
+// ticketAccessErrorResponse map lỗi nghiệp vụ khi chủ vé thao tác trên vé của mình
+func ticketAccessErrorResponse(err error, fallback string) (events.APIGatewayProxyResponse, error) {
+	if appErr, ok := apperrors.AsAppError(err); ok {
+		switch appErr.Code {
+		case apperrors.ErrCodeNotFound:
+			return createMessageResponse(http.StatusNotFound, appErr.Message)
+		case apperrors.ErrCodeBusinessRule:
+			return createMessageResponse(http.StatusConflict, appErr.Message)
+		case apperrors.ErrCodeValidation:
+			return createMessageResponse(http.StatusBadRequest, appErr.Message)
+		}
+	}
+	fmt.Printf("[ERROR] %s: %v\n", fallback, err)
+	return createMessageResponse(http.StatusInternalServerError, fallback)
+}
+
+// ============================================================
+// Giới hạn gửi lại vé theo (user, ticket_id) - cửa sổ trượt, in-memory
+// Tính theo user để người khác đoán ticket_id không làm hết lượt của chủ vé
+// ============================================================
+
+var resendLimiter = newResendRateLimiter()
+
+type resendRateLimiter struct {
+	mu     sync.Mutex
+	window time.Duration
+	limit  int
+	hits   map[string][]time.Time
+}
+
+func newResendRateLimiter() *resendRateLimiter {
+	return &resendRateLimiter{
+		window: time.Duration(envInt("TICKET_RESEND_WINDOW_MINUTES", 60)) * time.Minute,
+		limit:  envInt("TICKET_RESEND_LIMIT", 3),
+		hits:   make(map[string][]time.Time),
+	}
+}
+
+// Allow ghi nhận một lần gửi; false kèm thời gian phải chờ nếu vượt hạn mức
+func (l *resendRateLimiter) Allow(userID, ticketID int) (bool, time.Duration) {
+	return l.allow(fmt.Sprintf("%d:%d", userID, ticketID))
+}
+
+// allow - Allow theo key bất kỳ (guestLookupLimiter dùng IP)
+func (l *resendRateLimiter) allow(key string) (bool, time.Duration) {
+	now := time.Now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	recent := l.hits[key][:0]
+	for _, t := range l.hits[key] {
+		if now.Sub(t) < l.window {
+			recent = append(recent, t)
+		}
+	}
+	if len(recent) >= l.limit {
+		l.hits[key] = recent
+		return false, l.window - now.Sub(recent[0])
+	}
+	l.hits[key] = append(recent, now)
+	return true, 0
+}
+
+func envInt(key string, defaultValue int) int {
+	if n, err := strconv.Atoi(os.Getenv(key)); err == nil && n > 0 {
+		return n
+	}
+	return defaultValue
+}
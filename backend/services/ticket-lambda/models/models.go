@@ -2,6 +2,10 @@ package models
 
 import (
 	"time"
+
+	"github.com/fpt-event-services/common/pagination"
+	"github.com/fpt-event-services/common/statuslabel"
+	"github.com/fpt-event-services/common/tickethistory"
 )
 
 // ============================================================
@@ -9,23 +13,54 @@ import (
 // Dùng cho: GET /api/registrations/my-tickets
 // ============================================================
 type MyTicketResponse struct {
-	TicketID      int        `json:"ticketId"`
-	TicketCode    *string    `json:"ticketCode"` // qr_code_value
-	EventName     *string    `json:"eventName"`
-	VenueName     *string    `json:"venueName"`
-	StartTime     *time.Time `json:"startTime"`
-	Status        string     `json:"status"`
-	CheckInTime   *time.Time `json:"checkInTime"`
-	CheckOutTime  *time.Time `json:"checkOutTime"`
-	Category      *string    `json:"category"`
-	CategoryPrice *float64   `json:"categoryPrice"`
-	SeatCode      *string    `json:"seatCode"`
-	BuyerName     *string    `json:"buyerName"`
-	PurchaseDate  *time.Time `json:"purchaseDate"`
+	TicketID      int                `json:"ticketId"`
+	TicketCode    *string            `json:"ticketCode"` // qr_code_value
+	EventName     *string            `json:"eventName"`
+	VenueName     *string            `json:"venueName"`
+	StartTime     *time.Time         `json:"startTime"`
+	Status        string             `json:"status"`
+	StatusLabel   *statuslabel.Label `json:"statusLabel"` // Nhãn hiển thị của status (điền khi serialize)
+	CheckInTime   *time.Time         `json:"checkInTime"`
+	CheckOutTime  *time.Time         `json:"checkOutTime"`
+	Category      *string            `json:"category"`
+	CategoryPrice *float64           `json:"categoryPrice"`
+	SeatCode      *string            `json:"seatCode"`
+	BuyerName     *string            `json:"buyerName"`
+	PurchaseDate  *time.Time         `json:"purchaseDate"`
+	// Chỉ có ở danh sách vé có bộ lọc (STAFF/ADMIN/ORGANIZER)
+	BuyerEmail *string `json:"buyerEmail,omitempty"`
 }
 
+// ============================================================
+// TicketListFilter - Bộ lọc GET /api/tickets/list (phân trang và xuất CSV)
+// ============================================================
+type TicketListFilter struct {
+	EventID          *int
+	Status           string // Một trong TicketStatuses, "" = mọi trạng thái
+	CategoryTicketID *int
+	CheckedIn        *bool  // true: đã check-in (checkin_time có giá trị), false: chưa
+	Search           string // Tên hoặc email người mua
+	Sort             string // Một khóa của TicketListSorts, "" = ticketId
+	Desc             bool
+	Page             int
+	Limit            int
+}
+
+// TicketStatuses - Trạng thái hợp lệ của Ticket.status
+var TicketStatuses = []string{"PENDING", "BOOKED", "CHECKED_IN", "CHECKED_OUT", "EXPIRED", "REFUNDED"}
+
+// TicketListSorts - Khóa sắp xếp của danh sách vé (query ?sort=)
+var TicketListSorts = []string{"ticketId", "purchaseDate", "checkInTime", "buyerName", "status", "eventStart"}
+
+// Giới hạn danh sách vé
+const (
+	TicketListMaxLimit  = 200
+	TicketExportMaxRows = 20000
+)
+
 // ============================================================
 // CategoryTicket - Loại vé
+// Price là giá đang áp dụng (bậc giá theo thời gian nếu có, ngược lại giá gốc)
 // ============================================================
 type CategoryTicket struct {
 	CategoryTicketID int     `json:"categoryTicketId"`
@@ -35,6 +70,19 @@ type CategoryTicket struct {
 	Price            float64 `json:"price"`
 	MaxQuantity      int     `json:"maxQuantity"`
 	Status           string  `json:"status"`
+	BasePrice        float64 `json:"basePrice"`
+	// Tên bậc giá đang áp dụng và thời điểm bậc đó hết hiệu lực (nil = giá gốc / không giới hạn)
+	PriceTier            *string       `json:"priceTier"`
+	PriceValidUntil      *time.Time    `json:"priceValidUntil"`
+	UpcomingPriceChanges []PriceChange `json:"upcomingPriceChanges"`
+}
+
+// PriceChange - Bậc giá sắp áp dụng của loại vé
+type PriceChange struct {
+	Name      string     `json:"name"`
+	Price     float64    `json:"price"`
+	ValidFrom time.Time  `json:"validFrom"`
+	ValidTo   *time.Time `json:"validTo"`
 }
 
 // ============================================================
@@ -86,6 +134,34 @@ type BuyTicketRequest struct {
 	Quantity         int   `json:"quantity"`
 }
 
+// ============================================================
+// GroupedTicketsResponse - Vé của user nhóm theo sự kiện
+// Dùng cho: GET /api/registrations/my-tickets/grouped
+// Upcoming: sự kiện chưa kết thúc (sớm nhất trước); Past: đã kết thúc (gần nhất trước)
+// ============================================================
+type GroupedTicketsResponse struct {
+	Upcoming []TicketEventGroup `json:"upcoming"`
+	Past     []TicketEventGroup `json:"past"`
+}
+
+// TicketEventGroup - Một sự kiện và các vé của user
+type TicketEventGroup struct {
+	EventID   int                  `json:"eventId"`
+	EventName *string              `json:"eventName"`
+	VenueName *string              `json:"venueName"`
+	StartTime *time.Time           `json:"startTime"`
+	EndTime   *time.Time           `json:"endTime"`
+	Tickets   []TicketWithTimeline `json:"tickets"`
+}
+
+// TicketWithTimeline - Vé kèm dòng thời gian trạng thái (mua → thanh toán → check-in → check-out / hoàn tiền)
+type TicketWithTimeline struct {
+	MyTicketResponse
+	Timeline []tickethistory.Step `json:"timeline"`
+	EventID  int                  `json:"-"`
+	EndTime  *time.Time           `json:"-"`
+}
+
 // ============================================================
 // MyBillResponse - Response danh sách hóa đơn
 // ============================================================
@@ -119,21 +195,346 @@ type TicketEmailData struct {
 }
 
 // ============================================================
-// PaginatedTicketsResponse - Paginated tickets với metadata
+// PaginatedTicketsResponse / PaginatedBillsResponse - Một trang vé / hóa đơn
+// (envelope chung pagination.Page: items, page, pageSize, totalItems, totalPages, nextCursor)
+// ============================================================
+type PaginatedTicketsResponse = pagination.Page[MyTicketResponse]
+
+type PaginatedBillsResponse = pagination.Page[MyBillResponse]
+
+// ============================================================
+// PaymentInitResult - Kết quả tạo URL thanh toán VNPay
+// HoldExpiresAt: thời điểm ghế PENDING bị nhả nếu chưa thanh toán
+// DepositAmount: số tiền VNPay thu khi mua trả góp (tiền cọc)
+// ============================================================
+type PaymentInitResult struct {
+	PaymentURL    string    `json:"paymentUrl"`
+	TicketIDs     []int     `json:"ticketIds"`
+	HoldExpiresAt time.Time `json:"holdExpiresAt"`
+	DepositAmount float64   `json:"depositAmount,omitempty"`
+}
+
+// ============================================================
+// TicketHold - Vé PENDING đang giữ ghế chờ thanh toán
+// Dùng cho: GET /api/registrations/holds
+// ============================================================
+type TicketHold struct {
+	TicketID         int       `json:"ticketId"`
+	EventID          int       `json:"eventId"`
+	EventName        string    `json:"eventName"`
+	CategoryTicketID int       `json:"categoryTicketId"`
+	SeatID           *int      `json:"seatId"`
+	SeatCode         *string   `json:"seatCode"`
+	CreatedAt        time.Time `json:"createdAt"`
+	HoldExpiresAt    time.Time `json:"holdExpiresAt"`
+	RemainingSeconds int       `json:"remainingSeconds"`
+	Extended         bool      `json:"extended"`
+	CanExtend        bool      `json:"canExtend"`
+}
+
+// ============================================================
+// ExtendHoldRequest - Request gia hạn giữ ghế
+// TicketIDs rỗng = gia hạn tất cả vé PENDING còn hạn của user
+// ============================================================
+type ExtendHoldRequest struct {
+	TicketIDs []int `json:"ticketIds"`
+}
+
+// ============================================================
+// UpcomingTicket - Vé còn hiệu lực của sự kiện sắp/đang diễn ra
+// Dùng cho: GET /api/me/dashboard (không kèm QR để payload nhẹ)
 // ============================================================
-type PaginatedTicketsResponse struct {
-	Tickets      []MyTicketResponse `json:"tickets"`
-	TotalPages   int                `json:"totalPages"`
-	CurrentPage  int                `json:"currentPage"`
-	TotalRecords int                `json:"totalRecords"`
+type UpcomingTicket struct {
+	TicketID    int                `json:"ticketId"`
+	EventID     int                `json:"eventId"`
+	EventName   string             `json:"eventName"`
+	VenueName   *string            `json:"venueName"`
+	AreaName    *string            `json:"areaName"`
+	StartTime   time.Time          `json:"startTime"`
+	EndTime     time.Time          `json:"endTime"`
+	Status      string             `json:"status"`
+	StatusLabel *statuslabel.Label `json:"statusLabel"`
+	Category    *string            `json:"category"`
+	SeatCode    *string            `json:"seatCode"`
+}
+
+// ============================================================
+// TicketQuote - Báo giá trước khi thanh toán (không giữ ghế)
+// Dùng cho: POST /api/tickets/quote
+// Giá là giá đang áp dụng của loại vé (bậc giá theo thời gian) ± giá riêng của ghế, giống hệt lúc tạo bill (CalculateSeatsTotal)
+// ============================================================
+type TicketQuoteRequest struct {
+	EventID int   `json:"eventId"`
+	SeatIDs []int `json:"seatIds"`
+}
+
+type TicketQuote struct {
+	EventID  int               `json:"eventId"`
+	Currency string            `json:"currency"`
+	Seats    []TicketQuoteSeat `json:"seats"`
+	Subtotal float64           `json:"subtotal"`
+	Fees     []TicketQuoteFee  `json:"fees"`
+	FeeTotal float64           `json:"feeTotal"`
+	Discount float64           `json:"discount"`
+	Total    float64           `json:"total"`
+	// Bookable = sự kiện đang mở bán và mọi ghế đều còn trống
+	Bookable bool   `json:"bookable"`
+	Reason   string `json:"reason,omitempty"`
+}
+
+type TicketQuoteSeat struct {
+	SeatID            int     `json:"seatId"`
+	SeatCode          string  `json:"seatCode"`
+	CategoryTicketID  int     `json:"categoryTicketId"`
+	CategoryName      string  `json:"categoryName"`
+	Price             float64 `json:"price"`
+	PriceTier         *string `json:"priceTier,omitempty"`
+	SeatAdjustPercent int     `json:"seatAdjustPercent,omitempty"` // Giá riêng của ghế so với giá loại vé (+20 = đắt hơn 20%)
+	Available         bool    `json:"available"`
+	UnavailableReason string  `json:"unavailableReason,omitempty"`
+}
+
+type TicketQuoteFee struct {
+	Name   string  `json:"name"`
+	Amount float64 `json:"amount"`
+}
+
+// ============================================================
+// CompTicket - Vé mời 0 đồng do ban tổ chức phát
+// Dùng cho: POST /api/organizer/events/{id}/comp-tickets
+// ============================================================
+type CompTicketRequest struct {
+	CategoryTicketID int      `json:"categoryTicketId"`
+	Emails           []string `json:"emails"`
+}
+
+// CompTicketResult - Kết quả phát vé cho từng email
+type CompTicketResult struct {
+	Email    string `json:"email"`
+	Status   string `json:"status"` // ISSUED, FAILED
+	TicketID int    `json:"ticketId,omitempty"`
+	SeatCode string `json:"seatCode,omitempty"`
+	Reason   string `json:"reason,omitempty"`
+}
+
+type CompTicketResponse struct {
+	EventID   int                `json:"eventId"`
+	Quota     int                `json:"quota"`
+	Used      int                `json:"used"`
+	Remaining int                `json:"remaining"`
+	Issued    int                `json:"issued"`
+	Results   []CompTicketResult `json:"results"`
 }
 
 // ============================================================
-// PaginatedBillsResponse - Paginated bills với metadata
+// GuestCheckout - Khách không có tài khoản mua vé bằng email
+// Dùng cho: POST /api/guest/checkout, GET /api/guest/tickets?code=...
+// Mã tra cứu chỉ gửi qua email sau khi thanh toán, không có trong response
 // ============================================================
-type PaginatedBillsResponse struct {
-	Bills        []MyBillResponse `json:"bills"`
-	TotalPages   int              `json:"totalPages"`
-	CurrentPage  int              `json:"currentPage"`
-	TotalRecords int              `json:"totalRecords"`
+type GuestCheckoutRequest struct {
+	EventID          int    `json:"eventId"`
+	CategoryTicketID int    `json:"categoryTicketId"`
+	SeatIDs          []int  `json:"seatIds"`
+	Email            string `json:"email"`
+	FullName         string `json:"fullName"`
+	Phone            string `json:"phone"`
+}
+
+// GuestTicketsResponse - Vé của khách (ticketCode là QR Base64 như /api/registrations/my-tickets)
+type GuestTicketsResponse struct {
+	Tickets []MyTicketResponse `json:"tickets"`
+}
+
+// ============================================================
+// EventPass - Vé học kỳ / season pass do organizer (CLB) bán
+// Scope ORGANIZER: mọi sự kiện của organizer; EVENTS: chỉ các sự kiện được chọn
+// Chỉ sự kiện bắt đầu trong [validFrom, validTo] được tính
+// Dùng cho: /api/passes, /api/passes/{id}, /api/passes/my
+// ============================================================
+type EventPass struct {
+	PassID        int              `json:"passId"`
+	OrganizerID   int              `json:"organizerId"`
+	OrganizerName string           `json:"organizerName,omitempty"`
+	Name          string           `json:"name"`
+	Description   *string          `json:"description,omitempty"`
+	Price         float64          `json:"price"`
+	Scope         string           `json:"scope"` // ORGANIZER, EVENTS
+	ValidFrom     time.Time        `json:"validFrom"`
+	ValidTo       time.Time        `json:"validTo"`
+	MaxHolders    *int             `json:"maxHolders,omitempty"`
+	Holders       int              `json:"holders"`
+	Status        string           `json:"status"` // ACTIVE, INACTIVE
+	Events        []EventPassEvent `json:"events,omitempty"`
+}
+
+// EventPassEvent - Sự kiện nằm trong pass (categoryTicketId = loại vé cấp cho người giữ pass)
+type EventPassEvent struct {
+	EventID          int       `json:"eventId"`
+	Title            string    `json:"title"`
+	StartTime        time.Time `json:"startTime"`
+	Status           string    `json:"status"`
+	CategoryTicketID *int      `json:"categoryTicketId,omitempty"`
+}
+
+// EventPassEventInput - Sự kiện chọn khi tạo pass scope EVENTS
+type EventPassEventInput struct {
+	EventID          int  `json:"eventId"`
+	CategoryTicketID *int `json:"categoryTicketId,omitempty"`
+}
+
+// CreateEventPassRequest - POST /api/passes
+type CreateEventPassRequest struct {
+	Name        string                `json:"name"`
+	Description *string               `json:"description,omitempty"`
+	Price       float64               `json:"price"`
+	Scope       string                `json:"scope"`
+	ValidFrom   time.Time             `json:"validFrom"`
+	ValidTo     time.Time             `json:"validTo"`
+	MaxHolders  *int                  `json:"maxHolders,omitempty"`
+	Events      []EventPassEventInput `json:"events,omitempty"`
+}
+
+// UpdateEventPassStatusRequest - PUT /api/passes/{id}/status (INACTIVE = ngừng bán, người đã mua vẫn dùng được)
+type UpdateEventPassStatusRequest struct {
+	Status string `json:"status"`
+}
+
+// PassPurchaseRequest - POST /api/passes/{id}/purchase
+type PassPurchaseRequest struct {
+	PaymentMethod string `json:"paymentMethod"` // wallet, vnpay
+}
+
+// PassPurchaseResult - Mua bằng ví: holderId + vé đã cấp ngay; VNPay: paymentUrl
+type PassPurchaseResult struct {
+	HolderID   int          `json:"holderId,omitempty"`
+	PaymentURL string       `json:"paymentUrl,omitempty"`
+	Tickets    []PassTicket `json:"tickets"`
+}
+
+// PassClaimRequest - POST /api/passes/{id}/claim
+// categoryTicketId chỉ dùng khi pass không cố định loại vé cho sự kiện
+type PassClaimRequest struct {
+	EventID          int  `json:"eventId"`
+	CategoryTicketID *int `json:"categoryTicketId,omitempty"`
+}
+
+// PassTicket - Vé đã cấp từ pass
+type PassTicket struct {
+	EventID  int    `json:"eventId"`
+	TicketID int    `json:"ticketId"`
+	SeatCode string `json:"seatCode"`
+	Status   string `json:"status"`
+}
+
+// PassHolding - Pass của user hiện tại (GET /api/passes/my)
+// Claimable: sự kiện OPEN chưa bắt đầu, chưa có vé từ pass
+type PassHolding struct {
+	HolderID    int              `json:"holderId"`
+	Status      string           `json:"status"` // ACTIVE, REVOKED
+	PurchasedAt time.Time        `json:"purchasedAt"`
+	Pass        EventPass        `json:"pass"`
+	Tickets     []PassTicket     `json:"tickets"`
+	Claimable   []EventPassEvent `json:"claimable"`
+}
+
+// ============================================================
+// Trả góp (migration 055)
+// GET|PUT|DELETE /api/events/{id}/installment-plan
+// GET /api/bills/{id}/installments, POST /api/bills/{id}/installments/{seq}/pay
+// ============================================================
+
+// InstallmentPlan - Cấu hình trả góp của sự kiện
+type InstallmentPlan struct {
+	EventID          int       `json:"eventId"`
+	DepositPercent   int       `json:"depositPercent"`
+	InstallmentCount int       `json:"installmentCount"`
+	IntervalDays     int       `json:"intervalDays"`
+	UpdatedAt        time.Time `json:"updatedAt"`
+}
+
+// InstallmentPlanRequest - PUT /api/events/{id}/installment-plan
+type InstallmentPlanRequest struct {
+	DepositPercent   int `json:"depositPercent"`
+	InstallmentCount int `json:"installmentCount"`
+	IntervalDays     int `json:"intervalDays"`
+}
+
+// BillInstallment - Một kỳ của lịch trả góp (seq 0 = tiền cọc)
+type BillInstallment struct {
+	Seq           int        `json:"seq"`
+	Amount        float64    `json:"amount"`
+	DueAt         time.Time  `json:"dueAt"`
+	Status        string     `json:"status"` // PENDING, PAID
+	Overdue       bool       `json:"overdue"`
+	PaidAt        *time.Time `json:"paidAt,omitempty"`
+	PaymentMethod *string    `json:"paymentMethod,omitempty"`
+}
+
+// BillInstallments - Lịch trả góp của hóa đơn
+// Suspended: vé của hóa đơn đang bị tạm khóa vì có kỳ quá hạn
+type BillInstallments struct {
+	BillID        int               `json:"billId"`
+	PaymentStatus string            `json:"paymentStatus"` // PARTIAL, PAID
+	TotalAmount   float64           `json:"totalAmount"`
+	PaidAmount    float64           `json:"paidAmount"`
+	Remaining     float64           `json:"remaining"`
+	Suspended     bool              `json:"suspended"`
+	Installments  []BillInstallment `json:"installments"`
+}
+
+// InstallmentPaymentRequest - POST /api/bills/{id}/installments/{seq}/pay
+type InstallmentPaymentRequest struct {
+	PaymentMethod string `json:"paymentMethod"` // wallet, vnpay
+}
+
+// InstallmentPaymentResult - wallet: lịch sau khi trả; vnpay: link thanh toán
+type InstallmentPaymentResult struct {
+	PaymentURL string            `json:"paymentUrl,omitempty"`
+	Schedule   *BillInstallments `json:"schedule,omitempty"`
+}
+
+// ============================================================
+// QRTokenResponse - GET /api/registrations/{ticketId}/qr-token
+// Mã QR xoay vòng của sự kiện bật QR động (common/qrtoken)
+// ============================================================
+type QRTokenResponse struct {
+	TicketID     int       `json:"ticketId"`
+	Token        string    `json:"token"` // Nội dung cần mã hóa thành QR
+	ExpiresAt    time.Time `json:"expiresAt"`
+	ValidSeconds int       `json:"validSeconds"`
+}
+
+// ============================================================
+// BookingQueueStatus - Vị trí trong hàng chờ mở bán (common/waitingroom)
+// Dùng cho: POST|GET /api/events/{id}/queue và body 429 của API đặt vé
+// status: OPEN (sự kiện không bật hàng chờ, đặt vé ngay), WAITING, ADMITTED
+// ============================================================
+type BookingQueueStatus struct {
+	EventID              int        `json:"eventId"`
+	Status               string     `json:"status"`
+	Message              string     `json:"message,omitempty"`
+	QueueToken           string     `json:"queueToken,omitempty"`
+	Position             int        `json:"position,omitempty"` // 1 = người kế tiếp được vào
+	EstimatedWaitSeconds int        `json:"estimatedWaitSeconds,omitempty"`
+	PollAfterSeconds     int        `json:"pollAfterSeconds,omitempty"`
+	AdmittedUntil        *time.Time `json:"admittedUntil,omitempty"` // Hạn đặt vé khi ADMITTED
+}
+
+// ============================================================
+// EventTicketCounts - Số vé của một sự kiện theo trạng thái
+// Khớp message EventTicketCounts (services/proto/ticket.proto)
+// ============================================================
+type EventTicketCounts struct {
+	EventID    int `json:"eventId"`
+	Pending    int `json:"pending"`
+	Booked     int `json:"booked"`
+	CheckedIn  int `json:"checkedIn"`
+	CheckedOut int `json:"checkedOut"`
+	Refunded   int `json:"refunded"`
+	Expired    int `json:"expired"`
+}
+
+// Active - Vé đang giữ ghế (PENDING, BOOKED, CHECKED_IN, CHECKED_OUT)
+func (c EventTicketCounts) Active() int {
+	return c.Pending + c.Booked + c.CheckedIn + c.CheckedOut
 }
@@ -0,0 +1,41 @@
+package models
+
+import (
+	"encoding/json"
+
+	"github.com/fpt-event-services/common/statuslabel"
+	"github.com/fpt-event-services/common/tickethistory"
+)
+
+// ============================================================
+// Nhãn trạng thái vé - điền lúc serialize để mọi đường trả vé
+// (danh sách của tôi, danh sách có lọc, dashboard) đều có statusLabel
+// ============================================================
+
+// MarshalJSON - MyTicketResponse kèm nhãn trạng thái
+func (t MyTicketResponse) MarshalJSON() ([]byte, error) {
+	type plain MyTicketResponse
+	out := plain(t)
+	out.StatusLabel = statuslabel.Lookup(statuslabel.KindTicket, out.Status)
+	return json.Marshal(out)
+}
+
+// MarshalJSON - UpcomingTicket kèm nhãn trạng thái
+func (t UpcomingTicket) MarshalJSON() ([]byte, error) {
+	type plain UpcomingTicket
+	out := plain(t)
+	out.StatusLabel = statuslabel.Lookup(statuslabel.KindTicket, out.Status)
+	return json.Marshal(out)
+}
+
+// MarshalJSON - TicketWithTimeline: MyTicketResponse được nhúng nên MarshalJSON
+// của nó sẽ được promote và làm mất timeline; serialize tường minh cả hai phần
+func (t TicketWithTimeline) MarshalJSON() ([]byte, error) {
+	type plainTicket MyTicketResponse
+	out := struct {
+		plainTicket
+		Timeline []tickethistory.Step `json:"timeline"`
+	}{plainTicket(t.MyTicketResponse), t.Timeline}
+	out.StatusLabel = statuslabel.Lookup(statuslabel.KindTicket, out.Status)
+	return json.Marshal(out)
+}
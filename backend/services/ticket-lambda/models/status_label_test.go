@@ -0,0 +1,30 @@
+package models
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/fpt-event-services/common/tickethistory"
+)
+
+func TestTicketWithTimelineKeepsTimelineAndLabel(t *testing.T) {
+	ticket := TicketWithTimeline{
+		MyTicketResponse: MyTicketResponse{TicketID: 7, Status: tickethistory.StatusCheckedIn},
+		Timeline:         []tickethistory.Step{},
+		EventID:          3,
+	}
+	b, err := json.Marshal(ticket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := string(b)
+	for _, want := range []string{`"ticketId":7`, `"timeline":[]`, `"statusLabel":{"code":"CHECKED_IN"`} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("missing %s in %s", want, out)
+		}
+	}
+	if strings.Contains(out, `"eventId"`) {
+		t.Fatalf("json:\"-\" fields must stay hidden: %s", out)
+	}
+}
@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/fpt-event-services/common/billing"
+	"github.com/fpt-event-services/common/rules"
+)
+
+// insertBillItems - Ghi Bill_Item cho các vé vừa thanh toán, trong transaction tạo Bill
+// Phí nền tảng lấy theo sự kiện / organizer / hệ thống tại thời điểm mua và được lưu lại,
+// đổi mức phí sau đó không ảnh hưởng vé đã bán. Tổng bill chia theo giá từng ghế (ghế có giá riêng)
+func insertBillItems(ctx context.Context, tx *sql.Tx, billID, eventID int, total float64, ticketIDs []int) error {
+	feeBps, _, err := rules.PlatformFee(ctx, tx, eventID)
+	if err != nil {
+		return fmt.Errorf("error resolving platform fee: %w", err)
+	}
+	weights, err := ticketSeatPrices(ctx, tx, ticketIDs)
+	if err != nil {
+		return err
+	}
+	if err := billing.InsertItems(ctx, tx, billID, billing.SplitWeighted(total, eventID, ticketIDs, weights, feeBps)); err != nil {
+		return fmt.Errorf("error creating bill items: %w", err)
+	}
+	return nil
+}
+
+// ticketSeatPrices - Giá hiện tại của ghế từng vé (seatPriceSQL), cùng thứ tự ticketIDs
+func ticketSeatPrices(ctx context.Context, tx *sql.Tx, ticketIDs []int) ([]int64, error) {
+	if len(ticketIDs) == 0 {
+		return nil, nil
+	}
+	placeholders := make([]string, len(ticketIDs))
+	args := make([]interface{}, len(ticketIDs))
+	for i, id := range ticketIDs {
+		placeholders[i] = "?"
+		args[i] = id
+	}
+	rows, err := tx.QueryContext(ctx, `
+		SELECT t.ticket_id, `+seatPriceSQL+`
+		FROM Ticket t
+		JOIN Category_Ticket ct ON ct.category_ticket_id = t.category_ticket_id
+		LEFT JOIN Seat s ON s.seat_id = t.seat_id
+		WHERE t.ticket_id IN (`+strings.Join(placeholders, ",")+`)
+	`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error reading seat prices: %w", err)
+	}
+	defer rows.Close()
+
+	byTicket := make(map[int]int64, len(ticketIDs))
+	for rows.Next() {
+		var ticketID int
+		var price float64
+		if err := rows.Scan(&ticketID, &price); err != nil {
+			return nil, err
+		}
+		byTicket[ticketID] = int64(price)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	weights := make([]int64, len(ticketIDs))
+	for i, id := range ticketIDs {
+		weights[i] = byTicket[id]
+	}
+	return weights, nil
+}
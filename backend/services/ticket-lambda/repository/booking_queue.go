@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	apperrors "github.com/fpt-event-services/common/errors"
+	eventclient "github.com/fpt-event-services/services/event-lambda/client"
+)
+
+// ============================================================
+// Cấu hình hàng chờ mở bán của sự kiện (Event.booking_queue, migration 058)
+// Lúc mở bán mọi request đặt vé đều hỏi cấu hình này nên giữ trong bộ nhớ
+// bookingQueueCacheTTL: bật / tắt trong event-lambda áp dụng chậm tối đa 15 giây
+// ============================================================
+
+const bookingQueueCacheTTL = 15 * time.Second
+
+// BookingQueueConfig - Sự kiện có bật hàng chờ không
+// (số người được vào mỗi nhịp do dispatcher đọc thẳng từ Event, xem common/waitingroom)
+type BookingQueueConfig struct {
+	Enabled bool
+}
+
+type bookingQueueCacheEntry struct {
+	config   BookingQueueConfig
+	loadedAt time.Time
+}
+
+var bookingQueueCache = struct {
+	mu     sync.Mutex
+	events map[int]bookingQueueCacheEntry
+}{events: make(map[int]bookingQueueCacheEntry)}
+
+// GetBookingQueueConfig - Cấu hình hàng chờ của sự kiện (đọc qua EventService, có cache)
+func (r *TicketRepository) GetBookingQueueConfig(ctx context.Context, eventID int) (BookingQueueConfig, error) {
+	bookingQueueCache.mu.Lock()
+	cached, ok := bookingQueueCache.events[eventID]
+	bookingQueueCache.mu.Unlock()
+	if ok && time.Since(cached.loadedAt) < bookingQueueCacheTTL {
+		return cached.config, nil
+	}
+
+	info, err := r.events.GetEventBookingInfo(ctx, eventID)
+	if errors.Is(err, eventclient.ErrEventNotFound) {
+		return BookingQueueConfig{}, apperrors.NotFound("Sự kiện")
+	}
+	if err != nil {
+		return BookingQueueConfig{}, apperrors.DatabaseError(err)
+	}
+	config := BookingQueueConfig{Enabled: info.BookingQueue}
+
+	bookingQueueCache.mu.Lock()
+	bookingQueueCache.events[eventID] = bookingQueueCacheEntry{config: config, loadedAt: time.Now()}
+	bookingQueueCache.mu.Unlock()
+	return config, nil
+}
@@ -0,0 +1,205 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	apperrors "github.com/fpt-event-services/common/errors"
+	"github.com/fpt-event-services/common/logger"
+	"github.com/fpt-event-services/common/qrcode"
+	"github.com/fpt-event-services/services/ticket-lambda/models"
+)
+
+// Trạng thái phát vé mời cho từng email
+const (
+	CompTicketIssued = "ISSUED"
+	CompTicketFailed = "FAILED"
+)
+
+// defaultCompTicketQuota - Số vé mời tối đa khi Event.comp_ticket_quota = NULL
+func defaultCompTicketQuota() int {
+	if n, err := strconv.Atoi(os.Getenv("COMP_TICKET_DEFAULT_QUOTA")); err == nil && n >= 0 {
+		return n
+	}
+	return 20
+}
+
+// ============================================================
+// IssueCompTickets - Phát vé mời 0 đồng cho danh sách email
+// - Chỉ chủ sự kiện (hoặc ADMIN), sự kiện chưa kết thúc và chưa hủy
+// - Email phải có tài khoản ACTIVE (vé luôn gắn với user)
+// - Mỗi vé nhận một ghế trống của loại vé, trạng thái BOOKED, không có bill
+// - Không vượt quá quota vé mời của sự kiện và max_quantity của loại vé
+// Email lỗi không làm hỏng cả lô: trả về kết quả từng email
+// ============================================================
+func (r *TicketRepository) IssueCompTickets(ctx context.Context, issuerID int, role string, eventID int, req models.CompTicketRequest) (*models.CompTicketResponse, error) {
+	log := logger.Default().WithContext(ctx)
+
+	eventInfo, err := r.events.GetEventBookingInfo(ctx, eventID)
+	if err != nil {
+		return nil, apperrors.NotFound("Sự kiện")
+	}
+	if role != "ADMIN" && eventInfo.CreatedBy != issuerID {
+		return nil, apperrors.AccessDenied()
+	}
+	if eventInfo.Status == "CANCELLED" || !time.Now().Before(eventInfo.EndTime) {
+		return nil, apperrors.BusinessError("Sự kiện đã kết thúc hoặc đã hủy, không thể phát vé mời")
+	}
+
+	var categoryName, categoryStatus string
+	err = r.db.QueryRowContext(ctx,
+		"SELECT name, status FROM Category_Ticket WHERE category_ticket_id = ? AND event_id = ?",
+		req.CategoryTicketID, eventID,
+	).Scan(&categoryName, &categoryStatus)
+	if err == sql.ErrNoRows {
+		return nil, apperrors.NotFound("Loại vé")
+	}
+	if err != nil {
+		return nil, apperrors.DatabaseError(err)
+	}
+	if categoryStatus != "ACTIVE" {
+		return nil, apperrors.BusinessError("Loại vé này không khả dụng")
+	}
+
+	quota := defaultCompTicketQuota()
+	if eventInfo.CompTicketQuota != nil {
+		quota = *eventInfo.CompTicketQuota
+	}
+	var used int
+	err = r.db.QueryRowContext(ctx, `
+		SELECT COUNT(*) FROM Ticket
+		WHERE event_id = ? AND is_complimentary = 1 AND status IN `+inventoryActiveStatuses,
+		eventID).Scan(&used)
+	if err != nil {
+		return nil, apperrors.DatabaseError(err)
+	}
+	if used+len(req.Emails) > quota {
+		return nil, apperrors.BusinessError(fmt.Sprintf("Vượt quá hạn mức vé mời. Còn lại: %d, Yêu cầu: %d", max(quota-used, 0), len(req.Emails)))
+	}
+	remaining, err := inventoryRemaining(ctx, r.db, req.CategoryTicketID)
+	if err != nil {
+		return nil, apperrors.DatabaseError(err)
+	}
+	if remaining < len(req.Emails) {
+		return nil, apperrors.BusinessError(fmt.Sprintf("Không đủ vé %s. Còn lại: %d, Yêu cầu: %d", categoryName, remaining, len(req.Emails)))
+	}
+
+	resp := &models.CompTicketResponse{EventID: eventID, Quota: quota, Results: []models.CompTicketResult{}}
+	for _, addr := range req.Emails {
+		result := r.issueCompTicket(ctx, issuerID, eventID, req.CategoryTicketID, addr)
+		if result.Status == CompTicketIssued {
+			resp.Issued++
+		} else {
+			log.Warn("Complimentary ticket not issued", "event_id", eventID, "reason", result.Reason)
+		}
+		resp.Results = append(resp.Results, result)
+	}
+
+	resp.Used = used + resp.Issued
+	resp.Remaining = max(quota-resp.Used, 0)
+	log.Info("Complimentary tickets issued", "event_id", eventID, "issuer_id", issuerID,
+		"requested", len(req.Emails), "issued", resp.Issued)
+	return resp, nil
+}
+
+// issueCompTicket phát một vé mời; ghế bị người khác giành thì thử ghế trống kế tiếp
+func (r *TicketRepository) issueCompTicket(ctx context.Context, issuerID, eventID, categoryTicketID int, addr string) models.CompTicketResult {
+	result := models.CompTicketResult{Email: addr, Status: CompTicketFailed}
+
+	var userID int
+	err := r.db.QueryRowContext(ctx,
+		"SELECT user_id FROM Users WHERE email = ? AND status = 'ACTIVE'", addr,
+	).Scan(&userID)
+	if err != nil {
+		result.Reason = "Email chưa có tài khoản hoạt động"
+		return result
+	}
+
+	for attempt := 0; attempt < 3; attempt++ {
+		seatID, seatCode, err := firstFreeSeat(ctx, r.db, categoryTicketID, eventID)
+		if err == sql.ErrNoRows {
+			result.Reason = "Loại vé đã hết ghế trống"
+			return result
+		}
+		if err != nil {
+			result.Reason = "Không thể chọn ghế"
+			return result
+		}
+
+		ticketID, err := r.insertCompTicket(ctx, issuerID, userID, eventID, categoryTicketID, seatID)
+		if errors.Is(err, errSeatTaken) {
+			continue
+		}
+		if errors.Is(err, errInventoryExhausted) {
+			result.Reason = "Loại vé đã bán hết"
+			return result
+		}
+		if err != nil {
+			result.Reason = "Không thể tạo vé"
+			return result
+		}
+
+		go r.sendTicketEmailAsync(context.WithoutCancel(ctx), userID, eventID, seatID, int(ticketID), "0", categoryTicketID, "Vé mời")
+
+		result.Status = CompTicketIssued
+		result.TicketID = int(ticketID)
+		result.SeatCode = seatCode
+		result.Reason = ""
+		return result
+	}
+
+	result.Reason = "Ghế trống vừa được người khác đặt, vui lòng thử lại"
+	return result
+}
+
+// insertCompTicket giữ một suất và tạo vé mời BOOKED (kèm QR) trong cùng transaction
+func (r *TicketRepository) insertCompTicket(ctx context.Context, issuerID, userID, eventID, categoryTicketID, seatID int) (int64, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	if err := reserveInventory(ctx, tx, categoryTicketID, 1); err != nil {
+		return 0, err
+	}
+	ticketID, err := insertSeatTicket(ctx, tx, userID, eventID, categoryTicketID, seatID, "BOOKED", nil)
+	if err != nil {
+		return 0, err
+	}
+
+	qrBase64, err := qrcode.GenerateTicketQRBase64(int(ticketID), 300)
+	if err != nil {
+		qrBase64 = fmt.Sprintf("PENDING_QR_%d", ticketID)
+	}
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE Ticket SET qr_code_value = ?, is_complimentary = 1, issued_by = ? WHERE ticket_id = ?`,
+		qrBase64, issuerID, ticketID,
+	); err != nil {
+		return 0, err
+	}
+	return ticketID, tx.Commit()
+}
+
+// firstFreeSeat - Ghế ACTIVE đầu tiên (theo hàng, cột) của loại vé chưa có vé hiệu lực trong sự kiện
+// sql.ErrNoRows nếu loại vé đã hết ghế trống
+func firstFreeSeat(ctx context.Context, q queryRower, categoryTicketID, eventID int) (int, string, error) {
+	var seatID int
+	var seatCode string
+	err := q.QueryRowContext(ctx, `
+		SELECT s.seat_id, s.seat_code
+		FROM Seat s
+		WHERE s.category_ticket_id = ? AND s.status = 'ACTIVE'
+		  AND NOT EXISTS (SELECT 1 FROM Ticket t
+		                  WHERE t.event_id = ? AND t.seat_id = s.seat_id
+		                    AND t.status IN ('PENDING', 'BOOKED', 'CHECKED_IN', 'CHECKED_OUT'))
+		ORDER BY s.row_no, s.col_no, s.seat_code
+		LIMIT 1
+	`, categoryTicketID, eventID).Scan(&seatID, &seatCode)
+	return seatID, seatCode, err
+}
@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	apperrors "github.com/fpt-event-services/common/errors"
+)
+
+// ============================================================
+// checkCompanionSeats - Ghế người đi kèm chỉ được mua cùng ghế xe lăn liền kề
+// (Seat.companion_of_seat_id phải nằm trong cùng lần mua)
+// Áp dụng cho cả VNPay và thanh toán bằng ví
+// ============================================================
+func (r *TicketRepository) checkCompanionSeats(ctx context.Context, seatIDs []int) error {
+	if len(seatIDs) == 0 {
+		return nil
+	}
+	placeholders := make([]string, len(seatIDs))
+	args := make([]interface{}, len(seatIDs))
+	selected := make(map[int]bool, len(seatIDs))
+	for i, id := range seatIDs {
+		placeholders[i] = "?"
+		args[i] = id
+		selected[id] = true
+	}
+
+	rows, err := r.db.QueryContext(ctx, fmt.Sprintf(`
+		SELECT s.seat_code, s.companion_of_seat_id, COALESCE(a.seat_code, '')
+		FROM Seat s
+		LEFT JOIN Seat a ON a.seat_id = s.companion_of_seat_id
+		WHERE s.seat_id IN (%s) AND s.companion_of_seat_id IS NOT NULL
+	`, strings.Join(placeholders, ",")), args...)
+	if err != nil {
+		return apperrors.DatabaseError(err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var seatCode, accessibleCode string
+		var accessibleSeatID int
+		if err := rows.Scan(&seatCode, &accessibleSeatID, &accessibleCode); err != nil {
+			return apperrors.DatabaseError(err)
+		}
+		if !selected[accessibleSeatID] {
+			return apperrors.BusinessError(fmt.Sprintf(
+				"Ghế %s là ghế người đi kèm, chỉ được mua cùng ghế xe lăn %s", seatCode, accessibleCode))
+		}
+	}
+	return rows.Err()
+}
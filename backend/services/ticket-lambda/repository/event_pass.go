@@ -0,0 +1,832 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fpt-event-services/common/db"
+	apperrors "github.com/fpt-event-services/common/errors"
+	"github.com/fpt-event-services/common/ledger"
+	"github.com/fpt-event-services/common/logger"
+	"github.com/fpt-event-services/common/qrcode"
+	"github.com/fpt-event-services/common/vnpay"
+	"github.com/fpt-event-services/services/ticket-lambda/models"
+)
+
+// ============================================================
+// Event_Pass - Vé học kỳ / season pass (migration 049)
+// Mua bằng ví (trừ ví + Bill + Event_Pass_Holder trong một transaction) hoặc VNPay
+// (txnRef "PASS_...", xử lý trong ProcessVNPayCallback, chống trùng qua Payment_Transaction).
+// Người giữ pass được cấp vé 0 đồng (BOOKED, Ticket.pass_holder_id) cho từng sự kiện:
+// ngay sau khi mua, khi bấm claim, và job pass-ticket-issue cho sự kiện mở bán sau đó.
+// Vé pass giữ một suất của loại vé (reserveInventory) như vé thường
+// ============================================================
+
+// Phạm vi pass / trạng thái người giữ pass
+const (
+	PassScopeOrganizer = "ORGANIZER"
+	PassScopeEvents    = "EVENTS"
+
+	PassHolderActive  = "ACTIVE"
+	PassHolderRevoked = "REVOKED"
+)
+
+// passTxnPrefix - txnRef VNPay của lượt mua pass: PASS_userID_passID_timestamp
+const passTxnPrefix = "PASS_"
+
+// passEmailLabel - "Phương thức thanh toán" trên email vé cấp từ pass
+const passEmailLabel = "Vé học kỳ"
+
+var (
+	// ErrPassInsufficientBalance - Ví không đủ tiền mua pass
+	ErrPassInsufficientBalance = errors.New("insufficient wallet balance for pass")
+
+	// errPassTicketExists - Người giữ pass đã có vé pass cho sự kiện
+	errPassTicketExists = errors.New("pass ticket already issued for event")
+)
+
+// passEventsSQL - Sự kiện thuộc pass (alias p): bắt đầu trong thời hạn pass và
+// thuộc organizer (scope ORGANIZER) hoặc được chọn trong Event_Pass_Event (scope EVENTS)
+const passEventsSQL = `
+	JOIN Event e ON e.start_time >= p.valid_from AND e.start_time <= p.valid_to
+	LEFT JOIN Event_Pass_Event pe ON pe.pass_id = p.pass_id AND pe.event_id = e.event_id
+	WHERE ((p.scope = 'ORGANIZER' AND e.created_by = p.organizer_id)
+	       OR (p.scope = 'EVENTS' AND pe.event_id IS NOT NULL))`
+
+// passSelectSQL - Cột của EventPass (alias p, u) kèm số người đang giữ pass
+const passSelectSQL = `
+	SELECT p.pass_id, p.organizer_id, COALESCE(u.full_name, ''), p.name, p.description, p.price, p.scope,
+	       p.valid_from, p.valid_to, p.max_holders, p.status,
+	       (SELECT COUNT(*) FROM Event_Pass_Holder h WHERE h.pass_id = p.pass_id AND h.status = 'ACTIVE')
+	FROM Event_Pass p
+	LEFT JOIN Users u ON u.user_id = p.organizer_id`
+
+// IsPassTxnRef - txnRef VNPay thuộc lượt mua pass (không phải mua vé)
+func IsPassTxnRef(txnRef string) bool {
+	return strings.HasPrefix(txnRef, passTxnPrefix)
+}
+
+func scanEventPass(row interface{ Scan(...any) error }) (models.EventPass, error) {
+	var p models.EventPass
+	var description sql.NullString
+	var maxHolders sql.NullInt64
+	err := row.Scan(&p.PassID, &p.OrganizerID, &p.OrganizerName, &p.Name, &description, &p.Price, &p.Scope,
+		&p.ValidFrom, &p.ValidTo, &maxHolders, &p.Status, &p.Holders)
+	if description.Valid {
+		p.Description = &description.String
+	}
+	if maxHolders.Valid {
+		n := int(maxHolders.Int64)
+		p.MaxHolders = &n
+	}
+	return p, err
+}
+
+// ============================================================
+// CreatePass - Organizer tạo pass; scope EVENTS chỉ chọn được sự kiện của mình
+// (ADMIN chọn được mọi sự kiện), loại vé cố định phải thuộc đúng sự kiện
+// ============================================================
+func (r *TicketRepository) CreatePass(ctx context.Context, organizerID int, role string, req models.CreateEventPassRequest) (*models.EventPass, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, apperrors.DatabaseError(err)
+	}
+	defer tx.Rollback()
+
+	for _, ev := range req.Events {
+		var createdBy int
+		err := tx.QueryRowContext(ctx, "SELECT created_by FROM Event WHERE event_id = ?", ev.EventID).Scan(&createdBy)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperrors.NotFound(fmt.Sprintf("Sự kiện ID %d", ev.EventID))
+		}
+		if err != nil {
+			return nil, apperrors.DatabaseError(err)
+		}
+		if role != "ADMIN" && createdBy != organizerID {
+			return nil, apperrors.BusinessError(fmt.Sprintf("Sự kiện ID %d không thuộc ban tổ chức của bạn", ev.EventID))
+		}
+		if ev.CategoryTicketID != nil {
+			var n int
+			if err := tx.QueryRowContext(ctx,
+				"SELECT COUNT(*) FROM Category_Ticket WHERE category_ticket_id = ? AND event_id = ?",
+				*ev.CategoryTicketID, ev.EventID,
+			).Scan(&n); err != nil {
+				return nil, apperrors.DatabaseError(err)
+			}
+			if n == 0 {
+				return nil, apperrors.BusinessError(fmt.Sprintf("Loại vé ID %d không thuộc sự kiện ID %d", *ev.CategoryTicketID, ev.EventID))
+			}
+		}
+	}
+
+	result, err := tx.ExecContext(ctx, `
+		INSERT INTO Event_Pass (organizer_id, name, description, price, scope, valid_from, valid_to, max_holders, status)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, 'ACTIVE')
+	`, organizerID, req.Name, req.Description, req.Price, req.Scope, req.ValidFrom, req.ValidTo, req.MaxHolders)
+	if err != nil {
+		return nil, apperrors.DatabaseError(err)
+	}
+	passID, err := result.LastInsertId()
+	if err != nil {
+		return nil, apperrors.DatabaseError(err)
+	}
+	for _, ev := range req.Events {
+		if _, err := tx.ExecContext(ctx,
+			"INSERT INTO Event_Pass_Event (pass_id, event_id, category_ticket_id) VALUES (?, ?, ?)",
+			passID, ev.EventID, ev.CategoryTicketID,
+		); err != nil {
+			return nil, apperrors.DatabaseError(err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, apperrors.DatabaseError(err)
+	}
+	return r.GetPass(ctx, int(passID))
+}
+
+// ListPasses - Pass đang bán (ACTIVE, chưa hết hạn), lọc theo organizer nếu có
+func (r *TicketRepository) ListPasses(ctx context.Context, organizerID *int) ([]models.EventPass, error) {
+	query := passSelectSQL + ` WHERE p.status = 'ACTIVE' AND p.valid_to > NOW()`
+	args := []interface{}{}
+	if organizerID != nil {
+		query += ` AND p.organizer_id = ?`
+		args = append(args, *organizerID)
+	}
+	rows, err := r.db.QueryContext(ctx, query+` ORDER BY p.valid_from, p.pass_id`, args...)
+	if err != nil {
+		return nil, apperrors.DatabaseError(err)
+	}
+	defer rows.Close()
+
+	passes := []models.EventPass{}
+	for rows.Next() {
+		p, err := scanEventPass(rows)
+		if err != nil {
+			return nil, apperrors.DatabaseError(err)
+		}
+		passes = append(passes, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, apperrors.DatabaseError(err)
+	}
+	return passes, nil
+}
+
+// GetPass - Chi tiết pass kèm các sự kiện thuộc pass
+func (r *TicketRepository) GetPass(ctx context.Context, passID int) (*models.EventPass, error) {
+	p, err := scanEventPass(r.db.QueryRowContext(ctx, passSelectSQL+` WHERE p.pass_id = ?`, passID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, apperrors.NotFound("Pass")
+	}
+	if err != nil {
+		return nil, apperrors.DatabaseError(err)
+	}
+	p.Events, err = r.passEvents(ctx, passID, "")
+	if err != nil {
+		return nil, apperrors.DatabaseError(err)
+	}
+	return &p, nil
+}
+
+// passEvents - Sự kiện thuộc pass theo giờ bắt đầu (extraWhere thêm điều kiện trên alias e)
+func (r *TicketRepository) passEvents(ctx context.Context, passID int, extraWhere string, args ...interface{}) ([]models.EventPassEvent, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT e.event_id, e.title, e.start_time, e.status, pe.category_ticket_id
+		FROM Event_Pass p`+passEventsSQL+` AND p.pass_id = ? `+extraWhere+`
+		ORDER BY e.start_time, e.event_id
+	`, append([]interface{}{passID}, args...)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pass events: %w", err)
+	}
+	defer rows.Close()
+
+	events := []models.EventPassEvent{}
+	for rows.Next() {
+		var ev models.EventPassEvent
+		var categoryTicketID sql.NullInt64
+		if err := rows.Scan(&ev.EventID, &ev.Title, &ev.StartTime, &ev.Status, &categoryTicketID); err != nil {
+			return nil, err
+		}
+		if categoryTicketID.Valid {
+			id := int(categoryTicketID.Int64)
+			ev.CategoryTicketID = &id
+		}
+		events = append(events, ev)
+	}
+	return events, rows.Err()
+}
+
+// UpdatePassStatus - Chủ pass hoặc ADMIN bật / ngừng bán pass
+func (r *TicketRepository) UpdatePassStatus(ctx context.Context, userID int, role string, passID int, status string) (*models.EventPass, error) {
+	if err := r.requirePassOwner(ctx, userID, role, passID); err != nil {
+		return nil, err
+	}
+	if _, err := r.db.ExecContext(ctx, "UPDATE Event_Pass SET status = ? WHERE pass_id = ?", status, passID); err != nil {
+		return nil, apperrors.DatabaseError(err)
+	}
+	return r.GetPass(ctx, passID)
+}
+
+// RevokePassHolder - Thu hồi pass của một người giữ: vé đã cấp không check-in được,
+// không cấp thêm vé (hoàn tiền, nếu có, xử lý riêng)
+func (r *TicketRepository) RevokePassHolder(ctx context.Context, userID int, role string, passID, holderID int) error {
+	if err := r.requirePassOwner(ctx, userID, role, passID); err != nil {
+		return err
+	}
+	result, err := r.db.ExecContext(ctx,
+		"UPDATE Event_Pass_Holder SET status = 'REVOKED' WHERE holder_id = ? AND pass_id = ?", holderID, passID)
+	if err != nil {
+		return apperrors.DatabaseError(err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		var exists int
+		if err := r.db.QueryRowContext(ctx,
+			"SELECT COUNT(*) FROM Event_Pass_Holder WHERE holder_id = ? AND pass_id = ?", holderID, passID,
+		).Scan(&exists); err != nil {
+			return apperrors.DatabaseError(err)
+		}
+		if exists == 0 {
+			return apperrors.NotFound("Người giữ pass")
+		}
+	}
+	return nil
+}
+
+func (r *TicketRepository) requirePassOwner(ctx context.Context, userID int, role string, passID int) error {
+	var organizerID int
+	err := r.db.QueryRowContext(ctx, "SELECT organizer_id FROM Event_Pass WHERE pass_id = ?", passID).Scan(&organizerID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return apperrors.NotFound("Pass")
+	}
+	if err != nil {
+		return apperrors.DatabaseError(err)
+	}
+	if role != "ADMIN" && organizerID != userID {
+		return apperrors.AccessDenied()
+	}
+	return nil
+}
+
+// passForPurchase - Pass đang bán, còn chỗ và user chưa mua (lock = khóa dòng Event_Pass đến hết transaction)
+func passForPurchase(ctx context.Context, q queryRower, passID, userID int, lock bool) (*models.EventPass, error) {
+	query := passSelectSQL + ` WHERE p.pass_id = ?`
+	if lock {
+		query += ` FOR UPDATE`
+	}
+	p, err := scanEventPass(q.QueryRowContext(ctx, query, passID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, apperrors.NotFound("Pass")
+	}
+	if err != nil {
+		return nil, apperrors.DatabaseError(err)
+	}
+	if p.Status != "ACTIVE" || !time.Now().Before(p.ValidTo) {
+		return nil, apperrors.BusinessError("Pass đã ngừng bán hoặc đã hết hạn")
+	}
+	if p.MaxHolders != nil && p.Holders >= *p.MaxHolders {
+		return nil, apperrors.BusinessError("Pass đã bán hết")
+	}
+	var owned int
+	if err := q.QueryRowContext(ctx,
+		"SELECT COUNT(*) FROM Event_Pass_Holder WHERE pass_id = ? AND user_id = ?", passID, userID,
+	).Scan(&owned); err != nil {
+		return nil, apperrors.DatabaseError(err)
+	}
+	if owned > 0 {
+		return nil, apperrors.BusinessError("Bạn đã mua pass này")
+	}
+	return &p, nil
+}
+
+// insertPassHolder - Ghi người giữ pass trong transaction thanh toán
+func insertPassHolder(ctx context.Context, tx *sql.Tx, passID, userID int, billID int64) (int, error) {
+	result, err := tx.ExecContext(ctx,
+		"INSERT INTO Event_Pass_Holder (pass_id, user_id, bill_id, status) VALUES (?, ?, ?, 'ACTIVE')",
+		passID, userID, billID)
+	if db.IsDuplicateEntry(err) {
+		return 0, apperrors.BusinessError("Bạn đã mua pass này")
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to create pass holder: %w", err)
+	}
+	id, err := result.LastInsertId()
+	return int(id), err
+}
+
+// ============================================================
+// PurchasePassWithWallet - Mua pass bằng ví
+// Khóa dòng Event_Pass (max_holders) và số dư ví, trừ ví, tạo Bill + bút toán sổ cái,
+// ghi người giữ pass rồi cấp vé cho các sự kiện đang mở bán của pass
+// ============================================================
+func (r *TicketRepository) PurchasePassWithWallet(ctx context.Context, userID, passID int) (*models.PassPurchaseResult, error) {
+	log := logger.Default().WithContext(ctx)
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, apperrors.DatabaseError(err)
+	}
+	defer tx.Rollback()
+
+	pass, err := passForPurchase(ctx, tx, passID, userID, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var balance float64
+	err = tx.QueryRowContext(ctx, `SELECT COALESCE(Wallet, 0) FROM users WHERE user_id = ? FOR UPDATE`, userID).Scan(&balance)
+	if err != nil {
+		return nil, apperrors.DatabaseError(err)
+	}
+	if balance < pass.Price {
+		return nil, ErrPassInsufficientBalance
+	}
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE users SET Wallet = Wallet - ? WHERE user_id = ? AND Wallet >= ?`, pass.Price, userID, pass.Price,
+	); err != nil {
+		return nil, apperrors.DatabaseError(err)
+	}
+
+	billResult, err := tx.ExecContext(ctx,
+		"INSERT INTO Bill (user_id, total_amount, currency, payment_method, payment_status, created_at, paid_at) VALUES (?, ?, 'VND', 'Wallet', 'PAID', NOW(), NOW())",
+		userID, pass.Price,
+	)
+	if err != nil {
+		return nil, apperrors.DatabaseError(err)
+	}
+	billID, err := billResult.LastInsertId()
+	if err != nil {
+		return nil, apperrors.DatabaseError(err)
+	}
+	// Sổ cái: Nợ USER_WALLET / Có PLATFORM_REVENUE
+	if _, err := ledger.Post(ctx, tx, ledger.BillPayment(int(billID), userID, "Wallet", pass.Price)); err != nil {
+		return nil, apperrors.DatabaseError(err)
+	}
+
+	holderID, err := insertPassHolder(ctx, tx, passID, userID, billID)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, apperrors.DatabaseError(err)
+	}
+	log.Info("Pass purchased", "pass_id", passID, "user_id", userID, "holder_id", holderID, "bill_id", billID, "method", "Wallet")
+
+	return &models.PassPurchaseResult{HolderID: holderID, Tickets: r.issueHolderTickets(ctx, holderID)}, nil
+}
+
+// CreatePassVNPayURL - Link VNPay mua pass; pass chỉ được ghi khi callback thành công
+func (r *TicketRepository) CreatePassVNPayURL(ctx context.Context, userID, passID int) (*models.PassPurchaseResult, error) {
+	pass, err := passForPurchase(ctx, r.db, passID, userID, false)
+	if err != nil {
+		return nil, err
+	}
+	txnRef := fmt.Sprintf("%s%d_%d_%d", passTxnPrefix, userID, passID, time.Now().UnixMilli())
+	paymentURL, err := getVNPayService().CreatePaymentURL(vnpay.PaymentRequest{
+		OrderInfo: fmt.Sprintf("Payment for pass %s", pass.Name),
+		Amount:    pass.Price,
+		TxnRef:    txnRef,
+		IPAddr:    "127.0.0.1",
+	})
+	if err != nil {
+		logger.Default().WithContext(ctx).Error("Failed to create VNPay URL for pass", "pass_id", passID, "error", err)
+		return nil, apperrors.VNPayError("Không thể tạo link thanh toán")
+	}
+	return &models.PassPurchaseResult{PaymentURL: paymentURL, Tickets: []models.PassTicket{}}, nil
+}
+
+// processPassVNPayCallback - Phần mua pass của ProcessVNPayCallback (chữ ký đã được kiểm tra)
+// Trả về holder_id; tiền đã trừ ở VNPay nên pass vừa hết chỗ vẫn được ghi (chỉ cảnh báo)
+func (r *TicketRepository) processPassVNPayCallback(ctx context.Context, callback *vnpay.PaymentResponse) (string, error) {
+	log := logger.Default().WithContext(ctx)
+	txnRef := callback.TxnRef
+
+	// PASS_userID_passID_timestamp
+	parts := strings.Split(strings.TrimPrefix(txnRef, passTxnPrefix), "_")
+	if len(parts) != 3 {
+		return "Invalid transaction reference format", fmt.Errorf("invalid pass txn ref: %s", txnRef)
+	}
+	userID, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return "Invalid userID in txn ref", err
+	}
+	passID, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return "Invalid passID in txn ref", err
+	}
+
+	if result, ok, err := r.processedPayment(ctx, txnRef); err != nil {
+		log.Warn("Payment dedup lookup failed, relying on unique txn_ref", "txn_ref", txnRef, "error", err)
+	} else if ok {
+		return result, nil
+	}
+	if callback.ResponseCode != "00" {
+		log.Warn("Pass payment failed/cancelled", "txn_ref", txnRef, "response_code", callback.ResponseCode)
+		return "Payment was cancelled or failed. Response code: " + callback.ResponseCode, apperrors.PaymentFailed(callback.ResponseCode)
+	}
+
+	amountFromVNPay, err := strconv.ParseFloat(callback.Amount, 64)
+	if err != nil {
+		return "Invalid amount format", err
+	}
+	billAmount := amountFromVNPay / 100
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return "Database error", err
+	}
+	defer tx.Rollback()
+
+	if err := claimPaymentTx(ctx, tx, txnRef, userID, billAmount, callback.ResponseCode); err != nil {
+		if !errors.Is(err, errPaymentAlreadyProcessed) {
+			return "Database error", err
+		}
+		tx.Rollback()
+		result, _, lookupErr := r.processedPayment(ctx, txnRef)
+		if lookupErr != nil {
+			return "Database error", lookupErr
+		}
+		return result, nil
+	}
+
+	if _, err := passForPurchase(ctx, tx, passID, userID, true); err != nil {
+		appErr, ok := apperrors.AsAppError(err)
+		if !ok || appErr.Code != apperrors.ErrCodeBusinessRule {
+			return "Pass not found", err
+		}
+		log.Warn("Pass paid after it became unavailable, recording holder anyway", "pass_id", passID, "user_id", userID, "reason", appErr.Message)
+	}
+
+	billResult, err := tx.ExecContext(ctx,
+		"INSERT INTO Bill (user_id, total_amount, currency, payment_method, payment_status, created_at, paid_at) VALUES (?, ?, 'VND', 'VNPAY', 'PAID', NOW(), NOW())",
+		userID, billAmount,
+	)
+	if err != nil {
+		return "Failed to create bill", err
+	}
+	billID, err := billResult.LastInsertId()
+	if err != nil {
+		return "Failed to get bill ID", err
+	}
+	// Sổ cái: Nợ VNPAY_CLEARING / Có PLATFORM_REVENUE
+	if _, err := ledger.Post(ctx, tx, ledger.BillPayment(int(billID), userID, "VNPAY", billAmount)); err != nil {
+		return "Failed to post bill to ledger", err
+	}
+
+	holderID, err := insertPassHolder(ctx, tx, passID, userID, billID)
+	if err != nil {
+		return "Failed to create pass holder", err
+	}
+	result := strconv.Itoa(holderID)
+	if err := completePaymentTx(ctx, tx, txnRef, billID, result); err != nil {
+		return "Database error", err
+	}
+	if err := tx.Commit(); err != nil {
+		return "Failed to commit transaction", err
+	}
+	log.Info("Pass purchased", "pass_id", passID, "user_id", userID, "holder_id", holderID, "bill_id", billID, "method", "VNPAY")
+
+	r.issueHolderTickets(ctx, holderID)
+	return result, nil
+}
+
+// ============================================================
+// ClaimPassTicket - Người giữ pass nhận vé cho một sự kiện của pass
+// Sự kiện phải OPEN, chưa bắt đầu; mỗi sự kiện một vé pass
+// Loại vé: loại vé pass cố định cho sự kiện, nếu không thì loại vé được chọn,
+// nếu không chọn thì loại vé ACTIVE rẻ nhất còn chỗ
+// ============================================================
+func (r *TicketRepository) ClaimPassTicket(ctx context.Context, userID, passID int, req models.PassClaimRequest) (*models.PassTicket, error) {
+	var holderID int
+	var status string
+	err := r.db.QueryRowContext(ctx,
+		"SELECT holder_id, status FROM Event_Pass_Holder WHERE pass_id = ? AND user_id = ?", passID, userID,
+	).Scan(&holderID, &status)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, apperrors.NotFound("Pass của bạn")
+	}
+	if err != nil {
+		return nil, apperrors.DatabaseError(err)
+	}
+	if status != PassHolderActive {
+		return nil, apperrors.BusinessError("Pass của bạn đã bị thu hồi")
+	}
+
+	events, err := r.passEvents(ctx, passID, "AND e.event_id = ?", req.EventID)
+	if err != nil {
+		return nil, apperrors.DatabaseError(err)
+	}
+	if len(events) == 0 {
+		return nil, apperrors.NotFound("Sự kiện trong pass")
+	}
+	ev := events[0]
+	if ev.Status != "OPEN" || !time.Now().Before(ev.StartTime) {
+		return nil, apperrors.BusinessError("Sự kiện không mở nhận vé hoặc đã bắt đầu")
+	}
+
+	categoryTicketID, err := r.pickPassCategory(ctx, ev.EventID, ev.CategoryTicketID, req.CategoryTicketID)
+	if err != nil {
+		return nil, err
+	}
+	ticket, err := r.issuePassTicket(ctx, holderID, userID, ev.EventID, categoryTicketID)
+	if errors.Is(err, errPassTicketExists) {
+		return nil, apperrors.BusinessError("Bạn đã nhận vé của sự kiện này từ pass")
+	}
+	if errors.Is(err, errInventoryExhausted) || errors.Is(err, sql.ErrNoRows) {
+		return nil, apperrors.BusinessError("Loại vé đã hết chỗ")
+	}
+	if err != nil {
+		return nil, apperrors.DatabaseError(err)
+	}
+	return ticket, nil
+}
+
+// pickPassCategory - Loại vé cấp cho người giữ pass (xem ClaimPassTicket)
+func (r *TicketRepository) pickPassCategory(ctx context.Context, eventID int, fixed, requested *int) (int, error) {
+	if fixed != nil {
+		return *fixed, nil
+	}
+	if requested != nil {
+		var status string
+		err := r.db.QueryRowContext(ctx,
+			"SELECT status FROM Category_Ticket WHERE category_ticket_id = ? AND event_id = ?", *requested, eventID,
+		).Scan(&status)
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, apperrors.NotFound("Loại vé")
+		}
+		if err != nil {
+			return 0, apperrors.DatabaseError(err)
+		}
+		if status != "ACTIVE" {
+			return 0, apperrors.BusinessError("Loại vé này không khả dụng")
+		}
+		return *requested, nil
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT category_ticket_id FROM Category_Ticket
+		WHERE event_id = ? AND status = 'ACTIVE'
+		ORDER BY price, category_ticket_id
+	`, eventID)
+	if err != nil {
+		return 0, apperrors.DatabaseError(err)
+	}
+	var ids []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return 0, apperrors.DatabaseError(err)
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+	for _, id := range ids {
+		remaining, err := inventoryRemaining(ctx, r.db, id)
+		if err != nil {
+			return 0, apperrors.DatabaseError(err)
+		}
+		if remaining > 0 {
+			return id, nil
+		}
+	}
+	return 0, apperrors.BusinessError("Sự kiện đã hết vé")
+}
+
+// issuePassTicket - Chọn ghế trống và tạo vé pass; ghế bị người khác giành thì thử ghế kế tiếp
+func (r *TicketRepository) issuePassTicket(ctx context.Context, holderID, userID, eventID, categoryTicketID int) (*models.PassTicket, error) {
+	for attempt := 0; attempt < 3; attempt++ {
+		seatID, seatCode, err := firstFreeSeat(ctx, r.db, categoryTicketID, eventID)
+		if err != nil {
+			return nil, err
+		}
+		ticketID, err := r.insertPassTicket(ctx, holderID, userID, eventID, categoryTicketID, seatID)
+		if errors.Is(err, errSeatTaken) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		go r.sendTicketEmailAsync(context.WithoutCancel(ctx), userID, eventID, seatID, int(ticketID), "0", categoryTicketID, passEmailLabel)
+		return &models.PassTicket{EventID: eventID, TicketID: int(ticketID), SeatCode: seatCode, Status: "BOOKED"}, nil
+	}
+	return nil, errSeatTaken
+}
+
+// insertPassTicket giữ một suất và tạo vé BOOKED gắn với người giữ pass trong cùng transaction
+// Khóa dòng Event_Pass_Holder để claim và job cấp vé chạy song song không cấp hai vé cho một sự kiện
+func (r *TicketRepository) insertPassTicket(ctx context.Context, holderID, userID, eventID, categoryTicketID, seatID int) (int64, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	var status string
+	if err := tx.QueryRowContext(ctx,
+		"SELECT status FROM Event_Pass_Holder WHERE holder_id = ? FOR UPDATE", holderID,
+	).Scan(&status); err != nil {
+		return 0, err
+	}
+	if status != PassHolderActive {
+		return 0, apperrors.BusinessError("Pass đã bị thu hồi")
+	}
+	var issued int
+	if err := tx.QueryRowContext(ctx,
+		"SELECT COUNT(*) FROM Ticket WHERE pass_holder_id = ? AND event_id = ?", holderID, eventID,
+	).Scan(&issued); err != nil {
+		return 0, err
+	}
+	if issued > 0 {
+		return 0, errPassTicketExists
+	}
+
+	if err := reserveInventory(ctx, tx, categoryTicketID, 1); err != nil {
+		return 0, err
+	}
+	ticketID, err := insertSeatTicket(ctx, tx, userID, eventID, categoryTicketID, seatID, "BOOKED", nil)
+	if err != nil {
+		return 0, err
+	}
+	qrBase64, err := qrcode.GenerateTicketQRBase64(int(ticketID), 300)
+	if err != nil {
+		qrBase64 = fmt.Sprintf("PENDING_QR_%d", ticketID)
+	}
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE Ticket SET qr_code_value = ?, pass_holder_id = ? WHERE ticket_id = ?`, qrBase64, holderID, ticketID,
+	); err != nil {
+		return 0, err
+	}
+	return ticketID, tx.Commit()
+}
+
+// passIssueTarget - Một vé cần cấp tự động (người giữ pass × sự kiện)
+type passIssueTarget struct {
+	holderID         int
+	userID           int
+	eventID          int
+	categoryTicketID *int
+}
+
+// autoIssueSQL - Người giữ pass ACTIVE × sự kiện OPEN chưa bắt đầu của pass, chưa có vé pass
+// (kể cả vé đã hoàn tiền) và user chưa tự mua vé cho sự kiện đó (vẫn claim tay được)
+const autoIssueSQL = `
+	SELECT h.holder_id, h.user_id, e.event_id, pe.category_ticket_id
+	FROM Event_Pass_Holder h
+	JOIN Event_Pass p ON p.pass_id = h.pass_id` + passEventsSQL + `
+	  AND h.status = 'ACTIVE' AND e.status = 'OPEN' AND e.start_time > NOW()
+	  AND NOT EXISTS (SELECT 1 FROM Ticket t WHERE t.pass_holder_id = h.holder_id AND t.event_id = e.event_id)
+	  AND NOT EXISTS (SELECT 1 FROM Ticket t WHERE t.user_id = h.user_id AND t.event_id = e.event_id
+	                    AND t.status IN ` + inventoryActiveStatuses + `)`
+
+func (r *TicketRepository) passIssueTargets(ctx context.Context, where string, args ...interface{}) ([]passIssueTarget, error) {
+	rows, err := r.db.QueryContext(ctx, autoIssueSQL+where+` ORDER BY h.holder_id, e.start_time`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pass issue targets: %w", err)
+	}
+	defer rows.Close()
+
+	var targets []passIssueTarget
+	for rows.Next() {
+		var t passIssueTarget
+		var categoryTicketID sql.NullInt64
+		if err := rows.Scan(&t.holderID, &t.userID, &t.eventID, &categoryTicketID); err != nil {
+			return nil, err
+		}
+		if categoryTicketID.Valid {
+			id := int(categoryTicketID.Int64)
+			t.categoryTicketID = &id
+		}
+		targets = append(targets, t)
+	}
+	return targets, rows.Err()
+}
+
+// issueTargets cấp vé cho từng target; lỗi một sự kiện (hết vé...) không chặn các sự kiện khác
+func (r *TicketRepository) issueTargets(ctx context.Context, targets []passIssueTarget) ([]models.PassTicket, int) {
+	log := logger.Default().WithContext(ctx)
+	issued := []models.PassTicket{}
+	failed := 0
+	for _, t := range targets {
+		categoryTicketID, err := r.pickPassCategory(ctx, t.eventID, t.categoryTicketID, nil)
+		var ticket *models.PassTicket
+		if err == nil {
+			ticket, err = r.issuePassTicket(ctx, t.holderID, t.userID, t.eventID, categoryTicketID)
+		}
+		if errors.Is(err, errPassTicketExists) {
+			continue
+		}
+		if err != nil {
+			failed++
+			log.Warn("Pass ticket not issued", "holder_id", t.holderID, "event_id", t.eventID, "error", err)
+			continue
+		}
+		issued = append(issued, *ticket)
+	}
+	return issued, failed
+}
+
+// issueHolderTickets - Cấp vé cho các sự kiện đang mở bán ngay sau khi mua pass
+func (r *TicketRepository) issueHolderTickets(ctx context.Context, holderID int) []models.PassTicket {
+	targets, err := r.passIssueTargets(ctx, ` AND h.holder_id = ?`, holderID)
+	if err != nil {
+		logger.Default().WithContext(ctx).Warn("Failed to list events for new pass holder", "holder_id", holderID, "error", err)
+		return []models.PassTicket{}
+	}
+	issued, _ := r.issueTargets(ctx, targets)
+	return issued
+}
+
+// IssuePassTickets - Cấp vé cho mọi người giữ pass ở các sự kiện mới mở bán (job pass-ticket-issue)
+func (r *TicketRepository) IssuePassTickets(ctx context.Context) (issued, failed int, err error) {
+	targets, err := r.passIssueTargets(ctx, "")
+	if err != nil {
+		return 0, 0, err
+	}
+	tickets, failed := r.issueTargets(ctx, targets)
+	return len(tickets), failed, ctx.Err()
+}
+
+// ============================================================
+// GetMyPasses - Pass của user kèm vé đã cấp và sự kiện còn nhận vé được
+// ============================================================
+func (r *TicketRepository) GetMyPasses(ctx context.Context, userID int) ([]models.PassHolding, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT holder_id, pass_id, status, purchased_at
+		FROM Event_Pass_Holder WHERE user_id = ?
+		ORDER BY purchased_at DESC, holder_id DESC
+	`, userID)
+	if err != nil {
+		return nil, apperrors.DatabaseError(err)
+	}
+	type holding struct {
+		models.PassHolding
+		passID int
+	}
+	var list []holding
+	for rows.Next() {
+		var h holding
+		if err := rows.Scan(&h.HolderID, &h.passID, &h.Status, &h.PurchasedAt); err != nil {
+			rows.Close()
+			return nil, apperrors.DatabaseError(err)
+		}
+		list = append(list, h)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, apperrors.DatabaseError(err)
+	}
+
+	holdings := []models.PassHolding{}
+	for _, h := range list {
+		pass, err := r.GetPass(ctx, h.passID)
+		if err != nil {
+			return nil, err
+		}
+		pass.Events = nil
+		h.Pass = *pass
+		if h.Tickets, err = r.holderTickets(ctx, h.HolderID); err != nil {
+			return nil, apperrors.DatabaseError(err)
+		}
+		h.Claimable = []models.EventPassEvent{}
+		if h.Status == PassHolderActive {
+			h.Claimable, err = r.passEvents(ctx, h.passID,
+				`AND e.status = 'OPEN' AND e.start_time > NOW()
+				 AND NOT EXISTS (SELECT 1 FROM Ticket t WHERE t.pass_holder_id = ? AND t.event_id = e.event_id)`, h.HolderID)
+			if err != nil {
+				return nil, apperrors.DatabaseError(err)
+			}
+		}
+		holdings = append(holdings, h.PassHolding)
+	}
+	return holdings, nil
+}
+
+func (r *TicketRepository) holderTickets(ctx context.Context, holderID int) ([]models.PassTicket, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT t.event_id, t.ticket_id, COALESCE(s.seat_code, ''), t.status
+		FROM Ticket t
+		LEFT JOIN Seat s ON s.seat_id = t.seat_id
+		WHERE t.pass_holder_id = ?
+		ORDER BY t.ticket_id
+	`, holderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tickets := []models.PassTicket{}
+	for rows.Next() {
+		var t models.PassTicket
+		if err := rows.Scan(&t.EventID, &t.TicketID, &t.SeatCode, &t.Status); err != nil {
+			return nil, err
+		}
+		tickets = append(tickets, t)
+	}
+	return tickets, rows.Err()
+}
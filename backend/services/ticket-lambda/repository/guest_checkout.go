@@ -0,0 +1,159 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/fpt-event-services/common/crypto"
+	apperrors "github.com/fpt-event-services/common/errors"
+)
+
+// ============================================================
+// Khách mua vé không có tài khoản (migration 044)
+// Vé luôn gắn với user nên mỗi email khách có một user role GUEST:
+// password_hash rỗng (không đăng nhập được), không có quyền nào.
+// Mã tra cứu chỉ lưu SHA-256 trong Guest_Lookup_Code; đăng ký bằng email đó
+// (auth-lambda) nâng user lên STUDENT và xóa mã
+// ============================================================
+
+// RoleGuest - Role của user vỏ tạo cho khách
+const RoleGuest = "GUEST"
+
+// GuestContact - Email / tên của user GUEST (gửi mã tra cứu)
+type GuestContact struct {
+	UserID   int
+	Email    string
+	FullName string
+}
+
+// CheckGuestCheckoutAllowed - Sự kiện tồn tại và organizer đã bật allow_guest_checkout
+func (r *TicketRepository) CheckGuestCheckoutAllowed(ctx context.Context, eventID int) error {
+	info, err := r.events.GetEventBookingInfo(ctx, eventID)
+	if err != nil {
+		return apperrors.NotFound("Sự kiện")
+	}
+	if !info.AllowGuestCheckout {
+		return apperrors.BusinessError("Sự kiện này không mở bán vé cho khách không có tài khoản, vui lòng đăng nhập để mua vé")
+	}
+	return nil
+}
+
+// FindOrCreateGuestUser - User GUEST của email; email đã thuộc tài khoản thật thì phải đăng nhập
+func (r *TicketRepository) FindOrCreateGuestUser(ctx context.Context, email, fullName, phone string) (int, error) {
+	userID, err := r.findGuestUser(ctx, email)
+	if err == nil || !errors.Is(err, sql.ErrNoRows) {
+		return userID, err
+	}
+
+	encryptedPhone, err := crypto.EncryptPII(phone)
+	if err != nil {
+		return 0, err
+	}
+	result, err := r.db.ExecContext(ctx, `
+		INSERT INTO Users (full_name, email, phone, password_hash, role, status, Wallet)
+		VALUES (?, ?, ?, '', ?, 'ACTIVE', 0)
+	`, fullName, email, encryptedPhone, RoleGuest)
+	if err != nil {
+		// Hai lần checkout cùng email chạy song song: bản kia vừa tạo user, đọc lại
+		if userID, findErr := r.findGuestUser(ctx, email); findErr == nil {
+			return userID, nil
+		}
+		return 0, fmt.Errorf("failed to create guest user: %w", err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get guest user id: %w", err)
+	}
+	return int(id), nil
+}
+
+// findGuestUser trả về sql.ErrNoRows nếu email chưa có user
+func (r *TicketRepository) findGuestUser(ctx context.Context, email string) (int, error) {
+	var userID int
+	var role, status string
+	err := r.db.QueryRowContext(ctx,
+		"SELECT user_id, role, status FROM Users WHERE email = ?", email,
+	).Scan(&userID, &role, &status)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, err
+		}
+		return 0, fmt.Errorf("failed to load user: %w", err)
+	}
+	if role != RoleGuest {
+		return 0, apperrors.BusinessError("Email đã có tài khoản, vui lòng đăng nhập để mua vé")
+	}
+	if status != "ACTIVE" {
+		return 0, apperrors.BusinessError("Email này không thể mua vé")
+	}
+	return userID, nil
+}
+
+// GetGuestContact - Thông tin liên hệ nếu user là GUEST (ok = false với tài khoản thường)
+func (r *TicketRepository) GetGuestContact(ctx context.Context, userID int) (*GuestContact, bool, error) {
+	c := GuestContact{UserID: userID}
+	var role string
+	err := r.db.QueryRowContext(ctx,
+		"SELECT email, full_name, role FROM Users WHERE user_id = ?", userID,
+	).Scan(&c.Email, &c.FullName, &role)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to load user: %w", err)
+	}
+	if role != RoleGuest {
+		return nil, false, nil
+	}
+	return &c, true, nil
+}
+
+// SaveGuestLookupCode - Lưu hash của mã tra cứu vừa gửi cho khách
+func (r *TicketRepository) SaveGuestLookupCode(ctx context.Context, userID, billID int, codeHash string, expiresAt time.Time) error {
+	var bill sql.NullInt64
+	if billID > 0 {
+		bill = sql.NullInt64{Int64: int64(billID), Valid: true}
+	}
+	if _, err := r.db.ExecContext(ctx, `
+		INSERT INTO Guest_Lookup_Code (user_id, code_hash, bill_id, expires_at)
+		VALUES (?, ?, ?, ?)
+	`, userID, codeHash, bill, expiresAt); err != nil {
+		return fmt.Errorf("failed to save guest lookup code: %w", err)
+	}
+	return nil
+}
+
+// FindGuestByLookupCode - User GUEST sở hữu mã (mã sai / hết hạn / đã chuyển thành tài khoản đều NotFound)
+func (r *TicketRepository) FindGuestByLookupCode(ctx context.Context, codeHash string) (int, error) {
+	var codeID, userID int
+	err := r.db.QueryRowContext(ctx, `
+		SELECT g.code_id, g.user_id
+		FROM Guest_Lookup_Code g
+		JOIN Users u ON u.user_id = g.user_id
+		WHERE g.code_hash = ? AND g.expires_at > NOW() AND u.role = ?
+	`, codeHash, RoleGuest).Scan(&codeID, &userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, apperrors.NotFound("Mã tra cứu")
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to look up guest code: %w", err)
+	}
+	if _, err := r.db.ExecContext(ctx,
+		"UPDATE Guest_Lookup_Code SET last_used_at = NOW() WHERE code_id = ?", codeID,
+	); err != nil {
+		return 0, fmt.Errorf("failed to touch guest code: %w", err)
+	}
+	return userID, nil
+}
+
+// GetEventTitle - Tên sự kiện cho email gửi khách ("" nếu không đọc được)
+func (r *TicketRepository) GetEventTitle(ctx context.Context, eventID int) string {
+	info, err := r.events.GetEventBookingInfo(ctx, eventID)
+	if err != nil {
+		return ""
+	}
+	return info.Title
+}
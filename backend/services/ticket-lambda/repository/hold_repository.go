@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	apperrors "github.com/fpt-event-services/common/errors"
+	"github.com/fpt-event-services/common/rules"
+	"github.com/fpt-event-services/services/ticket-lambda/models"
+)
+
+// ============================================================
+// GIỮ GHẾ (PENDING HOLD)
+// Vé PENDING giữ ghế trong rules.HoldDuration (rule.hold_minutes) kể từ lúc tạo URL VNPay.
+// User được gia hạn MỘT lần thêm rules.HoldExtension; job pending-ticket-cleanup
+// chỉ xóa vé khi đã qua hold_expires_at
+// ============================================================
+
+// GetActiveHolds - Lấy các vé PENDING còn hạn giữ ghế của user
+func (r *TicketRepository) GetActiveHolds(ctx context.Context, userID int) ([]models.TicketHold, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT t.ticket_id, t.event_id, e.title, t.category_ticket_id, t.seat_id, s.seat_code,
+		       t.created_at, t.hold_expires_at, t.hold_extended
+		FROM Ticket t
+		JOIN Event e ON t.event_id = e.event_id
+		LEFT JOIN Seat s ON t.seat_id = s.seat_id
+		WHERE t.user_id = ? AND t.status = 'PENDING' AND t.hold_expires_at > NOW()
+		ORDER BY t.hold_expires_at ASC, t.ticket_id ASC
+	`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query active holds: %w", err)
+	}
+	defer rows.Close()
+
+	now := time.Now()
+	holds := []models.TicketHold{}
+	for rows.Next() {
+		var h models.TicketHold
+		if err := rows.Scan(&h.TicketID, &h.EventID, &h.EventName, &h.CategoryTicketID, &h.SeatID, &h.SeatCode,
+			&h.CreatedAt, &h.HoldExpiresAt, &h.Extended); err != nil {
+			return nil, fmt.Errorf("failed to scan hold: %w", err)
+		}
+		h.RemainingSeconds = max(int(h.HoldExpiresAt.Sub(now).Seconds()), 0)
+		h.CanExtend = !h.Extended
+		holds = append(holds, h)
+	}
+	return holds, rows.Err()
+}
+
+// ExtendHolds - Gia hạn giữ ghế thêm rules.HoldExtension (mỗi vé chỉ được gia hạn 1 lần)
+// ticketIDs rỗng: gia hạn tất cả vé PENDING còn hạn, chưa gia hạn của user
+func (r *TicketRepository) ExtendHolds(ctx context.Context, userID int, ticketIDs []int) ([]models.TicketHold, error) {
+	query := `
+		UPDATE Ticket
+		SET hold_expires_at = DATE_ADD(hold_expires_at, INTERVAL ? SECOND), hold_extended = 1
+		WHERE user_id = ? AND status = 'PENDING' AND hold_extended = 0 AND hold_expires_at > NOW()
+	`
+	args := []interface{}{int(rules.HoldExtension(ctx).Seconds()), userID}
+
+	if len(ticketIDs) > 0 {
+		placeholders := make([]string, len(ticketIDs))
+		for i, id := range ticketIDs {
+			placeholders[i] = "?"
+			args = append(args, id)
+		}
+		query += " AND ticket_id IN (" + strings.Join(placeholders, ",") + ")"
+	}
+
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return nil, apperrors.DatabaseError(err)
+	}
+	affected, _ := result.RowsAffected()
+	if affected == 0 {
+		return nil, apperrors.BusinessError("Không có vé nào có thể gia hạn (đã hết hạn hoặc đã gia hạn trước đó)")
+	}
+
+	return r.GetActiveHolds(ctx, userID)
+}
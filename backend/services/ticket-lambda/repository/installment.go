@@ -0,0 +1,603 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fpt-event-services/common/billing"
+	"github.com/fpt-event-services/common/email"
+	apperrors "github.com/fpt-event-services/common/errors"
+	"github.com/fpt-event-services/common/ledger"
+	"github.com/fpt-event-services/common/logger"
+	"github.com/fpt-event-services/common/outbox"
+	"github.com/fpt-event-services/common/permission"
+	"github.com/fpt-event-services/common/vnpay"
+	eventclient "github.com/fpt-event-services/services/event-lambda/client"
+	eventModels "github.com/fpt-event-services/services/event-lambda/models"
+	"github.com/fpt-event-services/services/ticket-lambda/models"
+)
+
+// ============================================================
+// Trả góp hóa đơn vé (migration 055)
+// Sự kiện có Event_Installment_Plan cho mua vé bằng tiền cọc (installment=true): vé BOOKED ngay,
+// Bill ghi tổng tiền với payment_status PARTIAL kèm lịch Bill_Installment (kỳ 0 = cọc đã trả).
+// Các kỳ còn lại trả lần lượt bằng ví hoặc VNPay (txnRef "INST_installmentID_timestamp");
+// trả đủ thì Bill → PAID và gửi biên lai. Job installment-reminder nhắc kỳ sắp đến hạn và
+// tạm khóa vé (Ticket.suspended_at) khi có kỳ quá hạn; trả hết kỳ quá hạn thì mở khóa
+// ============================================================
+
+// installmentTxnPrefix - txnRef VNPay của một kỳ trả góp: INST_installmentID_timestamp
+const installmentTxnPrefix = "INST_"
+
+// depositTxnMarker - Phần cuối txnRef mua vé trả góp: userID_eventID_categoryID_ticketIDs_timestamp_D<tổng tiền>
+const depositTxnMarker = "D"
+
+// installmentDeadlineGap - Kỳ cuối đến hạn muộn nhất trước giờ bắt đầu sự kiện
+const installmentDeadlineGap = 24 * time.Hour
+
+// ErrInstallmentInsufficientBalance - Ví không đủ tiền trả kỳ trả góp
+var ErrInstallmentInsufficientBalance = errors.New("insufficient wallet balance for installment")
+
+// installmentNoticeSQL - Kỳ PENDING của hóa đơn PARTIAL kèm người mua, tên sự kiện và số tiền còn nợ
+const installmentNoticeSQL = `
+	SELECT bi.installment_id, bi.bill_id, bi.seq, bi.amount, bi.due_at, u.email, u.full_name,
+	       COALESCE((SELECT e.title FROM Bill_Item x JOIN Event e ON e.event_id = x.event_id WHERE x.bill_id = bi.bill_id LIMIT 1), ''),
+	       (SELECT COALESCE(SUM(r.amount), 0) FROM Bill_Installment r WHERE r.bill_id = bi.bill_id AND r.status = 'PENDING')
+	FROM Bill_Installment bi
+	JOIN Bill b ON b.bill_id = bi.bill_id
+	JOIN Users u ON u.user_id = b.user_id
+	WHERE bi.status = 'PENDING' AND b.payment_status = 'PARTIAL'`
+
+// IsInstallmentTxnRef - txnRef VNPay thuộc một kỳ trả góp (không phải mua vé)
+func IsInstallmentTxnRef(txnRef string) bool {
+	return strings.HasPrefix(txnRef, installmentTxnPrefix)
+}
+
+// installmentDeadline - Hạn muộn nhất của các kỳ trả góp
+func installmentDeadline(eventStart time.Time) time.Time {
+	return eventStart.Add(-installmentDeadlineGap)
+}
+
+// installmentPayURL - Trang hóa đơn trên frontend (link trong email nhắc hạn)
+func installmentPayURL(billID int) string {
+	base := strings.TrimRight(os.Getenv("FRONTEND_URL"), "/")
+	if base == "" {
+		base = "http://localhost:3000"
+	}
+	return fmt.Sprintf("%s/my-bills/%d", base, billID)
+}
+
+// loadInstallmentPlan - Cấu hình trả góp của sự kiện (ok = false nếu sự kiện không cho trả góp)
+func loadInstallmentPlan(ctx context.Context, q queryRower, eventID int) (billing.InstallmentPlan, bool, error) {
+	var plan billing.InstallmentPlan
+	err := q.QueryRowContext(ctx,
+		"SELECT deposit_percent, installment_count, interval_days FROM Event_Installment_Plan WHERE event_id = ?", eventID,
+	).Scan(&plan.DepositPercent, &plan.Count, &plan.IntervalDays)
+	if errors.Is(err, sql.ErrNoRows) {
+		return plan, false, nil
+	}
+	if err != nil {
+		return plan, false, fmt.Errorf("failed to load installment plan: %w", err)
+	}
+	return plan, true, nil
+}
+
+// installmentDeposit - Cấu hình và tiền cọc khi mua vé trả góp tổng tiền total
+// Sự kiện không cho trả góp hoặc đã quá hạn cuối của lịch trả góp → lỗi nghiệp vụ
+func installmentDeposit(ctx context.Context, q queryRower, eventID int, eventStart time.Time, total int64) (billing.InstallmentPlan, int64, error) {
+	plan, ok, err := loadInstallmentPlan(ctx, q, eventID)
+	if err != nil {
+		return plan, 0, apperrors.DatabaseError(err)
+	}
+	if !ok {
+		return plan, 0, apperrors.BusinessError("Sự kiện không hỗ trợ trả góp")
+	}
+	if !time.Now().Before(installmentDeadline(eventStart)) {
+		return plan, 0, apperrors.BusinessError("Sự kiện sắp diễn ra, vui lòng thanh toán toàn bộ")
+	}
+	return plan, plan.Deposit(total), nil
+}
+
+// InstallmentDeposit - Tiền cọc của lượt mua vé trả góp (handler kiểm tra số dư ví trước khi trừ)
+func (r *TicketRepository) InstallmentDeposit(ctx context.Context, eventID, total int) (int, error) {
+	info, err := r.events.GetEventBookingInfo(ctx, eventID)
+	if err != nil {
+		return 0, apperrors.NotFound("Sự kiện")
+	}
+	_, deposit, err := installmentDeposit(ctx, r.db, eventID, info.StartTime, int64(total))
+	return int(deposit), err
+}
+
+// insertInstallmentSchedule - Ghi lịch trả góp trong transaction tạo Bill; kỳ 0 (cọc) đã trả bằng method / paymentRef
+func insertInstallmentSchedule(ctx context.Context, tx *sql.Tx, billID int64, plan billing.InstallmentPlan, total, deposit int64, eventStart time.Time, method, paymentRef string) error {
+	for _, inst := range plan.Schedule(total, deposit, time.Now(), installmentDeadline(eventStart)) {
+		var err error
+		if inst.Seq == 0 {
+			_, err = tx.ExecContext(ctx, `
+				INSERT INTO Bill_Installment (bill_id, seq, amount, due_at, status, paid_at, payment_method, payment_ref)
+				VALUES (?, 0, ?, ?, 'PAID', NOW(), ?, ?)
+			`, billID, inst.Amount, inst.DueAt, method, paymentRef)
+		} else {
+			_, err = tx.ExecContext(ctx,
+				"INSERT INTO Bill_Installment (bill_id, seq, amount, due_at) VALUES (?, ?, ?, ?)",
+				billID, inst.Seq, inst.Amount, inst.DueAt)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to create installment %d: %w", inst.Seq, err)
+		}
+	}
+	return nil
+}
+
+// ============================================================
+// Cấu hình trả góp của sự kiện (organizer của sự kiện hoặc quyền event.manage_any)
+// ============================================================
+
+// GetInstallmentPlan - Cấu hình trả góp (NotFound nếu sự kiện không cho trả góp)
+func (r *TicketRepository) GetInstallmentPlan(ctx context.Context, eventID int) (*models.InstallmentPlan, error) {
+	plan := models.InstallmentPlan{EventID: eventID}
+	err := r.db.QueryRowContext(ctx, `
+		SELECT deposit_percent, installment_count, interval_days, updated_at
+		FROM Event_Installment_Plan WHERE event_id = ?
+	`, eventID).Scan(&plan.DepositPercent, &plan.InstallmentCount, &plan.IntervalDays, &plan.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, apperrors.NotFound("Cấu hình trả góp")
+	}
+	if err != nil {
+		return nil, apperrors.DatabaseError(err)
+	}
+	return &plan, nil
+}
+
+// SaveInstallmentPlan - Bật / sửa trả góp cho sự kiện (hóa đơn đã tạo giữ lịch cũ)
+func (r *TicketRepository) SaveInstallmentPlan(ctx context.Context, userID int, role string, eventID int, req models.InstallmentPlanRequest) (*models.InstallmentPlan, error) {
+	if err := r.requireEditDetails(ctx, userID, role, eventID); err != nil {
+		return nil, err
+	}
+	if _, err := r.db.ExecContext(ctx, `
+		INSERT INTO Event_Installment_Plan (event_id, deposit_percent, installment_count, interval_days, updated_by)
+		VALUES (?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE deposit_percent = VALUES(deposit_percent), installment_count = VALUES(installment_count),
+			interval_days = VALUES(interval_days), updated_by = VALUES(updated_by)
+	`, eventID, req.DepositPercent, req.InstallmentCount, req.IntervalDays, userID); err != nil {
+		return nil, apperrors.DatabaseError(err)
+	}
+	return r.GetInstallmentPlan(ctx, eventID)
+}
+
+// DeleteInstallmentPlan - Tắt trả góp cho lượt mua mới (hóa đơn đang trả góp không đổi)
+func (r *TicketRepository) DeleteInstallmentPlan(ctx context.Context, userID int, role string, eventID int) error {
+	if err := r.requireEditDetails(ctx, userID, role, eventID); err != nil {
+		return err
+	}
+	result, err := r.db.ExecContext(ctx, "DELETE FROM Event_Installment_Plan WHERE event_id = ?", eventID)
+	if err != nil {
+		return apperrors.DatabaseError(err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return apperrors.NotFound("Cấu hình trả góp")
+	}
+	return nil
+}
+
+// requireEditDetails - ADMIN (event.manage_any), chủ sự kiện hoặc co-organizer có quyền EDIT_DETAILS
+func (r *TicketRepository) requireEditDetails(ctx context.Context, userID int, role string, eventID int) error {
+	if _, err := r.events.GetEventBookingInfo(ctx, eventID); errors.Is(err, eventclient.ErrEventNotFound) {
+		return apperrors.NotFound("Sự kiện")
+	} else if err != nil {
+		return apperrors.DatabaseError(err)
+	}
+	if permission.RoleHas(ctx, role, permission.EventManageAny) {
+		return nil
+	}
+	allowed, err := r.events.CheckEventPermission(ctx, eventID, userID, eventModels.PermissionEditDetails)
+	if err != nil {
+		return apperrors.DatabaseError(err)
+	}
+	if !allowed {
+		return apperrors.AccessDenied()
+	}
+	return nil
+}
+
+// ============================================================
+// GetBillInstallments - Lịch trả góp của hóa đơn của chính user
+// Hóa đơn không tồn tại / của người khác / không trả góp đều trả NotFound
+// ============================================================
+func (r *TicketRepository) GetBillInstallments(ctx context.Context, billID, userID int) (*models.BillInstallments, error) {
+	result := models.BillInstallments{BillID: billID, Installments: []models.BillInstallment{}}
+	err := r.db.QueryRowContext(ctx, `
+		SELECT b.payment_status, b.total_amount,
+		       EXISTS (SELECT 1 FROM Ticket t WHERE t.bill_id = b.bill_id AND t.suspended_at IS NOT NULL)
+		FROM Bill b WHERE b.bill_id = ? AND b.user_id = ?
+	`, billID, userID).Scan(&result.PaymentStatus, &result.TotalAmount, &result.Suspended)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, apperrors.NotFound("Hóa đơn")
+	}
+	if err != nil {
+		return nil, apperrors.DatabaseError(err)
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT seq, amount, due_at, status, paid_at, payment_method
+		FROM Bill_Installment WHERE bill_id = ? ORDER BY seq
+	`, billID)
+	if err != nil {
+		return nil, apperrors.DatabaseError(err)
+	}
+	defer rows.Close()
+	now := time.Now()
+	for rows.Next() {
+		var inst models.BillInstallment
+		var paidAt sql.NullTime
+		var method sql.NullString
+		if err := rows.Scan(&inst.Seq, &inst.Amount, &inst.DueAt, &inst.Status, &paidAt, &method); err != nil {
+			return nil, apperrors.DatabaseError(err)
+		}
+		if paidAt.Valid {
+			inst.PaidAt = &paidAt.Time
+		}
+		if method.Valid {
+			inst.PaymentMethod = &method.String
+		}
+		if inst.Status == "PAID" {
+			result.PaidAmount += inst.Amount
+		} else {
+			inst.Overdue = inst.DueAt.Before(now)
+			result.Remaining += inst.Amount
+		}
+		result.Installments = append(result.Installments, inst)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, apperrors.DatabaseError(err)
+	}
+	if len(result.Installments) == 0 {
+		return nil, apperrors.NotFound("Lịch trả góp")
+	}
+	return &result, nil
+}
+
+// installmentDue - Kỳ trả góp đang chờ thanh toán
+type installmentDue struct {
+	InstallmentID int
+	BillID        int
+	UserID        int
+	Seq           int
+	Amount        float64
+}
+
+// installmentForPayment - Kỳ seq của hóa đơn của user, chưa trả và các kỳ trước đã trả
+// (lock = khóa dòng Bill / Bill_Installment đến hết transaction)
+func installmentForPayment(ctx context.Context, q queryRower, billID, seq, userID int, lock bool) (*installmentDue, error) {
+	query := `
+		SELECT bi.installment_id, bi.amount, bi.status, b.payment_status,
+		       (SELECT COUNT(*) FROM Bill_Installment p WHERE p.bill_id = bi.bill_id AND p.seq < bi.seq AND p.status = 'PENDING')
+		FROM Bill_Installment bi
+		JOIN Bill b ON b.bill_id = bi.bill_id
+		WHERE bi.bill_id = ? AND bi.seq = ? AND b.user_id = ?`
+	if lock {
+		query += ` FOR UPDATE`
+	}
+	inst := installmentDue{BillID: billID, UserID: userID, Seq: seq}
+	var status, billStatus string
+	var earlierPending int
+	err := q.QueryRowContext(ctx, query, billID, seq, userID).Scan(&inst.InstallmentID, &inst.Amount, &status, &billStatus, &earlierPending)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, apperrors.NotFound("Kỳ trả góp")
+	}
+	if err != nil {
+		return nil, apperrors.DatabaseError(err)
+	}
+	if status == "PAID" {
+		return nil, apperrors.BusinessError("Kỳ trả góp đã được thanh toán")
+	}
+	if billStatus != "PARTIAL" {
+		return nil, apperrors.BusinessError("Hóa đơn không còn ở trạng thái trả góp")
+	}
+	if earlierPending > 0 {
+		return nil, apperrors.BusinessError("Vui lòng thanh toán các kỳ trước")
+	}
+	return &inst, nil
+}
+
+// markInstallmentPaid - Ghi kỳ đã trả
+func markInstallmentPaid(ctx context.Context, tx *sql.Tx, installmentID int, method, paymentRef string) error {
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE Bill_Installment SET status = 'PAID', paid_at = NOW(), payment_method = ?, payment_ref = ?
+		WHERE installment_id = ?
+	`, method, paymentRef, installmentID); err != nil {
+		return fmt.Errorf("failed to mark installment paid: %w", err)
+	}
+	return nil
+}
+
+// settleInstallmentBill - Sau mỗi kỳ: hết kỳ quá hạn thì mở khóa vé; trả đủ thì Bill → PAID
+// và ghi biên lai vào outbox (trả về id message, 0 nếu chưa trả đủ)
+func settleInstallmentBill(ctx context.Context, tx *sql.Tx, billID int) (int64, error) {
+	var pending, overdue int
+	if err := tx.QueryRowContext(ctx, `
+		SELECT COUNT(*), COALESCE(SUM(due_at <= NOW()), 0)
+		FROM Bill_Installment WHERE bill_id = ? AND status = 'PENDING'
+	`, billID).Scan(&pending, &overdue); err != nil {
+		return 0, fmt.Errorf("failed to count pending installments: %w", err)
+	}
+	if overdue == 0 {
+		if _, err := tx.ExecContext(ctx,
+			"UPDATE Ticket SET suspended_at = NULL WHERE bill_id = ? AND suspended_at IS NOT NULL", billID,
+		); err != nil {
+			return 0, fmt.Errorf("failed to lift ticket suspension: %w", err)
+		}
+	}
+	if pending > 0 {
+		return 0, nil
+	}
+	if _, err := tx.ExecContext(ctx,
+		"UPDATE Bill SET payment_status = 'PAID', paid_at = NOW() WHERE bill_id = ?", billID,
+	); err != nil {
+		return 0, fmt.Errorf("failed to settle bill: %w", err)
+	}
+	return enqueueReceiptEmail(ctx, tx, billID)
+}
+
+// ============================================================
+// PayInstallmentWithWallet - Trả một kỳ bằng ví
+// Khóa kỳ + số dư ví, trừ ví, ghi bút toán BILL_INSTALLMENT, đánh dấu kỳ đã trả rồi tất toán hóa đơn
+// ============================================================
+func (r *TicketRepository) PayInstallmentWithWallet(ctx context.Context, userID, billID, seq int) (*models.BillInstallments, error) {
+	log := logger.Default().WithContext(ctx)
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, apperrors.DatabaseError(err)
+	}
+	defer tx.Rollback()
+
+	inst, err := installmentForPayment(ctx, tx, billID, seq, userID, true)
+	if err != nil {
+		return nil, err
+	}
+	var balance float64
+	err = tx.QueryRowContext(ctx, `SELECT COALESCE(Wallet, 0) FROM users WHERE user_id = ? FOR UPDATE`, userID).Scan(&balance)
+	if err != nil {
+		return nil, apperrors.DatabaseError(err)
+	}
+	if balance < inst.Amount {
+		return nil, ErrInstallmentInsufficientBalance
+	}
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE users SET Wallet = Wallet - ? WHERE user_id = ? AND Wallet >= ?`, inst.Amount, userID, inst.Amount,
+	); err != nil {
+		return nil, apperrors.DatabaseError(err)
+	}
+
+	// Sổ cái: Nợ USER_WALLET / Có PLATFORM_REVENUE
+	entryID, err := ledger.Post(ctx, tx, ledger.InstallmentPayment(inst.InstallmentID, billID, userID, "Wallet", inst.Amount))
+	if err != nil {
+		return nil, apperrors.DatabaseError(err)
+	}
+	if err := markInstallmentPaid(ctx, tx, inst.InstallmentID, "Wallet", fmt.Sprintf("WALLET-%d", entryID)); err != nil {
+		return nil, apperrors.DatabaseError(err)
+	}
+	receiptMessageID, err := settleInstallmentBill(ctx, tx, billID)
+	if err != nil {
+		return nil, apperrors.DatabaseError(err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, apperrors.DatabaseError(err)
+	}
+	log.Info("Installment paid", "bill_id", billID, "seq", seq, "user_id", userID, "method", "Wallet")
+
+	if receiptMessageID > 0 {
+		go outbox.Default().Deliver(context.WithoutCancel(ctx), receiptMessageID)
+	}
+	return r.GetBillInstallments(ctx, billID, userID)
+}
+
+// CreateInstallmentVNPayURL - Link VNPay trả một kỳ; kỳ chỉ được ghi khi callback thành công
+func (r *TicketRepository) CreateInstallmentVNPayURL(ctx context.Context, userID, billID, seq int) (string, error) {
+	inst, err := installmentForPayment(ctx, r.db, billID, seq, userID, false)
+	if err != nil {
+		return "", err
+	}
+	txnRef := fmt.Sprintf("%s%d_%d", installmentTxnPrefix, inst.InstallmentID, time.Now().UnixMilli())
+	paymentURL, err := getVNPayService().CreatePaymentURL(vnpay.PaymentRequest{
+		OrderInfo: fmt.Sprintf("Installment %d of bill %d", seq, billID),
+		Amount:    inst.Amount,
+		TxnRef:    txnRef,
+		IPAddr:    "127.0.0.1",
+	})
+	if err != nil {
+		logger.Default().WithContext(ctx).Error("Failed to create VNPay URL for installment", "bill_id", billID, "seq", seq, "error", err)
+		return "", apperrors.VNPayError("Không thể tạo link thanh toán")
+	}
+	return paymentURL, nil
+}
+
+// processInstallmentVNPayCallback - Phần trả góp của ProcessVNPayCallback (chữ ký đã được kiểm tra)
+// Trả về bill_id; chống trùng qua Payment_Transaction như mua vé
+func (r *TicketRepository) processInstallmentVNPayCallback(ctx context.Context, callback *vnpay.PaymentResponse) (string, error) {
+	log := logger.Default().WithContext(ctx)
+	txnRef := callback.TxnRef
+
+	// INST_installmentID_timestamp
+	parts := strings.Split(strings.TrimPrefix(txnRef, installmentTxnPrefix), "_")
+	if len(parts) != 2 {
+		return "Invalid transaction reference format", fmt.Errorf("invalid installment txn ref: %s", txnRef)
+	}
+	installmentID, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return "Invalid installment in txn ref", err
+	}
+
+	if result, ok, err := r.processedPayment(ctx, txnRef); err != nil {
+		log.Warn("Payment dedup lookup failed, relying on unique txn_ref", "txn_ref", txnRef, "error", err)
+	} else if ok {
+		return result, nil
+	}
+	if callback.ResponseCode != "00" {
+		log.Warn("Installment payment failed/cancelled", "txn_ref", txnRef, "response_code", callback.ResponseCode)
+		return "Payment was cancelled or failed. Response code: " + callback.ResponseCode, apperrors.PaymentFailed(callback.ResponseCode)
+	}
+
+	var billID, userID, seq int
+	err = r.db.QueryRowContext(ctx, `
+		SELECT bi.bill_id, b.user_id, bi.seq FROM Bill_Installment bi JOIN Bill b ON b.bill_id = bi.bill_id
+		WHERE bi.installment_id = ?
+	`, installmentID).Scan(&billID, &userID, &seq)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "Installment not found", apperrors.NotFound("Kỳ trả góp")
+	}
+	if err != nil {
+		return "Database error", err
+	}
+	amountFromVNPay, err := strconv.ParseFloat(callback.Amount, 64)
+	if err != nil {
+		return "Invalid amount format", err
+	}
+	paidAmount := amountFromVNPay / 100
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return "Database error", err
+	}
+	defer tx.Rollback()
+
+	if err := claimPaymentTx(ctx, tx, txnRef, userID, paidAmount, callback.ResponseCode); err != nil {
+		if !errors.Is(err, errPaymentAlreadyProcessed) {
+			return "Database error", err
+		}
+		tx.Rollback()
+		result, _, lookupErr := r.processedPayment(ctx, txnRef)
+		if lookupErr != nil {
+			return "Database error", lookupErr
+		}
+		return result, nil
+	}
+
+	if _, err := installmentForPayment(ctx, tx, billID, seq, userID, true); err != nil {
+		// Kỳ vừa được trả bằng ví trong lúc chờ VNPay: tiền VNPay cần hoàn thủ công
+		log.Error("Installment paid via VNPay is no longer payable, manual refund required",
+			"txn_ref", txnRef, "bill_id", billID, "seq", seq, "amount", paidAmount, "error", err)
+		return "Installment is no longer payable", err
+	}
+
+	// Sổ cái: Nợ VNPAY_CLEARING / Có PLATFORM_REVENUE
+	if _, err := ledger.Post(ctx, tx, ledger.InstallmentPayment(installmentID, billID, userID, "VNPAY", paidAmount)); err != nil {
+		return "Failed to post installment to ledger", err
+	}
+	paymentRef := callback.TransactionNo
+	if paymentRef == "" {
+		paymentRef = txnRef
+	}
+	if err := markInstallmentPaid(ctx, tx, installmentID, "VNPAY", paymentRef); err != nil {
+		return "Database error", err
+	}
+	receiptMessageID, err := settleInstallmentBill(ctx, tx, billID)
+	if err != nil {
+		return "Database error", err
+	}
+	result := strconv.Itoa(billID)
+	if err := completePaymentTx(ctx, tx, txnRef, int64(billID), result); err != nil {
+		return "Database error", err
+	}
+	if err := tx.Commit(); err != nil {
+		return "Failed to commit transaction", err
+	}
+	log.Info("Installment paid", "bill_id", billID, "seq", seq, "user_id", userID, "method", "VNPAY")
+
+	if receiptMessageID > 0 {
+		go outbox.Default().Deliver(context.WithoutCancel(ctx), receiptMessageID)
+	}
+	return result, nil
+}
+
+// ============================================================
+// RemindInstallments - Job installment-reminder
+// 1. Kỳ đến hạn trong remindBefore, chưa nhắc: gửi email nhắc (reminded_at)
+// 2. Hóa đơn có kỳ quá hạn và vé chưa khóa: khóa vé BOOKED (suspended_at) và gửi email báo quá hạn
+// ============================================================
+func (r *TicketRepository) RemindInstallments(ctx context.Context, remindBefore time.Duration) (reminded, suspended int, err error) {
+	log := logger.Default().WithContext(ctx)
+
+	dueSoon, err := r.installmentNotices(ctx, `
+		AND bi.seq > 0 AND bi.reminded_at IS NULL AND bi.due_at > NOW() AND bi.due_at <= ?`,
+		time.Now().Add(remindBefore))
+	if err != nil {
+		return 0, 0, err
+	}
+	for _, n := range dueSoon {
+		r.sendInstallmentNotice(ctx, n, false)
+		reminded++
+	}
+
+	// Mỗi hóa đơn một dòng: kỳ chưa trả sớm nhất (các kỳ phải trả theo thứ tự)
+	overdue, err := r.installmentNotices(ctx, `
+		AND bi.due_at <= NOW()
+		AND bi.seq = (SELECT MIN(o.seq) FROM Bill_Installment o WHERE o.bill_id = bi.bill_id AND o.status = 'PENDING')
+		AND EXISTS (SELECT 1 FROM Ticket t WHERE t.bill_id = bi.bill_id AND t.status = 'BOOKED' AND t.suspended_at IS NULL)`)
+	if err != nil {
+		return reminded, 0, err
+	}
+	for _, n := range overdue {
+		result, err := r.db.ExecContext(ctx,
+			"UPDATE Ticket SET suspended_at = NOW() WHERE bill_id = ? AND status = 'BOOKED' AND suspended_at IS NULL", n.BillID)
+		if err != nil {
+			log.Error("Failed to suspend tickets of overdue bill", "bill_id", n.BillID, "error", err)
+			continue
+		}
+		if rows, _ := result.RowsAffected(); rows == 0 {
+			continue
+		}
+		log.Warn("Tickets suspended for overdue installment", "bill_id", n.BillID, "seq", n.Seq, "due_at", n.DueAt)
+		r.sendInstallmentNotice(ctx, n, true)
+		suspended++
+	}
+	return reminded, suspended, nil
+}
+
+// installmentNotice - Một kỳ cần nhắc kèm thông tin email
+type installmentNotice struct {
+	InstallmentID int
+	email.InstallmentEmailData
+}
+
+func (r *TicketRepository) installmentNotices(ctx context.Context, where string, args ...any) ([]installmentNotice, error) {
+	rows, err := r.db.QueryContext(ctx, installmentNoticeSQL+where, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query installments: %w", err)
+	}
+	defer rows.Close()
+	notices := []installmentNotice{}
+	for rows.Next() {
+		var n installmentNotice
+		var amount, remaining float64
+		if err := rows.Scan(&n.InstallmentID, &n.BillID, &n.Seq, &amount, &n.DueAt, &n.UserEmail, &n.UserName,
+			&n.EventTitle, &remaining); err != nil {
+			return nil, fmt.Errorf("failed to scan installment: %w", err)
+		}
+		n.Amount, n.Remaining = ledger.Dong(amount), ledger.Dong(remaining)
+		n.PayURL = installmentPayURL(n.BillID)
+		notices = append(notices, n)
+	}
+	return notices, rows.Err()
+}
+
+// sendInstallmentNotice - Gửi email nhắc / quá hạn qua Email_Queue rồi ghi reminded_at
+// Lỗi gửi chỉ ghi log (hàng đợi tự gửi lại)
+func (r *TicketRepository) sendInstallmentNotice(ctx context.Context, n installmentNotice, overdue bool) {
+	log := logger.Default().WithContext(ctx)
+	n.Overdue = overdue
+	msg := email.NewEmailService(nil).BuildInstallmentEmail(n.InstallmentEmailData)
+	if err := email.DefaultQueue().Send(ctx, email.TemplateInstallment, msg, fmt.Sprintf("installment#%d", n.InstallmentID)); err != nil {
+		log.Error("Failed to send installment reminder", "installment_id", n.InstallmentID, "error", err)
+	}
+	if _, err := r.db.ExecContext(ctx, "UPDATE Bill_Installment SET reminded_at = NOW() WHERE installment_id = ?", n.InstallmentID); err != nil {
+		log.Error("Failed to record installment reminder", "installment_id", n.InstallmentID, "error", err)
+	}
+}
@@ -0,0 +1,201 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// ============================================================
+// Category_Ticket_Inventory - Bộ đếm vé đã bán theo loại vé
+// sold = số vé PENDING / BOOKED / CHECKED_IN / CHECKED_OUT (inventoryActiveStatuses)
+// Tăng bằng một câu UPDATE có điều kiện trong cùng transaction với INSERT Ticket,
+// giảm khi vé rời khỏi các trạng thái trên (xóa PENDING, hết hạn, hoàn tiền)
+// Job inventory-reconcile đếm lại mỗi đêm nếu bộ đếm lệch
+// ============================================================
+
+// errInventoryExhausted - Loại vé không còn đủ số lượng (sold + qty > max_quantity)
+var errInventoryExhausted = errors.New("category ticket sold out")
+
+// inventoryActiveStatuses - Trạng thái vé chiếm một suất của loại vé
+const inventoryActiveStatuses = `('PENDING', 'BOOKED', 'CHECKED_IN', 'CHECKED_OUT')`
+
+// countSoldSQL - Đếm lại từ bảng Ticket, dùng khi khởi tạo bộ đếm và khi đối soát
+const countSoldSQL = `SELECT COUNT(*) FROM Ticket WHERE category_ticket_id = ? AND status IN ` + inventoryActiveStatuses
+
+// reserveInventory - Giữ qty suất của loại vé, trả về errInventoryExhausted nếu không đủ
+// Phải gọi trong transaction tạo vé: dòng bộ đếm bị khóa đến khi commit/rollback
+// Loại vé chưa có dòng bộ đếm (mới tạo ở event-lambda) được khởi tạo từ COUNT(*) một lần
+func reserveInventory(ctx context.Context, exec execer, categoryTicketID, qty int) error {
+	for attempt := 0; attempt < 2; attempt++ {
+		result, err := exec.ExecContext(ctx, `
+			UPDATE Category_Ticket_Inventory i
+			JOIN Category_Ticket ct ON ct.category_ticket_id = i.category_ticket_id
+			SET i.sold = i.sold + ?
+			WHERE i.category_ticket_id = ? AND i.sold + ? <= ct.max_quantity
+		`, qty, categoryTicketID, qty)
+		if err != nil {
+			return fmt.Errorf("failed to reserve inventory: %w", err)
+		}
+		if n, _ := result.RowsAffected(); n > 0 {
+			return nil
+		}
+		if attempt > 0 {
+			break
+		}
+
+		created, err := exec.ExecContext(ctx, `
+			INSERT IGNORE INTO Category_Ticket_Inventory (category_ticket_id, sold)
+			SELECT ct.category_ticket_id, (`+countSoldSQL+`)
+			FROM Category_Ticket ct WHERE ct.category_ticket_id = ?
+		`, categoryTicketID, categoryTicketID)
+		if err != nil {
+			return fmt.Errorf("failed to init inventory: %w", err)
+		}
+		if n, _ := created.RowsAffected(); n == 0 {
+			// Đã có dòng bộ đếm (hoặc loại vé không tồn tại): thực sự hết vé
+			break
+		}
+	}
+	return errInventoryExhausted
+}
+
+// releaseTicketInventory - Trả lại suất của vé nếu vé đang ở trạng thái status
+// Gọi TRƯỚC câu DELETE / UPDATE status trong cùng transaction (sau đó không còn biết loại vé)
+func releaseTicketInventory(ctx context.Context, exec execer, ticketID int, status string) error {
+	_, err := exec.ExecContext(ctx, `
+		UPDATE Category_Ticket_Inventory i
+		JOIN Ticket t ON t.category_ticket_id = i.category_ticket_id
+		SET i.sold = GREATEST(i.sold - 1, 0)
+		WHERE t.ticket_id = ? AND t.status = ?
+	`, ticketID, status)
+	if err != nil {
+		return fmt.Errorf("failed to release inventory: %w", err)
+	}
+	return nil
+}
+
+// inventoryRemaining - Số suất còn lại của loại vé (đọc bộ đếm, chưa có thì đếm lại)
+func inventoryRemaining(ctx context.Context, q queryRower, categoryTicketID int) (int, error) {
+	var maxQty int
+	var sold sql.NullInt64
+	err := q.QueryRowContext(ctx, `
+		SELECT ct.max_quantity, i.sold
+		FROM Category_Ticket ct
+		LEFT JOIN Category_Ticket_Inventory i ON i.category_ticket_id = ct.category_ticket_id
+		WHERE ct.category_ticket_id = ?
+	`, categoryTicketID).Scan(&maxQty, &sold)
+	if err != nil {
+		return 0, err
+	}
+	if !sold.Valid {
+		if err := q.QueryRowContext(ctx, countSoldSQL, categoryTicketID).Scan(&sold.Int64); err != nil {
+			return 0, err
+		}
+	}
+	return max(maxQty-int(sold.Int64), 0), nil
+}
+
+// deletePendingTickets - Xóa vé PENDING (thanh toán thất bại / hủy) và trả lại suất
+// Vé đã chuyển BOOKED hoặc đã bị job dọn dẹp xóa thì bỏ qua
+func (r *TicketRepository) deletePendingTickets(ctx context.Context, ticketIDs []int) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, tid := range ticketIDs {
+		if err := releaseTicketInventory(ctx, tx, tid, "PENDING"); err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, "DELETE FROM Ticket WHERE ticket_id = ? AND status = 'PENDING'", tid); err != nil {
+			return fmt.Errorf("failed to delete pending ticket: %w", err)
+		}
+	}
+	return tx.Commit()
+}
+
+// InventoryDrift - Bộ đếm lệch so với số vé thực tế
+type InventoryDrift struct {
+	CategoryTicketID int
+	Counter          int
+	Actual           int
+}
+
+// ReconcileInventory - Đếm lại vé theo loại vé, sửa các bộ đếm bị lệch (job inventory-reconcile)
+// Mỗi loại vé khóa dòng bộ đếm trong transaction riêng nên không chặn lâu các lượt mua
+func (r *TicketRepository) ReconcileInventory(ctx context.Context) ([]InventoryDrift, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT category_ticket_id FROM Category_Ticket`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list category tickets: %w", err)
+	}
+	var ids []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	drifts := []InventoryDrift{}
+	for _, id := range ids {
+		if ctx.Err() != nil {
+			return drifts, ctx.Err()
+		}
+		drift, err := r.reconcileCategoryInventory(ctx, id)
+		if err != nil {
+			return drifts, err
+		}
+		if drift != nil {
+			drifts = append(drifts, *drift)
+		}
+	}
+	return drifts, nil
+}
+
+func (r *TicketRepository) reconcileCategoryInventory(ctx context.Context, categoryTicketID int) (*InventoryDrift, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Khóa bộ đếm trước khi đếm: các lượt mua đang chờ sẽ tăng sau khi sửa xong
+	counter := -1
+	err = tx.QueryRowContext(ctx,
+		`SELECT sold FROM Category_Ticket_Inventory WHERE category_ticket_id = ? FOR UPDATE`, categoryTicketID,
+	).Scan(&counter)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, fmt.Errorf("failed to lock inventory: %w", err)
+	}
+	var actual int
+	if err := tx.QueryRowContext(ctx, countSoldSQL, categoryTicketID).Scan(&actual); err != nil {
+		return nil, fmt.Errorf("failed to count tickets: %w", err)
+	}
+	if counter == actual {
+		return nil, nil
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO Category_Ticket_Inventory (category_ticket_id, sold) VALUES (?, ?)
+		ON DUPLICATE KEY UPDATE sold = VALUES(sold)
+	`, categoryTicketID, actual); err != nil {
+		return nil, fmt.Errorf("failed to fix inventory: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	if counter < 0 {
+		// Loại vé chưa có bộ đếm: chỉ khởi tạo, không tính là lệch
+		return nil, nil
+	}
+	return &InventoryDrift{CategoryTicketID: categoryTicketID, Counter: counter, Actual: actual}, nil
+}
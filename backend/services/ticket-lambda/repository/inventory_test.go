@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/fpt-event-services/common/db"
+)
+
+// TestReserveInventoryConcurrent - 50 goroutine cùng giữ 1 suất khi loại vé chỉ còn 3 suất
+// Bộ đếm không bao giờ vượt max_quantity. Cần MySQL đã chạy migration 022:
+//
+//	TEST_DB_SERVER, TEST_DB_NAME, TEST_DB_USER, TEST_DB_PASSWORD, TEST_CATEGORY_TICKET_ID
+func TestReserveInventoryConcurrent(t *testing.T) {
+	if os.Getenv("TEST_DB_NAME") == "" {
+		t.Skip("TEST_DB_NAME not set, skipping MySQL concurrency test")
+	}
+	categoryTicketID, err := strconv.Atoi(os.Getenv("TEST_CATEGORY_TICKET_ID"))
+	if err != nil {
+		t.Skip("TEST_CATEGORY_TICKET_ID not set, skipping MySQL concurrency test")
+	}
+	if err := db.InitDBWithConfig(db.Config{
+		Server:   os.Getenv("TEST_DB_SERVER"),
+		Port:     3306,
+		Database: os.Getenv("TEST_DB_NAME"),
+		User:     os.Getenv("TEST_DB_USER"),
+		Password: os.Getenv("TEST_DB_PASSWORD"),
+	}); err != nil {
+		t.Fatalf("InitDB: %v", err)
+	}
+	defer db.CloseDB()
+
+	ctx := context.Background()
+	conn := db.GetDB()
+
+	var maxQty, original int
+	if err := conn.QueryRowContext(ctx, `
+		SELECT ct.max_quantity, COALESCE(i.sold, 0)
+		FROM Category_Ticket ct
+		LEFT JOIN Category_Ticket_Inventory i ON i.category_ticket_id = ct.category_ticket_id
+		WHERE ct.category_ticket_id = ?
+	`, categoryTicketID).Scan(&maxQty, &original); err != nil {
+		t.Fatalf("read inventory: %v", err)
+	}
+	const free = 3
+	if maxQty < free {
+		t.Skipf("category ticket %d has max_quantity %d < %d", categoryTicketID, maxQty, free)
+	}
+	if _, err := conn.ExecContext(ctx, `
+		INSERT INTO Category_Ticket_Inventory (category_ticket_id, sold) VALUES (?, ?)
+		ON DUPLICATE KEY UPDATE sold = VALUES(sold)
+	`, categoryTicketID, maxQty-free); err != nil {
+		t.Fatalf("seed inventory: %v", err)
+	}
+	defer conn.ExecContext(ctx, `UPDATE Category_Ticket_Inventory SET sold = ? WHERE category_ticket_id = ?`, original, categoryTicketID)
+
+	const workers = 50
+	start := make(chan struct{})
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var reserved, exhausted int
+	var unexpected []error
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			err := reserveInventory(ctx, conn, categoryTicketID, 1)
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case err == nil:
+				reserved++
+			case errors.Is(err, errInventoryExhausted):
+				exhausted++
+			default:
+				unexpected = append(unexpected, err)
+			}
+		}()
+	}
+	close(start)
+	wg.Wait()
+
+	if len(unexpected) > 0 {
+		t.Fatalf("unexpected errors: %v", unexpected)
+	}
+	if reserved != free || exhausted != workers-free {
+		t.Fatalf("reserved %d, rejected %d; want %d reserved and %d rejected", reserved, exhausted, free, workers-free)
+	}
+	remaining, err := inventoryRemaining(ctx, conn, categoryTicketID)
+	if err != nil {
+		t.Fatalf("inventoryRemaining: %v", err)
+	}
+	if remaining != 0 {
+		t.Fatalf("remaining = %d, want 0", remaining)
+	}
+}
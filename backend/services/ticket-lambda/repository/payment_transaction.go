@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/fpt-event-services/common/db"
+)
+
+// ============================================================
+// Payment_Transaction - Chống xử lý trùng callback VNPay theo vnp_TxnRef
+// ProcessVNPayCallback:
+//  1. processedPayment: txnRef đã xử lý xong → trả lại kết quả cũ, không làm gì thêm
+//  2. claimPaymentTx: INSERT đầu tiên trong transaction đặt vé; callback đồng thời
+//     chờ khóa PRIMARY KEY rồi nhận errPaymentAlreadyProcessed
+//  3. completePaymentTx: điền bill_id + kết quả trước khi commit
+// ============================================================
+
+// errPaymentAlreadyProcessed - txnRef đã được một callback khác ghi nhận
+var errPaymentAlreadyProcessed = errors.New("payment already processed")
+
+// processedPayment trả về kết quả (ticket_id BOOKED, phân cách dấu phẩy) của lần xử lý trước
+func (r *TicketRepository) processedPayment(ctx context.Context, txnRef string) (string, bool, error) {
+	var result sql.NullString
+	err := r.db.QueryRowContext(ctx,
+		`SELECT result FROM Payment_Transaction WHERE txn_ref = ?`, txnRef,
+	).Scan(&result)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, fmt.Errorf("lookup payment transaction: %w", err)
+	}
+	return result.String, true, nil
+}
+
+// claimPaymentTx ghi nhận txnRef trong transaction đặt vé
+func claimPaymentTx(ctx context.Context, tx *sql.Tx, txnRef string, userID int, amount float64, responseCode string) error {
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO Payment_Transaction (txn_ref, provider, user_id, amount, response_code)
+		VALUES (?, 'VNPAY', ?, ?, ?)
+	`, txnRef, userID, amount, responseCode)
+	if db.IsDuplicateEntry(err) {
+		return errPaymentAlreadyProcessed
+	}
+	if err != nil {
+		return fmt.Errorf("record payment transaction: %w", err)
+	}
+	return nil
+}
+
+// completePaymentTx lưu Bill và kết quả để callback lặp lại trả về đúng như lần đầu
+func completePaymentTx(ctx context.Context, tx *sql.Tx, txnRef string, billID int64, result string) error {
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE Payment_Transaction SET bill_id = ?, result = ? WHERE txn_ref = ?`, billID, result, txnRef,
+	); err != nil {
+		return fmt.Errorf("complete payment transaction: %w", err)
+	}
+	return nil
+}
+
+// joinTicketIDs - "123,124,125" (kết quả trả về của ProcessVNPayCallback)
+func joinTicketIDs(ids []int) string {
+	parts := make([]string, len(ids))
+	for i, id := range ids {
+		parts[i] = strconv.Itoa(id)
+	}
+	return strings.Join(parts, ",")
+}
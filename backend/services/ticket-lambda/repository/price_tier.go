@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/fpt-event-services/services/ticket-lambda/models"
+)
+
+// ============================================================
+// Category_Ticket_Price_Tier - Bậc giá theo thời gian (cấu hình ở event-lambda)
+// Giá của một vé = bậc giá đang hiệu lực lúc mua, không có thì giá gốc Category_Ticket.price,
+// rồi cộng / trừ % riêng của ghế (Seat_Price, migration 048) nếu organizer có đặt
+// Báo giá, tạo link VNPay và thanh toán ví đều dùng seatPriceSQL nên luôn khớp nhau
+// ============================================================
+
+// activeTierFromSQL - Bậc giá đang hiệu lực của loại vé (alias ct), tính theo NOW() của DB
+const activeTierFromSQL = `FROM Category_Ticket_Price_Tier pt
+		WHERE pt.category_ticket_id = ct.category_ticket_id
+		  AND pt.valid_from <= NOW() AND (pt.valid_to IS NULL OR pt.valid_to > NOW())
+		ORDER BY pt.valid_from DESC, pt.tier_id DESC LIMIT 1`
+
+// activePriceSQL - Giá đang áp dụng của loại vé (alias ct)
+const activePriceSQL = `COALESCE((SELECT pt.price ` + activeTierFromSQL + `), ct.price, 0)`
+
+// seatAdjustSQL - % cộng / trừ riêng của ghế (alias s, ct), 0 nếu không có override
+const seatAdjustSQL = `COALESCE((SELECT sp.adjust_percent FROM Seat_Price sp
+		WHERE sp.category_ticket_id = ct.category_ticket_id AND sp.seat_id = s.seat_id), 0)`
+
+// seatPriceSQL - Giá của một ghế (alias s, ct): giá đang áp dụng của loại vé ± override, làm tròn đến đồng
+const seatPriceSQL = `ROUND(` + activePriceSQL + ` * (100 + ` + seatAdjustSQL + `) / 100, 0)`
+
+// activeTierNameSQL / activeTierEndSQL - Tên và thời điểm hết hiệu lực của bậc giá đang áp dụng (NULL = giá gốc)
+const (
+	activeTierNameSQL = `(SELECT pt.name ` + activeTierFromSQL + `)`
+	activeTierEndSQL  = `(SELECT pt.valid_to ` + activeTierFromSQL + `)`
+)
+
+// paidUnitPriceSQL - Giá đã trả cho một vé (alias t, ct, b): dòng Bill_Item của vé; bill cũ chưa có
+// dòng hóa đơn thì chia đều tổng bill cho số vé của bill. Vé không có bill (vé mời) trả về giá gốc
+const paidUnitPriceSQL = `COALESCE((SELECT bi.unit_price FROM Bill_Item bi WHERE bi.ticket_id = t.ticket_id LIMIT 1),
+	b.total_amount / NULLIF((SELECT COUNT(*) FROM Ticket tb WHERE tb.bill_id = b.bill_id), 0), ct.price, 0)`
+
+type queryRower interface {
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// listUpcomingPriceChanges - Bậc giá chưa tới giờ áp dụng của các loại vé trong sự kiện, theo category_ticket_id
+func (r *TicketRepository) listUpcomingPriceChanges(ctx context.Context, eventID int) (map[int][]models.PriceChange, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT pt.category_ticket_id, pt.name, pt.price, pt.valid_from, pt.valid_to
+		FROM Category_Ticket_Price_Tier pt
+		JOIN Category_Ticket ct ON ct.category_ticket_id = pt.category_ticket_id
+		WHERE ct.event_id = ? AND pt.valid_from > NOW()
+		ORDER BY pt.valid_from, pt.tier_id
+	`, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list upcoming price changes: %w", err)
+	}
+	defer rows.Close()
+
+	changes := map[int][]models.PriceChange{}
+	for rows.Next() {
+		var categoryTicketID int
+		var c models.PriceChange
+		if err := rows.Scan(&categoryTicketID, &c.Name, &c.Price, &c.ValidFrom, &c.ValidTo); err != nil {
+			return nil, err
+		}
+		changes[categoryTicketID] = append(changes[categoryTicketID], c)
+	}
+	return changes, rows.Err()
+}
+
+// categorySeatPrices - Giá từng ghế (seatPriceSQL) của các ghế thuộc loại vé, theo seat_id
+func categorySeatPrices(ctx context.Context, db *sql.DB, categoryTicketID int, seatIDs []int) (map[int]float64, error) {
+	prices := make(map[int]float64, len(seatIDs))
+	if len(seatIDs) == 0 {
+		return prices, nil
+	}
+	placeholders := make([]string, len(seatIDs))
+	args := make([]interface{}, 0, len(seatIDs)+1)
+	args = append(args, categoryTicketID)
+	for i, id := range seatIDs {
+		placeholders[i] = "?"
+		args = append(args, id)
+	}
+	rows, err := db.QueryContext(ctx, `
+		SELECT s.seat_id, `+seatPriceSQL+`
+		FROM Seat s
+		JOIN Category_Ticket ct ON ct.category_ticket_id = s.category_ticket_id
+		WHERE ct.category_ticket_id = ? AND s.seat_id IN (`+strings.Join(placeholders, ",")+`)
+	`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read seat prices: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var seatID int
+		var price float64
+		if err := rows.Scan(&seatID, &price); err != nil {
+			return nil, err
+		}
+		prices[seatID] = price
+	}
+	return prices, rows.Err()
+}
@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	apperrors "github.com/fpt-event-services/common/errors"
+)
+
+// GetTicketDynamicQR - Sự kiện của vé có bật QR động không (Event.dynamic_qr, migration 057)
+func (r *TicketRepository) GetTicketDynamicQR(ctx context.Context, ticketID int) (bool, error) {
+	var enabled bool
+	err := r.db.QueryRowContext(ctx, `
+		SELECT e.dynamic_qr FROM Ticket t JOIN Event e ON e.event_id = t.event_id WHERE t.ticket_id = ?
+	`, ticketID).Scan(&enabled)
+	if err == sql.ErrNoRows {
+		return false, apperrors.NotFound("Vé")
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to load dynamic QR setting: %w", err)
+	}
+	return enabled, nil
+}
@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	apperrors "github.com/fpt-event-services/common/errors"
+	"github.com/fpt-event-services/services/ticket-lambda/models"
+)
+
+// ============================================================
+// QuoteSeats - Báo giá các ghế đã chọn, KHÔNG tạo vé PENDING
+// Cùng điều kiện với CreatePaymentURL/ProcessWalletPayment:
+//   - sự kiện OPEN và chưa bắt đầu
+//   - ghế ACTIVE, thuộc loại vé của sự kiện
+//   - ghế chưa bị giữ/đặt (PENDING, BOOKED, CHECKED_IN)
+//   - giá theo bậc giá đang hiệu lực ± giá riêng của ghế (seatPriceSQL)
+//
+// Total lấy từ CalculateSeatsTotal để khớp số tiền sẽ bị trừ/ghi bill
+// ============================================================
+func (r *TicketRepository) QuoteSeats(ctx context.Context, eventID int, seatIDs []int) (*models.TicketQuote, error) {
+	quote := &models.TicketQuote{
+		EventID:  eventID,
+		Currency: "VND",
+		Seats:    []models.TicketQuoteSeat{},
+		Fees:     []models.TicketQuoteFee{},
+		Bookable: true,
+	}
+
+	eventInfo, err := r.events.GetEventBookingInfo(ctx, eventID)
+	if err != nil {
+		return nil, apperrors.NotFound("Sự kiện")
+	}
+	switch {
+	case eventInfo.Status != "OPEN":
+		quote.Bookable, quote.Reason = false, "Sự kiện không mở bán vé"
+	case !time.Now().Before(eventInfo.StartTime):
+		quote.Bookable, quote.Reason = false, "Sự kiện đã bắt đầu hoặc kết thúc"
+	}
+
+	placeholders := make([]string, len(seatIDs))
+	args := make([]interface{}, 0, len(seatIDs)+2)
+	args = append(args, eventID, eventID)
+	for i, id := range seatIDs {
+		placeholders[i] = "?"
+		args = append(args, id)
+	}
+
+	rows, err := r.db.QueryContext(ctx, fmt.Sprintf(`
+		SELECT s.seat_id, s.seat_code, s.status, ct.category_ticket_id, ct.name, `+seatPriceSQL+`, `+seatAdjustSQL+`, `+activeTierNameSQL+`, ct.status,
+		       EXISTS (SELECT 1 FROM Ticket t
+		               WHERE t.event_id = ? AND t.seat_id = s.seat_id
+		                 AND t.status IN ('PENDING', 'BOOKED', 'CHECKED_IN', 'CHECKED_OUT')) AS taken
+		FROM Seat s
+		JOIN Category_Ticket ct ON s.category_ticket_id = ct.category_ticket_id
+		WHERE ct.event_id = ? AND s.seat_id IN (%s)
+	`, strings.Join(placeholders, ",")), args...)
+	if err != nil {
+		return nil, apperrors.DatabaseError(err)
+	}
+	defer rows.Close()
+
+	found := make(map[int]models.TicketQuoteSeat, len(seatIDs))
+	for rows.Next() {
+		var line models.TicketQuoteSeat
+		var seatStatus, categoryStatus string
+		var taken bool
+		if err := rows.Scan(&line.SeatID, &line.SeatCode, &seatStatus, &line.CategoryTicketID, &line.CategoryName,
+			&line.Price, &line.SeatAdjustPercent, &line.PriceTier, &categoryStatus, &taken); err != nil {
+			return nil, apperrors.DatabaseError(err)
+		}
+		line.Available = true
+		switch {
+		case seatStatus != "ACTIVE":
+			line.Available, line.UnavailableReason = false, "Ghế không khả dụng"
+		case categoryStatus != "ACTIVE":
+			line.Available, line.UnavailableReason = false, "Loại vé đã ngừng bán"
+		case taken:
+			line.Available, line.UnavailableReason = false, "Ghế đã được người khác giữ/đặt"
+		}
+		found[line.SeatID] = line
+	}
+	if err := rows.Err(); err != nil {
+		return nil, apperrors.DatabaseError(err)
+	}
+
+	// Giữ đúng thứ tự client gửi lên
+	for _, id := range seatIDs {
+		line, ok := found[id]
+		if !ok {
+			return nil, apperrors.NotFound(fmt.Sprintf("Ghế ID %d của sự kiện", id))
+		}
+		if !line.Available && quote.Bookable {
+			quote.Bookable, quote.Reason = false, fmt.Sprintf("Ghế %s: %s", line.SeatCode, line.UnavailableReason)
+		}
+		quote.Seats = append(quote.Seats, line)
+		quote.Subtotal += line.Price
+	}
+
+	if err := r.checkCompanionSeats(ctx, seatIDs); err != nil {
+		appErr, ok := apperrors.AsAppError(err)
+		if !ok || appErr.Code != apperrors.ErrCodeBusinessRule {
+			return nil, err
+		}
+		if quote.Bookable {
+			quote.Bookable, quote.Reason = false, appErr.Message
+		}
+	}
+
+	// Hiện chưa thu phí dịch vụ và chưa có mã giảm giá: Total = tổng giá vé
+	total, err := r.CalculateSeatsTotal(ctx, eventID, seatIDs)
+	if err != nil {
+		return nil, apperrors.DatabaseError(err)
+	}
+	quote.Total = float64(total) + quote.FeeTotal - quote.Discount
+	return quote, nil
+}
@@ -0,0 +1,160 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/fpt-event-services/common/billing"
+	"github.com/fpt-event-services/common/email"
+	apperrors "github.com/fpt-event-services/common/errors"
+	"github.com/fpt-event-services/common/ledger"
+	"github.com/fpt-event-services/common/logger"
+	"github.com/fpt-event-services/common/outbox"
+	"github.com/fpt-event-services/common/rules"
+)
+
+// ============================================================
+// Biên lai thanh toán (email payment_receipt, migration 054)
+// Gửi riêng với email vé: phương thức, mã giao dịch, từng vé (Bill_Item) và dòng VAT.
+// Giá vé đã gồm VAT; thuế suất lưu trên Bill.vat_bps lúc thanh toán (NULL = rule.vat_bps hiện tại)
+// ProcessVNPayCallback / ProcessWalletPayment ghi TopicReceiptEmail vào outbox
+// trong transaction tạo Bill
+// ============================================================
+
+// TopicReceiptEmail - Gửi biên lai sau khi thanh toán vé thành công
+const TopicReceiptEmail = "bill.receipt.email"
+
+// receiptEmailPayload - Payload của TopicReceiptEmail
+type receiptEmailPayload struct {
+	BillID int `json:"billId"`
+}
+
+// enqueueReceiptEmail - Ghi việc gửi biên lai vào outbox (trong transaction tạo Bill)
+func enqueueReceiptEmail(ctx context.Context, tx *sql.Tx, billID int) (int64, error) {
+	return outbox.Enqueue(ctx, tx, outbox.Message{
+		Topic:     TopicReceiptEmail,
+		Payload:   receiptEmailPayload{BillID: billID},
+		Reference: fmt.Sprintf("receipt:bill#%d", billID),
+	})
+}
+
+// currentVATBps - Thuế suất VAT lưu lên Bill lúc thanh toán
+func currentVATBps(ctx context.Context) int {
+	return rules.Get(ctx).VATBps
+}
+
+// receiptMethodLabel - Tên phương thức thanh toán in trên biên lai
+func receiptMethodLabel(method string) string {
+	switch method {
+	case "VNPAY":
+		return "VNPay"
+	case "Wallet":
+		return "FPT Event wallet"
+	}
+	return method
+}
+
+// ============================================================
+// SendReceiptEmail - Dựng biên lai của hóa đơn và gửi qua Email_Queue
+// Lỗi gửi không trả về (hàng đợi tự gửi lại); chỉ lỗi đọc dữ liệu trả về
+// ============================================================
+func (r *TicketRepository) SendReceiptEmail(ctx context.Context, billID int) error {
+	log := logger.Default().WithContext(ctx)
+
+	data, err := r.loadReceipt(ctx, billID)
+	if err != nil {
+		return err
+	}
+	msg := email.NewEmailService(nil).BuildReceiptEmail(*data)
+	if err := email.DefaultQueue().Send(ctx, email.TemplateReceipt, msg, fmt.Sprintf("receipt:bill#%d", billID)); err != nil {
+		log.Error("Failed to send payment receipt", "bill_id", billID, "error", err)
+		return nil
+	}
+	log.Info("Payment receipt sent", "bill_id", billID, "item_count", len(data.Items))
+	return nil
+}
+
+// loadReceipt - Hóa đơn, người mua, mã giao dịch và các dòng vé của biên lai
+func (r *TicketRepository) loadReceipt(ctx context.Context, billID int) (*email.ReceiptEmailData, error) {
+	var data email.ReceiptEmailData
+	var total float64
+	var method string
+	var vatBps sql.NullInt64
+	err := r.db.QueryRowContext(ctx, `
+		SELECT u.email, u.full_name, b.total_amount, b.payment_method, COALESCE(b.paid_at, b.created_at),
+		       b.vat_bps, COALESCE(b.payment_ref, pt.txn_ref, ''), COALESCE(pt.txn_ref, '')
+		FROM Bill b
+		JOIN Users u ON u.user_id = b.user_id
+		LEFT JOIN Payment_Transaction pt ON pt.bill_id = b.bill_id
+		WHERE b.bill_id = ?
+		LIMIT 1
+	`, billID).Scan(&data.UserEmail, &data.UserName, &total, &method, &data.PaidAt,
+		&vatBps, &data.PaymentRef, &data.OrderRef)
+	if err == sql.ErrNoRows {
+		return nil, apperrors.NotFound("Hóa đơn")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load bill: %w", err)
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT bi.ticket_id, e.title, COALESCE(ct.name, ''), COALESCE(s.seat_code, ''), bi.unit_price
+		FROM Bill_Item bi
+		JOIN Ticket t ON t.ticket_id = bi.ticket_id
+		JOIN Event e ON e.event_id = bi.event_id
+		LEFT JOIN Category_Ticket ct ON ct.category_ticket_id = t.category_ticket_id
+		LEFT JOIN Seat s ON s.seat_id = t.seat_id
+		WHERE bi.bill_id = ?
+		ORDER BY bi.ticket_id
+	`, billID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load bill items: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var item email.ReceiptItem
+		var price float64
+		if err := rows.Scan(&item.TicketID, &item.EventTitle, &item.Category, &item.Seat, &price); err != nil {
+			return nil, fmt.Errorf("failed to scan bill item: %w", err)
+		}
+		item.UnitPrice = ledger.Dong(price)
+		data.Items = append(data.Items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to load bill items: %w", err)
+	}
+	// Hóa đơn mua vé học kỳ không có dòng vé
+	if len(data.Items) == 0 {
+		return nil, apperrors.BusinessError("Hóa đơn không có vé, không có biên lai để gửi")
+	}
+
+	data.BillID = billID
+	data.PaymentMethod = receiptMethodLabel(method)
+	data.VATBps = currentVATBps(ctx)
+	if vatBps.Valid {
+		data.VATBps = int(vatBps.Int64)
+	}
+	data.Total = ledger.Dong(total)
+	data.Subtotal, data.VATAmount = billing.VATBreakdown(data.Total, data.VATBps)
+	return &data, nil
+}
+
+// ============================================================
+// GetOwnedBillStatus - Trạng thái thanh toán của hóa đơn của chính user
+// Hóa đơn không tồn tại hoặc của người khác đều trả NotFound
+// ============================================================
+func (r *TicketRepository) GetOwnedBillStatus(ctx context.Context, billID, userID int) (string, error) {
+	var status string
+	err := r.db.QueryRowContext(ctx,
+		"SELECT payment_status FROM Bill WHERE bill_id = ? AND user_id = ?",
+		billID, userID,
+	).Scan(&status)
+	if err == sql.ErrNoRows {
+		return "", apperrors.NotFound("Hóa đơn")
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to load bill: %w", err)
+	}
+	return status, nil
+}
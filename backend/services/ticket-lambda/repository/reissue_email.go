@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	apperrors "github.com/fpt-event-services/common/errors"
+	"github.com/fpt-event-services/common/qrcode"
+)
+
+// ============================================================
+// ReissueTicketEmail - Gửi lại email vé điện tử (QR + PDF) cho chủ vé
+// Chỉ vé BOOKED / CHECKED_IN; chạy đồng bộ, email đi qua Email_Queue như lúc mua vé
+// ============================================================
+func (r *TicketRepository) ReissueTicketEmail(ctx context.Context, ticketID int) error {
+	var userID, eventID, categoryTicketID int
+	var seatID sql.NullInt64
+	var status, paymentMethod string
+	var price float64
+	var complimentary bool
+	err := r.db.QueryRowContext(ctx, `
+		SELECT t.user_id, t.event_id, t.category_ticket_id, t.seat_id, t.status, t.is_complimentary,
+		       `+paidUnitPriceSQL+`, COALESCE(b.payment_method, '')
+		FROM Ticket t
+		LEFT JOIN Category_Ticket ct ON ct.category_ticket_id = t.category_ticket_id
+		LEFT JOIN Bill b ON b.bill_id = t.bill_id
+		WHERE t.ticket_id = ?
+	`, ticketID).Scan(&userID, &eventID, &categoryTicketID, &seatID, &status, &complimentary, &price, &paymentMethod)
+	if err == sql.ErrNoRows {
+		return apperrors.NotFound("Vé")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load ticket: %w", err)
+	}
+	if status != "BOOKED" && status != "CHECKED_IN" {
+		return apperrors.BusinessError(fmt.Sprintf("Vé đang ở trạng thái %s, chỉ gửi lại được vé BOOKED/CHECKED_IN", status))
+	}
+
+	amount := fmt.Sprintf("%.0f", price)
+	if complimentary {
+		amount, paymentMethod = "0", "Vé mời"
+	}
+	r.sendTicketEmailAsync(ctx, userID, eventID, int(seatID.Int64), ticketID, amount, categoryTicketID, paymentMethod)
+	return nil
+}
+
+// ============================================================
+// GetOwnedTicketStatus - Trạng thái vé của chính user
+// Vé không tồn tại hoặc của người khác đều trả NotFound (không lộ ticket_id của người khác)
+// ============================================================
+func (r *TicketRepository) GetOwnedTicketStatus(ctx context.Context, ticketID, userID int) (string, error) {
+	var status string
+	err := r.db.QueryRowContext(ctx,
+		"SELECT status FROM Ticket WHERE ticket_id = ? AND user_id = ?",
+		ticketID, userID,
+	).Scan(&status)
+	if err == sql.ErrNoRows {
+		return "", apperrors.NotFound("Vé")
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to load ticket: %w", err)
+	}
+	return status, nil
+}
+
+// ============================================================
+// RegenerateTicketQR - Tạo lại QR (Base64 PNG) và lưu vào Ticket.qr_code_value
+// Sửa các vé còn PENDING_QR / QR hỏng trước khi gửi lại email
+// ============================================================
+func (r *TicketRepository) RegenerateTicketQR(ctx context.Context, ticketID int) error {
+	qrBase64, err := qrcode.GenerateTicketQRBase64(ticketID, 300)
+	if err != nil {
+		return fmt.Errorf("failed to generate QR: %w", err)
+	}
+	if _, err := r.db.ExecContext(ctx,
+		"UPDATE Ticket SET qr_code_value = ? WHERE ticket_id = ?",
+		qrBase64, ticketID,
+	); err != nil {
+		return fmt.Errorf("failed to update QR: %w", err)
+	}
+	return nil
+}
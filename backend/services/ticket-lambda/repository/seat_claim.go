@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/fpt-event-services/common/db"
+	"github.com/fpt-event-services/common/tickethistory"
+)
+
+// errSeatTaken - Ghế đã có vé đang hiệu lực (UQ_Ticket_Event_ActiveSeat chặn INSERT)
+var errSeatTaken = errors.New("seat already has an active ticket")
+
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+// ============================================================
+// insertSeatTicket - Tạo vé cho một ghế
+// Bước kiểm tra COUNT(*) trước đó có thể bị hai request cùng vượt qua;
+// khi đó DB trả lỗi duplicate key và hàm trả về errSeatTaken thay vì lỗi SQL
+// holdExpiresAt = nil với vé BOOKED; trạng thái đầu tiên được ghi vào lịch sử vé
+// ============================================================
+func insertSeatTicket(ctx context.Context, exec execer, userID, eventID, categoryTicketID, seatID int, status string, holdExpiresAt *time.Time) (int64, error) {
+	result, err := exec.ExecContext(ctx,
+		`INSERT INTO Ticket (user_id, event_id, category_ticket_id, seat_id, qr_code_value, status, created_at, hold_expires_at)
+		 VALUES (?, ?, ?, ?, 'PENDING_QR', ?, NOW(), ?)`,
+		userID, eventID, categoryTicketID, seatID, status, holdExpiresAt,
+	)
+	if err != nil {
+		if db.IsDuplicateEntry(err) {
+			return 0, errSeatTaken
+		}
+		return 0, err
+	}
+	ticketID, err := result.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+	if err := tickethistory.Create(ctx, exec, int(ticketID), status, nil); err != nil {
+		return 0, err
+	}
+	return ticketID, nil
+}
@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fpt-event-services/services/ticket-lambda/models"
+)
+
+// GetEventTicketCounts - Số vé của sự kiện theo trạng thái (sự kiện không có vé = toàn 0)
+func (r *TicketRepository) GetEventTicketCounts(ctx context.Context, eventID int) (*models.EventTicketCounts, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT status, COUNT(*) FROM Ticket WHERE event_id = ? GROUP BY status`, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count tickets: %w", err)
+	}
+	defer rows.Close()
+
+	counts := &models.EventTicketCounts{EventID: eventID}
+	for rows.Next() {
+		var status string
+		var n int
+		if err := rows.Scan(&status, &n); err != nil {
+			return nil, fmt.Errorf("failed to scan ticket count: %w", err)
+		}
+		switch status {
+		case "PENDING":
+			counts.Pending = n
+		case "BOOKED":
+			counts.Booked = n
+		case "CHECKED_IN":
+			counts.CheckedIn = n
+		case "CHECKED_OUT":
+			counts.CheckedOut = n
+		case "REFUNDED":
+			counts.Refunded = n
+		case "EXPIRED":
+			counts.Expired = n
+		}
+	}
+	return counts, rows.Err()
+}
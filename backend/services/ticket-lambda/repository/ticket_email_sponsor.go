@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/fpt-event-services/common/email"
+	"github.com/fpt-event-services/common/logger"
+	eventModels "github.com/fpt-event-services/services/event-lambda/models"
+)
+
+// goldSponsorLogos - Logo nhà tài trợ GOLD in trong email xác nhận vé
+// Lỗi đọc nhà tài trợ không chặn việc gửi vé: email gửi không kèm logo
+func (r *TicketRepository) goldSponsorLogos(ctx context.Context, eventID int) []email.SponsorLogo {
+	sponsors, err := r.events.GetEventSponsors(ctx, eventID)
+	if err != nil {
+		logger.Default().WithContext(ctx).Warn("Failed to load event sponsors for ticket email", "event_id", eventID, "error", err)
+		return nil
+	}
+	var logos []email.SponsorLogo
+	for _, s := range sponsors {
+		if s.Tier != eventModels.SponsorTierGold || s.LogoURL == nil {
+			continue
+		}
+		logo := email.SponsorLogo{Name: s.Name, LogoURL: *s.LogoURL}
+		if s.WebsiteURL != nil {
+			logo.WebsiteURL = *s.WebsiteURL
+		}
+		logos = append(logos, logo)
+	}
+	return logos
+}
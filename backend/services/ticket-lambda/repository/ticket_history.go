@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fpt-event-services/common/tickethistory"
+	"github.com/fpt-event-services/services/ticket-lambda/models"
+)
+
+// ============================================================
+// GetTicketHistoryByUserID - Vé đã mua của user kèm timeline trạng thái
+// Bỏ vé PENDING (đang giữ ghế, xem GET /api/registrations/holds)
+// Timeline lấy từ Ticket_Status_History; vé tạo trước migration 037 dựng từ các cột thời gian
+// ============================================================
+func (r *TicketRepository) GetTicketHistoryByUserID(ctx context.Context, userID int) ([]models.TicketWithTimeline, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT
+			t.ticket_id, t.qr_code_value, t.event_id, e.title, v.venue_name, e.start_time, e.end_time,
+			t.status, t.checkin_time, t.check_out_time, t.created_at,
+			ct.name, ct.price, s.seat_code
+		FROM Ticket t
+		LEFT JOIN Event e ON t.event_id = e.event_id
+		LEFT JOIN Category_Ticket ct ON t.category_ticket_id = ct.category_ticket_id
+		LEFT JOIN Seat s ON t.seat_id = s.seat_id
+		LEFT JOIN Venue_Area va ON e.area_id = va.area_id
+		LEFT JOIN Venue v ON va.venue_id = v.venue_id
+		WHERE t.user_id = ? AND t.status <> 'PENDING'
+		ORDER BY e.start_time, t.ticket_id
+	`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query ticket history: %w", err)
+	}
+	defer rows.Close()
+
+	var tickets []models.TicketWithTimeline
+	var ticketIDs []int
+	for rows.Next() {
+		var t models.TicketWithTimeline
+		if err := rows.Scan(
+			&t.TicketID, &t.TicketCode, &t.EventID, &t.EventName, &t.VenueName, &t.StartTime, &t.EndTime,
+			&t.Status, &t.CheckInTime, &t.CheckOutTime, &t.PurchaseDate,
+			&t.Category, &t.CategoryPrice, &t.SeatCode,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan ticket history: %w", err)
+		}
+		tickets = append(tickets, t)
+		ticketIDs = append(ticketIDs, t.TicketID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read ticket history: %w", err)
+	}
+
+	history, err := tickethistory.ForTickets(ctx, r.db, ticketIDs)
+	if err != nil {
+		return nil, err
+	}
+	for i := range tickets {
+		t := &tickets[i]
+		t.Timeline = tickethistory.Timeline(history[t.TicketID], tickethistory.Snapshot{
+			Status:       t.Status,
+			CreatedAt:    t.PurchaseDate,
+			CheckInTime:  t.CheckInTime,
+			CheckOutTime: t.CheckOutTime,
+		})
+	}
+	return tickets, nil
+}
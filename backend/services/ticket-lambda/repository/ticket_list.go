@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fpt-event-services/common/db"
+	"github.com/fpt-event-services/common/pagination"
+	"github.com/fpt-event-services/common/permission"
+	"github.com/fpt-event-services/services/ticket-lambda/models"
+)
+
+// ============================================================
+// Danh sách vé có bộ lọc cho STAFF/ADMIN/ORGANIZER (GET /api/tickets/list)
+// Điều kiện WHERE và ORDER BY được ghép từ TicketListFilter; mọi giá trị
+// người dùng nhập đi qua tham số "?", cột sắp xếp lấy từ danh sách cố định
+// ============================================================
+
+// ticketListScope - Phạm vi vé người gọi được xem (giống GetTicketsByRole)
+type ticketListScope struct {
+	ViewAll   bool // ticket.view_all: mọi vé
+	Organizer bool // ORGANIZER: vé của sự kiện mình tạo / được mời với quyền VIEW_STATS
+	UserID    int
+}
+
+// ticketListSortColumns - Khóa ?sort= → cột SQL
+var ticketListSortColumns = map[string]string{
+	"ticketId":     "t.ticket_id",
+	"purchaseDate": "t.created_at",
+	"checkInTime":  "t.checkin_time",
+	"buyerName":    "u.full_name",
+	"status":       "t.status",
+	"eventStart":   "e.start_time",
+}
+
+const ticketListFrom = `Ticket t
+		LEFT JOIN Event e ON t.event_id = e.event_id
+		LEFT JOIN Category_Ticket ct ON t.category_ticket_id = ct.category_ticket_id
+		LEFT JOIN Seat s ON t.seat_id = s.seat_id
+		LEFT JOIN Venue_Area va ON e.area_id = va.area_id
+		LEFT JOIN Venue v ON va.venue_id = v.venue_id
+		LEFT JOIN Users u ON t.user_id = u.user_id`
+
+// organizerTicketScopeSQL - Vé của sự kiện ORGANIZER tạo hoặc được mời với quyền VIEW_STATS (tham số: userID, userID)
+const organizerTicketScopeSQL = `e.created_by = ? OR EXISTS (
+			SELECT 1 FROM Event_Collaborator ec
+			WHERE ec.event_id = e.event_id AND ec.user_id = ? AND ec.status = 'ACCEPTED'
+			  AND FIND_IN_SET('VIEW_STATS', ec.permissions) > 0)`
+
+// ticketListQuery - Phạm vi + bộ lọc + sắp xếp cho TicketListFilter (chưa LIMIT)
+func ticketListQuery(scope ticketListScope, f models.TicketListFilter) *db.Query {
+	q := db.From(ticketListFrom)
+	switch {
+	case scope.ViewAll:
+	case scope.Organizer:
+		q.Where(organizerTicketScopeSQL, scope.UserID, scope.UserID)
+	default:
+		q.Where("t.user_id = ?", scope.UserID)
+	}
+
+	if f.EventID != nil {
+		q.Where("t.event_id = ?", *f.EventID)
+	}
+	q.WhereIf(f.Status != "", "t.status = ?", f.Status)
+	if f.CategoryTicketID != nil {
+		q.Where("t.category_ticket_id = ?", *f.CategoryTicketID)
+	}
+	if f.CheckedIn != nil {
+		q.WhereIf(*f.CheckedIn, "t.checkin_time IS NOT NULL").WhereIf(!*f.CheckedIn, "t.checkin_time IS NULL")
+	}
+	if f.Search != "" {
+		like := db.Contains(f.Search)
+		q.Where("u.full_name LIKE ? OR u.email LIKE ?", like, like)
+	}
+
+	// Thứ tự ổn định giữa các trang khi nhiều vé cùng giá trị
+	return q.OrderByAllowed(f.Sort, f.Desc, ticketListSortColumns, "t.ticket_id", "t.ticket_id")
+}
+
+// ListTickets - Một trang vé theo bộ lọc, trong phạm vi role của người gọi
+func (r *TicketRepository) ListTickets(ctx context.Context, role string, userID int, f models.TicketListFilter) (*models.PaginatedTicketsResponse, error) {
+	scope := ticketListScope{
+		ViewAll:   permission.RoleHas(ctx, role, permission.TicketViewAll),
+		Organizer: role == "ORGANIZER",
+		UserID:    userID,
+	}
+	q := ticketListQuery(scope, f)
+
+	var totalRecords int
+	countSQL, countArgs := q.Count()
+	if err := r.db.QueryRowContext(ctx, countSQL, countArgs...).Scan(&totalRecords); err != nil {
+		return nil, fmt.Errorf("failed to count tickets: %w", err)
+	}
+
+	query, args := q.Page(f.Page, f.Limit).Select(`
+			t.ticket_id, t.qr_code_value, e.title, v.venue_name, e.start_time,
+			t.status, t.checkin_time, t.check_out_time,
+			ct.name, ct.price, s.seat_code,
+			u.full_name, u.email, t.created_at`)
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tickets: %w", err)
+	}
+	defer rows.Close()
+
+	tickets := []models.MyTicketResponse{}
+	for rows.Next() {
+		var ticket models.MyTicketResponse
+		if err := rows.Scan(
+			&ticket.TicketID, &ticket.TicketCode, &ticket.EventName, &ticket.VenueName, &ticket.StartTime,
+			&ticket.Status, &ticket.CheckInTime, &ticket.CheckOutTime,
+			&ticket.Category, &ticket.CategoryPrice, &ticket.SeatCode,
+			&ticket.BuyerName, &ticket.BuyerEmail, &ticket.PurchaseDate,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan ticket: %w", err)
+		}
+		tickets = append(tickets, ticket)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read tickets: %w", err)
+	}
+
+	page := pagination.New(tickets, pagination.Params{Page: f.Page, PageSize: f.Limit}, totalRecords)
+	return &page, nil
+}
@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/fpt-event-services/services/ticket-lambda/models"
+)
+
+func TestTicketListQueryFilters(t *testing.T) {
+	eventID, categoryID, checkedIn := 7, 3, false
+	q := ticketListQuery(ticketListScope{Organizer: true, UserID: 42}, models.TicketListFilter{
+		EventID:          &eventID,
+		Status:           "BOOKED",
+		CategoryTicketID: &categoryID,
+		CheckedIn:        &checkedIn,
+		Search:           "50%_an",
+		Sort:             "buyerName",
+		Desc:             true,
+	})
+	where, args := q.WhereSQL()
+
+	for _, want := range []string{"e.created_by = ?", "t.event_id = ?", "t.status = ?", "t.category_ticket_id = ?", "t.checkin_time IS NULL", "u.email LIKE ?"} {
+		if !strings.Contains(where, want) {
+			t.Errorf("where missing %q: %s", want, where)
+		}
+	}
+	if strings.Count(where, "?") != len(args) {
+		t.Fatalf("%d placeholders, %d args", strings.Count(where, "?"), len(args))
+	}
+	if args[len(args)-1] != `%50\%\_an%` {
+		t.Errorf("search arg = %v, want escaped LIKE pattern", args[len(args)-1])
+	}
+	if query, _ := q.Select("t.ticket_id"); !strings.HasSuffix(query, " ORDER BY u.full_name DESC, t.ticket_id DESC") {
+		t.Errorf("query = %q", query)
+	}
+}
+
+func TestTicketListQueryScopeAndUnknownSort(t *testing.T) {
+	q := ticketListQuery(ticketListScope{ViewAll: true}, models.TicketListFilter{Sort: "t.ticket_id; DROP TABLE Ticket"})
+	if where, args := q.WhereSQL(); where != "" || len(args) != 0 {
+		t.Errorf("view_all without filters = %q %v", where, args)
+	}
+	if query, _ := q.Select("t.ticket_id"); !strings.HasSuffix(query, " ORDER BY t.ticket_id ASC") {
+		t.Errorf("unknown sort must fall back to ticket_id, got %q", query)
+	}
+
+	where, args := ticketListQuery(ticketListScope{UserID: 9}, models.TicketListFilter{}).WhereSQL()
+	if where != " WHERE (t.user_id = ?)" || len(args) != 1 || args[0] != 9 {
+		t.Errorf("buyer scope = %q %v", where, args)
+	}
+}
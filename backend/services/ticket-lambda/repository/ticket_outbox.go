@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/fpt-event-services/common/outbox"
+)
+
+// ============================================================
+// Outbox của thanh toán vé
+// ProcessVNPayCallback ghi việc gửi email vé vào Outbox_Message trong cùng transaction
+// tạo Bill / chuyển vé BOOKED: process chết ngay sau commit thì job "outbox" vẫn gửi
+// ============================================================
+
+// TopicTicketBookedEmail - Gửi email vé (kèm PDF) sau khi thanh toán VNPay thành công
+const TopicTicketBookedEmail = "ticket.booked.email"
+
+// ticketBookedEmailPayload - Payload của TopicTicketBookedEmail
+type ticketBookedEmailPayload struct {
+	UserID           int    `json:"userId"`
+	EventID          int    `json:"eventId"`
+	TicketIDs        []int  `json:"ticketIds"`
+	TotalAmount      string `json:"totalAmount"` // VND, đã chia 100 từ vnp_Amount
+	CategoryTicketID int    `json:"categoryTicketId"`
+	BillID           int    `json:"billId"`
+}
+
+// RegisterOutboxHandlers - Đăng ký handler cho các topic do ticket-lambda ghi vào outbox
+// Gọi sau khi đã kết nối DB
+func RegisterOutboxHandlers(o *outbox.Outbox) {
+	r := DefaultTicketRepository()
+	o.Handle(TopicTicketBookedEmail, func(ctx context.Context, payload json.RawMessage) error {
+		var p ticketBookedEmailPayload
+		if err := json.Unmarshal(payload, &p); err != nil {
+			return fmt.Errorf("decode %s payload: %w", TopicTicketBookedEmail, err)
+		}
+		return r.sendMultipleTicketEmails(ctx, p.UserID, p.EventID, p.TicketIDs, p.TotalAmount, p.CategoryTicketID, p.BillID)
+	})
+	o.Handle(TopicReceiptEmail, func(ctx context.Context, payload json.RawMessage) error {
+		var p receiptEmailPayload
+		if err := json.Unmarshal(payload, &p); err != nil {
+			return fmt.Errorf("decode %s payload: %w", TopicReceiptEmail, err)
+		}
+		return r.SendReceiptEmail(ctx, p.BillID)
+	})
+}
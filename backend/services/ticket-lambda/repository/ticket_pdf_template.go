@@ -0,0 +1,152 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fpt-event-services/common/logger"
+	ticketpdf "github.com/fpt-event-services/common/pdf"
+)
+
+// ============================================================
+// Vé PDF theo template của sự kiện (GET|PUT /api/events/{id}/ticket-template)
+// Template + ảnh (banner, logo BTC, logo nhà tài trợ) tải một lần cho mỗi lần gửi email,
+// dùng chung cho mọi vé. Lỗi tải template/ảnh không chặn việc gửi vé:
+// thiếu ảnh thì bỏ ảnh, không đọc được template thì dùng layout classic
+// ============================================================
+
+const (
+	// ticketAssetMaxBytes - Giới hạn dung lượng mỗi ảnh in trên vé
+	ticketAssetMaxBytes = 2 << 20
+
+	// ticketAssetsTimeout - Tổng thời gian tải ảnh cho một lần gửi
+	ticketAssetsTimeout = 10 * time.Second
+)
+
+var ticketAssetHTTPClient = &http.Client{Timeout: 5 * time.Second}
+
+// ticketPDFStyle - Template và ảnh đã tải của một sự kiện
+type ticketPDFStyle struct {
+	template      ticketpdf.TicketTemplate
+	banner        []byte
+	organizerLogo []byte
+	sponsorLogos  [][]byte
+}
+
+// loadTicketPDFStyle đọc template qua EventService và tải ảnh (chỉ khi layout cần)
+func (r *TicketRepository) loadTicketPDFStyle(ctx context.Context, eventID int) *ticketPDFStyle {
+	log := logger.Default().WithContext(ctx)
+	style := &ticketPDFStyle{template: ticketpdf.DefaultTemplate()}
+
+	cfg, err := r.events.GetTicketTemplate(ctx, eventID)
+	if err != nil {
+		log.Warn("Failed to load ticket template, using default", "event_id", eventID, "error", err)
+		return style
+	}
+	style.template = ticketpdf.TicketTemplate{
+		Layout:      cfg.Layout,
+		PageSize:    cfg.PageSize,
+		AccentColor: cfg.AccentColor,
+		FooterNote:  cfg.FooterNote,
+	}
+	if style.template.Layout != ticketpdf.LayoutBranded {
+		return style
+	}
+
+	fetchCtx, cancel := context.WithTimeout(ctx, ticketAssetsTimeout)
+	defer cancel()
+	fetch := func(kind, rawURL string) []byte {
+		if rawURL == "" {
+			return nil
+		}
+		data, err := fetchTicketAsset(fetchCtx, rawURL)
+		if err != nil {
+			log.Warn("Failed to fetch ticket image", "event_id", eventID, "kind", kind, "url", rawURL, "error", err)
+			return nil
+		}
+		return data
+	}
+
+	if cfg.ShowBanner {
+		style.banner = fetch("banner", cfg.BannerURL)
+	}
+	style.organizerLogo = fetch("organizer_logo", cfg.OrganizerLogoURL)
+	for _, sponsorURL := range cfg.SponsorLogoURLs {
+		if logo := fetch("sponsor_logo", sponsorURL); logo != nil {
+			style.sponsorLogos = append(style.sponsorLogos, logo)
+		}
+	}
+	return style
+}
+
+// render tạo PDF một vé theo template của sự kiện
+func (s *ticketPDFStyle) render(data ticketpdf.TicketPDFData) ([]byte, error) {
+	data.BannerImage = s.banner
+	data.OrganizerLogo = s.organizerLogo
+	data.SponsorLogos = s.sponsorLogos
+	return ticketpdf.GenerateTicketPDFWithTemplate(data, s.template)
+}
+
+// fetchTicketAsset đọc ảnh theo URL
+// URL tương đối của local storage (UPLOAD_BASE_URL, vd /uploads/...) đọc thẳng từ UPLOAD_DIR
+func fetchTicketAsset(ctx context.Context, rawURL string) ([]byte, error) {
+	if strings.HasPrefix(rawURL, "/") {
+		return readLocalUpload(rawURL)
+	}
+	if !strings.HasPrefix(rawURL, "http://") && !strings.HasPrefix(rawURL, "https://") {
+		return nil, fmt.Errorf("unsupported image url")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := ticketAssetHTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, ticketAssetMaxBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > ticketAssetMaxBytes {
+		return nil, fmt.Errorf("image larger than %dMB", ticketAssetMaxBytes>>20)
+	}
+	return data, nil
+}
+
+func readLocalUpload(urlPath string) ([]byte, error) {
+	baseURL := strings.TrimRight(os.Getenv("UPLOAD_BASE_URL"), "/")
+	if baseURL == "" {
+		baseURL = "/uploads"
+	}
+	key, ok := strings.CutPrefix(urlPath, baseURL+"/")
+	if !ok {
+		return nil, fmt.Errorf("not a local upload url")
+	}
+	dir := os.Getenv("UPLOAD_DIR")
+	if dir == "" {
+		dir = "uploads"
+	}
+	// Clean với "/" phía trước để key không thoát ra ngoài UPLOAD_DIR (../)
+	fullPath := filepath.Join(dir, filepath.FromSlash(path.Clean("/"+key)))
+	info, err := os.Stat(fullPath)
+	if err != nil {
+		return nil, err
+	}
+	if info.Size() > ticketAssetMaxBytes {
+		return nil, fmt.Errorf("image larger than %dMB", ticketAssetMaxBytes>>20)
+	}
+	return os.ReadFile(fullPath)
+}
@@ -4,39 +4,77 @@ import (
 	"context"
 	"database/sql"
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"net/url"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/fpt-event-services/common/billing"
 	"github.com/fpt-event-services/common/db"
 	"github.com/fpt-event-services/common/email"
 	apperrors "github.com/fpt-event-services/common/errors"
+	"github.com/fpt-event-services/common/eventbus"
+	"github.com/fpt-event-services/common/ledger"
 	"github.com/fpt-event-services/common/logger"
+	"github.com/fpt-event-services/common/outbox"
+	"github.com/fpt-event-services/common/pagination"
 	ticketpdf "github.com/fpt-event-services/common/pdf"
+	"github.com/fpt-event-services/common/permission"
 	"github.com/fpt-event-services/common/qrcode"
+	"github.com/fpt-event-services/common/rules"
+	"github.com/fpt-event-services/common/tickethistory"
+	"github.com/fpt-event-services/common/tracing"
 	"github.com/fpt-event-services/common/vnpay"
+	eventclient "github.com/fpt-event-services/services/event-lambda/client"
 	"github.com/fpt-event-services/services/ticket-lambda/models"
 )
 
 type TicketRepository struct {
-	db *sql.DB
+	db     *sql.DB
+	events eventclient.EventService // Dữ liệu Event đọc qua API nội bộ của event-lambda
 }
 
 func NewTicketRepository() *TicketRepository {
 	return &TicketRepository{
-		db: db.GetDB(),
+		db:     db.GetDB(),
+		events: eventclient.Default(),
 	}
 }
 
+// DefaultTicketRepository - Repository dùng chung của process
+var DefaultTicketRepository = sync.OnceValue(NewTicketRepository)
+
 // ============================================================
 // GetTicketsByUserID - Lấy danh sách vé của user
 // KHỚP VỚI Java: TicketDAO.getTicketsByUserId()
 // ============================================================
 func (r *TicketRepository) GetTicketsByUserID(ctx context.Context, userID int) ([]models.MyTicketResponse, error) {
-	query := `
-		SELECT 
+	query, args := db.From(ticketListFrom).Where("t.user_id = ?", userID).OrderBy("t.ticket_id DESC").Select(myTicketColumns)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tickets: %w", err)
+	}
+	defer rows.Close()
+
+	tickets := []models.MyTicketResponse{}
+	for rows.Next() {
+		ticket, err := scanMyTicket(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		tickets = append(tickets, ticket)
+	}
+
+	return tickets, nil
+}
+
+// myTicketColumns - Cột của MyTicketResponse theo thứ tự scanMyTicket (FROM ticketListFrom)
+const myTicketColumns = `
 			t.ticket_id,
 			t.qr_code_value,
 			e.title AS event_name,
@@ -49,172 +87,82 @@ func (r *TicketRepository) GetTicketsByUserID(ctx context.Context, userID int) (
 			ct.price AS category_price,
 			s.seat_code,
 			u.full_name AS buyer_name,
-			e.start_time AS purchase_date
+			e.start_time AS purchase_date`
+
+// scanMyTicket - Một dòng vé của GetTicketsByUserID / GetTicketsByUserIDPaginated / GetTicketsByRole
+// (ticket_id, mã QR, sự kiện, địa điểm, giờ bắt đầu, trạng thái, check-in/out, loại vé, giá, ghế, người mua, ngày mua)
+func scanMyTicket(rows *sql.Rows) (models.MyTicketResponse, error) {
+	var ticket models.MyTicketResponse
+	err := rows.Scan(
+		&ticket.TicketID, &ticket.TicketCode, &ticket.EventName, &ticket.VenueName, &ticket.StartTime,
+		&ticket.Status, &ticket.CheckInTime, &ticket.CheckOutTime,
+		&ticket.Category, &ticket.CategoryPrice, &ticket.SeatCode,
+		&ticket.BuyerName, &ticket.PurchaseDate,
+	)
+	if err != nil {
+		return ticket, fmt.Errorf("failed to scan ticket: %w", err)
+	}
+	return ticket, nil
+}
+
+// ============================================================
+// GetUpcomingTicketsByUserID - Vé BOOKED/CHECKED_IN của sự kiện chưa kết thúc
+// Sắp xếp theo giờ bắt đầu gần nhất
+// ============================================================
+func (r *TicketRepository) GetUpcomingTicketsByUserID(ctx context.Context, userID, limit int) ([]models.UpcomingTicket, error) {
+	query := `
+		SELECT t.ticket_id, e.event_id, e.title, v.venue_name, va.area_name,
+		       e.start_time, e.end_time, t.status, ct.name, s.seat_code
 		FROM Ticket t
-		LEFT JOIN Event e ON t.event_id = e.event_id
+		JOIN Event e ON t.event_id = e.event_id
 		LEFT JOIN Category_Ticket ct ON t.category_ticket_id = ct.category_ticket_id
 		LEFT JOIN Seat s ON t.seat_id = s.seat_id
 		LEFT JOIN Venue_Area va ON e.area_id = va.area_id
 		LEFT JOIN Venue v ON va.venue_id = v.venue_id
-		LEFT JOIN Users u ON t.user_id = u.user_id
 		WHERE t.user_id = ?
-		ORDER BY t.ticket_id DESC
+		  AND t.status IN ('BOOKED', 'CHECKED_IN')
+		  AND e.end_time > NOW()
+		ORDER BY e.start_time ASC, t.ticket_id ASC
+		LIMIT ?
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, userID)
+	rows, err := r.db.QueryContext(ctx, query, userID, limit)
 	if err != nil {
-		return nil, fmt.Errorf("failed to query tickets: %w", err)
+		return nil, fmt.Errorf("failed to query upcoming tickets: %w", err)
 	}
 	defer rows.Close()
 
-	tickets := []models.MyTicketResponse{}
+	tickets := []models.UpcomingTicket{}
 	for rows.Next() {
-		var ticket models.MyTicketResponse
-		var (
-			ticketCode    sql.NullString
-			eventName     sql.NullString
-			venueName     sql.NullString
-			startTime     sql.NullTime
-			checkinTime   sql.NullTime
-			checkoutTime  sql.NullTime
-			category      sql.NullString
-			categoryPrice sql.NullFloat64
-			seatCode      sql.NullString
-			buyerName     sql.NullString
-			purchaseDate  sql.NullTime
-		)
-
-		err := rows.Scan(
-			&ticket.TicketID,
-			&ticketCode,
-			&eventName,
-			&venueName,
-			&startTime,
-			&ticket.Status,
-			&checkinTime,
-			&checkoutTime,
-			&category,
-			&categoryPrice,
-			&seatCode,
-			&buyerName,
-			&purchaseDate,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("failed to scan ticket: %w", err)
+		var t models.UpcomingTicket
+		if err := rows.Scan(&t.TicketID, &t.EventID, &t.EventName, &t.VenueName, &t.AreaName,
+			&t.StartTime, &t.EndTime, &t.Status, &t.Category, &t.SeatCode); err != nil {
+			return nil, fmt.Errorf("failed to scan upcoming ticket: %w", err)
 		}
-
-		if ticketCode.Valid {
-			ticket.TicketCode = &ticketCode.String
-		}
-		if eventName.Valid {
-			ticket.EventName = &eventName.String
-		}
-		if venueName.Valid {
-			ticket.VenueName = &venueName.String
-		}
-		if startTime.Valid {
-			ticket.StartTime = &startTime.Time
-		}
-		if checkinTime.Valid {
-			ticket.CheckInTime = &checkinTime.Time
-		}
-		if checkoutTime.Valid {
-			ticket.CheckOutTime = &checkoutTime.Time
-		}
-		if category.Valid {
-			ticket.Category = &category.String
-		}
-		if categoryPrice.Valid {
-			ticket.CategoryPrice = &categoryPrice.Float64
-		}
-		if seatCode.Valid {
-			ticket.SeatCode = &seatCode.String
-		}
-		if buyerName.Valid {
-			ticket.BuyerName = &buyerName.String
-		}
-		if purchaseDate.Valid {
-			ticket.PurchaseDate = &purchaseDate.Time
-		}
-
-		tickets = append(tickets, ticket)
+		tickets = append(tickets, t)
 	}
-
-	return tickets, nil
+	return tickets, rows.Err()
 }
 
 // ============================================================
 // GetTicketsByUserIDPaginated - Lấy danh sách vé với pagination và search/filter
 // ============================================================
-func (r *TicketRepository) GetTicketsByUserIDPaginated(ctx context.Context, userID, page, limit int, search, status string) (*models.PaginatedTicketsResponse, error) {
-	offset := (page - 1) * limit
-
-	// Build query với WHERE conditions
-	whereConditions := []string{"t.user_id = ?"}
-	args := []interface{}{userID}
-
-	// Search theo tên sự kiện
-	if search != "" {
-		whereConditions = append(whereConditions, "e.title LIKE ?")
-		args = append(args, "%"+search+"%")
-	}
-
-	// Filter theo status
-	if status != "" {
-		whereConditions = append(whereConditions, "t.status = ?")
-		args = append(args, status)
-	}
-
-	whereClause := strings.Join(whereConditions, " AND ")
+func (r *TicketRepository) GetTicketsByUserIDPaginated(ctx context.Context, userID int, p pagination.Params, search, status string) (*models.PaginatedTicketsResponse, error) {
+	q := db.From(ticketListFrom).
+		Where("t.user_id = ?", userID).
+		WhereIf(search != "", "e.title LIKE ?", db.Contains(search)). // Search theo tên sự kiện
+		WhereIf(status != "", "t.status = ?", status)
 
 	// Count total records
-	countQuery := fmt.Sprintf(`
-		SELECT COUNT(*) 
-		FROM Ticket t
-		LEFT JOIN Event e ON t.event_id = e.event_id
-		WHERE %s
-	`, whereClause)
-
 	var totalRecords int
-	err := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&totalRecords)
+	countQuery, countArgs := q.Count()
+	err := r.db.QueryRowContext(ctx, countQuery, countArgs...).Scan(&totalRecords)
 	if err != nil {
 		return nil, fmt.Errorf("failed to count tickets: %w", err)
 	}
 
-	totalPages := (totalRecords + limit - 1) / limit
-	if totalPages < 1 {
-		totalPages = 1
-	}
-
 	// Query tickets với pagination
-	query := fmt.Sprintf(`
-		SELECT 
-			t.ticket_id,
-			t.qr_code_value,
-			e.title AS event_name,
-			v.venue_name,
-			e.start_time,
-			t.status,
-			t.checkin_time,
-			t.check_out_time,
-			ct.name AS category,
-			ct.price AS category_price,
-			s.seat_code,
-			u.full_name AS buyer_name,
-			e.start_time AS purchase_date
-		FROM Ticket t
-		LEFT JOIN Event e ON t.event_id = e.event_id
-		LEFT JOIN Category_Ticket ct ON t.category_ticket_id = ct.category_ticket_id
-		LEFT JOIN Seat s ON t.seat_id = s.seat_id
-		LEFT JOIN Venue_Area va ON e.area_id = va.area_id
-		LEFT JOIN Venue v ON va.venue_id = v.venue_id
-		LEFT JOIN Users u ON t.user_id = u.user_id
-		WHERE %s
-		ORDER BY t.ticket_id DESC
-		LIMIT ? OFFSET ?
-	`, whereClause)
-
-	args = append(args, limit, offset)
+	query, args := q.OrderBy("t.ticket_id DESC").Limit(p.PageSize, p.Offset()).Select(myTicketColumns)
 	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query tickets: %w", err)
@@ -223,140 +171,39 @@ func (r *TicketRepository) GetTicketsByUserIDPaginated(ctx context.Context, user
 
 	tickets := []models.MyTicketResponse{}
 	for rows.Next() {
-		var ticket models.MyTicketResponse
-		var (
-			ticketCode    sql.NullString
-			eventName     sql.NullString
-			venueName     sql.NullString
-			startTime     sql.NullTime
-			checkinTime   sql.NullTime
-			checkoutTime  sql.NullTime
-			category      sql.NullString
-			categoryPrice sql.NullFloat64
-			seatCode      sql.NullString
-			buyerName     sql.NullString
-			purchaseDate  sql.NullTime
-		)
-
-		err := rows.Scan(
-			&ticket.TicketID,
-			&ticketCode,
-			&eventName,
-			&venueName,
-			&startTime,
-			&ticket.Status,
-			&checkinTime,
-			&checkoutTime,
-			&category,
-			&categoryPrice,
-			&seatCode,
-			&buyerName,
-			&purchaseDate,
-		)
+		ticket, err := scanMyTicket(rows)
 		if err != nil {
-			return nil, fmt.Errorf("failed to scan ticket: %w", err)
-		}
-
-		if ticketCode.Valid {
-			ticket.TicketCode = &ticketCode.String
-		}
-		if eventName.Valid {
-			ticket.EventName = &eventName.String
-		}
-		if venueName.Valid {
-			ticket.VenueName = &venueName.String
-		}
-		if startTime.Valid {
-			ticket.StartTime = &startTime.Time
-		}
-		if checkinTime.Valid {
-			ticket.CheckInTime = &checkinTime.Time
-		}
-		if checkoutTime.Valid {
-			ticket.CheckOutTime = &checkoutTime.Time
-		}
-		if category.Valid {
-			ticket.Category = &category.String
-		}
-		if categoryPrice.Valid {
-			ticket.CategoryPrice = &categoryPrice.Float64
-		}
-		if seatCode.Valid {
-			ticket.SeatCode = &seatCode.String
-		}
-		if buyerName.Valid {
-			ticket.BuyerName = &buyerName.String
-		}
-		if purchaseDate.Valid {
-			ticket.PurchaseDate = &purchaseDate.Time
+			return nil, err
 		}
 
 		tickets = append(tickets, ticket)
 	}
 
-	return &models.PaginatedTicketsResponse{
-		Tickets:      tickets,
-		TotalPages:   totalPages,
-		CurrentPage:  page,
-		TotalRecords: totalRecords,
-	}, nil
+	page := pagination.New(tickets, p, totalRecords)
+	return &page, nil
 }
 
 // ============================================================
-// GetTicketsByRole - Lấy danh sách vé theo role (ADMIN/STAFF/ORGANIZER)
+// GetTicketsByRole - Lấy danh sách vé theo role (ticket.view_all/ORGANIZER/người mua)
 // KHỚP VỚI Java: TicketDAO.getTicketsByRole()
 // ============================================================
 func (r *TicketRepository) GetTicketsByRole(ctx context.Context, role string, userID int, eventID *int) ([]models.MyTicketResponse, error) {
-	var query string
-	var args []interface{}
-
-	baseQuery := `
-		SELECT 
-			t.ticket_id,
-			t.qr_code_value,
-			e.title AS event_name,
-			v.venue_name,
-			e.start_time,
-			t.status,
-			t.checkin_time,
-			t.check_out_time,
-			ct.name AS category,
-			ct.price AS category_price,
-			s.seat_code,
-			u.full_name AS buyer_name,
-			e.start_time AS purchase_date
-		FROM Ticket t
-		LEFT JOIN Event e ON t.event_id = e.event_id
-		LEFT JOIN Category_Ticket ct ON t.category_ticket_id = ct.category_ticket_id
-		LEFT JOIN Seat s ON t.seat_id = s.seat_id
-		LEFT JOIN Venue_Area va ON e.area_id = va.area_id
-		LEFT JOIN Venue v ON va.venue_id = v.venue_id
-		LEFT JOIN Users u ON t.user_id = u.user_id
-	`
-
-	switch role {
-	case "ADMIN", "STAFF":
-		// Admin/Staff see all tickets, optionally filtered by eventId
-		if eventID != nil {
-			query = baseQuery + " WHERE t.event_id = ? ORDER BY t.ticket_id DESC"
-			args = append(args, *eventID)
-		} else {
-			query = baseQuery + " ORDER BY t.ticket_id DESC"
-		}
-	case "ORGANIZER":
-		// Organizer sees tickets for their events only
-		if eventID != nil {
-			query = baseQuery + " WHERE e.created_by = ? AND t.event_id = ? ORDER BY t.ticket_id DESC"
-			args = append(args, userID, *eventID)
-		} else {
-			query = baseQuery + " WHERE e.created_by = ? ORDER BY t.ticket_id DESC"
-			args = append(args, userID)
-		}
+	q := db.From(ticketListFrom)
+	switch {
+	case permission.RoleHas(ctx, role, permission.TicketViewAll):
+		// Có quyền ticket.view_all (Admin/Staff) see all tickets, optionally filtered by eventId
+	case role == "ORGANIZER":
+		// Organizer sees tickets for their events only (kể cả co-organizer có quyền VIEW_STATS)
+		q.Where(organizerTicketScopeSQL, userID, userID)
 	default:
 		// Regular user sees only their own tickets
-		query = baseQuery + " WHERE t.user_id = ? ORDER BY t.ticket_id DESC"
-		args = append(args, userID)
+		q.Where("t.user_id = ?", userID)
+		eventID = nil
 	}
+	if eventID != nil {
+		q.Where("t.event_id = ?", *eventID)
+	}
+	query, args := q.OrderBy("t.ticket_id DESC").Select(myTicketColumns)
 
 	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
@@ -366,72 +213,9 @@ func (r *TicketRepository) GetTicketsByRole(ctx context.Context, role string, us
 
 	tickets := []models.MyTicketResponse{}
 	for rows.Next() {
-		var ticket models.MyTicketResponse
-		var (
-			ticketCode    sql.NullString
-			eventName     sql.NullString
-			venueName     sql.NullString
-			startTime     sql.NullTime
-			checkinTime   sql.NullTime
-			checkoutTime  sql.NullTime
-			category      sql.NullString
-			categoryPrice sql.NullFloat64
-			seatCode      sql.NullString
-			buyerName     sql.NullString
-			purchaseDate  sql.NullTime
-		)
-
-		err := rows.Scan(
-			&ticket.TicketID,
-			&ticketCode,
-			&eventName,
-			&venueName,
-			&startTime,
-			&ticket.Status,
-			&checkinTime,
-			&checkoutTime,
-			&category,
-			&categoryPrice,
-			&seatCode,
-			&buyerName,
-			&purchaseDate,
-		)
+		ticket, err := scanMyTicket(rows)
 		if err != nil {
-			return nil, fmt.Errorf("failed to scan ticket: %w", err)
-		}
-
-		if ticketCode.Valid {
-			ticket.TicketCode = &ticketCode.String
-		}
-		if eventName.Valid {
-			ticket.EventName = &eventName.String
-		}
-		if venueName.Valid {
-			ticket.VenueName = &venueName.String
-		}
-		if startTime.Valid {
-			ticket.StartTime = &startTime.Time
-		}
-		if checkinTime.Valid {
-			ticket.CheckInTime = &checkinTime.Time
-		}
-		if checkoutTime.Valid {
-			ticket.CheckOutTime = &checkoutTime.Time
-		}
-		if category.Valid {
-			ticket.Category = &category.String
-		}
-		if categoryPrice.Valid {
-			ticket.CategoryPrice = &categoryPrice.Float64
-		}
-		if seatCode.Valid {
-			ticket.SeatCode = &seatCode.String
-		}
-		if buyerName.Valid {
-			ticket.BuyerName = &buyerName.String
-		}
-		if purchaseDate.Valid {
-			ticket.PurchaseDate = &purchaseDate.Time
+			return nil, err
 		}
 
 		tickets = append(tickets, ticket)
@@ -442,13 +226,15 @@ func (r *TicketRepository) GetTicketsByRole(ctx context.Context, role string, us
 
 // ============================================================
 // GetCategoryTicketsByEventID - Lấy các loại vé của event
+// Kèm giá đang áp dụng (bậc giá theo thời gian) và các lần đổi giá sắp tới
 // ============================================================
 func (r *TicketRepository) GetCategoryTicketsByEventID(ctx context.Context, eventID int) ([]models.CategoryTicket, error) {
 	query := `
-		SELECT category_ticket_id, event_id, name, description, price, max_quantity, status
-		FROM Category_Ticket
-		WHERE event_id = ?
-		ORDER BY price ASC
+		SELECT ct.category_ticket_id, ct.event_id, ct.name, ct.description, ` + activePriceSQL + `, ct.max_quantity, ct.status,
+		       COALESCE(ct.price, 0), ` + activeTierNameSQL + `, ` + activeTierEndSQL + `
+		FROM Category_Ticket ct
+		WHERE ct.event_id = ?
+		ORDER BY ct.price ASC
 	`
 
 	rows, err := r.db.QueryContext(ctx, query, eventID)
@@ -460,27 +246,39 @@ func (r *TicketRepository) GetCategoryTicketsByEventID(ctx context.Context, even
 	tickets := []models.CategoryTicket{}
 	for rows.Next() {
 		var ct models.CategoryTicket
-		var description sql.NullString
 
 		err := rows.Scan(
 			&ct.CategoryTicketID,
 			&ct.EventID,
 			&ct.Name,
-			&description,
+			&ct.Description,
 			&ct.Price,
 			&ct.MaxQuantity,
 			&ct.Status,
+			&ct.BasePrice,
+			&ct.PriceTier,
+			&ct.PriceValidUntil,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan category ticket: %w", err)
 		}
 
-		if description.Valid {
-			ct.Description = &description.String
-		}
-
 		tickets = append(tickets, ct)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read category tickets: %w", err)
+	}
+
+	upcoming, err := r.listUpcomingPriceChanges(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range tickets {
+		tickets[i].UpcomingPriceChanges = upcoming[tickets[i].CategoryTicketID]
+		if tickets[i].UpcomingPriceChanges == nil {
+			tickets[i].UpcomingPriceChanges = []models.PriceChange{}
+		}
+	}
 
 	return tickets, nil
 }
@@ -514,12 +312,11 @@ func (r *TicketRepository) GetBillsByUserID(ctx context.Context, userID int) ([]
 	bills := []models.MyBillResponse{}
 	for rows.Next() {
 		var bill models.MyBillResponse
-		var paymentMethod sql.NullString
 
 		err := rows.Scan(
 			&bill.BillID,
 			&bill.TotalAmount,
-			&paymentMethod,
+			&bill.PaymentMethod,
 			&bill.PaymentStatus,
 			&bill.CreatedAt,
 		)
@@ -528,10 +325,6 @@ func (r *TicketRepository) GetBillsByUserID(ctx context.Context, userID int) ([]
 			return nil, fmt.Errorf("failed to scan bill: %w", err)
 		}
 
-		if paymentMethod.Valid {
-			bill.PaymentMethod = &paymentMethod.String
-		}
-
 		// Set default values for fields we can't get without Bill_Detail
 		defaultEventName := "Event"
 		bill.EventName = &defaultEventName
@@ -547,70 +340,28 @@ func (r *TicketRepository) GetBillsByUserID(ctx context.Context, userID int) ([]
 // ============================================================
 // GetBillsByUserIDPaginated - Lấy danh sách hóa đơn với pagination và search/filter
 // ============================================================
-func (r *TicketRepository) GetBillsByUserIDPaginated(ctx context.Context, userID, page, limit int, search, paymentStatus, paymentMethod string) (*models.PaginatedBillsResponse, error) {
+func (r *TicketRepository) GetBillsByUserIDPaginated(ctx context.Context, userID int, p pagination.Params, search, paymentStatus, paymentMethod string) (*models.PaginatedBillsResponse, error) {
 	fmt.Printf("[DEBUG] GetBillsByUserIDPaginated - userID: %d, page: %d, limit: %d, search: %s, status: %s, method: %s\n",
-		userID, page, limit, search, paymentStatus, paymentMethod)
-
-	offset := (page - 1) * limit
-
-	// Build query với WHERE conditions
-	whereConditions := []string{"b.user_id = ?"}
-	args := []interface{}{userID}
+		userID, p.Page, p.PageSize, search, paymentStatus, paymentMethod)
 
-	// Search theo mã hóa đơn
-	if search != "" {
-		whereConditions = append(whereConditions, "CAST(b.bill_id AS CHAR) LIKE ?")
-		args = append(args, "%"+search+"%")
-	}
-
-	// Filter theo payment status
-	if paymentStatus != "" {
-		whereConditions = append(whereConditions, "b.payment_status = ?")
-		args = append(args, paymentStatus)
-	}
-
-	// Filter theo payment method
-	if paymentMethod != "" {
-		whereConditions = append(whereConditions, "b.payment_method = ?")
-		args = append(args, paymentMethod)
-	}
-
-	whereClause := strings.Join(whereConditions, " AND ")
+	q := db.From("Bill b").
+		Where("b.user_id = ?", userID).
+		WhereIf(search != "", "CAST(b.bill_id AS CHAR) LIKE ?", db.Contains(search)). // Search theo mã hóa đơn
+		WhereIf(paymentStatus != "", "b.payment_status = ?", paymentStatus).
+		WhereIf(paymentMethod != "", "b.payment_method = ?", paymentMethod)
 
 	// Count total records
-	countQuery := fmt.Sprintf(`
-		SELECT COUNT(*) 
-		FROM Bill b
-		WHERE %s
-	`, whereClause)
-
 	var totalRecords int
-	err := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&totalRecords)
+	countQuery, countArgs := q.Count()
+	err := r.db.QueryRowContext(ctx, countQuery, countArgs...).Scan(&totalRecords)
 	if err != nil {
 		fmt.Printf("[ERROR] GetBillsByUserIDPaginated - Count error: %v\n", err)
 		return nil, fmt.Errorf("failed to count bills: %w", err)
 	}
 
-	totalPages := (totalRecords + limit - 1) / limit
-	if totalPages < 1 {
-		totalPages = 1
-	}
-
 	// Query bills với pagination
-	query := fmt.Sprintf(`
-		SELECT 
-			b.bill_id,
-			b.total_amount,
-			b.payment_method,
-			b.payment_status,
-			b.created_at
-		FROM Bill b
-		WHERE %s
-		ORDER BY b.created_at DESC
-		LIMIT ? OFFSET ?
-	`, whereClause)
-
-	args = append(args, limit, offset)
+	query, args := q.OrderBy("b.created_at DESC").Limit(p.PageSize, p.Offset()).
+		Select("b.bill_id, b.total_amount, b.payment_method, b.payment_status, b.created_at")
 	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		fmt.Printf("[ERROR] GetBillsByUserIDPaginated - Query error: %v\n", err)
@@ -621,12 +372,11 @@ func (r *TicketRepository) GetBillsByUserIDPaginated(ctx context.Context, userID
 	bills := []models.MyBillResponse{}
 	for rows.Next() {
 		var bill models.MyBillResponse
-		var paymentMethodVal sql.NullString
 
 		err := rows.Scan(
 			&bill.BillID,
 			&bill.TotalAmount,
-			&paymentMethodVal,
+			&bill.PaymentMethod,
 			&bill.PaymentStatus,
 			&bill.CreatedAt,
 		)
@@ -635,10 +385,6 @@ func (r *TicketRepository) GetBillsByUserIDPaginated(ctx context.Context, userID
 			return nil, fmt.Errorf("failed to scan bill: %w", err)
 		}
 
-		if paymentMethodVal.Valid {
-			bill.PaymentMethod = &paymentMethodVal.String
-		}
-
 		// Set default values
 		defaultEventName := "Event"
 		bill.EventName = &defaultEventName
@@ -648,12 +394,8 @@ func (r *TicketRepository) GetBillsByUserIDPaginated(ctx context.Context, userID
 	}
 
 	fmt.Printf("[DEBUG] GetBillsByUserIDPaginated - Found %d bills\n", len(bills))
-	return &models.PaginatedBillsResponse{
-		Bills:        bills,
-		TotalPages:   totalPages,
-		CurrentPage:  page,
-		TotalRecords: totalRecords,
-	}, nil
+	page := pagination.New(bills, p, totalRecords)
+	return &page, nil
 }
 
 // ============================================================
@@ -677,31 +419,30 @@ func getVNPayService() *vnpay.VNPayService {
 // KHỚP VỚI Java: PaymentService.createPaymentUrl()
 // PRODUCTION: Sử dụng HMAC-SHA512 signature
 // UPDATED: Hỗ trợ mua nhiều ghế cùng lúc (max 4 ghế)
-func (r *TicketRepository) CreateVNPayURL(ctx context.Context, userID, eventID, categoryTicketID int, seatIDs []int) (string, error) {
+// installment: chỉ thanh toán tiền cọc theo cấu hình trả góp của sự kiện (installment.go)
+func (r *TicketRepository) CreateVNPayURL(ctx context.Context, userID, eventID, categoryTicketID int, seatIDs []int, installment bool) (*models.PaymentInitResult, error) {
 	log := logger.Default().WithContext(ctx)
 
 	// Validate số lượng ghế (max 4)
 	if len(seatIDs) == 0 {
-		return "", apperrors.BusinessError("Vui lòng chọn ít nhất 1 ghế")
+		return nil, apperrors.BusinessError("Vui lòng chọn ít nhất 1 ghế")
 	}
 	if len(seatIDs) > 4 {
-		return "", apperrors.BusinessError("Chỉ được mua tối đa 4 ghế mỗi lần")
+		return nil, apperrors.BusinessError("Chỉ được mua tối đa 4 ghế mỗi lần")
 	}
 
 	// Kiểm tra event có tồn tại và đang active không
-	var eventTitle string
-	var status string
-	var startTime time.Time
-	err := r.db.QueryRowContext(ctx, "SELECT title, status, start_time FROM Event WHERE event_id = ?", eventID).Scan(&eventTitle, &status, &startTime)
+	eventInfo, err := r.events.GetEventBookingInfo(ctx, eventID)
 	if err != nil {
 		log.Error("Event not found", "event_id", eventID, "error", err)
-		return "", apperrors.NotFound("Sự kiện")
+		return nil, apperrors.NotFound("Sự kiện")
 	}
+	eventTitle, status, startTime := eventInfo.Title, eventInfo.Status, eventInfo.StartTime
 	// Event phải ở trạng thái OPEN để có thể mua vé
 	// ENUM: 'OPEN','CLOSED','CANCELLED','DRAFT'
 	if status != "OPEN" {
 		log.Warn("Event not open", "event_id", eventID, "status", status)
-		return "", apperrors.BusinessError(fmt.Sprintf("Sự kiện không mở bán vé (trạng thái: %s)", status))
+		return nil, apperrors.BusinessError(fmt.Sprintf("Sự kiện không mở bán vé (trạng thái: %s)", status))
 	}
 
 	// ⭐ SECURITY: Kiểm tra xem event đã bắt đầu chưa
@@ -710,7 +451,7 @@ func (r *TicketRepository) CreateVNPayURL(ctx context.Context, userID, eventID,
 	if now.After(startTime) || now.Equal(startTime) {
 		log.Warn("[BOOKING_SECURITY] User blocked from buying ticket for event that has started",
 			"user_id", userID, "event_id", eventID, "event_start_time", startTime, "current_time", now)
-		return "", apperrors.BusinessError("Sự kiện đã bắt đầu hoặc kết thúc, không thể đặt thêm vé")
+		return nil, apperrors.BusinessError("Sự kiện đã bắt đầu hoặc kết thúc, không thể đặt thêm vé")
 	}
 
 	// Kiểm tra category ticket và lấy giá
@@ -718,34 +459,69 @@ func (r *TicketRepository) CreateVNPayURL(ctx context.Context, userID, eventID,
 	var pricePerSeat float64 // DECIMAL từ DB có phần thập phân
 	var catStatus string
 	var maxQty int
+	// Giá theo bậc giá đang hiệu lực lúc tạo link; số tiền này đi vào VNPay nên không đổi sau đó
 	err = r.db.QueryRowContext(ctx,
-		"SELECT price, status, max_quantity FROM Category_Ticket WHERE category_ticket_id = ? AND event_id = ?",
+		"SELECT "+activePriceSQL+", ct.status, ct.max_quantity FROM Category_Ticket ct WHERE ct.category_ticket_id = ? AND ct.event_id = ?",
 		categoryTicketID, eventID,
 	).Scan(&pricePerSeat, &catStatus, &maxQty)
 	if err != nil {
 		log.Error("Category ticket not found", "category_ticket_id", categoryTicketID, "error", err)
-		return "", apperrors.NotFound("Loại vé")
+		return nil, apperrors.NotFound("Loại vé")
 	}
 	// Category_Ticket status ENUM: 'ACTIVE','INACTIVE'
 	if catStatus != "ACTIVE" {
-		return "", apperrors.BusinessError("Loại vé này không khả dụng")
+		return nil, apperrors.BusinessError("Loại vé này không khả dụng")
 	}
 
 	log.Info("[INVOICE DEBUG] Category Ticket Retrieved", "category_ticket_id", categoryTicketID, "price_from_db", pricePerSeat, "price_type", "float64")
 
-	// Kiểm tra số lượng vé đã bán
-	var soldCount int
-	r.db.QueryRowContext(ctx,
-		"SELECT COUNT(*) FROM Ticket WHERE category_ticket_id = ?",
-		categoryTicketID,
-	).Scan(&soldCount)
-	if soldCount+len(seatIDs) > maxQty {
-		log.Warn("Not enough tickets", "category_ticket_id", categoryTicketID, "sold", soldCount, "max", maxQty, "requested", len(seatIDs))
-		return "", apperrors.BusinessError(fmt.Sprintf("Không đủ vé. Còn lại: %d, Yêu cầu: %d", maxQty-soldCount, len(seatIDs)))
+	// Kiểm tra số lượng vé còn lại (đọc bộ đếm Category_Ticket_Inventory thay vì COUNT(*) trên Ticket)
+	remaining, err := inventoryRemaining(ctx, r.db, categoryTicketID)
+	if err != nil {
+		return nil, apperrors.DatabaseError(err)
+	}
+	if remaining < len(seatIDs) {
+		log.Warn("Not enough tickets", "category_ticket_id", categoryTicketID, "remaining", remaining, "max", maxQty, "requested", len(seatIDs))
+		return nil, apperrors.BusinessError(fmt.Sprintf("Không đủ vé. Còn lại: %d, Yêu cầu: %d", remaining, len(seatIDs)))
+	}
+
+	if err := r.checkCompanionSeats(ctx, seatIDs); err != nil {
+		log.Warn("Companion seat without accessible seat", "event_id", eventID, "seat_ids", seatIDs)
+		return nil, err
+	}
+
+	// Trả góp: kiểm tra trước khi giữ ghế (sự kiện có cấu hình, chưa quá hạn cuối)
+	if installment {
+		if _, _, err := installmentDeposit(ctx, r.db, eventID, startTime, 0); err != nil {
+			return nil, err
+		}
+	}
+
+	// Giá riêng của ghế (Seat_Price) cộng / trừ trên giá của loại vé
+	seatPrices, err := categorySeatPrices(ctx, r.db, categoryTicketID, seatIDs)
+	if err != nil {
+		return nil, apperrors.DatabaseError(err)
+	}
+
+	// Giữ suất và tạo vé PENDING trong một transaction: lỗi ở bất kỳ ghế nào thì rollback cả hai
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, apperrors.DatabaseError(err)
+	}
+	defer tx.Rollback()
+
+	if err := reserveInventory(ctx, tx, categoryTicketID, len(seatIDs)); err != nil {
+		if errors.Is(err, errInventoryExhausted) {
+			log.Warn("Not enough tickets", "category_ticket_id", categoryTicketID, "requested", len(seatIDs))
+			return nil, apperrors.BusinessError(fmt.Sprintf("Không đủ vé. Yêu cầu: %d", len(seatIDs)))
+		}
+		return nil, apperrors.DatabaseError(err)
 	}
 
 	// Kiểm tra TẤT CẢ ghế có active và available không
 	pendingTicketIDs := []int64{}
+	// Tất cả vé của lần thanh toán này hết hạn giữ ghế cùng lúc
+	holdExpiresAt := time.Now().Add(rules.HoldDuration(ctx))
 	// ⭐ FIX: Dùng float64 để xử lý DECIMAL từ MySQL
 	var totalAmount float64 = 0 // Tổng tiền theo giá DECIMAL từ DB
 
@@ -755,54 +531,62 @@ func (r *TicketRepository) CreateVNPayURL(ctx context.Context, userID, eventID,
 		err = r.db.QueryRowContext(ctx, "SELECT status FROM Seat WHERE seat_id = ?", seatID).Scan(&seatStatus)
 		if err != nil {
 			log.Error("Seat not found", "seat_id", seatID, "error", err)
-			return "", apperrors.NotFound(fmt.Sprintf("Ghế ID %d", seatID))
+			return nil, apperrors.NotFound(fmt.Sprintf("Ghế ID %d", seatID))
 		}
 		if seatStatus != "ACTIVE" {
-			return "", apperrors.BusinessError(fmt.Sprintf("Ghế ID %d không khả dụng", seatID))
+			return nil, apperrors.BusinessError(fmt.Sprintf("Ghế ID %d không khả dụng", seatID))
 		}
 
 		// RACE CONDITION CHECK: Kiểm tra ghế đã bị giữ/đặt chưa
 		var existingTicketCount int
 		err = r.db.QueryRowContext(ctx,
 			`SELECT COUNT(*) FROM Ticket 
-			 WHERE event_id = ? AND seat_id = ? AND status IN ('PENDING', 'BOOKED', 'CHECKED_IN')`,
+			 WHERE event_id = ? AND seat_id = ? AND status IN ('PENDING', 'BOOKED', 'CHECKED_IN', 'CHECKED_OUT')`,
 			eventID, seatID,
 		).Scan(&existingTicketCount)
 		if err != nil {
 			log.Error("Error checking existing tickets", "error", err)
-			return "", apperrors.DatabaseError(err)
+			return nil, apperrors.DatabaseError(err)
 		}
 		if existingTicketCount > 0 {
 			log.Warn("Seat already reserved/booked", "event_id", eventID, "seat_id", seatID)
-			return "", apperrors.BusinessError(fmt.Sprintf("Ghế ID %d đã được người khác giữ/đặt", seatID))
+			return nil, apperrors.BusinessError(fmt.Sprintf("Ghế ID %d đã được người khác giữ/đặt", seatID))
 		}
 
 		// TẠO PENDING TICKET để giữ chỗ
-		pendingResult, err := r.db.ExecContext(ctx,
-			`INSERT INTO Ticket (user_id, event_id, category_ticket_id, seat_id, qr_code_value, status, created_at) 
-			 VALUES (?, ?, ?, ?, 'PENDING_QR', 'PENDING', NOW())`,
-			userID, eventID, categoryTicketID, seatID,
-		)
+		pendingTicketID, err := insertSeatTicket(ctx, tx, userID, eventID, categoryTicketID, seatID, "PENDING", &holdExpiresAt)
 		if err != nil {
 			log.Error("Failed to create PENDING ticket", "seat_id", seatID, "error", err)
-			// Rollback: xóa tất cả PENDING tickets đã tạo
-			for _, tid := range pendingTicketIDs {
-				r.db.ExecContext(ctx, "DELETE FROM Ticket WHERE ticket_id = ?", tid)
+			// Rollback transaction: các PENDING tickets đã tạo và suất đã giữ đều bị hủy
+			if errors.Is(err, errSeatTaken) {
+				return nil, apperrors.BusinessError(fmt.Sprintf("Ghế ID %d đã được người khác giữ/đặt", seatID))
 			}
-			return "", apperrors.BusinessError(fmt.Sprintf("Không thể giữ ghế ID %d", seatID))
+			return nil, apperrors.BusinessError(fmt.Sprintf("Không thể giữ ghế ID %d", seatID))
 		}
 
-		pendingTicketID, _ := pendingResult.LastInsertId()
 		pendingTicketIDs = append(pendingTicketIDs, pendingTicketID)
-		totalAmount += pricePerSeat
+		seatPrice := pricePerSeat
+		if p, ok := seatPrices[seatID]; ok {
+			seatPrice = p
+		}
+		totalAmount += seatPrice
 
 		log.Info("[INVOICE DEBUG] Seat Added To Bill",
 			"seat_id", seatID,
-			"price_per_seat", pricePerSeat,
+			"price_per_seat", seatPrice,
 			"running_total", totalAmount,
 			"seat_position", len(pendingTicketIDs))
 	}
 
+	if err := tx.Commit(); err != nil {
+		log.Error("Failed to commit PENDING tickets", "error", err)
+		return nil, apperrors.DatabaseError(err)
+	}
+	ticketIDs := make([]int, len(pendingTicketIDs))
+	for i, tid := range pendingTicketIDs {
+		ticketIDs[i] = int(tid)
+	}
+
 	// Tạo mã giao dịch - Chứa ALL pendingTicketIDs (comma-separated)
 	timestamp := fmt.Sprintf("%d", time.Now().UnixMilli())
 	// Format: userID_eventID_categoryID_ticketIDs_timestamp
@@ -815,6 +599,20 @@ func (r *TicketRepository) CreateVNPayURL(ctx context.Context, userID, eventID,
 		ticketIDsStr += fmt.Sprintf("%d", tid)
 	}
 	txnRef := fmt.Sprintf("%d_%d_%d_%s_%s", userID, eventID, categoryTicketID, ticketIDsStr, timestamp)
+	// Trả góp: VNPay thu tiền cọc, txnRef mang tổng tiền để callback ghi Bill và lịch các kỳ
+	chargeAmount := totalAmount
+	if installment {
+		_, deposit, err := installmentDeposit(ctx, r.db, eventID, startTime, ledger.Dong(totalAmount))
+		if err != nil {
+			if delErr := r.deletePendingTickets(ctx, ticketIDs); delErr != nil {
+				log.Error("Failed to release PENDING tickets", "ticket_ids", ticketIDs, "error", delErr)
+			}
+			return nil, err
+		}
+		chargeAmount = float64(deposit)
+		txnRef += fmt.Sprintf("_%s%d", depositTxnMarker, ledger.Dong(totalAmount))
+	}
+	tracing.SpanFromContext(ctx).SetAttributes(tracing.String("booking.txn_ref", txnRef))
 
 	// Tạo orderInfo
 	orderInfo := fmt.Sprintf("Payment for %s - %d seats", eventTitle, len(seatIDs))
@@ -829,19 +627,25 @@ func (r *TicketRepository) CreateVNPayURL(ctx context.Context, userID, eventID,
 
 	// SỬ DỤNG VNPAY SERVICE VỚI PROPER SIGNATURE
 	service := getVNPayService()
+	_, vnpSpan := tracing.Start(ctx, "vnpay.CreatePaymentURL", tracing.WithKind(tracing.SpanKindClient),
+		tracing.WithAttributes(tracing.String("booking.txn_ref", txnRef)))
 	paymentURL, err := service.CreatePaymentURL(vnpay.PaymentRequest{
 		OrderInfo: orderInfo,
-		Amount:    totalAmount, // totalAmount đã là float64
+		Amount:    chargeAmount, // totalAmount (hoặc tiền cọc khi trả góp) đã là float64
 		TxnRef:    txnRef,
 		IPAddr:    "127.0.0.1",
+		// Link VNPay sống đến hết thời gian giữ ghế tối đa (kể cả gia hạn)
+		ExpireDate: holdExpiresAt.Add(rules.HoldExtension(ctx)).Format("20060102150405"),
 	})
+	vnpSpan.RecordError(err)
+	vnpSpan.End()
 	if err != nil {
-		// Rollback: xóa TẤT CẢ PENDING tickets
-		for _, tid := range pendingTicketIDs {
-			r.db.ExecContext(ctx, "DELETE FROM Ticket WHERE ticket_id = ?", tid)
+		// Rollback: xóa TẤT CẢ PENDING tickets và trả lại suất
+		if delErr := r.deletePendingTickets(ctx, ticketIDs); delErr != nil {
+			log.Error("Failed to release PENDING tickets", "ticket_ids", ticketIDs, "error", delErr)
 		}
 		log.Error("Failed to create VNPay URL", "error", err)
-		return "", apperrors.VNPayError("Không thể tạo link thanh toán")
+		return nil, apperrors.VNPayError("Không thể tạo link thanh toán")
 	}
 
 	log.LogEvent(logger.EventLog{
@@ -857,28 +661,62 @@ func (r *TicketRepository) CreateVNPayURL(ctx context.Context, userID, eventID,
 			"seat_count":         len(seatIDs),
 			"seat_ids":           seatIDs,
 			"pending_ticket_ids": pendingTicketIDs,
+			"hold_expires_at":    holdExpiresAt,
 		},
 	})
 
-	return paymentURL, nil
+	result := &models.PaymentInitResult{
+		PaymentURL:    paymentURL,
+		TicketIDs:     ticketIDs,
+		HoldExpiresAt: holdExpiresAt,
+	}
+	if installment {
+		result.DepositAmount = chargeAmount
+	}
+	return result, nil
 }
 
 // ProcessVNPayCallback - Xử lý callback từ VNPay
 // KHỚP VỚI Java: BuyTicketService.processPayment()
 // PRODUCTION: Verify HMAC-SHA512 signature trước khi xử lý
 // UPDATED: Hỗ trợ update NHIỀU PENDING tickets thành BOOKED
-func (r *TicketRepository) ProcessVNPayCallback(ctx context.Context, amount, responseCode, orderInfo, txnRef, secureHash string) (string, error) {
+// params: toàn bộ query của return URL; sai / thiếu chữ ký thì không đụng tới vé
+func (r *TicketRepository) ProcessVNPayCallback(ctx context.Context, params url.Values) (string, error) {
 	log := logger.Default().WithContext(ctx)
 
+	// ⭐ SECURITY: amount / response code chỉ đáng tin khi chữ ký HMAC-SHA512 khớp
+	callback, err := getVNPayService().VerifyCallback(params)
+	if err != nil {
+		log.Warn("[PAYMENT_SECURITY] VNPay callback rejected", "txn_ref", params.Get("vnp_TxnRef"), "error", err)
+		return "Invalid payment signature", err
+	}
+	amount, responseCode, txnRef := callback.Amount, callback.ResponseCode, callback.TxnRef
+
 	// Log callback receipt
 	log.Info("VNPay callback received", "txn_ref", txnRef, "response_code", responseCode)
 
-	// Parse txnRef: userID_eventID_categoryTicketID_ticketIDs_timestamp
+	// Mua vé học kỳ (PASS_userID_passID_timestamp): không có vé PENDING, kết quả là holder_id
+	if IsPassTxnRef(txnRef) {
+		return r.processPassVNPayCallback(ctx, callback)
+	}
+	// Trả một kỳ trả góp (INST_installmentID_timestamp): kết quả là bill_id
+	if IsInstallmentTxnRef(txnRef) {
+		return r.processInstallmentVNPayCallback(ctx, callback)
+	}
+
+	// Parse txnRef: userID_eventID_categoryTicketID_ticketIDs_timestamp[_D<tổng tiền> khi trả góp]
 	// ticketIDs format: "123,124,125,126" (comma-separated)
 	parts := strings.Split(txnRef, "_")
 	if len(parts) < 5 {
 		return "Invalid transaction reference format", fmt.Errorf("invalid txn ref: %s", txnRef)
 	}
+	var depositTotal int64
+	if len(parts) > 5 && strings.HasPrefix(parts[5], depositTxnMarker) {
+		depositTotal, err = strconv.ParseInt(strings.TrimPrefix(parts[5], depositTxnMarker), 10, 64)
+		if err != nil || depositTotal <= 0 {
+			return "Invalid installment total in txn ref", fmt.Errorf("invalid txn ref: %s", txnRef)
+		}
+	}
 
 	userID, err := strconv.Atoi(parts[0])
 	if err != nil {
@@ -913,14 +751,23 @@ func (r *TicketRepository) ProcessVNPayCallback(ctx context.Context, amount, res
 
 	log.Info("Parsed txnRef", "user_id", userID, "event_id", eventID, "ticket_ids", pendingTicketIDs)
 
+	// Callback lặp lại (F5 return URL, VNPay gửi lại): trả kết quả lần đầu, không tạo Bill mới
+	if result, ok, err := r.processedPayment(ctx, txnRef); err != nil {
+		log.Warn("Payment dedup lookup failed, relying on unique txn_ref", "txn_ref", txnRef, "error", err)
+	} else if ok {
+		log.Info("Duplicate VNPay callback, returning original result", "txn_ref", txnRef)
+		return result, nil
+	}
+
 	// Check response code
 	if responseCode != "00" {
 		log.Warn("Payment failed/cancelled", "txn_ref", txnRef, "response_code", responseCode)
 
-		// Xóa TẤT CẢ PENDING tickets
-		for _, tid := range pendingTicketIDs {
-			r.db.ExecContext(ctx, "DELETE FROM Ticket WHERE ticket_id = ? AND status = 'PENDING'", tid)
-			log.Info("Deleted PENDING ticket after failed payment", "ticket_id", tid)
+		// Xóa TẤT CẢ PENDING tickets và trả lại suất
+		if err := r.deletePendingTickets(ctx, pendingTicketIDs); err != nil {
+			log.Error("Failed to delete PENDING tickets after failed payment", "ticket_ids", pendingTicketIDs, "error", err)
+		} else {
+			log.Info("Deleted PENDING tickets after failed payment", "ticket_ids", pendingTicketIDs)
 		}
 
 		return "Payment was cancelled or failed. Response code: " + responseCode, apperrors.PaymentFailed(responseCode)
@@ -928,17 +775,16 @@ func (r *TicketRepository) ProcessVNPayCallback(ctx context.Context, amount, res
 
 	// ⭐ SECURITY: Double-check event time even at callback time
 	// In case event started after payment was initiated but before callback came back
-	var eventStatus string
-	var startTime time.Time
-	err = r.db.QueryRowContext(ctx, "SELECT status, start_time FROM Event WHERE event_id = ?", eventID).Scan(&eventStatus, &startTime)
+	eventInfo, err := r.events.GetEventBookingInfo(ctx, eventID)
 	if err != nil {
 		log.Error("Event validation failed", "event_id", eventID, "error", err)
 		// Clean up pending tickets
-		for _, tid := range pendingTicketIDs {
-			r.db.ExecContext(ctx, "DELETE FROM Ticket WHERE ticket_id = ? AND status = 'PENDING'", tid)
+		if err := r.deletePendingTickets(ctx, pendingTicketIDs); err != nil {
+			log.Error("Failed to delete PENDING tickets", "ticket_ids", pendingTicketIDs, "error", err)
 		}
 		return "Event not found", err
 	}
+	startTime := eventInfo.StartTime
 
 	// Check if event has already started
 	now := time.Now()
@@ -946,8 +792,8 @@ func (r *TicketRepository) ProcessVNPayCallback(ctx context.Context, amount, res
 		log.Warn("[BOOKING_SECURITY] Payment callback rejected - Event has started",
 			"user_id", userID, "event_id", eventID, "event_start_time", startTime, "current_time", now)
 		// Clean up pending tickets
-		for _, tid := range pendingTicketIDs {
-			r.db.ExecContext(ctx, "DELETE FROM Ticket WHERE ticket_id = ? AND status = 'PENDING'", tid)
+		if err := r.deletePendingTickets(ctx, pendingTicketIDs); err != nil {
+			log.Error("Failed to delete PENDING tickets", "ticket_ids", pendingTicketIDs, "error", err)
 		}
 		return "Event has started, booking is not allowed", fmt.Errorf("event already started")
 	}
@@ -970,6 +816,20 @@ func (r *TicketRepository) ProcessVNPayCallback(ctx context.Context, amount, res
 	}
 	billAmount = amountFromVNPay / 100 // Chia 100 để lấy giá trị VND thực tế
 
+	// Ghi nhận txnRef trước mọi thay đổi: callback đồng thời chờ ở đây rồi nhận kết quả của callback đầu
+	if err := claimPaymentTx(ctx, tx, txnRef, userID, billAmount, responseCode); err != nil {
+		if !errors.Is(err, errPaymentAlreadyProcessed) {
+			return "Database error", err
+		}
+		tx.Rollback()
+		result, _, lookupErr := r.processedPayment(ctx, txnRef)
+		if lookupErr != nil {
+			return "Database error", lookupErr
+		}
+		log.Info("Concurrent VNPay callback already processed", "txn_ref", txnRef)
+		return result, nil
+	}
+
 	// ⭐ DEBUG: In ra toàn bộ quá trình tính toán
 	fmt.Printf("\n========== BILL CURRENCY CALCULATION ==========\n")
 	fmt.Printf("[1] amount (raw string from VNPay callback): %s\n", amount)
@@ -984,9 +844,19 @@ func (r *TicketRepository) ProcessVNPayCallback(ctx context.Context, amount, res
 		"user_id", userID,
 	)
 
+	// payment_ref: mã giao dịch phía VNPay in trên biên lai (thiếu thì dùng vnp_TxnRef)
+	paymentRef := callback.TransactionNo
+	if paymentRef == "" {
+		paymentRef = txnRef
+	}
+	// Trả góp: billAmount là tiền cọc, Bill ghi tổng tiền và ở trạng thái PARTIAL tới khi trả đủ
+	billTotal, billStatus := billAmount, "PAID"
+	if depositTotal > 0 {
+		billTotal, billStatus = float64(depositTotal), "PARTIAL"
+	}
 	billResult, err := tx.ExecContext(ctx,
-		"INSERT INTO Bill (user_id, total_amount, currency, payment_method, payment_status, created_at, paid_at) VALUES (?, ?, 'VND', 'VNPAY', 'PAID', NOW(), NOW())",
-		userID, billAmount,
+		"INSERT INTO Bill (user_id, total_amount, currency, payment_method, payment_status, created_at, paid_at, vat_bps, payment_ref) VALUES (?, ?, 'VND', 'VNPAY', ?, NOW(), IF(? = 'PAID', NOW(), NULL), ?, ?)",
+		userID, billTotal, billStatus, billStatus, currentVATBps(ctx), paymentRef,
 	)
 
 	fmt.Printf("[INSERT] SAVING TO DB - user_id: %d, total_amount (billAmount): %.0f\n", userID, billAmount)
@@ -1001,6 +871,11 @@ func (r *TicketRepository) ProcessVNPayCallback(ctx context.Context, amount, res
 
 	fmt.Printf("[BILL_CREATED] ✅ Da xuat hoa don ID: %d cho phuong thuc: %s\n", billID, "VNPAY")
 
+	// Sổ cái: Nợ VNPAY_CLEARING / Có PLATFORM_REVENUE
+	if _, err := ledger.Post(ctx, tx, ledger.BillPayment(int(billID), userID, "VNPAY", billAmount)); err != nil {
+		return "Failed to post bill to ledger", err
+	}
+
 	// 2. Update TẤT CẢ PENDING tickets thành BOOKED với QR codes
 	bookedTicketIDs := []int{}
 	for _, ticketID := range pendingTicketIDs {
@@ -1011,26 +886,72 @@ func (r *TicketRepository) ProcessVNPayCallback(ctx context.Context, amount, res
 			qrBase64 = fmt.Sprintf("PENDING_QR_%d", ticketID)
 		}
 
-		// Update ticket
-		updateResult, err := tx.ExecContext(ctx,
-			`UPDATE Ticket SET status = 'BOOKED', bill_id = ?, qr_code_value = ? WHERE ticket_id = ? AND status = 'PENDING'`,
-			billID, qrBase64, ticketID,
-		)
+		// Update ticket: PENDING → BOOKED (vé đã bị dọn dẹp / không còn PENDING coi như hết giữ chỗ)
+		_, err = tickethistory.Transition(ctx, tx, tickethistory.Change{
+			TicketID: ticketID,
+			To:       tickethistory.StatusBooked,
+			Set:      "bill_id = ?, qr_code_value = ?",
+			SetArgs:  []any{billID, qrBase64},
+		})
+		if errors.Is(err, tickethistory.ErrTicketNotFound) || errors.Is(err, tickethistory.ErrInvalidTransition) {
+			log.Warn("PENDING ticket not found", "ticket_id", ticketID, "error", err)
+			return fmt.Sprintf("Ticket ID %d đã hết thời gian giữ chỗ", ticketID), fmt.Errorf("ticket %d expired", ticketID)
+		}
 		if err != nil {
 			log.Error("Failed to update ticket", "ticket_id", ticketID, "error", err)
 			return "Failed to update ticket", err
 		}
 
-		rowsAffected, _ := updateResult.RowsAffected()
-		if rowsAffected == 0 {
-			log.Warn("PENDING ticket not found", "ticket_id", ticketID)
-			return fmt.Sprintf("Ticket ID %d đã hết thời gian giữ chỗ", ticketID), fmt.Errorf("ticket %d expired", ticketID)
-		}
-
 		bookedTicketIDs = append(bookedTicketIDs, ticketID)
 		log.Info("Ticket updated to BOOKED", "ticket_id", ticketID, "qr_length", len(qrBase64))
 	}
 
+	// Dòng hóa đơn: giá từng vé + phí nền tảng đang áp cho sự kiện
+	if err := insertBillItems(ctx, tx, int(billID), eventID, billTotal, bookedTicketIDs); err != nil {
+		return "Failed to create bill items", err
+	}
+	if depositTotal > 0 {
+		// Cấu hình bị tắt sau lúc tạo link: phần còn lại thành một kỳ, hạn trước giờ bắt đầu sự kiện
+		plan, ok, err := loadInstallmentPlan(ctx, tx, eventID)
+		if err != nil {
+			return "Database error", err
+		}
+		if !ok {
+			log.Warn("Installment plan removed before deposit callback, using a single installment", "event_id", eventID, "bill_id", billID)
+			plan = billing.InstallmentPlan{Count: 1}
+		}
+		if err := insertInstallmentSchedule(ctx, tx, billID, plan, depositTotal, ledger.Dong(billAmount), startTime, "VNPAY", paymentRef); err != nil {
+			return "Failed to create installment schedule", err
+		}
+	}
+
+	ticketIDsResult := joinTicketIDs(bookedTicketIDs)
+	if err := completePaymentTx(ctx, tx, txnRef, billID, ticketIDsResult); err != nil {
+		return "Database error", err
+	}
+
+	// 3. Email vé ghi vào outbox trong cùng transaction (không mất khi process chết sau commit)
+	// ⭐ CRITICAL FIX: billAmount đã là giá trị VND gốc (chia 100 từ callback)
+	emailMessageID, err := outbox.Enqueue(ctx, tx, outbox.Message{
+		Topic: TopicTicketBookedEmail,
+		Payload: ticketBookedEmailPayload{
+			UserID: userID, EventID: eventID, TicketIDs: bookedTicketIDs,
+			TotalAmount: fmt.Sprintf("%.0f", billAmount), CategoryTicketID: categoryTicketID, BillID: int(billID),
+		},
+		Reference: fmt.Sprintf("bill#%d", billID),
+	})
+	if err != nil {
+		return "Failed to schedule ticket email", err
+	}
+	// Biên lai gửi khi hóa đơn trả đủ (trả góp: sau kỳ cuối)
+	var receiptMessageID int64
+	if billStatus == "PAID" {
+		receiptMessageID, err = enqueueReceiptEmail(ctx, tx, int(billID))
+		if err != nil {
+			return "Failed to schedule payment receipt", err
+		}
+	}
+
 	// Commit transaction
 	if err := tx.Commit(); err != nil {
 		return "Failed to commit transaction", err
@@ -1051,31 +972,29 @@ func (r *TicketRepository) ProcessVNPayCallback(ctx context.Context, amount, res
 		},
 	})
 
-	// 3. GỬI EMAIL với NHIỀU PDF attachments
-	// ⭐ CRITICAL FIX: billAmount đã là giá trị VND gốc (chia 100 từ callback)
+	eventbus.Publish(ctx, eventbus.TicketBooked{
+		BillID: int(billID), UserID: userID, EventID: eventID, TicketIDs: bookedTicketIDs,
+		TotalAmount: billAmount, PaymentMethod: "vnpay", OccurredAt: time.Now(),
+	})
+
 	log.Info("[CURRENCY DEBUG] VNPay callback processed",
 		"original_vnp_amount", amountFromVNPay,
 		"billAmount_for_email", billAmount,
 		"ticket_count", len(bookedTicketIDs))
 
-	// Convert billAmount float64 to string format cho email
-	realAmount := fmt.Sprintf("%.0f", billAmount)
-	go r.sendMultipleTicketEmailsAsync(ctx, userID, eventID, bookedTicketIDs, realAmount, categoryTicketID, int(billID))
+	// GỬI EMAIL với NHIỀU PDF attachments ngay (không block response); lỗi thì job "outbox" gửi lại
+	go outbox.Default().Deliver(context.WithoutCancel(ctx), emailMessageID)
+	if receiptMessageID > 0 {
+		go outbox.Default().Deliver(context.WithoutCancel(ctx), receiptMessageID)
+	}
 
 	// Trả về comma-separated ticket IDs
-	ticketIDsResult := ""
-	for i, tid := range bookedTicketIDs {
-		if i > 0 {
-			ticketIDsResult += ","
-		}
-		ticketIDsResult += fmt.Sprintf("%d", tid)
-	}
 	return ticketIDsResult, nil
 }
 
 // sendTicketEmailAsync gửi email vé điện tử trong goroutine (không block payment response)
 // KHỚP VỚI Java BuyTicketController gọi EmailUtils.sendEmail()
-func (r *TicketRepository) sendTicketEmailAsync(ctx context.Context, userID, eventID, seatID, ticketID int, amount string, categoryTicketID int) {
+func (r *TicketRepository) sendTicketEmailAsync(ctx context.Context, userID, eventID, seatID, ticketID int, amount string, categoryTicketID int, paymentMethod string) {
 	log := logger.Default().WithContext(ctx)
 	log.Info("🔔 STARTING sendTicketEmailAsync", "user_id", userID, "ticket_id", ticketID)
 
@@ -1106,27 +1025,12 @@ func (r *TicketRepository) sendTicketEmailAsync(ctx context.Context, userID, eve
 	}
 
 	// Lấy thông tin event + venue (KHỚP VỚI GetEventByID logic)
-	var eventTitle string
-	var startTime time.Time
-	var areaID sql.NullInt64
-	var areaName sql.NullString
-	var venueName sql.NullString
-	var venueLocation sql.NullString
-	err = r.db.QueryRowContext(ctx,
-		`SELECT e.title, e.start_time, e.area_id, 
-		        COALESCE(va.area_name, 'Chưa xác định') as area_name,
-		        COALESCE(v.venue_name, 'Chưa xác định') as venue_name,
-		        COALESCE(v.location, 'Chưa xác định') as location
-		 FROM Event e
-		 LEFT JOIN Venue_Area va ON e.area_id = va.area_id
-		 LEFT JOIN Venue v ON va.venue_id = v.venue_id
-		 WHERE e.event_id = ?`,
-		eventID,
-	).Scan(&eventTitle, &startTime, &areaID, &areaName, &venueName, &venueLocation)
+	eventInfo, err := r.events.GetEventBookingInfo(ctx, eventID)
 	if err != nil {
 		log.Error("Failed to get event for email", "event_id", eventID, "error", err)
 		return
 	}
+	eventTitle, startTime := eventInfo.Title, eventInfo.StartTime
 
 	// Lấy thông tin ghế
 	var seatCode string
@@ -1148,16 +1052,16 @@ func (r *TicketRepository) sendTicketEmailAsync(ctx context.Context, userID, eve
 	finalVenueAddress := "Chưa xác định"
 	finalAreaName := "Chưa xác định"
 
-	if areaName.Valid && areaName.String != "" && areaName.String != "Chưa xác định" {
-		finalAreaName = areaName.String
+	if eventInfo.AreaName != nil && *eventInfo.AreaName != "" {
+		finalAreaName = *eventInfo.AreaName
 	}
 
-	if venueName.Valid && venueName.String != "" && venueName.String != "Chưa xác định" {
-		finalVenueName = venueName.String
+	if eventInfo.VenueName != nil && *eventInfo.VenueName != "" {
+		finalVenueName = *eventInfo.VenueName
 	}
 
-	if venueLocation.Valid && venueLocation.String != "" && venueLocation.String != "Chưa xác định" {
-		finalVenueAddress = venueLocation.String
+	if eventInfo.VenueLocation != nil && *eventInfo.VenueLocation != "" {
+		finalVenueAddress = *eventInfo.VenueLocation
 	}
 
 	// Format giá tiền giống Java: 250.000 đ (dấu chấm phân cách hàng nghìn)
@@ -1206,7 +1110,7 @@ func (r *TicketRepository) sendTicketEmailAsync(ctx context.Context, userID, eve
 	var pdfBytes []byte
 	var pdfFilename string
 	if qrPngBytes != nil && len(qrPngBytes) > 0 {
-		pdfBytes, err = ticketpdf.GenerateTicketPDF(ticketpdf.TicketPDFData{
+		pdfBytes, err = r.loadTicketPDFStyle(ctx, eventID).render(ticketpdf.TicketPDFData{
 			TicketCode:     fmt.Sprintf("TKT_%d", ticketID),
 			EventName:      eventTitle,
 			EventDate:      startTime,
@@ -1232,7 +1136,8 @@ func (r *TicketRepository) sendTicketEmailAsync(ctx context.Context, userID, eve
 
 	// Gửi email với QR code Base64 trong body + PDF attachment (KHỚP VỚI JAVA)
 	emailService := email.NewEmailService(nil)
-	err = emailService.SendTicketEmail(email.TicketEmailData{
+	emailSpan := startEmailSpan(ctx, "SendTicketEmail", 1)
+	err = email.DefaultQueue().Send(ctx, email.TemplateTicket, emailService.BuildTicketEmail(email.TicketEmailData{
 		UserEmail:     userEmail,
 		UserName:      userName,
 		EventTitle:    eventTitle,
@@ -1245,11 +1150,14 @@ func (r *TicketRepository) sendTicketEmailAsync(ctx context.Context, userID, eve
 		MapURL:        mapURL,
 		TotalAmount:   formattedAmount,
 		StartTime:     startTime.Format("15:04 02/01/2006"),
-		PaymentMethod: "VNPAY",
+		PaymentMethod: paymentMethod,
 		QRCodeBase64:  qrBase64, // ✅ Base64 từ database
 		PDFAttachment: pdfBytes, // ✅ Attach PDF
 		PDFFilename:   pdfFilename,
-	})
+		Sponsors:      r.goldSponsorLogos(ctx, eventID),
+	}), fmt.Sprintf("ticket#%d", ticketID))
+	emailSpan.RecordError(err)
+	emailSpan.End()
 	if err != nil {
 		log.Error("Failed to send ticket email", "user_email", userEmail, "error", err)
 	} else {
@@ -1257,11 +1165,19 @@ func (r *TicketRepository) sendTicketEmailAsync(ctx context.Context, userID, eve
 	}
 }
 
-// sendMultipleTicketEmailsAsync gửi 1 email với NHIỀU PDF attachments (mỗi vé 1 PDF)
-// Được gọi khi user mua nhiều ghế cùng lúc (max 4 ghế)
-func (r *TicketRepository) sendMultipleTicketEmailsAsync(ctx context.Context, userID, eventID int, ticketIDs []int, totalAmount string, categoryTicketID, billID int) {
+// startEmailSpan mở span client cho một lần gửi email vé (SMTP)
+func startEmailSpan(ctx context.Context, kind string, ticketCount int) *tracing.Span {
+	_, span := tracing.Start(ctx, "email."+kind, tracing.WithKind(tracing.SpanKindClient),
+		tracing.WithAttributes(tracing.Int("email.ticket_count", ticketCount)))
+	return span
+}
+
+// sendMultipleTicketEmails gửi 1 email với NHIỀU PDF attachments (mỗi vé 1 PDF)
+// Được gọi khi user mua nhiều ghế cùng lúc (max 4 ghế), qua outbox topic TopicTicketBookedEmail
+// Trả lỗi khi chưa dựng được email (outbox chạy lại); lỗi gửi SMTP do Email_Queue retry, trả nil
+func (r *TicketRepository) sendMultipleTicketEmails(ctx context.Context, userID, eventID int, ticketIDs []int, totalAmount string, categoryTicketID, billID int) error {
 	log := logger.Default().WithContext(ctx)
-	log.Info("🔔 STARTING sendMultipleTicketEmailsAsync", "user_id", userID, "ticket_count", len(ticketIDs))
+	log.Info("🔔 STARTING sendMultipleTicketEmails", "user_id", userID, "ticket_count", len(ticketIDs))
 
 	// Lấy thông tin user
 	var userEmail, userName string
@@ -1270,49 +1186,32 @@ func (r *TicketRepository) sendMultipleTicketEmailsAsync(ctx context.Context, us
 		userID,
 	).Scan(&userEmail, &userName)
 	if err != nil {
-		log.Error("Failed to get user for email", "user_id", userID, "error", err)
-		return
+		return fmt.Errorf("get user %d for email: %w", userID, err)
 	}
 	if userEmail == "" {
 		log.Warn("User has no email", "user_id", userID)
-		return
+		return nil
 	}
 
 	// Lấy thông tin event + venue (chung cho tất cả vé)
-	var eventTitle string
-	var startTime time.Time
-	var areaID sql.NullInt64
-	var areaName sql.NullString
-	var venueName sql.NullString
-	var venueLocation sql.NullString
-	err = r.db.QueryRowContext(ctx,
-		`SELECT e.title, e.start_time, e.area_id, 
-		        COALESCE(va.area_name, 'Chưa xác định') as area_name,
-		        COALESCE(v.venue_name, 'Chưa xác định') as venue_name,
-		        COALESCE(v.location, 'Chưa xác định') as location
-		 FROM Event e
-		 LEFT JOIN Venue_Area va ON e.area_id = va.area_id
-		 LEFT JOIN Venue v ON va.venue_id = v.venue_id
-		 WHERE e.event_id = ?`,
-		eventID,
-	).Scan(&eventTitle, &startTime, &areaID, &areaName, &venueName, &venueLocation)
+	eventInfo, err := r.events.GetEventBookingInfo(ctx, eventID)
 	if err != nil {
-		log.Error("Failed to get event for email", "event_id", eventID, "error", err)
-		return
+		return fmt.Errorf("get event %d for email: %w", eventID, err)
 	}
+	eventTitle, startTime := eventInfo.Title, eventInfo.StartTime
 
 	finalVenueName := "Chưa xác định"
 	finalVenueAddress := "Chưa xác định"
 	finalAreaName := "Chưa xác định"
 
-	if areaName.Valid && areaName.String != "" && areaName.String != "Chưa xác định" {
-		finalAreaName = areaName.String
+	if eventInfo.AreaName != nil && *eventInfo.AreaName != "" {
+		finalAreaName = *eventInfo.AreaName
 	}
-	if venueName.Valid && venueName.String != "" && venueName.String != "Chưa xác định" {
-		finalVenueName = venueName.String
+	if eventInfo.VenueName != nil && *eventInfo.VenueName != "" {
+		finalVenueName = *eventInfo.VenueName
 	}
-	if venueLocation.Valid && venueLocation.String != "" && venueLocation.String != "Chưa xác định" {
-		finalVenueAddress = venueLocation.String
+	if eventInfo.VenueLocation != nil && *eventInfo.VenueLocation != "" {
+		finalVenueAddress = *eventInfo.VenueLocation
 	}
 
 	// Lấy category name
@@ -1332,6 +1231,7 @@ func (r *TicketRepository) sendMultipleTicketEmailsAsync(ctx context.Context, us
 
 	// Generate PDF cho MỖI vé
 	pdfAttachments := []email.PDFAttachment{}
+	var pdfStyle *ticketPDFStyle // Tải template/ảnh khi tạo PDF đầu tiên, dùng chung cho các vé sau
 	seatCodes := []string{}
 
 	for _, ticketID := range ticketIDs {
@@ -1355,9 +1255,10 @@ func (r *TicketRepository) sendMultipleTicketEmailsAsync(ctx context.Context, us
 			"SELECT seat_code FROM Seat WHERE seat_id = ?",
 			seatID,
 		).Scan(&seatCode)
+		// Giá đã trả theo bill (bậc giá lúc tạo link VNPay), không phải giá hiện tại của loại vé
 		r.db.QueryRowContext(ctx,
-			"SELECT price FROM Category_Ticket WHERE category_ticket_id = ?",
-			categoryTicketID,
+			"SELECT "+paidUnitPriceSQL+" FROM Ticket t JOIN Category_Ticket ct ON ct.category_ticket_id = t.category_ticket_id LEFT JOIN Bill b ON b.bill_id = t.bill_id WHERE t.ticket_id = ?",
+			ticketID,
 		).Scan(&price)
 
 		seatCodes = append(seatCodes, seatCode)
@@ -1391,7 +1292,10 @@ func (r *TicketRepository) sendMultipleTicketEmailsAsync(ctx context.Context, us
 
 		// Generate PDF
 		if qrPngBytes != nil && len(qrPngBytes) > 0 {
-			pdfBytes, err := ticketpdf.GenerateTicketPDF(ticketpdf.TicketPDFData{
+			if pdfStyle == nil {
+				pdfStyle = r.loadTicketPDFStyle(ctx, eventID)
+			}
+			pdfBytes, err := pdfStyle.render(ticketpdf.TicketPDFData{
 				TicketCode:   fmt.Sprintf("TKT_%d", ticketID),
 				EventName:    eventTitle,
 				EventDate:    startTime,
@@ -1421,8 +1325,7 @@ func (r *TicketRepository) sendMultipleTicketEmailsAsync(ctx context.Context, us
 	}
 
 	if len(pdfAttachments) == 0 {
-		log.Error("No PDFs generated - cannot send email")
-		return
+		return fmt.Errorf("no ticket PDFs generated for bill %d", billID)
 	}
 
 	// Gửi 1 email với TẤT CẢ PDF attachments
@@ -1431,7 +1334,8 @@ func (r *TicketRepository) sendMultipleTicketEmailsAsync(ctx context.Context, us
 	// Format seat list cho email body
 	seatListStr := strings.Join(seatCodes, ", ")
 
-	err = emailService.SendMultipleTicketsEmail(email.MultipleTicketsEmailData{
+	emailSpan := startEmailSpan(ctx, "SendMultipleTicketsEmail", len(ticketIDs))
+	err = email.DefaultQueue().Send(ctx, email.TemplateMultipleTickets, emailService.BuildMultipleTicketsEmail(email.MultipleTicketsEmailData{
 		UserEmail:      userEmail,
 		UserName:       userName,
 		EventTitle:     eventTitle,
@@ -1443,13 +1347,17 @@ func (r *TicketRepository) sendMultipleTicketEmailsAsync(ctx context.Context, us
 		TotalAmount:    formatCurrency(totalAmount),
 		GoogleMapsURL:  mapURL,
 		PDFAttachments: pdfAttachments,
-	})
+		Sponsors:       r.goldSponsorLogos(ctx, eventID),
+	}), fmt.Sprintf("bill#%d", billID))
+	emailSpan.RecordError(err)
+	emailSpan.End()
 
 	if err != nil {
 		log.Error("Failed to send multiple tickets email", "user_email", userEmail, "ticket_count", len(ticketIDs), "error", err)
 	} else {
 		log.Info("Multiple tickets email sent successfully", "user_email", userEmail, "ticket_count", len(ticketIDs))
 	}
+	return nil
 }
 
 // parseBase64ToPNG converts base64 string to PNG bytes
@@ -1522,9 +1430,14 @@ func (r *TicketRepository) GetUserWalletBalance(ctx context.Context, userID int)
 	return balance, nil
 }
 
-// CalculateSeatsTotal - Tính tổng giá cho các ghế
+// CalculateSeatsTotal - Tính tổng giá cho các ghế (giá đang áp dụng của loại vé ± giá riêng của ghế)
 // KHỚP VỚI Java: SeatService.calculateSeatsPrice()
 func (r *TicketRepository) CalculateSeatsTotal(ctx context.Context, eventID int, seatIDs []int) (int, error) {
+	return calculateSeatsTotal(ctx, r.db, eventID, seatIDs)
+}
+
+// calculateSeatsTotal - Dùng được trong transaction (thanh toán ví tính lại giá lúc mua)
+func calculateSeatsTotal(ctx context.Context, q queryRower, eventID int, seatIDs []int) (int, error) {
 	if len(seatIDs) == 0 {
 		return 0, fmt.Errorf("no seats provided")
 	}
@@ -1544,7 +1457,7 @@ func (r *TicketRepository) CalculateSeatsTotal(ctx context.Context, eventID int,
 	}
 
 	query := fmt.Sprintf(`
-		SELECT COALESCE(SUM(ct.price), 0) as total
+		SELECT COALESCE(SUM(`+seatPriceSQL+`), 0) as total
 		FROM Seat s
 		JOIN Category_Ticket ct ON s.category_ticket_id = ct.category_ticket_id
 		WHERE ct.event_id = ? AND s.seat_id IN (%s)
@@ -1555,7 +1468,7 @@ func (r *TicketRepository) CalculateSeatsTotal(ctx context.Context, eventID int,
 	fmt.Printf("[SQL_FIX_SUCCESS] Đã đổi alias sang ct.event_id cho EventID: %d\n", eventID)
 
 	var total float64
-	err := r.db.QueryRowContext(ctx, query, args...).Scan(&total)
+	err := q.QueryRowContext(ctx, query, args...).Scan(&total)
 	if err != nil {
 		fmt.Printf("[SQL_FIX] ❌ Error calculating seats total: %v\n", err)
 		return 0, fmt.Errorf("error calculating seats total: %w", err)
@@ -1579,15 +1492,16 @@ func (r *TicketRepository) CalculateSeatsTotal(ctx context.Context, eventID int,
 // 4. Deduct balance atomically
 // 5. Commit (releases lock)
 // 6. Send email notifications
-func (r *TicketRepository) ProcessWalletPayment(ctx context.Context, userID, eventID, categoryTicketID int, seatIDs []int, amount int) (string, error) {
+//
+// installment: chỉ trừ tiền cọc; Bill ghi tổng tiền (PARTIAL) kèm lịch các kỳ còn lại
+func (r *TicketRepository) ProcessWalletPayment(ctx context.Context, userID, eventID, categoryTicketID int, seatIDs []int, amount int, installment bool) (string, error) {
 	// ===== VALIDATION: CHECK EVENT STATUS BEFORE TRANSACTION =====
 	// Prevent booking on closed/cancelled events
-	var eventStatus string
-	var startTime time.Time
-	err := r.db.QueryRowContext(ctx, "SELECT status, start_time FROM Event WHERE event_id = ?", eventID).Scan(&eventStatus, &startTime)
+	eventInfo, err := r.events.GetEventBookingInfo(ctx, eventID)
 	if err != nil {
 		return "", fmt.Errorf("event not found")
 	}
+	eventStatus, startTime := eventInfo.Status, eventInfo.StartTime
 
 	// Event phải ở trạng thái OPEN để có thể mua vé
 	if eventStatus != "OPEN" {
@@ -1603,6 +1517,11 @@ func (r *TicketRepository) ProcessWalletPayment(ctx context.Context, userID, eve
 		return "", fmt.Errorf("Sự kiện đã bắt đầu hoặc kết thúc, không thể đặt thêm vé")
 	}
 
+	// Ghế người đi kèm phải mua cùng ghế xe lăn liền kề
+	if err := r.checkCompanionSeats(ctx, seatIDs); err != nil {
+		return "", err
+	}
+
 	// Start transaction with appropriate isolation level
 	opts := &sql.TxOptions{
 		Isolation: sql.LevelRepeatableRead, // Prevents dirty reads and non-repeatable reads
@@ -1633,13 +1552,36 @@ func (r *TicketRepository) ProcessWalletPayment(ctx context.Context, userID, eve
 		return "", fmt.Errorf("error locking user balance: %w", err)
 	}
 
+	// Giá tính lại trong transaction theo bậc giá đang hiệu lực lúc mua
+	// (bậc giá có thể vừa đổi sau lúc handler tính amount)
+	purchaseAmount, err := calculateSeatsTotal(ctx, tx, eventID, seatIDs)
+	if err != nil {
+		return "", err
+	}
+	if purchaseAmount != amount {
+		fmt.Printf("[PRICE_TIER] Price changed during checkout for userID=%d: %d -> %d\n", userID, amount, purchaseAmount)
+		amount = purchaseAmount
+	}
+
+	// Số tiền trừ ví: toàn bộ, hoặc tiền cọc khi trả góp
+	charge := amount
+	var plan billing.InstallmentPlan
+	if installment {
+		p, deposit, err := installmentDeposit(ctx, tx, eventID, startTime, int64(amount))
+		if err != nil {
+			return "", err
+		}
+		plan, charge = p, int(deposit)
+	}
+	eventStart := startTime
+
 	fmt.Printf("[WALLET_FINAL_CHECK] User %d has Wallet: %f\n", userID, currentBalance)
 	fmt.Printf("[PAYMENT_CHECK] UserID: %d, Balance: %.2f, Amount: %d (%.2f VND)\n", userID, currentBalance, amount, float64(amount))
 	fmt.Printf("[DEBUG] ProcessWalletPayment: Current balance=%.2f, Required amount=%d\n", currentBalance, amount)
 
 	// Check if sufficient balance
-	if currentBalance < float64(amount) {
-		insufficientAmount := float64(amount) - currentBalance
+	if currentBalance < float64(charge) {
+		insufficientAmount := float64(charge) - currentBalance
 		fmt.Printf("[PAYMENT_CHECK] ❌ INSUFFICIENT BALANCE - UserID: %d, Balance: %.2f, Required: %d, Shortage: %.2f\n", userID, currentBalance, amount, insufficientAmount)
 		fmt.Printf("[DEBUG] ProcessWalletPayment: INSUFFICIENT BALANCE - need %.2f more, current %.2f\n", insufficientAmount, currentBalance)
 		return "", fmt.Errorf("insufficient_balance|%d|%.0f", int(insufficientAmount), currentBalance)
@@ -1647,6 +1589,14 @@ func (r *TicketRepository) ProcessWalletPayment(ctx context.Context, userID, eve
 
 	fmt.Printf("[PAYMENT_CHECK] ✅ SUFFICIENT BALANCE - UserID: %d, Balance: %.2f, Required: %d, Remaining after: %.2f\n", userID, currentBalance, amount, currentBalance-float64(amount))
 
+	// Giữ suất của loại vé trong cùng transaction (thay cho COUNT(*) trên Ticket)
+	if err := reserveInventory(ctx, tx, categoryTicketID, len(seatIDs)); err != nil {
+		if errors.Is(err, errInventoryExhausted) {
+			return "", apperrors.BusinessError(fmt.Sprintf("Không đủ vé. Yêu cầu: %d", len(seatIDs)))
+		}
+		return "", err
+	}
+
 	// ===== STEP 2: CREATE TICKETS =====
 	// Collect ticket info for email and PDF generation
 	ticketIds := []string{}
@@ -1663,22 +1613,16 @@ func (r *TicketRepository) ProcessWalletPayment(ctx context.Context, userID, eve
 		fmt.Printf("[SQL_FIX] Creating ticket for seatID: %d, userID: %d, eventID: %d\n", seatID, userID, eventID)
 
 		// Create ticket in database with PENDING_QR (will update after getting ticketID)
-		insertTicketQuery := `
-			INSERT INTO Ticket (user_id, event_id, category_ticket_id, seat_id, qr_code_value, status, created_at)
-			VALUES (?, ?, ?, ?, 'PENDING_QR', 'BOOKED', NOW())
-		`
-
-		result, err := tx.ExecContext(ctx, insertTicketQuery, userID, eventID, categoryTicketID, seatID)
+		// Ghế đã có vé hiệu lực -> DB chặn, transaction rollback và trừ ví không xảy ra
+		ticketID, err := insertSeatTicket(ctx, tx, userID, eventID, categoryTicketID, seatID, "BOOKED", nil)
 		if err != nil {
 			fmt.Printf("[SQL_FIX] ❌ Error creating ticket: %v\n", err)
+			if errors.Is(err, errSeatTaken) {
+				return "", apperrors.BusinessError(fmt.Sprintf("Ghế ID %d đã được người khác giữ/đặt", seatID))
+			}
 			return "", fmt.Errorf("error creating ticket: %w", err)
 		}
 
-		ticketID, err := result.LastInsertId()
-		if err != nil {
-			return "", fmt.Errorf("error getting ticket ID: %w", err)
-		}
-
 		// Generate QR code Base64 from ticketID (same as VNPAY)
 		qrBase64, err := qrcode.GenerateTicketQRBase64(int(ticketID), 300)
 		if err != nil {
@@ -1709,7 +1653,7 @@ func (r *TicketRepository) ProcessWalletPayment(ctx context.Context, userID, eve
 				va.area_name,
 				s.seat_code,
 				ct.name as category_name,
-				ct.price,
+				` + seatPriceSQL + `,
 				u.email,
 				u.full_name
 			FROM Ticket t
@@ -1755,7 +1699,7 @@ func (r *TicketRepository) ProcessWalletPayment(ctx context.Context, userID, eve
 	// This UPDATE happens while user row is locked (from SELECT ... FOR UPDATE)
 	// No other transaction can modify this user's balance until we COMMIT or ROLLBACK
 	updateWalletQuery := `UPDATE users SET Wallet = Wallet - ? WHERE user_id = ? AND Wallet >= ?`
-	result, err := tx.ExecContext(ctx, updateWalletQuery, amount, userID, amount)
+	result, err := tx.ExecContext(ctx, updateWalletQuery, charge, userID, charge)
 	if err != nil {
 		return "", fmt.Errorf("error updating wallet: %w", err)
 	}
@@ -1767,14 +1711,18 @@ func (r *TicketRepository) ProcessWalletPayment(ctx context.Context, userID, eve
 		return "", fmt.Errorf("Số dư ví không đủ để hoàn tất giao dịch")
 	}
 
-	fmt.Printf("[PAYMENT_CHECK] ✅ WALLET DEDUCTED - UserID: %d, Amount: %d, New Balance: %.2f\n", userID, amount, currentBalance-float64(amount))
-	fmt.Printf("[DEBUG] ProcessWalletPayment: Successfully deducted %d from userID=%d\n", amount, userID)
+	fmt.Printf("[PAYMENT_CHECK] ✅ WALLET DEDUCTED - UserID: %d, Amount: %d, New Balance: %.2f\n", userID, charge, currentBalance-float64(charge))
+	fmt.Printf("[DEBUG] ProcessWalletPayment: Successfully deducted %d from userID=%d\n", charge, userID)
 
 	// ===== STEP 3.5: CREATE BILL =====
 	// Create bill record for this wallet payment within the same transaction
+	billStatus := "PAID"
+	if installment {
+		billStatus = "PARTIAL"
+	}
 	billResult, err := tx.ExecContext(ctx,
-		"INSERT INTO Bill (user_id, total_amount, currency, payment_method, payment_status, created_at, paid_at) VALUES (?, ?, 'VND', 'Wallet', 'PAID', NOW(), NOW())",
-		userID, float64(amount),
+		"INSERT INTO Bill (user_id, total_amount, currency, payment_method, payment_status, created_at, paid_at, vat_bps) VALUES (?, ?, 'VND', 'Wallet', ?, NOW(), IF(? = 'PAID', NOW(), NULL), ?)",
+		userID, float64(amount), billStatus, billStatus, currentVATBps(ctx),
 	)
 	if err != nil {
 		return "", fmt.Errorf("error creating bill: %w", err)
@@ -1787,6 +1735,42 @@ func (r *TicketRepository) ProcessWalletPayment(ctx context.Context, userID, eve
 
 	fmt.Printf("[BILL_CREATED] ✅ Da xuat hoa don ID: %d cho phuong thuc: %s\n", billID, "Wallet")
 
+	// Sổ cái: Nợ USER_WALLET / Có PLATFORM_REVENUE
+	entryID, err := ledger.Post(ctx, tx, ledger.BillPayment(int(billID), userID, "Wallet", float64(charge)))
+	if err != nil {
+		return "", fmt.Errorf("error posting bill to ledger: %w", err)
+	}
+	// payment_ref: bút toán trừ ví là mã giao dịch in trên biên lai
+	paymentRef := fmt.Sprintf("WALLET-%d", entryID)
+	if entryID > 0 {
+		if _, err := tx.ExecContext(ctx, "UPDATE Bill SET payment_ref = ? WHERE bill_id = ?", paymentRef, billID); err != nil {
+			return "", fmt.Errorf("error saving payment reference: %w", err)
+		}
+	}
+	if installment {
+		if err := insertInstallmentSchedule(ctx, tx, billID, plan, int64(amount), int64(charge), eventStart, "Wallet", paymentRef); err != nil {
+			return "", err
+		}
+	}
+
+	// Dòng hóa đơn: giá từng vé + phí nền tảng đang áp cho sự kiện
+	billTicketIDs := make([]int, 0, len(ticketIds))
+	for _, idStr := range ticketIds {
+		id, _ := strconv.Atoi(idStr)
+		billTicketIDs = append(billTicketIDs, id)
+	}
+	if err := insertBillItems(ctx, tx, int(billID), eventID, float64(amount), billTicketIDs); err != nil {
+		return "", err
+	}
+	// Biên lai gửi khi hóa đơn trả đủ (trả góp: sau kỳ cuối)
+	var receiptMessageID int64
+	if !installment {
+		receiptMessageID, err = enqueueReceiptEmail(ctx, tx, int(billID))
+		if err != nil {
+			return "", fmt.Errorf("error scheduling payment receipt: %w", err)
+		}
+	}
+
 	// ===== STEP 4: COMMIT TRANSACTION =====
 	// This releases the lock and makes changes permanent
 	if err = tx.Commit(); err != nil {
@@ -1794,10 +1778,18 @@ func (r *TicketRepository) ProcessWalletPayment(ctx context.Context, userID, eve
 	}
 
 	fmt.Printf("[DEBUG] ProcessWalletPayment: Transaction committed for userID=%d\n", userID)
+	if receiptMessageID > 0 {
+		go outbox.Default().Deliver(context.WithoutCancel(ctx), receiptMessageID)
+	}
+	eventbus.Publish(ctx, eventbus.TicketBooked{
+		BillID: int(billID), UserID: userID, EventID: eventID, TicketIDs: billTicketIDs,
+		TotalAmount: float64(charge), PaymentMethod: "wallet", OccurredAt: time.Now(),
+	})
 
 	// ===== STEP 4.5: GENERATE PDF TICKETS WITH QR CODES =====
 	// Generate PDF for each ticket to attach to email
 	pdfAttachments := []email.PDFAttachment{}
+	var pdfStyle *ticketPDFStyle // Tải template/ảnh khi tạo PDF đầu tiên, dùng chung cho các vé sau
 
 	for i, ticketIDStr := range ticketIds {
 		// Convert ticket ID to int
@@ -1830,7 +1822,10 @@ func (r *TicketRepository) ProcessWalletPayment(ctx context.Context, userID, eve
 		}
 
 		// Generate PDF ticket
-		pdfBytes, err := ticketpdf.GenerateTicketPDF(ticketpdf.TicketPDFData{
+		if pdfStyle == nil {
+			pdfStyle = r.loadTicketPDFStyle(ctx, eventID)
+		}
+		pdfBytes, err := pdfStyle.render(ticketpdf.TicketPDFData{
 			TicketCode:     fmt.Sprintf("TKT_%d", ticketID),
 			EventName:      eventTitle,
 			EventDate:      startTime,
@@ -1880,6 +1875,7 @@ func (r *TicketRepository) ProcessWalletPayment(ctx context.Context, userID, eve
 				StartTime:     startTime.Format("2006-01-02 15:04"),
 				PaymentMethod: "wallet",
 				MapURL:        fmt.Sprintf("https://www.google.com/maps/search/?api=1&query=%s", url.QueryEscape(venueAddress)),
+				Sponsors:      r.goldSponsorLogos(ctx, eventID),
 			}
 			// Add PDF attachment if generated
 			if len(pdfAttachments) > 0 {
@@ -1887,7 +1883,11 @@ func (r *TicketRepository) ProcessWalletPayment(ctx context.Context, userID, eve
 				emailData.PDFFilename = pdfAttachments[0].Filename
 				fmt.Printf("[EMAIL] Sending single ticket email with PDF: %s\n", pdfAttachments[0].Filename)
 			}
-			if err := emailService.SendTicketEmail(emailData); err != nil {
+			emailSpan := startEmailSpan(ctx, "SendTicketEmail", 1)
+			err := email.DefaultQueue().Send(ctx, email.TemplateTicket, emailService.BuildTicketEmail(emailData), fmt.Sprintf("bill#%d", billID))
+			emailSpan.RecordError(err)
+			emailSpan.End()
+			if err != nil {
 				fmt.Printf("[WARN] Failed to send ticket email: %v\n", err)
 				// Continue anyway - tickets are already created
 			}
@@ -1904,13 +1904,18 @@ func (r *TicketRepository) ProcessWalletPayment(ctx context.Context, userID, eve
 				SeatList:      seatList,
 				TotalAmount:   fmt.Sprintf("%.0f", totalPrice),
 				GoogleMapsURL: fmt.Sprintf("https://www.google.com/maps/search/?api=1&query=%s", url.QueryEscape(venueAddress)),
+				Sponsors:      r.goldSponsorLogos(ctx, eventID),
 			}
 			// Add PDF attachments if generated
 			if len(pdfAttachments) > 0 {
 				emailData.PDFAttachments = pdfAttachments
 				fmt.Printf("[EMAIL] Sending multiple tickets email with %d PDFs\n", len(pdfAttachments))
 			}
-			if err := emailService.SendMultipleTicketsEmail(emailData); err != nil {
+			emailSpan := startEmailSpan(ctx, "SendMultipleTicketsEmail", len(ticketIds))
+			err := email.DefaultQueue().Send(ctx, email.TemplateMultipleTickets, emailService.BuildMultipleTicketsEmail(emailData), fmt.Sprintf("bill#%d", billID))
+			emailSpan.RecordError(err)
+			emailSpan.End()
+			if err != nil {
 				fmt.Printf("[WARN] Failed to send multiple tickets email: %v\n", err)
 				// Continue anyway - tickets are already created
 			}
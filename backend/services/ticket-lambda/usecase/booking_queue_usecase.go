@@ -0,0 +1,97 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	apperrors "github.com/fpt-event-services/common/errors"
+	"github.com/fpt-event-services/common/waitingroom"
+	"github.com/fpt-event-services/services/ticket-lambda/models"
+)
+
+// BookingQueueOpen - Sự kiện không bật hàng chờ, client đặt vé ngay
+const BookingQueueOpen = "OPEN"
+
+// JoinBookingQueue - Vào hàng chờ mở bán của sự kiện; gọi lại trả về chỗ cũ
+func (uc *TicketUseCase) JoinBookingQueue(ctx context.Context, userID, eventID int) (*models.BookingQueueStatus, error) {
+	config, err := uc.ticketRepo.GetBookingQueueConfig(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	if !config.Enabled {
+		return &models.BookingQueueStatus{EventID: eventID, Status: BookingQueueOpen}, nil
+	}
+	pos, err := waitingroom.Join(ctx, eventID, userID)
+	if err != nil {
+		return nil, apperrors.DatabaseError(err)
+	}
+	return bookingQueueStatus(eventID, pos), nil
+}
+
+// GetBookingQueueStatus - Vị trí theo queue token (một UPDATE + một SELECT theo index, client hỏi liên tục được)
+func (uc *TicketUseCase) GetBookingQueueStatus(ctx context.Context, eventID int, token string) (*models.BookingQueueStatus, error) {
+	pos, err := waitingroom.Status(ctx, eventID, token)
+	if errors.Is(err, waitingroom.ErrUnknownToken) {
+		return nil, apperrors.NotFound("Queue token")
+	}
+	if err != nil {
+		return nil, apperrors.DatabaseError(err)
+	}
+	return bookingQueueStatus(eventID, pos), nil
+}
+
+// AdmitBooking - Kiểm tra trước khi giữ ghế: nil khi sự kiện không bật hàng chờ hoặc user
+// đã được vào; ngược lại xếp user vào hàng (nếu chưa có) và trả vị trí để client chờ
+func (uc *TicketUseCase) AdmitBooking(ctx context.Context, userID, eventID int) (*models.BookingQueueStatus, error) {
+	config, err := uc.ticketRepo.GetBookingQueueConfig(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	if !config.Enabled {
+		return nil, nil
+	}
+	admitted, err := waitingroom.Admitted(ctx, eventID, userID)
+	if err != nil {
+		return nil, apperrors.DatabaseError(err)
+	}
+	if admitted {
+		return nil, nil
+	}
+	pos, err := waitingroom.Join(ctx, eventID, userID)
+	if err != nil {
+		return nil, apperrors.DatabaseError(err)
+	}
+	status := bookingQueueStatus(eventID, pos)
+	if status.Status == waitingroom.StatusAdmitted {
+		return nil, nil
+	}
+	status.Message = "Sự kiện đang mở bán qua hàng chờ, vui lòng chờ tới lượt để giữ ghế"
+	return status, nil
+}
+
+// CheckGuestBookingQueue - Khách không có tài khoản không xếp hàng được nên tạm đóng mua vé khách
+// trong lúc sự kiện bật hàng chờ
+func (uc *TicketUseCase) CheckGuestBookingQueue(ctx context.Context, eventID int) error {
+	config, err := uc.ticketRepo.GetBookingQueueConfig(ctx, eventID)
+	if err != nil {
+		return err
+	}
+	if config.Enabled {
+		return apperrors.BusinessError("Sự kiện đang mở bán qua hàng chờ, vui lòng đăng nhập để xếp hàng mua vé")
+	}
+	return nil
+}
+
+func bookingQueueStatus(eventID int, pos waitingroom.Position) *models.BookingQueueStatus {
+	status := &models.BookingQueueStatus{EventID: eventID, Status: pos.Status, QueueToken: pos.Token}
+	if pos.Status == waitingroom.StatusAdmitted {
+		admittedUntil := pos.AdmittedUntil
+		status.AdmittedUntil = &admittedUntil
+		return status
+	}
+	status.Position = pos.Position
+	status.EstimatedWaitSeconds = int(pos.EstimatedWait / time.Second)
+	status.PollAfterSeconds = int(waitingroom.Default().Interval() / time.Second)
+	return status
+}
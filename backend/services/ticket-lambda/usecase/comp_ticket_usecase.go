@@ -0,0 +1,61 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	apperrors "github.com/fpt-event-services/common/errors"
+	"github.com/fpt-event-services/common/tracing"
+	"github.com/fpt-event-services/common/validator"
+	"github.com/fpt-event-services/services/ticket-lambda/models"
+)
+
+// maxCompTicketsPerRequest - Giới hạn số email mỗi lần phát vé mời
+const maxCompTicketsPerRequest = 100
+
+// IssueCompTickets - Phát vé mời 0 đồng cho danh sách email (chủ sự kiện, ADMIN)
+func (uc *TicketUseCase) IssueCompTickets(ctx context.Context, issuerID int, role string, eventID int, req models.CompTicketRequest) (*models.CompTicketResponse, error) {
+	if req.CategoryTicketID <= 0 {
+		return nil, apperrors.ValidationError("categoryTicketId is required")
+	}
+	emails, err := normalizeCompEmails(req.Emails)
+	if err != nil {
+		return nil, err
+	}
+	req.Emails = emails
+
+	ctx, span := tracing.Start(ctx, "TicketUseCase.IssueCompTickets", tracing.WithAttributes(
+		tracing.Int("event.id", eventID),
+		tracing.Int("comp.requested", len(emails)),
+	))
+	defer span.End()
+
+	resp, err := uc.ticketRepo.IssueCompTickets(ctx, issuerID, role, eventID, req)
+	span.RecordError(err)
+	return resp, err
+}
+
+// normalizeCompEmails chuẩn hóa (lowercase, bỏ khoảng trắng), loại trùng và kiểm tra định dạng
+func normalizeCompEmails(emails []string) ([]string, error) {
+	seen := make(map[string]bool, len(emails))
+	out := make([]string, 0, len(emails))
+	for _, e := range emails {
+		e = strings.ToLower(strings.TrimSpace(e))
+		if e == "" || seen[e] {
+			continue
+		}
+		if !validator.IsValidEmail(e) {
+			return nil, apperrors.ValidationError(fmt.Sprintf("Email không hợp lệ: %s", e))
+		}
+		seen[e] = true
+		out = append(out, e)
+	}
+	if len(out) == 0 {
+		return nil, apperrors.ValidationError("emails is required")
+	}
+	if len(out) > maxCompTicketsPerRequest {
+		return nil, apperrors.ValidationError(fmt.Sprintf("Tối đa %d email mỗi lần", maxCompTicketsPerRequest))
+	}
+	return out, nil
+}
@@ -0,0 +1,168 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/fpt-event-services/common/email"
+	apperrors "github.com/fpt-event-services/common/errors"
+	"github.com/fpt-event-services/common/eventbus"
+	"github.com/fpt-event-services/common/validator"
+	"github.com/fpt-event-services/services/ticket-lambda/models"
+)
+
+// ============================================================
+// Khách mua vé không có tài khoản
+// POST /api/guest/checkout: tạo (hoặc dùng lại) user GUEST theo email rồi đi
+// đúng luồng VNPay như sinh viên; email vé QR + PDF tới email khách như thường.
+// Sau khi vé BOOKED, subscriber TicketBooked gửi thêm mã tra cứu; khách xem lại vé
+// bằng GET /api/guest/tickets?code=... cho tới khi đăng ký tài khoản bằng email đó
+// ============================================================
+
+const (
+	guestCodePrefix = "GT"
+	// guestCodeBytes - 10 byte ngẫu nhiên = 16 ký tự base32 (80 bit)
+	guestCodeBytes = 10
+	// guestLookupCodeTTL - Mã tra cứu hết hạn sau khoảng này
+	guestLookupCodeTTL = 180 * 24 * time.Hour
+)
+
+// guestCodeEncoding - Base32 Crockford (bỏ I, L, O, U để khách đọc / gõ lại không nhầm)
+var guestCodeEncoding = base32.NewEncoding("0123456789ABCDEFGHJKMNPQRSTVWXYZ").WithPadding(base32.NoPadding)
+
+// GuestCheckout - Giữ ghế và tạo URL VNPay cho khách (sự kiện phải bật allow_guest_checkout)
+func (uc *TicketUseCase) GuestCheckout(ctx context.Context, req models.GuestCheckoutRequest) (*models.PaymentInitResult, error) {
+	req.Email = strings.ToLower(strings.TrimSpace(req.Email))
+	req.FullName = strings.TrimSpace(req.FullName)
+	req.Phone = strings.TrimSpace(req.Phone)
+	if msg := validator.GetEmailError(req.Email); msg != "" {
+		return nil, apperrors.ValidationError(msg)
+	}
+	if msg := validator.GetFullNameError(req.FullName); msg != "" {
+		return nil, apperrors.ValidationError(msg)
+	}
+	if msg := validator.GetPhoneError(req.Phone); msg != "" {
+		return nil, apperrors.ValidationError(msg)
+	}
+	if req.EventID <= 0 || req.CategoryTicketID <= 0 || len(req.SeatIDs) == 0 {
+		return nil, apperrors.ValidationError("eventId, categoryTicketId và seatIds là bắt buộc")
+	}
+
+	if err := uc.ticketRepo.CheckGuestCheckoutAllowed(ctx, req.EventID); err != nil {
+		return nil, err
+	}
+	if err := uc.CheckGuestBookingQueue(ctx, req.EventID); err != nil {
+		return nil, err
+	}
+	userID, err := uc.ticketRepo.FindOrCreateGuestUser(ctx, req.Email, req.FullName, req.Phone)
+	if err != nil {
+		return nil, err
+	}
+	return uc.CreatePaymentURL(ctx, userID, req.EventID, req.CategoryTicketID, req.SeatIDs, false)
+}
+
+// LookupGuestTickets - Vé của khách theo mã tra cứu
+func (uc *TicketUseCase) LookupGuestTickets(ctx context.Context, code string) (*models.GuestTicketsResponse, error) {
+	normalized, ok := normalizeGuestCode(code)
+	if !ok {
+		return nil, apperrors.NotFound("Mã tra cứu")
+	}
+	userID, err := uc.ticketRepo.FindGuestByLookupCode(ctx, hashGuestCode(normalized))
+	if err != nil {
+		return nil, err
+	}
+	tickets, err := uc.ticketRepo.GetTicketsByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &models.GuestTicketsResponse{Tickets: tickets}, nil
+}
+
+// RegisterSubscribers - Side effect của ticket-lambda trên bus (gọi sau khi đã kết nối DB)
+func RegisterSubscribers(bus *eventbus.Bus) {
+	uc := NewTicketUseCase()
+	eventbus.On(bus, "guest-lookup-code", eventbus.Async, func(ctx context.Context, e eventbus.TicketBooked) error {
+		return uc.sendGuestLookupCode(ctx, e.UserID, e.EventID, e.BillID)
+	})
+}
+
+// sendGuestLookupCode - Tạo mã tra cứu mới cho lần mua của khách và gửi qua email
+// Tài khoản thường bỏ qua; mỗi lần mua một mã riêng, mã cũ vẫn dùng được tới khi hết hạn
+func (uc *TicketUseCase) sendGuestLookupCode(ctx context.Context, userID, eventID, billID int) error {
+	guest, ok, err := uc.ticketRepo.GetGuestContact(ctx, userID)
+	if err != nil || !ok {
+		return err
+	}
+
+	code, err := generateGuestCode()
+	if err != nil {
+		return err
+	}
+	expiresAt := time.Now().Add(guestLookupCodeTTL)
+	normalized, _ := normalizeGuestCode(code)
+	if err := uc.ticketRepo.SaveGuestLookupCode(ctx, userID, billID, hashGuestCode(normalized), expiresAt); err != nil {
+		return err
+	}
+
+	msg := email.NewEmailService(nil).BuildGuestLookupEmail(guest.Email, guest.FullName,
+		uc.ticketRepo.GetEventTitle(ctx, eventID), code, guestLookupURL(code), expiresAt)
+	if err := email.DefaultQueue().Send(ctx, email.TemplateGuestLookup, msg, fmt.Sprintf("guest:%d:bill:%d", userID, billID)); err != nil {
+		log.Printf("[GUEST_CHECKOUT] ⚠️ Failed to send lookup code to user %d (bill %d): %v", userID, billID, err)
+	}
+	return nil
+}
+
+// generateGuestCode - Mã dạng GT-XXXX-XXXX-XXXX-XXXX
+func generateGuestCode() (string, error) {
+	raw := make([]byte, guestCodeBytes)
+	if _, err := rand.Read(raw); err != nil {
+		return "", fmt.Errorf("failed to generate guest lookup code: %w", err)
+	}
+	body := guestCodeEncoding.EncodeToString(raw)
+	groups := []string{guestCodePrefix}
+	for i := 0; i < len(body); i += 4 {
+		groups = append(groups, body[i:i+4])
+	}
+	return strings.Join(groups, "-"), nil
+}
+
+// normalizeGuestCode - Bỏ gạch / khoảng trắng / tiền tố, viết hoa, đổi ký tự dễ nhầm (O→0, I/L→1)
+func normalizeGuestCode(code string) (string, bool) {
+	s := strings.ToUpper(strings.Map(func(r rune) rune {
+		if r == '-' || r == ' ' {
+			return -1
+		}
+		return r
+	}, strings.TrimSpace(code)))
+	if len(s) == len(guestCodePrefix)+guestCodeEncoding.EncodedLen(guestCodeBytes) {
+		s = strings.TrimPrefix(s, guestCodePrefix)
+	}
+	s = strings.NewReplacer("O", "0", "I", "1", "L", "1").Replace(s)
+	if _, err := guestCodeEncoding.DecodeString(s); err != nil || len(s) != guestCodeEncoding.EncodedLen(guestCodeBytes) {
+		return "", false
+	}
+	return s, true
+}
+
+func hashGuestCode(normalized string) string {
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
+
+// guestLookupURL - Trang tra cứu vé của khách trên frontend
+func guestLookupURL(code string) string {
+	base := strings.TrimRight(os.Getenv("FRONTEND_URL"), "/")
+	if base == "" {
+		base = "http://localhost:3000"
+	}
+	return base + "/guest/tickets?code=" + url.QueryEscape(code)
+}
@@ -0,0 +1,37 @@
+package usecase
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerateGuestCodeRoundTrip(t *testing.T) {
+	code, err := generateGuestCode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(code, "GT-") || len(code) != len("GT-XXXX-XXXX-XXXX-XXXX") {
+		t.Fatalf("unexpected code format %q", code)
+	}
+	normalized, ok := normalizeGuestCode(code)
+	if !ok {
+		t.Fatalf("generated code %q does not normalize", code)
+	}
+	// Khách gõ lại bằng chữ thường, bỏ gạch, nhầm 0 thành O vẫn ra cùng hash
+	typed := strings.ReplaceAll(strings.ToLower(strings.ReplaceAll(code, "-", " ")), "0", "o")
+	again, ok := normalizeGuestCode(typed)
+	if !ok || hashGuestCode(again) != hashGuestCode(normalized) {
+		t.Fatalf("typed %q normalized to %q, want %q", typed, again, normalized)
+	}
+}
+
+func TestNormalizeGuestCodeRejectsMalformed(t *testing.T) {
+	for _, code := range []string{"", "GT-", "GT-1234-5678", "GT-7KQ2-M9XD-4HPA-WR3E-ZZZZ", "GT-7KQ2-M9XD-4HPA-WR3U"} {
+		if s, ok := normalizeGuestCode(code); ok {
+			t.Errorf("normalizeGuestCode(%q) = %q, want rejected", code, s)
+		}
+	}
+	if s, ok := normalizeGuestCode("7kq2m9xd4hpawr3e"); !ok || s != "7KQ2M9XD4HPAWR3E" {
+		t.Errorf("code without prefix = %q, %v", s, ok)
+	}
+}
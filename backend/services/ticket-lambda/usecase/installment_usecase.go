@@ -0,0 +1,83 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	apperrors "github.com/fpt-event-services/common/errors"
+	"github.com/fpt-event-services/common/tracing"
+	"github.com/fpt-event-services/services/ticket-lambda/models"
+)
+
+// Giới hạn cấu hình trả góp
+const (
+	minDepositPercent      = 10
+	maxDepositPercent      = 90
+	maxInstallmentCount    = 6
+	maxInstallmentInterval = 60
+)
+
+// GetInstallmentPlan - Cấu hình trả góp của sự kiện
+func (uc *TicketUseCase) GetInstallmentPlan(ctx context.Context, eventID int) (*models.InstallmentPlan, error) {
+	return uc.ticketRepo.GetInstallmentPlan(ctx, eventID)
+}
+
+// SaveInstallmentPlan - Organizer bật / sửa trả góp cho sự kiện của mình
+func (uc *TicketUseCase) SaveInstallmentPlan(ctx context.Context, userID int, role string, eventID int, req models.InstallmentPlanRequest) (*models.InstallmentPlan, error) {
+	if err := validateInstallmentPlan(req); err != nil {
+		return nil, err
+	}
+	return uc.ticketRepo.SaveInstallmentPlan(ctx, userID, role, eventID, req)
+}
+
+// DeleteInstallmentPlan - Tắt trả góp cho lượt mua mới
+func (uc *TicketUseCase) DeleteInstallmentPlan(ctx context.Context, userID int, role string, eventID int) error {
+	return uc.ticketRepo.DeleteInstallmentPlan(ctx, userID, role, eventID)
+}
+
+// GetBillInstallments - Lịch trả góp của hóa đơn của user
+func (uc *TicketUseCase) GetBillInstallments(ctx context.Context, userID, billID int) (*models.BillInstallments, error) {
+	return uc.ticketRepo.GetBillInstallments(ctx, billID, userID)
+}
+
+// PayInstallment - Trả một kỳ bằng ví (trả lịch mới) hoặc tạo link VNPay
+func (uc *TicketUseCase) PayInstallment(ctx context.Context, userID, billID, seq int, method string) (*models.InstallmentPaymentResult, error) {
+	ctx, span := tracing.Start(ctx, "TicketUseCase.PayInstallment", tracing.WithAttributes(
+		tracing.Int("user.id", userID),
+		tracing.Int("bill.id", billID),
+		tracing.Int("installment.seq", seq),
+		tracing.String("payment.method", method),
+	))
+	defer span.End()
+
+	result := &models.InstallmentPaymentResult{}
+	var err error
+	switch strings.ToLower(strings.TrimSpace(method)) {
+	case PassPaymentWallet:
+		result.Schedule, err = uc.ticketRepo.PayInstallmentWithWallet(ctx, userID, billID, seq)
+	case PassPaymentVNPay:
+		result.PaymentURL, err = uc.ticketRepo.CreateInstallmentVNPayURL(ctx, userID, billID, seq)
+	default:
+		err = apperrors.ValidationError("paymentMethod phải là wallet hoặc vnpay")
+	}
+	span.RecordError(err)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// validateInstallmentPlan - Tỷ lệ cọc, số kỳ sau cọc và khoảng cách giữa các kỳ (ngày) trong giới hạn
+func validateInstallmentPlan(req models.InstallmentPlanRequest) error {
+	if req.DepositPercent < minDepositPercent || req.DepositPercent > maxDepositPercent {
+		return apperrors.ValidationError(fmt.Sprintf("depositPercent phải từ %d đến %d", minDepositPercent, maxDepositPercent))
+	}
+	if req.InstallmentCount < 1 || req.InstallmentCount > maxInstallmentCount {
+		return apperrors.ValidationError(fmt.Sprintf("installmentCount phải từ 1 đến %d", maxInstallmentCount))
+	}
+	if req.IntervalDays < 1 || req.IntervalDays > maxInstallmentInterval {
+		return apperrors.ValidationError(fmt.Sprintf("intervalDays phải từ 1 đến %d", maxInstallmentInterval))
+	}
+	return nil
+}
@@ -0,0 +1,24 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/fpt-event-services/services/ticket-lambda/models"
+)
+
+func TestValidateInstallmentPlan(t *testing.T) {
+	if err := validateInstallmentPlan(models.InstallmentPlanRequest{DepositPercent: 30, InstallmentCount: 2, IntervalDays: 14}); err != nil {
+		t.Fatal(err)
+	}
+	for name, req := range map[string]models.InstallmentPlanRequest{
+		"deposit too low":  {DepositPercent: 5, InstallmentCount: 2, IntervalDays: 14},
+		"deposit too high": {DepositPercent: 95, InstallmentCount: 2, IntervalDays: 14},
+		"no installments":  {DepositPercent: 30, InstallmentCount: 0, IntervalDays: 14},
+		"too many":         {DepositPercent: 30, InstallmentCount: 7, IntervalDays: 14},
+		"zero interval":    {DepositPercent: 30, InstallmentCount: 2, IntervalDays: 0},
+	} {
+		if err := validateInstallmentPlan(req); err == nil {
+			t.Errorf("%s: expected validation error", name)
+		}
+	}
+}
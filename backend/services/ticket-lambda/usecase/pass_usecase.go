@@ -0,0 +1,152 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	apperrors "github.com/fpt-event-services/common/errors"
+	"github.com/fpt-event-services/common/tracing"
+	"github.com/fpt-event-services/services/ticket-lambda/models"
+	"github.com/fpt-event-services/services/ticket-lambda/repository"
+)
+
+// Giới hạn khi tạo pass
+const (
+	maxPassNameLength = 200
+	maxPassEvents     = 100
+)
+
+// Phương thức thanh toán pass
+const (
+	PassPaymentWallet = "wallet"
+	PassPaymentVNPay  = "vnpay"
+)
+
+// CreatePass - Organizer tạo vé học kỳ
+func (uc *TicketUseCase) CreatePass(ctx context.Context, organizerID int, role string, req models.CreateEventPassRequest) (*models.EventPass, error) {
+	if err := normalizePassRequest(&req, time.Now()); err != nil {
+		return nil, err
+	}
+	return uc.ticketRepo.CreatePass(ctx, organizerID, role, req)
+}
+
+// ListPasses - Pass đang bán (lọc theo organizer nếu có)
+func (uc *TicketUseCase) ListPasses(ctx context.Context, organizerID *int) ([]models.EventPass, error) {
+	return uc.ticketRepo.ListPasses(ctx, organizerID)
+}
+
+// GetPass - Chi tiết pass và các sự kiện thuộc pass
+func (uc *TicketUseCase) GetPass(ctx context.Context, passID int) (*models.EventPass, error) {
+	return uc.ticketRepo.GetPass(ctx, passID)
+}
+
+// UpdatePassStatus - Bật / ngừng bán pass (ACTIVE, INACTIVE)
+func (uc *TicketUseCase) UpdatePassStatus(ctx context.Context, userID int, role string, passID int, status string) (*models.EventPass, error) {
+	status = strings.ToUpper(strings.TrimSpace(status))
+	if status != "ACTIVE" && status != "INACTIVE" {
+		return nil, apperrors.ValidationError("status phải là ACTIVE hoặc INACTIVE")
+	}
+	return uc.ticketRepo.UpdatePassStatus(ctx, userID, role, passID, status)
+}
+
+// RevokePassHolder - Thu hồi pass của một người giữ
+func (uc *TicketUseCase) RevokePassHolder(ctx context.Context, userID int, role string, passID, holderID int) error {
+	return uc.ticketRepo.RevokePassHolder(ctx, userID, role, passID, holderID)
+}
+
+// PurchasePass - Mua pass bằng ví (cấp vé ngay) hoặc tạo link VNPay
+func (uc *TicketUseCase) PurchasePass(ctx context.Context, userID, passID int, method string) (*models.PassPurchaseResult, error) {
+	ctx, span := tracing.Start(ctx, "TicketUseCase.PurchasePass", tracing.WithAttributes(
+		tracing.Int("user.id", userID),
+		tracing.Int("pass.id", passID),
+		tracing.String("payment.method", method),
+	))
+	defer span.End()
+
+	var result *models.PassPurchaseResult
+	var err error
+	switch strings.ToLower(strings.TrimSpace(method)) {
+	case PassPaymentWallet:
+		result, err = uc.ticketRepo.PurchasePassWithWallet(ctx, userID, passID)
+	case PassPaymentVNPay:
+		result, err = uc.ticketRepo.CreatePassVNPayURL(ctx, userID, passID)
+	default:
+		err = apperrors.ValidationError("paymentMethod phải là wallet hoặc vnpay")
+	}
+	span.RecordError(err)
+	return result, err
+}
+
+// ClaimPassTicket - Người giữ pass nhận vé cho một sự kiện của pass
+func (uc *TicketUseCase) ClaimPassTicket(ctx context.Context, userID, passID int, req models.PassClaimRequest) (*models.PassTicket, error) {
+	if req.EventID <= 0 {
+		return nil, apperrors.ValidationError("eventId is required")
+	}
+	return uc.ticketRepo.ClaimPassTicket(ctx, userID, passID, req)
+}
+
+// GetMyPasses - Pass của user hiện tại
+func (uc *TicketUseCase) GetMyPasses(ctx context.Context, userID int) ([]models.PassHolding, error) {
+	return uc.ticketRepo.GetMyPasses(ctx, userID)
+}
+
+// normalizePassRequest chuẩn hóa và kiểm tra yêu cầu tạo pass
+// Scope mặc định ORGANIZER (không nhận danh sách sự kiện); EVENTS cần ít nhất một sự kiện, không trùng
+func normalizePassRequest(req *models.CreateEventPassRequest, now time.Time) error {
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" {
+		return apperrors.ValidationError("name is required")
+	}
+	if len([]rune(req.Name)) > maxPassNameLength {
+		return apperrors.ValidationError(fmt.Sprintf("Tên pass tối đa %d ký tự", maxPassNameLength))
+	}
+	if req.Description != nil {
+		d := strings.TrimSpace(*req.Description)
+		req.Description = &d
+		if d == "" {
+			req.Description = nil
+		}
+	}
+	if req.Price <= 0 {
+		return apperrors.ValidationError("price phải lớn hơn 0")
+	}
+	if req.ValidFrom.IsZero() || req.ValidTo.IsZero() || !req.ValidTo.After(req.ValidFrom) {
+		return apperrors.ValidationError("validTo phải sau validFrom")
+	}
+	if !req.ValidTo.After(now) {
+		return apperrors.ValidationError("Pass đã hết hạn")
+	}
+	if req.MaxHolders != nil && *req.MaxHolders <= 0 {
+		return apperrors.ValidationError("maxHolders phải lớn hơn 0")
+	}
+
+	req.Scope = strings.ToUpper(strings.TrimSpace(req.Scope))
+	if req.Scope == "" {
+		req.Scope = repository.PassScopeOrganizer
+	}
+	switch req.Scope {
+	case repository.PassScopeOrganizer:
+		if len(req.Events) > 0 {
+			return apperrors.ValidationError("Pass scope ORGANIZER áp dụng cho mọi sự kiện, không chọn events")
+		}
+	case repository.PassScopeEvents:
+		if len(req.Events) == 0 {
+			return apperrors.ValidationError("Pass scope EVENTS cần ít nhất một sự kiện")
+		}
+		if len(req.Events) > maxPassEvents {
+			return apperrors.ValidationError(fmt.Sprintf("Tối đa %d sự kiện mỗi pass", maxPassEvents))
+		}
+		seen := make(map[int]bool, len(req.Events))
+		for _, ev := range req.Events {
+			if ev.EventID <= 0 || seen[ev.EventID] {
+				return apperrors.ValidationError("events không hợp lệ hoặc bị trùng")
+			}
+			seen[ev.EventID] = true
+		}
+	default:
+		return apperrors.ValidationError("scope phải là ORGANIZER hoặc EVENTS")
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fpt-event-services/services/ticket-lambda/models"
+)
+
+func TestNormalizePassRequest(t *testing.T) {
+	now := time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)
+	desc := "  "
+	req := models.CreateEventPassRequest{
+		Name:        "  Vé học kỳ Fall  ",
+		Description: &desc,
+		Price:       150000,
+		ValidFrom:   now,
+		ValidTo:     now.AddDate(0, 4, 0),
+	}
+	if err := normalizePassRequest(&req, now); err != nil {
+		t.Fatal(err)
+	}
+	if req.Name != "Vé học kỳ Fall" || req.Description != nil || req.Scope != "ORGANIZER" {
+		t.Fatalf("unexpected normalized request: %+v", req)
+	}
+}
+
+func TestNormalizePassRequestRejects(t *testing.T) {
+	now := time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)
+	zero := 0
+	base := func() models.CreateEventPassRequest {
+		return models.CreateEventPassRequest{Name: "Pass", Price: 100000, ValidFrom: now, ValidTo: now.AddDate(0, 1, 0)}
+	}
+	cases := map[string]func(*models.CreateEventPassRequest){
+		"no name":         func(r *models.CreateEventPassRequest) { r.Name = " " },
+		"free":            func(r *models.CreateEventPassRequest) { r.Price = 0 },
+		"inverted window": func(r *models.CreateEventPassRequest) { r.ValidTo = r.ValidFrom },
+		"expired": func(r *models.CreateEventPassRequest) {
+			r.ValidFrom, r.ValidTo = now.AddDate(0, -2, 0), now.AddDate(0, -1, 0)
+		},
+		"zero holders":     func(r *models.CreateEventPassRequest) { r.MaxHolders = &zero },
+		"unknown scope":    func(r *models.CreateEventPassRequest) { r.Scope = "CAMPUS" },
+		"events w/o scope": func(r *models.CreateEventPassRequest) { r.Events = []models.EventPassEventInput{{EventID: 1}} },
+		"empty EVENTS":     func(r *models.CreateEventPassRequest) { r.Scope = "events" },
+		"duplicate events": func(r *models.CreateEventPassRequest) {
+			r.Scope = "EVENTS"
+			r.Events = []models.EventPassEventInput{{EventID: 3}, {EventID: 3}}
+		},
+	}
+	for name, mutate := range cases {
+		req := base()
+		mutate(&req)
+		if err := normalizePassRequest(&req, now); err == nil {
+			t.Errorf("%s: expected validation error", name)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apperrors "github.com/fpt-event-services/common/errors"
+	"github.com/fpt-event-services/common/qrtoken"
+	"github.com/fpt-event-services/services/ticket-lambda/models"
+)
+
+// ============================================================
+// QR động (event.dynamic_qr, migration 057)
+// App của chủ vé lấy mã RQR: mới trước mỗi ExpiresAt; check-in sự kiện bật QR động
+// chỉ nhận mã này (staff-lambda, common/qrtoken)
+// ============================================================
+
+// GetMyTicketQRToken - Mã QR xoay vòng (30 giây) của vé thuộc sự kiện bật QR động
+// App gọi lại trước ExpiresAt để hiển thị mã mới
+func (uc *TicketUseCase) GetMyTicketQRToken(ctx context.Context, userID, ticketID int) (*models.QRTokenResponse, error) {
+	if err := uc.requireUsableTicket(ctx, userID, ticketID); err != nil {
+		return nil, err
+	}
+	enabled, err := uc.ticketRepo.GetTicketDynamicQR(ctx, ticketID)
+	if err != nil {
+		return nil, err
+	}
+	if !enabled {
+		return nil, apperrors.BusinessError("Sự kiện không dùng QR động, hãy dùng QR của vé")
+	}
+	tok, err := qrtoken.Issue(ticketID, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to issue QR token: %w", err)
+	}
+	return &models.QRTokenResponse{
+		TicketID:     ticketID,
+		Token:        tok.Value,
+		ExpiresAt:    tok.ExpiresAt,
+		ValidSeconds: int(qrtoken.Step / time.Second),
+	}, nil
+}
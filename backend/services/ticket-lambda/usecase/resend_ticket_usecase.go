@@ -0,0 +1,67 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	apperrors "github.com/fpt-event-services/common/errors"
+	"github.com/fpt-event-services/common/qrcode"
+)
+
+// ============================================================
+// Gửi lại vé / hiển thị QR trong app cho chủ vé
+// Chỉ vé BOOKED / CHECKED_IN (vé PENDING chưa thanh toán, vé đã hoàn không còn dùng được)
+// ============================================================
+
+// Kích thước QR trả về cho app (pixel)
+const (
+	DefaultTicketQRSize = 300
+	MinTicketQRSize     = 150
+	MaxTicketQRSize     = 1000
+)
+
+// requireUsableTicket - Vé thuộc user và còn dùng để vào cổng được
+func (uc *TicketUseCase) requireUsableTicket(ctx context.Context, userID, ticketID int) error {
+	status, err := uc.ticketRepo.GetOwnedTicketStatus(ctx, ticketID, userID)
+	if err != nil {
+		return err
+	}
+	if status != "BOOKED" && status != "CHECKED_IN" {
+		return apperrors.BusinessError(fmt.Sprintf("Vé đang ở trạng thái %s, không có QR để sử dụng", status))
+	}
+	return nil
+}
+
+// ResendMyTicket - Tạo lại QR + PDF và gửi lại email vé cho chủ vé
+func (uc *TicketUseCase) ResendMyTicket(ctx context.Context, userID, ticketID int) error {
+	if err := uc.requireUsableTicket(ctx, userID, ticketID); err != nil {
+		return err
+	}
+	if err := uc.ticketRepo.RegenerateTicketQR(ctx, ticketID); err != nil {
+		return err
+	}
+	return uc.ticketRepo.ReissueTicketEmail(ctx, ticketID)
+}
+
+// GetMyTicketQR - Ảnh QR (PNG) của vé để hiển thị trong app
+func (uc *TicketUseCase) GetMyTicketQR(ctx context.Context, userID, ticketID, size int) ([]byte, error) {
+	if size < MinTicketQRSize || size > MaxTicketQRSize {
+		return nil, apperrors.ValidationError(fmt.Sprintf("size phải từ %d đến %d", MinTicketQRSize, MaxTicketQRSize))
+	}
+	if err := uc.requireUsableTicket(ctx, userID, ticketID); err != nil {
+		return nil, err
+	}
+	return qrcode.GenerateTicketQRPngBytes(ticketID, size)
+}
+
+// ResendMyReceipt - Gửi lại biên lai thanh toán của hóa đơn cho chủ hóa đơn
+func (uc *TicketUseCase) ResendMyReceipt(ctx context.Context, userID, billID int) error {
+	status, err := uc.ticketRepo.GetOwnedBillStatus(ctx, billID, userID)
+	if err != nil {
+		return err
+	}
+	if status != "PAID" {
+		return apperrors.BusinessError(fmt.Sprintf("Hóa đơn đang ở trạng thái %s, chưa có biên lai", status))
+	}
+	return uc.ticketRepo.SendReceiptEmail(ctx, billID)
+}
@@ -0,0 +1,58 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/fpt-event-services/services/ticket-lambda/models"
+)
+
+// GetMyTicketsGrouped - Vé của user nhóm theo sự kiện sắp diễn ra / đã qua, kèm timeline trạng thái
+func (uc *TicketUseCase) GetMyTicketsGrouped(ctx context.Context, userID int) (*models.GroupedTicketsResponse, error) {
+	tickets, err := uc.ticketRepo.GetTicketHistoryByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return groupTicketsByEvent(tickets, time.Now()), nil
+}
+
+// groupTicketsByEvent - tickets đã sắp theo start_time tăng dần
+// Sự kiện chưa có end_time coi như kết thúc lúc start_time
+func groupTicketsByEvent(tickets []models.TicketWithTimeline, now time.Time) *models.GroupedTicketsResponse {
+	resp := &models.GroupedTicketsResponse{
+		Upcoming: []models.TicketEventGroup{},
+		Past:     []models.TicketEventGroup{},
+	}
+	index := map[int]*models.TicketEventGroup{}
+	var order []int
+	for _, t := range tickets {
+		g, ok := index[t.EventID]
+		if !ok {
+			g = &models.TicketEventGroup{
+				EventID:   t.EventID,
+				EventName: t.EventName,
+				VenueName: t.VenueName,
+				StartTime: t.StartTime,
+				EndTime:   t.EndTime,
+			}
+			index[t.EventID] = g
+			order = append(order, t.EventID)
+		}
+		g.Tickets = append(g.Tickets, t)
+	}
+
+	for _, eventID := range order {
+		g := index[eventID]
+		end := g.EndTime
+		if end == nil {
+			end = g.StartTime
+		}
+		if end == nil || !end.Before(now) {
+			resp.Upcoming = append(resp.Upcoming, *g)
+		} else {
+			// Đã qua: gần nhất trước
+			resp.Past = append([]models.TicketEventGroup{*g}, resp.Past...)
+		}
+	}
+	return resp
+}
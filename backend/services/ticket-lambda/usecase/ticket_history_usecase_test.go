@@ -0,0 +1,39 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fpt-event-services/services/ticket-lambda/models"
+)
+
+func ticketAt(ticketID, eventID int, start time.Time) models.TicketWithTimeline {
+	end := start.Add(2 * time.Hour)
+	t := models.TicketWithTimeline{EventID: eventID, EndTime: &end}
+	t.TicketID = ticketID
+	t.StartTime = &start
+	return t
+}
+
+func TestGroupTicketsByEvent(t *testing.T) {
+	now := time.Date(2026, 5, 10, 12, 0, 0, 0, time.UTC)
+	tickets := []models.TicketWithTimeline{
+		ticketAt(1, 10, now.AddDate(0, 0, -30)),
+		ticketAt(2, 20, now.AddDate(0, 0, -3)),
+		ticketAt(3, 20, now.AddDate(0, 0, -3)),
+		ticketAt(4, 30, now.Add(-time.Hour)), // đang diễn ra
+		ticketAt(5, 40, now.AddDate(0, 0, 7)),
+	}
+
+	got := groupTicketsByEvent(tickets, now)
+
+	if len(got.Upcoming) != 2 || got.Upcoming[0].EventID != 30 || got.Upcoming[1].EventID != 40 {
+		t.Errorf("upcoming = %+v, want events 30, 40", got.Upcoming)
+	}
+	if len(got.Past) != 2 || got.Past[0].EventID != 20 || got.Past[1].EventID != 10 {
+		t.Fatalf("past = %+v, want events 20, 10 (most recent first)", got.Past)
+	}
+	if len(got.Past[0].Tickets) != 2 {
+		t.Errorf("event 20 has %d tickets, want 2", len(got.Past[0].Tickets))
+	}
+}
@@ -0,0 +1,90 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/fpt-event-services/common/csvutil"
+	apperrors "github.com/fpt-event-services/common/errors"
+	"github.com/fpt-event-services/services/ticket-lambda/models"
+)
+
+// ============================================================
+// Danh sách vé có bộ lọc và xuất CSV (GET /api/tickets/list)
+// ============================================================
+
+// ListTickets - Một trang vé theo bộ lọc (phạm vi theo role như GetTicketsByRole)
+func (uc *TicketUseCase) ListTickets(ctx context.Context, role string, userID int, filter models.TicketListFilter) (*models.PaginatedTicketsResponse, error) {
+	return uc.ticketRepo.ListTickets(ctx, role, userID, filter)
+}
+
+// ExportTicketsCSV - Toàn bộ vé khớp bộ lọc dạng CSV (tối đa models.TicketExportMaxRows dòng)
+func (uc *TicketUseCase) ExportTicketsCSV(ctx context.Context, role string, userID int, filter models.TicketListFilter) (string, []byte, error) {
+	filter.Page = 1
+	filter.Limit = models.TicketExportMaxRows
+	page, err := uc.ticketRepo.ListTickets(ctx, role, userID, filter)
+	if err != nil {
+		return "", nil, err
+	}
+	if page.TotalItems > models.TicketExportMaxRows {
+		return "", nil, apperrors.ValidationError(fmt.Sprintf(
+			"Có %d vé khớp bộ lọc, chỉ xuất được tối đa %d vé mỗi lần; hãy lọc theo sự kiện hoặc trạng thái",
+			page.TotalItems, models.TicketExportMaxRows))
+	}
+	data, err := ticketsCSV(page.Items)
+	if err != nil {
+		return "", nil, err
+	}
+	fileName := "tickets.csv"
+	if filter.EventID != nil {
+		fileName = fmt.Sprintf("tickets_event_%d.csv", *filter.EventID)
+	}
+	return fileName, data, nil
+}
+
+// ticketsCSV - CSV UTF-8 (có BOM để Excel đọc đúng tiếng Việt)
+func ticketsCSV(tickets []models.MyTicketResponse) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString("\ufeff")
+	w := csv.NewWriter(&buf)
+
+	if err := w.Write([]string{
+		"Ticket ID", "Event", "Category", "Price", "Seat", "Buyer", "Buyer Email",
+		"Status", "Purchased At", "Checked In At", "Checked Out At",
+	}); err != nil {
+		return nil, err
+	}
+	for _, t := range tickets {
+		price := ""
+		if t.CategoryPrice != nil {
+			price = strconv.FormatFloat(*t.CategoryPrice, 'f', 0, 64)
+		}
+		if err := w.Write([]string{
+			strconv.Itoa(t.TicketID), csvutil.Safe(deref(t.EventName)), csvutil.Safe(deref(t.Category)), price,
+			csvutil.Safe(deref(t.SeatCode)), csvutil.Safe(deref(t.BuyerName)), csvutil.Safe(deref(t.BuyerEmail)),
+			t.Status, formatTime(t.PurchaseDate), formatTime(t.CheckInTime), formatTime(t.CheckOutTime),
+		}); err != nil {
+			return nil, err
+		}
+	}
+	w.Flush()
+	return buf.Bytes(), w.Error()
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
@@ -2,7 +2,10 @@ package usecase
 
 import (
 	"context"
+	"net/url"
 
+	"github.com/fpt-event-services/common/pagination"
+	"github.com/fpt-event-services/common/tracing"
 	"github.com/fpt-event-services/services/ticket-lambda/models"
 	"github.com/fpt-event-services/services/ticket-lambda/repository"
 )
@@ -13,7 +16,7 @@ type TicketUseCase struct {
 
 func NewTicketUseCase() *TicketUseCase {
 	return &TicketUseCase{
-		ticketRepo: repository.NewTicketRepository(),
+		ticketRepo: repository.DefaultTicketRepository(),
 	}
 }
 
@@ -23,8 +26,8 @@ func (uc *TicketUseCase) GetMyTickets(ctx context.Context, userID int) ([]models
 }
 
 // GetMyTicketsPaginated - Lấy danh sách vé với pagination và search/filter
-func (uc *TicketUseCase) GetMyTicketsPaginated(ctx context.Context, userID, page, limit int, search, status string) (*models.PaginatedTicketsResponse, error) {
-	return uc.ticketRepo.GetTicketsByUserIDPaginated(ctx, userID, page, limit, search, status)
+func (uc *TicketUseCase) GetMyTicketsPaginated(ctx context.Context, userID int, p pagination.Params, search, status string) (*models.PaginatedTicketsResponse, error) {
+	return uc.ticketRepo.GetTicketsByUserIDPaginated(ctx, userID, p, search, status)
 }
 
 // GetTicketsByRole - Lấy danh sách vé theo role
@@ -43,8 +46,8 @@ func (uc *TicketUseCase) GetMyBills(ctx context.Context, userID int) ([]models.M
 }
 
 // GetMyBillsPaginated - Lấy danh sách hóa đơn với pagination và search/filter
-func (uc *TicketUseCase) GetMyBillsPaginated(ctx context.Context, userID, page, limit int, search, paymentStatus, paymentMethod string) (*models.PaginatedBillsResponse, error) {
-	return uc.ticketRepo.GetBillsByUserIDPaginated(ctx, userID, page, limit, search, paymentStatus, paymentMethod)
+func (uc *TicketUseCase) GetMyBillsPaginated(ctx context.Context, userID int, p pagination.Params, search, paymentStatus, paymentMethod string) (*models.PaginatedBillsResponse, error) {
+	return uc.ticketRepo.GetBillsByUserIDPaginated(ctx, userID, p, search, paymentStatus, paymentMethod)
 }
 
 // ============================================================
@@ -52,14 +55,44 @@ func (uc *TicketUseCase) GetMyBillsPaginated(ctx context.Context, userID, page,
 // KHỚP VỚI Java PaymentService & BuyTicketService
 // ============================================================
 
-// CreatePaymentURL - Tạo URL thanh toán VNPay cho nhiều ghế
-func (uc *TicketUseCase) CreatePaymentURL(ctx context.Context, userID, eventID, categoryTicketID int, seatIDs []int) (string, error) {
-	return uc.ticketRepo.CreateVNPayURL(ctx, userID, eventID, categoryTicketID, seatIDs)
+// CreatePaymentURL - Tạo URL thanh toán VNPay cho nhiều ghế (kèm thời hạn giữ ghế)
+// installment: chỉ thu tiền cọc, phần còn lại trả theo lịch trả góp của sự kiện
+func (uc *TicketUseCase) CreatePaymentURL(ctx context.Context, userID, eventID, categoryTicketID int, seatIDs []int, installment bool) (*models.PaymentInitResult, error) {
+	ctx, span := tracing.Start(ctx, "TicketUseCase.CreatePaymentURL", tracing.WithAttributes(
+		tracing.Int("user.id", userID),
+		tracing.Int("event.id", eventID),
+		tracing.Int("booking.seat_count", len(seatIDs)),
+		tracing.Bool("booking.installment", installment),
+	))
+	defer span.End()
+
+	result, err := uc.ticketRepo.CreateVNPayURL(ctx, userID, eventID, categoryTicketID, seatIDs, installment)
+	span.RecordError(err)
+	return result, err
+}
+
+// GetActiveHolds - Lấy các vé PENDING đang giữ ghế của user
+func (uc *TicketUseCase) GetActiveHolds(ctx context.Context, userID int) ([]models.TicketHold, error) {
+	return uc.ticketRepo.GetActiveHolds(ctx, userID)
+}
+
+// ExtendHolds - Gia hạn giữ ghế (1 lần / vé)
+func (uc *TicketUseCase) ExtendHolds(ctx context.Context, userID int, ticketIDs []int) ([]models.TicketHold, error) {
+	return uc.ticketRepo.ExtendHolds(ctx, userID, ticketIDs)
 }
 
 // ProcessPaymentCallback - Xử lý callback từ VNPay
-func (uc *TicketUseCase) ProcessPaymentCallback(ctx context.Context, amount, responseCode, orderInfo, txnRef, secureHash string) (string, error) {
-	return uc.ticketRepo.ProcessVNPayCallback(ctx, amount, responseCode, orderInfo, txnRef, secureHash)
+// txn_ref được gắn vào span để nối trace callback với trace tạo URL thanh toán
+func (uc *TicketUseCase) ProcessPaymentCallback(ctx context.Context, params url.Values) (string, error) {
+	ctx, span := tracing.Start(ctx, "TicketUseCase.ProcessPaymentCallback", tracing.WithAttributes(
+		tracing.String("booking.txn_ref", params.Get("vnp_TxnRef")),
+		tracing.String("vnpay.response_code", params.Get("vnp_ResponseCode")),
+	))
+	defer span.End()
+
+	message, err := uc.ticketRepo.ProcessVNPayCallback(ctx, params)
+	span.RecordError(err)
+	return message, err
 }
 
 // ============================================================
@@ -77,9 +110,29 @@ func (uc *TicketUseCase) CalculateSeatsPriceForWallet(ctx context.Context, event
 	return uc.ticketRepo.CalculateSeatsTotal(ctx, eventID, seatIDs)
 }
 
-// ProcessWalletPayment - Xử lý thanh toán bằng ví
-func (uc *TicketUseCase) ProcessWalletPayment(ctx context.Context, userID, eventID, categoryTicketID int, seatIDs []int, amount int) (string, error) {
-	return uc.ticketRepo.ProcessWalletPayment(ctx, userID, eventID, categoryTicketID, seatIDs, amount)
+// InstallmentDeposit - Tiền cọc của lượt mua trả góp tổng tiền total
+func (uc *TicketUseCase) InstallmentDeposit(ctx context.Context, eventID, total int) (int, error) {
+	return uc.ticketRepo.InstallmentDeposit(ctx, eventID, total)
+}
+
+// QuoteSeats - Báo giá ghế đã chọn trước khi thanh toán (không giữ ghế)
+func (uc *TicketUseCase) QuoteSeats(ctx context.Context, eventID int, seatIDs []int) (*models.TicketQuote, error) {
+	return uc.ticketRepo.QuoteSeats(ctx, eventID, seatIDs)
+}
+
+// ProcessWalletPayment - Xử lý thanh toán bằng ví (installment: chỉ trừ tiền cọc)
+func (uc *TicketUseCase) ProcessWalletPayment(ctx context.Context, userID, eventID, categoryTicketID int, seatIDs []int, amount int, installment bool) (string, error) {
+	ctx, span := tracing.Start(ctx, "TicketUseCase.ProcessWalletPayment", tracing.WithAttributes(
+		tracing.Int("user.id", userID),
+		tracing.Int("event.id", eventID),
+		tracing.Int("booking.seat_count", len(seatIDs)),
+		tracing.Bool("booking.installment", installment),
+	))
+	defer span.End()
+
+	ticketIDs, err := uc.ticketRepo.ProcessWalletPayment(ctx, userID, eventID, categoryTicketID, seatIDs, amount, installment)
+	span.RecordError(err)
+	return ticketIDs, err
 }
 
 // ============================================================
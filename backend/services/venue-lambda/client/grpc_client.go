@@ -0,0 +1,49 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/fpt-event-services/services/proto/venuepb"
+	"github.com/fpt-event-services/services/venue-lambda/models"
+)
+
+// GRPCVenueService - Adapter gọi venue-lambda qua gRPC (VENUE_SERVICE_GRPC_ADDR)
+// NOT_FOUND → ErrAreaNotFound, giống LocalVenueService
+type GRPCVenueService struct {
+	client venuepb.VenueServiceClient
+}
+
+// NewGRPCVenueService creates a VenueService over conn
+func NewGRPCVenueService(conn grpc.ClientConnInterface) *GRPCVenueService {
+	return &GRPCVenueService{client: venuepb.NewVenueServiceClient(conn)}
+}
+
+func (s *GRPCVenueService) GetArea(ctx context.Context, areaID int) (*models.AreaInfo, error) {
+	resp, err := s.client.GetArea(ctx, &venuepb.GetAreaRequest{AreaId: int32(areaID)})
+	if status.Code(err) == codes.NotFound {
+		return nil, ErrAreaNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get area: %w", err)
+	}
+	a := &models.AreaInfo{
+		AreaID:        int(resp.GetAreaId()),
+		VenueID:       int(resp.GetVenueId()),
+		AreaName:      resp.GetAreaName(),
+		Floor:         resp.Floor,
+		Capacity:      int(resp.GetCapacity()),
+		Status:        resp.GetStatus(),
+		VenueName:     resp.GetVenueName(),
+		VenueLocation: resp.VenueLocation,
+	}
+	if resp.CampusId != nil {
+		id := int(resp.GetCampusId())
+		a.CampusID = &id
+	}
+	return a, nil
+}
@@ -0,0 +1,51 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/fpt-event-services/services/proto/venuepb"
+)
+
+// GRPCServer - Phục vụ service VenueService (venue.proto) bằng một VenueService Go
+// (thường là LocalVenueService); main.go đăng ký khi đặt GRPC_PORT
+// ErrAreaNotFound → NOT_FOUND; lỗi khác → INTERNAL (chi tiết chỉ ghi log)
+type GRPCServer struct {
+	venuepb.UnimplementedVenueServiceServer
+	svc VenueService
+}
+
+// NewGRPCServer creates a gRPC server backed by svc
+func NewGRPCServer(svc VenueService) *GRPCServer {
+	return &GRPCServer{svc: svc}
+}
+
+func (s *GRPCServer) GetArea(ctx context.Context, req *venuepb.GetAreaRequest) (*venuepb.Area, error) {
+	a, err := s.svc.GetArea(ctx, int(req.GetAreaId()))
+	if errors.Is(err, ErrAreaNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if err != nil {
+		log.Printf("[RPC] VenueService.GetArea failed: %v", err)
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+	out := &venuepb.Area{
+		AreaId:        int32(a.AreaID),
+		VenueId:       int32(a.VenueID),
+		AreaName:      a.AreaName,
+		Floor:         a.Floor,
+		Capacity:      int32(a.Capacity),
+		Status:        a.Status,
+		VenueName:     a.VenueName,
+		VenueLocation: a.VenueLocation,
+	}
+	if a.CampusID != nil {
+		id := int32(*a.CampusID)
+		out.CampusId = &id
+	}
+	return out, nil
+}
@@ -0,0 +1,66 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net"
+	"reflect"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/fpt-event-services/services/proto/venuepb"
+	"github.com/fpt-event-services/services/venue-lambda/models"
+)
+
+// fakeVenueService - VenueService trong bộ nhớ cho test
+type fakeVenueService map[int]*models.AreaInfo
+
+func (f fakeVenueService) GetArea(ctx context.Context, areaID int) (*models.AreaInfo, error) {
+	if a, ok := f[areaID]; ok {
+		return a, nil
+	}
+	return nil, ErrAreaNotFound
+}
+
+func TestGRPCVenueServiceGetArea(t *testing.T) {
+	floor, campusID := "2", 4
+	areas := fakeVenueService{
+		1: {AreaID: 1, VenueID: 9, AreaName: "Hall A", Floor: &floor, Capacity: 120, Status: "AVAILABLE", VenueName: "Alpha", CampusID: &campusID},
+		2: {AreaID: 2, VenueID: 9, AreaName: "Hall B", Capacity: 80, Status: "AVAILABLE", VenueName: "Alpha"},
+	}
+
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer()
+	venuepb.RegisterVenueServiceServer(srv, NewGRPCServer(areas))
+	go srv.Serve(lis)
+	defer srv.Stop()
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return lis.Dial() }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer conn.Close()
+	svc := NewGRPCVenueService(conn)
+	ctx := context.Background()
+
+	// CampusID / Floor nil phải giữ nil, không thành 0 / ""
+	for id, want := range areas {
+		got, err := svc.GetArea(ctx, id)
+		if err != nil {
+			t.Fatalf("GetArea(%d): %v", id, err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("GetArea(%d) = %+v, want %+v", id, got, want)
+		}
+	}
+
+	if _, err := svc.GetArea(ctx, 99); !errors.Is(err, ErrAreaNotFound) {
+		t.Errorf("GetArea(99) err = %v, want ErrAreaNotFound", err)
+	}
+}
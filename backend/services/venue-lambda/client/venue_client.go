@@ -0,0 +1,68 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/fpt-event-services/common/rpc"
+	"github.com/fpt-event-services/services/venue-lambda/models"
+	"github.com/fpt-event-services/services/venue-lambda/repository"
+)
+
+// ErrAreaNotFound - Khu vực không tồn tại
+var ErrAreaNotFound = errors.New("area not found")
+
+// AddrEnv - Biến môi trường chứa địa chỉ gRPC của venue-lambda (rỗng = in-process)
+const AddrEnv = "VENUE_SERVICE_GRPC_ADDR"
+
+// ============================================================
+// VenueService - API nội bộ của venue-lambda cho các service khác
+// Khớp service VenueService trong services/proto/venue.proto.
+// Service khác (event-lambda, ...) đọc khu vực / venue qua interface này
+// thay vì tự viết SQL lên Venue / Venue_Area
+// ============================================================
+type VenueService interface {
+	GetArea(ctx context.Context, areaID int) (*models.AreaInfo, error)
+}
+
+// LocalVenueService - Adapter in-process: gọi thẳng repository của venue-lambda
+type LocalVenueService struct {
+	repo *repository.VenueRepository
+}
+
+// NewLocalVenueService creates a new in-process venue service
+func NewLocalVenueService() *LocalVenueService {
+	return &LocalVenueService{repo: repository.DefaultVenueRepository()}
+}
+
+// GetArea trả về ErrAreaNotFound nếu khu vực không tồn tại
+func (s *LocalVenueService) GetArea(ctx context.Context, areaID int) (*models.AreaInfo, error) {
+	area, err := s.repo.GetAreaInfo(ctx, areaID)
+	if errors.Is(err, repository.ErrVenueNotFound) {
+		return nil, ErrAreaNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get area: %w", err)
+	}
+	return area, nil
+}
+
+var (
+	defaultService     VenueService
+	defaultServiceOnce sync.Once
+)
+
+// Default trả về VenueService dùng chung của process:
+// gRPC khi đặt VENUE_SERVICE_GRPC_ADDR, ngược lại adapter in-process
+func Default() VenueService {
+	defaultServiceOnce.Do(func() {
+		if rpc.ServiceAddr(AddrEnv) != "" {
+			defaultService = NewGRPCVenueService(rpc.MustDial(AddrEnv))
+			return
+		}
+		defaultService = NewLocalVenueService()
+	})
+	return defaultService
+}
@@ -3,13 +3,18 @@ package handler
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
 
 	"github.com/aws/aws-lambda-go/events"
+	"github.com/fpt-event-services/common/amenity"
+	"github.com/fpt-event-services/common/campus"
+	"github.com/fpt-event-services/common/permission"
 	"github.com/fpt-event-services/services/venue-lambda/models"
+	"github.com/fpt-event-services/services/venue-lambda/repository"
 	"github.com/fpt-event-services/services/venue-lambda/usecase"
 )
 
@@ -23,9 +28,14 @@ func NewVenueHandler() *VenueHandler {
 	}
 }
 
-// HandleGetVenues - GET /api/venues
+// HandleGetVenues - GET /api/venues?campusId=
+// Admin/staff gắn campus chỉ thấy venue của campus mình
 func (h *VenueHandler) HandleGetVenues(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
-	venues, err := h.useCase.GetAllVenues(ctx)
+	campusID, err := resolveCampusParam(ctx, request)
+	if err != nil {
+		return campusErrorResponse(err)
+	}
+	venues, err := h.useCase.GetAllVenues(ctx, campusID)
 	if err != nil {
 		return createMessageResponse(http.StatusInternalServerError, "Error loading venues")
 	}
@@ -39,10 +49,8 @@ func (h *VenueHandler) HandleGetVenues(ctx context.Context, request events.APIGa
 
 // HandleCreateVenue - POST /api/venues
 func (h *VenueHandler) HandleCreateVenue(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
-	// Check role (ADMIN only)
-	role := request.Headers["X-User-Role"]
-	if role != "ADMIN" {
-		return createStatusResponse(http.StatusForbidden, "fail", "ADMIN role required")
+	if !permission.Has(ctx, permission.VenueManage) {
+		return createStatusResponse(http.StatusForbidden, "fail", "venue.manage permission required")
 	}
 
 	var req models.CreateVenueRequest
@@ -56,6 +64,9 @@ func (h *VenueHandler) HandleCreateVenue(ctx context.Context, request events.API
 
 	_, err := h.useCase.CreateVenue(ctx, req)
 	if err != nil {
+		if isCampusError(err) {
+			return campusErrorResponse(err)
+		}
 		return createStatusResponse(http.StatusInternalServerError, "fail", "Error creating venue")
 	}
 
@@ -64,9 +75,8 @@ func (h *VenueHandler) HandleCreateVenue(ctx context.Context, request events.API
 
 // HandleUpdateVenue - PUT /api/venues
 func (h *VenueHandler) HandleUpdateVenue(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
-	role := request.Headers["X-User-Role"]
-	if role != "ADMIN" {
-		return createStatusResponse(http.StatusForbidden, "fail", "ADMIN role required")
+	if !permission.Has(ctx, permission.VenueManage) {
+		return createStatusResponse(http.StatusForbidden, "fail", "venue.manage permission required")
 	}
 
 	var req models.UpdateVenueRequest
@@ -77,6 +87,9 @@ func (h *VenueHandler) HandleUpdateVenue(ctx context.Context, request events.API
 	if req.VenueID == 0 {
 		return createStatusResponse(http.StatusBadRequest, "fail", "Venue ID is required")
 	}
+	if err := h.useCase.CheckVenueScope(ctx, req.VenueID); err != nil {
+		return campusErrorResponse(err)
+	}
 
 	err := h.useCase.UpdateVenue(ctx, req)
 	if err != nil {
@@ -88,9 +101,8 @@ func (h *VenueHandler) HandleUpdateVenue(ctx context.Context, request events.API
 
 // HandleDeleteVenue - DELETE /api/venues?venueId=
 func (h *VenueHandler) HandleDeleteVenue(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
-	role := request.Headers["X-User-Role"]
-	if role != "ADMIN" {
-		return createStatusResponse(http.StatusForbidden, "fail", "ADMIN role required")
+	if !permission.Has(ctx, permission.VenueManage) {
+		return createStatusResponse(http.StatusForbidden, "fail", "venue.manage permission required")
 	}
 
 	venueIDStr := request.QueryStringParameters["venueId"]
@@ -102,6 +114,9 @@ func (h *VenueHandler) HandleDeleteVenue(ctx context.Context, request events.API
 	if err != nil {
 		return createStatusResponse(http.StatusBadRequest, "fail", "Mã địa điểm không hợp lệ")
 	}
+	if err := h.useCase.CheckVenueScope(ctx, venueID); err != nil {
+		return campusErrorResponse(err)
+	}
 
 	err = h.useCase.DeleteVenue(ctx, venueID)
 	if err != nil {
@@ -158,9 +173,8 @@ func (h *VenueHandler) HandleGetAreas(ctx context.Context, request events.APIGat
 
 // HandleCreateArea - POST /api/venues/areas
 func (h *VenueHandler) HandleCreateArea(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
-	role := request.Headers["X-User-Role"]
-	if role != "ADMIN" {
-		return createStatusResponse(http.StatusForbidden, "fail", "ADMIN role required")
+	if !permission.Has(ctx, permission.VenueManage) {
+		return createStatusResponse(http.StatusForbidden, "fail", "venue.manage permission required")
 	}
 
 	var req models.CreateAreaRequest
@@ -181,8 +195,14 @@ func (h *VenueHandler) HandleCreateArea(ctx context.Context, request events.APIG
 	if req.Capacity <= 0 {
 		return createStatusResponse(http.StatusBadRequest, "fail", "Sức chứa phải lớn hơn 0")
 	}
+	if err := h.useCase.CheckVenueScope(ctx, req.VenueID); err != nil {
+		return campusErrorResponse(err)
+	}
 
 	_, err := h.useCase.CreateArea(ctx, req)
+	if errors.Is(err, amenity.ErrUnknown) {
+		return createStatusResponse(http.StatusBadRequest, "fail", "Tiện ích không hợp lệ: "+err.Error())
+	}
 	if err != nil {
 		return createStatusResponse(http.StatusInternalServerError, "fail", "Lỗi tạo phòng: "+err.Error())
 	}
@@ -192,9 +212,8 @@ func (h *VenueHandler) HandleCreateArea(ctx context.Context, request events.APIG
 
 // HandleUpdateArea - PUT /api/venues/areas
 func (h *VenueHandler) HandleUpdateArea(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
-	role := request.Headers["X-User-Role"]
-	if role != "ADMIN" {
-		return createStatusResponse(http.StatusForbidden, "fail", "ADMIN role required")
+	if !permission.Has(ctx, permission.VenueManage) {
+		return createStatusResponse(http.StatusForbidden, "fail", "venue.manage permission required")
 	}
 
 	var req models.UpdateAreaRequest
@@ -215,8 +234,14 @@ func (h *VenueHandler) HandleUpdateArea(ctx context.Context, request events.APIG
 	if req.Capacity <= 0 {
 		return createStatusResponse(http.StatusBadRequest, "fail", "Sức chứa phải lớn hơn 0")
 	}
+	if err := h.useCase.CheckAreaScope(ctx, req.AreaID); err != nil {
+		return campusErrorResponse(err)
+	}
 
 	err := h.useCase.UpdateArea(ctx, req)
+	if errors.Is(err, amenity.ErrUnknown) {
+		return createStatusResponse(http.StatusBadRequest, "fail", "Tiện ích không hợp lệ: "+err.Error())
+	}
 	if err != nil {
 		return createStatusResponse(http.StatusInternalServerError, "fail", "Lỗi cập nhật phòng: "+err.Error())
 	}
@@ -226,9 +251,8 @@ func (h *VenueHandler) HandleUpdateArea(ctx context.Context, request events.APIG
 
 // HandleDeleteArea - DELETE /api/venue-areas?id=
 func (h *VenueHandler) HandleDeleteArea(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
-	role := request.Headers["X-User-Role"]
-	if role != "ADMIN" {
-		return createStatusResponse(http.StatusForbidden, "fail", "ADMIN role required")
+	if !permission.Has(ctx, permission.VenueManage) {
+		return createStatusResponse(http.StatusForbidden, "fail", "venue.manage permission required")
 	}
 
 	areaIDStr := request.QueryStringParameters["id"]
@@ -240,6 +264,9 @@ func (h *VenueHandler) HandleDeleteArea(ctx context.Context, request events.APIG
 	if err != nil {
 		return createStatusResponse(http.StatusBadRequest, "fail", "Invalid area ID")
 	}
+	if err := h.useCase.CheckAreaScope(ctx, areaID); err != nil {
+		return campusErrorResponse(err)
+	}
 
 	err = h.useCase.DeleteArea(ctx, areaID)
 	if err != nil {
@@ -249,7 +276,7 @@ func (h *VenueHandler) HandleDeleteArea(ctx context.Context, request events.APIG
 	return createStatusResponse(http.StatusOK, "success", "Area deleted successfully")
 }
 
-// HandleGetFreeAreas - GET /api/free-areas?startTime=&endTime=
+// HandleGetFreeAreas - GET /api/free-areas?startTime=&endTime=&campusId=
 func (h *VenueHandler) HandleGetFreeAreas(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
 	startTime := request.QueryStringParameters["startTime"]
 	endTime := request.QueryStringParameters["endTime"]
@@ -257,8 +284,12 @@ func (h *VenueHandler) HandleGetFreeAreas(ctx context.Context, request events.AP
 	if startTime == "" || endTime == "" {
 		return createMessageResponse(http.StatusBadRequest, "startTime and endTime are required")
 	}
+	campusID, err := resolveCampusParam(ctx, request)
+	if err != nil {
+		return campusErrorResponse(err)
+	}
 
-	areas, err := h.useCase.GetFreeAreas(ctx, startTime, endTime)
+	areas, err := h.useCase.GetFreeAreas(ctx, startTime, endTime, campusID)
 	if err != nil {
 		return createMessageResponse(http.StatusInternalServerError, "Error loading free areas")
 	}
@@ -280,10 +311,11 @@ func (h *VenueHandler) HandleGetFreeAreas(ctx context.Context, request events.AP
 	return createJSONResponse(http.StatusOK, response)
 }
 
-// HandleGetSeats - GET /api/seats?areaId=&eventId=&seatType=
+// HandleGetSeats - GET /api/seats?areaId=&eventId=&seatType=&accessible=
 // Tương tự Java GetAllSeatsController:
 // - Nếu có eventId: lấy ghế từ Event_Seat_Layout (layout cho event cụ thể)
 // - Nếu không có eventId: lấy ghế vật lý từ Seat (theo area)
+// - accessible=true: chỉ trả ghế xe lăn và ghế người đi kèm
 func (h *VenueHandler) HandleGetSeats(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
 	eventIDStr := request.QueryStringParameters["eventId"]
 	areaIDStr := request.QueryStringParameters["areaId"]
@@ -333,6 +365,16 @@ func (h *VenueHandler) HandleGetSeats(ctx context.Context, request events.APIGat
 		}
 	}
 
+	if request.QueryStringParameters["accessible"] == "true" {
+		filtered := []models.Seat{}
+		for _, seat := range seats {
+			if seat.Accessible || seat.CompanionOfSeatID != nil {
+				filtered = append(filtered, seat)
+			}
+		}
+		seats = filtered
+	}
+
 	if seats == nil {
 		seats = []models.Seat{}
 	}
@@ -349,6 +391,103 @@ func (h *VenueHandler) HandleGetSeats(ctx context.Context, request events.APIGat
 	return createJSONResponse(http.StatusOK, response)
 }
 
+// HandleUpdateSeatAccessibility - PUT /api/seats/accessibility
+// ADMIN đánh dấu ghế xe lăn / ghế người đi kèm
+func (h *VenueHandler) HandleUpdateSeatAccessibility(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	if !permission.Has(ctx, permission.VenueManage) {
+		return createStatusResponse(http.StatusForbidden, "fail", "venue.manage permission required")
+	}
+
+	var req models.UpdateSeatAccessibilityRequest
+	if err := json.Unmarshal([]byte(request.Body), &req); err != nil {
+		return createStatusResponse(http.StatusBadRequest, "fail", "Invalid request body")
+	}
+	if req.SeatID <= 0 {
+		return createStatusResponse(http.StatusBadRequest, "fail", "seatId is required")
+	}
+
+	err := h.useCase.UpdateSeatAccessibility(ctx, req)
+	switch {
+	case errors.Is(err, repository.ErrSeatNotFound):
+		return createStatusResponse(http.StatusNotFound, "fail", "Seat not found")
+	case errors.Is(err, repository.ErrInvalidSeatAccessibility):
+		return createStatusResponse(http.StatusBadRequest, "fail", err.Error())
+	case err != nil:
+		return createStatusResponse(http.StatusInternalServerError, "fail", "Error updating seat")
+	}
+
+	return createStatusResponse(http.StatusOK, "success", "Seat updated successfully")
+}
+
+// HandleGetCampuses - GET /api/campuses
+func (h *VenueHandler) HandleGetCampuses(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	campuses, err := h.useCase.ListCampuses(ctx)
+	if err != nil {
+		return createMessageResponse(http.StatusInternalServerError, "Error loading campuses")
+	}
+	if campuses == nil {
+		campuses = []models.Campus{}
+	}
+	return createJSONResponse(http.StatusOK, campuses)
+}
+
+// HandleCreateCampus - POST /api/campuses (super admin)
+func (h *VenueHandler) HandleCreateCampus(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	if !permission.Has(ctx, permission.CampusManage) {
+		return createStatusResponse(http.StatusForbidden, "fail", "campus.manage permission required")
+	}
+
+	var req models.CreateCampusRequest
+	if err := json.Unmarshal([]byte(request.Body), &req); err != nil {
+		return createStatusResponse(http.StatusBadRequest, "fail", "Invalid request body")
+	}
+
+	id, err := h.useCase.CreateCampus(ctx, req)
+	switch {
+	case errors.Is(err, usecase.ErrInvalidCampusRequest):
+		return createStatusResponse(http.StatusBadRequest, "fail", "Mã campus (2-10 chữ in hoa/số) và tên campus là bắt buộc")
+	case errors.Is(err, repository.ErrCampusCodeExists):
+		return createStatusResponse(http.StatusConflict, "fail", "Mã campus đã tồn tại")
+	case isCampusError(err):
+		return campusErrorResponse(err)
+	case err != nil:
+		return createStatusResponse(http.StatusInternalServerError, "fail", "Error creating campus")
+	}
+
+	return createJSONResponse(http.StatusCreated, map[string]interface{}{"status": "success", "campusId": id})
+}
+
+// resolveCampusParam - Bộ lọc campus theo ?campusId= và phạm vi của người dùng
+func resolveCampusParam(ctx context.Context, request events.APIGatewayProxyRequest) (*int, error) {
+	requested, err := campus.ParseID(request.QueryStringParameters["campusId"])
+	if err != nil {
+		return nil, err
+	}
+	return campus.Resolve(ctx, requested)
+}
+
+func isCampusError(err error) bool {
+	return errors.Is(err, campus.ErrOutOfScope) || errors.Is(err, campus.ErrInvalidCampus) ||
+		errors.Is(err, usecase.ErrCampusRequired) || errors.Is(err, repository.ErrCampusNotFound) ||
+		errors.Is(err, repository.ErrVenueNotFound)
+}
+
+// campusErrorResponse - Lỗi phạm vi campus / campus không hợp lệ
+func campusErrorResponse(err error) (events.APIGatewayProxyResponse, error) {
+	switch {
+	case errors.Is(err, campus.ErrOutOfScope):
+		return createStatusResponse(http.StatusForbidden, "fail", "Bạn không có quyền với campus này")
+	case errors.Is(err, campus.ErrInvalidCampus), errors.Is(err, usecase.ErrCampusRequired):
+		return createStatusResponse(http.StatusBadRequest, "fail", "campusId không hợp lệ hoặc bị thiếu")
+	case errors.Is(err, repository.ErrCampusNotFound):
+		return createStatusResponse(http.StatusBadRequest, "fail", "Campus không tồn tại")
+	case errors.Is(err, repository.ErrVenueNotFound):
+		return createStatusResponse(http.StatusNotFound, "fail", "Địa điểm không tồn tại")
+	default:
+		return createStatusResponse(http.StatusInternalServerError, "fail", "Error checking campus")
+	}
+}
+
 // Helper functions
 func createJSONResponse(statusCode int, data interface{}) (events.APIGatewayProxyResponse, error) {
 	body, err := json.Marshal(data)
@@ -387,8 +526,6 @@ func createStatusResponse(statusCode int, status, message string) (events.APIGat
 
 func defaultHeaders() map[string]string {
 	return map[string]string{
-		"Content-Type":                     "application/json;charset=UTF-8",
-		"Access-Control-Allow-Origin":      "*",
-		"Access-Control-Allow-Credentials": "true",
+		"Content-Type": "application/json;charset=UTF-8",
 	}
 }
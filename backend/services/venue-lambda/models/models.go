@@ -1,10 +1,31 @@
 package models
 
+// ============================================================
+// Campus - Cơ sở của trường (HCM, Hà Nội, Đà Nẵng)
+// ============================================================
+type Campus struct {
+	CampusID   int     `json:"campusId"`
+	Code       string  `json:"code"`
+	CampusName string  `json:"campusName"`
+	Address    *string `json:"address"`
+	Status     string  `json:"status"`
+}
+
+// ============================================================
+// CreateCampusRequest - Request tạo campus mới (super admin)
+// ============================================================
+type CreateCampusRequest struct {
+	Code       string  `json:"code"`
+	CampusName string  `json:"campusName"`
+	Address    *string `json:"address"`
+}
+
 // ============================================================
 // Venue - Địa điểm tổ chức
 // ============================================================
 type Venue struct {
 	VenueID   int         `json:"venueId"`
+	CampusID  *int        `json:"campusId"`
 	VenueName string      `json:"venueName"`
 	Location  *string     `json:"location"`
 	Status    string      `json:"status"`
@@ -15,12 +36,13 @@ type Venue struct {
 // VenueArea - Khu vực trong địa điểm
 // ============================================================
 type VenueArea struct {
-	AreaID   int     `json:"areaId"`
-	VenueID  int     `json:"venueId"`
-	AreaName string  `json:"areaName"`
-	Floor    *string `json:"floor"`
-	Capacity *int    `json:"capacity"`
-	Status   string  `json:"status"`
+	AreaID    int      `json:"areaId"`
+	VenueID   int      `json:"venueId"`
+	AreaName  string   `json:"areaName"`
+	Floor     *string  `json:"floor"`
+	Capacity  *int     `json:"capacity"`
+	Status    string   `json:"status"`
+	Amenities []string `json:"amenities"` // common/amenity.All
 }
 
 // ============================================================
@@ -39,6 +61,19 @@ type Seat struct {
 	CategoryTicketID  *int     `json:"categoryTicketId,omitempty"`  // ✅ FIXED: Pointer để handle NULL
 	CategoryName      *string  `json:"categoryName,omitempty"`      // ✅ FIXED: Pointer để handle NULL
 	Price             *float64 `json:"price,omitempty"`             // ✅ NEW: Price from category_ticket
+	PriceAdjust       *int     `json:"priceAdjustPercent,omitempty"` // Giá riêng của ghế so với giá loại vé (Seat_Price), price đã tính sẵn
+	Accessible        bool     `json:"accessible"`                  // Ghế dành cho xe lăn
+	CompanionOfSeatID *int     `json:"companionOfSeatId,omitempty"` // Ghế người đi kèm của ghế xe lăn này
+}
+
+// ============================================================
+// UpdateSeatAccessibilityRequest - Đánh dấu ghế xe lăn / ghế người đi kèm (ADMIN)
+// companionOfSeatId phải là ghế xe lăn liền kề (cùng hàng, cột kế bên)
+// ============================================================
+type UpdateSeatAccessibilityRequest struct {
+	SeatID            int  `json:"seatId"`
+	Accessible        bool `json:"accessible"`
+	CompanionOfSeatID *int `json:"companionOfSeatId"`
 }
 
 // ============================================================
@@ -52,6 +87,7 @@ type FreeAreaResponse struct {
 	VenueID      int     `json:"venueId"`
 	VenueName    string  `json:"venueName"`
 	VenueAddress *string `json:"venueAddress"`
+	CampusID     *int    `json:"campusId"`
 }
 
 // ============================================================
@@ -60,6 +96,8 @@ type FreeAreaResponse struct {
 type CreateVenueRequest struct {
 	VenueName string  `json:"venueName"`
 	Location  *string `json:"location"`
+	// CampusID: Bắt buộc với super admin; admin campus mặc định campus của mình
+	CampusID *int `json:"campusId"`
 }
 
 // ============================================================
@@ -76,19 +114,37 @@ type UpdateVenueRequest struct {
 // CreateAreaRequest - Request tạo area mới
 // ============================================================
 type CreateAreaRequest struct {
-	VenueID  int    `json:"venueId"`
-	AreaName string `json:"areaName"`
-	Floor    int    `json:"floor"`
-	Capacity int    `json:"capacity"`
+	VenueID   int      `json:"venueId"`
+	AreaName  string   `json:"areaName"`
+	Floor     int      `json:"floor"`
+	Capacity  int      `json:"capacity"`
+	Amenities []string `json:"amenities"`
 }
 
 // ============================================================
 // UpdateAreaRequest - Request cập nhật area
 // ============================================================
 type UpdateAreaRequest struct {
-	AreaID   int    `json:"areaId"`
-	AreaName string `json:"areaName"`
-	Floor    int    `json:"floor"`
-	Capacity int    `json:"capacity"`
-	Status   string `json:"status"`
+	AreaID    int      `json:"areaId"`
+	AreaName  string   `json:"areaName"`
+	Floor     int      `json:"floor"`
+	Capacity  int      `json:"capacity"`
+	Status    string   `json:"status"`
+	Amenities []string `json:"amenities"` // nil = giữ nguyên, [] = xóa hết
+}
+
+// ============================================================
+// AreaInfo - Khu vực kèm venue, cho service khác đọc qua VenueService
+// Khớp message Area (services/proto/venue.proto)
+// ============================================================
+type AreaInfo struct {
+	AreaID        int     `json:"areaId"`
+	VenueID       int     `json:"venueId"`
+	AreaName      string  `json:"areaName"`
+	Floor         *string `json:"floor"`
+	Capacity      int     `json:"capacity"`
+	Status        string  `json:"status"`
+	VenueName     string  `json:"venueName"`
+	VenueLocation *string `json:"venueLocation"`
+	CampusID      *int    `json:"campusId"`
 }
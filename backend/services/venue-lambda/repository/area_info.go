@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/fpt-event-services/services/venue-lambda/models"
+)
+
+// GetAreaInfo - Khu vực kèm venue và campus; ErrVenueNotFound nếu không có khu vực
+func (r *VenueRepository) GetAreaInfo(ctx context.Context, areaID int) (*models.AreaInfo, error) {
+	var a models.AreaInfo
+	var floor, location sql.NullString
+	var capacity, campusID sql.NullInt64
+	err := r.db.QueryRowContext(ctx, `
+		SELECT va.area_id, va.venue_id, va.area_name, va.floor, va.capacity, va.status,
+		       v.venue_name, v.location, v.campus_id
+		FROM Venue_Area va
+		JOIN Venue v ON v.venue_id = va.venue_id
+		WHERE va.area_id = ?
+	`, areaID).Scan(&a.AreaID, &a.VenueID, &a.AreaName, &floor, &capacity, &a.Status,
+		&a.VenueName, &location, &campusID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrVenueNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load area: %w", err)
+	}
+	if floor.Valid {
+		a.Floor = &floor.String
+	}
+	if location.Valid {
+		a.VenueLocation = &location.String
+	}
+	a.Capacity = int(capacity.Int64)
+	a.CampusID = nullIntPtr(campusID)
+	return &a, nil
+}
@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/fpt-event-services/services/venue-lambda/models"
+)
+
+var (
+	// ErrCampusNotFound - campus không tồn tại / đã ngừng hoạt động
+	ErrCampusNotFound = errors.New("campus not found")
+	// ErrCampusCodeExists - Mã campus đã được dùng
+	ErrCampusCodeExists = errors.New("campus code already exists")
+	// ErrVenueNotFound - venue / area không tồn tại
+	ErrVenueNotFound = errors.New("venue not found")
+)
+
+// ============================================================
+// ListCampuses - Danh sách campus đang hoạt động
+// ============================================================
+func (r *VenueRepository) ListCampuses(ctx context.Context) ([]models.Campus, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT campus_id, code, campus_name, address, status
+		FROM Campus
+		WHERE status = 'ACTIVE'
+		ORDER BY campus_id
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query campuses: %w", err)
+	}
+	defer rows.Close()
+
+	var campuses []models.Campus
+	for rows.Next() {
+		var c models.Campus
+		var address sql.NullString
+		if err := rows.Scan(&c.CampusID, &c.Code, &c.CampusName, &address, &c.Status); err != nil {
+			return nil, fmt.Errorf("failed to scan campus: %w", err)
+		}
+		if address.Valid {
+			c.Address = &address.String
+		}
+		campuses = append(campuses, c)
+	}
+	return campuses, rows.Err()
+}
+
+// ============================================================
+// CreateCampus - Tạo campus mới (mã campus là duy nhất)
+// ============================================================
+func (r *VenueRepository) CreateCampus(ctx context.Context, req models.CreateCampusRequest) (int64, error) {
+	var exists bool
+	if err := r.db.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM Campus WHERE code = ?)`, req.Code).Scan(&exists); err != nil {
+		return 0, fmt.Errorf("failed to check campus code: %w", err)
+	}
+	if exists {
+		return 0, ErrCampusCodeExists
+	}
+
+	result, err := r.db.ExecContext(ctx,
+		`INSERT INTO Campus (code, campus_name, address, status) VALUES (?, ?, ?, 'ACTIVE')`,
+		req.Code, req.CampusName, req.Address)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create campus: %w", err)
+	}
+	return result.LastInsertId()
+}
+
+// CampusExists - campus tồn tại và đang hoạt động
+func (r *VenueRepository) CampusExists(ctx context.Context, campusID int) (bool, error) {
+	var exists bool
+	err := r.db.QueryRowContext(ctx,
+		`SELECT EXISTS(SELECT 1 FROM Campus WHERE campus_id = ? AND status = 'ACTIVE')`, campusID).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to check campus: %w", err)
+	}
+	return exists, nil
+}
+
+// GetVenueCampusID - campus của venue (nil nếu venue chưa gắn campus)
+func (r *VenueRepository) GetVenueCampusID(ctx context.Context, venueID int) (*int, error) {
+	var campusID sql.NullInt64
+	err := r.db.QueryRowContext(ctx, `SELECT campus_id FROM Venue WHERE venue_id = ?`, venueID).Scan(&campusID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrVenueNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load venue campus: %w", err)
+	}
+	return nullIntPtr(campusID), nil
+}
+
+// GetAreaCampusID - campus của venue chứa area
+func (r *VenueRepository) GetAreaCampusID(ctx context.Context, areaID int) (*int, error) {
+	var campusID sql.NullInt64
+	err := r.db.QueryRowContext(ctx, `
+		SELECT v.campus_id
+		FROM Venue_Area va
+		JOIN Venue v ON v.venue_id = va.venue_id
+		WHERE va.area_id = ?
+	`, areaID).Scan(&campusID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrVenueNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load area campus: %w", err)
+	}
+	return nullIntPtr(campusID), nil
+}
+
+func nullIntPtr(v sql.NullInt64) *int {
+	if !v.Valid {
+		return nil
+	}
+	n := int(v.Int64)
+	return &n
+}
@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/fpt-event-services/services/venue-lambda/models"
+)
+
+var (
+	ErrSeatNotFound = errors.New("seat not found")
+	// ErrInvalidSeatAccessibility - Cấu hình ghế xe lăn / người đi kèm không hợp lệ
+	ErrInvalidSeatAccessibility = errors.New("invalid seat accessibility")
+)
+
+// ============================================================
+// UpdateSeatAccessibility - Đánh dấu ghế xe lăn / ghế người đi kèm
+// Ghế người đi kèm phải liền kề ghế xe lăn (cùng area, cùng hàng, cột kế bên)
+// Bỏ đánh dấu xe lăn thì các ghế đi kèm của nó cũng được gỡ liên kết
+// ============================================================
+func (r *VenueRepository) UpdateSeatAccessibility(ctx context.Context, req models.UpdateSeatAccessibilityRequest) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	seat, err := getSeatPosition(ctx, tx, req.SeatID)
+	if err != nil {
+		return err
+	}
+
+	if req.CompanionOfSeatID != nil {
+		if req.Accessible {
+			return fmt.Errorf("%w: ghế xe lăn không thể đồng thời là ghế người đi kèm", ErrInvalidSeatAccessibility)
+		}
+		target, err := getSeatPosition(ctx, tx, *req.CompanionOfSeatID)
+		if err != nil {
+			return err
+		}
+		if !target.accessible {
+			return fmt.Errorf("%w: ghế %s không phải ghế xe lăn", ErrInvalidSeatAccessibility, target.code)
+		}
+		if !seat.adjacentTo(target) {
+			return fmt.Errorf("%w: ghế %s không liền kề ghế %s", ErrInvalidSeatAccessibility, seat.code, target.code)
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE Seat SET is_accessible = ?, companion_of_seat_id = ? WHERE seat_id = ?`,
+		req.Accessible, req.CompanionOfSeatID, req.SeatID); err != nil {
+		return fmt.Errorf("failed to update seat accessibility: %w", err)
+	}
+	if !req.Accessible && seat.accessible {
+		if _, err := tx.ExecContext(ctx,
+			`UPDATE Seat SET companion_of_seat_id = NULL WHERE companion_of_seat_id = ?`, req.SeatID); err != nil {
+			return fmt.Errorf("failed to unlink companion seats: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+type seatPosition struct {
+	id         int
+	areaID     int
+	code       string
+	row        sql.NullString
+	col        sql.NullString
+	accessible bool
+}
+
+func getSeatPosition(ctx context.Context, tx *sql.Tx, seatID int) (*seatPosition, error) {
+	p := &seatPosition{id: seatID}
+	err := tx.QueryRowContext(ctx,
+		`SELECT area_id, seat_code, row_no, col_no, is_accessible FROM Seat WHERE seat_id = ? FOR UPDATE`, seatID,
+	).Scan(&p.areaID, &p.code, &p.row, &p.col, &p.accessible)
+	if err == sql.ErrNoRows {
+		return nil, ErrSeatNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get seat: %w", err)
+	}
+	return p, nil
+}
+
+// adjacentTo - Cùng area, cùng hàng và cột chênh lệch 1
+func (p *seatPosition) adjacentTo(other *seatPosition) bool {
+	if p.areaID != other.areaID || !p.row.Valid || !other.row.Valid || p.row.String != other.row.String {
+		return false
+	}
+	a, errA := strconv.Atoi(p.col.String)
+	b, errB := strconv.Atoi(other.col.String)
+	if errA != nil || errB != nil {
+		return false
+	}
+	return a-b == 1 || b-a == 1
+}
@@ -5,8 +5,10 @@ import (
 	"database/sql"
 	"fmt"
 	"log"
+	"sync"
 	"time"
 
+	"github.com/fpt-event-services/common/amenity"
 	"github.com/fpt-event-services/common/db"
 	"github.com/fpt-event-services/services/venue-lambda/models"
 )
@@ -21,13 +23,22 @@ func NewVenueRepository() *VenueRepository {
 	}
 }
 
+// DefaultVenueRepository - Repository dùng chung của process
+var DefaultVenueRepository = sync.OnceValue(NewVenueRepository)
+
 // ============================================================
 // GetAllVenues - Lấy tất cả venues với nested areas
 // ============================================================
-func (r *VenueRepository) GetAllVenues(ctx context.Context) ([]models.Venue, error) {
+// campusID != nil: chỉ venue của campus đó
+func (r *VenueRepository) GetAllVenues(ctx context.Context, campusID *int) ([]models.Venue, error) {
 	// Get venues
-	venueQuery := `SELECT venue_id, venue_name, location, status FROM Venue WHERE status != 'DELETED' ORDER BY venue_id`
-	rows, err := r.db.QueryContext(ctx, venueQuery)
+	venueQuery := `SELECT venue_id, campus_id, venue_name, location, status FROM Venue WHERE status != 'DELETED'`
+	var args []interface{}
+	if campusID != nil {
+		venueQuery += ` AND campus_id = ?`
+		args = append(args, *campusID)
+	}
+	rows, err := r.db.QueryContext(ctx, venueQuery+` ORDER BY venue_id`, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query venues: %w", err)
 	}
@@ -39,12 +50,14 @@ func (r *VenueRepository) GetAllVenues(ctx context.Context) ([]models.Venue, err
 	for rows.Next() {
 		var venue models.Venue
 		var location sql.NullString
+		var campusID sql.NullInt64
 
-		err := rows.Scan(&venue.VenueID, &venue.VenueName, &location, &venue.Status)
+		err := rows.Scan(&venue.VenueID, &campusID, &venue.VenueName, &location, &venue.Status)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan venue: %w", err)
 		}
 
+		venue.CampusID = nullIntPtr(campusID)
 		if location.Valid {
 			venue.Location = &location.String
 		}
@@ -54,7 +67,7 @@ func (r *VenueRepository) GetAllVenues(ctx context.Context) ([]models.Venue, err
 	}
 
 	// Get areas for all venues
-	areaQuery := `SELECT area_id, venue_id, area_name, floor, capacity, status FROM Venue_Area WHERE status != 'DELETED' ORDER BY venue_id, area_id`
+	areaQuery := `SELECT area_id, venue_id, area_name, floor, capacity, status, amenities FROM Venue_Area WHERE status != 'DELETED' ORDER BY venue_id, area_id`
 	areaRows, err := r.db.QueryContext(ctx, areaQuery)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query areas: %w", err)
@@ -65,11 +78,13 @@ func (r *VenueRepository) GetAllVenues(ctx context.Context) ([]models.Venue, err
 		var area models.VenueArea
 		var floor sql.NullString
 		var capacity sql.NullInt64
+		var amenities string
 
-		err := areaRows.Scan(&area.AreaID, &area.VenueID, &area.AreaName, &floor, &capacity, &area.Status)
+		err := areaRows.Scan(&area.AreaID, &area.VenueID, &area.AreaName, &floor, &capacity, &area.Status, &amenities)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan area: %w", err)
 		}
+		area.Amenities = amenity.Split(amenities)
 
 		if floor.Valid {
 			area.Floor = &floor.String
@@ -91,12 +106,13 @@ func (r *VenueRepository) GetAllVenues(ctx context.Context) ([]models.Venue, err
 // GetVenueByID - Lấy venue theo ID
 // ============================================================
 func (r *VenueRepository) GetVenueByID(ctx context.Context, venueID int) (*models.Venue, error) {
-	query := `SELECT venue_id, venue_name, location, status FROM Venue WHERE venue_id = ?`
+	query := `SELECT venue_id, campus_id, venue_name, location, status FROM Venue WHERE venue_id = ?`
 
 	var venue models.Venue
 	var location sql.NullString
+	var campusID sql.NullInt64
 
-	err := r.db.QueryRowContext(ctx, query, venueID).Scan(&venue.VenueID, &venue.VenueName, &location, &venue.Status)
+	err := r.db.QueryRowContext(ctx, query, venueID).Scan(&venue.VenueID, &campusID, &venue.VenueName, &location, &venue.Status)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
@@ -104,12 +120,13 @@ func (r *VenueRepository) GetVenueByID(ctx context.Context, venueID int) (*model
 		return nil, fmt.Errorf("failed to query venue: %w", err)
 	}
 
+	venue.CampusID = nullIntPtr(campusID)
 	if location.Valid {
 		venue.Location = &location.String
 	}
 
 	// Get areas
-	areaQuery := `SELECT area_id, venue_id, area_name, floor, capacity, status FROM Venue_Area WHERE venue_id = ? AND status != 'DELETED'`
+	areaQuery := `SELECT area_id, venue_id, area_name, floor, capacity, status, amenities FROM Venue_Area WHERE venue_id = ? AND status != 'DELETED'`
 	rows, err := r.db.QueryContext(ctx, areaQuery, venueID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query areas: %w", err)
@@ -121,11 +138,13 @@ func (r *VenueRepository) GetVenueByID(ctx context.Context, venueID int) (*model
 		var area models.VenueArea
 		var floor sql.NullString
 		var capacity sql.NullInt64
+		var amenities string
 
-		err := rows.Scan(&area.AreaID, &area.VenueID, &area.AreaName, &floor, &capacity, &area.Status)
+		err := rows.Scan(&area.AreaID, &area.VenueID, &area.AreaName, &floor, &capacity, &area.Status, &amenities)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan area: %w", err)
 		}
+		area.Amenities = amenity.Split(amenities)
 
 		if floor.Valid {
 			area.Floor = &floor.String
@@ -144,9 +163,9 @@ func (r *VenueRepository) GetVenueByID(ctx context.Context, venueID int) (*model
 // CreateVenue - Tạo venue mới
 // ============================================================
 func (r *VenueRepository) CreateVenue(ctx context.Context, req models.CreateVenueRequest) (int64, error) {
-	query := `INSERT INTO Venue (venue_name, location, status) VALUES (?, ?, 'AVAILABLE')`
+	query := `INSERT INTO Venue (campus_id, venue_name, location, status) VALUES (?, ?, ?, 'AVAILABLE')`
 
-	result, err := r.db.ExecContext(ctx, query, req.VenueName, req.Location)
+	result, err := r.db.ExecContext(ctx, query, req.CampusID, req.VenueName, req.Location)
 	if err != nil {
 		return 0, fmt.Errorf("failed to create venue: %w", err)
 	}
@@ -208,7 +227,7 @@ func (r *VenueRepository) DeleteVenue(ctx context.Context, venueID int) error {
 // GetAllAreas - Lấy tất cả areas
 // ============================================================
 func (r *VenueRepository) GetAllAreas(ctx context.Context) ([]models.VenueArea, error) {
-	query := `SELECT area_id, venue_id, area_name, floor, capacity, status FROM Venue_Area WHERE status != 'DELETED' ORDER BY venue_id, area_id`
+	query := `SELECT area_id, venue_id, area_name, floor, capacity, status, amenities FROM Venue_Area WHERE status != 'DELETED' ORDER BY venue_id, area_id`
 
 	rows, err := r.db.QueryContext(ctx, query)
 	if err != nil {
@@ -221,11 +240,13 @@ func (r *VenueRepository) GetAllAreas(ctx context.Context) ([]models.VenueArea,
 		var area models.VenueArea
 		var floor sql.NullString
 		var capacity sql.NullInt64
+		var amenities string
 
-		err := rows.Scan(&area.AreaID, &area.VenueID, &area.AreaName, &floor, &capacity, &area.Status)
+		err := rows.Scan(&area.AreaID, &area.VenueID, &area.AreaName, &floor, &capacity, &area.Status, &amenities)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan area: %w", err)
 		}
+		area.Amenities = amenity.Split(amenities)
 
 		if floor.Valid {
 			area.Floor = &floor.String
@@ -243,7 +264,7 @@ func (r *VenueRepository) GetAllAreas(ctx context.Context) ([]models.VenueArea,
 // GetAreasByVenueID - Lấy areas theo venue ID
 // ============================================================
 func (r *VenueRepository) GetAreasByVenueID(ctx context.Context, venueID int) ([]models.VenueArea, error) {
-	query := `SELECT area_id, venue_id, area_name, floor, capacity, status FROM Venue_Area WHERE venue_id = ? AND status != 'DELETED' ORDER BY area_id`
+	query := `SELECT area_id, venue_id, area_name, floor, capacity, status, amenities FROM Venue_Area WHERE venue_id = ? AND status != 'DELETED' ORDER BY area_id`
 
 	rows, err := r.db.QueryContext(ctx, query, venueID)
 	if err != nil {
@@ -256,11 +277,13 @@ func (r *VenueRepository) GetAreasByVenueID(ctx context.Context, venueID int) ([
 		var area models.VenueArea
 		var floor sql.NullString
 		var capacity sql.NullInt64
+		var amenities string
 
-		err := rows.Scan(&area.AreaID, &area.VenueID, &area.AreaName, &floor, &capacity, &area.Status)
+		err := rows.Scan(&area.AreaID, &area.VenueID, &area.AreaName, &floor, &capacity, &area.Status, &amenities)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan area: %w", err)
 		}
+		area.Amenities = amenity.Split(amenities)
 
 		if floor.Valid {
 			area.Floor = &floor.String
@@ -279,7 +302,8 @@ func (r *VenueRepository) GetAreasByVenueID(ctx context.Context, venueID int) ([
 // GetFreeAreas - Lấy các area còn trống trong khoảng thời gian
 // Buffer 1 giờ: startBuffer = startTime - 1h, endBuffer = endTime + 1h
 // ============================================================
-func (r *VenueRepository) GetFreeAreas(ctx context.Context, startTime, endTime string) ([]models.FreeAreaResponse, error) {
+// campusID != nil: chỉ khu vực thuộc campus đó
+func (r *VenueRepository) GetFreeAreas(ctx context.Context, startTime, endTime string, campusID *int) ([]models.FreeAreaResponse, error) {
 	// Helper function to parse time with multiple formats
 	parseTime := func(timeStr string) (time.Time, error) {
 		// Try format with T (ISO8601): 2006-01-02T15:04:05
@@ -312,11 +336,12 @@ func (r *VenueRepository) GetFreeAreas(ctx context.Context, startTime, endTime s
 	endBuffer := endParsed.Add(1 * time.Hour).Format("2006-01-02 15:04:05")
 
 	query := `
-		SELECT va.area_id, va.area_name, va.floor, va.capacity, v.venue_id, v.venue_name, v.location
+		SELECT va.area_id, va.area_name, va.floor, va.capacity, v.venue_id, v.venue_name, v.location, v.campus_id
 		FROM Venue_Area va
 		JOIN Venue v ON va.venue_id = v.venue_id
 		WHERE va.status = 'AVAILABLE' 
 		AND v.status = 'AVAILABLE'
+		AND (? IS NULL OR v.campus_id = ?)
 		AND va.area_id NOT IN (
 			SELECT e.area_id FROM Event e
 			WHERE e.status IN ('OPEN', 'CLOSED', 'DRAFT')
@@ -327,7 +352,7 @@ func (r *VenueRepository) GetFreeAreas(ctx context.Context, startTime, endTime s
 		ORDER BY v.venue_name, va.area_name
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, endBuffer, startBuffer)
+	rows, err := r.db.QueryContext(ctx, query, campusID, campusID, endBuffer, startBuffer)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query free areas: %w", err)
 	}
@@ -339,8 +364,9 @@ func (r *VenueRepository) GetFreeAreas(ctx context.Context, startTime, endTime s
 		var floor sql.NullString
 		var capacity sql.NullInt64
 		var location sql.NullString
+		var areaCampus sql.NullInt64
 
-		err := rows.Scan(&area.AreaID, &area.AreaName, &floor, &capacity, &area.VenueID, &area.VenueName, &location)
+		err := rows.Scan(&area.AreaID, &area.AreaName, &floor, &capacity, &area.VenueID, &area.VenueName, &location, &areaCampus)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan area: %w", err)
 		}
@@ -355,6 +381,7 @@ func (r *VenueRepository) GetFreeAreas(ctx context.Context, startTime, endTime s
 		if location.Valid {
 			area.VenueAddress = &location.String
 		}
+		area.CampusID = nullIntPtr(areaCampus)
 		areas = append(areas, area)
 	}
 
@@ -375,7 +402,9 @@ func (r *VenueRepository) GetAllSeats(ctx context.Context, areaID int) ([]models
 			s.row_no,
 			s.col_no,
 			s.category_ticket_id,
-			ct.name AS category_name
+			ct.name AS category_name,
+			s.is_accessible,
+			s.companion_of_seat_id
 		FROM Seat s
 		LEFT JOIN category_ticket ct ON s.category_ticket_id = ct.category_ticket_id
 		WHERE s.area_id = ?
@@ -396,6 +425,7 @@ func (r *VenueRepository) GetAllSeats(ctx context.Context, areaID int) ([]models
 		var column sql.NullInt64
 		var categoryTicketID sql.NullInt64
 		var categoryName sql.NullString
+		var companionOf sql.NullInt64
 
 		err := rows.Scan(
 			&seat.SeatID,
@@ -406,6 +436,8 @@ func (r *VenueRepository) GetAllSeats(ctx context.Context, areaID int) ([]models
 			&column,
 			&categoryTicketID,
 			&categoryName,
+			&seat.Accessible,
+			&companionOf,
 		)
 		if err != nil {
 			log.Printf("SQL Scan Error in GetAllSeats: %v", err)
@@ -431,6 +463,10 @@ func (r *VenueRepository) GetAllSeats(ctx context.Context, areaID int) ([]models
 			seat.CategoryName = &categoryName.String
 			seat.SeatType = &categoryName.String
 		}
+		if companionOf.Valid {
+			cid := int(companionOf.Int64)
+			seat.CompanionOfSeatID = &cid
+		}
 
 		seats = append(seats, seat)
 	}
@@ -442,9 +478,9 @@ func (r *VenueRepository) GetAllSeats(ctx context.Context, areaID int) ([]models
 // CreateArea - Tạo area mới
 // ============================================================
 func (r *VenueRepository) CreateArea(ctx context.Context, req models.CreateAreaRequest) (int64, error) {
-	query := `INSERT INTO Venue_Area (venue_id, area_name, floor, capacity, status) VALUES (?, ?, ?, ?, 'AVAILABLE')`
+	query := `INSERT INTO Venue_Area (venue_id, area_name, floor, capacity, status, amenities) VALUES (?, ?, ?, ?, 'AVAILABLE', ?)`
 
-	result, err := r.db.ExecContext(ctx, query, req.VenueID, req.AreaName, req.Floor, req.Capacity)
+	result, err := r.db.ExecContext(ctx, query, req.VenueID, req.AreaName, req.Floor, req.Capacity, amenity.Join(req.Amenities))
 	if err != nil {
 		return 0, fmt.Errorf("failed to create area: %w", err)
 	}
@@ -456,9 +492,15 @@ func (r *VenueRepository) CreateArea(ctx context.Context, req models.CreateAreaR
 // UpdateArea - Cập nhật area
 // ============================================================
 func (r *VenueRepository) UpdateArea(ctx context.Context, req models.UpdateAreaRequest) error {
-	query := `UPDATE Venue_Area SET area_name = ?, floor = ?, capacity = ?, status = ? WHERE area_id = ?`
+	// Amenities nil: giữ nguyên tiện ích hiện có
+	var amenities *string
+	if req.Amenities != nil {
+		joined := amenity.Join(req.Amenities)
+		amenities = &joined
+	}
+	query := `UPDATE Venue_Area SET area_name = ?, floor = ?, capacity = ?, status = ?, amenities = COALESCE(?, amenities) WHERE area_id = ?`
 
-	_, err := r.db.ExecContext(ctx, query, req.AreaName, req.Floor, req.Capacity, req.Status, req.AreaID)
+	_, err := r.db.ExecContext(ctx, query, req.AreaName, req.Floor, req.Capacity, req.Status, amenities, req.AreaID)
 	if err != nil {
 		return fmt.Errorf("failed to update area: %w", err)
 	}
@@ -508,7 +550,10 @@ func (r *VenueRepository) GetSeatsForEvent(ctx context.Context, eventID int, sea
 			s.col_no AS seat_column,
 			s.category_ticket_id,
 			ct.name AS category_name,
-			ct.price AS ticket_price,
+			ROUND(ct.price * (100 + COALESCE(sp.adjust_percent, 0)) / 100, 0) AS ticket_price,
+			sp.adjust_percent,
+			s.is_accessible,
+			s.companion_of_seat_id,
 			CASE 
 				WHEN EXISTS (
 					SELECT 1 FROM Ticket t
@@ -526,6 +571,7 @@ func (r *VenueRepository) GetSeatsForEvent(ctx context.Context, eventID int, sea
 			END AS seat_status
 			FROM Seat s
 			LEFT JOIN category_ticket ct ON s.category_ticket_id = ct.category_ticket_id AND ct.event_id = ?
+			LEFT JOIN seat_price sp ON sp.category_ticket_id = ct.category_ticket_id AND sp.seat_id = s.seat_id
 			WHERE s.area_id = ?
 			  AND s.status = 'ACTIVE'
 	`
@@ -578,6 +624,8 @@ func (r *VenueRepository) GetSeatsForEvent(ctx context.Context, eventID int, sea
 		var categoryTicketID sql.NullInt64
 		var categoryName sql.NullString
 		var ticketPrice sql.NullFloat64
+		var priceAdjust sql.NullInt64
+		var companionOf sql.NullInt64
 		var status string
 
 		err := rows.Scan(
@@ -589,6 +637,9 @@ func (r *VenueRepository) GetSeatsForEvent(ctx context.Context, eventID int, sea
 			&categoryTicketID,
 			&categoryName,
 			&ticketPrice,
+			&priceAdjust,
+			&seat.Accessible,
+			&companionOf,
 			&status,
 		)
 		if err != nil {
@@ -631,6 +682,14 @@ func (r *VenueRepository) GetSeatsForEvent(ctx context.Context, eventID int, sea
 		if ticketPrice.Valid {
 			seat.Price = &ticketPrice.Float64
 		}
+		if priceAdjust.Valid {
+			adjust := int(priceAdjust.Int64)
+			seat.PriceAdjust = &adjust
+		}
+		if companionOf.Valid {
+			cid := int(companionOf.Int64)
+			seat.CompanionOfSeatID = &cid
+		}
 
 		seat.Status = status
 		seats = append(seats, seat)
@@ -639,7 +698,7 @@ func (r *VenueRepository) GetSeatsForEvent(ctx context.Context, eventID int, sea
 		seatCount++
 		if seatCount <= 3 {
 			log.Printf("[GetSeatsForEvent] ✓ Seat[%d]: ID=%d, Code=%s, Area=%d, Row=%v, Category=%s, Status=%s",
-				seatCount, seat.SeatID, seat.SeatCode, seat.AreaID, rowName, categoryName, status)
+				seatCount, seat.SeatID, seat.SeatCode, seat.AreaID, rowName, categoryName.String, status)
 		}
 	}
 
@@ -0,0 +1,85 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+
+	"github.com/fpt-event-services/common/campus"
+	"github.com/fpt-event-services/services/venue-lambda/models"
+	"github.com/fpt-event-services/services/venue-lambda/repository"
+)
+
+// ============================================================
+// Campus - Danh sách / tạo campus và kiểm tra phạm vi campus
+// của admin khi thao tác venue, area
+// ============================================================
+
+var (
+	// ErrCampusRequired - Super admin tạo venue phải chọn campus
+	ErrCampusRequired = errors.New("campusId is required")
+	// ErrInvalidCampusRequest - Mã / tên campus không hợp lệ
+	ErrInvalidCampusRequest = errors.New("invalid campus request")
+)
+
+var campusCodePattern = regexp.MustCompile(`^[A-Z0-9]{2,10}$`)
+
+// ListCampuses - Campus đang hoạt động
+func (uc *VenueUseCase) ListCampuses(ctx context.Context) ([]models.Campus, error) {
+	return uc.venueRepo.ListCampuses(ctx)
+}
+
+// CreateCampus - Chỉ super admin (ADMIN không gắn campus)
+func (uc *VenueUseCase) CreateCampus(ctx context.Context, req models.CreateCampusRequest) (int64, error) {
+	super, err := campus.IsSuperAdmin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if !super {
+		return 0, campus.ErrOutOfScope
+	}
+	req.Code = strings.ToUpper(strings.TrimSpace(req.Code))
+	req.CampusName = strings.TrimSpace(req.CampusName)
+	if !campusCodePattern.MatchString(req.Code) || req.CampusName == "" {
+		return 0, ErrInvalidCampusRequest
+	}
+	return uc.venueRepo.CreateCampus(ctx, req)
+}
+
+// CheckVenueScope - Venue thuộc campus admin hiện tại được quản lý
+func (uc *VenueUseCase) CheckVenueScope(ctx context.Context, venueID int) error {
+	campusID, err := uc.venueRepo.GetVenueCampusID(ctx, venueID)
+	if err != nil {
+		return err
+	}
+	return campus.Check(ctx, campusID)
+}
+
+// CheckAreaScope - Area (qua venue) thuộc campus admin hiện tại được quản lý
+func (uc *VenueUseCase) CheckAreaScope(ctx context.Context, areaID int) error {
+	campusID, err := uc.venueRepo.GetAreaCampusID(ctx, areaID)
+	if err != nil {
+		return err
+	}
+	return campus.Check(ctx, campusID)
+}
+
+// resolveVenueCampus - Campus cho venue mới: admin campus luôn dùng campus của mình
+func (uc *VenueUseCase) resolveVenueCampus(ctx context.Context, requested *int) (*int, error) {
+	campusID, err := campus.Resolve(ctx, requested)
+	if err != nil {
+		return nil, err
+	}
+	if campusID == nil {
+		return nil, ErrCampusRequired
+	}
+	exists, err := uc.venueRepo.CampusExists(ctx, *campusID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, repository.ErrCampusNotFound
+	}
+	return campusID, nil
+}
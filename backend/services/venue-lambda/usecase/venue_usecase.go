@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/fpt-event-services/common/amenity"
 	"github.com/fpt-event-services/services/venue-lambda/models"
 	"github.com/fpt-event-services/services/venue-lambda/repository"
 )
@@ -14,13 +15,13 @@ type VenueUseCase struct {
 
 func NewVenueUseCase() *VenueUseCase {
 	return &VenueUseCase{
-		venueRepo: repository.NewVenueRepository(),
+		venueRepo: repository.DefaultVenueRepository(),
 	}
 }
 
-// GetAllVenues - Lấy tất cả venues với nested areas
-func (uc *VenueUseCase) GetAllVenues(ctx context.Context) ([]models.Venue, error) {
-	return uc.venueRepo.GetAllVenues(ctx)
+// GetAllVenues - Lấy tất cả venues với nested areas (campusID != nil: chỉ campus đó)
+func (uc *VenueUseCase) GetAllVenues(ctx context.Context, campusID *int) ([]models.Venue, error) {
+	return uc.venueRepo.GetAllVenues(ctx, campusID)
 }
 
 // GetVenueByID - Lấy venue theo ID
@@ -28,8 +29,13 @@ func (uc *VenueUseCase) GetVenueByID(ctx context.Context, venueID int) (*models.
 	return uc.venueRepo.GetVenueByID(ctx, venueID)
 }
 
-// CreateVenue - Tạo venue mới
+// CreateVenue - Tạo venue mới trong campus (admin campus: campus của mình)
 func (uc *VenueUseCase) CreateVenue(ctx context.Context, req models.CreateVenueRequest) (int64, error) {
+	campusID, err := uc.resolveVenueCampus(ctx, req.CampusID)
+	if err != nil {
+		return 0, err
+	}
+	req.CampusID = campusID
 	return uc.venueRepo.CreateVenue(ctx, req)
 }
 
@@ -65,9 +71,9 @@ func (uc *VenueUseCase) GetAreasByVenueID(ctx context.Context, venueID int) ([]m
 	return uc.venueRepo.GetAreasByVenueID(ctx, venueID)
 }
 
-// GetFreeAreas - Lấy các area còn trống
-func (uc *VenueUseCase) GetFreeAreas(ctx context.Context, startTime, endTime string) ([]models.FreeAreaResponse, error) {
-	return uc.venueRepo.GetFreeAreas(ctx, startTime, endTime)
+// GetFreeAreas - Lấy các area còn trống (campusID != nil: chỉ campus đó)
+func (uc *VenueUseCase) GetFreeAreas(ctx context.Context, startTime, endTime string, campusID *int) ([]models.FreeAreaResponse, error) {
+	return uc.venueRepo.GetFreeAreas(ctx, startTime, endTime, campusID)
 }
 
 // GetAllSeats - Lấy seats theo area (ghế vật lý)
@@ -80,16 +86,33 @@ func (uc *VenueUseCase) GetSeatsForEvent(ctx context.Context, eventID int, seatT
 	return uc.venueRepo.GetSeatsForEvent(ctx, eventID, seatType)
 }
 
-// CreateArea - Tạo area mới
+// CreateArea - Tạo area mới (tiện ích lạ trả amenity.ErrUnknown)
 func (uc *VenueUseCase) CreateArea(ctx context.Context, req models.CreateAreaRequest) (int64, error) {
+	amenities, err := amenity.Normalize(req.Amenities)
+	if err != nil {
+		return 0, err
+	}
+	req.Amenities = amenities
 	return uc.venueRepo.CreateArea(ctx, req)
 }
 
-// UpdateArea - Cập nhật area
+// UpdateArea - Cập nhật area (Amenities nil = giữ nguyên tiện ích)
 func (uc *VenueUseCase) UpdateArea(ctx context.Context, req models.UpdateAreaRequest) error {
+	if req.Amenities != nil {
+		amenities, err := amenity.Normalize(req.Amenities)
+		if err != nil {
+			return err
+		}
+		req.Amenities = amenities
+	}
 	return uc.venueRepo.UpdateArea(ctx, req)
 }
 
+// UpdateSeatAccessibility - Đánh dấu ghế xe lăn / ghế người đi kèm
+func (uc *VenueUseCase) UpdateSeatAccessibility(ctx context.Context, req models.UpdateSeatAccessibilityRequest) error {
+	return uc.venueRepo.UpdateSeatAccessibility(ctx, req)
+}
+
 // DeleteArea - Soft delete area
 func (uc *VenueUseCase) DeleteArea(ctx context.Context, areaID int) error {
 	return uc.venueRepo.DeleteArea(ctx, areaID)
@@ -24,10 +24,11 @@ type Bill = {
 
 // Response type từ backend pagination
 type PaginatedBillsResponse = {
-  bills: Bill[]
+  items: Bill[]
+  page: number
+  pageSize: number
+  totalItems: number
   totalPages: number
-  currentPage: number
-  totalRecords: number
 }
 
 // Component MyBills: trang "Hóa đơn của tôi"
@@ -128,7 +129,7 @@ export default function MyBills() {
        * - FE dùng status, nhưng BE trả paymentStatus
        * => status = b.paymentStatus
        */
-      const mapped: Bill[] = data.bills.map((b: any) => ({
+      const mapped: Bill[] = data.items.map((b: any) => ({
         // billId có thể là number -> ép sang string để hiển thị
         id: b.billId?.toString(),
 
@@ -148,8 +149,8 @@ export default function MyBills() {
       // Lưu danh sách hóa đơn vào state để render UI
       setBills(mapped)
       setTotalPages(data.totalPages || 1)
-      setCurrentPage(data.currentPage || 1)
-      setTotalRecords(data.totalRecords || 0)
+      setCurrentPage(data.page || 1)
+      setTotalRecords(data.totalItems || 0)
     } catch (err: any) {
       // Nếu lỗi network/parse/json...
       // setError để UI hiển thị lỗi
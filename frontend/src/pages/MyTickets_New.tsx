@@ -88,10 +88,11 @@ type MyTicket = {
 
 // Response type từ backend pagination
 type PaginatedTicketsResponse = {
-    tickets: MyTicket[]
+    items: MyTicket[]
+    page: number
+    pageSize: number
+    totalItems: number
     totalPages: number
-    currentPage: number
-    totalRecords: number
 }
 
 /**
@@ -200,10 +201,10 @@ export default function MyTickets() {
             console.log('Paginated tickets from API:', data)
 
             // Cập nhật state
-            setTickets(Array.isArray(data.tickets) ? data.tickets : [])
+            setTickets(Array.isArray(data.items) ? data.items : [])
             setTotalPages(data.totalPages || 1)
-            setCurrentPage(data.currentPage || 1)
-            setTotalRecords(data.totalRecords || 0)
+            setCurrentPage(data.page || 1)
+            setTotalRecords(data.totalItems || 0)
         } catch (err) {
             // Nếu lỗi network/cors/timeout
             console.error('Error loading tickets:', err)
@@ -107,6 +107,17 @@ type ApiTabResponse = {
   totalCount: number
 }
 
+/**
+ * Envelope phân trang chung của backend (items, page, pageSize, totalItems, totalPages)
+ */
+type PageResponse<T> = {
+  items: T[]
+  page: number
+  pageSize: number
+  totalItems: number
+  totalPages: number
+}
+
 /**
  * getStatusLabel - Chuyển status code -> text tiếng Việt
  */
@@ -309,15 +320,13 @@ export default function OrganizerEventRequests() {
   /**
    * fetchActiveRequests: Call /api/event-requests/my/active
    * Tab "Chờ" (Active) = PENDING + UPDATING only
-   * Supports: limit, offset, search
+   * Supports: page, pageSize, search
    */
   const fetchActiveRequests = async (page: number) => {
     try {
       setActiveLoading(true)
       const token = localStorage.getItem('token')
-      const offset = (page - 1) * ITEMS_PER_PAGE
-
-      let url = `/api/event-requests/my/active?limit=${ITEMS_PER_PAGE}&offset=${offset}`
+      let url = `/api/event-requests/my/active?page=${page}&pageSize=${ITEMS_PER_PAGE}`
       if (searchQuery.trim()) {
         url += `&search=${encodeURIComponent(searchQuery.trim())}`
       }
@@ -329,12 +338,12 @@ export default function OrganizerEventRequests() {
       })
 
       if (response.ok) {
-        const data: ApiTabResponse = await response.json()
+        const data: PageResponse<EventRequest> = await response.json()
         console.log('Active requests data:', data)
         // ✅ FIX: Ensure requests is always an array, never null
         const normalizedData: ApiTabResponse = {
-          requests: data.requests || [],
-          totalCount: data.totalCount || 0,
+          requests: data.items || [],
+          totalCount: data.totalItems || 0,
         }
         setActiveTabData(normalizedData)
         setActiveTabPage(page)
@@ -352,15 +361,13 @@ export default function OrganizerEventRequests() {
   /**
    * fetchArchivedRequests: Call /api/event-requests/my/archived
    * Tab "Đã xử lý" (Archived) = CLOSED + CANCELLED + OPEN + APPROVED
-   * Supports: limit, offset, search
+   * Supports: page, pageSize, search
    */
   const fetchArchivedRequests = async (page: number) => {
     try {
       setArchivedLoading(true)
       const token = localStorage.getItem('token')
-      const offset = (page - 1) * ITEMS_PER_PAGE
-
-      let url = `/api/event-requests/my/archived?limit=${ITEMS_PER_PAGE}&offset=${offset}`
+      let url = `/api/event-requests/my/archived?page=${page}&pageSize=${ITEMS_PER_PAGE}`
       if (searchQuery.trim()) {
         url += `&search=${encodeURIComponent(searchQuery.trim())}`
       }
@@ -372,12 +379,12 @@ export default function OrganizerEventRequests() {
       })
 
       if (response.ok) {
-        const data: ApiTabResponse = await response.json()
+        const data: PageResponse<EventRequest> = await response.json()
         console.log('Archived requests data:', data)
         // ✅ FIX: Ensure requests is always an array, never null
         const normalizedData: ApiTabResponse = {
-          requests: data.requests || [],
-          totalCount: data.totalCount || 0,
+          requests: data.items || [],
+          totalCount: data.totalItems || 0,
         }
         setArchivedTabData(normalizedData)
         setArchivedTabPage(page)
@@ -150,14 +150,17 @@ export default function ReportRequests() {
         /**
          * Hệ thống có thể trả nhiều dạng:
          * - data là array trực tiếp
-         * - hoặc {data: array}
+         * - envelope phân trang {items: array, page, totalItems, ...}
+         * - hoặc {data: array} (định dạng cũ)
          * => normalize về list array
          */
         const list = Array.isArray(data)
           ? data
-          : data && Array.isArray(data.data)
-            ? data.data
-            : []
+          : data && Array.isArray(data.items)
+            ? data.items
+            : data && Array.isArray(data.data)
+              ? data.data
+              : []
 
         /**
          * Map dữ liệu về đúng cấu trúc ReportSummary (đảm bảo field tồn tại)